@@ -0,0 +1,78 @@
+// Package actor carries the identity of whoever triggered a change through the
+// request context, so repositories can stamp updated_by columns without every
+// service method threading the user ID through by hand.
+package actor
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// System is recorded for changes made by background jobs rather than a person.
+const System = "system"
+
+// HeaderUserID is set by the API gateway after it authenticates the caller.
+const HeaderUserID = "X-User-ID"
+
+// HeaderUserRole carries the role claim of the caller's access token. The
+// gateway sets it only from a verified token and drops any value the client
+// sent.
+const HeaderUserRole = "X-User-Role"
+
+// RoleAdmin is the role of platform back-office staff.
+const RoleAdmin = "admin"
+
+type contextKey struct{}
+
+type roleKey struct{}
+
+// NewContext returns a copy of ctx that records id as the acting user.
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the acting user recorded in ctx, or "" if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware copies the gateway's X-User-ID header into the request context.
+// Requests without the header pass through untouched.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.Header.Get(HeaderUserID); id != "" {
+			r = r.WithContext(NewContext(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole refuses requests the gateway didn't authenticate with role,
+// answering 403. Requests it lets through record the role, so handlers and
+// services can confirm it with UserWithRole.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(HeaderUserID)
+			if id == "" || r.Header.Get(HeaderUserRole) != role {
+				httpx.WriteError(w, r, http.StatusForbidden, "FORBIDDEN", "This route requires the "+role+" role")
+				return
+			}
+			ctx := context.WithValue(r.Context(), roleKey{}, role)
+			next.ServeHTTP(w, r.WithContext(NewContext(ctx, id)))
+		})
+	}
+}
+
+// UserWithRole returns the acting user when RequireRole admitted the request
+// with role, and false otherwise.
+func UserWithRole(ctx context.Context, role string) (string, bool) {
+	id := FromContext(ctx)
+	if got, _ := ctx.Value(roleKey{}).(string); got != role || id == "" {
+		return "", false
+	}
+	return id, true
+}
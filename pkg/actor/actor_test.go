@@ -0,0 +1,94 @@
+package actor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if got := FromContext(context.Background()); got != "" {
+		t.Errorf("FromContext(empty) = %q, want empty", got)
+	}
+
+	ctx := NewContext(context.Background(), "user-1")
+	if got := FromContext(ctx); got != "user-1" {
+		t.Errorf("FromContext() = %q, want user-1", got)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"header set", "admin-42", "admin-42"},
+		{"header missing", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = FromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(HeaderUserID, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("actor = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		role       string
+		wantStatus int
+	}{
+		{"admin", "admin-42", RoleAdmin, http.StatusOK},
+		{"customer", "user-1", "", http.StatusForbidden},
+		{"provider staff", "user-2", "provider_admin", http.StatusForbidden},
+		{"role without user", "", RoleAdmin, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			var ok bool
+			handler := RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got, ok = UserWithRole(r.Context(), RoleAdmin)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.userID != "" {
+				req.Header.Set(HeaderUserID, tt.userID)
+			}
+			if tt.role != "" {
+				req.Header.Set(HeaderUserRole, tt.role)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && (!ok || got != tt.userID) {
+				t.Errorf("UserWithRole() = %q, %v, want %q", got, ok, tt.userID)
+			}
+		})
+	}
+
+	if _, ok := UserWithRole(NewContext(context.Background(), "user-1"), RoleAdmin); ok {
+		t.Error("UserWithRole() without RequireRole = true")
+	}
+}
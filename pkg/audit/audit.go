@@ -0,0 +1,227 @@
+// Package audit records who performed a sensitive operation, from where, and
+// what it changed. Each service keeps its own audit_log table; the package
+// provides the entry format, the diffing, storage and an admin query API so
+// every service records and serves the trail the same way.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+)
+
+var (
+	ErrEntryNotFound  = errors.New("audit entry not found")
+	ErrInvalidRange   = errors.New("from must be before to")
+	ErrActionRequired = errors.New("audit action is required")
+)
+
+// UnknownActor is recorded when a request reaches a service without an
+// authenticated user, e.g. a call made directly rather than via the gateway
+const UnknownActor = "unknown"
+
+// Search limits
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Change is the before and after value of one field
+type Change struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// Entry is one recorded operation. Before and After are snapshots of the
+// target as JSON objects; Changes holds only the fields that differ.
+type Entry struct {
+	ID         uuid.UUID              `json:"id"`
+	Service    string                 `json:"service"`
+	Actor      string                 `json:"actor"`
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"target_type"`
+	TargetID   string                 `json:"target_id"`
+	IP         string                 `json:"ip,omitempty"`
+	Reason     string                 `json:"reason,omitempty"`
+	Before     map[string]interface{} `json:"before,omitempty"`
+	After      map[string]interface{} `json:"after,omitempty"`
+	Changes    map[string]Change      `json:"changes"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// Event describes an operation to record. Before and After may be any value
+// that marshals to a JSON object, or nil for operations that create or
+// remove the target. Callers leave secrets out of the snapshots.
+type Event struct {
+	Action     string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     interface{}
+	After      interface{}
+}
+
+// NewEntry builds an entry for an event performed by the actor and client
+// IP recorded in ctx. Requests without an actor are recorded as unknown.
+func NewEntry(ctx context.Context, service string, event Event) (*Entry, error) {
+	if event.Action == "" {
+		return nil, ErrActionRequired
+	}
+
+	before, err := snapshot(event.Before)
+	if err != nil {
+		return nil, err
+	}
+	after, err := snapshot(event.After)
+	if err != nil {
+		return nil, err
+	}
+
+	who := actor.FromContext(ctx)
+	if who == "" {
+		who = UnknownActor
+	}
+
+	return &Entry{
+		ID:         uuid.New(),
+		Service:    service,
+		Actor:      who,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		IP:         IPFromContext(ctx),
+		Reason:     event.Reason,
+		Before:     before,
+		After:      after,
+		Changes:    Diff(before, after),
+		OccurredAt: time.Now().UTC(),
+	}, nil
+}
+
+// snapshot turns a value into its JSON object form so entries store and
+// compare the same representation the API returns
+func snapshot(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Diff returns the top-level fields whose values differ between two
+// snapshots. A field missing on one side is reported with a nil value.
+func Diff(before, after map[string]interface{}) map[string]Change {
+	changes := make(map[string]Change)
+	for key, from := range before {
+		to, ok := after[key]
+		if !ok || !reflect.DeepEqual(from, to) {
+			changes[key] = Change{From: from, To: to}
+		}
+	}
+	for key, to := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = Change{From: nil, To: to}
+		}
+	}
+	return changes
+}
+
+// Query filters a search. Empty fields match everything. Action may end in
+// "*" to match a prefix, e.g. "credentials.*".
+type Query struct {
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	Offset     int
+}
+
+// Normalize validates the query and clamps its paging
+func (q *Query) Normalize() error {
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return ErrInvalidRange
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return nil
+}
+
+// Matches reports whether an entry satisfies the query's filters
+func (q Query) Matches(e *Entry) bool {
+	if q.Actor != "" && e.Actor != q.Actor {
+		return false
+	}
+	if q.Action != "" {
+		if prefix, ok := strings.CutSuffix(q.Action, "*"); ok {
+			if !strings.HasPrefix(e.Action, prefix) {
+				return false
+			}
+		} else if e.Action != q.Action {
+			return false
+		}
+	}
+	if q.TargetType != "" && e.TargetType != q.TargetType {
+		return false
+	}
+	if q.TargetID != "" && e.TargetID != q.TargetID {
+		return false
+	}
+	if !q.From.IsZero() && e.OccurredAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !e.OccurredAt.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Store persists audit entries. Search returns matches newest first.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	Append(ctx context.Context, entry *Entry) error
+	Get(ctx context.Context, id uuid.UUID) (*Entry, error)
+	Search(ctx context.Context, q Query) ([]*Entry, error)
+}
+
+// Recorder writes a service's audit entries to its store
+type Recorder struct {
+	store   Store
+	service string
+}
+
+// NewRecorder creates a recorder that stamps entries with the service name
+func NewRecorder(store Store, service string) *Recorder {
+	return &Recorder{store: store, service: service}
+}
+
+// Record stores an entry for the event
+func (r *Recorder) Record(ctx context.Context, event Event) error {
+	entry, err := NewEntry(ctx, r.service, event)
+	if err != nil {
+		return err
+	}
+	return r.store.Append(ctx, entry)
+}
@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/actor"
+)
+
+func TestDiff(t *testing.T) {
+	before := map[string]interface{}{"status": "active", "name": "Acme", "removed": "x"}
+	after := map[string]interface{}{"status": "frozen", "name": "Acme", "added": true}
+
+	changes := Diff(before, after)
+
+	tests := []struct {
+		field    string
+		want     Change
+		reported bool
+	}{
+		{"status", Change{From: "active", To: "frozen"}, true},
+		{"removed", Change{From: "x", To: nil}, true},
+		{"added", Change{From: nil, To: true}, true},
+		{"name", Change{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			got, ok := changes[tt.field]
+			if ok != tt.reported {
+				t.Fatalf("field reported = %v, want %v", ok, tt.reported)
+			}
+			if ok && got != tt.want {
+				t.Errorf("change = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewEntry(t *testing.T) {
+	type wallet struct {
+		Status  string `json:"status"`
+		Balance string `json:"balance"`
+	}
+
+	ctx := WithIP(actor.NewContext(context.Background(), "admin-1"), "10.0.0.7")
+	entry, err := NewEntry(ctx, "wallet", Event{
+		Action:     "wallet.frozen",
+		TargetType: "wallet",
+		TargetID:   "w1",
+		Before:     wallet{Status: "active", Balance: "10.00"},
+		After:      wallet{Status: "frozen", Balance: "10.00"},
+	})
+	if err != nil {
+		t.Fatalf("NewEntry() error = %v", err)
+	}
+
+	if entry.Actor != "admin-1" || entry.IP != "10.0.0.7" || entry.Service != "wallet" {
+		t.Errorf("got actor %q ip %q service %q", entry.Actor, entry.IP, entry.Service)
+	}
+	if len(entry.Changes) != 1 || entry.Changes["status"].To != "frozen" {
+		t.Errorf("Changes = %+v, want only status", entry.Changes)
+	}
+
+	entry, err = NewEntry(context.Background(), "provider", Event{Action: "credentials.generated", After: map[string]interface{}{"environment": "sandbox"}})
+	if err != nil {
+		t.Fatalf("NewEntry() error = %v", err)
+	}
+	if entry.Actor != UnknownActor {
+		t.Errorf("Actor = %q, want %q", entry.Actor, UnknownActor)
+	}
+	if entry.Before != nil || entry.Changes["environment"].To != "sandbox" {
+		t.Errorf("creation should diff against nothing, got %+v", entry.Changes)
+	}
+
+	if _, err := NewEntry(ctx, "wallet", Event{}); !errors.Is(err, ErrActionRequired) {
+		t.Errorf("NewEntry() without action error = %v, want ErrActionRequired", err)
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	e := &Entry{Actor: "admin-1", Action: "credentials.rotated", TargetType: "credentials", TargetID: "c1", OccurredAt: at}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{"empty query", Query{}, true},
+		{"actor and target", Query{Actor: "admin-1", TargetType: "credentials", TargetID: "c1"}, true},
+		{"other actor", Query{Actor: "admin-2"}, false},
+		{"action prefix", Query{Action: "credentials.*"}, true},
+		{"action prefix mismatch", Query{Action: "wallet.*"}, false},
+		{"exact action", Query{Action: "credentials.revoked"}, false},
+		{"inside window", Query{From: at.Add(-time.Minute), To: at.Add(time.Minute)}, true},
+		{"to is exclusive", Query{To: at}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Matches(e); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Search(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	store := NewMemoryStore()
+	recorder := NewRecorder(store, "provider")
+
+	for _, action := range []string{"provider.activated", "credentials.generated", "credentials.revoked"} {
+		if err := recorder.Record(ctx, Event{Action: action, TargetType: "provider", TargetID: "p1"}); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	entries, err := store.Search(ctx, Query{Action: "credentials.*"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(entries) != 2 || entries[0].Action != "credentials.revoked" {
+		t.Fatalf("got %d entries, want 2 newest first", len(entries))
+	}
+
+	got, err := store.Get(ctx, entries[1].ID)
+	if err != nil || got.Action != "credentials.generated" {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       string
+	}{
+		{"host and port", "192.0.2.10:52100", "192.0.2.10"},
+		{"address from RealIP", "198.51.100.4", "198.51.100.4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			handler := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = IPFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("IPFromContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// Handler exposes a service's audit trail to admins
+type Handler struct {
+	store Store
+}
+
+func NewHandler(store Store) *Handler {
+	return &Handler{store: store}
+}
+
+// Routes registers the audit query endpoints on the given router
+func (h *Handler) Routes(r chi.Router) {
+	r.Get("/", h.Search)
+	r.Get("/{id}", h.Get)
+}
+
+// Search filters entries by actor, action (a trailing "*" matches a prefix),
+// target_type, target_id and an RFC 3339 from/to window, newest first
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := Query{
+		Actor:      params.Get("actor"),
+		Action:     params.Get("action"),
+		TargetType: params.Get("target_type"),
+		TargetID:   params.Get("target_id"),
+	}
+
+	var err error
+	if q.From, err = parseTime(params.Get("from")); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", "from must be an RFC 3339 timestamp",
+			httpx.FieldError{Field: "from", Message: "must be an RFC 3339 timestamp"})
+		return
+	}
+	if q.To, err = parseTime(params.Get("to")); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", "to must be an RFC 3339 timestamp",
+			httpx.FieldError{Field: "to", Message: "must be an RFC 3339 timestamp"})
+		return
+	}
+	q.Limit, _ = strconv.Atoi(params.Get("limit"))
+	q.Offset, _ = strconv.Atoi(params.Get("offset"))
+
+	entries, err := h.store.Search(r.Context(), q)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if entries == nil {
+		entries = []*Entry{}
+	}
+	httpx.WriteJSON(w, http.StatusOK, entries)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_AUDIT_ID", "Invalid audit entry ID")
+		return
+	}
+
+	entry, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.WriteJSON(w, http.StatusOK, entry)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, ErrEntryNotFound):
+		httpx.WriteError(w, r, http.StatusNotFound, "AUDIT_ENTRY_NOT_FOUND", err.Error())
+	case errors.Is(err, ErrInvalidRange):
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+	default:
+		httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to read audit log")
+	}
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+)
+
+type ipKey struct{}
+
+// WithIP returns a copy of ctx that records ip as the client address
+func WithIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, ipKey{}, ip)
+}
+
+// IPFromContext returns the client address recorded in ctx, or ""
+func IPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(ipKey{}).(string)
+	return ip
+}
+
+// Middleware records the client address in the request context. It reads
+// RemoteAddr, so it belongs after chi's RealIP which resolves the address
+// forwarded by the gateway.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		if ip != "" {
+			r = r.WithContext(WithIP(r.Context(), ip))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryStore keeps entries in process memory. Useful for tests and local
+// development; nothing survives a restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	entries []*Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, entry *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *entry
+	s.entries = append(s.entries, &copied)
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id uuid.UUID) (*Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, e := range s.entries {
+		if e.ID == id {
+			copied := *e
+			return &copied, nil
+		}
+	}
+	return nil, ErrEntryNotFound
+}
+
+func (s *MemoryStore) Search(ctx context.Context, q Query) ([]*Entry, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []*Entry
+	skipped := 0
+	for i := len(s.entries) - 1; i >= 0 && len(matches) < q.Limit; i-- {
+		e := s.entries[i]
+		if !q.Matches(e) {
+			continue
+		}
+		if skipped < q.Offset {
+			skipped++
+			continue
+		}
+		copied := *e
+		matches = append(matches, &copied)
+	}
+	return matches, nil
+}
@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresStore keeps entries in a service's audit_log table. Services
+// create the table in their own migrations.
+type PostgresStore struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Append(ctx context.Context, entry *Entry) error {
+	beforeJSON, err := json.Marshal(entry.Before)
+	if err != nil {
+		return err
+	}
+	afterJSON, err := json.Marshal(entry.After)
+	if err != nil {
+		return err
+	}
+	changesJSON, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO audit_log (
+			id, service, actor, action, target_type, target_id, ip, reason,
+			before_state, after_state, changes, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err = s.db.Exec(ctx, query,
+		entry.ID, entry.Service, entry.Actor, entry.Action, entry.TargetType, entry.TargetID,
+		entry.IP, entry.Reason, beforeJSON, afterJSON, changesJSON, entry.OccurredAt,
+	)
+	return err
+}
+
+const selectEntry = `
+		SELECT id, service, actor, action, target_type, target_id, ip, reason,
+			before_state, after_state, changes, occurred_at
+		FROM audit_log
+	`
+
+func (s *PostgresStore) Get(ctx context.Context, id uuid.UUID) (*Entry, error) {
+	e, err := scanEntry(s.db.QueryRow(ctx, selectEntry+"WHERE id = $1", id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrEntryNotFound
+		}
+		return nil, err
+	}
+	return e, nil
+}
+
+func (s *PostgresStore) Search(ctx context.Context, q Query) ([]*Entry, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	where := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
+	}
+
+	if q.Actor != "" {
+		where("actor = $%d", q.Actor)
+	}
+	if q.Action != "" {
+		if prefix, ok := strings.CutSuffix(q.Action, "*"); ok {
+			where("action LIKE $%d", escapeLike(prefix)+"%")
+		} else {
+			where("action = $%d", q.Action)
+		}
+	}
+	if q.TargetType != "" {
+		where("target_type = $%d", q.TargetType)
+	}
+	if q.TargetID != "" {
+		where("target_id = $%d", q.TargetID)
+	}
+	if !q.From.IsZero() {
+		where("occurred_at >= $%d", q.From)
+	}
+	if !q.To.IsZero() {
+		where("occurred_at < $%d", q.To)
+	}
+
+	query := selectEntry
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+	args = append(args, q.Limit, q.Offset)
+	query += fmt.Sprintf("ORDER BY occurred_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*Entry
+	for rows.Next() {
+		e, err := scanEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func scanEntry(row pgx.Row) (*Entry, error) {
+	var e Entry
+	var beforeJSON, afterJSON, changesJSON []byte
+	if err := row.Scan(
+		&e.ID, &e.Service, &e.Actor, &e.Action, &e.TargetType, &e.TargetID, &e.IP, &e.Reason,
+		&beforeJSON, &afterJSON, &changesJSON, &e.OccurredAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(beforeJSON, &e.Before); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(afterJSON, &e.After); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(changesJSON, &e.Changes); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// escapeLike escapes LIKE wildcards so an action prefix is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,28 @@
+// Package cache keeps encoded values for a while so hot reads skip the
+// database.
+//
+// LRUStore holds them in process memory, RedisStore shares them between
+// replicas, and Tiered puts an LRU in front of Redis. A value missing from
+// the cache is never an error: callers read through to the source.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key is absent or expired.
+var ErrMiss = errors.New("cache miss")
+
+// Store caches byte values with a time to live.
+type Store interface {
+	// Get returns the value for key or ErrMiss.
+	Get(ctx context.Context, key string) ([]byte, error)
+
+	// Set stores value under key until ttl passes.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the keys, if present.
+	Delete(ctx context.Context, keys ...string) error
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return map[string]Store{
+		"lru":    NewLRUStore(10),
+		"redis":  NewRedisStore(client, "test:"),
+		"tiered": NewTiered(NewLRUStore(10), NewRedisStore(client, "tiered:"), time.Minute),
+	}
+}
+
+func TestStore_SetGetDelete(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			if _, err := s.Get(ctx, "a"); !errors.Is(err, ErrMiss) {
+				t.Fatalf("Get() on empty store error = %v, want ErrMiss", err)
+			}
+			if err := s.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+				t.Fatalf("Set() error = %v", err)
+			}
+			s.Set(ctx, "b", []byte("2"), time.Minute)
+
+			got, err := s.Get(ctx, "a")
+			if err != nil || string(got) != "1" {
+				t.Fatalf("Get() = %q, %v, want 1", got, err)
+			}
+
+			if err := s.Delete(ctx, "a", "b", "missing"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			for _, key := range []string{"a", "b"} {
+				if _, err := s.Get(ctx, key); !errors.Is(err, ErrMiss) {
+					t.Errorf("Get(%q) after Delete error = %v, want ErrMiss", key, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUStore_Expiry(t *testing.T) {
+	s := NewLRUStore(10)
+	now := time.Now()
+	s.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	s.Set(ctx, "a", []byte("1"), time.Minute)
+	now = now.Add(time.Minute)
+
+	if _, err := s.Get(ctx, "a"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get() after ttl error = %v, want ErrMiss", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d, want expired entry dropped", s.Len())
+	}
+}
+
+func TestLRUStore_EvictsLeastRecentlyUsed(t *testing.T) {
+	s := NewLRUStore(2)
+	ctx := context.Background()
+
+	s.Set(ctx, "a", []byte("1"), time.Minute)
+	s.Set(ctx, "b", []byte("2"), time.Minute)
+	s.Get(ctx, "a") // b is now the least recently used
+	s.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, err := s.Get(ctx, "b"); !errors.Is(err, ErrMiss) {
+		t.Errorf("Get(b) error = %v, want it evicted", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, err := s.Get(ctx, key); err != nil {
+			t.Errorf("Get(%q) error = %v, want it kept", key, err)
+		}
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+}
+
+func TestTiered_FillsLocalFromShared(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	ctx := context.Background()
+
+	shared := NewRedisStore(client, "")
+	local := NewLRUStore(10)
+	tiered := NewTiered(local, shared, time.Minute)
+
+	// Written by another replica
+	shared.Set(ctx, "a", []byte("1"), time.Minute)
+
+	if got, err := tiered.Get(ctx, "a"); err != nil || string(got) != "1" {
+		t.Fatalf("Get() = %q, %v, want 1", got, err)
+	}
+	if got, err := local.Get(ctx, "a"); err != nil || string(got) != "1" {
+		t.Errorf("local Get() = %q, %v, want the shared value copied", got, err)
+	}
+
+	// A local hit no longer needs Redis
+	mr.FlushAll()
+	if _, err := tiered.Get(ctx, "a"); err != nil {
+		t.Errorf("Get() error = %v, want local hit", err)
+	}
+}
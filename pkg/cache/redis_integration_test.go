@@ -0,0 +1,49 @@
+//go:build integration
+
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/pkg/testenv"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func TestRedisStore(t *testing.T) {
+	ctx := context.Background()
+	store := cache.NewRedisStore(testenv.RedisClient(t), "test:")
+
+	if err := store.Set(ctx, "provider:1", []byte(`{"id":1}`), time.Minute); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	got, err := store.Get(ctx, "provider:1")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(got) != `{"id":1}` {
+		t.Errorf("Get() = %s", got)
+	}
+
+	if err := store.Delete(ctx, "provider:1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, "provider:1"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, cache.ErrMiss)
+	}
+
+	if err := store.Set(ctx, "short", []byte("x"), time.Second); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	time.Sleep(1100 * time.Millisecond)
+	if _, err := store.Get(ctx, "short"); !errors.Is(err, cache.ErrMiss) {
+		t.Errorf("Get() after expiry error = %v, want %v", err, cache.ErrMiss)
+	}
+}
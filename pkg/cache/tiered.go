@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Tiered reads from a local store first and falls back to a shared one,
+// copying what it finds locally. Local entries live for at most localTTL,
+// which bounds how stale a replica can be when it misses an invalidation.
+type Tiered struct {
+	local    Store
+	shared   Store
+	localTTL time.Duration
+}
+
+func NewTiered(local, shared Store, localTTL time.Duration) *Tiered {
+	return &Tiered{local: local, shared: shared, localTTL: localTTL}
+}
+
+func (t *Tiered) Get(ctx context.Context, key string) ([]byte, error) {
+	if value, err := t.local.Get(ctx, key); err == nil {
+		return value, nil
+	}
+
+	value, err := t.shared.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	t.local.Set(ctx, key, value, t.localTTL)
+	return value, nil
+}
+
+func (t *Tiered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	t.local.Set(ctx, key, value, min(ttl, t.localTTL))
+	return t.shared.Set(ctx, key, value, ttl)
+}
+
+// Delete removes the keys from both tiers. Other replicas keep their local
+// copies until they delete them too or localTTL passes.
+func (t *Tiered) Delete(ctx context.Context, keys ...string) error {
+	return errors.Join(t.local.Delete(ctx, keys...), t.shared.Delete(ctx, keys...))
+}
@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	authv1 "github.com/parking-super-app/pkg/proto/auth/v1"
+	"google.golang.org/grpc"
+)
+
+// UserProfile is the signed-in user's account
+type UserProfile struct {
+	ID                  string `json:"id"`
+	Phone               string `json:"phone"`
+	Email               string `json:"email,omitempty"`
+	FullName            string `json:"full_name"`
+	Status              string `json:"status"`
+	PreferredOTPChannel string `json:"preferred_otp_channel"`
+	KYCLevel            string `json:"kyc_level"`
+}
+
+// AuthClient calls the auth service's REST API
+type AuthClient struct {
+	rest *restClient
+}
+
+func NewAuthClient(cfg HTTPConfig) *AuthClient {
+	return &AuthClient{rest: newRESTClient("auth", cfg)}
+}
+
+// Profile returns the caller's profile. Auth checks the caller's token
+// itself, so the context needs a Caller with one.
+func (c *AuthClient) Profile(ctx context.Context) (*UserProfile, error) {
+	var profile UserProfile
+	if err := c.rest.get(ctx, "/api/v1/auth/me", nil, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// AuthGRPC is the auth service's gRPC API
+type AuthGRPC struct {
+	authv1.AuthServiceClient
+	conn *grpc.ClientConn
+}
+
+func DialAuth(cfg client.Config, opts ...grpc.DialOption) (*AuthGRPC, error) {
+	conn, err := dial("auth", cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthGRPC{AuthServiceClient: authv1.NewAuthServiceClient(conn), conn: conn}, nil
+}
+
+func (c *AuthGRPC) Close() error {
+	return c.conn.Close()
+}
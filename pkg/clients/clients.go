@@ -0,0 +1,285 @@
+// Package clients has typed clients for the platform services, so callers
+// don't each hand-roll requests, envelopes and error decoding.
+//
+// HTTP clients call a service's REST API as the caller in the context (see
+// WithCaller): the user ID, access token and request ID are sent as the
+// gateway would forward them, with the time left before the context's
+// deadline (see pkg/deadline). Reads are retried with backoff when the
+// service is unreachable or answers 502, 503 or 504, and every request is
+// traced with the trace context passed on in its headers.
+//
+// gRPC clients are the generated clients dialed with pkg/grpc/client, which
+// provides deadlines, retries, tracing and the caller's user and request IDs.
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/grpc/client"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+)
+
+const tracerName = "github.com/parking-super-app/pkg/clients"
+
+// Caller is who a call is made for
+type Caller struct {
+	UserID string
+	// Token is the user's access token, for services such as auth that
+	// verify it themselves rather than trusting X-User-ID
+	Token     string
+	RequestID string
+}
+
+type tokenKey struct{}
+
+// WithCaller makes calls with ctx on behalf of c. The user and request IDs
+// are also the ones logged and sent over gRPC.
+func WithCaller(ctx context.Context, c Caller) context.Context {
+	if c.UserID != "" {
+		ctx = logging.WithUserID(ctx, c.UserID)
+	}
+	if c.RequestID != "" {
+		ctx = logging.WithRequestID(ctx, c.RequestID)
+	}
+	if c.Token != "" {
+		ctx = context.WithValue(ctx, tokenKey{}, c.Token)
+	}
+	return ctx
+}
+
+// CallerFromRequest reads the caller of an incoming request that passed the
+// gateway's authentication
+func CallerFromRequest(r *http.Request) Caller {
+	return Caller{
+		UserID:    r.Header.Get("X-User-ID"),
+		Token:     strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "),
+		RequestID: httpx.RequestID(r),
+	}
+}
+
+// HTTPConfig describes how to reach one service's REST API
+type HTTPConfig struct {
+	BaseURL string
+	// Timeout bounds each attempt
+	Timeout time.Duration
+	// MaxAttempts includes the first; 1 or less disables retries. Only
+	// reads are retried.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Client is used for requests when set, for custom transports
+	Client *http.Client
+}
+
+// DefaultHTTPConfig returns the recommended settings for calls between services
+func DefaultHTTPConfig(baseURL string) HTTPConfig {
+	return HTTPConfig{
+		BaseURL:        baseURL,
+		Timeout:        5 * time.Second,
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+	}
+}
+
+// Error is a service's error response
+type Error struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// StatusCode returns the HTTP status of an *Error, or 0 for other errors
+func StatusCode(err error) int {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode
+	}
+	return 0
+}
+
+// restClient makes requests to one service and decodes its envelope
+type restClient struct {
+	service string
+	cfg     HTTPConfig
+	http    *http.Client
+}
+
+func newRESTClient(service string, cfg HTTPConfig) *restClient {
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	client := cfg.Client
+	if client == nil {
+		client = &http.Client{}
+	}
+	return &restClient{service: service, cfg: cfg, http: client}
+}
+
+// get reads path into out, the data field of the service's envelope
+func (c *restClient) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	target := c.cfg.BaseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, c.service+" GET "+path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.HTTPMethod(http.MethodGet), semconv.HTTPURL(target)),
+	)
+	defer span.End()
+	done := deadline.Track(ctx, c.service+" GET "+path)
+
+	attempts := c.cfg.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := c.cfg.InitialBackoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		var retry bool
+		if retry, err = c.attempt(ctx, target, out); !retry || attempt >= attempts {
+			break
+		}
+		if err = sleep(ctx, backoff); err != nil {
+			break
+		}
+		backoff = min(2*backoff, c.cfg.MaxBackoff)
+	}
+
+	done(err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("%s: %w", c.service, err)
+	}
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// attempt makes one request and reports whether a failure is worth retrying
+func (c *restClient) attempt(parent context.Context, target string, out interface{}) (bool, error) {
+	ctx := parent
+	if c.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, c.cfg.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if userID := logging.UserIDFromContext(ctx); userID != "" {
+		req.Header.Set("X-User-ID", userID)
+	}
+	if token, _ := ctx.Value(tokenKey{}).(string); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if requestID := logging.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(httpx.RequestIDHeader, requestID)
+	}
+	deadline.Inject(ctx, req.Header)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		// The caller's own deadline or cancellation is final; a timed out
+		// attempt is not
+		return parent.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	trace.SpanFromContext(ctx).SetAttributes(semconv.HTTPStatusCode(resp.StatusCode))
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return retryableStatus(resp.StatusCode), decodeError(resp)
+	}
+
+	envelope := struct {
+		Data interface{} `json:"data"`
+	}{Data: out}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return false, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// decodeError reads a problem response, including the legacy error object
+func decodeError(resp *http.Response) error {
+	var problem struct {
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+		Error  *struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	json.Unmarshal(body, &problem)
+
+	e := &Error{StatusCode: resp.StatusCode, Code: problem.Code, Message: problem.Detail}
+	if problem.Error != nil {
+		if e.Code == "" {
+			e.Code = problem.Error.Code
+		}
+		if e.Message == "" {
+			e.Message = problem.Error.Message
+		}
+	}
+	if e.Message == "" {
+		e.Message = problem.Title
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(resp.StatusCode)
+	}
+	return e
+}
+
+// dial connects to a service's gRPC API
+func dial(service string, cfg client.Config, opts []grpc.DialOption) (*grpc.ClientConn, error) {
+	conn, err := client.New(cfg, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s service: %w", service, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testConfig(url string) HTTPConfig {
+	cfg := DefaultHTTPConfig(url)
+	cfg.InitialBackoff = time.Millisecond
+	cfg.MaxBackoff = 5 * time.Millisecond
+	return cfg
+}
+
+func TestWalletClient_SendsCaller(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"success":true,"data":{"id":"w1","label":"Personal","is_default":true,"balance":"12.50","currency":"MYR"}}`))
+	}))
+	defer srv.Close()
+
+	ctx := WithCaller(context.Background(), Caller{UserID: "u1", Token: "tok", RequestID: "req-1"})
+	ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	wallet, err := NewWalletClient(testConfig(srv.URL)).DefaultWallet(ctx)
+	if err != nil {
+		t.Fatalf("DefaultWallet() error = %v", err)
+	}
+	if wallet.ID != "w1" || !wallet.Balance.Equal(decimal.RequireFromString("12.50")) {
+		t.Errorf("wallet = %+v", wallet)
+	}
+	if got.Get("X-User-ID") != "u1" || got.Get("Authorization") != "Bearer tok" || got.Get("X-Request-ID") != "req-1" {
+		t.Errorf("caller headers = %v", got)
+	}
+	if got.Get("traceparent") == "" {
+		t.Error("trace context was not passed on")
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int32
+		status    int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"recovers after unavailable", 2, http.StatusServiceUnavailable, 3, false},
+		{"gives up after max attempts", 5, http.StatusBadGateway, 3, true},
+		{"client errors are final", 5, http.StatusNotFound, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.Header().Set("Content-Type", "application/problem+json")
+					w.WriteHeader(tt.status)
+					w.Write([]byte(`{"code":"UNAVAILABLE","detail":"try later","success":false,"error":{"code":"UNAVAILABLE","message":"try later"}}`))
+					return
+				}
+				w.Write([]byte(`{"success":true,"data":{"unread":4}}`))
+			}))
+			defer srv.Close()
+
+			unread, err := NewNotificationClient(testConfig(srv.URL)).UnreadCount(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnreadCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && unread != 4 {
+				t.Errorf("unread = %d, want 4", unread)
+			}
+			if tt.wantErr && StatusCode(err) != tt.status {
+				t.Errorf("StatusCode(%v) = %d, want %d", err, StatusCode(err), tt.status)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestDecodeError_LegacyEnvelope(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"success":false,"error":{"code":"INVALID_TOKEN","message":"Token expired"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewAuthClient(testConfig(srv.URL)).Profile(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Code != "INVALID_TOKEN" || apiErr.Message != "Token expired" {
+		t.Errorf("Profile() error = %v, want INVALID_TOKEN", err)
+	}
+}
@@ -0,0 +1,48 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"google.golang.org/grpc"
+)
+
+// NotificationClient calls the notification service's REST API
+type NotificationClient struct {
+	rest *restClient
+}
+
+func NewNotificationClient(cfg HTTPConfig) *NotificationClient {
+	return &NotificationClient{rest: newRESTClient("notification", cfg)}
+}
+
+// UnreadCount returns how many of the caller's notifications are unread
+func (c *NotificationClient) UnreadCount(ctx context.Context) (int, error) {
+	var resp struct {
+		Unread int `json:"unread"`
+	}
+	if err := c.rest.get(ctx, "/api/v1/notifications/unread-count", nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Unread, nil
+}
+
+// NotificationGRPC is the notification service's gRPC API. pkg/notify wraps
+// it for services that only send notifications.
+type NotificationGRPC struct {
+	notificationv1.NotificationServiceClient
+	conn *grpc.ClientConn
+}
+
+func DialNotification(cfg client.Config, opts ...grpc.DialOption) (*NotificationGRPC, error) {
+	conn, err := dial("notification", cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationGRPC{NotificationServiceClient: notificationv1.NewNotificationServiceClient(conn), conn: conn}, nil
+}
+
+func (c *NotificationGRPC) Close() error {
+	return c.conn.Close()
+}
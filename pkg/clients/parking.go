@@ -0,0 +1,62 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+// Session is a parking session
+type Session struct {
+	ID              string          `json:"id"`
+	UserID          string          `json:"user_id"`
+	ProviderID      string          `json:"provider_id"`
+	LocationID      string          `json:"location_id"`
+	VehiclePlate    string          `json:"vehicle_plate"`
+	VehicleType     string          `json:"vehicle_type"`
+	EntryTime       string          `json:"entry_time"`
+	ExitTime        string          `json:"exit_time,omitempty"`
+	DurationMinutes int             `json:"duration_minutes"`
+	Amount          decimal.Decimal `json:"amount"`
+	Status          string          `json:"status"`
+	PaymentStatus   string          `json:"payment_status"`
+}
+
+// ParkingClient calls the parking service's REST API
+type ParkingClient struct {
+	rest *restClient
+}
+
+func NewParkingClient(cfg HTTPConfig) *ParkingClient {
+	return &ParkingClient{rest: newRESTClient("parking", cfg)}
+}
+
+// ActiveSessions lists the caller's sessions that have not ended
+func (c *ParkingClient) ActiveSessions(ctx context.Context) ([]Session, error) {
+	var sessions []Session
+	if err := c.rest.get(ctx, "/api/v1/parking/sessions/active", nil, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// ParkingGRPC is the parking service's gRPC API
+type ParkingGRPC struct {
+	parkingv1.ParkingServiceClient
+	conn *grpc.ClientConn
+}
+
+func DialParking(cfg client.Config, opts ...grpc.DialOption) (*ParkingGRPC, error) {
+	conn, err := dial("parking", cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ParkingGRPC{ParkingServiceClient: parkingv1.NewParkingServiceClient(conn), conn: conn}, nil
+}
+
+func (c *ParkingGRPC) Close() error {
+	return c.conn.Close()
+}
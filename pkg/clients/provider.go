@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"google.golang.org/grpc"
+)
+
+// ProviderModule is a provider's published micro-frontend
+type ProviderModule struct {
+	ProviderID   string   `json:"provider_id"`
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	LogoURL      string   `json:"logo_url,omitempty"`
+	MFEURL       string   `json:"mfe_url"`
+	Version      string   `json:"version"`
+	Capabilities []string `json:"capabilities"`
+	HealthURL    string   `json:"health_url,omitempty"`
+}
+
+// ProviderClient calls the provider service's REST API
+type ProviderClient struct {
+	rest *restClient
+}
+
+func NewProviderClient(cfg HTTPConfig) *ProviderClient {
+	return &ProviderClient{rest: newRESTClient("provider", cfg)}
+}
+
+// Manifest lists the published provider modules
+func (c *ProviderClient) Manifest(ctx context.Context) ([]ProviderModule, error) {
+	var modules []ProviderModule
+	if err := c.rest.get(ctx, "/api/v1/providers/manifest", nil, &modules); err != nil {
+		return nil, err
+	}
+	return modules, nil
+}
+
+// ProviderGRPC is the provider service's gRPC API
+type ProviderGRPC struct {
+	providerv1.ProviderServiceClient
+	conn *grpc.ClientConn
+}
+
+func DialProvider(cfg client.Config, opts ...grpc.DialOption) (*ProviderGRPC, error) {
+	conn, err := dial("provider", cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ProviderGRPC{ProviderServiceClient: providerv1.NewProviderServiceClient(conn), conn: conn}, nil
+}
+
+func (c *ProviderGRPC) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,66 @@
+package clients
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+// Wallet is one of a user's wallets
+type Wallet struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	Label     string          `json:"label"`
+	IsDefault bool            `json:"is_default"`
+	Balance   decimal.Decimal `json:"balance"`
+	Currency  string          `json:"currency"`
+	Status    string          `json:"status"`
+}
+
+// WalletClient calls the wallet service's REST API
+type WalletClient struct {
+	rest *restClient
+}
+
+func NewWalletClient(cfg HTTPConfig) *WalletClient {
+	return &WalletClient{rest: newRESTClient("wallet", cfg)}
+}
+
+// DefaultWallet returns the caller's default wallet
+func (c *WalletClient) DefaultWallet(ctx context.Context) (*Wallet, error) {
+	var wallet Wallet
+	if err := c.rest.get(ctx, "/api/v1/wallet", nil, &wallet); err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// Wallets lists all of the caller's wallets
+func (c *WalletClient) Wallets(ctx context.Context) ([]Wallet, error) {
+	var wallets []Wallet
+	if err := c.rest.get(ctx, "/api/v1/wallet/wallets", nil, &wallets); err != nil {
+		return nil, err
+	}
+	return wallets, nil
+}
+
+// WalletGRPC is the wallet service's gRPC API
+type WalletGRPC struct {
+	walletv1.WalletServiceClient
+	conn *grpc.ClientConn
+}
+
+func DialWallet(cfg client.Config, opts ...grpc.DialOption) (*WalletGRPC, error) {
+	conn, err := dial("wallet", cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &WalletGRPC{WalletServiceClient: walletv1.NewWalletServiceClient(conn), conn: conn}, nil
+}
+
+func (c *WalletGRPC) Close() error {
+	return c.conn.Close()
+}
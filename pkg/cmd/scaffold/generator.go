@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+const (
+	templateRoot   = "templates"
+	templateSuffix = ".tmpl"
+)
+
+var (
+	ErrInvalidName  = errors.New("name must be lower-case letters, digits and dashes, starting with a letter")
+	ErrInvalidPort  = errors.New("ports must be numeric")
+	ErrTargetExists = errors.New("target directory already exists")
+	namePattern     = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+	portPattern     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Options describe the service to generate.
+type Options struct {
+	// Name is the service name in kebab case, e.g. ev-charging
+	Name string
+	// Entity is the first aggregate of the service in kebab case, e.g. charge-point
+	Entity string
+	// ModulePrefix is prepended to the service name to form its module path
+	ModulePrefix string
+	// PkgModule is the module path of the shared pkg module
+	PkgModule string
+	HTTPPort  string
+	GRPCPort  string
+	// OutDir is the directory the service directory is created in
+	OutDir string
+}
+
+// Dir returns the directory the service is generated into.
+func (o Options) Dir() string {
+	return filepath.Join(o.OutDir, o.Name)
+}
+
+func (o Options) validate() error {
+	if !namePattern.MatchString(o.Name) {
+		return fmt.Errorf("invalid service name %q: %w", o.Name, ErrInvalidName)
+	}
+	if !namePattern.MatchString(o.Entity) {
+		return fmt.Errorf("invalid entity name %q: %w", o.Entity, ErrInvalidName)
+	}
+	if !portPattern.MatchString(o.HTTPPort) || !portPattern.MatchString(o.GRPCPort) {
+		return ErrInvalidPort
+	}
+	return nil
+}
+
+// templateData is what the templates see. Every identifier form of the
+// service and entity names is derived up front so templates stay logic-free.
+type templateData struct {
+	Name        string // ev-charging
+	Title       string // ev charging, as written in comments
+	Module      string // github.com/parking-super-app/services/ev-charging
+	PkgModule   string
+	Type        string // EvCharging
+	Snake       string // ev_charging
+	Entity      string // ChargePoint
+	EntityVar   string // chargePoint
+	EntitySnake string // charge_point
+	EntityHuman string // charge point
+	Table       string // charge_points
+	DBName      string // ev_charging_db
+	Topic       string // ev-charging.events
+	HTTPPort    string
+	GRPCPort    string
+
+	EntityPlural      string // ChargePoints
+	EntityVarPlural   string // chargePoints
+	EntityHumanPlural string // charge points
+	EntityPath        string // charge-points, the REST collection
+}
+
+func newTemplateData(o Options) templateData {
+	entityType := pascal(o.Entity)
+	entityPlural := pascal(plural(o.Entity))
+	return templateData{
+		Name:        o.Name,
+		Title:       strings.ReplaceAll(o.Name, "-", " "),
+		Module:      strings.TrimSuffix(o.ModulePrefix, "/") + "/" + o.Name,
+		PkgModule:   o.PkgModule,
+		Type:        pascal(o.Name),
+		Snake:       snake(o.Name),
+		Entity:      entityType,
+		EntityVar:   lowerFirst(entityType),
+		EntitySnake: snake(o.Entity),
+		EntityHuman: strings.ReplaceAll(o.Entity, "-", " "),
+		Table:       snake(plural(o.Entity)),
+		DBName:      snake(o.Name) + "_db",
+		Topic:       o.Name + ".events",
+		HTTPPort:    o.HTTPPort,
+		GRPCPort:    o.GRPCPort,
+
+		EntityPlural:      entityPlural,
+		EntityVarPlural:   lowerFirst(entityPlural),
+		EntityHumanPlural: strings.ReplaceAll(plural(o.Entity), "-", " "),
+		EntityPath:        plural(o.Entity),
+	}
+}
+
+// Generate renders every template into the service directory and returns
+// the paths it wrote, relative to that directory. Go sources are gofmt'ed,
+// so a template that renders invalid Go fails generation.
+func Generate(o Options) ([]string, error) {
+	if err := o.validate(); err != nil {
+		return nil, err
+	}
+
+	dir := o.Dir()
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s: %w", dir, ErrTargetExists)
+	}
+
+	data := newTemplateData(o)
+	replacer := pathReplacer(data)
+
+	var written []string
+	err := fs.WalkDir(templates, templateRoot, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, templateSuffix) {
+			return err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(name, templateRoot+"/"), templateSuffix)
+		rel = replacer.Replace(rel)
+
+		content, err := render(name, data)
+		if err != nil {
+			return err
+		}
+		if path.Ext(rel) == ".go" {
+			formatted, err := format.Source(content)
+			if err != nil {
+				return fmt.Errorf("failed to format %s: %w", rel, err)
+			}
+			content = formatted
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+		written = append(written, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+func render(name string, data templateData) ([]byte, error) {
+	tmpl, err := template.ParseFS(templates, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// pathReplacer substitutes the upper-case placeholders used in template
+// file names.
+func pathReplacer(data templateData) *strings.Replacer {
+	return strings.NewReplacer(
+		"SERVICE", data.Snake,
+		"ENTITY", data.EntitySnake,
+		"TABLE", data.Table,
+	)
+}
+
+func pascal(kebab string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(kebab, "-") {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func lowerFirst(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func snake(kebab string) string {
+	return strings.ReplaceAll(kebab, "-", "_")
+}
+
+// plural is deliberately naive; rename the table afterwards if English
+// disagrees.
+func plural(word string) string {
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case strings.HasSuffix(word, "y") && len(word) > 1 && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	default:
+		return word + "s"
+	}
+}
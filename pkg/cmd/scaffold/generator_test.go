@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testOptions(t *testing.T) Options {
+	return Options{
+		Name:         "ev-charging",
+		Entity:       "charge-point",
+		ModulePrefix: "github.com/parking-super-app/services",
+		PkgModule:    "github.com/parking-super-app/pkg",
+		HTTPPort:     "8080",
+		GRPCPort:     "9000",
+		OutDir:       t.TempDir(),
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	opts := testOptions(t)
+
+	files, err := Generate(opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []string{
+		"go.mod",
+		"Dockerfile",
+		"Makefile",
+		"cmd/server/main.go",
+		"config/config.go",
+		"internal/domain/charge_point.go",
+		"internal/domain/charge_point_test.go",
+		"internal/ports/repositories.go",
+		"internal/application/ev_charging_service.go",
+		"internal/adapters/http/router.go",
+		"internal/adapters/repository/postgres/charge_point_repo.go",
+		"migrations/001_create_charge_points.up.sql",
+	}
+	got := make(map[string]bool, len(files))
+	for _, f := range files {
+		got[f] = true
+	}
+	for _, f := range want {
+		if !got[f] {
+			t.Errorf("missing generated file %s", f)
+		}
+	}
+
+	for _, f := range files {
+		content, err := os.ReadFile(filepath.Join(opts.Dir(), f))
+		if err != nil {
+			t.Fatalf("read %s: %v", f, err)
+		}
+		if strings.Contains(string(content), "{{") || strings.Contains(string(content), "<no value>") {
+			t.Errorf("%s contains unrendered template data", f)
+		}
+	}
+
+	goMod, _ := os.ReadFile(filepath.Join(opts.Dir(), "go.mod"))
+	if !strings.HasPrefix(string(goMod), "module github.com/parking-super-app/services/ev-charging\n") {
+		t.Errorf("unexpected go.mod:\n%s", goMod)
+	}
+}
+
+func TestGenerate_RefusesExistingDirectory(t *testing.T) {
+	opts := testOptions(t)
+	if err := os.MkdirAll(opts.Dir(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Generate(opts); !errors.Is(err, ErrTargetExists) {
+		t.Errorf("Generate() error = %v, want %v", err, ErrTargetExists)
+	}
+}
+
+func TestGenerate_ValidatesNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		entity  string
+	}{
+		{"upper case", "EvCharging", "charger"},
+		{"underscore", "ev_charging", "charger"},
+		{"leading digit", "1ev", "charger"},
+		{"trailing dash", "ev-", "charger"},
+		{"bad entity", "ev-charging", "Charge Point"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions(t)
+			opts.Name, opts.Entity = tt.service, tt.entity
+
+			if _, err := Generate(opts); !errors.Is(err, ErrInvalidName) {
+				t.Errorf("Generate() error = %v, want %v", err, ErrInvalidName)
+			}
+		})
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := map[string]string{
+		"charger":      "chargers",
+		"charge-point": "charge-points",
+		"category":     "categories",
+		"bay":          "bays",
+		"pass":         "passes",
+		"box":          "boxes",
+		"batch":        "batches",
+	}
+	for word, want := range tests {
+		if got := plural(word); got != want {
+			t.Errorf("plural(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Command scaffold generates the skeleton of a new microservice with the
+// same hexagonal layout as the existing services: config, a main with
+// graceful shutdown, domain, ports, application service, HTTP router and
+// handlers, a Postgres repository, migrations, Makefile and Dockerfile. The
+// result is wired to the shared kafka, eventstore, telemetry, httpserver and
+// gRPC interceptor packages and exposes HTTP and gRPC health checks.
+//
+// Run it from the repository root:
+//
+//	go run ./pkg/cmd/scaffold -name ev-charging -entity charge-point
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	var opts Options
+	flag.StringVar(&opts.Name, "name", "", "service name in kebab case, e.g. ev-charging (required)")
+	flag.StringVar(&opts.Entity, "entity", "", "first aggregate in kebab case, e.g. charge-point (required)")
+	flag.StringVar(&opts.ModulePrefix, "module-prefix", "github.com/parking-super-app/services", "module path prefix for the new service")
+	flag.StringVar(&opts.PkgModule, "pkg-module", "github.com/parking-super-app/pkg", "module path of the shared pkg module")
+	flag.StringVar(&opts.HTTPPort, "http-port", "8080", "default HTTP port")
+	flag.StringVar(&opts.GRPCPort, "grpc-port", "9000", "default gRPC port")
+	flag.StringVar(&opts.OutDir, "out", "services", "directory to create the service in")
+	flag.Parse()
+
+	if opts.Name == "" || opts.Entity == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	files, err := Generate(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "scaffold: %v\n", err)
+		os.Exit(1)
+	}
+
+	dir := opts.Dir()
+	for _, f := range files {
+		fmt.Println("  created", filepath.Join(dir, f))
+	}
+
+	fmt.Printf(`
+Next steps:
+  go work use ./%[1]s
+  (cd %[1]s && go mod tidy && go test ./...)
+  add %[2]s_db to POSTGRES_MULTIPLE_DATABASES and a %[3]s-service to deployments/docker/docker-compose.yml
+`, filepath.ToSlash(dir), snake(opts.Name), opts.Name)
+}
@@ -0,0 +1,545 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Options controls how much data is generated and how it is spread.
+type Options struct {
+	Users     int
+	Providers int
+	Locations int
+	// SessionsPerUser is the mean; individual users follow a heavy-tailed
+	// distribution so a few power users have long histories to page through
+	SessionsPerUser float64
+	// History is how far back accounts and sessions go
+	History time.Duration
+	Seed    int64
+	Now     time.Time
+}
+
+func (o Options) Validate() error {
+	switch {
+	case o.Users < 0 || o.Providers < 0 || o.Locations < 0 || o.SessionsPerUser < 0:
+		return errors.New("volumes must not be negative")
+	case o.Locations > 0 && o.Providers == 0:
+		return errors.New("locations need at least one provider")
+	case o.Users > 0 && o.SessionsPerUser > 0 && o.Locations == 0:
+		return errors.New("sessions need at least one location")
+	case o.History <= 0:
+		return errors.New("history must be positive")
+	}
+	return nil
+}
+
+type User struct {
+	ID        uuid.UUID
+	Phone     string
+	Email     string
+	FullName  string
+	CreatedAt time.Time
+}
+
+type Vehicle struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Plate     string
+	Type      string
+	IsDefault bool
+	CreatedAt time.Time
+}
+
+type Wallet struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Balance   decimal.Decimal
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Transaction struct {
+	ID             uuid.UUID
+	WalletID       uuid.UUID
+	Type           string
+	Amount         decimal.Decimal
+	BalanceBefore  decimal.Decimal
+	BalanceAfter   decimal.Decimal
+	ReferenceID    string
+	ProviderID     *uuid.UUID
+	Description    string
+	IdempotencyKey string
+	CreatedAt      time.Time
+}
+
+// LedgerEntry is one side of a transaction's double-entry posting
+type LedgerEntry struct {
+	TransactionID uuid.UUID
+	Account       string
+	Amount        decimal.Decimal
+	CreatedAt     time.Time
+}
+
+type Provider struct {
+	ID        uuid.UUID
+	Name      string
+	Code      string
+	CreatedAt time.Time
+}
+
+type Location struct {
+	ID          uuid.UUID
+	ProviderID  uuid.UUID
+	Name        string
+	Address     string
+	City        string
+	State       string
+	Latitude    float64
+	Longitude   float64
+	TotalSpaces int
+	Amenities   []string
+	HourlyRate  decimal.Decimal
+	DailyMax    decimal.Decimal
+	CreatedAt   time.Time
+}
+
+type Session struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ProviderID    uuid.UUID
+	LocationID    uuid.UUID
+	ExternalID    string
+	VehiclePlate  string
+	VehicleType   string
+	EntryTime     time.Time
+	ExitTime      *time.Time
+	Duration      int
+	Amount        decimal.Decimal
+	Status        string
+	PaymentStatus string
+	PaymentID     *uuid.UUID
+}
+
+// Catalog is the provider side of the data set, generated once up front
+type Catalog struct {
+	Providers []Provider
+	Locations []Location
+}
+
+// Batch is a slice of users with everything that hangs off them
+type Batch struct {
+	Users        []User
+	Vehicles     []Vehicle
+	Wallets      []Wallet
+	Transactions []Transaction
+	Ledger       []LedgerEntry
+	Sessions     []Session
+}
+
+// Generator produces a deterministic data set for a given seed.
+type Generator struct {
+	opts    Options
+	rng     *rand.Rand
+	catalog Catalog
+	// locationWeights is cumulative popularity, so a few sites get most of
+	// the traffic
+	locationWeights []float64
+	locationIndex   map[uuid.UUID]int
+	users           int
+}
+
+func NewGenerator(opts Options) *Generator {
+	return &Generator{opts: opts, rng: rand.New(rand.NewSource(opts.Seed))}
+}
+
+// cities the locations cluster around, with a spread in km
+var cities = []struct {
+	name, state string
+	lat, lng    float64
+	spreadKm    float64
+	weight      float64
+}{
+	{"Kuala Lumpur", "Wilayah Persekutuan", 3.1478, 101.6953, 6, 0.35},
+	{"Petaling Jaya", "Selangor", 3.1073, 101.6067, 5, 0.15},
+	{"Shah Alam", "Selangor", 3.0733, 101.5185, 6, 0.08},
+	{"Subang Jaya", "Selangor", 3.0567, 101.5851, 4, 0.07},
+	{"George Town", "Penang", 5.4141, 100.3288, 5, 0.1},
+	{"Johor Bahru", "Johor", 1.4927, 103.7414, 7, 0.1},
+	{"Ipoh", "Perak", 4.5975, 101.0901, 5, 0.05},
+	{"Kota Kinabalu", "Sabah", 5.9804, 116.0735, 5, 0.04},
+	{"Kuching", "Sarawak", 1.5535, 110.3593, 5, 0.04},
+	{"Melaka", "Melaka", 2.1896, 102.2501, 4, 0.02},
+}
+
+var (
+	providerStems = []string{"MetroPark", "CityPark", "UrbanBay", "ParkEasy", "SmartLot", "GoPark", "Parkir", "EzyPark", "PrimeSpot", "LotOne"}
+	siteKinds     = []string{"Mall", "Plaza", "Tower", "Hospital", "Station", "Square", "Central", "Park & Ride", "Business Park", "Medical Centre"}
+	streets       = []string{"Jalan Ampang", "Jalan Tun Razak", "Jalan Sultan Ismail", "Jalan Bukit Bintang", "Jalan Raja Chulan", "Jalan Imbi", "Lebuh Pantai", "Jalan Wong Ah Fook", "Jalan SS 2", "Persiaran Surian"}
+	amenities     = []string{"covered", "ev_charging", "disabled_access", "cctv", "valet", "motorcycle_bays", "car_wash"}
+	firstNames    = []string{"Ahmad", "Siti", "Muhammad", "Nur", "Wei Ming", "Mei Ling", "Raj", "Priya", "Farah", "Hafiz", "Jia Hui", "Arjun", "Aisyah", "Daniel", "Kavitha", "Amir"}
+	lastNames     = []string{"Abdullah", "Ismail", "Tan", "Lim", "Lee", "Wong", "Kumar", "Raman", "Hassan", "Ibrahim", "Ng", "Chong", "Nair", "Yusof", "Ong", "Rahman"}
+	plateLetters  = []string{"W", "V", "B", "J", "P", "A", "S", "Q", "M", "N"}
+	topUpAmounts  = []int64{20, 30, 50, 100, 200}
+)
+
+// Catalog generates providers and their locations. Provider sizes follow a
+// power law, as a handful of operators run most sites.
+func (g *Generator) Catalog() Catalog {
+	if g.catalog.Providers != nil {
+		return g.catalog
+	}
+
+	providers := make([]Provider, g.opts.Providers)
+	for i := range providers {
+		stem := providerStems[i%len(providerStems)]
+		providers[i] = Provider{
+			ID:        g.uuid(),
+			Name:      fmt.Sprintf("%s %d", stem, i/len(providerStems)+1),
+			Code:      fmt.Sprintf("%s-%d", strings.ToLower(stem), i+1),
+			CreatedAt: g.pastTime(g.opts.History),
+		}
+	}
+
+	providerWeights := cumulative(g.opts.Providers, func(i int) float64 { return 1 / float64(i+1) })
+	cityWeights := cumulative(len(cities), func(i int) float64 { return cities[i].weight })
+
+	locations := make([]Location, g.opts.Locations)
+	for i := range locations {
+		provider := providers[g.pick(providerWeights)]
+		city := cities[g.pick(cityWeights)]
+		lat, lng := g.scatter(city.lat, city.lng, city.spreadKm)
+
+		hourly := decimal.NewFromFloat(1 + math.Round(g.rng.Float64()*8)/2) // 1.00 to 5.00 in 0.50 steps
+		locations[i] = Location{
+			ID:          g.uuid(),
+			ProviderID:  provider.ID,
+			Name:        fmt.Sprintf("%s %s", city.name, siteKinds[g.rng.Intn(len(siteKinds))]),
+			Address:     fmt.Sprintf("%d, %s", 1+g.rng.Intn(300), streets[g.rng.Intn(len(streets))]),
+			City:        city.name,
+			State:       city.state,
+			Latitude:    lat,
+			Longitude:   lng,
+			TotalSpaces: 50 + int(g.rng.ExpFloat64()*400),
+			Amenities:   g.amenities(),
+			HourlyRate:  hourly,
+			DailyMax:    hourly.Mul(decimal.NewFromInt(int64(6 + g.rng.Intn(5)))),
+			CreatedAt:   provider.CreatedAt,
+		}
+	}
+
+	g.catalog = Catalog{Providers: providers, Locations: locations}
+	g.locationIndex = make(map[uuid.UUID]int, len(locations))
+	for i, l := range locations {
+		g.locationIndex[l.ID] = i
+	}
+	g.locationWeights = cumulative(len(locations), func(i int) float64 { return 1 / math.Sqrt(float64(i+1)) })
+	return g.catalog
+}
+
+// Batch generates the next n users with their vehicles, wallets, sessions
+// and the wallet transactions that paid for them.
+func (g *Generator) Batch(n int) Batch {
+	g.Catalog()
+
+	var b Batch
+	for i := 0; i < n; i++ {
+		g.users++
+		user := User{
+			ID:        g.uuid(),
+			Phone:     fmt.Sprintf("+6011%08d", g.users),
+			FullName:  firstNames[g.rng.Intn(len(firstNames))] + " " + lastNames[g.rng.Intn(len(lastNames))],
+			CreatedAt: g.pastTime(g.opts.History),
+		}
+		if g.rng.Float64() < 0.7 {
+			user.Email = fmt.Sprintf("user%d@example.com", g.users)
+		}
+		b.Users = append(b.Users, user)
+
+		vehicles := g.vehicles(user)
+		b.Vehicles = append(b.Vehicles, vehicles...)
+
+		wallet := Wallet{ID: g.uuid(), UserID: user.ID, CreatedAt: user.CreatedAt.Add(time.Minute)}
+		sessions := g.sessions(user, vehicles)
+		txs := g.payForSessions(&wallet, sessions)
+
+		b.Wallets = append(b.Wallets, wallet)
+		b.Sessions = append(b.Sessions, sessions...)
+		b.Transactions = append(b.Transactions, txs...)
+		for _, tx := range txs {
+			b.Ledger = append(b.Ledger, postings(tx)...)
+		}
+	}
+	return b
+}
+
+func (g *Generator) vehicles(user User) []Vehicle {
+	n := 1
+	if g.rng.Float64() < 0.25 {
+		n = 2
+	}
+	vehicles := make([]Vehicle, n)
+	for i := range vehicles {
+		vehicleType := "car"
+		if g.rng.Float64() < 0.1 {
+			vehicleType = "motorcycle"
+		}
+		vehicles[i] = Vehicle{
+			ID:        g.uuid(),
+			UserID:    user.ID,
+			Plate:     g.plate(),
+			Type:      vehicleType,
+			IsDefault: i == 0,
+			CreatedAt: user.CreatedAt,
+		}
+	}
+	return vehicles
+}
+
+func (g *Generator) sessions(user User, vehicles []Vehicle) []Session {
+	if g.opts.SessionsPerUser == 0 || len(g.catalog.Locations) == 0 {
+		return nil
+	}
+
+	n := int(g.rng.ExpFloat64() * g.opts.SessionsPerUser)
+	if n == 0 {
+		return nil
+	}
+	// Regulars go back to the same few places
+	favourites := make([]Location, 1+g.rng.Intn(3))
+	for i := range favourites {
+		favourites[i] = g.catalog.Locations[g.pick(g.locationWeights)]
+	}
+
+	sessions := make([]Session, n)
+	for i := range sessions {
+		location := favourites[g.rng.Intn(len(favourites))]
+		if g.rng.Float64() < 0.3 {
+			location = g.catalog.Locations[g.pick(g.locationWeights)]
+		}
+		vehicle := vehicles[0]
+		if len(vehicles) > 1 && g.rng.Float64() < 0.3 {
+			vehicle = vehicles[1]
+		}
+		sessions[i] = Session{
+			ID:           g.uuid(),
+			UserID:       user.ID,
+			ProviderID:   location.ProviderID,
+			LocationID:   location.ID,
+			ExternalID:   fmt.Sprintf("ext-%s", g.uuid().String()[:12]),
+			VehiclePlate: vehicle.Plate,
+			VehicleType:  vehicle.Type,
+			EntryTime:    g.entryTime(user.CreatedAt),
+			Amount:       decimal.Zero,
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].EntryTime.Before(sessions[j].EntryTime) })
+
+	// Only the latest session can still be running
+	recent := g.opts.Now.Add(-24 * time.Hour)
+	for i := range sessions {
+		s := &sessions[i]
+		// Log-normal stay with a median of about 90 minutes
+		minutes := max(5, int(math.Exp(math.Log(90)+g.rng.NormFloat64()*0.8)))
+		exit := s.EntryTime.Add(time.Duration(minutes) * time.Minute)
+		last := i == len(sessions)-1
+		if !last && exit.After(g.opts.Now) {
+			exit = g.opts.Now
+			minutes = max(1, int(exit.Sub(s.EntryTime).Minutes()))
+		}
+
+		switch roll := g.rng.Float64(); {
+		case last && (exit.After(g.opts.Now) || (s.EntryTime.After(recent) && roll < 0.5)):
+			s.Status = "active"
+			s.PaymentStatus = "none"
+		case roll < 0.04:
+			s.Status = "cancelled"
+			s.PaymentStatus = "none"
+			s.ExitTime = &s.EntryTime
+		default:
+			location := g.catalog.Locations[g.locationIndex[s.LocationID]]
+			s.Status = "completed"
+			s.PaymentStatus = "paid"
+			s.ExitTime = &exit
+			s.Duration = minutes
+			s.Amount = charge(location, minutes)
+			if s.Amount.IsZero() {
+				s.PaymentStatus = "waived"
+			}
+		}
+	}
+	return sessions
+}
+
+// payForSessions replays the user's history against their wallet: each
+// completed session is paid from the balance, topped up first when short.
+func (g *Generator) payForSessions(wallet *Wallet, sessions []Session) []Transaction {
+	var txs []Transaction
+	balance := decimal.Zero
+	record := func(txType string, amount decimal.Decimal, at time.Time, session *Session) {
+		after := balance.Add(amount)
+		if txType == "payment" {
+			after = balance.Sub(amount)
+		}
+		tx := Transaction{
+			ID:             g.uuid(),
+			WalletID:       wallet.ID,
+			Type:           txType,
+			Amount:         amount,
+			BalanceBefore:  balance,
+			BalanceAfter:   after,
+			Description:    "Wallet top-up",
+			IdempotencyKey: "seed-" + g.uuid().String(),
+			CreatedAt:      at,
+		}
+		if session != nil {
+			tx.ReferenceID = session.ID.String()
+			tx.ProviderID = &session.ProviderID
+			tx.Description = "Parking payment"
+		}
+		txs = append(txs, tx)
+		balance = after
+	}
+
+	// Most users load some credit when they sign up
+	if g.rng.Float64() < 0.6 {
+		record("topup", g.topUp(decimal.Zero), wallet.CreatedAt.Add(2*time.Minute), nil)
+	}
+	for i := range sessions {
+		s := &sessions[i]
+		if s.Status != "completed" || s.Amount.IsZero() {
+			continue
+		}
+		if balance.LessThan(s.Amount) {
+			record("topup", g.topUp(s.Amount.Sub(balance)), s.EntryTime, nil)
+		}
+		record("payment", s.Amount, *s.ExitTime, s)
+		s.PaymentID = &txs[len(txs)-1].ID
+	}
+
+	wallet.Balance = balance
+	wallet.UpdatedAt = wallet.CreatedAt
+	if len(txs) > 0 {
+		wallet.UpdatedAt = txs[len(txs)-1].CreatedAt
+	}
+	return txs
+}
+
+// postings books a completed transaction against the wallet and the
+// external account the money came from or went to
+func postings(tx Transaction) []LedgerEntry {
+	delta := tx.BalanceAfter.Sub(tx.BalanceBefore)
+	counter := "external:payment_gateway"
+	if tx.Type == "payment" {
+		counter = "external:providers"
+	}
+	return []LedgerEntry{
+		{TransactionID: tx.ID, Account: "wallet:" + tx.WalletID.String(), Amount: delta, CreatedAt: tx.CreatedAt},
+		{TransactionID: tx.ID, Account: counter, Amount: delta.Neg(), CreatedAt: tx.CreatedAt},
+	}
+}
+
+// charge bills every started hour at the hourly rate, capped per day
+func charge(location Location, minutes int) decimal.Decimal {
+	days := minutes / (24 * 60)
+	hours := int(math.Ceil(float64(minutes%(24*60)) / 60))
+	partial := location.HourlyRate.Mul(decimal.NewFromInt(int64(hours)))
+	if partial.GreaterThan(location.DailyMax) {
+		partial = location.DailyMax
+	}
+	return location.DailyMax.Mul(decimal.NewFromInt(int64(days))).Add(partial)
+}
+
+// topUp picks the smallest usual amount that covers need
+func (g *Generator) topUp(need decimal.Decimal) decimal.Decimal {
+	start := g.rng.Intn(len(topUpAmounts))
+	for _, amount := range topUpAmounts[start:] {
+		if d := decimal.NewFromInt(amount); d.GreaterThanOrEqual(need) {
+			return d
+		}
+	}
+	return need.Ceil()
+}
+
+// entryTime picks a time after since, weighted to the day time
+func (g *Generator) entryTime(since time.Time) time.Time {
+	span := g.opts.Now.Sub(since)
+	if span <= 0 {
+		return g.opts.Now
+	}
+	day := since.Add(time.Duration(g.rng.Int63n(int64(span)))).Truncate(24 * time.Hour)
+	hour := 12 + g.rng.NormFloat64()*3
+	if hour < 0 || hour >= 24 {
+		hour = 12
+	}
+	entry := day.Add(time.Duration(hour * float64(time.Hour)))
+	if entry.Before(since) {
+		entry = since
+	}
+	if entry.After(g.opts.Now) {
+		entry = g.opts.Now.Add(-time.Duration(g.rng.Intn(60)+1) * time.Minute)
+	}
+	return entry
+}
+
+func (g *Generator) amenities() []string {
+	var picked []string
+	for _, a := range amenities {
+		if g.rng.Float64() < 0.3 {
+			picked = append(picked, a)
+		}
+	}
+	return picked
+}
+
+func (g *Generator) plate() string {
+	letters := plateLetters[g.rng.Intn(len(plateLetters))]
+	for i := 0; i < 2; i++ {
+		letters += string(rune('A' + g.rng.Intn(26)))
+	}
+	return fmt.Sprintf("%s%d", letters, 1+g.rng.Intn(9999))
+}
+
+// scatter places a point around a centre with a normal spread in km
+func (g *Generator) scatter(lat, lng, spreadKm float64) (float64, float64) {
+	const kmPerDegree = 111.32
+	dLat := g.rng.NormFloat64() * spreadKm / kmPerDegree
+	dLng := g.rng.NormFloat64() * spreadKm / (kmPerDegree * math.Cos(lat*math.Pi/180))
+	return math.Round((lat+dLat)*1e6) / 1e6, math.Round((lng+dLng)*1e6) / 1e6
+}
+
+func (g *Generator) pastTime(within time.Duration) time.Time {
+	return g.opts.Now.Add(-time.Duration(g.rng.Int63n(int64(within)))).Truncate(time.Second)
+}
+
+// uuid draws from the generator's source so a seed reproduces the same IDs
+func (g *Generator) uuid() uuid.UUID {
+	var id uuid.UUID
+	g.rng.Read(id[:])
+	id[6] = (id[6] & 0x0f) | 0x40
+	id[8] = (id[8] & 0x3f) | 0x80
+	return id
+}
+
+// pick draws an index from cumulative weights
+func (g *Generator) pick(weights []float64) int {
+	r := g.rng.Float64() * weights[len(weights)-1]
+	return sort.SearchFloat64s(weights, r)
+}
+
+func cumulative(n int, weight func(i int) float64) []float64 {
+	weights := make([]float64, n)
+	total := 0.0
+	for i := range weights {
+		total += weight(i)
+		weights[i] = total
+	}
+	return weights
+}
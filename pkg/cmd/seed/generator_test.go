@@ -0,0 +1,189 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func testOptions() Options {
+	return Options{
+		Users:           200,
+		Providers:       5,
+		Locations:       50,
+		SessionsPerUser: 10,
+		History:         180 * 24 * time.Hour,
+		Seed:            42,
+		Now:             time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Options)
+		wantErr bool
+	}{
+		{"valid", func(*Options) {}, false},
+		{"negative users", func(o *Options) { o.Users = -1 }, true},
+		{"locations without providers", func(o *Options) { o.Providers = 0 }, true},
+		{"sessions without locations", func(o *Options) { o.Locations = 0 }, true},
+		{"no sessions needs no locations", func(o *Options) { o.Locations, o.SessionsPerUser = 0, 0 }, false},
+		{"no history", func(o *Options) { o.History = 0 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := testOptions()
+			tt.modify(&opts)
+			if err := opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratorIsDeterministic(t *testing.T) {
+	first := NewGenerator(testOptions())
+	second := NewGenerator(testOptions())
+
+	if !reflect.DeepEqual(first.Catalog(), second.Catalog()) {
+		t.Fatal("Catalog() differs for the same seed")
+	}
+	if !reflect.DeepEqual(first.Batch(20), second.Batch(20)) {
+		t.Fatal("Batch() differs for the same seed")
+	}
+
+	other := testOptions()
+	other.Seed = 7
+	if reflect.DeepEqual(first.Catalog(), NewGenerator(other).Catalog()) {
+		t.Error("Catalog() is the same for different seeds")
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	opts := testOptions()
+	catalog := NewGenerator(opts).Catalog()
+
+	if len(catalog.Providers) != opts.Providers || len(catalog.Locations) != opts.Locations {
+		t.Fatalf("Catalog() = %d providers and %d locations, want %d and %d",
+			len(catalog.Providers), len(catalog.Locations), opts.Providers, opts.Locations)
+	}
+
+	providers := make(map[uuid.UUID]bool)
+	codes := make(map[string]bool)
+	for _, p := range catalog.Providers {
+		providers[p.ID] = true
+		if codes[p.Code] || len(p.Code) > 20 {
+			t.Errorf("provider code %q is duplicated or too long", p.Code)
+		}
+		codes[p.Code] = true
+	}
+
+	for _, l := range catalog.Locations {
+		if !providers[l.ProviderID] {
+			t.Errorf("location %s belongs to an unknown provider", l.Name)
+		}
+		if !l.HourlyRate.IsPositive() || l.DailyMax.LessThan(l.HourlyRate) {
+			t.Errorf("location %s pricing = %s hourly, %s daily", l.Name, l.HourlyRate, l.DailyMax)
+		}
+		if d := nearestCityKm(l.Latitude, l.Longitude); d > 50 {
+			t.Errorf("location %s is %.0f km from any city", l.Name, d)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	opts := testOptions()
+	gen := NewGenerator(opts)
+	catalog := gen.Catalog()
+
+	locations := make(map[uuid.UUID]Location)
+	for _, l := range catalog.Locations {
+		locations[l.ID] = l
+	}
+
+	first, second := gen.Batch(opts.Users/2), gen.Batch(opts.Users/2)
+	users := append(first.Users, second.Users...)
+	if len(users) != opts.Users {
+		t.Fatalf("batches have %d users, want %d", len(users), opts.Users)
+	}
+	phones := make(map[string]bool)
+	for _, u := range users {
+		if phones[u.Phone] {
+			t.Errorf("phone %s is duplicated", u.Phone)
+		}
+		phones[u.Phone] = true
+	}
+
+	for _, b := range []Batch{first, second} {
+		if len(b.Wallets) != len(b.Users) {
+			t.Errorf("batch has %d wallets for %d users", len(b.Wallets), len(b.Users))
+		}
+
+		payments := make(map[uuid.UUID]Transaction)
+		for _, tx := range b.Transactions {
+			if tx.BalanceAfter.IsNegative() {
+				t.Errorf("transaction %s leaves a negative balance %s", tx.ID, tx.BalanceAfter)
+			}
+			if tx.Type == "payment" {
+				payments[tx.ID] = tx
+			}
+		}
+
+		active := make(map[uuid.UUID]int)
+		for _, s := range b.Sessions {
+			location, ok := locations[s.LocationID]
+			if !ok || location.ProviderID != s.ProviderID {
+				t.Errorf("session %s references a location outside the catalog", s.ID)
+			}
+			if s.EntryTime.After(opts.Now) || (s.ExitTime != nil && s.ExitTime.Before(s.EntryTime)) {
+				t.Errorf("session %s runs from %v to %v", s.ID, s.EntryTime, s.ExitTime)
+			}
+			switch s.Status {
+			case "active":
+				active[s.UserID]++
+			case "completed":
+				if s.PaymentStatus == "paid" {
+					if s.PaymentID == nil || !payments[*s.PaymentID].Amount.Equal(s.Amount) {
+						t.Errorf("session %s is not paid by a matching transaction", s.ID)
+					}
+				}
+			}
+		}
+		for user, n := range active {
+			if n > 1 {
+				t.Errorf("user %s has %d active sessions", user, n)
+			}
+		}
+
+		// The ledger balances and agrees with every wallet
+		accounts := make(map[string]decimal.Decimal)
+		total := decimal.Zero
+		for _, e := range b.Ledger {
+			accounts[e.Account] = accounts[e.Account].Add(e.Amount)
+			total = total.Add(e.Amount)
+		}
+		if !total.IsZero() {
+			t.Errorf("ledger sums to %s, want 0", total)
+		}
+		for _, w := range b.Wallets {
+			if got := accounts["wallet:"+w.ID.String()]; !got.Equal(w.Balance) {
+				t.Errorf("wallet %s balance = %s, ledger = %s", w.ID, w.Balance, got)
+			}
+		}
+	}
+}
+
+func nearestCityKm(lat, lng float64) float64 {
+	nearest := math.Inf(1)
+	for _, c := range cities {
+		dLat := (lat - c.lat) * 111.32
+		dLng := (lng - c.lng) * 111.32 * math.Cos(c.lat*math.Pi/180)
+		nearest = math.Min(nearest, math.Hypot(dLat, dLng))
+	}
+	return nearest
+}
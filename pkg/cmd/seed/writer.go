@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Databases holds a pool per service database. Each service owns its schema,
+// so rows are copied straight into the tables the services read.
+type Databases struct {
+	Auth     *pgxpool.Pool
+	Wallet   *pgxpool.Pool
+	Provider *pgxpool.Pool
+	Parking  *pgxpool.Pool
+}
+
+// seededTables lists what Truncate clears, per database
+var seededTables = map[string][]string{
+	"auth":     {"users"},
+	"wallet":   {"ledger_entries", "transactions", "wallets"},
+	"provider": {"locations", "providers"},
+	"parking":  {"parking_sessions", "vehicles"},
+}
+
+func (d *Databases) each() map[string]*pgxpool.Pool {
+	return map[string]*pgxpool.Pool{
+		"auth":     d.Auth,
+		"wallet":   d.Wallet,
+		"provider": d.Provider,
+		"parking":  d.Parking,
+	}
+}
+
+// Truncate empties the seeded tables and everything referencing them
+func (d *Databases) Truncate(ctx context.Context) error {
+	for name, pool := range d.each() {
+		for _, table := range seededTables[name] {
+			if _, err := pool.Exec(ctx, "TRUNCATE "+table+" CASCADE"); err != nil {
+				return fmt.Errorf("failed to truncate %s.%s: %w", name, table, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WriteCatalog copies providers and locations into the provider database
+func (d *Databases) WriteCatalog(ctx context.Context, c Catalog) error {
+	return pgx.BeginFunc(ctx, d.Provider, func(tx pgx.Tx) error {
+		if err := copyRows(ctx, tx, "providers",
+			[]string{"id", "name", "code", "status", "mfe_url", "api_base_url", "created_at", "updated_at"},
+			len(c.Providers), func(i int) []any {
+				p := c.Providers[i]
+				return []any{p.ID, p.Name, p.Code, "active",
+					"https://mfe.example.com/" + p.Code, "https://api.example.com/" + p.Code,
+					p.CreatedAt, p.CreatedAt}
+			}); err != nil {
+			return err
+		}
+		return copyRows(ctx, tx, "locations",
+			[]string{"id", "provider_id", "name", "address", "city", "state", "latitude", "longitude",
+				"total_spaces", "amenities", "hourly_rate", "daily_max", "created_at", "updated_at"},
+			len(c.Locations), func(i int) []any {
+				l := c.Locations[i]
+				return []any{l.ID, l.ProviderID, l.Name, l.Address, l.City, l.State, l.Latitude, l.Longitude,
+					l.TotalSpaces, l.Amenities, l.HourlyRate, l.DailyMax, l.CreatedAt, l.CreatedAt}
+			})
+	})
+}
+
+// WriteBatch copies a batch of users into each service database. Every
+// database commits on its own, so a failure part way leaves earlier batches
+// in place.
+func (d *Databases) WriteBatch(ctx context.Context, b Batch) error {
+	err := pgx.BeginFunc(ctx, d.Auth, func(tx pgx.Tx) error {
+		return copyRows(ctx, tx, "users",
+			[]string{"id", "phone", "email", "password_hash", "full_name", "status", "created_at", "updated_at"},
+			len(b.Users), func(i int) []any {
+				u := b.Users[i]
+				return []any{u.ID, u.Phone, nullable(u.Email), passwordHash, u.FullName, "active", u.CreatedAt, u.CreatedAt}
+			})
+	})
+	if err != nil {
+		return err
+	}
+
+	err = pgx.BeginFunc(ctx, d.Wallet, func(tx pgx.Tx) error {
+		if err := copyRows(ctx, tx, "wallets",
+			[]string{"id", "user_id", "balance", "currency", "status", "created_at", "updated_at"},
+			len(b.Wallets), func(i int) []any {
+				w := b.Wallets[i]
+				return []any{w.ID, w.UserID, w.Balance, "MYR", "active", w.CreatedAt, w.UpdatedAt}
+			}); err != nil {
+			return err
+		}
+		if err := copyRows(ctx, tx, "transactions",
+			[]string{"id", "wallet_id", "type", "amount", "balance_before", "balance_after", "reference_id",
+				"provider_id", "status", "description", "idempotency_key", "created_at", "updated_at"},
+			len(b.Transactions), func(i int) []any {
+				t := b.Transactions[i]
+				return []any{t.ID, t.WalletID, t.Type, t.Amount, t.BalanceBefore, t.BalanceAfter, nullable(t.ReferenceID),
+					t.ProviderID, "completed", t.Description, t.IdempotencyKey, t.CreatedAt, t.CreatedAt}
+			}); err != nil {
+			return err
+		}
+		return copyRows(ctx, tx, "ledger_entries",
+			[]string{"transaction_id", "account", "amount", "created_at"},
+			len(b.Ledger), func(i int) []any {
+				e := b.Ledger[i]
+				return []any{e.TransactionID, e.Account, e.Amount, e.CreatedAt}
+			})
+	})
+	if err != nil {
+		return err
+	}
+
+	return pgx.BeginFunc(ctx, d.Parking, func(tx pgx.Tx) error {
+		if err := copyRows(ctx, tx, "vehicles",
+			[]string{"id", "user_id", "plate", "type", "is_default", "created_at"},
+			len(b.Vehicles), func(i int) []any {
+				v := b.Vehicles[i]
+				return []any{v.ID, v.UserID, v.Plate, v.Type, v.IsDefault, v.CreatedAt}
+			}); err != nil {
+			return err
+		}
+		return copyRows(ctx, tx, "parking_sessions",
+			[]string{"id", "user_id", "provider_id", "location_id", "external_session_id", "vehicle_plate",
+				"vehicle_type", "entry_time", "exit_time", "duration_minutes", "amount", "currency", "status",
+				"payment_status", "payment_id", "created_at", "updated_at"},
+			len(b.Sessions), func(i int) []any {
+				s := b.Sessions[i]
+				updated := s.EntryTime
+				if s.ExitTime != nil {
+					updated = *s.ExitTime
+				}
+				return []any{s.ID, s.UserID, s.ProviderID, s.LocationID, s.ExternalID, s.VehiclePlate,
+					s.VehicleType, s.EntryTime, s.ExitTime, s.Duration, s.Amount, "MYR", s.Status,
+					s.PaymentStatus, s.PaymentID, s.EntryTime, updated}
+			})
+	})
+}
+
+func copyRows(ctx context.Context, tx pgx.Tx, table string, columns []string, n int, row func(i int) []any) error {
+	if n == 0 {
+		return nil
+	}
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{table}, columns, pgx.CopyFromSlice(n, func(i int) ([]any, error) {
+		return row(i), nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to copy %s: %w", table, err)
+	}
+	return nil
+}
+
+func nullable(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
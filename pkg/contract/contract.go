@@ -0,0 +1,144 @@
+// Package contract checks the HTTP and gRPC boundaries between services.
+//
+// A consumer records what it relies on - routes, gRPC methods and the
+// fields it sends and reads - as a fixture under fixtures/, generated from
+// its own DTOs and proto messages. Each provider verifies every fixture
+// naming it against its router, its gRPC service descriptors and its DTOs,
+// so a change that breaks a consumer fails the provider's build.
+//
+// Fixtures are rewritten by running the consumer's tests with
+// CONTRACT_UPDATE=1; without it a consumer test fails when its
+// expectations drift from the recorded fixture.
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// UpdateEnv rewrites fixtures when set to a true value
+const UpdateEnv = "CONTRACT_UPDATE"
+
+// Kind is the transport of an interaction
+type Kind string
+
+const (
+	KindHTTP Kind = "http"
+	KindGRPC Kind = "grpc"
+)
+
+// Interaction is one call a consumer makes
+type Interaction struct {
+	Name string `json:"name"`
+	Kind Kind   `json:"kind"`
+	// Method is the HTTP method, "*" for any, or the full gRPC method name
+	Method string `json:"method"`
+	// Path is the HTTP route pattern. A pattern ending in /* asks for any
+	// route under the prefix, for paths a consumer forwards without
+	// knowing them.
+	Path     string `json:"path,omitempty"`
+	Request  Shape  `json:"request,omitempty"`
+	Response Shape  `json:"response,omitempty"`
+}
+
+// Contract is what one consumer expects of one provider
+type Contract struct {
+	Consumer     string        `json:"consumer"`
+	Provider     string        `json:"provider"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Name is the fixture name of the contract
+func (c Contract) Name() string {
+	return c.Consumer + "-" + c.Provider
+}
+
+// GRPCInteraction describes a call to a gRPC method by the request fields
+// the consumer sets and the response fields it reads
+func GRPCInteraction(method protoreflect.MethodDescriptor, sends, reads []string) Interaction {
+	service := method.Parent().(protoreflect.ServiceDescriptor)
+	return Interaction{
+		Name:     string(service.Name()) + "." + string(method.Name()),
+		Kind:     KindGRPC,
+		Method:   "/" + string(service.FullName()) + "/" + string(method.Name()),
+		Request:  ShapeOfMessage(method.Input()).Pick(sends...),
+		Response: ShapeOfMessage(method.Output()).Pick(reads...),
+	}
+}
+
+// fixtureDir is the fixtures directory next to this file, shared by every
+// module in the workspace
+func fixtureDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "fixtures")
+}
+
+func encode(c Contract) ([]byte, error) {
+	sort.SliceStable(c.Interactions, func(i, j int) bool {
+		return c.Interactions[i].Name < c.Interactions[j].Name
+	})
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Record checks a consumer's expectations against its recorded fixture, or
+// rewrites the fixture when CONTRACT_UPDATE is set
+func Record(t *testing.T, c Contract) {
+	t.Helper()
+	data, err := encode(c)
+	if err != nil {
+		t.Fatalf("failed to encode contract %s: %v", c.Name(), err)
+	}
+	path := filepath.Join(fixtureDir(), c.Name()+".json")
+
+	if update, _ := strconv.ParseBool(os.Getenv(UpdateEnv)); update {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatalf("failed to write contract %s: %v", c.Name(), err)
+		}
+		return
+	}
+
+	recorded, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("contract %s is not recorded; run the tests with %s=1: %v", c.Name(), UpdateEnv, err)
+	}
+	if !bytes.Equal(recorded, data) {
+		t.Errorf("contract %s differs from %s; run the tests with %s=1 and check that %s still verifies it",
+			c.Name(), path, UpdateEnv, c.Provider)
+	}
+}
+
+// Load returns every recorded contract with the given provider
+func Load(provider string) ([]Contract, error) {
+	paths, err := filepath.Glob(filepath.Join(fixtureDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var contracts []Contract
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c Contract
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("invalid contract %s: %w", filepath.Base(path), err)
+		}
+		if c.Provider == provider {
+			contracts = append(contracts, c)
+		}
+	}
+	return contracts, nil
+}
@@ -0,0 +1,108 @@
+package contract
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+)
+
+type testLine struct {
+	SKU   string  `json:"sku"`
+	Price float64 `json:"price"`
+}
+
+type testBase struct {
+	ID string `json:"id"`
+}
+
+type testOrder struct {
+	testBase
+	Status    string            `json:"status,omitempty"`
+	Paid      bool              `json:"paid"`
+	Lines     []testLine        `json:"lines"`
+	Meta      map[string]string `json:"meta"`
+	CreatedAt time.Time         `json:"created_at"`
+	Note      *string           `json:"note"`
+	Raw       []byte            `json:"raw"`
+	internal  string
+	Skipped   string `json:"-"`
+}
+
+func TestShapeOf(t *testing.T) {
+	want := Shape{
+		"id":            TypeString,
+		"status":        TypeString,
+		"paid":          TypeBoolean,
+		"lines[]":       TypeObject,
+		"lines[].sku":   TypeString,
+		"lines[].price": TypeNumber,
+		"meta":          TypeAny,
+		"created_at":    TypeString,
+		"note":          TypeString,
+		"raw":           TypeBytes,
+	}
+	if got := ShapeOf(testOrder{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ShapeOf() = %v, want %v", got, want)
+	}
+}
+
+func TestShapeOfMessage(t *testing.T) {
+	shape := ShapeOfMessage((&walletv1.PayRequest{}).ProtoReflect().Descriptor())
+	if shape["wallet_id"] != TypeString {
+		t.Errorf("wallet_id = %q, want string in %v", shape["wallet_id"], shape)
+	}
+}
+
+func TestMissing(t *testing.T) {
+	expected := Shape{"id": TypeString, "amount": TypeString, "paid": TypeBoolean, "extra": TypeAny}
+	actual := Shape{"id": TypeString, "amount": TypeNumber, "extra": TypeObject, "new": TypeString}
+
+	want := []string{
+		`field "amount" is number, want string`,
+		`field "paid" is missing`,
+	}
+	if got := Missing(expected, actual); !reflect.DeepEqual(got, want) {
+		t.Errorf("Missing() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	noop := func(w http.ResponseWriter, r *http.Request) {}
+	r := chi.NewRouter()
+	r.Get("/health", noop)
+	r.Route("/api/v1/providers", func(r chi.Router) {
+		r.Get("/", noop)
+		r.Get("/{providerID}", noop)
+		r.Post("/{id:[0-9a-f-]+}/locations", noop)
+	})
+	routes, err := walkRoutes(r)
+	if err != nil {
+		t.Fatalf("walkRoutes() error = %v", err)
+	}
+
+	tests := []struct {
+		method, path string
+		want         bool
+	}{
+		{"GET", "/health", true},
+		{"POST", "/health", false},
+		{"GET", "/api/v1/providers", true},
+		{"GET", "/api/v1/providers/{id}", true},
+		{"POST", "/api/v1/providers/{id}/locations", true},
+		{"POST", "/api/v1/providers/{id}/*", true},
+		{"*", "/api/v1/providers/*", true},
+		{"*", "/health/*", true},
+		{"*", "/api/v1/wallet/*", false},
+		{"*", "/api/v1/provider/*", false},
+		{"DELETE", "/api/v1/providers/{id}", false},
+	}
+	for _, tt := range tests {
+		if got := matchRoute(routes, tt.method, tt.path); got != tt.want {
+			t.Errorf("matchRoute(%s %s) = %v, want %v", tt.method, tt.path, got, tt.want)
+		}
+	}
+}
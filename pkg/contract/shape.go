@@ -0,0 +1,208 @@
+package contract
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Field types of a shape, as they appear in JSON or protobuf
+const (
+	TypeString  = "string"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+	TypeObject  = "object"
+	TypeBytes   = "bytes"
+	TypeEnum    = "enum"
+	TypeAny     = "any"
+)
+
+// Shape maps the path of each field of a message to its type. Nested fields
+// are joined with dots and list elements are marked with [], e.g.
+// "locations[].id".
+type Shape map[string]string
+
+// Paths returns the field paths in order
+func (s Shape) Paths() []string {
+	paths := make([]string, 0, len(s))
+	for path := range s {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Pick returns the shape of the given fields only, for a consumer that reads
+// part of a message. It panics on a field the shape does not have, which is
+// a mistake in the consumer's test.
+func (s Shape) Pick(paths ...string) Shape {
+	picked := make(Shape, len(paths))
+	for _, path := range paths {
+		typ, ok := s[path]
+		if !ok {
+			panic(fmt.Sprintf("contract: no field %q in shape", path))
+		}
+		picked[path] = typ
+	}
+	return picked
+}
+
+var (
+	textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// ShapeOf derives the JSON shape of a DTO from its type and json tags.
+// Types that marshal themselves to text, such as time.Time, uuid.UUID and
+// decimal.Decimal, are strings.
+func ShapeOf(v interface{}) Shape {
+	shape := make(Shape)
+	addType(shape, "", reflect.TypeOf(v))
+	return shape
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func addType(shape Shape, path string, t reflect.Type) {
+	t = indirect(t)
+	switch {
+	case t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler):
+		shape[path] = TypeString
+		return
+	case t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler):
+		shape[path] = TypeAny
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if path != "" {
+			shape[path] = TypeObject
+		}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := jsonName(field)
+			if !ok {
+				continue
+			}
+			if field.Anonymous && name == "" {
+				if indirect(field.Type).Kind() == reflect.Struct {
+					addType(shape, path, field.Type)
+				}
+				continue
+			}
+			addType(shape, join(path, name), field.Type)
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			shape[path] = TypeBytes
+			return
+		}
+		addType(shape, path+"[]", t.Elem())
+	case reflect.Map, reflect.Interface:
+		shape[path] = TypeAny
+	case reflect.String:
+		shape[path] = TypeString
+	case reflect.Bool:
+		shape[path] = TypeBoolean
+	default:
+		shape[path] = TypeNumber
+	}
+}
+
+// jsonName returns the JSON name of a struct field, "" for an embedded
+// struct whose fields are promoted, and false for a field not marshalled
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	// Fields of an embedded struct are promoted even when its type is unexported
+	if !field.IsExported() && !(field.Anonymous && tag == "") {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+	return name, true
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// ShapeOfMessage derives the shape of a protobuf message from its
+// descriptor, using the proto field names
+func ShapeOfMessage(desc protoreflect.MessageDescriptor) Shape {
+	shape := make(Shape)
+	addMessage(shape, "", desc, map[protoreflect.FullName]bool{})
+	return shape
+}
+
+func addMessage(shape Shape, path string, desc protoreflect.MessageDescriptor, seen map[protoreflect.FullName]bool) {
+	if seen[desc.FullName()] {
+		shape[path] = TypeObject // Recursive message; its fields are listed where it first appears
+		return
+	}
+	seen[desc.FullName()] = true
+	defer delete(seen, desc.FullName())
+
+	fields := desc.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		fieldPath := join(path, string(field.Name()))
+		if field.IsList() {
+			fieldPath += "[]"
+		}
+		if field.IsMap() {
+			shape[fieldPath] = TypeAny
+			continue
+		}
+		switch field.Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			shape[fieldPath] = TypeObject
+			addMessage(shape, fieldPath, field.Message(), seen)
+		case protoreflect.StringKind:
+			shape[fieldPath] = TypeString
+		case protoreflect.BoolKind:
+			shape[fieldPath] = TypeBoolean
+		case protoreflect.BytesKind:
+			shape[fieldPath] = TypeBytes
+		case protoreflect.EnumKind:
+			shape[fieldPath] = TypeEnum
+		default:
+			shape[fieldPath] = TypeNumber
+		}
+	}
+}
+
+// Missing lists how actual fails to carry what expected relies on: absent
+// fields and fields of another type. Extra fields in actual are fine.
+func Missing(expected, actual Shape) []string {
+	var problems []string
+	for _, path := range expected.Paths() {
+		want := expected[path]
+		got, ok := actual[path]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("field %q is missing", path))
+		case want != got && want != TypeAny && got != TypeAny:
+			problems = append(problems, fmt.Sprintf("field %q is %s, want %s", path, got, want))
+		}
+	}
+	return problems
+}
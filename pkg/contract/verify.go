@@ -0,0 +1,162 @@
+package contract
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Shapes are the request and response bodies a provider uses for an HTTP
+// interaction
+type Shapes struct {
+	Request  Shape
+	Response Shape
+}
+
+// Provider is what a provider offers its consumers
+type Provider struct {
+	Name     string
+	Routes   chi.Routes
+	Services []protoreflect.ServiceDescriptor
+	// Bodies holds the provider's DTO shapes by interaction name, for the
+	// HTTP interactions whose consumer records bodies
+	Bodies map[string]Shapes
+}
+
+// Verify checks the provider against every contract recorded for it
+func Verify(t *testing.T, p Provider) {
+	t.Helper()
+	contracts, err := Load(p.Name)
+	if err != nil {
+		t.Fatalf("failed to load contracts: %v", err)
+	}
+	if len(contracts) == 0 {
+		t.Fatalf("no contracts are recorded for %s", p.Name)
+	}
+
+	var routes []route
+	if p.Routes != nil {
+		if routes, err = walkRoutes(p.Routes); err != nil {
+			t.Fatalf("failed to list routes: %v", err)
+		}
+	}
+
+	for _, c := range contracts {
+		t.Run(c.Consumer, func(t *testing.T) {
+			for _, in := range c.Interactions {
+				var problems []string
+				switch in.Kind {
+				case KindHTTP:
+					problems = p.verifyHTTP(routes, in)
+				case KindGRPC:
+					problems = p.verifyGRPC(in)
+				default:
+					problems = []string{"unknown interaction kind " + string(in.Kind)}
+				}
+				for _, problem := range problems {
+					t.Errorf("%s breaks %q of %s: %s", p.Name, in.Name, c.Consumer, problem)
+				}
+			}
+		})
+	}
+}
+
+func (p Provider) verifyHTTP(routes []route, in Interaction) []string {
+	if p.Routes == nil {
+		return []string{"provider has no HTTP routes"}
+	}
+	if !matchRoute(routes, in.Method, in.Path) {
+		return []string{"no route for " + in.Method + " " + in.Path}
+	}
+	if len(in.Request) == 0 && len(in.Response) == 0 {
+		return nil
+	}
+	bodies, ok := p.Bodies[in.Name]
+	if !ok {
+		return []string{"provider gives no body shapes to check"}
+	}
+	var problems []string
+	// The provider must accept every field the consumer sends and return
+	// every field it reads
+	for _, problem := range Missing(in.Request, bodies.Request) {
+		problems = append(problems, "request "+problem)
+	}
+	for _, problem := range Missing(in.Response, bodies.Response) {
+		problems = append(problems, "response "+problem)
+	}
+	return problems
+}
+
+func (p Provider) verifyGRPC(in Interaction) []string {
+	service, method, ok := strings.Cut(strings.TrimPrefix(in.Method, "/"), "/")
+	if !ok {
+		return []string{"invalid gRPC method " + in.Method}
+	}
+	for _, desc := range p.Services {
+		if string(desc.FullName()) != service {
+			continue
+		}
+		m := desc.Methods().ByName(protoreflect.Name(method))
+		if m == nil {
+			return []string{"no method " + in.Method}
+		}
+		var problems []string
+		for _, problem := range Missing(in.Request, ShapeOfMessage(m.Input())) {
+			problems = append(problems, "request "+problem)
+		}
+		for _, problem := range Missing(in.Response, ShapeOfMessage(m.Output())) {
+			problems = append(problems, "response "+problem)
+		}
+		return problems
+	}
+	return []string{"no service " + service}
+}
+
+type route struct {
+	method  string
+	pattern string
+}
+
+func walkRoutes(routes chi.Routes) ([]route, error) {
+	var found []route
+	err := chi.Walk(routes, func(method, pattern string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		found = append(found, route{method: method, pattern: normalizePattern(pattern)})
+		return nil
+	})
+	return found, err
+}
+
+var paramPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// normalizePattern makes patterns comparable: parameter names and regexps
+// are dropped and a trailing slash is ignored
+func normalizePattern(pattern string) string {
+	pattern = paramPattern.ReplaceAllString(pattern, "{}")
+	if len(pattern) > 1 {
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+	return pattern
+}
+
+func matchRoute(routes []route, method, path string) bool {
+	prefix, isPrefix := strings.CutSuffix(path, "/*")
+	prefix = normalizePattern(prefix)
+	path = normalizePattern(path)
+	for _, r := range routes {
+		if method != "*" && r.method != method && r.method != "*" {
+			continue
+		}
+		// A forwarded prefix also covers the prefix itself
+		if isPrefix && (r.pattern == prefix || strings.HasPrefix(r.pattern, prefix+"/")) {
+			return true
+		}
+		if !isPrefix && r.pattern == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Package deadline carries a request's time budget from the gateway to every
+// service it calls.
+//
+// The gateway starts a Budget for each request; the context's deadline is
+// the end of the budget. gRPC passes the deadline on by itself. HTTP calls
+// send what remains in the X-Request-Timeout header (Inject), and services
+// adopt it as their own deadline (FromHeader), so a service stops working on
+// a request the gateway has already given up on.
+//
+// Downstream calls are recorded on the budget (Track), so a request that
+// runs out of time can report which calls finished and which were still
+// pending.
+package deadline
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Header carries the remaining budget in milliseconds
+const Header = "X-Request-Timeout"
+
+// Call states
+const (
+	StatePending = "pending"
+	StateOK      = "ok"
+	StateFailed  = "failed"
+)
+
+// Call is one downstream call made within a budget
+type Call struct {
+	Target    string `json:"target"`
+	State     string `json:"state"`
+	ElapsedMS int64  `json:"elapsed_ms"`
+}
+
+// Budget is the time a request may take, and the calls it made
+type Budget struct {
+	Limit time.Duration
+	Start time.Time
+
+	mu    sync.Mutex
+	calls []*call
+}
+
+type call struct {
+	target string
+	start  time.Time
+	end    time.Time
+	err    error
+}
+
+type budgetKey struct{}
+
+// WithBudget bounds ctx by limit and records calls made with it
+func WithBudget(ctx context.Context, limit time.Duration) (context.Context, *Budget, context.CancelFunc) {
+	b := &Budget{Limit: limit, Start: time.Now()}
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, budgetKey{}, b), limit)
+	return ctx, b, cancel
+}
+
+// FromContext returns the budget ctx was made with, or nil
+func FromContext(ctx context.Context) *Budget {
+	b, _ := ctx.Value(budgetKey{}).(*Budget)
+	return b
+}
+
+// Track records a call to target on the context's budget. Call the returned
+// function with the call's error when it completes. Without a budget it does
+// nothing.
+func Track(ctx context.Context, target string) func(error) {
+	b := FromContext(ctx)
+	if b == nil {
+		return func(error) {}
+	}
+	c := &call{target: target, start: time.Now()}
+	b.mu.Lock()
+	b.calls = append(b.calls, c)
+	b.mu.Unlock()
+
+	return func(err error) {
+		b.mu.Lock()
+		c.end, c.err = time.Now(), err
+		b.mu.Unlock()
+	}
+}
+
+// Elapsed is the time spent since the budget started
+func (b *Budget) Elapsed() time.Duration {
+	return time.Since(b.Start)
+}
+
+// Calls returns the calls made so far, in the order they started. A pending
+// call's elapsed time runs to now.
+func (b *Budget) Calls() []Call {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	calls := make([]Call, len(b.calls))
+	for i, c := range b.calls {
+		end, state := c.end, StateOK
+		switch {
+		case end.IsZero():
+			end, state = now, StatePending
+		case c.err != nil:
+			state = StateFailed
+		}
+		calls[i] = Call{Target: c.target, State: state, ElapsedMS: end.Sub(c.start).Milliseconds()}
+	}
+	return calls
+}
+
+// Inject sets Header to the time left before the context's deadline. It
+// does nothing when the context has no deadline.
+func Inject(ctx context.Context, h http.Header) {
+	if deadline, ok := ctx.Deadline(); ok {
+		remaining := time.Until(deadline).Milliseconds()
+		if remaining < 1 {
+			remaining = 1
+		}
+		h.Set(Header, strconv.FormatInt(remaining, 10))
+	}
+}
+
+// FromHeader bounds each request's context by the budget its caller sent in
+// Header. Requests without a valid header are left as they are.
+func FromHeader(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ms, err := strconv.ParseInt(r.Header.Get(Header), 10, 64)
+		if err != nil || ms <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), time.Duration(ms)*time.Millisecond)
+		defer cancel()
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
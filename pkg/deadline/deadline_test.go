@@ -0,0 +1,88 @@
+package deadline
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBudget_Calls(t *testing.T) {
+	ctx, b, cancel := WithBudget(context.Background(), time.Second)
+	defer cancel()
+
+	Track(ctx, "wallet")(nil)
+	Track(ctx, "parking")(errors.New("unavailable"))
+	Track(ctx, "provider")
+
+	calls := b.Calls()
+	want := []string{StateOK, StateFailed, StatePending}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %+v", calls)
+	}
+	for i, state := range want {
+		if calls[i].State != state {
+			t.Errorf("calls[%d] = %+v, want state %s", i, calls[i], state)
+		}
+	}
+
+	// Without a budget, tracking is a no-op
+	Track(context.Background(), "auth")(nil)
+}
+
+func TestInject(t *testing.T) {
+	h := http.Header{}
+	Inject(context.Background(), h)
+	if h.Get(Header) != "" {
+		t.Errorf("header without a deadline = %q", h.Get(Header))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	Inject(ctx, h)
+	ms, err := strconv.Atoi(h.Get(Header))
+	if err != nil || ms <= 1000 || ms > 2000 {
+		t.Errorf("header = %q, want about 2000", h.Get(Header))
+	}
+}
+
+func TestFromHeader(t *testing.T) {
+	tests := []struct {
+		name         string
+		header       string
+		wantDeadline bool
+	}{
+		{"budget", "1500", true},
+		{"no header", "", false},
+		{"invalid", "soon", false},
+		{"zero", "0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			var ok bool
+			handler := FromHeader(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var deadline time.Time
+				deadline, ok = r.Context().Deadline()
+				remaining = time.Until(deadline)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if ok != tt.wantDeadline {
+				t.Fatalf("deadline set = %v, want %v", ok, tt.wantDeadline)
+			}
+			if ok && (remaining <= time.Second || remaining > 1500*time.Millisecond) {
+				t.Errorf("remaining = %v, want about 1.5s", remaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Package eventstore keeps a searchable history of the domain events services
+// publish, so support and engineers can follow a flow across services
+// ("all wallet events for user X today") without replaying Kafka topics.
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/kafka"
+)
+
+var (
+	ErrRecordNotFound = errors.New("event record not found")
+	ErrInvalidRange   = errors.New("from must be before to")
+)
+
+// Search limits
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+// Record is a published event as stored for later inspection
+type Record struct {
+	ID          uuid.UUID              `json:"id"`
+	Service     string                 `json:"service"`
+	Type        string                 `json:"type"`
+	AggregateID string                 `json:"aggregate_id,omitempty"`
+	UserID      string                 `json:"user_id,omitempty"`
+	TraceID     string                 `json:"trace_id,omitempty"`
+	Payload     map[string]interface{} `json:"payload"`
+	OccurredAt  time.Time              `json:"occurred_at"`
+}
+
+// aggregateKeys are the payload keys that identify the entity an event is
+// about, most specific first. A key named after the event's prefix
+// ("wallet.created" -> "wallet_id") wins over this list.
+var aggregateKeys = []string{
+	"session_id",
+	"transaction_id",
+	"wallet_id",
+	"notification_id",
+	"location_id",
+	"provider_id",
+	"user_id",
+}
+
+// NewRecord builds a record for an event published by service
+func NewRecord(service string, event kafka.Event) *Record {
+	occurredAt := event.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+	payload := event.Payload
+	if payload == nil {
+		payload = map[string]interface{}{}
+	}
+
+	return &Record{
+		ID:          uuid.New(),
+		Service:     service,
+		Type:        event.Type,
+		AggregateID: aggregateID(event.Type, payload),
+		UserID:      stringValue(payload, "user_id"),
+		TraceID:     event.TraceID,
+		Payload:     payload,
+		OccurredAt:  occurredAt.UTC(),
+	}
+}
+
+func aggregateID(eventType string, payload map[string]interface{}) string {
+	if prefix, _, ok := strings.Cut(eventType, "."); ok {
+		if id := stringValue(payload, prefix+"_id"); id != "" {
+			return id
+		}
+	}
+	for _, key := range aggregateKeys {
+		if id := stringValue(payload, key); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+func stringValue(payload map[string]interface{}, key string) string {
+	switch v := payload[key].(type) {
+	case string:
+		return v
+	case interface{ String() string }:
+		return v.String()
+	default:
+		return ""
+	}
+}
+
+// Query filters a search. Empty fields match everything. Type may end in "*"
+// to match a prefix, e.g. "wallet.*".
+type Query struct {
+	Service     string
+	Type        string
+	AggregateID string
+	UserID      string
+	TraceID     string
+	From        time.Time
+	To          time.Time
+	Limit       int
+	Offset      int
+}
+
+// Normalize validates the query and clamps its paging
+func (q *Query) Normalize() error {
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return ErrInvalidRange
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return nil
+}
+
+// Matches reports whether a record satisfies the query's filters
+func (q Query) Matches(r *Record) bool {
+	if q.Service != "" && r.Service != q.Service {
+		return false
+	}
+	if q.Type != "" {
+		if prefix, ok := strings.CutSuffix(q.Type, "*"); ok {
+			if !strings.HasPrefix(r.Type, prefix) {
+				return false
+			}
+		} else if r.Type != q.Type {
+			return false
+		}
+	}
+	if q.AggregateID != "" && r.AggregateID != q.AggregateID {
+		return false
+	}
+	if q.UserID != "" && r.UserID != q.UserID {
+		return false
+	}
+	if q.TraceID != "" && r.TraceID != q.TraceID {
+		return false
+	}
+	if !q.From.IsZero() && r.OccurredAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !r.OccurredAt.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// Store persists event records. Search returns matches newest first.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	Append(ctx context.Context, record *Record) error
+	Get(ctx context.Context, id uuid.UUID) (*Record, error)
+	Search(ctx context.Context, q Query) ([]*Record, error)
+}
@@ -0,0 +1,172 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/kafka"
+)
+
+func TestNewRecord_AggregateID(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		payload map[string]interface{}
+		want    string
+	}{
+		{"prefix key wins", "wallet.created", map[string]interface{}{"user_id": "u1", "wallet_id": "w1"}, "w1"},
+		{"most specific fallback", "parking.session.started", map[string]interface{}{"session_id": "s1", "user_id": "u1"}, "s1"},
+		{"user as last resort", "user.logged_in", map[string]interface{}{"user_id": "u1"}, "u1"},
+		{"stringer values", "wallet.created", map[string]interface{}{"wallet_id": uuid.MustParse("6f1c2e0e-8d4b-4a5e-9c3e-2b1d0f9a7e11")}, "6f1c2e0e-8d4b-4a5e-9c3e-2b1d0f9a7e11"},
+		{"no identifiers", "system.tick", map[string]interface{}{"count": 3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRecord("svc", kafka.Event{Type: tt.event, Payload: tt.payload})
+			if r.AggregateID != tt.want {
+				t.Errorf("AggregateID = %q, want %q", r.AggregateID, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRecord_Defaults(t *testing.T) {
+	r := NewRecord("wallet", kafka.Event{Type: "wallet.created"})
+	if r.Payload == nil {
+		t.Error("expected empty payload, got nil")
+	}
+	if r.OccurredAt.IsZero() {
+		t.Error("expected occurred_at to default to now")
+	}
+	if r.UserID != "" {
+		t.Errorf("UserID = %q, want empty", r.UserID)
+	}
+}
+
+func TestQuery_Normalize(t *testing.T) {
+	now := time.Now()
+
+	q := Query{From: now, To: now.Add(-time.Hour)}
+	if err := q.Normalize(); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("Normalize() error = %v, want ErrInvalidRange", err)
+	}
+
+	q = Query{Limit: 10000, Offset: -5}
+	if err := q.Normalize(); err != nil {
+		t.Fatalf("Normalize() error = %v", err)
+	}
+	if q.Limit != MaxLimit || q.Offset != 0 {
+		t.Errorf("got limit %d offset %d, want %d and 0", q.Limit, q.Offset, MaxLimit)
+	}
+}
+
+func TestQuery_Matches(t *testing.T) {
+	at := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	r := &Record{Service: "wallet", Type: "wallet.topup.completed", AggregateID: "w1", UserID: "u1", TraceID: "t1", OccurredAt: at}
+
+	tests := []struct {
+		name  string
+		query Query
+		want  bool
+	}{
+		{"empty query", Query{}, true},
+		{"service and user", Query{Service: "wallet", UserID: "u1"}, true},
+		{"other user", Query{UserID: "u2"}, false},
+		{"type prefix", Query{Type: "wallet.*"}, true},
+		{"type prefix mismatch", Query{Type: "parking.*"}, false},
+		{"exact type", Query{Type: "wallet.topup.completed"}, true},
+		{"exact type is not a prefix", Query{Type: "wallet.topup"}, false},
+		{"within day", Query{From: at.Truncate(24 * time.Hour), To: at.Truncate(24 * time.Hour).Add(24 * time.Hour)}, true},
+		{"to is exclusive", Query{To: at}, false},
+		{"trace", Query{TraceID: "t1"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Matches(r); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMemoryStore_Search(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(3)
+
+	base := time.Now().UTC()
+	for i, typ := range []string{"wallet.created", "wallet.topup.completed", "user.logged_in", "wallet.payment.completed"} {
+		store.Append(ctx, &Record{ID: uuid.New(), Service: "wallet", Type: typ, OccurredAt: base.Add(time.Duration(i) * time.Second)})
+	}
+
+	all, err := store.Search(ctx, Query{})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d records, want 3 after the oldest was dropped", len(all))
+	}
+	if all[0].Type != "wallet.payment.completed" {
+		t.Errorf("first record = %s, want newest first", all[0].Type)
+	}
+
+	wallet, _ := store.Search(ctx, Query{Type: "wallet.*", Offset: 1})
+	if len(wallet) != 1 || wallet[0].Type != "wallet.topup.completed" {
+		t.Errorf("unexpected paged prefix search result: %v", wallet)
+	}
+
+	if _, err := store.Get(ctx, all[0].ID); err != nil {
+		t.Errorf("Get() error = %v", err)
+	}
+	if _, err := store.Get(ctx, uuid.New()); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Get() error = %v, want ErrRecordNotFound", err)
+	}
+}
+
+type stubPublisher struct {
+	err       error
+	published []kafka.Event
+}
+
+func (p *stubPublisher) Publish(ctx context.Context, event kafka.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+func TestRecordingPublisher(t *testing.T) {
+	ctx := context.Background()
+	next := &stubPublisher{}
+	store := NewMemoryStore(10)
+	publisher := NewRecordingPublisher(next, store, "wallet")
+
+	event := kafka.Event{Type: "wallet.created", Payload: map[string]interface{}{"wallet_id": "w1", "user_id": "u1"}}
+	if err := publisher.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	records, _ := store.Search(ctx, Query{UserID: "u1"})
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	if records[0].Service != "wallet" || records[0].AggregateID != "w1" {
+		t.Errorf("unexpected record: %+v", records[0])
+	}
+	if !records[0].OccurredAt.Equal(next.published[0].Timestamp) {
+		t.Error("record and published event should share a timestamp")
+	}
+
+	next.err = errors.New("broker down")
+	if err := publisher.Publish(ctx, event); err == nil {
+		t.Error("expected publish error to be returned")
+	}
+	if records, _ := store.Search(ctx, Query{}); len(records) != 1 {
+		t.Errorf("got %d records, want failed publishes not to be recorded", len(records))
+	}
+}
@@ -0,0 +1,77 @@
+package eventstore
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMemoryCapacity is how many records a memory store keeps by default
+const DefaultMemoryCapacity = 1000
+
+// MemoryStore keeps the most recent records in process memory. Useful for tests
+// and local development; the oldest records are dropped once it is full.
+type MemoryStore struct {
+	mu       sync.RWMutex
+	records  []*Record
+	capacity int
+}
+
+// NewMemoryStore creates a memory store holding up to capacity records
+func NewMemoryStore(capacity int) *MemoryStore {
+	if capacity <= 0 {
+		capacity = DefaultMemoryCapacity
+	}
+	return &MemoryStore{capacity: capacity}
+}
+
+func (s *MemoryStore) Append(ctx context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *record
+	s.records = append(s.records, &copied)
+	if len(s.records) > s.capacity {
+		s.records = s.records[len(s.records)-s.capacity:]
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, id uuid.UUID) (*Record, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, r := range s.records {
+		if r.ID == id {
+			copied := *r
+			return &copied, nil
+		}
+	}
+	return nil, ErrRecordNotFound
+}
+
+func (s *MemoryStore) Search(ctx context.Context, q Query) ([]*Record, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var matches []*Record
+	skipped := 0
+	for i := len(s.records) - 1; i >= 0 && len(matches) < q.Limit; i-- {
+		r := s.records[i]
+		if !q.Matches(r) {
+			continue
+		}
+		if skipped < q.Offset {
+			skipped++
+			continue
+		}
+		copied := *r
+		matches = append(matches, &copied)
+	}
+	return matches, nil
+}
@@ -0,0 +1,44 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Supported store backends
+const (
+	BackendMemory   = "memory"
+	BackendPostgres = "postgres"
+)
+
+// StoreConfig selects and configures the event store backend
+type StoreConfig struct {
+	Backend     string
+	PostgresURL string
+}
+
+// OpenStore connects to the configured backend. The returned close func
+// releases the connection and is always safe to call.
+func OpenStore(ctx context.Context, cfg StoreConfig) (Store, func(), error) {
+	switch cfg.Backend {
+	case "", BackendMemory:
+		return NewMemoryStore(DefaultMemoryCapacity), func() {}, nil
+
+	case BackendPostgres:
+		pool, err := pgxpool.New(ctx, cfg.PostgresURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		store := NewPostgresStore(pool)
+		if err := store.EnsureSchema(ctx); err != nil {
+			pool.Close()
+			return nil, nil, fmt.Errorf("failed to create event store schema: %w", err)
+		}
+		return store, pool.Close, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown event store backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,168 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSchema creates the table used by PostgresStore. Services that share
+// an event store database run it once, e.g. from a migration.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS event_log (
+    id UUID PRIMARY KEY,
+    service VARCHAR(64) NOT NULL,
+    type VARCHAR(128) NOT NULL,
+    aggregate_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    trace_id VARCHAR(64) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_log_occurred_at ON event_log(occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_log_type ON event_log(type, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_log_aggregate_id ON event_log(aggregate_id, occurred_at DESC) WHERE aggregate_id <> '';
+CREATE INDEX IF NOT EXISTS idx_event_log_user_id ON event_log(user_id, occurred_at DESC) WHERE user_id <> '';
+CREATE INDEX IF NOT EXISTS idx_event_log_trace_id ON event_log(trace_id) WHERE trace_id <> '';`
+
+// PostgresStore keeps records in the event_log table
+type PostgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresStore creates a Postgres-backed store
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// EnsureSchema creates the event_log table and its indexes if they do not exist
+func (s *PostgresStore) EnsureSchema(ctx context.Context) error {
+	_, err := s.db.Exec(ctx, PostgresSchema)
+	return err
+}
+
+func (s *PostgresStore) Append(ctx context.Context, record *Record) error {
+	payloadJSON, err := json.Marshal(record.Payload)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO event_log (
+			id, service, type, aggregate_id, user_id, trace_id, payload, occurred_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = s.db.Exec(ctx, query,
+		record.ID, record.Service, record.Type, record.AggregateID,
+		record.UserID, record.TraceID, payloadJSON, record.OccurredAt,
+	)
+	return err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, id uuid.UUID) (*Record, error) {
+	query := `
+		SELECT id, service, type, aggregate_id, user_id, trace_id, payload, occurred_at
+		FROM event_log
+		WHERE id = $1
+	`
+	r, err := scanRecord(s.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRecordNotFound
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *PostgresStore) Search(ctx context.Context, q Query) ([]*Record, error) {
+	if err := q.Normalize(); err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	where := func(clause string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
+	}
+
+	if q.Service != "" {
+		where("service = $%d", q.Service)
+	}
+	if q.Type != "" {
+		if prefix, ok := strings.CutSuffix(q.Type, "*"); ok {
+			where("type LIKE $%d", escapeLike(prefix)+"%")
+		} else {
+			where("type = $%d", q.Type)
+		}
+	}
+	if q.AggregateID != "" {
+		where("aggregate_id = $%d", q.AggregateID)
+	}
+	if q.UserID != "" {
+		where("user_id = $%d", q.UserID)
+	}
+	if q.TraceID != "" {
+		where("trace_id = $%d", q.TraceID)
+	}
+	if !q.From.IsZero() {
+		where("occurred_at >= $%d", q.From)
+	}
+	if !q.To.IsZero() {
+		where("occurred_at < $%d", q.To)
+	}
+
+	query := `
+		SELECT id, service, type, aggregate_id, user_id, trace_id, payload, occurred_at
+		FROM event_log
+	`
+	if len(conditions) > 0 {
+		query += "WHERE " + strings.Join(conditions, " AND ") + "\n"
+	}
+	args = append(args, q.Limit, q.Offset)
+	query += fmt.Sprintf("ORDER BY occurred_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := s.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*Record
+	for rows.Next() {
+		r, err := scanRecord(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+func scanRecord(row pgx.Row) (*Record, error) {
+	var r Record
+	var payloadJSON []byte
+	if err := row.Scan(
+		&r.ID, &r.Service, &r.Type, &r.AggregateID,
+		&r.UserID, &r.TraceID, &payloadJSON, &r.OccurredAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(payloadJSON, &r.Payload); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// escapeLike escapes LIKE wildcards so a type prefix is matched literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
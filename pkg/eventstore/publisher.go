@@ -0,0 +1,51 @@
+package eventstore
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/parking-super-app/pkg/kafka"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecordingPublisher publishes events through the wrapped publisher and then
+// appends them to the store. A failure to record is logged, never returned:
+// the event store is a debugging aid and must not block the business flow.
+type RecordingPublisher struct {
+	next    kafka.EventPublisher
+	store   Store
+	service string
+}
+
+// NewRecordingPublisher wraps next so every event service publishes is recorded
+func NewRecordingPublisher(next kafka.EventPublisher, store Store, service string) *RecordingPublisher {
+	return &RecordingPublisher{
+		next:    next,
+		store:   store,
+		service: service,
+	}
+}
+
+func (p *RecordingPublisher) Publish(ctx context.Context, event kafka.Event) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	if err := p.next.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	if event.TraceID == "" {
+		if spanCtx := trace.SpanFromContext(ctx).SpanContext(); spanCtx.IsValid() {
+			event.TraceID = spanCtx.TraceID().String()
+		}
+	}
+	if err := p.store.Append(context.WithoutCancel(ctx), NewRecord(p.service, event)); err != nil {
+		log.Printf("failed to record %s event: %v", event.Type, err)
+	}
+	return nil
+}
+
+// Ensure RecordingPublisher implements kafka.EventPublisher
+var _ kafka.EventPublisher = (*RecordingPublisher)(nil)
@@ -0,0 +1,128 @@
+// Package fanout delivers events to in-process subscribers, such as SSE
+// streams, grouped by a key like a user or a session. Subscriptions are
+// limited in total and per owner, each gets a buffered channel, and a
+// subscriber whose buffer is full misses the event rather than block the
+// publisher.
+package fanout
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrLimitReached = errors.New("too many open subscriptions")
+
+// DefaultBufferSize is used when Config.BufferSize is not set
+const DefaultBufferSize = 16
+
+// Config holds the subscription limits. A zero limit means no limit.
+type Config struct {
+	MaxSubscribers         int // Total open subscriptions
+	MaxSubscribersPerOwner int // Open subscriptions per owner
+	BufferSize             int // Events buffered per subscription before dropping
+}
+
+type subscriber[K comparable, T any] struct {
+	key    K
+	owner  string
+	events chan T
+}
+
+// Hub fans out events published for a key to that key's subscribers
+type Hub[K comparable, T any] struct {
+	cfg    Config
+	mu     sync.RWMutex
+	subs   map[K]map[*subscriber[K, T]]struct{}
+	owners map[string]int
+	total  int
+}
+
+// NewHub creates a hub with the given limits
+func NewHub[K comparable, T any](cfg Config) *Hub[K, T] {
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = DefaultBufferSize
+	}
+	return &Hub[K, T]{
+		cfg:    cfg,
+		subs:   make(map[K]map[*subscriber[K, T]]struct{}),
+		owners: make(map[string]int),
+	}
+}
+
+// Subscribe opens a subscription to key on behalf of owner, who counts it
+// towards their limit. cancel closes the channel and frees the slot; it is
+// safe to call more than once.
+func (h *Hub[K, T]) Subscribe(key K, owner string) (events <-chan T, cancel func(), err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cfg.MaxSubscribers > 0 && h.total >= h.cfg.MaxSubscribers {
+		return nil, nil, ErrLimitReached
+	}
+	if h.cfg.MaxSubscribersPerOwner > 0 && h.owners[owner] >= h.cfg.MaxSubscribersPerOwner {
+		return nil, nil, ErrLimitReached
+	}
+
+	sub := &subscriber[K, T]{key: key, owner: owner, events: make(chan T, h.cfg.BufferSize)}
+	if h.subs[key] == nil {
+		h.subs[key] = make(map[*subscriber[K, T]]struct{})
+	}
+	h.subs[key][sub] = struct{}{}
+	h.owners[owner]++
+	h.total++
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() { h.unsubscribe(sub) })
+	}
+	return sub.events, cancel, nil
+}
+
+func (h *Hub[K, T]) unsubscribe(sub *subscriber[K, T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.subs[sub.key]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subs, sub.key)
+	}
+
+	h.owners[sub.owner]--
+	if h.owners[sub.owner] <= 0 {
+		delete(h.owners, sub.owner)
+	}
+	h.total--
+	close(sub.events)
+}
+
+// Publish delivers event to key's subscribers. Those with a full buffer
+// miss it.
+func (h *Hub[K, T]) Publish(key K, event T) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for sub := range h.subs[key] {
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Subscribers returns the number of open subscriptions to key
+func (h *Hub[K, T]) Subscribers(key K) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.subs[key])
+}
+
+// Count returns the number of open subscriptions
+func (h *Hub[K, T]) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.total
+}
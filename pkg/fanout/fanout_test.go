@@ -0,0 +1,108 @@
+package fanout
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHub_PublishFiltersByKey(t *testing.T) {
+	hub := NewHub[string, int](Config{})
+
+	a, cancelA, err := hub.Subscribe("a", "alice")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancelA()
+	b, cancelB, err := hub.Subscribe("b", "alice")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancelB()
+
+	hub.Publish("a", 1)
+
+	select {
+	case got := <-a:
+		if got != 1 {
+			t.Errorf("a got %d, want 1", got)
+		}
+	default:
+		t.Error("a should receive its event")
+	}
+	select {
+	case got := <-b:
+		t.Errorf("b should not receive a's events, got %d", got)
+	default:
+	}
+}
+
+func TestHub_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		owners  []string
+		wantErr []error
+	}{
+		{"per owner", Config{MaxSubscribers: 10, MaxSubscribersPerOwner: 1}, []string{"alice", "alice", "bob"}, []error{nil, ErrLimitReached, nil}},
+		{"total", Config{MaxSubscribers: 1, MaxSubscribersPerOwner: 5}, []string{"alice", "bob"}, []error{nil, ErrLimitReached}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub[string, int](tt.cfg)
+			for i, owner := range tt.owners {
+				_, cancel, err := hub.Subscribe("key", owner)
+				if !errors.Is(err, tt.wantErr[i]) {
+					t.Fatalf("subscription %d: error = %v, want %v", i, err, tt.wantErr[i])
+				}
+				if cancel != nil {
+					defer cancel()
+				}
+			}
+		})
+	}
+}
+
+func TestHub_FullBufferDropsEvents(t *testing.T) {
+	hub := NewHub[string, int](Config{BufferSize: 1})
+	events, cancel, err := hub.Subscribe("key", "alice")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancel()
+
+	hub.Publish("key", 1)
+	hub.Publish("key", 2)
+
+	if got := <-events; got != 1 {
+		t.Errorf("got %d, want the first event", got)
+	}
+	select {
+	case got := <-events:
+		t.Errorf("event %d should have been dropped", got)
+	default:
+	}
+}
+
+func TestHub_CancelReleasesSlot(t *testing.T) {
+	hub := NewHub[string, int](Config{MaxSubscribers: 1})
+	events, cancel, err := hub.Subscribe("key", "alice")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	cancel()
+	cancel() // safe to call twice
+
+	if _, ok := <-events; ok {
+		t.Error("channel should be closed after cancel")
+	}
+	if hub.Count() != 0 || hub.Subscribers("key") != 0 {
+		t.Errorf("Count() = %d, Subscribers() = %d, want 0", hub.Count(), hub.Subscribers("key"))
+	}
+	if _, cancel, err := hub.Subscribe("key", "bob"); err != nil {
+		t.Errorf("slot should be released, got %v", err)
+	} else {
+		cancel()
+	}
+}
@@ -0,0 +1,98 @@
+package featureflags
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ClientConfig holds configuration for the flag client
+type ClientConfig struct {
+	RefreshInterval time.Duration
+	// Defaults are used for flags missing from the store, and before the
+	// first successful load. Unlisted flags default to off.
+	Defaults map[string]bool
+}
+
+// DefaultClientConfig returns sensible default configuration
+func DefaultClientConfig() ClientConfig {
+	return ClientConfig{
+		RefreshInterval: 30 * time.Second,
+	}
+}
+
+// Client evaluates flags against an in-memory snapshot of the store,
+// refreshed in the background.
+type Client struct {
+	store Store
+	cfg   ClientConfig
+
+	mu    sync.RWMutex
+	flags map[string]*Flag
+}
+
+// NewClient creates a flag client. Call Start to load flags and begin refreshing.
+func NewClient(store Store, cfg ClientConfig) *Client {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = DefaultClientConfig().RefreshInterval
+	}
+	return &Client{
+		store: store,
+		cfg:   cfg,
+		flags: make(map[string]*Flag),
+	}
+}
+
+// Start loads the current flags and refreshes them until ctx is cancelled.
+// The initial load error is returned so callers can log it; the client keeps
+// serving defaults and retries on the next refresh.
+func (c *Client) Start(ctx context.Context) error {
+	err := c.Refresh(ctx)
+
+	go func() {
+		ticker := time.NewTicker(c.cfg.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				// Keep the last good snapshot if the store is unreachable
+				c.Refresh(ctx)
+			}
+		}
+	}()
+
+	return err
+}
+
+// Refresh replaces the snapshot with the flags currently in the store
+func (c *Client) Refresh(ctx context.Context) error {
+	flags, err := c.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := make(map[string]*Flag, len(flags))
+	for _, f := range flags {
+		snapshot[f.Key] = f
+	}
+
+	c.mu.Lock()
+	c.flags = snapshot
+	c.mu.Unlock()
+	return nil
+}
+
+// IsEnabled reports whether a flag is on for the given user.
+// Pass an empty userID for flags that are not user-specific.
+func (c *Client) IsEnabled(ctx context.Context, key, userID string) bool {
+	c.mu.RLock()
+	f, ok := c.flags[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return c.cfg.Defaults[key]
+	}
+	return f.Evaluate(userID)
+}
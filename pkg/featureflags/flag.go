@@ -0,0 +1,91 @@
+// Package featureflags provides soft-launch flags shared across services.
+//
+// Flags live in a shared store (Redis or Postgres) and are evaluated locally
+// against a periodically refreshed snapshot, so checking a flag on a hot path
+// never touches the network.
+package featureflags
+
+import (
+	"errors"
+	"hash/fnv"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrFlagNotFound   = errors.New("feature flag not found")
+	ErrInvalidFlagKey = errors.New("flag key must be lowercase letters, digits, dots, dashes or underscores")
+	ErrInvalidRollout = errors.New("rollout percentage must be between 0 and 100")
+)
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,127}$`)
+
+// Flag is a named switch that can be enabled for everyone, a percentage of users,
+// or an explicit list of users.
+type Flag struct {
+	Key            string    `json:"key"`
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	RolloutPercent int       `json:"rollout_percent"`
+	AllowUsers     []string  `json:"allow_users,omitempty"`
+	DenyUsers      []string  `json:"deny_users,omitempty"`
+	UpdatedBy      string    `json:"updated_by,omitempty"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Validate checks that the flag can be stored
+func (f *Flag) Validate() error {
+	if !flagKeyPattern.MatchString(f.Key) {
+		return ErrInvalidFlagKey
+	}
+	if f.RolloutPercent < 0 || f.RolloutPercent > 100 {
+		return ErrInvalidRollout
+	}
+	return nil
+}
+
+// Evaluate reports whether the flag is on for the given user.
+//
+// A disabled flag is off for everyone. An enabled flag is then checked in order:
+// deny list, allow list, then percentage rollout. Users are bucketed by a hash of
+// the flag key and user ID, so the same user always gets the same answer for a flag
+// and raising the percentage only ever adds users. An empty user ID is only
+// included at 100% rollout.
+func (f *Flag) Evaluate(userID string) bool {
+	if !f.Enabled {
+		return false
+	}
+	if userID != "" {
+		if contains(f.DenyUsers, userID) {
+			return false
+		}
+		if contains(f.AllowUsers, userID) {
+			return true
+		}
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	if f.RolloutPercent <= 0 || userID == "" {
+		return false
+	}
+	return bucket(f.Key, userID) < f.RolloutPercent
+}
+
+// bucket maps a user to a stable value in [0, 100) for a given flag
+func bucket(key, userID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{':'})
+	h.Write([]byte(userID))
+	return int(h.Sum32() % 100)
+}
+
+func contains(values []string, v string) bool {
+	for _, candidate := range values {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestFlag_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    Flag
+		wantErr error
+	}{
+		{"valid", Flag{Key: "wallet.hold-capture", RolloutPercent: 50}, nil},
+		{"empty key", Flag{Key: ""}, ErrInvalidFlagKey},
+		{"uppercase key", Flag{Key: "Wallet.Hold"}, ErrInvalidFlagKey},
+		{"spaces in key", Flag{Key: "wallet hold"}, ErrInvalidFlagKey},
+		{"negative rollout", Flag{Key: "a", RolloutPercent: -1}, ErrInvalidRollout},
+		{"rollout over 100", Flag{Key: "a", RolloutPercent: 101}, ErrInvalidRollout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.flag.Validate(); err != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFlag_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   Flag
+		userID string
+		want   bool
+	}{
+		{"disabled", Flag{Key: "f", Enabled: false, RolloutPercent: 100}, "u1", false},
+		{"disabled ignores allow list", Flag{Key: "f", AllowUsers: []string{"u1"}}, "u1", false},
+		{"full rollout", Flag{Key: "f", Enabled: true, RolloutPercent: 100}, "u1", true},
+		{"full rollout without user", Flag{Key: "f", Enabled: true, RolloutPercent: 100}, "", true},
+		{"zero rollout", Flag{Key: "f", Enabled: true}, "u1", false},
+		{"allow list", Flag{Key: "f", Enabled: true, AllowUsers: []string{"u1"}}, "u1", true},
+		{"deny list beats allow list", Flag{Key: "f", Enabled: true, RolloutPercent: 100, AllowUsers: []string{"u1"}, DenyUsers: []string{"u1"}}, "u1", false},
+		{"partial rollout without user", Flag{Key: "f", Enabled: true, RolloutPercent: 99}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.flag.Evaluate(tt.userID); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFlag_EvaluatePercentageRollout(t *testing.T) {
+	flag := Flag{Key: "parking.entry-time-pricing", Enabled: true, RolloutPercent: 30}
+
+	enabled := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		userID := fmt.Sprintf("user-%d", i)
+		enabled[userID] = flag.Evaluate(userID)
+		if flag.Evaluate(userID) != enabled[userID] {
+			t.Fatalf("evaluation for %s is not stable", userID)
+		}
+	}
+
+	count := 0
+	for _, on := range enabled {
+		if on {
+			count++
+		}
+	}
+	if count < 250 || count > 350 {
+		t.Errorf("expected roughly 30%% of users enabled, got %d of 1000", count)
+	}
+
+	// Raising the percentage must keep everyone who was already enabled
+	flag.RolloutPercent = 60
+	for userID, on := range enabled {
+		if on && !flag.Evaluate(userID) {
+			t.Errorf("user %s lost the flag when rollout increased", userID)
+		}
+	}
+}
+
+func TestClient_IsEnabled(t *testing.T) {
+	store := NewMemoryStore(&Flag{Key: "on", Enabled: true, RolloutPercent: 100})
+	client := NewClient(store, ClientConfig{Defaults: map[string]bool{"missing-default-on": true}})
+
+	if client.IsEnabled(context.Background(), "on", "u1") {
+		t.Error("expected flags to use defaults before the first refresh")
+	}
+
+	if err := client.Refresh(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"on", true},
+		{"missing", false},
+		{"missing-default-on", true},
+	}
+	for _, tt := range tests {
+		if got := client.IsEnabled(context.Background(), tt.key, "u1"); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.key, tt.want, got)
+		}
+	}
+
+	store.Delete(context.Background(), "on")
+	client.Refresh(context.Background())
+	if client.IsEnabled(context.Background(), "on", "u1") {
+		t.Error("expected deleted flag to be off after refresh")
+	}
+}
@@ -0,0 +1,62 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+)
+
+// Supported store backends
+const (
+	BackendMemory   = "memory"
+	BackendRedis    = "redis"
+	BackendPostgres = "postgres"
+)
+
+// StoreConfig selects and configures the flag store backend
+type StoreConfig struct {
+	Backend       string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	RedisKey      string
+	PostgresURL   string
+}
+
+// OpenStore connects to the configured backend. The returned close func
+// releases the connection and is always safe to call.
+func OpenStore(ctx context.Context, cfg StoreConfig) (Store, func(), error) {
+	switch cfg.Backend {
+	case "", BackendMemory:
+		return NewMemoryStore(), func() {}, nil
+
+	case BackendRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.RedisAddr,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		})
+		if err := client.Ping(ctx).Err(); err != nil {
+			client.Close()
+			return nil, nil, fmt.Errorf("failed to connect to redis: %w", err)
+		}
+		return NewRedisStore(client, cfg.RedisKey), func() { client.Close() }, nil
+
+	case BackendPostgres:
+		pool, err := pgxpool.New(ctx, cfg.PostgresURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		store := NewPostgresStore(pool)
+		if err := store.EnsureSchema(ctx); err != nil {
+			pool.Close()
+			return nil, nil, fmt.Errorf("failed to create feature flag schema: %w", err)
+		}
+		return store, pool.Close, nil
+
+	default:
+		return nil, nil, fmt.Errorf("unknown feature flag backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,137 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresSchema creates the table used by PostgresStore. Services that share a
+// flag database run it once, e.g. from a migration.
+const PostgresSchema = `
+CREATE TABLE IF NOT EXISTS feature_flags (
+    key VARCHAR(128) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    rollout_percent INT NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    allow_users TEXT[] NOT NULL DEFAULT '{}',
+    deny_users TEXT[] NOT NULL DEFAULT '{}',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);`
+
+// PostgresStore keeps flags in the feature_flags table
+type PostgresStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresStore creates a Postgres-backed store
+func NewPostgresStore(db *pgxpool.Pool) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// EnsureSchema creates the feature_flags table if it does not exist
+func (s *PostgresStore) EnsureSchema(ctx context.Context) error {
+	_, err := s.db.Exec(ctx, PostgresSchema)
+	return err
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (*Flag, error) {
+	query := `
+		SELECT key, description, enabled, rollout_percent, allow_users, deny_users, updated_by, updated_at
+		FROM feature_flags
+		WHERE key = $1
+	`
+	f, err := scanFlag(s.db.QueryRow(ctx, query, key))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrFlagNotFound
+		}
+		return nil, err
+	}
+	return f, nil
+}
+
+func (s *PostgresStore) List(ctx context.Context) ([]*Flag, error) {
+	query := `
+		SELECT key, description, enabled, rollout_percent, allow_users, deny_users, updated_by, updated_at
+		FROM feature_flags
+		ORDER BY key
+	`
+	rows, err := s.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var flags []*Flag
+	for rows.Next() {
+		f, err := scanFlag(rows)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, f)
+	}
+	return flags, rows.Err()
+}
+
+func (s *PostgresStore) Upsert(ctx context.Context, flag *Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if flag.UpdatedAt.IsZero() {
+		flag.UpdatedAt = time.Now().UTC()
+	}
+
+	query := `
+		INSERT INTO feature_flags (
+			key, description, enabled, rollout_percent, allow_users, deny_users, updated_by, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (key) DO UPDATE SET
+			description = EXCLUDED.description,
+			enabled = EXCLUDED.enabled,
+			rollout_percent = EXCLUDED.rollout_percent,
+			allow_users = EXCLUDED.allow_users,
+			deny_users = EXCLUDED.deny_users,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := s.db.Exec(ctx, query,
+		flag.Key, flag.Description, flag.Enabled, flag.RolloutPercent,
+		nonNil(flag.AllowUsers), nonNil(flag.DenyUsers), flag.UpdatedBy, flag.UpdatedAt,
+	)
+	return err
+}
+
+func (s *PostgresStore) Delete(ctx context.Context, key string) error {
+	result, err := s.db.Exec(ctx, `DELETE FROM feature_flags WHERE key = $1`, key)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrFlagNotFound
+	}
+	return nil
+}
+
+func scanFlag(row pgx.Row) (*Flag, error) {
+	var f Flag
+	err := row.Scan(
+		&f.Key, &f.Description, &f.Enabled, &f.RolloutPercent,
+		&f.AllowUsers, &f.DenyUsers, &f.UpdatedBy, &f.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,87 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisKey is the hash that holds all flags, keyed by flag key
+const DefaultRedisKey = "featureflags"
+
+// RedisStore keeps flags as JSON values in a single Redis hash
+type RedisStore struct {
+	client redis.UniversalClient
+	key    string
+}
+
+// NewRedisStore creates a Redis-backed store. An empty hashKey uses DefaultRedisKey.
+func NewRedisStore(client redis.UniversalClient, hashKey string) *RedisStore {
+	if hashKey == "" {
+		hashKey = DefaultRedisKey
+	}
+	return &RedisStore{client: client, key: hashKey}
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (*Flag, error) {
+	raw, err := s.client.HGet(ctx, s.key, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrFlagNotFound
+		}
+		return nil, err
+	}
+
+	var f Flag
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (s *RedisStore) List(ctx context.Context) ([]*Flag, error) {
+	values, err := s.client.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make([]*Flag, 0, len(values))
+	for _, raw := range values {
+		var f Flag
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			return nil, err
+		}
+		flags = append(flags, &f)
+	}
+	sortFlags(flags)
+	return flags, nil
+}
+
+func (s *RedisStore) Upsert(ctx context.Context, flag *Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if flag.UpdatedAt.IsZero() {
+		flag.UpdatedAt = time.Now().UTC()
+	}
+
+	raw, err := json.Marshal(flag)
+	if err != nil {
+		return err
+	}
+	return s.client.HSet(ctx, s.key, flag.Key, raw).Err()
+}
+
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	removed, err := s.client.HDel(ctx, s.key, key).Result()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return ErrFlagNotFound
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package featureflags
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store persists flags. Implementations must be safe for concurrent use.
+type Store interface {
+	Get(ctx context.Context, key string) (*Flag, error)
+	List(ctx context.Context) ([]*Flag, error)
+	Upsert(ctx context.Context, flag *Flag) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryStore keeps flags in process memory. Useful for tests and local development
+// where flags do not need to be shared between services.
+type MemoryStore struct {
+	mu    sync.RWMutex
+	flags map[string]Flag
+}
+
+// NewMemoryStore creates a memory store seeded with the given flags
+func NewMemoryStore(flags ...*Flag) *MemoryStore {
+	s := &MemoryStore{flags: make(map[string]Flag)}
+	for _, f := range flags {
+		s.flags[f.Key] = *f
+	}
+	return s
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Flag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.flags[key]
+	if !ok {
+		return nil, ErrFlagNotFound
+	}
+	return &f, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context) ([]*Flag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flags := make([]*Flag, 0, len(s.flags))
+	for _, f := range s.flags {
+		f := f
+		flags = append(flags, &f)
+	}
+	sortFlags(flags)
+	return flags, nil
+}
+
+func (s *MemoryStore) Upsert(ctx context.Context, flag *Flag) error {
+	if err := flag.Validate(); err != nil {
+		return err
+	}
+	if flag.UpdatedAt.IsZero() {
+		flag.UpdatedAt = time.Now().UTC()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.flags[flag.Key] = *flag
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[key]; !ok {
+		return ErrFlagNotFound
+	}
+	delete(s.flags, key)
+	return nil
+}
+
+func sortFlags(flags []*Flag) {
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Key < flags[j].Key })
+}
@@ -0,0 +1,304 @@
+// Package client builds gRPC client connections with the platform's default
+// call policy: per-method deadlines, retries with backoff on UNAVAILABLE,
+// keepalive pings, and round-robin balancing across the addresses DNS returns.
+//
+// Deadlines and retries are expressed as a gRPC service config rather than
+// hand-written interceptors, so they are applied by grpc-go itself and retries
+// stay within the call's overall deadline.
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// ErrMissingTarget is returned when Config has no target address.
+var ErrMissingTarget = errors.New("grpc client: target is required")
+
+// Load balancing policies understood by grpc-go.
+const (
+	RoundRobin = "round_robin"
+	PickFirst  = "pick_first"
+)
+
+// maxRetryAttempts is grpc-go's upper bound; larger values are silently capped.
+const maxRetryAttempts = 5
+
+// Config describes how to connect to one upstream service.
+type Config struct {
+	// Target is a gRPC target such as "provider-service:9083" or
+	// "dns:///provider-service:9083". Targets without a scheme are resolved
+	// through DNS so every A record becomes a backend.
+	Target string
+
+	// DefaultTimeout bounds every call that has no entry in MethodTimeouts.
+	// Zero leaves calls bounded only by the caller's context.
+	DefaultTimeout time.Duration
+
+	// MethodTimeouts overrides DefaultTimeout per full method name,
+	// e.g. "/provider.v1.ProviderService/StartSession".
+	MethodTimeouts map[string]time.Duration
+
+	Retry     RetryPolicy
+	Keepalive KeepaliveConfig
+
+	// LoadBalancing is RoundRobin (default) or PickFirst.
+	LoadBalancing string
+
+	// Credentials secures the connection. Nil means plaintext, which is only
+	// appropriate inside the cluster network.
+	Credentials credentials.TransportCredentials
+}
+
+// RetryPolicy controls transparent retries of failed calls.
+type RetryPolicy struct {
+	// MaxAttempts includes the original call; 1 or less disables retries.
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	// RetryableCodes defaults to UNAVAILABLE only. Other codes may mean the
+	// server already acted on the request, so only add codes for idempotent
+	// services.
+	RetryableCodes []codes.Code
+}
+
+// KeepaliveConfig controls client pings on idle connections so broken
+// connections are noticed before the next call.
+type KeepaliveConfig struct {
+	Time                time.Duration
+	Timeout             time.Duration
+	PermitWithoutStream bool
+}
+
+// DefaultConfig returns the recommended settings for calls between services.
+func DefaultConfig(target string) Config {
+	return Config{
+		Target:         target,
+		DefaultTimeout: 5 * time.Second,
+		Retry: RetryPolicy{
+			MaxAttempts:       3,
+			InitialBackoff:    100 * time.Millisecond,
+			MaxBackoff:        2 * time.Second,
+			BackoffMultiplier: 2,
+			RetryableCodes:    []codes.Code{codes.Unavailable},
+		},
+		Keepalive: KeepaliveConfig{
+			Time:                30 * time.Second,
+			Timeout:             10 * time.Second,
+			PermitWithoutStream: true,
+		},
+		LoadBalancing: RoundRobin,
+	}
+}
+
+// UseMutualTLS makes the connection present the service certificate from
+// tlsCfg and verify the server against its CA. The connection stays
+// plaintext when tlsCfg has no certificate files.
+func (c *Config) UseMutualTLS(tlsCfg mtls.Config) error {
+	if !tlsCfg.Enabled() {
+		return nil
+	}
+	creds, err := mtls.ClientCredentials(tlsCfg)
+	if err != nil {
+		return err
+	}
+	c.Credentials = creds
+	return nil
+}
+
+// New dials the target described by cfg. The connection is established
+// lazily, so New does not fail when the upstream is down; calls do.
+// Extra options are applied after the defaults.
+func New(cfg Config, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if cfg.Target == "" {
+		return nil, ErrMissingTarget
+	}
+
+	serviceConfig, err := buildServiceConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	creds := cfg.Credentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithChainUnaryInterceptor(interceptors.DefaultClientInterceptors()...),
+		grpc.WithChainStreamInterceptor(interceptors.DefaultStreamClientInterceptors()...),
+	}
+	if cfg.Keepalive.Time > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.Keepalive.Time,
+			Timeout:             cfg.Keepalive.Timeout,
+			PermitWithoutStream: cfg.Keepalive.PermitWithoutStream,
+		}))
+	}
+	dialOpts = append(dialOpts, opts...)
+
+	conn, err := grpc.Dial(normalizeTarget(cfg.Target), dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC client for %s: %w", cfg.Target, err)
+	}
+	return conn, nil
+}
+
+// normalizeTarget routes scheme-less targets through the DNS resolver. grpc-go
+// otherwise uses the passthrough resolver, which dials a single address and
+// leaves round-robin with nothing to balance.
+func normalizeTarget(target string) string {
+	if strings.Contains(target, "://") {
+		return target
+	}
+	return "dns:///" + target
+}
+
+type serviceConfig struct {
+	LoadBalancingConfig []map[string]struct{} `json:"loadBalancingConfig"`
+	MethodConfig        []methodConfig        `json:"methodConfig,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []methodName       `json:"name"`
+	Timeout     string             `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicyConfig `json:"retryPolicy,omitempty"`
+}
+
+type methodName struct {
+	Service string `json:"service,omitempty"`
+	Method  string `json:"method,omitempty"`
+}
+
+type retryPolicyConfig struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+func buildServiceConfig(cfg Config) (string, error) {
+	lb := cfg.LoadBalancing
+	if lb == "" {
+		lb = RoundRobin
+	}
+
+	retry := buildRetryPolicy(cfg.Retry)
+
+	// An empty name matches every method on the connection
+	sc := serviceConfig{
+		LoadBalancingConfig: []map[string]struct{}{{lb: {}}},
+		MethodConfig: []methodConfig{{
+			Name:        []methodName{{}},
+			Timeout:     formatDuration(cfg.DefaultTimeout),
+			RetryPolicy: retry,
+		}},
+	}
+
+	for fullMethod, timeout := range cfg.MethodTimeouts {
+		name, err := parseMethodName(fullMethod)
+		if err != nil {
+			return "", err
+		}
+		sc.MethodConfig = append(sc.MethodConfig, methodConfig{
+			Name:        []methodName{name},
+			Timeout:     formatDuration(timeout),
+			RetryPolicy: retry,
+		})
+	}
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode service config: %w", err)
+	}
+	return string(data), nil
+}
+
+func buildRetryPolicy(p RetryPolicy) *retryPolicyConfig {
+	if p.MaxAttempts <= 1 {
+		return nil
+	}
+
+	attempts := p.MaxAttempts
+	if attempts > maxRetryAttempts {
+		attempts = maxRetryAttempts
+	}
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff < initial {
+		maxBackoff = initial
+	}
+	multiplier := p.BackoffMultiplier
+	if multiplier <= 0 {
+		multiplier = 1
+	}
+
+	retryable := p.RetryableCodes
+	if len(retryable) == 0 {
+		retryable = []codes.Code{codes.Unavailable}
+	}
+	names := make([]string, len(retryable))
+	for i, c := range retryable {
+		names[i] = codeName(c)
+	}
+
+	return &retryPolicyConfig{
+		MaxAttempts:          attempts,
+		InitialBackoff:       formatDuration(initial),
+		MaxBackoff:           formatDuration(maxBackoff),
+		BackoffMultiplier:    multiplier,
+		RetryableStatusCodes: names,
+	}
+}
+
+// parseMethodName splits "/package.Service/Method" into its parts.
+func parseMethodName(fullMethod string) (methodName, error) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return methodName{}, fmt.Errorf("grpc client: invalid method name %q", fullMethod)
+	}
+	return methodName{Service: parts[0], Method: parts[1]}, nil
+}
+
+// formatDuration renders d in the service config's "1.5s" format.
+func formatDuration(d time.Duration) string {
+	if d <= 0 {
+		return ""
+	}
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+// codeName returns the service config spelling of a status code,
+// e.g. DEADLINE_EXCEEDED.
+func codeName(c codes.Code) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range c.String() {
+		if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	return b.String()
+}
@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// startServer runs a health server whose calls pass through intercept.
+func startServer(t *testing.T, intercept grpc.UnaryServerInterceptor) grpc.DialOption {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(intercept))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
+
+func testConfig() Config {
+	cfg := DefaultConfig("passthrough:///bufnet")
+	cfg.Retry.InitialBackoff = time.Millisecond
+	cfg.Retry.MaxBackoff = 5 * time.Millisecond
+	return cfg
+}
+
+func check(t *testing.T, cfg Config, dialer grpc.DialOption) error {
+	t.Helper()
+
+	conn, err := New(cfg, dialer)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer conn.Close()
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	return err
+}
+
+// failFirst returns code for the first n calls, then lets calls through.
+func failFirst(n int32, code codes.Code, calls *atomic.Int32) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if calls.Add(1) <= n {
+			return nil, status.Error(code, "injected failure")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func TestNew_RetriesUnavailable(t *testing.T) {
+	var calls atomic.Int32
+	dialer := startServer(t, failFirst(2, codes.Unavailable, &calls))
+
+	if err := check(t, testConfig(), dialer); err != nil {
+		t.Fatalf("Check() error = %v, want success after retries", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d calls, want 3", got)
+	}
+}
+
+func TestNew_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	dialer := startServer(t, failFirst(10, codes.Unavailable, &calls))
+
+	err := check(t, testConfig(), dialer)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("Check() error = %v, want Unavailable", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d calls, want 3", got)
+	}
+}
+
+func TestNew_DoesNotRetryOtherCodes(t *testing.T) {
+	var calls atomic.Int32
+	dialer := startServer(t, failFirst(1, codes.Internal, &calls))
+
+	if err := check(t, testConfig(), dialer); status.Code(err) != codes.Internal {
+		t.Fatalf("Check() error = %v, want Internal", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d calls, want 1", got)
+	}
+}
+
+func TestNew_MethodTimeout(t *testing.T) {
+	dialer := startServer(t, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+		}
+		return handler(ctx, req)
+	})
+
+	cfg := testConfig()
+	cfg.MethodTimeouts = map[string]time.Duration{
+		"/grpc.health.v1.Health/Check": 50 * time.Millisecond,
+	}
+
+	start := time.Now()
+	err := check(t, cfg, dialer)
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("Check() error = %v, want DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("call took %v, method timeout was not applied", elapsed)
+	}
+}
+
+func TestNew_PropagatesTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	var traceparent string
+	dialer := startServer(t, func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("traceparent"); len(v) > 0 {
+			traceparent = v[0]
+		}
+		return handler(ctx, req)
+	})
+
+	conn, err := New(testConfig(), dialer)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer conn.Close()
+
+	ctx := trace.ContextWithSpanContext(context.Background(), parent)
+	if _, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	if !strings.Contains(traceparent, traceID.String()) {
+		t.Errorf("traceparent = %q, want trace ID %s", traceparent, traceID)
+	}
+}
+
+func TestNew_MissingTarget(t *testing.T) {
+	if _, err := New(Config{}); err != ErrMissingTarget {
+		t.Errorf("New() error = %v, want ErrMissingTarget", err)
+	}
+}
+
+func TestNew_InvalidMethodName(t *testing.T) {
+	cfg := DefaultConfig("localhost:9000")
+	cfg.MethodTimeouts = map[string]time.Duration{"StartSession": time.Second}
+
+	if _, err := New(cfg); err == nil {
+		t.Error("New() should reject a method name without a service")
+	}
+}
+
+func TestNormalizeTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"provider-service:9083", "dns:///provider-service:9083"},
+		{"localhost:9083", "dns:///localhost:9083"},
+		{"dns:///provider-service:9083", "dns:///provider-service:9083"},
+		{"passthrough:///10.0.0.1:9083", "passthrough:///10.0.0.1:9083"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if got := normalizeTarget(tt.target); got != tt.want {
+				t.Errorf("normalizeTarget() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildServiceConfig(t *testing.T) {
+	cfg := DefaultConfig("localhost:9000")
+	cfg.Retry.MaxAttempts = 10
+	cfg.Retry.RetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+
+	raw, err := buildServiceConfig(cfg)
+	if err != nil {
+		t.Fatalf("buildServiceConfig() error = %v", err)
+	}
+
+	var sc serviceConfig
+	if err := json.Unmarshal([]byte(raw), &sc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if _, ok := sc.LoadBalancingConfig[0][RoundRobin]; !ok {
+		t.Errorf("load balancing = %v, want round_robin", sc.LoadBalancingConfig)
+	}
+
+	mc := sc.MethodConfig[0]
+	if mc.Timeout != "5s" {
+		t.Errorf("default timeout = %q, want 5s", mc.Timeout)
+	}
+	if mc.RetryPolicy.MaxAttempts != maxRetryAttempts {
+		t.Errorf("maxAttempts = %d, want capped at %d", mc.RetryPolicy.MaxAttempts, maxRetryAttempts)
+	}
+	if mc.RetryPolicy.InitialBackoff != "0.1s" {
+		t.Errorf("initialBackoff = %q, want 0.1s", mc.RetryPolicy.InitialBackoff)
+	}
+	want := []string{"UNAVAILABLE", "RESOURCE_EXHAUSTED"}
+	for i, code := range mc.RetryPolicy.RetryableStatusCodes {
+		if code != want[i] {
+			t.Errorf("retryable code %d = %q, want %q", i, code, want[i])
+		}
+	}
+}
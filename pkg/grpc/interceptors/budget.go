@@ -0,0 +1,27 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/deadline"
+	"google.golang.org/grpc"
+)
+
+// BudgetUnaryClientInterceptor records each call on the request's time
+// budget, so a request that runs out of time can report the calls still
+// pending. The deadline itself travels with the call.
+func BudgetUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		done := deadline.Track(ctx, method)
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		done(err)
+		return err
+	}
+}
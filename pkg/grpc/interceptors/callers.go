@@ -0,0 +1,61 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"google.golang.org/grpc"
+)
+
+// CallerAllowlistUnaryServerInterceptor rejects calls whose client
+// certificate doesn't name one of the allowed services
+func CallerAllowlistUnaryServerInterceptor(allowed []string) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if err := mtls.Authorize(ctx, allowed); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// CallerAllowlistStreamServerInterceptor is the stream counterpart of
+// CallerAllowlistUnaryServerInterceptor
+func CallerAllowlistStreamServerInterceptor(allowed []string) grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		if err := mtls.Authorize(ss.Context(), allowed); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// WithMutualTLS returns the server options that require callers to present
+// a certificate from cfg's CA and, when cfg.AllowedClients is set, to be
+// one of those services. It returns no options when TLS is not configured.
+//
+//	tlsOpts, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+//	grpcServer := interceptors.NewServerWithDefaults(tlsOpts...)
+func WithMutualTLS(cfg mtls.Config) ([]grpc.ServerOption, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+	creds, err := mtls.ServerCredentials(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(CallerAllowlistUnaryServerInterceptor(cfg.AllowedClients)),
+		grpc.ChainStreamInterceptor(CallerAllowlistStreamServerInterceptor(cfg.AllowedClients)),
+	}, nil
+}
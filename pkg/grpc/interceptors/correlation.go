@@ -0,0 +1,63 @@
+package interceptors
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys carrying the correlation IDs between services
+const (
+	requestIDMetadataKey = "x-request-id"
+	userIDMetadataKey    = "x-user-id"
+)
+
+// CorrelationUnaryServerInterceptor stores the caller's request and user IDs
+// in the context so log records of the handled call carry them
+func CorrelationUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		return handler(correlationFromIncoming(ctx), req)
+	}
+}
+
+// CorrelationUnaryClientInterceptor forwards the request and user IDs in the
+// context as outgoing metadata
+func CorrelationUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if id := logging.RequestIDFromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, requestIDMetadataKey, id)
+		}
+		if id := logging.UserIDFromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, userIDMetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func correlationFromIncoming(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if ids := md.Get(requestIDMetadataKey); len(ids) > 0 {
+		ctx = logging.WithRequestID(ctx, ids[0])
+	}
+	if ids := md.Get(userIDMetadataKey); len(ids) > 0 {
+		ctx = logging.WithUserID(ctx, ids[0])
+	}
+	return ctx
+}
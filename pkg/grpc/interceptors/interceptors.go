@@ -1,16 +1,20 @@
 package interceptors
 
 import (
+	"time"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
 // DefaultServerInterceptors returns the recommended chain of server interceptors
-// Order: Recovery -> Tracing -> Logging
+// Order: Recovery -> Tracing -> Correlation -> Logging
 // Recovery is first to catch panics from all other interceptors
 func DefaultServerInterceptors() []grpc.UnaryServerInterceptor {
 	return []grpc.UnaryServerInterceptor{
 		RecoveryUnaryServerInterceptor(),
 		TracingUnaryServerInterceptor(),
+		CorrelationUnaryServerInterceptor(),
 		LoggingUnaryServerInterceptor(),
 	}
 }
@@ -25,11 +29,13 @@ func DefaultStreamServerInterceptors() []grpc.StreamServerInterceptor {
 }
 
 // DefaultClientInterceptors returns the recommended chain of client interceptors
-// Order: Tracing -> Logging
+// Order: Tracing -> Correlation -> Logging -> Budget
 func DefaultClientInterceptors() []grpc.UnaryClientInterceptor {
 	return []grpc.UnaryClientInterceptor{
 		TracingUnaryClientInterceptor(),
+		CorrelationUnaryClientInterceptor(),
 		LoggingUnaryClientInterceptor(),
+		BudgetUnaryClientInterceptor(),
 	}
 }
 
@@ -40,11 +46,22 @@ func DefaultStreamClientInterceptors() []grpc.StreamClientInterceptor {
 	}
 }
 
-// NewServerWithDefaults creates a gRPC server with default interceptors
+// KeepaliveEnforcementPolicy accepts the keepalive pings sent by clients built
+// with pkg/grpc/client. grpc-go's default policy rejects pings more frequent
+// than every 5 minutes and closes the connection with "too_many_pings".
+var KeepaliveEnforcementPolicy = keepalive.EnforcementPolicy{
+	MinTime:             20 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// NewServerWithDefaults creates a gRPC server with default interceptors.
+// Pass the options from WithMutualTLS to accept only authenticated callers;
+// their interceptors run after the defaults.
 func NewServerWithDefaults(opts ...grpc.ServerOption) *grpc.Server {
 	serverOpts := []grpc.ServerOption{
 		grpc.ChainUnaryInterceptor(DefaultServerInterceptors()...),
 		grpc.ChainStreamInterceptor(DefaultStreamServerInterceptors()...),
+		grpc.KeepaliveEnforcementPolicy(KeepaliveEnforcementPolicy),
 	}
 	serverOpts = append(serverOpts, opts...)
 	return grpc.NewServer(serverOpts...)
@@ -0,0 +1,225 @@
+// Package mtls secures gRPC traffic between services with mutual TLS.
+//
+// Each service gets a certificate signed by the internal CA. Servers require
+// callers to present a certificate from the same CA and may restrict which
+// identities (the certificate's DNS names or Common Name) can call them.
+// Certificate and key files are read again when they change on disk, so
+// secrets can be rotated without a restart.
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ErrIncompleteConfig is returned when only some of the certificate files
+// are set.
+var ErrIncompleteConfig = errors.New("mtls: cert file, key file and CA file must all be set")
+
+// Config locates the service's certificate, key and the CA that signs every
+// service certificate. A zero Config disables TLS.
+type Config struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+
+	// AllowedClients lists the caller identities the server accepts.
+	// Empty accepts any certificate signed by the CA.
+	AllowedClients []string
+}
+
+// ConfigFromEnv reads GRPC_TLS_CERT_FILE, GRPC_TLS_KEY_FILE, GRPC_TLS_CA_FILE
+// and GRPC_TLS_ALLOWED_CLIENTS (comma separated).
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		CertFile: os.Getenv("GRPC_TLS_CERT_FILE"),
+		KeyFile:  os.Getenv("GRPC_TLS_KEY_FILE"),
+		CAFile:   os.Getenv("GRPC_TLS_CA_FILE"),
+	}
+	for _, id := range strings.Split(os.Getenv("GRPC_TLS_ALLOWED_CLIENTS"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			cfg.AllowedClients = append(cfg.AllowedClients, id)
+		}
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Enabled reports whether certificate files are configured.
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// Validate checks that the files are either all set or all empty.
+func (c Config) Validate() error {
+	if c.Enabled() && (c.CertFile == "" || c.KeyFile == "" || c.CAFile == "") {
+		return ErrIncompleteConfig
+	}
+	if !c.Enabled() && len(c.AllowedClients) > 0 {
+		return fmt.Errorf("mtls: allowed clients need a certificate to verify callers")
+	}
+	return nil
+}
+
+// ServerCredentials requires callers to present a certificate signed by the
+// CA.
+func ServerCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	pair, pool, err := load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  pool,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return pair.get()
+		},
+	}), nil
+}
+
+// ClientCredentials presents the service's certificate and verifies the
+// server's against the CA. The server name is taken from the dial target.
+func ClientCredentials(cfg Config) (credentials.TransportCredentials, error) {
+	pair, pool, err := load(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return pair.get()
+		},
+	}), nil
+}
+
+func load(cfg Config) (*keyPair, *x509.CertPool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, nil, ErrIncompleteConfig
+	}
+
+	caPEM, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, nil, fmt.Errorf("mtls: no certificates found in %s", cfg.CAFile)
+	}
+
+	pair := &keyPair{certFile: cfg.CertFile, keyFile: cfg.KeyFile}
+	if _, err := pair.get(); err != nil {
+		return nil, nil, err
+	}
+	return pair, pool, nil
+}
+
+// keyPair reloads the certificate and key when either file changes.
+type keyPair struct {
+	certFile, keyFile string
+
+	mu       sync.Mutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+func (p *keyPair) get() (*tls.Certificate, error) {
+	modified, err := latestModTime(p.certFile, p.keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cert != nil && !modified.After(p.modified) {
+		return p.cert, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(p.certFile, p.keyFile)
+	if err != nil {
+		if p.cert != nil {
+			// Mid-rotation the files may not match yet; keep the old pair
+			return p.cert, nil
+		}
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	p.cert, p.modified = &cert, modified
+	return p.cert, nil
+}
+
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to stat %s: %w", f, err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// Identities returns the names a verified peer certificate vouches for:
+// its DNS names, then its Common Name.
+func Identities(cert *x509.Certificate) []string {
+	ids := append([]string(nil), cert.DNSNames...)
+	if cert.Subject.CommonName != "" {
+		ids = append(ids, cert.Subject.CommonName)
+	}
+	return ids
+}
+
+// PeerIdentities returns the identities of the caller's verified
+// certificate, or nil when the connection is not mutual TLS.
+func PeerIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return Identities(info.State.VerifiedChains[0][0])
+}
+
+// Authorize returns PermissionDenied unless the caller's certificate names
+// one of the allowed identities. An empty allowlist accepts any verified
+// caller.
+func Authorize(ctx context.Context, allowed []string) error {
+	ids := PeerIdentities(ctx)
+	if ids == nil {
+		return status.Error(codes.Unauthenticated, "client certificate required")
+	}
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, id := range ids {
+		for _, a := range allowed {
+			if id == a {
+				return nil
+			}
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "caller %s is not allowed", ids[0])
+}
@@ -0,0 +1,186 @@
+package mtls_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testCA struct {
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	ca := &testCA{dir: t.TempDir(), cert: cert, key: key}
+	ca.file = ca.write(t, "ca.pem", "CERTIFICATE", der)
+	return ca
+}
+
+func (ca *testCA) write(t *testing.T, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(ca.dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// issue signs a certificate for name and returns a Config using it
+func (ca *testCA) issue(t *testing.T, name string, allowed ...string) mtls.Config {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("failed to issue certificate: %v", err)
+	}
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	return mtls.Config{
+		CertFile:       ca.write(t, name+".pem", "CERTIFICATE", der),
+		KeyFile:        ca.write(t, name+"-key.pem", "EC PRIVATE KEY", keyDER),
+		CAFile:         ca.file,
+		AllowedClients: allowed,
+	}
+}
+
+func startServer(t *testing.T, cfg mtls.Config) grpc.DialOption {
+	t.Helper()
+	opts, err := interceptors.WithMutualTLS(cfg)
+	if err != nil {
+		t.Fatalf("failed to build server options: %v", err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	srv := interceptors.NewServerWithDefaults(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
+
+func check(t *testing.T, dialer grpc.DialOption, tlsCfg mtls.Config) error {
+	t.Helper()
+	cfg := client.DefaultConfig("passthrough:///bufnet")
+	cfg.Retry.MaxAttempts = 1
+	if err := cfg.UseMutualTLS(tlsCfg); err != nil {
+		t.Fatalf("failed to configure client TLS: %v", err)
+	}
+	conn, err := client.New(cfg, dialer)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	dialer := startServer(t, ca.issue(t, "bufnet", "parking-service"))
+
+	tests := []struct {
+		name     string
+		client   mtls.Config
+		wantCode codes.Code
+	}{
+		{"allowed caller", ca.issue(t, "parking-service"), codes.OK},
+		{"caller not in allowlist", ca.issue(t, "notification-service"), codes.PermissionDenied},
+		{"certificate from another CA", newTestCA(t).issue(t, "parking-service"), codes.Unavailable},
+		{"no certificate", mtls.Config{}, codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every client trusts the server's CA, so only the caller's
+			// certificate decides the outcome
+			if tt.client.Enabled() {
+				tt.client.CAFile = ca.file
+			}
+			err := check(t, dialer, tt.client)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("expected %s, got %s (%v)", tt.wantCode, got, err)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     mtls.Config
+		wantErr bool
+	}{
+		{"disabled", mtls.Config{}, false},
+		{"complete", mtls.Config{CertFile: "c", KeyFile: "k", CAFile: "ca"}, false},
+		{"missing CA", mtls.Config{CertFile: "c", KeyFile: "k"}, true},
+		{"allowlist without certificate", mtls.Config{AllowedClients: []string{"a"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Package httpserver builds the services' HTTP servers from one shared
+// configuration, so timeouts, header limits and optional TLS termination are
+// applied the same way everywhere.
+//
+// The defaults are deliberately conservative: ReadHeaderTimeout and
+// MaxHeaderBytes guard against slow-header (slowloris) clients, which the
+// net/http zero values leave unbounded.
+package httpserver
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ErrIncompleteTLS is returned when only one of the certificate and key files is set.
+var ErrIncompleteTLS = errors.New("httpserver: TLS requires both a certificate and a key file")
+
+// Default server settings.
+const (
+	DefaultReadTimeout       = 15 * time.Second
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultWriteTimeout      = 15 * time.Second
+	DefaultIdleTimeout       = 60 * time.Second
+	DefaultMaxHeaderBytes    = 64 << 10
+)
+
+// Config holds the settings shared by every service's HTTP server.
+type Config struct {
+	ReadTimeout       time.Duration
+	ReadHeaderTimeout time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MaxHeaderBytes    int
+	TLS               TLSConfig
+}
+
+// TLSConfig enables TLS termination in the service itself. Leave both files
+// empty when TLS is terminated by the load balancer or service mesh.
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	// MinVersion is a crypto/tls version constant; zero means TLS 1.2.
+	MinVersion uint16
+}
+
+// Enabled reports whether the server should serve HTTPS.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// DefaultConfig returns the settings used when nothing is configured.
+func DefaultConfig() Config {
+	return Config{
+		ReadTimeout:       DefaultReadTimeout,
+		ReadHeaderTimeout: DefaultReadHeaderTimeout,
+		WriteTimeout:      DefaultWriteTimeout,
+		IdleTimeout:       DefaultIdleTimeout,
+		MaxHeaderBytes:    DefaultMaxHeaderBytes,
+	}
+}
+
+// ConfigFromEnv reads the server settings from SERVER_* environment
+// variables, falling back to DefaultConfig for anything unset:
+//
+//	SERVER_READ_TIMEOUT, SERVER_READ_HEADER_TIMEOUT, SERVER_WRITE_TIMEOUT,
+//	SERVER_IDLE_TIMEOUT   durations such as "15s"
+//	SERVER_MAX_HEADER_BYTES
+//	SERVER_TLS_CERT_FILE, SERVER_TLS_KEY_FILE
+//	SERVER_TLS_MIN_VERSION   "1.2" or "1.3"
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"SERVER_READ_TIMEOUT", &cfg.ReadTimeout},
+		{"SERVER_READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout},
+		{"SERVER_WRITE_TIMEOUT", &cfg.WriteTimeout},
+		{"SERVER_IDLE_TIMEOUT", &cfg.IdleTimeout},
+	}
+	for _, d := range durations {
+		value := os.Getenv(d.key)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s: %w", d.key, err)
+		}
+		*d.dst = parsed
+	}
+
+	if value := os.Getenv("SERVER_MAX_HEADER_BYTES"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return Config{}, fmt.Errorf("invalid SERVER_MAX_HEADER_BYTES: %q", value)
+		}
+		cfg.MaxHeaderBytes = n
+	}
+
+	cfg.TLS.CertFile = os.Getenv("SERVER_TLS_CERT_FILE")
+	cfg.TLS.KeyFile = os.Getenv("SERVER_TLS_KEY_FILE")
+	switch value := os.Getenv("SERVER_TLS_MIN_VERSION"); value {
+	case "", "1.2":
+		cfg.TLS.MinVersion = tls.VersionTLS12
+	case "1.3":
+		cfg.TLS.MinVersion = tls.VersionTLS13
+	default:
+		return Config{}, fmt.Errorf("invalid SERVER_TLS_MIN_VERSION: %q", value)
+	}
+
+	return cfg, nil
+}
+
+// Server is an http.Server whose ListenAndServe serves HTTPS when TLS is configured.
+type Server struct {
+	*http.Server
+}
+
+// New creates a server listening on addr. The TLS key pair is loaded here so
+// a bad certificate fails at startup rather than on the first connection.
+func New(addr string, handler http.Handler, cfg Config) (*Server, error) {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	if cfg.TLS.Enabled() {
+		if cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" {
+			return nil, ErrIncompleteTLS
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.TLS.CertFile, cfg.TLS.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+		}
+		minVersion := cfg.TLS.MinVersion
+		if minVersion == 0 {
+			minVersion = tls.VersionTLS12
+		}
+		srv.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   minVersion,
+		}
+	}
+
+	return &Server{Server: srv}, nil
+}
+
+// TLSEnabled reports whether the server terminates TLS itself.
+func (s *Server) TLSEnabled() bool {
+	return s.TLSConfig != nil
+}
+
+// ListenAndServe serves HTTPS when TLS is configured and plain HTTP otherwise.
+func (s *Server) ListenAndServe() error {
+	if s.TLSEnabled() {
+		// The certificate is already in TLSConfig
+		return s.Server.ListenAndServeTLS("", "")
+	}
+	return s.Server.ListenAndServe()
+}
@@ -0,0 +1,144 @@
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConfigFromEnv_Defaults(t *testing.T) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.ReadHeaderTimeout != DefaultReadHeaderTimeout || cfg.MaxHeaderBytes != DefaultMaxHeaderBytes {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+	if cfg.TLS.Enabled() {
+		t.Error("TLS should be disabled by default")
+	}
+}
+
+func TestConfigFromEnv_Overrides(t *testing.T) {
+	t.Setenv("SERVER_READ_TIMEOUT", "30s")
+	t.Setenv("SERVER_READ_HEADER_TIMEOUT", "2s")
+	t.Setenv("SERVER_MAX_HEADER_BYTES", "8192")
+	t.Setenv("SERVER_TLS_MIN_VERSION", "1.3")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.ReadTimeout != 30*time.Second || cfg.ReadHeaderTimeout != 2*time.Second {
+		t.Errorf("timeouts = %v/%v, want 30s/2s", cfg.ReadTimeout, cfg.ReadHeaderTimeout)
+	}
+	if cfg.WriteTimeout != DefaultWriteTimeout {
+		t.Errorf("WriteTimeout = %v, want default", cfg.WriteTimeout)
+	}
+	if cfg.MaxHeaderBytes != 8192 || cfg.TLS.MinVersion != tls.VersionTLS13 {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	tests := []struct {
+		key, value string
+	}{
+		{"SERVER_IDLE_TIMEOUT", "forever"},
+		{"SERVER_MAX_HEADER_BYTES", "-1"},
+		{"SERVER_TLS_MIN_VERSION", "1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Setenv(tt.key, tt.value)
+			if _, err := ConfigFromEnv(); err == nil {
+				t.Errorf("expected error for %s=%s", tt.key, tt.value)
+			}
+		})
+	}
+}
+
+func TestNew_AppliesConfig(t *testing.T) {
+	srv, err := New(":0", http.NotFoundHandler(), DefaultConfig())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if srv.ReadHeaderTimeout != DefaultReadHeaderTimeout || srv.MaxHeaderBytes != DefaultMaxHeaderBytes {
+		t.Errorf("server not configured: %+v", srv.Server)
+	}
+	if srv.TLSEnabled() {
+		t.Error("TLS should be disabled")
+	}
+}
+
+func TestNew_TLS(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t)
+
+	cfg := DefaultConfig()
+	cfg.TLS.CertFile = certFile
+	_, err := New(":0", http.NotFoundHandler(), cfg)
+	if !errors.Is(err, ErrIncompleteTLS) {
+		t.Errorf("New() error = %v, want ErrIncompleteTLS", err)
+	}
+
+	cfg.TLS.KeyFile = filepath.Join(t.TempDir(), "missing.pem")
+	if _, err := New(":0", http.NotFoundHandler(), cfg); err == nil {
+		t.Error("expected error for missing key file")
+	}
+
+	cfg.TLS.KeyFile = keyFile
+	srv, err := New(":0", http.NotFoundHandler(), cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if !srv.TLSEnabled() || srv.TLSConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("unexpected TLS config: %+v", srv.TLSConfig)
+	}
+}
+
+func writeKeyPair(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
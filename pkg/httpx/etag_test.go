@@ -0,0 +1,56 @@
+package httpx
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestETag(t *testing.T) {
+	body := `{"success":true,"data":[]}`
+	handler := ETag(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			NotFound(w, r, "NOT_FOUND", "not found")
+			return
+		}
+		OK(w, []string{})
+	}))
+	tag := ETagFor([]byte(body + "\n"))
+
+	tests := []struct {
+		name        string
+		path        string
+		ifNoneMatch string
+		wantStatus  int
+		wantETag    bool
+		wantBody    bool
+	}{
+		{"first request", "/list", "", http.StatusOK, true, true},
+		{"unchanged", "/list", tag, http.StatusNotModified, true, false},
+		{"weak match", "/list", "W/" + tag, http.StatusNotModified, true, false},
+		{"one of several", "/list", `"old", ` + tag, http.StatusNotModified, true, false},
+		{"changed", "/list", `"old"`, http.StatusOK, true, true},
+		{"error response", "/missing", tag, http.StatusNotFound, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("ETag"); (got != "") != tt.wantETag || (tt.wantETag && got != tag) {
+				t.Errorf("ETag = %q, want %q: %v", got, tag, tt.wantETag)
+			}
+			if (rec.Body.Len() > 0) != tt.wantBody {
+				t.Errorf("body = %q, want body: %v", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package httpx holds the HTTP response helpers shared by the services.
+//
+// Errors are written as RFC 7807 problem details (application/problem+json)
+// carrying a machine-readable code and the request and trace IDs, so a
+// support ticket quoting an error can be matched to logs and traces.
+package httpx
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ProblemContentType is the media type defined by RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// RequestIDHeader carries the request ID between the gateway, the services and clients.
+const RequestIDHeader = "X-Request-ID"
+
+// CodeInternal is used for unexpected server-side failures.
+const CodeInternal = "INTERNAL_ERROR"
+
+// Problem is an RFC 7807 problem details body. Code, RequestID, TraceID,
+// Errors and Diagnostics are extension members.
+//
+// Success and Error repeat the code and message in the envelope the services
+// returned before problem details, so existing clients keep working.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	TraceID   string       `json:"trace_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// Diagnostics describes why the request failed in more detail, such as
+	// the downstream calls of a request that timed out
+	Diagnostics interface{} `json:"diagnostics,omitempty"`
+
+	Success bool         `json:"success"`
+	Error   *LegacyError `json:"error"`
+}
+
+// FieldError describes one invalid input field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// LegacyError is the error object of the original {success, data, error} envelope.
+type LegacyError struct {
+	Code      string       `json:"code"`
+	Message   string       `json:"message"`
+	RequestID string       `json:"request_id,omitempty"`
+	Details   []FieldError `json:"details,omitempty"`
+}
+
+// NewProblem builds a problem for the request, filling in the request and
+// trace IDs from its context.
+func NewProblem(r *http.Request, status int, code, detail string, fieldErrors ...FieldError) *Problem {
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: fieldErrors,
+	}
+	if r != nil {
+		p.Instance = r.URL.Path
+		p.RequestID = RequestID(r)
+		if spanCtx := trace.SpanContextFromContext(r.Context()); spanCtx.IsValid() {
+			p.TraceID = spanCtx.TraceID().String()
+		}
+	}
+	return p
+}
+
+// WriteProblem writes p with the problem+json content type. Server errors
+// are logged with their request ID so the generic message a client sees can
+// be traced back.
+func WriteProblem(w http.ResponseWriter, p *Problem) {
+	p.Success = false
+	p.Error = &LegacyError{
+		Code:      p.Code,
+		Message:   p.Detail,
+		RequestID: p.RequestID,
+		Details:   p.Errors,
+	}
+
+	if p.Status >= http.StatusInternalServerError {
+		log.Printf("request %s failed: %d %s %s (trace %s)", p.RequestID, p.Status, p.Code, p.Instance, p.TraceID)
+	}
+
+	if p.RequestID != "" {
+		w.Header().Set(RequestIDHeader, p.RequestID)
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// WriteError writes a problem with the given status, machine-readable code
+// and human-readable message.
+func WriteError(w http.ResponseWriter, r *http.Request, status int, code, message string, fieldErrors ...FieldError) {
+	WriteProblem(w, NewProblem(r, status, code, message, fieldErrors...))
+}
+
+// RequestID returns the ID assigned by chi's RequestID middleware, falling
+// back to the X-Request-ID header set by the gateway.
+func RequestID(r *http.Request) string {
+	if id := chimw.GetReqID(r.Context()); id != "" {
+		return id
+	}
+	return r.Header.Get(RequestIDHeader)
+}
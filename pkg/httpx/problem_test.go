@@ -0,0 +1,80 @@
+package httpx
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWriteError(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	ctx = context.WithValue(ctx, chimw.RequestIDKey, "host/abc-000001")
+
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/wallets", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	WriteError(w, r, http.StatusBadRequest, "INVALID_AMOUNT", "Amount must be positive",
+		FieldError{Field: "amount", Code: "positive", Message: "must be greater than zero"})
+
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", got, ProblemContentType)
+	}
+	if got := w.Header().Get(RequestIDHeader); got != "host/abc-000001" {
+		t.Errorf("%s = %q", RequestIDHeader, got)
+	}
+
+	var body map[string]interface{}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := map[string]interface{}{
+		"title":      "Bad Request",
+		"status":     float64(400),
+		"code":       "INVALID_AMOUNT",
+		"detail":     "Amount must be positive",
+		"instance":   "/api/v1/wallets",
+		"request_id": "host/abc-000001",
+		"trace_id":   "4bf92f3577b34da6a3ce929d0e0e4736",
+		"success":    false,
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %v", k, body[k], v)
+		}
+	}
+	if errs, _ := body["errors"].([]interface{}); len(errs) != 1 {
+		t.Errorf("errors = %v, want one field error", body["errors"])
+	}
+
+	legacy, _ := body["error"].(map[string]interface{})
+	if legacy["code"] != "INVALID_AMOUNT" || legacy["message"] != "Amount must be positive" {
+		t.Errorf("legacy error = %v", legacy)
+	}
+}
+
+func TestRequestID_HeaderFallback(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if got := RequestID(r); got != "" {
+		t.Errorf("RequestID() = %q, want empty", got)
+	}
+
+	r.Header.Set(RequestIDHeader, "gw-123")
+	if got := RequestID(r); got != "gw-123" {
+		t.Errorf("RequestID() = %q, want gw-123", got)
+	}
+
+	p := NewProblem(r, http.StatusInternalServerError, CodeInternal, "An internal error occurred")
+	if p.RequestID != "gw-123" || p.TraceID != "" {
+		t.Errorf("unexpected problem: %+v", p)
+	}
+}
@@ -0,0 +1,66 @@
+package httpx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBodyBytes bounds request bodies decoded by DecodeJSON.
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// CodeInvalidJSON and CodeBodyTooLarge are the codes WriteDecodeError uses.
+const (
+	CodeInvalidJSON  = "INVALID_JSON"
+	CodeBodyTooLarge = "BODY_TOO_LARGE"
+)
+
+// ErrEmptyBody is returned by DecodeJSON when the request has no body.
+var ErrEmptyBody = errors.New("request body is empty")
+
+// DecodeJSON decodes the request body into dst, reading at most
+// DefaultMaxBodyBytes.
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	return DecodeJSONLimit(w, r, dst, DefaultMaxBodyBytes)
+}
+
+// DecodeJSONLimit decodes the request body into dst, reading at most limit
+// bytes. Trailing data after the JSON value is rejected.
+func DecodeJSONLimit(w http.ResponseWriter, r *http.Request, dst interface{}, limit int64) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	if err := dec.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrEmptyBody
+		}
+		return err
+	}
+	if dec.More() {
+		return errors.New("request body must contain a single JSON value")
+	}
+	return nil
+}
+
+// WriteDecodeError reports a DecodeJSON failure: 413 when the body was too
+// large, otherwise 400 with the offending field when it is known.
+func WriteDecodeError(w http.ResponseWriter, r *http.Request, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		WriteError(w, r, http.StatusRequestEntityTooLarge, CodeBodyTooLarge,
+			fmt.Sprintf("Request body must not exceed %d bytes", maxBytesErr.Limit))
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		BadRequest(w, r, CodeInvalidJSON, "Invalid request body", FieldError{
+			Field:   typeErr.Field,
+			Code:    "type",
+			Message: fmt.Sprintf("must be %s", typeErr.Type),
+		})
+		return
+	}
+
+	BadRequest(w, r, CodeInvalidJSON, "Invalid request body")
+}
@@ -0,0 +1,88 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Response is the envelope every successful JSON response is wrapped in.
+type Response struct {
+	Success bool        `json:"success"`
+	Data    interface{} `json:"data,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// ResponseOption customises the envelope written by WriteJSON.
+type ResponseOption func(*Response)
+
+// WithMeta attaches out-of-band data such as pagination to the envelope.
+func WithMeta(meta interface{}) ResponseOption {
+	return func(resp *Response) {
+		resp.Meta = meta
+	}
+}
+
+// WriteJSON writes data in the standard envelope. Success is derived from
+// the status code.
+func WriteJSON(w http.ResponseWriter, status int, data interface{}, opts ...ResponseOption) {
+	resp := Response{
+		Success: status >= 200 && status < 300,
+		Data:    data,
+	}
+	for _, opt := range opts {
+		opt(&resp)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// WriteRaw writes data as JSON without the envelope, for endpoints whose
+// consumers expect a bare document.
+func WriteRaw(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+// OK writes a 200 response.
+func OK(w http.ResponseWriter, data interface{}, opts ...ResponseOption) {
+	WriteJSON(w, http.StatusOK, data, opts...)
+}
+
+// Created writes a 201 response.
+func Created(w http.ResponseWriter, data interface{}) {
+	WriteJSON(w, http.StatusCreated, data)
+}
+
+// NoContent writes a 204 response with no body.
+func NoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// BadRequest writes a 400 problem.
+func BadRequest(w http.ResponseWriter, r *http.Request, code, message string, fieldErrors ...FieldError) {
+	WriteError(w, r, http.StatusBadRequest, code, message, fieldErrors...)
+}
+
+// Unauthorized writes a 401 problem.
+func Unauthorized(w http.ResponseWriter, r *http.Request, code, message string) {
+	WriteError(w, r, http.StatusUnauthorized, code, message)
+}
+
+// Forbidden writes a 403 problem.
+func Forbidden(w http.ResponseWriter, r *http.Request, code, message string) {
+	WriteError(w, r, http.StatusForbidden, code, message)
+}
+
+// NotFound writes a 404 problem.
+func NotFound(w http.ResponseWriter, r *http.Request, code, message string) {
+	WriteError(w, r, http.StatusNotFound, code, message)
+}
+
+// InternalError writes a 500 problem with a generic message. The request ID
+// in the body is what ties it to the server-side log.
+func InternalError(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, http.StatusInternalServerError, CodeInternal, "An internal error occurred")
+}
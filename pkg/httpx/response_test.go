@@ -0,0 +1,98 @@
+package httpx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteJSON_Envelope(t *testing.T) {
+	w := httptest.NewRecorder()
+	OK(w, map[string]string{"id": "w1"}, WithMeta(map[string]int{"total": 1}))
+
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q", got)
+	}
+	var body struct {
+		Success bool              `json:"success"`
+		Data    map[string]string `json:"data"`
+		Meta    map[string]int    `json:"meta"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !body.Success || body.Data["id"] != "w1" || body.Meta["total"] != 1 {
+		t.Errorf("unexpected body: %+v", body)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	type request struct {
+		Amount int `json:"amount"`
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		limit      int64
+		wantStatus int
+		wantField  string
+	}{
+		{"valid", `{"amount": 10}`, DefaultMaxBodyBytes, 0, ""},
+		{"empty", ``, DefaultMaxBodyBytes, http.StatusBadRequest, ""},
+		{"malformed", `{"amount":`, DefaultMaxBodyBytes, http.StatusBadRequest, ""},
+		{"wrong type", `{"amount": "ten"}`, DefaultMaxBodyBytes, http.StatusBadRequest, "amount"},
+		{"trailing data", `{"amount": 1} {}`, DefaultMaxBodyBytes, http.StatusBadRequest, ""},
+		{"too large", `{"amount": 1000000}`, 8, http.StatusRequestEntityTooLarge, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			var req request
+			err := DecodeJSONLimit(w, r, &req, tt.limit)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Fatalf("DecodeJSON() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected decode error")
+			}
+
+			WriteDecodeError(w, r, err)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var p Problem
+			json.NewDecoder(w.Body).Decode(&p)
+			if tt.wantField != "" && (len(p.Errors) != 1 || p.Errors[0].Field != tt.wantField) {
+				t.Errorf("field errors = %+v, want %s", p.Errors, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	h := SecurityHeaders(JSONContentType(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+	})))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got := w.Header().Get("X-Content-Type-Options"); got != "nosniff" {
+		t.Errorf("X-Content-Type-Options = %q", got)
+	}
+	if got := w.Header().Get("X-Frame-Options"); got != "DENY" {
+		t.Errorf("X-Frame-Options = %q", got)
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("handler should be able to override Content-Type, got %q", got)
+	}
+}
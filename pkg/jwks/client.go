@@ -0,0 +1,116 @@
+package jwks
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long fetched keys are trusted before the set is
+// fetched again
+const DefaultCacheTTL = 10 * time.Minute
+
+// minRefreshInterval stops a flood of tokens with bogus key IDs from
+// turning into a flood of requests to the JWKS endpoint
+const minRefreshInterval = 30 * time.Second
+
+// Client fetches a key set and caches it. A kid it hasn't seen triggers a
+// refresh, since that is what a token signed with a newly rotated key
+// looks like.
+type Client struct {
+	url        string
+	cacheTTL   time.Duration
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu        sync.RWMutex
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time
+}
+
+// NewClient creates a client for the key set at url. A non-positive
+// cacheTTL uses DefaultCacheTTL.
+func NewClient(url string, cacheTTL time.Duration) *Client {
+	if cacheTTL <= 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+	return &Client{
+		url:        url,
+		cacheTTL:   cacheTTL,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		now:        time.Now,
+		keys:       make(map[string]crypto.PublicKey),
+	}
+}
+
+// Key returns the public key with the given kid
+func (c *Client) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	age := c.now().Sub(c.fetchedAt)
+	c.mu.RUnlock()
+
+	if ok && age < c.cacheTTL {
+		return key, nil
+	}
+	if !ok && age < minRefreshInterval {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+
+	if err := c.Refresh(ctx); err != nil {
+		// Keep using a cached key while the endpoint is unreachable
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if key, ok = c.keys[kid]; !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, kid)
+	}
+	return key, nil
+}
+
+// Refresh fetches the key set now. Keys of unsupported types are skipped.
+func (c *Client) Refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("JWKS endpoint returned status %d", resp.StatusCode)
+	}
+
+	var set Set
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		pub, err := k.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = pub
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.fetchedAt = c.now()
+	c.mu.Unlock()
+	return nil
+}
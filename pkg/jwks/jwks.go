@@ -0,0 +1,154 @@
+// Package jwks publishes and fetches JSON Web Key Sets (RFC 7517), the
+// documents through which the auth service shares the public halves of its
+// token signing keys.
+//
+// RSA keys (RS256) and Ed25519 keys (EdDSA) are supported.
+package jwks
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Signing algorithms, as named in the JWT alg header
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	ErrUnsupportedKey = errors.New("unsupported key type")
+	ErrUnknownKey     = errors.New("unknown signing key")
+)
+
+// Key is a public key in JWK form
+type Key struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+
+	// Ed25519 curve and public key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// Set is the document served at a JWKS endpoint
+type Set struct {
+	Keys []Key `json:"keys"`
+}
+
+// NewKey describes pub as a signing key. Its kid is the key's thumbprint.
+func NewKey(pub crypto.PublicKey) (Key, error) {
+	var k Key
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		k = Key{
+			Kty: "RSA",
+			Alg: AlgRS256,
+			N:   encode(pub.N.Bytes()),
+			E:   encode(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		k = Key{Kty: "OKP", Alg: AlgEdDSA, Crv: "Ed25519", X: encode(pub)}
+	default:
+		return Key{}, fmt.Errorf("%w: %T", ErrUnsupportedKey, pub)
+	}
+
+	kid, err := k.thumbprint()
+	if err != nil {
+		return Key{}, err
+	}
+	k.Kid = kid
+	k.Use = "sig"
+	return k, nil
+}
+
+// Thumbprint returns the RFC 7638 thumbprint of pub. Every process that
+// loads the same key derives the same ID, so it makes a stable kid.
+func Thumbprint(pub crypto.PublicKey) (string, error) {
+	k, err := NewKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return k.Kid, nil
+}
+
+// thumbprint hashes the key's required members in lexicographic order
+func (k Key) thumbprint() (string, error) {
+	var members any
+	switch k.Kty {
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{k.E, k.Kty, k.N}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Crv, k.Kty, k.X}
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedKey, k.Kty)
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return encode(sum[:]), nil
+}
+
+// PublicKey decodes the key: *rsa.PublicKey for RSA, ed25519.PublicKey
+// for Ed25519
+func (k Key) PublicKey() (crypto.PublicKey, error) {
+	switch {
+	case k.Kty == "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		if len(n) == 0 || len(e) == 0 {
+			return nil, errors.New("empty modulus or exponent")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case k.Kty == "OKP" && k.Crv == "Ed25519":
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %w", err)
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key length")
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s %s", ErrUnsupportedKey, k.Kty, k.Crv)
+	}
+}
+
+func encode(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func decode(s string) ([]byte, error) { return base64.RawURLEncoding.DecodeString(s) }
@@ -0,0 +1,156 @@
+package jwks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestThumbprint_RFC7638Example(t *testing.T) {
+	// The example key from RFC 7638, section 3.1
+	k := Key{
+		Kty: "RSA",
+		E:   "AQAB",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	}
+	pub, err := k.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey() error = %v", err)
+	}
+
+	got, err := Thumbprint(pub)
+	if err != nil {
+		t.Fatalf("Thumbprint() error = %v", err)
+	}
+	if want := "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"; got != want {
+		t.Errorf("Thumbprint() = %s, want %s", got, want)
+	}
+}
+
+func TestKey_RoundTrip(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pub     any
+		wantAlg string
+	}{
+		{"RSA", &rsaKey.PublicKey, AlgRS256},
+		{"Ed25519", edPub, AlgEdDSA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewKey(tt.pub)
+			if err != nil {
+				t.Fatalf("NewKey() error = %v", err)
+			}
+			if k.Alg != tt.wantAlg || k.Kid == "" || k.Use != "sig" {
+				t.Errorf("NewKey() = %+v, want alg %s, a kid and use sig", k, tt.wantAlg)
+			}
+
+			// Through JSON, as a client would see it
+			data, _ := json.Marshal(Set{Keys: []Key{k}})
+			var set Set
+			if err := json.Unmarshal(data, &set); err != nil {
+				t.Fatal(err)
+			}
+			pub, err := set.Keys[0].PublicKey()
+			if err != nil {
+				t.Fatalf("PublicKey() error = %v", err)
+			}
+			if kid, _ := Thumbprint(pub); kid != k.Kid {
+				t.Errorf("decoded key has thumbprint %s, want %s", kid, k.Kid)
+			}
+		})
+	}
+}
+
+func TestNewKey_Unsupported(t *testing.T) {
+	if _, err := NewKey("not a key"); !errors.Is(err, ErrUnsupportedKey) {
+		t.Errorf("NewKey() error = %v, want %v", err, ErrUnsupportedKey)
+	}
+}
+
+func newTestServer(t *testing.T, set *Set) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(set)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &fetches
+}
+
+func TestClient_CachesAndRefreshesOnUnknownKid(t *testing.T) {
+	ctx := context.Background()
+	first, _, _ := ed25519.GenerateKey(rand.Reader)
+	second, _, _ := ed25519.GenerateKey(rand.Reader)
+	firstKey, _ := NewKey(first)
+	secondKey, _ := NewKey(second)
+
+	set := &Set{Keys: []Key{firstKey}}
+	srv, fetches := newTestServer(t, set)
+
+	now := time.Now()
+	c := NewClient(srv.URL, time.Hour)
+	c.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Key(ctx, firstKey.Kid); err != nil {
+			t.Fatalf("Key() error = %v", err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("expected 1 fetch for a cached key, got %d", n)
+	}
+
+	// Rotated: the new kid isn't fetched again until the refresh interval passes
+	set.Keys = append(set.Keys, secondKey)
+	if _, err := c.Key(ctx, secondKey.Kid); !errors.Is(err, ErrUnknownKey) {
+		t.Fatalf("Key() right after a fetch error = %v, want %v", err, ErrUnknownKey)
+	}
+
+	now = now.Add(minRefreshInterval)
+	if _, err := c.Key(ctx, secondKey.Kid); err != nil {
+		t.Fatalf("Key() after rotation error = %v", err)
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("expected 2 fetches, got %d", n)
+	}
+}
+
+func TestClient_KeepsCachedKeysWhenEndpointFails(t *testing.T) {
+	ctx := context.Background()
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := NewKey(pub)
+	srv, _ := newTestServer(t, &Set{Keys: []Key{key}})
+
+	now := time.Now()
+	c := NewClient(srv.URL, time.Minute)
+	c.now = func() time.Time { return now }
+	if _, err := c.Key(ctx, key.Kid); err != nil {
+		t.Fatalf("Key() error = %v", err)
+	}
+
+	srv.Close()
+	now = now.Add(time.Hour)
+	if _, err := c.Key(ctx, key.Kid); err != nil {
+		t.Errorf("Key() with the endpoint down error = %v, want the cached key", err)
+	}
+}
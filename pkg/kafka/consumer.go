@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"sync"
 
@@ -21,6 +22,9 @@ type ConsumerConfig struct {
 	GroupID  string
 	MinBytes int
 	MaxBytes int
+	// StartOffset is where a new consumer group starts reading:
+	// kafka.FirstOffset (the default) or kafka.LastOffset
+	StartOffset int64
 }
 
 // DefaultConsumerConfig returns sensible default configuration
@@ -38,6 +42,9 @@ func DefaultConsumerConfig(brokers []string, topic, groupID string) ConsumerConf
 type Consumer struct {
 	reader   *kafka.Reader
 	handlers map[string]EventHandler
+	fallback EventHandler
+	groupID  string
+	store    ProcessedStore
 	mu       sync.RWMutex
 	tracer   trace.Tracer
 }
@@ -46,13 +53,15 @@ type Consumer struct {
 func NewConsumer(cfg ConsumerConfig) *Consumer {
 	return &Consumer{
 		reader: kafka.NewReader(kafka.ReaderConfig{
-			Brokers:  cfg.Brokers,
-			Topic:    cfg.Topic,
-			GroupID:  cfg.GroupID,
-			MinBytes: cfg.MinBytes,
-			MaxBytes: cfg.MaxBytes,
+			Brokers:     cfg.Brokers,
+			Topic:       cfg.Topic,
+			GroupID:     cfg.GroupID,
+			MinBytes:    cfg.MinBytes,
+			MaxBytes:    cfg.MaxBytes,
+			StartOffset: cfg.StartOffset,
 		}),
 		handlers: make(map[string]EventHandler),
+		groupID:  cfg.GroupID,
 		tracer:   otel.Tracer("kafka-consumer"),
 	}
 }
@@ -64,6 +73,22 @@ func (c *Consumer) RegisterHandler(eventType string, handler EventHandler) {
 	c.handlers[eventType] = handler
 }
 
+// RegisterDefaultHandler registers a handler for event types that have no
+// handler of their own
+func (c *Consumer) RegisterDefaultHandler(handler EventHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.fallback = handler
+}
+
+// SetProcessedStore makes the consumer skip events its group has already
+// handled, see Deduplicate
+func (c *Consumer) SetProcessedStore(store ProcessedStore) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store = store
+}
+
 // Start begins consuming messages
 func (c *Consumer) Start(ctx context.Context) error {
 	for {
@@ -99,12 +124,23 @@ func (c *Consumer) processMessage(ctx context.Context, msg kafka.Message) error
 		log.Printf("error unmarshaling event: %v", err)
 		return err
 	}
+	if event.ID == "" {
+		// Events from producers that predate IDs are identified by position
+		event.ID = fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+	}
 
-	ctx, span := c.tracer.Start(ctx, "kafka.consume."+event.Type)
+	// Continue the producer's trace, so the handler's work shows up under
+	// the request that published the event
+	ctx = extractTraceContext(ctx, msg, event)
+	ctx, span := c.tracer.Start(ctx, "kafka.consume."+event.Type, trace.WithSpanKind(trace.SpanKindConsumer))
 	defer span.End()
 
 	c.mu.RLock()
 	handler, ok := c.handlers[event.Type]
+	if !ok && c.fallback != nil {
+		handler, ok = c.fallback, true
+	}
+	store := c.store
 	c.mu.RUnlock()
 
 	if !ok {
@@ -112,6 +148,9 @@ func (c *Consumer) processMessage(ctx context.Context, msg kafka.Message) error
 		return nil
 	}
 
+	if store != nil {
+		handler = Deduplicate(store, c.groupID, handler)
+	}
 	if err := handler(ctx, event); err != nil {
 		span.RecordError(err)
 		return err
@@ -0,0 +1,108 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DefaultProcessedRetention is how long processed events are remembered,
+// matching Kafka's default topic retention
+const DefaultProcessedRetention = 7 * 24 * time.Hour
+
+// ProcessedStore remembers which events a consumer group has handled, so a
+// redelivered event is usually not handled twice
+type ProcessedStore interface {
+	Processed(ctx context.Context, group, eventID string) (bool, error)
+	MarkProcessed(ctx context.Context, group, eventID string) error
+}
+
+// Deduplicate wraps handler so an event the group has already handled is
+// skipped. Delivery stays at-least-once: the event is marked only after the
+// handler succeeds, outside the handler's own writes, so a crash or failed
+// mark in between, or two consumers racing on a redelivery, handles it again
+// rather than losing it. Handlers must still tolerate the odd repeat; this
+// only keeps routine redeliveries from reaching them.
+func Deduplicate(store ProcessedStore, group string, handler EventHandler) EventHandler {
+	return func(ctx context.Context, event Event) error {
+		if event.ID == "" {
+			return handler(ctx, event)
+		}
+		done, err := store.Processed(ctx, group, event.ID)
+		if err != nil {
+			return fmt.Errorf("failed to check processed events: %w", err)
+		}
+		if done {
+			return nil
+		}
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+		if err := store.MarkProcessed(context.WithoutCancel(ctx), group, event.ID); err != nil {
+			return fmt.Errorf("failed to mark event processed: %w", err)
+		}
+		return nil
+	}
+}
+
+// PostgresProcessedStore keeps handled events in the processed_events table:
+//
+//	consumer_group VARCHAR(255), event_id VARCHAR(255), processed_at TIMESTAMPTZ,
+//	PRIMARY KEY (consumer_group, event_id)
+//
+// Services create it in their own migrations.
+type PostgresProcessedStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresProcessedStore creates a Postgres-backed processed event store
+func NewPostgresProcessedStore(db *pgxpool.Pool) *PostgresProcessedStore {
+	return &PostgresProcessedStore{db: db}
+}
+
+func (s *PostgresProcessedStore) Processed(ctx context.Context, group, eventID string) (bool, error) {
+	var exists bool
+	err := s.db.QueryRow(ctx, `
+		SELECT EXISTS (SELECT 1 FROM processed_events WHERE consumer_group = $1 AND event_id = $2)
+	`, group, eventID).Scan(&exists)
+	return exists, err
+}
+
+func (s *PostgresProcessedStore) MarkProcessed(ctx context.Context, group, eventID string) error {
+	_, err := s.db.Exec(ctx, `
+		INSERT INTO processed_events (consumer_group, event_id, processed_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (consumer_group, event_id) DO NOTHING
+	`, group, eventID)
+	return err
+}
+
+// Prune deletes events processed before the cutoff
+func (s *PostgresProcessedStore) Prune(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.Exec(ctx, `DELETE FROM processed_events WHERE processed_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// PruneProcessed deletes processed events older than retention every hour
+// until ctx is done. Retention should outlast the topics' own retention, or
+// a replay from the start of a topic handles old events again.
+func PruneProcessed(ctx context.Context, store *PostgresProcessedStore, retention time.Duration) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := store.Prune(ctx, time.Now().Add(-retention)); err != nil {
+				log.Printf("failed to prune processed events: %v", err)
+			}
+		}
+	}
+}
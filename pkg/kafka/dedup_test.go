@@ -0,0 +1,64 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type memoryProcessedStore map[string]bool
+
+func (s memoryProcessedStore) Processed(_ context.Context, group, eventID string) (bool, error) {
+	return s[group+"/"+eventID], nil
+}
+
+func (s memoryProcessedStore) MarkProcessed(_ context.Context, group, eventID string) error {
+	s[group+"/"+eventID] = true
+	return nil
+}
+
+func TestDeduplicate(t *testing.T) {
+	store := memoryProcessedStore{}
+	calls := 0
+	fail := false
+	handler := Deduplicate(store, "notification-service", func(ctx context.Context, event Event) error {
+		calls++
+		if fail {
+			return errors.New("handler failed")
+		}
+		return nil
+	})
+	ctx := context.Background()
+
+	event := Event{ID: "e1", Type: "session.ended"}
+	if err := handler(ctx, event); err != nil {
+		t.Fatalf("first delivery: %v", err)
+	}
+	if err := handler(ctx, event); err != nil {
+		t.Fatalf("redelivery: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("redelivered event handled %d times, want 1", calls)
+	}
+
+	other := Deduplicate(store, "wallet-service", func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+	if err := other(ctx, event); err != nil || calls != 2 {
+		t.Errorf("another group should handle the event: calls = %d, err = %v", calls, err)
+	}
+
+	fail = true
+	failed := Event{ID: "e2", Type: "session.ended"}
+	if err := handler(ctx, failed); err == nil {
+		t.Fatal("expected handler error")
+	}
+	if store["notification-service/e2"] {
+		t.Error("failed event should not be marked processed")
+	}
+	fail = false
+	if err := handler(ctx, failed); err != nil || calls != 4 {
+		t.Errorf("failed event should be retried: calls = %d, err = %v", calls, err)
+	}
+}
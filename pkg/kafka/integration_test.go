@@ -0,0 +1,52 @@
+//go:build integration
+
+package kafka_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/testenv"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+// TestPublishConsume round-trips an event through a real broker
+func TestPublishConsume(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	brokers := testenv.KafkaBrokers(t)
+	topic := testenv.KafkaTopic(t, "events")
+
+	publisher := kafka.NewPublisher(kafka.DefaultPublisherConfig(brokers, topic))
+	defer publisher.Close()
+	if err := publisher.Publish(ctx, kafka.Event{
+		Type:    "session.started",
+		Payload: map[string]interface{}{"session_id": "abc"},
+	}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	received := make(chan kafka.Event, 1)
+	consumer := kafka.NewConsumer(kafka.DefaultConsumerConfig(brokers, topic, "integration-test"))
+	consumer.RegisterHandler("session.started", func(ctx context.Context, event kafka.Event) error {
+		received <- event
+		return nil
+	})
+	go consumer.Start(ctx)
+
+	select {
+	case event := <-received:
+		if event.ID == "" || event.Payload["session_id"] != "abc" {
+			t.Errorf("received %+v", event)
+		}
+	case <-ctx.Done():
+		t.Fatal("event not consumed")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/segmentio/kafka-go"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
@@ -12,6 +13,7 @@ import (
 
 // Event represents a domain event to be published
 type Event struct {
+	ID        string                 `json:"id,omitempty"`
 	Type      string                 `json:"type"`
 	Payload   map[string]interface{} `json:"payload"`
 	Timestamp time.Time              `json:"timestamp"`
@@ -62,10 +64,13 @@ func NewPublisher(cfg PublisherConfig) *Publisher {
 
 // Publish sends an event to Kafka
 func (p *Publisher) Publish(ctx context.Context, event Event) error {
-	ctx, span := p.tracer.Start(ctx, "kafka.publish."+event.Type)
+	ctx, span := p.tracer.Start(ctx, "kafka.publish."+event.Type, trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
-	// Set timestamp if not provided
+	// Set ID and timestamp if not provided
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now().UTC()
 	}
@@ -97,6 +102,7 @@ func (p *Publisher) Publish(ctx context.Context, event Event) error {
 			Value: []byte(event.TraceID),
 		})
 	}
+	injectTraceContext(ctx, &msg)
 
 	if err := p.writer.WriteMessages(ctx, msg); err != nil {
 		span.RecordError(err)
@@ -108,11 +114,14 @@ func (p *Publisher) Publish(ctx context.Context, event Event) error {
 
 // PublishBatch sends multiple events to Kafka
 func (p *Publisher) PublishBatch(ctx context.Context, events []Event) error {
-	ctx, span := p.tracer.Start(ctx, "kafka.publish.batch")
+	ctx, span := p.tracer.Start(ctx, "kafka.publish.batch", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	messages := make([]kafka.Message, len(events))
 	for i, event := range events {
+		if event.ID == "" {
+			event.ID = uuid.NewString()
+		}
 		if event.Timestamp.IsZero() {
 			event.Timestamp = time.Now().UTC()
 		}
@@ -136,6 +145,7 @@ func (p *Publisher) PublishBatch(ctx context.Context, events []Event) error {
 				{Key: "timestamp", Value: []byte(event.Timestamp.Format(time.RFC3339))},
 			},
 		}
+		injectTraceContext(ctx, &messages[i])
 	}
 
 	if err := p.writer.WriteMessages(ctx, messages...); err != nil {
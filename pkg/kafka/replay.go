@@ -0,0 +1,296 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ReplayUsage describes the arguments ParseReplayArgs accepts.
+const ReplayUsage = "replay -topic <topic> [-partition n] [-from-offset n] [-to-offset n] [-from time] [-to time] [-type t1,t2] [-dry-run]"
+
+var ErrReplayUsage = errors.New("usage: " + ReplayUsage)
+
+// replayIdle is how long a replay waits for the next message before
+// deciding the partition has nothing more in range
+const replayIdle = 10 * time.Second
+
+// ReplayConfig selects the events to replay. Offsets and times bound the
+// range on every partition replayed; ToOffset and To are exclusive.
+type ReplayConfig struct {
+	Brokers    []string
+	Topic      string
+	Partition  int   // -1 replays every partition
+	FromOffset int64 // -1 starts at the oldest retained message
+	ToOffset   int64 // -1 stops at the end of the partition as of the start
+	From       time.Time
+	To         time.Time
+	Types      []string // empty replays every type with a handler
+	DryRun     bool
+}
+
+// ReplayResult counts what a replay did
+type ReplayResult struct {
+	Read    int `json:"read"`
+	Handled int `json:"handled"`
+	Skipped int `json:"skipped"`
+	Failed  int `json:"failed"`
+}
+
+// ParseReplayArgs parses the replay subcommand of a server binary, e.g.
+// "notification-service replay -topic auth.events -type user.synced".
+func ParseReplayArgs(args []string) (ReplayConfig, error) {
+	cfg := ReplayConfig{Partition: -1, FromOffset: -1, ToOffset: -1}
+	var from, to, types string
+
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.Topic, "topic", "", "")
+	fs.IntVar(&cfg.Partition, "partition", -1, "")
+	fs.Int64Var(&cfg.FromOffset, "from-offset", -1, "")
+	fs.Int64Var(&cfg.ToOffset, "to-offset", -1, "")
+	fs.StringVar(&from, "from", "", "")
+	fs.StringVar(&to, "to", "", "")
+	fs.StringVar(&types, "type", "", "")
+	fs.BoolVar(&cfg.DryRun, "dry-run", false, "")
+	if err := fs.Parse(args); err != nil {
+		return ReplayConfig{}, fmt.Errorf("%v: %w", err, ErrReplayUsage)
+	}
+	if cfg.Topic == "" || fs.NArg() > 0 {
+		return ReplayConfig{}, ErrReplayUsage
+	}
+
+	var err error
+	if cfg.From, err = parseReplayTime("from", from); err != nil {
+		return ReplayConfig{}, err
+	}
+	if cfg.To, err = parseReplayTime("to", to); err != nil {
+		return ReplayConfig{}, err
+	}
+	if !cfg.From.IsZero() && !cfg.To.IsZero() && !cfg.From.Before(cfg.To) {
+		return ReplayConfig{}, fmt.Errorf("-from must be before -to: %w", ErrReplayUsage)
+	}
+	if cfg.FromOffset >= 0 && cfg.ToOffset >= 0 && cfg.FromOffset >= cfg.ToOffset {
+		return ReplayConfig{}, fmt.Errorf("-from-offset must be below -to-offset: %w", ErrReplayUsage)
+	}
+	for _, t := range strings.Split(types, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			cfg.Types = append(cfg.Types, t)
+		}
+	}
+	return cfg, nil
+}
+
+func parseReplayTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("-%s must be an RFC 3339 time: %w", name, ErrReplayUsage)
+	}
+	return t, nil
+}
+
+// Replayer reads a range of a topic without a consumer group and passes the
+// events to its handlers again, to rebuild read models or redo work a
+// broken handler got wrong. Handlers are called directly, so events are
+// handled even if a consumer group has already processed them.
+type Replayer struct {
+	cfg      ReplayConfig
+	handlers map[string]EventHandler
+	mu       sync.RWMutex
+}
+
+// NewReplayer creates a replayer for the configured range
+func NewReplayer(cfg ReplayConfig) *Replayer {
+	return &Replayer{cfg: cfg, handlers: make(map[string]EventHandler)}
+}
+
+// RegisterHandler registers a handler for a specific event type, as on Consumer
+func (r *Replayer) RegisterHandler(eventType string, handler EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = handler
+}
+
+// Run replays the range, writing one line per event to out in dry-run mode
+// and a summary at the end. A failing handler is reported and the replay
+// carries on.
+func (r *Replayer) Run(ctx context.Context, out io.Writer) (ReplayResult, error) {
+	var result ReplayResult
+	if len(r.cfg.Brokers) == 0 {
+		return result, errors.New("no Kafka brokers configured")
+	}
+
+	partitions, err := r.partitions()
+	if err != nil {
+		return result, err
+	}
+	for _, partition := range partitions {
+		if err := r.replayPartition(ctx, partition, out, &result); err != nil {
+			return result, fmt.Errorf("failed to replay partition %d: %w", partition, err)
+		}
+	}
+
+	mode := "replayed"
+	if r.cfg.DryRun {
+		mode = "dry run"
+	}
+	fmt.Fprintf(out, "%s %s: %d read, %d handled, %d skipped, %d failed\n",
+		mode, r.cfg.Topic, result.Read, result.Handled, result.Skipped, result.Failed)
+	return result, nil
+}
+
+func (r *Replayer) partitions() ([]int, error) {
+	if r.cfg.Partition >= 0 {
+		return []int{r.cfg.Partition}, nil
+	}
+	conn, err := kafka.Dial("tcp", r.cfg.Brokers[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Kafka: %w", err)
+	}
+	defer conn.Close()
+
+	found, err := conn.ReadPartitions(r.cfg.Topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read partitions of %s: %w", r.cfg.Topic, err)
+	}
+	ids := make([]int, len(found))
+	for i, p := range found {
+		ids[i] = p.ID
+	}
+	return ids, nil
+}
+
+// bounds resolves the configured range to [start, end) offsets of a partition
+func (r *Replayer) bounds(ctx context.Context, partition int) (int64, int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", r.cfg.Brokers[0], r.cfg.Topic, partition)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to connect to partition leader: %w", err)
+	}
+	defer conn.Close()
+
+	start, end, err := conn.ReadOffsets()
+	if err != nil {
+		return 0, 0, err
+	}
+	if r.cfg.FromOffset > start {
+		start = r.cfg.FromOffset
+	}
+	if r.cfg.ToOffset >= 0 && r.cfg.ToOffset < end {
+		end = r.cfg.ToOffset
+	}
+	if !r.cfg.From.IsZero() {
+		at, err := conn.ReadOffset(r.cfg.From)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to find offset for %s: %w", r.cfg.From.Format(time.RFC3339), err)
+		}
+		if at > start {
+			start = at
+		}
+	}
+	return start, end, nil
+}
+
+func (r *Replayer) replayPartition(ctx context.Context, partition int, out io.Writer, result *ReplayResult) error {
+	start, end, err := r.bounds(ctx, partition)
+	if err != nil {
+		return err
+	}
+	if start >= end {
+		return nil
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   r.cfg.Brokers,
+		Topic:     r.cfg.Topic,
+		Partition: partition,
+		MinBytes:  1,
+		MaxBytes:  10e6,
+	})
+	defer reader.Close()
+	if err := reader.SetOffset(start); err != nil {
+		return err
+	}
+
+	for {
+		readCtx, cancel := context.WithTimeout(ctx, replayIdle)
+		msg, err := reader.ReadMessage(readCtx)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				return nil // Nothing more in range, e.g. a gap at the end of the partition
+			}
+			return err
+		}
+		if msg.Offset >= end || (!r.cfg.To.IsZero() && !msg.Time.Before(r.cfg.To)) {
+			return nil
+		}
+
+		result.Read++
+		r.replayMessage(ctx, msg, out, result)
+		if msg.Offset+1 >= end {
+			return nil
+		}
+	}
+}
+
+func (r *Replayer) replayMessage(ctx context.Context, msg kafka.Message, out io.Writer, result *ReplayResult) {
+	var event Event
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		fmt.Fprintf(out, "%d/%d: skipped, not an event: %v\n", msg.Partition, msg.Offset, err)
+		result.Skipped++
+		return
+	}
+	if event.ID == "" {
+		event.ID = fmt.Sprintf("%s/%d/%d", msg.Topic, msg.Partition, msg.Offset)
+	}
+
+	handler, ok := r.handler(event.Type)
+	if !ok {
+		result.Skipped++
+		return
+	}
+	if r.cfg.DryRun {
+		fmt.Fprintf(out, "%d/%d: would replay %s %s (%s)\n",
+			msg.Partition, msg.Offset, event.Type, event.ID, event.Timestamp.Format(time.RFC3339))
+		result.Handled++
+		return
+	}
+
+	if err := handler(extractTraceContext(ctx, msg, event), event); err != nil {
+		fmt.Fprintf(out, "%d/%d: %s %s failed: %v\n", msg.Partition, msg.Offset, event.Type, event.ID, err)
+		result.Failed++
+		return
+	}
+	result.Handled++
+}
+
+// handler finds the handler for an event type the replay includes
+func (r *Replayer) handler(eventType string) (EventHandler, bool) {
+	if len(r.cfg.Types) > 0 {
+		included := false
+		for _, t := range r.cfg.Types {
+			if t == eventType {
+				included = true
+				break
+			}
+		}
+		if !included {
+			return nil, false
+		}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handler, ok := r.handlers[eventType]
+	return handler, ok
+}
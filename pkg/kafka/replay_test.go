@@ -0,0 +1,83 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseReplayArgs(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    ReplayConfig
+		wantErr bool
+	}{
+		{
+			name: "topic only",
+			args: []string{"-topic", "auth.events"},
+			want: ReplayConfig{Topic: "auth.events", Partition: -1, FromOffset: -1, ToOffset: -1},
+		},
+		{
+			name: "everything",
+			args: []string{"-topic", "auth.events", "-partition", "2", "-from-offset", "10", "-to-offset", "20",
+				"-from", "2026-03-01T00:00:00Z", "-to", "2026-03-02T00:00:00Z", "-type", "user.synced, user.registered", "-dry-run"},
+			want: ReplayConfig{
+				Topic: "auth.events", Partition: 2, FromOffset: 10, ToOffset: 20, From: from, To: to,
+				Types: []string{"user.synced", "user.registered"}, DryRun: true,
+			},
+		},
+		{name: "no topic", args: []string{"-dry-run"}, wantErr: true},
+		{name: "unknown flag", args: []string{"-topic", "t", "-since", "1h"}, wantErr: true},
+		{name: "stray argument", args: []string{"-topic", "t", "now"}, wantErr: true},
+		{name: "bad time", args: []string{"-topic", "t", "-from", "yesterday"}, wantErr: true},
+		{name: "times reversed", args: []string{"-topic", "t", "-from", "2026-03-02T00:00:00Z", "-to", "2026-03-01T00:00:00Z"}, wantErr: true},
+		{name: "offsets reversed", args: []string{"-topic", "t", "-from-offset", "5", "-to-offset", "5"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseReplayArgs(tt.args)
+			if tt.wantErr {
+				if !errors.Is(err, ErrReplayUsage) {
+					t.Errorf("ParseReplayArgs() error = %v, want ErrReplayUsage", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseReplayArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReplayArgs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplayer_Handler(t *testing.T) {
+	noop := func(ctx context.Context, event Event) error { return nil }
+	r := NewReplayer(ReplayConfig{Types: []string{"user.synced"}})
+	r.RegisterHandler("user.synced", noop)
+	r.RegisterHandler("user.registered", noop)
+
+	if _, ok := r.handler("user.synced"); !ok {
+		t.Error("user.synced should be replayed")
+	}
+	if _, ok := r.handler("user.registered"); ok {
+		t.Error("user.registered is not in -type and should be skipped")
+	}
+
+	all := NewReplayer(ReplayConfig{})
+	all.RegisterHandler("user.registered", noop)
+	if _, ok := all.handler("user.registered"); !ok {
+		t.Error("without -type every handled type should be replayed")
+	}
+	if _, ok := all.handler("user.deleted"); ok {
+		t.Error("types without a handler should be skipped")
+	}
+}
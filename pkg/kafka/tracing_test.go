@@ -0,0 +1,82 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func TestTraceContextRoundTrip(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	sc := testSpanContext(t)
+
+	msg := kafka.Message{Headers: []kafka.Header{{Key: "event_type", Value: []byte("session.ended")}}}
+	injectTraceContext(trace.ContextWithSpanContext(context.Background(), sc), &msg)
+
+	if got := (headerCarrier{headers: &msg.Headers}).Get("traceparent"); got == "" {
+		t.Fatal("traceparent header not written")
+	}
+
+	got := trace.SpanContextFromContext(extractTraceContext(context.Background(), msg, Event{}))
+	if got.TraceID() != sc.TraceID() || got.SpanID() != sc.SpanID() {
+		t.Errorf("extracted %s/%s, want %s/%s", got.TraceID(), got.SpanID(), sc.TraceID(), sc.SpanID())
+	}
+	if !got.IsRemote() {
+		t.Error("extracted span context should be remote")
+	}
+}
+
+func TestExtractTraceContextFallsBackToEventBody(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	sc := testSpanContext(t)
+
+	tests := []struct {
+		name      string
+		event     Event
+		wantValid bool
+	}{
+		{"ids in body", Event{TraceID: sc.TraceID().String(), SpanID: sc.SpanID().String()}, true},
+		{"no ids", Event{}, false},
+		{"trace id only", Event{TraceID: sc.TraceID().String()}, false},
+		{"malformed", Event{TraceID: "not-hex", SpanID: sc.SpanID().String()}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := extractTraceContext(context.Background(), kafka.Message{}, tt.event)
+			got := trace.SpanContextFromContext(ctx)
+			if got.IsValid() != tt.wantValid {
+				t.Fatalf("valid = %v, want %v", got.IsValid(), tt.wantValid)
+			}
+			if tt.wantValid && got.TraceID() != sc.TraceID() {
+				t.Errorf("trace ID = %s, want %s", got.TraceID(), sc.TraceID())
+			}
+		})
+	}
+}
+
+func TestHeaderCarrierSetReplaces(t *testing.T) {
+	headers := []kafka.Header{{Key: "traceparent", Value: []byte("old")}}
+	c := headerCarrier{headers: &headers}
+
+	c.Set("traceparent", "new")
+
+	if len(headers) != 1 || c.Get("traceparent") != "new" {
+		t.Errorf("headers = %v, want a single replaced traceparent", headers)
+	}
+}
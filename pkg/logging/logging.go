@@ -0,0 +1,203 @@
+// Package logging is the structured logger shared by every service. It is
+// backed by log/slog, writes JSON by default and adds the correlation IDs of
+// the logging context (trace, span, request and user) to each record.
+//
+// Services alias Logger and Field in their ports package, so application and
+// adapter code keeps depending on ports only.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Field is a key-value pair attached to a log record.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key, value string) Field                 { return Field{Key: key, Value: value} }
+func Int(key string, value int) Field                { return Field{Key: key, Value: value} }
+func Bool(key string, value bool) Field              { return Field{Key: key, Value: value} }
+func Duration(key string, value time.Duration) Field { return Field{Key: key, Value: value} }
+func Err(err error) Field                            { return Field{Key: "error", Value: err} }
+func Any(key string, value interface{}) Field        { return Field{Key: key, Value: value} }
+
+// Logger is the logging port the services program against.
+type Logger interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+	// WithFields returns a logger that adds fields to every record.
+	WithFields(fields ...Field) Logger
+	// WithContext returns a logger whose records carry the correlation IDs
+	// found in ctx.
+	WithContext(ctx context.Context) Logger
+}
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+var (
+	ErrInvalidLevel  = errors.New("log level must be debug, info, warn or error")
+	ErrInvalidFormat = errors.New("log format must be json or text")
+)
+
+// Config selects the level and output format of a logger.
+type Config struct {
+	// Service is added to every record as the service field
+	Service string
+	Level   slog.Level
+	Format  string
+	// Output defaults to stdout
+	Output io.Writer
+}
+
+// ConfigFromEnv reads LOG_LEVEL (default info) and LOG_FORMAT (default json).
+func ConfigFromEnv(service string) (Config, error) {
+	level, err := ParseLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		return Config{}, err
+	}
+
+	format := strings.ToLower(getEnv("LOG_FORMAT", FormatJSON))
+	if format != FormatJSON && format != FormatText {
+		return Config{}, fmt.Errorf("invalid LOG_FORMAT %q: %w", format, ErrInvalidFormat)
+	}
+
+	return Config{Service: service, Level: level, Format: format}, nil
+}
+
+// ParseLevel parses debug, info, warn or error, case-insensitively.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("invalid LOG_LEVEL %q: %w", s, ErrInvalidLevel)
+	}
+}
+
+// SlogLogger implements Logger on top of a slog.Logger.
+type SlogLogger struct {
+	logger *slog.Logger
+	// level is shared with every derived logger, so SetLevel applies to
+	// all of them. Nil for loggers built with NewWithHandler.
+	level *slog.LevelVar
+	// ctx is handed to the handler with every record so correlation IDs
+	// can be read from it
+	ctx context.Context
+}
+
+// New creates a logger from cfg.
+func New(cfg Config) *SlogLogger {
+	out := cfg.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	level := new(slog.LevelVar)
+	level.Set(cfg.Level)
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if cfg.Format == FormatText {
+		handler = slog.NewTextHandler(out, opts)
+	} else {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+
+	logger := NewWithHandler(handler)
+	logger.level = level
+	if cfg.Service != "" {
+		logger.logger = logger.logger.With(slog.String("service", cfg.Service))
+	}
+	return logger
+}
+
+// NewWithHandler creates a logger that writes to handler, adding
+// correlation IDs to each record.
+func NewWithHandler(handler slog.Handler) *SlogLogger {
+	return &SlogLogger{
+		logger: slog.New(contextHandler{handler}),
+		ctx:    context.Background(),
+	}
+}
+
+// Nop returns a logger that discards everything, for tests.
+func Nop() *SlogLogger {
+	return NewWithHandler(slog.DiscardHandler)
+}
+
+func (l *SlogLogger) Debug(msg string, fields ...Field) { l.log(slog.LevelDebug, msg, fields) }
+func (l *SlogLogger) Info(msg string, fields ...Field)  { l.log(slog.LevelInfo, msg, fields) }
+func (l *SlogLogger) Warn(msg string, fields ...Field)  { l.log(slog.LevelWarn, msg, fields) }
+func (l *SlogLogger) Error(msg string, fields ...Field) { l.log(slog.LevelError, msg, fields) }
+
+func (l *SlogLogger) WithFields(fields ...Field) Logger {
+	return &SlogLogger{
+		logger: slog.New(l.logger.Handler().WithAttrs(attrs(fields))),
+		level:  l.level,
+		ctx:    l.ctx,
+	}
+}
+
+func (l *SlogLogger) WithContext(ctx context.Context) Logger {
+	return &SlogLogger{logger: l.logger, level: l.level, ctx: ctx}
+}
+
+// SetLevel changes the minimum level of the logger and every logger
+// derived from it.
+func (l *SlogLogger) SetLevel(level slog.Level) {
+	if l.level != nil {
+		l.level.Set(level)
+	}
+}
+
+// ReloadLevel applies the current LOG_LEVEL, for use as a config reload
+// hook. An invalid level is returned and the current one kept.
+func (l *SlogLogger) ReloadLevel() error {
+	level, err := ParseLevel(getEnv("LOG_LEVEL", "info"))
+	if err != nil {
+		return err
+	}
+	l.SetLevel(level)
+	return nil
+}
+
+func (l *SlogLogger) log(level slog.Level, msg string, fields []Field) {
+	if !l.logger.Enabled(l.ctx, level) {
+		return
+	}
+	l.logger.LogAttrs(l.ctx, level, msg, attrs(fields)...)
+}
+
+func attrs(fields []Field) []slog.Attr {
+	out := make([]slog.Attr, len(fields))
+	for i, f := range fields {
+		out[i] = slog.Any(f.Key, f.Value)
+	}
+	return out
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,209 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	return records
+}
+
+func TestSlogLogger_JSONFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Service: "wallet-service", Level: slog.LevelInfo, Output: &buf})
+
+	logger.WithFields(String("wallet_id", "w1")).Error("top-up failed", Err(errors.New("declined")), Int("attempt", 2))
+	logger.Debug("filtered out")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+	rec := records[0]
+	want := map[string]interface{}{
+		"level":     "ERROR",
+		"msg":       "top-up failed",
+		"service":   "wallet-service",
+		"wallet_id": "w1",
+		"error":     "declined",
+		"attempt":   float64(2),
+	}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("%s = %v, want %v", k, rec[k], v)
+		}
+	}
+}
+
+func TestSlogLogger_ReloadLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Level: slog.LevelInfo, Output: &buf})
+	derived := logger.WithFields(String("component", "test"))
+
+	derived.Debug("filtered out")
+	t.Setenv("LOG_LEVEL", "debug")
+	if err := logger.ReloadLevel(); err != nil {
+		t.Fatalf("ReloadLevel() error = %v", err)
+	}
+	derived.Debug("now logged")
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	if err := logger.ReloadLevel(); !errors.Is(err, ErrInvalidLevel) {
+		t.Errorf("ReloadLevel() error = %v, want ErrInvalidLevel", err)
+	}
+	derived.Debug("still logged")
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 || records[0]["msg"] != "now logged" {
+		t.Errorf("got %v, want the two records logged after the reload", records)
+	}
+}
+
+func TestSlogLogger_TraceIDFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Output: &buf})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	logger.WithContext(ctx).Info("with trace")
+	logger.Info("without trace")
+
+	records := decodeLines(t, &buf)
+	if records[0]["trace_id"] != sc.TraceID().String() || records[0]["span_id"] != sc.SpanID().String() {
+		t.Errorf("trace fields missing: %v", records[0])
+	}
+	if _, ok := records[1]["trace_id"]; ok {
+		t.Errorf("unexpected trace_id without span: %v", records[1])
+	}
+}
+
+func TestSlogLogger_WithContextCorrelation(t *testing.T) {
+	var buf bytes.Buffer
+	// A service-level logger, as held by application services
+	logger := New(Config{Output: &buf}).WithFields(String("component", "wallet"))
+
+	var ctx context.Context
+	handler := Correlate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set(RequestIDHeader, "req-9")
+	req.Header.Set(UserIDHeader, "user-9")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	logger.WithContext(ctx).Info("balance updated")
+	logger.Info("background job")
+
+	records := decodeLines(t, &buf)
+	if records[0]["request_id"] != "req-9" || records[0]["user_id"] != "user-9" || records[0]["component"] != "wallet" {
+		t.Errorf("correlation fields missing: %v", records[0])
+	}
+	if _, ok := records[1]["request_id"]; ok {
+		t.Errorf("unexpected request_id without context: %v", records[1])
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name      string
+		level     string
+		format    string
+		wantLevel slog.Level
+		wantErr   error
+	}{
+		{"defaults", "", "", slog.LevelInfo, nil},
+		{"debug text", "DEBUG", "text", slog.LevelDebug, nil},
+		{"warning alias", "warning", "json", slog.LevelWarn, nil},
+		{"bad level", "verbose", "", 0, ErrInvalidLevel},
+		{"bad format", "info", "xml", 0, ErrInvalidFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LOG_LEVEL", tt.level)
+			t.Setenv("LOG_FORMAT", tt.format)
+
+			cfg, err := ConfigFromEnv("svc")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConfigFromEnv() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Level != tt.wantLevel {
+				t.Errorf("Level = %v, want %v", cfg.Level, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestRequestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(Config{Output: &buf})
+
+	handler := RequestLogger(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context(), Nop()).Info("handling")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallet", nil)
+	req.Header.Set(RequestIDHeader, "req-123")
+	req.Header.Set(UserIDHeader, "user-1")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "req-123" {
+		t.Errorf("response %s = %q", RequestIDHeader, got)
+	}
+
+	records := decodeLines(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	for _, rec := range records {
+		if rec["request_id"] != "req-123" || rec["user_id"] != "user-1" || rec["path"] != "/api/v1/wallet" {
+			t.Errorf("request fields missing: %v", rec)
+		}
+	}
+	if records[1]["level"] != "WARN" || records[1]["status"] != float64(http.StatusNotFound) {
+		t.Errorf("unexpected completion record: %v", records[1])
+	}
+}
+
+func TestRequestLogger_AssignsRequestID(t *testing.T) {
+	var seen string
+	handler := RequestLogger(Nop())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Get(RequestIDHeader)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if seen == "" || w.Header().Get(RequestIDHeader) != seen {
+		t.Errorf("request ID not propagated: request %q, response %q", seen, w.Header().Get(RequestIDHeader))
+	}
+}
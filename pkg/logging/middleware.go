@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"net/http"
+	"time"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the request ID. chi's RequestID middleware adopts
+// an incoming value, so IDs assigned here match those in error responses.
+const RequestIDHeader = "X-Request-ID"
+
+// UserIDHeader carries the ID of the user the gateway authenticated.
+const UserIDHeader = "X-User-ID"
+
+// Correlate stores the request ID and authenticated user ID in the request
+// context so every record logged through Logger.WithContext carries them.
+// Requests without an X-Request-ID are assigned one.
+func Correlate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if requestID == "" {
+			requestID = uuid.NewString()
+			r.Header.Set(RequestIDHeader, requestID)
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := WithRequestID(r.Context(), requestID)
+		if userID := r.Header.Get(UserIDHeader); userID != "" {
+			ctx = WithUserID(ctx, userID)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestLogger correlates the request (see Correlate), logs one record per
+// request and stores a logger tagged with the method and path in the request
+// context.
+//
+// Install it inside the tracing middleware so records carry the trace ID.
+func RequestLogger(logger Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return Correlate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			reqLogger := logger.WithFields(
+				String("method", r.Method),
+				String("path", r.URL.Path),
+			).WithContext(r.Context())
+
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(NewContext(r.Context(), reqLogger)))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			fields := []Field{
+				Int("status", status),
+				Int("bytes", ww.BytesWritten()),
+				Duration("duration", time.Since(start)),
+			}
+
+			switch {
+			case status >= 500:
+				reqLogger.Error("request completed", fields...)
+			case status >= 400:
+				reqLogger.Warn("request completed", fields...)
+			case r.URL.Path == "/health" || r.URL.Path == "/ready":
+				reqLogger.Debug("request completed", fields...)
+			default:
+				reqLogger.Info("request completed", fields...)
+			}
+		}))
+	}
+}
@@ -26,6 +26,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap exposes the underlying writer to http.ResponseController so
+// flushing and deadline control still work for streaming handlers
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // Tracing returns HTTP middleware that adds OpenTelemetry tracing
 func Tracing(serviceName string) func(http.Handler) http.Handler {
 	tracer := otel.Tracer(serviceName)
@@ -0,0 +1,97 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Usage describes the arguments RunCommand accepts.
+const Usage = "migrate up | down [steps] | version | force <version>"
+
+var ErrUsage = errors.New("usage: " + Usage)
+
+// command is a parsed migrate subcommand.
+type command struct {
+	name string
+	n    uint64
+}
+
+func parseCommand(args []string) (command, error) {
+	if len(args) == 0 {
+		return command{}, ErrUsage
+	}
+
+	cmd := command{name: args[0]}
+	switch cmd.name {
+	case "up", "version":
+		if len(args) != 1 {
+			return command{}, ErrUsage
+		}
+	case "down":
+		cmd.n = 1
+		if len(args) == 2 {
+			steps, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil || steps == 0 {
+				return command{}, fmt.Errorf("invalid steps %q: %w", args[1], ErrUsage)
+			}
+			cmd.n = steps
+		} else if len(args) > 2 {
+			return command{}, ErrUsage
+		}
+	case "force":
+		if len(args) != 2 {
+			return command{}, ErrUsage
+		}
+		version, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return command{}, fmt.Errorf("invalid version %q: %w", args[1], ErrUsage)
+		}
+		cmd.n = version
+	default:
+		return command{}, ErrUsage
+	}
+	return cmd, nil
+}
+
+// RunCommand runs the migrate subcommand of a server binary, e.g.
+// "wallet-service migrate up", and reports the result to out.
+func RunCommand(ctx context.Context, m *Migrator, args []string, out io.Writer) error {
+	cmd, err := parseCommand(args)
+	if err != nil {
+		return err
+	}
+
+	switch cmd.name {
+	case "up":
+		applied, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migration(s)\n", applied)
+	case "down":
+		reverted, err := m.Down(ctx, int(cmd.n))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "reverted %d migration(s)\n", reverted)
+	case "force":
+		if err := m.Force(ctx, cmd.n); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "forced version %d\n", cmd.n)
+	case "version":
+		version, dirty, err := m.Version(ctx)
+		if err != nil {
+			return err
+		}
+		state := "clean"
+		if dirty {
+			state = "dirty"
+		}
+		fmt.Fprintf(out, "version %d of %d (%s)\n", version, m.Latest(), state)
+	}
+	return nil
+}
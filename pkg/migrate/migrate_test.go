@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_add_index.up.sql":      {Data: []byte("CREATE INDEX i ON t(c);")},
+		"002_add_index.down.sql":    {Data: []byte("DROP INDEX i;")},
+		"001_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c INT);")},
+		"001_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"010_seed.up.sql":           {Data: []byte("INSERT INTO t VALUES (1);")},
+		"migrations.go":             {Data: []byte("package migrations")},
+		"README.md":                 {Data: []byte("notes")},
+	}
+
+	got, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	wantVersions := []uint64{1, 2, 10}
+	if len(got) != len(wantVersions) {
+		t.Fatalf("got %d migrations, want %d", len(got), len(wantVersions))
+	}
+	for i, v := range wantVersions {
+		if got[i].Version != v {
+			t.Errorf("migration %d version = %d, want %d", i, got[i].Version, v)
+		}
+	}
+	if got[0].Name != "create_table" || got[0].Down != "DROP TABLE t;" {
+		t.Errorf("unexpected first migration: %+v", got[0])
+	}
+	if got[2].Down != "" {
+		t.Errorf("010_seed should have no down migration, got %q", got[2].Down)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		wantErr error
+	}{
+		{
+			"duplicate version",
+			fstest.MapFS{
+				"001_a.up.sql": {Data: []byte("SELECT 1;")},
+				"001_b.up.sql": {Data: []byte("SELECT 2;")},
+			},
+			ErrDuplicateVersion,
+		},
+		{
+			"down without up",
+			fstest.MapFS{
+				"001_a.down.sql": {Data: []byte("SELECT 1;")},
+			},
+			ErrMissingUp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.fsys); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    command
+		wantErr bool
+	}{
+		{[]string{"up"}, command{name: "up"}, false},
+		{[]string{"version"}, command{name: "version"}, false},
+		{[]string{"down"}, command{name: "down", n: 1}, false},
+		{[]string{"down", "3"}, command{name: "down", n: 3}, false},
+		{[]string{"force", "0"}, command{name: "force", n: 0}, false},
+		{[]string{"force", "5"}, command{name: "force", n: 5}, false},
+		{nil, command{}, true},
+		{[]string{"sideways"}, command{}, true},
+		{[]string{"up", "2"}, command{}, true},
+		{[]string{"down", "0"}, command{}, true},
+		{[]string{"down", "x"}, command{}, true},
+		{[]string{"force"}, command{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseCommand(tt.args)
+		if tt.wantErr {
+			if !errors.Is(err, ErrUsage) {
+				t.Errorf("parseCommand(%v) error = %v, want %v", tt.args, err, ErrUsage)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseCommand(%v) = %+v, %v; want %+v", tt.args, got, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Package notify is a client for the notification service's gRPC API, for
+// services that need a notification sent before they respond rather than
+// whenever Kafka delivers their event.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/client"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"google.golang.org/grpc"
+)
+
+// Channels the notification service delivers on
+const (
+	ChannelPush  = "push"
+	ChannelSMS   = "sms"
+	ChannelEmail = "email"
+	ChannelInbox = "inbox"
+)
+
+// Message is a notification written by the caller. An empty Recipient uses
+// the phone number or email address the notification service has on file;
+// push messages need the device token.
+type Message struct {
+	UserID    uuid.UUID
+	Channel   string
+	Type      string
+	Title     string
+	Body      string
+	Recipient string
+	Data      map[string]string
+	Priority  string // "high" is delivered during quiet hours
+}
+
+// Result is the outcome of a send. Notifications held back by the user's
+// quiet hours are "scheduled" until ScheduledAt.
+type Result struct {
+	NotificationID string
+	Status         string
+	ScheduledAt    time.Time
+}
+
+// Preferences are a user's notification settings
+type Preferences struct {
+	PushEnabled       bool
+	SMSEnabled        bool
+	EmailEnabled      bool
+	SimplifiedContent bool
+	QuietHours        bool
+	QuietHoursStart   int // Hour of day in Timezone, when QuietHours is set
+	QuietHoursEnd     int
+	Timezone          string
+}
+
+// Client sends notifications synchronously. Calls fail with the gRPC status
+// the service returned: FailedPrecondition when the user has turned the
+// notification off or has no address for the channel, NotFound for an
+// unknown template.
+type Client struct {
+	conn *grpc.ClientConn
+	api  notificationv1.NotificationServiceClient
+}
+
+// New connects to the notification service
+func New(cfg client.Config, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := client.New(cfg, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to notification service: %w", err)
+	}
+	return &Client{conn: conn, api: notificationv1.NewNotificationServiceClient(conn)}, nil
+}
+
+// Send delivers a notification
+func (c *Client) Send(ctx context.Context, msg Message) (*Result, error) {
+	resp, err := c.api.Send(ctx, &notificationv1.SendRequest{
+		UserId:    msg.UserID.String(),
+		Channel:   msg.Channel,
+		Type:      msg.Type,
+		Title:     msg.Title,
+		Body:      msg.Body,
+		Recipient: msg.Recipient,
+		Data:      msg.Data,
+		Priority:  msg.Priority,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResult(resp), nil
+}
+
+// SendTemplate renders the named template for the user and delivers it on
+// the template's channel
+func (c *Client) SendTemplate(ctx context.Context, userID uuid.UUID, template, recipient string, variables map[string]string) (*Result, error) {
+	resp, err := c.api.SendFromTemplate(ctx, &notificationv1.SendFromTemplateRequest{
+		UserId:       userID.String(),
+		TemplateName: template,
+		Recipient:    recipient,
+		Variables:    variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResult(resp), nil
+}
+
+// Preferences retrieves the user's notification settings
+func (c *Client) Preferences(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	resp, err := c.api.GetPreferences(ctx, &notificationv1.GetPreferencesRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+	return &Preferences{
+		PushEnabled:       resp.PushEnabled,
+		SMSEnabled:        resp.SmsEnabled,
+		EmailEnabled:      resp.EmailEnabled,
+		SimplifiedContent: resp.SimplifiedContent,
+		QuietHours:        resp.QuietHoursEnabled,
+		QuietHoursStart:   int(resp.QuietHoursStart),
+		QuietHoursEnd:     int(resp.QuietHoursEnd),
+		Timezone:          resp.Timezone,
+	}, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func toResult(resp *notificationv1.SendResponse) *Result {
+	r := &Result{NotificationID: resp.NotificationId, Status: resp.Status}
+	if resp.ScheduledAt != "" {
+		// A malformed time leaves ScheduledAt zero; the send still happened
+		r.ScheduledAt, _ = time.Parse(time.RFC3339, resp.ScheduledAt)
+	}
+	return r
+}
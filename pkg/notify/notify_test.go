@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/client"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeServer struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	sent *notificationv1.SendFromTemplateRequest
+}
+
+func (f *fakeServer) SendFromTemplate(_ context.Context, req *notificationv1.SendFromTemplateRequest) (*notificationv1.SendResponse, error) {
+	if req.TemplateName == "missing" {
+		return nil, status.Error(codes.NotFound, "template not found")
+	}
+	f.sent = req
+	return &notificationv1.SendResponse{NotificationId: "n-1", Status: "scheduled", ScheduledAt: "2026-01-02T07:00:00Z"}, nil
+}
+
+func (f *fakeServer) GetPreferences(_ context.Context, req *notificationv1.GetPreferencesRequest) (*notificationv1.PreferencesResponse, error) {
+	return &notificationv1.PreferencesResponse{
+		UserId:            req.UserId,
+		PushEnabled:       true,
+		QuietHoursEnabled: true,
+		QuietHoursStart:   22,
+		QuietHoursEnd:     7,
+		Timezone:          "Asia/Kuala_Lumpur",
+	}, nil
+}
+
+func newTestClient(t *testing.T, srv notificationv1.NotificationServiceServer) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	notificationv1.RegisterNotificationServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := New(client.DefaultConfig("passthrough:///bufnet"), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClient_SendTemplate(t *testing.T) {
+	srv := &fakeServer{}
+	c := newTestClient(t, srv)
+	userID := uuid.New()
+
+	result, err := c.SendTemplate(context.Background(), userID, "welcome_push", "device-token", map[string]string{"name": "Aina"})
+	if err != nil {
+		t.Fatalf("SendTemplate() error = %v", err)
+	}
+	if srv.sent.UserId != userID.String() || srv.sent.Recipient != "device-token" || srv.sent.Variables["name"] != "Aina" {
+		t.Errorf("server got %+v", srv.sent)
+	}
+	want := time.Date(2026, 1, 2, 7, 0, 0, 0, time.UTC)
+	if result.NotificationID != "n-1" || result.Status != "scheduled" || !result.ScheduledAt.Equal(want) {
+		t.Errorf("result = %+v", result)
+	}
+
+	_, err = c.SendTemplate(context.Background(), userID, "missing", "", nil)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("unknown template error = %v, want NotFound", err)
+	}
+}
+
+func TestClient_Preferences(t *testing.T) {
+	c := newTestClient(t, &fakeServer{})
+
+	prefs, err := c.Preferences(context.Background(), uuid.New())
+	if err != nil {
+		t.Fatalf("Preferences() error = %v", err)
+	}
+	if !prefs.PushEnabled || prefs.SMSEnabled || !prefs.QuietHours || prefs.QuietHoursStart != 22 || prefs.QuietHoursEnd != 7 {
+		t.Errorf("preferences = %+v", prefs)
+	}
+}
@@ -0,0 +1,98 @@
+package otpstore
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps entries in process memory. Only suitable for a single instance.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*Entry
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		now:     time.Now,
+		entries: make(map[string]*Entry),
+	}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, key string, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Sweep on write so abandoned codes don't accumulate
+	now := s.now()
+	for k, v := range s.entries {
+		if !now.Before(v.ExpiresAt) {
+			delete(s.entries, k)
+		}
+	}
+
+	s.entries[key] = &e
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.live(key)
+	if err != nil {
+		return nil, err
+	}
+	copied := *e
+	return &copied, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, key string, e Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.live(key)
+	if err != nil || current.ID != e.ID {
+		return ErrNotFound
+	}
+	if e.Attempts > current.Attempts {
+		current.Attempts = e.Attempts
+	}
+	current.Verified = e.Verified
+	return nil
+}
+
+func (s *MemoryStore) IncrementAttempts(ctx context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, err := s.live(key)
+	if err != nil {
+		return 0, err
+	}
+	e.Attempts++
+	return e.Attempts, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// live returns the unexpired entry for key. Callers must hold s.mu.
+func (s *MemoryStore) live(key string) (*Entry, error) {
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if !s.now().Before(e.ExpiresAt) {
+		delete(s.entries, key)
+		return nil, ErrNotFound
+	}
+	return e, nil
+}
@@ -0,0 +1,45 @@
+// Package otpstore keeps one-time password state where every service replica
+// can see it.
+//
+// Each key (typically a phone number) holds at most one live code. Attempts
+// are counted atomically, so parallel guesses spread across replicas still
+// hit the same attempt limit.
+package otpstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNotFound = errors.New("otp not found")
+
+// Entry is the stored state of a single one-time password
+type Entry struct {
+	ID        string
+	Code      string
+	Attempts  int
+	Verified  bool
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Store persists OTP entries. Expired entries behave as if they were deleted.
+type Store interface {
+	// Put stores e under key, replacing any previous entry.
+	Put(ctx context.Context, key string, e Entry) error
+
+	// Get returns the live entry for key or ErrNotFound.
+	Get(ctx context.Context, key string) (*Entry, error)
+
+	// Update saves Attempts and Verified for the entry with e.ID. Attempts never
+	// go down. Returns ErrNotFound if the entry expired or was replaced.
+	Update(ctx context.Context, key string, e Entry) error
+
+	// IncrementAttempts atomically records one verification attempt and
+	// returns the new total, or ErrNotFound.
+	IncrementAttempts(ctx context.Context, key string) (int, error)
+
+	// Delete removes the entry for key, if any.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,191 @@
+package otpstore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func stores(t *testing.T) map[string]Store {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return map[string]Store{
+		"memory": NewMemoryStore(),
+		"redis":  NewRedisStore(client, ""),
+	}
+}
+
+func newEntry(id string) Entry {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return Entry{
+		ID:        id,
+		Code:      "123456",
+		CreatedAt: now,
+		ExpiresAt: now.Add(5 * time.Minute),
+	}
+}
+
+func TestStore_PutGetDelete(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			want := newEntry("otp-1")
+
+			if err := s.Put(ctx, "+60123456789", want); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			got, err := s.Get(ctx, "+60123456789")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if got.ID != want.ID || got.Code != want.Code || !got.ExpiresAt.Equal(want.ExpiresAt) || !got.CreatedAt.Equal(want.CreatedAt) {
+				t.Errorf("Get() = %+v, want %+v", got, want)
+			}
+
+			if err := s.Delete(ctx, "+60123456789"); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := s.Get(ctx, "+60123456789"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStore_PutReplaces(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			old := newEntry("old")
+			s.Put(ctx, "k", old)
+			s.IncrementAttempts(ctx, "k")
+
+			s.Put(ctx, "k", newEntry("new"))
+			got, err := s.Get(ctx, "k")
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if got.ID != "new" || got.Attempts != 0 {
+				t.Errorf("Get() = %+v, want fresh entry \"new\"", got)
+			}
+
+			// A stale update for the replaced code must not apply
+			old.Attempts = 3
+			if err := s.Update(ctx, "k", old); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Update(stale) error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStore_UpdateNeverLowersAttempts(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			e := newEntry("otp-1")
+			s.Put(ctx, "k", e)
+			s.IncrementAttempts(ctx, "k")
+			s.IncrementAttempts(ctx, "k")
+
+			e.Attempts = 1
+			e.Verified = true
+			if err := s.Update(ctx, "k", e); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+
+			got, _ := s.Get(ctx, "k")
+			if got.Attempts != 2 || !got.Verified {
+				t.Errorf("Get() = attempts %d verified %v, want 2 true", got.Attempts, got.Verified)
+			}
+		})
+	}
+}
+
+func TestStore_IncrementMissing(t *testing.T) {
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if _, err := s.IncrementAttempts(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("IncrementAttempts() error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStore_ConcurrentAttempts(t *testing.T) {
+	const callers = 50
+
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			s.Put(ctx, "k", newEntry("otp-1"))
+
+			var mu sync.Mutex
+			seen := make(map[int]bool)
+			var wg sync.WaitGroup
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					n, err := s.IncrementAttempts(ctx, "k")
+					if err != nil {
+						t.Errorf("IncrementAttempts() error = %v", err)
+						return
+					}
+					mu.Lock()
+					seen[n] = true
+					mu.Unlock()
+				}()
+			}
+			wg.Wait()
+
+			// Every caller must observe a distinct count, or two guesses shared one attempt
+			if len(seen) != callers {
+				t.Errorf("saw %d distinct attempt counts, want %d", len(seen), callers)
+			}
+			got, _ := s.Get(ctx, "k")
+			if got.Attempts != callers {
+				t.Errorf("Attempts = %d, want %d", got.Attempts, callers)
+			}
+		})
+	}
+}
+
+func TestStore_Expiry(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		s := NewMemoryStore()
+		now := time.Now()
+		s.now = func() time.Time { return now }
+
+		s.Put(context.Background(), "k", newEntry("otp-1"))
+		now = now.Add(6 * time.Minute)
+
+		if _, err := s.Get(context.Background(), "k"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		mr := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+		defer client.Close()
+		s := NewRedisStore(client, "")
+
+		s.Put(context.Background(), "k", newEntry("otp-1"))
+		mr.FastForward(6 * time.Minute)
+
+		if _, err := s.Get(context.Background(), "k"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Get() error = %v, want ErrNotFound", err)
+		}
+		if _, err := s.IncrementAttempts(context.Background(), "k"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("IncrementAttempts() error = %v, want ErrNotFound", err)
+		}
+	})
+}
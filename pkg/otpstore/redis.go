@@ -0,0 +1,152 @@
+package otpstore
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix namespaces OTP keys in a shared Redis
+const DefaultRedisPrefix = "otp:"
+
+// Each entry is a hash that expires with the code, so there is nothing to clean up.
+const (
+	fieldID        = "id"
+	fieldCode      = "code"
+	fieldAttempts  = "attempts"
+	fieldVerified  = "verified"
+	fieldCreatedAt = "created_at"
+	fieldExpiresAt = "expires_at"
+)
+
+// incrementScript bumps the attempt counter only if the entry still exists,
+// so a late attempt can't recreate an expired code as a bare counter.
+var incrementScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+return redis.call('HINCRBY', KEYS[1], 'attempts', 1)
+`)
+
+// updateScript applies Update only to the entry it was read from (ARGV[1] is
+// its ID) and never lowers the attempt count recorded by other replicas.
+var updateScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'id') ~= ARGV[1] then
+	return 0
+end
+local attempts = tonumber(redis.call('HGET', KEYS[1], 'attempts'))
+if tonumber(ARGV[2]) > attempts then
+	redis.call('HSET', KEYS[1], 'attempts', ARGV[2])
+end
+redis.call('HSET', KEYS[1], 'verified', ARGV[3])
+return 1
+`)
+
+// RedisStore keeps entries in Redis hashes shared by all replicas
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a Redis-backed store. An empty prefix uses DefaultRedisPrefix.
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) Put(ctx context.Context, key string, e Entry) error {
+	k := s.prefix + key
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, k)
+		pipe.HSet(ctx, k,
+			fieldID, e.ID,
+			fieldCode, e.Code,
+			fieldAttempts, e.Attempts,
+			fieldVerified, boolToInt(e.Verified),
+			fieldCreatedAt, e.CreatedAt.UnixMilli(),
+			fieldExpiresAt, e.ExpiresAt.UnixMilli(),
+		)
+		pipe.PExpireAt(ctx, k, e.ExpiresAt)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store otp: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (*Entry, error) {
+	values, err := s.client.HGetAll(ctx, s.prefix+key).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get otp: %w", err)
+	}
+	if len(values) == 0 {
+		return nil, ErrNotFound
+	}
+
+	attempts, err := strconv.Atoi(values[fieldAttempts])
+	if err != nil {
+		return nil, fmt.Errorf("invalid otp attempts %q: %w", values[fieldAttempts], err)
+	}
+	createdAt, err := strconv.ParseInt(values[fieldCreatedAt], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid otp created_at %q: %w", values[fieldCreatedAt], err)
+	}
+	expiresAt, err := strconv.ParseInt(values[fieldExpiresAt], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid otp expires_at %q: %w", values[fieldExpiresAt], err)
+	}
+
+	return &Entry{
+		ID:        values[fieldID],
+		Code:      values[fieldCode],
+		Attempts:  attempts,
+		Verified:  values[fieldVerified] == "1",
+		CreatedAt: time.UnixMilli(createdAt).UTC(),
+		ExpiresAt: time.UnixMilli(expiresAt).UTC(),
+	}, nil
+}
+
+func (s *RedisStore) Update(ctx context.Context, key string, e Entry) error {
+	updated, err := updateScript.Run(ctx, s.client,
+		[]string{s.prefix + key},
+		e.ID, e.Attempts, boolToInt(e.Verified),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to update otp: %w", err)
+	}
+	if updated == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (s *RedisStore) IncrementAttempts(ctx context.Context, key string) (int, error) {
+	attempts, err := incrementScript.Run(ctx, s.client, []string{s.prefix + key}).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record otp attempt: %w", err)
+	}
+	if attempts < 0 {
+		return 0, ErrNotFound
+	}
+	return attempts, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if err := s.client.Del(ctx, s.prefix+key).Err(); err != nil {
+		return fmt.Errorf("failed to delete otp: %w", err)
+	}
+	return nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,157 @@
+package pricing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func intPtr(n int) *int { return &n }
+
+func testSchedule() *Schedule {
+	return &Schedule{
+		Timezone: "Asia/Kuala_Lumpur",
+		Rules: []Rule{
+			{Name: "weekend", Kind: RuleHourly, Days: []Day{"sat", "sun"}, Start: "00:00", End: "00:00",
+				HourlyRate: decimal.RequireFromString("2.00"), GracePeriodMin: intPtr(30)},
+			{Name: "peak", Kind: RuleHourly, Days: []Day{"mon", "tue", "wed", "thu", "fri"}, Start: "08:00", End: "18:00",
+				HourlyRate: decimal.RequireFromString("4.00")},
+			{Name: "night", Kind: RuleFlat, Start: "22:00", End: "07:00", FlatRate: decimal.RequireFromString("5.00")},
+		},
+	}
+}
+
+func TestTariff_Price(t *testing.T) {
+	kl, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 12 October 2026 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, kl)
+	}
+
+	tests := []struct {
+		name        string
+		schedule    *Schedule
+		entry, exit time.Time
+		want        string
+	}{
+		{"flat rate without a schedule", nil, at(12, 9, 0), at(12, 11, 30), "9.00"},
+		{"flat rate capped at the daily max", nil, at(12, 0, 0), at(13, 12, 0), "30.00"},
+		{"peak hours", testSchedule(), at(12, 9, 0), at(12, 11, 0), "8.00"},
+		{"peak into off-peak", testSchedule(), at(12, 17, 0), at(12, 19, 30), "10.00"},
+		{"overnight flat rate", testSchedule(), at(12, 21, 0), at(13, 8, 0), "11.00"},
+		{"weekend within the grace period", testSchedule(), at(17, 10, 0), at(17, 10, 20), "0"},
+		{"weekend past the grace period", testSchedule(), at(17, 10, 0), at(17, 10, 40), "2.00"},
+		{"capped at the daily max", testSchedule(), at(12, 8, 0), at(12, 18, 0), "30.00"},
+		{"capped for each day", testSchedule(), at(12, 8, 0), at(13, 18, 0), "60.00"},
+		{"invalid schedule falls back to the flat rate", &Schedule{Timezone: "Mars/Olympus", Rules: testSchedule().Rules},
+			at(12, 9, 0), at(12, 11, 0), "6.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tariff := Tariff{
+				HourlyRate: decimal.RequireFromString("3.00"),
+				DailyMax:   decimal.RequireFromString("30.00"),
+				Schedule:   tt.schedule,
+			}
+			got := tariff.Price(tt.entry.UTC(), tt.exit.UTC())
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Price() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		rule Rule
+		tz   string
+		want error
+	}{
+		{"valid", Rule{Kind: RuleHourly, Start: "08:00", End: "18:00"}, "Asia/Kuala_Lumpur", nil},
+		{"unknown time zone", Rule{Kind: RuleHourly, Start: "08:00", End: "18:00"}, "Mars/Olympus", ErrInvalidTimezone},
+		{"missing time zone", Rule{Kind: RuleHourly, Start: "08:00", End: "18:00"}, "", ErrInvalidTimezone},
+		{"unknown kind", Rule{Kind: "tiered", Start: "08:00", End: "18:00"}, "UTC", ErrInvalidRule},
+		{"unknown day", Rule{Kind: RuleHourly, Days: []Day{"monday"}, Start: "08:00", End: "18:00"}, "UTC", ErrInvalidRule},
+		{"bad time", Rule{Kind: RuleHourly, Start: "8am", End: "18:00"}, "UTC", ErrInvalidRule},
+		{"negative rate", Rule{Kind: RuleFlat, Start: "22:00", End: "06:00", FlatRate: decimal.NewFromInt(-1)}, "UTC", ErrInvalidRule},
+		{"negative grace period", Rule{Kind: RuleHourly, Start: "08:00", End: "18:00", GracePeriodMin: intPtr(-5)}, "UTC", ErrInvalidRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Schedule{Timezone: tt.tz, Rules: []Rule{tt.rule}}
+			if err := s.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestHours_OpenAt(t *testing.T) {
+	hours := &Hours{
+		Timezone: "Asia/Kuala_Lumpur",
+		Windows: []OpeningWindow{
+			{Day: "mon", Open: "07:00", Close: "23:00"},
+			{Day: "fri", Open: "07:00", Close: "02:00"},
+			{Day: "sat", Open: "00:00", Close: "00:00"},
+		},
+	}
+	if err := hours.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	kl, _ := time.LoadLocation("Asia/Kuala_Lumpur")
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"monday daytime", time.Date(2026, 10, 12, 12, 0, 0, 0, kl), true},
+		{"monday after closing", time.Date(2026, 10, 12, 23, 30, 0, 0, kl), false},
+		{"closed on tuesday", time.Date(2026, 10, 13, 12, 0, 0, 0, kl), false},
+		{"friday late opening runs into saturday", time.Date(2026, 10, 17, 1, 30, 0, 0, kl), true},
+		{"open all saturday", time.Date(2026, 10, 17, 23, 59, 0, 0, kl), true},
+		{"sunday morning after saturday", time.Date(2026, 10, 18, 0, 30, 0, 0, kl), false},
+		{"compared in the location's time zone", time.Date(2026, 10, 12, 4, 0, 0, 0, time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hours.OpenAt(tt.at); got != tt.want {
+				t.Errorf("OpenAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !(*Hours)(nil).OpenAt(time.Now()) {
+		t.Error("a location without hours should always be open")
+	}
+}
+
+func TestHours_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		window OpeningWindow
+		want   error
+	}{
+		{"valid", OpeningWindow{Day: "mon", Open: "07:00", Close: "23:00"}, nil},
+		{"missing day", OpeningWindow{Open: "07:00", Close: "23:00"}, ErrInvalidHours},
+		{"bad time", OpeningWindow{Day: "mon", Open: "7", Close: "23:00"}, ErrInvalidHours},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Hours{Timezone: "UTC", Windows: []OpeningWindow{tt.window}}
+			if err := h.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
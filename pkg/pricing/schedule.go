@@ -0,0 +1,283 @@
+// Package pricing holds a location's pricing schedule and opening hours,
+// and the engine that bills a stay against them.
+//
+// A location's flat rate bills every hour the same. A Schedule refines it
+// with rules for windows of the week, in the location's time zone: peak and
+// off-peak hourly rates, weekend rates, a flat rate covering a whole window
+// such as overnight, and a free grace period for stays that begin in a
+// window. Hours the schedule does not cover are billed at the flat rate.
+//
+// The provider service stores schedules; services that bill or quote stays
+// price them with a Tariff.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	_ "time/tzdata" // schedules name time zones the host may not have
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidTimezone is returned for a time zone that is not an IANA name
+	ErrInvalidTimezone = errors.New("timezone must be an IANA time zone such as Asia/Kuala_Lumpur")
+	// ErrInvalidRule is returned for a schedule rule that cannot be applied
+	ErrInvalidRule = errors.New("invalid pricing rule")
+	// ErrInvalidHours is returned for opening hours that cannot be applied
+	ErrInvalidHours = errors.New("invalid operating hours")
+)
+
+// MaxRules bounds the rules in one schedule
+const MaxRules = 32
+
+// Day is a day of the week: mon, tue, wed, thu, fri, sat or sun
+type Day string
+
+var weekdays = map[Day]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Weekday returns the day as a time.Weekday
+func (d Day) Weekday() (time.Weekday, bool) {
+	w, ok := weekdays[d]
+	return w, ok
+}
+
+// RuleKind is how a rule charges for the hours it covers
+type RuleKind string
+
+const (
+	// RuleHourly charges its own hourly rate for each hour it covers
+	RuleHourly RuleKind = "hourly"
+	// RuleFlat charges its flat rate once for each of its windows a stay
+	// overlaps, however many hours that is
+	RuleFlat RuleKind = "flat"
+)
+
+// Rule prices the hours of a stay that begin within its window.
+//
+// The window runs from Start to End (HH:MM, local time) on each of Days, or
+// every day when Days is empty. A window whose End is not after its Start
+// runs past midnight into the next day, so 22:00-06:00 is overnight and
+// 00:00-00:00 is the whole day.
+type Rule struct {
+	Name       string          `json:"name"`
+	Kind       RuleKind        `json:"kind"`
+	Days       []Day           `json:"days,omitempty"`
+	Start      string          `json:"start"`
+	End        string          `json:"end"`
+	HourlyRate decimal.Decimal `json:"hourly_rate"`
+	FlatRate   decimal.Decimal `json:"flat_rate"`
+	// GracePeriodMin makes stays that begin in the window free when they
+	// last no longer than this many minutes
+	GracePeriodMin *int `json:"grace_period_min,omitempty"`
+
+	span window
+}
+
+// Schedule is a location's pricing rules. Where rules overlap, the first
+// listed wins.
+type Schedule struct {
+	Timezone string `json:"timezone"`
+	Rules    []Rule `json:"rules"`
+
+	loc *time.Location
+}
+
+// Validate checks every rule can be applied and prepares the schedule for
+// pricing. Schedules are validated before they are stored, and again by
+// Tariff before they are used.
+func (s *Schedule) Validate() error {
+	loc, err := loadTimezone(s.Timezone)
+	if err != nil {
+		return err
+	}
+	if len(s.Rules) > MaxRules {
+		return fmt.Errorf("%w: a schedule holds at most %d rules", ErrInvalidRule, MaxRules)
+	}
+	for i := range s.Rules {
+		if err := s.Rules[i].prepare(); err != nil {
+			return err
+		}
+	}
+	s.loc = loc
+	return nil
+}
+
+func (r *Rule) prepare() error {
+	switch r.Kind {
+	case RuleHourly:
+		if r.HourlyRate.IsNegative() {
+			return fmt.Errorf("%w: %q has a negative hourly rate", ErrInvalidRule, r.Name)
+		}
+	case RuleFlat:
+		if r.FlatRate.IsNegative() {
+			return fmt.Errorf("%w: %q has a negative flat rate", ErrInvalidRule, r.Name)
+		}
+	default:
+		return fmt.Errorf("%w: %q must be of kind hourly or flat", ErrInvalidRule, r.Name)
+	}
+	if r.GracePeriodMin != nil && *r.GracePeriodMin < 0 {
+		return fmt.Errorf("%w: %q has a negative grace period", ErrInvalidRule, r.Name)
+	}
+
+	days, err := parseDays(r.Days)
+	if err != nil {
+		return fmt.Errorf("%w: %q: %v", ErrInvalidRule, r.Name, err)
+	}
+	start, errStart := parseClock(r.Start)
+	end, errEnd := parseClock(r.End)
+	if errStart != nil || errEnd != nil {
+		return fmt.Errorf("%w: %q needs start and end times as HH:MM", ErrInvalidRule, r.Name)
+	}
+	r.span = window{start: start, end: end, days: days}
+	return nil
+}
+
+// ruleAt returns the first rule whose window holds t, and when that window
+// began
+func (s *Schedule) ruleAt(t time.Time) (int, time.Time) {
+	t = t.In(s.loc)
+	for i := range s.Rules {
+		if ok, from := s.Rules[i].span.covers(t); ok {
+			return i, from
+		}
+	}
+	return -1, time.Time{}
+}
+
+// OpeningWindow is one day's opening time, HH:MM local time. A window
+// closing at or before it opens runs past midnight, and 00:00-00:00 is open
+// all day.
+type OpeningWindow struct {
+	Day   Day    `json:"day"`
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Hours is when a location is open. A day with no window is closed; a
+// location without Hours is always open.
+type Hours struct {
+	Timezone string          `json:"timezone"`
+	Windows  []OpeningWindow `json:"windows"`
+
+	loc   *time.Location
+	spans []window
+}
+
+// Validate checks the hours can be applied and prepares them for OpenAt
+func (h *Hours) Validate() error {
+	loc, err := loadTimezone(h.Timezone)
+	if err != nil {
+		return err
+	}
+	windows := make([]window, len(h.Windows))
+	for i, w := range h.Windows {
+		days, err := parseDays([]Day{w.Day})
+		if err != nil || w.Day == "" {
+			return fmt.Errorf("%w: window %d needs a day such as mon", ErrInvalidHours, i+1)
+		}
+		open, errOpen := parseClock(w.Open)
+		closing, errClose := parseClock(w.Close)
+		if errOpen != nil || errClose != nil {
+			return fmt.Errorf("%w: window %d needs open and close times as HH:MM", ErrInvalidHours, i+1)
+		}
+		windows[i] = window{start: open, end: closing, days: days}
+	}
+	h.loc, h.spans = loc, windows
+	return nil
+}
+
+// OpenAt reports whether the location is open at t
+func (h *Hours) OpenAt(t time.Time) bool {
+	if h == nil || len(h.Windows) == 0 {
+		return true
+	}
+	if h.loc == nil && h.Validate() != nil {
+		return true
+	}
+	t = t.In(h.loc)
+	for _, w := range h.spans {
+		if ok, _ := w.covers(t); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// window is a daily span of minutes after midnight on some days of the week
+type window struct {
+	start, end int
+	days       [7]bool
+}
+
+// covers reports whether the window holds t, and when that window began.
+// The part of an overnight window after midnight belongs to the day it
+// started on.
+func (w window) covers(t time.Time) (bool, time.Time) {
+	minute := t.Hour()*60 + t.Minute()
+	day := t
+	switch {
+	case w.end > w.start:
+		if minute < w.start || minute >= w.end {
+			return false, time.Time{}
+		}
+	case minute < w.start:
+		if minute >= w.end {
+			return false, time.Time{}
+		}
+		day = t.AddDate(0, 0, -1)
+	}
+	if !w.days[day.Weekday()] {
+		return false, time.Time{}
+	}
+	y, m, d := day.Date()
+	return true, time.Date(y, m, d, w.start/60, w.start%60, 0, 0, t.Location())
+}
+
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// parseDays returns the days a rule applies on; no days means every day
+func parseDays(days []Day) ([7]bool, error) {
+	var set [7]bool
+	if len(days) == 0 {
+		for i := range set {
+			set[i] = true
+		}
+		return set, nil
+	}
+	for _, d := range days {
+		w, ok := d.Weekday()
+		if !ok {
+			return set, fmt.Errorf("unknown day %q", d)
+		}
+		set[w] = true
+	}
+	return set, nil
+}
+
+// parseClock returns the minutes after midnight of an HH:MM time
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
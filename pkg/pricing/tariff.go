@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Tariff is what a location charges: its flat rate, and the schedule that
+// refines it, if any
+type Tariff struct {
+	HourlyRate decimal.Decimal
+	DailyMax   decimal.Decimal
+	Schedule   *Schedule
+}
+
+// Price bills a stay from entry to exit. Stays are billed in whole hours
+// from entry, rounded up.
+//
+// Without a schedule every hour is charged the hourly rate and the total is
+// capped at the daily max. With one, each hour is charged by the rule its
+// start falls in, or the hourly rate when none does; a flat rule is charged
+// once per window; and the daily max caps each 24 hours from entry. A stay
+// that begins in a rule's window and lasts no longer than its grace period
+// is free.
+func (t Tariff) Price(entry, exit time.Time) decimal.Decimal {
+	duration := exit.Sub(entry)
+	if duration < 0 {
+		duration = 0
+	}
+	minutes := int64(duration.Minutes())
+	hours := (minutes + 59) / 60
+
+	s := t.Schedule.prepared()
+	if s == nil || len(s.Rules) == 0 {
+		amount := decimal.NewFromInt(hours).Mul(t.HourlyRate)
+		return t.capped(amount).Round(2)
+	}
+
+	if i, _ := s.ruleAt(entry); i >= 0 {
+		if grace := s.Rules[i].GracePeriodMin; grace != nil && minutes <= int64(*grace) {
+			return decimal.Zero
+		}
+	}
+
+	total, day := decimal.Zero, decimal.Zero
+	flatWindows := make(map[flatWindow]bool)
+	for h := int64(0); h < hours; h++ {
+		if h > 0 && h%24 == 0 {
+			total = total.Add(t.capped(day))
+			day = decimal.Zero
+		}
+
+		i, from := s.ruleAt(entry.Add(time.Duration(h) * time.Hour))
+		switch {
+		case i < 0:
+			day = day.Add(t.HourlyRate)
+		case s.Rules[i].Kind == RuleFlat:
+			key := flatWindow{rule: i, from: from.Unix()}
+			if !flatWindows[key] {
+				flatWindows[key] = true
+				day = day.Add(s.Rules[i].FlatRate)
+			}
+		default:
+			day = day.Add(s.Rules[i].HourlyRate)
+		}
+	}
+	return total.Add(t.capped(day)).Round(2)
+}
+
+// capped limits an amount to the daily max, when one is set
+func (t Tariff) capped(amount decimal.Decimal) decimal.Decimal {
+	if t.DailyMax.IsPositive() && amount.GreaterThan(t.DailyMax) {
+		return t.DailyMax
+	}
+	return amount
+}
+
+// flatWindow is one occurrence of a flat rule's window
+type flatWindow struct {
+	rule int
+	from int64
+}
+
+// prepared returns the schedule ready for pricing. One decoded from storage
+// or the wire is validated as a copy, leaving the shared value untouched;
+// an invalid schedule is nil, so the flat rate applies.
+func (s *Schedule) prepared() *Schedule {
+	if s == nil || s.loc != nil {
+		return s
+	}
+	c := *s
+	c.Rules = append([]Rule(nil), s.Rules...)
+	if c.Validate() != nil {
+		return nil
+	}
+	return &c
+}
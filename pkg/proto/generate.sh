@@ -1,32 +1,33 @@
 #!/bin/bash
 # Generate Go code from proto files
 #
+# Generated code is committed, so services build without any proto tooling.
+# Re-run this after editing a .proto file and commit the result.
+#
 # Prerequisites:
-#   go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
-#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+#   buf (https://buf.build/docs/installation)
 #
 # Usage:
-#   ./generate.sh
+#   ./generate.sh            lint and generate
+#   ./generate.sh breaking   also check for breaking changes against main
 
 set -e
 
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
-PKG_DIR="$(dirname "$SCRIPT_DIR")"
+cd "${SCRIPT_DIR}"
 
-echo "Generating Go code from proto files..."
+echo "Linting proto files..."
+buf lint
 
-for service in auth wallet provider parking notification; do
-    echo "  Generating ${service}..."
-    protoc \
-        --proto_path="${SCRIPT_DIR}" \
-        --go_out="${PKG_DIR}" \
-        --go_opt=paths=source_relative \
-        --go-grpc_out="${PKG_DIR}" \
-        --go-grpc_opt=paths=source_relative \
-        "${SCRIPT_DIR}/${service}/v1/${service}.proto"
-done
+if [ "$1" = "breaking" ]; then
+    echo "Checking for breaking changes against main..."
+    buf breaking --against "$(git rev-parse --show-toplevel)/.git#branch=main,subdir=pkg/proto"
+fi
+
+echo "Generating Go code from proto files..."
+buf generate
 
 echo "Proto generation complete!"
 echo ""
 echo "Generated files:"
-find "${PKG_DIR}/proto" -name "*.pb.go" -type f
+find "${SCRIPT_DIR}" -name "*.pb.go" -type f
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter keeps a log of recent actions per key in process memory
+type MemoryLimiter struct {
+	limit Limit
+	now   func() time.Time
+
+	mu     sync.Mutex
+	events map[string][]time.Time
+}
+
+func NewMemoryLimiter(limit Limit) (*MemoryLimiter, error) {
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+	return &MemoryLimiter{
+		limit:  limit,
+		now:    time.Now,
+		events: make(map[string][]time.Time),
+	}, nil
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	cutoff := now.Add(-l.limit.Window)
+
+	recent := l.events[key][:0]
+	for _, t := range l.events[key] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= l.limit.Requests {
+		l.events[key] = recent
+		return Result{RetryAfter: recent[0].Add(l.limit.Window).Sub(now)}, nil
+	}
+
+	recent = append(recent, now)
+	l.events[key] = recent
+	return Result{Allowed: true, Remaining: l.limit.Requests - len(recent)}, nil
+}
+
+// Cleanup drops keys with no actions inside the window. Call it periodically
+// when the key space is unbounded (e.g. per phone number).
+func (l *MemoryLimiter) Cleanup() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := l.now().Add(-l.limit.Window)
+	for key, times := range l.events {
+		if len(times) == 0 || !times[len(times)-1].After(cutoff) {
+			delete(l.events, key)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// Package ratelimit provides sliding-window rate limiters.
+//
+// The Redis limiter keeps its counters in a shared instance, so every replica
+// of a service enforces the same limit. The memory limiter is for local
+// development and single-instance deployments.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrInvalidLimit = errors.New("rate limit requests and window must be positive")
+
+// Limit allows Requests actions per key within any Window-long period.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+func (l Limit) validate() error {
+	if l.Requests <= 0 || l.Window <= 0 {
+		return ErrInvalidLimit
+	}
+	return nil
+}
+
+// Result describes the outcome of a single Allow call.
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next action would be allowed.
+	// Zero when Allowed is true.
+	RetryAfter time.Duration
+}
+
+// Limiter records an action for key and reports whether it is within the limit.
+// Rejected actions are not counted against the key.
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
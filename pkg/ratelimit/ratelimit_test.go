@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func newRedisLimiter(t *testing.T, limit Limit) (*RedisLimiter, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	l, err := NewRedisLimiter(client, "test:", limit)
+	if err != nil {
+		t.Fatalf("NewRedisLimiter() error = %v", err)
+	}
+	return l, mr
+}
+
+func limiters(t *testing.T, limit Limit) map[string]Limiter {
+	t.Helper()
+	mem, err := NewMemoryLimiter(limit)
+	if err != nil {
+		t.Fatalf("NewMemoryLimiter() error = %v", err)
+	}
+	rl, _ := newRedisLimiter(t, limit)
+	return map[string]Limiter{"memory": mem, "redis": rl}
+}
+
+func TestLimit_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit Limit
+		want  error
+	}{
+		{"valid", Limit{Requests: 5, Window: time.Minute}, nil},
+		{"zero requests", Limit{Requests: 0, Window: time.Minute}, ErrInvalidLimit},
+		{"zero window", Limit{Requests: 5}, ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMemoryLimiter(tt.limit); err != tt.want {
+				t.Errorf("expected error %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestLimiter_AllowsUpToLimit(t *testing.T) {
+	for name, l := range limiters(t, Limit{Requests: 3, Window: time.Minute}) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < 3; i++ {
+				res, err := l.Allow(ctx, "phone-1")
+				if err != nil {
+					t.Fatalf("Allow() error = %v", err)
+				}
+				if !res.Allowed {
+					t.Fatalf("request %d rejected, want allowed", i+1)
+				}
+				if res.Remaining != 2-i {
+					t.Errorf("request %d remaining = %d, want %d", i+1, res.Remaining, 2-i)
+				}
+			}
+
+			res, err := l.Allow(ctx, "phone-1")
+			if err != nil {
+				t.Fatalf("Allow() error = %v", err)
+			}
+			if res.Allowed {
+				t.Error("fourth request allowed, want rejected")
+			}
+			if res.RetryAfter <= 0 || res.RetryAfter > time.Minute {
+				t.Errorf("RetryAfter = %v, want within (0, 1m]", res.RetryAfter)
+			}
+
+			// Other keys have their own budget
+			res, err = l.Allow(ctx, "phone-2")
+			if err != nil || !res.Allowed {
+				t.Errorf("other key rejected: %+v, %v", res, err)
+			}
+		})
+	}
+}
+
+func TestLimiter_ConcurrentAccess(t *testing.T) {
+	const limit, callers = 10, 50
+
+	for name, l := range limiters(t, Limit{Requests: limit, Window: time.Minute}) {
+		t.Run(name, func(t *testing.T) {
+			var allowed int32
+			var wg sync.WaitGroup
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					res, err := l.Allow(context.Background(), "shared")
+					if err != nil {
+						t.Errorf("Allow() error = %v", err)
+						return
+					}
+					if res.Allowed {
+						atomic.AddInt32(&allowed, 1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if allowed != limit {
+				t.Errorf("allowed %d of %d concurrent requests, want exactly %d", allowed, callers, limit)
+			}
+		})
+	}
+}
+
+func TestRedisLimiter_SharedAcrossInstances(t *testing.T) {
+	limit := Limit{Requests: 2, Window: time.Minute}
+	first, mr := newRedisLimiter(t, limit)
+
+	// A second replica pointing at the same Redis
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	second, _ := NewRedisLimiter(client, "test:", limit)
+
+	ctx := context.Background()
+	first.Allow(ctx, "k")
+	second.Allow(ctx, "k")
+
+	res, err := first.Allow(ctx, "k")
+	if err != nil {
+		t.Fatalf("Allow() error = %v", err)
+	}
+	if res.Allowed {
+		t.Error("third request across replicas allowed, want rejected")
+	}
+}
+
+func TestMemoryLimiter_WindowSlides(t *testing.T) {
+	l, _ := NewMemoryLimiter(Limit{Requests: 1, Window: time.Minute})
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	l.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	if res, _ := l.Allow(ctx, "k"); !res.Allowed {
+		t.Fatal("first request rejected")
+	}
+	if res, _ := l.Allow(ctx, "k"); res.Allowed {
+		t.Fatal("second request inside window allowed")
+	}
+
+	now = now.Add(time.Minute + time.Second)
+	if res, _ := l.Allow(ctx, "k"); !res.Allowed {
+		t.Error("request after window rejected")
+	}
+
+	now = now.Add(2 * time.Minute)
+	l.Cleanup()
+	if len(l.events) != 0 {
+		t.Errorf("Cleanup() left %d keys, want 0", len(l.events))
+	}
+}
@@ -0,0 +1,80 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryList keeps revocations in process memory. The auth service and the
+// gateway run as separate processes, so it is only useful in tests and
+// single-process setups.
+type MemoryList struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]time.Time
+	users  map[string]userCutoff
+}
+
+type userCutoff struct {
+	cutoff    int64
+	expiresAt time.Time
+}
+
+func NewMemoryList() *MemoryList {
+	return &MemoryList{
+		now:    time.Now,
+		tokens: make(map[string]time.Time),
+		users:  make(map[string]userCutoff),
+	}
+}
+
+func (l *MemoryList) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep()
+	if expiresAt.After(l.now()) {
+		l.tokens[id] = expiresAt
+	}
+	return nil
+}
+
+func (l *MemoryList) RevokeUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep()
+	l.users[userID] = userCutoff{cutoff: at.Unix(), expiresAt: l.now().Add(ttl)}
+	return nil
+}
+
+func (l *MemoryList) IsRevoked(ctx context.Context, t Token) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if expiresAt, ok := l.tokens[t.ID]; ok && t.ID != "" && now.Before(expiresAt) {
+		return true, nil
+	}
+	if u, ok := l.users[t.UserID]; ok && now.Before(u.expiresAt) {
+		return issuedBy(t.IssuedAt, u.cutoff), nil
+	}
+	return false, nil
+}
+
+// sweep drops entries whose tokens have all expired. Callers hold mu.
+func (l *MemoryList) sweep() {
+	now := l.now()
+	for id, expiresAt := range l.tokens {
+		if !now.Before(expiresAt) {
+			delete(l.tokens, id)
+		}
+	}
+	for id, u := range l.users {
+		if !now.Before(u.expiresAt) {
+			delete(l.users, id)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix namespaces revocation keys in a shared Redis. The auth
+// service and the gateway must use the same prefix.
+const DefaultRedisPrefix = "revocation:"
+
+// RedisList keeps revocations in Redis keys that expire with the tokens
+// they cover
+type RedisList struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisList creates a Redis-backed list. An empty prefix uses DefaultRedisPrefix.
+func NewRedisList(client redis.UniversalClient, prefix string) *RedisList {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	return &RedisList{client: client, prefix: prefix}
+}
+
+func (l *RedisList) tokenKey(id string) string    { return l.prefix + "jti:" + id }
+func (l *RedisList) userKey(userID string) string { return l.prefix + "user:" + userID }
+
+func (l *RedisList) RevokeToken(ctx context.Context, id string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	if err := l.client.Set(ctx, l.tokenKey(id), "1", ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+func (l *RedisList) RevokeUser(ctx context.Context, userID string, at time.Time, ttl time.Duration) error {
+	if err := l.client.Set(ctx, l.userKey(userID), at.Unix(), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	return nil
+}
+
+// IsRevoked reads both keys in one round trip, since it runs on every
+// authenticated request
+func (l *RedisList) IsRevoked(ctx context.Context, t Token) (bool, error) {
+	keys := []string{l.userKey(t.UserID)}
+	if t.ID != "" {
+		keys = append(keys, l.tokenKey(t.ID))
+	}
+
+	values, err := l.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check revocation: %w", err)
+	}
+	if len(values) > 1 && values[1] != nil {
+		return true, nil
+	}
+	if s, ok := values[0].(string); ok {
+		cutoff, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid revocation cutoff %q: %w", s, err)
+		}
+		return issuedBy(t.IssuedAt, cutoff), nil
+	}
+	return false, nil
+}
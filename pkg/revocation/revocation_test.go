@@ -0,0 +1,126 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func lists(t *testing.T) (map[string]List, *miniredis.Miniredis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return map[string]List{
+		"memory": NewMemoryList(),
+		"redis":  NewRedisList(client, "test:"),
+	}, mr
+}
+
+func TestList_RevokeToken(t *testing.T) {
+	all, _ := lists(t)
+	for name, l := range all {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			issued := time.Now()
+
+			if err := l.RevokeToken(ctx, "jti-1", issued.Add(time.Minute)); err != nil {
+				t.Fatalf("RevokeToken() error = %v", err)
+			}
+
+			tests := []struct {
+				name  string
+				token Token
+				want  bool
+			}{
+				{"revoked token", Token{ID: "jti-1", UserID: "user-1", IssuedAt: issued}, true},
+				{"other token of same user", Token{ID: "jti-2", UserID: "user-1", IssuedAt: issued}, false},
+				{"token without jti", Token{UserID: "user-1", IssuedAt: issued}, false},
+			}
+			for _, tt := range tests {
+				got, err := l.IsRevoked(ctx, tt.token)
+				if err != nil {
+					t.Fatalf("%s: IsRevoked() error = %v", tt.name, err)
+				}
+				if got != tt.want {
+					t.Errorf("%s: IsRevoked() = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestList_RevokeUser(t *testing.T) {
+	all, _ := lists(t)
+	for name, l := range all {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cutoff := time.Now().Truncate(time.Second)
+
+			if err := l.RevokeUser(ctx, "user-1", cutoff, 15*time.Minute); err != nil {
+				t.Fatalf("RevokeUser() error = %v", err)
+			}
+
+			tests := []struct {
+				name  string
+				token Token
+				want  bool
+			}{
+				{"issued before cutoff", Token{ID: "a", UserID: "user-1", IssuedAt: cutoff.Add(-time.Minute)}, true},
+				{"issued in cutoff second", Token{ID: "b", UserID: "user-1", IssuedAt: cutoff.Add(500 * time.Millisecond)}, true},
+				{"issued after cutoff", Token{ID: "c", UserID: "user-1", IssuedAt: cutoff.Add(time.Second)}, false},
+				{"other user", Token{ID: "d", UserID: "user-2", IssuedAt: cutoff.Add(-time.Minute)}, false},
+			}
+			for _, tt := range tests {
+				got, err := l.IsRevoked(ctx, tt.token)
+				if err != nil {
+					t.Fatalf("%s: IsRevoked() error = %v", tt.name, err)
+				}
+				if got != tt.want {
+					t.Errorf("%s: IsRevoked() = %v, want %v", tt.name, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestMemoryList_EntriesExpire(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	l := NewMemoryList()
+	l.now = func() time.Time { return now }
+
+	l.RevokeToken(ctx, "jti-1", now.Add(time.Minute))
+	l.RevokeUser(ctx, "user-1", now, time.Minute)
+
+	now = now.Add(time.Minute)
+	token := Token{ID: "jti-1", UserID: "user-1", IssuedAt: now.Add(-2 * time.Minute)}
+	if revoked, _ := l.IsRevoked(ctx, token); revoked {
+		t.Error("expected revocations to lapse once the tokens expired")
+	}
+
+	l.RevokeToken(ctx, "jti-2", now.Add(time.Minute))
+	if len(l.tokens) != 1 || len(l.users) != 0 {
+		t.Errorf("expected expired entries to be swept, got %d tokens and %d users", len(l.tokens), len(l.users))
+	}
+}
+
+func TestRedisList_EntriesExpire(t *testing.T) {
+	all, mr := lists(t)
+	l := all["redis"]
+	ctx := context.Background()
+	issued := time.Now().Add(-time.Minute)
+
+	l.RevokeToken(ctx, "jti-1", time.Now().Add(time.Minute))
+	l.RevokeUser(ctx, "user-1", time.Now(), time.Minute)
+
+	mr.FastForward(time.Minute + time.Second)
+
+	if revoked, err := l.IsRevoked(ctx, Token{ID: "jti-1", UserID: "user-1", IssuedAt: issued}); err != nil || revoked {
+		t.Errorf("IsRevoked() = %v, %v; want false once the keys expired", revoked, err)
+	}
+}
@@ -0,0 +1,128 @@
+package settings
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// ReloadPath is where services mount the Reloader's HTTP handler.
+const ReloadPath = "/internal/config/reload"
+
+type hook struct {
+	name  string
+	apply func() error
+}
+
+// Reloader rereads the configuration file and applies the values that can
+// change at runtime.
+type Reloader struct {
+	loader *Loader
+	// token guards the HTTP trigger; without it the endpoint is disabled
+	token string
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+// NewReloader creates a reloader for loader. The HTTP trigger accepts
+// requests carrying CONFIG_RELOAD_TOKEN as a bearer token.
+func NewReloader(loader *Loader) *Reloader {
+	return &Reloader{loader: loader, token: os.Getenv("CONFIG_RELOAD_TOKEN")}
+}
+
+// OnReload registers apply to run after the file is reread. apply reads its
+// values from the environment and should validate them before changing
+// anything, so a bad value leaves the previous one in place.
+func (r *Reloader) OnReload(name string, apply func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = append(r.hooks, hook{name: name, apply: apply})
+}
+
+// Reload rereads the file and runs every hook. A failing hook doesn't stop
+// the others; their errors are returned together.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.loader.Load(); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, h := range r.hooks {
+		if err := h.apply(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WatchSignals reloads on every SIGHUP until ctx is cancelled.
+func (r *Reloader) WatchSignals(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				if err := r.Reload(); err != nil {
+					log.Printf("config reload failed: %v", err)
+				} else {
+					log.Println("config reloaded")
+				}
+			}
+		}
+	}()
+}
+
+// ServeHTTP triggers a reload on POST with the reload token.
+func (r *Reloader) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.token == "" {
+		httpx.NotFound(w, req, "NOT_FOUND", "Config reload is disabled")
+		return
+	}
+	if req.Method != http.MethodPost {
+		httpx.WriteError(w, req, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Use POST to reload config")
+		return
+	}
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(r.token)) != 1 {
+		httpx.Unauthorized(w, req, "INVALID_TOKEN", "Invalid reload token")
+		return
+	}
+
+	if err := r.Reload(); err != nil {
+		log.Printf("config reload failed: %v", err)
+		httpx.WriteError(w, req, http.StatusUnprocessableEntity, "RELOAD_FAILED", err.Error())
+		return
+	}
+	httpx.NoContent(w)
+}
+
+// Mount serves the reload endpoint at ReloadPath and everything else
+// with next.
+func (r *Reloader) Mount(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == ReloadPath {
+			r.ServeHTTP(w, req)
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
@@ -0,0 +1,149 @@
+// Package settings layers a configuration file under the process
+// environment and reloads non-critical values while a service runs.
+//
+// Services keep reading their configuration from environment variables.
+// Load reads the file named by CONFIG_FILE (KEY=VALUE lines, as in a .env
+// file) and sets every key the environment doesn't already set, so the
+// environment always wins over the file. Values read once at startup, such
+// as database addresses and ports, need a restart to change; values with a
+// reload hook (log level, rate limits, feature flags) change on SIGHUP or a
+// call to the reload endpoint.
+package settings
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrMissingRequired is returned by Require in strict mode.
+var ErrMissingRequired = errors.New("required configuration not set")
+
+// Loader applies a configuration file to the process environment.
+type Loader struct {
+	path string
+
+	mu sync.Mutex
+	// fromFile holds the keys the file set, which a reload may change.
+	// Keys set by the environment itself are never touched.
+	fromFile map[string]bool
+}
+
+// NewLoader creates a loader for the file at path. An empty path loads
+// nothing.
+func NewLoader(path string) *Loader {
+	return &Loader{path: path, fromFile: make(map[string]bool)}
+}
+
+// Load reads CONFIG_FILE, if set, into the environment and returns the
+// loader so the file can be read again on reload.
+func Load() (*Loader, error) {
+	l := NewLoader(os.Getenv("CONFIG_FILE"))
+	if err := l.Load(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Path returns the file the loader reads.
+func (l *Loader) Path() string {
+	return l.path
+}
+
+// Load reads the file and sets its keys in the environment. Keys removed
+// from the file since the last load are unset, so their defaults apply.
+func (l *Loader) Load() error {
+	if l.path == "" {
+		return nil
+	}
+	values, err := readFile(l.path)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); set && !l.fromFile[key] {
+			continue
+		}
+		os.Setenv(key, value)
+		l.fromFile[key] = true
+	}
+	for key := range l.fromFile {
+		if _, ok := values[key]; !ok {
+			os.Unsetenv(key)
+			delete(l.fromFile, key)
+		}
+	}
+	return nil
+}
+
+// readFile parses KEY=VALUE lines. Blank lines, # comments and a leading
+// "export " are allowed, and values may be wrapped in matching quotes.
+func readFile(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer f.Close()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=VALUE", path, n)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return values, nil
+}
+
+// Strict reports whether CONFIG_STRICT is on, as it should be in
+// production.
+func Strict() bool {
+	strict, _ := strconv.ParseBool(os.Getenv("CONFIG_STRICT"))
+	return strict
+}
+
+// Require checks that every key is set. In strict mode a missing key is an
+// error; otherwise the missing keys are logged and the service falls back
+// to its development defaults.
+func Require(keys ...string) error {
+	var missing []string
+	for _, key := range keys {
+		if os.Getenv(key) == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if Strict() {
+		return fmt.Errorf("%w: %s", ErrMissingRequired, strings.Join(missing, ", "))
+	}
+	log.Printf("warning: %s not set, using development defaults", strings.Join(missing, ", "))
+	return nil
+}
@@ -0,0 +1,142 @@
+package settings
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+}
+
+func TestLoader_Layering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	writeFile(t, path, `
+# comment
+export SETTINGS_TEST_FILE_ONLY=from-file
+SETTINGS_TEST_OVERRIDDEN = "from-file"
+SETTINGS_TEST_REMOVED='soon gone'
+`)
+	t.Setenv("SETTINGS_TEST_OVERRIDDEN", "from-env")
+	t.Cleanup(func() {
+		os.Unsetenv("SETTINGS_TEST_FILE_ONLY")
+		os.Unsetenv("SETTINGS_TEST_REMOVED")
+	})
+
+	l := NewLoader(path)
+	if err := l.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := os.Getenv("SETTINGS_TEST_FILE_ONLY"); got != "from-file" {
+		t.Errorf("SETTINGS_TEST_FILE_ONLY = %q, want from-file", got)
+	}
+	if got := os.Getenv("SETTINGS_TEST_OVERRIDDEN"); got != "from-env" {
+		t.Errorf("environment should win over the file, got %q", got)
+	}
+	if got := os.Getenv("SETTINGS_TEST_REMOVED"); got != "soon gone" {
+		t.Errorf("quotes should be stripped, got %q", got)
+	}
+
+	writeFile(t, path, "SETTINGS_TEST_FILE_ONLY=changed\nSETTINGS_TEST_OVERRIDDEN=changed\n")
+	if err := l.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if got := os.Getenv("SETTINGS_TEST_FILE_ONLY"); got != "changed" {
+		t.Errorf("reload should update file values, got %q", got)
+	}
+	if got := os.Getenv("SETTINGS_TEST_OVERRIDDEN"); got != "from-env" {
+		t.Errorf("reload should not override the environment, got %q", got)
+	}
+	if _, set := os.LookupEnv("SETTINGS_TEST_REMOVED"); set {
+		t.Error("keys removed from the file should be unset")
+	}
+}
+
+func TestLoader_InvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	writeFile(t, path, "VALID=1\nnot a setting\n")
+
+	if err := NewLoader(path).Load(); err == nil {
+		t.Error("expected an error for a line without =")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	t.Setenv("SETTINGS_TEST_SET", "value")
+	os.Unsetenv("SETTINGS_TEST_UNSET")
+
+	t.Setenv("CONFIG_STRICT", "false")
+	if err := Require("SETTINGS_TEST_SET", "SETTINGS_TEST_UNSET"); err != nil {
+		t.Errorf("non-strict Require() error = %v, want nil", err)
+	}
+
+	t.Setenv("CONFIG_STRICT", "true")
+	if err := Require("SETTINGS_TEST_SET"); err != nil {
+		t.Errorf("Require() error = %v, want nil", err)
+	}
+	if err := Require("SETTINGS_TEST_SET", "SETTINGS_TEST_UNSET"); !errors.Is(err, ErrMissingRequired) {
+		t.Errorf("strict Require() error = %v, want ErrMissingRequired", err)
+	}
+}
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.env")
+	writeFile(t, path, "SETTINGS_TEST_LEVEL=info\n")
+	t.Cleanup(func() { os.Unsetenv("SETTINGS_TEST_LEVEL") })
+	t.Setenv("CONFIG_RELOAD_TOKEN", "secret")
+
+	l := NewLoader(path)
+	if err := l.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	var level string
+	r := NewReloader(l)
+	r.OnReload("level", func() error {
+		level = os.Getenv("SETTINGS_TEST_LEVEL")
+		return nil
+	})
+	r.OnReload("broken", func() error { return errors.New("bad value") })
+
+	writeFile(t, path, "SETTINGS_TEST_LEVEL=debug\n")
+	handler := r.Mount(http.NotFoundHandler())
+
+	tests := []struct {
+		name       string
+		method     string
+		token      string
+		wantStatus int
+	}{
+		{"missing token", http.MethodPost, "", http.StatusUnauthorized},
+		{"wrong method", http.MethodGet, "secret", http.StatusMethodNotAllowed},
+		{"failing hook", http.MethodPost, "secret", http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, ReloadPath, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+
+	// The failing hook doesn't stop the others from applying
+	if level != "debug" {
+		t.Errorf("level = %q, want debug", level)
+	}
+}
@@ -0,0 +1,146 @@
+// Package startup waits for a service's dependencies to become reachable
+// before it starts serving.
+//
+// In docker-compose (and on a fresh Kubernetes rollout) Postgres and Kafka
+// are often still booting when the services start. Rather than crash on the
+// first failed ping, a service retries with exponential backoff and gives up
+// only once MaxWait has passed.
+package startup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/segmentio/kafka-go"
+)
+
+// ErrNotReady is returned when a dependency is still unreachable at the deadline.
+var ErrNotReady = errors.New("startup: dependency not ready")
+
+// Default retry settings.
+const (
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+	DefaultMaxWait        = 60 * time.Second
+)
+
+// Config controls how long a service waits for its dependencies.
+type Config struct {
+	// InitialBackoff is the pause after the first failed attempt; it doubles
+	// after every further failure up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// MaxWait bounds the total time spent waiting for one dependency.
+	MaxWait time.Duration
+}
+
+// DefaultConfig returns the settings used when nothing is configured.
+func DefaultConfig() Config {
+	return Config{
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		MaxWait:        DefaultMaxWait,
+	}
+}
+
+// ConfigFromEnv reads STARTUP_INITIAL_BACKOFF, STARTUP_MAX_BACKOFF and
+// STARTUP_MAX_WAIT (durations such as "30s"), falling back to DefaultConfig
+// for anything unset.
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	durations := []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"STARTUP_INITIAL_BACKOFF", &cfg.InitialBackoff},
+		{"STARTUP_MAX_BACKOFF", &cfg.MaxBackoff},
+		{"STARTUP_MAX_WAIT", &cfg.MaxWait},
+	}
+	for _, d := range durations {
+		value := os.Getenv(d.key)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed <= 0 {
+			return Config{}, fmt.Errorf("invalid %s: %q", d.key, value)
+		}
+		*d.dst = parsed
+	}
+	return cfg, nil
+}
+
+// Check reports whether a dependency is reachable.
+type Check func(ctx context.Context) error
+
+// Wait runs check until it succeeds, sleeping between attempts with
+// exponential backoff. It logs every failed attempt and returns ErrNotReady
+// once cfg.MaxWait has passed.
+func Wait(ctx context.Context, cfg Config, logger logging.Logger, name string, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, cfg.MaxWait)
+	defer cancel()
+
+	start := time.Now()
+	backoff := cfg.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := check(ctx)
+		if err == nil {
+			if attempt > 1 {
+				logger.Info("dependency ready",
+					logging.String("dependency", name),
+					logging.Int("attempts", attempt),
+					logging.Duration("waited", time.Since(start)))
+			}
+			return nil
+		}
+
+		logger.Warn("waiting for dependency",
+			logging.String("dependency", name),
+			logging.Int("attempt", attempt),
+			logging.Duration("retry_in", backoff),
+			logging.Err(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w: %s unreachable after %d attempts: %w", ErrNotReady, name, attempt, err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// Postgres checks that the pool can reach the database.
+func Postgres(pool *pgxpool.Pool) Check {
+	return pool.Ping
+}
+
+// Kafka checks that at least one of the brokers accepts connections.
+func Kafka(brokers []string) Check {
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, broker := range brokers {
+			conn, err := kafka.DialContext(ctx, "tcp", broker)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			return conn.Close()
+		}
+		if len(errs) == 0 {
+			return errors.New("no kafka brokers configured")
+		}
+		return errors.Join(errs...)
+	}
+}
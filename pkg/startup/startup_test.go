@@ -0,0 +1,80 @@
+package startup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/logging"
+)
+
+func testConfig() Config {
+	return Config{
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     4 * time.Millisecond,
+		MaxWait:        200 * time.Millisecond,
+	}
+}
+
+func TestWait_SucceedsAfterRetries(t *testing.T) {
+	attempts := 0
+	err := Wait(context.Background(), testConfig(), logging.Nop(), "db", func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestWait_FailsAfterMaxWait(t *testing.T) {
+	cfg := testConfig()
+	cfg.MaxWait = 20 * time.Millisecond
+	refused := errors.New("connection refused")
+
+	start := time.Now()
+	err := Wait(context.Background(), cfg, logging.Nop(), "db", func(ctx context.Context) error {
+		return refused
+	})
+	if !errors.Is(err, ErrNotReady) || !errors.Is(err, refused) {
+		t.Fatalf("Wait() error = %v, want ErrNotReady wrapping the last failure", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait() took %v, expected to stop near MaxWait", elapsed)
+	}
+}
+
+func TestWait_StopsWhenContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Wait(ctx, DefaultConfig(), logging.Nop(), "kafka", func(ctx context.Context) error {
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrNotReady) {
+		t.Fatalf("Wait() error = %v, want ErrNotReady", err)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("STARTUP_MAX_WAIT", "2m")
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv() error = %v", err)
+	}
+	if cfg.MaxWait != 2*time.Minute || cfg.InitialBackoff != DefaultInitialBackoff {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+
+	t.Setenv("STARTUP_MAX_BACKOFF", "soon")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("expected an error for an invalid duration")
+	}
+}
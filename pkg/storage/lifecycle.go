@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LifecycleRule expires the objects under Prefix once they are older than
+// MaxAge, such as abandoned uploads or short-lived exports. Rules work the
+// same on every backend; S3 buckets may also have their own lifecycle
+// configuration.
+type LifecycleRule struct {
+	Prefix string
+	MaxAge time.Duration
+}
+
+// Expired reports whether the rule has expired the object at now
+func (r LifecycleRule) Expired(o Object, now time.Time) bool {
+	return strings.HasPrefix(o.Key, r.Prefix) && now.Sub(o.LastModified) > r.MaxAge
+}
+
+// ExpiresAt is when an object written at modified expires under the rule
+func (r LifecycleRule) ExpiresAt(modified time.Time) time.Time {
+	return modified.Add(r.MaxAge)
+}
+
+// Sweep deletes the objects the rules have expired at now and returns how
+// many it deleted. A rule without a positive MaxAge is skipped. Deleting
+// stops at the first error, which is returned with the count so far.
+func Sweep(ctx context.Context, store Store, rules []LifecycleRule, now time.Time) (int, error) {
+	deleted := 0
+	for _, rule := range rules {
+		if rule.MaxAge <= 0 {
+			continue
+		}
+		var expired []string
+		err := store.List(ctx, rule.Prefix, func(o Object) error {
+			if rule.Expired(o, now) {
+				expired = append(expired, o.Key)
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to list %q: %w", rule.Prefix, err)
+		}
+		for _, key := range expired {
+			if err := store.Delete(ctx, key); err != nil && !errors.Is(err, ErrNotFound) {
+				return deleted, fmt.Errorf("failed to delete %q: %w", key, err)
+			}
+			deleted++
+		}
+	}
+	return deleted, nil
+}
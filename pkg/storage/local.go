@@ -0,0 +1,330 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// LocalConfig places objects in a directory, for development and tests
+type LocalConfig struct {
+	Dir string
+	// BaseURL is where the store's Handler is served, such as
+	// http://localhost:8084/files; presigned requests point there
+	BaseURL string
+	// SigningKey signs presigned requests
+	SigningKey string
+}
+
+// LocalStore keeps objects as files under a directory. Presigned requests
+// are served by its Handler, which the service mounts at BaseURL.
+type LocalStore struct {
+	objects string
+	meta    string
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+// localMeta is kept beside each object for what the file system does not
+// record
+type localMeta struct {
+	ContentType string `json:"content_type"`
+}
+
+func NewLocalStore(config LocalConfig) (*LocalStore, error) {
+	if config.Dir == "" {
+		return nil, errors.New("a directory is required")
+	}
+	if len(config.SigningKey) < 16 {
+		return nil, errors.New("a signing key of at least 16 characters is required")
+	}
+	if u, err := url.Parse(config.BaseURL); err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q", config.BaseURL)
+	}
+
+	s := &LocalStore{
+		objects: filepath.Join(config.Dir, "objects"),
+		meta:    filepath.Join(config.Dir, "meta"),
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		key:     []byte(config.SigningKey),
+		now:     time.Now,
+	}
+	for _, dir := range []string{s.objects, s.meta} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+	return s, nil
+}
+
+// Put writes the object to a temporary file and renames it into place, so
+// readers never see part of a file
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	path := s.objectPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, io.LimitReader(body, size+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if n != size {
+		return ErrSizeMismatch
+	}
+
+	if err := s.writeMeta(key, localMeta{ContentType: contentType}); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	o, err := s.Stat(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(s.objectPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, err
+	}
+	return f, o, nil
+}
+
+func (s *LocalStore) Stat(ctx context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(s.objectPath(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, ErrNotFound
+	}
+
+	o := &Object{Key: key, Size: info.Size(), LastModified: info.ModTime().UTC()}
+	if data, err := os.ReadFile(s.metaPath(key)); err == nil {
+		var meta localMeta
+		if json.Unmarshal(data, &meta) == nil {
+			o.ContentType = meta.ContentType
+		}
+	}
+	return o, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	for _, path := range []string{s.objectPath(key), s.metaPath(key)} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	return filepath.WalkDir(s.objects, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.objects, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(Object{Key: key, Size: info.Size(), LastModified: info.ModTime().UTC()})
+	})
+}
+
+func (s *LocalStore) PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedRequest, error) {
+	headers := map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": strconv.FormatInt(size, 10),
+	}
+	return s.presign(http.MethodPut, key, contentType, size, headers, ttl)
+}
+
+func (s *LocalStore) PresignGet(ctx context.Context, key string, ttl time.Duration) (*PresignedRequest, error) {
+	return s.presign(http.MethodGet, key, "", 0, nil, ttl)
+}
+
+func (s *LocalStore) presign(method, key, contentType string, size int64, headers map[string]string, ttl time.Duration) (*PresignedRequest, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	if err := checkExpiry(ttl); err != nil {
+		return nil, err
+	}
+	expiresAt := s.now().UTC().Add(ttl).Truncate(time.Second)
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	query := url.Values{}
+	query.Set("expires", expires)
+	query.Set("signature", s.sign(method, key, expires, contentType, size))
+	if method == http.MethodPut {
+		query.Set("size", strconv.FormatInt(size, 10))
+		query.Set("type", contentType)
+	}
+
+	return &PresignedRequest{
+		Method:    method,
+		URL:       s.baseURL + "/" + uriEncode(key, false) + "?" + query.Encode(),
+		Headers:   headers,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Handler serves presigned requests: GET and HEAD download an object, PUT
+// uploads one. Mount it at BaseURL with the prefix stripped.
+func (s *LocalStore) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/")
+		query := r.URL.Query()
+
+		method := r.Method
+		if method == http.MethodHead {
+			method = http.MethodGet
+		}
+		var size int64
+		contentType := ""
+		if method == http.MethodPut {
+			size, _ = strconv.ParseInt(query.Get("size"), 10, 64)
+			contentType = query.Get("type")
+		}
+		if method != http.MethodGet && method != http.MethodPut {
+			httpx.WriteError(w, r, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "Only GET, HEAD and PUT are supported")
+			return
+		}
+
+		expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+		want := s.sign(method, key, query.Get("expires"), contentType, size)
+		if err != nil || !hmac.Equal([]byte(query.Get("signature")), []byte(want)) || ValidateKey(key) != nil {
+			httpx.WriteError(w, r, http.StatusForbidden, "INVALID_SIGNATURE", "The request signature is not valid")
+			return
+		}
+		if s.now().Unix() > expires {
+			httpx.WriteError(w, r, http.StatusForbidden, "REQUEST_EXPIRED", "The presigned request has expired")
+			return
+		}
+
+		if method == http.MethodPut {
+			s.serveUpload(w, r, key, contentType, size)
+			return
+		}
+		s.serveDownload(w, r, key)
+	})
+}
+
+func (s *LocalStore) serveUpload(w http.ResponseWriter, r *http.Request, key, contentType string, size int64) {
+	if r.ContentLength != size || r.Header.Get("Content-Type") != contentType {
+		httpx.WriteError(w, r, http.StatusForbidden, "SIGNATURE_MISMATCH", "The upload does not match the signed content type and length")
+		return
+	}
+	if err := s.Put(r.Context(), key, r.Body, size, contentType); err != nil {
+		if errors.Is(err, ErrSizeMismatch) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INCOMPLETE_BODY", "The upload is not the signed length")
+			return
+		}
+		httpx.InternalError(w, r)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *LocalStore) serveDownload(w http.ResponseWriter, r *http.Request, key string) {
+	body, o, err := s.Get(r.Context(), key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			httpx.WriteError(w, r, http.StatusNotFound, "NOT_FOUND", "Object not found")
+			return
+		}
+		httpx.InternalError(w, r)
+		return
+	}
+	defer body.Close()
+
+	if o.ContentType != "" {
+		w.Header().Set("Content-Type", o.ContentType)
+	}
+	http.ServeContent(w, r, "", o.LastModified, body.(io.ReadSeeker))
+}
+
+// sign is the HMAC of everything a presigned request is bound to
+func (s *LocalStore) sign(method, key, expires, contentType string, size int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%d", method, key, expires, contentType, size)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStore) writeMeta(key string, meta localMeta) error {
+	path := s.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write metadata: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) objectPath(key string) string {
+	return filepath.Join(s.objects, filepath.FromSlash(key))
+}
+
+func (s *LocalStore) metaPath(key string) string {
+	return filepath.Join(s.meta, filepath.FromSlash(key)+".json")
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestLocalStore(t *testing.T) *LocalStore {
+	t.Helper()
+	store, err := NewLocalStore(LocalConfig{
+		Dir:        t.TempDir(),
+		BaseURL:    "http://localhost:8080/files",
+		SigningKey: "test-signing-key-0123456789",
+	})
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+	return store
+}
+
+func TestLocalStore_Objects(t *testing.T) {
+	testStore(t, newTestLocalStore(t))
+}
+
+func TestLocalStore_PutChecksSize(t *testing.T) {
+	store := newTestLocalStore(t)
+	err := store.Put(context.Background(), "short.jpg", strings.NewReader("abc"), 10, "image/jpeg")
+	if err != ErrSizeMismatch {
+		t.Errorf("Put() of a short body error = %v, want %v", err, ErrSizeMismatch)
+	}
+	if _, err := store.Stat(context.Background(), "short.jpg"); err != ErrNotFound {
+		t.Errorf("Stat() after a failed Put() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestLocalStore_Presigned(t *testing.T) {
+	store := newTestLocalStore(t)
+	server := httptest.NewServer(http.StripPrefix("/files", store.Handler()))
+	defer server.Close()
+	store.baseURL = server.URL + "/files"
+	ctx := context.Background()
+
+	send := func(p *PresignedRequest, body string, headers map[string]string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(p.Method, p.URL, strings.NewReader(body))
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s error = %v", p.Method, p.URL, err)
+		}
+		return resp
+	}
+
+	put, err := store.PresignPut(ctx, "sessions/a/photo.jpg", "image/jpeg", 5, time.Minute)
+	if err != nil {
+		t.Fatalf("PresignPut() error = %v", err)
+	}
+
+	if resp := send(put, "hello", map[string]string{"Content-Type": "image/png"}); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("PUT with another content type = %d, want 403", resp.StatusCode)
+	}
+	if resp := send(put, "hello!", put.Headers); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("PUT of another length = %d, want 403", resp.StatusCode)
+	}
+	tampered := *put
+	tampered.URL = strings.Replace(put.URL, "photo.jpg", "other.jpg", 1)
+	if resp := send(&tampered, "hello", put.Headers); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("PUT to another key = %d, want 403", resp.StatusCode)
+	}
+	if resp := send(put, "hello", put.Headers); resp.StatusCode != http.StatusOK {
+		t.Fatalf("PUT = %d, want 200", resp.StatusCode)
+	}
+
+	get, err := store.PresignGet(ctx, "sessions/a/photo.jpg", time.Minute)
+	if err != nil {
+		t.Fatalf("PresignGet() error = %v", err)
+	}
+	resp := send(get, "", nil)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "hello" || resp.Header.Get("Content-Type") != "image/jpeg" {
+		t.Errorf("GET = %d %q %s, want the uploaded photo", resp.StatusCode, body, resp.Header.Get("Content-Type"))
+	}
+
+	store.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	if resp := send(get, "", nil); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("GET after expiry = %d, want 403", resp.StatusCode)
+	}
+}
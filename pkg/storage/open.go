@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Supported store backends
+const (
+	BackendS3    = "s3"
+	BackendLocal = "local"
+)
+
+// Config selects and configures the store backend. S3 also covers MinIO and
+// other S3-compatible stores.
+type Config struct {
+	Backend string
+	S3      S3Config
+	Local   LocalConfig
+}
+
+// Open creates the configured backend
+func Open(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendS3:
+		return NewS3Store(cfg.S3)
+	case BackendLocal:
+		return NewLocalStore(cfg.Local)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// ConfigFromEnv reads a store's settings from environment variables named
+// with prefix, so each use of storage in a service is configured apart:
+//
+//	{prefix}_STORAGE_BACKEND   s3 or local; empty leaves Backend empty
+//	{prefix}_S3_ENDPOINT, {prefix}_S3_PUBLIC_ENDPOINT, {prefix}_S3_REGION,
+//	{prefix}_S3_BUCKET, {prefix}_S3_ACCESS_KEY, {prefix}_S3_SECRET_KEY,
+//	{prefix}_S3_PATH_STYLE   true for MinIO
+//	{prefix}_LOCAL_DIR, {prefix}_LOCAL_BASE_URL, {prefix}_LOCAL_SIGNING_KEY
+func ConfigFromEnv(prefix string) (Config, error) {
+	env := func(name string) string { return os.Getenv(prefix + "_" + name) }
+
+	cfg := Config{
+		Backend: env("STORAGE_BACKEND"),
+		S3: S3Config{
+			Endpoint:       env("S3_ENDPOINT"),
+			PublicEndpoint: env("S3_PUBLIC_ENDPOINT"),
+			Region:         env("S3_REGION"),
+			Bucket:         env("S3_BUCKET"),
+			AccessKey:      env("S3_ACCESS_KEY"),
+			SecretKey:      env("S3_SECRET_KEY"),
+		},
+		Local: LocalConfig{
+			Dir:        env("LOCAL_DIR"),
+			BaseURL:    env("LOCAL_BASE_URL"),
+			SigningKey: env("LOCAL_SIGNING_KEY"),
+		},
+	}
+	if value := env("S3_PATH_STYLE"); value != "" {
+		pathStyle, err := strconv.ParseBool(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid %s_S3_PATH_STYLE: %q", prefix, value)
+		}
+		cfg.S3.UsePathStyle = pathStyle
+	}
+	switch cfg.Backend {
+	case "", BackendS3, BackendLocal:
+		return cfg, nil
+	default:
+		return Config{}, fmt.Errorf("invalid %s_STORAGE_BACKEND %q", prefix, cfg.Backend)
+	}
+}
@@ -0,0 +1,427 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	unsignedPayload  = "UNSIGNED-PAYLOAD"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// S3Config locates a bucket and the credentials that can use it
+type S3Config struct {
+	// Endpoint is the store's base URL, such as http://minio:9000. Empty
+	// means AWS S3 in Region.
+	Endpoint string
+	// PublicEndpoint, when set, is the base URL presigned requests point at,
+	// for a store clients reach by another name than services do
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+	// UsePathStyle puts the bucket in the path rather than the host name,
+	// as MinIO expects
+	UsePathStyle bool
+	Timeout      time.Duration
+}
+
+// S3Store keeps objects in an S3 bucket. Requests are signed with AWS
+// Signature Version 4.
+type S3Store struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Store(config S3Config) (*S3Store, error) {
+	if config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+		return nil, errors.New("bucket, access key and secret key are required")
+	}
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+	}
+	for _, endpoint := range []string{config.Endpoint, config.PublicEndpoint} {
+		if endpoint == "" {
+			continue
+		}
+		if u, err := url.Parse(endpoint); err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q", endpoint)
+		}
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+
+	return &S3Store{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+		now:    time.Now,
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	u := s.objectURL(s.config.Endpoint, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := s.do(req, u)
+	if err != nil {
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put object: %w", responseError(resp))
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.objectRequest(ctx, http.MethodGet, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get object: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil, ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to get object: %w", responseError(resp))
+	}
+	return resp.Body, objectFromResponse(key, resp), nil
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (*Object, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	resp, err := s.objectRequest(ctx, http.MethodHead, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return objectFromResponse(key, resp), nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("failed to stat object: %w", responseError(resp))
+	}
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+	resp, err := s.objectRequest(ctx, http.MethodDelete, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete object: %w", responseError(resp))
+	}
+}
+
+// listResult is the part of a ListObjectsV2 response List reads
+type listResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List pages through the bucket with ListObjectsV2
+func (s *S3Store) List(ctx context.Context, prefix string, fn func(Object) error) error {
+	token := ""
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		query.Set("prefix", prefix)
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u := s.objectURL(s.config.Endpoint, "")
+		u.RawQuery = canonicalQuery(query)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := s.do(req, u)
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+		var page listResult
+		if resp.StatusCode != http.StatusOK {
+			err = responseError(resp)
+		} else {
+			err = xml.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, c := range page.Contents {
+			if err := fn(Object{Key: c.Key, Size: c.Size, LastModified: c.LastModified}); err != nil {
+				return err
+			}
+		}
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// PresignPut signs the content type and length, so the store refuses any
+// other file
+func (s *S3Store) PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedRequest, error) {
+	headers := map[string]string{
+		"Content-Type":   contentType,
+		"Content-Length": strconv.FormatInt(size, 10),
+	}
+	return s.presign(http.MethodPut, key, headers, ttl)
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, ttl time.Duration) (*PresignedRequest, error) {
+	return s.presign(http.MethodGet, key, nil, ttl)
+}
+
+func (s *S3Store) presign(method, key string, headers map[string]string, ttl time.Duration) (*PresignedRequest, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+	if err := checkExpiry(ttl); err != nil {
+		return nil, err
+	}
+	endpoint := s.config.PublicEndpoint
+	if endpoint == "" {
+		endpoint = s.config.Endpoint
+	}
+	u := s.objectURL(endpoint, key)
+	now := s.now().UTC()
+
+	signed := map[string]string{"host": u.Host}
+	for name, value := range headers {
+		signed[strings.ToLower(name)] = value
+	}
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", signingAlgorithm)
+	query.Set("X-Amz-Credential", s.config.AccessKey+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format(amzDateFormat))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", headerNames(signed))
+	u.RawQuery = canonicalQuery(query)
+
+	signature := s.signature(method, u, signed, now)
+	u.RawQuery += "&X-Amz-Signature=" + signature
+
+	return &PresignedRequest{
+		Method:    method,
+		URL:       u.String(),
+		Headers:   headers,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+func (s *S3Store) objectRequest(ctx context.Context, method, key string) (*http.Response, error) {
+	u := s.objectURL(s.config.Endpoint, key)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.do(req, u)
+}
+
+// do signs the request in its Authorization header and sends it. The body
+// is not hashed, so it can be streamed.
+func (s *S3Store) do(req *http.Request, u *url.URL) (*http.Response, error) {
+	now := s.now().UTC()
+	req.Header.Set("X-Amz-Date", now.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signed := map[string]string{
+		"host":                 u.Host,
+		"x-amz-date":           req.Header.Get("X-Amz-Date"),
+		"x-amz-content-sha256": unsignedPayload,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		signed["content-type"] = contentType
+	}
+	signature := s.signature(req.Method, u, signed, now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, s.config.AccessKey, s.scope(now), headerNames(signed), signature))
+
+	return s.client.Do(req)
+}
+
+// objectURL addresses key in the bucket, path or virtual-hosted style. An
+// empty key addresses the bucket itself.
+func (s *S3Store) objectURL(endpoint, key string) *url.URL {
+	u, _ := url.Parse(strings.TrimRight(endpoint, "/"))
+	path := "/" + key
+	if s.config.UsePathStyle {
+		path = "/" + s.config.Bucket
+		if key != "" {
+			path += "/" + key
+		}
+	} else {
+		u.Host = s.config.Bucket + "." + u.Host
+	}
+	u.Path = path
+	u.RawPath = uriEncode(path, false)
+	return u
+}
+
+func (s *S3Store) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.config.Region + "/s3/aws4_request"
+}
+
+// signature signs a request whose query, if any, is already canonical
+func (s *S3Store) signature(method string, u *url.URL, headers map[string]string, now time.Time) string {
+	names := strings.Split(headerNames(headers), ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		u.EscapedPath(),
+		u.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(names, ";"),
+		unsignedPayload,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		signingAlgorithm,
+		now.Format(amzDateFormat),
+		s.scope(now),
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func objectFromResponse(key string, resp *http.Response) *Object {
+	o := &Object{Key: key, Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		o.LastModified = modified
+	}
+	return o
+}
+
+// responseError describes a failed response, with the S3 error code when
+// the body has one
+func responseError(resp *http.Response) error {
+	var body struct {
+		Code string `xml:"Code"`
+	}
+	if xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body) == nil && body.Code != "" {
+		return fmt.Errorf("storage returned %s (%s)", resp.Status, body.Code)
+	}
+	return fmt.Errorf("storage returned %s", resp.Status)
+}
+
+// headerNames lists the lowercase header names, sorted and joined with
+// semicolons as SigV4 signs them
+func headerNames(headers map[string]string) string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ";")
+}
+
+// canonicalQuery sorts and encodes query parameters as SigV4 requires
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		for _, v := range query[k] {
+			parts = append(parts, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// uriEncode escapes everything but unreserved characters, and slashes
+// unless encodeSlash is set
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,89 @@
+// Package storage keeps files for services in object storage: S3 or an
+// S3-compatible store such as MinIO, or a local directory for development.
+//
+// Services depend on the Store interface and are handed a backend from
+// Open. Clients can be given presigned requests to upload and download
+// files directly, so large files never pass through a service; a presigned
+// upload is bound to the content type and size it was signed for.
+// LifecycleRule and Sweep expire files a service only keeps for a while.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("object not found")
+	ErrInvalidKey    = errors.New("object keys must be relative paths without empty, . or .. segments")
+	ErrInvalidExpiry = errors.New("presigned requests must expire within 7 days")
+	ErrSizeMismatch  = errors.New("object body does not match its declared size")
+)
+
+// MaxPresignExpiry is the longest a presigned request can stay valid, the
+// limit S3 sets
+const MaxPresignExpiry = 7 * 24 * time.Hour
+
+// Store keeps objects under slash-separated keys such as
+// "sessions/{id}/{file}.jpg"
+type Store interface {
+	// Put writes exactly size bytes from body, replacing any object at key
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object; the caller closes the body
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	// Stat describes the object, or returns ErrNotFound
+	Stat(ctx context.Context, key string) (*Object, error)
+	// Delete removes the object; deleting a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// List calls fn for each object whose key starts with prefix, until fn
+	// returns an error
+	List(ctx context.Context, prefix string, fn func(Object) error) error
+	// PresignPut returns a request that uploads exactly size bytes of
+	// contentType to key
+	PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*PresignedRequest, error)
+	// PresignGet returns a request that downloads the object at key
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (*PresignedRequest, error)
+}
+
+// Object describes a stored file. Listings may leave ContentType empty.
+type Object struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	ContentType  string    `json:"content_type,omitempty"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// PresignedRequest is a request a client makes to storage itself. Headers
+// are signed and must be sent as given.
+type PresignedRequest struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ValidateKey checks a key is a clean relative path, so no backend can be
+// led outside its bucket or directory
+func ValidateKey(key string) error {
+	if key == "" || len(key) > 1024 || strings.ContainsAny(key, "\\\x00") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// checkExpiry validates a presigned request's lifetime
+func checkExpiry(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxPresignExpiry {
+		return fmt.Errorf("%w: got %s", ErrInvalidExpiry, ttl)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testStore runs the behaviour every backend shares
+func testStore(t *testing.T, store Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	for key, body := range map[string]string{
+		"sessions/a/1.jpg": "first",
+		"sessions/a/2.png": "second!",
+		"logos/p/orig.png": "logo",
+	} {
+		if err := store.Put(ctx, key, strings.NewReader(body), int64(len(body)), "image/png"); err != nil {
+			t.Fatalf("Put(%s) error = %v", key, err)
+		}
+	}
+
+	o, err := store.Stat(ctx, "sessions/a/2.png")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if o.Size != 7 || o.ContentType != "image/png" {
+		t.Errorf("Stat() = %+v", o)
+	}
+	if _, err := store.Stat(ctx, "sessions/a/missing.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat() of a missing object error = %v, want %v", err, ErrNotFound)
+	}
+
+	body, _, err := store.Get(ctx, "sessions/a/1.jpg")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "first" {
+		t.Errorf("Get() body = %q, want first", data)
+	}
+
+	var keys []string
+	if err := store.List(ctx, "sessions/", func(o Object) error {
+		keys = append(keys, o.Key)
+		return nil
+	}); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "sessions/a/1.jpg" || keys[1] != "sessions/a/2.png" {
+		t.Errorf("List() = %v, want the two session objects", keys)
+	}
+
+	if err := store.Delete(ctx, "sessions/a/1.jpg"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, "sessions/a/1.jpg"); err != nil {
+		t.Errorf("Delete() of a missing object error = %v", err)
+	}
+	if _, _, err := store.Get(ctx, "sessions/a/1.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want error
+	}{
+		{"sessions/a/b.jpg", nil},
+		{"logo.png", nil},
+		{"", ErrInvalidKey},
+		{"/absolute", ErrInvalidKey},
+		{"sessions//b.jpg", ErrInvalidKey},
+		{"sessions/../../etc/passwd", ErrInvalidKey},
+		{"./here", ErrInvalidKey},
+		{`windows\path`, ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if err := ValidateKey(tt.key); !errors.Is(err, tt.want) {
+				t.Errorf("ValidateKey(%q) = %v, want %v", tt.key, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	store := newTestLocalStore(t)
+	ctx := context.Background()
+	for _, key := range []string{"uploads/old.jpg", "uploads/new.jpg", "logos/old.png"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, "image/jpeg"); err != nil {
+			t.Fatalf("Put() error = %v", err)
+		}
+	}
+
+	// Everything was just written, so sweep from a day later with one rule
+	// for a day and a half and one for half a day
+	now := time.Now().Add(24 * time.Hour)
+	rules := []LifecycleRule{
+		{Prefix: "uploads/", MaxAge: 12 * time.Hour},
+		{Prefix: "logos/", MaxAge: 36 * time.Hour},
+		{Prefix: "", MaxAge: 0},
+	}
+	deleted, err := Sweep(ctx, store, rules, now)
+	if err != nil {
+		t.Fatalf("Sweep() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("Sweep() deleted %d, want the 2 uploads", deleted)
+	}
+	if _, err := store.Stat(ctx, "logos/old.png"); err != nil {
+		t.Errorf("Stat() of an unexpired object error = %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanIDFromContext extracts the span ID from context if available
+func SpanIDFromContext(ctx context.Context) string {
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		return span.SpanContext().SpanID().String()
+	}
+	return ""
+}
+
+// LogAttrs returns trace_id and span_id attributes for the active span, for
+// code that logs through log/slog directly rather than pkg/logging, whose
+// WithContext adds them already. It returns nil outside a span.
+func LogAttrs(ctx context.Context) []slog.Attr {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []slog.Attr{
+		slog.String("trace_id", sc.TraceID().String()),
+		slog.String("span_id", sc.SpanID().String()),
+	}
+}
+
+// AddLogAttrs returns a logger whose records carry the active span's
+// trace_id and span_id
+func AddLogAttrs(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	attrs := LogAttrs(ctx)
+	if attrs == nil {
+		return logger
+	}
+	args := make([]any, len(attrs))
+	for i, a := range attrs {
+		args[i] = a
+	}
+	return logger.With(args...)
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestAddLogAttrs(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+
+	var buf bytes.Buffer
+	AddLogAttrs(ctx, slog.New(slog.NewJSONHandler(&buf, nil))).Info("hello")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("invalid log record: %v", err)
+	}
+	if record["trace_id"] != traceID.String() || record["span_id"] != spanID.String() {
+		t.Errorf("record = %v, want trace_id %s and span_id %s", record, traceID, spanID)
+	}
+	if got := SpanIDFromContext(ctx); got != spanID.String() {
+		t.Errorf("SpanIDFromContext() = %q, want %q", got, spanID)
+	}
+}
+
+func TestLogAttrsWithoutSpan(t *testing.T) {
+	if attrs := LogAttrs(context.Background()); attrs != nil {
+		t.Errorf("LogAttrs() = %v, want nil outside a span", attrs)
+	}
+	logger := slog.Default()
+	if got := AddLogAttrs(context.Background(), logger); got != logger {
+		t.Error("AddLogAttrs() should return the logger unchanged outside a span")
+	}
+}
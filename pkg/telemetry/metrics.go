@@ -0,0 +1,68 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// InitMeter initializes OpenTelemetry metrics, pushed over OTLP to the same
+// collector as traces every cfg.MetricsInterval.
+// Returns a shutdown function that flushes the last readings; call it when
+// the application exits.
+func InitMeter(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	opts := []otlpmetricgrpc.Option{
+		otlpmetricgrpc.WithEndpoint(cfg.OTLPEndpoint),
+	}
+	if cfg.Insecure {
+		opts = append(opts, otlpmetricgrpc.WithInsecure())
+	}
+
+	exporter, err := otlpmetricgrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	res, err := newResource(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := cfg.MetricsInterval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter, sdkmetric.WithInterval(interval))),
+		sdkmetric.WithResource(res),
+	)
+	otel.SetMeterProvider(mp)
+
+	if cfg.RuntimeMetrics {
+		// Reading memory stats stops the world briefly, so read them no
+		// more often than they are exported
+		err := runtime.Start(
+			runtime.WithMeterProvider(mp),
+			runtime.WithMinimumReadMemStatsInterval(interval),
+		)
+		if err != nil {
+			mp.Shutdown(ctx)
+			return nil, fmt.Errorf("failed to start runtime metrics: %w", err)
+		}
+	}
+
+	return mp.Shutdown, nil
+}
+
+// Meter returns a named meter from the global meter provider. Until
+// InitMeter runs, instruments from it record nothing.
+func Meter(name string) metric.Meter {
+	return otel.Meter(name)
+}
@@ -3,6 +3,7 @@ package telemetry
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
@@ -21,6 +22,11 @@ type Config struct {
 	OTLPEndpoint   string
 	Insecure       bool
 	SampleRate     float64
+
+	// MetricsInterval is how often metrics are pushed to the collector
+	MetricsInterval time.Duration
+	// RuntimeMetrics adds Go runtime metrics: GC, heap and goroutines
+	RuntimeMetrics bool
 }
 
 // DefaultConfig returns sensible default configuration
@@ -32,6 +38,9 @@ func DefaultConfig(serviceName string) Config {
 		OTLPEndpoint:   "localhost:4317",
 		Insecure:       true,
 		SampleRate:     1.0, // Sample everything in development
+
+		MetricsInterval: 30 * time.Second,
+		RuntimeMetrics:  true,
 	}
 }
 
@@ -51,18 +60,9 @@ func InitTracer(ctx context.Context, cfg Config) (func(context.Context) error, e
 		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
 	}
 
-	// Create resource with service information
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(cfg.ServiceName),
-			semconv.ServiceVersion(cfg.ServiceVersion),
-			semconv.DeploymentEnvironment(cfg.Environment),
-		),
-		resource.WithHost(),
-		resource.WithProcess(),
-	)
+	res, err := newResource(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create resource: %w", err)
+		return nil, err
 	}
 
 	// Create sampler based on sample rate
@@ -94,6 +94,24 @@ func InitTracer(ctx context.Context, cfg Config) (func(context.Context) error, e
 	return tp.Shutdown, nil
 }
 
+// newResource describes the service to the collector, so traces and metrics
+// from the same process carry the same attributes
+func newResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+			semconv.DeploymentEnvironment(cfg.Environment),
+		),
+		resource.WithHost(),
+		resource.WithProcess(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resource: %w", err)
+	}
+	return res, nil
+}
+
 // Tracer returns a named tracer from the global trace provider
 func Tracer(name string) trace.Tracer {
 	return otel.Tracer(name)
@@ -0,0 +1,98 @@
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	KafkaBrokersVar = "TEST_KAFKA_BROKERS"
+	KafkaImage      = "apache/kafka:3.7.0"
+)
+
+var kafkaService service
+
+// KafkaBrokers returns the broker addresses of a shared single-node Kafka.
+func KafkaBrokers(t testing.TB) []string {
+	t.Helper()
+	return strings.Split(kafkaService.get(t, KafkaBrokersVar, startKafka), ",")
+}
+
+// KafkaTopic creates a topic with a unique name for the test.
+func KafkaTopic(t testing.TB, prefix string) string {
+	t.Helper()
+
+	brokers := KafkaBrokers(t)
+	topic := prefix + "-" + uuid.NewString()[:8]
+
+	conn, err := kafka.Dial("tcp", brokers[0])
+	if err != nil {
+		t.Fatalf("dial kafka: %v", err)
+	}
+	defer conn.Close()
+	controller, err := conn.Controller()
+	if err != nil {
+		t.Fatalf("find controller: %v", err)
+	}
+	controllerConn, err := kafka.Dial("tcp", fmt.Sprintf("%s:%d", controller.Host, controller.Port))
+	if err != nil {
+		t.Fatalf("dial controller: %v", err)
+	}
+	defer controllerConn.Close()
+
+	if err := controllerConn.CreateTopics(kafka.TopicConfig{
+		Topic:             topic,
+		NumPartitions:     1,
+		ReplicationFactor: 1,
+	}); err != nil {
+		t.Fatalf("create topic %s: %v", topic, err)
+	}
+	return topic
+}
+
+func startKafka(ctx context.Context) (string, error) {
+	// Kafka hands clients the advertised address, so the host port has to be
+	// known before the broker starts
+	port, err := freePort()
+	if err != nil {
+		return "", err
+	}
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+
+	_, err = startContainer(ctx, KafkaImage,
+		"-e", "KAFKA_NODE_ID=1",
+		"-e", "KAFKA_PROCESS_ROLES=broker,controller",
+		"-e", "KAFKA_LISTENERS=PLAINTEXT://:9092,CONTROLLER://:9093",
+		"-e", "KAFKA_ADVERTISED_LISTENERS=PLAINTEXT://"+addr,
+		"-e", "KAFKA_CONTROLLER_LISTENER_NAMES=CONTROLLER",
+		"-e", "KAFKA_LISTENER_SECURITY_PROTOCOL_MAP=CONTROLLER:PLAINTEXT,PLAINTEXT:PLAINTEXT",
+		"-e", "KAFKA_CONTROLLER_QUORUM_VOTERS=1@localhost:9093",
+		"-e", "KAFKA_OFFSETS_TOPIC_REPLICATION_FACTOR=1",
+		"-e", "KAFKA_TRANSACTION_STATE_LOG_REPLICATION_FACTOR=1",
+		"-e", "KAFKA_TRANSACTION_STATE_LOG_MIN_ISR=1",
+		"-e", "KAFKA_GROUP_INITIAL_REBALANCE_DELAY_MS=0",
+		"-p", fmt.Sprintf("%s:9092", addr),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	err = waitFor(ctx, func(ctx context.Context) error {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		_, err = conn.Brokers()
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return addr, nil
+}
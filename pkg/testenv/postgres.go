@@ -0,0 +1,100 @@
+package testenv
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/migrate"
+)
+
+const (
+	PostgresURLVar = "TEST_POSTGRES_URL"
+	PostgresImage  = "postgres:15-alpine"
+)
+
+var postgres service
+
+// PostgresURL returns a connection string for a shared Postgres instance.
+func PostgresURL(t testing.TB) string {
+	t.Helper()
+	return postgres.get(t, PostgresURLVar, startPostgres)
+}
+
+// PostgresPool migrates fsys into a fresh schema and returns a pool whose
+// connections use it. The schema is dropped when the test ends, so tests
+// never see each other's rows.
+func PostgresPool(t testing.TB, fsys fs.FS) *pgxpool.Pool {
+	t.Helper()
+
+	url := PostgresURL(t)
+	ctx := context.Background()
+
+	admin, err := pgxpool.New(ctx, url)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := "test_" + uuid.NewString()[:8]
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		admin.Close()
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		admin.Close()
+	})
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatalf("parse config: %v", err)
+	}
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	migrator, err := migrate.New(pool, fsys, logging.Nop())
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("apply migrations: %v", err)
+	}
+	return pool
+}
+
+func startPostgres(ctx context.Context) (string, error) {
+	c, err := startContainer(ctx, PostgresImage,
+		"-e", "POSTGRES_USER=postgres",
+		"-e", "POSTGRES_PASSWORD=postgres",
+		"-e", "POSTGRES_DB=test",
+		"-p", "127.0.0.1::5432",
+	)
+	if err != nil {
+		return "", err
+	}
+	addr, err := c.hostPort(ctx, "5432/tcp")
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("postgres://postgres:postgres@%s/test?sslmode=disable", addr)
+	err = waitFor(ctx, func(ctx context.Context) error {
+		pool, err := pgxpool.New(ctx, url)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+		return pool.Ping(ctx)
+	})
+	if err != nil {
+		return "", err
+	}
+	return url, nil
+}
@@ -0,0 +1,55 @@
+package testenv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	RedisAddrVar = "TEST_REDIS_ADDR"
+	RedisImage   = "redis:7-alpine"
+)
+
+var redisService service
+
+// RedisAddr returns the address of a shared Redis instance.
+func RedisAddr(t testing.TB) string {
+	t.Helper()
+	return redisService.get(t, RedisAddrVar, startRedis)
+}
+
+// RedisClient returns a client for the shared Redis instance. The database
+// is flushed when the test ends.
+func RedisClient(t testing.TB) *redis.Client {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: RedisAddr(t)})
+	t.Cleanup(func() {
+		client.FlushDB(context.Background())
+		client.Close()
+	})
+	return client
+}
+
+func startRedis(ctx context.Context) (string, error) {
+	c, err := startContainer(ctx, RedisImage, "-p", "127.0.0.1::6379")
+	if err != nil {
+		return "", err
+	}
+	addr, err := c.hostPort(ctx, "6379/tcp")
+	if err != nil {
+		return "", err
+	}
+
+	err = waitFor(ctx, func(ctx context.Context) error {
+		client := redis.NewClient(&redis.Options{Addr: addr})
+		defer client.Close()
+		return client.Ping(ctx).Err()
+	})
+	if err != nil {
+		return "", err
+	}
+	return addr, nil
+}
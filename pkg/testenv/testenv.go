@@ -0,0 +1,162 @@
+// Package testenv starts throwaway Postgres, Redis and Kafka containers for
+// integration tests. Containers are started through the docker CLI on first
+// use and shared by every test in the package; Run removes them once the
+// tests finish.
+//
+// Each dependency can instead point at an existing instance through an
+// environment variable (TEST_POSTGRES_URL, TEST_REDIS_ADDR, TEST_KAFKA_BROKERS),
+// which is how CI reuses its service containers. Without either, tests that
+// need the dependency are skipped.
+//
+// Integration tests live behind the integration build tag:
+//
+//	//go:build integration
+//
+//	func TestMain(m *testing.M) { os.Exit(testenv.Run(m)) }
+package testenv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// StartTimeout bounds how long a container may take to become ready.
+const StartTimeout = 2 * time.Minute
+
+var ErrDockerUnavailable = errors.New("docker is not available")
+
+var (
+	mu         sync.Mutex
+	containers []*container
+)
+
+// container is a running docker container started by this package.
+type container struct {
+	id string
+}
+
+// Run runs the tests and removes every container they started.
+func Run(m *testing.M) int {
+	code := m.Run()
+	Terminate()
+	return code
+}
+
+// Terminate removes the containers started so far.
+func Terminate() {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, c := range containers {
+		exec.Command("docker", "rm", "-f", "-v", c.id).Run()
+	}
+	containers = nil
+}
+
+// service starts a container once per test binary and remembers its address
+// or the error that stopped it.
+type service struct {
+	once sync.Once
+	addr string
+	err  error
+}
+
+func (s *service) get(t testing.TB, envVar string, start func(ctx context.Context) (string, error)) string {
+	t.Helper()
+
+	s.once.Do(func() {
+		if addr := os.Getenv(envVar); addr != "" {
+			s.addr = addr
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), StartTimeout)
+		defer cancel()
+		s.addr, s.err = start(ctx)
+	})
+	if errors.Is(s.err, ErrDockerUnavailable) {
+		t.Skipf("%s not set and %v", envVar, s.err)
+	}
+	if s.err != nil {
+		t.Fatalf("start container: %v", s.err)
+	}
+	return s.addr
+}
+
+// startContainer runs image detached with the given docker run arguments.
+func startContainer(ctx context.Context, image string, args ...string) (*container, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return nil, ErrDockerUnavailable
+	}
+
+	runArgs := append([]string{"run", "-d", "--rm", "--label", "parking-super-app.testenv=true"}, args...)
+	out, err := docker(ctx, append(runArgs, image)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", image, err)
+	}
+
+	c := &container{id: out}
+	mu.Lock()
+	containers = append(containers, c)
+	mu.Unlock()
+	return c, nil
+}
+
+// hostPort returns the host address docker mapped to the container port.
+func (c *container) hostPort(ctx context.Context, port string) (string, error) {
+	out, err := docker(ctx, "port", c.id, port)
+	if err != nil {
+		return "", fmt.Errorf("failed to read mapped port: %w", err)
+	}
+	// One line per address family; the first is enough
+	addr := strings.TrimSpace(strings.SplitN(out, "\n", 2)[0])
+	addr = strings.Replace(addr, "0.0.0.0", "127.0.0.1", 1)
+	return addr, nil
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("docker %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("docker %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// waitFor polls ready until it succeeds or ctx expires.
+func waitFor(ctx context.Context, ready func(ctx context.Context) error) error {
+	for {
+		err := ready(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("container not ready: %w", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// freePort reserves a local port for containers that must advertise their
+// host address, such as Kafka.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
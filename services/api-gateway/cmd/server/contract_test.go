@@ -0,0 +1,106 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	"github.com/parking-super-app/services/api-gateway/internal/transcode"
+)
+
+// forwarded lists the routes the gateway proxies to each service, as set up
+// in main, and those the home summary calls. A path ending in /* is
+// forwarded whole, so the service must serve something under it. Keep in
+// step with main and rerun with CONTRACT_UPDATE=1.
+var forwarded = map[string][]struct {
+	method, path string
+}{
+	"auth": {
+		{http.MethodGet, "/health"},
+		{http.MethodGet, "/.well-known/jwks.json"},
+		{http.MethodPost, "/api/v1/auth/guest"},
+		{"*", "/api/v1/auth/*"},
+		{http.MethodGet, "/api/v1/auth/me"},
+		{"*", "/api/v1/admin/kyc/*"},
+		{"*", "/api/v1/admin/users/*"},
+	},
+	"wallet": {
+		{http.MethodGet, "/health"},
+		{http.MethodPost, "/api/v1/wallet/webhooks/{gateway}"},
+		{"*", "/api/v1/wallet/*"},
+		{http.MethodGet, "/api/v1/wallet"},
+		{"*", "/api/v1/admin/ledger/*"},
+		{"*", "/api/v1/admin/promotions/*"},
+		{"*", "/api/v1/admin/invoices/*"},
+		{"*", "/api/v1/admin/loyalty/*"},
+		{"*", "/api/v1/admin/wallets/*"},
+		{"*", "/api/v1/admin/transactions/*"},
+		{"*", "/api/v1/admin/settlements/*"},
+		{"*", "/api/v1/admin/fees/*"},
+	},
+	"provider": {
+		{http.MethodGet, "/health"},
+		{http.MethodGet, "/api/v1/providers"},
+		{http.MethodGet, "/api/v1/providers/{id}"},
+		{http.MethodGet, "/api/v1/providers/code/{code}"},
+		{http.MethodGet, "/api/v1/providers/{id}/locations"},
+		{http.MethodGet, "/api/v1/providers/locations/nearby"},
+		{http.MethodGet, "/api/v1/providers/manifest"},
+		{http.MethodGet, "/api/v1/locations/amenities"},
+		{http.MethodGet, "/api/v1/locations/clusters"},
+		{http.MethodGet, "/api/v1/locations/clusters/{z}/{x}/{y}"},
+		{http.MethodPost, "/api/v1/providers"},
+		{http.MethodPost, "/api/v1/providers/{id}/*"},
+		{"*", "/api/v1/portal/*"},
+		{"*", "/api/v1/provider-portal/*"},
+		{"*", "/api/v1/admin/providers/*"},
+	},
+	"parking": {
+		{http.MethodGet, "/health"},
+		{"*", "/api/v1/parking/*"},
+		{http.MethodGet, "/api/v1/parking/sessions/active"},
+		{"*", "/api/v1/guest/*"},
+		{"*", "/api/v1/admin/consistency/*"},
+		{"*", "/api/v1/admin/subscriptions/*"},
+		{"*", "/api/v1/admin/reservations/*"},
+		{"*", "/api/v1/admin/street/*"},
+		{"*", "/api/v1/admin/compounds/*"},
+		{"*", "/api/v1/admin/disputes/*"},
+		{"*", "/api/v1/admin/taxes/*"},
+	},
+	"notification": {
+		{http.MethodGet, "/health"},
+		{http.MethodPost, "/api/v1/notifications/webhooks/{channel}"},
+		{"*", "/api/v1/notifications/*"},
+		{http.MethodGet, "/api/v1/notifications/unread-count"},
+		{"*", "/api/v1/preferences/*"},
+		{"*", "/api/v1/admin/notifications/*"},
+		{"*", "/api/v1/admin/templates/*"},
+	},
+}
+
+func TestServiceContracts(t *testing.T) {
+	for service, routes := range forwarded {
+		c := contract.Contract{Consumer: "api-gateway", Provider: service}
+		for _, r := range routes {
+			c.Interactions = append(c.Interactions, contract.Interaction{
+				Name:   r.method + " " + r.path,
+				Kind:   contract.KindHTTP,
+				Method: r.method,
+				Path:   r.path,
+			})
+		}
+		// Transcoded routes pass every field on, so the whole messages are
+		// relied on
+		for _, b := range rpcBindings[service] {
+			method, err := transcode.Method(b.RPC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c.Interactions = append(c.Interactions, contract.GRPCInteraction(method,
+				contract.ShapeOfMessage(method.Input()).Paths(),
+				contract.ShapeOfMessage(method.Output()).Paths()))
+		}
+		contract.Record(t, c)
+	}
+}
@@ -11,15 +11,36 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/pkg/clients"
+	"github.com/parking-super-app/pkg/eventstore"
+	"github.com/parking-super-app/pkg/featureflags"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/jwks"
+	"github.com/parking-super-app/pkg/kafka"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/revocation"
+	"github.com/parking-super-app/pkg/settings"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/api-gateway/config"
+	"github.com/parking-super-app/services/api-gateway/internal/events"
+	"github.com/parking-super-app/services/api-gateway/internal/flags"
 	"github.com/parking-super-app/services/api-gateway/internal/health"
+	"github.com/parking-super-app/services/api-gateway/internal/home"
+	"github.com/parking-super-app/services/api-gateway/internal/manifest"
 	gatewaymw "github.com/parking-super-app/services/api-gateway/internal/middleware"
 	"github.com/parking-super-app/services/api-gateway/internal/proxy"
+	"github.com/parking-super-app/services/api-gateway/internal/push"
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
 )
 
 func main() {
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
@@ -30,15 +51,16 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -47,11 +69,158 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			log.Println("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Initialize components
-	authMw := gatewaymw.NewAuthMiddleware(cfg.Auth.JWTSecret)
-	rateLimiter := gatewaymw.NewRateLimiter(100, time.Minute)
+	var authMw *gatewaymw.AuthMiddleware
+	if cfg.Auth.SigningAlgorithm == "HS256" {
+		authMw = gatewaymw.NewAuthMiddleware(cfg.Auth.JWTSecret)
+	} else {
+		authMw = gatewaymw.NewJWKSAuthMiddleware(jwks.NewClient(cfg.Auth.JWKSURL, cfg.Auth.JWKSCacheTTL))
+		log.Printf("Verifying access tokens against %s", cfg.Auth.JWKSURL)
+	}
+	// Back-office routes are for platform admins, whose tokens carry the
+	// admin role
+	adminOnly := gatewaymw.RequireRole(gatewaymw.RoleAdmin)
+	if cfg.Auth.DenylistRedisAddr != "" {
+		denylistClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Auth.DenylistRedisAddr,
+			Password: cfg.Auth.DenylistRedisPassword,
+			DB:       cfg.Auth.DenylistRedisDB,
+		})
+		defer denylistClient.Close()
+		authMw.SetDenylist(revocation.NewRedisList(denylistClient, revocation.DefaultRedisPrefix))
+		log.Println("Rejecting revoked access tokens")
+	} else {
+		log.Println("warning: TOKEN_DENYLIST_REDIS_ADDR not set, revoked access tokens are accepted until they expire")
+	}
+	rateLimiter := gatewaymw.NewRateLimiter(cfg.RateLimit.Requests, cfg.RateLimit.Window)
+
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("rate limit", func() error {
+		limit, err := config.LoadRateLimit()
+		if err != nil {
+			return err
+		}
+		rateLimiter.SetLimit(limit.Requests, limit.Window)
+		return nil
+	})
+	reloader.WatchSignals(ctx)
 	serviceProxy := proxy.NewServiceProxy()
 
+	// Bound the requests in flight to each service, so a slow one can't
+	// take every connection
+	for name, url := range map[string]string{
+		"auth":         cfg.Services.AuthURL,
+		"wallet":       cfg.Services.WalletURL,
+		"provider":     cfg.Services.ProviderURL,
+		"parking":      cfg.Services.ParkingURL,
+		"notification": cfg.Services.NotificationURL,
+	} {
+		serviceProxy.Limit(name, url, proxy.BulkheadConfig{
+			MaxConcurrent: cfg.Bulkheads.For(name),
+			MaxWait:       cfg.Bulkheads.MaxWait,
+			RejectStatus:  cfg.Bulkheads.RejectStatus,
+			RetryAfter:    cfg.Bulkheads.RetryAfter,
+		})
+	}
+
+	// Feature flag store shared with backend services
+	flagStore, closeFlagStore, err := featureflags.OpenStore(ctx, featureflags.StoreConfig{
+		Backend:       cfg.Flags.Backend,
+		RedisAddr:     cfg.Flags.RedisAddr,
+		RedisPassword: cfg.Flags.RedisPassword,
+		RedisDB:       cfg.Flags.RedisDB,
+		PostgresURL:   cfg.Flags.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: feature flag store unavailable, using in-memory flags: %v", err)
+		flagStore, closeFlagStore = featureflags.NewMemoryStore(), func() {}
+	}
+	defer closeFlagStore()
+
+	// Event store the services record published events into
+	eventStore, closeEventStore, err := eventstore.OpenStore(ctx, eventstore.StoreConfig{
+		Backend:     cfg.Events.Backend,
+		PostgresURL: cfg.Events.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: event store unavailable, event search will be empty: %v", err)
+		eventStore, closeEventStore = eventstore.NewMemoryStore(eventstore.DefaultMemoryCapacity), func() {}
+	}
+	defer closeEventStore()
+
+	// Real-time push: backend events addressed to a user are streamed to
+	// that user's open connections
+	pushHub := push.NewHub(push.Config{
+		MaxConnections:        cfg.Push.MaxConnections,
+		MaxConnectionsPerUser: cfg.Push.MaxConnectionsPerUser,
+		BufferSize:            push.DefaultConfig().BufferSize,
+		ReplaySize:            cfg.Push.ReplaySize,
+		ReplayWindow:          cfg.Push.ReplayWindow,
+	})
+	if cfg.Push.KafkaEnabled {
+		for _, topic := range cfg.Push.Topics {
+			consumer := kafka.NewConsumer(kafka.DefaultConsumerConfig(cfg.Push.Brokers, topic, cfg.Push.ConsumerGroup))
+			consumer.RegisterDefaultHandler(func(ctx context.Context, event kafka.Event) error {
+				pushHub.Publish(event.Type, event.Payload)
+				return nil
+			})
+			go func(topic string) {
+				log.Printf("streaming %s events to push clients", topic)
+				if err := consumer.Start(ctx); err != nil {
+					log.Printf("push consumer for %s stopped: %v", topic, err)
+				}
+			}(topic)
+		}
+	} else {
+		log.Println("warning: Kafka disabled, push streams will only send heartbeats")
+	}
+
+	// Public provider and location listings are cached, and dropped as soon
+	// as the provider service publishes a change
+	cachePublic := func(next http.Handler) http.Handler { return next }
+	if cfg.ResponseCache.Enabled {
+		var store cache.Store = cache.NewLRUStore(cfg.ResponseCache.Size)
+		if cfg.ResponseCache.RedisAddr != "" {
+			cacheClient := redis.NewClient(&redis.Options{
+				Addr:     cfg.ResponseCache.RedisAddr,
+				Password: cfg.ResponseCache.RedisPassword,
+				DB:       cfg.ResponseCache.RedisDB,
+			})
+			defer cacheClient.Close()
+			store = cache.NewRedisStore(cacheClient, "api-gateway:responses:")
+		}
+		responseCache := gatewaymw.NewResponseCache(store, cfg.ResponseCache.TTL)
+		cachePublic = responseCache.Cache
+
+		if cfg.Push.KafkaEnabled {
+			hostname, _ := os.Hostname()
+			consumerCfg := kafka.DefaultConsumerConfig(cfg.Push.Brokers, cfg.ResponseCache.ProviderEventsTopic, "api-gateway-cache-"+hostname)
+			consumerCfg.StartOffset = kafkago.LastOffset
+			consumer := kafka.NewConsumer(consumerCfg)
+			consumer.RegisterDefaultHandler(func(ctx context.Context, event kafka.Event) error {
+				return responseCache.Invalidate(ctx)
+			})
+			go func() {
+				if err := consumer.Start(ctx); err != nil {
+					log.Printf("response cache invalidation consumer stopped: %v", err)
+				}
+			}()
+		} else {
+			log.Printf("warning: Kafka disabled, cached provider listings may be up to %s old", cfg.ResponseCache.TTL)
+		}
+	}
+
 	// Initialize health checker
 	healthChecker := health.NewServiceHealth(map[string]string{
 		"auth":         cfg.Services.AuthURL,
@@ -61,6 +230,54 @@ func main() {
 		"notification": cfg.Services.NotificationURL,
 	})
 
+	// Request bodies are JSON and capped, except where a route needs more
+	requestGuard := gatewaymw.NewRequestGuard(gatewaymw.BodyPolicy{
+		MaxBytes:     cfg.Security.MaxBodyBytes,
+		ContentTypes: []string{"application/json"},
+	})
+	requestGuard.Route(http.MethodPost, "/api/v1/auth/me/kyc", gatewaymw.BodyPolicy{
+		MaxBytes:     cfg.Security.UploadMaxBodyBytes,
+		ContentTypes: []string{"application/json", "multipart/form-data"},
+	})
+	requestGuard.Route(http.MethodPut, "/api/v1/provider-portal/logo", gatewaymw.BodyPolicy{
+		MaxBytes:     cfg.Security.UploadMaxBodyBytes,
+		ContentTypes: []string{"image/png", "image/jpeg"},
+	})
+	// Webhooks arrive in each sender's own format and are verified by the
+	// receiving service
+	for _, webhooks := range []string{"/api/v1/wallet/webhooks/", "/api/v1/notifications/webhooks/"} {
+		requestGuard.Route(http.MethodPost, webhooks, gatewaymw.BodyPolicy{MaxBytes: cfg.Security.MaxBodyBytes})
+	}
+
+	// Each request's time budget covers its downstream calls. Uploads get
+	// longer, and the event stream is never cut off.
+	deadlines := gatewaymw.NewDeadlines(cfg.Deadlines.Default)
+	deadlines.Route("/api/v1/auth/me/kyc", cfg.Deadlines.Upload)
+	deadlines.Route("/api/v1/provider-portal/logo", cfg.Deadlines.Upload)
+	deadlines.Route("/api/v1/stream", 0)
+	for pattern, budget := range cfg.Deadlines.Routes {
+		deadlines.Route(pattern, budget)
+	}
+
+	homeHandler := home.NewHandler(home.Services{
+		Auth:          clients.NewAuthClient(clients.DefaultHTTPConfig(cfg.Services.AuthURL)),
+		Wallet:        clients.NewWalletClient(clients.DefaultHTTPConfig(cfg.Services.WalletURL)),
+		Parking:       clients.NewParkingClient(clients.DefaultHTTPConfig(cfg.Services.ParkingURL)),
+		Notifications: clients.NewNotificationClient(clients.DefaultHTTPConfig(cfg.Services.NotificationURL)),
+	})
+
+	// REST routes served by the services' gRPC APIs
+	rpcRouter, closeRPC, err := rpcRoutes(cfg)
+	if err != nil {
+		log.Fatalf("failed to set up gRPC routes: %v", err)
+	}
+	defer closeRPC()
+
+	manifestHandler := manifest.NewHandler(cfg.Services.ProviderURL, manifest.Config{
+		ProbeTimeout: cfg.Manifest.ProbeTimeout,
+		TTL:          cfg.Manifest.HealthTTL,
+	})
+
 	// Create router
 	r := chi.NewRouter()
 
@@ -69,8 +286,19 @@ func main() {
 	r.Use(chimw.RealIP)
 	r.Use(chimw.Logger)
 	r.Use(chimw.Recoverer)
-	r.Use(gatewaymw.CORS)
+	r.Use(httpx.SecurityHeaders)
+	r.Use(gatewaymw.StrictTransportSecurity(cfg.Security.HSTSMaxAge, cfg.Security.HSTSIncludeSubdomains))
+	r.Use(gatewaymw.NewCORS(gatewaymw.CORSPolicy{
+		AllowedOrigins:   cfg.CORS.AllowedOrigins,
+		AllowedMethods:   cfg.CORS.AllowedMethods,
+		AllowedHeaders:   cfg.CORS.AllowedHeaders,
+		ExposedHeaders:   cfg.CORS.ExposedHeaders,
+		AllowCredentials: cfg.CORS.AllowCredentials,
+		MaxAge:           cfg.CORS.MaxAge,
+	}).Handler)
 	r.Use(rateLimiter.Limit)
+	r.Use(requestGuard.Guard)
+	r.Use(deadlines.Handler)
 
 	// Add tracing middleware
 	if cfg.OTEL.Enabled {
@@ -80,11 +308,30 @@ func main() {
 	// Health endpoint
 	r.Get("/health", healthChecker.Handler())
 
+	// Public signing keys, for services that verify access tokens themselves
+	r.Get("/.well-known/jwks.json", serviceProxy.Forward(cfg.Services.AuthURL))
+
 	// Auth routes (public)
 	r.Route("/api/v1/auth", func(router chi.Router) {
+		// Guest tokens, for parking without registering
+		router.Post("/guest", serviceProxy.Forward(cfg.Services.AuthURL))
 		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.AuthURL))
 	})
 
+	// Guest checkout: plate-only parking paid by card, with a guest token
+	r.Route("/api/v1/guest", func(router chi.Router) {
+		router.Use(authMw.AuthenticateGuest)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Payment gateway webhooks (public; the wallet service verifies each
+	// gateway's signature)
+	r.Post("/api/v1/wallet/webhooks/{gateway}", serviceProxy.Forward(cfg.Services.WalletURL))
+
+	// Delivery receipts from SMS, push and email providers (public; signed
+	// per channel and verified by the notification service)
+	r.Post("/api/v1/notifications/webhooks/{channel}", serviceProxy.Forward(cfg.Services.NotificationURL))
+
 	// Protected routes
 	r.Group(func(router chi.Router) {
 		router.Use(authMw.Authenticate)
@@ -107,30 +354,217 @@ func main() {
 		router.Route("/api/v1/preferences", func(r chi.Router) {
 			r.HandleFunc("/*", serviceProxy.Forward(cfg.Services.NotificationURL))
 		})
+
+		// Home screen summary gathered from auth, wallet, parking and
+		// notification
+		router.Get("/api/v1/home", homeHandler.Get)
+
+		router.Route("/api/v1/rpc", rpcRouter)
+	})
+
+	// Real-time event stream
+	r.With(authMw.Authenticate).Get("/api/v1/stream", push.NewHandler(pushHub, cfg.Push.Heartbeat).Stream)
+
+	// Public: clustered location pins for the map, by area or map tile, and
+	// the amenities searches can filter by
+	r.Route("/api/v1/locations", func(router chi.Router) {
+		router.With(authMw.OptionalAuth, cachePublic).Get("/amenities", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/clusters", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/clusters/{z}/{x}/{y}", serviceProxy.Forward(cfg.Services.ProviderURL))
 	})
 
 	// Provider routes (partially public)
 	r.Route("/api/v1/providers", func(router chi.Router) {
-		// Public: list providers
-		router.With(authMw.OptionalAuth).Get("/", serviceProxy.Forward(cfg.Services.ProviderURL))
-		router.With(authMw.OptionalAuth).Get("/{id}", serviceProxy.Forward(cfg.Services.ProviderURL))
-		router.With(authMw.OptionalAuth).Get("/code/{code}", serviceProxy.Forward(cfg.Services.ProviderURL))
+		// Public: list providers and their locations
+		router.With(authMw.OptionalAuth, cachePublic).Get("/", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/{id}", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/code/{code}", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/{id}/locations", serviceProxy.Forward(cfg.Services.ProviderURL))
+		router.With(authMw.OptionalAuth, cachePublic).Get("/locations/nearby", serviceProxy.Forward(cfg.Services.ProviderURL))
+
+		// Public: provider MFE modules for the shell app, with probed health
+		router.With(authMw.OptionalAuth).Get("/manifest", manifestHandler.Get)
 
 		// Protected: admin operations
 		router.Group(func(r chi.Router) {
 			r.Use(authMw.Authenticate)
+			r.Use(adminOnly)
 			r.Post("/", serviceProxy.Forward(cfg.Services.ProviderURL))
 			r.Post("/{id}/*", serviceProxy.Forward(cfg.Services.ProviderURL))
 		})
 	})
 
+	// Developer portal: partners authenticate with their API key and secret,
+	// which the provider service verifies, so no user token is required here
+	r.Route("/api/v1/portal", func(router chi.Router) {
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ProviderURL))
+	})
+
+	// Provider portal for providers' own staff; the token's role and provider
+	// scope what they can see
+	r.Route("/api/v1/provider-portal", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ProviderURL))
+	})
+
+	// Provider back-office routes
+	r.Route("/api/v1/admin/providers", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ProviderURL))
+	})
+
+	// Session/payment consistency reports
+	r.Route("/api/v1/admin/consistency", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Parking season pass plans and renewals
+	r.Route("/api/v1/admin/subscriptions", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Parking reservation no-show job
+	r.Route("/api/v1/admin/reservations", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Street parking zones and the expiry job
+	r.Route("/api/v1/admin/street", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Compound settlement retries
+	r.Route("/api/v1/admin/compounds", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Session dispute review and resolution
+	r.Route("/api/v1/admin/disputes", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Provider and location tax rates charged on sessions
+	r.Route("/api/v1/admin/taxes", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.ParkingURL))
+	})
+
+	// Wallet ledger reconciliation and repair
+	r.Route("/api/v1/admin/ledger", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Wallet promo code campaigns
+	r.Route("/api/v1/admin/promotions", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Business invoice runs
+	r.Route("/api/v1/admin/invoices", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Loyalty points expiry runs
+	r.Route("/api/v1/admin/loyalty", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Wallet freezes and the wallet audit trail
+	r.Route("/api/v1/admin/wallets", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Admin transaction reversals
+	r.Route("/api/v1/admin/transactions", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Provider settlements and their payout
+	r.Route("/api/v1/admin/settlements", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// Provider fee schedules
+	r.Route("/api/v1/admin/fees", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.WalletURL))
+	})
+
+	// KYC submission review and the auth audit trail
+	r.Route("/api/v1/admin/kyc", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.AuthURL))
+	})
+
+	// Account bans
+	r.Route("/api/v1/admin/users", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.AuthURL))
+	})
+
+	// Scheduled notification dispatch
+	r.Route("/api/v1/admin/notifications", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.NotificationURL))
+	})
+
+	// Notification template management
+	r.Route("/api/v1/admin/templates", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		router.HandleFunc("/*", serviceProxy.Forward(cfg.Services.NotificationURL))
+	})
+
+	// Feature flag admin routes
+	r.Route("/api/v1/admin/flags", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		flags.NewHandler(flagStore).Routes(router)
+	})
+
+	// Event browser for support and debugging cross-service flows
+	r.Route("/api/v1/admin/events", func(router chi.Router) {
+		router.Use(authMw.Authenticate)
+		router.Use(adminOnly)
+		events.NewHandler(eventStore).Routes(router)
+	})
+
 	// Create server
-	server := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      r,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	server, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(r), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
 	// Start server
@@ -162,6 +596,11 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	log.Println("API Gateway stopped")
 }
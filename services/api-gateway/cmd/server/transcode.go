@@ -0,0 +1,80 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/grpc/client"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+	"github.com/parking-super-app/services/api-gateway/config"
+	"github.com/parking-super-app/services/api-gateway/internal/transcode"
+	"google.golang.org/grpc"
+)
+
+// rpcBindings are the gRPC methods served as REST routes under /api/v1/rpc,
+// by the service that implements them. A method needs no HTTP handler in
+// its service to be listed here.
+var rpcBindings = map[string][]transcode.Binding{
+	"parking": {
+		{Method: http.MethodPost, Path: "/parking/sessions", RPC: parkingv1.ParkingService_StartSession_FullMethodName},
+		{Method: http.MethodGet, Path: "/parking/sessions/active", RPC: parkingv1.ParkingService_GetActiveSessions_FullMethodName},
+		{Method: http.MethodGet, Path: "/parking/sessions/{session_id}", RPC: parkingv1.ParkingService_GetSession_FullMethodName},
+		{Method: http.MethodPost, Path: "/parking/sessions/{session_id}/end", RPC: parkingv1.ParkingService_EndSession_FullMethodName},
+	},
+	"wallet": {
+		{Method: http.MethodGet, Path: "/wallet/wallets", RPC: walletv1.WalletService_ListWallets_FullMethodName},
+	},
+	"notification": {
+		{Method: http.MethodGet, Path: "/notifications/preferences", RPC: notificationv1.NotificationService_GetPreferences_FullMethodName},
+	},
+}
+
+// rpcRoutes dials each service with bindings and returns a function that
+// registers their routes, and one that closes the connections
+func rpcRoutes(cfg *config.Config) (func(chi.Router), func(), error) {
+	addrs := map[string]string{
+		"parking":      cfg.Services.ParkingGRPC,
+		"wallet":       cfg.Services.WalletGRPC,
+		"notification": cfg.Services.NotificationGRPC,
+	}
+
+	var transcoders []*transcode.Transcoder
+	var conns []*grpc.ClientConn
+	closeAll := func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+
+	for service, bindings := range rpcBindings {
+		clientCfg := client.DefaultConfig(addrs[service])
+		clientCfg.DefaultTimeout = cfg.Transcode.CallTimeout
+		if err := clientCfg.UseMutualTLS(cfg.Transcode.TLS); err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to load gRPC TLS certificates: %w", err)
+		}
+		conn, err := client.New(clientCfg)
+		if err != nil {
+			closeAll()
+			return nil, nil, fmt.Errorf("failed to connect to %s service: %w", service, err)
+		}
+		conns = append(conns, conn)
+
+		t, err := transcode.New(conn, bindings...)
+		if err != nil {
+			closeAll()
+			return nil, nil, err
+		}
+		transcoders = append(transcoders, t)
+	}
+
+	routes := func(r chi.Router) {
+		for _, t := range transcoders {
+			t.Routes(r)
+		}
+	}
+	return routes, closeAll, nil
+}
@@ -1,8 +1,15 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/settings"
 )
 
 // Config holds API Gateway configuration
@@ -11,10 +18,23 @@ type Config struct {
 	Services ServicesConfig
 	Auth     AuthConfig
 	OTEL     OTELConfig
+	Flags    FlagsConfig
+	Events   EventStoreConfig
+	Push     PushConfig
+	// RateLimit can be changed at runtime by reloading the config
+	RateLimit     RateLimitConfig
+	ResponseCache ResponseCacheConfig
+	Security      SecurityConfig
+	CORS          CORSConfig
+	Manifest      ManifestConfig
+	Transcode     TranscodeConfig
+	Deadlines     DeadlineConfig
+	Bulkheads     BulkheadConfig
 }
 
 type ServerConfig struct {
 	Port string
+	HTTP httpserver.Config
 }
 
 type ServicesConfig struct {
@@ -34,7 +54,18 @@ type ServicesConfig struct {
 }
 
 type AuthConfig struct {
-	JWTSecret string
+	// SigningAlgorithm is RS256 or EdDSA to verify tokens against the auth
+	// service's JWKS, or HS256 to verify them with JWTSecret
+	SigningAlgorithm string
+	JWTSecret        string
+	JWKSURL          string
+	JWKSCacheTTL     time.Duration
+
+	// Redis the auth service records revoked tokens in. Leave
+	// DenylistRedisAddr empty to accept tokens until they expire.
+	DenylistRedisAddr     string
+	DenylistRedisPassword string
+	DenylistRedisDB       int
 }
 
 type OTELConfig struct {
@@ -42,19 +73,243 @@ type OTELConfig struct {
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
+}
+
+// FlagsConfig selects the shared feature flag store: memory, redis or postgres
+type FlagsConfig struct {
+	Backend       string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+	PostgresURL   string
+}
+
+// EventStoreConfig selects the shared event store the services record
+// published events into: memory or postgres
+type EventStoreConfig struct {
+	Backend     string
+	PostgresURL string
+}
+
+// PushConfig controls the real-time event stream. Every gateway instance
+// reads every event, so the consumer group must be unique per instance.
+type PushConfig struct {
+	KafkaEnabled          bool
+	Brokers               []string
+	Topics                []string
+	ConsumerGroup         string
+	MaxConnections        int
+	MaxConnectionsPerUser int
+	ReplaySize            int
+	ReplayWindow          time.Duration
+	Heartbeat             time.Duration
+}
+
+// ResponseCacheConfig controls caching of public provider and location
+// listings. Without RedisAddr each gateway instance caches in memory.
+// Entries are dropped early when an event arrives on ProviderEventsTopic.
+type ResponseCacheConfig struct {
+	Enabled             bool
+	TTL                 time.Duration
+	Size                int
+	RedisAddr           string
+	RedisPassword       string
+	RedisDB             int
+	ProviderEventsTopic string
+}
+
+// SecurityConfig bounds request bodies and sets the HSTS policy. Uploads
+// (KYC documents) get the larger limit.
+type SecurityConfig struct {
+	MaxBodyBytes          int64
+	UploadMaxBodyBytes    int64
+	HSTSMaxAge            time.Duration
+	HSTSIncludeSubdomains bool
+}
+
+// CORSConfig is the browser cross-origin policy. Origins may be "*", exact
+// ("https://app.example.com") or wildcard subdomains
+// ("https://*.mfe.example.com", for provider MFE hosts).
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// corsPresets are the CORS_PRESET defaults; each CORS_* variable overrides
+// its part. Only development allows any origin, staging and production must
+// list theirs.
+var corsPresets = map[string]CORSConfig{
+	"development": {
+		AllowedOrigins: []string{"*"},
+		MaxAge:         10 * time.Minute,
+	},
+	"staging": {
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	},
+	"production": {
+		AllowCredentials: true,
+		MaxAge:           24 * time.Hour,
+	},
+}
+
+// LoadCORS builds the CORS policy from CORS_PRESET and the CORS_* overrides
+func LoadCORS() (CORSConfig, error) {
+	name := getEnv("CORS_PRESET", "development")
+	preset, ok := corsPresets[name]
+	if !ok {
+		return CORSConfig{}, fmt.Errorf("invalid CORS_PRESET %q: must be development, staging or production", name)
+	}
+
+	cors := preset
+	cors.AllowedOrigins = getListEnv("CORS_ALLOWED_ORIGINS", preset.AllowedOrigins)
+	cors.AllowedMethods = getListEnv("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"})
+	cors.AllowedHeaders = getListEnv("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-Request-ID", "X-API-Key", "X-API-Secret", "If-None-Match", "Idempotency-Key"})
+	cors.ExposedHeaders = getListEnv("CORS_EXPOSED_HEADERS", []string{"ETag", "X-Cache", "X-Request-ID", "Retry-After"})
+	cors.MaxAge = getDurationEnv("CORS_MAX_AGE", preset.MaxAge)
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		credentials, err := strconv.ParseBool(v)
+		if err != nil {
+			return CORSConfig{}, fmt.Errorf("invalid CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+		cors.AllowCredentials = credentials
+	}
+
+	if len(cors.AllowedOrigins) == 0 {
+		return CORSConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS is required for the %s preset", name)
+	}
+	for _, origin := range cors.AllowedOrigins {
+		if origin == "*" {
+			if cors.AllowCredentials {
+				return CORSConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS must list origins when credentials are allowed")
+			}
+			continue
+		}
+		if !strings.Contains(origin, "://") || strings.Count(origin, "*") > 1 ||
+			(strings.Contains(origin, "*") && !strings.Contains(origin, "://*.")) {
+			return CORSConfig{}, fmt.Errorf("invalid CORS origin %q: must be *, scheme://host or scheme://*.domain", origin)
+		}
+	}
+	return cors, nil
+}
+
+// ManifestConfig controls the health probes of provider MFE hosts listed in
+// the MFE manifest
+type ManifestConfig struct {
+	ProbeTimeout time.Duration
+	HealthTTL    time.Duration
+}
+
+// TranscodeConfig controls the REST routes served by calling the services'
+// gRPC APIs
+type TranscodeConfig struct {
+	CallTimeout time.Duration
+	// TLS secures calls to the services' gRPC listeners
+	TLS mtls.Config
+}
+
+// DeadlineConfig sets how long a request may take, including every call it
+// makes downstream. Routes overrides the default by path; a path ending in
+// "/" covers every path under it.
+type DeadlineConfig struct {
+	Default time.Duration
+	Upload  time.Duration
+	Routes  map[string]time.Duration
+}
+
+// BulkheadConfig bounds the requests forwarded to each service at once.
+// Limits overrides MaxConcurrent by service name (auth, wallet, provider,
+// parking, notification); zero turns the bound off.
+type BulkheadConfig struct {
+	MaxConcurrent int
+	Limits        map[string]int
+	MaxWait       time.Duration
+	// RejectStatus is 503 or 429
+	RejectStatus int
+	RetryAfter   time.Duration
+}
+
+// For returns the limit for a service
+func (b BulkheadConfig) For(service string) int {
+	if limit, ok := b.Limits[service]; ok {
+		return limit
+	}
+	return b.MaxConcurrent
+}
+
+// RateLimitConfig caps requests per client (user, or IP when anonymous)
+type RateLimitConfig struct {
+	Requests int
+	Window   time.Duration
 }
 
 func Load() (*Config, error) {
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	flagsRedisDB, _ := strconv.Atoi(getEnv("FEATURE_FLAGS_REDIS_DB", "0"))
+	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
+	responseCacheEnabled, _ := strconv.ParseBool(getEnv("RESPONSE_CACHE_ENABLED", "true"))
+	hstsSubdomains, _ := strconv.ParseBool(getEnv("HSTS_INCLUDE_SUBDOMAINS", "true"))
+	hostname, _ := os.Hostname()
+	authURL := getEnv("AUTH_SERVICE_URL", "http://localhost:8081")
+
+	required := []string{"AUTH_SERVICE_URL", "WALLET_SERVICE_URL", "PROVIDER_SERVICE_URL", "PARKING_SERVICE_URL", "NOTIFICATION_SERVICE_URL"}
+	if getEnv("JWT_SIGNING_ALG", "RS256") == "HS256" {
+		required = append(required, "JWT_SECRET")
+	}
+	if err := settings.Require(required...); err != nil {
+		return nil, err
+	}
+
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	rateLimit, err := LoadRateLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	cors, err := LoadCORS()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	deadlineRoutes, err := getBudgetsEnv("REQUEST_BUDGET_ROUTES")
+	if err != nil {
+		return nil, err
+	}
+
+	bulkheads, err := loadBulkheads()
+	if err != nil {
+		return nil, err
+	}
 
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
 		Services: ServicesConfig{
 			// HTTP URLs
-			AuthURL:         getEnv("AUTH_SERVICE_URL", "http://localhost:8081"),
+			AuthURL:         authURL,
 			WalletURL:       getEnv("WALLET_SERVICE_URL", "http://localhost:8082"),
 			ProviderURL:     getEnv("PROVIDER_SERVICE_URL", "http://localhost:8083"),
 			ParkingURL:      getEnv("PARKING_SERVICE_URL", "http://localhost:8084"),
@@ -67,20 +322,166 @@ func Load() (*Config, error) {
 			NotificationGRPC: getEnv("NOTIFICATION_SERVICE_GRPC", "localhost:9085"),
 		},
 		Auth: AuthConfig{
-			JWTSecret: getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			SigningAlgorithm:      getEnv("JWT_SIGNING_ALG", "RS256"),
+			JWTSecret:             getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+			JWKSURL:               getEnv("JWT_JWKS_URL", strings.TrimSuffix(authURL, "/")+"/.well-known/jwks.json"),
+			JWKSCacheTTL:          getDurationEnv("JWT_JWKS_CACHE_TTL", 10*time.Minute),
+			DenylistRedisAddr:     getEnv("TOKEN_DENYLIST_REDIS_ADDR", ""),
+			DenylistRedisPassword: getEnv("TOKEN_DENYLIST_REDIS_PASSWORD", ""),
+			DenylistRedisDB:       getIntEnv("TOKEN_DENYLIST_REDIS_DB", 0),
 		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "api-gateway"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "api-gateway"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: getDurationEnv("OTEL_METRICS_INTERVAL", 30*time.Second),
+			RuntimeMetrics:  otelRuntimeMetrics,
+		},
+		Flags: FlagsConfig{
+			Backend:       getEnv("FEATURE_FLAGS_BACKEND", "memory"),
+			RedisAddr:     getEnv("FEATURE_FLAGS_REDIS_ADDR", "localhost:6379"),
+			RedisPassword: getEnv("FEATURE_FLAGS_REDIS_PASSWORD", ""),
+			RedisDB:       flagsRedisDB,
+			PostgresURL:   getEnv("FEATURE_FLAGS_POSTGRES_URL", ""),
+		},
+		Events: EventStoreConfig{
+			Backend:     getEnv("EVENT_STORE_BACKEND", "memory"),
+			PostgresURL: getEnv("EVENT_STORE_POSTGRES_URL", ""),
 		},
+		Push: PushConfig{
+			KafkaEnabled:          kafkaEnabled,
+			Brokers:               strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
+			Topics:                strings.Split(getEnv("PUSH_TOPICS", "parking.events,wallet.events"), ","),
+			ConsumerGroup:         getEnv("PUSH_CONSUMER_GROUP", "api-gateway-push-"+hostname),
+			MaxConnections:        getIntEnv("PUSH_MAX_CONNECTIONS", 5000),
+			MaxConnectionsPerUser: getIntEnv("PUSH_MAX_CONNECTIONS_PER_USER", 5),
+			ReplaySize:            getIntEnv("PUSH_REPLAY_SIZE", 50),
+			ReplayWindow:          getDurationEnv("PUSH_REPLAY_WINDOW", 2*time.Minute),
+			Heartbeat:             getDurationEnv("PUSH_HEARTBEAT_INTERVAL", 15*time.Second),
+		},
+		RateLimit: rateLimit,
+		ResponseCache: ResponseCacheConfig{
+			Enabled:             responseCacheEnabled,
+			TTL:                 getDurationEnv("RESPONSE_CACHE_TTL", time.Minute),
+			Size:                getIntEnv("RESPONSE_CACHE_SIZE", 1000),
+			RedisAddr:           getEnv("RESPONSE_CACHE_REDIS_ADDR", ""),
+			RedisPassword:       getEnv("RESPONSE_CACHE_REDIS_PASSWORD", ""),
+			RedisDB:             getIntEnv("RESPONSE_CACHE_REDIS_DB", 0),
+			ProviderEventsTopic: getEnv("PROVIDER_EVENTS_TOPIC", "provider.events"),
+		},
+		Security: SecurityConfig{
+			MaxBodyBytes:          int64(getIntEnv("MAX_BODY_BYTES", 1<<20)),
+			UploadMaxBodyBytes:    int64(getIntEnv("UPLOAD_MAX_BODY_BYTES", 10<<20)),
+			HSTSMaxAge:            getDurationEnv("HSTS_MAX_AGE", 365*24*time.Hour),
+			HSTSIncludeSubdomains: hstsSubdomains,
+		},
+		CORS: cors,
+		Manifest: ManifestConfig{
+			ProbeTimeout: getDurationEnv("MFE_PROBE_TIMEOUT", 2*time.Second),
+			HealthTTL:    getDurationEnv("MFE_HEALTH_TTL", 30*time.Second),
+		},
+		Transcode: TranscodeConfig{
+			CallTimeout: getDurationEnv("GRPC_CALL_TIMEOUT", 5*time.Second),
+			TLS:         grpcTLS,
+		},
+		Deadlines: DeadlineConfig{
+			Default: getDurationEnv("REQUEST_BUDGET", 10*time.Second),
+			Upload:  getDurationEnv("REQUEST_BUDGET_UPLOAD", 30*time.Second),
+			Routes:  deadlineRoutes,
+		},
+		Bulkheads: bulkheads,
 	}, nil
 }
 
+func loadBulkheads() (BulkheadConfig, error) {
+	cfg := BulkheadConfig{
+		MaxConcurrent: getIntEnv("BULKHEAD_MAX_CONCURRENT", 200),
+		Limits:        make(map[string]int),
+		MaxWait:       getDurationEnv("BULKHEAD_MAX_WAIT", 100*time.Millisecond),
+		RejectStatus:  getIntEnv("BULKHEAD_REJECT_STATUS", 503),
+		RetryAfter:    getDurationEnv("BULKHEAD_RETRY_AFTER", time.Second),
+	}
+	if cfg.RejectStatus != 503 && cfg.RejectStatus != 429 {
+		return BulkheadConfig{}, fmt.Errorf("invalid BULKHEAD_REJECT_STATUS: must be 503 or 429")
+	}
+	for _, item := range getListEnv("BULKHEAD_LIMITS", nil) {
+		service, value, ok := strings.Cut(item, "=")
+		limit, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil || limit < 0 {
+			return BulkheadConfig{}, fmt.Errorf("invalid BULKHEAD_LIMITS entry %q: must be service=limit", item)
+		}
+		cfg.Limits[strings.TrimSpace(service)] = limit
+	}
+	return cfg, nil
+}
+
+// LoadRateLimit reads RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW, both at
+// startup and on reload
+func LoadRateLimit() (RateLimitConfig, error) {
+	requests, err := strconv.Atoi(getEnv("RATE_LIMIT_REQUESTS", "100"))
+	if err != nil || requests <= 0 {
+		return RateLimitConfig{}, fmt.Errorf("invalid RATE_LIMIT_REQUESTS: must be a positive number")
+	}
+	window, err := time.ParseDuration(getEnv("RATE_LIMIT_WINDOW", "1m"))
+	if err != nil || window < time.Second {
+		return RateLimitConfig{}, fmt.Errorf("invalid RATE_LIMIT_WINDOW: must be a duration of at least 1s")
+	}
+	return RateLimitConfig{Requests: requests, Window: window}, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getListEnv reads a comma-separated list
+func getListEnv(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getBudgetsEnv reads a comma-separated list of path=duration pairs, such as
+// "/api/v1/home=3s,/api/v1/providers/=5s"
+func getBudgetsEnv(key string) (map[string]time.Duration, error) {
+	budgets := make(map[string]time.Duration)
+	for _, item := range getListEnv(key, nil) {
+		path, value, ok := strings.Cut(item, "=")
+		budget, err := time.ParseDuration(strings.TrimSpace(value))
+		if !ok || err != nil || budget < 0 || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid %s entry %q: must be /path=duration", key, item)
+		}
+		budgets[strings.TrimSpace(path)] = budget
+	}
+	return budgets, nil
+}
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
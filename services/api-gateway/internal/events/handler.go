@@ -0,0 +1,98 @@
+package events
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/eventstore"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// Handler exposes the internal API for searching events published by the services
+type Handler struct {
+	store eventstore.Store
+}
+
+func NewHandler(store eventstore.Store) *Handler {
+	return &Handler{store: store}
+}
+
+// Routes registers the event browser endpoints on the given router
+func (h *Handler) Routes(r chi.Router) {
+	r.Get("/", h.Search)
+	r.Get("/{id}", h.Get)
+}
+
+// Search filters events by service, type (a trailing "*" matches a prefix),
+// aggregate_id, user_id, trace_id and an RFC 3339 from/to window, newest first
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := eventstore.Query{
+		Service:     params.Get("service"),
+		Type:        params.Get("type"),
+		AggregateID: params.Get("aggregate_id"),
+		UserID:      params.Get("user_id"),
+		TraceID:     params.Get("trace_id"),
+	}
+
+	var err error
+	if q.From, err = parseTime(params.Get("from")); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", "from must be an RFC 3339 timestamp",
+			httpx.FieldError{Field: "from", Message: "must be an RFC 3339 timestamp"})
+		return
+	}
+	if q.To, err = parseTime(params.Get("to")); err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", "to must be an RFC 3339 timestamp",
+			httpx.FieldError{Field: "to", Message: "must be an RFC 3339 timestamp"})
+		return
+	}
+	q.Limit, _ = strconv.Atoi(params.Get("limit"))
+	q.Offset, _ = strconv.Atoi(params.Get("offset"))
+
+	records, err := h.store.Search(r.Context(), q)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if records == nil {
+		records = []*eventstore.Record{}
+	}
+	httpx.WriteRaw(w, http.StatusOK, records)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_EVENT_ID", "Invalid event ID")
+		return
+	}
+
+	record, err := h.store.Get(r.Context(), id)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, record)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, eventstore.ErrRecordNotFound):
+		httpx.WriteError(w, r, http.StatusNotFound, "EVENT_NOT_FOUND", err.Error())
+	case errors.Is(err, eventstore.ErrInvalidRange):
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+	default:
+		httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to access event store")
+	}
+}
@@ -0,0 +1,99 @@
+package flags
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/featureflags"
+	"github.com/parking-super-app/pkg/httpx"
+	gatewaymw "github.com/parking-super-app/services/api-gateway/internal/middleware"
+)
+
+// Handler exposes the admin API for toggling feature flags
+type Handler struct {
+	store featureflags.Store
+}
+
+func NewHandler(store featureflags.Store) *Handler {
+	return &Handler{store: store}
+}
+
+// Routes registers the flag admin endpoints on the given router
+func (h *Handler) Routes(r chi.Router) {
+	r.Get("/", h.List)
+	r.Get("/{key}", h.Get)
+	r.Put("/{key}", h.Put)
+	r.Delete("/{key}", h.Delete)
+}
+
+type updateFlagRequest struct {
+	Description    string   `json:"description"`
+	Enabled        bool     `json:"enabled"`
+	RolloutPercent int      `json:"rollout_percent"`
+	AllowUsers     []string `json:"allow_users"`
+	DenyUsers      []string `json:"deny_users"`
+}
+
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	flags, err := h.store.List(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, flags)
+}
+
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	flag, err := h.store.Get(r.Context(), chi.URLParam(r, "key"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, flag)
+}
+
+// Put creates or replaces a flag. Services pick up the change on their next refresh.
+func (h *Handler) Put(w http.ResponseWriter, r *http.Request) {
+	var req updateFlagRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	flag := &featureflags.Flag{
+		Key:            chi.URLParam(r, "key"),
+		Description:    req.Description,
+		Enabled:        req.Enabled,
+		RolloutPercent: req.RolloutPercent,
+		AllowUsers:     req.AllowUsers,
+		DenyUsers:      req.DenyUsers,
+		UpdatedBy:      gatewaymw.GetUserID(r.Context()),
+		UpdatedAt:      time.Now().UTC(),
+	}
+	if err := h.store.Upsert(r.Context(), flag); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, flag)
+}
+
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.Delete(r.Context(), chi.URLParam(r, "key")); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.NoContent(w)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, featureflags.ErrFlagNotFound):
+		httpx.WriteError(w, r, http.StatusNotFound, "FLAG_NOT_FOUND", err.Error())
+	case errors.Is(err, featureflags.ErrInvalidFlagKey), errors.Is(err, featureflags.ErrInvalidRollout):
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_FLAG", err.Error())
+	default:
+		httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to access feature flag store")
+	}
+}
@@ -0,0 +1,106 @@
+package home
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/parking-super-app/pkg/clients"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// Sections of the summary, as named in Summary.Unavailable
+const (
+	SectionProfile       = "profile"
+	SectionWallet        = "wallet"
+	SectionSessions      = "active_sessions"
+	SectionNotifications = "unread_notifications"
+)
+
+// Summary is what the app's home screen shows, gathered from four services
+// in one round trip. A section whose service failed is null and listed in
+// Unavailable, so the app can still render the rest.
+type Summary struct {
+	Profile             *clients.UserProfile `json:"profile"`
+	Wallet              *clients.Wallet      `json:"wallet"`
+	ActiveSessions      []clients.Session    `json:"active_sessions"`
+	UnreadNotifications *int                 `json:"unread_notifications"`
+	Unavailable         []string             `json:"unavailable,omitempty"`
+}
+
+// Services are the clients the summary is gathered with
+type Services struct {
+	Auth          *clients.AuthClient
+	Wallet        *clients.WalletClient
+	Parking       *clients.ParkingClient
+	Notifications *clients.NotificationClient
+}
+
+// Handler serves the home screen summary for the signed-in user
+type Handler struct {
+	services Services
+}
+
+func NewHandler(services Services) *Handler {
+	return &Handler{services: services}
+}
+
+// Get returns the caller's summary. It fails only when every service does.
+//
+// GET /api/v1/home
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx := clients.WithCaller(r.Context(), clients.CallerFromRequest(r))
+
+	var (
+		summary Summary
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+	)
+	fetch := func(section string, call func(context.Context) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := call(ctx); err != nil {
+				log.Printf("home: %s unavailable: %v", section, err)
+				mu.Lock()
+				summary.Unavailable = append(summary.Unavailable, section)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	fetch(SectionProfile, func(ctx context.Context) (err error) {
+		summary.Profile, err = h.services.Auth.Profile(ctx)
+		return err
+	})
+	fetch(SectionWallet, func(ctx context.Context) (err error) {
+		summary.Wallet, err = h.services.Wallet.DefaultWallet(ctx)
+		return err
+	})
+	fetch(SectionSessions, func(ctx context.Context) error {
+		sessions, err := h.services.Parking.ActiveSessions(ctx)
+		if err != nil {
+			return err
+		}
+		summary.ActiveSessions = append([]clients.Session{}, sessions...)
+		return nil
+	})
+	fetch(SectionNotifications, func(ctx context.Context) error {
+		unread, err := h.services.Notifications.UnreadCount(ctx)
+		if err != nil {
+			return err
+		}
+		summary.UnreadNotifications = &unread
+		return nil
+	})
+	wg.Wait()
+
+	if len(summary.Unavailable) == 4 {
+		httpx.WriteError(w, r, http.StatusBadGateway, "SERVICE_UNAVAILABLE", "Home summary unavailable")
+		return
+	}
+	sort.Strings(summary.Unavailable)
+	httpx.WriteJSON(w, http.StatusOK, summary)
+}
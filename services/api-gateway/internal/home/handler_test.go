@@ -0,0 +1,106 @@
+package home
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/clients"
+)
+
+// newBackend serves the four services' routes, failing those in down
+func newBackend(t *testing.T, down map[string]bool) Services {
+	t.Helper()
+	routes := map[string]string{
+		"/api/v1/auth/me":                    `{"id":"u1","full_name":"Aina"}`,
+		"/api/v1/wallet":                     `{"id":"w1","balance":"20.00","currency":"MYR","is_default":true}`,
+		"/api/v1/parking/sessions/active":    `[{"id":"s1","vehicle_plate":"WXY1234","status":"active"}]`,
+		"/api/v1/notifications/unread-count": `{"unread":2}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-User-ID") != "u1" || r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data, ok := routes[r.URL.Path]
+		if !ok || down[r.URL.Path] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":` + data + `}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := clients.DefaultHTTPConfig(srv.URL)
+	cfg.InitialBackoff = time.Millisecond
+	return Services{
+		Auth:          clients.NewAuthClient(cfg),
+		Wallet:        clients.NewWalletClient(cfg),
+		Parking:       clients.NewParkingClient(cfg),
+		Notifications: clients.NewNotificationClient(cfg),
+	}
+}
+
+func getSummary(t *testing.T, services Services) (*httptest.ResponseRecorder, Summary) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/home", nil)
+	req.Header.Set("X-User-ID", "u1")
+	req.Header.Set("Authorization", "Bearer tok")
+	rec := httptest.NewRecorder()
+	NewHandler(services).Get(rec, req)
+
+	var body struct {
+		Data Summary `json:"data"`
+	}
+	json.NewDecoder(rec.Body).Decode(&body)
+	return rec, body.Data
+}
+
+func TestHandler_Get(t *testing.T) {
+	rec, summary := getSummary(t, newBackend(t, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if summary.Profile == nil || summary.Profile.FullName != "Aina" {
+		t.Errorf("profile = %+v", summary.Profile)
+	}
+	if summary.Wallet == nil || summary.Wallet.Balance.String() != "20" {
+		t.Errorf("wallet = %+v", summary.Wallet)
+	}
+	if len(summary.ActiveSessions) != 1 || summary.ActiveSessions[0].VehiclePlate != "WXY1234" {
+		t.Errorf("active sessions = %+v", summary.ActiveSessions)
+	}
+	if summary.UnreadNotifications == nil || *summary.UnreadNotifications != 2 {
+		t.Errorf("unread = %v", summary.UnreadNotifications)
+	}
+	if len(summary.Unavailable) != 0 {
+		t.Errorf("unavailable = %v, want none", summary.Unavailable)
+	}
+}
+
+func TestHandler_Get_PartialFailure(t *testing.T) {
+	rec, summary := getSummary(t, newBackend(t, map[string]bool{"/api/v1/wallet": true}))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if summary.Wallet != nil || len(summary.Unavailable) != 1 || summary.Unavailable[0] != SectionWallet {
+		t.Errorf("wallet = %+v, unavailable = %v", summary.Wallet, summary.Unavailable)
+	}
+	if summary.Profile == nil || summary.UnreadNotifications == nil {
+		t.Error("sections from healthy services should still be returned")
+	}
+}
+
+func TestHandler_Get_AllDown(t *testing.T) {
+	rec, _ := getSummary(t, newBackend(t, map[string]bool{
+		"/api/v1/auth/me":                    true,
+		"/api/v1/wallet":                     true,
+		"/api/v1/parking/sessions/active":    true,
+		"/api/v1/notifications/unread-count": true,
+	}))
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", rec.Code)
+	}
+}
@@ -0,0 +1,194 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parking-super-app/pkg/clients"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// Health statuses of a module
+const (
+	StatusHealthy   = "healthy"
+	StatusUnhealthy = "unhealthy"
+)
+
+// Config controls how module hosts are probed
+type Config struct {
+	// ProbeTimeout bounds each probe of a module's health URL
+	ProbeTimeout time.Duration
+	// TTL is how long a probe result is reused
+	TTL time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{ProbeTimeout: 2 * time.Second, TTL: 30 * time.Second}
+}
+
+// Module is one provider micro-frontend in the manifest
+type Module struct {
+	ProviderID   string   `json:"provider_id"`
+	Code         string   `json:"code"`
+	Name         string   `json:"name"`
+	LogoURL      string   `json:"logo_url,omitempty"`
+	MFEURL       string   `json:"mfe_url"`
+	Version      string   `json:"version"`
+	Capabilities []string `json:"capabilities"`
+	HealthURL    string   `json:"-"`
+	Health       Health   `json:"health"`
+	// Compatible is set when the shell sent the capabilities it supports
+	Compatible *bool `json:"compatible,omitempty"`
+}
+
+// Health is the gateway's latest probe of a module's host
+type Health struct {
+	Status    string    `json:"status"`
+	CheckedAt time.Time `json:"checked_at"`
+	LatencyMs int64     `json:"latency_ms,omitempty"`
+}
+
+// Manifest is the document the shell app renders provider modules from
+type Manifest struct {
+	Modules     []Module  `json:"modules"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Handler serves the MFE manifest: the provider service's list of published
+// modules, each with a health status probed from the gateway and cached, so
+// the shell app only loads modules whose host is up.
+type Handler struct {
+	provider *clients.ProviderClient
+	cfg      Config
+	client   *http.Client
+
+	mu     sync.Mutex
+	probes map[string]Health
+}
+
+func NewHandler(providerURL string, cfg Config) *Handler {
+	return &Handler{
+		provider: clients.NewProviderClient(clients.DefaultHTTPConfig(providerURL)),
+		cfg:      cfg,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		probes:   make(map[string]Health),
+	}
+}
+
+// Get returns the manifest. A capabilities query parameter (comma-separated)
+// lists what the calling shell supports; each module is then marked
+// compatible when the shell has every capability it requires.
+//
+// GET /api/v1/providers/manifest?capabilities=wallet.pay,location
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	modules, err := h.fetch(r)
+	if err != nil {
+		log.Printf("manifest: %v", err)
+		httpx.WriteError(w, r, http.StatusBadGateway, "SERVICE_UNAVAILABLE", "Provider manifest unavailable")
+		return
+	}
+
+	var supported map[string]bool
+	if list := r.URL.Query().Get("capabilities"); list != "" {
+		supported = make(map[string]bool)
+		for _, c := range strings.Split(list, ",") {
+			supported[strings.TrimSpace(c)] = true
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := range modules {
+		m := &modules[i]
+		if supported != nil {
+			compatible := true
+			for _, c := range m.Capabilities {
+				compatible = compatible && supported[c]
+			}
+			m.Compatible = &compatible
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Health = h.health(r.Context(), m.HealthURL)
+		}()
+	}
+	wg.Wait()
+
+	httpx.WriteJSON(w, http.StatusOK, Manifest{Modules: modules, GeneratedAt: time.Now().UTC()})
+}
+
+func (h *Handler) fetch(r *http.Request) ([]Module, error) {
+	ctx := clients.WithCaller(r.Context(), clients.Caller{RequestID: httpx.RequestID(r)})
+	entries, err := h.provider.Manifest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch provider manifest: %w", err)
+	}
+
+	modules := make([]Module, len(entries))
+	for i, entry := range entries {
+		modules[i] = Module{
+			ProviderID:   entry.ProviderID,
+			Code:         entry.Code,
+			Name:         entry.Name,
+			LogoURL:      entry.LogoURL,
+			MFEURL:       entry.MFEURL,
+			Version:      entry.Version,
+			Capabilities: entry.Capabilities,
+			HealthURL:    entry.HealthURL,
+		}
+		if modules[i].HealthURL == "" {
+			modules[i].HealthURL = entry.MFEURL
+		}
+	}
+	return modules, nil
+}
+
+// health returns the cached probe of url, probing again once it is older
+// than the TTL
+func (h *Handler) health(ctx context.Context, url string) Health {
+	h.mu.Lock()
+	cached, ok := h.probes[url]
+	h.mu.Unlock()
+	if ok && time.Since(cached.CheckedAt) < h.cfg.TTL {
+		return cached
+	}
+
+	result := h.probe(context.WithoutCancel(ctx), url)
+
+	h.mu.Lock()
+	h.probes[url] = result
+	h.mu.Unlock()
+	return result
+}
+
+func (h *Handler) probe(ctx context.Context, url string) Health {
+	ctx, cancel := context.WithTimeout(ctx, h.cfg.ProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	result := Health{Status: StatusUnhealthy, CheckedAt: start.UTC()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return result
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return result
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+
+	if resp.StatusCode < http.StatusBadRequest {
+		result.Status = StatusHealthy
+		result.LatencyMs = time.Since(start).Milliseconds()
+	}
+	return result
+}
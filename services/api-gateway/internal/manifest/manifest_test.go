@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newMFEHost(t *testing.T, status int, probes *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		probes.Add(1)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newProviderService(t *testing.T, healthy, broken string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/providers/manifest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"success":true,"data":[
+			{"provider_id":"p1","code":"metro","name":"Metro","mfe_url":%q,"version":"2.1.0","capabilities":["wallet.pay"],"health_url":%q},
+			{"provider_id":"p2","code":"city","name":"City","mfe_url":%q,"version":"1.0.0","capabilities":["wallet.pay","camera"],"health_url":""}
+		]}`, healthy+"/remoteEntry.js", healthy+"/health", broken+"/remoteEntry.js")
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getManifest(t *testing.T, h *Handler, query string) Manifest {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.Get(rec, httptest.NewRequest(http.MethodGet, "/api/v1/providers/manifest"+query, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	var body struct {
+		Data Manifest `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	return body.Data
+}
+
+func TestHandler_Get(t *testing.T) {
+	var healthyProbes, brokenProbes atomic.Int32
+	healthy := newMFEHost(t, http.StatusOK, &healthyProbes)
+	broken := newMFEHost(t, http.StatusServiceUnavailable, &brokenProbes)
+	providers := newProviderService(t, healthy.URL, broken.URL)
+
+	h := NewHandler(providers.URL, Config{ProbeTimeout: time.Second, TTL: time.Minute})
+	m := getManifest(t, h, "?capabilities=wallet.pay,location")
+
+	if len(m.Modules) != 2 {
+		t.Fatalf("manifest has %d modules, want 2", len(m.Modules))
+	}
+	metro, city := m.Modules[0], m.Modules[1]
+	if metro.Health.Status != StatusHealthy || city.Health.Status != StatusUnhealthy {
+		t.Errorf("health = %s and %s, want healthy and unhealthy", metro.Health.Status, city.Health.Status)
+	}
+	if metro.Compatible == nil || !*metro.Compatible {
+		t.Error("metro should be compatible with a shell that supports wallet.pay")
+	}
+	if city.Compatible == nil || *city.Compatible {
+		t.Error("city needs camera, which the shell does not support")
+	}
+
+	// Probe results are reused within the TTL
+	m = getManifest(t, h, "")
+	if healthyProbes.Load() != 1 || brokenProbes.Load() != 1 {
+		t.Errorf("probes = %d and %d, want one each", healthyProbes.Load(), brokenProbes.Load())
+	}
+	if m.Modules[0].Compatible != nil {
+		t.Error("compatibility should be omitted when the shell sends no capabilities")
+	}
+}
+
+func TestHandler_Get_ProbeExpires(t *testing.T) {
+	var probes, unused atomic.Int32
+	healthy := newMFEHost(t, http.StatusOK, &probes)
+	broken := newMFEHost(t, http.StatusOK, &unused)
+	providers := newProviderService(t, healthy.URL, broken.URL)
+
+	h := NewHandler(providers.URL, Config{ProbeTimeout: time.Second, TTL: time.Nanosecond})
+	getManifest(t, h, "")
+	getManifest(t, h, "")
+
+	if probes.Load() != 2 {
+		t.Errorf("probes = %d, want a fresh probe per request once the TTL has passed", probes.Load())
+	}
+}
+
+func TestHandler_Get_ProviderServiceDown(t *testing.T) {
+	providers := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer providers.Close()
+
+	rec := httptest.NewRecorder()
+	NewHandler(providers.URL, DefaultConfig()).Get(rec, httptest.NewRequest(http.MethodGet, "/api/v1/providers/manifest", nil))
+
+	if rec.Code != http.StatusBadGateway {
+		t.Errorf("status = %d, want 502", rec.Code)
+	}
+}
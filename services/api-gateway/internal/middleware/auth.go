@@ -2,10 +2,15 @@ package middleware
 
 import (
 	"context"
+	"crypto"
+	"log"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/revocation"
 )
 
 type contextKey string
@@ -14,53 +19,154 @@ const (
 	UserIDKey contextKey = "user_id"
 )
 
+// KeySource looks up the public key a token was signed with by its kid
+type KeySource interface {
+	Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+}
+
 // AuthMiddleware validates JWT tokens and extracts user info
 type AuthMiddleware struct {
 	jwtSecret []byte
+	keys      KeySource
+	denylist  revocation.List
 }
 
+// NewAuthMiddleware accepts HS256 tokens signed with the shared secret
 func NewAuthMiddleware(secret string) *AuthMiddleware {
 	return &AuthMiddleware{jwtSecret: []byte(secret)}
 }
 
-// Authenticate validates the JWT token and adds user info to context
+// NewJWKSAuthMiddleware accepts RS256 and EdDSA tokens signed with one of
+// the auth service's published keys. No secret is shared with the gateway.
+func NewJWKSAuthMiddleware(keys KeySource) *AuthMiddleware {
+	return &AuthMiddleware{keys: keys}
+}
+
+// parse verifies the token's signature and expiry. The accepted algorithms
+// are pinned, so a token can't pick "none" or HS256 with a public key as
+// the secret.
+func (m *AuthMiddleware) parse(ctx context.Context, tokenString string) (*jwt.Token, error) {
+	if m.keys == nil {
+		return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return m.jwtSecret, nil
+		}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	}
+
+	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return m.keys.Key(ctx, kid)
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}))
+}
+
+// SetDenylist rejects tokens the auth service revoked on logout or a ban,
+// instead of accepting them until they expire
+func (m *AuthMiddleware) SetDenylist(denylist revocation.List) {
+	m.denylist = denylist
+}
+
+// revoked checks the token against the denylist. A failed check lets the
+// token through, so a Redis outage doesn't lock every user out.
+func (m *AuthMiddleware) revoked(ctx context.Context, userID string, claims jwt.MapClaims) bool {
+	if m.denylist == nil {
+		return false
+	}
+	jti, _ := claims["jti"].(string)
+	var issuedAt time.Time
+	if iat, err := claims.GetIssuedAt(); err == nil && iat != nil {
+		issuedAt = iat.Time
+	}
+
+	revoked, err := m.denylist.IsRevoked(ctx, revocation.Token{ID: jti, UserID: userID, IssuedAt: issuedAt})
+	if err != nil {
+		log.Printf("failed to check token revocation: %v", err)
+		return false
+	}
+	return revoked
+}
+
+// roleGuest is the role of tokens issued for parking without an account
+const roleGuest = "guest"
+
+// forwardScope passes a provider staff token's role and provider ID, or a
+// guest token's device ID, on to downstream services. Values sent by the
+// client are always dropped, since services trust these headers to scope
+// what the caller can see.
+func forwardScope(r *http.Request, claims jwt.MapClaims) {
+	r.Header.Del("X-User-Role")
+	r.Header.Del("X-Provider-ID")
+	r.Header.Del("X-Device-ID")
+	if role, ok := claims["role"].(string); ok && role != "" {
+		r.Header.Set("X-User-Role", role)
+	}
+	if providerID, ok := claims["provider_id"].(string); ok && providerID != "" {
+		r.Header.Set("X-Provider-ID", providerID)
+	}
+	if deviceID, ok := claims["device_id"].(string); ok && deviceID != "" {
+		r.Header.Set("X-Device-ID", deviceID)
+	}
+}
+
+// Authenticate validates the JWT token and adds user info to context.
+// Guest tokens are refused; they are only good on guest routes.
 func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
+	return m.authenticate(false, next)
+}
+
+// AuthenticateGuest accepts only guest tokens, for the routes people use to
+// park without an account. The guest's device is passed on in X-Device-ID.
+func (m *AuthMiddleware) AuthenticateGuest(next http.Handler) http.Handler {
+	return m.authenticate(true, next)
+}
+
+func (m *AuthMiddleware) authenticate(guest bool, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardScope(r, nil)
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, `{"error":"missing authorization header"}`, http.StatusUnauthorized)
+			httpx.WriteError(w, r, http.StatusUnauthorized, "MISSING_AUTHORIZATION", "Missing authorization header")
 			return
 		}
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			http.Error(w, `{"error":"invalid authorization header format"}`, http.StatusUnauthorized)
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_AUTHORIZATION", "Invalid authorization header format")
 			return
 		}
 
 		tokenString := parts[1]
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return m.jwtSecret, nil
-		})
+		token, err := m.parse(r.Context(), tokenString)
 
 		if err != nil || !token.Valid {
-			http.Error(w, `{"error":"invalid token"}`, http.StatusUnauthorized)
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid token")
 			return
 		}
 
 		claims, ok := token.Claims.(jwt.MapClaims)
 		if !ok {
-			http.Error(w, `{"error":"invalid token claims"}`, http.StatusUnauthorized)
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid token claims")
 			return
 		}
 
 		userID, ok := claims["sub"].(string)
 		if !ok {
-			http.Error(w, `{"error":"invalid user id in token"}`, http.StatusUnauthorized)
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid user ID in token")
+			return
+		}
+
+		if m.revoked(r.Context(), userID, claims) {
+			httpx.WriteError(w, r, http.StatusUnauthorized, "TOKEN_REVOKED", "Token has been revoked")
+			return
+		}
+
+		role, _ := claims["role"].(string)
+		if guest && role != roleGuest {
+			httpx.WriteError(w, r, http.StatusForbidden, "GUEST_ONLY", "This route is for guest checkout; use the parking routes when signed in")
+			return
+		}
+		if !guest && role == roleGuest {
+			httpx.WriteError(w, r, http.StatusForbidden, "GUEST_NOT_ALLOWED", "Sign in or register to continue")
 			return
 		}
 
@@ -70,6 +176,7 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 
 		// Also add to header for downstream services
 		r.Header.Set("X-User-ID", userID)
+		forwardScope(r, claims)
 
 		next.ServeHTTP(w, r)
 	})
@@ -78,6 +185,8 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 // OptionalAuth extracts user info if token present but doesn't require it
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwardScope(r, nil)
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
 			next.ServeHTTP(w, r)
@@ -90,19 +199,17 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 			return
 		}
 
-		token, err := jwt.Parse(parts[1], func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return m.jwtSecret, nil
-		})
+		token, err := m.parse(r.Context(), parts[1])
 
 		if err == nil && token.Valid {
 			if claims, ok := token.Claims.(jwt.MapClaims); ok {
-				if userID, ok := claims["sub"].(string); ok {
+				// Guests are treated as anonymous outside the guest routes
+				role, _ := claims["role"].(string)
+				if userID, ok := claims["sub"].(string); ok && role != roleGuest && !m.revoked(r.Context(), userID, claims) {
 					ctx := context.WithValue(r.Context(), UserIDKey, userID)
 					r = r.WithContext(ctx)
 					r.Header.Set("X-User-ID", userID)
+					forwardScope(r, claims)
 				}
 			}
 		}
@@ -111,6 +218,24 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	})
 }
 
+// RoleAdmin is the role of platform back-office staff
+const RoleAdmin = "admin"
+
+// RequireRole lets through only callers whose token carries role. It runs
+// after Authenticate and reads the role claim forwarded in X-User-Role,
+// which a client can't set itself.
+func RequireRole(role string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if GetUserID(r.Context()) == "" || r.Header.Get("X-User-Role") != role {
+				httpx.WriteError(w, r, http.StatusForbidden, "FORBIDDEN", "This route requires the "+role+" role")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(ctx context.Context) string {
 	if userID, ok := ctx.Value(UserIDKey).(string); ok {
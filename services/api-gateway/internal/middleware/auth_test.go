@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/parking-super-app/pkg/revocation"
 )
 
 func TestAuthMiddleware_Authenticate(t *testing.T) {
@@ -148,6 +154,289 @@ func TestAuthMiddleware_OptionalAuth(t *testing.T) {
 	}
 }
 
+func TestAuthMiddleware_ProviderScope(t *testing.T) {
+	secret := "test-secret-key"
+	authMw := NewAuthMiddleware(secret)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("failed to create test token: %v", err)
+		}
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name         string
+		token        string
+		wantRole     string
+		wantProvider string
+	}{
+		{
+			name:         "staff token",
+			token:        sign(jwt.MapClaims{"sub": "user-123", "exp": exp, "role": "provider_admin", "provider_id": "prov-1"}),
+			wantRole:     "provider_admin",
+			wantProvider: "prov-1",
+		},
+		{
+			name:  "customer token drops spoofed headers",
+			token: sign(jwt.MapClaims{"sub": "user-123", "exp": exp}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var role, provider string
+			handler := authMw.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				role, provider = r.Header.Get("X-User-Role"), r.Header.Get("X-Provider-ID")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.Header.Set("X-User-Role", "provider_admin")
+			req.Header.Set("X-Provider-ID", "someone-else")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if role != tt.wantRole || provider != tt.wantProvider {
+				t.Errorf("forwarded role %q provider %q, want %q %q", role, provider, tt.wantRole, tt.wantProvider)
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	secret := "test-secret-key"
+	authMw := NewAuthMiddleware(secret)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("failed to create test token: %v", err)
+		}
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+
+	tests := []struct {
+		name       string
+		token      string
+		spoofRole  string
+		wantStatus int
+	}{
+		{"admin token", sign(jwt.MapClaims{"sub": "admin-1", "exp": exp, "role": "admin"}), "", http.StatusOK},
+		{"customer token", sign(jwt.MapClaims{"sub": "user-123", "exp": exp}), "", http.StatusForbidden},
+		{"customer token with spoofed role", sign(jwt.MapClaims{"sub": "user-123", "exp": exp}), "admin", http.StatusForbidden},
+		{"provider staff token", sign(jwt.MapClaims{"sub": "user-123", "exp": exp, "role": "provider_admin", "provider_id": "prov-1"}), "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := authMw.Authenticate(RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/providers/p1/approve", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			if tt.spoofRole != "" {
+				req.Header.Set("X-User-Role", tt.spoofRole)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+
+	// Without Authenticate in front there is no caller to check
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/flags", nil)
+	req.Header.Set("X-User-Role", "admin")
+	RequireRole(RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status without Authenticate = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestAuthMiddleware_Guest(t *testing.T) {
+	secret := "test-secret-key"
+	authMw := NewAuthMiddleware(secret)
+
+	sign := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatalf("failed to create test token: %v", err)
+		}
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	guestToken := sign(jwt.MapClaims{"sub": "guest-1", "exp": exp, "role": "guest", "device_id": "device-1234"})
+	userToken := sign(jwt.MapClaims{"sub": "user-123", "exp": exp})
+
+	tests := []struct {
+		name       string
+		middleware func(http.Handler) http.Handler
+		token      string
+		wantStatus int
+		wantDevice string
+	}{
+		{"guest on guest route", authMw.AuthenticateGuest, guestToken, http.StatusOK, "device-1234"},
+		{"user on guest route", authMw.AuthenticateGuest, userToken, http.StatusForbidden, ""},
+		{"guest on user route", authMw.Authenticate, guestToken, http.StatusForbidden, ""},
+		{"user on user route drops spoofed device", authMw.Authenticate, userToken, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var device string
+			handler := tt.middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				device = r.Header.Get("X-Device-ID")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.Header.Set("X-Device-ID", "someone-else")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if device != tt.wantDevice {
+				t.Errorf("forwarded device %q, want %q", device, tt.wantDevice)
+			}
+		})
+	}
+
+	// A guest browsing public routes is anonymous
+	var userID string
+	handler := authMw.OptionalAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID = r.Header.Get("X-User-ID")
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Authorization", "Bearer "+guestToken)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if userID != "" {
+		t.Errorf("OptionalAuth forwarded guest as user %q", userID)
+	}
+}
+
+func TestAuthMiddleware_Denylist(t *testing.T) {
+	secret := "test-secret-key"
+	denylist := revocation.NewMemoryList()
+	authMw := NewAuthMiddleware(secret)
+	authMw.SetDenylist(denylist)
+
+	ctx := context.Background()
+	issued := time.Now().Add(-time.Minute)
+	denylist.RevokeToken(ctx, "revoked-jti", time.Now().Add(time.Hour))
+	denylist.RevokeUser(ctx, "banned-user", time.Now(), time.Hour)
+
+	tests := []struct {
+		name           string
+		userID         string
+		jti            string
+		issuedAt       time.Time
+		expectedStatus int
+	}{
+		{"live token", "user-123", "live-jti", issued, http.StatusOK},
+		{"revoked token", "user-123", "revoked-jti", issued, http.StatusUnauthorized},
+		{"token issued before user revocation", "banned-user", "old-jti", issued, http.StatusUnauthorized},
+		{"token issued after user revocation", "banned-user", "new-jti", time.Now().Add(time.Minute), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"sub": tt.userID,
+				"jti": tt.jti,
+				"iat": tt.issuedAt.Unix(),
+				"exp": time.Now().Add(time.Hour).Unix(),
+			})
+			signed, err := token.SignedString([]byte(secret))
+			if err != nil {
+				t.Fatalf("failed to sign token: %v", err)
+			}
+
+			handler := authMw.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+signed)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+type staticKeys map[string]crypto.PublicKey
+
+func (k staticKeys) Key(_ context.Context, kid string) (crypto.PublicKey, error) {
+	if key, ok := k[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.New("unknown key")
+}
+
+func TestAuthMiddleware_JWKS(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	_, otherPriv, _ := ed25519.GenerateKey(rand.Reader)
+	authMw := NewJWKSAuthMiddleware(staticKeys{"current": pub})
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}) string {
+		token := jwt.NewWithClaims(method, jwt.MapClaims{
+			"sub": "user-123",
+			"iat": time.Now().Unix(),
+			"exp": time.Now().Add(time.Hour).Unix(),
+		})
+		token.Header["kid"] = kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return signed
+	}
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus int
+	}{
+		{"signed with published key", sign(jwt.SigningMethodEdDSA, "current", priv), http.StatusOK},
+		{"unknown kid", sign(jwt.SigningMethodEdDSA, "retired", priv), http.StatusUnauthorized},
+		{"wrong key for kid", sign(jwt.SigningMethodEdDSA, "current", otherPriv), http.StatusUnauthorized},
+		{"HS256 with public key as secret", sign(jwt.SigningMethodHS256, "current", []byte(pub)), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := authMw.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if GetUserID(r.Context()) != "user-123" {
+					t.Errorf("expected user-123 in context, got %q", GetUserID(r.Context()))
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
 func createTestToken(t *testing.T, secret, userID string, expiresAt time.Time) string {
 	t.Helper()
 
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// generationKey holds the current cache generation. Entries are stored
+// under it, so Invalidate drops every entry at once by moving to a new one.
+// A generation that expires or is evicted just starts a fresh one.
+const (
+	generationKey = "generation"
+	generationTTL = 24 * time.Hour
+)
+
+// ResponseCache serves repeated GET requests for public, rarely changing
+// listings from a shared store. The cache key is the path and the sorted
+// query string; responses don't vary by user on the routes it is used for.
+type ResponseCache struct {
+	store cache.Store
+	ttl   time.Duration
+}
+
+type cachedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag,omitempty"`
+	Body        []byte `json:"body"`
+}
+
+func NewResponseCache(store cache.Store, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{store: store, ttl: ttl}
+}
+
+// Cache serves GET requests from the cache and stores successful upstream
+// responses. Store errors fall through to the upstream.
+func (c *ResponseCache) Cache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || strings.Contains(r.Header.Get("Cache-Control"), "no-cache") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		key, err := c.key(ctx, r)
+		if err != nil {
+			log.Printf("response cache unavailable: %v", err)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if data, err := c.store.Get(ctx, key); err == nil {
+			var resp cachedResponse
+			if json.Unmarshal(data, &resp) == nil {
+				w.Header().Set("Content-Type", resp.ContentType)
+				w.Header().Set("X-Cache", "HIT")
+				if resp.ETag != "" {
+					w.Header().Set("ETag", resp.ETag)
+					if httpx.ETagMatches(r.Header.Get("If-None-Match"), resp.ETag) {
+						w.WriteHeader(http.StatusNotModified)
+						return
+					}
+				}
+				w.WriteHeader(resp.Status)
+				w.Write(resp.Body)
+				return
+			}
+		}
+
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		w.Header().Set("X-Cache", "MISS")
+		next.ServeHTTP(rec, r)
+
+		if rec.status != http.StatusOK || !cacheable(w.Header()) {
+			return
+		}
+		data, err := json.Marshal(cachedResponse{
+			Status:      rec.status,
+			ContentType: w.Header().Get("Content-Type"),
+			ETag:        w.Header().Get("ETag"),
+			Body:        rec.body.Bytes(),
+		})
+		if err != nil {
+			return
+		}
+		if err := c.store.Set(context.WithoutCancel(ctx), key, data, c.ttl); err != nil {
+			log.Printf("failed to cache response: %v", err)
+		}
+	})
+}
+
+// Invalidate drops every cached response
+func (c *ResponseCache) Invalidate(ctx context.Context) error {
+	return c.store.Set(ctx, generationKey, []byte(uuid.NewString()), generationTTL)
+}
+
+func (c *ResponseCache) key(ctx context.Context, r *http.Request) (string, error) {
+	generation, err := c.store.Get(ctx, generationKey)
+	if errors.Is(err, cache.ErrMiss) {
+		generation = []byte(uuid.NewString())
+		err = c.store.Set(ctx, generationKey, generation, generationTTL)
+	}
+	if err != nil {
+		return "", err
+	}
+	// Encode sorts the parameters, so ?a=1&b=2 and ?b=2&a=1 share an entry
+	return string(generation) + ":" + r.URL.Path + "?" + r.URL.Query().Encode(), nil
+}
+
+// cacheable reports whether the upstream allowed the response to be shared
+func cacheable(h http.Header) bool {
+	cc := h.Get("Cache-Control")
+	return !strings.Contains(cc, "no-store") && !strings.Contains(cc, "private")
+}
+
+// recordingWriter copies the response body while passing it through
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
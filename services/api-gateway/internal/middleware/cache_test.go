@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/cache"
+)
+
+func TestResponseCache(t *testing.T) {
+	calls := 0
+	upstream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/private":
+			w.Header().Set("Cache-Control", "private")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"data":[]}`))
+	})
+
+	responseCache := NewResponseCache(cache.NewLRUStore(100), time.Minute)
+	handler := responseCache.Cache(upstream)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	if rec := get("/providers?active=true&page=1"); rec.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("first request: expected MISS, got %q", rec.Header().Get("X-Cache"))
+	}
+	rec := get("/providers?page=1&active=true")
+	if rec.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("same query in another order: expected HIT, got %q", rec.Header().Get("X-Cache"))
+	}
+	if rec.Body.String() != `{"data":[]}` || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("cached response differs: %q %q", rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", calls)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/providers?active=true&page=1", nil)
+	req.Header.Set("If-None-Match", `"v1"`)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("cached response with matching ETag: expected empty 304, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	if rec := get("/providers?active=false"); rec.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("different query: expected MISS, got %q", rec.Header().Get("X-Cache"))
+	}
+
+	if err := responseCache.Invalidate(context.Background()); err != nil {
+		t.Fatalf("Invalidate() error = %v", err)
+	}
+	if rec := get("/providers?active=true&page=1"); rec.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("after invalidation: expected MISS, got %q", rec.Header().Get("X-Cache"))
+	}
+
+	for _, path := range []string{"/missing", "/private"} {
+		get(path)
+		if rec := get(path); rec.Header().Get("X-Cache") != "MISS" {
+			t.Errorf("%s should not be cached, got %q", path, rec.Header().Get("X-Cache"))
+		}
+	}
+}
@@ -2,17 +2,135 @@ package middleware
 
 import (
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
-// CORS middleware handles Cross-Origin Resource Sharing
-func CORS(next http.Handler) http.Handler {
+// CORSPolicy controls which browser origins may call the API and how.
+// An origin is "*" for any origin, an exact origin such as
+// "https://app.example.com", or a wildcard subdomain such as
+// "https://*.mfe.example.com", which matches any host below that domain but
+// not the domain itself.
+type CORSPolicy struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+type originPattern struct {
+	scheme string
+	// host is the exact host, or the domain suffix (with its leading dot)
+	// for wildcard subdomains
+	host     string
+	wildcard bool
+}
+
+// CORS answers preflight requests and sets the CORS headers on responses to
+// allowed origins. Responses to other origins carry no CORS headers, so the
+// browser blocks them.
+type CORS struct {
+	policy    CORSPolicy
+	anyOrigin bool
+	patterns  []originPattern
+	methods   string
+	headers   string
+	exposed   string
+	maxAge    string
+}
+
+func NewCORS(policy CORSPolicy) *CORS {
+	c := &CORS{
+		policy:  policy,
+		methods: strings.Join(policy.AllowedMethods, ", "),
+		headers: strings.Join(policy.AllowedHeaders, ", "),
+		exposed: strings.Join(policy.ExposedHeaders, ", "),
+		maxAge:  strconv.Itoa(int(policy.MaxAge.Seconds())),
+	}
+	for _, origin := range policy.AllowedOrigins {
+		if origin == "*" {
+			c.anyOrigin = true
+			continue
+		}
+		if p, ok := parseOriginPattern(origin); ok {
+			c.patterns = append(c.patterns, p)
+		}
+	}
+	return c
+}
+
+func parseOriginPattern(origin string) (originPattern, bool) {
+	scheme, host, ok := strings.Cut(strings.ToLower(strings.TrimSuffix(origin, "/")), "://")
+	if !ok || host == "" {
+		return originPattern{}, false
+	}
+	if suffix, ok := strings.CutPrefix(host, "*."); ok {
+		return originPattern{scheme: scheme, host: "." + suffix, wildcard: true}, true
+	}
+	return originPattern{scheme: scheme, host: host}, true
+}
+
+// Allowed reports whether the policy admits the origin
+func (c *CORS) Allowed(origin string) bool {
+	if origin == "" {
+		return false
+	}
+	if c.anyOrigin {
+		return true
+	}
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Host == "" {
+		return false
+	}
+	for _, p := range c.patterns {
+		if p.scheme != u.Scheme {
+			continue
+		}
+		if p.wildcard && strings.HasSuffix(u.Host, p.host) && len(u.Host) > len(p.host) {
+			return true
+		}
+		if !p.wildcard && p.host == u.Host {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CORS) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID")
-		w.Header().Set("Access-Control-Max-Age", "86400")
+		h := w.Header()
+		origin := r.Header.Get("Origin")
+		allowed := c.Allowed(origin)
+
+		if allowed {
+			// Credentials can't be shared with "*", so the origin is echoed
+			// back whenever the answer depends on it
+			if c.anyOrigin && !c.policy.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			} else {
+				h.Set("Access-Control-Allow-Origin", origin)
+				h.Add("Vary", "Origin")
+			}
+			if c.policy.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+			if c.exposed != "" {
+				h.Set("Access-Control-Expose-Headers", c.exposed)
+			}
+		} else if !c.anyOrigin {
+			h.Add("Vary", "Origin")
+		}
 
 		if r.Method == http.MethodOptions {
+			if allowed && r.Header.Get("Access-Control-Request-Method") != "" {
+				h.Set("Access-Control-Allow-Methods", c.methods)
+				h.Set("Access-Control-Allow-Headers", c.headers)
+				h.Set("Access-Control-Max-Age", c.maxAge)
+			}
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCORS_Allowed(t *testing.T) {
+	cors := NewCORS(CORSPolicy{AllowedOrigins: []string{
+		"https://app.parking.my",
+		"https://*.mfe.parking.my",
+		"http://localhost:3000",
+	}})
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://app.parking.my", true},
+		{"https://APP.parking.my", true},
+		{"http://app.parking.my", false},
+		{"https://evil.app.parking.my", false},
+		{"https://metropark.mfe.parking.my", true},
+		{"https://a.b.mfe.parking.my", true},
+		{"https://mfe.parking.my", false},
+		{"https://metropark.mfe.parking.my.evil.com", false},
+		{"https://evilmfe.parking.my", false},
+		{"http://localhost:3000", true},
+		{"http://localhost:3001", false},
+		{"", false},
+		{"null", false},
+	}
+	for _, tt := range tests {
+		if got := cors.Allowed(tt.origin); got != tt.want {
+			t.Errorf("Allowed(%q) = %v, want %v", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestCORS_Handler(t *testing.T) {
+	policy := CORSPolicy{
+		AllowedOrigins:   []string{"https://*.mfe.parking.my"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		ExposedHeaders:   []string{"ETag"},
+		AllowCredentials: true,
+		MaxAge:           time.Hour,
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := NewCORS(policy).Handler(next)
+
+	t.Run("preflight from an allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/api/v1/wallet", nil)
+		req.Header.Set("Origin", "https://metropark.mfe.parking.my")
+		req.Header.Set("Access-Control-Request-Method", "POST")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		h := rec.Header()
+		if rec.Code != http.StatusOK {
+			t.Errorf("status = %d, want 200", rec.Code)
+		}
+		if h.Get("Access-Control-Allow-Origin") != "https://metropark.mfe.parking.my" ||
+			h.Get("Access-Control-Allow-Credentials") != "true" ||
+			h.Get("Access-Control-Allow-Methods") != "GET, POST" ||
+			h.Get("Access-Control-Allow-Headers") != "Content-Type, Authorization" ||
+			h.Get("Access-Control-Max-Age") != "3600" ||
+			h.Get("Vary") != "Origin" {
+			t.Errorf("preflight headers = %v", h)
+		}
+	})
+
+	t.Run("request from a disallowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/wallet", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusTeapot {
+			t.Errorf("status = %d, want the handler's", rec.Code)
+		}
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want none", got)
+		}
+	})
+
+	t.Run("any origin without credentials", func(t *testing.T) {
+		handler := NewCORS(CORSPolicy{AllowedOrigins: []string{"*"}, ExposedHeaders: []string{"ETag"}}).Handler(next)
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/providers", nil)
+		req.Header.Set("Origin", "https://anywhere.example.com")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+			t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+		}
+		if got := rec.Header().Get("Access-Control-Expose-Headers"); got != "ETag" {
+			t.Errorf("Access-Control-Expose-Headers = %q, want ETag", got)
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+type routeBudget struct {
+	pattern string
+	budget  time.Duration
+}
+
+// Deadlines gives each request a time budget shared by every call it makes
+// downstream. A request still unanswered when its budget runs out gets a 504
+// listing its downstream calls and how far each got.
+type Deadlines struct {
+	fallback time.Duration
+	routes   []routeBudget
+}
+
+func NewDeadlines(fallback time.Duration) *Deadlines {
+	return &Deadlines{fallback: fallback}
+}
+
+// Route sets the budget for a path. A pattern ending in "/" matches every
+// path under it; the most specific match wins. A zero budget leaves the
+// route unbounded, for streams.
+func (d *Deadlines) Route(pattern string, budget time.Duration) {
+	d.routes = append(d.routes, routeBudget{pattern: pattern, budget: budget})
+	sort.SliceStable(d.routes, func(i, j int) bool {
+		return len(d.routes[i].pattern) > len(d.routes[j].pattern)
+	})
+}
+
+func (d *Deadlines) budget(r *http.Request) time.Duration {
+	for _, route := range d.routes {
+		if route.pattern == r.URL.Path ||
+			(strings.HasSuffix(route.pattern, "/") && strings.HasPrefix(r.URL.Path, route.pattern)) {
+			return route.budget
+		}
+	}
+	return d.fallback
+}
+
+// timeoutDiagnostics is the diagnostics member of a 504
+type timeoutDiagnostics struct {
+	BudgetMS  int64           `json:"budget_ms"`
+	ElapsedMS int64           `json:"elapsed_ms"`
+	Calls     []deadline.Call `json:"calls"`
+}
+
+// Handler runs each request within its budget. Event streams are never
+// bounded. Once a response has started it is allowed to finish, since its
+// status can no longer change.
+func (d *Deadlines) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit := d.budget(r)
+		if limit <= 0 || strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx, budget, cancel := deadline.WithBudget(r.Context(), limit)
+		defer cancel()
+
+		tw := &timeoutWriter{w: w, header: make(http.Header)}
+		done := make(chan struct{})
+		panicked := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+					return
+				}
+				close(done)
+			}()
+			next.ServeHTTP(tw, r.WithContext(ctx))
+		}()
+
+		wait := func() {
+			select {
+			case <-done:
+			case p := <-panicked:
+				panic(p)
+			}
+		}
+
+		select {
+		case <-done:
+		case p := <-panicked:
+			panic(p)
+		case <-ctx.Done():
+			// A client that went away gets no answer, and a response already
+			// under way is left to finish
+			if ctx.Err() != context.DeadlineExceeded || !tw.timeOut() {
+				wait()
+				return
+			}
+			p := httpx.NewProblem(r, http.StatusGatewayTimeout, "GATEWAY_TIMEOUT",
+				fmt.Sprintf("Request did not complete within %s", limit))
+			p.Diagnostics = timeoutDiagnostics{
+				BudgetMS:  limit.Milliseconds(),
+				ElapsedMS: budget.Elapsed().Milliseconds(),
+				Calls:     budget.Calls(),
+			}
+			httpx.WriteProblem(w, p)
+		}
+	})
+}
+
+// timeoutWriter passes a handler's response through until the request times
+// out, after which the handler's writes are dropped. The handler gets its
+// own header map so it can't race the 504.
+type timeoutWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+
+	mu          sync.Mutex
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.writeHeader(status)
+}
+
+func (tw *timeoutWriter) writeHeader(status int) {
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	dst := tw.w.Header()
+	for key, values := range tw.header {
+		dst[key] = values
+	}
+	tw.w.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	tw.writeHeader(http.StatusOK)
+	return tw.w.Write(b)
+}
+
+// timeOut stops the handler's writes, unless its response has started
+func (tw *timeoutWriter) timeOut() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.wroteHeader {
+		return false
+	}
+	tw.timedOut = true
+	return true
+}
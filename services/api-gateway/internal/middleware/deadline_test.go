@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/pkg/deadline"
+)
+
+// slowHandler makes one fast and one slow downstream call, then answers
+func slowHandler(w http.ResponseWriter, r *http.Request) {
+	deadline.Track(r.Context(), "wallet")(nil)
+
+	done := deadline.Track(r.Context(), "provider")
+	select {
+	case <-time.After(200 * time.Millisecond):
+		done(nil)
+	case <-r.Context().Done():
+		done(r.Context().Err())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestDeadlines(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		accept     string
+		wantStatus int
+	}{
+		{"over budget", "/api/v1/home", "", http.StatusGatewayTimeout},
+		{"route with a longer budget", "/api/v1/auth/me/kyc", "", http.StatusOK},
+		{"unbounded route", "/api/v1/stream", "", http.StatusOK},
+		{"event stream", "/api/v1/parking/sessions/s-1", "text/event-stream", http.StatusOK},
+	}
+
+	deadlines := NewDeadlines(50 * time.Millisecond)
+	deadlines.Route("/api/v1/auth/me/kyc", time.Second)
+	deadlines.Route("/api/v1/stream", 0)
+	handler := deadlines.Handler(http.HandlerFunc(slowHandler))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeadlines_Diagnostics(t *testing.T) {
+	handler := NewDeadlines(50 * time.Millisecond).Handler(http.HandlerFunc(slowHandler))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/home", nil))
+
+	var problem struct {
+		Code        string             `json:"code"`
+		Diagnostics timeoutDiagnostics `json:"diagnostics"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&problem); err != nil {
+		t.Fatal(err)
+	}
+	if problem.Code != "GATEWAY_TIMEOUT" || problem.Diagnostics.BudgetMS != 50 || problem.Diagnostics.ElapsedMS < 50 {
+		t.Errorf("problem = %+v", problem)
+	}
+	calls := problem.Diagnostics.Calls
+	if len(calls) != 2 || calls[0].Target != "wallet" || calls[0].State != deadline.StateOK ||
+		calls[1].Target != "provider" || calls[1].State == deadline.StateOK {
+		t.Errorf("calls = %+v", calls)
+	}
+}
+
+func TestDeadlines_StartedResponseFinishes(t *testing.T) {
+	handler := NewDeadlines(20 * time.Millisecond).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		<-r.Context().Done()
+		if _, err := w.Write([]byte("late")); errors.Is(err, http.ErrHandlerTimeout) {
+			t.Error("write after the response started was dropped")
+		}
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/wallet", nil))
+
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "late" {
+		t.Errorf("response = %d %q", rec.Code, rec.Body)
+	}
+}
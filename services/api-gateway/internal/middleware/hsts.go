@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// StrictTransportSecurity tells browsers to reach the API over HTTPS only,
+// for maxAge. Browsers ignore the header on plain HTTP, so it is safe to send
+// when TLS is terminated in front of the gateway. A zero maxAge sends nothing.
+func StrictTransportSecurity(maxAge time.Duration, includeSubdomains bool) func(http.Handler) http.Handler {
+	value := "max-age=" + strconv.FormatInt(int64(maxAge.Seconds()), 10)
+	if includeSubdomains {
+		value += "; includeSubDomains"
+	}
+	return func(next http.Handler) http.Handler {
+		if maxAge <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Strict-Transport-Security", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
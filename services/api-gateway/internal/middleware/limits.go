@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/parking-super-app/pkg/httpx"
+)
+
+// BodyPolicy is what a route accepts in a request body
+type BodyPolicy struct {
+	MaxBytes int64
+	// ContentTypes lists the accepted media types; empty accepts any
+	ContentTypes []string
+}
+
+type routePolicy struct {
+	method  string
+	pattern string
+	policy  BodyPolicy
+}
+
+// RequestGuard rejects request bodies that are too large or of a media type
+// the route does not accept, before they reach a service. Routes that take
+// uploads or third-party webhooks get their own policy.
+type RequestGuard struct {
+	fallback BodyPolicy
+	routes   []routePolicy
+}
+
+func NewRequestGuard(fallback BodyPolicy) *RequestGuard {
+	return &RequestGuard{fallback: fallback}
+}
+
+// Route sets the policy for requests with the given method (empty for any)
+// and path. A pattern ending in "/" matches every path under it; the most
+// specific match wins.
+func (g *RequestGuard) Route(method, pattern string, policy BodyPolicy) {
+	g.routes = append(g.routes, routePolicy{method: method, pattern: pattern, policy: policy})
+	sort.SliceStable(g.routes, func(i, j int) bool {
+		return len(g.routes[i].pattern) > len(g.routes[j].pattern)
+	})
+}
+
+func (g *RequestGuard) policy(r *http.Request) BodyPolicy {
+	for _, route := range g.routes {
+		if route.method != "" && route.method != r.Method {
+			continue
+		}
+		if route.pattern == r.URL.Path ||
+			(strings.HasSuffix(route.pattern, "/") && strings.HasPrefix(r.URL.Path, route.pattern)) {
+			return route.policy
+		}
+	}
+	return g.fallback
+}
+
+// Guard enforces the matching policy. Bodies without a Content-Length are
+// cut off at the limit while they are read.
+func (g *RequestGuard) Guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength == 0 || r.Body == nil || r.Body == http.NoBody {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		policy := g.policy(r)
+		if len(policy.ContentTypes) > 0 && !acceptsContentType(policy.ContentTypes, r.Header.Get("Content-Type")) {
+			httpx.WriteError(w, r, http.StatusUnsupportedMediaType, "UNSUPPORTED_MEDIA_TYPE",
+				"Content-Type must be "+strings.Join(policy.ContentTypes, " or "))
+			return
+		}
+		if policy.MaxBytes > 0 {
+			if r.ContentLength > policy.MaxBytes {
+				httpx.WriteError(w, r, http.StatusRequestEntityTooLarge, httpx.CodeBodyTooLarge,
+					fmt.Sprintf("Request body must not exceed %d bytes", policy.MaxBytes))
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, policy.MaxBytes)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// acceptsContentType reports whether header names one of the accepted media
+// types. JSON must be UTF-8, the only encoding the services decode.
+func acceptsContentType(accepted []string, header string) bool {
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err != nil {
+		return false
+	}
+	if mediaType == "application/json" {
+		if charset, ok := params["charset"]; ok && !strings.EqualFold(charset, "utf-8") {
+			return false
+		}
+	}
+	for _, t := range accepted {
+		if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestGuard() http.Handler {
+	guard := NewRequestGuard(BodyPolicy{MaxBytes: 16, ContentTypes: []string{"application/json"}})
+	guard.Route(http.MethodPost, "/api/v1/auth/me/kyc", BodyPolicy{MaxBytes: 64, ContentTypes: []string{"application/json", "multipart/form-data"}})
+	guard.Route(http.MethodPost, "/api/v1/wallet/webhooks/", BodyPolicy{MaxBytes: 16})
+
+	return guard.Guard(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestRequestGuard(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+	}{
+		{"small json", http.MethodPost, "/api/v1/wallet/topup", "application/json", `{"amount":10}`, http.StatusOK},
+		{"json with utf-8 charset", http.MethodPost, "/api/v1/wallet/topup", "application/json; charset=UTF-8", `{}`, http.StatusOK},
+		{"json with other charset", http.MethodPost, "/api/v1/wallet/topup", "application/json; charset=latin1", `{}`, http.StatusUnsupportedMediaType},
+		{"missing content type", http.MethodPost, "/api/v1/wallet/topup", "", `{}`, http.StatusUnsupportedMediaType},
+		{"form body", http.MethodPut, "/api/v1/wallet/topup", "application/x-www-form-urlencoded", "amount=10", http.StatusUnsupportedMediaType},
+		{"too large", http.MethodPost, "/api/v1/wallet/topup", "application/json", `{"amount":1000000}`, http.StatusRequestEntityTooLarge},
+		{"no body", http.MethodGet, "/api/v1/wallet", "", "", http.StatusOK},
+		{"kyc upload over the default limit", http.MethodPost, "/api/v1/auth/me/kyc", "multipart/form-data; boundary=x", strings.Repeat("a", 40), http.StatusOK},
+		{"kyc upload too large", http.MethodPost, "/api/v1/auth/me/kyc", "application/json", strings.Repeat("a", 65), http.StatusRequestEntityTooLarge},
+		{"kyc limit is for posts only", http.MethodPut, "/api/v1/auth/me/kyc", "application/json", strings.Repeat("a", 40), http.StatusRequestEntityTooLarge},
+		{"webhook in any format", http.MethodPost, "/api/v1/wallet/webhooks/fpx", "application/x-www-form-urlencoded", "status=ok", http.StatusOK},
+		{"webhook too large", http.MethodPost, "/api/v1/wallet/webhooks/fpx", "text/plain", strings.Repeat("a", 17), http.StatusRequestEntityTooLarge},
+	}
+
+	handler := newTestGuard()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequestGuard_UnknownLength(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/wallet/topup", strings.NewReader(`{"amount":1000000}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+
+	newTestGuard().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d once the limit is read past", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestStrictTransportSecurity(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	rec := httptest.NewRecorder()
+	StrictTransportSecurity(365*24*time.Hour, true)(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "max-age=31536000; includeSubDomains" {
+		t.Errorf("Strict-Transport-Security = %q", got)
+	}
+
+	rec = httptest.NewRecorder()
+	StrictTransportSecurity(0, true)(ok).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if got := rec.Header().Get("Strict-Transport-Security"); got != "" {
+		t.Errorf("Strict-Transport-Security with zero max age = %q, want none", got)
+	}
+}
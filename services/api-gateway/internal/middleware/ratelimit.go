@@ -2,8 +2,11 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
 )
 
 // RateLimiter implements a simple in-memory rate limiter
@@ -33,6 +36,14 @@ func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
 	return rl
 }
 
+// SetLimit changes the limit and window for subsequent requests
+func (rl *RateLimiter) SetLimit(limit int, window time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.limit = limit
+	rl.window = window
+}
+
 func (rl *RateLimiter) cleanup() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
@@ -86,8 +97,11 @@ func (rl *RateLimiter) Limit(next http.Handler) http.Handler {
 		}
 
 		if !rl.isAllowed(key) {
-			w.Header().Set("Retry-After", "60")
-			http.Error(w, `{"error":"rate limit exceeded"}`, http.StatusTooManyRequests)
+			rl.mu.RLock()
+			retryAfter := int(rl.window.Seconds())
+			rl.mu.RUnlock()
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			httpx.WriteError(w, r, http.StatusTooManyRequests, "RATE_LIMITED", "Rate limit exceeded")
 			return
 		}
 
@@ -129,3 +129,36 @@ func TestRateLimiter_WindowReset(t *testing.T) {
 		t.Errorf("request 3 after reset: expected 200, got %d", rec3.Code)
 	}
 }
+
+func TestRateLimiter_SetLimit(t *testing.T) {
+	limiter := NewRateLimiter(1, time.Minute)
+
+	handler := limiter.Limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RemoteAddr = "10.0.0.3:12345"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	send()
+	if rec := send(); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429 before raising the limit, got %d", rec.Code)
+	}
+
+	limiter.SetLimit(3, 30*time.Second)
+	if rec := send(); rec.Code != http.StatusOK {
+		t.Errorf("expected status 200 after raising the limit, got %d", rec.Code)
+	}
+	send()
+	rec := send()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 at the new limit, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("expected Retry-After 30, got %q", got)
+	}
+}
@@ -0,0 +1,126 @@
+package proxy
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// BulkheadConfig bounds the requests in flight to one service, so a slow
+// service holds at most MaxConcurrent of the gateway's connections and
+// goroutines rather than all of them
+type BulkheadConfig struct {
+	MaxConcurrent int
+	// MaxWait is how long a request waits for a free slot before it is
+	// rejected
+	MaxWait time.Duration
+	// RejectStatus is the status of rejected requests: 503, or 429 to have
+	// clients back off as they do when rate limited
+	RejectStatus int
+	// RetryAfter is sent with rejections
+	RetryAfter time.Duration
+}
+
+// bulkhead is a semaphore over one service's requests
+type bulkhead struct {
+	name    string
+	cfg     BulkheadConfig
+	slots   chan struct{}
+	waiting atomic.Int64
+	attrs   metric.MeasurementOption
+}
+
+func newBulkhead(name string, cfg BulkheadConfig) *bulkhead {
+	return &bulkhead{
+		name:  name,
+		cfg:   cfg,
+		slots: make(chan struct{}, cfg.MaxConcurrent),
+		attrs: metric.WithAttributes(attribute.String("upstream", name)),
+	}
+}
+
+// acquire takes a slot, waiting up to MaxWait, and reports whether it got
+// one. Call release when the request is done.
+func (b *bulkhead) acquire(ctx context.Context) bool {
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	default:
+	}
+	if b.cfg.MaxWait <= 0 {
+		return false
+	}
+
+	b.waiting.Add(1)
+	defer b.waiting.Add(-1)
+	timer := time.NewTimer(b.cfg.MaxWait)
+	defer timer.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (b *bulkhead) release() {
+	<-b.slots
+}
+
+// retryAfter is the Retry-After header value in whole seconds
+func (b *bulkhead) retryAfter() string {
+	seconds := int(b.cfg.RetryAfter.Round(time.Second) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.Itoa(seconds)
+}
+
+// bulkheadMetrics reports each bulkhead's use
+type bulkheadMetrics struct {
+	rejected metric.Int64Counter
+}
+
+// registerMetrics reports in-flight, waiting and maximum requests per
+// service when the gauges are read, and counts rejections
+func registerMetrics(meter metric.Meter, bulkheads func() []*bulkhead) (*bulkheadMetrics, error) {
+	inFlight, err := meter.Int64ObservableGauge("gateway.upstream.in_flight",
+		metric.WithDescription("Requests being forwarded to the service"))
+	if err != nil {
+		return nil, err
+	}
+	waiting, err := meter.Int64ObservableGauge("gateway.upstream.waiting",
+		metric.WithDescription("Requests waiting for a free slot to the service"))
+	if err != nil {
+		return nil, err
+	}
+	limit, err := meter.Int64ObservableGauge("gateway.upstream.limit",
+		metric.WithDescription("Maximum requests forwarded to the service at once"))
+	if err != nil {
+		return nil, err
+	}
+	rejected, err := meter.Int64Counter("gateway.upstream.rejected",
+		metric.WithDescription("Requests rejected because the service had no free slot"))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, b := range bulkheads() {
+			o.ObserveInt64(inFlight, int64(len(b.slots)), b.attrs)
+			o.ObserveInt64(waiting, b.waiting.Load(), b.attrs)
+			o.ObserveInt64(limit, int64(b.cfg.MaxConcurrent), b.attrs)
+		}
+		return nil
+	}, inFlight, waiting, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &bulkheadMetrics{rejected: rejected}, nil
+}
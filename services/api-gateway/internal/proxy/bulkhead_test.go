@@ -0,0 +1,82 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForward_Bulkhead(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        BulkheadConfig
+		wantStatus int
+	}{
+		{"rejected when saturated", BulkheadConfig{MaxConcurrent: 1}, http.StatusServiceUnavailable},
+		{"rejected as rate limited", BulkheadConfig{MaxConcurrent: 1, RejectStatus: http.StatusTooManyRequests}, http.StatusTooManyRequests},
+		{"waits for a free slot", BulkheadConfig{MaxConcurrent: 1, MaxWait: time.Second}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := make(chan struct{}, 1)
+			unblock := make(chan struct{})
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/slow" {
+					started <- struct{}{}
+					<-unblock
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer upstream.Close()
+
+			p := NewServiceProxy()
+			p.Limit("wallet", upstream.URL, tt.cfg)
+			forward := p.Forward(upstream.URL)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				forward(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+			}()
+			<-started
+
+			// The slow request finishes while the next one waits
+			time.AfterFunc(50*time.Millisecond, func() { close(unblock) })
+			rec := httptest.NewRecorder()
+			forward(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+			wg.Wait()
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK && rec.Header().Get("Retry-After") != "1" {
+				t.Errorf("Retry-After = %q, want 1", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestForward_StreamsBypassBulkhead(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer upstream.Close()
+
+	p := NewServiceProxy()
+	p.Limit("parking", upstream.URL, BulkheadConfig{MaxConcurrent: 1})
+	p.bulkheads[upstream.URL].slots <- struct{}{} // saturated
+	forward := p.Forward(upstream.URL)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	req.Header.Set("Accept", "text/event-stream")
+	rec := httptest.NewRecorder()
+	forward(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
@@ -1,41 +1,119 @@
 package proxy
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 // ServiceProxy handles request forwarding to backend services
 type ServiceProxy struct {
-	client *http.Client
+	client       *http.Client
+	streamClient *http.Client
+
+	mu        sync.RWMutex
+	bulkheads map[string]*bulkhead // by target URL
+	metrics   *bulkheadMetrics
 }
 
 func NewServiceProxy() *ServiceProxy {
-	return &ServiceProxy{
+	transport := &http.Transport{
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		IdleConnTimeout:     90 * time.Second,
+	}
+
+	p := &ServiceProxy{
 		client: &http.Client{
-			Timeout: 30 * time.Second,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 10,
-				IdleConnTimeout:     90 * time.Second,
-			},
+			Timeout:   30 * time.Second,
+			Transport: transport,
+		},
+		// Event streams stay open until the client goes away, so no overall timeout
+		streamClient: &http.Client{
+			Transport: transport,
 		},
+		bulkheads: make(map[string]*bulkhead),
+	}
+
+	metrics, err := registerMetrics(telemetry.Meter("github.com/parking-super-app/services/api-gateway/internal/proxy"), p.allBulkheads)
+	if err != nil {
+		log.Printf("failed to register proxy metrics: %v", err)
+	}
+	p.metrics = metrics
+	return p
+}
+
+// Limit puts the service at targetURL behind a bulkhead; name labels its
+// metrics. Call it before Forward for the same URL.
+func (p *ServiceProxy) Limit(name, targetURL string, cfg BulkheadConfig) {
+	if cfg.MaxConcurrent <= 0 {
+		return
+	}
+	if cfg.RejectStatus == 0 {
+		cfg.RejectStatus = http.StatusServiceUnavailable
 	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.bulkheads[targetURL] = newBulkhead(name, cfg)
 }
 
-// Forward proxies the request to the target service
+func (p *ServiceProxy) allBulkheads() []*bulkhead {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	bulkheads := make([]*bulkhead, 0, len(p.bulkheads))
+	for _, b := range p.bulkheads {
+		bulkheads = append(bulkheads, b)
+	}
+	return bulkheads
+}
+
+// isEventStream checks if the client asked for a Server-Sent Events response
+func isEventStream(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+// Forward proxies the request to the target service. Event streams stay
+// open for as long as the client listens, so they don't count against the
+// service's bulkhead.
 func (p *ServiceProxy) Forward(targetURL string) http.HandlerFunc {
+	p.mu.RLock()
+	b := p.bulkheads[targetURL]
+	p.mu.RUnlock()
+
 	return func(w http.ResponseWriter, r *http.Request) {
 		target, err := url.Parse(targetURL)
 		if err != nil {
-			http.Error(w, `{"error":"invalid target URL"}`, http.StatusInternalServerError)
+			httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Invalid target URL")
 			return
 		}
 
+		streaming := isEventStream(r)
+		if b != nil && !streaming {
+			if !b.acquire(r.Context()) {
+				if p.metrics != nil {
+					p.metrics.rejected.Add(r.Context(), 1, b.attrs)
+				}
+				w.Header().Set("Retry-After", b.retryAfter())
+				httpx.WriteError(w, r, b.cfg.RejectStatus, "UPSTREAM_BUSY",
+					fmt.Sprintf("The %s service is busy, please retry shortly", b.name))
+				return
+			}
+			defer b.release()
+		}
+
 		// Build the full target URL
 		proxyURL := *target
 		proxyURL.Path = r.URL.Path
@@ -44,7 +122,7 @@ func (p *ServiceProxy) Forward(targetURL string) http.HandlerFunc {
 		// Create the proxy request
 		proxyReq, err := http.NewRequestWithContext(r.Context(), r.Method, proxyURL.String(), r.Body)
 		if err != nil {
-			http.Error(w, `{"error":"failed to create request"}`, http.StatusInternalServerError)
+			httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to create request")
 			return
 		}
 
@@ -55,32 +133,93 @@ func (p *ServiceProxy) Forward(targetURL string) http.HandlerFunc {
 			}
 		}
 
-		// Add forwarding headers
-		proxyReq.Header.Set("X-Forwarded-For", r.RemoteAddr)
+		// Add forwarding headers. Services resolve the client address from
+		// X-Forwarded-For, which must hold a bare IP.
+		clientIP := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(clientIP); err == nil {
+			clientIP = host
+		}
+		proxyReq.Header.Set("X-Forwarded-For", clientIP)
 		proxyReq.Header.Set("X-Forwarded-Host", r.Host)
 
+		// Services reuse the gateway's request ID, so one ID covers the whole call
+		if requestID := httpx.RequestID(r); requestID != "" {
+			proxyReq.Header.Set(httpx.RequestIDHeader, requestID)
+		}
+
+		// Pass the gateway's span on, so the service's spans join its trace
+		otel.GetTextMapPropagator().Inject(r.Context(), propagation.HeaderCarrier(proxyReq.Header))
+
+		// The service stops when the request's budget runs out, as the gateway does
+		deadline.Inject(r.Context(), proxyReq.Header)
+
 		// Make the request
-		resp, err := p.client.Do(proxyReq)
+		client := p.client
+		if streaming {
+			client = p.streamClient
+		}
+
+		done := deadline.Track(r.Context(), proxyReq.Method+" "+proxyURL.Host+proxyURL.Path)
+		resp, err := client.Do(proxyReq)
+		done(err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			httpx.WriteError(w, r, http.StatusRequestEntityTooLarge, httpx.CodeBodyTooLarge,
+				fmt.Sprintf("Request body must not exceed %d bytes", maxBytesErr.Limit))
+			return
+		}
 		if err != nil {
 			log.Printf("proxy error: %v", err)
-			http.Error(w, `{"error":"service unavailable"}`, http.StatusBadGateway)
+			httpx.WriteError(w, r, http.StatusBadGateway, "SERVICE_UNAVAILABLE", "Service unavailable")
 			return
 		}
 		defer resp.Body.Close()
 
-		// Copy response headers
+		// Copy response headers; the service's values replace any the
+		// gateway set, such as the security headers both send, except Vary,
+		// which both add to
 		for key, values := range resp.Header {
+			if key != "Vary" {
+				w.Header().Del(key)
+			}
 			for _, value := range values {
 				w.Header().Add(key, value)
 			}
 		}
 
 		// Write response
+		if streaming {
+			copyStream(w, resp)
+			return
+		}
 		w.WriteHeader(resp.StatusCode)
 		io.Copy(w, resp.Body)
 	}
 }
 
+// copyStream relays a streaming response, flushing each chunk as it arrives
+func copyStream(w http.ResponseWriter, resp *http.Response) {
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.WriteHeader(resp.StatusCode)
+	rc.Flush()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := w.Write(buf[:n]); writeErr != nil {
+				return
+			}
+			rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 // StripPrefix removes a prefix from the request path before forwarding
 func (p *ServiceProxy) StripPrefix(prefix, targetURL string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
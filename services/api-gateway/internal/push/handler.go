@@ -0,0 +1,155 @@
+package push
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
+	gatewaymw "github.com/parking-super-app/services/api-gateway/internal/middleware"
+)
+
+const (
+	// EventStreamReset tells a reconnecting client that events were missed
+	// and it should refetch what it is showing
+	EventStreamReset = "stream.reset"
+
+	// reconnectDelay is the retry hint sent to clients, in milliseconds
+	reconnectDelay = 3000
+)
+
+// Handler streams a user's events as Server-Sent Events
+type Handler struct {
+	hub       *Hub
+	heartbeat time.Duration
+}
+
+func NewHandler(hub *Hub, heartbeat time.Duration) *Handler {
+	if heartbeat <= 0 {
+		heartbeat = 15 * time.Second
+	}
+	return &Handler{hub: hub, heartbeat: heartbeat}
+}
+
+// Stream sends the authenticated user's events until the client disconnects.
+// ?types= takes comma-separated event type prefixes, e.g. parking.session.,wallet.
+// Clients resume after a dropped connection with the Last-Event-ID header.
+func (h *Handler) Stream(w http.ResponseWriter, r *http.Request) {
+	userID := gatewaymw.GetUserID(r.Context())
+	if userID == "" {
+		httpx.WriteError(w, r, http.StatusUnauthorized, "MISSING_AUTHORIZATION", "Authentication required")
+		return
+	}
+
+	var lastEventID uint64
+	if v := r.Header.Get("Last-Event-ID"); v != "" {
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_LAST_EVENT_ID", "Last-Event-ID must be an event ID from this stream")
+			return
+		}
+		lastEventID = parsed
+	}
+	prefixes := parsePrefixes(r.URL.Query().Get("types"))
+
+	events, missed, complete, cancel, err := h.hub.Subscribe(userID, lastEventID)
+	if err != nil {
+		if errors.Is(err, ErrConnectionLimit) {
+			httpx.WriteError(w, r, http.StatusTooManyRequests, "TOO_MANY_STREAMS", "Too many open streams")
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to open stream")
+		return
+	}
+	defer cancel()
+
+	// Streams outlive the server write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay); err != nil {
+		return
+	}
+	if !complete {
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: {}\n\n", EventStreamReset); err != nil {
+			return
+		}
+	}
+	for _, event := range missed {
+		if matches(event.Type, prefixes) {
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if !matches(event.Type, prefixes) {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			rc.Flush()
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event Event) error {
+	payload, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, payload)
+	return err
+}
+
+func parsePrefixes(types string) []string {
+	var prefixes []string
+	for _, p := range strings.Split(types, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return prefixes
+}
+
+func matches(eventType string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, p := range prefixes {
+		if strings.HasPrefix(eventType, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,190 @@
+package push
+
+import (
+	"sync"
+	"time"
+
+	"github.com/parking-super-app/pkg/fanout"
+)
+
+var ErrConnectionLimit = fanout.ErrLimitReached
+
+// Config holds connection limits and the reconnect buffer for the push hub
+type Config struct {
+	MaxConnections        int           // Total open streams across all users
+	MaxConnectionsPerUser int           // Open streams per user
+	BufferSize            int           // Events buffered per stream before dropping
+	ReplaySize            int           // Recent events kept per user for reconnecting clients
+	ReplayWindow          time.Duration // How long a disconnected user's events are still kept
+}
+
+// DefaultConfig returns sensible default limits
+func DefaultConfig() Config {
+	return Config{
+		MaxConnections:        5000,
+		MaxConnectionsPerUser: 5,
+		BufferSize:            32,
+		ReplaySize:            50,
+		ReplayWindow:          2 * time.Minute,
+	}
+}
+
+// Event is a backend event addressed to one user. IDs increase over the life
+// of a hub and are sent as the SSE id so clients can resume with Last-Event-ID.
+type Event struct {
+	ID      uint64
+	Type    string
+	Payload map[string]interface{}
+}
+
+// userLog is a user's recent events. trimmed is the ID of the newest event
+// that fell out of the log; a client that last saw anything older has a gap.
+// Logs exist only while the user has a stream open or closed one recently.
+type userLog struct {
+	events    []Event
+	trimmed   uint64
+	idleSince time.Time
+}
+
+// Hub fans out user events to that user's open streams and keeps a short
+// history per user so a reconnecting client can catch up. mu orders each
+// event's ID, its place in the log and its delivery.
+type Hub struct {
+	cfg    Config
+	mu     sync.Mutex
+	seq    uint64
+	subs   *fanout.Hub[string, Event]
+	recent map[string]*userLog
+	swept  time.Time
+}
+
+// NewHub creates a push hub. IDs start from the current time in milliseconds
+// scaled up, so IDs from a previous process are older than any this one issues.
+func NewHub(cfg Config) *Hub {
+	defaults := DefaultConfig()
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaults.BufferSize
+	}
+	if cfg.ReplaySize < 0 {
+		cfg.ReplaySize = 0
+	}
+	if cfg.ReplayWindow <= 0 {
+		cfg.ReplayWindow = defaults.ReplayWindow
+	}
+	return &Hub{
+		cfg: cfg,
+		seq: uint64(time.Now().UnixMilli()) * 1000,
+		subs: fanout.NewHub[string, Event](fanout.Config{
+			MaxSubscribers:         cfg.MaxConnections,
+			MaxSubscribersPerOwner: cfg.MaxConnectionsPerUser,
+			BufferSize:             cfg.BufferSize,
+		}),
+		recent: make(map[string]*userLog),
+	}
+}
+
+// Subscribe opens a stream for the user. With a lastEventID from a previous
+// connection it also returns the events the client missed; complete is false
+// when some of them are no longer held and the client should refetch state.
+func (h *Hub) Subscribe(userID string, lastEventID uint64) (events <-chan Event, missed []Event, complete bool, cancel func(), err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	events, unsubscribe, err := h.subs.Subscribe(userID, userID)
+	if err != nil {
+		return nil, nil, false, nil, err
+	}
+
+	complete = true
+	if lastEventID > 0 {
+		missed, complete = h.since(userID, lastEventID)
+	}
+	if h.recent[userID] == nil {
+		h.recent[userID] = &userLog{trimmed: h.seq}
+	}
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() { h.unsubscribe(userID, unsubscribe) })
+	}
+	return events, missed, complete, cancel, nil
+}
+
+// since returns the user's events after lastEventID, and whether the log
+// reaches back far enough to hold all of them
+func (h *Hub) since(userID string, lastEventID uint64) ([]Event, bool) {
+	log := h.recent[userID]
+	if log == nil || h.cfg.ReplaySize == 0 || lastEventID < log.trimmed || lastEventID > h.seq {
+		return nil, false
+	}
+
+	var missed []Event
+	for _, event := range log.events {
+		if event.ID > lastEventID {
+			missed = append(missed, event)
+		}
+	}
+	return missed, true
+}
+
+// unsubscribe closes a stream and starts the replay window once the user
+// has no streams left
+func (h *Hub) unsubscribe(userID string, unsubscribe func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	unsubscribe()
+	if h.subs.Subscribers(userID) == 0 {
+		if log := h.recent[userID]; log != nil {
+			log.idleSince = time.Now()
+		}
+	}
+}
+
+// Publish delivers an event to the user named by the payload's user_id.
+// Events without one are not meant for a single user and are ignored. Slow
+// streams with a full buffer miss the event rather than block the hub; they
+// can recover it from the replay log by reconnecting.
+func (h *Hub) Publish(eventType string, payload map[string]interface{}) {
+	userID, _ := payload["user_id"].(string)
+	if userID == "" {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	h.seq++
+	event := Event{ID: h.seq, Type: eventType, Payload: payload}
+	h.sweep(now)
+
+	if log := h.recent[userID]; log != nil && h.cfg.ReplaySize > 0 {
+		log.events = append(log.events, event)
+		if over := len(log.events) - h.cfg.ReplaySize; over > 0 {
+			log.trimmed = log.events[over-1].ID
+			log.events = append(log.events[:0:0], log.events[over:]...)
+		}
+	}
+
+	h.subs.Publish(userID, event)
+}
+
+// sweep drops the logs of users who disconnected more than the replay
+// window ago. It runs at most once per window.
+func (h *Hub) sweep(now time.Time) {
+	if now.Sub(h.swept) < h.cfg.ReplayWindow {
+		return
+	}
+	h.swept = now
+	for userID, log := range h.recent {
+		if h.subs.Subscribers(userID) == 0 && now.Sub(log.idleSince) > h.cfg.ReplayWindow {
+			delete(h.recent, userID)
+		}
+	}
+}
+
+// Count returns the number of open streams
+func (h *Hub) Count() int {
+	return h.subs.Count()
+}
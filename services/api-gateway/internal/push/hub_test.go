@@ -0,0 +1,122 @@
+package push
+
+import (
+	"errors"
+	"testing"
+)
+
+func receive(t *testing.T, events <-chan Event) (Event, bool) {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event, true
+	default:
+		return Event{}, false
+	}
+}
+
+func TestHub_PublishFiltersByUser(t *testing.T) {
+	hub := NewHub(DefaultConfig())
+
+	alice, _, _, cancelAlice, err := hub.Subscribe("alice", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancelAlice()
+	bob, _, _, cancelBob, err := hub.Subscribe("bob", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	defer cancelBob()
+
+	hub.Publish("wallet.payment.completed", map[string]interface{}{"user_id": "alice"})
+	hub.Publish("parking.location.updated", map[string]interface{}{"location_id": "x"})
+
+	event, ok := receive(t, alice)
+	if !ok || event.Type != "wallet.payment.completed" {
+		t.Errorf("alice got %+v, %v; want wallet.payment.completed", event, ok)
+	}
+	if event, ok := receive(t, alice); ok {
+		t.Errorf("events without a user_id should not be delivered, got %s", event.Type)
+	}
+	if event, ok := receive(t, bob); ok {
+		t.Errorf("bob should not receive alice's events, got %s", event.Type)
+	}
+}
+
+func TestHub_ReplayAfterReconnect(t *testing.T) {
+	hub := NewHub(Config{BufferSize: 8, ReplaySize: 2})
+
+	events, _, _, cancel, err := hub.Subscribe("alice", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	hub.Publish("parking.session.started", map[string]interface{}{"user_id": "alice"})
+	first, _ := receive(t, events)
+	cancel()
+
+	hub.Publish("parking.session.amount_updated", map[string]interface{}{"user_id": "alice"})
+	hub.Publish("parking.session.ended", map[string]interface{}{"user_id": "alice"})
+
+	tests := []struct {
+		name         string
+		lastEventID  uint64
+		wantMissed   []string
+		wantComplete bool
+	}{
+		{"resumes after last event", first.ID, []string{"parking.session.amount_updated", "parking.session.ended"}, true},
+		{"last event fell out of the log", first.ID - 1, nil, false},
+		{"id from the future", first.ID + 100, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, missed, complete, cancel, err := hub.Subscribe("alice", tt.lastEventID)
+			if err != nil {
+				t.Fatalf("Subscribe() error = %v", err)
+			}
+			defer cancel()
+
+			if complete != tt.wantComplete {
+				t.Errorf("complete = %v, want %v", complete, tt.wantComplete)
+			}
+			if len(missed) != len(tt.wantMissed) {
+				t.Fatalf("missed %d events, want %d", len(missed), len(tt.wantMissed))
+			}
+			for i, event := range missed {
+				if event.Type != tt.wantMissed[i] {
+					t.Errorf("missed[%d] = %s, want %s", i, event.Type, tt.wantMissed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHub_ConnectionLimits(t *testing.T) {
+	hub := NewHub(Config{MaxConnections: 3, MaxConnectionsPerUser: 2})
+
+	for i := 0; i < 2; i++ {
+		if _, _, _, _, err := hub.Subscribe("alice", 0); err != nil {
+			t.Fatalf("Subscribe() #%d error = %v", i+1, err)
+		}
+	}
+	if _, _, _, _, err := hub.Subscribe("alice", 0); !errors.Is(err, ErrConnectionLimit) {
+		t.Errorf("third stream for one user error = %v, want %v", err, ErrConnectionLimit)
+	}
+
+	_, _, _, cancel, err := hub.Subscribe("bob", 0)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	if _, _, _, _, err := hub.Subscribe("carol", 0); !errors.Is(err, ErrConnectionLimit) {
+		t.Errorf("stream over the total limit error = %v, want %v", err, ErrConnectionLimit)
+	}
+
+	cancel()
+	if hub.Count() != 2 {
+		t.Errorf("Count() after cancel = %d, want 2", hub.Count())
+	}
+	if _, _, _, _, err := hub.Subscribe("carol", 0); err != nil {
+		t.Errorf("Subscribe() after a stream closed error = %v", err)
+	}
+}
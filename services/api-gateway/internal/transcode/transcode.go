@@ -0,0 +1,270 @@
+// Package transcode exposes gRPC methods as REST routes, so a service can
+// add an API in its proto alone and have the gateway serve it to the apps.
+//
+// A request message is built from the route's path parameters, then the
+// query string, then the JSON body (in protojson form, with the proto field
+// names). A user_id field is always set to the signed-in user, so callers
+// can only act as themselves. The response message is written as JSON in the
+// usual envelope, and gRPC status codes become the matching HTTP errors.
+package transcode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/clients"
+	"github.com/parking-super-app/pkg/httpx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// userIDField is the request field set to the signed-in user
+const userIDField = "user_id"
+
+// Binding exposes one unary gRPC method as a REST route
+type Binding struct {
+	Method string // HTTP method
+	Path   string // chi pattern; {name} parameters fill fields of that name
+	RPC    string // full gRPC method name, e.g. "/parking.v1.ParkingService/GetSession"
+}
+
+// Transcoder serves bindings to one upstream service
+type Transcoder struct {
+	conn     grpc.ClientConnInterface
+	bindings []binding
+}
+
+type binding struct {
+	Binding
+	desc protoreflect.MethodDescriptor
+}
+
+// New resolves each binding's method. The generated package of every bound
+// service must be linked in so its descriptors are registered.
+func New(conn grpc.ClientConnInterface, bindings ...Binding) (*Transcoder, error) {
+	t := &Transcoder{conn: conn}
+	for _, b := range bindings {
+		desc, err := Method(b.RPC)
+		if err != nil {
+			return nil, err
+		}
+		t.bindings = append(t.bindings, binding{Binding: b, desc: desc})
+	}
+	return t, nil
+}
+
+// Method looks up a unary method by its full name
+func Method(rpc string) (protoreflect.MethodDescriptor, error) {
+	service, method, ok := strings.Cut(strings.TrimPrefix(rpc, "/"), "/")
+	if !ok {
+		return nil, fmt.Errorf("transcode: invalid method name %q", rpc)
+	}
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("transcode: unknown service %s: %w", service, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("transcode: %s is not a service", service)
+	}
+	md := sd.Methods().ByName(protoreflect.Name(method))
+	if md == nil {
+		return nil, fmt.Errorf("transcode: %s has no method %s", service, method)
+	}
+	if md.IsStreamingClient() || md.IsStreamingServer() {
+		return nil, fmt.Errorf("transcode: %s is streaming", rpc)
+	}
+	return md, nil
+}
+
+// Routes registers the bindings on r
+func (t *Transcoder) Routes(r chi.Router) {
+	for _, b := range t.bindings {
+		r.Method(b.Method, b.Path, t.handler(b))
+	}
+}
+
+func (t *Transcoder) handler(b binding) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		in := dynamicpb.NewMessage(b.desc.Input())
+		if err := decodeRequest(r, in); err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+			return
+		}
+
+		out := dynamicpb.NewMessage(b.desc.Output())
+		ctx := clients.WithCaller(r.Context(), clients.CallerFromRequest(r))
+		if err := t.conn.Invoke(ctx, b.RPC, in, out); err != nil {
+			writeStatus(w, r, err)
+			return
+		}
+
+		data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(out)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "Failed to encode response")
+			return
+		}
+		httpx.WriteJSON(w, http.StatusOK, json.RawMessage(data))
+	}
+}
+
+// decodeRequest fills msg from the body, query and path, in increasing
+// precedence, then sets the caller's user ID
+func decodeRequest(r *http.Request, msg *dynamicpb.Message) error {
+	if r.Body != nil && r.Method != http.MethodGet && r.Method != http.MethodDelete {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return errors.New("failed to read request body")
+		}
+		if len(body) > 0 {
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, msg); err != nil {
+				return fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+	}
+
+	fields := msg.Descriptor().Fields()
+	for name, values := range r.URL.Query() {
+		field := fieldByName(fields, name)
+		if field == nil {
+			continue
+		}
+		for _, value := range values {
+			if err := setField(msg, field, value); err != nil {
+				return err
+			}
+		}
+	}
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		for i, name := range rctx.URLParams.Keys {
+			if field := fieldByName(fields, name); field != nil {
+				if err := setField(msg, field, rctx.URLParams.Values[i]); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if field := fields.ByName(userIDField); field != nil && field.Kind() == protoreflect.StringKind {
+		msg.Set(field, protoreflect.ValueOfString(r.Header.Get("X-User-ID")))
+	}
+	return nil
+}
+
+func fieldByName(fields protoreflect.FieldDescriptors, name string) protoreflect.FieldDescriptor {
+	if field := fields.ByName(protoreflect.Name(name)); field != nil {
+		return field
+	}
+	return fields.ByJSONName(name)
+}
+
+// setField parses a path or query value into a scalar or repeated scalar field
+func setField(msg *dynamicpb.Message, field protoreflect.FieldDescriptor, raw string) error {
+	if field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		return fmt.Errorf("%s cannot be set from the URL", field.Name())
+	}
+	value, err := parseScalar(field, raw)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", field.Name(), err)
+	}
+	if field.IsList() {
+		msg.Mutable(field).List().Append(value)
+		return nil
+	}
+	msg.Set(field, value)
+	return nil
+}
+
+func parseScalar(field protoreflect.FieldDescriptor, raw string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(raw), nil
+	case protoreflect.BoolKind:
+		v, err := strconv.ParseBool(raw)
+		return protoreflect.ValueOfBool(v), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		v, err := strconv.ParseInt(raw, 10, 32)
+		return protoreflect.ValueOfInt32(int32(v)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		v, err := strconv.ParseInt(raw, 10, 64)
+		return protoreflect.ValueOfInt64(v), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		v, err := strconv.ParseUint(raw, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(v)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		v, err := strconv.ParseUint(raw, 10, 64)
+		return protoreflect.ValueOfUint64(v), err
+	case protoreflect.DoubleKind:
+		v, err := strconv.ParseFloat(raw, 64)
+		return protoreflect.ValueOfFloat64(v), err
+	case protoreflect.FloatKind:
+		v, err := strconv.ParseFloat(raw, 32)
+		return protoreflect.ValueOfFloat32(float32(v)), err
+	case protoreflect.EnumKind:
+		if value := field.Enum().Values().ByName(protoreflect.Name(raw)); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("unknown value %q", raw)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(raw)), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("unsupported field type %s", field.Kind())
+}
+
+// httpStatus maps gRPC codes to HTTP statuses the way grpc-gateway does
+var httpStatus = map[codes.Code]int{
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// codeNames are the error codes written for each status, in the
+// UPPER_SNAKE form of the REST APIs
+var codeNames = map[codes.Code]string{
+	codes.Canceled:           "CANCELLED",
+	codes.InvalidArgument:    "INVALID_ARGUMENT",
+	codes.DeadlineExceeded:   "DEADLINE_EXCEEDED",
+	codes.NotFound:           "NOT_FOUND",
+	codes.AlreadyExists:      "ALREADY_EXISTS",
+	codes.PermissionDenied:   "PERMISSION_DENIED",
+	codes.Unauthenticated:    "UNAUTHENTICATED",
+	codes.ResourceExhausted:  "RESOURCE_EXHAUSTED",
+	codes.FailedPrecondition: "FAILED_PRECONDITION",
+	codes.Aborted:            "ABORTED",
+	codes.OutOfRange:         "OUT_OF_RANGE",
+	codes.Unimplemented:      "UNIMPLEMENTED",
+	codes.Unavailable:        "SERVICE_UNAVAILABLE",
+}
+
+// writeStatus writes a gRPC error as a problem. Internal errors are not
+// passed on, since their messages may describe the service's internals.
+func writeStatus(w http.ResponseWriter, r *http.Request, err error) {
+	st := status.Convert(err)
+	code, ok := httpStatus[st.Code()]
+	if !ok {
+		httpx.WriteError(w, r, http.StatusInternalServerError, httpx.CodeInternal, "An internal error occurred")
+		return
+	}
+	httpx.WriteError(w, r, code, codeNames[st.Code()], st.Message())
+}
@@ -0,0 +1,147 @@
+package transcode
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/grpc/client"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeParking struct {
+	parkingv1.UnimplementedParkingServiceServer
+	started *parkingv1.StartSessionRequest
+}
+
+func (f *fakeParking) StartSession(_ context.Context, req *parkingv1.StartSessionRequest) (*parkingv1.SessionResponse, error) {
+	f.started = req
+	return &parkingv1.SessionResponse{Id: "s-1", UserId: req.UserId, VehiclePlate: req.VehiclePlate, Status: "active"}, nil
+}
+
+func (f *fakeParking) GetSession(_ context.Context, req *parkingv1.GetSessionRequest) (*parkingv1.SessionResponse, error) {
+	if req.UserId != "u-1" {
+		return nil, status.Error(codes.PermissionDenied, "session belongs to another user")
+	}
+	if req.SessionId != "s-1" {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+	return &parkingv1.SessionResponse{Id: req.SessionId, UserId: req.UserId, DurationMinutes: 42}, nil
+}
+
+func (f *fakeParking) EndSession(context.Context, *parkingv1.EndSessionRequest) (*parkingv1.EndSessionResponse, error) {
+	return nil, status.Error(codes.Internal, "pq: connection refused")
+}
+
+func newTestRouter(t *testing.T, srv *fakeParking) http.Handler {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	parkingv1.RegisterParkingServiceServer(s, srv)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := client.New(client.DefaultConfig("passthrough:///bufnet"), grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	tc, err := New(conn,
+		Binding{Method: http.MethodPost, Path: "/sessions", RPC: parkingv1.ParkingService_StartSession_FullMethodName},
+		Binding{Method: http.MethodGet, Path: "/sessions/{session_id}", RPC: parkingv1.ParkingService_GetSession_FullMethodName},
+		Binding{Method: http.MethodPost, Path: "/sessions/{session_id}/end", RPC: parkingv1.ParkingService_EndSession_FullMethodName},
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	r := chi.NewRouter()
+	tc.Routes(r)
+	return r
+}
+
+func TestTranscoder_Body(t *testing.T) {
+	srv := &fakeParking{}
+	router := newTestRouter(t, srv)
+
+	// user_id in the body is replaced by the signed-in user
+	req := httptest.NewRequest(http.MethodPost, "/sessions", strings.NewReader(`{"user_id":"someone-else","vehicle_plate":"WXY 1234","locationId":"loc-1"}`))
+	req.Header.Set("X-User-ID", "u-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if srv.started.UserId != "u-1" || srv.started.VehiclePlate != "WXY 1234" || srv.started.LocationId != "loc-1" {
+		t.Errorf("server got %+v", srv.started)
+	}
+
+	var resp struct {
+		Success bool                   `json:"success"`
+		Data    map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Success || resp.Data["id"] != "s-1" || resp.Data["vehicle_plate"] != "WXY 1234" {
+		t.Errorf("response = %+v", resp)
+	}
+	if _, ok := resp.Data["exit_time"]; !ok {
+		t.Error("unset fields should be written")
+	}
+}
+
+func TestTranscoder_Status(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		userID   string
+		want     int
+		wantCode string
+	}{
+		{"path parameter", http.MethodGet, "/sessions/s-1", "u-1", http.StatusOK, ""},
+		{"not found", http.MethodGet, "/sessions/s-2", "u-1", http.StatusNotFound, "NOT_FOUND"},
+		{"another user's session", http.MethodGet, "/sessions/s-1", "u-2", http.StatusForbidden, "PERMISSION_DENIED"},
+		{"internal error", http.MethodPost, "/sessions/s-1/end", "u-1", http.StatusInternalServerError, "INTERNAL_ERROR"},
+	}
+
+	router := newTestRouter(t, &fakeParking{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("X-User-ID", tt.userID)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body %s", rec.Code, tt.want, rec.Body)
+			}
+			if tt.wantCode != "" && !strings.Contains(rec.Body.String(), `"code":"`+tt.wantCode+`"`) {
+				t.Errorf("body = %s, want code %s", rec.Body, tt.wantCode)
+			}
+			if strings.Contains(rec.Body.String(), "pq:") {
+				t.Errorf("internal error leaked: %s", rec.Body)
+			}
+		})
+	}
+}
+
+func TestNew_RejectsUnknownMethod(t *testing.T) {
+	if _, err := New(nil, Binding{Method: http.MethodGet, Path: "/x", RPC: "/parking.v1.ParkingService/Missing"}); err == nil {
+		t.Error("New() should reject a method the service does not have")
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/parking-super-app/services/auth/config"
+	"github.com/parking-super-app/services/auth/internal/adapters/external"
+)
+
+// loadKeyRing reads the configured signing keys. Without a key file it
+// generates one, which lasts only until the process exits: tokens stop
+// validating after a restart, and each replica would sign with its own key.
+func loadKeyRing(cfg config.JWTConfig) (*external.KeyRing, error) {
+	if cfg.SigningKeyFile == "" {
+		log.Println("WARNING: JWT_SIGNING_KEY_FILE not set. Using a temporary signing key; run a single replica only!")
+		signer, err := external.GenerateSigningKey(cfg.SigningAlgorithm)
+		if err != nil {
+			return nil, err
+		}
+		return external.NewKeyRing(signer)
+	}
+
+	ring, err := external.LoadKeyRing(cfg.SigningKeyFile, cfg.PreviousKeyFiles)
+	if err != nil {
+		return nil, err
+	}
+	if ring.Algorithm() != cfg.SigningAlgorithm {
+		return nil, fmt.Errorf("JWT_SIGNING_KEY_FILE holds an %s key but JWT_SIGNING_ALG is %s", ring.Algorithm(), cfg.SigningAlgorithm)
+	}
+	return ring, nil
+}
+
+// keygen writes a new PEM-encoded signing key to out
+func keygen(args []string, out io.Writer) error {
+	alg := "RS256"
+	if len(args) > 0 {
+		alg = args[0]
+	}
+
+	signer, err := external.GenerateSigningKey(alg)
+	if err != nil {
+		return err
+	}
+	data, err := external.EncodePrivateKeyPEM(signer)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(data)
+	return err
+}
@@ -17,46 +17,80 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"sync"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/eventstore"
+	"github.com/parking-super-app/pkg/grpc/client"
 	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/httpserver"
 	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/migrate"
+	"github.com/parking-super-app/pkg/notify"
+	"github.com/parking-super-app/pkg/otpstore"
+	authv1 "github.com/parking-super-app/pkg/proto/auth/v1"
+	"github.com/parking-super-app/pkg/ratelimit"
+	"github.com/parking-super-app/pkg/revocation"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/auth/config"
 	"github.com/parking-super-app/services/auth/internal/adapters/external"
+	grpcAdapter "github.com/parking-super-app/services/auth/internal/adapters/grpc"
 	httpAdapter "github.com/parking-super-app/services/auth/internal/adapters/http"
 	"github.com/parking-super-app/services/auth/internal/adapters/repository/postgres"
 	"github.com/parking-super-app/services/auth/internal/application"
 	"github.com/parking-super-app/services/auth/internal/domain"
 	"github.com/parking-super-app/services/auth/internal/ports"
-	"google.golang.org/grpc"
+	"github.com/parking-super-app/services/auth/migrations"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
+	// "auth-service keygen [RS256|EdDSA]" prints a new signing key and exits
+	if len(os.Args) > 1 && os.Args[1] == "keygen" {
+		if err := keygen(os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Failed to generate key: %v", err)
+		}
+		return
+	}
+
 	// Load configuration
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
 
+	logger := logging.New(cfg.Log)
 	log.Printf("Starting auth service on port %s", cfg.Server.Port)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Values with a reload hook change on SIGHUP or a POST to
+	// settings.ReloadPath; everything else needs a restart
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("log level", logger.ReloadLevel)
+	reloader.WatchSignals(ctx)
+
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -65,6 +99,17 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			log.Println("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Set up database connection
 	dbPool, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
 	if err != nil {
@@ -72,21 +117,114 @@ func main() {
 	}
 	defer dbPool.Close()
 
-	if err := dbPool.Ping(ctx); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	if err := startup.Wait(ctx, cfg.Startup, logger, "postgres", startup.Postgres(dbPool)); err != nil {
+		log.Fatalf("Failed to reach database: %v", err)
 	}
 	log.Println("Connected to database")
 
+	// Schema migrations are embedded in the binary. "auth-service migrate ..."
+	// manages them and exits; otherwise they can be applied before serving.
+	migrator, err := migrate.New(dbPool, migrations.FS, logger)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.RunCommand(ctx, migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Failed to run migrations: %v", err)
+		}
+		return
+	}
+	if cfg.Database.MigrateOnStart {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatalf("Failed to apply migrations: %v", err)
+		}
+	}
+
 	// Create dependencies
 	userRepo := postgres.NewUserRepository(dbPool)
 	tokenRepo := postgres.NewRefreshTokenRepository(dbPool)
-	otpRepo := NewInMemoryOTPRepository()
+
+	// Shared state
+	// ============
+	// OTPs and rate limit counters must be visible to every replica, or a
+	// code sent by one instance can't be verified by another and each
+	// instance enforces its own separate limit. Redis holds them when it's
+	// configured; memory is only correct for a single instance.
+	otpLimit := ratelimit.Limit{Requests: cfg.RateLimit.OTPRequests, Window: cfg.RateLimit.OTPRequestWindow}
+	loginLimit := ratelimit.Limit{Requests: cfg.RateLimit.LoginAttempts, Window: cfg.RateLimit.LoginWindow}
+
+	var otpStore otpstore.Store
+	var otpLimiter, loginLimiter ratelimit.Limiter
+	var revocationList revocation.List
+	if cfg.Redis.Enabled() {
+		redisClient := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr(),
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer redisClient.Close()
+
+		redisReady := func(ctx context.Context) error { return redisClient.Ping(ctx).Err() }
+		if err := startup.Wait(ctx, cfg.Startup, logger, "redis", redisReady); err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+
+		otpStore = otpstore.NewRedisStore(redisClient, "auth:otp:")
+		if otpLimiter, err = ratelimit.NewRedisLimiter(redisClient, "auth:ratelimit:otp:", otpLimit); err != nil {
+			log.Fatalf("Invalid OTP rate limit: %v", err)
+		}
+		if loginLimiter, err = ratelimit.NewRedisLimiter(redisClient, "auth:ratelimit:login:", loginLimit); err != nil {
+			log.Fatalf("Invalid login rate limit: %v", err)
+		}
+		// The gateway reads this list to reject revoked access tokens
+		revocationList = revocation.NewRedisList(redisClient, revocation.DefaultRedisPrefix)
+		log.Println("Connected to Redis")
+	} else {
+		log.Println("WARNING: REDIS_HOST not set. OTPs and rate limits are per instance; run a single replica only!")
+		log.Println("WARNING: access tokens stay valid until they expire after logout or a ban")
+		otpStore = otpstore.NewMemoryStore()
+
+		memOTPLimiter, err := ratelimit.NewMemoryLimiter(otpLimit)
+		if err != nil {
+			log.Fatalf("Invalid OTP rate limit: %v", err)
+		}
+		memLoginLimiter, err := ratelimit.NewMemoryLimiter(loginLimit)
+		if err != nil {
+			log.Fatalf("Invalid login rate limit: %v", err)
+		}
+		otpLimiter, loginLimiter = memOTPLimiter, memLoginLimiter
+
+		// Forget phones that have gone quiet so memory doesn't grow forever
+		go func() {
+			ticker := time.NewTicker(10 * time.Minute)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					memOTPLimiter.Cleanup()
+					memLoginLimiter.Cleanup()
+				}
+			}
+		}()
+	}
+	otpRepo := external.NewOTPStoreRepository(otpStore)
 
 	passwordHasher := external.NewBcryptPasswordHasher(12)
-	tokenService := external.NewJWTTokenService(
-		cfg.JWT.SecretKey,
-		cfg.JWT.AccessTokenTTL,
-	)
+	// Access tokens are signed with a private key and verified by others
+	// with the public keys from the JWKS endpoint, unless HS256 is chosen
+	var tokenService *external.JWTTokenService
+	var keyRing *external.KeyRing
+	if cfg.JWT.SigningAlgorithm == "HS256" {
+		tokenService = external.NewJWTTokenService(cfg.JWT.SecretKey, cfg.JWT.AccessTokenTTL)
+	} else {
+		if keyRing, err = loadKeyRing(cfg.JWT); err != nil {
+			log.Fatalf("Failed to load JWT signing keys: %v", err)
+		}
+		tokenService = external.NewKeyRingTokenService(keyRing, cfg.JWT.AccessTokenTTL)
+		log.Printf("Signing access tokens with %s key %s", keyRing.Algorithm(), keyRing.JWKS().Keys[0].Kid)
+	}
 	otpGenerator := external.NewSecureOTPGenerator(6)
 
 	var smsService ports.SMSService
@@ -101,19 +239,48 @@ func main() {
 		smsService = external.NewConsoleSMSService()
 	}
 
+	// WhatsApp is optional; users who prefer it fall back to SMS on failure
+	var whatsAppService ports.SMSService
+	switch cfg.WhatsApp.Provider {
+	case "meta":
+		whatsAppService = external.NewMetaWhatsAppService(
+			cfg.WhatsApp.AccessToken,
+			cfg.WhatsApp.PhoneNumberID,
+			cfg.WhatsApp.TemplateName,
+			cfg.WhatsApp.LanguageCode,
+			cfg.WhatsApp.APIVersion,
+		)
+	case "console":
+		whatsAppService = external.NewConsoleWhatsAppService()
+	}
+
+	// Record published events for the admin event browser
+	eventStore, closeEventStore, err := eventstore.OpenStore(ctx, eventstore.StoreConfig{
+		Backend:     cfg.EventStore.Backend,
+		PostgresURL: cfg.EventStore.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: event store unavailable, keeping events in memory: %v", err)
+		eventStore, closeEventStore = eventstore.NewMemoryStore(eventstore.DefaultMemoryCapacity), func() {}
+	}
+	defer closeEventStore()
+
 	// Initialize event publisher (Kafka or Noop)
 	var eventPublisher ports.EventPublisher
 	var kafkaPublisher *kafka.Publisher
 	if cfg.Kafka.Enabled {
+		if err := startup.Wait(ctx, cfg.Startup, logger, "kafka", startup.Kafka(cfg.Kafka.Brokers)); err != nil {
+			log.Fatalf("Failed to reach Kafka: %v", err)
+		}
 		kafkaPublisher = kafka.NewPublisher(kafka.DefaultPublisherConfig(cfg.Kafka.Brokers, cfg.Kafka.Topic))
-		eventPublisher = &kafkaEventAdapter{publisher: kafkaPublisher}
+		eventPublisher = &kafkaEventAdapter{
+			publisher: eventstore.NewRecordingPublisher(kafkaPublisher, eventStore, "auth"),
+		}
 		log.Println("Kafka event publisher initialized")
 	} else {
 		eventPublisher = NewNoOpEventPublisher()
 	}
 
-	logger := NewSimpleLogger()
-
 	// Create application service
 	authService := application.NewAuthService(
 		userRepo,
@@ -126,25 +293,120 @@ func main() {
 		eventPublisher,
 		logger,
 	)
+	if whatsAppService != nil {
+		authService.SetWhatsAppService(whatsAppService)
+	}
+	authService.SetRateLimiters(
+		external.NewRateLimiter(otpLimiter),
+		external.NewRateLimiter(loginLimiter),
+	)
+
+	// "auth-service backfill-users" publishes a user.synced snapshot of every
+	// user so other services can build their copy of contact details, then exits
+	if len(os.Args) > 1 && os.Args[1] == "backfill-users" {
+		if kafkaPublisher == nil {
+			log.Fatalf("backfill-users needs KAFKA_ENABLED=true")
+		}
+		published, err := authService.PublishUserSnapshots(ctx, 500)
+		if closeErr := kafkaPublisher.Close(); closeErr != nil {
+			log.Printf("failed to close Kafka publisher: %v", closeErr)
+		}
+		if err != nil {
+			log.Fatalf("Failed to backfill users after %d published: %v", published, err)
+		}
+		log.Printf("Published %d user snapshots", published)
+		return
+	}
 
-	// Create HTTP router with tracing middleware
-	router := httpAdapter.NewRouter(authService, tokenService)
+	// Social login is enabled per provider once its client IDs are configured
+	verifiers := make(map[domain.SocialProvider]ports.IDTokenVerifier)
+	if len(cfg.Social.GoogleClientIDs) > 0 {
+		verifiers[domain.SocialProviderGoogle] = external.NewGoogleIDTokenVerifier(cfg.Social.GoogleClientIDs)
+	}
+	if len(cfg.Social.AppleClientIDs) > 0 {
+		verifiers[domain.SocialProviderApple] = external.NewAppleIDTokenVerifier(cfg.Social.AppleClientIDs)
+	}
+	if len(verifiers) > 0 {
+		authService.SetSocialLogin(postgres.NewIdentityRepository(dbPool), verifiers)
+		log.Printf("Social login enabled for %d provider(s)", len(verifiers))
+	}
+
+	// Account deletion: accounts are erased once their grace period ends
+	authService.SetAccountDeletion(postgres.NewDeletionRepository(dbPool), cfg.Deletion.GracePeriod)
+	if cfg.Deletion.Enabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Deletion.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := authService.EraseDueAccounts(ctx, now.UTC(), cfg.Deletion.BatchSize); err != nil {
+						logger.Error("account erasure run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// KYC: reviews are recorded in the audit log
+	auditStore := audit.NewPostgresStore(dbPool)
+	authService.SetKYC(postgres.NewKYCRepository(dbPool), audit.NewRecorder(auditStore, "auth"))
+	if cfg.Devices.VerificationEnabled {
+		authService.SetDeviceVerification(postgres.NewDeviceRepository(dbPool))
+	}
+	authService.SetStepUp(postgres.NewStepUpRepository(dbPool), external.NewStepUpTokenService(cfg.JWT.SecretKey), cfg.StepUp.TTL)
+	authService.SetPINs(postgres.NewPINRepository(dbPool), domain.PINPolicy{
+		MaxAttempts: cfg.PIN.MaxAttempts,
+		Lockout:     cfg.PIN.Lockout,
+	})
+	authService.SetOrganizations(postgres.NewOrganizationRepository(dbPool))
+	authService.SetProviderStaff(postgres.NewProviderStaffRepository(dbPool))
+	authService.SetAdmins(cfg.Admin.UserIDs)
+	authService.SetGuestCheckout(cfg.Guest.TokenTTL)
+	authService.SetReferrals(postgres.NewReferralRepository(dbPool))
+	if cfg.Notification.GRPC != "" {
+		clientCfg := client.DefaultConfig(cfg.Notification.GRPC)
+		clientCfg.DefaultTimeout = cfg.Notification.CallTimeout
+		if err := clientCfg.UseMutualTLS(cfg.GRPC.TLS); err != nil {
+			log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+		}
+		notifyClient, err := notify.New(clientCfg)
+		if err != nil {
+			log.Fatalf("failed to create notification client: %v", err)
+		}
+		defer notifyClient.Close()
+		authService.SetNotifier(external.NewNotificationClient(notifyClient))
+	}
+	if revocationList != nil {
+		authService.SetTokenRevocation(external.NewTokenDenylist(revocationList, cfg.JWT.AccessTokenTTL))
+	}
+
+	// Create HTTP router with request logging and tracing middleware.
+	// Tracing is added last so it wraps the request logger.
+	router := httpAdapter.NewRouter(authService, tokenService, auditStore)
+	if keyRing != nil {
+		router.ServeJWKS(keyRing.JWKS())
+	}
+	router.Use(logging.RequestLogger(logger))
 	if cfg.OTEL.Enabled {
 		router.Use(middleware.Tracing(cfg.OTEL.ServiceName))
 	}
 
 	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      router,
-		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
+	httpServer, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(router), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
 	// Create gRPC server
-	grpcServer := interceptors.NewServerWithDefaults()
-	// Register gRPC services when proto is generated
-	// authv1.RegisterAuthServiceServer(grpcServer, authGRPCServer)
+	grpcTLS, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+	}
+	grpcServer := interceptors.NewServerWithDefaults(grpcTLS...)
+	authv1.RegisterAuthServiceServer(grpcServer, grpcAdapter.NewAuthServiceServer(authService))
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
@@ -197,6 +459,11 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	log.Println("Server exited")
 }
@@ -205,61 +472,6 @@ func main() {
 // TEMPORARY IMPLEMENTATIONS
 // ================================================
 
-// InMemoryOTPRepository is a simple in-memory OTP store.
-type InMemoryOTPRepository struct {
-	otps map[string]*domain.OTP
-	mu   sync.RWMutex
-}
-
-func NewInMemoryOTPRepository() *InMemoryOTPRepository {
-	return &InMemoryOTPRepository{
-		otps: make(map[string]*domain.OTP),
-	}
-}
-
-func (r *InMemoryOTPRepository) Create(ctx context.Context, otp *domain.OTP) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	r.otps[otp.Phone] = otp
-	return nil
-}
-
-func (r *InMemoryOTPRepository) GetLatestByPhone(ctx context.Context, phone string) (*domain.OTP, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	otp, ok := r.otps[phone]
-	if !ok {
-		return nil, domain.ErrTokenNotFound
-	}
-	return otp, nil
-}
-
-func (r *InMemoryOTPRepository) Update(ctx context.Context, otp *domain.OTP) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	r.otps[otp.Phone] = otp
-	return nil
-}
-
-func (r *InMemoryOTPRepository) DeleteByPhone(ctx context.Context, phone string) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	delete(r.otps, phone)
-	return nil
-}
-
-func (r *InMemoryOTPRepository) DeleteExpired(ctx context.Context) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	now := time.Now()
-	for k, v := range r.otps {
-		if now.After(v.ExpiresAt) {
-			delete(r.otps, k)
-		}
-	}
-	return nil
-}
-
 // NoOpEventPublisher is a no-op event publisher for development.
 type NoOpEventPublisher struct{}
 
@@ -272,36 +484,9 @@ func (p *NoOpEventPublisher) Publish(ctx context.Context, event ports.Event) err
 	return nil
 }
 
-// SimpleLogger is a simple logger for development.
-type SimpleLogger struct{}
-
-func NewSimpleLogger() *SimpleLogger {
-	return &SimpleLogger{}
-}
-
-func (l *SimpleLogger) Debug(msg string, fields ...ports.Field) {
-	log.Printf("[DEBUG] %s %v", msg, fields)
-}
-
-func (l *SimpleLogger) Info(msg string, fields ...ports.Field) {
-	log.Printf("[INFO] %s %v", msg, fields)
-}
-
-func (l *SimpleLogger) Warn(msg string, fields ...ports.Field) {
-	log.Printf("[WARN] %s %v", msg, fields)
-}
-
-func (l *SimpleLogger) Error(msg string, fields ...ports.Field) {
-	log.Printf("[ERROR] %s %v", msg, fields)
-}
-
-func (l *SimpleLogger) WithFields(fields ...ports.Field) ports.Logger {
-	return l
-}
-
 // kafkaEventAdapter adapts kafka.Publisher to ports.EventPublisher
 type kafkaEventAdapter struct {
-	publisher *kafka.Publisher
+	publisher kafka.EventPublisher
 }
 
 func (a *kafkaEventAdapter) Publish(ctx context.Context, event ports.Event) error {
@@ -20,6 +20,13 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
 )
 
 // Config holds all application configuration.
@@ -27,6 +34,12 @@ type Config struct {
 	// Server configuration
 	Server ServerConfig
 
+	// Logging configuration: level and output format
+	Log logging.Config
+
+	// Startup configuration: how long to wait for Postgres, Redis and Kafka
+	Startup startup.Config
+
 	// gRPC configuration
 	GRPC GRPCConfig
 
@@ -39,23 +52,63 @@ type Config struct {
 	// SMS configuration (optional)
 	SMS SMSConfig
 
+	// WhatsApp configuration (optional)
+	WhatsApp WhatsAppConfig
+
+	// Social login configuration (optional)
+	Social SocialConfig
+
+	// Redis configuration (shared OTP and rate limit state)
+	Redis RedisConfig
+
+	// Rate limit configuration
+	RateLimit RateLimitConfig
+
+	// Account deletion configuration
+	Deletion DeletionConfig
+
+	// New-device sign-in verification
+	Devices DevicesConfig
+
+	// Step-up challenges for sensitive actions in other services
+	StepUp StepUpConfig
+
+	// Guest checkout for parking without an account
+	Guest GuestConfig
+
+	// Transaction PIN lockout
+	PIN PINConfig
+
+	// Platform admins, whose access tokens carry the admin role
+	Admin AdminConfig
+
+	// Notification service for the welcome push (optional)
+	Notification NotificationClientConfig
+
 	// Kafka configuration
 	Kafka KafkaConfig
 
+	// Event store configuration (searchable history of published events)
+	EventStore EventStoreConfig
+
 	// OpenTelemetry configuration
 	OTEL OTELConfig
 }
 
 // ServerConfig holds HTTP server settings.
+//
+// Timeouts, header limits and TLS come from the shared httpserver package
+// so every service hardens its HTTP server the same way.
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
+	Port string
+	HTTP httpserver.Config
 }
 
 // GRPCConfig holds gRPC server settings.
 type GRPCConfig struct {
 	Port string
+	// TLS secures the gRPC listener and calls to other services
+	TLS mtls.Config
 }
 
 // DatabaseConfig holds PostgreSQL connection settings.
@@ -66,6 +119,8 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool
 }
 
 // ConnectionString returns the PostgreSQL connection string.
@@ -80,6 +135,16 @@ func (c DatabaseConfig) ConnectionString() string {
 type JWTConfig struct {
 	SecretKey      string
 	AccessTokenTTL time.Duration
+
+	// SigningAlgorithm is RS256, EdDSA or HS256. With RS256 and EdDSA,
+	// access tokens are signed with the private key in SigningKeyFile and
+	// verified by others through the JWKS endpoint; SecretKey then only
+	// signs step-up tokens, which never leave the auth service.
+	SigningAlgorithm string
+	SigningKeyFile   string
+	// PreviousKeyFiles hold rotated-out keys, still published until the
+	// tokens they signed expire
+	PreviousKeyFiles []string
 }
 
 // SMSConfig holds SMS provider settings.
@@ -90,6 +155,97 @@ type SMSConfig struct {
 	FromPhone  string
 }
 
+// WhatsAppConfig holds WhatsApp provider settings.
+// Leave Provider empty to send all OTPs via SMS.
+type WhatsAppConfig struct {
+	Provider      string // "", "console", "meta"
+	AccessToken   string
+	PhoneNumberID string
+	TemplateName  string // Approved authentication template for OTPs
+	LanguageCode  string
+	APIVersion    string
+}
+
+// SocialConfig holds the OAuth client IDs our apps use with each
+// identity provider. A provider is disabled when it has no client IDs.
+type SocialConfig struct {
+	GoogleClientIDs []string // Android, iOS and web client IDs
+	AppleClientIDs  []string // iOS bundle ID and Services ID
+}
+
+// RedisConfig holds settings for the Redis instance shared by all replicas.
+// Leave Host empty to keep OTPs and rate limits in memory, which is only
+// correct when a single instance is running.
+type RedisConfig struct {
+	Host     string
+	Port     string
+	Password string
+	DB       int
+}
+
+// Enabled reports whether a Redis instance is configured.
+func (c RedisConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// Addr returns the host:port address for the Redis client.
+func (c RedisConfig) Addr() string {
+	return c.Host + ":" + c.Port
+}
+
+// RateLimitConfig holds per-phone limits for sensitive actions.
+type RateLimitConfig struct {
+	OTPRequests      int
+	OTPRequestWindow time.Duration
+	LoginAttempts    int
+	LoginWindow      time.Duration
+}
+
+// DeletionConfig controls account deletion and the job that erases
+// accounts once their grace period ends.
+type DeletionConfig struct {
+	GracePeriod time.Duration
+	Enabled     bool          // Run the erasure job in this instance
+	Interval    time.Duration // How often the erasure job runs
+	BatchSize   int           // Accounts erased per run
+}
+
+// DevicesConfig controls the device registry. When enabled, sign-ins must
+// identify their device, and an unknown device needs an OTP.
+type DevicesConfig struct {
+	VerificationEnabled bool
+}
+
+// StepUpConfig controls step-up challenges. TTL covers both confirming the
+// challenge and using the token it gives.
+type StepUpConfig struct {
+	TTL time.Duration
+}
+
+// GuestConfig sets how long guest tokens last. Zero turns guest checkout off.
+type GuestConfig struct {
+	TokenTTL time.Duration
+}
+
+// PINConfig locks PIN entry for Lockout after MaxAttempts wrong PINs in a row.
+type PINConfig struct {
+	MaxAttempts int
+	Lockout     time.Duration
+}
+
+// AdminConfig lists the users who run the back office. Their access tokens
+// carry the admin role the gateway requires on /api/v1/admin routes.
+type AdminConfig struct {
+	UserIDs []uuid.UUID
+}
+
+// NotificationClientConfig is how auth reaches the notification service to
+// send the welcome push. An empty GRPC address turns it off.
+type NotificationClientConfig struct {
+	GRPC        string
+	CallTimeout time.Duration
+}
+
 // KafkaConfig holds Kafka settings.
 type KafkaConfig struct {
 	Brokers []string
@@ -97,12 +253,24 @@ type KafkaConfig struct {
 	Enabled bool
 }
 
+// EventStoreConfig selects where published events are recorded for the
+// admin event browser: memory or postgres
+type EventStoreConfig struct {
+	Backend     string
+	PostgresURL string
+}
+
 // OTELConfig holds OpenTelemetry settings.
 type OTELConfig struct {
 	Enabled     bool
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
 }
 
 // Load reads configuration from environment variables.
@@ -111,32 +279,82 @@ type OTELConfig struct {
 // If required configuration is missing, fail immediately at startup
 // rather than failing later when the config is needed.
 func Load() (*Config, error) {
+	// Without CONFIG_STRICT these fall back to local development values
+	required := []string{"DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME", "JWT_SECRET"}
+	if getEnv("JWT_SIGNING_ALG", "RS256") != "HS256" {
+		required = append(required, "JWT_SIGNING_KEY_FILE")
+	}
+	if err := settings.Require(required...); err != nil {
+		return nil, err
+	}
+
 	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
+	migrateOnStart, _ := strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	deletionJobEnabled, _ := strconv.ParseBool(getEnv("DELETION_JOB_ENABLED", "true"))
+	deviceVerificationEnabled, _ := strconv.ParseBool(getEnv("DEVICE_VERIFICATION_ENABLED", "true"))
 
 	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	logCfg, err := logging.ConfigFromEnv("auth-service")
+	if err != nil {
+		return nil, err
+	}
+
+	startupCfg, err := startup.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	var adminIDs []uuid.UUID
+	for _, value := range getListEnv("ADMIN_USER_IDS") {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ADMIN_USER_IDS entry %q: %w", value, err)
+		}
+		adminIDs = append(adminIDs, id)
+	}
+
 	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnv("SERVER_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("SERVER_READ_TIMEOUT", 10*time.Second),
-			WriteTimeout: getDurationEnv("SERVER_WRITE_TIMEOUT", 10*time.Second),
+			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
+		Log:     logCfg,
+		Startup: startupCfg,
 		GRPC: GRPCConfig{
 			Port: getEnv("GRPC_PORT", "9000"),
+			TLS:  grpcTLS,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "auth_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "5432"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "auth_db"),
+			SSLMode:        getEnv("DB_SSLMODE", "disable"),
+			MigrateOnStart: migrateOnStart,
 		},
 		JWT: JWTConfig{
 			SecretKey:      getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
 			AccessTokenTTL: getDurationEnv("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+
+			SigningAlgorithm: getEnv("JWT_SIGNING_ALG", "RS256"),
+			SigningKeyFile:   getEnv("JWT_SIGNING_KEY_FILE", ""),
+			PreviousKeyFiles: getListEnv("JWT_PREVIOUS_KEY_FILES"),
 		},
 		SMS: SMSConfig{
 			Provider:   getEnv("SMS_PROVIDER", "console"),
@@ -144,19 +362,82 @@ func Load() (*Config, error) {
 			AuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
 			FromPhone:  getEnv("TWILIO_FROM_PHONE", ""),
 		},
+		WhatsApp: WhatsAppConfig{
+			Provider:      getEnv("WHATSAPP_PROVIDER", ""),
+			AccessToken:   getEnv("WHATSAPP_ACCESS_TOKEN", ""),
+			PhoneNumberID: getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
+			TemplateName:  getEnv("WHATSAPP_OTP_TEMPLATE", "otp_verification"),
+			LanguageCode:  getEnv("WHATSAPP_LANGUAGE_CODE", "en"),
+			APIVersion:    getEnv("WHATSAPP_API_VERSION", "v19.0"),
+		},
+		Social: SocialConfig{
+			GoogleClientIDs: getListEnv("GOOGLE_CLIENT_IDS"),
+			AppleClientIDs:  getListEnv("APPLE_CLIENT_IDS"),
+		},
+		Redis: RedisConfig{
+			Host:     getEnv("REDIS_HOST", ""),
+			Port:     getEnv("REDIS_PORT", "6379"),
+			Password: getEnv("REDIS_PASSWORD", ""),
+			DB:       getIntEnv("REDIS_DB", 0),
+		},
+		RateLimit: RateLimitConfig{
+			OTPRequests:      getIntEnv("RATE_LIMIT_OTP_REQUESTS", 5),
+			OTPRequestWindow: getDurationEnv("RATE_LIMIT_OTP_WINDOW", time.Hour),
+			LoginAttempts:    getIntEnv("RATE_LIMIT_LOGIN_ATTEMPTS", 10),
+			LoginWindow:      getDurationEnv("RATE_LIMIT_LOGIN_WINDOW", 15*time.Minute),
+		},
+		Deletion: DeletionConfig{
+			GracePeriod: getDurationEnv("DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			Enabled:     deletionJobEnabled,
+			Interval:    getDurationEnv("DELETION_JOB_INTERVAL", time.Hour),
+			BatchSize:   getIntEnv("DELETION_JOB_BATCH_SIZE", 100),
+		},
+		Devices: DevicesConfig{
+			VerificationEnabled: deviceVerificationEnabled,
+		},
+		StepUp: StepUpConfig{
+			TTL: getDurationEnv("STEP_UP_TTL", 5*time.Minute),
+		},
+		Guest: GuestConfig{
+			TokenTTL: getDurationEnv("GUEST_TOKEN_TTL", 2*time.Hour),
+		},
+		PIN: PINConfig{
+			MaxAttempts: getIntEnv("PIN_MAX_ATTEMPTS", 5),
+			Lockout:     getDurationEnv("PIN_LOCKOUT", 30*time.Minute),
+		},
+		Admin: AdminConfig{
+			UserIDs: adminIDs,
+		},
+		Notification: NotificationClientConfig{
+			GRPC:        getEnv("NOTIFICATION_SERVICE_GRPC", ""),
+			CallTimeout: getDurationEnv("NOTIFICATION_GRPC_TIMEOUT", 3*time.Second),
+		},
 		Kafka: KafkaConfig{
 			Brokers: brokers,
 			Topic:   getEnv("KAFKA_TOPIC", "auth.events"),
 			Enabled: kafkaEnabled,
 		},
+		EventStore: EventStoreConfig{
+			Backend:     getEnv("EVENT_STORE_BACKEND", "memory"),
+			PostgresURL: getEnv("EVENT_STORE_POSTGRES_URL", ""),
+		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "auth-service"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "auth-service"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: getDurationEnv("OTEL_METRICS_INTERVAL", 30*time.Second),
+			RuntimeMetrics:  otelRuntimeMetrics,
 		},
 	}
 
+	switch cfg.JWT.SigningAlgorithm {
+	case "RS256", "EdDSA", "HS256":
+	default:
+		return nil, fmt.Errorf("invalid JWT_SIGNING_ALG %q: must be RS256, EdDSA or HS256", cfg.JWT.SigningAlgorithm)
+	}
+
 	// Validate required configuration
 	if cfg.JWT.SecretKey == "your-super-secret-key-change-in-production" {
 		fmt.Println("WARNING: Using default JWT secret key. Set JWT_SECRET in production!")
@@ -191,3 +472,14 @@ func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+// getListEnv reads a comma-separated list, skipping empty entries.
+func getListEnv(key string) []string {
+	var values []string
+	for _, v := range strings.Split(os.Getenv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,40 @@
+package external
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/revocation"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// TokenDenylist implements ports.TokenRevoker over the revocation list the
+// gateway checks on every request.
+type TokenDenylist struct {
+	list           revocation.List
+	accessTokenTTL time.Duration
+}
+
+// NewTokenDenylist creates a revoker. accessTokenTTL must match the token
+// service's, so user-wide revocations last until the newest token covered
+// has expired.
+func NewTokenDenylist(list revocation.List, accessTokenTTL time.Duration) *TokenDenylist {
+	return &TokenDenylist{list: list, accessTokenTTL: accessTokenTTL}
+}
+
+func (d *TokenDenylist) RevokeAccessToken(ctx context.Context, claims *ports.AccessTokenClaims) error {
+	return d.list.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt)
+}
+
+func (d *TokenDenylist) RevokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	return d.list.RevokeUser(ctx, userID.String(), time.Now(), d.accessTokenTTL)
+}
+
+func (d *TokenDenylist) IsRevoked(ctx context.Context, claims *ports.AccessTokenClaims) (bool, error) {
+	return d.list.IsRevoked(ctx, revocation.Token{
+		ID:       claims.TokenID,
+		UserID:   claims.UserID.String(),
+		IssuedAt: claims.IssuedAt,
+	})
+}
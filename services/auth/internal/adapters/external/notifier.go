@@ -0,0 +1,26 @@
+package external
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/notify"
+)
+
+// NotificationClient implements ports.Notifier with the notification
+// service's gRPC API.
+type NotificationClient struct {
+	client *notify.Client
+}
+
+// NewNotificationClient wraps a connected notify client.
+func NewNotificationClient(client *notify.Client) *NotificationClient {
+	return &NotificationClient{client: client}
+}
+
+// SendTemplate sends the named template to the user and waits for the
+// notification service to accept it.
+func (c *NotificationClient) SendTemplate(ctx context.Context, userID uuid.UUID, template, recipient string, variables map[string]string) error {
+	_, err := c.client.SendTemplate(ctx, userID, template, recipient, variables)
+	return err
+}
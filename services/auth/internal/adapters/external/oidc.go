@@ -0,0 +1,171 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/parking-super-app/pkg/jwks"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// Well-known OpenID Connect settings for the providers we support.
+const (
+	GoogleJWKSURL = "https://www.googleapis.com/oauth2/v3/certs"
+	AppleJWKSURL  = "https://appleid.apple.com/auth/keys"
+)
+
+var (
+	googleIssuers = []string{"https://accounts.google.com", "accounts.google.com"}
+	appleIssuers  = []string{"https://appleid.apple.com"}
+)
+
+// OIDCConfig describes how to verify one provider's ID tokens.
+type OIDCConfig struct {
+	Provider domain.SocialProvider
+	JWKSURL  string
+	Issuers  []string
+
+	// ClientIDs are OUR app's client IDs registered with the provider.
+	// Android, iOS and web usually each have their own.
+	ClientIDs []string
+
+	// KeyCacheTTL controls how long fetched signing keys are trusted
+	// before being refreshed. Providers rotate keys every few days.
+	KeyCacheTTL time.Duration
+}
+
+// OIDCVerifier implements ports.IDTokenVerifier for any OpenID Connect
+// provider that publishes its signing keys as a JWKS document.
+//
+// HOW ID TOKEN VERIFICATION WORKS:
+// ================================
+// 1. Read the "kid" (key ID) from the token header
+// 2. Find the matching public key in the provider's JWKS
+// 3. Check the RS256 signature with that key
+// 4. Check issuer, audience (our client ID) and expiry
+//
+// Keys are cached in memory. A token signed with an unknown kid triggers
+// a refresh, because that is exactly what happens right after the
+// provider rotates its keys.
+type OIDCVerifier struct {
+	cfg  OIDCConfig
+	keys *jwks.Client
+}
+
+// NewOIDCVerifier creates a verifier for the given provider configuration.
+func NewOIDCVerifier(cfg OIDCConfig) *OIDCVerifier {
+	if cfg.KeyCacheTTL <= 0 {
+		cfg.KeyCacheTTL = time.Hour
+	}
+	return &OIDCVerifier{
+		cfg:  cfg,
+		keys: jwks.NewClient(cfg.JWKSURL, cfg.KeyCacheTTL),
+	}
+}
+
+// NewGoogleIDTokenVerifier verifies "Sign in with Google" ID tokens.
+func NewGoogleIDTokenVerifier(clientIDs []string) *OIDCVerifier {
+	return NewOIDCVerifier(OIDCConfig{
+		Provider:  domain.SocialProviderGoogle,
+		JWKSURL:   GoogleJWKSURL,
+		Issuers:   googleIssuers,
+		ClientIDs: clientIDs,
+	})
+}
+
+// NewAppleIDTokenVerifier verifies "Sign in with Apple" ID tokens.
+// For native iOS apps the client ID is the app's bundle ID.
+func NewAppleIDTokenVerifier(clientIDs []string) *OIDCVerifier {
+	return NewOIDCVerifier(OIDCConfig{
+		Provider:  domain.SocialProviderApple,
+		JWKSURL:   AppleJWKSURL,
+		Issuers:   appleIssuers,
+		ClientIDs: clientIDs,
+	})
+}
+
+// oidcClaims are the ID token claims we use.
+//
+// email_verified and phone_number_verified are booleans for Google but
+// Apple sends them as the strings "true"/"false", so flexBool accepts both.
+type oidcClaims struct {
+	jwt.RegisteredClaims
+	Email               string   `json:"email"`
+	EmailVerified       flexBool `json:"email_verified"`
+	PhoneNumber         string   `json:"phone_number"`
+	PhoneNumberVerified flexBool `json:"phone_number_verified"`
+	Name                string   `json:"name"`
+}
+
+// Verify validates the ID token and returns its identity claims.
+func (v *OIDCVerifier) Verify(ctx context.Context, idToken string) (*domain.SocialClaims, error) {
+	claims := &oidcClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return v.keys.Key(ctx, kid)
+		},
+		// Pin the algorithm - never let the token choose (e.g. "none" or HS256)
+		jwt.WithValidMethods([]string{"RS256"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidIDToken, err)
+	}
+
+	if !containsString(v.cfg.Issuers, claims.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", domain.ErrInvalidIDToken, claims.Issuer)
+	}
+	if !v.audienceMatches(claims.Audience) {
+		return nil, fmt.Errorf("%w: token was not issued for this app", domain.ErrInvalidIDToken)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", domain.ErrInvalidIDToken)
+	}
+
+	return &domain.SocialClaims{
+		Provider:      v.cfg.Provider,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Phone:         claims.PhoneNumber,
+		PhoneVerified: bool(claims.PhoneNumberVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+func (v *OIDCVerifier) audienceMatches(audience jwt.ClaimStrings) bool {
+	for _, aud := range audience {
+		if containsString(v.cfg.ClientIDs, aud) {
+			return true
+		}
+	}
+	return false
+}
+
+// flexBool decodes a JSON boolean or a "true"/"false" string.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `true`, `"true"`:
+		*b = true
+	case `false`, `"false"`, `null`:
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean value %s", data)
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package external
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// newTestJWKS serves the public half of key under the given key ID.
+func newTestJWKS(t *testing.T, kid string, key *rsa.PrivateKey) (*httptest.Server, *int) {
+	t.Helper()
+	fetches := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": kid,
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func signTestToken(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestOIDCVerifier_Verify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server, _ := newTestJWKS(t, "key-1", key)
+
+	verifier := NewOIDCVerifier(OIDCConfig{
+		Provider:  domain.SocialProviderGoogle,
+		JWKSURL:   server.URL,
+		Issuers:   googleIssuers,
+		ClientIDs: []string{"android-client", "ios-client"},
+	})
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            "https://accounts.google.com",
+			"aud":            "ios-client",
+			"sub":            "google-user-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"name":           "Jane Tan",
+		}
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := verifier.Verify(context.Background(), signTestToken(t, key, "key-1", validClaims()))
+		if err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+		if claims.Subject != "google-user-1" {
+			t.Errorf("Subject = %q, want google-user-1", claims.Subject)
+		}
+		if claims.Provider != domain.SocialProviderGoogle {
+			t.Errorf("Provider = %q, want google", claims.Provider)
+		}
+		if !claims.EmailVerified || claims.Email != "jane@example.com" {
+			t.Errorf("unexpected email claims: %q verified=%v", claims.Email, claims.EmailVerified)
+		}
+		if claims.Name != "Jane Tan" {
+			t.Errorf("Name = %q, want Jane Tan", claims.Name)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		key    *rsa.PrivateKey
+		kid    string
+	}{
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "someone-elses-app" }, key, "key-1"},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }, key, "key-1"},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }, key, "key-1"},
+		{"missing expiry", func(c jwt.MapClaims) { delete(c, "exp") }, key, "key-1"},
+		{"missing subject", func(c jwt.MapClaims) { delete(c, "sub") }, key, "key-1"},
+		{"signed by another key", func(c jwt.MapClaims) {}, otherKey, "key-1"},
+		{"unknown key id", func(c jwt.MapClaims) {}, key, "key-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.mutate(claims)
+
+			_, err := verifier.Verify(context.Background(), signTestToken(t, tt.key, tt.kid, claims))
+			if !errors.Is(err, domain.ErrInvalidIDToken) {
+				t.Errorf("Verify() error = %v, want ErrInvalidIDToken", err)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifier_RejectsHMACTokens(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server, _ := newTestJWKS(t, "key-1", key)
+	verifier := NewOIDCVerifier(OIDCConfig{
+		JWKSURL: server.URL, Issuers: appleIssuers, ClientIDs: []string{"com.example.app"},
+	})
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"iss": "https://appleid.apple.com", "aud": "com.example.app", "sub": "x",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	token.Header["kid"] = "key-1"
+	signed, _ := token.SignedString([]byte("secret"))
+
+	if _, err := verifier.Verify(context.Background(), signed); !errors.Is(err, domain.ErrInvalidIDToken) {
+		t.Errorf("Verify() error = %v, want ErrInvalidIDToken", err)
+	}
+}
+
+func TestOIDCVerifier_AppleStringBooleans(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server, _ := newTestJWKS(t, "apple-1", key)
+	verifier := NewOIDCVerifier(OIDCConfig{
+		Provider:  domain.SocialProviderApple,
+		JWKSURL:   server.URL,
+		Issuers:   appleIssuers,
+		ClientIDs: []string{"com.example.app"},
+	})
+
+	token := signTestToken(t, key, "apple-1", jwt.MapClaims{
+		"iss":            "https://appleid.apple.com",
+		"aud":            "com.example.app",
+		"sub":            "001234.abcd",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"email":          "relay@privaterelay.appleid.com",
+		"email_verified": "true",
+	})
+
+	claims, err := verifier.Verify(context.Background(), token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !claims.EmailVerified {
+		t.Error("expected string \"true\" to be read as verified")
+	}
+}
+
+func TestOIDCVerifier_CachesKeys(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server, fetches := newTestJWKS(t, "key-1", key)
+	verifier := NewOIDCVerifier(OIDCConfig{
+		JWKSURL: server.URL, Issuers: googleIssuers, ClientIDs: []string{"web"},
+	})
+
+	claims := jwt.MapClaims{
+		"iss": "accounts.google.com", "aud": "web", "sub": "1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := verifier.Verify(context.Background(), signTestToken(t, key, "key-1", claims)); err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+	}
+	// Unknown key IDs right after a fetch must not hit the JWKS endpoint again
+	verifier.Verify(context.Background(), signTestToken(t, key, "bogus", claims))
+
+	if *fetches != 1 {
+		t.Errorf("JWKS fetched %d times, want 1", *fetches)
+	}
+}
@@ -0,0 +1,102 @@
+package external
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/otpstore"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// OTPStoreRepository implements ports.OTPRepository on top of a shared
+// otpstore.Store, keyed by phone number.
+//
+// PATTERN: Adapter
+// ================
+// pkg/otpstore knows nothing about our domain. This adapter translates
+// between domain.OTP and the store's generic Entry, and between the
+// store's ErrNotFound and our domain errors.
+//
+// Use the Redis store whenever more than one auth replica is running.
+// The memory store is fine for local development.
+type OTPStoreRepository struct {
+	store otpstore.Store
+}
+
+// NewOTPStoreRepository creates an OTP repository backed by store.
+func NewOTPStoreRepository(store otpstore.Store) *OTPStoreRepository {
+	return &OTPStoreRepository{store: store}
+}
+
+// Create stores a new OTP, replacing any earlier one for the same phone.
+func (r *OTPStoreRepository) Create(ctx context.Context, otp *domain.OTP) error {
+	return r.store.Put(ctx, otp.Phone, otpstore.Entry{
+		ID:        otp.ID.String(),
+		Code:      otp.Code,
+		Attempts:  otp.Attempts,
+		Verified:  otp.Verified,
+		CreatedAt: otp.CreatedAt,
+		ExpiresAt: otp.ExpiresAt,
+	})
+}
+
+// GetLatestByPhone returns the phone's current OTP.
+func (r *OTPStoreRepository) GetLatestByPhone(ctx context.Context, phone string) (*domain.OTP, error) {
+	entry, err := r.store.Get(ctx, phone)
+	if err != nil {
+		return nil, mapOTPStoreError(err)
+	}
+
+	id, err := uuid.Parse(entry.ID)
+	if err != nil {
+		return nil, domain.ErrTokenNotFound
+	}
+
+	return &domain.OTP{
+		ID:        id,
+		Phone:     phone,
+		Code:      entry.Code,
+		ExpiresAt: entry.ExpiresAt,
+		Verified:  entry.Verified,
+		Attempts:  entry.Attempts,
+		CreatedAt: entry.CreatedAt,
+	}, nil
+}
+
+// Update saves attempts and verification state. The store never lowers the
+// attempt count, so a stale copy can't undo attempts made on other replicas.
+func (r *OTPStoreRepository) Update(ctx context.Context, otp *domain.OTP) error {
+	err := r.store.Update(ctx, otp.Phone, otpstore.Entry{
+		ID:       otp.ID.String(),
+		Attempts: otp.Attempts,
+		Verified: otp.Verified,
+	})
+	return mapOTPStoreError(err)
+}
+
+// IncrementAttempts atomically records a verification attempt.
+func (r *OTPStoreRepository) IncrementAttempts(ctx context.Context, phone string) (int, error) {
+	attempts, err := r.store.IncrementAttempts(ctx, phone)
+	if err != nil {
+		return 0, mapOTPStoreError(err)
+	}
+	return attempts, nil
+}
+
+// DeleteByPhone removes the phone's OTP.
+func (r *OTPStoreRepository) DeleteByPhone(ctx context.Context, phone string) error {
+	return r.store.Delete(ctx, phone)
+}
+
+// DeleteExpired is a no-op: entries expire inside the store.
+func (r *OTPStoreRepository) DeleteExpired(ctx context.Context) error {
+	return nil
+}
+
+func mapOTPStoreError(err error) error {
+	if errors.Is(err, otpstore.ErrNotFound) {
+		return domain.ErrTokenNotFound
+	}
+	return err
+}
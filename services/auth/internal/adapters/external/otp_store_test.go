@@ -0,0 +1,71 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/parking-super-app/pkg/otpstore"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+func TestOTPStoreRepository_RoundTrip(t *testing.T) {
+	repo := NewOTPStoreRepository(otpstore.NewMemoryStore())
+	ctx := context.Background()
+
+	otp := domain.NewOTP("+60123456789", "123456")
+	if err := repo.Create(ctx, otp); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetLatestByPhone(ctx, otp.Phone)
+	if err != nil {
+		t.Fatalf("GetLatestByPhone() error = %v", err)
+	}
+	if got.ID != otp.ID || got.Code != otp.Code || got.Phone != otp.Phone {
+		t.Errorf("GetLatestByPhone() = %+v, want %+v", got, otp)
+	}
+}
+
+func TestOTPStoreRepository_IncrementAttempts(t *testing.T) {
+	repo := NewOTPStoreRepository(otpstore.NewMemoryStore())
+	ctx := context.Background()
+
+	otp := domain.NewOTP("+60123456789", "123456")
+	repo.Create(ctx, otp)
+
+	for want := 1; want <= domain.MaxOTPAttempts; want++ {
+		got, err := repo.IncrementAttempts(ctx, otp.Phone)
+		if err != nil {
+			t.Fatalf("IncrementAttempts() error = %v", err)
+		}
+		if got != want {
+			t.Errorf("IncrementAttempts() = %d, want %d", got, want)
+		}
+	}
+
+	stored, _ := repo.GetLatestByPhone(ctx, otp.Phone)
+	if stored.IsValid() {
+		t.Error("OTP should be invalid after MaxOTPAttempts attempts")
+	}
+}
+
+func TestOTPStoreRepository_NotFound(t *testing.T) {
+	repo := NewOTPStoreRepository(otpstore.NewMemoryStore())
+	ctx := context.Background()
+
+	if _, err := repo.GetLatestByPhone(ctx, "+60123456789"); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("GetLatestByPhone() error = %v, want ErrTokenNotFound", err)
+	}
+	if _, err := repo.IncrementAttempts(ctx, "+60123456789"); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("IncrementAttempts() error = %v, want ErrTokenNotFound", err)
+	}
+
+	// Deleting the OTP (after successful verification) makes later updates fail
+	otp := domain.NewOTP("+60123456789", "123456")
+	repo.Create(ctx, otp)
+	repo.DeleteByPhone(ctx, otp.Phone)
+	if err := repo.Update(ctx, otp); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("Update() error = %v, want ErrTokenNotFound", err)
+	}
+}
@@ -0,0 +1,29 @@
+package external
+
+import (
+	"context"
+
+	"github.com/parking-super-app/pkg/ratelimit"
+)
+
+// RateLimiter implements ports.RateLimiter using pkg/ratelimit.
+//
+// Each limiter enforces one rule, so the service holds one per action
+// (OTP requests, login attempts) rather than one with many settings.
+type RateLimiter struct {
+	limiter ratelimit.Limiter
+}
+
+// NewRateLimiter wraps a Redis or in-memory limiter from pkg/ratelimit.
+func NewRateLimiter(limiter ratelimit.Limiter) *RateLimiter {
+	return &RateLimiter{limiter: limiter}
+}
+
+// Allow records an attempt for key and reports whether it is permitted.
+func (l *RateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	result, err := l.limiter.Allow(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	return result.Allowed, nil
+}
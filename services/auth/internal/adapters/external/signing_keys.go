@@ -0,0 +1,164 @@
+package external
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/parking-super-app/pkg/jwks"
+)
+
+// KeyRing holds the key new access tokens are signed with, plus retired
+// keys whose tokens may still be in circulation. All of them are published
+// in the JWKS so the gateway can verify any live token.
+//
+// KEY ROTATION:
+// =============
+//  1. Generate a new key ("auth-service keygen")
+//  2. Move the current key file to JWT_PREVIOUS_KEY_FILES, and point
+//     JWT_SIGNING_KEY_FILE at the new one
+//  3. After one access token TTL, drop the old file
+//
+// Key IDs are thumbprints of the keys, so every replica loading the same
+// files issues the same kid.
+type KeyRing struct {
+	signer crypto.Signer
+	method jwt.SigningMethod
+	kid    string
+
+	keys map[string]crypto.PublicKey
+	set  jwks.Set
+}
+
+// NewKeyRing creates a key ring that signs with current. RSA keys sign
+// with RS256 and Ed25519 keys with EdDSA.
+func NewKeyRing(current crypto.Signer, previous ...crypto.PublicKey) (*KeyRing, error) {
+	method, err := signingMethod(current)
+	if err != nil {
+		return nil, err
+	}
+
+	ring := &KeyRing{
+		signer: current,
+		method: method,
+		keys:   make(map[string]crypto.PublicKey),
+	}
+	for _, pub := range append([]crypto.PublicKey{current.Public()}, previous...) {
+		k, err := jwks.NewKey(pub)
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := ring.keys[k.Kid]; dup {
+			continue
+		}
+		ring.keys[k.Kid] = pub
+		ring.set.Keys = append(ring.set.Keys, k)
+	}
+	ring.kid = ring.set.Keys[0].Kid
+	return ring, nil
+}
+
+// LoadKeyRing reads the current private key and any previous keys from
+// PEM files. Previous keys may be private or public keys.
+func LoadKeyRing(currentFile string, previousFiles []string) (*KeyRing, error) {
+	data, err := os.ReadFile(currentFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	current, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key %s: %w", currentFile, err)
+	}
+
+	var previous []crypto.PublicKey
+	for _, file := range previousFiles {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read previous key: %w", err)
+		}
+		pub, err := parsePublicKeyPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid previous key %s: %w", file, err)
+		}
+		previous = append(previous, pub)
+	}
+	return NewKeyRing(current, previous...)
+}
+
+// Algorithm is the JWT alg new tokens are signed with
+func (r *KeyRing) Algorithm() string {
+	return r.method.Alg()
+}
+
+// JWKS returns the public keys to publish
+func (r *KeyRing) JWKS() jwks.Set {
+	return r.set
+}
+
+// GenerateSigningKey creates a key for jwks.AlgRS256 or jwks.AlgEdDSA.
+func GenerateSigningKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case jwks.AlgRS256:
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case jwks.AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+}
+
+// EncodePrivateKeyPEM encodes key as a PKCS #8 PEM block.
+func EncodePrivateKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// ParsePrivateKeyPEM reads a PKCS #8 or PKCS #1 (RSA) private key.
+func ParsePrivateKeyPEM(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func parsePublicKeyPEM(data []byte) (crypto.PublicKey, error) {
+	if signer, err := ParsePrivateKeyPEM(data); err == nil {
+		return signer.Public(), nil
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+func signingMethod(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PrivateKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key)
+	}
+}
@@ -0,0 +1,89 @@
+package external
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+const stepUpAudience = "step-up"
+
+// StepUpTokenService implements ports.StepUpTokens with HS256 JWTs.
+//
+// The signing key is derived from the access token secret, so a step-up
+// token never validates as an access token or the other way round.
+type StepUpTokenService struct {
+	secretKey []byte
+}
+
+// NewStepUpTokenService creates a step-up token service from the JWT secret.
+func NewStepUpTokenService(jwtSecret string) *StepUpTokenService {
+	mac := hmac.New(sha256.New, []byte(jwtSecret))
+	mac.Write([]byte("step-up-token"))
+	return &StepUpTokenService{secretKey: mac.Sum(nil)}
+}
+
+type stepUpClaims struct {
+	jwt.RegisteredClaims
+	Action string `json:"act"`
+	Amount string `json:"amt,omitempty"`
+}
+
+// Generate signs a token for a confirmed challenge. It expires with the
+// challenge.
+func (s *StepUpTokenService) Generate(c *domain.StepUpChallenge) (string, error) {
+	claims := stepUpClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        c.ID.String(),
+			Subject:   c.UserID.String(),
+			Audience:  jwt.ClaimStrings{stepUpAudience},
+			Issuer:    "parking-super-app-auth",
+			IssuedAt:  jwt.NewNumericDate(*c.VerifiedAt),
+			ExpiresAt: jwt.NewNumericDate(c.ExpiresAt),
+		},
+		Action: c.Action,
+		Amount: c.Amount,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign step-up token: %w", err)
+	}
+	return signed, nil
+}
+
+// Validate checks the signature, audience and expiry and returns the claims.
+func (s *StepUpTokenService) Validate(token string) (*ports.StepUpClaims, error) {
+	parsed, err := jwt.ParseWithClaims(token, &stepUpClaims{}, func(t *jwt.Token) (interface{}, error) {
+		return s.secretKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithAudience(stepUpAudience))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse step-up token: %w", err)
+	}
+
+	claims, ok := parsed.Claims.(*stepUpClaims)
+	if !ok || !parsed.Valid {
+		return nil, fmt.Errorf("invalid step-up token claims")
+	}
+	challengeID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid step-up challenge ID: %w", err)
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid step-up subject: %w", err)
+	}
+
+	return &ports.StepUpClaims{
+		ChallengeID: challengeID,
+		UserID:      userID,
+		Action:      claims.Action,
+		Amount:      claims.Amount,
+		ExpiresAt:   claims.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,64 @@
+package external
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+func verifiedChallenge(t *testing.T) *domain.StepUpChallenge {
+	t.Helper()
+	now := time.Now().UTC()
+	c, err := domain.NewStepUpChallenge(uuid.New(), "wallet.payment", "250.00", now, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("NewStepUpChallenge() error = %v", err)
+	}
+	if err := c.Verify(now); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	return c
+}
+
+func TestStepUpTokenService_RoundTrip(t *testing.T) {
+	service := NewStepUpTokenService("test-secret-key-32-chars-long!!")
+	c := verifiedChallenge(t)
+
+	token, err := service.Generate(c)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	claims, err := service.Validate(token)
+	if err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if claims.ChallengeID != c.ID || claims.UserID != c.UserID {
+		t.Errorf("claims = %+v, want challenge %v for user %v", claims, c.ID, c.UserID)
+	}
+	if claims.Action != "wallet.payment" || claims.Amount != "250.00" {
+		t.Errorf("claims action/amount = %q/%q", claims.Action, claims.Amount)
+	}
+}
+
+func TestStepUpTokenService_RejectsOtherTokens(t *testing.T) {
+	const secret = "test-secret-key-32-chars-long!!"
+	stepUp := NewStepUpTokenService(secret)
+	access := NewJWTTokenService(secret, 15*time.Minute)
+
+	accessToken, _ := access.GenerateAccessToken(uuid.New(), "+60123456789", uuid.New())
+	if _, err := stepUp.Validate(accessToken); err == nil {
+		t.Error("access token accepted as step-up token")
+	}
+
+	stepUpToken, _ := stepUp.Generate(verifiedChallenge(t))
+	if _, err := access.ValidateAccessToken(stepUpToken); err == nil {
+		t.Error("step-up token accepted as access token")
+	}
+
+	other, _ := NewStepUpTokenService("another-secret-key-32-chars-long").Generate(verifiedChallenge(t))
+	if _, err := stepUp.Validate(other); err == nil {
+		t.Error("token signed with another key accepted")
+	}
+}
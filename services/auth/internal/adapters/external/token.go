@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
 	"github.com/parking-super-app/services/auth/internal/ports"
 )
 
@@ -29,6 +30,9 @@ import (
 type JWTTokenService struct {
 	secretKey       []byte
 	accessTokenTTL  time.Duration
+
+	// keys replaces secretKey when tokens are signed asymmetrically
+	keys *KeyRing
 }
 
 // NewJWTTokenService creates a new JWT token service.
@@ -43,15 +47,44 @@ func NewJWTTokenService(secretKey string, accessTokenTTL time.Duration) *JWTToke
 	}
 }
 
+// NewKeyRingTokenService creates a JWT token service that signs with the
+// key ring's current key (RS256 or EdDSA).
+//
+// WHY ASYMMETRIC KEYS?
+// ====================
+// With HS256 everything that verifies tokens holds the secret and could
+// mint them. Here only the auth service holds a private key; the gateway
+// verifies with the public keys it fetches from the JWKS endpoint.
+func NewKeyRingTokenService(keys *KeyRing, accessTokenTTL time.Duration) *JWTTokenService {
+	return &JWTTokenService{
+		keys:           keys,
+		accessTokenTTL: accessTokenTTL,
+	}
+}
+
 // jwtClaims represents the custom claims in our JWT.
 type jwtClaims struct {
 	jwt.RegisteredClaims
 	UserID uuid.UUID `json:"uid"`
 	Phone  string    `json:"phone"`
+	// SessionID ties the access token to the refresh token (device session)
+	// it was issued with. Stored as a string so it can be omitted.
+	SessionID string `json:"sid,omitempty"`
+	// Role and ProviderID scope provider staff to their provider's portal
+	Role       string `json:"role,omitempty"`
+	ProviderID string `json:"provider_id,omitempty"`
+	// DeviceID is the device a guest token was issued to
+	DeviceID string `json:"device_id,omitempty"`
 }
 
 // GenerateAccessToken creates a new JWT access token.
-func (s *JWTTokenService) GenerateAccessToken(userID uuid.UUID, phone string) (string, error) {
+func (s *JWTTokenService) GenerateAccessToken(userID uuid.UUID, phone string, sessionID uuid.UUID) (string, error) {
+	return s.GenerateScopedAccessToken(userID, phone, sessionID, ports.TokenScope{})
+}
+
+// GenerateScopedAccessToken creates a JWT access token carrying the
+// provider staff role and provider ID in scope, when set.
+func (s *JWTTokenService) GenerateScopedAccessToken(userID uuid.UUID, phone string, sessionID uuid.UUID, scope ports.TokenScope) (string, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.accessTokenTTL)
 
@@ -65,18 +98,50 @@ func (s *JWTTokenService) GenerateAccessToken(userID uuid.UUID, phone string) (s
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			// Issuer identifies who created the token
 			Issuer: "parking-super-app-auth",
+			// ID (jti) lets this one token be revoked before it expires
+			ID: uuid.NewString(),
 		},
-		UserID: userID,
-		Phone:  phone,
+		UserID:    userID,
+		Phone:     phone,
+		SessionID: sessionID.String(),
+		Role:      scope.Role,
 	}
+	if scope.ProviderID != uuid.Nil {
+		claims.ProviderID = scope.ProviderID.String()
+	}
+	return s.sign(claims)
+}
 
-	// Create token with HS256 algorithm
-	// HS256 = HMAC with SHA-256 (symmetric key)
-	// For distributed microservices, consider RS256 (asymmetric) instead
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+// GenerateGuestToken creates a short-lived access token for a guest. It
+// has no session, so it can't be refreshed; the guest asks for a new one.
+func (s *JWTTokenService) GenerateGuestToken(guestID uuid.UUID, deviceID string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	return s.sign(jwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   guestID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+			Issuer:    "parking-super-app-auth",
+			ID:        uuid.NewString(),
+		},
+		UserID:   guestID,
+		Role:     domain.RoleGuest,
+		DeviceID: deviceID,
+	})
+}
 
-	// Sign the token with our secret key
-	signedToken, err := token.SignedString(s.secretKey)
+func (s *JWTTokenService) sign(claims jwtClaims) (string, error) {
+	// HS256 = HMAC with SHA-256 (symmetric key), unless a key ring is set
+	var signedToken string
+	var err error
+	if s.keys != nil {
+		token := jwt.NewWithClaims(s.keys.method, claims)
+		// kid tells verifiers which published key to check the signature with
+		token.Header["kid"] = s.keys.kid
+		signedToken, err = token.SignedString(s.keys.signer)
+	} else {
+		signedToken, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -87,13 +152,10 @@ func (s *JWTTokenService) GenerateAccessToken(userID uuid.UUID, phone string) (s
 // ValidateAccessToken validates a JWT and returns the claims.
 func (s *JWTTokenService) ValidateAccessToken(tokenString string) (*ports.AccessTokenClaims, error) {
 	// Parse and validate the token
-	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &jwtClaims{}, s.verificationKey,
+		// Pin the algorithms - never let the token choose (e.g. "none")
+		jwt.WithValidMethods(s.validMethods()),
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -105,14 +167,44 @@ func (s *JWTTokenService) ValidateAccessToken(tokenString string) (*ports.Access
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	// Tokens issued before session tracking have no sid; treat as uuid.Nil
+	sessionID, _ := uuid.Parse(claims.SessionID)
+	providerID, _ := uuid.Parse(claims.ProviderID)
+
 	return &ports.AccessTokenClaims{
-		UserID:    claims.UserID,
-		Phone:     claims.Phone,
-		ExpiresAt: claims.ExpiresAt.Time,
-		IssuedAt:  claims.IssuedAt.Time,
+		UserID:     claims.UserID,
+		Phone:      claims.Phone,
+		SessionID:  sessionID,
+		ExpiresAt:  claims.ExpiresAt.Time,
+		IssuedAt:   claims.IssuedAt.Time,
+		TokenID:    claims.ID,
+		Role:       claims.Role,
+		ProviderID: providerID,
+		DeviceID:   claims.DeviceID,
 	}, nil
 }
 
+func (s *JWTTokenService) validMethods() []string {
+	if s.keys != nil {
+		return []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	}
+	return []string{jwt.SigningMethodHS256.Alg()}
+}
+
+// verificationKey picks the key the token was signed with: the secret, or
+// the key ring key named by its kid header.
+func (s *JWTTokenService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.keys == nil {
+		return s.secretKey, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
 // GenerateRefreshToken creates a cryptographically secure random token.
 //
 // WHY NOT JWT FOR REFRESH TOKENS?
@@ -4,7 +4,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/ports"
 )
 
 func TestJWTTokenService_GenerateAccessToken(t *testing.T) {
@@ -12,7 +14,7 @@ func TestJWTTokenService_GenerateAccessToken(t *testing.T) {
 	userID := uuid.New()
 	phone := "+60123456789"
 
-	token, err := service.GenerateAccessToken(userID, phone)
+	token, err := service.GenerateAccessToken(userID, phone, uuid.New())
 	if err != nil {
 		t.Fatalf("GenerateAccessToken() error = %v", err)
 	}
@@ -26,8 +28,9 @@ func TestJWTTokenService_ValidateAccessToken(t *testing.T) {
 	service := NewJWTTokenService("test-secret-key-32-chars-long!!", 15*time.Minute)
 	userID := uuid.New()
 	phone := "+60123456789"
+	sessionID := uuid.New()
 
-	token, _ := service.GenerateAccessToken(userID, phone)
+	token, _ := service.GenerateAccessToken(userID, phone, sessionID)
 
 	claims, err := service.ValidateAccessToken(token)
 	if err != nil {
@@ -40,6 +43,76 @@ func TestJWTTokenService_ValidateAccessToken(t *testing.T) {
 	if claims.Phone != phone {
 		t.Errorf("claims.Phone = %v, want %v", claims.Phone, phone)
 	}
+	if claims.SessionID != sessionID {
+		t.Errorf("claims.SessionID = %v, want %v", claims.SessionID, sessionID)
+	}
+}
+
+func TestJWTTokenService_ScopedAccessToken(t *testing.T) {
+	service := NewJWTTokenService("test-secret-key-32-chars-long!!", 15*time.Minute)
+	scope := ports.TokenScope{Role: "provider_admin", ProviderID: uuid.New()}
+
+	token, err := service.GenerateScopedAccessToken(uuid.New(), "+60123456789", uuid.New(), scope)
+	if err != nil {
+		t.Fatalf("GenerateScopedAccessToken() error = %v", err)
+	}
+	claims, err := service.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+	if claims.Role != scope.Role || claims.ProviderID != scope.ProviderID {
+		t.Errorf("claims scope = %q %v, want %q %v", claims.Role, claims.ProviderID, scope.Role, scope.ProviderID)
+	}
+
+	token, _ = service.GenerateAccessToken(uuid.New(), "+60123456789", uuid.New())
+	claims, _ = service.ValidateAccessToken(token)
+	if claims.Role != "" || claims.ProviderID != uuid.Nil {
+		t.Errorf("unscoped token has scope %q %v", claims.Role, claims.ProviderID)
+	}
+}
+
+func TestJWTTokenService_GuestToken(t *testing.T) {
+	service := NewJWTTokenService("test-secret-key-32-chars-long!!", 15*time.Minute)
+	guestID := uuid.New()
+
+	token, err := service.GenerateGuestToken(guestID, "device-1234", 2*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateGuestToken() error = %v", err)
+	}
+	claims, err := service.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken() error = %v", err)
+	}
+	if claims.UserID != guestID || claims.Role != "guest" || claims.DeviceID != "device-1234" {
+		t.Errorf("claims = %q %v %q, want guest %v device-1234", claims.Role, claims.UserID, claims.DeviceID, guestID)
+	}
+	if claims.SessionID != uuid.Nil {
+		t.Errorf("claims.SessionID = %v, want none", claims.SessionID)
+	}
+	if ttl := claims.ExpiresAt.Sub(claims.IssuedAt); ttl != 2*time.Hour {
+		t.Errorf("token lifetime = %v, want 2h", ttl)
+	}
+}
+
+func TestJWTTokenService_TokenIDIsUnique(t *testing.T) {
+	service := NewJWTTokenService("test-secret-key-32-chars-long!!", 15*time.Minute)
+	userID, sessionID := uuid.New(), uuid.New()
+
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		token, _ := service.GenerateAccessToken(userID, "+60123456789", sessionID)
+		claims, err := service.ValidateAccessToken(token)
+		if err != nil {
+			t.Fatalf("ValidateAccessToken() error = %v", err)
+		}
+		if claims.TokenID == "" {
+			t.Fatal("expected a jti claim")
+		}
+		if seen[claims.TokenID] {
+			t.Errorf("jti %s issued twice", claims.TokenID)
+		}
+		seen[claims.TokenID] = true
+	}
 }
 
 func TestJWTTokenService_ValidateExpiredToken(t *testing.T) {
@@ -47,7 +120,7 @@ func TestJWTTokenService_ValidateExpiredToken(t *testing.T) {
 	service := NewJWTTokenService("test-secret-key-32-chars-long!!", 1*time.Millisecond)
 	userID := uuid.New()
 
-	token, _ := service.GenerateAccessToken(userID, "+60123456789")
+	token, _ := service.GenerateAccessToken(userID, "+60123456789", uuid.New())
 
 	// Wait for token to expire
 	time.Sleep(10 * time.Millisecond)
@@ -71,7 +144,7 @@ func TestJWTTokenService_ValidateTokenWithWrongSecret(t *testing.T) {
 	service1 := NewJWTTokenService("secret-key-one-32-chars-long!!!", 15*time.Minute)
 	service2 := NewJWTTokenService("secret-key-two-32-chars-long!!!", 15*time.Minute)
 
-	token, _ := service1.GenerateAccessToken(uuid.New(), "+60123456789")
+	token, _ := service1.GenerateAccessToken(uuid.New(), "+60123456789", uuid.New())
 
 	_, err := service2.ValidateAccessToken(token)
 	if err == nil {
@@ -127,3 +200,96 @@ func TestJWTTokenService_HashRefreshToken(t *testing.T) {
 		t.Errorf("hash length = %d, want 64", len(hash1))
 	}
 }
+
+func TestKeyRingTokenService_SignsAndValidates(t *testing.T) {
+	for _, alg := range []string{"RS256", "EdDSA"} {
+		t.Run(alg, func(t *testing.T) {
+			signer, err := GenerateSigningKey(alg)
+			if err != nil {
+				t.Fatalf("GenerateSigningKey() error = %v", err)
+			}
+			ring, err := NewKeyRing(signer)
+			if err != nil {
+				t.Fatalf("NewKeyRing() error = %v", err)
+			}
+			service := NewKeyRingTokenService(ring, 15*time.Minute)
+			userID := uuid.New()
+
+			token, err := service.GenerateAccessToken(userID, "+60123456789", uuid.New())
+			if err != nil {
+				t.Fatalf("GenerateAccessToken() error = %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Header["alg"] != alg || parsed.Header["kid"] != ring.JWKS().Keys[0].Kid {
+				t.Errorf("header = %v, want alg %s and the key's kid", parsed.Header, alg)
+			}
+
+			claims, err := service.ValidateAccessToken(token)
+			if err != nil {
+				t.Fatalf("ValidateAccessToken() error = %v", err)
+			}
+			if claims.UserID != userID {
+				t.Errorf("claims.UserID = %v, want %v", claims.UserID, userID)
+			}
+		})
+	}
+}
+
+func TestKeyRingTokenService_Rotation(t *testing.T) {
+	oldKey, _ := GenerateSigningKey("RS256")
+	newKey, _ := GenerateSigningKey("EdDSA")
+
+	oldRing, _ := NewKeyRing(oldKey)
+	oldToken, _ := NewKeyRingTokenService(oldRing, 15*time.Minute).GenerateAccessToken(uuid.New(), "+60123456789", uuid.New())
+
+	rotated, err := NewKeyRing(newKey, oldKey.Public())
+	if err != nil {
+		t.Fatalf("NewKeyRing() error = %v", err)
+	}
+	if n := len(rotated.JWKS().Keys); n != 2 {
+		t.Errorf("JWKS has %d keys, want 2", n)
+	}
+	if _, err := NewKeyRingTokenService(rotated, 15*time.Minute).ValidateAccessToken(oldToken); err != nil {
+		t.Errorf("token signed with the previous key should validate, got %v", err)
+	}
+
+	// Once the old key is dropped its tokens are rejected
+	dropped, _ := NewKeyRing(newKey)
+	if _, err := NewKeyRingTokenService(dropped, 15*time.Minute).ValidateAccessToken(oldToken); err == nil {
+		t.Error("token signed with a dropped key should fail validation")
+	}
+}
+
+func TestKeyRingTokenService_RejectsHMACTokens(t *testing.T) {
+	signer, _ := GenerateSigningKey("EdDSA")
+	ring, _ := NewKeyRing(signer)
+	service := NewKeyRingTokenService(ring, 15*time.Minute)
+
+	hmacToken, _ := NewJWTTokenService("test-secret-key-32-chars-long!!", 15*time.Minute).
+		GenerateAccessToken(uuid.New(), "+60123456789", uuid.New())
+	if _, err := service.ValidateAccessToken(hmacToken); err == nil {
+		t.Error("HS256 token should fail validation once tokens are signed asymmetrically")
+	}
+}
+
+func TestParsePrivateKeyPEM_RoundTrip(t *testing.T) {
+	signer, _ := GenerateSigningKey("EdDSA")
+	data, err := EncodePrivateKeyPEM(signer)
+	if err != nil {
+		t.Fatalf("EncodePrivateKeyPEM() error = %v", err)
+	}
+
+	parsed, err := ParsePrivateKeyPEM(data)
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyPEM() error = %v", err)
+	}
+	a, _ := NewKeyRing(signer)
+	b, _ := NewKeyRing(parsed)
+	if a.JWKS().Keys[0].Kid != b.JWKS().Keys[0].Kid {
+		t.Error("parsed key should have the same kid as the original")
+	}
+}
@@ -0,0 +1,195 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WhatsApp senders implement ports.SMSService so the application layer
+// can treat WhatsApp as just another channel for reaching a phone number.
+//
+// WHY WHATSAPP?
+// =============
+// Many Malaysian users prefer WhatsApp to SMS. SMS delivery through
+// local telcos can also be slow or filtered, while WhatsApp messages
+// arrive over data. SMS is still kept as the fallback channel.
+
+// ConsoleWhatsAppService is a mock WhatsApp sender that logs messages.
+// Use this for development and testing.
+type ConsoleWhatsAppService struct{}
+
+// NewConsoleWhatsAppService creates a new console WhatsApp service.
+func NewConsoleWhatsAppService() *ConsoleWhatsAppService {
+	return &ConsoleWhatsAppService{}
+}
+
+// SendOTP logs the OTP to console instead of sending a WhatsApp message.
+func (s *ConsoleWhatsAppService) SendOTP(ctx context.Context, phone, code string) error {
+	log.Printf("[WHATSAPP] Sending OTP %s to %s", code, phone)
+	return nil
+}
+
+// SendMessage logs the message to console.
+func (s *ConsoleWhatsAppService) SendMessage(ctx context.Context, phone, message string) error {
+	log.Printf("[WHATSAPP] Sending message to %s: %s", phone, message)
+	return nil
+}
+
+// DefaultMetaGraphURL is the base URL of the Meta Graph API.
+const DefaultMetaGraphURL = "https://graph.facebook.com"
+
+// MetaWhatsAppService sends messages through the WhatsApp Business
+// Cloud API hosted by Meta.
+//
+// SETUP:
+// 1. Create a Meta app with the WhatsApp product enabled
+// 2. Register a business phone number and note its Phone Number ID
+// 3. Create a permanent system user access token
+// 4. Create and get approval for an "Authentication" message template
+//
+// IMPORTANT: Templates vs Free-form Messages
+// ==========================================
+// WhatsApp only allows businesses to START a conversation with a
+// pre-approved template. OTPs must therefore use an authentication
+// template. Free-form text (SendMessage) is only delivered if the user
+// has messaged us in the last 24 hours.
+type MetaWhatsAppService struct {
+	accessToken   string
+	phoneNumberID string
+	templateName  string
+	languageCode  string
+	apiVersion    string
+	baseURL       string
+	client        *http.Client
+}
+
+// NewMetaWhatsAppService creates a new WhatsApp Cloud API sender.
+func NewMetaWhatsAppService(accessToken, phoneNumberID, templateName, languageCode, apiVersion string) *MetaWhatsAppService {
+	return &MetaWhatsAppService{
+		accessToken:   accessToken,
+		phoneNumberID: phoneNumberID,
+		templateName:  templateName,
+		languageCode:  languageCode,
+		apiVersion:    apiVersion,
+		baseURL:       DefaultMetaGraphURL,
+		// Fail fast so the caller can fall back to SMS quickly
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// whatsAppMessage is the request body for the Cloud API messages endpoint.
+type whatsAppMessage struct {
+	MessagingProduct string            `json:"messaging_product"`
+	To               string            `json:"to"`
+	Type             string            `json:"type"`
+	Template         *whatsAppTemplate `json:"template,omitempty"`
+	Text             *whatsAppText     `json:"text,omitempty"`
+}
+
+type whatsAppTemplate struct {
+	Name       string              `json:"name"`
+	Language   whatsAppLanguage    `json:"language"`
+	Components []whatsAppComponent `json:"components"`
+}
+
+type whatsAppLanguage struct {
+	Code string `json:"code"`
+}
+
+type whatsAppComponent struct {
+	Type       string              `json:"type"`
+	SubType    string              `json:"sub_type,omitempty"`
+	Index      string              `json:"index,omitempty"`
+	Parameters []whatsAppParameter `json:"parameters"`
+}
+
+type whatsAppParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type whatsAppText struct {
+	Body string `json:"body"`
+}
+
+// SendOTP sends an OTP using the configured authentication template.
+//
+// Authentication templates take the code twice: once for the message
+// body and once for the "copy code" button.
+func (s *MetaWhatsAppService) SendOTP(ctx context.Context, phone, code string) error {
+	return s.send(ctx, whatsAppMessage{
+		MessagingProduct: "whatsapp",
+		To:               toWhatsAppNumber(phone),
+		Type:             "template",
+		Template: &whatsAppTemplate{
+			Name:     s.templateName,
+			Language: whatsAppLanguage{Code: s.languageCode},
+			Components: []whatsAppComponent{
+				{
+					Type:       "body",
+					Parameters: []whatsAppParameter{{Type: "text", Text: code}},
+				},
+				{
+					Type:       "button",
+					SubType:    "url",
+					Index:      "0",
+					Parameters: []whatsAppParameter{{Type: "text", Text: code}},
+				},
+			},
+		},
+	})
+}
+
+// SendMessage sends a free-form text message.
+func (s *MetaWhatsAppService) SendMessage(ctx context.Context, phone, message string) error {
+	return s.send(ctx, whatsAppMessage{
+		MessagingProduct: "whatsapp",
+		To:               toWhatsAppNumber(phone),
+		Type:             "text",
+		Text:             &whatsAppText{Body: message},
+	})
+}
+
+// send posts a message to the Cloud API.
+// Any non-2xx response is treated as a delivery failure.
+func (s *MetaWhatsAppService) send(ctx context.Context, msg whatsAppMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode WhatsApp message: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/%s/%s/messages", s.baseURL, s.apiVersion, s.phoneNumberID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create WhatsApp request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send WhatsApp message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		// The error body explains why (e.g. the number isn't on WhatsApp)
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("WhatsApp API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	return nil
+}
+
+// toWhatsAppNumber converts +60123456789 into 60123456789.
+// The Cloud API expects the number in international format without "+".
+func toWhatsAppNumber(phone string) string {
+	return strings.TrimPrefix(phone, "+")
+}
@@ -0,0 +1,68 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetaWhatsAppService_SendOTP(t *testing.T) {
+	var got whatsAppMessage
+	var gotPath, gotAuth string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"messages":[{"id":"wamid.1"}]}`))
+	}))
+	defer server.Close()
+
+	svc := NewMetaWhatsAppService("token", "12345", "otp_verification", "en", "v19.0")
+	svc.baseURL = server.URL
+
+	if err := svc.SendOTP(context.Background(), "+60123456789", "654321"); err != nil {
+		t.Fatalf("SendOTP() error = %v", err)
+	}
+
+	if gotPath != "/v19.0/12345/messages" {
+		t.Errorf("path = %s, want /v19.0/12345/messages", gotPath)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %s, want Bearer token", gotAuth)
+	}
+	if got.To != "60123456789" {
+		t.Errorf("to = %s, want 60123456789", got.To)
+	}
+	if got.Type != "template" || got.Template == nil {
+		t.Fatalf("expected a template message, got type %s", got.Type)
+	}
+	if got.Template.Name != "otp_verification" {
+		t.Errorf("template = %s, want otp_verification", got.Template.Name)
+	}
+	for _, c := range got.Template.Components {
+		if len(c.Parameters) != 1 || c.Parameters[0].Text != "654321" {
+			t.Errorf("component %s does not carry the OTP code", c.Type)
+		}
+	}
+}
+
+func TestMetaWhatsAppService_SendOTP_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"message":"Recipient phone number not in allowed list"}}`))
+	}))
+	defer server.Close()
+
+	svc := NewMetaWhatsAppService("token", "12345", "otp_verification", "en", "v19.0")
+	svc.baseURL = server.URL
+
+	if err := svc.SendOTP(context.Background(), "+60123456789", "654321"); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
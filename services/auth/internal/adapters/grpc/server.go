@@ -0,0 +1,97 @@
+// Package grpc serves the auth gRPC API to other services.
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	authv1 "github.com/parking-super-app/pkg/proto/auth/v1"
+	"github.com/parking-super-app/services/auth/internal/application"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthServiceServer implements authv1.AuthServiceServer. Only step-up and
+// PIN checks are served so far; the user lookups answer Unimplemented.
+type AuthServiceServer struct {
+	authv1.UnimplementedAuthServiceServer
+	authService *application.AuthService
+}
+
+// NewAuthServiceServer creates a new gRPC server for the auth service
+func NewAuthServiceServer(authService *application.AuthService) *AuthServiceServer {
+	return &AuthServiceServer{authService: authService}
+}
+
+// CreateStepUpChallenge sends the user an OTP to confirm an action
+func (s *AuthServiceServer) CreateStepUpChallenge(ctx context.Context, req *authv1.CreateStepUpChallengeRequest) (*authv1.StepUpChallenge, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	challenge, err := s.authService.CreateStepUpChallenge(ctx, userID, req.Action, req.Amount)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &authv1.StepUpChallenge{
+		ChallengeId: challenge.ChallengeID.String(),
+		Method:      challenge.Method,
+		ExpiresAt:   challenge.ExpiresAt.Unix(),
+	}, nil
+}
+
+// RedeemStepUpToken checks and uses up a step-up token
+func (s *AuthServiceServer) RedeemStepUpToken(ctx context.Context, req *authv1.RedeemStepUpTokenRequest) (*authv1.RedeemStepUpTokenResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	challengeID, err := s.authService.RedeemStepUpToken(ctx, req.Token, userID, req.Action, req.Amount)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &authv1.RedeemStepUpTokenResponse{ChallengeId: challengeID.String()}, nil
+}
+
+// VerifyPIN checks the user's transaction PIN
+func (s *AuthServiceServer) VerifyPIN(ctx context.Context, req *authv1.VerifyPINRequest) (*authv1.VerifyPINResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	if err := s.authService.VerifyPIN(ctx, userID, req.Pin); err != nil {
+		return nil, toStatus(err)
+	}
+	return &authv1.VerifyPINResponse{}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return status.Error(codes.NotFound, "user not found")
+	case errors.Is(err, domain.ErrChallengeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrUserInactive):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrStepUpActionRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPINNotSet):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrPINIncorrect):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrTooManyAttempts), errors.Is(err, domain.ErrPINLocked):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrChallengeMismatch),
+		errors.Is(err, domain.ErrChallengeExpired), errors.Is(err, domain.ErrChallengeUsed):
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
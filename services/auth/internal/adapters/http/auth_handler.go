@@ -2,12 +2,13 @@ package http
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/auth/internal/application"
 	"github.com/parking-super-app/services/auth/internal/domain"
 	"github.com/parking-super-app/services/auth/internal/ports"
@@ -19,6 +20,13 @@ type contextKey string
 const (
 	// UserIDKey is the context key for the authenticated user's ID.
 	UserIDKey contextKey = "user_id"
+
+	// SessionIDKey is the context key for the session (refresh token ID)
+	// the access token was issued with. uuid.Nil for older tokens.
+	SessionIDKey contextKey = "session_id"
+
+	// ClaimsKey is the context key for the access token's claims.
+	ClaimsKey contextKey = "claims"
 )
 
 // AuthHandler handles HTTP requests for authentication endpoints.
@@ -41,31 +49,10 @@ func (h *AuthHandler) SetTokenService(ts ports.TokenService) {
 }
 
 // ---- Response Helpers ----
-// These functions help create consistent JSON responses.
-
-// APIResponse is the standard response format.
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   *APIError   `json:"error,omitempty"`
-}
-
-// APIError represents an error in the API response.
-type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-// writeJSON writes a JSON response with the given status code.
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: status >= 200 && status < 300,
-		Data:    data,
-	})
-}
-
-// writeError writes an error response.
+// Responses are written with pkg/httpx, shared by every service:
+// - httpx.WriteJSON wraps data in the {success, data} envelope
+// - httpx.WriteError writes an RFC 7807 problem with request and trace IDs
+// - httpx.DecodeJSON bounds the request body size before decoding
 //
 // BEST PRACTICE: Error Responses
 // ==============================
@@ -73,16 +60,6 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 // - Include error codes for programmatic handling
 // - Don't expose internal errors to clients
 // - Log detailed errors server-side
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: false,
-		Error: &APIError{
-			Code:    code,
-			Message: message,
-		},
-	})
-}
 
 // mapDomainError maps domain errors to HTTP status codes and error codes.
 func mapDomainError(err error) (int, string, string) {
@@ -101,12 +78,102 @@ func mapDomainError(err error) (int, string, string) {
 		return http.StatusBadRequest, "WEAK_PASSWORD", "Password must be at least 8 characters"
 	case errors.Is(err, domain.ErrUserInactive):
 		return http.StatusForbidden, "USER_INACTIVE", "Your account is inactive"
+	case errors.Is(err, domain.ErrUserAlreadyBanned):
+		return http.StatusConflict, "USER_ALREADY_BANNED", "User is already banned"
+	case errors.Is(err, domain.ErrUserNotBanned):
+		return http.StatusConflict, "USER_NOT_BANNED", "User is not banned"
 	case errors.Is(err, domain.ErrTokenExpired):
 		return http.StatusUnauthorized, "TOKEN_EXPIRED", "Token has expired"
 	case errors.Is(err, domain.ErrTokenRevoked):
 		return http.StatusUnauthorized, "TOKEN_REVOKED", "Token has been revoked"
 	case errors.Is(err, domain.ErrInvalidToken):
 		return http.StatusUnauthorized, "INVALID_TOKEN", "Invalid token"
+	case errors.Is(err, domain.ErrTooManyAttempts):
+		return http.StatusTooManyRequests, "TOO_MANY_ATTEMPTS", "Too many attempts. Please try again later"
+	case errors.Is(err, domain.ErrInvalidOTPChannel):
+		return http.StatusBadRequest, "INVALID_OTP_CHANNEL", "OTP channel must be sms or whatsapp"
+	case errors.Is(err, domain.ErrSessionNotFound):
+		return http.StatusNotFound, "SESSION_NOT_FOUND", "Session not found"
+	case errors.Is(err, domain.ErrInvalidSocialProvider):
+		return http.StatusBadRequest, "INVALID_SOCIAL_PROVIDER", "Social login provider is not supported"
+	case errors.Is(err, domain.ErrInvalidIDToken):
+		return http.StatusUnauthorized, "INVALID_ID_TOKEN", "ID token could not be verified"
+	case errors.Is(err, domain.ErrPhoneRequired):
+		return http.StatusUnprocessableEntity, "PHONE_REQUIRED", "A phone number is required to create your account"
+	case errors.Is(err, domain.ErrIdentityAlreadyLinked):
+		return http.StatusConflict, "IDENTITY_LINKED", "This social account is already linked"
+	case errors.Is(err, domain.ErrPhoneUnchanged):
+		return http.StatusBadRequest, "PHONE_UNCHANGED", "This is already your phone number"
+	case errors.Is(err, domain.ErrDeletionNotFound):
+		return http.StatusNotFound, "DELETION_NOT_FOUND", "No account deletion request found"
+	case errors.Is(err, domain.ErrDeletionPending):
+		return http.StatusConflict, "DELETION_PENDING", "Account deletion has already been requested"
+	case errors.Is(err, domain.ErrDeletionNotPending):
+		return http.StatusConflict, "DELETION_NOT_PENDING", "Account deletion can no longer be cancelled"
+	case errors.Is(err, domain.ErrInvalidICNumber):
+		return http.StatusBadRequest, "INVALID_IC_NUMBER", "Invalid MyKad number"
+	case errors.Is(err, domain.ErrSelfieRequired):
+		return http.StatusBadRequest, "SELFIE_REQUIRED", "A selfie is required"
+	case errors.Is(err, domain.ErrKYCAlreadyVerified):
+		return http.StatusConflict, "KYC_ALREADY_VERIFIED", "Your identity is already verified"
+	case errors.Is(err, domain.ErrKYCPending):
+		return http.StatusConflict, "KYC_PENDING", "Your verification is already under review"
+	case errors.Is(err, domain.ErrKYCNotPending):
+		return http.StatusConflict, "KYC_NOT_PENDING", "Submission has already been reviewed"
+	case errors.Is(err, domain.ErrKYCSubmissionNotFound):
+		return http.StatusNotFound, "KYC_SUBMISSION_NOT_FOUND", "Verification submission not found"
+	case errors.Is(err, domain.ErrRejectionReasonRequired):
+		return http.StatusBadRequest, "REASON_REQUIRED", "A reason is required"
+	case errors.Is(err, domain.ErrDeviceIDRequired):
+		return http.StatusBadRequest, "DEVICE_ID_REQUIRED", "A device ID is required to sign in"
+	case errors.Is(err, domain.ErrDeviceNotFound):
+		return http.StatusNotFound, "DEVICE_NOT_FOUND", "Device not found"
+	case errors.Is(err, domain.ErrChallengeNotFound):
+		return http.StatusNotFound, "CHALLENGE_NOT_FOUND", "Verification request not found"
+	case errors.Is(err, domain.ErrChallengeExpired):
+		return http.StatusGone, "CHALLENGE_EXPIRED", "Verification request has expired. Please start again"
+	case errors.Is(err, domain.ErrChallengeNotPending):
+		return http.StatusConflict, "CHALLENGE_ALREADY_VERIFIED", "Verification request has already been confirmed"
+	case errors.Is(err, domain.ErrInvalidPIN):
+		return http.StatusBadRequest, "INVALID_PIN", "PIN must be 6 digits"
+	case errors.Is(err, domain.ErrWeakPIN):
+		return http.StatusBadRequest, "WEAK_PIN", "Choose a PIN that isn't a repeated digit or a sequence"
+	case errors.Is(err, domain.ErrPINNotSet):
+		return http.StatusNotFound, "PIN_NOT_SET", "Set a transaction PIN first"
+	case errors.Is(err, domain.ErrPINAlreadySet):
+		return http.StatusConflict, "PIN_ALREADY_SET", "A transaction PIN is already set"
+	case errors.Is(err, domain.ErrPINIncorrect):
+		return http.StatusUnauthorized, "PIN_INCORRECT", "Incorrect PIN"
+	case errors.Is(err, domain.ErrPINLocked):
+		return http.StatusLocked, "PIN_LOCKED", "Too many incorrect PINs. Try again later or reset your PIN"
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return http.StatusNotFound, "ORGANIZATION_NOT_FOUND", "Organization not found"
+	case errors.Is(err, domain.ErrInvalidOrganization):
+		return http.StatusBadRequest, "INVALID_ORGANIZATION", "Organization name is required"
+	case errors.Is(err, domain.ErrNotOrganizationOwner):
+		return http.StatusForbidden, "NOT_ORGANIZATION_OWNER", "Only the organization owner can do this"
+	case errors.Is(err, domain.ErrMemberNotFound):
+		return http.StatusNotFound, "MEMBER_NOT_FOUND", "Member not found"
+	case errors.Is(err, domain.ErrAlreadyMember):
+		return http.StatusConflict, "ALREADY_MEMBER", "User is already a member or invited"
+	case errors.Is(err, domain.ErrInvitationNotFound):
+		return http.StatusNotFound, "INVITATION_NOT_FOUND", "Invitation not found"
+	case errors.Is(err, domain.ErrCannotRemoveOwner):
+		return http.StatusConflict, "CANNOT_REMOVE_OWNER", "The organization owner cannot be removed"
+	case errors.Is(err, domain.ErrInvalidProviderRole):
+		return http.StatusBadRequest, "INVALID_PROVIDER_ROLE", "Role must be provider_admin or provider_viewer"
+	case errors.Is(err, domain.ErrInvalidProviderID):
+		return http.StatusBadRequest, "INVALID_PROVIDER_ID", "Provider ID is required"
+	case errors.Is(err, domain.ErrProviderStaffNotFound):
+		return http.StatusNotFound, "PROVIDER_STAFF_NOT_FOUND", "User is not provider staff"
+	case errors.Is(err, domain.ErrAdminRequired):
+		return http.StatusForbidden, "FORBIDDEN", "This action requires the admin role"
+	case errors.Is(err, domain.ErrInvalidDeviceID):
+		return http.StatusBadRequest, "INVALID_DEVICE_ID", "Device ID must be 8 to 128 characters"
+	case errors.Is(err, domain.ErrGuestCheckoutDisabled):
+		return http.StatusNotFound, "GUEST_CHECKOUT_DISABLED", "Guest checkout is not available"
+	case errors.Is(err, domain.ErrReferralCodeNotFound):
+		return http.StatusBadRequest, "INVALID_REFERRAL_CODE", "Referral code not found"
 	default:
 		return http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred"
 	}
@@ -121,30 +188,34 @@ func mapDomainError(err error) (int, string, string) {
 // Response: { "success": true, "data": { "user_id": "...", "message": "..." } }
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req application.RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	resp, err := h.authService.Register(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 // Login handles user login.
 //
 // POST /api/v1/auth/login
-// Request: { "phone": "+60123456789", "password": "..." }
+// Request: { "phone": "+60123456789", "password": "...", "device_id": "...", "device_fingerprint": "..." }
 // Response: { "success": true, "data": { "access_token": "...", "refresh_token": "...", "expires_in": 900 } }
+//
+// When the device hasn't been confirmed, the response is 202 with
+// device_verification_required set and no tokens. An OTP has been sent;
+// the client completes the sign-in with POST /login/device.
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req application.LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
@@ -155,11 +226,63 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.authService.Login(r.Context(), req, userAgent, ipAddress)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+	if resp.DeviceVerificationRequired {
+		httpx.WriteJSON(w, http.StatusAccepted, resp)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// VerifyDevice completes a sign-in from a new device.
+//
+// POST /api/v1/auth/login/device
+// Request: { "phone": "+60123456789", "code": "123456", "device_id": "...", "device_fingerprint": "..." }
+// Response: { "success": true, "data": { "access_token": "...", "refresh_token": "...", "expires_in": 900 } }
+func (h *AuthHandler) VerifyDevice(w http.ResponseWriter, r *http.Request) {
+	var req application.VerifyDeviceRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.VerifyDevice(r.Context(), req, r.Header.Get("User-Agent"), r.RemoteAddr)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// SocialLogin handles sign-in with a Google or Apple ID token.
+//
+// POST /api/v1/auth/social/login
+// Request: { "provider": "google", "id_token": "...", "phone": "+60123456789", "full_name": "..." }
+// Response: { "success": true, "data": { "access_token": "...", "refresh_token": "...", "is_new_user": false, ... } }
+//
+// phone is only needed the first time, when the social account can't be
+// matched to an existing user. Clients get PHONE_REQUIRED (422) in that
+// case and should prompt for a phone number and retry.
+func (h *AuthHandler) SocialLogin(w http.ResponseWriter, r *http.Request) {
+	var req application.SocialLoginRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.SocialLogin(r.Context(), req, r.Header.Get("User-Agent"), r.RemoteAddr)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 // RefreshToken handles token refresh.
@@ -169,8 +292,8 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 // Response: { "success": true, "data": { "access_token": "...", "refresh_token": "...", "expires_in": 900 } }
 func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	var req application.RefreshTokenRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
@@ -180,11 +303,33 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.authService.RefreshToken(r.Context(), req.RefreshToken, userAgent, ipAddress)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// Guest issues a short-lived token for parking without an account.
+//
+// POST /api/v1/auth/guest
+// Request: { "device_id": "..." }
+// Response: { "success": true, "data": { "access_token": "...", "expires_in": 7200, "guest_id": "..." } }
+func (h *AuthHandler) Guest(w http.ResponseWriter, r *http.Request) {
+	var req application.GuestRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.StartGuest(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 // RequestOTP handles OTP request.
@@ -194,15 +339,15 @@ func (h *AuthHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
 // Response: { "success": true, "data": { "message": "OTP sent" } }
 func (h *AuthHandler) RequestOTP(w http.ResponseWriter, r *http.Request) {
 	var req application.RequestOTPRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	// Always return success to prevent phone enumeration attacks
 	_ = h.authService.RequestOTP(r.Context(), req)
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
 		"message": "If the phone number is registered, an OTP has been sent",
 	})
 }
@@ -214,17 +359,17 @@ func (h *AuthHandler) RequestOTP(w http.ResponseWriter, r *http.Request) {
 // Response: { "success": true, "data": { "message": "Phone verified" } }
 func (h *AuthHandler) VerifyOTP(w http.ResponseWriter, r *http.Request) {
 	var req application.VerifyOTPRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	if err := h.authService.VerifyOTP(r.Context(), req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_OTP", "Invalid or expired OTP")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_OTP", "Invalid or expired OTP")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
 		"message": "Phone number verified successfully",
 	})
 }
@@ -238,16 +383,17 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	var req struct {
 		RefreshToken string `json:"refresh_token"`
 	}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
-	if err := h.authService.Logout(r.Context(), req.RefreshToken); err != nil {
+	claims, _ := r.Context().Value(ClaimsKey).(*ports.AccessTokenClaims)
+	if err := h.authService.Logout(r.Context(), req.RefreshToken, claims); err != nil {
 		// Log but don't fail - user should be logged out regardless
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
 		"message": "Logged out successfully",
 	})
 }
@@ -261,11 +407,11 @@ func (h *AuthHandler) LogoutAllDevices(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.authService.LogoutAllDevices(r.Context(), userID); err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
 		"message": "Logged out from all devices",
 	})
 }
@@ -280,11 +426,646 @@ func (h *AuthHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	profile, err := h.authService.GetProfile(r.Context(), userID)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// UpdateProfile handles changing the user's name and email.
+//
+// PATCH /api/v1/auth/me (requires authentication)
+// Request: { "full_name": "...", "email": "..." } (either field may be left out)
+// Response: { "success": true, "data": { "id": "...", "full_name": "...", ... } }
+func (h *AuthHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.UpdateProfileRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	profile, err := h.authService.UpdateProfile(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// RequestPhoneChange handles sending an OTP to a new phone number.
+//
+// POST /api/v1/auth/me/phone (requires authentication)
+// Request: { "phone": "+60198765432" }
+// Response: { "success": true, "data": { "message": "OTP sent" } }
+func (h *AuthHandler) RequestPhoneChange(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.PhoneChangeRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.authService.RequestPhoneChange(r.Context(), userID, req); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"message": "OTP sent"})
+}
+
+// ConfirmPhoneChange handles moving the account to the new phone number.
+//
+// POST /api/v1/auth/me/phone/verify (requires authentication)
+// Request: { "phone": "+60198765432", "code": "123456" }
+// Response: { "success": true, "data": { "id": "...", "phone": "+60198765432", ... } }
+func (h *AuthHandler) ConfirmPhoneChange(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.ConfirmPhoneChangeRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	profile, err := h.authService.ConfirmPhoneChange(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// RequestDeletionOTP handles sending the OTP that confirms account deletion.
+//
+// POST /api/v1/auth/me/deletion/otp (requires authentication)
+// Response: { "success": true, "data": { "message": "OTP sent" } }
+func (h *AuthHandler) RequestDeletionOTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	if err := h.authService.RequestDeletionOTP(r.Context(), userID); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"message": "OTP sent"})
+}
+
+// RequestDeletion handles scheduling the account for deletion.
+//
+// POST /api/v1/auth/me/deletion (requires authentication)
+// Request: { "code": "123456" }
+// Response: { "success": true, "data": { "id": "...", "status": "pending", "scheduled_for": "...", ... } }
+func (h *AuthHandler) RequestDeletion(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.ConfirmDeletionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	deletion, err := h.authService.RequestDeletion(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusAccepted, deletion)
+}
+
+// GetDeletionStatus handles reading the user's latest deletion request.
+//
+// GET /api/v1/auth/me/deletion (requires authentication)
+// Response: { "success": true, "data": { "id": "...", "status": "pending", ... } }
+func (h *AuthHandler) GetDeletionStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	deletion, err := h.authService.GetDeletionStatus(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, deletion)
+}
+
+// CancelDeletion handles withdrawing a pending deletion request.
+//
+// DELETE /api/v1/auth/me/deletion (requires authentication)
+// Response: { "success": true, "data": { "id": "...", "status": "cancelled", ... } }
+func (h *AuthHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	deletion, err := h.authService.CancelDeletion(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, deletion)
+}
+
+// SubmitKYC handles submitting the user's identity documents for review.
+//
+// POST /api/v1/auth/me/kyc (requires authentication)
+// Request: { "ic_number": "900101-14-5678", "selfie_ref": "..." }
+// Response: { "success": true, "data": { "kyc_level": "basic", "status": "pending", ... } }
+func (h *AuthHandler) SubmitKYC(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.SubmitKYCRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.SubmitKYC(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusAccepted, resp)
+}
+
+// GetKYCStatus handles reading the user's KYC level and latest submission.
+//
+// GET /api/v1/auth/me/kyc (requires authentication)
+// Response: { "success": true, "data": { "kyc_level": "verified", "status": "approved", ... } }
+func (h *AuthHandler) GetKYCStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	resp, err := h.authService.GetKYCStatus(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// UpdateOTPChannel handles changing the user's preferred OTP channel.
+//
+// PUT /api/v1/auth/me/otp-channel (requires authentication)
+// Request: { "channel": "whatsapp" }
+// Response: { "success": true, "data": { "id": "...", "preferred_otp_channel": "whatsapp", ... } }
+func (h *AuthHandler) UpdateOTPChannel(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.UpdateOTPChannelRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	profile, err := h.authService.UpdateOTPChannel(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// ListSessions handles listing the user's signed-in devices.
+//
+// GET /api/v1/auth/sessions (requires authentication)
+// Response: { "success": true, "data": [ { "id": "...", "user_agent": "...", "ip_address": "...", "current": true, ... } ] }
+func (h *AuthHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	sessionID, _ := r.Context().Value(SessionIDKey).(uuid.UUID)
+
+	sessions, err := h.authService.ListSessions(r.Context(), userID, sessionID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, sessions)
+}
+
+// RevokeSession handles signing out a single device.
+//
+// DELETE /api/v1/auth/sessions/{id} (requires authentication)
+// Response: { "success": true }
+func (h *AuthHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid session ID format")
+		return
+	}
+
+	if err := h.authService.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Session revoked",
+	})
+}
+
+// GetReferralCode handles returning the code the user shares with friends.
+//
+// GET /api/v1/auth/me/referral-code (requires authentication)
+// Response: { "success": true, "data": { "user_id": "...", "code": "K7QM2XRP", "created_at": "..." } }
+func (h *AuthHandler) GetReferralCode(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	referral, err := h.authService.GetReferralCode(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, referral)
+}
+
+// ListDevices handles listing the devices the user has confirmed.
+//
+// GET /api/v1/auth/me/devices (requires authentication)
+// Response: { "success": true, "data": [ { "id": "...", "device_id": "...", "name": "...", "last_seen_at": "..." } ] }
+func (h *AuthHandler) ListDevices(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	devices, err := h.authService.ListDevices(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, devices)
+}
+
+// ForgetDevice handles removing a confirmed device.
+//
+// DELETE /api/v1/auth/me/devices/{id} (requires authentication)
+// Response: { "success": true }
+func (h *AuthHandler) ForgetDevice(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid device ID format")
+		return
+	}
+
+	if err := h.authService.ForgetDevice(r.Context(), userID, id); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Device removed",
+	})
+}
+
+// VerifyStepUp confirms a step-up challenge with the OTP sent to the user.
+//
+// POST /api/v1/auth/step-up/{id}/verify (requires authentication)
+// Request: { "code": "123456" }
+// Response: { "success": true, "data": { "step_up_token": "...", "expires_at": "..." } }
+//
+// The token goes to the service that asked for the challenge, e.g. in the
+// X-Step-Up-Token header of the wallet payment.
+func (h *AuthHandler) VerifyStepUp(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	challengeID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid challenge ID format")
+		return
+	}
+
+	var req application.VerifyStepUpRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.authService.VerifyStepUp(r.Context(), userID, challengeID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetPINStatus handles checking whether the user has a transaction PIN.
+//
+// GET /api/v1/auth/me/pin (requires authentication)
+// Response: { "success": true, "data": { "set": true, "locked_until": "..." } }
+func (h *AuthHandler) GetPINStatus(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	resp, err := h.authService.GetPINStatus(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// SetPIN handles setting the user's first transaction PIN.
+//
+// POST /api/v1/auth/me/pin (requires authentication)
+// Request: { "pin": "482915" }
+func (h *AuthHandler) SetPIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.SetPINRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.authService.SetPIN(r.Context(), userID, req); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, map[string]string{
+		"message": "PIN set",
+	})
+}
+
+// ChangePIN handles replacing the PIN with the current one.
+//
+// PUT /api/v1/auth/me/pin (requires authentication)
+// Request: { "current_pin": "482915", "new_pin": "907153" }
+func (h *AuthHandler) ChangePIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.ChangePINRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.authService.ChangePIN(r.Context(), userID, req); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "PIN changed",
+	})
+}
+
+// VerifyPIN handles checking the PIN, e.g. before showing card details.
+//
+// POST /api/v1/auth/me/pin/verify (requires authentication)
+// Request: { "pin": "482915" }
+func (h *AuthHandler) VerifyPIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.VerifyPINRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.authService.VerifyPIN(r.Context(), userID, req.PIN); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "PIN verified",
+	})
+}
+
+// RequestPINResetOTP handles sending the OTP for a PIN reset.
+//
+// POST /api/v1/auth/me/pin/reset/otp (requires authentication)
+func (h *AuthHandler) RequestPINResetOTP(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	if err := h.authService.RequestPINResetOTP(r.Context(), userID); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "OTP sent",
+	})
+}
+
+// ResetPIN handles replacing a forgotten or locked PIN.
+//
+// POST /api/v1/auth/me/pin/reset (requires authentication)
+// Request: { "code": "123456", "new_pin": "907153" }
+func (h *AuthHandler) ResetPIN(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.ResetPINRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	if err := h.authService.ResetPIN(r.Context(), userID, req); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "PIN reset",
+	})
+}
+
+// CreateOrganization handles creating an organization owned by the caller.
+//
+// POST /api/v1/auth/orgs (requires authentication)
+// Request: { "name": "Acme Logistics" }
+func (h *AuthHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	var req application.CreateOrganizationRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	org, err := h.authService.CreateOrganization(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, org)
+}
+
+// ListOrganizations handles listing the caller's organizations and invitations.
+//
+// GET /api/v1/auth/orgs (requires authentication)
+func (h *AuthHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+
+	memberships, err := h.authService.ListMyOrganizations(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, memberships)
+}
+
+// ListMembers handles listing an organization's members.
+//
+// GET /api/v1/auth/orgs/{id}/members (requires authentication)
+func (h *AuthHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	orgID, ok := orgIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	members, err := h.authService.ListMembers(r.Context(), userID, orgID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, members)
+}
+
+// InviteMember handles the owner inviting a user by phone number.
+//
+// POST /api/v1/auth/orgs/{id}/members (requires authentication)
+// Request: { "phone": "+60123456789" }
+func (h *AuthHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	orgID, ok := orgIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.InviteMemberRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	member, err := h.authService.InviteMember(r.Context(), userID, orgID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, member)
+}
+
+// RemoveMember handles the owner removing a member, or a member leaving.
+//
+// DELETE /api/v1/auth/orgs/{id}/members/{userID} (requires authentication)
+func (h *AuthHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	orgID, ok := orgIDParam(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid user ID format")
+		return
+	}
+
+	if err := h.authService.RemoveMember(r.Context(), userID, orgID, memberID); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Member removed",
+	})
+}
+
+// AcceptInvitation handles joining an organization that invited the caller.
+//
+// POST /api/v1/auth/orgs/{id}/accept (requires authentication)
+func (h *AuthHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	orgID, ok := orgIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	member, err := h.authService.AcceptInvitation(r.Context(), userID, orgID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, member)
+}
+
+// DeclineInvitation handles turning down an invitation.
+//
+// POST /api/v1/auth/orgs/{id}/decline (requires authentication)
+func (h *AuthHandler) DeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	userID := r.Context().Value(UserIDKey).(uuid.UUID)
+	orgID, ok := orgIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.DeclineInvitation(r.Context(), userID, orgID); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, profile)
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{
+		"message": "Invitation declined",
+	})
+}
+
+func orgIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid organization ID format")
+		return uuid.Nil, false
+	}
+	return id, true
 }
 
 // ---- Middleware ----
@@ -302,32 +1083,43 @@ func (h *AuthHandler) AuthMiddleware(next http.Handler) http.Handler {
 		// Get Authorization header
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			writeError(w, http.StatusUnauthorized, "MISSING_TOKEN", "Authorization header required")
+			httpx.WriteError(w, r, http.StatusUnauthorized, "MISSING_TOKEN", "Authorization header required")
 			return
 		}
 
 		// Extract token from "Bearer <token>"
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			writeError(w, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid authorization format")
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid authorization format")
 			return
 		}
 		token := parts[1]
 
 		// Validate token
 		if h.tokenService == nil {
-			writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Token service not configured")
+			httpx.WriteError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "Token service not configured")
 			return
 		}
 
 		claims, err := h.tokenService.ValidateAccessToken(token)
 		if err != nil {
-			writeError(w, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid or expired token")
+			httpx.WriteError(w, r, http.StatusUnauthorized, "INVALID_TOKEN", "Invalid or expired token")
+			return
+		}
+		// Guests have no account to manage
+		if claims.Role == domain.RoleGuest {
+			httpx.WriteError(w, r, http.StatusForbidden, "GUEST_NOT_ALLOWED", "Sign in or register to continue")
+			return
+		}
+		if h.authService.IsAccessTokenRevoked(r.Context(), claims) {
+			httpx.WriteError(w, r, http.StatusUnauthorized, "TOKEN_REVOKED", "Token has been revoked")
 			return
 		}
 
-		// Add user ID to context
+		// Add user ID and session ID to context
 		ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, SessionIDKey, claims.SessionID)
+		ctx = context.WithValue(ctx, ClaimsKey, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
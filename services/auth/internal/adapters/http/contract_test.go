@@ -0,0 +1,18 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	"github.com/parking-super-app/pkg/jwks"
+)
+
+// TestContracts verifies the routes that other services rely on
+func TestContracts(t *testing.T) {
+	router := NewRouter(nil, nil, nil)
+	router.ServeJWKS(jwks.Set{}) // As main does for asymmetric signing keys
+	contract.Verify(t, contract.Provider{
+		Name:   "auth",
+		Routes: router.router,
+	})
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/auth/internal/application"
+)
+
+// KYCAdminHandler serves the back-office review of KYC submissions. The
+// gateway authenticates the reviewer and passes their ID in X-User-ID.
+type KYCAdminHandler struct {
+	authService *application.AuthService
+}
+
+func NewKYCAdminHandler(authService *application.AuthService) *KYCAdminHandler {
+	return &KYCAdminHandler{authService: authService}
+}
+
+// List handles listing submissions for review.
+//
+// GET /api/v1/admin/kyc?status=pending&limit=50&offset=0
+func (h *KYCAdminHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	submissions, err := h.authService.ListKYCSubmissions(r.Context(), r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, submissions)
+}
+
+// Get handles reading a submission in full, including the IC number.
+//
+// GET /api/v1/admin/kyc/{id}
+func (h *KYCAdminHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, ok := submissionID(w, r)
+	if !ok {
+		return
+	}
+
+	submission, err := h.authService.GetKYCSubmission(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, submission)
+}
+
+// Approve handles verifying the user behind a submission.
+//
+// POST /api/v1/admin/kyc/{id}/approve
+func (h *KYCAdminHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, ok := submissionID(w, r)
+	if !ok {
+		return
+	}
+
+	submission, err := h.authService.ApproveKYC(r.Context(), id, r.Header.Get("X-User-ID"))
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, submission)
+}
+
+// Reject handles declining a submission.
+//
+// POST /api/v1/admin/kyc/{id}/reject
+// Request: { "reason": "Selfie does not match the MyKad photo" }
+func (h *KYCAdminHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	id, ok := submissionID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.RejectKYCRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	submission, err := h.authService.RejectKYC(r.Context(), id, r.Header.Get("X-User-ID"), req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, submission)
+}
+
+func submissionID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_SUBMISSION_ID", "Invalid submission ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
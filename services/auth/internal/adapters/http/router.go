@@ -16,6 +16,11 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/jwks"
 	"github.com/parking-super-app/services/auth/internal/application"
 	"github.com/parking-super-app/services/auth/internal/ports"
 )
@@ -24,7 +29,9 @@ import (
 type Router struct {
 	authService  *application.AuthService
 	tokenService ports.TokenService
+	auditStore   audit.Store
 	router       chi.Router
+	handler      http.Handler
 }
 
 // NewRouter creates a new HTTP router with all routes configured.
@@ -36,19 +43,27 @@ type Router struct {
 // - Compatible with net/http
 // - Has great middleware support
 // - Easy to test
-func NewRouter(authService *application.AuthService, tokenService ports.TokenService) *Router {
+func NewRouter(authService *application.AuthService, tokenService ports.TokenService, auditStore audit.Store) *Router {
 	r := &Router{
 		authService:  authService,
 		tokenService: tokenService,
+		auditStore:   auditStore,
 		router:       chi.NewRouter(),
 	}
 
 	r.setupMiddleware()
 	r.setupRoutes()
+	r.handler = r.router
 
 	return r
 }
 
+// Use wraps the router with additional middleware (e.g. tracing).
+// Unlike chi's Use, this can be called after routes are registered.
+func (r *Router) Use(mw func(http.Handler) http.Handler) {
+	r.handler = mw(r.handler)
+}
+
 // setupMiddleware configures global middleware.
 //
 // MICROSERVICES PATTERN: Cross-Cutting Concerns
@@ -67,22 +82,25 @@ func (r *Router) setupMiddleware() {
 	// RealIP extracts the real client IP from X-Forwarded-For
 	r.router.Use(middleware.RealIP)
 
+	// Actor and client IP for the audit log
+	r.router.Use(actor.Middleware)
+	r.router.Use(audit.Middleware)
+
 	// Logger logs the start and end of each request
-	r.router.Use(middleware.Logger)
 
 	// Recoverer catches panics and returns 500 instead of crashing
 	r.router.Use(middleware.Recoverer)
 
+	// Stop work on requests the gateway has given up on
+	r.router.Use(deadline.FromHeader)
+
 	// Content-Type enforcement
 	r.router.Use(middleware.AllowContentType("application/json"))
 
-	// Set response content type
-	r.router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			next.ServeHTTP(w, req)
-		})
-	})
+	// Security headers (nosniff, no framing) and a JSON content type
+	// on every response
+	r.router.Use(httpx.SecurityHeaders)
+	r.router.Use(httpx.JSONContentType)
 }
 
 // setupRoutes configures all HTTP routes.
@@ -101,7 +119,10 @@ func (r *Router) setupRoutes() {
 		// Public routes (no authentication required)
 		router.Post("/register", handler.Register)
 		router.Post("/login", handler.Login)
+		router.Post("/login/device", handler.VerifyDevice)
+		router.Post("/social/login", handler.SocialLogin)
 		router.Post("/refresh", handler.RefreshToken)
+		router.Post("/guest", handler.Guest)
 		router.Post("/otp/request", handler.RequestOTP)
 		router.Post("/otp/verify", handler.VerifyOTP)
 
@@ -110,11 +131,62 @@ func (r *Router) setupRoutes() {
 			protected.Use(handler.AuthMiddleware)
 
 			protected.Get("/me", handler.GetProfile)
+			protected.Patch("/me", handler.UpdateProfile)
+			protected.Post("/me/phone", handler.RequestPhoneChange)
+			protected.Post("/me/phone/verify", handler.ConfirmPhoneChange)
+			protected.Post("/me/deletion/otp", handler.RequestDeletionOTP)
+			protected.Post("/me/deletion", handler.RequestDeletion)
+			protected.Get("/me/deletion", handler.GetDeletionStatus)
+			protected.Delete("/me/deletion", handler.CancelDeletion)
+			protected.Post("/me/kyc", handler.SubmitKYC)
+			protected.Get("/me/kyc", handler.GetKYCStatus)
+			protected.Get("/me/referral-code", handler.GetReferralCode)
+			protected.Get("/me/devices", handler.ListDevices)
+			protected.Delete("/me/devices/{id}", handler.ForgetDevice)
+			protected.Get("/me/pin", handler.GetPINStatus)
+			protected.Post("/me/pin", handler.SetPIN)
+			protected.Put("/me/pin", handler.ChangePIN)
+			protected.Post("/me/pin/verify", handler.VerifyPIN)
+			protected.Post("/me/pin/reset/otp", handler.RequestPINResetOTP)
+			protected.Post("/me/pin/reset", handler.ResetPIN)
+			protected.Post("/step-up/{id}/verify", handler.VerifyStepUp)
+			protected.Post("/orgs", handler.CreateOrganization)
+			protected.Get("/orgs", handler.ListOrganizations)
+			protected.Get("/orgs/{id}/members", handler.ListMembers)
+			protected.Post("/orgs/{id}/members", handler.InviteMember)
+			protected.Delete("/orgs/{id}/members/{userID}", handler.RemoveMember)
+			protected.Post("/orgs/{id}/accept", handler.AcceptInvitation)
+			protected.Post("/orgs/{id}/decline", handler.DeclineInvitation)
+			protected.Put("/me/otp-channel", handler.UpdateOTPChannel)
 			protected.Post("/logout", handler.Logout)
 			protected.Post("/logout/all", handler.LogoutAllDevices)
+			protected.Get("/sessions", handler.ListSessions)
+			protected.Delete("/sessions/{id}", handler.RevokeSession)
 		})
 	})
 
+	// Admin routes: the gateway authenticates the reviewer, and only tokens
+	// with the admin role get through
+	kycAdmin := NewKYCAdminHandler(r.authService)
+	r.router.Route("/api/v1/admin/kyc", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Get("/", kycAdmin.List)
+		router.Route("/audit", audit.NewHandler(r.auditStore).Routes)
+		router.Get("/{id}", kycAdmin.Get)
+		router.Post("/{id}/approve", kycAdmin.Approve)
+		router.Post("/{id}/reject", kycAdmin.Reject)
+	})
+
+	userAdmin := NewUserAdminHandler(r.authService)
+	r.router.Route("/api/v1/admin/users", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/{id}/ban", userAdmin.Ban)
+		router.Delete("/{id}/ban", userAdmin.Unban)
+		router.Get("/{id}/provider-role", userAdmin.GetProviderRole)
+		router.Put("/{id}/provider-role", userAdmin.GrantProviderRole)
+		router.Delete("/{id}/provider-role", userAdmin.RevokeProviderRole)
+	})
+
 	// Health check endpoint (for Kubernetes probes)
 	r.router.Get("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -129,8 +201,18 @@ func (r *Router) setupRoutes() {
 	})
 }
 
+// ServeJWKS publishes the public keys access tokens are signed with, for
+// the gateway to verify them.
+func (r *Router) ServeJWKS(set jwks.Set) {
+	r.router.Get("/.well-known/jwks.json", func(w http.ResponseWriter, req *http.Request) {
+		// Clients refetch on an unknown kid, so a short cache is safe
+		w.Header().Set("Cache-Control", "public, max-age=300")
+		httpx.WriteJSON(w, http.StatusOK, set)
+	})
+}
+
 // ServeHTTP implements http.Handler interface.
 // This allows our Router to be used with standard http.Server.
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
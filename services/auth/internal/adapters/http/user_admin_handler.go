@@ -0,0 +1,138 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/auth/internal/application"
+)
+
+// UserAdminHandler serves back-office account actions. Only platform admins
+// get through: the gateway and the router both check the admin role, and
+// the service records the acting admin.
+type UserAdminHandler struct {
+	authService *application.AuthService
+}
+
+func NewUserAdminHandler(authService *application.AuthService) *UserAdminHandler {
+	return &UserAdminHandler{authService: authService}
+}
+
+// Ban handles blocking a user and ending their sessions.
+//
+// POST /api/v1/admin/users/{id}/ban
+// Request: { "reason": "Chargeback fraud" }
+func (h *UserAdminHandler) Ban(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.BanUserRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	profile, err := h.authService.BanUser(r.Context(), id, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// Unban handles lifting a ban.
+//
+// DELETE /api/v1/admin/users/{id}/ban
+func (h *UserAdminHandler) Unban(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	profile, err := h.authService.UnbanUser(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, profile)
+}
+
+// GetProviderRole returns the provider the user works for and their role.
+//
+// GET /api/v1/admin/users/{id}/provider-role
+func (h *UserAdminHandler) GetProviderRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	staff, err := h.authService.GetProviderRole(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, staff)
+}
+
+// GrantProviderRole makes the user staff of a parking provider.
+//
+// PUT /api/v1/admin/users/{id}/provider-role
+// Request: { "provider_id": "...", "role": "provider_admin" }
+func (h *UserAdminHandler) GrantProviderRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.ProviderRoleRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	staff, err := h.authService.GrantProviderRole(r.Context(), id, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, staff)
+}
+
+// RevokeProviderRole removes the user from their provider's staff.
+//
+// DELETE /api/v1/admin/users/{id}/provider-role
+func (h *UserAdminHandler) RevokeProviderRole(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.authService.RevokeProviderRole(r.Context(), id); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func pathUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// DeletionRepository implements ports.DeletionRepository using PostgreSQL.
+type DeletionRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeletionRepository creates a new PostgreSQL deletion request repository.
+func NewDeletionRepository(db *pgxpool.Pool) *DeletionRepository {
+	return &DeletionRepository{db: db}
+}
+
+const deletionColumns = `id, user_id, status, requested_at, scheduled_for, cancelled_at, completed_at`
+
+// Create stores a new request. The partial unique index allows only one
+// pending request per user.
+func (r *DeletionRepository) Create(ctx context.Context, d *domain.DeletionRequest) error {
+	query := `INSERT INTO account_deletion_requests (` + deletionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err := r.db.Exec(ctx, query, d.ID, d.UserID, d.Status, d.RequestedAt, d.ScheduledFor, d.CancelledAt, d.CompletedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrDeletionPending
+		}
+		return fmt.Errorf("failed to insert deletion request: %w", err)
+	}
+	return nil
+}
+
+// GetLatestByUserID returns the user's most recent request.
+func (r *DeletionRepository) GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.DeletionRequest, error) {
+	query := `
+		SELECT ` + deletionColumns + `
+		FROM account_deletion_requests
+		WHERE user_id = $1
+		ORDER BY requested_at DESC
+		LIMIT 1
+	`
+	d, err := scanDeletion(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeletionNotFound
+		}
+		return nil, fmt.Errorf("failed to get deletion request: %w", err)
+	}
+	return d, nil
+}
+
+// Cancel withdraws a pending request. It returns ErrDeletionNotPending if
+// the request was completed or cancelled in the meantime.
+func (r *DeletionRepository) Cancel(ctx context.Context, d *domain.DeletionRequest) error {
+	query := `
+		UPDATE account_deletion_requests
+		SET status = $2, cancelled_at = $3
+		WHERE id = $1 AND status = 'pending'
+	`
+	result, err := r.db.Exec(ctx, query, d.ID, d.Status, d.CancelledAt)
+	if err != nil {
+		return fmt.Errorf("failed to cancel deletion request: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDeletionNotPending
+	}
+	return nil
+}
+
+// ListDue returns pending requests whose grace period ended by now, oldest first.
+func (r *DeletionRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.DeletionRequest, error) {
+	query := `
+		SELECT ` + deletionColumns + `
+		FROM account_deletion_requests
+		WHERE status = 'pending' AND scheduled_for <= $1
+		ORDER BY scheduled_for
+		LIMIT $2
+	`
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due deletion requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*domain.DeletionRequest
+	for rows.Next() {
+		d, err := scanDeletion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deletion request: %w", err)
+		}
+		requests = append(requests, d)
+	}
+	return requests, rows.Err()
+}
+
+// Erase completes the request and wipes the user's personal data in one
+// transaction: the anonymized user row is saved, and linked social
+// identities, refresh tokens, registered devices (which hold device and
+// IP details), the transaction PIN and KYC submissions are deleted. It
+// returns ErrDeletionNotPending if another instance got there first or the
+// user cancelled.
+func (r *DeletionRepository) Erase(ctx context.Context, d *domain.DeletionRequest, user *domain.User) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE account_deletion_requests
+		SET status = $2, completed_at = $3
+		WHERE id = $1 AND status = 'pending'
+	`, d.ID, d.Status, d.CompletedAt)
+	if err != nil {
+		return fmt.Errorf("failed to complete deletion request: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDeletionNotPending
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE users
+		SET phone = $2, email = $3, password_hash = $4, full_name = $5, status = $6, kyc_level = $7, updated_at = $8
+		WHERE id = $1
+	`, user.ID, user.Phone, user.Email, user.PasswordHash, user.FullName, user.Status, user.KYC(), user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM user_identities WHERE user_id = $1`, user.ID); err != nil {
+		return fmt.Errorf("failed to delete identities: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, user.ID); err != nil {
+		return fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM kyc_submissions WHERE user_id = $1`, user.ID); err != nil {
+		return fmt.Errorf("failed to delete KYC submissions: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM user_devices WHERE user_id = $1`, user.ID); err != nil {
+		return fmt.Errorf("failed to delete devices: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM transaction_pins WHERE user_id = $1`, user.ID); err != nil {
+		return fmt.Errorf("failed to delete transaction PIN: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit erasure: %w", err)
+	}
+	return nil
+}
+
+func scanDeletion(row pgx.Row) (*domain.DeletionRequest, error) {
+	d := &domain.DeletionRequest{}
+	err := row.Scan(&d.ID, &d.UserID, &d.Status, &d.RequestedAt, &d.ScheduledFor, &d.CancelledAt, &d.CompletedAt)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
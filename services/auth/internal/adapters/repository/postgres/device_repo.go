@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// DeviceRepository implements ports.DeviceRepository using PostgreSQL.
+type DeviceRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewDeviceRepository creates a new PostgreSQL device repository.
+func NewDeviceRepository(db *pgxpool.Pool) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+const deviceColumns = `id, user_id, device_id, fingerprint_hash, name, last_ip, first_seen_at, last_seen_at`
+
+// Get returns the user's device with a client device ID.
+func (r *DeviceRepository) Get(ctx context.Context, userID uuid.UUID, deviceID string) (*domain.Device, error) {
+	query := `SELECT ` + deviceColumns + ` FROM user_devices WHERE user_id = $1 AND device_id = $2`
+
+	d, err := scanDevice(r.db.QueryRow(ctx, query, userID, deviceID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDeviceNotFound
+		}
+		return nil, fmt.Errorf("failed to get device: %w", err)
+	}
+	return d, nil
+}
+
+// Save inserts the device or, for a device ID the user already has,
+// replaces its fingerprint and sign-in details. The original ID and
+// first_seen_at are kept.
+func (r *DeviceRepository) Save(ctx context.Context, d *domain.Device) error {
+	query := `
+		INSERT INTO user_devices (` + deviceColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (user_id, device_id) DO UPDATE
+		SET fingerprint_hash = EXCLUDED.fingerprint_hash, name = EXCLUDED.name,
+			last_ip = EXCLUDED.last_ip, last_seen_at = EXCLUDED.last_seen_at
+	`
+	_, err := r.db.Exec(ctx, query,
+		d.ID, d.UserID, d.DeviceID, d.FingerprintHash, d.Name, d.LastIP, d.FirstSeenAt, d.LastSeenAt)
+	if err != nil {
+		return fmt.Errorf("failed to save device: %w", err)
+	}
+	return nil
+}
+
+// ListByUserID returns the user's devices, most recently seen first.
+func (r *DeviceRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	query := `SELECT ` + deviceColumns + ` FROM user_devices WHERE user_id = $1 ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*domain.Device
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan device: %w", err)
+		}
+		devices = append(devices, d)
+	}
+	return devices, rows.Err()
+}
+
+// Delete forgets one of the user's devices.
+func (r *DeviceRepository) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM user_devices WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete device: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDeviceNotFound
+	}
+	return nil
+}
+
+func scanDevice(row pgx.Row) (*domain.Device, error) {
+	d := &domain.Device{}
+	err := row.Scan(&d.ID, &d.UserID, &d.DeviceID, &d.FingerprintHash, &d.Name, &d.LastIP, &d.FirstSeenAt, &d.LastSeenAt)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// IdentityRepository implements ports.IdentityRepository using PostgreSQL.
+type IdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewIdentityRepository creates a new IdentityRepository.
+func NewIdentityRepository(db *pgxpool.Pool) *IdentityRepository {
+	return &IdentityRepository{db: db}
+}
+
+// Create links a social identity to a user.
+func (r *IdentityRepository) Create(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		INSERT INTO user_identities (id, user_id, provider, subject, email, created_at, last_login_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		identity.ID,
+		identity.UserID,
+		identity.Provider,
+		identity.Subject,
+		identity.Email,
+		identity.CreatedAt,
+		identity.LastLoginAt,
+	)
+	if err != nil {
+		// (provider, subject) is unique - a concurrent sign-in already linked it
+		if isUniqueViolation(err) {
+			return domain.ErrIdentityAlreadyLinked
+		}
+		return fmt.Errorf("failed to insert identity: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderSubject finds the identity for a provider's user ID.
+func (r *IdentityRepository) GetByProviderSubject(ctx context.Context, provider domain.SocialProvider, subject string) (*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE provider = $1 AND subject = $2
+	`
+
+	identity, err := scanIdentity(r.db.QueryRow(ctx, query, provider, subject))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrIdentityNotFound
+		}
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+	return identity, nil
+}
+
+// GetByUserID lists all identities linked to a user.
+func (r *IdentityRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserIdentity, error) {
+	query := `
+		SELECT id, user_id, provider, subject, email, created_at, last_login_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query identities: %w", err)
+	}
+	defer rows.Close()
+
+	var identities []*domain.UserIdentity
+	for rows.Next() {
+		identity, err := scanIdentity(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan identity: %w", err)
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// Update saves the identity's latest email and login time.
+func (r *IdentityRepository) Update(ctx context.Context, identity *domain.UserIdentity) error {
+	query := `
+		UPDATE user_identities
+		SET email = $2, last_login_at = $3
+		WHERE id = $1
+	`
+
+	result, err := r.db.Exec(ctx, query, identity.ID, identity.Email, identity.LastLoginAt)
+	if err != nil {
+		return fmt.Errorf("failed to update identity: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrIdentityNotFound
+	}
+
+	return nil
+}
+
+func scanIdentity(row pgx.Row) (*domain.UserIdentity, error) {
+	identity := &domain.UserIdentity{}
+	err := row.Scan(
+		&identity.ID,
+		&identity.UserID,
+		&identity.Provider,
+		&identity.Subject,
+		&identity.Email,
+		&identity.CreatedAt,
+		&identity.LastLoginAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
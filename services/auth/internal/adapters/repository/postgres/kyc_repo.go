@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// KYCRepository implements ports.KYCRepository using PostgreSQL.
+type KYCRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewKYCRepository creates a new PostgreSQL KYC submission repository.
+func NewKYCRepository(db *pgxpool.Pool) *KYCRepository {
+	return &KYCRepository{db: db}
+}
+
+const kycColumns = `id, user_id, ic_number, selfie_ref, status, reason, reviewed_by, submitted_at, reviewed_at`
+
+// Create stores a new submission. The partial unique index allows only one
+// pending submission per user.
+func (r *KYCRepository) Create(ctx context.Context, k *domain.KYCSubmission) error {
+	query := `INSERT INTO kyc_submissions (` + kycColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(ctx, query,
+		k.ID, k.UserID, k.ICNumber, k.SelfieRef, k.Status, k.Reason, k.ReviewedBy, k.SubmittedAt, k.ReviewedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrKYCPending
+		}
+		return fmt.Errorf("failed to insert KYC submission: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a submission.
+func (r *KYCRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.KYCSubmission, error) {
+	query := `SELECT ` + kycColumns + ` FROM kyc_submissions WHERE id = $1`
+	return r.getOne(ctx, query, id)
+}
+
+// GetLatestByUserID returns the user's most recent submission.
+func (r *KYCRepository) GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.KYCSubmission, error) {
+	query := `
+		SELECT ` + kycColumns + `
+		FROM kyc_submissions
+		WHERE user_id = $1
+		ORDER BY submitted_at DESC
+		LIMIT 1
+	`
+	return r.getOne(ctx, query, userID)
+}
+
+func (r *KYCRepository) getOne(ctx context.Context, query string, arg interface{}) (*domain.KYCSubmission, error) {
+	k, err := scanKYC(r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrKYCSubmissionNotFound
+		}
+		return nil, fmt.Errorf("failed to get KYC submission: %w", err)
+	}
+	return k, nil
+}
+
+// ListByStatus returns submissions in a status, oldest first.
+func (r *KYCRepository) ListByStatus(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]*domain.KYCSubmission, error) {
+	query := `
+		SELECT ` + kycColumns + `
+		FROM kyc_submissions
+		WHERE status = $1
+		ORDER BY submitted_at
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var submissions []*domain.KYCSubmission
+	for rows.Next() {
+		k, err := scanKYC(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan KYC submission: %w", err)
+		}
+		submissions = append(submissions, k)
+	}
+	return submissions, rows.Err()
+}
+
+// Review saves the reviewed submission and the user's level together, so
+// a user is never verified without an approved submission behind it.
+func (r *KYCRepository) Review(ctx context.Context, k *domain.KYCSubmission, level domain.KYCLevel, now time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, `
+		UPDATE kyc_submissions
+		SET status = $2, reason = $3, reviewed_by = $4, reviewed_at = $5
+		WHERE id = $1 AND status = 'pending'
+	`, k.ID, k.Status, k.Reason, k.ReviewedBy, k.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update KYC submission: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrKYCNotPending
+	}
+
+	// updated_at moves so services holding a copy of the user accept the
+	// new level over older snapshots
+	_, err = tx.Exec(ctx, `
+		UPDATE users SET kyc_level = $2, updated_at = $3
+		WHERE id = $1 AND kyc_level <> $2
+	`, k.UserID, level, now)
+	if err != nil {
+		return fmt.Errorf("failed to update KYC level: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit KYC review: %w", err)
+	}
+	return nil
+}
+
+func scanKYC(row pgx.Row) (*domain.KYCSubmission, error) {
+	k := &domain.KYCSubmission{}
+	err := row.Scan(&k.ID, &k.UserID, &k.ICNumber, &k.SelfieRef, &k.Status, &k.Reason, &k.ReviewedBy, &k.SubmittedAt, &k.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
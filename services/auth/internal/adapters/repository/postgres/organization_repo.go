@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// OrganizationRepository implements ports.OrganizationRepository using PostgreSQL.
+type OrganizationRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewOrganizationRepository creates a new PostgreSQL organization repository.
+func NewOrganizationRepository(db *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const (
+	organizationColumns = `id, name, owner_id, created_at, updated_at`
+	orgMemberColumns    = `org_id, user_id, role, status, invited_by, invited_at, joined_at`
+
+	insertOrgMemberQuery = `INSERT INTO org_members (` + orgMemberColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+)
+
+// Create stores the organization and its owner's membership together.
+func (r *OrganizationRepository) Create(ctx context.Context, org *domain.Organization, owner *domain.OrgMember) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `INSERT INTO organizations (`+organizationColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		org.ID, org.Name, org.OwnerID, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert organization: %w", err)
+	}
+	_, err = tx.Exec(ctx, insertOrgMemberQuery,
+		owner.OrgID, owner.UserID, owner.Role, owner.Status, owner.InvitedBy, owner.InvitedAt, owner.JoinedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert organization owner: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit organization: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns ErrOrganizationNotFound if the organization doesn't exist.
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE id = $1`
+
+	o := &domain.Organization{}
+	err := r.db.QueryRow(ctx, query, id).Scan(&o.ID, &o.Name, &o.OwnerID, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return o, nil
+}
+
+// GetMember returns ErrMemberNotFound if the user isn't a member or invited.
+func (r *OrganizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE org_id = $1 AND user_id = $2`
+
+	m, err := scanOrgMember(r.db.QueryRow(ctx, query, orgID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization member: %w", err)
+	}
+	return m, nil
+}
+
+// AddMember returns ErrAlreadyMember if the user is already a member or invited.
+func (r *OrganizationRepository) AddMember(ctx context.Context, m *domain.OrgMember) error {
+	_, err := r.db.Exec(ctx, insertOrgMemberQuery, m.OrgID, m.UserID, m.Role, m.Status, m.InvitedBy, m.InvitedAt, m.JoinedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyMember
+		}
+		return fmt.Errorf("failed to insert organization member: %w", err)
+	}
+	return nil
+}
+
+// UpdateMember saves a member's role and status.
+func (r *OrganizationRepository) UpdateMember(ctx context.Context, m *domain.OrgMember) error {
+	query := `
+		UPDATE org_members
+		SET role = $3, status = $4, joined_at = $5
+		WHERE org_id = $1 AND user_id = $2
+	`
+	result, err := r.db.Exec(ctx, query, m.OrgID, m.UserID, m.Role, m.Status, m.JoinedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// RemoveMember deletes a membership or declined invitation.
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrMemberNotFound
+	}
+	return nil
+}
+
+// ListMembers returns an organization's members and invitations, oldest first.
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE org_id = $1 ORDER BY invited_at`
+	return r.listMembers(ctx, query, orgID)
+}
+
+// ListByUserID returns the user's memberships and invitations, newest first.
+func (r *OrganizationRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.OrgMember, error) {
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE user_id = $1 ORDER BY invited_at DESC`
+	return r.listMembers(ctx, query, userID)
+}
+
+func (r *OrganizationRepository) listMembers(ctx context.Context, query string, arg uuid.UUID) ([]*domain.OrgMember, error) {
+	rows, err := r.db.Query(ctx, query, arg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*domain.OrgMember
+	for rows.Next() {
+		m, err := scanOrgMember(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan organization member: %w", err)
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func scanOrgMember(row pgx.Row) (*domain.OrgMember, error) {
+	m := &domain.OrgMember{}
+	err := row.Scan(&m.OrgID, &m.UserID, &m.Role, &m.Status, &m.InvitedBy, &m.InvitedAt, &m.JoinedAt)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// PINRepository implements ports.PINRepository using PostgreSQL.
+type PINRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPINRepository creates a new PostgreSQL transaction PIN repository.
+func NewPINRepository(db *pgxpool.Pool) *PINRepository {
+	return &PINRepository{db: db}
+}
+
+const pinColumns = `user_id, pin_hash, failed_attempts, locked_until, created_at, updated_at`
+
+// Get returns the user's PIN, or ErrPINNotSet.
+func (r *PINRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.TransactionPIN, error) {
+	query := `SELECT ` + pinColumns + ` FROM transaction_pins WHERE user_id = $1`
+
+	p, err := scanPIN(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPINNotSet
+		}
+		return nil, fmt.Errorf("failed to get PIN: %w", err)
+	}
+	return p, nil
+}
+
+// Create stores a user's first PIN. Returns ErrPINAlreadySet if they have one.
+func (r *PINRepository) Create(ctx context.Context, p *domain.TransactionPIN) error {
+	query := `INSERT INTO transaction_pins (` + pinColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+
+	_, err := r.db.Exec(ctx, query, p.UserID, p.Hash, p.FailedAttempts, p.LockedUntil, p.CreatedAt, p.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrPINAlreadySet
+		}
+		return fmt.Errorf("failed to insert PIN: %w", err)
+	}
+	return nil
+}
+
+// Update saves a changed or reset PIN.
+func (r *PINRepository) Update(ctx context.Context, p *domain.TransactionPIN) error {
+	query := `
+		UPDATE transaction_pins
+		SET pin_hash = $2, failed_attempts = $3, locked_until = $4, updated_at = $5
+		WHERE user_id = $1
+	`
+	result, err := r.db.Exec(ctx, query, p.UserID, p.Hash, p.FailedAttempts, p.LockedUntil, p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update PIN: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPINNotSet
+	}
+	return nil
+}
+
+// ReserveAttempt counts an attempt in one statement, before the PIN is
+// compared, so concurrent guesses can't all pass the lockout check and
+// then be counted. The attempt that reaches the policy's limit starts the
+// lockout and resets the count; a locked PIN matches no row.
+func (r *PINRepository) ReserveAttempt(ctx context.Context, userID uuid.UUID, policy domain.PINPolicy, now time.Time) (*domain.TransactionPIN, error) {
+	query := `
+		UPDATE transaction_pins
+		SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE NULL END,
+			updated_at = $4
+		WHERE user_id = $1 AND (locked_until IS NULL OR locked_until <= $4)
+		RETURNING ` + pinColumns
+
+	p, err := scanPIN(r.db.QueryRow(ctx, query, userID, policy.MaxAttempts, now.Add(policy.Lockout), now))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, err := r.Get(ctx, userID); err != nil {
+				return nil, err
+			}
+			return nil, domain.ErrPINLocked
+		}
+		return nil, fmt.Errorf("failed to reserve PIN attempt: %w", err)
+	}
+	return p, nil
+}
+
+// ResetFailures clears the attempt count, and the lockout the last allowed
+// attempt started, after a correct PIN.
+func (r *PINRepository) ResetFailures(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE transaction_pins SET failed_attempts = 0, locked_until = NULL
+		WHERE user_id = $1 AND (failed_attempts > 0 OR locked_until IS NOT NULL)
+	`
+	if _, err := r.db.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to reset PIN attempts: %w", err)
+	}
+	return nil
+}
+
+func scanPIN(row pgx.Row) (*domain.TransactionPIN, error) {
+	p := &domain.TransactionPIN{}
+	err := row.Scan(&p.UserID, &p.Hash, &p.FailedAttempts, &p.LockedUntil, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
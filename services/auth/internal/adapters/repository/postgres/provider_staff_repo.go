@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// ProviderStaffRepository implements ports.ProviderStaffRepository using PostgreSQL.
+type ProviderStaffRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewProviderStaffRepository creates a new PostgreSQL provider staff repository.
+func NewProviderStaffRepository(db *pgxpool.Pool) *ProviderStaffRepository {
+	return &ProviderStaffRepository{db: db}
+}
+
+const providerStaffColumns = `user_id, provider_id, role, created_at, updated_at`
+
+// Get returns the user's provider role, or ErrProviderStaffNotFound.
+func (r *ProviderStaffRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.ProviderStaff, error) {
+	query := `SELECT ` + providerStaffColumns + ` FROM provider_staff WHERE user_id = $1`
+
+	s := &domain.ProviderStaff{}
+	err := r.db.QueryRow(ctx, query, userID).Scan(&s.UserID, &s.ProviderID, &s.Role, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrProviderStaffNotFound
+		}
+		return nil, fmt.Errorf("failed to get provider staff: %w", err)
+	}
+	return s, nil
+}
+
+// Save grants the role, replacing any role the user already had.
+func (r *ProviderStaffRepository) Save(ctx context.Context, s *domain.ProviderStaff) error {
+	query := `
+		INSERT INTO provider_staff (` + providerStaffColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET provider_id = EXCLUDED.provider_id, role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+	`
+	if _, err := r.db.Exec(ctx, query, s.UserID, s.ProviderID, s.Role, s.CreatedAt, s.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save provider staff: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the user's provider role. Returns ErrProviderStaffNotFound
+// if they had none.
+func (r *ProviderStaffRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM provider_staff WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete provider staff: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrProviderStaffNotFound
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// ReferralRepository implements ports.ReferralRepository using PostgreSQL.
+type ReferralRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewReferralRepository creates a new PostgreSQL referral repository.
+func NewReferralRepository(db *pgxpool.Pool) *ReferralRepository {
+	return &ReferralRepository{db: db}
+}
+
+// GetCode returns the user's referral code, or ErrReferralCodeNotFound.
+func (r *ReferralRepository) GetCode(ctx context.Context, userID uuid.UUID) (*domain.ReferralCode, error) {
+	return r.getCode(ctx, `SELECT user_id, code, created_at FROM referral_codes WHERE user_id = $1`, userID)
+}
+
+// GetCodeByCode looks up who owns a code, or returns ErrReferralCodeNotFound.
+func (r *ReferralRepository) GetCodeByCode(ctx context.Context, code string) (*domain.ReferralCode, error) {
+	return r.getCode(ctx, `SELECT user_id, code, created_at FROM referral_codes WHERE code = $1`, code)
+}
+
+func (r *ReferralRepository) getCode(ctx context.Context, query string, arg any) (*domain.ReferralCode, error) {
+	c := &domain.ReferralCode{}
+	err := r.db.QueryRow(ctx, query, arg).Scan(&c.UserID, &c.Code, &c.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReferralCodeNotFound
+		}
+		return nil, fmt.Errorf("failed to get referral code: %w", err)
+	}
+	return c, nil
+}
+
+// CreateCode stores a new code. A user who already has one keeps it; a
+// code that collides with another user's returns ErrReferralCodeTaken.
+func (r *ReferralRepository) CreateCode(ctx context.Context, c *domain.ReferralCode) error {
+	query := `
+		INSERT INTO referral_codes (user_id, code, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, query, c.UserID, c.Code, c.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrReferralCodeTaken
+		}
+		return fmt.Errorf("failed to create referral code: %w", err)
+	}
+	return nil
+}
+
+// Create records a referral. The unique referee, phone hash and device
+// columns reject a second referral of any of them.
+func (r *ReferralRepository) Create(ctx context.Context, ref *domain.Referral) error {
+	query := `
+		INSERT INTO referrals (id, referrer_id, referee_id, code, phone_hash, device_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query,
+		ref.ID, ref.ReferrerID, ref.RefereeID, ref.Code, ref.PhoneHash, ref.DeviceID, ref.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrAlreadyReferred
+		}
+		return fmt.Errorf("failed to create referral: %w", err)
+	}
+	return nil
+}
+
+// Seen reports whether the phone or device was referred before, or the
+// device has already signed in to an account.
+func (r *ReferralRepository) Seen(ctx context.Context, phoneHash, deviceID string) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM referrals WHERE phone_hash = $1 OR device_id = $2)
+			OR EXISTS (SELECT 1 FROM user_devices WHERE device_id = $2)
+	`
+	var seen bool
+	if err := r.db.QueryRow(ctx, query, phoneHash, deviceID).Scan(&seen); err != nil {
+		return false, fmt.Errorf("failed to check referral history: %w", err)
+	}
+	return seen, nil
+}
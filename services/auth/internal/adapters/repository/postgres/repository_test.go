@@ -0,0 +1,266 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/testenv"
+	"github.com/parking-super-app/services/auth/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/migrations"
+)
+
+// Run with:
+//
+//	go test -tags integration ./internal/adapters/repository/postgres/...
+//
+// A Postgres container is started unless TEST_POSTGRES_URL is set.
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func newUser(t *testing.T) *domain.User {
+	t.Helper()
+	phone := fmt.Sprintf("+6012%07d", rand.Intn(10000000))
+	user, err := domain.NewUser(phone, "", "Test User", "hash")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	return user
+}
+
+func TestUserRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := postgres.NewUserRepository(testenv.PostgresPool(t, migrations.FS))
+
+	user := newUser(t)
+	user.Email = "driver@example.com"
+	if err := repo.Create(ctx, user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	t.Run("duplicate phone", func(t *testing.T) {
+		dup := newUser(t)
+		dup.Phone = user.Phone
+		if err := repo.Create(ctx, dup); !errors.Is(err, domain.ErrUserAlreadyExists) {
+			t.Errorf("Create() error = %v, want %v", err, domain.ErrUserAlreadyExists)
+		}
+	})
+
+	t.Run("lookups", func(t *testing.T) {
+		byID, err := repo.GetByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if byID.Phone != user.Phone || byID.FullName != user.FullName || byID.Status != user.Status {
+			t.Errorf("GetByID() = %+v, want %+v", byID, user)
+		}
+		if _, err := repo.GetByPhone(ctx, user.Phone); err != nil {
+			t.Errorf("GetByPhone() error = %v", err)
+		}
+		if _, err := repo.GetByEmail(ctx, user.Email); err != nil {
+			t.Errorf("GetByEmail() error = %v", err)
+		}
+		exists, err := repo.ExistsByPhone(ctx, user.Phone)
+		if err != nil || !exists {
+			t.Errorf("ExistsByPhone() = %v, %v, want true", exists, err)
+		}
+		if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("GetByID() of unknown user error = %v, want %v", err, domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		if err := user.UpdateProfile("Renamed", "renamed@example.com"); err != nil {
+			t.Fatalf("UpdateProfile() error = %v", err)
+		}
+		if err := repo.Update(ctx, user); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.FullName != "Renamed" || got.Email != "renamed@example.com" {
+			t.Errorf("after Update() got %q <%s>", got.FullName, got.Email)
+		}
+		if err := repo.Update(ctx, newUser(t)); !errors.Is(err, domain.ErrUserNotFound) {
+			t.Errorf("Update() of unknown user error = %v, want %v", err, domain.ErrUserNotFound)
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		if err := repo.Delete(ctx, user.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		got, err := repo.GetByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Status != domain.UserStatusInactive {
+			t.Errorf("status = %s, want %s", got.Status, domain.UserStatusInactive)
+		}
+	})
+
+	t.Run("list after", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if err := repo.Create(ctx, newUser(t)); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+		first, err := repo.ListAfter(ctx, uuid.Nil, 2)
+		if err != nil {
+			t.Fatalf("ListAfter() error = %v", err)
+		}
+		if len(first) != 2 {
+			t.Fatalf("first page has %d users, want 2", len(first))
+		}
+		rest, err := repo.ListAfter(ctx, first[1].ID, 10)
+		if err != nil {
+			t.Fatalf("ListAfter() error = %v", err)
+		}
+		if len(rest) != 2 {
+			t.Errorf("second page has %d users, want 2", len(rest))
+		}
+	})
+}
+
+func TestRefreshTokenRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	users := postgres.NewUserRepository(pool)
+	repo := postgres.NewRefreshTokenRepository(pool)
+
+	user := newUser(t)
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatalf("Create() user error = %v", err)
+	}
+
+	token := domain.NewRefreshToken(user.ID, "hash-1", "test-agent", "127.0.0.1")
+	if err := repo.Create(ctx, token); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	other := domain.NewRefreshToken(user.ID, "hash-2", "test-agent", "127.0.0.1")
+	if err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	expired := domain.NewRefreshToken(user.ID, "hash-3", "test-agent", "127.0.0.1")
+	expired.ExpiresAt = time.Now().Add(-time.Hour)
+	if err := repo.Create(ctx, expired); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByTokenHash(ctx, "hash-1")
+	if err != nil {
+		t.Fatalf("GetByTokenHash() error = %v", err)
+	}
+	if got.ID != token.ID || got.UserAgent != "test-agent" || got.Revoked {
+		t.Errorf("GetByTokenHash() = %+v", got)
+	}
+	if _, err := repo.GetByTokenHash(ctx, "missing"); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("GetByTokenHash() of unknown hash error = %v, want %v", err, domain.ErrTokenNotFound)
+	}
+
+	active, err := repo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(active) != 2 {
+		t.Errorf("active tokens = %d, want 2", len(active))
+	}
+
+	if err := repo.Revoke(ctx, token.ID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if got, _ := repo.GetByTokenHash(ctx, "hash-1"); !got.Revoked || got.RevokedAt == nil {
+		t.Errorf("token not marked revoked: %+v", got)
+	}
+	if err := repo.Revoke(ctx, uuid.New()); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("Revoke() of unknown token error = %v, want %v", err, domain.ErrTokenNotFound)
+	}
+
+	if err := repo.RevokeAllForUser(ctx, user.ID); err != nil {
+		t.Fatalf("RevokeAllForUser() error = %v", err)
+	}
+	if active, _ := repo.GetByUserID(ctx, user.ID); len(active) != 0 {
+		t.Errorf("active tokens after RevokeAllForUser() = %d, want 0", len(active))
+	}
+
+	if err := repo.DeleteExpired(ctx); err != nil {
+		t.Fatalf("DeleteExpired() error = %v", err)
+	}
+	if _, err := repo.GetByTokenHash(ctx, "hash-3"); !errors.Is(err, domain.ErrTokenNotFound) {
+		t.Errorf("expired token still stored: %v", err)
+	}
+}
+
+func TestPINRepository_ReserveAttemptConcurrently(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	users := postgres.NewUserRepository(pool)
+	repo := postgres.NewPINRepository(pool)
+
+	user := newUser(t)
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatalf("Create() user error = %v", err)
+	}
+	now := time.Now().UTC()
+	if err := repo.Create(ctx, domain.NewTransactionPIN(user.ID, "hash", now)); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// Twenty guesses racing at once must get no more than the policy's
+	// attempts before the PIN locks
+	policy := domain.PINPolicy{MaxAttempts: 5, Lockout: time.Hour}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reserved, locked := 0, 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.ReserveAttempt(ctx, user.ID, policy, now)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				reserved++
+			case errors.Is(err, domain.ErrPINLocked):
+				locked++
+			default:
+				t.Errorf("ReserveAttempt() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if reserved != policy.MaxAttempts || locked != 20-policy.MaxAttempts {
+		t.Errorf("reserved %d and refused %d attempts, want %d and %d", reserved, locked, policy.MaxAttempts, 20-policy.MaxAttempts)
+	}
+
+	// A correct PIN clears the count and the lockout
+	if err := repo.ResetFailures(ctx, user.ID); err != nil {
+		t.Fatalf("ResetFailures() error = %v", err)
+	}
+	pin, err := repo.ReserveAttempt(ctx, user.ID, policy, now)
+	if err != nil {
+		t.Fatalf("ReserveAttempt() after reset error = %v", err)
+	}
+	if pin.FailedAttempts != 1 || pin.IsLocked(now) {
+		t.Errorf("after reset got %d attempts, locked %v, want 1 and unlocked", pin.FailedAttempts, pin.IsLocked(now))
+	}
+
+	if _, err := repo.ReserveAttempt(ctx, uuid.New(), policy, now); !errors.Is(err, domain.ErrPINNotSet) {
+		t.Errorf("ReserveAttempt() without a PIN error = %v, want %v", err, domain.ErrPINNotSet)
+	}
+}
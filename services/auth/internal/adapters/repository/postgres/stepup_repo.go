@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// StepUpRepository implements ports.StepUpRepository using PostgreSQL.
+type StepUpRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewStepUpRepository creates a new PostgreSQL step-up challenge repository.
+func NewStepUpRepository(db *pgxpool.Pool) *StepUpRepository {
+	return &StepUpRepository{db: db}
+}
+
+const challengeColumns = `id, user_id, action, amount, status, created_at, expires_at, verified_at, redeemed_at`
+
+// Create stores a new challenge.
+func (r *StepUpRepository) Create(ctx context.Context, c *domain.StepUpChallenge) error {
+	query := `INSERT INTO step_up_challenges (` + challengeColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err := r.db.Exec(ctx, query,
+		c.ID, c.UserID, c.Action, c.Amount, c.Status, c.CreatedAt, c.ExpiresAt, c.VerifiedAt, c.RedeemedAt)
+	if err != nil {
+		return fmt.Errorf("failed to insert step-up challenge: %w", err)
+	}
+	return nil
+}
+
+func (r *StepUpRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.StepUpChallenge, error) {
+	query := `SELECT ` + challengeColumns + ` FROM step_up_challenges WHERE id = $1`
+
+	c, err := scanChallenge(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrChallengeNotFound
+		}
+		return nil, fmt.Errorf("failed to get step-up challenge: %w", err)
+	}
+	return c, nil
+}
+
+// Verify saves a confirmed challenge. It returns ErrChallengeNotPending if
+// a concurrent request confirmed it first.
+func (r *StepUpRepository) Verify(ctx context.Context, c *domain.StepUpChallenge) error {
+	query := `
+		UPDATE step_up_challenges
+		SET status = $2, verified_at = $3
+		WHERE id = $1 AND status = 'pending'
+	`
+	result, err := r.db.Exec(ctx, query, c.ID, c.Status, c.VerifiedAt)
+	if err != nil {
+		return fmt.Errorf("failed to verify step-up challenge: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrChallengeNotPending
+	}
+	return nil
+}
+
+// Redeem saves a used challenge. It returns ErrChallengeUsed if a
+// concurrent request used the token first.
+func (r *StepUpRepository) Redeem(ctx context.Context, c *domain.StepUpChallenge) error {
+	query := `
+		UPDATE step_up_challenges
+		SET status = $2, redeemed_at = $3
+		WHERE id = $1 AND status = 'verified'
+	`
+	result, err := r.db.Exec(ctx, query, c.ID, c.Status, c.RedeemedAt)
+	if err != nil {
+		return fmt.Errorf("failed to redeem step-up challenge: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrChallengeUsed
+	}
+	return nil
+}
+
+func scanChallenge(row pgx.Row) (*domain.StepUpChallenge, error) {
+	c := &domain.StepUpChallenge{}
+	err := row.Scan(&c.ID, &c.UserID, &c.Action, &c.Amount, &c.Status,
+		&c.CreatedAt, &c.ExpiresAt, &c.VerifiedAt, &c.RedeemedAt)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
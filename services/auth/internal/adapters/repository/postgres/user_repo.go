@@ -52,8 +52,8 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 // - ON CONFLICT DO NOTHING could be used to handle duplicates gracefully
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, phone, email, password_hash, full_name, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, phone, email, password_hash, full_name, status, preferred_otp_channel, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 
 	_, err := r.db.Exec(ctx, query,
@@ -63,6 +63,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 		user.PasswordHash,
 		user.FullName,
 		user.Status,
+		user.OTPChannel(),
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -87,7 +88,7 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 // Make sure the SELECT columns match the Scan arguments exactly.
 func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, phone, email, password_hash, full_name, status, created_at, updated_at
+		SELECT id, phone, email, password_hash, full_name, status, preferred_otp_channel, kyc_level, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -100,6 +101,8 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 		&user.PasswordHash,
 		&user.FullName,
 		&user.Status,
+		&user.PreferredOTPChannel,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -117,7 +120,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Use
 // GetByPhone retrieves a user by their phone number.
 func (r *UserRepository) GetByPhone(ctx context.Context, phone string) (*domain.User, error) {
 	query := `
-		SELECT id, phone, email, password_hash, full_name, status, created_at, updated_at
+		SELECT id, phone, email, password_hash, full_name, status, preferred_otp_channel, kyc_level, created_at, updated_at
 		FROM users
 		WHERE phone = $1
 	`
@@ -130,6 +133,8 @@ func (r *UserRepository) GetByPhone(ctx context.Context, phone string) (*domain.
 		&user.PasswordHash,
 		&user.FullName,
 		&user.Status,
+		&user.PreferredOTPChannel,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -147,7 +152,7 @@ func (r *UserRepository) GetByPhone(ctx context.Context, phone string) (*domain.
 // GetByEmail retrieves a user by their email.
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, phone, email, password_hash, full_name, status, created_at, updated_at
+		SELECT id, phone, email, password_hash, full_name, status, preferred_otp_channel, kyc_level, created_at, updated_at
 		FROM users
 		WHERE email = $1
 	`
@@ -160,6 +165,8 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 		&user.PasswordHash,
 		&user.FullName,
 		&user.Status,
+		&user.PreferredOTPChannel,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -184,7 +191,8 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE users
-		SET phone = $2, email = $3, password_hash = $4, full_name = $5, status = $6, updated_at = $7
+		SET phone = $2, email = $3, password_hash = $4, full_name = $5, status = $6,
+		    preferred_otp_channel = $7, updated_at = $8
 		WHERE id = $1
 	`
 
@@ -195,10 +203,14 @@ func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 		user.PasswordHash,
 		user.FullName,
 		user.Status,
+		user.OTPChannel(),
 		user.UpdatedAt,
 	)
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrUserAlreadyExists
+		}
 		return fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -255,6 +267,45 @@ func (r *UserRepository) ExistsByPhone(ctx context.Context, phone string) (bool,
 	return exists, nil
 }
 
+// ListAfter pages through all users in ID order, starting after afterID.
+// Keyset paging keeps each page cheap however far into the table it is.
+func (r *UserRepository) ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*domain.User, error) {
+	query := `
+		SELECT id, phone, email, password_hash, full_name, status, preferred_otp_channel, kyc_level, created_at, updated_at
+		FROM users
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*domain.User
+	for rows.Next() {
+		user := &domain.User{}
+		if err := rows.Scan(
+			&user.ID,
+			&user.Phone,
+			&user.Email,
+			&user.PasswordHash,
+			&user.FullName,
+			&user.Status,
+			&user.PreferredOTPChannel,
+			&user.KYCLevel,
+			&user.CreatedAt,
+			&user.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
 // isUniqueViolation checks if the error is a PostgreSQL unique constraint violation.
 // PostgreSQL error code 23505 = unique_violation
 func isUniqueViolation(err error) bool {
@@ -284,7 +335,7 @@ func NewUserRepositorySQL(db *sql.DB) *UserRepositorySQL {
 // Implementation similar to above, but uses sql.Row instead of pgx.Row.
 func (r *UserRepositorySQL) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, phone, email, password_hash, full_name, status, created_at, updated_at
+		SELECT id, phone, email, password_hash, full_name, status, preferred_otp_channel, kyc_level, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -297,6 +348,8 @@ func (r *UserRepositorySQL) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		&user.PasswordHash,
 		&user.FullName,
 		&user.Status,
+		&user.PreferredOTPChannel,
+		&user.KYCLevel,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
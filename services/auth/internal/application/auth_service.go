@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/auth/internal/domain"
@@ -42,6 +43,59 @@ type AuthService struct {
 	otpGenerator   ports.OTPGenerator
 	events         ports.EventPublisher
 	logger         ports.Logger
+
+	// whatsAppService is optional. When nil, all OTPs go out via SMS.
+	whatsAppService ports.SMSService
+
+	// Social login is optional. Without verifiers, only phone login works.
+	identities       ports.IdentityRepository
+	idTokenVerifiers map[domain.SocialProvider]ports.IDTokenVerifier
+
+	// Rate limiters are optional. When nil, the action is not limited.
+	otpRequestLimiter ports.RateLimiter
+	loginLimiter      ports.RateLimiter
+
+	// Account deletion is optional. Without a repository it is unavailable.
+	deletions     ports.DeletionRepository
+	deletionGrace time.Duration
+
+	// KYC review is optional. Without a repository users stay at basic.
+	kyc      ports.KYCRepository
+	auditLog ports.AuditLog
+
+	// The device registry is optional. Without it any device can sign in
+	// with just the password.
+	devices ports.DeviceRepository
+
+	// Step-up challenges are optional; see SetStepUp
+	stepUps      ports.StepUpRepository
+	stepUpTokens ports.StepUpTokens
+	stepUpTTL    time.Duration
+
+	// Transaction PINs are optional; see SetPINs
+	pins      ports.PINRepository
+	pinPolicy domain.PINPolicy
+
+	// Access token revocation is optional; see SetTokenRevocation
+	revoker ports.TokenRevoker
+
+	// Organizations are optional; see SetOrganizations
+	orgs ports.OrganizationRepository
+
+	// Provider staff roles are optional; see SetProviderStaff
+	providerStaff ports.ProviderStaffRepository
+
+	// Platform admins are optional; see SetAdmins
+	admins map[uuid.UUID]bool
+
+	// Guest checkout is optional; see SetGuestCheckout
+	guestTTL time.Duration
+
+	// Referrals are optional; see SetReferrals
+	referrals ports.ReferralRepository
+
+	// The welcome push is optional; see SetNotifier
+	notifier ports.Notifier
 }
 
 // NewAuthService creates a new AuthService with all dependencies.
@@ -74,6 +128,61 @@ func NewAuthService(
 	}
 }
 
+// SetWhatsAppService enables OTP delivery over WhatsApp for users who
+// prefer it. SMS is still used as the fallback channel.
+func (s *AuthService) SetWhatsAppService(whatsApp ports.SMSService) {
+	s.whatsAppService = whatsApp
+}
+
+// SetNotifier sends new users a welcome push while they register, rather
+// than after the registration event reaches the notification service
+func (s *AuthService) SetNotifier(notifier ports.Notifier) {
+	s.notifier = notifier
+}
+
+// sendWelcome sends the welcome push. Registration has already succeeded,
+// so a failed send is only logged.
+func (s *AuthService) sendWelcome(ctx context.Context, user *domain.User, pushToken string) {
+	err := s.notifier.SendTemplate(ctx, user.ID, ports.WelcomePushTemplate, pushToken, map[string]string{
+		"name": user.FullName,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("failed to send welcome push", ports.Err(err), ports.String("user_id", user.ID.String()))
+	}
+}
+
+// SetRateLimiters limits OTP requests and login attempts per phone number.
+//
+// SECURITY NOTE: Why limit per phone?
+// ===================================
+// - OTP requests cost money (SMS/WhatsApp) and can be used to spam a victim
+// - Login attempts against one phone are how passwords get brute-forced
+// Limits are keyed by phone rather than IP because attackers rotate IPs.
+func (s *AuthService) SetRateLimiters(otpRequests, logins ports.RateLimiter) {
+	s.otpRequestLimiter = otpRequests
+	s.loginLimiter = logins
+}
+
+// checkRateLimit returns ErrTooManyAttempts if key is over the limit.
+//
+// If the limiter itself fails (e.g., Redis is down) we let the request
+// through: locking every user out during an outage is worse than briefly
+// losing the limit, and OTP attempts are still capped per code.
+func (s *AuthService) checkRateLimit(ctx context.Context, limiter ports.RateLimiter, key string) error {
+	if limiter == nil {
+		return nil
+	}
+	allowed, err := limiter.Allow(ctx, key)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("rate limiter unavailable", ports.Err(err))
+		return nil
+	}
+	if !allowed {
+		return domain.ErrTooManyAttempts
+	}
+	return nil
+}
+
 // ---- Request/Response DTOs ----
 // DTOs (Data Transfer Objects) define the input/output of our use cases.
 // They are different from domain entities because they're shaped for the
@@ -85,6 +194,19 @@ type RegisterRequest struct {
 	Email    string `json:"email" validate:"omitempty,email"`
 	Password string `json:"password" validate:"required,min=8"`
 	FullName string `json:"full_name" validate:"required"`
+
+	// GuestDeviceID is the device the user parked from as a guest, if any.
+	// Their guest sessions are moved to the new account.
+	GuestDeviceID string `json:"guest_device_id,omitempty"`
+
+	// ReferralCode is a friend's code, if the user was referred. DeviceID
+	// is then required so a device can only be referred once.
+	ReferralCode string `json:"referral_code,omitempty"`
+	DeviceID     string `json:"device_id,omitempty"`
+
+	// PushToken is the app's push notification token. With it the user is
+	// sent a welcome push.
+	PushToken string `json:"push_token,omitempty"`
 }
 
 // RegisterResponse is returned after successful registration.
@@ -97,6 +219,10 @@ type RegisterResponse struct {
 type LoginRequest struct {
 	Phone    string `json:"phone" validate:"required"`
 	Password string `json:"password" validate:"required"`
+
+	// Identify the device when device verification is enabled
+	DeviceID          string `json:"device_id,omitempty"`
+	DeviceFingerprint string `json:"device_fingerprint,omitempty"`
 }
 
 // LoginResponse contains tokens returned after successful login.
@@ -105,6 +231,10 @@ type LoginResponse struct {
 	RefreshToken string    `json:"refresh_token"`
 	ExpiresIn    int       `json:"expires_in"` // Seconds until access token expires
 	UserID       uuid.UUID `json:"user_id"`
+
+	// Set, with no tokens, when the sign-in came from a device the user
+	// hasn't confirmed. An OTP has been sent; see VerifyDevice.
+	DeviceVerificationRequired bool `json:"device_verification_required,omitempty"`
 }
 
 // RefreshTokenRequest contains the refresh token to exchange.
@@ -130,6 +260,14 @@ type UserProfile struct {
 	Email     string    `json:"email,omitempty"`
 	FullName  string    `json:"full_name"`
 	Status    string    `json:"status"`
+
+	PreferredOTPChannel string `json:"preferred_otp_channel"`
+	KYCLevel            string `json:"kyc_level"`
+}
+
+// UpdateOTPChannelRequest changes where the user's OTPs are delivered.
+type UpdateOTPChannelRequest struct {
+	Channel string `json:"channel" validate:"required,oneof=sms whatsapp"`
 }
 
 // ---- Use Cases ----
@@ -144,7 +282,7 @@ type UserProfile struct {
 // 5. Generate and send OTP for verification
 // 6. Publish user.registered event
 func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*RegisterResponse, error) {
-	s.logger.Info("registering new user", ports.String("phone", req.Phone))
+	s.logger.WithContext(ctx).Info("registering new user", ports.String("phone", req.Phone))
 
 	// Check if password meets requirements
 	if err := domain.ValidatePassword(req.Password); err != nil {
@@ -154,17 +292,23 @@ func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*Regis
 	// Check if user already exists
 	exists, err := s.users.ExistsByPhone(ctx, req.Phone)
 	if err != nil {
-		s.logger.Error("failed to check user existence", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to check user existence", ports.Err(err))
 		return nil, fmt.Errorf("failed to check user existence: %w", err)
 	}
 	if exists {
 		return nil, domain.ErrUserAlreadyExists
 	}
 
+	// Resolve the referral code first so a mistyped one can be corrected
+	referralCode, err := s.referralCode(ctx, req.ReferralCode, req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Hash the password
 	passwordHash, err := s.passwordHasher.Hash(req.Password)
 	if err != nil {
-		s.logger.Error("failed to hash password", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to hash password", ports.Err(err))
 		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 
@@ -176,39 +320,48 @@ func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*Regis
 
 	// Persist the user
 	if err := s.users.Create(ctx, user); err != nil {
-		s.logger.Error("failed to create user", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to create user", ports.Err(err))
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if referralCode != nil {
+		s.attributeReferral(ctx, referralCode, user, req.DeviceID)
+	}
+
 	// Generate and send OTP
 	otp := domain.NewOTP(req.Phone, s.otpGenerator.Generate())
 	if err := s.otps.Create(ctx, otp); err != nil {
-		s.logger.Error("failed to create OTP", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to create OTP", ports.Err(err))
 		// Continue - user is created, they can request OTP again
 	} else {
-		// Send OTP via SMS (don't fail registration if SMS fails)
+		// Send OTP (don't fail registration if delivery fails)
 		go func() {
-			if err := s.smsService.SendOTP(context.Background(), req.Phone, otp.Code); err != nil {
-				s.logger.Error("failed to send OTP", ports.Err(err), ports.String("phone", req.Phone))
+			if err := s.sendOTP(context.Background(), user.Phone, user.OTPChannel(), otp.Code); err != nil {
+				s.logger.WithContext(ctx).Error("failed to send OTP", ports.Err(err), ports.String("phone", req.Phone))
 			}
 		}()
 	}
 
+	if s.notifier != nil && req.PushToken != "" {
+		s.sendWelcome(ctx, user, req.PushToken)
+	}
+
 	// Publish event (async)
 	go func() {
+		payload := userPayload(user)
+		if deviceID, err := domain.ValidateDeviceID(req.GuestDeviceID); err == nil {
+			payload["guest_device_id"] = deviceID
+		}
 		event := ports.Event{
-			Type: ports.EventUserRegistered,
-			Payload: map[string]interface{}{
-				"user_id": user.ID.String(),
-				"phone":   user.Phone,
-			},
+			Type:    ports.EventUserRegistered,
+			Payload: payload,
 		}
-		if err := s.events.Publish(context.Background(), event); err != nil {
-			s.logger.Error("failed to publish event", ports.Err(err))
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
 		}
 	}()
 
-	s.logger.Info("user registered successfully", ports.String("user_id", user.ID.String()))
+	s.logger.WithContext(ctx).Info("user registered successfully", ports.String("user_id", user.ID.String()))
 
 	return &RegisterResponse{
 		UserID:  user.ID,
@@ -226,7 +379,12 @@ func (s *AuthService) Register(ctx context.Context, req RegisterRequest) (*Regis
 // 5. Store refresh token hash
 // 6. Publish user.logged_in event
 func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ipAddress string) (*LoginResponse, error) {
-	s.logger.Info("user attempting login", ports.String("phone", req.Phone))
+	s.logger.WithContext(ctx).Info("user attempting login", ports.String("phone", req.Phone))
+
+	if err := s.checkRateLimit(ctx, s.loginLimiter, req.Phone); err != nil {
+		s.logger.WithContext(ctx).Warn("login rate limit exceeded", ports.String("phone", req.Phone))
+		return nil, err
+	}
 
 	// Find user
 	user, err := s.users.GetByPhone(ctx, req.Phone)
@@ -234,13 +392,13 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ip
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return nil, domain.ErrInvalidCredentials // Don't reveal if user exists
 		}
-		s.logger.Error("failed to get user", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to get user", ports.Err(err))
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Verify password
 	if err := s.passwordHasher.Compare(req.Password, user.PasswordHash); err != nil {
-		s.logger.Warn("invalid password attempt", ports.String("phone", req.Phone))
+		s.logger.WithContext(ctx).Warn("invalid password attempt", ports.String("phone", req.Phone))
 		return nil, domain.ErrInvalidCredentials
 	}
 
@@ -249,25 +407,37 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ip
 		return nil, domain.ErrUserInactive
 	}
 
-	// Generate access token
-	accessToken, err := s.tokenService.GenerateAccessToken(user.ID, user.Phone)
-	if err != nil {
-		s.logger.Error("failed to generate access token", ports.Err(err))
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	// A device the user hasn't confirmed gets an OTP instead of tokens
+	if s.devices != nil {
+		known, err := s.checkDevice(ctx, user, req, userAgent, ipAddress)
+		if err != nil {
+			return nil, err
+		}
+		if !known {
+			return &LoginResponse{UserID: user.ID, DeviceVerificationRequired: true}, nil
+		}
 	}
 
 	// Generate refresh token
 	refreshToken, err := s.tokenService.GenerateRefreshToken()
 	if err != nil {
-		s.logger.Error("failed to generate refresh token", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to generate refresh token", ports.Err(err))
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
-
-	// Hash and store refresh token
 	tokenHash := s.tokenService.HashRefreshToken(refreshToken)
 	rt := domain.NewRefreshToken(user.ID, tokenHash, userAgent, ipAddress)
+
+	// Generate access token, tagged with the refresh token's ID as the
+	// session ID so the user's devices list can mark the current session
+	accessToken, err := s.generateAccessToken(ctx, user, rt.ID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to generate access token", ports.Err(err))
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	// Store refresh token hash
 	if err := s.tokens.Create(ctx, rt); err != nil {
-		s.logger.Error("failed to store refresh token", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to store refresh token", ports.Err(err))
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
 
@@ -280,12 +450,12 @@ func (s *AuthService) Login(ctx context.Context, req LoginRequest, userAgent, ip
 				"ip_address": ipAddress,
 			},
 		}
-		if err := s.events.Publish(context.Background(), event); err != nil {
-			s.logger.Error("failed to publish event", ports.Err(err))
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
 		}
 	}()
 
-	s.logger.Info("user logged in successfully", ports.String("user_id", user.ID.String()))
+	s.logger.WithContext(ctx).Info("user logged in successfully", ports.String("user_id", user.ID.String()))
 
 	return &LoginResponse{
 		AccessToken:  accessToken,
@@ -312,7 +482,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken, userAgent,
 		if errors.Is(err, domain.ErrTokenNotFound) {
 			return nil, domain.ErrInvalidToken
 		}
-		s.logger.Error("failed to get refresh token", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to get refresh token", ports.Err(err))
 		return nil, fmt.Errorf("failed to get refresh token: %w", err)
 	}
 
@@ -324,7 +494,7 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken, userAgent,
 	// Get the user
 	user, err := s.users.GetByID(ctx, storedToken.UserID)
 	if err != nil {
-		s.logger.Error("failed to get user for token refresh", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to get user for token refresh", ports.Err(err))
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
@@ -335,25 +505,25 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken, userAgent,
 
 	// Revoke the old token (token rotation)
 	if err := s.tokens.Revoke(ctx, storedToken.ID); err != nil {
-		s.logger.Error("failed to revoke old token", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to revoke old token", ports.Err(err))
 		// Continue anyway - don't block the user
 	}
 
-	// Generate new access token
-	accessToken, err := s.tokenService.GenerateAccessToken(user.ID, user.Phone)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate access token: %w", err)
-	}
-
 	// Generate new refresh token
 	newRefreshToken, err := s.tokenService.GenerateRefreshToken()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
 	}
-
-	// Store new refresh token
 	newTokenHash := s.tokenService.HashRefreshToken(newRefreshToken)
 	newRT := domain.NewRefreshToken(user.ID, newTokenHash, userAgent, ipAddress)
+
+	// Generate new access token for the rotated session
+	accessToken, err := s.generateAccessToken(ctx, user, newRT.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	// Store new refresh token
 	if err := s.tokens.Create(ctx, newRT); err != nil {
 		return nil, fmt.Errorf("failed to store refresh token: %w", err)
 	}
@@ -366,8 +536,11 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken, userAgent,
 	}, nil
 }
 
-// Logout revokes the user's refresh token.
-func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+// Logout revokes the user's refresh token, and the access token the request
+// was made with when one is given.
+func (s *AuthService) Logout(ctx context.Context, refreshToken string, accessToken *ports.AccessTokenClaims) error {
+	s.revokeAccessToken(ctx, accessToken)
+
 	tokenHash := s.tokenService.HashRefreshToken(refreshToken)
 
 	storedToken, err := s.tokens.GetByTokenHash(ctx, tokenHash)
@@ -385,18 +558,25 @@ func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
 	return nil
 }
 
-// LogoutAllDevices revokes all refresh tokens for a user.
+// LogoutAllDevices revokes all refresh tokens for a user, and with token
+// revocation enabled every access token issued to them so far.
 func (s *AuthService) LogoutAllDevices(ctx context.Context, userID uuid.UUID) error {
 	if err := s.tokens.RevokeAllForUser(ctx, userID); err != nil {
 		return fmt.Errorf("failed to revoke all tokens: %w", err)
 	}
-	return nil
+	return s.revokeUserTokens(ctx, userID)
 }
 
 // RequestOTP generates and sends a new OTP to the user's phone.
 func (s *AuthService) RequestOTP(ctx context.Context, req RequestOTPRequest) error {
+	// Checked before the user lookup so the response doesn't reveal
+	// whether the phone is registered
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, req.Phone); err != nil {
+		return err
+	}
+
 	// Check if user exists
-	_, err := s.users.GetByPhone(ctx, req.Phone)
+	user, err := s.users.GetByPhone(ctx, req.Phone)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			// Don't reveal if user exists - just pretend we sent OTP
@@ -411,15 +591,52 @@ func (s *AuthService) RequestOTP(ctx context.Context, req RequestOTPRequest) err
 		return fmt.Errorf("failed to create OTP: %w", err)
 	}
 
-	// Send OTP
-	if err := s.smsService.SendOTP(ctx, req.Phone, otp.Code); err != nil {
-		s.logger.Error("failed to send OTP", ports.Err(err))
+	// Send OTP on the user's preferred channel
+	if err := s.sendOTP(ctx, user.Phone, user.OTPChannel(), otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send OTP", ports.Err(err))
 		return fmt.Errorf("failed to send OTP: %w", err)
 	}
 
+	// Lets the notification service warn the user about codes they did not
+	// ask for. The code itself never leaves this service.
+	go func() {
+		event := ports.Event{
+			Type: ports.EventOTPRequested,
+			Payload: map[string]interface{}{
+				"user_id": user.ID.String(),
+				"phone":   user.Phone,
+				"channel": string(user.OTPChannel()),
+			},
+		}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+	}()
+
 	return nil
 }
 
+// sendOTP delivers an OTP on the requested channel.
+//
+// PATTERN: Fallback
+// =================
+// WhatsApp delivery can fail for reasons outside our control: the number
+// isn't registered on WhatsApp, the template was paused, or Meta's API is
+// down. An OTP that never arrives locks the user out, so any WhatsApp
+// failure is retried over SMS, which every phone can receive.
+func (s *AuthService) sendOTP(ctx context.Context, phone string, channel domain.OTPChannel, code string) error {
+	if channel == domain.OTPChannelWhatsApp && s.whatsAppService != nil {
+		err := s.whatsAppService.SendOTP(ctx, phone, code)
+		if err == nil {
+			return nil
+		}
+		s.logger.WithContext(ctx).Warn("WhatsApp OTP delivery failed, falling back to SMS",
+			ports.Err(err), ports.String("phone", phone))
+	}
+
+	return s.smsService.SendOTP(ctx, phone, code)
+}
+
 // VerifyOTP verifies an OTP code and activates the user if pending.
 func (s *AuthService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) error {
 	// Get the latest OTP
@@ -428,12 +645,24 @@ func (s *AuthService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) error
 		return domain.ErrInvalidToken
 	}
 
+	if !otp.IsValid() {
+		return domain.ErrInvalidToken
+	}
+
+	// Record the attempt atomically BEFORE comparing the code. Another
+	// replica may be checking a guess for the same OTP right now; the
+	// shared counter makes sure both guesses count.
+	attempts, err := s.otps.IncrementAttempts(ctx, req.Phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrTokenNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to record OTP attempt: %w", err)
+	}
+	otp.Attempts = attempts - 1 // Verify counts this attempt itself
+
 	// Verify the code
 	if !otp.Verify(req.Code) {
-		// Update the OTP to record the failed attempt
-		if err := s.otps.Update(ctx, otp); err != nil {
-			s.logger.Error("failed to update OTP attempts", ports.Err(err))
-		}
 		return domain.ErrInvalidToken
 	}
 
@@ -452,7 +681,7 @@ func (s *AuthService) VerifyOTP(ctx context.Context, req VerifyOTPRequest) error
 
 	// Clean up OTPs for this phone
 	if err := s.otps.DeleteByPhone(ctx, req.Phone); err != nil {
-		s.logger.Error("failed to delete OTPs", ports.Err(err))
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
 	}
 
 	return nil
@@ -471,5 +700,31 @@ func (s *AuthService) GetProfile(ctx context.Context, userID uuid.UUID) (*UserPr
 		Email:    user.Email,
 		FullName: user.FullName,
 		Status:   string(user.Status),
+
+		PreferredOTPChannel: string(user.OTPChannel()),
+		KYCLevel:            string(user.KYC()),
 	}, nil
 }
+
+// UpdateOTPChannel sets the channel the user wants to receive OTPs on.
+func (s *AuthService) UpdateOTPChannel(ctx context.Context, userID uuid.UUID, req UpdateOTPChannelRequest) (*UserProfile, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := user.SetPreferredOTPChannel(domain.OTPChannel(req.Channel)); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.Update(ctx, user); err != nil {
+		s.logger.WithContext(ctx).Error("failed to update OTP channel", ports.Err(err))
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("OTP channel updated",
+		ports.String("user_id", user.ID.String()),
+		ports.String("channel", req.Channel))
+
+	return s.GetProfile(ctx, userID)
+}
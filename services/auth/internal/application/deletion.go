@@ -0,0 +1,181 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// ConfirmDeletionRequest carries the OTP sent to the user's phone.
+type ConfirmDeletionRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+// DeletionStatusResponse describes the user's latest deletion request.
+type DeletionStatusResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	Status       string     `json:"status"`
+	RequestedAt  time.Time  `json:"requested_at"`
+	ScheduledFor time.Time  `json:"scheduled_for"`
+	CancelledAt  *time.Time `json:"cancelled_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+// SetAccountDeletion enables account deletion. Personal data is erased
+// once grace has passed since the request.
+func (s *AuthService) SetAccountDeletion(deletions ports.DeletionRepository, grace time.Duration) {
+	s.deletions = deletions
+	s.deletionGrace = grace
+}
+
+// RequestDeletionOTP sends the OTP that confirms an account deletion to
+// the user's phone.
+func (s *AuthService) RequestDeletionOTP(ctx context.Context, userID uuid.UUID) error {
+	if s.deletions == nil {
+		return domain.ErrDeletionNotFound
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, user.Phone); err != nil {
+		return err
+	}
+
+	otp := domain.NewOTP(domain.DeletionOTPKey(user.ID), s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		return fmt.Errorf("failed to create OTP: %w", err)
+	}
+	if err := s.sendOTP(ctx, user.Phone, user.OTPChannel(), otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send deletion OTP", ports.Err(err))
+		return fmt.Errorf("failed to send OTP: %w", err)
+	}
+	return nil
+}
+
+// RequestDeletion verifies the OTP and schedules the account for erasure.
+// The user can keep using the account, and cancel, until then.
+func (s *AuthService) RequestDeletion(ctx context.Context, userID uuid.UUID, req ConfirmDeletionRequest) (*DeletionStatusResponse, error) {
+	if s.deletions == nil {
+		return nil, domain.ErrDeletionNotFound
+	}
+	key := domain.DeletionOTPKey(userID)
+
+	if err := s.verifyKeyedOTP(ctx, key, req.Code); err != nil {
+		return nil, err
+	}
+	if err := s.otps.DeleteByPhone(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
+	}
+
+	deletion := domain.NewDeletionRequest(userID, time.Now().UTC(), s.deletionGrace)
+	if err := s.deletions.Create(ctx, deletion); err != nil {
+		if errors.Is(err, domain.ErrDeletionPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create deletion request: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("account deletion requested",
+		ports.String("user_id", userID.String()),
+		ports.String("scheduled_for", deletion.ScheduledFor.Format(time.RFC3339)))
+
+	return toDeletionStatus(deletion), nil
+}
+
+// GetDeletionStatus returns the user's latest deletion request.
+func (s *AuthService) GetDeletionStatus(ctx context.Context, userID uuid.UUID) (*DeletionStatusResponse, error) {
+	if s.deletions == nil {
+		return nil, domain.ErrDeletionNotFound
+	}
+	deletion, err := s.deletions.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toDeletionStatus(deletion), nil
+}
+
+// CancelDeletion withdraws a pending deletion request.
+func (s *AuthService) CancelDeletion(ctx context.Context, userID uuid.UUID) (*DeletionStatusResponse, error) {
+	if s.deletions == nil {
+		return nil, domain.ErrDeletionNotFound
+	}
+	deletion, err := s.deletions.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := deletion.Cancel(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.deletions.Cancel(ctx, deletion); err != nil {
+		if errors.Is(err, domain.ErrDeletionNotPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to cancel deletion request: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("account deletion cancelled", ports.String("user_id", userID.String()))
+	return toDeletionStatus(deletion), nil
+}
+
+// EraseDueAccounts erases the personal data of accounts whose grace period
+// has ended and publishes user.deleted for each, so other services scrub
+// their copies. It returns the number of accounts erased.
+func (s *AuthService) EraseDueAccounts(ctx context.Context, now time.Time, limit int) (int, error) {
+	if s.deletions == nil {
+		return 0, nil
+	}
+	due, err := s.deletions.ListDue(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	erased := 0
+	for _, deletion := range due {
+		user, err := s.users.GetByID(ctx, deletion.UserID)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("failed to load user for erasure",
+				ports.String("user_id", deletion.UserID.String()), ports.Err(err))
+			continue
+		}
+
+		user.Anonymize(now)
+		if err := deletion.Complete(now); err != nil {
+			continue
+		}
+		if err := s.deletions.Erase(ctx, deletion, user); err != nil {
+			if !errors.Is(err, domain.ErrDeletionNotPending) {
+				s.logger.WithContext(ctx).Error("failed to erase account",
+					ports.String("user_id", user.ID.String()), ports.Err(err))
+			}
+			continue
+		}
+		erased++
+		s.revokeUserTokens(ctx, user.ID)
+
+		// Synchronous so a failure is logged against this run. A missed
+		// event is recovered by the backfill-users snapshot.
+		event := ports.Event{Type: ports.EventUserDeleted, Payload: deletedPayload(user)}
+		if err := s.events.Publish(ctx, event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+		s.logger.WithContext(ctx).Info("account erased", ports.String("user_id", user.ID.String()))
+	}
+	return erased, nil
+}
+
+func toDeletionStatus(d *domain.DeletionRequest) *DeletionStatusResponse {
+	return &DeletionStatusResponse{
+		ID:           d.ID,
+		Status:       string(d.Status),
+		RequestedAt:  d.RequestedAt,
+		ScheduledFor: d.ScheduledFor,
+		CancelledAt:  d.CancelledAt,
+		CompletedAt:  d.CompletedAt,
+	}
+}
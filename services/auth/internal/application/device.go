@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// VerifyDeviceRequest confirms a sign-in from a new device with the OTP
+// sent to the user's phone.
+type VerifyDeviceRequest struct {
+	Phone             string `json:"phone" validate:"required"`
+	Code              string `json:"code" validate:"required,len=6"`
+	DeviceID          string `json:"device_id" validate:"required"`
+	DeviceFingerprint string `json:"device_fingerprint"`
+}
+
+// SetDeviceVerification enables the device registry. Password sign-ins
+// must then identify their device, and an unknown one needs an OTP.
+func (s *AuthService) SetDeviceVerification(devices ports.DeviceRepository) {
+	s.devices = devices
+}
+
+// checkDevice reports whether the user has confirmed the device before.
+// If not, it sends the OTP that confirms it.
+func (s *AuthService) checkDevice(ctx context.Context, user *domain.User, req LoginRequest, userAgent, ipAddress string) (bool, error) {
+	deviceID, err := domain.NormalizeDeviceID(req.DeviceID)
+	if err != nil {
+		return false, err
+	}
+
+	device, err := s.devices.Get(ctx, user.ID, deviceID)
+	switch {
+	case err == nil && device.Matches(req.DeviceFingerprint):
+		device.Seen(userAgent, ipAddress, time.Now().UTC())
+		if err := s.devices.Save(ctx, device); err != nil {
+			s.logger.WithContext(ctx).Error("failed to update device", ports.Err(err))
+		}
+		return true, nil
+	case err != nil && !errors.Is(err, domain.ErrDeviceNotFound):
+		return false, err
+	}
+
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, user.Phone); err != nil {
+		return false, err
+	}
+	otp := domain.NewOTP(domain.DeviceOTPKey(user.ID, deviceID), s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		return false, fmt.Errorf("failed to create OTP: %w", err)
+	}
+	if err := s.sendOTP(ctx, user.Phone, user.OTPChannel(), otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send device OTP", ports.Err(err))
+		return false, fmt.Errorf("failed to send OTP: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("sign-in from new device needs verification",
+		ports.String("user_id", user.ID.String()))
+	return false, nil
+}
+
+// VerifyDevice checks the OTP sent when the user signed in from a new
+// device, remembers the device and completes the sign-in. The user is
+// alerted through user.new_device_login.
+func (s *AuthService) VerifyDevice(ctx context.Context, req VerifyDeviceRequest, userAgent, ipAddress string) (*LoginResponse, error) {
+	if s.devices == nil {
+		return nil, domain.ErrInvalidToken
+	}
+	deviceID, err := domain.NormalizeDeviceID(req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetByPhone(ctx, req.Phone)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	key := domain.DeviceOTPKey(user.ID, deviceID)
+	if err := s.verifyKeyedOTP(ctx, key, req.Code); err != nil {
+		return nil, err
+	}
+	if !user.CanLogin() {
+		return nil, domain.ErrUserInactive
+	}
+
+	now := time.Now().UTC()
+	device, err := domain.NewDevice(user.ID, deviceID, req.DeviceFingerprint, userAgent, ipAddress, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.devices.Save(ctx, device); err != nil {
+		return nil, err
+	}
+	if err := s.otps.DeleteByPhone(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
+	}
+
+	resp, err := s.issueTokens(ctx, user, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventNewDeviceLogin,
+			Payload: map[string]interface{}{
+				"user_id":      user.ID.String(),
+				"phone":        user.Phone,
+				"device_id":    deviceID,
+				"device_name":  userAgent,
+				"ip_address":   ipAddress,
+				"logged_in_at": now.Format(time.RFC3339),
+			},
+		}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+	}()
+
+	s.logger.WithContext(ctx).Info("new device verified", ports.String("user_id", user.ID.String()))
+	return resp, nil
+}
+
+// ListDevices returns the devices the user has confirmed.
+func (s *AuthService) ListDevices(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error) {
+	if s.devices == nil {
+		return []*domain.Device{}, nil
+	}
+	devices, err := s.devices.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if devices == nil {
+		devices = []*domain.Device{}
+	}
+	return devices, nil
+}
+
+// ForgetDevice removes a device, so the next sign-in from it needs an OTP.
+// Sessions already open on it are not ended; see RevokeSession.
+func (s *AuthService) ForgetDevice(ctx context.Context, userID, id uuid.UUID) error {
+	if s.devices == nil {
+		return domain.ErrDeviceNotFound
+	}
+	return s.devices.Delete(ctx, userID, id)
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// GuestRequest identifies the device a guest is parking from. The app
+// generates the ID once per install and keeps it.
+type GuestRequest struct {
+	DeviceID string `json:"device_id" validate:"required"`
+}
+
+// GuestResponse carries a guest's access token. There is no refresh token;
+// the app asks for a new guest token when this one expires.
+type GuestResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresIn   int       `json:"expires_in"`
+	GuestID     uuid.UUID `json:"guest_id"`
+}
+
+// SetGuestCheckout lets people park without registering. Guest tokens
+// expire after ttl.
+func (s *AuthService) SetGuestCheckout(ttl time.Duration) {
+	s.guestTTL = ttl
+}
+
+// StartGuest issues a short-lived guest token for a device. Guests can only
+// use the guest parking routes; when they register with the same device ID
+// their guest sessions move to the new account.
+func (s *AuthService) StartGuest(ctx context.Context, req GuestRequest) (*GuestResponse, error) {
+	if s.guestTTL <= 0 {
+		return nil, domain.ErrGuestCheckoutDisabled
+	}
+	deviceID, err := domain.ValidateDeviceID(req.DeviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	guestID := domain.GuestID(deviceID)
+	token, err := s.tokenService.GenerateGuestToken(guestID, deviceID, s.guestTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate guest token: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("guest token issued", ports.String("guest_id", guestID.String()))
+	return &GuestResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(s.guestTTL.Seconds()),
+		GuestID:     guestID,
+	}, nil
+}
@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+const (
+	defaultKYCPageSize = 50
+	maxKYCPageSize     = 200
+)
+
+// SubmitKYCRequest carries the user's MyKad number and a reference to the
+// selfie they uploaded to document storage.
+type SubmitKYCRequest struct {
+	ICNumber  string `json:"ic_number" validate:"required"`
+	SelfieRef string `json:"selfie_ref" validate:"required"`
+}
+
+// RejectKYCRequest explains to the user why they were not verified.
+type RejectKYCRequest struct {
+	Reason string `json:"reason" validate:"required"`
+}
+
+// KYCStatusResponse is the user's own view of their verification. The IC
+// number is masked.
+type KYCStatusResponse struct {
+	Level        string     `json:"kyc_level"`
+	SubmissionID *uuid.UUID `json:"submission_id,omitempty"`
+	Status       string     `json:"status,omitempty"`
+	ICNumber     string     `json:"ic_number,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	SubmittedAt  *time.Time `json:"submitted_at,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// SetKYC enables identity verification. Reviews are recorded in auditLog.
+func (s *AuthService) SetKYC(kyc ports.KYCRepository, auditLog ports.AuditLog) {
+	s.kyc = kyc
+	s.auditLog = auditLog
+}
+
+// SubmitKYC stores the user's documents for an admin to review. Users who
+// are verified or already waiting on a review cannot submit again.
+func (s *AuthService) SubmitKYC(ctx context.Context, userID uuid.UUID, req SubmitKYCRequest) (*KYCStatusResponse, error) {
+	if s.kyc == nil {
+		return nil, domain.ErrKYCSubmissionNotFound
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.KYC() == domain.KYCLevelVerified {
+		return nil, domain.ErrKYCAlreadyVerified
+	}
+
+	submission, err := domain.NewKYCSubmission(userID, req.ICNumber, req.SelfieRef, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.kyc.Create(ctx, submission); err != nil {
+		if errors.Is(err, domain.ErrKYCPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create KYC submission: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("KYC submitted", ports.String("user_id", userID.String()))
+	return toKYCStatus(user, submission), nil
+}
+
+// GetKYCStatus returns the user's level and their latest submission.
+func (s *AuthService) GetKYCStatus(ctx context.Context, userID uuid.UUID) (*KYCStatusResponse, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if s.kyc == nil {
+		return toKYCStatus(user, nil), nil
+	}
+
+	submission, err := s.kyc.GetLatestByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrKYCSubmissionNotFound) {
+			return toKYCStatus(user, nil), nil
+		}
+		return nil, fmt.Errorf("failed to get KYC submission: %w", err)
+	}
+	return toKYCStatus(user, submission), nil
+}
+
+// ListKYCSubmissions returns submissions in a status for review, oldest
+// first. The status defaults to pending.
+func (s *AuthService) ListKYCSubmissions(ctx context.Context, status string, limit, offset int) ([]*domain.KYCSubmission, error) {
+	if s.kyc == nil {
+		return []*domain.KYCSubmission{}, nil
+	}
+	if status == "" {
+		status = string(domain.KYCStatusPending)
+	}
+	if limit <= 0 {
+		limit = defaultKYCPageSize
+	}
+	if limit > maxKYCPageSize {
+		limit = maxKYCPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	submissions, err := s.kyc.ListByStatus(ctx, domain.KYCStatus(status), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KYC submissions: %w", err)
+	}
+	if submissions == nil {
+		submissions = []*domain.KYCSubmission{}
+	}
+	return submissions, nil
+}
+
+// GetKYCSubmission returns a submission in full for review.
+func (s *AuthService) GetKYCSubmission(ctx context.Context, id uuid.UUID) (*domain.KYCSubmission, error) {
+	if s.kyc == nil {
+		return nil, domain.ErrKYCSubmissionNotFound
+	}
+	return s.kyc.GetByID(ctx, id)
+}
+
+// ApproveKYC verifies the user. The new level is published so the wallet
+// service lifts its limits.
+func (s *AuthService) ApproveKYC(ctx context.Context, id uuid.UUID, reviewer string) (*domain.KYCSubmission, error) {
+	return s.reviewKYC(ctx, id, ports.AuditKYCApproved, func(k *domain.KYCSubmission, now time.Time) error {
+		return k.Approve(reviewer, now)
+	})
+}
+
+// RejectKYC declines a submission with a reason the user can see.
+func (s *AuthService) RejectKYC(ctx context.Context, id uuid.UUID, reviewer string, req RejectKYCRequest) (*domain.KYCSubmission, error) {
+	return s.reviewKYC(ctx, id, ports.AuditKYCRejected, func(k *domain.KYCSubmission, now time.Time) error {
+		return k.Reject(reviewer, req.Reason, now)
+	})
+}
+
+func (s *AuthService) reviewKYC(ctx context.Context, id uuid.UUID, action string, review func(*domain.KYCSubmission, time.Time) error) (*domain.KYCSubmission, error) {
+	if s.kyc == nil {
+		return nil, domain.ErrKYCSubmissionNotFound
+	}
+	submission, err := s.kyc.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetByID(ctx, submission.UserID)
+	if err != nil {
+		return nil, err
+	}
+	before := *submission
+	previousLevel := user.KYC()
+
+	now := time.Now().UTC()
+	if err := review(submission, now); err != nil {
+		return nil, err
+	}
+	level := previousLevel
+	if submission.Status == domain.KYCStatusApproved {
+		level = domain.KYCLevelVerified
+	}
+
+	if err := s.kyc.Review(ctx, submission, level, now); err != nil {
+		if errors.Is(err, domain.ErrKYCNotPending) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save KYC review: %w", err)
+	}
+
+	// The IC number stays out of the audit trail; the submission ID leads
+	// back to it
+	if s.auditLog != nil {
+		event := ports.AuditEvent{
+			Action:     action,
+			TargetType: "kyc_submission",
+			TargetID:   submission.ID.String(),
+			Reason:     submission.Reason,
+			Before:     map[string]interface{}{"status": before.Status, "kyc_level": previousLevel, "user_id": user.ID},
+			After:      map[string]interface{}{"status": submission.Status, "kyc_level": level, "user_id": user.ID},
+		}
+		if err := s.auditLog.Record(ctx, event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to write audit log", ports.String("action", action), ports.Err(err))
+		}
+	}
+
+	if level != previousLevel {
+		user.KYCLevel = level
+		user.UpdatedAt = now
+		go func() {
+			event := ports.Event{Type: ports.EventKYCUpdated, Payload: userPayload(user)}
+			if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+				s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+			}
+		}()
+	}
+
+	s.logger.WithContext(ctx).Info("KYC reviewed",
+		ports.String("submission_id", submission.ID.String()),
+		ports.String("status", string(submission.Status)))
+	return submission, nil
+}
+
+func toKYCStatus(user *domain.User, submission *domain.KYCSubmission) *KYCStatusResponse {
+	resp := &KYCStatusResponse{Level: string(user.KYC())}
+	if submission == nil {
+		return resp
+	}
+	resp.SubmissionID = &submission.ID
+	resp.Status = string(submission.Status)
+	resp.ICNumber = submission.MaskedICNumber()
+	resp.Reason = submission.Reason
+	resp.SubmittedAt = &submission.SubmittedAt
+	resp.ReviewedAt = submission.ReviewedAt
+	return resp
+}
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// CreateOrganizationRequest names a new organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// InviteMemberRequest invites a registered user by phone number
+type InviteMemberRequest struct {
+	Phone string `json:"phone" validate:"required"`
+}
+
+// OrganizationMembership is one of the user's organizations with their
+// place in it
+type OrganizationMembership struct {
+	Organization *domain.Organization `json:"organization"`
+	Role         domain.MemberRole    `json:"role"`
+	Status       domain.MemberStatus  `json:"status"`
+}
+
+// SetOrganizations enables organizations. Members charge parking to the
+// organization's wallet, which the wallet service opens on org.created.
+func (s *AuthService) SetOrganizations(orgs ports.OrganizationRepository) {
+	s.orgs = orgs
+}
+
+// CreateOrganization creates an organization owned by userID
+func (s *AuthService) CreateOrganization(ctx context.Context, userID uuid.UUID, req CreateOrganizationRequest) (*domain.Organization, error) {
+	if s.orgs == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	org, owner, err := domain.NewOrganization(req.Name, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgs.Create(ctx, org, owner); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("organization created",
+		ports.String("org_id", org.ID.String()),
+		ports.String("owner_id", userID.String()),
+	)
+	s.publishOrgEvent(ctx, ports.EventOrgCreated, map[string]interface{}{
+		"org_id":   org.ID.String(),
+		"owner_id": userID.String(),
+		"name":     org.Name,
+	})
+	return org, nil
+}
+
+// ListMyOrganizations returns the organizations the user belongs to or is
+// invited to
+func (s *AuthService) ListMyOrganizations(ctx context.Context, userID uuid.UUID) ([]*OrganizationMembership, error) {
+	memberships := []*OrganizationMembership{}
+	if s.orgs == nil {
+		return memberships, nil
+	}
+	members, err := s.orgs.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		org, err := s.orgs.GetByID(ctx, m.OrgID)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &OrganizationMembership{Organization: org, Role: m.Role, Status: m.Status})
+	}
+	return memberships, nil
+}
+
+// InviteMember invites the user registered with req.Phone. Only the owner
+// may invite; the invitee joins when they accept.
+func (s *AuthService) InviteMember(ctx context.Context, userID, orgID uuid.UUID, req InviteMemberRequest) (*domain.OrgMember, error) {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	invitee, err := s.users.GetByPhone(ctx, req.Phone)
+	if err != nil {
+		return nil, err
+	}
+	member, err := org.Invite(userID, invitee.ID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.orgs.AddMember(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.publishOrgEvent(ctx, ports.EventOrgMemberInvited, map[string]interface{}{
+		"org_id":   org.ID.String(),
+		"user_id":  invitee.ID.String(),
+		"name":     org.Name,
+		"owner_id": org.OwnerID.String(),
+	})
+	return member, nil
+}
+
+// ListMembers returns an organization's members and pending invitations.
+// Only members may see them.
+func (s *AuthService) ListMembers(ctx context.Context, userID, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	if _, err := s.getOrganization(ctx, orgID); err != nil {
+		return nil, err
+	}
+	caller, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil || !caller.IsActive() {
+		if err == nil || errors.Is(err, domain.ErrMemberNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	members, err := s.orgs.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if members == nil {
+		members = []*domain.OrgMember{}
+	}
+	return members, nil
+}
+
+// AcceptInvitation makes the user a member of an organization that
+// invited them
+func (s *AuthService) AcceptInvitation(ctx context.Context, userID, orgID uuid.UUID) (*domain.OrgMember, error) {
+	if s.orgs == nil {
+		return nil, domain.ErrInvitationNotFound
+	}
+	member, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMemberNotFound) {
+			return nil, domain.ErrInvitationNotFound
+		}
+		return nil, err
+	}
+	if err := member.Accept(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.orgs.UpdateMember(ctx, member); err != nil {
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("organization member joined",
+		ports.String("org_id", orgID.String()),
+		ports.String("user_id", userID.String()),
+	)
+	s.publishOrgEvent(ctx, ports.EventOrgMemberJoined, map[string]interface{}{
+		"org_id":  orgID.String(),
+		"user_id": userID.String(),
+		"role":    string(member.Role),
+	})
+	return member, nil
+}
+
+// DeclineInvitation drops an invitation the user doesn't want
+func (s *AuthService) DeclineInvitation(ctx context.Context, userID, orgID uuid.UUID) error {
+	if s.orgs == nil {
+		return domain.ErrInvitationNotFound
+	}
+	member, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrMemberNotFound) {
+			return domain.ErrInvitationNotFound
+		}
+		return err
+	}
+	if member.IsActive() {
+		return domain.ErrInvitationNotFound
+	}
+	return s.orgs.RemoveMember(ctx, orgID, userID)
+}
+
+// RemoveMember takes a member out of an organization or withdraws their
+// invitation. The owner may remove anyone but themselves; members may
+// remove only themselves, to leave.
+func (s *AuthService) RemoveMember(ctx context.Context, userID, orgID, memberID uuid.UUID) error {
+	org, err := s.getOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if memberID == org.OwnerID {
+		return domain.ErrCannotRemoveOwner
+	}
+	if userID != org.OwnerID && userID != memberID {
+		return domain.ErrNotOrganizationOwner
+	}
+
+	member, err := s.orgs.GetMember(ctx, orgID, memberID)
+	if err != nil {
+		return err
+	}
+	if err := s.orgs.RemoveMember(ctx, orgID, memberID); err != nil {
+		return err
+	}
+
+	if member.IsActive() {
+		s.logger.WithContext(ctx).Info("organization member removed",
+			ports.String("org_id", orgID.String()),
+			ports.String("user_id", memberID.String()),
+		)
+		s.publishOrgEvent(ctx, ports.EventOrgMemberRemoved, map[string]interface{}{
+			"org_id":  orgID.String(),
+			"user_id": memberID.String(),
+		})
+	}
+	return nil
+}
+
+func (s *AuthService) getOrganization(ctx context.Context, orgID uuid.UUID) (*domain.Organization, error) {
+	if s.orgs == nil {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	return s.orgs.GetByID(ctx, orgID)
+}
+
+func (s *AuthService) publishOrgEvent(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		event := ports.Event{Type: eventType, Payload: payload}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+	}()
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/parking-super-app/services/auth/internal/domain"
+)
+
+// verifyKeyedOTP checks a code against the OTP stored under key. The attempt
+// is counted before comparing, as in VerifyOTP. The OTP is left in place;
+// callers delete it once the action it confirms has succeeded.
+func (s *AuthService) verifyKeyedOTP(ctx context.Context, key, code string) error {
+	otp, err := s.otps.GetLatestByPhone(ctx, key)
+	if err != nil || !otp.IsValid() {
+		return domain.ErrInvalidToken
+	}
+	attempts, err := s.otps.IncrementAttempts(ctx, key)
+	if err != nil {
+		if errors.Is(err, domain.ErrTokenNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to record OTP attempt: %w", err)
+	}
+	otp.Attempts = attempts - 1
+	if !otp.Verify(code) {
+		return domain.ErrInvalidToken
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// SetPINRequest sets the user's first transaction PIN.
+type SetPINRequest struct {
+	PIN string `json:"pin" validate:"required,len=6"`
+}
+
+// ChangePINRequest replaces the PIN; the current one must be given.
+type ChangePINRequest struct {
+	CurrentPIN string `json:"current_pin" validate:"required,len=6"`
+	NewPIN     string `json:"new_pin" validate:"required,len=6"`
+}
+
+// VerifyPINRequest checks a PIN without doing anything else.
+type VerifyPINRequest struct {
+	PIN string `json:"pin" validate:"required,len=6"`
+}
+
+// ResetPINRequest replaces a forgotten or locked PIN with the OTP sent by
+// RequestPINResetOTP.
+type ResetPINRequest struct {
+	Code   string `json:"code" validate:"required,len=6"`
+	NewPIN string `json:"new_pin" validate:"required,len=6"`
+}
+
+// PINStatusResponse tells the app whether to ask the user to set a PIN.
+type PINStatusResponse struct {
+	Set         bool       `json:"set"`
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+// SetPINs enables transaction PINs, locking entry for policy.Lockout after
+// policy.MaxAttempts wrong PINs in a row.
+func (s *AuthService) SetPINs(pins ports.PINRepository, policy domain.PINPolicy) {
+	s.pins = pins
+	s.pinPolicy = policy
+}
+
+// GetPINStatus reports whether the user has set a PIN and if it is locked.
+func (s *AuthService) GetPINStatus(ctx context.Context, userID uuid.UUID) (*PINStatusResponse, error) {
+	if s.pins == nil {
+		return &PINStatusResponse{}, nil
+	}
+	pin, err := s.pins.Get(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrPINNotSet) {
+			return &PINStatusResponse{}, nil
+		}
+		return nil, err
+	}
+
+	resp := &PINStatusResponse{Set: true}
+	if pin.IsLocked(time.Now().UTC()) {
+		resp.LockedUntil = pin.LockedUntil
+	}
+	return resp, nil
+}
+
+// SetPIN sets the user's first PIN. Use ChangePIN or ResetPIN afterwards.
+func (s *AuthService) SetPIN(ctx context.Context, userID uuid.UUID, req SetPINRequest) error {
+	if s.pins == nil {
+		return domain.ErrPINNotSet
+	}
+	hash, err := s.hashPIN(req.PIN)
+	if err != nil {
+		return err
+	}
+	if err := s.pins.Create(ctx, domain.NewTransactionPIN(userID, hash, time.Now().UTC())); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("transaction PIN set", ports.String("user_id", userID.String()))
+	return nil
+}
+
+// ChangePIN replaces the PIN after checking the current one. A wrong
+// current PIN counts towards the lockout.
+func (s *AuthService) ChangePIN(ctx context.Context, userID uuid.UUID, req ChangePINRequest) error {
+	if s.pins == nil {
+		return domain.ErrPINNotSet
+	}
+	pin, err := s.checkPIN(ctx, userID, req.CurrentPIN)
+	if err != nil {
+		return err
+	}
+	hash, err := s.hashPIN(req.NewPIN)
+	if err != nil {
+		return err
+	}
+	pin.Replace(hash, time.Now().UTC())
+	if err := s.pins.Update(ctx, pin); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("transaction PIN changed", ports.String("user_id", userID.String()))
+	return nil
+}
+
+// VerifyPIN checks the user's PIN. The wallet service calls it over gRPC
+// before debiting a wallet.
+func (s *AuthService) VerifyPIN(ctx context.Context, userID uuid.UUID, pin string) error {
+	if s.pins == nil {
+		return domain.ErrPINNotSet
+	}
+	_, err := s.checkPIN(ctx, userID, pin)
+	return err
+}
+
+// RequestPINResetOTP sends the OTP that lets the user reset their PIN.
+func (s *AuthService) RequestPINResetOTP(ctx context.Context, userID uuid.UUID) error {
+	if s.pins == nil {
+		return domain.ErrPINNotSet
+	}
+	if _, err := s.pins.Get(ctx, userID); err != nil {
+		return err
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, user.Phone); err != nil {
+		return err
+	}
+
+	otp := domain.NewOTP(domain.PINResetOTPKey(userID), s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		return fmt.Errorf("failed to create OTP: %w", err)
+	}
+	if err := s.sendOTP(ctx, user.Phone, user.OTPChannel(), otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send PIN reset OTP", ports.Err(err))
+		return fmt.Errorf("failed to send OTP: %w", err)
+	}
+	return nil
+}
+
+// ResetPIN verifies the OTP and replaces the PIN, clearing any lockout.
+func (s *AuthService) ResetPIN(ctx context.Context, userID uuid.UUID, req ResetPINRequest) error {
+	if s.pins == nil {
+		return domain.ErrPINNotSet
+	}
+	hash, err := s.hashPIN(req.NewPIN)
+	if err != nil {
+		return err
+	}
+	key := domain.PINResetOTPKey(userID)
+	if err := s.verifyKeyedOTP(ctx, key, req.Code); err != nil {
+		return err
+	}
+
+	pin, err := s.pins.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	pin.Replace(hash, time.Now().UTC())
+	if err := s.pins.Update(ctx, pin); err != nil {
+		return err
+	}
+	if err := s.otps.DeleteByPhone(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
+	}
+
+	s.logger.WithContext(ctx).Info("transaction PIN reset", ports.String("user_id", userID.String()))
+	return nil
+}
+
+// checkPIN compares pin with the user's PIN. The attempt is counted
+// towards the lockout before the comparison and cleared only if the PIN is
+// right, so concurrent guesses can't exceed the policy. A locked PIN is
+// refused without being compared.
+func (s *AuthService) checkPIN(ctx context.Context, userID uuid.UUID, pin string) (*domain.TransactionPIN, error) {
+	now := time.Now().UTC()
+	stored, err := s.pins.ReserveAttempt(ctx, userID, s.pinPolicy, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.passwordHasher.Compare(pin, stored.Hash); err != nil {
+		if stored.IsLocked(now) {
+			s.logger.WithContext(ctx).Warn("transaction PIN locked",
+				ports.String("user_id", userID.String()))
+			return nil, domain.ErrPINLocked
+		}
+		return nil, domain.ErrPINIncorrect
+	}
+
+	if err := s.pins.ResetFailures(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).Error("failed to reset PIN attempts", ports.Err(err))
+	}
+	stored.FailedAttempts = 0
+	stored.LockedUntil = nil
+	return stored, nil
+}
+
+func (s *AuthService) hashPIN(pin string) (string, error) {
+	if err := domain.ValidatePIN(pin); err != nil {
+		return "", err
+	}
+	hash, err := s.passwordHasher.Hash(pin)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash PIN: %w", err)
+	}
+	return hash, nil
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// UpdateProfileRequest changes the fields that are set. An empty email
+// removes it.
+type UpdateProfileRequest struct {
+	FullName *string `json:"full_name"`
+	Email    *string `json:"email"`
+}
+
+// PhoneChangeRequest starts moving the account to a new phone number.
+type PhoneChangeRequest struct {
+	Phone string `json:"phone" validate:"required"`
+}
+
+// ConfirmPhoneChangeRequest completes a phone change with the OTP sent to
+// the new number.
+type ConfirmPhoneChangeRequest struct {
+	Phone string `json:"phone" validate:"required"`
+	Code  string `json:"code" validate:"required,len=6"`
+}
+
+// UpdateProfile changes the user's name and email.
+func (s *AuthService) UpdateProfile(ctx context.Context, userID uuid.UUID, req UpdateProfileRequest) (*UserProfile, error) {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.FullName == nil && req.Email == nil {
+		return s.GetProfile(ctx, userID)
+	}
+
+	fullName, email := user.FullName, user.Email
+	if req.FullName != nil {
+		fullName = strings.TrimSpace(*req.FullName)
+	}
+	if req.Email != nil {
+		email = strings.TrimSpace(*req.Email)
+	}
+	if err := user.UpdateProfile(fullName, email); err != nil {
+		return nil, err
+	}
+
+	if err := s.users.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	s.publishProfileUpdated(ctx, user, nil)
+
+	return s.GetProfile(ctx, userID)
+}
+
+// RequestPhoneChange sends an OTP to the new number. The number must be
+// valid, different from the current one and not used by another account.
+func (s *AuthService) RequestPhoneChange(ctx context.Context, userID uuid.UUID, req PhoneChangeRequest) error {
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Validate against a copy; the account changes only once the OTP is confirmed
+	candidate := *user
+	if err := candidate.ChangePhone(req.Phone); err != nil {
+		return err
+	}
+
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, req.Phone); err != nil {
+		return err
+	}
+
+	taken, err := s.users.ExistsByPhone(ctx, req.Phone)
+	if err != nil {
+		return fmt.Errorf("failed to check phone: %w", err)
+	}
+	if taken {
+		return domain.ErrUserAlreadyExists
+	}
+
+	otp := domain.NewOTP(domain.PhoneChangeOTPKey(user.ID, req.Phone), s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		return fmt.Errorf("failed to create OTP: %w", err)
+	}
+
+	if err := s.sendOTP(ctx, req.Phone, user.OTPChannel(), otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send phone change OTP", ports.Err(err))
+		return fmt.Errorf("failed to send OTP: %w", err)
+	}
+	return nil
+}
+
+// ConfirmPhoneChange verifies the OTP sent to the new number and moves the
+// account to it.
+func (s *AuthService) ConfirmPhoneChange(ctx context.Context, userID uuid.UUID, req ConfirmPhoneChangeRequest) (*UserProfile, error) {
+	key := domain.PhoneChangeOTPKey(userID, req.Phone)
+
+	if err := s.verifyKeyedOTP(ctx, key, req.Code); err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	previousPhone := user.Phone
+	if err := user.ChangePhone(req.Phone); err != nil {
+		return nil, err
+	}
+
+	// The unique index on phone catches another account claiming the
+	// number since the OTP was sent
+	if err := s.users.Update(ctx, user); err != nil {
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := s.otps.DeleteByPhone(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
+	}
+
+	s.logger.WithContext(ctx).Info("phone number changed", ports.String("user_id", user.ID.String()))
+	s.publishProfileUpdated(ctx, user, map[string]interface{}{"previous_phone": previousPhone})
+
+	return s.GetProfile(ctx, userID)
+}
+
+// publishProfileUpdated sends the user's new contact snapshot so services
+// holding a copy can update it.
+func (s *AuthService) publishProfileUpdated(ctx context.Context, user *domain.User, extra map[string]interface{}) {
+	payload := userPayload(user)
+	for key, value := range extra {
+		payload[key] = value
+	}
+
+	go func() {
+		event := ports.Event{Type: ports.EventProfileUpdated, Payload: payload}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+	}()
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// ProviderRoleRequest makes a user staff of a parking provider
+type ProviderRoleRequest struct {
+	ProviderID uuid.UUID           `json:"provider_id"`
+	Role       domain.ProviderRole `json:"role"`
+}
+
+// SetProviderStaff enables provider staff roles. Staff access tokens carry
+// their role and provider ID, which the provider service uses to scope its
+// portal to their own provider.
+func (s *AuthService) SetProviderStaff(staff ports.ProviderStaffRepository) {
+	s.providerStaff = staff
+}
+
+// GetProviderRole returns the user's provider role
+func (s *AuthService) GetProviderRole(ctx context.Context, userID uuid.UUID) (*domain.ProviderStaff, error) {
+	if s.providerStaff == nil {
+		return nil, domain.ErrProviderStaffNotFound
+	}
+	return s.providerStaff.Get(ctx, userID)
+}
+
+// GrantProviderRole makes the user staff of a provider, replacing any role
+// they had. Their access tokens are revoked so the next refresh picks up
+// the new scope.
+func (s *AuthService) GrantProviderRole(ctx context.Context, userID uuid.UUID, req ProviderRoleRequest) (*domain.ProviderStaff, error) {
+	adminID, err := requireAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.providerStaff == nil {
+		return nil, domain.ErrProviderStaffNotFound
+	}
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	staff, err := domain.NewProviderStaff(userID, req.ProviderID, req.Role, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.providerStaff.Get(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrProviderStaffNotFound) {
+		return nil, err
+	}
+	if before != nil {
+		staff.CreatedAt = before.CreatedAt
+	}
+	if err := s.providerStaff.Save(ctx, staff); err != nil {
+		return nil, err
+	}
+
+	// Already logged; the old scope lasts until the token expires
+	s.revokeUserTokens(ctx, userID)
+	s.recordProviderRoleChange(ctx, userID, ports.AuditProviderRoleGranted, before, staff)
+
+	s.logger.WithContext(ctx).Info("provider role granted",
+		ports.String("user_id", userID.String()),
+		ports.String("provider_id", staff.ProviderID.String()),
+		ports.String("role", string(staff.Role)),
+		ports.String("admin_id", adminID),
+	)
+	return staff, nil
+}
+
+// RevokeProviderRole removes the user from their provider's staff
+func (s *AuthService) RevokeProviderRole(ctx context.Context, userID uuid.UUID) error {
+	adminID, err := requireAdmin(ctx)
+	if err != nil {
+		return err
+	}
+	before, err := s.GetProviderRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.providerStaff.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	// Already logged; the old scope lasts until the token expires
+	s.revokeUserTokens(ctx, userID)
+	s.recordProviderRoleChange(ctx, userID, ports.AuditProviderRoleRevoked, before, nil)
+
+	s.logger.WithContext(ctx).Info("provider role revoked",
+		ports.String("user_id", userID.String()), ports.String("admin_id", adminID))
+	return nil
+}
+
+// SetAdmins names the users who run the back office. Their access tokens
+// carry the admin role, which the gateway requires on every admin route.
+func (s *AuthService) SetAdmins(userIDs []uuid.UUID) {
+	s.admins = make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		s.admins[id] = true
+	}
+}
+
+// requireAdmin returns the platform admin acting in ctx. Back-office
+// changes to users are refused to anyone else, even if a route forgets to
+// check the role.
+func requireAdmin(ctx context.Context) (string, error) {
+	adminID, ok := actor.UserWithRole(ctx, actor.RoleAdmin)
+	if !ok {
+		return "", domain.ErrAdminRequired
+	}
+	return adminID, nil
+}
+
+// generateAccessToken issues the user's access token, with the admin role
+// for platform admins or scoped to their provider when they are provider
+// staff. A failed lookup issues an unscoped token rather than blocking
+// sign-in.
+func (s *AuthService) generateAccessToken(ctx context.Context, user *domain.User, sessionID uuid.UUID) (string, error) {
+	if s.admins[user.ID] {
+		scope := ports.TokenScope{Role: domain.RoleAdmin}
+		return s.tokenService.GenerateScopedAccessToken(user.ID, user.Phone, sessionID, scope)
+	}
+	if s.providerStaff == nil {
+		return s.tokenService.GenerateAccessToken(user.ID, user.Phone, sessionID)
+	}
+
+	staff, err := s.providerStaff.Get(ctx, user.ID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrProviderStaffNotFound) {
+			s.logger.WithContext(ctx).Error("failed to get provider role",
+				ports.String("user_id", user.ID.String()), ports.Err(err))
+		}
+		return s.tokenService.GenerateAccessToken(user.ID, user.Phone, sessionID)
+	}
+
+	scope := ports.TokenScope{Role: string(staff.Role), ProviderID: staff.ProviderID}
+	return s.tokenService.GenerateScopedAccessToken(user.ID, user.Phone, sessionID, scope)
+}
+
+func (s *AuthService) recordProviderRoleChange(ctx context.Context, userID uuid.UUID, action string, before, after *domain.ProviderStaff) {
+	if s.auditLog == nil {
+		return
+	}
+	event := ports.AuditEvent{
+		Action:     action,
+		TargetType: "user",
+		TargetID:   userID.String(),
+		Before:     before,
+		After:      after,
+	}
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		s.logger.WithContext(ctx).Error("failed to write audit log", ports.String("action", action), ports.Err(err))
+	}
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// referralCodeAttempts bounds retries when a generated code collides
+const referralCodeAttempts = 3
+
+// SetReferrals enables referral codes. Users who register with a code are
+// attributed to its owner, and the wallet service rewards both once the
+// new user pays on user.referred.
+func (s *AuthService) SetReferrals(referrals ports.ReferralRepository) {
+	s.referrals = referrals
+}
+
+// GetReferralCode returns the user's referral code, creating it on first use
+func (s *AuthService) GetReferralCode(ctx context.Context, userID uuid.UUID) (*domain.ReferralCode, error) {
+	if s.referrals == nil {
+		return nil, domain.ErrReferralCodeNotFound
+	}
+	code, err := s.referrals.GetCode(ctx, userID)
+	if !errors.Is(err, domain.ErrReferralCodeNotFound) {
+		return code, err
+	}
+
+	for range referralCodeAttempts {
+		code, err = domain.NewReferralCode(userID, time.Now().UTC())
+		if err != nil {
+			return nil, err
+		}
+		err = s.referrals.CreateCode(ctx, code)
+		if errors.Is(err, domain.ErrReferralCodeTaken) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Read it back in case a concurrent request created a different one
+		return s.referrals.GetCode(ctx, userID)
+	}
+	return nil, fmt.Errorf("failed to create referral code: %w", err)
+}
+
+// referralCode looks up the code a registering user entered. Referrals are
+// tied to the registering device, so one is required with a code.
+func (s *AuthService) referralCode(ctx context.Context, code, deviceID string) (*domain.ReferralCode, error) {
+	code = domain.NormalizeReferralCode(code)
+	if code == "" || s.referrals == nil {
+		return nil, nil
+	}
+	if _, err := domain.NormalizeDeviceID(deviceID); err != nil {
+		return nil, err
+	}
+	return s.referrals.GetCodeByCode(ctx, code)
+}
+
+// attributeReferral records that user registered with code. A phone or
+// device that has been referred before, or a device already signed in to
+// an account, is registered without the referral so the same person can't
+// collect rewards over and over. Failures never fail the registration.
+func (s *AuthService) attributeReferral(ctx context.Context, code *domain.ReferralCode, user *domain.User, deviceID string) {
+	log := s.logger.WithContext(ctx)
+	referral, err := domain.NewReferral(code, user.ID, user.Phone, deviceID, time.Now().UTC())
+	if err != nil {
+		log.Warn("referral not attributed", ports.String("user_id", user.ID.String()), ports.Err(err))
+		return
+	}
+
+	seen, err := s.referrals.Seen(ctx, referral.PhoneHash, referral.DeviceID)
+	if err == nil && seen {
+		err = domain.ErrAlreadyReferred
+	}
+	if err == nil {
+		err = s.referrals.Create(ctx, referral)
+	}
+	if err != nil {
+		log.Warn("referral not attributed",
+			ports.String("user_id", user.ID.String()),
+			ports.String("referrer_id", code.UserID.String()),
+			ports.Err(err),
+		)
+		return
+	}
+
+	log.Info("referral attributed",
+		ports.String("user_id", user.ID.String()),
+		ports.String("referrer_id", code.UserID.String()),
+	)
+	go func() {
+		event := ports.Event{
+			Type: ports.EventUserReferred,
+			Payload: map[string]interface{}{
+				"referral_id": referral.ID.String(),
+				"referrer_id": referral.ReferrerID.String(),
+				"referee_id":  referral.RefereeID.String(),
+			},
+		}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			log.Error("failed to publish event", ports.Err(err))
+		}
+	}()
+}
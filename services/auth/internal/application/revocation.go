@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// SetTokenRevocation makes logout, logout from all devices, bans and
+// account erasure revoke access tokens already issued, instead of leaving
+// them valid until they expire.
+func (s *AuthService) SetTokenRevocation(revoker ports.TokenRevoker) {
+	s.revoker = revoker
+}
+
+// IsAccessTokenRevoked reports whether claims belong to a revoked token.
+// A failed check is logged and treated as not revoked, so an outage of the
+// denylist doesn't sign every user out.
+func (s *AuthService) IsAccessTokenRevoked(ctx context.Context, claims *ports.AccessTokenClaims) bool {
+	if s.revoker == nil {
+		return false
+	}
+	revoked, err := s.revoker.IsRevoked(ctx, claims)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to check access token revocation", ports.Err(err))
+		return false
+	}
+	return revoked
+}
+
+// revokeAccessToken revokes the token a request was made with. Logging out
+// still succeeds if it fails; the token expires on its own.
+func (s *AuthService) revokeAccessToken(ctx context.Context, claims *ports.AccessTokenClaims) {
+	if s.revoker == nil || claims == nil || claims.TokenID == "" {
+		return
+	}
+	if err := s.revoker.RevokeAccessToken(ctx, claims); err != nil {
+		s.logger.WithContext(ctx).Error("failed to revoke access token",
+			ports.String("user_id", claims.UserID.String()), ports.Err(err))
+	}
+}
+
+func (s *AuthService) revokeUserTokens(ctx context.Context, userID uuid.UUID) error {
+	if s.revoker == nil {
+		return nil
+	}
+	if err := s.revoker.RevokeUserTokens(ctx, userID); err != nil {
+		s.logger.WithContext(ctx).Error("failed to revoke access tokens",
+			ports.String("user_id", userID.String()), ports.Err(err))
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// SessionInfo describes one signed-in device.
+//
+// Each active refresh token is a session. Token rotation replaces the
+// refresh token on every refresh, so the session ID changes over time,
+// but there is always exactly one active token per signed-in device.
+type SessionInfo struct {
+	ID        uuid.UUID `json:"id"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Current   bool      `json:"current"` // True for the session making this request
+}
+
+// ListSessions returns the user's active sessions, newest first.
+// currentSessionID comes from the caller's access token and is used to
+// flag the device making the request.
+func (s *AuthService) ListSessions(ctx context.Context, userID, currentSessionID uuid.UUID) ([]*SessionInfo, error) {
+	tokens, err := s.tokens.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sessions := make([]*SessionInfo, 0, len(tokens))
+	for _, t := range tokens {
+		if !t.IsValid() {
+			continue
+		}
+		sessions = append(sessions, &SessionInfo{
+			ID:        t.ID,
+			UserAgent: t.UserAgent,
+			IPAddress: t.IPAddress,
+			CreatedAt: t.CreatedAt,
+			ExpiresAt: t.ExpiresAt,
+			Current:   t.ID == currentSessionID,
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs out one of the user's devices.
+//
+// SECURITY NOTE: Revoking the refresh token stops the device from getting
+// new access tokens, but an access token it already holds stays valid
+// until it expires (at most 15 minutes).
+//
+// Sessions belonging to other users are reported as not found, so the
+// endpoint can't be used to probe for valid session IDs.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	tokens, err := s.tokens.GetByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	var session *domain.RefreshToken
+	for _, t := range tokens {
+		if t.ID == sessionID && t.IsValid() {
+			session = t
+			break
+		}
+	}
+	if session == nil {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := s.tokens.Revoke(ctx, session.ID); err != nil {
+		s.logger.WithContext(ctx).Error("failed to revoke session", ports.Err(err))
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("session revoked",
+		ports.String("user_id", userID.String()),
+		ports.String("session_id", sessionID.String()))
+
+	return nil
+}
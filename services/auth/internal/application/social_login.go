@@ -0,0 +1,264 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// SetSocialLogin enables sign-in with external identity providers.
+// Only providers with a verifier are accepted; others are rejected with
+// ErrInvalidSocialProvider.
+func (s *AuthService) SetSocialLogin(identities ports.IdentityRepository, verifiers map[domain.SocialProvider]ports.IDTokenVerifier) {
+	s.identities = identities
+	s.idTokenVerifiers = verifiers
+}
+
+// SocialLoginRequest carries an ID token obtained from the provider's SDK.
+//
+// Phone and FullName are only needed the first time a user signs in with a
+// social account that can't be matched to an existing user. Our accounts
+// are keyed by Malaysian phone number, which Google and Apple don't give us.
+type SocialLoginRequest struct {
+	Provider string `json:"provider" validate:"required,oneof=google apple"`
+	IDToken  string `json:"id_token" validate:"required"`
+	Phone    string `json:"phone,omitempty"`
+	FullName string `json:"full_name,omitempty"`
+}
+
+// SocialLoginResponse contains tokens plus whether a new account was created.
+// New accounts start pending until the phone number is verified with OTP.
+type SocialLoginResponse struct {
+	LoginResponse
+	IsNewUser bool `json:"is_new_user"`
+}
+
+// SocialLogin signs a user in with a Google or Apple ID token.
+//
+// Flow:
+// 1. Verify the ID token with the provider's verifier
+// 2. If this identity is already linked, log that user in
+// 3. Otherwise link to an existing user with the same VERIFIED email or phone
+// 4. Otherwise create a new (pending) user, which requires a phone number
+// 5. Issue access and refresh tokens
+//
+// SECURITY NOTE: Account Linking
+// ==============================
+// Step 3 only uses contact details the provider has verified. Linking on an
+// unverified email would let an attacker create a Google account with a
+// victim's address and walk straight into the victim's parking account.
+func (s *AuthService) SocialLogin(ctx context.Context, req SocialLoginRequest, userAgent, ipAddress string) (*SocialLoginResponse, error) {
+	provider := domain.SocialProvider(req.Provider)
+	verifier, ok := s.idTokenVerifiers[provider]
+	if !ok || s.identities == nil {
+		return nil, domain.ErrInvalidSocialProvider
+	}
+
+	claims, err := verifier.Verify(ctx, req.IDToken)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("ID token verification failed", ports.Err(err), ports.String("provider", req.Provider))
+		return nil, domain.ErrInvalidIDToken
+	}
+	claims.Provider = provider
+
+	identity, err := s.identities.GetByProviderSubject(ctx, provider, claims.Subject)
+	if err != nil && !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	var user *domain.User
+	isNewUser := false
+
+	if identity != nil {
+		// Returning social user
+		user, err = s.users.GetByID(ctx, identity.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		if !user.CanLogin() {
+			return nil, domain.ErrUserInactive
+		}
+
+		identity.RecordLogin(claims)
+		if err := s.identities.Update(ctx, identity); err != nil {
+			// Not fatal - last login time is informational
+			s.logger.WithContext(ctx).Error("failed to update identity", ports.Err(err))
+		}
+	} else {
+		// First sign-in with this social account
+		user, err = s.findUserForClaims(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			user, err = s.createSocialUser(ctx, claims, req)
+			if err != nil {
+				return nil, err
+			}
+			isNewUser = true
+		}
+		if !user.CanLogin() {
+			return nil, domain.ErrUserInactive
+		}
+
+		identity, err = domain.NewUserIdentity(user.ID, claims)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.identities.Create(ctx, identity); err != nil {
+			s.logger.WithContext(ctx).Error("failed to link identity", ports.Err(err))
+			return nil, fmt.Errorf("failed to link identity: %w", err)
+		}
+
+		go func() {
+			event := ports.Event{
+				Type: ports.EventIdentityLinked,
+				Payload: map[string]interface{}{
+					"user_id":  user.ID.String(),
+					"provider": string(provider),
+					"new_user": isNewUser,
+				},
+			}
+			if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+				s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+			}
+		}()
+	}
+
+	tokens, err := s.issueTokens(ctx, user, userAgent, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("user logged in with social account",
+		ports.String("user_id", user.ID.String()),
+		ports.String("provider", req.Provider))
+
+	return &SocialLoginResponse{
+		LoginResponse: *tokens,
+		IsNewUser:     isNewUser,
+	}, nil
+}
+
+// findUserForClaims looks for an existing user that owns the verified
+// email or phone in the claims. Returns nil (and no error) if none match.
+func (s *AuthService) findUserForClaims(ctx context.Context, claims *domain.SocialClaims) (*domain.User, error) {
+	if email := claims.VerifiedEmail(); email != "" {
+		user, err := s.users.GetByEmail(ctx, email)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to get user by email: %w", err)
+		}
+	}
+
+	if phone := claims.VerifiedPhone(); phone != "" {
+		user, err := s.users.GetByPhone(ctx, phone)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, domain.ErrUserNotFound) {
+			return nil, fmt.Errorf("failed to get user by phone: %w", err)
+		}
+	}
+
+	return nil, nil
+}
+
+// createSocialUser registers a new user from a social sign-in.
+//
+// The account has no password (password login always fails until one is
+// set) and stays pending until the phone number is verified by OTP, just
+// like a normal registration.
+func (s *AuthService) createSocialUser(ctx context.Context, claims *domain.SocialClaims, req SocialLoginRequest) (*domain.User, error) {
+	phone := req.Phone
+	if phone == "" {
+		phone = claims.VerifiedPhone()
+	}
+	if phone == "" {
+		return nil, domain.ErrPhoneRequired
+	}
+
+	// The phone belongs to someone else and nothing in the token proves
+	// this person owns that account - they must log in and link instead.
+	exists, err := s.users.ExistsByPhone(ctx, phone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check user existence: %w", err)
+	}
+	if exists {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	fullName := req.FullName
+	if fullName == "" {
+		fullName = claims.Name
+	}
+
+	user, err := domain.NewUser(phone, claims.VerifiedEmail(), fullName, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid user data: %w", err)
+	}
+	if err := s.users.Create(ctx, user); err != nil {
+		s.logger.WithContext(ctx).Error("failed to create user", ports.Err(err))
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	// Verify the phone number the same way Register does
+	otp := domain.NewOTP(phone, s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		s.logger.WithContext(ctx).Error("failed to create OTP", ports.Err(err))
+	} else {
+		go func() {
+			if err := s.sendOTP(context.Background(), user.Phone, user.OTPChannel(), otp.Code); err != nil {
+				s.logger.WithContext(ctx).Error("failed to send OTP", ports.Err(err), ports.String("phone", phone))
+			}
+		}()
+	}
+
+	go func() {
+		payload := userPayload(user)
+		payload["provider"] = string(claims.Provider)
+		event := ports.Event{
+			Type:    ports.EventUserRegistered,
+			Payload: payload,
+		}
+		if err := s.events.Publish(context.WithoutCancel(ctx), event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to publish event", ports.Err(err))
+		}
+	}()
+
+	return user, nil
+}
+
+// issueTokens creates an access token and a stored refresh token for a user.
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User, userAgent, ipAddress string) (*LoginResponse, error) {
+	refreshToken, err := s.tokenService.GenerateRefreshToken()
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to generate refresh token", ports.Err(err))
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	tokenHash := s.tokenService.HashRefreshToken(refreshToken)
+	rt := domain.NewRefreshToken(user.ID, tokenHash, userAgent, ipAddress)
+
+	accessToken, err := s.generateAccessToken(ctx, user, rt.ID)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to generate access token", ports.Err(err))
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	if err := s.tokens.Create(ctx, rt); err != nil {
+		s.logger.WithContext(ctx).Error("failed to store refresh token", ports.Err(err))
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
+
+	return &LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    900, // 15 minutes in seconds
+		UserID:       user.ID,
+	}, nil
+}
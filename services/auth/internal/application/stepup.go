@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// StepUpChallengeResponse tells the caller how the user will confirm a
+// challenge.
+type StepUpChallengeResponse struct {
+	ChallengeID uuid.UUID `json:"challenge_id"`
+	Method      string    `json:"method"`
+	Channel     string    `json:"channel"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// VerifyStepUpRequest confirms a challenge with the OTP sent to the user.
+type VerifyStepUpRequest struct {
+	Code string `json:"code" validate:"required,len=6"`
+}
+
+// StepUpTokenResponse carries the token the user presents to the service
+// that asked for the challenge.
+type StepUpTokenResponse struct {
+	StepUpToken string    `json:"step_up_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// SetStepUp enables step-up challenges for sensitive actions in other
+// services. Challenges and their tokens expire after ttl.
+func (s *AuthService) SetStepUp(challenges ports.StepUpRepository, tokens ports.StepUpTokens, ttl time.Duration) {
+	s.stepUps = challenges
+	s.stepUpTokens = tokens
+	s.stepUpTTL = ttl
+}
+
+// CreateStepUpChallenge sends the user an OTP to confirm action. Other
+// services call this when an operation, such as a large payment, needs
+// more than a session.
+func (s *AuthService) CreateStepUpChallenge(ctx context.Context, userID uuid.UUID, action, amount string) (*StepUpChallengeResponse, error) {
+	if s.stepUps == nil {
+		return nil, domain.ErrChallengeNotFound
+	}
+	user, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanLogin() {
+		return nil, domain.ErrUserInactive
+	}
+	if err := s.checkRateLimit(ctx, s.otpRequestLimiter, user.Phone); err != nil {
+		return nil, err
+	}
+
+	challenge, err := domain.NewStepUpChallenge(user.ID, action, amount, time.Now().UTC(), s.stepUpTTL)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.stepUps.Create(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	otp := domain.NewOTP(domain.StepUpOTPKey(challenge.ID), s.otpGenerator.Generate())
+	if err := s.otps.Create(ctx, otp); err != nil {
+		return nil, fmt.Errorf("failed to create OTP: %w", err)
+	}
+	channel := user.OTPChannel()
+	if err := s.sendOTP(ctx, user.Phone, channel, otp.Code); err != nil {
+		s.logger.WithContext(ctx).Error("failed to send step-up OTP", ports.Err(err))
+		return nil, fmt.Errorf("failed to send OTP: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("step-up challenge created",
+		ports.String("user_id", user.ID.String()),
+		ports.String("challenge_id", challenge.ID.String()),
+		ports.String("action", challenge.Action))
+
+	return &StepUpChallengeResponse{
+		ChallengeID: challenge.ID,
+		Method:      "otp",
+		Channel:     string(channel),
+		ExpiresAt:   challenge.ExpiresAt,
+	}, nil
+}
+
+// VerifyStepUp checks the OTP for one of the user's challenges and returns
+// the token that lets the action go ahead.
+func (s *AuthService) VerifyStepUp(ctx context.Context, userID, challengeID uuid.UUID, req VerifyStepUpRequest) (*StepUpTokenResponse, error) {
+	if s.stepUps == nil {
+		return nil, domain.ErrChallengeNotFound
+	}
+	challenge, err := s.stepUps.GetByID(ctx, challengeID)
+	if err != nil {
+		return nil, err
+	}
+	// Someone else's challenge looks the same as a missing one
+	if challenge.UserID != userID {
+		return nil, domain.ErrChallengeNotFound
+	}
+
+	now := time.Now().UTC()
+	if challenge.IsExpired(now) {
+		return nil, domain.ErrChallengeExpired
+	}
+	key := domain.StepUpOTPKey(challenge.ID)
+	if err := s.verifyKeyedOTP(ctx, key, req.Code); err != nil {
+		return nil, err
+	}
+	if err := challenge.Verify(now); err != nil {
+		return nil, err
+	}
+	if err := s.stepUps.Verify(ctx, challenge); err != nil {
+		return nil, err
+	}
+	if err := s.otps.DeleteByPhone(ctx, key); err != nil {
+		s.logger.WithContext(ctx).Error("failed to delete OTPs", ports.Err(err))
+	}
+
+	token, err := s.stepUpTokens.Generate(challenge)
+	if err != nil {
+		return nil, err
+	}
+	return &StepUpTokenResponse{StepUpToken: token, ExpiresAt: challenge.ExpiresAt}, nil
+}
+
+// RedeemStepUpToken checks that token was issued to userID for this action
+// and amount, and uses it up so it cannot confirm anything else.
+func (s *AuthService) RedeemStepUpToken(ctx context.Context, token string, userID uuid.UUID, action, amount string) (uuid.UUID, error) {
+	if s.stepUps == nil {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+	claims, err := s.stepUpTokens.Validate(token)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidToken
+	}
+
+	challenge, err := s.stepUps.GetByID(ctx, claims.ChallengeID)
+	if err != nil {
+		if errors.Is(err, domain.ErrChallengeNotFound) {
+			return uuid.Nil, domain.ErrInvalidToken
+		}
+		return uuid.Nil, err
+	}
+	if err := challenge.Redeem(userID, action, amount, time.Now().UTC()); err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.stepUps.Redeem(ctx, challenge); err != nil {
+		return uuid.Nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("step-up token redeemed",
+		ports.String("user_id", userID.String()),
+		ports.String("challenge_id", challenge.ID.String()),
+		ports.String("action", challenge.Action))
+	return challenge.ID, nil
+}
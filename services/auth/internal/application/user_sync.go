@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/auth/internal/domain"
+	"github.com/parking-super-app/services/auth/internal/ports"
+)
+
+// userPayload is the contact snapshot other services keep a copy of. They
+// apply a snapshot only if its updated_at is newer than the one they hold,
+// so snapshots may arrive in any order.
+func userPayload(user *domain.User) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":    user.ID.String(),
+		"phone":      user.Phone,
+		"email":      user.Email,
+		"full_name":  user.FullName,
+		"status":     string(user.Status),
+		"kyc_level":  string(user.KYC()),
+		"updated_at": user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// deletedPayload is published in place of a snapshot once a user's data
+// has been erased. updated_at orders it after every earlier snapshot.
+func deletedPayload(user *domain.User) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":    user.ID.String(),
+		"updated_at": user.UpdatedAt.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// PublishUserSnapshots publishes a user.synced event for every user so
+// services that keep contact details can fill their copy for users who
+// registered before they started listening. Erased users get user.deleted
+// instead. It returns the number published.
+func (s *AuthService) PublishUserSnapshots(ctx context.Context, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+
+	published := 0
+	after := uuid.Nil
+	for {
+		users, err := s.users.ListAfter(ctx, after, batchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, user := range users {
+			event := ports.Event{Type: ports.EventUserSynced, Payload: userPayload(user)}
+			if user.IsDeleted() {
+				event = ports.Event{Type: ports.EventUserDeleted, Payload: deletedPayload(user)}
+			}
+			if err := s.events.Publish(ctx, event); err != nil {
+				return published, fmt.Errorf("failed to publish snapshot for user %s: %w", user.ID, err)
+			}
+			published++
+		}
+		if len(users) < batchSize {
+			return published, nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// RoleAdmin is the access token role of platform back-office staff. The
+// gateway lets only admin tokens reach the /api/v1/admin routes.
+const RoleAdmin = "admin"
+
+// ErrAdminRequired is returned when a back-office action is attempted by
+// anyone but a platform admin
+var ErrAdminRequired = errors.New("only platform admins can do this")
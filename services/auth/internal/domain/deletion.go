@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Account deletion errors
+var (
+	ErrDeletionNotFound   = errors.New("account deletion request not found")
+	ErrDeletionPending    = errors.New("account deletion already requested")
+	ErrDeletionNotPending = errors.New("account deletion is no longer pending")
+)
+
+// DefaultDeletionGracePeriod is how long a user has to change their mind
+// before their personal data is erased.
+const DefaultDeletionGracePeriod = 30 * 24 * time.Hour
+
+// UserStatusDeleted marks an account whose personal data has been erased.
+// It cannot sign in again.
+const UserStatusDeleted UserStatus = "deleted"
+
+// DeletionStatus is where an account deletion request stands.
+type DeletionStatus string
+
+const (
+	DeletionStatusPending   DeletionStatus = "pending"
+	DeletionStatusCancelled DeletionStatus = "cancelled"
+	DeletionStatusCompleted DeletionStatus = "completed"
+)
+
+// DeletionRequest is a user's confirmed request to delete their account.
+//
+// PDPA NOTE: The account stays usable until ScheduledFor so the user can
+// cancel. After that the user row is kept only as an anonymous tombstone,
+// because wallet and parking records still refer to its ID.
+type DeletionRequest struct {
+	ID           uuid.UUID      `json:"id"`
+	UserID       uuid.UUID      `json:"user_id"`
+	Status       DeletionStatus `json:"status"`
+	RequestedAt  time.Time      `json:"requested_at"`
+	ScheduledFor time.Time      `json:"scheduled_for"`
+	CancelledAt  *time.Time     `json:"cancelled_at,omitempty"`
+	CompletedAt  *time.Time     `json:"completed_at,omitempty"`
+}
+
+// NewDeletionRequest schedules erasure of the user's data after the grace period.
+func NewDeletionRequest(userID uuid.UUID, now time.Time, grace time.Duration) *DeletionRequest {
+	if grace < 0 {
+		grace = 0
+	}
+	return &DeletionRequest{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Status:       DeletionStatusPending,
+		RequestedAt:  now,
+		ScheduledFor: now.Add(grace),
+	}
+}
+
+// IsDue reports whether the grace period is over and the data can be erased.
+func (d *DeletionRequest) IsDue(now time.Time) bool {
+	return d.Status == DeletionStatusPending && !now.Before(d.ScheduledFor)
+}
+
+// Cancel withdraws the request during the grace period.
+func (d *DeletionRequest) Cancel(now time.Time) error {
+	if d.Status != DeletionStatusPending {
+		return ErrDeletionNotPending
+	}
+	d.Status = DeletionStatusCancelled
+	d.CancelledAt = &now
+	return nil
+}
+
+// Complete records that the user's data has been erased.
+func (d *DeletionRequest) Complete(now time.Time) error {
+	if d.Status != DeletionStatusPending {
+		return ErrDeletionNotPending
+	}
+	d.Status = DeletionStatusCompleted
+	d.CompletedAt = &now
+	return nil
+}
+
+// DeletionOTPKey is what the OTP confirming an account deletion is stored
+// under, kept apart from the sign-in OTP for the user's phone.
+func DeletionOTPKey(userID uuid.UUID) string {
+	return "account-deletion:" + userID.String()
+}
+
+// Anonymize erases the user's personal data, leaving only the ID. The phone
+// is replaced by a unique placeholder so the number can be registered again.
+func (u *User) Anonymize(now time.Time) {
+	u.Phone = "deleted:" + u.ID.String()
+	u.Email = ""
+	u.FullName = ""
+	u.PasswordHash = ""
+	u.KYCLevel = KYCLevelBasic
+	u.Status = UserStatusDeleted
+	u.UpdatedAt = now
+}
+
+// IsDeleted reports whether the user's data has been erased.
+func (u *User) IsDeleted() bool {
+	return u.Status == UserStatusDeleted
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDeletionRequest_Lifecycle(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name       string
+		apply      func(d *DeletionRequest) error
+		wantErr    error
+		wantStatus DeletionStatus
+	}{
+		{"cancel pending", func(d *DeletionRequest) error { return d.Cancel(now) }, nil, DeletionStatusCancelled},
+		{"complete pending", func(d *DeletionRequest) error { return d.Complete(now) }, nil, DeletionStatusCompleted},
+		{"cancel completed", func(d *DeletionRequest) error {
+			d.Complete(now)
+			return d.Cancel(now)
+		}, ErrDeletionNotPending, DeletionStatusCompleted},
+		{"complete cancelled", func(d *DeletionRequest) error {
+			d.Cancel(now)
+			return d.Complete(now)
+		}, ErrDeletionNotPending, DeletionStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeletionRequest(uuid.New(), now, DefaultDeletionGracePeriod)
+
+			if err := tt.apply(d); err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if d.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", d.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestDeletionRequest_IsDue(t *testing.T) {
+	now := time.Now().UTC()
+	d := NewDeletionRequest(uuid.New(), now, 24*time.Hour)
+
+	if d.IsDue(now.Add(23 * time.Hour)) {
+		t.Error("request should not be due during the grace period")
+	}
+	if !d.IsDue(now.Add(24 * time.Hour)) {
+		t.Error("request should be due once the grace period ends")
+	}
+
+	d.Cancel(now)
+	if d.IsDue(now.Add(48 * time.Hour)) {
+		t.Error("cancelled request should never be due")
+	}
+}
+
+func TestUser_Anonymize(t *testing.T) {
+	user, _ := NewUser("+60123456789", "ali@example.com", "Ali", "hash")
+	user.Activate()
+
+	user.Anonymize(time.Now().UTC())
+
+	if user.Phone == "+60123456789" || user.Email != "" || user.FullName != "" || user.PasswordHash != "" {
+		t.Errorf("personal data left after Anonymize(): %+v", user)
+	}
+	if !user.IsDeleted() || user.CanLogin() {
+		t.Error("anonymized user should be deleted and unable to log in")
+	}
+}
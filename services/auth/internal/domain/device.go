@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Device errors
+var (
+	ErrDeviceIDRequired = errors.New("device ID is required")
+	ErrDeviceNotFound   = errors.New("device not found")
+)
+
+// maxDeviceIDLength bounds the client-provided device ID we store
+const maxDeviceIDLength = 128
+
+// Device is a phone or browser the user has signed in from and confirmed
+// with an OTP. Signing in from anything else needs an OTP first.
+//
+// The client sends a stable device ID (e.g. the app's install ID) and a
+// fingerprint of the device's characteristics. A device ID copied to
+// another device won't carry the same fingerprint, so both must match.
+// Only a hash of the fingerprint is kept.
+type Device struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"-"`
+	DeviceID        string    `json:"device_id"`
+	FingerprintHash string    `json:"-"`
+	Name            string    `json:"name"` // User agent when last seen
+	LastIP          string    `json:"last_ip"`
+	FirstSeenAt     time.Time `json:"first_seen_at"`
+	LastSeenAt      time.Time `json:"last_seen_at"`
+}
+
+// NewDevice registers a device the user has just confirmed.
+func NewDevice(userID uuid.UUID, deviceID, fingerprint, userAgent, ip string, now time.Time) (*Device, error) {
+	deviceID, err := NormalizeDeviceID(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return &Device{
+		ID:              uuid.New(),
+		UserID:          userID,
+		DeviceID:        deviceID,
+		FingerprintHash: HashFingerprint(fingerprint),
+		Name:            userAgent,
+		LastIP:          ip,
+		FirstSeenAt:     now,
+		LastSeenAt:      now,
+	}, nil
+}
+
+// NormalizeDeviceID trims a client-provided device ID and checks it is usable.
+func NormalizeDeviceID(deviceID string) (string, error) {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" || len(deviceID) > maxDeviceIDLength {
+		return "", ErrDeviceIDRequired
+	}
+	return deviceID, nil
+}
+
+// HashFingerprint hashes a client fingerprint for storage and comparison.
+func HashFingerprint(fingerprint string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(fingerprint)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Matches reports whether a sign-in presenting fingerprint comes from this
+// device.
+func (d *Device) Matches(fingerprint string) bool {
+	return d.FingerprintHash == HashFingerprint(fingerprint)
+}
+
+// Seen records a sign-in from the device.
+func (d *Device) Seen(userAgent, ip string, now time.Time) {
+	d.Name = userAgent
+	d.LastIP = ip
+	d.LastSeenAt = now
+}
+
+// DeviceOTPKey is what the OTP confirming a sign-in from a new device is
+// stored under. It is only issued after the password was accepted, so a
+// valid code proves both.
+func DeviceOTPKey(userID uuid.UUID, deviceID string) string {
+	return "device-login:" + userID.String() + ":" + deviceID
+}
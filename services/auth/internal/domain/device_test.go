@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewDevice(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		want     string
+		wantErr  error
+	}{
+		{"valid", "install-123", "install-123", nil},
+		{"trimmed", "  install-123 ", "install-123", nil},
+		{"empty", "", "", ErrDeviceIDRequired},
+		{"blank", "   ", "", ErrDeviceIDRequired},
+		{"too long", strings.Repeat("a", 129), "", ErrDeviceIDRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDevice(uuid.New(), tt.deviceID, "fp", "app/1.0", "203.0.113.7", time.Now())
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && d.DeviceID != tt.want {
+				t.Errorf("DeviceID = %q, want %q", d.DeviceID, tt.want)
+			}
+		})
+	}
+}
+
+func TestDevice_Matches(t *testing.T) {
+	d, _ := NewDevice(uuid.New(), "install-123", "pixel-8|android-14", "app/1.0", "203.0.113.7", time.Now())
+
+	tests := []struct {
+		fingerprint string
+		want        bool
+	}{
+		{"pixel-8|android-14", true},
+		{" pixel-8|android-14 ", true},
+		{"iphone-15|ios-17", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := d.Matches(tt.fingerprint); got != tt.want {
+			t.Errorf("Matches(%q) = %v, want %v", tt.fingerprint, got, tt.want)
+		}
+	}
+	if d.FingerprintHash == "pixel-8|android-14" {
+		t.Error("fingerprint should be stored hashed")
+	}
+}
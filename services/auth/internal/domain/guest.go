@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// RoleGuest is the access token role of someone parking without an account
+const RoleGuest = "guest"
+
+// Guest checkout errors
+var (
+	ErrInvalidDeviceID       = errors.New("device ID must be 8 to 128 characters")
+	ErrGuestCheckoutDisabled = errors.New("guest checkout is not enabled")
+)
+
+// guestNamespace seeds guest IDs so they never collide with user IDs
+var guestNamespace = uuid.MustParse("6f1c2d3e-8a4b-4c5d-9e6f-7a8b9c0d1e2f")
+
+// ValidateDeviceID checks the identifier the app generated for its install
+func ValidateDeviceID(deviceID string) (string, error) {
+	deviceID = strings.TrimSpace(deviceID)
+	if len(deviceID) < 8 || len(deviceID) > 128 {
+		return "", ErrInvalidDeviceID
+	}
+	return deviceID, nil
+}
+
+// GuestID is the stable ID a device parks under as a guest. Every guest
+// token for the device carries it, so a guest can see sessions started
+// under an earlier token.
+func GuestID(deviceID string) uuid.UUID {
+	return uuid.NewSHA1(guestNamespace, []byte(deviceID))
+}
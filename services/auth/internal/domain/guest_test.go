@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateDeviceID(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		want     string
+		wantErr  error
+	}{
+		{"install ID", "b2f1c9e4-0d7a-4f3e", "b2f1c9e4-0d7a-4f3e", nil},
+		{"trimmed", "  device-1234  ", "device-1234", nil},
+		{"too short", "abc", "", ErrInvalidDeviceID},
+		{"blank", "          ", "", ErrInvalidDeviceID},
+		{"too long", strings.Repeat("x", 129), "", ErrInvalidDeviceID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateDeviceID(tt.deviceID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ValidateDeviceID() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateDeviceID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuestID(t *testing.T) {
+	if GuestID("device-1234") != GuestID("device-1234") {
+		t.Error("GuestID() differs for the same device")
+	}
+	if GuestID("device-1234") == GuestID("device-5678") {
+		t.Error("GuestID() is the same for different devices")
+	}
+}
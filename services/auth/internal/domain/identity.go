@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Social login errors
+var (
+	ErrInvalidSocialProvider = errors.New("unsupported social login provider")
+	ErrInvalidIDToken        = errors.New("invalid ID token")
+	ErrIdentityNotFound      = errors.New("linked identity not found")
+	ErrIdentityAlreadyLinked = errors.New("identity is already linked to an account")
+	ErrPhoneRequired         = errors.New("phone number is required to create an account")
+)
+
+// SocialProvider identifies an external OpenID Connect identity provider.
+type SocialProvider string
+
+const (
+	SocialProviderGoogle SocialProvider = "google"
+	SocialProviderApple  SocialProvider = "apple"
+)
+
+// IsValid checks that the provider is one we can verify tokens for.
+func (p SocialProvider) IsValid() bool {
+	return p == SocialProviderGoogle || p == SocialProviderApple
+}
+
+// SocialClaims are the identity facts extracted from a verified ID token.
+//
+// SECURITY NOTE: Only VERIFIED email/phone claims may be used to link a
+// social identity to an existing account. An unverified email is just a
+// string the user typed into Google or Apple - trusting it would let anyone
+// take over an account by claiming its email address.
+type SocialClaims struct {
+	Provider      SocialProvider
+	Subject       string // Stable, provider-unique user ID ("sub" claim)
+	Email         string
+	EmailVerified bool
+	Phone         string
+	PhoneVerified bool
+	Name          string
+}
+
+// VerifiedEmail returns the email if the provider vouches for it, or "".
+func (c *SocialClaims) VerifiedEmail() string {
+	if !c.EmailVerified {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(c.Email))
+}
+
+// VerifiedPhone returns the phone if the provider vouches for it, or "".
+func (c *SocialClaims) VerifiedPhone() string {
+	if !c.PhoneVerified {
+		return ""
+	}
+	return strings.TrimSpace(c.Phone)
+}
+
+// UserIdentity links a user account to an external identity provider.
+//
+// PATTERN: Entity
+// A user can have several identities (e.g., Google on Android and Apple
+// on iOS), but each (provider, subject) pair belongs to exactly one user.
+type UserIdentity struct {
+	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	Provider    SocialProvider `json:"provider"`
+	Subject     string         `json:"-"`
+	Email       string         `json:"email,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	LastLoginAt time.Time      `json:"last_login_at"`
+}
+
+// NewUserIdentity links the identity described by the claims to a user.
+func NewUserIdentity(userID uuid.UUID, claims *SocialClaims) (*UserIdentity, error) {
+	if !claims.Provider.IsValid() {
+		return nil, ErrInvalidSocialProvider
+	}
+	if claims.Subject == "" {
+		return nil, ErrInvalidIDToken
+	}
+
+	now := time.Now().UTC()
+	return &UserIdentity{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    claims.Provider,
+		Subject:     claims.Subject,
+		Email:       claims.VerifiedEmail(),
+		CreatedAt:   now,
+		LastLoginAt: now,
+	}, nil
+}
+
+// RecordLogin updates the identity after a successful social login.
+// Providers let users change their email, so we keep the latest verified one.
+func (i *UserIdentity) RecordLogin(claims *SocialClaims) {
+	if email := claims.VerifiedEmail(); email != "" {
+		i.Email = email
+	}
+	i.LastLoginAt = time.Now().UTC()
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestSocialProvider_IsValid(t *testing.T) {
+	tests := []struct {
+		provider SocialProvider
+		want     bool
+	}{
+		{SocialProviderGoogle, true},
+		{SocialProviderApple, true},
+		{SocialProvider("facebook"), false},
+		{SocialProvider(""), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.provider.IsValid(); got != tt.want {
+			t.Errorf("%q.IsValid() = %v, want %v", tt.provider, got, tt.want)
+		}
+	}
+}
+
+func TestSocialClaims_VerifiedContacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		claims    SocialClaims
+		wantEmail string
+		wantPhone string
+	}{
+		{
+			name:      "verified email is normalised",
+			claims:    SocialClaims{Email: " Jane@Example.com ", EmailVerified: true},
+			wantEmail: "jane@example.com",
+		},
+		{
+			name:   "unverified email is ignored",
+			claims: SocialClaims{Email: "jane@example.com"},
+		},
+		{
+			name:      "verified phone",
+			claims:    SocialClaims{Phone: "+60123456789", PhoneVerified: true},
+			wantPhone: "+60123456789",
+		},
+		{
+			name:   "unverified phone is ignored",
+			claims: SocialClaims{Phone: "+60123456789"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.claims.VerifiedEmail(); got != tt.wantEmail {
+				t.Errorf("VerifiedEmail() = %q, want %q", got, tt.wantEmail)
+			}
+			if got := tt.claims.VerifiedPhone(); got != tt.wantPhone {
+				t.Errorf("VerifiedPhone() = %q, want %q", got, tt.wantPhone)
+			}
+		})
+	}
+}
+
+func TestNewUserIdentity(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		claims  SocialClaims
+		wantErr error
+	}{
+		{
+			name:   "valid google identity",
+			claims: SocialClaims{Provider: SocialProviderGoogle, Subject: "1234", Email: "a@b.com", EmailVerified: true},
+		},
+		{
+			name:    "unknown provider",
+			claims:  SocialClaims{Provider: "facebook", Subject: "1234"},
+			wantErr: ErrInvalidSocialProvider,
+		},
+		{
+			name:    "missing subject",
+			claims:  SocialClaims{Provider: SocialProviderApple},
+			wantErr: ErrInvalidIDToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := NewUserIdentity(userID, &tt.claims)
+			if err != tt.wantErr {
+				t.Fatalf("NewUserIdentity() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if identity.UserID != userID {
+				t.Errorf("UserID = %v, want %v", identity.UserID, userID)
+			}
+			if identity.Subject != tt.claims.Subject {
+				t.Errorf("Subject = %q, want %q", identity.Subject, tt.claims.Subject)
+			}
+			if identity.Email != "a@b.com" {
+				t.Errorf("Email = %q, want a@b.com", identity.Email)
+			}
+		})
+	}
+}
+
+func TestUserIdentity_RecordLogin(t *testing.T) {
+	identity, _ := NewUserIdentity(uuid.New(), &SocialClaims{
+		Provider: SocialProviderApple, Subject: "abc", Email: "old@example.com", EmailVerified: true,
+	})
+	before := identity.LastLoginAt
+
+	identity.RecordLogin(&SocialClaims{Email: "new@example.com", EmailVerified: true})
+	if identity.Email != "new@example.com" {
+		t.Errorf("Email = %q, want new@example.com", identity.Email)
+	}
+
+	identity.RecordLogin(&SocialClaims{Email: "spoofed@example.com"})
+	if identity.Email != "new@example.com" {
+		t.Errorf("unverified email should not replace stored email, got %q", identity.Email)
+	}
+	if identity.LastLoginAt.Before(before) {
+		t.Error("LastLoginAt should move forward")
+	}
+}
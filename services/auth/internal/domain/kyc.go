@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// KYC errors
+var (
+	ErrInvalidICNumber         = errors.New("invalid MyKad number")
+	ErrSelfieRequired          = errors.New("selfie reference is required")
+	ErrKYCAlreadyVerified      = errors.New("identity is already verified")
+	ErrKYCPending              = errors.New("a verification submission is already under review")
+	ErrKYCNotPending           = errors.New("verification submission has already been reviewed")
+	ErrKYCSubmissionNotFound   = errors.New("verification submission not found")
+	ErrRejectionReasonRequired = errors.New("a reason is required to reject a submission")
+)
+
+// KYCLevel is how far a user's identity has been verified. The wallet
+// service caps balances, top-ups and payments by level.
+type KYCLevel string
+
+const (
+	KYCLevelBasic    KYCLevel = "basic"    // Phone verified only
+	KYCLevelVerified KYCLevel = "verified" // MyKad and selfie reviewed
+)
+
+// KYCStatus is where a verification submission stands.
+type KYCStatus string
+
+const (
+	KYCStatusPending  KYCStatus = "pending"
+	KYCStatusApproved KYCStatus = "approved"
+	KYCStatusRejected KYCStatus = "rejected"
+)
+
+// KYCSubmission is a user's request to be verified, reviewed by an admin.
+//
+// PDPA NOTE: The IC number is personal data. It is shown in full only to
+// reviewers and is deleted along with the account.
+type KYCSubmission struct {
+	ID          uuid.UUID  `json:"id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	ICNumber    string     `json:"ic_number"`
+	SelfieRef   string     `json:"selfie_ref"`
+	Status      KYCStatus  `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	ReviewedBy  string     `json:"reviewed_by,omitempty"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// NewKYCSubmission validates a MyKad number and selfie reference. The IC
+// number may be given with or without dashes; it is stored as 12 digits.
+func NewKYCSubmission(userID uuid.UUID, icNumber, selfieRef string, now time.Time) (*KYCSubmission, error) {
+	ic, ok := normalizeICNumber(icNumber)
+	if !ok {
+		return nil, ErrInvalidICNumber
+	}
+	selfieRef = strings.TrimSpace(selfieRef)
+	if selfieRef == "" {
+		return nil, ErrSelfieRequired
+	}
+
+	return &KYCSubmission{
+		ID:          uuid.New(),
+		UserID:      userID,
+		ICNumber:    ic,
+		SelfieRef:   selfieRef,
+		Status:      KYCStatusPending,
+		SubmittedAt: now,
+	}, nil
+}
+
+// Approve accepts the submission.
+func (k *KYCSubmission) Approve(reviewer string, now time.Time) error {
+	if k.Status != KYCStatusPending {
+		return ErrKYCNotPending
+	}
+	k.Status = KYCStatusApproved
+	k.ReviewedBy = reviewer
+	k.ReviewedAt = &now
+	return nil
+}
+
+// Reject declines the submission. The user may submit again.
+func (k *KYCSubmission) Reject(reviewer, reason string, now time.Time) error {
+	if k.Status != KYCStatusPending {
+		return ErrKYCNotPending
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrRejectionReasonRequired
+	}
+	k.Status = KYCStatusRejected
+	k.Reason = reason
+	k.ReviewedBy = reviewer
+	k.ReviewedAt = &now
+	return nil
+}
+
+// MaskedICNumber shows only the last four digits, for the user's own view.
+func (k *KYCSubmission) MaskedICNumber() string {
+	if len(k.ICNumber) != 12 {
+		return ""
+	}
+	return "******-**-" + k.ICNumber[8:]
+}
+
+// normalizeICNumber strips the dashes from a MyKad number (YYMMDD-PB-###G)
+// and checks its digits and birth date.
+func normalizeICNumber(ic string) (string, bool) {
+	ic = strings.ReplaceAll(strings.TrimSpace(ic), "-", "")
+	if len(ic) != 12 {
+		return "", false
+	}
+	for _, c := range ic {
+		if c < '0' || c > '9' {
+			return "", false
+		}
+	}
+	// Read as 20YY, which is a leap year whenever 19YY is (bar 1900)
+	if _, err := time.Parse("20060102", "20"+ic[:6]); err != nil {
+		return "", false
+	}
+	return ic, true
+}
+
+// KYC returns the user's verification level, basic unless it has been
+// verified.
+func (u *User) KYC() KYCLevel {
+	if u.KYCLevel == KYCLevelVerified {
+		return KYCLevelVerified
+	}
+	return KYCLevelBasic
+}
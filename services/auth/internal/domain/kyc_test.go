@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewKYCSubmission(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		ic      string
+		selfie  string
+		wantIC  string
+		wantErr error
+	}{
+		{"dashed", "900101-14-5678", "selfies/abc.jpg", "900101145678", nil},
+		{"digits only", "900101145678", "selfies/abc.jpg", "900101145678", nil},
+		{"leap day", "000229-10-1234", "selfies/abc.jpg", "000229101234", nil},
+		{"too short", "900101-14-567", "selfies/abc.jpg", "", ErrInvalidICNumber},
+		{"letters", "90010A-14-5678", "selfies/abc.jpg", "", ErrInvalidICNumber},
+		{"bad month", "901301-14-5678", "selfies/abc.jpg", "", ErrInvalidICNumber},
+		{"bad day", "900230-14-5678", "selfies/abc.jpg", "", ErrInvalidICNumber},
+		{"missing selfie", "900101-14-5678", "  ", "", ErrSelfieRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewKYCSubmission(uuid.New(), tt.ic, tt.selfie, now)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if k.ICNumber != tt.wantIC {
+				t.Errorf("ICNumber = %q, want %q", k.ICNumber, tt.wantIC)
+			}
+			if k.Status != KYCStatusPending {
+				t.Errorf("Status = %v, want %v", k.Status, KYCStatusPending)
+			}
+		})
+	}
+}
+
+func TestKYCSubmission_Review(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name       string
+		apply      func(k *KYCSubmission) error
+		wantErr    error
+		wantStatus KYCStatus
+	}{
+		{"approve pending", func(k *KYCSubmission) error { return k.Approve("admin", now) }, nil, KYCStatusApproved},
+		{"reject pending", func(k *KYCSubmission) error { return k.Reject("admin", "blurry selfie", now) }, nil, KYCStatusRejected},
+		{"reject without reason", func(k *KYCSubmission) error { return k.Reject("admin", " ", now) }, ErrRejectionReasonRequired, KYCStatusPending},
+		{"approve rejected", func(k *KYCSubmission) error {
+			k.Reject("admin", "blurry selfie", now)
+			return k.Approve("admin", now)
+		}, ErrKYCNotPending, KYCStatusRejected},
+		{"reject approved", func(k *KYCSubmission) error {
+			k.Approve("admin", now)
+			return k.Reject("admin", "changed my mind", now)
+		}, ErrKYCNotPending, KYCStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, _ := NewKYCSubmission(uuid.New(), "900101-14-5678", "selfies/abc.jpg", now)
+
+			if err := tt.apply(k); err != tt.wantErr {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+			if k.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", k.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestKYCSubmission_MaskedICNumber(t *testing.T) {
+	k, _ := NewKYCSubmission(uuid.New(), "900101-14-5678", "selfies/abc.jpg", time.Now())
+
+	if got := k.MaskedICNumber(); got != "******-**-5678" {
+		t.Errorf("MaskedICNumber() = %q, want %q", got, "******-**-5678")
+	}
+}
+
+func TestUser_KYC(t *testing.T) {
+	tests := []struct {
+		level KYCLevel
+		want  KYCLevel
+	}{
+		{KYCLevelVerified, KYCLevelVerified},
+		{KYCLevelBasic, KYCLevelBasic},
+		{"", KYCLevelBasic},
+	}
+
+	for _, tt := range tests {
+		u := &User{KYCLevel: tt.level}
+		if got := u.KYC(); got != tt.want {
+			t.Errorf("KYC() with level %q = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
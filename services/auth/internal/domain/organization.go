@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization errors
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrInvalidOrganization  = errors.New("organization name is required")
+	ErrNotOrganizationOwner = errors.New("only the organization owner can do this")
+	ErrMemberNotFound       = errors.New("organization member not found")
+	ErrAlreadyMember        = errors.New("user is already a member or invited")
+	ErrInvitationNotFound   = errors.New("organization invitation not found")
+	ErrCannotRemoveOwner    = errors.New("the organization owner cannot be removed")
+)
+
+// MemberRole is what a member may do in an organization
+type MemberRole string
+
+const (
+	// MemberRoleOwner manages members and the organization wallet
+	MemberRoleOwner MemberRole = "owner"
+	// MemberRoleMember can charge parking to the organization wallet
+	MemberRoleMember MemberRole = "member"
+)
+
+// MemberStatus tracks an invitation through to membership
+type MemberStatus string
+
+const (
+	MemberStatusInvited MemberStatus = "invited"
+	MemberStatusActive  MemberStatus = "active"
+)
+
+// Organization is a company or fleet whose members park on a shared wallet.
+// The user who creates it owns it.
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrgMember is a user's place in an organization. Invited users become
+// members once they accept.
+type OrgMember struct {
+	OrgID     uuid.UUID    `json:"org_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Role      MemberRole   `json:"role"`
+	Status    MemberStatus `json:"status"`
+	InvitedBy uuid.UUID    `json:"invited_by"`
+	InvitedAt time.Time    `json:"invited_at"`
+	JoinedAt  *time.Time   `json:"joined_at,omitempty"`
+}
+
+// NewOrganization creates an organization with ownerID as its first, active member.
+func NewOrganization(name string, ownerID uuid.UUID, now time.Time) (*Organization, *OrgMember, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, nil, ErrInvalidOrganization
+	}
+	org := &Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		OwnerID:   ownerID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	owner := &OrgMember{
+		OrgID:     org.ID,
+		UserID:    ownerID,
+		Role:      MemberRoleOwner,
+		Status:    MemberStatusActive,
+		InvitedBy: ownerID,
+		InvitedAt: now,
+		JoinedAt:  &now,
+	}
+	return org, owner, nil
+}
+
+// Invite returns a pending membership for userID. Only the owner may invite.
+func (o *Organization) Invite(invitedBy, userID uuid.UUID, now time.Time) (*OrgMember, error) {
+	if invitedBy != o.OwnerID {
+		return nil, ErrNotOrganizationOwner
+	}
+	return &OrgMember{
+		OrgID:     o.ID,
+		UserID:    userID,
+		Role:      MemberRoleMember,
+		Status:    MemberStatusInvited,
+		InvitedBy: invitedBy,
+		InvitedAt: now,
+	}, nil
+}
+
+// IsActive reports whether the member has joined
+func (m *OrgMember) IsActive() bool {
+	return m.Status == MemberStatusActive
+}
+
+// Accept turns an invitation into a membership.
+func (m *OrgMember) Accept(now time.Time) error {
+	if m.Status != MemberStatusInvited {
+		return ErrInvitationNotFound
+	}
+	m.Status = MemberStatusActive
+	m.JoinedAt = &now
+	return nil
+}
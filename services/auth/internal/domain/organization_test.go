@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewOrganization(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Now()
+
+	org, owner, err := NewOrganization("  Acme Fleet ", ownerID, now)
+	if err != nil {
+		t.Fatalf("NewOrganization() error = %v", err)
+	}
+	if org.Name != "Acme Fleet" {
+		t.Errorf("Name = %q, want it trimmed", org.Name)
+	}
+	if owner.UserID != ownerID || owner.Role != MemberRoleOwner || !owner.IsActive() {
+		t.Errorf("owner = %+v, want an active owner membership", owner)
+	}
+
+	if _, _, err := NewOrganization(" ", ownerID, now); err != ErrInvalidOrganization {
+		t.Errorf("NewOrganization(blank) error = %v, want %v", err, ErrInvalidOrganization)
+	}
+}
+
+func TestOrganization_Invite(t *testing.T) {
+	ownerID := uuid.New()
+	now := time.Now()
+	org, _, _ := NewOrganization("Acme Fleet", ownerID, now)
+
+	if _, err := org.Invite(uuid.New(), uuid.New(), now); err != ErrNotOrganizationOwner {
+		t.Errorf("Invite() by a non-owner error = %v, want %v", err, ErrNotOrganizationOwner)
+	}
+
+	member, err := org.Invite(ownerID, uuid.New(), now)
+	if err != nil {
+		t.Fatalf("Invite() error = %v", err)
+	}
+	if member.IsActive() || member.Role != MemberRoleMember {
+		t.Errorf("member = %+v, want a pending member invitation", member)
+	}
+
+	if err := member.Accept(now); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	if !member.IsActive() || member.JoinedAt == nil {
+		t.Errorf("member = %+v, want it active with a join time", member)
+	}
+	if err := member.Accept(now); err != ErrInvitationNotFound {
+		t.Errorf("Accept() twice error = %v, want %v", err, ErrInvitationNotFound)
+	}
+}
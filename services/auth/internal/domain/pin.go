@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Transaction PIN errors
+var (
+	ErrInvalidPIN    = errors.New("PIN must be 6 digits")
+	ErrWeakPIN       = errors.New("PIN is too easy to guess")
+	ErrPINNotSet     = errors.New("transaction PIN has not been set")
+	ErrPINAlreadySet = errors.New("transaction PIN is already set")
+	ErrPINIncorrect  = errors.New("incorrect PIN")
+	ErrPINLocked     = errors.New("too many incorrect PINs; try again later")
+)
+
+const pinLength = 6
+
+// Defaults for PINPolicy
+const (
+	DefaultPINMaxAttempts = 5
+	DefaultPINLockout     = 30 * time.Minute
+)
+
+// TransactionPIN is the 6-digit PIN a user enters to approve wallet
+// payments. Only a bcrypt hash is kept.
+type TransactionPIN struct {
+	UserID         uuid.UUID  `json:"-"`
+	Hash           string     `json:"-"`
+	FailedAttempts int        `json:"failed_attempts"`
+	LockedUntil    *time.Time `json:"locked_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// PINPolicy locks a PIN for Lockout after MaxAttempts wrong guesses in a row.
+type PINPolicy struct {
+	MaxAttempts int
+	Lockout     time.Duration
+}
+
+// ValidatePIN checks that pin is 6 digits and not a repeated digit or a
+// run like 123456.
+func ValidatePIN(pin string) error {
+	if len(pin) != pinLength {
+		return ErrInvalidPIN
+	}
+	for _, c := range pin {
+		if c < '0' || c > '9' {
+			return ErrInvalidPIN
+		}
+	}
+
+	same, up, down := true, true, true
+	for i := 1; i < len(pin); i++ {
+		step := int(pin[i]) - int(pin[i-1])
+		same = same && step == 0
+		up = up && step == 1
+		down = down && step == -1
+	}
+	if same || up || down {
+		return ErrWeakPIN
+	}
+	return nil
+}
+
+// NewTransactionPIN creates a PIN record from a hash made by the caller.
+func NewTransactionPIN(userID uuid.UUID, hash string, now time.Time) *TransactionPIN {
+	return &TransactionPIN{
+		UserID:    userID,
+		Hash:      hash,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// IsLocked reports whether PIN entry is locked out at now.
+func (p *TransactionPIN) IsLocked(now time.Time) bool {
+	return p.LockedUntil != nil && now.Before(*p.LockedUntil)
+}
+
+// Replace sets a new hash and clears any failed attempts and lockout.
+func (p *TransactionPIN) Replace(hash string, now time.Time) {
+	p.Hash = hash
+	p.FailedAttempts = 0
+	p.LockedUntil = nil
+	p.UpdatedAt = now
+}
+
+// PINResetOTPKey is what the OTP confirming a PIN reset is stored under.
+func PINResetOTPKey(userID uuid.UUID) string {
+	return "pin-reset:" + userID.String()
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestValidatePIN(t *testing.T) {
+	tests := []struct {
+		pin     string
+		wantErr error
+	}{
+		{"482915", nil},
+		{"102938", nil},
+		{"12345", ErrInvalidPIN},
+		{"1234567", ErrInvalidPIN},
+		{"12a456", ErrInvalidPIN},
+		{"", ErrInvalidPIN},
+		{"000000", ErrWeakPIN},
+		{"777777", ErrWeakPIN},
+		{"123456", ErrWeakPIN},
+		{"654321", ErrWeakPIN},
+		{"345678", ErrWeakPIN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pin, func(t *testing.T) {
+			if err := ValidatePIN(tt.pin); err != tt.wantErr {
+				t.Errorf("ValidatePIN(%q) = %v, want %v", tt.pin, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionPIN_Lockout(t *testing.T) {
+	now := time.Now().UTC()
+	pin := NewTransactionPIN(uuid.New(), "hash", now)
+
+	if pin.IsLocked(now) {
+		t.Fatal("new PIN should not be locked")
+	}
+
+	until := now.Add(DefaultPINLockout)
+	pin.FailedAttempts = 2
+	pin.LockedUntil = &until
+	if !pin.IsLocked(now.Add(time.Minute)) {
+		t.Error("PIN should be locked before LockedUntil")
+	}
+	if pin.IsLocked(until) {
+		t.Error("PIN should unlock at LockedUntil")
+	}
+
+	pin.Replace("new-hash", now)
+	if pin.IsLocked(now) || pin.FailedAttempts != 0 || pin.Hash != "new-hash" {
+		t.Errorf("Replace did not reset the PIN: %+v", pin)
+	}
+}
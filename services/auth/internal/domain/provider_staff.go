@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Provider staff errors
+var (
+	ErrInvalidProviderRole   = errors.New("invalid provider role")
+	ErrInvalidProviderID     = errors.New("provider ID is required")
+	ErrProviderStaffNotFound = errors.New("user is not provider staff")
+)
+
+// ProviderRole is what a parking operator's staff member may do in the
+// provider portal
+type ProviderRole string
+
+const (
+	// ProviderRoleAdmin manages the provider's locations
+	ProviderRoleAdmin ProviderRole = "provider_admin"
+	// ProviderRoleViewer sees locations and session reports only
+	ProviderRoleViewer ProviderRole = "provider_viewer"
+)
+
+// IsValid checks if the role is supported
+func (r ProviderRole) IsValid() bool {
+	switch r {
+	case ProviderRoleAdmin, ProviderRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProviderStaff links a user to the parking provider they work for. Their
+// access tokens carry the role and provider ID, which scope what they can
+// see in the provider portal.
+type ProviderStaff struct {
+	UserID     uuid.UUID    `json:"user_id"`
+	ProviderID uuid.UUID    `json:"provider_id"`
+	Role       ProviderRole `json:"role"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// NewProviderStaff makes userID a staff member of providerID.
+func NewProviderStaff(userID, providerID uuid.UUID, role ProviderRole, now time.Time) (*ProviderStaff, error) {
+	if providerID == uuid.Nil {
+		return nil, ErrInvalidProviderID
+	}
+	if !role.IsValid() {
+		return nil, ErrInvalidProviderRole
+	}
+	return &ProviderStaff{
+		UserID:     userID,
+		ProviderID: providerID,
+		Role:       role,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
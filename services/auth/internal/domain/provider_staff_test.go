@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewProviderStaff(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name       string
+		providerID uuid.UUID
+		role       ProviderRole
+		wantErr    error
+	}{
+		{"admin", uuid.New(), ProviderRoleAdmin, nil},
+		{"viewer", uuid.New(), ProviderRoleViewer, nil},
+		{"unknown role", uuid.New(), ProviderRole("owner"), ErrInvalidProviderRole},
+		{"no provider", uuid.Nil, ProviderRoleAdmin, ErrInvalidProviderID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staff, err := NewProviderStaff(uuid.New(), tt.providerID, tt.role, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewProviderStaff() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (staff.Role != tt.role || staff.ProviderID != tt.providerID) {
+				t.Errorf("NewProviderStaff() = %+v", staff)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Referral errors
+var (
+	ErrReferralCodeNotFound = errors.New("referral code not found")
+	ErrReferralCodeTaken    = errors.New("referral code is already in use")
+	ErrSelfReferral         = errors.New("users cannot refer themselves")
+	ErrAlreadyReferred      = errors.New("user, phone or device has already been referred")
+)
+
+// referralAlphabet leaves out 0/O and 1/I so codes read back unambiguously
+const (
+	referralAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	referralCodeLength = 8
+)
+
+// ReferralCode is the code a user shares to invite friends. Each user has
+// one, created the first time they ask for it.
+type ReferralCode struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Code      string    `json:"code"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewReferralCode generates a random code for userID
+func NewReferralCode(userID uuid.UUID, now time.Time) (*ReferralCode, error) {
+	buf := make([]byte, referralCodeLength)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate referral code: %w", err)
+	}
+	for i, b := range buf {
+		buf[i] = referralAlphabet[int(b)%len(referralAlphabet)]
+	}
+	return &ReferralCode{UserID: userID, Code: string(buf), CreatedAt: now}, nil
+}
+
+// NormalizeReferralCode puts a code typed by a user into stored form
+func NormalizeReferralCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Referral records that RefereeID registered with ReferrerID's code. The
+// referee's phone and device are kept so neither can be referred twice.
+type Referral struct {
+	ID         uuid.UUID `json:"id"`
+	ReferrerID uuid.UUID `json:"referrer_id"`
+	RefereeID  uuid.UUID `json:"referee_id"`
+	Code       string    `json:"code"`
+	PhoneHash  string    `json:"-"`
+	DeviceID   string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewReferral attributes the new user refereeID to the owner of code
+func NewReferral(code *ReferralCode, refereeID uuid.UUID, phone, deviceID string, now time.Time) (*Referral, error) {
+	if code.UserID == refereeID {
+		return nil, ErrSelfReferral
+	}
+	deviceID, err := NormalizeDeviceID(deviceID)
+	if err != nil {
+		return nil, err
+	}
+	return &Referral{
+		ID:         uuid.New(),
+		ReferrerID: code.UserID,
+		RefereeID:  refereeID,
+		Code:       code.Code,
+		PhoneHash:  HashPhone(phone),
+		DeviceID:   deviceID,
+		CreatedAt:  now,
+	}, nil
+}
+
+// HashPhone hashes a phone number so referrals can be matched on it
+// without keeping another copy of it
+func HashPhone(phone string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(phone)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewReferralCode(t *testing.T) {
+	code, err := NewReferralCode(uuid.New(), time.Now())
+	if err != nil {
+		t.Fatalf("NewReferralCode() error = %v", err)
+	}
+	if len(code.Code) != referralCodeLength {
+		t.Errorf("code length = %d, want %d", len(code.Code), referralCodeLength)
+	}
+	for _, c := range code.Code {
+		if !strings.ContainsRune(referralAlphabet, c) {
+			t.Errorf("code %q contains %q", code.Code, c)
+		}
+	}
+	if got := NormalizeReferralCode("  " + strings.ToLower(code.Code) + " "); got != code.Code {
+		t.Errorf("NormalizeReferralCode() = %q, want %q", got, code.Code)
+	}
+}
+
+func TestNewReferral(t *testing.T) {
+	code := &ReferralCode{UserID: uuid.New(), Code: "ABCD2345"}
+
+	tests := []struct {
+		name      string
+		refereeID uuid.UUID
+		deviceID  string
+		wantErr   error
+	}{
+		{"valid", uuid.New(), "device-1234", nil},
+		{"self referral", code.UserID, "device-1234", ErrSelfReferral},
+		{"no device", uuid.New(), " ", ErrDeviceIDRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := NewReferral(code, tt.refereeID, "+60123456789", tt.deviceID, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewReferral() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if ref.ReferrerID != code.UserID || ref.Code != code.Code {
+				t.Errorf("referral = %+v, want referrer %s", ref, code.UserID)
+			}
+			if ref.PhoneHash != HashPhone("+60123456789") || ref.PhoneHash == "+60123456789" {
+				t.Errorf("PhoneHash = %q, want the hashed phone", ref.PhoneHash)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Step-up errors
+var (
+	ErrStepUpActionRequired = errors.New("step-up action is required")
+	ErrChallengeNotFound    = errors.New("step-up challenge not found")
+	ErrChallengeExpired     = errors.New("step-up challenge has expired")
+	ErrChallengeNotPending  = errors.New("step-up challenge has already been confirmed")
+	ErrChallengeUsed        = errors.New("step-up token has already been used")
+	ErrChallengeMismatch    = errors.New("step-up token was issued for a different action")
+)
+
+// DefaultStepUpTTL is how long the user has to confirm a challenge and use
+// the token it gives them.
+const DefaultStepUpTTL = 5 * time.Minute
+
+// ChallengeStatus is where a step-up challenge stands.
+type ChallengeStatus string
+
+const (
+	ChallengeStatusPending  ChallengeStatus = "pending"  // OTP sent
+	ChallengeStatusVerified ChallengeStatus = "verified" // OTP confirmed, token issued
+	ChallengeStatusRedeemed ChallengeStatus = "redeemed" // Token used
+)
+
+// StepUpChallenge asks a signed-in user to confirm a sensitive action, such
+// as a large payment, with an OTP. Confirming it gives the user a token
+// that is good once, for that action and amount, until the challenge
+// expires.
+type StepUpChallenge struct {
+	ID         uuid.UUID       `json:"id"`
+	UserID     uuid.UUID       `json:"user_id"`
+	Action     string          `json:"action"`
+	Amount     string          `json:"amount"`
+	Status     ChallengeStatus `json:"status"`
+	CreatedAt  time.Time       `json:"created_at"`
+	ExpiresAt  time.Time       `json:"expires_at"`
+	VerifiedAt *time.Time      `json:"verified_at,omitempty"`
+	RedeemedAt *time.Time      `json:"redeemed_at,omitempty"`
+}
+
+// NewStepUpChallenge creates a challenge for action. The amount is compared
+// as given, so callers should format it the same way when redeeming.
+func NewStepUpChallenge(userID uuid.UUID, action, amount string, now time.Time, ttl time.Duration) (*StepUpChallenge, error) {
+	action = strings.TrimSpace(action)
+	if action == "" {
+		return nil, ErrStepUpActionRequired
+	}
+	if ttl <= 0 {
+		ttl = DefaultStepUpTTL
+	}
+	return &StepUpChallenge{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Action:    action,
+		Amount:    strings.TrimSpace(amount),
+		Status:    ChallengeStatusPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// IsExpired reports whether the challenge can no longer be confirmed or used.
+func (c *StepUpChallenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// Verify records that the user confirmed the challenge.
+func (c *StepUpChallenge) Verify(now time.Time) error {
+	if c.Status != ChallengeStatusPending {
+		return ErrChallengeNotPending
+	}
+	if c.IsExpired(now) {
+		return ErrChallengeExpired
+	}
+	c.Status = ChallengeStatusVerified
+	c.VerifiedAt = &now
+	return nil
+}
+
+// Redeem uses up the confirmed challenge for the given action. It fails if
+// the challenge was for another user, action or amount.
+func (c *StepUpChallenge) Redeem(userID uuid.UUID, action, amount string, now time.Time) error {
+	if c.UserID != userID || c.Action != strings.TrimSpace(action) || c.Amount != strings.TrimSpace(amount) {
+		return ErrChallengeMismatch
+	}
+	switch c.Status {
+	case ChallengeStatusRedeemed:
+		return ErrChallengeUsed
+	case ChallengeStatusPending:
+		return ErrInvalidToken
+	}
+	if c.IsExpired(now) {
+		return ErrChallengeExpired
+	}
+	c.Status = ChallengeStatusRedeemed
+	c.RedeemedAt = &now
+	return nil
+}
+
+// StepUpOTPKey is what the OTP confirming a step-up challenge is stored under.
+func StepUpOTPKey(challengeID uuid.UUID) string {
+	return "step-up:" + challengeID.String()
+}
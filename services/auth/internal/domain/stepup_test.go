@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewStepUpChallenge(t *testing.T) {
+	now := time.Now().UTC()
+	userID := uuid.New()
+
+	c, err := NewStepUpChallenge(userID, " wallet.payment ", "250.00", now, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Action != "wallet.payment" {
+		t.Errorf("Action = %q, want wallet.payment", c.Action)
+	}
+	if c.Status != ChallengeStatusPending {
+		t.Errorf("Status = %q, want pending", c.Status)
+	}
+	if !c.ExpiresAt.Equal(now.Add(DefaultStepUpTTL)) {
+		t.Errorf("ExpiresAt = %v, want %v", c.ExpiresAt, now.Add(DefaultStepUpTTL))
+	}
+
+	if _, err := NewStepUpChallenge(userID, "  ", "250.00", now, time.Minute); err != ErrStepUpActionRequired {
+		t.Errorf("error = %v, want ErrStepUpActionRequired", err)
+	}
+}
+
+func TestStepUpChallenge_Verify(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		status  ChallengeStatus
+		at      time.Time
+		wantErr error
+	}{
+		{"pending", ChallengeStatusPending, now.Add(time.Minute), nil},
+		{"expired", ChallengeStatusPending, now.Add(5 * time.Minute), ErrChallengeExpired},
+		{"already verified", ChallengeStatusVerified, now.Add(time.Minute), ErrChallengeNotPending},
+		{"redeemed", ChallengeStatusRedeemed, now.Add(time.Minute), ErrChallengeNotPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewStepUpChallenge(uuid.New(), "wallet.payment", "250.00", now, 5*time.Minute)
+			c.Status = tt.status
+
+			err := c.Verify(tt.at)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (c.Status != ChallengeStatusVerified || c.VerifiedAt == nil) {
+				t.Errorf("challenge not marked verified: %+v", c)
+			}
+		})
+	}
+}
+
+func TestStepUpChallenge_Redeem(t *testing.T) {
+	now := time.Now().UTC()
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		status  ChallengeStatus
+		userID  uuid.UUID
+		action  string
+		amount  string
+		at      time.Time
+		wantErr error
+	}{
+		{"verified", ChallengeStatusVerified, userID, "wallet.payment", "250.00", now.Add(time.Minute), nil},
+		{"not confirmed", ChallengeStatusPending, userID, "wallet.payment", "250.00", now.Add(time.Minute), ErrInvalidToken},
+		{"used", ChallengeStatusRedeemed, userID, "wallet.payment", "250.00", now.Add(time.Minute), ErrChallengeUsed},
+		{"expired", ChallengeStatusVerified, userID, "wallet.payment", "250.00", now.Add(5 * time.Minute), ErrChallengeExpired},
+		{"other user", ChallengeStatusVerified, uuid.New(), "wallet.payment", "250.00", now.Add(time.Minute), ErrChallengeMismatch},
+		{"other action", ChallengeStatusVerified, userID, "wallet.top_up", "250.00", now.Add(time.Minute), ErrChallengeMismatch},
+		{"other amount", ChallengeStatusVerified, userID, "wallet.payment", "251.00", now.Add(time.Minute), ErrChallengeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := NewStepUpChallenge(userID, "wallet.payment", "250.00", now, 5*time.Minute)
+			c.Status = tt.status
+
+			err := c.Redeem(tt.userID, tt.action, tt.amount, tt.at)
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (c.Status != ChallengeStatusRedeemed || c.RedeemedAt == nil) {
+				t.Errorf("challenge not marked redeemed: %+v", c)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ var (
 	ErrTokenExpired   = errors.New("token has expired")
 	ErrTokenRevoked   = errors.New("token has been revoked")
 	ErrInvalidToken   = errors.New("invalid token")
+	ErrSessionNotFound = errors.New("session not found")
 )
 
 // RefreshToken represents a refresh token stored in the database.
@@ -32,6 +32,11 @@ var (
 	ErrInvalidPhone       = errors.New("invalid phone format")
 	ErrWeakPassword       = errors.New("password must be at least 8 characters")
 	ErrUserInactive       = errors.New("user account is inactive")
+	ErrInvalidOTPChannel  = errors.New("invalid OTP channel")
+	ErrTooManyAttempts    = errors.New("too many attempts, please try again later")
+	ErrPhoneUnchanged     = errors.New("new phone number is the same as the current one")
+	ErrUserAlreadyBanned  = errors.New("user is already banned")
+	ErrUserNotBanned      = errors.New("user is not banned")
 )
 
 // UserStatus represents the possible states of a user account.
@@ -45,6 +50,23 @@ const (
 	UserStatusBanned   UserStatus = "banned"
 )
 
+// OTPChannel is the messaging channel used to deliver one-time passwords.
+//
+// Many Malaysian users prefer WhatsApp over SMS, so users can choose
+// where their OTPs are sent. SMS remains the default because every
+// phone can receive it, and it is the fallback if WhatsApp fails.
+type OTPChannel string
+
+const (
+	OTPChannelSMS      OTPChannel = "sms"
+	OTPChannelWhatsApp OTPChannel = "whatsapp"
+)
+
+// IsValid checks that the channel is one we know how to deliver to.
+func (c OTPChannel) IsValid() bool {
+	return c == OTPChannelSMS || c == OTPChannelWhatsApp
+}
+
 // User represents a user in our parking super app.
 //
 // DESIGN DECISION: Why use a struct with exported fields?
@@ -64,6 +86,13 @@ type User struct {
 	Status       UserStatus `json:"status"`
 	CreatedAt    time.Time  `json:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// PreferredOTPChannel is where OTPs are sent (defaults to SMS)
+	PreferredOTPChannel OTPChannel `json:"preferred_otp_channel"`
+
+	// KYCLevel is raised to verified once an admin approves the user's
+	// identity documents. It is only changed through KYC review.
+	KYCLevel KYCLevel `json:"kyc_level"`
 }
 
 // NewUser creates a new User entity with validation.
@@ -94,6 +123,9 @@ func NewUser(phone, email, fullName, passwordHash string) (*User, error) {
 		Status:       UserStatusPending, // New users start as pending (need OTP verification)
 		CreatedAt:    now,
 		UpdatedAt:    now,
+
+		PreferredOTPChannel: OTPChannelSMS,
+		KYCLevel:            KYCLevelBasic,
 	}, nil
 }
 
@@ -114,6 +146,28 @@ func (u *User) Deactivate() {
 	u.UpdatedAt = time.Now().UTC()
 }
 
+// Ban blocks the user from signing in. Erased accounts can't be banned.
+func (u *User) Ban() error {
+	switch u.Status {
+	case UserStatusDeleted:
+		return ErrUserNotFound
+	case UserStatusBanned:
+		return ErrUserAlreadyBanned
+	}
+	u.Status = UserStatusBanned
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Unban lifts a ban, leaving the user active.
+func (u *User) Unban() error {
+	if u.Status != UserStatusBanned {
+		return ErrUserNotBanned
+	}
+	u.Activate()
+	return nil
+}
+
 // IsActive checks if the user can perform actions.
 func (u *User) IsActive() bool {
 	return u.Status == UserStatusActive
@@ -136,6 +190,28 @@ func (u *User) UpdateProfile(fullName, email string) error {
 	return nil
 }
 
+// ChangePhone moves the account to a new phone number. The caller must
+// already have proven the user owns the new number.
+func (u *User) ChangePhone(phone string) error {
+	if !isValidMalaysianPhone(phone) {
+		return ErrInvalidPhone
+	}
+	if phone == u.Phone {
+		return ErrPhoneUnchanged
+	}
+
+	u.Phone = phone
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// PhoneChangeOTPKey is what a phone change OTP is stored under. It names
+// both the user and the new number, so the code can only confirm the change
+// it was sent for and never collides with a sign-in OTP for that number.
+func PhoneChangeOTPKey(userID uuid.UUID, phone string) string {
+	return "phone-change:" + userID.String() + ":" + phone
+}
+
 // UpdatePassword updates the user's password hash.
 // Note: Password hashing should be done in the application layer,
 // not here. This method just stores the already-hashed password.
@@ -144,6 +220,27 @@ func (u *User) UpdatePassword(newPasswordHash string) {
 	u.UpdatedAt = time.Now().UTC()
 }
 
+// SetPreferredOTPChannel changes where the user's OTPs are delivered.
+func (u *User) SetPreferredOTPChannel(channel OTPChannel) error {
+	if !channel.IsValid() {
+		return ErrInvalidOTPChannel
+	}
+
+	u.PreferredOTPChannel = channel
+	u.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// OTPChannel returns the channel to deliver OTPs on.
+// Users created before channel preferences existed have no value stored,
+// so they fall back to SMS.
+func (u *User) OTPChannel() OTPChannel {
+	if !u.PreferredOTPChannel.IsValid() {
+		return OTPChannelSMS
+	}
+	return u.PreferredOTPChannel
+}
+
 // Validation helpers - these are pure functions with no external dependencies
 
 // isValidMalaysianPhone validates Malaysian phone number format.
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"testing"
+
+	"github.com/google/uuid"
 )
 
 func TestNewUser(t *testing.T) {
@@ -170,3 +172,121 @@ func TestValidatePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUser_SetPreferredOTPChannel(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel OTPChannel
+		want    OTPChannel
+		wantErr error
+	}{
+		{"whatsapp", OTPChannelWhatsApp, OTPChannelWhatsApp, nil},
+		{"sms", OTPChannelSMS, OTPChannelSMS, nil},
+		{"unknown channel", OTPChannel("telegram"), OTPChannelSMS, ErrInvalidOTPChannel},
+		{"empty channel", OTPChannel(""), OTPChannelSMS, ErrInvalidOTPChannel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, _ := NewUser("+60123456789", "", "Test", "hash")
+
+			err := user.SetPreferredOTPChannel(tt.channel)
+			if err != tt.wantErr {
+				t.Errorf("SetPreferredOTPChannel() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := user.OTPChannel(); got != tt.want {
+				t.Errorf("OTPChannel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_OTPChannel_DefaultsToSMS(t *testing.T) {
+	// Users loaded from before channel preferences existed have no value
+	user := &User{}
+
+	if got := user.OTPChannel(); got != OTPChannelSMS {
+		t.Errorf("OTPChannel() = %v, want %v", got, OTPChannelSMS)
+	}
+}
+
+func TestUser_ChangePhone(t *testing.T) {
+	tests := []struct {
+		name    string
+		phone   string
+		want    string
+		wantErr error
+	}{
+		{"new number", "+60198765432", "+60198765432", nil},
+		{"same number", "+60123456789", "+60123456789", ErrPhoneUnchanged},
+		{"invalid number", "0198765432", "+60123456789", ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, _ := NewUser("+60123456789", "", "Test", "hash")
+
+			err := user.ChangePhone(tt.phone)
+			if err != tt.wantErr {
+				t.Errorf("ChangePhone() error = %v, want %v", err, tt.wantErr)
+			}
+			if user.Phone != tt.want {
+				t.Errorf("Phone = %v, want %v", user.Phone, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneChangeOTPKey(t *testing.T) {
+	alice, bob := uuid.New(), uuid.New()
+
+	if PhoneChangeOTPKey(alice, "+60198765432") == PhoneChangeOTPKey(bob, "+60198765432") {
+		t.Error("keys for different users should differ")
+	}
+	if PhoneChangeOTPKey(alice, "+60198765432") == "+60198765432" {
+		t.Error("key should not collide with the number's sign-in OTP")
+	}
+}
+
+func TestUser_Ban(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  UserStatus
+		wantErr error
+	}{
+		{"active user", UserStatusActive, nil},
+		{"pending user", UserStatusPending, nil},
+		{"already banned", UserStatusBanned, ErrUserAlreadyBanned},
+		{"erased user", UserStatusDeleted, ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, _ := NewUser("+60123456789", "", "Test", "hash")
+			user.Status = tt.status
+
+			err := user.Ban()
+			if err != tt.wantErr {
+				t.Fatalf("Ban() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && user.CanLogin() {
+				t.Error("banned user should not be able to login")
+			}
+		})
+	}
+}
+
+func TestUser_Unban(t *testing.T) {
+	user, _ := NewUser("+60123456789", "", "Test", "hash")
+	if err := user.Unban(); err != ErrUserNotBanned {
+		t.Fatalf("Unban() on a user who isn't banned error = %v, want %v", err, ErrUserNotBanned)
+	}
+
+	user.Ban()
+	if err := user.Unban(); err != nil {
+		t.Fatalf("Unban() error = %v", err)
+	}
+	if user.Status != UserStatusActive {
+		t.Errorf("status after Unban() = %v, want %v", user.Status, UserStatusActive)
+	}
+}
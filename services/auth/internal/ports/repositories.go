@@ -25,6 +25,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/auth/internal/domain"
@@ -69,6 +70,10 @@ type UserRepository interface {
 	// ExistsByPhone checks if a user with the given phone exists.
 	// This is more efficient than GetByPhone when we just need to check existence.
 	ExistsByPhone(ctx context.Context, phone string) (bool, error)
+
+	// ListAfter returns up to limit users with IDs after afterID, in ID order.
+	// Pass uuid.Nil for the first page.
+	ListAfter(ctx context.Context, afterID uuid.UUID, limit int) ([]*domain.User, error)
 }
 
 // RefreshTokenRepository defines the contract for refresh token persistence.
@@ -102,8 +107,8 @@ type RefreshTokenRepository interface {
 // OTPRepository defines the contract for OTP persistence.
 //
 // OTPs are temporary and should be cleaned up after expiration.
-// Consider using Redis for OTP storage in production for better
-// performance and automatic expiration.
+// Production uses Redis so every replica sees the same OTP state and
+// expired codes disappear on their own.
 type OTPRepository interface {
 	// Create stores a new OTP.
 	// Any existing OTPs for the same phone should be invalidated.
@@ -115,6 +120,15 @@ type OTPRepository interface {
 	// Update saves changes to an OTP (e.g., incrementing attempts).
 	Update(ctx context.Context, otp *domain.OTP) error
 
+	// IncrementAttempts atomically records a verification attempt for the
+	// phone's current OTP and returns the new attempt count.
+	//
+	// WHY ATOMIC?
+	// With several auth replicas, two guesses can be checked at the same
+	// time. Read-modify-write via Update would let both see "2 attempts"
+	// and both write "3", giving an attacker extra guesses.
+	IncrementAttempts(ctx context.Context, phone string) (int, error)
+
 	// DeleteByPhone removes all OTPs for a phone number.
 	// Called after successful verification.
 	DeleteByPhone(ctx context.Context, phone string) error
@@ -124,6 +138,184 @@ type OTPRepository interface {
 	DeleteExpired(ctx context.Context) error
 }
 
+// IdentityRepository defines the contract for linked social identity persistence.
+//
+// Each (provider, subject) pair is unique: one Google account can only
+// ever be linked to one user in our system.
+type IdentityRepository interface {
+	// Create links a new identity to a user.
+	Create(ctx context.Context, identity *domain.UserIdentity) error
+
+	// GetByProviderSubject finds the identity for a provider's user ID.
+	// Returns ErrIdentityNotFound if the identity hasn't been linked yet.
+	GetByProviderSubject(ctx context.Context, provider domain.SocialProvider, subject string) (*domain.UserIdentity, error)
+
+	// GetByUserID lists all identities linked to a user.
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.UserIdentity, error)
+
+	// Update saves changes to an identity (e.g., last login time).
+	Update(ctx context.Context, identity *domain.UserIdentity) error
+}
+
+// DeletionRepository defines the contract for account deletion requests.
+type DeletionRepository interface {
+	// Create stores a new request.
+	// Returns ErrDeletionPending if the user already has one open.
+	Create(ctx context.Context, req *domain.DeletionRequest) error
+
+	// GetLatestByUserID returns the user's most recent request.
+	GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.DeletionRequest, error)
+
+	// Cancel saves a cancelled request if it was still pending.
+	Cancel(ctx context.Context, req *domain.DeletionRequest) error
+
+	// ListDue returns pending requests whose grace period has ended.
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*domain.DeletionRequest, error)
+
+	// Erase saves the completed request and the anonymized user, and removes
+	// the user's identities, refresh tokens, devices, transaction PIN and KYC
+	// submissions, all or nothing.
+	Erase(ctx context.Context, req *domain.DeletionRequest, user *domain.User) error
+}
+
+// KYCRepository defines the contract for identity verification submissions.
+type KYCRepository interface {
+	// Create stores a new submission.
+	// Returns ErrKYCPending if the user already has one under review.
+	Create(ctx context.Context, submission *domain.KYCSubmission) error
+
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.KYCSubmission, error)
+
+	// GetLatestByUserID returns the user's most recent submission.
+	GetLatestByUserID(ctx context.Context, userID uuid.UUID) (*domain.KYCSubmission, error)
+
+	// ListByStatus returns submissions in a status, oldest first.
+	ListByStatus(ctx context.Context, status domain.KYCStatus, limit, offset int) ([]*domain.KYCSubmission, error)
+
+	// Review saves a reviewed submission if it was still pending, and for an
+	// approval raises the user's KYC level, all or nothing. It returns
+	// ErrKYCNotPending if another reviewer got there first.
+	Review(ctx context.Context, submission *domain.KYCSubmission, level domain.KYCLevel, now time.Time) error
+}
+
+// DeviceRepository defines the contract for the devices users have
+// confirmed they sign in from.
+type DeviceRepository interface {
+	// Get returns the user's device with a client device ID.
+	// Returns ErrDeviceNotFound if the user has not confirmed it.
+	Get(ctx context.Context, userID uuid.UUID, deviceID string) (*domain.Device, error)
+
+	// Save stores a device, replacing the user's record for the same
+	// device ID.
+	Save(ctx context.Context, device *domain.Device) error
+
+	// ListByUserID returns the user's devices, most recently seen first.
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Device, error)
+
+	// Delete forgets a device, so signing in from it needs an OTP again.
+	// Returns ErrDeviceNotFound if it isn't one of the user's.
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+}
+
+// StepUpRepository defines the contract for step-up challenge persistence.
+type StepUpRepository interface {
+	Create(ctx context.Context, challenge *domain.StepUpChallenge) error
+
+	// GetByID returns ErrChallengeNotFound if the challenge doesn't exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.StepUpChallenge, error)
+
+	// Verify saves a confirmed challenge if it was still pending.
+	// Returns ErrChallengeNotPending otherwise.
+	Verify(ctx context.Context, challenge *domain.StepUpChallenge) error
+
+	// Redeem saves a used challenge if it was confirmed and not yet used.
+	// Returns ErrChallengeUsed otherwise.
+	Redeem(ctx context.Context, challenge *domain.StepUpChallenge) error
+}
+
+// PINRepository defines the contract for transaction PIN persistence.
+type PINRepository interface {
+	// Get returns ErrPINNotSet if the user has no PIN.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.TransactionPIN, error)
+
+	// Create returns ErrPINAlreadySet if the user already has a PIN.
+	Create(ctx context.Context, pin *domain.TransactionPIN) error
+
+	Update(ctx context.Context, pin *domain.TransactionPIN) error
+
+	// ReserveAttempt atomically counts an attempt before the PIN is
+	// compared, starting the policy's lockout on the last allowed one, and
+	// returns the updated PIN. Returns ErrPINLocked if entry is locked.
+	ReserveAttempt(ctx context.Context, userID uuid.UUID, policy domain.PINPolicy, now time.Time) (*domain.TransactionPIN, error)
+
+	// ResetFailures clears the attempt count and any lockout after a
+	// correct PIN.
+	ResetFailures(ctx context.Context, userID uuid.UUID) error
+}
+
+// OrganizationRepository defines the contract for organizations and
+// their members.
+type OrganizationRepository interface {
+	// Create stores a new organization together with its owner's membership.
+	Create(ctx context.Context, org *domain.Organization, owner *domain.OrgMember) error
+
+	// GetByID returns ErrOrganizationNotFound if the organization doesn't exist.
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+
+	// GetMember returns ErrMemberNotFound if the user is neither a member
+	// nor invited.
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error)
+
+	// AddMember returns ErrAlreadyMember if the user is already a member
+	// or invited.
+	AddMember(ctx context.Context, member *domain.OrgMember) error
+
+	UpdateMember(ctx context.Context, member *domain.OrgMember) error
+
+	// RemoveMember returns ErrMemberNotFound if the user isn't a member.
+	RemoveMember(ctx context.Context, orgID, userID uuid.UUID) error
+
+	// ListMembers returns an organization's members and invitations,
+	// oldest first.
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error)
+
+	// ListByUserID returns the user's memberships and invitations.
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.OrgMember, error)
+}
+
+// ProviderStaffRepository defines the contract for provider staff roles.
+type ProviderStaffRepository interface {
+	// Get returns ErrProviderStaffNotFound if the user isn't provider staff.
+	Get(ctx context.Context, userID uuid.UUID) (*domain.ProviderStaff, error)
+
+	// Save grants the role, replacing the user's previous one.
+	Save(ctx context.Context, staff *domain.ProviderStaff) error
+
+	// Delete returns ErrProviderStaffNotFound if the user isn't provider staff.
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// ReferralRepository stores referral codes and attributions.
+type ReferralRepository interface {
+	// GetCode returns ErrReferralCodeNotFound if the user has no code yet.
+	GetCode(ctx context.Context, userID uuid.UUID) (*domain.ReferralCode, error)
+
+	// GetCodeByCode returns ErrReferralCodeNotFound for an unknown code.
+	GetCodeByCode(ctx context.Context, code string) (*domain.ReferralCode, error)
+
+	// CreateCode returns ErrReferralCodeTaken if the code belongs to
+	// someone else. If the user already has a code it is left as it is.
+	CreateCode(ctx context.Context, code *domain.ReferralCode) error
+
+	// Create returns ErrAlreadyReferred if the referee, their phone or
+	// their device has already been referred.
+	Create(ctx context.Context, referral *domain.Referral) error
+
+	// Seen reports whether the phone hash or device has been referred
+	// before, or the device has signed in to another account.
+	Seen(ctx context.Context, phoneHash, deviceID string) (bool, error)
+}
+
 // UnitOfWork provides transaction management across repositories.
 //
 // PATTERN: Unit of Work
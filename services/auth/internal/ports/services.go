@@ -5,6 +5,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/services/auth/internal/domain"
 )
 
 // PasswordHasher defines the contract for password hashing operations.
@@ -31,6 +34,10 @@ type PasswordHasher interface {
 // By defining an interface, our application layer doesn't know
 // (or care) whether we're using Twilio, AWS SNS, or a local
 // Malaysian SMS provider. This is the Dependency Inversion Principle.
+//
+// The same contract is used for other phone-number based channels
+// such as WhatsApp, so the application layer can route an OTP to
+// whichever channel the user prefers.
 type SMSService interface {
 	// SendOTP sends an OTP code to the given phone number.
 	// Returns an error if the SMS couldn't be sent.
@@ -48,7 +55,17 @@ type SMSService interface {
 type TokenService interface {
 	// GenerateAccessToken creates a new JWT access token for the user.
 	// The token contains claims like user ID, phone, and expiration.
-	GenerateAccessToken(userID uuid.UUID, phone string) (string, error)
+	// sessionID is the ID of the refresh token issued alongside it, which
+	// identifies the device session the access token belongs to.
+	GenerateAccessToken(userID uuid.UUID, phone string, sessionID uuid.UUID) (string, error)
+
+	// GenerateScopedAccessToken is GenerateAccessToken for provider staff:
+	// the token also carries their role and provider ID.
+	GenerateScopedAccessToken(userID uuid.UUID, phone string, sessionID uuid.UUID, scope TokenScope) (string, error)
+
+	// GenerateGuestToken creates an access token with the guest role for
+	// someone parking without an account, valid for ttl.
+	GenerateGuestToken(guestID uuid.UUID, deviceID string, ttl time.Duration) (string, error)
 
 	// ValidateAccessToken validates a JWT and returns the claims.
 	// Returns an error if the token is invalid or expired.
@@ -67,8 +84,54 @@ type TokenService interface {
 type AccessTokenClaims struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Phone     string    `json:"phone"`
+	SessionID uuid.UUID `json:"sid"` // uuid.Nil for tokens issued before sessions were tracked
 	ExpiresAt time.Time `json:"exp"`
 	IssuedAt  time.Time `json:"iat"`
+	TokenID   string    `json:"jti"` // empty for tokens issued before revocation was added
+
+	// Set for provider staff only
+	Role       string    `json:"role,omitempty"`
+	ProviderID uuid.UUID `json:"provider_id,omitempty"`
+
+	// Set for guests only
+	DeviceID string `json:"device_id,omitempty"`
+}
+
+// TokenScope limits an access token to one provider's portal.
+type TokenScope struct {
+	Role       string
+	ProviderID uuid.UUID
+}
+
+// TokenRevoker denylists access tokens so they stop working before they
+// expire.
+type TokenRevoker interface {
+	// RevokeAccessToken revokes a single access token.
+	RevokeAccessToken(ctx context.Context, claims *AccessTokenClaims) error
+
+	// RevokeUserTokens revokes every access token issued to the user so far.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID) error
+
+	// IsRevoked reports whether the token was revoked by either call.
+	IsRevoked(ctx context.Context, claims *AccessTokenClaims) (bool, error)
+}
+
+// StepUpTokens signs and checks the tokens given to users who confirm a
+// step-up challenge.
+type StepUpTokens interface {
+	Generate(challenge *domain.StepUpChallenge) (string, error)
+
+	// Validate checks the signature and expiry and returns the claims.
+	Validate(token string) (*StepUpClaims, error)
+}
+
+// StepUpClaims are what a step-up token vouches for.
+type StepUpClaims struct {
+	ChallengeID uuid.UUID
+	UserID      uuid.UUID
+	Action      string
+	Amount      string
+	ExpiresAt   time.Time
 }
 
 // OTPGenerator defines the contract for generating OTP codes.
@@ -81,6 +144,40 @@ type OTPGenerator interface {
 	Generate() string
 }
 
+// IDTokenVerifier verifies ID tokens issued by an OpenID Connect provider.
+//
+// SOCIAL LOGIN FLOW:
+// ==================
+// The mobile app signs the user in with Google or Apple using their native
+// SDK and receives an ID token (a signed JWT). The app sends that token to
+// us, and we verify it ourselves:
+// - Signature: checked against the provider's published public keys (JWKS)
+// - Issuer: must be the provider we expect
+// - Audience: must be one of OUR client IDs (otherwise a token minted for
+//   some other app could be replayed against us)
+// - Expiry: must not be expired
+//
+// We never trust claims from a token that fails any of these checks.
+type IDTokenVerifier interface {
+	// Verify validates the token and returns the identity claims.
+	// Returns ErrInvalidIDToken if the token fails verification.
+	Verify(ctx context.Context, idToken string) (*domain.SocialClaims, error)
+}
+
+// RateLimiter caps how often an action can be performed per key
+// (e.g., login attempts per phone number).
+//
+// MULTI-INSTANCE NOTE:
+// ====================
+// A counter kept in process memory only sees the requests that happen to
+// land on that replica. Behind a load balancer with N replicas an attacker
+// gets N times the budget. Production limiters keep counters in Redis.
+type RateLimiter interface {
+	// Allow records an attempt for key and reports whether it is permitted.
+	// Rejected attempts are not counted.
+	Allow(ctx context.Context, key string) (bool, error)
+}
+
 // EventPublisher defines the contract for publishing domain events.
 //
 // MICROSERVICES PATTERN: Event-Driven Architecture
@@ -120,30 +217,70 @@ const (
 	EventTokenRefreshed     = "user.token_refreshed"
 	EventOTPRequested       = "user.otp_requested"
 	EventOTPVerified        = "user.otp_verified"
+	EventIdentityLinked     = "user.identity_linked"
+	EventUserSynced         = "user.synced"
+	EventProfileUpdated     = "user.profile_updated"
+	EventUserDeleted        = "user.deleted"
+	EventKYCUpdated         = "user.kyc_updated"
+	EventNewDeviceLogin     = "user.new_device_login"
+	EventUserBanned         = "user.banned"
+	EventUserUnbanned       = "user.unbanned"
+	EventUserReferred       = "user.referred"
+	EventOrgCreated         = "org.created"
+	EventOrgMemberInvited   = "org.member.invited"
+	EventOrgMemberJoined    = "org.member.joined"
+	EventOrgMemberRemoved   = "org.member.removed"
+)
+
+// Notifier sends a notification through the notification service and waits
+// for the outcome, for messages the user should have before Kafka would
+// deliver an event.
+type Notifier interface {
+	SendTemplate(ctx context.Context, userID uuid.UUID, template, recipient string, variables map[string]string) error
+}
+
+// WelcomePushTemplate is the notification template sent when a user
+// registers from a device that gave its push token
+const WelcomePushTemplate = "user-welcome-push"
+
+// AuditLog records sensitive operations in the service's audit trail.
+type AuditLog interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+type AuditEvent = audit.Event
+
+// Audit actions
+const (
+	AuditKYCApproved  = "kyc.approved"
+	AuditKYCRejected  = "kyc.rejected"
+	AuditUserBanned   = "user.banned"
+	AuditUserUnbanned = "user.unbanned"
+
+	AuditProviderRoleGranted = "provider_role.granted"
+	AuditProviderRoleRevoked = "provider_role.revoked"
 )
 
 // Logger defines the contract for structured logging.
 //
 // We use an interface instead of a concrete logger so we can:
-// - Switch implementations (zap, zerolog, logrus)
+// - Switch implementations without touching use cases
 // - Mock in tests
 // - Add middleware (add trace IDs, user IDs to all logs)
-type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-
-	// WithFields returns a new logger with the given fields attached.
-	// All subsequent logs will include these fields.
-	WithFields(fields ...Field) Logger
-}
+//
+// It is an alias of the shared pkg/logging interface, so the slog-backed
+// logger every service uses satisfies it directly. Methods:
+// Debug/Info/Warn/Error(msg, fields...) and WithFields(fields...), which
+// returns a new logger with the fields attached to all subsequent logs.
+//
+// Use cases log through WithContext(ctx): the returned logger adds the
+// request ID, trace ID and user ID that the HTTP middleware and gRPC
+// interceptors stored in ctx, so log lines correlate without passing IDs
+// around by hand.
+type Logger = logging.Logger
 
 // Field represents a key-value pair for structured logging.
-type Field struct {
-	Key   string
-	Value interface{}
-}
+type Field = logging.Field
 
 // Helper functions for creating fields
 func String(key, value string) Field { return Field{Key: key, Value: value} }
@@ -0,0 +1,3 @@
+-- Rollback migration: Remove preferred OTP channel
+
+ALTER TABLE users DROP COLUMN IF EXISTS preferred_otp_channel;
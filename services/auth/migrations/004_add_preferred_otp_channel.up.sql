@@ -0,0 +1,12 @@
+-- Migration: Add preferred OTP channel to users
+-- Version: 004
+-- Description: Lets users receive OTPs via WhatsApp instead of SMS
+--
+-- Existing users keep SMS. If WhatsApp delivery fails, the service
+-- falls back to SMS, so this column is a preference, not a guarantee.
+
+ALTER TABLE users
+    ADD COLUMN preferred_otp_channel VARCHAR(20) NOT NULL DEFAULT 'sms'
+    CHECK (preferred_otp_channel IN ('sms', 'whatsapp'));
+
+COMMENT ON COLUMN users.preferred_otp_channel IS 'OTP delivery channel: sms, whatsapp';
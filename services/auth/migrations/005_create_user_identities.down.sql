@@ -0,0 +1,3 @@
+-- Rollback migration: Drop user identities table
+
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,30 @@
+-- Migration: Create user identities table
+-- Version: 005
+-- Description: Links user accounts to Google/Apple sign-in identities
+--
+-- A user can link several social accounts, but each provider account
+-- (provider + subject) maps to exactly one user. The subject is the
+-- provider's stable user ID; emails can change, subjects never do.
+
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+
+    -- Identity provider: google, apple
+    provider VARCHAR(20) NOT NULL CHECK (provider IN ('google', 'apple')),
+
+    -- The "sub" claim from the provider's ID token
+    subject VARCHAR(255) NOT NULL,
+
+    -- Latest verified email reported by the provider (may be an Apple relay address)
+    email VARCHAR(255) NOT NULL DEFAULT '',
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_login_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+COMMENT ON TABLE user_identities IS 'Social login identities linked to user accounts';
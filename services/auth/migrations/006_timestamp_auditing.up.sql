@@ -0,0 +1,12 @@
+-- Timestamp auditing
+-- ==================
+-- The users trigger from 001 keeps updated_at current. Also pin created_at
+-- so an UPDATE that rewrites every column can't accidentally change it.
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.created_at = OLD.created_at;
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS account_deletion_requests;
+
+-- Fails if erased accounts still hold placeholder phones
+ALTER TABLE users ALTER COLUMN phone TYPE VARCHAR(15);
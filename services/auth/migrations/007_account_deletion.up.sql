@@ -0,0 +1,31 @@
+-- Migration: Account deletion requests
+-- Version: 007
+-- Description: PDPA account deletion with a grace period
+--
+-- Erased accounts keep their row with a "deleted:<id>" placeholder phone,
+-- which is longer than a real number, so the column is widened.
+
+ALTER TABLE users ALTER COLUMN phone TYPE VARCHAR(64);
+
+CREATE TABLE account_deletion_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+
+    -- pending, cancelled, completed
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'cancelled', 'completed')),
+
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One open request per user
+CREATE UNIQUE INDEX idx_account_deletion_pending ON account_deletion_requests(user_id)
+    WHERE status = 'pending';
+
+-- The erasure job looks for requests whose grace period is over
+CREATE INDEX idx_account_deletion_due ON account_deletion_requests(scheduled_for)
+    WHERE status = 'pending';
+
+CREATE INDEX idx_account_deletion_user ON account_deletion_requests(user_id, requested_at DESC);
@@ -0,0 +1,57 @@
+-- Migration: KYC tiers
+-- Version: 008
+-- Description: Identity verification submissions and the user's KYC level
+--
+-- The wallet service caps balances and payments for users who are not
+-- verified, so the level is replicated to it in user events.
+
+ALTER TABLE users ADD COLUMN kyc_level VARCHAR(20) NOT NULL DEFAULT 'basic'
+    CHECK (kyc_level IN ('basic', 'verified'));
+
+CREATE TABLE kyc_submissions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+
+    -- MyKad number, 12 digits without dashes
+    ic_number VARCHAR(12) NOT NULL,
+    -- Where the selfie is kept in document storage
+    selfie_ref VARCHAR(500) NOT NULL,
+
+    -- pending, approved, rejected
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    reason TEXT NOT NULL DEFAULT '',
+    reviewed_by VARCHAR(255) NOT NULL DEFAULT '',
+
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reviewed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- One submission under review per user
+CREATE UNIQUE INDEX idx_kyc_submissions_pending ON kyc_submissions(user_id)
+    WHERE status = 'pending';
+
+-- The review queue, oldest first
+CREATE INDEX idx_kyc_submissions_status ON kyc_submissions(status, submitted_at);
+
+CREATE INDEX idx_kyc_submissions_user ON kyc_submissions(user_id, submitted_at DESC);
+
+-- Audit trail for sensitive operations such as KYC review; see pkg/audit
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    service VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(128) NOT NULL,
+    target_type VARCHAR(64) NOT NULL DEFAULT '',
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    before_state JSONB,
+    after_state JSONB,
+    changes JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_audit_log_occurred_at ON audit_log(occurred_at DESC);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor, occurred_at DESC);
+CREATE INDEX idx_audit_log_action ON audit_log(action, occurred_at DESC);
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id, occurred_at DESC);
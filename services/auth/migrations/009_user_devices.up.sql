@@ -0,0 +1,27 @@
+-- Migration: Device registry
+-- Version: 009
+-- Description: Devices users have confirmed with an OTP at sign-in
+--
+-- Signing in from a device that isn't listed here needs an OTP sent to the
+-- user's phone, and the user is alerted once it is confirmed.
+
+CREATE TABLE user_devices (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id),
+
+    -- Client-provided, stable per app install
+    device_id VARCHAR(128) NOT NULL,
+    -- SHA-256 of the client's device fingerprint
+    fingerprint_hash VARCHAR(64) NOT NULL,
+
+    -- User agent and IP of the latest sign-in
+    name TEXT NOT NULL DEFAULT '',
+    last_ip TEXT NOT NULL DEFAULT '',
+
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    UNIQUE (user_id, device_id)
+);
+
+CREATE INDEX idx_user_devices_user_id ON user_devices(user_id, last_seen_at DESC);
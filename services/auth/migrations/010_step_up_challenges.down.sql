@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_step_up_challenges_user;
+DROP TABLE IF EXISTS step_up_challenges;
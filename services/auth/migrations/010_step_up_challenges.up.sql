@@ -0,0 +1,27 @@
+-- Migration: Step-up challenges
+-- Version: 010
+-- Description: OTP challenges that confirm sensitive actions such as large
+-- wallet payments
+--
+-- A confirmed challenge gives the user a signed token. The service that
+-- asked for the challenge redeems the token once, for the action and
+-- amount recorded here.
+
+CREATE TABLE step_up_challenges (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+
+    -- e.g. wallet.payment
+    action VARCHAR(64) NOT NULL,
+    amount VARCHAR(32) NOT NULL DEFAULT '',
+
+    -- pending, verified, redeemed
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'verified', 'redeemed')),
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    verified_at TIMESTAMP WITH TIME ZONE,
+    redeemed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_step_up_challenges_user ON step_up_challenges(user_id, created_at DESC);
@@ -0,0 +1,20 @@
+-- Migration: Transaction PINs
+-- Version: 011
+-- Description: The 6-digit PIN users enter to approve wallet payments
+--
+-- The wallet service checks the PIN with auth over gRPC before it debits
+-- a wallet, so the hash never leaves this database.
+
+CREATE TABLE transaction_pins (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+
+    -- bcrypt hash
+    pin_hash VARCHAR(255) NOT NULL,
+
+    -- Wrong PINs in a row; reset on success and when a lockout starts
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    locked_until TIMESTAMP WITH TIME ZONE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,34 @@
+-- Migration: Organizations
+-- Version: 012
+-- Description: Companies and fleets whose members charge parking to a shared wallet
+--
+-- Auth owns membership. The wallet service keeps its own copy from
+-- org.* events to decide who may pay from the organization's wallet.
+
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_organizations_owner ON organizations(owner_id);
+
+CREATE TABLE org_members (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+
+    -- owner | member
+    role VARCHAR(20) NOT NULL,
+    -- invited | active
+    status VARCHAR(20) NOT NULL,
+
+    invited_by UUID NOT NULL REFERENCES users(id),
+    invited_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    joined_at TIMESTAMP WITH TIME ZONE,
+
+    PRIMARY KEY (org_id, user_id)
+);
+
+CREATE INDEX idx_org_members_user ON org_members(user_id);
@@ -0,0 +1,21 @@
+-- Migration: Provider staff
+-- Version: 013
+-- Description: Users who work for a parking provider and use its portal
+--
+-- A user works for at most one provider. The role and provider ID are put
+-- in the user's access tokens, so changes apply from their next token refresh.
+
+CREATE TABLE provider_staff (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+
+    -- The provider service owns providers, so there is no foreign key
+    provider_id UUID NOT NULL,
+
+    -- provider_admin or provider_viewer
+    role VARCHAR(20) NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_provider_staff_provider_id ON provider_staff(provider_id);
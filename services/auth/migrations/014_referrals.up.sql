@@ -0,0 +1,29 @@
+-- Migration: Referrals
+-- Version: 014
+-- Description: Referral codes and the users who registered with them
+--
+-- Each user has at most one code. A referee is attributed once, and their
+-- phone and device may only ever be referred once, so reinstalling the app
+-- or re-registering a number can't earn a second reward. The wallet
+-- service pays the rewards on user.referred.
+
+CREATE TABLE referral_codes (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    code VARCHAR(16) NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE TABLE referrals (
+    id UUID PRIMARY KEY,
+    referrer_id UUID NOT NULL REFERENCES users(id),
+    referee_id UUID NOT NULL UNIQUE REFERENCES users(id),
+    code VARCHAR(16) NOT NULL,
+
+    -- SHA-256 of the referee's phone number at registration
+    phone_hash VARCHAR(64) NOT NULL UNIQUE,
+    device_id VARCHAR(128) NOT NULL UNIQUE,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_referrals_referrer_id ON referrals(referrer_id, created_at DESC);
@@ -9,41 +9,63 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Quiet hours are read in each user's zone, which slim images may not ship
+	_ "time/tzdata"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/httpserver"
 	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/migrate"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/notification/config"
 	"github.com/parking-super-app/services/notification/internal/adapters/external"
+	grpcAdapter "github.com/parking-super-app/services/notification/internal/adapters/grpc"
 	httpAdapter "github.com/parking-super-app/services/notification/internal/adapters/http"
 	"github.com/parking-super-app/services/notification/internal/adapters/repository/postgres"
 	"github.com/parking-super-app/services/notification/internal/application"
-	"google.golang.org/grpc"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+	"github.com/parking-super-app/services/notification/migrations"
 )
 
 func main() {
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	logger := external.NewStdLogger()
+	logger := logging.New(cfg.Log)
 	logger.Info("starting notification service")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Values with a reload hook change on SIGHUP or a POST to
+	// settings.ReloadPath; everything else needs a restart
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("log level", logger.ReloadLevel)
+	reloader.WatchSignals(ctx)
+
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -52,6 +74,17 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			logger.Info("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Connect to database
 	pool, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
 	if err != nil {
@@ -59,14 +92,49 @@ func main() {
 	}
 	defer pool.Close()
 
-	if err := pool.Ping(ctx); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	if err := startup.Wait(ctx, cfg.Startup, logger, "postgres", startup.Postgres(pool)); err != nil {
+		log.Fatalf("failed to reach database: %v", err)
 	}
 	logger.Info("connected to database")
 
+	// Schema migrations are embedded in the binary. "notification-service migrate ..."
+	// manages them and exits; otherwise they can be applied before serving.
+	migrator, err := migrate.New(pool, migrations.FS, logger)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.RunCommand(ctx, migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("failed to run migrations: %v", err)
+		}
+		return
+	}
+	if cfg.Database.MigrateOnStart {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+	}
+
+	// Lag-tolerant reads go to the read replica when one is configured
+	readPool := pool
+	if cfg.Database.ReplicaURL != "" {
+		replica, err := pgxpool.New(ctx, cfg.Database.ReplicaURL)
+		if err != nil {
+			log.Fatalf("failed to connect to read replica: %v", err)
+		}
+		defer replica.Close()
+		if err := startup.Wait(ctx, cfg.Startup, logger, "postgres-replica", startup.Postgres(replica)); err != nil {
+			log.Fatalf("failed to reach read replica: %v", err)
+		}
+		readPool = replica
+		logger.Info("routing reads to database replica")
+	}
+
 	// Initialize repositories
-	notificationRepo := postgres.NewNotificationRepository(pool)
+	notificationRepo := postgres.NewNotificationRepository(pool, readPool)
 	preferenceRepo := postgres.NewPreferenceRepository(pool)
+	templateRepo := postgres.NewTemplateRepository(pool, readPool)
+	contactRepo := postgres.NewContactRepository(pool)
 
 	// Initialize providers
 	pushProvider := external.NewMockPushProvider()
@@ -76,71 +144,133 @@ func main() {
 	// Initialize application service
 	notificationService := application.NewNotificationService(
 		notificationRepo,
-		nil, // template repo
+		templateRepo,
 		preferenceRepo,
 		pushProvider,
 		smsProvider,
 		emailProvider,
 		logger,
+		application.DispatchConfig{
+			BatchSize: cfg.Dispatch.BatchSize,
+			Lease:     cfg.Dispatch.Lease,
+		},
 	)
+	templateService := application.NewTemplateService(templateRepo, logger)
+	contactService := application.NewContactService(contactRepo, logger)
+	notificationService.SetContacts(contactService)
+	userEraser := application.NewUserEraser(notificationRepo, preferenceRepo, contactRepo, logger)
 
-	// Initialize Kafka consumer for event-driven notifications
-	var kafkaConsumer *kafka.Consumer
-	if cfg.Kafka.Enabled && len(cfg.Kafka.Topics) > 0 {
-		// Create consumer for first topic (would need multiple consumers for multiple topics)
-		kafkaConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
-			cfg.Kafka.Brokers,
-			cfg.Kafka.Topics[0],
-			cfg.Kafka.ConsumerGroup,
-		))
-
-		// Register event handlers
-		kafkaConsumer.RegisterHandler("parking.session.started", func(ctx context.Context, event kafka.Event) error {
-			logger.Info("received parking session started event")
-			// Handle event - send notification to user
-			return nil
-		})
-
-		kafkaConsumer.RegisterHandler("parking.session.ended", func(ctx context.Context, event kafka.Event) error {
-			logger.Info("received parking session ended event")
-			// Handle event - send notification to user
-			return nil
-		})
-
-		kafkaConsumer.RegisterHandler("wallet.payment.completed", func(ctx context.Context, event kafka.Event) error {
-			logger.Info("received payment completed event")
-			// Handle event - send notification to user
-			return nil
-		})
-
-		// Start consumer in background
+	eventNotifier := application.NewEventNotifier(notificationService, templateRepo, contactService, logger)
+
+	// registerEventHandlers routes backend events to the services that handle
+	// them, for the consumers and for replays
+	registerEventHandlers := func(register func(string, kafka.EventHandler)) {
+		for _, eventType := range eventNotifier.EventTypes() {
+			register(eventType, func(ctx context.Context, event kafka.Event) error {
+				return eventNotifier.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		for _, eventType := range contactService.EventTypes() {
+			register(eventType, func(ctx context.Context, event kafka.Event) error {
+				return contactService.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		for _, eventType := range userEraser.EventTypes() {
+			register(eventType, func(ctx context.Context, event kafka.Event) error {
+				return userEraser.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+	}
+
+	// "notification-service replay -topic ..." hands a range of a topic to the
+	// event handlers again and exits. Use -dry-run first: replayed events
+	// send their notifications again.
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		replayCfg, err := kafka.ParseReplayArgs(os.Args[2:])
+		if err != nil {
+			log.Fatalf("invalid replay arguments: %v", err)
+		}
+		replayCfg.Brokers = cfg.Kafka.Brokers
+		replayer := kafka.NewReplayer(replayCfg)
+		registerEventHandlers(replayer.RegisterHandler)
+		if _, err := replayer.Run(ctx, os.Stdout); err != nil {
+			log.Fatalf("failed to replay events: %v", err)
+		}
+		return
+	}
+
+	// Sends notifications held back by quiet hours once the window ends
+	if cfg.Dispatch.Enabled {
 		go func() {
-			logger.Info("starting Kafka consumer")
-			if err := kafkaConsumer.Start(ctx); err != nil {
-				log.Printf("Kafka consumer error: %v", err)
+			ticker := time.NewTicker(cfg.Dispatch.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := notificationService.DispatchDue(ctx, now.UTC()); err != nil {
+						logger.Error("notification dispatch run failed", ports.Err(err))
+					}
+				}
 			}
 		}()
 	}
 
-	// Initialize HTTP router with tracing middleware
-	router := httpAdapter.NewRouter(notificationService)
+	// Kafka consumers turn backend events into notifications and keep the
+	// contact copy current from user events, one consumer per topic
+	var kafkaConsumers []*kafka.Consumer
+	if cfg.Kafka.Enabled && len(cfg.Kafka.Topics) > 0 {
+		if err := startup.Wait(ctx, cfg.Startup, logger, "kafka", startup.Kafka(cfg.Kafka.Brokers)); err != nil {
+			log.Fatalf("failed to reach Kafka: %v", err)
+		}
+		processed := kafka.NewPostgresProcessedStore(pool)
+		go kafka.PruneProcessed(ctx, processed, kafka.DefaultProcessedRetention)
+		for _, topic := range cfg.Kafka.Topics {
+			consumer := kafka.NewConsumer(kafka.DefaultConsumerConfig(
+				cfg.Kafka.Brokers,
+				topic,
+				cfg.Kafka.ConsumerGroup,
+			))
+			consumer.SetProcessedStore(processed)
+			registerEventHandlers(consumer.RegisterHandler)
+			kafkaConsumers = append(kafkaConsumers, consumer)
+
+			go func(topic string) {
+				logger.Info("starting Kafka consumer", ports.String("topic", topic))
+				if err := consumer.Start(ctx); err != nil {
+					log.Printf("Kafka consumer error: %v", err)
+				}
+			}(topic)
+		}
+	}
+
+	// Initialize HTTP router with request logging and tracing middleware.
+	// Tracing is added last so it wraps the request logger.
+	receiptVerifier := external.NewReceiptVerifier(map[domain.Channel]string{
+		domain.ChannelSMS:   cfg.Provider.SMSWebhookSecret,
+		domain.ChannelEmail: cfg.Provider.EmailWebhookSecret,
+		domain.ChannelPush:  cfg.Provider.PushWebhookSecret,
+	})
+	router := httpAdapter.NewRouter(notificationService, templateService, receiptVerifier)
+	router.Use(logging.RequestLogger(logger))
 	if cfg.OTEL.Enabled {
 		router.Use(middleware.Tracing(cfg.OTEL.ServiceName))
 	}
 
 	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	httpServer, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(router), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
 	// Create gRPC server
-	grpcServer := interceptors.NewServerWithDefaults()
-	// Register gRPC services when proto is generated
-	// notificationv1.RegisterNotificationServiceServer(grpcServer, notificationGRPCServer)
+	grpcTLS, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+	}
+	grpcServer := interceptors.NewServerWithDefaults(grpcTLS...)
+	notificationv1.RegisterNotificationServiceServer(grpcServer, grpcAdapter.NewNotificationServiceServer(notificationService))
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
@@ -181,9 +311,9 @@ func main() {
 	// Shutdown gRPC server
 	grpcServer.GracefulStop()
 
-	// Close Kafka consumer
-	if kafkaConsumer != nil {
-		if err := kafkaConsumer.Close(); err != nil {
+	// Close Kafka consumers
+	for _, consumer := range kafkaConsumers {
+		if err := consumer.Close(); err != nil {
 			log.Printf("failed to close Kafka consumer: %v", err)
 		}
 	}
@@ -194,6 +324,11 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	logger.Info("server stopped gracefully")
 }
@@ -5,23 +5,36 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
 )
 
 type Config struct {
 	Server   ServerConfig
+	Log      logging.Config
+	Startup  startup.Config
 	Database DatabaseConfig
 	GRPC     GRPCConfig
 	Kafka    KafkaConfig
 	OTEL     OTELConfig
 	Provider ProviderConfig
+	Dispatch DispatchConfig
 }
 
 type ServerConfig struct {
 	Port string
+	HTTP httpserver.Config
 }
 
 type GRPCConfig struct {
 	Port string
+	// TLS secures the gRPC listener and calls to other services
+	TLS mtls.Config
 }
 
 type DatabaseConfig struct {
@@ -31,6 +44,10 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool
+	// ReplicaURL is an optional read replica DSN for lag-tolerant queries
+	ReplicaURL string
 }
 
 type KafkaConfig struct {
@@ -45,6 +62,11 @@ type OTELConfig struct {
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
 }
 
 // ProviderConfig holds notification provider settings
@@ -52,6 +74,20 @@ type ProviderConfig struct {
 	SMS   string // "console", "twilio"
 	Email string // "console", "sendgrid"
 	Push  string // "console", "firebase"
+
+	// Shared secrets providers sign delivery receipts with. Receipts for a
+	// channel without one are rejected.
+	SMSWebhookSecret   string
+	EmailWebhookSecret string
+	PushWebhookSecret  string
+}
+
+// DispatchConfig controls the job that sends scheduled notifications
+type DispatchConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+	Lease     time.Duration // A claimed notification not sent within this is claimed again
 }
 
 func (d DatabaseConfig) ConnectionString() string {
@@ -62,27 +98,62 @@ func (d DatabaseConfig) ConnectionString() string {
 }
 
 func Load() (*Config, error) {
+	// Without CONFIG_STRICT these fall back to local development values
+	if err := settings.Require("DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME"); err != nil {
+		return nil, err
+	}
+
 	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
+	migrateOnStart, _ := strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	dispatchEnabled, _ := strconv.ParseBool(getEnv("DISPATCH_ENABLED", "true"))
 
 	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 	topics := strings.Split(getEnv("KAFKA_TOPICS", "parking.events,wallet.events,auth.events"), ",")
 
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	logCfg, err := logging.ConfigFromEnv("notification-service")
+	if err != nil {
+		return nil, err
+	}
+
+	startupCfg, err := startup.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
+		Log:     logCfg,
+		Startup: startupCfg,
 		GRPC: GRPCConfig{
 			Port: getEnv("GRPC_PORT", "9000"),
+			TLS:  grpcTLS,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5433"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "notification_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "5433"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "notification_db"),
+			SSLMode:        getEnv("DB_SSLMODE", "disable"),
+			MigrateOnStart: migrateOnStart,
+			ReplicaURL:     getEnv("DB_REPLICA_URL", ""),
 		},
 		Kafka: KafkaConfig{
 			Brokers:       brokers,
@@ -91,15 +162,28 @@ func Load() (*Config, error) {
 			Enabled:       kafkaEnabled,
 		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "notification-service"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "notification-service"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: getDurationEnv("OTEL_METRICS_INTERVAL", 30*time.Second),
+			RuntimeMetrics:  otelRuntimeMetrics,
 		},
 		Provider: ProviderConfig{
 			SMS:   getEnv("SMS_PROVIDER", "console"),
 			Email: getEnv("EMAIL_PROVIDER", "console"),
 			Push:  getEnv("PUSH_PROVIDER", "console"),
+
+			SMSWebhookSecret:   getEnv("SMS_WEBHOOK_SECRET", ""),
+			EmailWebhookSecret: getEnv("EMAIL_WEBHOOK_SECRET", ""),
+			PushWebhookSecret:  getEnv("PUSH_WEBHOOK_SECRET", ""),
+		},
+		Dispatch: DispatchConfig{
+			Enabled:   dispatchEnabled,
+			Interval:  getDurationEnv("DISPATCH_INTERVAL", time.Minute),
+			BatchSize: getIntEnv("DISPATCH_BATCH_SIZE", 100),
+			Lease:     getDurationEnv("DISPATCH_LEASE", 5*time.Minute),
 		},
 	}, nil
 }
@@ -110,3 +194,21 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,91 @@
+package external
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// ReceiptVerifier checks delivery callbacks signed with a shared secret per
+// channel. X-Signature carries "sha256=" and the hex HMAC-SHA256 of the body.
+type ReceiptVerifier struct {
+	secrets map[domain.Channel]string
+	now     func() time.Time
+}
+
+func NewReceiptVerifier(secrets map[domain.Channel]string) *ReceiptVerifier {
+	return &ReceiptVerifier{secrets: secrets, now: time.Now}
+}
+
+type receiptPayload struct {
+	Receipts []struct {
+		MessageID string `json:"message_id"`
+		Status    string `json:"status"`
+		Reason    string `json:"reason"`
+		Timestamp string `json:"timestamp"`
+	} `json:"receipts"`
+}
+
+// receiptStatuses maps provider states to outcomes; queued, sent and the
+// like are progress reports and are dropped
+var receiptStatuses = map[string]domain.Status{
+	"delivered":   domain.StatusDelivered,
+	"read":        domain.StatusDelivered,
+	"bounced":     domain.StatusBounced,
+	"failed":      domain.StatusFailed,
+	"undelivered": domain.StatusFailed,
+	"rejected":    domain.StatusFailed,
+	"expired":     domain.StatusFailed,
+}
+
+func (v *ReceiptVerifier) VerifyReceipts(channel domain.Channel, payload []byte, header http.Header) ([]ports.DeliveryReceipt, error) {
+	secret := v.secrets[channel]
+	if secret == "" {
+		return nil, fmt.Errorf("%w: no receipts accepted for channel %q", domain.ErrInvalidWebhook, channel)
+	}
+	signature, err := hex.DecodeString(strings.TrimPrefix(header.Get("X-Signature"), "sha256="))
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", domain.ErrInvalidWebhook)
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, fmt.Errorf("%w: signature mismatch", domain.ErrInvalidWebhook)
+	}
+
+	var body receiptPayload
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhook, err)
+	}
+
+	receipts := make([]ports.DeliveryReceipt, 0, len(body.Receipts))
+	for _, r := range body.Receipts {
+		status, ok := receiptStatuses[strings.ToLower(r.Status)]
+		if !ok || r.MessageID == "" {
+			continue
+		}
+		at := v.now().UTC()
+		if r.Timestamp != "" {
+			parsed, err := time.Parse(time.RFC3339, r.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("%w: bad timestamp %q", domain.ErrInvalidWebhook, r.Timestamp)
+			}
+			at = parsed.UTC()
+		}
+		receipts = append(receipts, ports.DeliveryReceipt{
+			ProviderID: r.MessageID,
+			Status:     status,
+			Reason:     r.Reason,
+			At:         at,
+		})
+	}
+	return receipts, nil
+}
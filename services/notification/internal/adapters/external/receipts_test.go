@@ -0,0 +1,53 @@
+package external
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/services/notification/internal/domain"
+)
+
+func TestReceiptVerifier_VerifyReceipts(t *testing.T) {
+	now := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	v := NewReceiptVerifier(map[domain.Channel]string{domain.ChannelSMS: "sms-secret"})
+	v.now = func() time.Time { return now }
+
+	payload := []byte(`{"receipts":[
+		{"message_id":"m-1","status":"delivered","timestamp":"2026-05-01T07:59:00Z"},
+		{"message_id":"m-2","status":"UNDELIVERED","reason":"handset off"},
+		{"message_id":"m-3","status":"queued"}
+	]}`)
+	sign := func(secret string) http.Header {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		header := http.Header{}
+		header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	receipts, err := v.VerifyReceipts(domain.ChannelSMS, payload, sign("sms-secret"))
+	if err != nil {
+		t.Fatalf("VerifyReceipts() error = %v", err)
+	}
+	if len(receipts) != 2 {
+		t.Fatalf("got %d receipts, want 2 (interim statuses dropped)", len(receipts))
+	}
+	if receipts[0].Status != domain.StatusDelivered || !receipts[0].At.Equal(now.Add(-time.Minute)) {
+		t.Errorf("receipts[0] = %+v", receipts[0])
+	}
+	if receipts[1].Status != domain.StatusFailed || receipts[1].Reason != "handset off" || !receipts[1].At.Equal(now) {
+		t.Errorf("receipts[1] = %+v", receipts[1])
+	}
+
+	if _, err := v.VerifyReceipts(domain.ChannelSMS, payload, sign("wrong")); !errors.Is(err, domain.ErrInvalidWebhook) {
+		t.Errorf("wrong secret error = %v, want %v", err, domain.ErrInvalidWebhook)
+	}
+	if _, err := v.VerifyReceipts(domain.ChannelEmail, payload, sign("sms-secret")); !errors.Is(err, domain.ErrInvalidWebhook) {
+		t.Errorf("channel without a secret error = %v, want %v", err, domain.ErrInvalidWebhook)
+	}
+}
@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"github.com/parking-super-app/services/notification/internal/application"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NotificationServiceServer implements notificationv1.NotificationServiceServer
+type NotificationServiceServer struct {
+	notificationv1.UnimplementedNotificationServiceServer
+	notificationService *application.NotificationService
+}
+
+// NewNotificationServiceServer creates a new gRPC server for the notification service
+func NewNotificationServiceServer(ns *application.NotificationService) *NotificationServiceServer {
+	return &NotificationServiceServer{notificationService: ns}
+}
+
+// Send delivers a notification written by the caller
+func (s *NotificationServiceServer) Send(ctx context.Context, req *notificationv1.SendRequest) (*notificationv1.SendResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	resp, err := s.notificationService.SendNotification(ctx, application.SendNotificationRequest{
+		UserID:    userID,
+		Channel:   req.Channel,
+		Type:      req.Type,
+		Title:     req.Title,
+		Body:      req.Body,
+		Recipient: req.Recipient,
+		Data:      req.Data,
+		Priority:  req.Priority,
+	})
+	if err != nil {
+		return nil, notificationError(err)
+	}
+	return toSendProto(resp), nil
+}
+
+// SendFromTemplate renders a named template for the user and delivers it
+func (s *NotificationServiceServer) SendFromTemplate(ctx context.Context, req *notificationv1.SendFromTemplateRequest) (*notificationv1.SendResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	if req.TemplateName == "" {
+		return nil, status.Error(codes.InvalidArgument, "template_name is required")
+	}
+
+	resp, err := s.notificationService.SendFromTemplate(ctx, application.SendFromTemplateRequest{
+		UserID:       userID,
+		TemplateName: req.TemplateName,
+		Recipient:    req.Recipient,
+		Variables:    req.Variables,
+	})
+	if err != nil {
+		return nil, notificationError(err)
+	}
+	return toSendProto(resp), nil
+}
+
+// GetPreferences retrieves the user's notification preferences
+func (s *NotificationServiceServer) GetPreferences(ctx context.Context, req *notificationv1.GetPreferencesRequest) (*notificationv1.PreferencesResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	pref, err := s.notificationService.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, notificationError(err)
+	}
+	resp := &notificationv1.PreferencesResponse{
+		UserId:            pref.UserID.String(),
+		PushEnabled:       pref.PushEnabled,
+		SmsEnabled:        pref.SMSEnabled,
+		EmailEnabled:      pref.EmailEnabled,
+		SimplifiedContent: pref.SimplifiedContent,
+		Timezone:          pref.Timezone,
+	}
+	if pref.QuietHoursStart != nil && pref.QuietHoursEnd != nil {
+		resp.QuietHoursEnabled = true
+		resp.QuietHoursStart = int32(*pref.QuietHoursStart)
+		resp.QuietHoursEnd = int32(*pref.QuietHoursEnd)
+	}
+	return resp, nil
+}
+
+// notificationError maps domain errors to gRPC status codes
+func notificationError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidChannel), errors.Is(err, domain.ErrInvalidRecipient):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrBlockedByPreference), errors.Is(err, domain.ErrRecipientUnknown):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		return status.Error(codes.NotFound, "template not found")
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toSendProto(n *application.NotificationResponse) *notificationv1.SendResponse {
+	resp := &notificationv1.SendResponse{
+		NotificationId: n.ID.String(),
+		Status:         n.Status,
+	}
+	if n.ScheduledAt != nil {
+		resp.ScheduledAt = *n.ScheduledAt
+	}
+	return resp
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	notificationv1 "github.com/parking-super-app/pkg/proto/notification/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestContracts verifies the routes and gRPC methods that other services rely on
+func TestContracts(t *testing.T) {
+	router := NewRouter(nil, nil, nil)
+	contract.Verify(t, contract.Provider{
+		Name:   "notification",
+		Routes: router.router,
+		Services: []protoreflect.ServiceDescriptor{
+			notificationv1.File_notification_v1_notification_proto.Services().ByName("NotificationService"),
+		},
+	})
+}
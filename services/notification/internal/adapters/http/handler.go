@@ -1,17 +1,20 @@
 package http
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/notification/internal/application"
 	"github.com/parking-super-app/services/notification/internal/domain"
 )
 
+const maxStatsWindow = 31 * 24 * time.Hour
+
 type NotificationHandler struct {
 	service *application.NotificationService
 }
@@ -20,33 +23,6 @@ func NewNotificationHandler(service *application.NotificationService) *Notificat
 	return &NotificationHandler{service: service}
 }
 
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   *APIError   `json:"error,omitempty"`
-}
-
-type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: status >= 200 && status < 300,
-		Data:    data,
-	})
-}
-
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: false,
-		Error:   &APIError{Code: code, Message: message},
-	})
-}
-
 func mapDomainError(err error) (int, string, string) {
 	switch {
 	case errors.Is(err, domain.ErrNotificationNotFound):
@@ -55,6 +31,22 @@ func mapDomainError(err error) (int, string, string) {
 		return http.StatusBadRequest, "INVALID_CHANNEL", "Invalid notification channel"
 	case errors.Is(err, domain.ErrInvalidRecipient):
 		return http.StatusBadRequest, "INVALID_RECIPIENT", "Invalid recipient"
+	case errors.Is(err, domain.ErrBlockedByPreference):
+		return http.StatusUnprocessableEntity, "NOTIFICATION_BLOCKED", "The user has turned off this notification"
+	case errors.Is(err, domain.ErrRecipientUnknown):
+		return http.StatusUnprocessableEntity, "RECIPIENT_UNKNOWN", "No recipient given and none on file for this channel"
+	case errors.Is(err, domain.ErrInvalidQuietHours):
+		return http.StatusBadRequest, "INVALID_QUIET_HOURS", "Quiet hours need a start hour 0-23 and a different end hour 0-24"
+	case errors.Is(err, domain.ErrInvalidTimezone):
+		return http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone must be an IANA zone name such as Asia/Kuala_Lumpur"
+	case errors.Is(err, domain.ErrInvalidStatus):
+		return http.StatusBadRequest, "INVALID_STATUS", "Status must be pending, sent, delivered, bounced or failed"
+	case errors.Is(err, domain.ErrInvalidDateRange):
+		return http.StatusBadRequest, "INVALID_DATE_RANGE", "from must be before to"
+	case errors.Is(err, domain.ErrQueryTooLong):
+		return http.StatusBadRequest, "QUERY_TOO_LONG", "Search query is too long"
+	case errors.Is(err, domain.ErrTemplateNotFound):
+		return http.StatusNotFound, "TEMPLATE_NOT_FOUND", "Template not found"
 	default:
 		return http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred"
 	}
@@ -62,138 +54,288 @@ func mapDomainError(err error) (int, string, string) {
 
 func (h *NotificationHandler) SendNotification(w http.ResponseWriter, r *http.Request) {
 	var req application.SendNotificationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	resp, err := h.service.SendNotification(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *NotificationHandler) SendFromTemplate(w http.ResponseWriter, r *http.Request) {
 	var req application.SendFromTemplateRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	resp, err := h.service.SendFromTemplate(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *NotificationHandler) GetNotification(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid notification ID")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid notification ID")
 		return
 	}
 
 	resp, err := h.service.GetNotification(r.Context(), id)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
+// GetUserNotifications lists the caller's notifications. It takes
+// ?type=, ?channel=, ?status=, ?from= and ?to= (RFC 3339) and ?q= to search
+// titles and bodies.
 func (h *NotificationHandler) GetUserNotifications(w http.ResponseWriter, r *http.Request) {
-	userIDStr := r.Header.Get("X-User-ID")
-	if userIDStr == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+	userID, ok := requireUserID(w, r)
+	if !ok {
 		return
 	}
+	h.listNotifications(w, r, userID)
+}
 
-	userID, err := uuid.Parse(userIDStr)
+// SearchUserNotifications lets support staff search a given user's
+// notifications, with the same parameters as GetUserNotifications
+func (h *NotificationHandler) SearchUserNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
 		return
 	}
+	h.listNotifications(w, r, userID)
+}
 
+func (h *NotificationHandler) listNotifications(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	q := r.URL.Query()
 	limit := 20
 	offset := 0
-	if l := r.URL.Query().Get("limit"); l != "" {
+	if l := q.Get("limit"); l != "" {
 		if parsed, err := strconv.Atoi(l); err == nil {
 			limit = parsed
 		}
 	}
-	if o := r.URL.Query().Get("offset"); o != "" {
+	if o := q.Get("offset"); o != "" {
 		if parsed, err := strconv.Atoi(o); err == nil {
 			offset = parsed
 		}
 	}
 
-	resp, err := h.service.GetUserNotifications(r.Context(), userID, limit, offset)
+	filter := domain.SearchFilter{
+		Type:    q.Get("type"),
+		Channel: domain.Channel(q.Get("channel")),
+		Status:  domain.Status(q.Get("status")),
+		Query:   q.Get("q"),
+	}
+	var ok bool
+	if filter.From, ok = timeParam(w, r, "from"); !ok {
+		return
+	}
+	if filter.To, ok = timeParam(w, r, "to"); !ok {
+		return
+	}
+
+	resp, err := h.service.GetUserNotifications(r.Context(), userID, filter, limit, offset)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.Header.Get("X-User-ID")
 	if userIDStr == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
 		return
 	}
 
 	resp, err := h.service.GetPreferences(r.Context(), userID)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		httpx.WriteError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
 	userIDStr := r.Header.Get("X-User-ID")
 	if userIDStr == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
 		return
 	}
 
 	userID, err := uuid.Parse(userIDStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
 		return
 	}
 
 	var req application.UpdatePreferenceRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 	req.UserID = userID
 
 	resp, err := h.service.UpdatePreferences(r.Context(), req)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		status, code, msg := mapDomainError(err)
+		if status == http.StatusInternalServerError {
+			msg = err.Error()
+		}
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid notification ID")
+		return
+	}
+
+	resp, err := h.service.MarkRead(r.Context(), userID, id)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.UnreadCount(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *NotificationHandler) RunDispatch(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.DispatchDue(r.Context(), time.Now().UTC())
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
+
+// DeliveryStats reports delivery outcomes per channel over ?window= (a
+// duration, default 24h, at most 31 days)
+func (h *NotificationHandler) DeliveryStats(w http.ResponseWriter, r *http.Request) {
+	window := 24 * time.Hour
+	if v := r.URL.Query().Get("window"); v != "" {
+		parsed, err := time.ParseDuration(v)
+		if err != nil || parsed <= 0 || parsed > maxStatsWindow {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WINDOW", "window must be a duration up to 744h")
+			return
+		}
+		window = parsed
+	}
+
+	resp, err := h.service.DeliveryStats(r.Context(), time.Now().UTC().Add(-window))
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// timeParam parses an optional RFC 3339 query parameter, writing the error
+// response when it is malformed
+func timeParam(w http.ResponseWriter, r *http.Request, name string) (*time.Time, bool) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_DATE", name+" must be an RFC 3339 time")
+		return nil, false
+	}
+	return &t, true
+}
+
+// requireUserID reads the caller's ID set by the gateway, writing the error
+// response when it is missing
+func requireUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID")
+		return uuid.Nil, false
+	}
+	return userID, true
 }
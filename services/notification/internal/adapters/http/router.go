@@ -5,56 +5,93 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/notification/internal/application"
+	"github.com/parking-super-app/services/notification/internal/ports"
 )
 
 type Router struct {
-	service *application.NotificationService
-	router  chi.Router
+	service         *application.NotificationService
+	templateService *application.TemplateService
+	receipts        ports.ReceiptVerifier
+	router          chi.Router
+	handler         http.Handler
 }
 
-func NewRouter(service *application.NotificationService) *Router {
+func NewRouter(service *application.NotificationService, templateService *application.TemplateService, receipts ports.ReceiptVerifier) *Router {
 	r := &Router{
-		service: service,
-		router:  chi.NewRouter(),
+		service:         service,
+		templateService: templateService,
+		receipts:        receipts,
+		router:          chi.NewRouter(),
 	}
 
 	r.setupMiddleware()
 	r.setupRoutes()
+	r.handler = r.router
 
 	return r
 }
 
+// Use wraps the router with additional middleware. Unlike chi's Use it can be
+// called after routes are registered; middlewares run in the order given.
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		r.handler = middlewares[i](r.handler)
+	}
+}
+
 func (r *Router) setupMiddleware() {
 	r.router.Use(middleware.RequestID)
 	r.router.Use(middleware.RealIP)
-	r.router.Use(middleware.Logger)
 	r.router.Use(middleware.Recoverer)
+	r.router.Use(deadline.FromHeader)
 	r.router.Use(middleware.AllowContentType("application/json"))
 
-	r.router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			next.ServeHTTP(w, req)
-		})
-	})
+	r.router.Use(httpx.SecurityHeaders)
+	r.router.Use(httpx.JSONContentType)
 }
 
 func (r *Router) setupRoutes() {
 	handler := NewNotificationHandler(r.service)
+	templateHandler := NewTemplateHandler(r.templateService)
+	webhookHandler := NewWebhookHandler(r.service, r.receipts)
 
 	r.router.Route("/api/v1/notifications", func(router chi.Router) {
 		router.Post("/", handler.SendNotification)
 		router.Post("/template", handler.SendFromTemplate)
 		router.Get("/", handler.GetUserNotifications)
+		router.Get("/unread-count", handler.UnreadCount)
+		router.Post("/read-all", handler.MarkAllRead)
 		router.Get("/{id}", handler.GetNotification)
+		router.Post("/{id}/read", handler.MarkRead)
+		router.Post("/webhooks/{channel}", webhookHandler.Receive)
 	})
 
 	r.router.Route("/api/v1/preferences", func(router chi.Router) {
-		router.Get("/", handler.GetPreferences)
+		router.With(httpx.ETag).Get("/", handler.GetPreferences)
 		router.Put("/", handler.UpdatePreferences)
 	})
 
+	r.router.Route("/api/v1/admin/notifications", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/dispatch", handler.RunDispatch)
+		router.Get("/stats", handler.DeliveryStats)
+		router.Get("/users/{userID}", handler.SearchUserNotifications)
+	})
+
+	r.router.Route("/api/v1/admin/templates", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/", templateHandler.CreateTemplate)
+		router.Get("/", templateHandler.ListTemplates)
+		router.Get("/{id}", templateHandler.GetTemplate)
+		router.Patch("/{id}", templateHandler.UpdateTemplate)
+		router.Delete("/{id}", templateHandler.DeleteTemplate)
+		router.Post("/{id}/preview", templateHandler.PreviewTemplate)
+	})
+
 	r.router.Get("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok"}`))
@@ -62,5 +99,5 @@ func (r *Router) setupRoutes() {
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/notification/internal/application"
+	"github.com/parking-super-app/services/notification/internal/domain"
+)
+
+type TemplateHandler struct {
+	service *application.TemplateService
+}
+
+func NewTemplateHandler(service *application.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func mapTemplateError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrTemplateAlreadyExists):
+		return http.StatusConflict, "TEMPLATE_EXISTS", "A template with this name already exists"
+	case errors.Is(err, domain.ErrInvalidTemplate):
+		return http.StatusBadRequest, "INVALID_TEMPLATE", "Template name and body are required"
+	case errors.Is(err, domain.ErrSimpleTextNotPlain):
+		return http.StatusBadRequest, "SIMPLE_TEXT_NOT_PLAIN", "Simple text must not contain emojis or markup"
+	case errors.Is(err, domain.ErrSimpleTextTooComplex):
+		return http.StatusBadRequest, "SIMPLE_TEXT_TOO_COMPLEX", "Simple text sentences must be 20 words or fewer"
+	case errors.Is(err, domain.ErrInvalidContentFormat):
+		return http.StatusBadRequest, "INVALID_FORMAT", "Format must be standard or simple"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *TemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req application.CreateTemplateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.service.CreateTemplate(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *TemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.service.ListTemplates(r.Context())
+	if err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	resp, err := h.service.GetTemplate(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	var req application.UpdateTemplateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.ID = id
+
+	resp, err := h.service.UpdateTemplate(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *TemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	if err := h.service.DeleteTemplate(r.Context(), id); err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *TemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid template ID")
+		return
+	}
+
+	var req application.PreviewTemplateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.ID = id
+
+	resp, err := h.service.PreviewTemplate(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapTemplateError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,56 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/notification/internal/application"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// maxWebhookBody caps receipt callbacks; providers batch at most a few hundred
+const maxWebhookBody = 256 << 10
+
+// WebhookHandler receives delivery receipts from SMS, push and email providers
+type WebhookHandler struct {
+	service  *application.NotificationService
+	verifier ports.ReceiptVerifier
+}
+
+func NewWebhookHandler(service *application.NotificationService, verifier ports.ReceiptVerifier) *WebhookHandler {
+	return &WebhookHandler{service: service, verifier: verifier}
+}
+
+// Receive verifies a provider callback and applies its receipts. Any non-2xx
+// answer makes the provider retry later.
+func (h *WebhookHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", "Webhook body too large")
+		return
+	}
+
+	channel := domain.Channel(chi.URLParam(r, "channel"))
+	receipts, err := h.verifier.VerifyReceipts(channel, payload, r.Header)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhook) {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WEBHOOK", "Webhook could not be verified")
+			return
+		}
+		httpx.WriteError(w, r, http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred")
+		return
+	}
+
+	result, err := h.service.ApplyReceipts(r.Context(), channel, receipts)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/notification/internal/domain"
+)
+
+type ContactRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewContactRepository(db *pgxpool.Pool) *ContactRepository {
+	return &ContactRepository{db: db}
+}
+
+func (r *ContactRepository) Upsert(ctx context.Context, c *domain.Contact) (bool, error) {
+	query := `
+		INSERT INTO user_contacts (user_id, phone, email, full_name, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO UPDATE
+		SET phone = EXCLUDED.phone, email = EXCLUDED.email,
+			full_name = EXCLUDED.full_name, updated_at = EXCLUDED.updated_at
+		WHERE user_contacts.updated_at < EXCLUDED.updated_at
+	`
+	tag, err := r.db.Exec(ctx, query, c.UserID, c.Phone, c.Email, c.FullName, c.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetByUserID reads from the primary: a contact is usually needed moments
+// after the event that created it
+func (r *ContactRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Contact, error) {
+	query := `
+		SELECT user_id, phone, email, full_name, updated_at
+		FROM user_contacts WHERE user_id = $1
+	`
+	var c domain.Contact
+	err := r.db.QueryRow(ctx, query, userID).Scan(&c.UserID, &c.Phone, &c.Email, &c.FullName, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrContactNotFound
+		}
+		return nil, err
+	}
+	return &c, nil
+}
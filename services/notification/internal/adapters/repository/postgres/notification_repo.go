@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -11,12 +12,15 @@ import (
 	"github.com/parking-super-app/services/notification/internal/domain"
 )
 
+// NotificationRepository serves inbox reads from replica, which is the
+// primary itself when no read replica is configured
 type NotificationRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
 }
 
-func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepository {
-	return &NotificationRepository{db: db}
+func NewNotificationRepository(db, replica *pgxpool.Pool) *NotificationRepository {
+	return &NotificationRepository{db: db, replica: replica}
 }
 
 func (r *NotificationRepository) Create(ctx context.Context, notif *domain.Notification) error {
@@ -41,22 +45,51 @@ func (r *NotificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 	query := `
 		SELECT id, user_id, channel, type, title, body, data, priority,
 			status, recipient, provider_id, scheduled_at, sent_at,
-			delivered_at, failed_at, error_msg, created_at
+			delivered_at, failed_at, error_msg, read_at, created_at
 		FROM notifications WHERE id = $1
 	`
-	return r.scanNotification(r.db.QueryRow(ctx, query, id))
+	return r.scanNotification(r.replica.QueryRow(ctx, query, id))
 }
 
-func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Notification, error) {
+// GetByProviderID finds a notification from a provider's receipt. It reads the
+// primary since receipts can arrive moments after the send is recorded.
+func (r *NotificationRepository) GetByProviderID(ctx context.Context, channel domain.Channel, providerID string) (*domain.Notification, error) {
 	query := `
 		SELECT id, user_id, channel, type, title, body, data, priority,
 			status, recipient, provider_id, scheduled_at, sent_at,
-			delivered_at, failed_at, error_msg, created_at
-		FROM notifications WHERE user_id = $1
+			delivered_at, failed_at, error_msg, read_at, created_at
+		FROM notifications WHERE channel = $1 AND provider_id = $2
+	`
+	return r.scanNotification(r.db.QueryRow(ctx, query, channel, providerID))
+}
+
+// searchConditions filters a user's notifications by $1-$7. The text match
+// repeats the expression of idx_notifications_search so the index is used.
+const searchConditions = `
+		user_id = $1
+		AND ($2 = '' OR type = $2)
+		AND ($3 = '' OR channel::text = $3)
+		AND ($4 = '' OR status::text = $4)
+		AND ($5::timestamptz IS NULL OR created_at >= $5)
+		AND ($6::timestamptz IS NULL OR created_at < $6)
+		AND ($7 = '' OR to_tsvector('simple', title || ' ' || body) @@ plainto_tsquery('simple', $7))
+`
+
+func searchArgs(userID uuid.UUID, filter domain.SearchFilter) []any {
+	return []any{userID, filter.Type, string(filter.Channel), string(filter.Status), filter.From, filter.To, filter.Query}
+}
+
+func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, filter domain.SearchFilter, limit, offset int) ([]*domain.Notification, error) {
+	query := `
+		SELECT id, user_id, channel, type, title, body, data, priority,
+			status, recipient, provider_id, scheduled_at, sent_at,
+			delivered_at, failed_at, error_msg, read_at, created_at
+		FROM notifications WHERE ` + searchConditions + `
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $8 OFFSET $9
 	`
-	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	args := append(searchArgs(userID, filter), limit, offset)
+	rows, err := r.replica.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -65,11 +98,12 @@ func (r *NotificationRepository) GetByUserID(ctx context.Context, userID uuid.UU
 	return r.scanNotifications(rows)
 }
 
+// GetPending hands work to the delivery worker, so it reads the primary
 func (r *NotificationRepository) GetPending(ctx context.Context, limit int) ([]*domain.Notification, error) {
 	query := `
 		SELECT id, user_id, channel, type, title, body, data, priority,
 			status, recipient, provider_id, scheduled_at, sent_at,
-			delivered_at, failed_at, error_msg, created_at
+			delivered_at, failed_at, error_msg, read_at, created_at
 		FROM notifications
 		WHERE status = 'pending' AND (scheduled_at IS NULL OR scheduled_at <= NOW())
 		ORDER BY priority DESC, created_at
@@ -84,6 +118,33 @@ func (r *NotificationRepository) GetPending(ctx context.Context, limit int) ([]*
 	return r.scanNotifications(rows)
 }
 
+// ClaimDue takes scheduled notifications whose time has come and pushes
+// scheduled_at out by the lease, so a dispatcher on another instance skips
+// them. One that is claimed but never sent is picked up again after the lease.
+// Unscheduled pending rows are being sent by the request that created them.
+func (r *NotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Notification, error) {
+	query := `
+		UPDATE notifications SET scheduled_at = $2
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = 'pending' AND scheduled_at <= $1
+			ORDER BY priority DESC, scheduled_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, channel, type, title, body, data, priority,
+			status, recipient, provider_id, scheduled_at, sent_at,
+			delivered_at, failed_at, error_msg, read_at, created_at
+	`
+	rows, err := r.db.Query(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanNotifications(rows)
+}
+
 func (r *NotificationRepository) Update(ctx context.Context, notif *domain.Notification) error {
 	query := `
 		UPDATE notifications
@@ -104,12 +165,95 @@ func (r *NotificationRepository) Update(ctx context.Context, notif *domain.Notif
 	return nil
 }
 
-func (r *NotificationRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+func (r *NotificationRepository) CountByUserID(ctx context.Context, userID uuid.UUID, filter domain.SearchFilter) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1`, userID).Scan(&count)
+	query := `SELECT COUNT(*) FROM notifications WHERE ` + searchConditions
+	err := r.replica.QueryRow(ctx, query, searchArgs(userID, filter)...).Scan(&count)
 	return count, err
 }
 
+// Anonymize blanks the content and address of every notification sent to
+// the user and fails any still waiting to go out. Rows are kept so delivery
+// stats stay whole.
+func (r *NotificationRepository) Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	query := `
+		UPDATE notifications
+		SET title = '', body = '', data = '{}', recipient = '',
+			status = CASE WHEN status = 'pending' THEN 'failed'::notification_status ELSE status END,
+			failed_at = CASE WHEN status = 'pending' THEN $2 ELSE failed_at END,
+			error_msg = CASE WHEN status = 'pending' THEN 'account deleted' ELSE error_msg END
+		WHERE user_id = $1
+	`
+	tag, err := r.db.Exec(ctx, query, userID, at)
+	if err != nil {
+		return 0, err
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// MarkRead sets read_at on one of the user's notifications, keeping the
+// first read time if it was already read
+func (r *NotificationRepository) MarkRead(ctx context.Context, id, userID uuid.UUID, at time.Time) (*domain.Notification, error) {
+	query := `
+		UPDATE notifications SET read_at = COALESCE(read_at, $3)
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, channel, type, title, body, data, priority,
+			status, recipient, provider_id, scheduled_at, sent_at,
+			delivered_at, failed_at, error_msg, read_at, created_at
+	`
+	return r.scanNotification(r.db.QueryRow(ctx, query, id, userID, at))
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	result, err := r.db.Exec(ctx,
+		`UPDATE notifications SET read_at = $2 WHERE user_id = $1 AND read_at IS NULL`,
+		userID, at,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
+// CountUnread leaves out failed notifications, which never reached the user
+func (r *NotificationRepository) CountUnread(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.replica.QueryRow(ctx,
+		`SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL AND status <> 'failed'`,
+		userID,
+	).Scan(&count)
+	return count, err
+}
+
+func (r *NotificationRepository) ChannelStats(ctx context.Context, since time.Time) ([]*domain.ChannelStats, error) {
+	query := `
+		SELECT channel, status, COUNT(*)
+		FROM notifications WHERE created_at >= $1
+		GROUP BY channel, status
+		ORDER BY channel
+	`
+	rows, err := r.replica.Query(ctx, query, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*domain.ChannelStats
+	for rows.Next() {
+		var channel domain.Channel
+		var status domain.Status
+		var count int
+		if err := rows.Scan(&channel, &status, &count); err != nil {
+			return nil, err
+		}
+		if len(stats) == 0 || stats[len(stats)-1].Channel != channel {
+			stats = append(stats, &domain.ChannelStats{Channel: channel})
+		}
+		stats[len(stats)-1].Add(status, count)
+	}
+	return stats, rows.Err()
+}
+
 func (r *NotificationRepository) scanNotification(row pgx.Row) (*domain.Notification, error) {
 	var n domain.Notification
 	var dataJSON []byte
@@ -117,7 +261,7 @@ func (r *NotificationRepository) scanNotification(row pgx.Row) (*domain.Notifica
 		&n.ID, &n.UserID, &n.Channel, &n.Type, &n.Title, &n.Body,
 		&dataJSON, &n.Priority, &n.Status, &n.Recipient, &n.ProviderID,
 		&n.ScheduledAt, &n.SentAt, &n.DeliveredAt, &n.FailedAt,
-		&n.ErrorMsg, &n.CreatedAt,
+		&n.ErrorMsg, &n.ReadAt, &n.CreatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -138,7 +282,7 @@ func (r *NotificationRepository) scanNotifications(rows pgx.Rows) ([]*domain.Not
 			&n.ID, &n.UserID, &n.Channel, &n.Type, &n.Title, &n.Body,
 			&dataJSON, &n.Priority, &n.Status, &n.Recipient, &n.ProviderID,
 			&n.ScheduledAt, &n.SentAt, &n.DeliveredAt, &n.FailedAt,
-			&n.ErrorMsg, &n.CreatedAt,
+			&n.ErrorMsg, &n.ReadAt, &n.CreatedAt,
 		)
 		if err != nil {
 			return nil, err
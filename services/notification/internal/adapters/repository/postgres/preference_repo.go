@@ -25,13 +25,13 @@ func (r *PreferenceRepository) Create(ctx context.Context, pref *domain.UserPref
 		INSERT INTO user_preferences (
 			id, user_id, push_enabled, sms_enabled, email_enabled,
 			quiet_hours_start, quiet_hours_end, type_preferences,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			simplified_content, timezone, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 	_, err := r.db.Exec(ctx, query,
 		pref.ID, pref.UserID, pref.PushEnabled, pref.SMSEnabled,
 		pref.EmailEnabled, pref.QuietHoursStart, pref.QuietHoursEnd,
-		typePrefsJSON, pref.CreatedAt, pref.UpdatedAt,
+		typePrefsJSON, pref.SimplifiedContent, pref.Timezone, pref.CreatedAt, pref.UpdatedAt,
 	)
 	return err
 }
@@ -40,7 +40,7 @@ func (r *PreferenceRepository) GetByUserID(ctx context.Context, userID uuid.UUID
 	query := `
 		SELECT id, user_id, push_enabled, sms_enabled, email_enabled,
 			quiet_hours_start, quiet_hours_end, type_preferences,
-			created_at, updated_at
+			simplified_content, timezone, created_at, updated_at
 		FROM user_preferences WHERE user_id = $1
 	`
 	var p domain.UserPreference
@@ -48,7 +48,7 @@ func (r *PreferenceRepository) GetByUserID(ctx context.Context, userID uuid.UUID
 	err := r.db.QueryRow(ctx, query, userID).Scan(
 		&p.ID, &p.UserID, &p.PushEnabled, &p.SMSEnabled, &p.EmailEnabled,
 		&p.QuietHoursStart, &p.QuietHoursEnd, &typePrefsJSON,
-		&p.CreatedAt, &p.UpdatedAt,
+		&p.SimplifiedContent, &p.Timezone, &p.CreatedAt, &p.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -66,12 +66,14 @@ func (r *PreferenceRepository) Update(ctx context.Context, pref *domain.UserPref
 		UPDATE user_preferences
 		SET push_enabled = $2, sms_enabled = $3, email_enabled = $4,
 			quiet_hours_start = $5, quiet_hours_end = $6,
-			type_preferences = $7, updated_at = $8
+			type_preferences = $7, simplified_content = $8, timezone = $9,
+			updated_at = $10
 		WHERE user_id = $1
 	`
 	_, err := r.db.Exec(ctx, query,
 		pref.UserID, pref.PushEnabled, pref.SMSEnabled, pref.EmailEnabled,
-		pref.QuietHoursStart, pref.QuietHoursEnd, typePrefsJSON, pref.UpdatedAt,
+		pref.QuietHoursStart, pref.QuietHoursEnd, typePrefsJSON,
+		pref.SimplifiedContent, pref.Timezone, pref.UpdatedAt,
 	)
 	return err
 }
@@ -82,8 +84,8 @@ func (r *PreferenceRepository) Upsert(ctx context.Context, pref *domain.UserPref
 		INSERT INTO user_preferences (
 			id, user_id, push_enabled, sms_enabled, email_enabled,
 			quiet_hours_start, quiet_hours_end, type_preferences,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			simplified_content, timezone, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		ON CONFLICT (user_id) DO UPDATE SET
 			push_enabled = EXCLUDED.push_enabled,
 			sms_enabled = EXCLUDED.sms_enabled,
@@ -91,12 +93,19 @@ func (r *PreferenceRepository) Upsert(ctx context.Context, pref *domain.UserPref
 			quiet_hours_start = EXCLUDED.quiet_hours_start,
 			quiet_hours_end = EXCLUDED.quiet_hours_end,
 			type_preferences = EXCLUDED.type_preferences,
+			simplified_content = EXCLUDED.simplified_content,
+			timezone = EXCLUDED.timezone,
 			updated_at = EXCLUDED.updated_at
 	`
 	_, err := r.db.Exec(ctx, query,
 		pref.ID, pref.UserID, pref.PushEnabled, pref.SMSEnabled,
 		pref.EmailEnabled, pref.QuietHoursStart, pref.QuietHoursEnd,
-		typePrefsJSON, pref.CreatedAt, pref.UpdatedAt,
+		typePrefsJSON, pref.SimplifiedContent, pref.Timezone, pref.CreatedAt, pref.UpdatedAt,
 	)
 	return err
 }
+
+func (r *PreferenceRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM user_preferences WHERE user_id = $1`, userID)
+	return err
+}
@@ -0,0 +1,167 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/testenv"
+	"github.com/parking-super-app/services/notification/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/migrations"
+)
+
+// Run with:
+//
+//	go test -tags integration ./internal/adapters/repository/postgres/...
+//
+// A Postgres container is started unless TEST_POSTGRES_URL is set.
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func newNotification(t *testing.T, userID uuid.UUID, channel domain.Channel, title string) *domain.Notification {
+	t.Helper()
+	notif, err := domain.NewNotification(userID, channel, "session.ended", title, "Your parking session has ended", "driver@example.com")
+	if err != nil {
+		t.Fatalf("NewNotification() error = %v", err)
+	}
+	return notif
+}
+
+func TestNotificationRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	repo := postgres.NewNotificationRepository(pool, pool)
+
+	userID := uuid.New()
+	email := newNotification(t, userID, domain.ChannelEmail, "Receipt for KLCC")
+	email.AddData("session_id", "abc")
+	inbox := newNotification(t, userID, domain.ChannelInbox, "Session ended")
+	scheduled := newNotification(t, userID, domain.ChannelEmail, "Reminder")
+	scheduled.Schedule(time.Now().Add(-time.Minute))
+	for _, n := range []*domain.Notification{email, inbox, scheduled} {
+		if err := repo.Create(ctx, n); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, email.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Title != email.Title || got.Status != domain.StatusPending || got.Data["session_id"] != "abc" {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrNotificationNotFound) {
+		t.Errorf("GetByID() of unknown notification error = %v, want %v", err, domain.ErrNotificationNotFound)
+	}
+
+	t.Run("delivery", func(t *testing.T) {
+		claimed, err := repo.ClaimDue(ctx, time.Now(), time.Minute, 10)
+		if err != nil {
+			t.Fatalf("ClaimDue() error = %v", err)
+		}
+		if len(claimed) != 1 || claimed[0].ID != scheduled.ID {
+			t.Fatalf("ClaimDue() = %d notifications, want the scheduled one", len(claimed))
+		}
+		if again, _ := repo.ClaimDue(ctx, time.Now(), time.Minute, 10); len(again) != 0 {
+			t.Errorf("second ClaimDue() within the lease = %d notifications, want 0", len(again))
+		}
+
+		email.MarkSent("msg-1")
+		if err := repo.Update(ctx, email); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		byReceipt, err := repo.GetByProviderID(ctx, domain.ChannelEmail, "msg-1")
+		if err != nil {
+			t.Fatalf("GetByProviderID() error = %v", err)
+		}
+		if byReceipt.ID != email.ID || byReceipt.Status != domain.StatusSent {
+			t.Errorf("GetByProviderID() = %+v", byReceipt)
+		}
+
+		pending, err := repo.GetPending(ctx, 10)
+		if err != nil {
+			t.Fatalf("GetPending() error = %v", err)
+		}
+		if len(pending) != 1 || pending[0].ID != inbox.ID {
+			t.Errorf("GetPending() = %d notifications, want the inbox one", len(pending))
+		}
+
+		stats, err := repo.ChannelStats(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("ChannelStats() error = %v", err)
+		}
+		if len(stats) != 2 {
+			t.Errorf("ChannelStats() = %d channels, want 2", len(stats))
+		}
+	})
+
+	t.Run("search", func(t *testing.T) {
+		all, err := repo.GetByUserID(ctx, userID, domain.SearchFilter{}, 10, 0)
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if len(all) != 3 {
+			t.Errorf("GetByUserID() = %d notifications, want 3", len(all))
+		}
+
+		filter := domain.SearchFilter{Channel: domain.ChannelEmail, Query: "klcc"}
+		found, err := repo.GetByUserID(ctx, userID, filter, 10, 0)
+		if err != nil {
+			t.Fatalf("GetByUserID() error = %v", err)
+		}
+		if len(found) != 1 || found[0].ID != email.ID {
+			t.Errorf("search for klcc = %d notifications, want the receipt", len(found))
+		}
+		if count, err := repo.CountByUserID(ctx, userID, domain.SearchFilter{Status: domain.StatusPending}); err != nil || count != 2 {
+			t.Errorf("CountByUserID(pending) = %d, %v, want 2", count, err)
+		}
+	})
+
+	t.Run("inbox", func(t *testing.T) {
+		if unread, err := repo.CountUnread(ctx, userID); err != nil || unread != 3 {
+			t.Fatalf("CountUnread() = %d, %v, want 3", unread, err)
+		}
+		read, err := repo.MarkRead(ctx, inbox.ID, userID, time.Now())
+		if err != nil {
+			t.Fatalf("MarkRead() error = %v", err)
+		}
+		if read.ReadAt == nil {
+			t.Error("MarkRead() did not set read_at")
+		}
+		if _, err := repo.MarkRead(ctx, inbox.ID, uuid.New(), time.Now()); !errors.Is(err, domain.ErrNotificationNotFound) {
+			t.Errorf("MarkRead() by another user error = %v, want %v", err, domain.ErrNotificationNotFound)
+		}
+		if n, err := repo.MarkAllRead(ctx, userID, time.Now()); err != nil || n != 2 {
+			t.Errorf("MarkAllRead() = %d, %v, want 2", n, err)
+		}
+		if unread, _ := repo.CountUnread(ctx, userID); unread != 0 {
+			t.Errorf("CountUnread() after MarkAllRead() = %d, want 0", unread)
+		}
+	})
+
+	t.Run("anonymize", func(t *testing.T) {
+		n, err := repo.Anonymize(ctx, userID, time.Now())
+		if err != nil {
+			t.Fatalf("Anonymize() error = %v", err)
+		}
+		if n != 3 {
+			t.Errorf("Anonymize() = %d rows, want 3", n)
+		}
+		got, err := repo.GetByID(ctx, inbox.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		if got.Title != "" || got.Recipient != "" || got.Status != domain.StatusFailed {
+			t.Errorf("anonymized notification = %+v", got)
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/notification/internal/domain"
+)
+
+type TemplateRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewTemplateRepository(db, replica *pgxpool.Pool) *TemplateRepository {
+	return &TemplateRepository{db: db, replica: replica}
+}
+
+const templateColumns = `
+	id, name, channel, type, title, body, simple_title, simple_body,
+	variables, is_active, created_at, updated_at
+`
+
+func (r *TemplateRepository) Create(ctx context.Context, t *domain.Template) error {
+	query := `
+		INSERT INTO notification_templates (` + templateColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Exec(ctx, query,
+		t.ID, t.Name, t.Channel, t.Type, t.Title, t.Body, t.SimpleTitle,
+		t.SimpleBody, t.Variables, t.IsActive, t.CreatedAt, t.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrTemplateAlreadyExists
+	}
+	return err
+}
+
+func (r *TemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM notification_templates WHERE id = $1`
+	return r.scanTemplate(r.replica.QueryRow(ctx, query, id))
+}
+
+func (r *TemplateRepository) GetByName(ctx context.Context, name string) (*domain.Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM notification_templates WHERE name = $1`
+	return r.scanTemplate(r.replica.QueryRow(ctx, query, name))
+}
+
+func (r *TemplateRepository) GetByType(ctx context.Context, notifType string, channel domain.Channel) (*domain.Template, error) {
+	query := `
+		SELECT ` + templateColumns + `
+		FROM notification_templates
+		WHERE type = $1 AND channel = $2 AND is_active = TRUE
+		ORDER BY updated_at DESC
+		LIMIT 1
+	`
+	return r.scanTemplate(r.replica.QueryRow(ctx, query, notifType, channel))
+}
+
+func (r *TemplateRepository) GetAll(ctx context.Context) ([]*domain.Template, error) {
+	query := `SELECT ` + templateColumns + ` FROM notification_templates ORDER BY name`
+	rows, err := r.replica.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []*domain.Template
+	for rows.Next() {
+		t, err := r.scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func (r *TemplateRepository) Update(ctx context.Context, t *domain.Template) error {
+	query := `
+		UPDATE notification_templates
+		SET title = $2, body = $3, simple_title = $4, simple_body = $5,
+			variables = $6, is_active = $7, updated_at = $8
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query,
+		t.ID, t.Title, t.Body, t.SimpleTitle, t.SimpleBody,
+		t.Variables, t.IsActive, t.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (r *TemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM notification_templates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (r *TemplateRepository) scanTemplate(row pgx.Row) (*domain.Template, error) {
+	var t domain.Template
+	err := row.Scan(
+		&t.ID, &t.Name, &t.Channel, &t.Type, &t.Title, &t.Body, &t.SimpleTitle,
+		&t.SimpleBody, &t.Variables, &t.IsActive, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == "23505"
+	}
+	return false
+}
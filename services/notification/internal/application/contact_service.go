@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// contactEvents are the auth events that carry a user's contact details
+var contactEvents = []string{
+	"user.registered",
+	"user.synced",
+	"user.profile_updated",
+}
+
+// ContactService keeps the local copy of user contact details up to date
+// from auth events and answers recipient lookups from it
+type ContactService struct {
+	contacts ports.ContactRepository
+	logger   ports.Logger
+}
+
+func NewContactService(contacts ports.ContactRepository, logger ports.Logger) *ContactService {
+	return &ContactService{contacts: contacts, logger: logger}
+}
+
+// EventTypes lists the events the service consumes
+func (s *ContactService) EventTypes() []string {
+	return contactEvents
+}
+
+// Handle stores the contact carried by a user event. Events without a
+// usable user are skipped; storage errors fail the event.
+func (s *ContactService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	userID, err := uuid.Parse(payloadString(payload, "user_id"))
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("user event has no user_id", ports.String("event_type", eventType))
+		return nil
+	}
+
+	// Events published before updated_at was added sort before any snapshot
+	var updatedAt time.Time
+	if v := payloadString(payload, "updated_at"); v != "" {
+		if updatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			s.logger.WithContext(ctx).Warn("user event has an invalid updated_at",
+				ports.String("event_type", eventType),
+				ports.String("user_id", userID.String()),
+			)
+			return nil
+		}
+	}
+
+	contact := &domain.Contact{
+		UserID:    userID,
+		Phone:     payloadString(payload, "phone"),
+		Email:     payloadString(payload, "email"),
+		FullName:  payloadString(payload, "full_name"),
+		UpdatedAt: updatedAt.UTC(),
+	}
+	if _, err := s.contacts.Upsert(ctx, contact); err != nil {
+		return fmt.Errorf("failed to store contact: %w", err)
+	}
+	return nil
+}
+
+// Recipient implements ports.ContactDirectory
+func (s *ContactService) Recipient(ctx context.Context, userID uuid.UUID, channel domain.Channel) (string, error) {
+	contact, err := s.contacts.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrContactNotFound) {
+			return "", domain.ErrRecipientUnknown
+		}
+		return "", err
+	}
+	return contact.Recipient(channel)
+}
@@ -0,0 +1,170 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// eventTypes maps the backend events users are told about to the
+// notification type their templates are registered under
+var eventTypes = map[string]string{
+	"parking.session.started":      ports.NotifTypeSessionStarted,
+	"parking.session.ended":        ports.NotifTypeSessionEnded,
+	"wallet.payment.completed":     ports.NotifTypePaymentSuccess,
+	"wallet.topup.completed":       ports.NotifTypeTopUpSuccess,
+	"user.otp_requested":           ports.NotifTypeOTPRequested,
+	"user.new_device_login":        ports.NotifTypeNewDeviceLogin,
+	"wallet.balance.low":           ports.NotifTypeBalanceLow,
+	"wallet.invoice.issued":        ports.NotifTypeInvoiceIssued,
+	"org.member.invited":           ports.NotifTypeOrgInvitation,
+	"parking.dispute.opened":       ports.NotifTypeDisputeOpened,
+	"parking.dispute.under_review": ports.NotifTypeDisputeReview,
+	"parking.dispute.resolved":     ports.NotifTypeDisputeResolved,
+	"wallet.loyalty.earned":        ports.NotifTypeLoyaltyEarned,
+	"wallet.loyalty.redeemed":      ports.NotifTypeLoyaltyRedeemed,
+	"wallet.referral.rewarded":     ports.NotifTypeReferralReward,
+}
+
+// eventChannels is the order channels are tried in for each event
+var eventChannels = []domain.Channel{
+	domain.ChannelInbox,
+	domain.ChannelPush,
+	domain.ChannelSMS,
+	domain.ChannelEmail,
+}
+
+// EventNotifier turns backend events into notifications. Each channel with an
+// active template for the event's notification type gets one, as long as the
+// user can be reached on it and has not turned it off.
+type EventNotifier struct {
+	notifications *NotificationService
+	templates     ports.TemplateRepository
+	contacts      ports.ContactDirectory
+	logger        ports.Logger
+}
+
+// NewEventNotifier creates an event notifier. contacts may be nil, in which
+// case only addresses carried in the event itself are used.
+func NewEventNotifier(
+	notifications *NotificationService,
+	templates ports.TemplateRepository,
+	contacts ports.ContactDirectory,
+	logger ports.Logger,
+) *EventNotifier {
+	return &EventNotifier{
+		notifications: notifications,
+		templates:     templates,
+		contacts:      contacts,
+		logger:        logger,
+	}
+}
+
+// EventTypes lists the events the notifier handles
+func (n *EventNotifier) EventTypes() []string {
+	types := make([]string, 0, len(eventTypes))
+	for eventType := range eventTypes {
+		types = append(types, eventType)
+	}
+	return types
+}
+
+// Handle sends the notifications for one event. Only lookups that may
+// succeed on redelivery fail the event; a send that fails is already
+// recorded against its notification.
+func (n *EventNotifier) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	notifType, ok := eventTypes[eventType]
+	if !ok {
+		return nil
+	}
+	log := n.logger.WithContext(ctx)
+
+	userID, err := uuid.Parse(payloadString(payload, "user_id"))
+	if err != nil {
+		log.Warn("event has no user to notify", ports.String("event_type", eventType))
+		return nil
+	}
+	vars := templateVariables(payload)
+
+	for _, channel := range eventChannels {
+		template, err := n.templates.GetByType(ctx, notifType, channel)
+		if err != nil {
+			if errors.Is(err, domain.ErrTemplateNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get %s template: %w", channel, err)
+		}
+
+		recipient, err := n.recipient(ctx, userID, channel, payload)
+		if err != nil {
+			if errors.Is(err, domain.ErrRecipientUnknown) {
+				continue
+			}
+			return fmt.Errorf("failed to resolve %s recipient: %w", channel, err)
+		}
+
+		_, err = n.notifications.SendFromTemplate(ctx, SendFromTemplateRequest{
+			UserID:       userID,
+			TemplateName: template.Name,
+			Recipient:    recipient,
+			Variables:    vars,
+		})
+		if err != nil && !errors.Is(err, domain.ErrBlockedByPreference) {
+			log.Warn("failed to send event notification",
+				ports.String("event_type", eventType),
+				ports.String("channel", string(channel)),
+				ports.String("user_id", userID.String()),
+				ports.Err(err),
+			)
+		}
+	}
+	return nil
+}
+
+// recipient prefers an address carried by the event, which is current for
+// events such as OTP requests, over the contact directory
+func (n *EventNotifier) recipient(ctx context.Context, userID uuid.UUID, channel domain.Channel, payload map[string]interface{}) (string, error) {
+	switch channel {
+	case domain.ChannelInbox:
+		return "", nil
+	case domain.ChannelSMS:
+		if phone := payloadString(payload, "phone"); phone != "" {
+			return phone, nil
+		}
+	case domain.ChannelEmail:
+		if email := payloadString(payload, "email"); email != "" {
+			return email, nil
+		}
+	}
+	if n.contacts == nil {
+		return "", domain.ErrRecipientUnknown
+	}
+	return n.contacts.Recipient(ctx, userID, channel)
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	s, _ := payload[key].(string)
+	return s
+}
+
+// templateVariables exposes the event's scalar fields to templates.
+// JSON numbers arrive as float64 and are printed without trailing zeros.
+func templateVariables(payload map[string]interface{}) map[string]string {
+	vars := make(map[string]string, len(payload))
+	for key, value := range payload {
+		switch v := value.(type) {
+		case string:
+			vars[key] = v
+		case float64:
+			vars[key] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			vars[key] = strconv.FormatBool(v)
+		}
+	}
+	return vars
+}
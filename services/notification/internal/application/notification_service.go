@@ -2,7 +2,10 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/notification/internal/domain"
@@ -17,7 +20,15 @@ type NotificationService struct {
 	push          ports.PushProvider
 	sms           ports.SMSProvider
 	email         ports.EmailProvider
+	contacts      ports.ContactDirectory
 	logger        ports.Logger
+	cfg           DispatchConfig
+}
+
+// DispatchConfig controls delivery of notifications held back by quiet hours
+type DispatchConfig struct {
+	BatchSize int
+	Lease     time.Duration // A claimed notification not sent within this is claimed again
 }
 
 func NewNotificationService(
@@ -28,7 +39,14 @@ func NewNotificationService(
 	sms ports.SMSProvider,
 	email ports.EmailProvider,
 	logger ports.Logger,
+	cfg DispatchConfig,
 ) *NotificationService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 5 * time.Minute
+	}
 	return &NotificationService{
 		notifications: notifications,
 		templates:     templates,
@@ -37,9 +55,16 @@ func NewNotificationService(
 		sms:           sms,
 		email:         email,
 		logger:        logger,
+		cfg:           cfg,
 	}
 }
 
+// SetContacts lets sends without a recipient go to the phone number or email
+// address on file for the user
+func (s *NotificationService) SetContacts(contacts ports.ContactDirectory) {
+	s.contacts = contacts
+}
+
 // Request/Response DTOs
 
 type SendNotificationRequest struct {
@@ -54,14 +79,24 @@ type SendNotificationRequest struct {
 }
 
 type NotificationResponse struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	Channel   string    `json:"channel"`
-	Type      string    `json:"type"`
-	Title     string    `json:"title"`
-	Body      string    `json:"body"`
-	Status    string    `json:"status"`
-	CreatedAt string    `json:"created_at"`
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Channel     string    `json:"channel"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	Status      string    `json:"status"`
+	ScheduledAt *string   `json:"scheduled_at,omitempty"`
+	ReadAt      *string   `json:"read_at,omitempty"`
+	CreatedAt   string    `json:"created_at"`
+}
+
+type UnreadCountResponse struct {
+	Unread int `json:"unread"`
+}
+
+type MarkAllReadResponse struct {
+	Marked int `json:"marked"`
 }
 
 type NotificationListResponse struct {
@@ -79,22 +114,54 @@ type SendFromTemplateRequest struct {
 }
 
 type UpdatePreferenceRequest struct {
-	UserID       uuid.UUID `json:"user_id"`
-	PushEnabled  *bool     `json:"push_enabled,omitempty"`
-	SMSEnabled   *bool     `json:"sms_enabled,omitempty"`
-	EmailEnabled *bool     `json:"email_enabled,omitempty"`
+	UserID            uuid.UUID `json:"user_id"`
+	PushEnabled       *bool     `json:"push_enabled,omitempty"`
+	SMSEnabled        *bool     `json:"sms_enabled,omitempty"`
+	EmailEnabled      *bool     `json:"email_enabled,omitempty"`
+	SimplifiedContent *bool     `json:"simplified_content,omitempty"`
+	// Quiet hours are set with both start and end, and turned off with
+	// quiet_hours_enabled=false
+	QuietHoursEnabled *bool   `json:"quiet_hours_enabled,omitempty"`
+	QuietHoursStart   *int    `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd     *int    `json:"quiet_hours_end,omitempty"`
+	Timezone          *string `json:"timezone,omitempty"`
 }
 
 type PreferenceResponse struct {
-	UserID       uuid.UUID `json:"user_id"`
-	PushEnabled  bool      `json:"push_enabled"`
-	SMSEnabled   bool      `json:"sms_enabled"`
-	EmailEnabled bool      `json:"email_enabled"`
+	UserID            uuid.UUID `json:"user_id"`
+	PushEnabled       bool      `json:"push_enabled"`
+	SMSEnabled        bool      `json:"sms_enabled"`
+	EmailEnabled      bool      `json:"email_enabled"`
+	SimplifiedContent bool      `json:"simplified_content"`
+	QuietHoursStart   *int      `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd     *int      `json:"quiet_hours_end,omitempty"`
+	Timezone          string    `json:"timezone"`
+}
+
+type ReceiptResult struct {
+	Applied int `json:"applied"`
+	Ignored int `json:"ignored"` // Duplicates and receipts that arrived after a final outcome
+	Unknown int `json:"unknown"` // Message IDs that match no notification
+}
+
+type ChannelStatsResponse struct {
+	*domain.ChannelStats
+	DeliveryRate float64 `json:"delivery_rate"`
+}
+
+type DeliveryStatsResponse struct {
+	Since    string                  `json:"since"`
+	Channels []*ChannelStatsResponse `json:"channels"`
+}
+
+type DispatchResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
 }
 
 // SendNotification sends a notification to a user
 func (s *NotificationService) SendNotification(ctx context.Context, req SendNotificationRequest) (*NotificationResponse, error) {
-	s.logger.Info("sending notification",
+	s.logger.WithContext(ctx).Info("sending notification",
 		ports.String("user_id", req.UserID.String()),
 		ports.String("channel", req.Channel),
 	)
@@ -102,14 +169,28 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 	channel := domain.Channel(req.Channel)
 
 	// Check user preferences
+	var quietUntil time.Time
+	var deferred bool
 	pref, err := s.preferences.GetByUserID(ctx, req.UserID)
 	if err == nil && pref != nil {
 		if !pref.IsChannelEnabled(channel) {
-			s.logger.Info("notification blocked by user preference")
-			return nil, fmt.Errorf("channel %s is disabled for user", req.Channel)
+			s.logger.WithContext(ctx).Info("notification blocked by user preference")
+			return nil, fmt.Errorf("%w: channel %s is disabled", domain.ErrBlockedByPreference, req.Channel)
 		}
-		if pref.IsInQuietHours() && req.Priority != "high" {
-			s.logger.Info("notification delayed due to quiet hours")
+		if !pref.IsTypeEnabled(req.Type) {
+			s.logger.WithContext(ctx).Info("notification blocked by user preference")
+			return nil, fmt.Errorf("%w: %s notifications are disabled", domain.ErrBlockedByPreference, req.Type)
+		}
+		// The inbox makes no sound, and urgent notifications are never held
+		if channel != domain.ChannelInbox && req.Priority != string(domain.PriorityHigh) {
+			quietUntil, deferred = pref.QuietUntil(time.Now())
+		}
+	}
+
+	recipient := req.Recipient
+	if recipient == "" && channel != domain.ChannelInbox && s.contacts != nil {
+		if recipient, err = s.contacts.Recipient(ctx, req.UserID, channel); err != nil {
+			return nil, err
 		}
 	}
 
@@ -119,7 +200,7 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 		req.Type,
 		req.Title,
 		req.Body,
-		req.Recipient,
+		recipient,
 	)
 	if err != nil {
 		return nil, err
@@ -133,11 +214,23 @@ func (s *NotificationService) SendNotification(ctx context.Context, req SendNoti
 		notif.SetPriority(domain.Priority(req.Priority))
 	}
 
+	if deferred {
+		notif.Schedule(quietUntil)
+	}
+
 	// Save notification
 	if err := s.notifications.Create(ctx, notif); err != nil {
 		return nil, fmt.Errorf("failed to save notification: %w", err)
 	}
 
+	if deferred {
+		s.logger.WithContext(ctx).Info("notification deferred until quiet hours end",
+			ports.String("notification_id", notif.ID.String()),
+			ports.String("scheduled_at", quietUntil.Format(time.RFC3339)),
+		)
+		return s.toResponse(notif), nil
+	}
+
 	// Send notification
 	if err := s.send(ctx, notif); err != nil {
 		notif.MarkFailed(err.Error())
@@ -155,7 +248,22 @@ func (s *NotificationService) SendFromTemplate(ctx context.Context, req SendFrom
 		return nil, fmt.Errorf("template not found: %w", err)
 	}
 
-	title, body := template.Render(req.Variables)
+	format := domain.FormatStandard
+	if pref, err := s.preferences.GetByUserID(ctx, req.UserID); err == nil && pref != nil {
+		format = pref.ContentFormatFor(template.Channel)
+	}
+
+	title, body := template.RenderAs(format, req.Variables)
+
+	var data map[string]string
+	if template.Channel == domain.ChannelPush {
+		// Lets the app announce the notification through the screen reader
+		// instead of reading out the visual title and body
+		data = map[string]string{
+			"content_format": string(format),
+			"tts_text":       domain.SpeechText(template.RenderAs(domain.FormatSimple, req.Variables)),
+		}
+	}
 
 	return s.SendNotification(ctx, SendNotificationRequest{
 		UserID:    req.UserID,
@@ -164,6 +272,7 @@ func (s *NotificationService) SendFromTemplate(ctx context.Context, req SendFrom
 		Title:     title,
 		Body:      body,
 		Recipient: req.Recipient,
+		Data:      data,
 	})
 }
 
@@ -176,8 +285,11 @@ func (s *NotificationService) GetNotification(ctx context.Context, id uuid.UUID)
 	return s.toResponse(notif), nil
 }
 
-// GetUserNotifications retrieves notifications for a user
-func (s *NotificationService) GetUserNotifications(ctx context.Context, userID uuid.UUID, limit, offset int) (*NotificationListResponse, error) {
+// GetUserNotifications retrieves a user's notifications matching filter, newest first
+func (s *NotificationService) GetUserNotifications(ctx context.Context, userID uuid.UUID, filter domain.SearchFilter, limit, offset int) (*NotificationListResponse, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
 	if limit <= 0 {
 		limit = 20
 	}
@@ -185,12 +297,12 @@ func (s *NotificationService) GetUserNotifications(ctx context.Context, userID u
 		limit = 100
 	}
 
-	notifications, err := s.notifications.GetByUserID(ctx, userID, limit, offset)
+	notifications, err := s.notifications.GetByUserID(ctx, userID, filter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get notifications: %w", err)
 	}
 
-	total, err := s.notifications.CountByUserID(ctx, userID)
+	total, err := s.notifications.CountByUserID(ctx, userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count notifications: %w", err)
 	}
@@ -208,6 +320,34 @@ func (s *NotificationService) GetUserNotifications(ctx context.Context, userID u
 	}, nil
 }
 
+// MarkRead marks one of the user's notifications as read. Marking it again
+// is a no-op, and other users' notifications are reported as not found.
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id uuid.UUID) (*NotificationResponse, error) {
+	notif, err := s.notifications.MarkRead(ctx, id, userID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	return s.toResponse(notif), nil
+}
+
+// MarkAllRead marks every unread notification of the user as read
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) (*MarkAllReadResponse, error) {
+	marked, err := s.notifications.MarkAllRead(ctx, userID, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	return &MarkAllReadResponse{Marked: marked}, nil
+}
+
+// UnreadCount returns the number of the user's unread notifications, for the app badge
+func (s *NotificationService) UnreadCount(ctx context.Context, userID uuid.UUID) (*UnreadCountResponse, error) {
+	unread, err := s.notifications.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return &UnreadCountResponse{Unread: unread}, nil
+}
+
 // UpdatePreferences updates user notification preferences
 func (s *NotificationService) UpdatePreferences(ctx context.Context, req UpdatePreferenceRequest) (*PreferenceResponse, error) {
 	pref, err := s.preferences.GetByUserID(ctx, req.UserID)
@@ -225,17 +365,30 @@ func (s *NotificationService) UpdatePreferences(ctx context.Context, req UpdateP
 	if req.EmailEnabled != nil {
 		pref.SetChannelEnabled(domain.ChannelEmail, *req.EmailEnabled)
 	}
+	if req.SimplifiedContent != nil {
+		pref.SetSimplifiedContent(*req.SimplifiedContent)
+	}
+	if req.Timezone != nil {
+		if err := pref.SetTimezone(*req.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	if req.QuietHoursEnabled != nil && !*req.QuietHoursEnabled {
+		pref.ClearQuietHours()
+	} else if req.QuietHoursStart != nil || req.QuietHoursEnd != nil {
+		if req.QuietHoursStart == nil || req.QuietHoursEnd == nil {
+			return nil, domain.ErrInvalidQuietHours
+		}
+		if err := pref.SetQuietHours(*req.QuietHoursStart, *req.QuietHoursEnd); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.preferences.Upsert(ctx, pref); err != nil {
 		return nil, fmt.Errorf("failed to update preferences: %w", err)
 	}
 
-	return &PreferenceResponse{
-		UserID:       pref.UserID,
-		PushEnabled:  pref.PushEnabled,
-		SMSEnabled:   pref.SMSEnabled,
-		EmailEnabled: pref.EmailEnabled,
-	}, nil
+	return toPreferenceResponse(pref), nil
 }
 
 // GetPreferences retrieves user notification preferences
@@ -246,12 +399,90 @@ func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UU
 		pref = domain.NewUserPreference(userID)
 	}
 
-	return &PreferenceResponse{
-		UserID:       pref.UserID,
-		PushEnabled:  pref.PushEnabled,
-		SMSEnabled:   pref.SMSEnabled,
-		EmailEnabled: pref.EmailEnabled,
-	}, nil
+	return toPreferenceResponse(pref), nil
+}
+
+// ApplyReceipts updates notifications from a provider's delivery receipts.
+// Providers retry on error, so a receipt is safe to apply more than once.
+func (s *NotificationService) ApplyReceipts(ctx context.Context, channel domain.Channel, receipts []ports.DeliveryReceipt) (*ReceiptResult, error) {
+	result := &ReceiptResult{}
+	for _, receipt := range receipts {
+		notif, err := s.notifications.GetByProviderID(ctx, channel, receipt.ProviderID)
+		if err != nil {
+			if errors.Is(err, domain.ErrNotificationNotFound) {
+				s.logger.WithContext(ctx).Warn("delivery receipt for unknown message",
+					ports.String("channel", string(channel)),
+					ports.String("provider_id", receipt.ProviderID),
+				)
+				result.Unknown++
+				continue
+			}
+			return nil, fmt.Errorf("failed to find notification: %w", err)
+		}
+
+		if !notif.ApplyReceipt(receipt.Status, receipt.Reason, receipt.At) {
+			result.Ignored++
+			continue
+		}
+		if err := s.notifications.Update(ctx, notif); err != nil {
+			return nil, fmt.Errorf("failed to update notification: %w", err)
+		}
+		result.Applied++
+	}
+	return result, nil
+}
+
+// DeliveryStats counts notifications created since the given time by channel
+// and delivery status
+func (s *NotificationService) DeliveryStats(ctx context.Context, since time.Time) (*DeliveryStatsResponse, error) {
+	stats, err := s.notifications.ChannelStats(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get delivery stats: %w", err)
+	}
+
+	resp := &DeliveryStatsResponse{
+		Since:    since.UTC().Format("2006-01-02T15:04:05Z"),
+		Channels: make([]*ChannelStatsResponse, len(stats)),
+	}
+	for i, channel := range stats {
+		resp.Channels[i] = &ChannelStatsResponse{
+			ChannelStats: channel,
+			DeliveryRate: math.Round(channel.DeliveryRate()*10000) / 10000,
+		}
+	}
+	return resp, nil
+}
+
+// DispatchDue sends notifications whose scheduled time has passed, such as
+// those held back by quiet hours
+func (s *NotificationService) DispatchDue(ctx context.Context, now time.Time) (*DispatchResult, error) {
+	result := &DispatchResult{}
+
+	due, err := s.notifications.ClaimDue(ctx, now, s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due notifications: %w", err)
+	}
+	for _, notif := range due {
+		if err := s.send(ctx, notif); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to send scheduled notification",
+				ports.String("notification_id", notif.ID.String()),
+				ports.Err(err),
+			)
+			notif.MarkFailed(err.Error())
+			s.notifications.Update(ctx, notif)
+			result.Failed++
+			continue
+		}
+		result.Sent++
+	}
+
+	if result.Sent > 0 || result.Failed > 0 {
+		s.logger.WithContext(ctx).Info("notification dispatch run finished",
+			ports.Any("sent", result.Sent),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
 }
 
 func (s *NotificationService) send(ctx context.Context, notif *domain.Notification) error {
@@ -294,6 +525,12 @@ func (s *NotificationService) send(ctx context.Context, notif *domain.Notificati
 		}
 		providerID = resp.MessageID
 
+	case domain.ChannelInbox:
+		// Saving it is the delivery
+		notif.MarkSent("")
+		notif.MarkDelivered()
+		return s.notifications.Update(ctx, notif)
+
 	default:
 		return domain.ErrInvalidChannel
 	}
@@ -306,13 +543,40 @@ func (s *NotificationService) send(ctx context.Context, notif *domain.Notificati
 
 func (s *NotificationService) toResponse(n *domain.Notification) *NotificationResponse {
 	return &NotificationResponse{
-		ID:        n.ID,
-		UserID:    n.UserID,
-		Channel:   string(n.Channel),
-		Type:      n.Type,
-		Title:     n.Title,
-		Body:      n.Body,
-		Status:    string(n.Status),
-		CreatedAt: n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:          n.ID,
+		UserID:      n.UserID,
+		Channel:     string(n.Channel),
+		Type:        n.Type,
+		Title:       n.Title,
+		Body:        n.Body,
+		Status:      string(n.Status),
+		ScheduledAt: formatTimestamp(n.ScheduledAt),
+		ReadAt:      formatTimestamp(n.ReadAt),
+		CreatedAt:   n.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func formatTimestamp(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.UTC().Format("2006-01-02T15:04:05Z")
+	return &formatted
+}
+
+func toPreferenceResponse(pref *domain.UserPreference) *PreferenceResponse {
+	timezone := pref.Timezone
+	if timezone == "" {
+		timezone = domain.DefaultTimezone
+	}
+	return &PreferenceResponse{
+		UserID:            pref.UserID,
+		PushEnabled:       pref.PushEnabled,
+		SMSEnabled:        pref.SMSEnabled,
+		EmailEnabled:      pref.EmailEnabled,
+		SimplifiedContent: pref.SimplifiedContent,
+		QuietHoursStart:   pref.QuietHoursStart,
+		QuietHoursEnd:     pref.QuietHoursEnd,
+		Timezone:          timezone,
 	}
 }
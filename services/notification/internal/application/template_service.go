@@ -0,0 +1,218 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// TemplateService manages notification templates and their simple-text
+// alternatives
+type TemplateService struct {
+	templates ports.TemplateRepository
+	logger    ports.Logger
+}
+
+func NewTemplateService(templates ports.TemplateRepository, logger ports.Logger) *TemplateService {
+	return &TemplateService{
+		templates: templates,
+		logger:    logger,
+	}
+}
+
+type CreateTemplateRequest struct {
+	Name        string `json:"name"`
+	Channel     string `json:"channel"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	SimpleTitle string `json:"simple_title,omitempty"`
+	SimpleBody  string `json:"simple_body,omitempty"`
+}
+
+// UpdateTemplateRequest changes only the fields that are set. Setting the
+// simple text to an empty string clears it.
+type UpdateTemplateRequest struct {
+	ID          uuid.UUID `json:"-"`
+	Title       *string   `json:"title,omitempty"`
+	Body        *string   `json:"body,omitempty"`
+	SimpleTitle *string   `json:"simple_title,omitempty"`
+	SimpleBody  *string   `json:"simple_body,omitempty"`
+	IsActive    *bool     `json:"is_active,omitempty"`
+}
+
+type PreviewTemplateRequest struct {
+	ID        uuid.UUID         `json:"-"`
+	Format    string            `json:"format"`
+	Variables map[string]string `json:"variables"`
+}
+
+type TemplateResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Name          string    `json:"name"`
+	Channel       string    `json:"channel"`
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	Body          string    `json:"body"`
+	SimpleTitle   string    `json:"simple_title"`
+	SimpleBody    string    `json:"simple_body"`
+	HasSimpleText bool      `json:"has_simple_text"`
+	Variables     []string  `json:"variables"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type TemplatePreviewResponse struct {
+	Format     string `json:"format"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	SpeechText string `json:"tts_text"`
+}
+
+// CreateTemplate validates and stores a new template
+func (s *TemplateService) CreateTemplate(ctx context.Context, req CreateTemplateRequest) (*TemplateResponse, error) {
+	template := domain.NewTemplate(req.Name, domain.Channel(req.Channel), req.Type, req.Title, req.Body)
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	if err := template.SetSimpleText(req.SimpleTitle, req.SimpleBody); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.Create(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to create template: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("template created",
+		ports.String("template_id", template.ID.String()),
+		ports.String("name", template.Name),
+	)
+
+	return toTemplateResponse(template), nil
+}
+
+// UpdateTemplate changes a template's text or active state
+func (s *TemplateService) UpdateTemplate(ctx context.Context, req UpdateTemplateRequest) (*TemplateResponse, error) {
+	template, err := s.templates.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil || req.Body != nil {
+		title, body := template.Title, template.Body
+		if req.Title != nil {
+			title = *req.Title
+		}
+		if req.Body != nil {
+			body = *req.Body
+		}
+		template.Update(title, body)
+		if err := template.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.SimpleTitle != nil || req.SimpleBody != nil {
+		simpleTitle, simpleBody := template.SimpleTitle, template.SimpleBody
+		if req.SimpleTitle != nil {
+			simpleTitle = *req.SimpleTitle
+		}
+		if req.SimpleBody != nil {
+			simpleBody = *req.SimpleBody
+		}
+		if err := template.SetSimpleText(simpleTitle, simpleBody); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.IsActive != nil {
+		if *req.IsActive {
+			template.Activate()
+		} else {
+			template.Deactivate()
+		}
+	}
+
+	if err := s.templates.Update(ctx, template); err != nil {
+		return nil, fmt.Errorf("failed to update template: %w", err)
+	}
+
+	return toTemplateResponse(template), nil
+}
+
+// GetTemplate retrieves a template by ID
+func (s *TemplateService) GetTemplate(ctx context.Context, id uuid.UUID) (*TemplateResponse, error) {
+	template, err := s.templates.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTemplateResponse(template), nil
+}
+
+// ListTemplates returns all templates
+func (s *TemplateService) ListTemplates(ctx context.Context) ([]*TemplateResponse, error) {
+	templates, err := s.templates.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+
+	responses := make([]*TemplateResponse, len(templates))
+	for i, t := range templates {
+		responses[i] = toTemplateResponse(t)
+	}
+	return responses, nil
+}
+
+// DeleteTemplate removes a template
+func (s *TemplateService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	return s.templates.Delete(ctx, id)
+}
+
+// PreviewTemplate renders a template with sample variables so authors can
+// check how the standard and simple versions read, including the text that
+// push notifications hand to text-to-speech
+func (s *TemplateService) PreviewTemplate(ctx context.Context, req PreviewTemplateRequest) (*TemplatePreviewResponse, error) {
+	format := domain.ContentFormat(req.Format)
+	if req.Format == "" {
+		format = domain.FormatStandard
+	}
+	if !format.IsValid() {
+		return nil, domain.ErrInvalidContentFormat
+	}
+
+	template, err := s.templates.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	title, body := template.RenderAs(format, req.Variables)
+	return &TemplatePreviewResponse{
+		Format:     string(format),
+		Title:      title,
+		Body:       body,
+		SpeechText: domain.SpeechText(template.RenderAs(domain.FormatSimple, req.Variables)),
+	}, nil
+}
+
+func toTemplateResponse(t *domain.Template) *TemplateResponse {
+	return &TemplateResponse{
+		ID:            t.ID,
+		Name:          t.Name,
+		Channel:       string(t.Channel),
+		Type:          t.Type,
+		Title:         t.Title,
+		Body:          t.Body,
+		SimpleTitle:   t.SimpleTitle,
+		SimpleBody:    t.SimpleBody,
+		HasSimpleText: t.HasSimpleText(),
+		Variables:     t.Variables,
+		IsActive:      t.IsActive,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+}
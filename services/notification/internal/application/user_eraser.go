@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/notification/internal/domain"
+	"github.com/parking-super-app/services/notification/internal/ports"
+)
+
+// UserEraser scrubs a deleted user's personal data from this service
+type UserEraser struct {
+	notifications ports.NotificationRepository
+	preferences   ports.PreferenceRepository
+	contacts      ports.ContactRepository
+	logger        ports.Logger
+}
+
+func NewUserEraser(
+	notifications ports.NotificationRepository,
+	preferences ports.PreferenceRepository,
+	contacts ports.ContactRepository,
+	logger ports.Logger,
+) *UserEraser {
+	return &UserEraser{
+		notifications: notifications,
+		preferences:   preferences,
+		contacts:      contacts,
+		logger:        logger,
+	}
+}
+
+// EventTypes lists the events the eraser consumes. Auth publishes
+// user.deleted once an account's grace period is over.
+func (e *UserEraser) EventTypes() []string {
+	return []string{"user.deleted"}
+}
+
+// Handle erases the user named by a user.deleted event. Every step is safe
+// to repeat, so a redelivered event does no harm.
+func (e *UserEraser) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	userID, err := uuid.Parse(payloadString(payload, "user_id"))
+	if err != nil {
+		e.logger.WithContext(ctx).Warn("user event has no user_id", ports.String("event_type", eventType))
+		return nil
+	}
+	now := time.Now().UTC()
+	updatedAt := now
+	if v := payloadString(payload, "updated_at"); v != "" {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			updatedAt = t.UTC()
+		}
+	}
+
+	// An empty contact stamped with the deletion time outranks any older
+	// user event that arrives late, so the contact cannot come back
+	if _, err := e.contacts.Upsert(ctx, &domain.Contact{UserID: userID, UpdatedAt: updatedAt}); err != nil {
+		return fmt.Errorf("failed to erase contact: %w", err)
+	}
+	if err := e.preferences.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete preferences: %w", err)
+	}
+	anonymized, err := e.notifications.Anonymize(ctx, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize notifications: %w", err)
+	}
+
+	e.logger.WithContext(ctx).Info("erased deleted user",
+		ports.String("user_id", userID.String()),
+		ports.Any("notifications", anonymized),
+	)
+	return nil
+}
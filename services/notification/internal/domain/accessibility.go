@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrSimpleTextNotPlain   = errors.New("simple text must not contain emojis or markup")
+	ErrSimpleTextTooComplex = errors.New("simple text sentences are too long")
+	ErrInvalidContentFormat = errors.New("invalid content format")
+)
+
+// MaxSimpleSentenceWords keeps simple text sentences short enough to be read
+// easily and spoken clearly by text-to-speech
+const MaxSimpleSentenceWords = 20
+
+// ContentFormat selects how a template is rendered for a user
+type ContentFormat string
+
+const (
+	// FormatStandard is the regular template text
+	FormatStandard ContentFormat = "standard"
+	// FormatSimple is plain text without emojis or markup, in short sentences,
+	// suitable for screen readers and text-to-speech
+	FormatSimple ContentFormat = "simple"
+)
+
+func (f ContentFormat) IsValid() bool {
+	return f == FormatStandard || f == FormatSimple
+}
+
+var (
+	htmlTagPattern      = regexp.MustCompile(`<[^>]+>`)
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	headingPattern      = regexp.MustCompile(`(?m)^\s*#+\s+`)
+	sentenceEndPattern  = regexp.MustCompile(`[.!?]+`)
+)
+
+// markdownMarkers are stripped from simple text; they are read aloud literally
+// by most screen readers
+const markdownMarkers = "*`~"
+
+// Simplify converts standard text into plain text: markup and emojis are
+// removed and whitespace is collapsed. Used when a template has no
+// hand-written simple text.
+func Simplify(text string) string {
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = htmlTagPattern.ReplaceAllString(text, " ")
+	text = headingPattern.ReplaceAllString(text, "")
+
+	var b strings.Builder
+	for _, r := range text {
+		if isEmoji(r) || strings.ContainsRune(markdownMarkers, r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// ValidateSimpleText checks hand-written simple text follows the accessibility rules
+func ValidateSimpleText(text string) error {
+	if htmlTagPattern.MatchString(text) || markdownLinkPattern.MatchString(text) ||
+		headingPattern.MatchString(text) || strings.ContainsAny(text, markdownMarkers) {
+		return ErrSimpleTextNotPlain
+	}
+	for _, r := range text {
+		if isEmoji(r) {
+			return ErrSimpleTextNotPlain
+		}
+	}
+
+	for _, sentence := range sentenceEndPattern.Split(text, -1) {
+		if len(strings.Fields(sentence)) > MaxSimpleSentenceWords {
+			return ErrSimpleTextTooComplex
+		}
+	}
+	return nil
+}
+
+// SpeechText joins a title and body into one string for text-to-speech,
+// making sure the title is read as its own sentence
+func SpeechText(title, body string) string {
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if title == "" {
+		return body
+	}
+	if !strings.ContainsAny(title[len(title)-1:], ".!?") {
+		title += "."
+	}
+	if body == "" {
+		return title
+	}
+	return title + " " + body
+}
+
+// isEmoji reports pictographs, emoji modifiers and the invisible joiners
+// used to build emoji sequences
+func isEmoji(r rune) bool {
+	switch {
+	case r == '\u200d', r == '\ufe0f', r == '\u20e3': // joiner, variation selector, keycap
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff: // skin tone modifiers
+		return true
+	}
+	return unicode.Is(unicode.So, r)
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSimplify(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain text unchanged", "Your parking has ended.", "Your parking has ended."},
+		{"emoji removed", "Payment received 🎉 Thanks!", "Payment received Thanks!"},
+		{"emoji sequence removed", "Well done 👍🏽 and 👨‍👩‍👧", "Well done and"},
+		{"markdown emphasis", "Your session **ends soon**", "Your session ends soon"},
+		{"markdown link keeps label", "See [your receipt](https://example.com/r/1)", "See your receipt"},
+		{"heading marker", "## Reminder\nTop up your wallet", "Reminder Top up your wallet"},
+		{"html tags", "<b>Low balance</b><br>Top up now", "Low balance Top up now"},
+		{"hash inside text kept", "Bay #12 is free", "Bay #12 is free"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Simplify(tt.text); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateSimpleText(t *testing.T) {
+	longSentence := strings.Repeat("word ", MaxSimpleSentenceWords+1) + "."
+
+	tests := []struct {
+		name    string
+		text    string
+		wantErr error
+	}{
+		{"empty", "", nil},
+		{"short sentences", "Your parking at {{location}} has ended. You paid {{amount}}.", nil},
+		{"emoji", "Payment received 🎉", ErrSimpleTextNotPlain},
+		{"markdown", "Your session **ends soon**", ErrSimpleTextNotPlain},
+		{"html", "<b>Low balance</b>", ErrSimpleTextNotPlain},
+		{"long sentence", longSentence, ErrSimpleTextTooComplex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateSimpleText(tt.text); err != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSpeechText(t *testing.T) {
+	tests := []struct {
+		title, body, expected string
+	}{
+		{"Parking ended", "You paid RM 5.00.", "Parking ended. You paid RM 5.00."},
+		{"Parking ended!", "You paid RM 5.00.", "Parking ended! You paid RM 5.00."},
+		{"", "You paid RM 5.00.", "You paid RM 5.00."},
+		{"Parking ended", "", "Parking ended."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := SpeechText(tt.title, tt.body); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
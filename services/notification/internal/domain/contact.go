@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrContactNotFound = errors.New("contact not found")
+
+// Contact is the notification service's copy of how to reach a user. It is
+// kept in step with the auth service from its user events.
+type Contact struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Phone     string    `json:"phone"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"full_name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Recipient returns the user's address on a channel. Push tokens are held
+// by the devices, not here.
+func (c *Contact) Recipient(channel Channel) (string, error) {
+	var recipient string
+	switch channel {
+	case ChannelSMS:
+		recipient = c.Phone
+	case ChannelEmail:
+		recipient = c.Email
+	}
+	if recipient == "" {
+		return "", ErrRecipientUnknown
+	}
+	return recipient, nil
+}
+
+// SupersededBy reports whether a snapshot taken at updatedAt is newer than
+// this copy. Events can arrive out of order, so older ones are ignored.
+func (c *Contact) SupersededBy(updatedAt time.Time) bool {
+	return updatedAt.After(c.UpdatedAt)
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestContact_Recipient(t *testing.T) {
+	contact := &Contact{UserID: uuid.New(), Phone: "+60123456789"}
+
+	tests := []struct {
+		name    string
+		channel Channel
+		want    string
+		wantErr error
+	}{
+		{"sms uses phone", ChannelSMS, "+60123456789", nil},
+		{"email missing", ChannelEmail, "", ErrRecipientUnknown},
+		{"push not held", ChannelPush, "", ErrRecipientUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := contact.Recipient(tt.channel)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Recipient() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Recipient() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContact_SupersededBy(t *testing.T) {
+	now := time.Now().UTC()
+	contact := &Contact{UpdatedAt: now}
+
+	if !contact.SupersededBy(now.Add(time.Second)) {
+		t.Error("newer snapshot should supersede")
+	}
+	if contact.SupersededBy(now) {
+		t.Error("snapshot from the same time should not supersede")
+	}
+	if contact.SupersededBy(now.Add(-time.Second)) {
+		t.Error("older snapshot should not supersede")
+	}
+}
@@ -12,6 +12,9 @@ var (
 	ErrInvalidChannel       = errors.New("invalid notification channel")
 	ErrInvalidRecipient     = errors.New("invalid recipient")
 	ErrNotificationFailed   = errors.New("notification delivery failed")
+	ErrInvalidWebhook       = errors.New("invalid delivery webhook")
+	ErrBlockedByPreference  = errors.New("notification blocked by user preference")
+	ErrRecipientUnknown     = errors.New("no recipient known for channel")
 )
 
 // Channel represents a notification delivery channel
@@ -21,6 +24,9 @@ const (
 	ChannelPush  Channel = "push"
 	ChannelSMS   Channel = "sms"
 	ChannelEmail Channel = "email"
+
+	// ChannelInbox is stored for the in-app inbox only and never leaves the service
+	ChannelInbox Channel = "inbox"
 )
 
 // Status represents notification delivery status
@@ -31,6 +37,7 @@ const (
 	StatusSent      Status = "sent"
 	StatusDelivered Status = "delivered"
 	StatusFailed    Status = "failed"
+	StatusBounced   Status = "bounced" // Rejected by the recipient's mail server or carrier
 )
 
 // Priority represents notification urgency
@@ -60,6 +67,7 @@ type Notification struct {
 	DeliveredAt *time.Time        `json:"delivered_at,omitempty"`
 	FailedAt    *time.Time        `json:"failed_at,omitempty"`
 	ErrorMsg    string            `json:"error_msg,omitempty"`
+	ReadAt      *time.Time        `json:"read_at,omitempty"`
 	CreatedAt   time.Time         `json:"created_at"`
 }
 
@@ -72,6 +80,10 @@ func NewNotification(
 	if !isValidChannel(channel) {
 		return nil, ErrInvalidChannel
 	}
+	if channel == ChannelInbox {
+		// Inbox messages are addressed by user, there is no device or address
+		recipient = userID.String()
+	}
 	if recipient == "" {
 		return nil, ErrInvalidRecipient
 	}
@@ -132,6 +144,42 @@ func (n *Notification) MarkFailed(errMsg string) {
 	n.ErrorMsg = errMsg
 }
 
+// ApplyReceipt records a provider's delivery receipt. Receipts may arrive
+// late, twice or out of order: failures are final, and a bounce can still
+// follow a delivery. It returns false when the receipt changes nothing.
+func (n *Notification) ApplyReceipt(status Status, reason string, at time.Time) bool {
+	if n.Status == StatusFailed || n.Status == StatusBounced || n.Status == status {
+		return false
+	}
+	switch status {
+	case StatusDelivered:
+		n.Status = StatusDelivered
+		n.DeliveredAt = &at
+	case StatusBounced, StatusFailed:
+		n.Status = status
+		n.FailedAt = &at
+		n.ErrorMsg = reason
+	default:
+		return false
+	}
+	return true
+}
+
+// MarkRead records when the user opened the notification. It returns false
+// if it was already read, keeping the first read time.
+func (n *Notification) MarkRead(at time.Time) bool {
+	if n.ReadAt != nil {
+		return false
+	}
+	n.ReadAt = &at
+	return true
+}
+
+// IsRead returns true once the user has opened the notification
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
 // IsReady checks if notification is ready to send
 func (n *Notification) IsReady() bool {
 	if n.Status != StatusPending {
@@ -149,5 +197,5 @@ func (n *Notification) IsPending() bool {
 }
 
 func isValidChannel(c Channel) bool {
-	return c == ChannelPush || c == ChannelSMS || c == ChannelEmail
+	return c == ChannelPush || c == ChannelSMS || c == ChannelEmail || c == ChannelInbox
 }
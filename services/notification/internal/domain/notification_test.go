@@ -46,6 +46,71 @@ func TestNewNotification_InvalidRecipient(t *testing.T) {
 	}
 }
 
+func TestNewNotification_InboxNeedsNoRecipient(t *testing.T) {
+	userID := uuid.New()
+	notif, err := NewNotification(userID, ChannelInbox, "test", "Title", "Body", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if notif.Recipient != userID.String() {
+		t.Errorf("expected recipient %s, got %s", userID, notif.Recipient)
+	}
+}
+
+func TestNotification_ApplyReceipt(t *testing.T) {
+	at := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		from        Status
+		receipt     Status
+		wantApplied bool
+		wantStatus  Status
+	}{
+		{"sent to delivered", StatusSent, StatusDelivered, true, StatusDelivered},
+		{"sent to bounced", StatusSent, StatusBounced, true, StatusBounced},
+		{"bounce after delivery", StatusDelivered, StatusBounced, true, StatusBounced},
+		{"duplicate delivery", StatusDelivered, StatusDelivered, false, StatusDelivered},
+		{"delivery after failure", StatusFailed, StatusDelivered, false, StatusFailed},
+		{"delivery after bounce", StatusBounced, StatusDelivered, false, StatusBounced},
+		{"interim status", StatusSent, StatusPending, false, StatusSent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notif, _ := NewNotification(uuid.New(), ChannelEmail, "test", "Title", "Body", "a@example.com")
+			notif.Status = tt.from
+
+			if got := notif.ApplyReceipt(tt.receipt, "mailbox full", at); got != tt.wantApplied {
+				t.Errorf("ApplyReceipt() = %v, want %v", got, tt.wantApplied)
+			}
+			if notif.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, notif.Status)
+			}
+			if tt.wantApplied && tt.receipt == StatusBounced && (notif.FailedAt == nil || notif.ErrorMsg != "mailbox full") {
+				t.Error("expected bounce to record failed_at and the reason")
+			}
+		})
+	}
+}
+
+func TestNotification_MarkRead(t *testing.T) {
+	notif, _ := NewNotification(uuid.New(), ChannelInbox, "test", "Title", "Body", "")
+	if notif.IsRead() {
+		t.Fatal("new notification should be unread")
+	}
+
+	first := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	if !notif.MarkRead(first) {
+		t.Error("expected first MarkRead to report a change")
+	}
+	if notif.MarkRead(first.Add(time.Hour)) {
+		t.Error("expected second MarkRead to be a no-op")
+	}
+	if !notif.IsRead() || !notif.ReadAt.Equal(first) {
+		t.Errorf("expected read at %v, got %v", first, notif.ReadAt)
+	}
+}
+
 func TestNotification_MarkSent(t *testing.T) {
 	notif, _ := NewNotification(uuid.New(), ChannelSMS, "test", "Title", "Body", "+60123456789")
 
@@ -138,6 +203,7 @@ func TestIsValidChannel(t *testing.T) {
 		{ChannelPush, true},
 		{ChannelSMS, true},
 		{ChannelEmail, true},
+		{ChannelInbox, true},
 		{"invalid", false},
 		{"", false},
 	}
@@ -1,23 +1,34 @@
 package domain
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrInvalidQuietHours = errors.New("invalid quiet hours")
+	ErrInvalidTimezone   = errors.New("invalid timezone")
+)
+
+// DefaultTimezone is used for quiet hours until the user picks one
+const DefaultTimezone = "Asia/Kuala_Lumpur"
+
 // UserPreference stores user notification preferences
 type UserPreference struct {
-	ID              uuid.UUID         `json:"id"`
-	UserID          uuid.UUID         `json:"user_id"`
-	PushEnabled     bool              `json:"push_enabled"`
-	SMSEnabled      bool              `json:"sms_enabled"`
-	EmailEnabled    bool              `json:"email_enabled"`
-	QuietHoursStart *int              `json:"quiet_hours_start,omitempty"`
-	QuietHoursEnd   *int              `json:"quiet_hours_end,omitempty"`
-	TypePreferences map[string]bool   `json:"type_preferences"`
-	CreatedAt       time.Time         `json:"created_at"`
-	UpdatedAt       time.Time         `json:"updated_at"`
+	ID                uuid.UUID       `json:"id"`
+	UserID            uuid.UUID       `json:"user_id"`
+	PushEnabled       bool            `json:"push_enabled"`
+	SMSEnabled        bool            `json:"sms_enabled"`
+	EmailEnabled      bool            `json:"email_enabled"`
+	QuietHoursStart   *int            `json:"quiet_hours_start,omitempty"`
+	QuietHoursEnd     *int            `json:"quiet_hours_end,omitempty"`
+	Timezone          string          `json:"timezone"`
+	TypePreferences   map[string]bool `json:"type_preferences"`
+	SimplifiedContent bool            `json:"simplified_content"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
 // NewUserPreference creates default preferences for a user
@@ -29,6 +40,7 @@ func NewUserPreference(userID uuid.UUID) *UserPreference {
 		PushEnabled:     true,
 		SMSEnabled:      true,
 		EmailEnabled:    true,
+		Timezone:        DefaultTimezone,
 		TypePreferences: make(map[string]bool),
 		CreatedAt:       now,
 		UpdatedAt:       now,
@@ -44,6 +56,9 @@ func (p *UserPreference) IsChannelEnabled(channel Channel) bool {
 		return p.SMSEnabled
 	case ChannelEmail:
 		return p.EmailEnabled
+	case ChannelInbox:
+		// The inbox is the record of what was sent and cannot be turned off
+		return true
 	default:
 		return false
 	}
@@ -81,26 +96,94 @@ func (p *UserPreference) SetTypeEnabled(notifType string, enabled bool) {
 	p.UpdatedAt = time.Now().UTC()
 }
 
-// SetQuietHours sets the quiet hours window (24-hour format)
-func (p *UserPreference) SetQuietHours(start, end int) {
+// SetSimplifiedContent turns simplified content on or off
+func (p *UserPreference) SetSimplifiedContent(enabled bool) {
+	p.SimplifiedContent = enabled
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// ContentFormatFor returns the format to render templates in for a channel.
+// Email keeps the standard format since mail clients handle markup well.
+func (p *UserPreference) ContentFormatFor(channel Channel) ContentFormat {
+	if p.SimplifiedContent && (channel == ChannelSMS || channel == ChannelPush) {
+		return FormatSimple
+	}
+	return FormatStandard
+}
+
+// SetQuietHours sets the quiet hours window (24-hour format). start == end
+// is rejected as ambiguous; 0 to 24 keeps the user quiet all day.
+func (p *UserPreference) SetQuietHours(start, end int) error {
+	if start < 0 || start > 23 || end < 0 || end > 24 || start == end {
+		return ErrInvalidQuietHours
+	}
 	p.QuietHoursStart = &start
 	p.QuietHoursEnd = &end
 	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// ClearQuietHours turns quiet hours off
+func (p *UserPreference) ClearQuietHours() {
+	p.QuietHoursStart = nil
+	p.QuietHoursEnd = nil
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// SetTimezone sets the IANA zone quiet hours are read in
+func (p *UserPreference) SetTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil || name == "" {
+		return ErrInvalidTimezone
+	}
+	p.Timezone = name
+	p.UpdatedAt = time.Now().UTC()
+	return nil
 }
 
 // IsInQuietHours checks if current time is in quiet hours
 func (p *UserPreference) IsInQuietHours() bool {
+	_, quiet := p.QuietUntil(time.Now())
+	return quiet
+}
+
+// QuietUntil reports whether now falls in the user's quiet hours and, if so,
+// when they end, which is the earliest a deferred notification may go out
+func (p *UserPreference) QuietUntil(now time.Time) (time.Time, bool) {
 	if p.QuietHoursStart == nil || p.QuietHoursEnd == nil {
-		return false
+		return time.Time{}, false
 	}
 
-	currentHour := time.Now().Hour()
+	local := now.In(p.location())
+	currentHour := local.Hour()
 	start := *p.QuietHoursStart
 	end := *p.QuietHoursEnd
 
+	var quiet bool
 	if start < end {
-		return currentHour >= start && currentHour < end
+		quiet = currentHour >= start && currentHour < end
+	} else {
+		// Quiet hours span midnight
+		quiet = currentHour >= start || currentHour < end
+	}
+	if !quiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end, 0, 0, 0, local.Location())
+	if !until.After(local) {
+		until = time.Date(local.Year(), local.Month(), local.Day()+1, end, 0, 0, 0, local.Location())
+	}
+	return until.UTC(), true
+}
+
+func (p *UserPreference) location() *time.Location {
+	name := p.Timezone
+	if name == "" {
+		name = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
 	}
-	// Quiet hours span midnight
-	return currentHour >= start || currentHour < end
+	return loc
 }
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -40,6 +41,12 @@ func TestUserPreference_IsChannelEnabled(t *testing.T) {
 	if pref.IsChannelEnabled(ChannelPush) {
 		t.Error("push should be disabled")
 	}
+
+	pref.SetChannelEnabled(ChannelInbox, false)
+
+	if !pref.IsChannelEnabled(ChannelInbox) {
+		t.Error("inbox should always be enabled")
+	}
 }
 
 func TestUserPreference_IsTypeEnabled(t *testing.T) {
@@ -85,3 +92,92 @@ func TestUserPreference_SetChannelEnabled(t *testing.T) {
 		t.Error("email should be disabled")
 	}
 }
+
+func TestUserPreference_ContentFormatFor(t *testing.T) {
+	pref := NewUserPreference(uuid.New())
+
+	if pref.ContentFormatFor(ChannelSMS) != FormatStandard {
+		t.Error("simplified content should be off by default")
+	}
+
+	pref.SetSimplifiedContent(true)
+
+	tests := []struct {
+		channel  Channel
+		expected ContentFormat
+	}{
+		{ChannelSMS, FormatSimple},
+		{ChannelPush, FormatSimple},
+		{ChannelEmail, FormatStandard},
+	}
+	for _, tt := range tests {
+		if got := pref.ContentFormatFor(tt.channel); got != tt.expected {
+			t.Errorf("%s: expected %s, got %s", tt.channel, tt.expected, got)
+		}
+	}
+}
+
+func TestUserPreference_QuietUntil(t *testing.T) {
+	// 22:00-07:00 in Kuala Lumpur is 14:00-23:00 UTC
+	tests := []struct {
+		name      string
+		start     int
+		end       int
+		now       time.Time
+		wantQuiet bool
+		wantUntil time.Time
+	}{
+		{"before window", 22, 7, time.Date(2026, 5, 1, 13, 30, 0, 0, time.UTC), false, time.Time{}},
+		{"late evening", 22, 7, time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC), true, time.Date(2026, 5, 1, 23, 0, 0, 0, time.UTC)},
+		{"after midnight", 22, 7, time.Date(2026, 5, 1, 20, 0, 0, 0, time.UTC), true, time.Date(2026, 5, 1, 23, 0, 0, 0, time.UTC)},
+		{"window over", 22, 7, time.Date(2026, 5, 1, 23, 0, 0, 0, time.UTC), false, time.Time{}},
+		{"same-day window", 13, 15, time.Date(2026, 5, 1, 5, 30, 0, 0, time.UTC), true, time.Date(2026, 5, 1, 7, 0, 0, 0, time.UTC)},
+		{"all day", 0, 24, time.Date(2026, 5, 1, 5, 30, 0, 0, time.UTC), true, time.Date(2026, 5, 1, 16, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pref := NewUserPreference(uuid.New())
+			if err := pref.SetQuietHours(tt.start, tt.end); err != nil {
+				t.Fatalf("SetQuietHours() error = %v", err)
+			}
+
+			until, quiet := pref.QuietUntil(tt.now)
+			if quiet != tt.wantQuiet {
+				t.Fatalf("QuietUntil() quiet = %v, want %v", quiet, tt.wantQuiet)
+			}
+			if !until.Equal(tt.wantUntil) {
+				t.Errorf("QuietUntil() = %v, want %v", until, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestUserPreference_SetQuietHoursValidation(t *testing.T) {
+	tests := []struct {
+		start, end int
+		wantErr    error
+	}{
+		{22, 7, nil},
+		{0, 24, nil},
+		{9, 9, ErrInvalidQuietHours},
+		{24, 7, ErrInvalidQuietHours},
+		{-1, 7, ErrInvalidQuietHours},
+		{22, 25, ErrInvalidQuietHours},
+	}
+
+	for _, tt := range tests {
+		pref := NewUserPreference(uuid.New())
+		if err := pref.SetQuietHours(tt.start, tt.end); err != tt.wantErr {
+			t.Errorf("SetQuietHours(%d, %d) error = %v, want %v", tt.start, tt.end, err, tt.wantErr)
+		}
+	}
+
+	pref := NewUserPreference(uuid.New())
+	if err := pref.SetTimezone("Mars/Olympus"); err != ErrInvalidTimezone {
+		t.Errorf("SetTimezone() error = %v, want %v", err, ErrInvalidTimezone)
+	}
+	if err := pref.SetTimezone("Asia/Singapore"); err != nil || pref.Timezone != "Asia/Singapore" {
+		t.Errorf("SetTimezone() error = %v, timezone = %s", err, pref.Timezone)
+	}
+}
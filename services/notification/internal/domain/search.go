@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidStatus    = errors.New("invalid notification status")
+	ErrInvalidDateRange = errors.New("invalid date range")
+	ErrQueryTooLong     = errors.New("search query too long")
+)
+
+// MaxQueryLength bounds the free-text part of a search
+const MaxQueryLength = 200
+
+// SearchFilter narrows a user's notifications. Empty fields match
+// everything; Query matches words in the title or body.
+type SearchFilter struct {
+	Type    string
+	Channel Channel
+	Status  Status
+	From    *time.Time
+	To      *time.Time
+	Query   string
+}
+
+// Validate checks the filter and trims the query
+func (f *SearchFilter) Validate() error {
+	if f.Channel != "" && !isValidChannel(f.Channel) {
+		return ErrInvalidChannel
+	}
+	if f.Status != "" && !isValidStatus(f.Status) {
+		return ErrInvalidStatus
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidDateRange
+	}
+	f.Query = strings.TrimSpace(f.Query)
+	if len(f.Query) > MaxQueryLength {
+		return ErrQueryTooLong
+	}
+	return nil
+}
+
+func isValidStatus(s Status) bool {
+	switch s {
+	case StatusPending, StatusSent, StatusDelivered, StatusFailed, StatusBounced:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchFilter_Validate(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		filter  SearchFilter
+		wantErr error
+	}{
+		{"empty", SearchFilter{}, nil},
+		{"all fields", SearchFilter{Type: "parking.receipt", Channel: ChannelInbox, Status: StatusDelivered, From: &earlier, To: &now, Query: "receipt"}, nil},
+		{"unknown channel", SearchFilter{Channel: "fax"}, ErrInvalidChannel},
+		{"unknown status", SearchFilter{Status: "lost"}, ErrInvalidStatus},
+		{"from after to", SearchFilter{From: &now, To: &earlier}, ErrInvalidDateRange},
+		{"empty range", SearchFilter{From: &now, To: &now}, ErrInvalidDateRange},
+		{"open range", SearchFilter{From: &now}, nil},
+		{"long query", SearchFilter{Query: strings.Repeat("a", MaxQueryLength+1)}, ErrQueryTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSearchFilter_ValidateTrimsQuery(t *testing.T) {
+	filter := SearchFilter{Query: "  parking receipt "}
+	if err := filter.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if filter.Query != "parking receipt" {
+		t.Errorf("Query = %q, want %q", filter.Query, "parking receipt")
+	}
+}
@@ -0,0 +1,39 @@
+package domain
+
+// ChannelStats counts one channel's notifications by delivery status
+type ChannelStats struct {
+	Channel   Channel `json:"channel"`
+	Total     int     `json:"total"`
+	Pending   int     `json:"pending"`
+	Sent      int     `json:"sent"`
+	Delivered int     `json:"delivered"`
+	Bounced   int     `json:"bounced"`
+	Failed    int     `json:"failed"`
+}
+
+// Add counts notifications in the given status
+func (s *ChannelStats) Add(status Status, count int) {
+	s.Total += count
+	switch status {
+	case StatusPending:
+		s.Pending += count
+	case StatusSent:
+		s.Sent += count
+	case StatusDelivered:
+		s.Delivered += count
+	case StatusBounced:
+		s.Bounced += count
+	case StatusFailed:
+		s.Failed += count
+	}
+}
+
+// DeliveryRate is the share of notifications with a known outcome that were
+// delivered. Sent notifications still waiting for a receipt are left out.
+func (s *ChannelStats) DeliveryRate() float64 {
+	settled := s.Delivered + s.Bounced + s.Failed
+	if settled == 0 {
+		return 0
+	}
+	return float64(s.Delivered) / float64(settled)
+}
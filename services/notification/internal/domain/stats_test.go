@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestChannelStats_DeliveryRate(t *testing.T) {
+	stats := &ChannelStats{Channel: ChannelSMS}
+	if stats.DeliveryRate() != 0 {
+		t.Errorf("empty stats rate = %v, want 0", stats.DeliveryRate())
+	}
+
+	stats.Add(StatusDelivered, 6)
+	stats.Add(StatusBounced, 1)
+	stats.Add(StatusFailed, 1)
+	stats.Add(StatusSent, 4)
+	stats.Add(StatusPending, 2)
+
+	if stats.Total != 14 {
+		t.Errorf("Total = %d, want 14", stats.Total)
+	}
+	if got := stats.DeliveryRate(); got != 0.75 {
+		t.Errorf("DeliveryRate() = %v, want 0.75", got)
+	}
+}
@@ -1,24 +1,33 @@
 package domain
 
 import (
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTemplateNotFound      = errors.New("template not found")
+	ErrTemplateAlreadyExists = errors.New("template with this name already exists")
+	ErrInvalidTemplate       = errors.New("template name and body are required")
+)
+
 // Template represents a notification template
 type Template struct {
-	ID        uuid.UUID         `json:"id"`
-	Name      string            `json:"name"`
-	Channel   Channel           `json:"channel"`
-	Type      string            `json:"type"`
-	Title     string            `json:"title"`
-	Body      string            `json:"body"`
-	Variables []string          `json:"variables"`
-	IsActive  bool              `json:"is_active"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Channel     Channel   `json:"channel"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	SimpleTitle string    `json:"simple_title,omitempty"`
+	SimpleBody  string    `json:"simple_body,omitempty"`
+	Variables   []string  `json:"variables"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // NewTemplate creates a new notification template
@@ -38,18 +47,74 @@ func NewTemplate(name string, channel Channel, notifType, title, body string) *T
 	}
 }
 
+// Validate checks the template has the fields needed to send
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.Name) == "" || strings.TrimSpace(t.Body) == "" {
+		return ErrInvalidTemplate
+	}
+	if !isValidChannel(t.Channel) {
+		return ErrInvalidChannel
+	}
+	return nil
+}
+
+// Update replaces the standard title and body
+func (t *Template) Update(title, body string) {
+	t.Title = title
+	t.Body = body
+	t.Variables = extractVariables(body)
+	t.UpdatedAt = time.Now().UTC()
+}
+
+// SetSimpleText sets the simplified rendering. Empty values clear it, in
+// which case RenderAs derives simple text from the standard text.
+func (t *Template) SetSimpleText(title, body string) error {
+	if err := ValidateSimpleText(title); err != nil {
+		return err
+	}
+	if err := ValidateSimpleText(body); err != nil {
+		return err
+	}
+	t.SimpleTitle = title
+	t.SimpleBody = body
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// HasSimpleText reports whether a hand-written simple body is set
+func (t *Template) HasSimpleText() bool {
+	return t.SimpleBody != ""
+}
+
 // Render renders the template with provided variables
 func (t *Template) Render(vars map[string]string) (title, body string) {
-	title = t.Title
-	body = t.Body
+	return substitute(t.Title, vars), substitute(t.Body, vars)
+}
 
-	for key, value := range vars {
-		placeholder := "{{" + key + "}}"
-		title = strings.ReplaceAll(title, placeholder, value)
-		body = strings.ReplaceAll(body, placeholder, value)
+// RenderAs renders the template in the given format. Simple rendering uses
+// the hand-written simple text when present and otherwise strips emojis and
+// markup from the standard text.
+func (t *Template) RenderAs(format ContentFormat, vars map[string]string) (title, body string) {
+	if format != FormatSimple {
+		return t.Render(vars)
+	}
+
+	title, body = t.Render(vars)
+	if t.SimpleTitle != "" {
+		title = substitute(t.SimpleTitle, vars)
+	}
+	if t.SimpleBody != "" {
+		body = substitute(t.SimpleBody, vars)
 	}
 
-	return title, body
+	// Variable values can carry emojis of their own
+	return Simplify(title), Simplify(body)
+}
+
+// Activate enables the template
+func (t *Template) Activate() {
+	t.IsActive = true
+	t.UpdatedAt = time.Now().UTC()
 }
 
 // Deactivate disables the template
@@ -58,6 +123,13 @@ func (t *Template) Deactivate() {
 	t.UpdatedAt = time.Now().UTC()
 }
 
+func substitute(text string, vars map[string]string) string {
+	for key, value := range vars {
+		text = strings.ReplaceAll(text, "{{"+key+"}}", value)
+	}
+	return text
+}
+
 // extractVariables finds all {{variable}} placeholders in text
 func extractVariables(text string) []string {
 	var vars []string
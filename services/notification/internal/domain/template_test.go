@@ -96,3 +96,75 @@ func TestExtractVariables(t *testing.T) {
 		})
 	}
 }
+
+func TestTemplate_RenderAs(t *testing.T) {
+	template := NewTemplate(
+		"payment-success",
+		ChannelPush,
+		"payment.success",
+		"✅ Payment Successful",
+		"**{{amount}}** paid for parking at {{location}} 🚗",
+	)
+	vars := map[string]string{"amount": "RM 5.00", "location": "KLCC"}
+
+	title, body := template.RenderAs(FormatStandard, vars)
+	if title != "✅ Payment Successful" || body != "**RM 5.00** paid for parking at KLCC 🚗" {
+		t.Errorf("standard render changed: %q / %q", title, body)
+	}
+
+	// Without hand-written simple text the standard text is simplified
+	title, body = template.RenderAs(FormatSimple, vars)
+	if title != "Payment Successful" {
+		t.Errorf("expected derived simple title, got %q", title)
+	}
+	if body != "RM 5.00 paid for parking at KLCC" {
+		t.Errorf("expected derived simple body, got %q", body)
+	}
+
+	if err := template.SetSimpleText("Payment done", "You paid {{amount}}. Parking is at {{location}}."); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !template.HasSimpleText() {
+		t.Error("template should have simple text")
+	}
+
+	title, body = template.RenderAs(FormatSimple, vars)
+	if title != "Payment done" {
+		t.Errorf("expected simple title, got %q", title)
+	}
+	if body != "You paid RM 5.00. Parking is at KLCC." {
+		t.Errorf("expected simple body, got %q", body)
+	}
+}
+
+func TestTemplate_SetSimpleText_Invalid(t *testing.T) {
+	template := NewTemplate("test", ChannelSMS, "test", "Test", "Test body")
+
+	if err := template.SetSimpleText("Done 🎉", "Body"); err != ErrSimpleTextNotPlain {
+		t.Errorf("expected ErrSimpleTextNotPlain, got %v", err)
+	}
+	if template.SimpleTitle != "" {
+		t.Error("invalid simple text should not be stored")
+	}
+}
+
+func TestTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    *Template
+		wantErr error
+	}{
+		{"valid", NewTemplate("a", ChannelSMS, "t", "Title", "Body"), nil},
+		{"missing name", NewTemplate("", ChannelSMS, "t", "Title", "Body"), ErrInvalidTemplate},
+		{"missing body", NewTemplate("a", ChannelSMS, "t", "Title", " "), ErrInvalidTemplate},
+		{"bad channel", NewTemplate("a", Channel("fax"), "t", "Title", "Body"), ErrInvalidChannel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tmpl.Validate(); err != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
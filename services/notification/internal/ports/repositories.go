@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/notification/internal/domain"
@@ -11,10 +12,17 @@ import (
 type NotificationRepository interface {
 	Create(ctx context.Context, notif *domain.Notification) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Notification, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Notification, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, filter domain.SearchFilter, limit, offset int) ([]*domain.Notification, error)
 	GetPending(ctx context.Context, limit int) ([]*domain.Notification, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Notification, error)
 	Update(ctx context.Context, notif *domain.Notification) error
-	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID, filter domain.SearchFilter) (int, error)
+	MarkRead(ctx context.Context, id, userID uuid.UUID, at time.Time) (*domain.Notification, error)
+	MarkAllRead(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+	CountUnread(ctx context.Context, userID uuid.UUID) (int, error)
+	GetByProviderID(ctx context.Context, channel domain.Channel, providerID string) (*domain.Notification, error)
+	ChannelStats(ctx context.Context, since time.Time) ([]*domain.ChannelStats, error)
+	Anonymize(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
 }
 
 // TemplateRepository defines persistence for notification templates
@@ -34,4 +42,13 @@ type PreferenceRepository interface {
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserPreference, error)
 	Update(ctx context.Context, pref *domain.UserPreference) error
 	Upsert(ctx context.Context, pref *domain.UserPreference) error
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
+
+// ContactRepository defines persistence for the local copy of user contacts
+type ContactRepository interface {
+	// Upsert stores the contact unless the stored copy is as new or newer,
+	// and reports whether it was stored
+	Upsert(ctx context.Context, contact *domain.Contact) (bool, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Contact, error)
 }
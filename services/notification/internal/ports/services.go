@@ -2,22 +2,17 @@ package ports
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/services/notification/internal/domain"
 )
 
 // Logger interface
-type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-}
-
-type Field struct {
-	Key   string
-	Value interface{}
-}
+type Logger = logging.Logger
+type Field = logging.Field
 
 func String(key, value string) Field { return Field{Key: key, Value: value} }
 func Err(err error) Field            { return Field{Key: "error", Value: err} }
@@ -76,6 +71,26 @@ type EmailResponse struct {
 	Error     string
 }
 
+// DeliveryReceipt is a provider's report on a message it accepted
+type DeliveryReceipt struct {
+	ProviderID string
+	Status     domain.Status // delivered, bounced or failed
+	Reason     string
+	At         time.Time
+}
+
+// ReceiptVerifier authenticates and decodes delivery callbacks from a
+// channel's provider. Receipts for interim states are dropped.
+type ReceiptVerifier interface {
+	VerifyReceipts(channel domain.Channel, payload []byte, header http.Header) ([]DeliveryReceipt, error)
+}
+
+// ContactDirectory looks up where to reach a user on a channel. It returns
+// domain.ErrRecipientUnknown when the user has no address for it.
+type ContactDirectory interface {
+	Recipient(ctx context.Context, userID uuid.UUID, channel domain.Channel) (string, error)
+}
+
 // NotificationSender unified interface for sending via any channel
 type NotificationSender interface {
 	Send(ctx context.Context, notif *domain.Notification) error
@@ -103,4 +118,17 @@ const (
 	NotifTypeSessionEnded     = "session.ended"
 	NotifTypePromotion        = "promotion"
 	NotifTypeAccountAlert     = "account.alert"
+	NotifTypeTopUpSuccess     = "topup.success"
+	NotifTypeOTPRequested     = "account.otp_requested"
+	NotifTypeNewDeviceLogin   = "account.new_device_login"
+	NotifTypeBalanceLow       = "wallet.balance_low"
+	NotifTypeInvoiceIssued    = "wallet.invoice_issued"
+	NotifTypeOrgInvitation    = "org.invitation"
+	NotifTypeDisputeOpened    = "dispute.opened"
+	NotifTypeDisputeReview    = "dispute.under_review"
+	NotifTypeDisputeResolved  = "dispute.resolved"
+	NotifTypeLoyaltyEarned    = "loyalty.earned"
+	NotifTypeLoyaltyRedeemed  = "loyalty.redeemed"
+	NotifTypeReferralReward   = "referral.rewarded"
+	NotifTypeWelcome          = "user.welcome"
 )
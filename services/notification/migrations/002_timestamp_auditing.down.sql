@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_user_preferences_updated_at ON user_preferences;
+DROP TRIGGER IF EXISTS update_notification_templates_updated_at ON notification_templates;
+DROP TRIGGER IF EXISTS update_notifications_updated_at ON notifications;
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS updated_at;
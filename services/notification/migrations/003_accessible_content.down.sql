@@ -0,0 +1,5 @@
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS simplified_content;
+
+ALTER TABLE notification_templates
+    DROP COLUMN IF EXISTS simple_body,
+    DROP COLUMN IF EXISTS simple_title;
@@ -0,0 +1,7 @@
+-- Plain-text alternative per template, used for users who opt into simplified content
+ALTER TABLE notification_templates
+    ADD COLUMN simple_title VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN simple_body TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE user_preferences
+    ADD COLUMN simplified_content BOOLEAN NOT NULL DEFAULT FALSE;
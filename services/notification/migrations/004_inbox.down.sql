@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_notifications_unread;
+
+ALTER TABLE notifications DROP COLUMN IF EXISTS read_at;
+
+-- Postgres cannot drop an enum value; 'inbox' stays on notification_channel
+-- and is unused once the service is rolled back
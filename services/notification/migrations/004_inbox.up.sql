@@ -0,0 +1,6 @@
+-- In-app inbox: a channel that is only stored, and read state for every notification
+ALTER TYPE notification_channel ADD VALUE IF NOT EXISTS 'inbox';
+
+ALTER TABLE notifications ADD COLUMN read_at TIMESTAMPTZ;
+
+CREATE INDEX idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
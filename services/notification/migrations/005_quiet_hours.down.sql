@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_due;
+
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS timezone;
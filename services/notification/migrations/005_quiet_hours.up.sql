@@ -0,0 +1,7 @@
+-- Quiet hours are read in the user's own zone
+ALTER TABLE user_preferences
+    ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'Asia/Kuala_Lumpur';
+
+-- Deferred notifications are found by the dispatcher when their time comes
+CREATE INDEX idx_notifications_due ON notifications(scheduled_at)
+    WHERE status = 'pending' AND scheduled_at IS NOT NULL;
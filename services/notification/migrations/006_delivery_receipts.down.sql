@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_notifications_provider_id;
+
+-- 'bounced' cannot be dropped from notification_status; bounced rows read
+-- as an unknown status after a rollback
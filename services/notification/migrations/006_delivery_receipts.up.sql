@@ -0,0 +1,4 @@
+-- Provider delivery receipts: bounces are kept apart from send failures
+ALTER TYPE notification_status ADD VALUE IF NOT EXISTS 'bounced';
+
+CREATE INDEX idx_notifications_provider_id ON notifications(channel, provider_id);
@@ -0,0 +1,9 @@
+DELETE FROM notification_templates WHERE name IN (
+    'session-started-inbox',
+    'session-ended-inbox',
+    'payment-success-inbox',
+    'payment-success-email',
+    'topup-success-inbox',
+    'topup-success-email',
+    'otp-requested-inbox'
+);
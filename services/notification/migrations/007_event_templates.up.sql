@@ -0,0 +1,18 @@
+-- Default templates for event-driven notifications. Admins can edit them, or
+-- add templates on other channels for the same type.
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'session-started-inbox', 'inbox', 'session.started',
+        'Parking started', 'Parking for {{plate}} has started.', '{plate}'),
+    (gen_random_uuid(), 'session-ended-inbox', 'inbox', 'session.ended',
+        'Parking ended', 'You parked for {{duration}} minutes. RM {{amount}} was charged.', '{duration,amount}'),
+    (gen_random_uuid(), 'payment-success-inbox', 'inbox', 'payment.success',
+        'Payment successful', 'RM {{amount}} was paid from your wallet.', '{amount}'),
+    (gen_random_uuid(), 'payment-success-email', 'email', 'payment.success',
+        'Your payment receipt', 'RM {{amount}} was paid from your wallet. Transaction reference: {{transaction_id}}.', '{amount,transaction_id}'),
+    (gen_random_uuid(), 'topup-success-inbox', 'inbox', 'topup.success',
+        'Top-up successful', 'RM {{amount}} was added to your wallet.', '{amount}'),
+    (gen_random_uuid(), 'topup-success-email', 'email', 'topup.success',
+        'Your top-up receipt', 'RM {{amount}} was added to your wallet. Transaction reference: {{transaction_id}}.', '{amount,transaction_id}'),
+    (gen_random_uuid(), 'otp-requested-inbox', 'inbox', 'account.otp_requested',
+        'Sign-in code sent', 'A sign-in code was sent to {{phone}}. If you did not ask for it, do not share it with anyone.', '{phone}')
+ON CONFLICT (name) DO NOTHING;
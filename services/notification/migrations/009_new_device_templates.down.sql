@@ -0,0 +1,4 @@
+DELETE FROM notification_templates WHERE name IN (
+    'new-device-login-inbox',
+    'new-device-login-sms'
+);
@@ -0,0 +1,7 @@
+-- Default templates for the alert sent when an account signs in on a new device
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'new-device-login-inbox', 'inbox', 'account.new_device_login',
+        'New device signed in', 'Your account was signed in on {{device_name}} at {{logged_in_at}}. If this was not you, change your password and sign out of all devices.', '{device_name,logged_in_at}'),
+    (gen_random_uuid(), 'new-device-login-sms', 'sms', 'account.new_device_login',
+        'New device signed in', 'ParkingApp: your account was signed in on a new device ({{device_name}}). Not you? Change your password now.', '{device_name}')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,6 @@
+-- Inbox search: full-text over title and body, and filtered listing by user
+CREATE INDEX idx_notifications_search ON notifications
+    USING GIN (to_tsvector('simple', title || ' ' || body));
+
+CREATE INDEX idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX idx_notifications_user_type ON notifications(user_id, type, created_at DESC);
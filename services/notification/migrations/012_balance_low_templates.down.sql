@@ -0,0 +1,4 @@
+DELETE FROM notification_templates WHERE name IN (
+    'balance-low-inbox',
+    'balance-low-push'
+);
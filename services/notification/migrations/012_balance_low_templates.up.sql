@@ -0,0 +1,8 @@
+-- Default templates for the alert sent when a payment takes a wallet below
+-- the user's low-balance threshold
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'balance-low-inbox', 'inbox', 'wallet.balance_low',
+        'Low wallet balance', 'Your wallet balance is RM {{balance}}, below your alert of RM {{threshold}}. Top up to keep paying for parking.', '{balance,threshold}'),
+    (gen_random_uuid(), 'balance-low-push', 'push', 'wallet.balance_low',
+        'Low wallet balance', 'Your balance is RM {{balance}}. Top up now to avoid failed payments.', '{balance}')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,4 @@
+DELETE FROM notification_templates WHERE name IN (
+    'invoice-issued-email',
+    'invoice-issued-inbox'
+);
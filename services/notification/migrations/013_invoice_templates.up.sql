@@ -0,0 +1,7 @@
+-- Default templates for the monthly invoice sent to business accounts
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'invoice-issued-email', 'email', 'wallet.invoice_issued',
+        'Your parking invoice {{invoice_number}}', 'Your invoice {{invoice_number}} for {{company_name}} covering {{period}} is ready. Total: RM {{total}}, including RM {{tax_total}} tax. Download it from Wallet > Invoices in the app.', '{invoice_number,company_name,period,total,tax_total}'),
+    (gen_random_uuid(), 'invoice-issued-inbox', 'inbox', 'wallet.invoice_issued',
+        'Invoice ready', 'Your {{period}} parking invoice {{invoice_number}} for RM {{total}} is ready to download.', '{period,invoice_number,total}')
+ON CONFLICT (name) DO NOTHING;
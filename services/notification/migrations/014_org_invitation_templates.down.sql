@@ -0,0 +1,4 @@
+DELETE FROM notification_templates WHERE name IN (
+    'org-invitation-inbox',
+    'org-invitation-push'
+);
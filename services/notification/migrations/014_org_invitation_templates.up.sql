@@ -0,0 +1,8 @@
+-- Default templates telling a user an organization invited them to charge
+-- their parking to its wallet
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'org-invitation-inbox', 'inbox', 'org.invitation',
+        'Organization invitation', '{{name}} invited you to join. Accept to charge your parking to {{name}}.', '{name}'),
+    (gen_random_uuid(), 'org-invitation-push', 'push', 'org.invitation',
+        'Organization invitation', '{{name}} invited you to park on its account.', '{name}')
+ON CONFLICT (name) DO NOTHING;
@@ -0,0 +1,7 @@
+DELETE FROM notification_templates WHERE name IN (
+    'dispute-opened-inbox',
+    'dispute-under-review-inbox',
+    'dispute-under-review-push',
+    'dispute-resolved-inbox',
+    'dispute-resolved-push'
+);
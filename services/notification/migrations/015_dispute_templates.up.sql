@@ -0,0 +1,14 @@
+-- Default templates keeping a user informed as their session dispute is
+-- opened, reviewed and resolved
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'dispute-opened-inbox', 'inbox', 'dispute.opened',
+        'Dispute received', 'We received your dispute of the {{currency}} {{amount}} parking charge and will look into it.', '{currency,amount}'),
+    (gen_random_uuid(), 'dispute-under-review-inbox', 'inbox', 'dispute.under_review',
+        'Dispute under review', 'Our team is reviewing your dispute of the {{currency}} {{amount}} parking charge.', '{currency,amount}'),
+    (gen_random_uuid(), 'dispute-under-review-push', 'push', 'dispute.under_review',
+        'Dispute under review', 'Your parking dispute is being reviewed.', '{}'),
+    (gen_random_uuid(), 'dispute-resolved-inbox', 'inbox', 'dispute.resolved',
+        'Dispute resolved', 'Your dispute of the {{currency}} {{amount}} parking charge was resolved: {{resolution}}. {{resolution_note}}', '{currency,amount,resolution,resolution_note}'),
+    (gen_random_uuid(), 'dispute-resolved-push', 'push', 'dispute.resolved',
+        'Dispute resolved', 'Your parking dispute was resolved: {{resolution}}.', '{resolution}')
+ON CONFLICT (name) DO NOTHING;
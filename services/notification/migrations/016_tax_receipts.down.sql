@@ -0,0 +1,11 @@
+UPDATE notification_templates
+SET body = 'You parked for {{duration}} minutes. RM {{amount}} was charged.',
+    variables = '{duration,amount}'
+WHERE name = 'session-ended-inbox'
+  AND body = 'You parked for {{duration}} minutes. RM {{amount}} was charged, including RM {{tax_amount}} tax.';
+
+UPDATE notification_templates
+SET body = 'RM {{amount}} was paid from your wallet. Transaction reference: {{transaction_id}}.',
+    variables = '{amount,transaction_id}'
+WHERE name = 'payment-success-email'
+  AND body = 'RM {{amount}} was paid from your wallet, including RM {{tax_amount}} tax. Transaction reference: {{transaction_id}}.';
@@ -0,0 +1,13 @@
+-- Show the tax in parking charges on the default receipts. Templates an
+-- admin has edited are left as they are.
+UPDATE notification_templates
+SET body = 'You parked for {{duration}} minutes. RM {{amount}} was charged, including RM {{tax_amount}} tax.',
+    variables = '{duration,amount,tax_amount}'
+WHERE name = 'session-ended-inbox'
+  AND body = 'You parked for {{duration}} minutes. RM {{amount}} was charged.';
+
+UPDATE notification_templates
+SET body = 'RM {{amount}} was paid from your wallet, including RM {{tax_amount}} tax. Transaction reference: {{transaction_id}}.',
+    variables = '{amount,tax_amount,transaction_id}'
+WHERE name = 'payment-success-email'
+  AND body = 'RM {{amount}} was paid from your wallet. Transaction reference: {{transaction_id}}.';
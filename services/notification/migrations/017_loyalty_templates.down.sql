@@ -0,0 +1,5 @@
+DELETE FROM notification_templates WHERE name IN (
+    'loyalty-earned-inbox',
+    'loyalty-redeemed-inbox',
+    'loyalty-redeemed-push'
+);
@@ -0,0 +1,10 @@
+-- Default templates telling a user when they earn loyalty points and when
+-- they redeem them for wallet credit
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'loyalty-earned-inbox', 'inbox', 'loyalty.earned',
+        'Points earned', 'You earned {{points}} points on your RM {{amount}} payment. You now have {{balance}} points.', '{points,amount,balance}'),
+    (gen_random_uuid(), 'loyalty-redeemed-inbox', 'inbox', 'loyalty.redeemed',
+        'Points redeemed', 'You redeemed {{points}} points for {{currency}} {{credit}} of wallet credit. {{balance}} points left.', '{points,currency,credit,balance}'),
+    (gen_random_uuid(), 'loyalty-redeemed-push', 'push', 'loyalty.redeemed',
+        'Points redeemed', '{{currency}} {{credit}} has been added to your wallet.', '{currency,credit}')
+ON CONFLICT (name) DO NOTHING;
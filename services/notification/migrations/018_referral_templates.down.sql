@@ -0,0 +1,4 @@
+DELETE FROM notification_templates WHERE name IN (
+    'referral-rewarded-inbox',
+    'referral-rewarded-push'
+);
@@ -0,0 +1,8 @@
+-- Default templates telling both sides of a referral that their reward has
+-- been paid into their wallet
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'referral-rewarded-inbox', 'inbox', 'referral.rewarded',
+        'Referral reward', 'RM {{amount}} referral reward has been added to your wallet. Your balance is RM {{balance}}.', '{amount,balance}'),
+    (gen_random_uuid(), 'referral-rewarded-push', 'push', 'referral.rewarded',
+        'Referral reward', 'RM {{amount}} has been added to your wallet.', '{amount}')
+ON CONFLICT (name) DO NOTHING;
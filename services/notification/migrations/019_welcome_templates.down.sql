@@ -0,0 +1 @@
+DELETE FROM notification_templates WHERE name = 'user-welcome-push';
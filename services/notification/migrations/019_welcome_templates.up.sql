@@ -0,0 +1,6 @@
+-- Default template for the welcome push the auth service sends over gRPC when
+-- a user registers from the app
+INSERT INTO notification_templates (id, name, channel, type, title, body, variables) VALUES
+    (gen_random_uuid(), 'user-welcome-push', 'push', 'user.welcome',
+        'Welcome to Parking', 'Hi {{name}}, your account is ready. Top up your wallet to start parking.', '{name}')
+ON CONFLICT (name) DO NOTHING;
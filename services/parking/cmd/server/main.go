@@ -11,41 +11,64 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/eventstore"
+	"github.com/parking-super-app/pkg/featureflags"
+	"github.com/parking-super-app/pkg/grpc/client"
 	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/httpserver"
 	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/migrate"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
+	"github.com/parking-super-app/pkg/storage"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/parking/config"
+	"github.com/parking-super-app/services/parking/internal/adapters/drivers"
 	"github.com/parking-super-app/services/parking/internal/adapters/external"
-	grpcClients "github.com/parking-super-app/services/parking/internal/adapters/grpc"
+	grpcAdapter "github.com/parking-super-app/services/parking/internal/adapters/grpc"
 	httpAdapter "github.com/parking-super-app/services/parking/internal/adapters/http"
 	"github.com/parking-super-app/services/parking/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/parking/internal/adapters/stream"
 	"github.com/parking-super-app/services/parking/internal/application"
 	"github.com/parking-super-app/services/parking/internal/ports"
-	"google.golang.org/grpc"
+	"github.com/parking-super-app/services/parking/migrations"
 )
 
 func main() {
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	logger := external.NewStdLogger()
+	logger := logging.New(cfg.Log)
 	logger.Info("starting parking service")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Values with a reload hook change on SIGHUP or a POST to
+	// settings.ReloadPath; everything else needs a restart
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("log level", logger.ReloadLevel)
+	reloader.WatchSignals(ctx)
+
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -54,6 +77,17 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			logger.Info("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Connect to PostgreSQL
 	pool, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
 	if err != nil {
@@ -61,33 +95,103 @@ func main() {
 	}
 	defer pool.Close()
 
-	if err := pool.Ping(ctx); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	if err := startup.Wait(ctx, cfg.Startup, logger, "postgres", startup.Postgres(pool)); err != nil {
+		log.Fatalf("failed to reach database: %v", err)
 	}
 	logger.Info("connected to database")
 
+	// Schema migrations are embedded in the binary. "parking-service migrate ..."
+	// manages them and exits; otherwise they can be applied before serving.
+	migrator, err := migrate.New(pool, migrations.FS, logger)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.RunCommand(ctx, migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("failed to run migrations: %v", err)
+		}
+		return
+	}
+	if cfg.Database.MigrateOnStart {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+	}
+
+	// Lag-tolerant reads go to the read replica when one is configured
+	readPool := pool
+	if cfg.Database.ReplicaURL != "" {
+		replica, err := pgxpool.New(ctx, cfg.Database.ReplicaURL)
+		if err != nil {
+			log.Fatalf("failed to connect to read replica: %v", err)
+		}
+		defer replica.Close()
+		if err := startup.Wait(ctx, cfg.Startup, logger, "postgres-replica", startup.Postgres(replica)); err != nil {
+			log.Fatalf("failed to reach read replica: %v", err)
+		}
+		readPool = replica
+		logger.Info("routing reads to database replica")
+	}
+
 	// Initialize repositories
-	sessionRepo := postgres.NewSessionRepository(pool)
-	vehicleRepo := postgres.NewVehicleRepository(pool)
+	sessionRepo := postgres.NewSessionRepository(pool, readPool)
+	vehicleRepo := postgres.NewVehicleRepository(pool, readPool)
+	consistencyRepo := postgres.NewConsistencyReportRepository(pool, readPool)
+	erasureRepo := postgres.NewErasureRepository(pool)
+	planRepo := postgres.NewSubscriptionPlanRepository(pool, readPool)
+	subscriptionRepo := postgres.NewSubscriptionRepository(pool, readPool)
+	reservationRepo := postgres.NewReservationRepository(pool, readPool)
+	streetZoneRepo := postgres.NewStreetZoneRepository(pool, readPool)
+	streetSessionRepo := postgres.NewStreetSessionRepository(pool, readPool)
+	compoundRepo := postgres.NewCompoundRepository(pool, readPool)
+	disputeRepo := postgres.NewDisputeRepository(pool)
+	taxRateRepo := postgres.NewTaxRateRepository(pool)
+	attachmentRepo := postgres.NewAttachmentRepository(pool)
 
 	// Initialize gRPC clients for dependent services or fallback to mock
 	var providerClient ports.ProviderClient
 	var walletClient ports.WalletClient
-	var providerGRPCClient *grpcClients.ProviderGRPCClient
-	var walletGRPCClient *grpcClients.WalletGRPCClient
+	var providerGRPCClient *grpcAdapter.ProviderGRPCClient
+	var walletGRPCClient *grpcAdapter.WalletGRPCClient
+	var providerDrivers *drivers.Registry
 
 	if cfg.Services.ProviderGRPC != "" && cfg.Services.WalletGRPC != "" {
+		clientConfig := func(target string) client.Config {
+			c := client.DefaultConfig(target)
+			c.DefaultTimeout = cfg.Services.CallTimeout
+			c.Retry.MaxAttempts = cfg.Services.MaxAttempts
+			c.Keepalive.Time = cfg.Services.KeepaliveTime
+			if err := c.UseMutualTLS(cfg.GRPC.TLS); err != nil {
+				log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+			}
+			return c
+		}
+
 		// Try to connect via gRPC
-		providerGRPCClient, err = grpcClients.NewProviderGRPCClient(cfg.Services.ProviderGRPC)
+		providerGRPCClient, err = grpcAdapter.NewProviderGRPCClient(clientConfig(cfg.Services.ProviderGRPC))
 		if err != nil {
 			log.Printf("warning: failed to connect to provider service, using mock: %v", err)
 			providerClient = external.NewMockProviderClient()
 		} else {
 			providerClient = providerGRPCClient
 			logger.Info("connected to provider service via gRPC")
+
+			// Providers with their own session APIs get a driver by code
+			if cfg.Services.ProviderDriversFile != "" {
+				driverConfigs, err := drivers.LoadConfigs(cfg.Services.ProviderDriversFile)
+				if err != nil {
+					log.Fatalf("failed to load provider drivers: %v", err)
+				}
+				providerDrivers, err = drivers.Build(driverConfigs, clientConfig)
+				if err != nil {
+					log.Fatalf("failed to create provider drivers: %v", err)
+				}
+				providerClient = drivers.NewRouter(providerGRPCClient, providerGRPCClient, providerDrivers)
+				logger.Info("provider drivers loaded", logging.Int("drivers", providerDrivers.Len()))
+			}
 		}
 
-		walletGRPCClient, err = grpcClients.NewWalletGRPCClient(cfg.Services.WalletGRPC)
+		walletGRPCClient, err = grpcAdapter.NewWalletGRPCClient(clientConfig(cfg.Services.WalletGRPC))
 		if err != nil {
 			log.Printf("warning: failed to connect to wallet service, using mock: %v", err)
 			walletClient = external.NewMockWalletClient()
@@ -102,44 +206,378 @@ func main() {
 		logger.Info("using mock clients for provider and wallet services")
 	}
 
+	// Session event hub for SSE streams
+	sessionHub := stream.NewHub(stream.HubConfig{
+		MaxStreams:        cfg.Stream.MaxConnections,
+		MaxStreamsPerUser: cfg.Stream.MaxConnectionsPerUser,
+		BufferSize:        stream.DefaultHubConfig().BufferSize,
+	})
+
+	// Record published events for the admin event browser
+	eventStore, closeEventStore, err := eventstore.OpenStore(ctx, eventstore.StoreConfig{
+		Backend:     cfg.EventStore.Backend,
+		PostgresURL: cfg.EventStore.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: event store unavailable, keeping events in memory: %v", err)
+		eventStore, closeEventStore = eventstore.NewMemoryStore(eventstore.DefaultMemoryCapacity), func() {}
+	}
+	defer closeEventStore()
+
 	// Initialize event publisher (Kafka or Noop)
 	var eventPublisher ports.EventPublisher
 	var kafkaPublisher *kafka.Publisher
+	var kafkaConsumer *kafka.Consumer
 	if cfg.Kafka.Enabled {
+		if err := startup.Wait(ctx, cfg.Startup, logger, "kafka", startup.Kafka(cfg.Kafka.Brokers)); err != nil {
+			log.Fatalf("failed to reach Kafka: %v", err)
+		}
 		kafkaPublisher = kafka.NewPublisher(kafka.DefaultPublisherConfig(cfg.Kafka.Brokers, cfg.Kafka.Topic))
-		eventPublisher = &kafkaEventAdapter{publisher: kafkaPublisher}
+		eventPublisher = &kafkaEventAdapter{
+			publisher: eventstore.NewRecordingPublisher(kafkaPublisher, eventStore, "parking"),
+		}
 		logger.Info("Kafka event publisher initialized")
+
+		// Session events come back through Kafka so every instance can serve every stream
+		kafkaConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.Topic,
+			cfg.Stream.ConsumerGroup,
+		))
+		for _, eventType := range ports.SessionEventTypes {
+			kafkaConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+				return sessionHub.Publish(ctx, ports.Event{Type: event.Type, Payload: event.Payload})
+			})
+		}
+		go func() {
+			logger.Info("starting Kafka consumer for session streams")
+			if err := kafkaConsumer.Start(ctx); err != nil {
+				log.Printf("Kafka consumer error: %v", err)
+			}
+		}()
 	} else {
-		eventPublisher = external.NewNoopEventPublisher()
+		// Without Kafka, relay events to local streams directly
+		eventPublisher = &fanoutEventPublisher{
+			publishers: []ports.EventPublisher{external.NewNoopEventPublisher(), sessionHub},
+		}
+	}
+
+	// Feature flags gate new flows; entry-time pricing stays on unless switched off
+	flagStore, closeFlagStore, err := featureflags.OpenStore(ctx, featureflags.StoreConfig{
+		Backend:       cfg.Flags.Backend,
+		RedisAddr:     cfg.Flags.RedisAddr,
+		RedisPassword: cfg.Flags.RedisPassword,
+		RedisDB:       cfg.Flags.RedisDB,
+		PostgresURL:   cfg.Flags.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: feature flag store unavailable, using defaults: %v", err)
+		flagStore, closeFlagStore = featureflags.NewMemoryStore(), func() {}
 	}
+	defer closeFlagStore()
+
+	flags := featureflags.NewClient(flagStore, featureflags.ClientConfig{
+		RefreshInterval: cfg.Flags.RefreshInterval,
+		Defaults: map[string]bool{
+			ports.FlagEntryTimePricing: true,
+		},
+	})
+	if err := flags.Start(ctx); err != nil {
+		logger.Warn("failed to load feature flags, using defaults", ports.Err(err))
+	}
+	reloader.OnReload("feature flags", func() error { return flags.Refresh(ctx) })
 
 	// Initialize application service
 	parkingService := application.NewParkingService(
 		sessionRepo,
 		vehicleRepo,
+		subscriptionRepo,
+		reservationRepo,
+		taxRateRepo,
+		providerClient,
+		walletClient,
+		eventPublisher,
+		flags,
+		logger,
+	)
+
+	// Push running amounts and auto-end warnings to session streams
+	go func() {
+		ticker := time.NewTicker(cfg.Monitor.Interval)
+		defer ticker.Stop()
+		monitorCfg := application.SessionMonitorConfig{
+			Interval:    cfg.Monitor.Interval,
+			MaxDuration: cfg.Monitor.MaxDuration,
+			WarnBefore:  cfg.Monitor.WarnBefore,
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := parkingService.PublishActiveSessionUpdates(ctx, monitorCfg); err != nil {
+					logger.Error("session monitor failed", ports.Err(err))
+				}
+			}
+		}
+	}()
+
+	// Cross-check paid sessions against wallet payments to catch dual-write orphans
+	consistencyService := application.NewConsistencyService(
+		sessionRepo,
+		consistencyRepo,
+		walletClient,
+		eventPublisher,
+		logger,
+		application.ConsistencyCheckConfig{
+			Interval: cfg.Consistency.Interval,
+			Lag:      cfg.Consistency.Lag,
+			Slack:    cfg.Consistency.Slack,
+		},
+	)
+	if cfg.Consistency.Enabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Consistency.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := consistencyService.RunScheduledCheck(ctx, now); err != nil {
+						logger.Error("consistency check failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Deleted users: erase vehicles and plates on user.deleted, and finish
+	// records that were still in use at the time once they end
+	erasureService := application.NewErasureService(erasureRepo, logger)
+	var userEventsConsumer *kafka.Consumer
+	if cfg.Kafka.Enabled && cfg.Kafka.UserEventsTopic != "" {
+		userEventsConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.UserEventsTopic,
+			cfg.Kafka.ConsumerGroup,
+		))
+		for _, eventType := range erasureService.EventTypes() {
+			userEventsConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+				return erasureService.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		// Guests who register take their guest sessions with them
+		guestClaims := application.NewGuestClaims(parkingService, logger)
+		for _, eventType := range guestClaims.EventTypes() {
+			userEventsConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+				return guestClaims.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		go func() {
+			logger.Info("starting Kafka consumer", ports.String("topic", cfg.Kafka.UserEventsTopic))
+			if err := userEventsConsumer.Start(ctx); err != nil {
+				log.Printf("Kafka consumer error: %v", err)
+			}
+		}()
+	}
+	if cfg.Erasure.Enabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Erasure.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := erasureService.FinishOpen(ctx, now.UTC(), cfg.Erasure.BatchSize); err != nil {
+						logger.Error("erasure run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Season passes: renew auto-renewing passes, warn before expiry, expire lapsed ones
+	subscriptionService := application.NewSubscriptionService(
+		planRepo,
+		subscriptionRepo,
+		walletClient,
+		eventPublisher,
+		logger,
+		application.SubscriptionConfig{
+			RenewalLead:   cfg.Subscription.RenewalLead,
+			ExpiryWarning: cfg.Subscription.ExpiryWarning,
+		},
+	)
+	if cfg.Subscription.RenewalEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Subscription.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := subscriptionService.ProcessRenewals(ctx, now.UTC()); err != nil {
+						logger.Error("subscription renewal run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Reservations: close bookings the vehicle did not arrive for
+	reservationService := application.NewReservationService(
+		reservationRepo,
+		providerClient,
+		walletClient,
+		eventPublisher,
+		logger,
+		application.ReservationConfig{
+			EarlyArrival:     cfg.Reservation.EarlyArrival,
+			NoShowGrace:      cfg.Reservation.NoShowGrace,
+			NoShowFeePercent: cfg.Reservation.NoShowFeePercent,
+			MaxDuration:      cfg.Reservation.MaxDuration,
+			MaxAdvance:       cfg.Reservation.MaxAdvance,
+		},
+	)
+	if cfg.Reservation.NoShowEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Reservation.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := reservationService.ProcessNoShows(ctx, now.UTC()); err != nil {
+						logger.Error("reservation no-show run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Street parking: remind users before paid time runs out, expire lapsed sessions
+	streetService := application.NewStreetParkingService(
+		streetZoneRepo,
+		streetSessionRepo,
+		walletClient,
+		eventPublisher,
+		logger,
+		application.StreetConfig{
+			ReminderLead: cfg.Street.ReminderLead,
+		},
+	)
+	if cfg.Street.ExpiryEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Street.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := streetService.ProcessExpiries(ctx, now.UTC()); err != nil {
+						logger.Error("street expiry run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Compounds: fines are looked up at their issuers and settled after wallet payment
+	compoundService := application.NewCompoundService(
+		compoundRepo,
+		vehicleRepo,
 		providerClient,
 		walletClient,
 		eventPublisher,
 		logger,
+		application.CompoundConfig{
+			RetryDelay: cfg.Compound.RetryDelay,
+		},
 	)
+	if cfg.Compound.SettleRetryEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Compound.Interval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := compoundService.ProcessSettlements(ctx, now.UTC()); err != nil {
+						logger.Error("compound settlement run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
 
-	// Initialize HTTP router with tracing middleware
-	router := httpAdapter.NewRouter(parkingService)
+	compareService := application.NewCompareService(providerClient, logger, application.CompareConfig{
+		DefaultRadiusKm: cfg.Compare.DefaultRadiusKm,
+		MaxRadiusKm:     cfg.Compare.MaxRadiusKm,
+		PricingCacheTTL: cfg.Compare.PricingCacheTTL,
+	})
+
+	// Disputes: admins resolve them, refunding through the wallet
+	disputeService := application.NewDisputeService(disputeRepo, sessionRepo, walletClient, eventPublisher, logger)
+
+	// Tax rates: set by admins, added to sessions' charges as they end
+	taxService := application.NewTaxService(taxRateRepo, logger)
+
+	// Session photos: files go straight to object storage with presigned URLs
+	var attachmentStore ports.AttachmentStore
+	var attachmentFiles http.Handler
+	if cfg.Attachments.Storage.Backend != "" {
+		store, err := storage.Open(cfg.Attachments.Storage)
+		if err != nil {
+			log.Fatalf("failed to configure attachment storage: %v", err)
+		}
+		attachmentStore = store
+		// The local backend serves its presigned requests itself
+		if local, ok := store.(*storage.LocalStore); ok {
+			attachmentFiles = http.StripPrefix("/files", local.Handler())
+		}
+		logger.Info("session attachments enabled", ports.String("backend", cfg.Attachments.Storage.Backend))
+	} else {
+		logger.Info("no attachment storage configured, session attachments disabled")
+	}
+	attachmentService := application.NewAttachmentService(attachmentRepo, sessionRepo, disputeRepo, attachmentStore, logger, application.AttachmentConfig{
+		UploadURLTTL:   cfg.Attachments.UploadURLTTL,
+		DownloadURLTTL: cfg.Attachments.DownloadURLTTL,
+	})
+
+	// Initialize HTTP router with request logging and tracing middleware.
+	// Tracing is added last so it wraps the request logger.
+	router := httpAdapter.NewRouter(parkingService, consistencyService, subscriptionService, reservationService, streetService, compoundService, compareService, disputeService, taxService, attachmentService, sessionHub)
+	router.Use(logging.RequestLogger(logger))
 	if cfg.OTEL.Enabled {
 		router.Use(middleware.Tracing(cfg.OTEL.ServiceName))
 	}
 
 	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	var handler http.Handler = router
+	if attachmentFiles != nil {
+		mux := http.NewServeMux()
+		mux.Handle("/files/", attachmentFiles)
+		mux.Handle("/", router)
+		handler = mux
+	}
+	httpServer, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(handler), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
-	// Create gRPC server (for future use when parking exposes gRPC)
-	grpcServer := interceptors.NewServerWithDefaults()
+	// Create gRPC server for other services to query and drive sessions
+	grpcTLS, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+	}
+	grpcServer := interceptors.NewServerWithDefaults(grpcTLS...)
+	parkingv1.RegisterParkingServiceServer(grpcServer, grpcAdapter.NewParkingServiceServer(parkingService))
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
@@ -181,6 +619,9 @@ func main() {
 	grpcServer.GracefulStop()
 
 	// Close gRPC clients
+	if providerDrivers != nil {
+		providerDrivers.Close()
+	}
 	if providerGRPCClient != nil {
 		providerGRPCClient.Close()
 	}
@@ -188,7 +629,17 @@ func main() {
 		walletGRPCClient.Close()
 	}
 
-	// Close Kafka publisher
+	// Close Kafka consumer and publisher
+	if userEventsConsumer != nil {
+		if err := userEventsConsumer.Close(); err != nil {
+			log.Printf("failed to close Kafka consumer: %v", err)
+		}
+	}
+	if kafkaConsumer != nil {
+		if err := kafkaConsumer.Close(); err != nil {
+			log.Printf("failed to close Kafka consumer: %v", err)
+		}
+	}
 	if kafkaPublisher != nil {
 		if err := kafkaPublisher.Close(); err != nil {
 			log.Printf("failed to close Kafka publisher: %v", err)
@@ -201,13 +652,18 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	logger.Info("server stopped gracefully")
 }
 
 // kafkaEventAdapter adapts kafka.Publisher to ports.EventPublisher
 type kafkaEventAdapter struct {
-	publisher *kafka.Publisher
+	publisher kafka.EventPublisher
 }
 
 func (a *kafkaEventAdapter) Publish(ctx context.Context, event ports.Event) error {
@@ -216,3 +672,17 @@ func (a *kafkaEventAdapter) Publish(ctx context.Context, event ports.Event) erro
 		Payload: event.Payload,
 	})
 }
+
+// fanoutEventPublisher publishes each event to every wrapped publisher
+type fanoutEventPublisher struct {
+	publishers []ports.EventPublisher
+}
+
+func (f *fanoutEventPublisher) Publish(ctx context.Context, event ports.Event) error {
+	for _, p := range f.publishers {
+		if err := p.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -5,23 +5,48 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
+	"github.com/parking-super-app/pkg/storage"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	GRPC     GRPCConfig
-	Kafka    KafkaConfig
-	OTEL     OTELConfig
-	Services ServicesConfig
+	Server       ServerConfig
+	Log          logging.Config
+	Startup      startup.Config
+	Database     DatabaseConfig
+	GRPC         GRPCConfig
+	Kafka        KafkaConfig
+	EventStore   EventStoreConfig
+	OTEL         OTELConfig
+	Services     ServicesConfig
+	Stream       StreamConfig
+	Monitor      MonitorConfig
+	Flags        FlagsConfig
+	Consistency  ConsistencyConfig
+	Erasure      ErasureConfig
+	Subscription SubscriptionConfig
+	Reservation  ReservationConfig
+	Street       StreetConfig
+	Compound     CompoundConfig
+	Compare      CompareConfig
+	Attachments  AttachmentsConfig
 }
 
 type ServerConfig struct {
 	Port string
+	HTTP httpserver.Config
 }
 
 type GRPCConfig struct {
 	Port string
+	// TLS secures the gRPC listener and calls to other services
+	TLS mtls.Config
 }
 
 type DatabaseConfig struct {
@@ -31,12 +56,25 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool
+	// ReplicaURL is an optional read replica DSN for lag-tolerant queries
+	ReplicaURL string
 }
 
 type KafkaConfig struct {
-	Brokers []string
-	Topic   string
-	Enabled bool
+	Brokers         []string
+	Topic           string
+	UserEventsTopic string // Auth events; user.deleted triggers erasure. Empty disables the consumer
+	ConsumerGroup   string // Shared by all instances, unlike the stream consumer group
+	Enabled         bool
+}
+
+// EventStoreConfig selects where published events are recorded for the
+// admin event browser: memory or postgres
+type EventStoreConfig struct {
+	Backend     string
+	PostgresURL string
 }
 
 type OTELConfig struct {
@@ -44,12 +82,114 @@ type OTELConfig struct {
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
 }
 
-// ServicesConfig holds addresses for dependent services
+// ServicesConfig holds addresses for dependent services and the call policy
+// used for them
 type ServicesConfig struct {
 	WalletGRPC   string
 	ProviderGRPC string
+
+	CallTimeout   time.Duration
+	MaxAttempts   int // including the first attempt; retries only on UNAVAILABLE
+	KeepaliveTime time.Duration
+
+	// ProviderDriversFile lists providers whose sessions run through their
+	// own API rather than the provider service; empty disables drivers
+	ProviderDriversFile string
+}
+
+// StreamConfig holds limits for session event streams (SSE)
+type StreamConfig struct {
+	MaxConnections        int
+	MaxConnectionsPerUser int
+	ConsumerGroup         string // Must be unique per instance so every instance sees every event
+}
+
+// MonitorConfig controls the active session update job
+type MonitorConfig struct {
+	Interval    time.Duration
+	MaxDuration time.Duration
+	WarnBefore  time.Duration
+}
+
+// ErasureConfig controls the job that finishes erasing deleted users'
+// records once they are no longer in use
+type ErasureConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	BatchSize int
+}
+
+// ConsistencyConfig controls the session/payment consistency checker
+type ConsistencyConfig struct {
+	Enabled  bool
+	Interval time.Duration
+	Lag      time.Duration
+	Slack    time.Duration
+}
+
+// SubscriptionConfig controls the season pass renewal and expiry job
+type SubscriptionConfig struct {
+	RenewalEnabled bool
+	Interval       time.Duration
+	RenewalLead    time.Duration // Auto-renewing passes are charged this long before they expire
+	ExpiryWarning  time.Duration // Holders are warned this long before their pass expires
+}
+
+// ReservationConfig controls booking limits and the no-show job
+type ReservationConfig struct {
+	NoShowEnabled    bool
+	Interval         time.Duration
+	EarlyArrival     time.Duration // A vehicle may arrive this long before its booking starts
+	NoShowGrace      time.Duration // Bookings not arrived for this long after they start are closed
+	NoShowFeePercent int           // Share of the hold kept for no-shows and late cancellations
+	MaxDuration      time.Duration
+	MaxAdvance       time.Duration
+}
+
+// StreetConfig controls the street session expiry job
+type StreetConfig struct {
+	ExpiryEnabled bool
+	Interval      time.Duration
+	ReminderLead  time.Duration // Users are reminded this long before their paid time runs out
+}
+
+// CompoundConfig controls the job that retries settling paid fines with their issuers
+type CompoundConfig struct {
+	SettleRetryEnabled bool
+	Interval           time.Duration
+	RetryDelay         time.Duration
+}
+
+// CompareConfig controls the price comparison endpoint
+type CompareConfig struct {
+	DefaultRadiusKm float64
+	MaxRadiusKm     float64
+	PricingCacheTTL time.Duration
+}
+
+// AttachmentsConfig selects where session photos are kept. Attachments are
+// disabled while no storage backend is set.
+type AttachmentsConfig struct {
+	Storage        storage.Config
+	UploadURLTTL   time.Duration
+	DownloadURLTTL time.Duration
+}
+
+// FlagsConfig selects the shared feature flag store: memory, redis or postgres
+type FlagsConfig struct {
+	Backend         string
+	RedisAddr       string
+	RedisPassword   string
+	RedisDB         int
+	PostgresURL     string
+	RefreshInterval time.Duration
 }
 
 func (d DatabaseConfig) ConnectionString() string {
@@ -60,41 +200,167 @@ func (d DatabaseConfig) ConnectionString() string {
 }
 
 func Load() (*Config, error) {
+	// Without CONFIG_STRICT these fall back to local development values
+	if err := settings.Require("DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME"); err != nil {
+		return nil, err
+	}
+
 	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
+	migrateOnStart, _ := strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	consistencyEnabled, _ := strconv.ParseBool(getEnv("CONSISTENCY_CHECK_ENABLED", "true"))
+	erasureEnabled, _ := strconv.ParseBool(getEnv("ERASURE_JOB_ENABLED", "true"))
+	renewalEnabled, _ := strconv.ParseBool(getEnv("SUBSCRIPTION_RENEWAL_ENABLED", "true"))
+	noShowEnabled, _ := strconv.ParseBool(getEnv("RESERVATION_NO_SHOW_ENABLED", "true"))
+	streetExpiryEnabled, _ := strconv.ParseBool(getEnv("STREET_EXPIRY_ENABLED", "true"))
+	compoundRetryEnabled, _ := strconv.ParseBool(getEnv("COMPOUND_SETTLE_RETRY_ENABLED", "true"))
 
 	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 
+	hostname, _ := os.Hostname()
+
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	logCfg, err := logging.ConfigFromEnv("parking-service")
+	if err != nil {
+		return nil, err
+	}
+
+	startupCfg, err := startup.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	attachmentStorage, err := storage.ConfigFromEnv("ATTACHMENTS")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
+		Log:     logCfg,
+		Startup: startupCfg,
 		GRPC: GRPCConfig{
 			Port: getEnv("GRPC_PORT", "9000"),
+			TLS:  grpcTLS,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5433"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "parking_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "5433"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "parking_db"),
+			SSLMode:        getEnv("DB_SSLMODE", "disable"),
+			MigrateOnStart: migrateOnStart,
+			ReplicaURL:     getEnv("DB_REPLICA_URL", ""),
 		},
 		Kafka: KafkaConfig{
-			Brokers: brokers,
-			Topic:   getEnv("KAFKA_TOPIC", "parking.events"),
-			Enabled: kafkaEnabled,
+			Brokers:         brokers,
+			Topic:           getEnv("KAFKA_TOPIC", "parking.events"),
+			UserEventsTopic: getEnv("KAFKA_USER_EVENTS_TOPIC", "auth.events"),
+			ConsumerGroup:   getEnv("KAFKA_CONSUMER_GROUP", "parking-service"),
+			Enabled:         kafkaEnabled,
+		},
+		EventStore: EventStoreConfig{
+			Backend:     getEnv("EVENT_STORE_BACKEND", "memory"),
+			PostgresURL: getEnv("EVENT_STORE_POSTGRES_URL", ""),
 		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "parking-service"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "parking-service"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: getDurationEnv("OTEL_METRICS_INTERVAL", 30*time.Second),
+			RuntimeMetrics:  otelRuntimeMetrics,
 		},
 		Services: ServicesConfig{
 			WalletGRPC:   getEnv("WALLET_SERVICE_GRPC", "localhost:9082"),
 			ProviderGRPC: getEnv("PROVIDER_SERVICE_GRPC", "localhost:9083"),
+
+			CallTimeout:   getDurationEnv("GRPC_CLIENT_TIMEOUT", 5*time.Second),
+			MaxAttempts:   getIntEnv("GRPC_CLIENT_MAX_ATTEMPTS", 3),
+			KeepaliveTime: getDurationEnv("GRPC_CLIENT_KEEPALIVE_TIME", 30*time.Second),
+
+			ProviderDriversFile: getEnv("PROVIDER_DRIVERS_FILE", ""),
+		},
+		Stream: StreamConfig{
+			MaxConnections:        getIntEnv("STREAM_MAX_CONNECTIONS", 1000),
+			MaxConnectionsPerUser: getIntEnv("STREAM_MAX_CONNECTIONS_PER_USER", 3),
+			ConsumerGroup:         getEnv("STREAM_CONSUMER_GROUP", "parking-stream-"+hostname),
+		},
+		Monitor: MonitorConfig{
+			Interval:    getDurationEnv("SESSION_MONITOR_INTERVAL", time.Minute),
+			MaxDuration: getDurationEnv("SESSION_MAX_DURATION", 24*time.Hour),
+			WarnBefore:  getDurationEnv("SESSION_WARN_BEFORE", 15*time.Minute),
+		},
+		Flags: FlagsConfig{
+			Backend:         getEnv("FEATURE_FLAGS_BACKEND", "memory"),
+			RedisAddr:       getEnv("FEATURE_FLAGS_REDIS_ADDR", "localhost:6379"),
+			RedisPassword:   getEnv("FEATURE_FLAGS_REDIS_PASSWORD", ""),
+			RedisDB:         getIntEnv("FEATURE_FLAGS_REDIS_DB", 0),
+			PostgresURL:     getEnv("FEATURE_FLAGS_POSTGRES_URL", ""),
+			RefreshInterval: getDurationEnv("FEATURE_FLAGS_REFRESH_INTERVAL", 30*time.Second),
+		},
+		Consistency: ConsistencyConfig{
+			Enabled:  consistencyEnabled,
+			Interval: getDurationEnv("CONSISTENCY_CHECK_INTERVAL", 15*time.Minute),
+			Lag:      getDurationEnv("CONSISTENCY_CHECK_LAG", 5*time.Minute),
+			Slack:    getDurationEnv("CONSISTENCY_CHECK_SLACK", 2*time.Minute),
+		},
+		Erasure: ErasureConfig{
+			Enabled:   erasureEnabled,
+			Interval:  getDurationEnv("ERASURE_JOB_INTERVAL", time.Hour),
+			BatchSize: getIntEnv("ERASURE_JOB_BATCH_SIZE", 100),
+		},
+		Subscription: SubscriptionConfig{
+			RenewalEnabled: renewalEnabled,
+			Interval:       getDurationEnv("SUBSCRIPTION_RENEWAL_INTERVAL", time.Hour),
+			RenewalLead:    getDurationEnv("SUBSCRIPTION_RENEWAL_LEAD", 24*time.Hour),
+			ExpiryWarning:  getDurationEnv("SUBSCRIPTION_EXPIRY_WARNING", 72*time.Hour),
+		},
+		Reservation: ReservationConfig{
+			NoShowEnabled:    noShowEnabled,
+			Interval:         getDurationEnv("RESERVATION_NO_SHOW_INTERVAL", 5*time.Minute),
+			EarlyArrival:     getDurationEnv("RESERVATION_EARLY_ARRIVAL", 15*time.Minute),
+			NoShowGrace:      getDurationEnv("RESERVATION_NO_SHOW_GRACE", 30*time.Minute),
+			NoShowFeePercent: getIntEnv("RESERVATION_NO_SHOW_FEE_PERCENT", 50),
+			MaxDuration:      getDurationEnv("RESERVATION_MAX_DURATION", 24*time.Hour),
+			MaxAdvance:       getDurationEnv("RESERVATION_MAX_ADVANCE", 7*24*time.Hour),
+		},
+		Street: StreetConfig{
+			ExpiryEnabled: streetExpiryEnabled,
+			Interval:      getDurationEnv("STREET_EXPIRY_INTERVAL", time.Minute),
+			ReminderLead:  getDurationEnv("STREET_REMINDER_LEAD", 10*time.Minute),
+		},
+		Compound: CompoundConfig{
+			SettleRetryEnabled: compoundRetryEnabled,
+			Interval:           getDurationEnv("COMPOUND_SETTLE_RETRY_INTERVAL", 5*time.Minute),
+			RetryDelay:         getDurationEnv("COMPOUND_SETTLE_RETRY_DELAY", time.Minute),
+		},
+		Compare: CompareConfig{
+			DefaultRadiusKm: getFloatEnv("COMPARE_DEFAULT_RADIUS_KM", 2),
+			MaxRadiusKm:     getFloatEnv("COMPARE_MAX_RADIUS_KM", 10),
+			PricingCacheTTL: getDurationEnv("COMPARE_PRICING_CACHE_TTL", 5*time.Minute),
+		},
+		Attachments: AttachmentsConfig{
+			Storage:        attachmentStorage,
+			UploadURLTTL:   getDurationEnv("ATTACHMENTS_UPLOAD_URL_TTL", 15*time.Minute),
+			DownloadURLTTL: getDurationEnv("ATTACHMENTS_DOWNLOAD_URL_TTL", time.Hour),
 		},
 	}, nil
 }
@@ -105,3 +371,30 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getFloatEnv(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
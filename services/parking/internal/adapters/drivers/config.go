@@ -0,0 +1,113 @@
+package drivers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Style is the kind of API a provider exposes for sessions
+type Style string
+
+const (
+	StyleREST    Style = "rest"    // JSON resources under /sessions
+	StyleWebhook Style = "webhook" // Signed event envelopes POSTed to one URL
+	StyleGRPC    Style = "grpc"    // provider.v1.ProviderService session RPCs
+)
+
+// DefaultTimeout bounds each call to a provider API when its config sets none
+const DefaultTimeout = 10 * time.Second
+
+var (
+	ErrUnknownStyle  = errors.New("unknown provider driver style")
+	ErrDuplicateCode = errors.New("provider code configured twice")
+	ErrMissingCode   = errors.New("provider driver has no code")
+	ErrMissingURL    = errors.New("provider driver has no base URL")
+)
+
+// Config configures the driver for one provider
+type Config struct {
+	Code      string
+	Style     Style
+	BaseURL   string // Endpoint address for gRPC drivers
+	APIKey    string
+	APISecret string // Signs webhook envelopes
+	Timeout   time.Duration
+}
+
+type fileConfig struct {
+	Providers []struct {
+		Code      string `json:"code"`
+		Style     string `json:"style"`
+		BaseURL   string `json:"base_url"`
+		APIKey    string `json:"api_key"`
+		APISecret string `json:"api_secret"`
+		Timeout   string `json:"timeout"`
+	} `json:"providers"`
+}
+
+// LoadConfigs reads driver configs from a JSON file. Credentials may
+// reference environment variables, e.g. "api_key": "${ACME_API_KEY}", so
+// the file itself holds no secrets.
+func LoadConfigs(path string) ([]Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read provider drivers file: %w", err)
+	}
+	return ParseConfigs(data)
+}
+
+// ParseConfigs parses and validates the contents of a drivers file
+func ParseConfigs(data []byte) ([]Config, error) {
+	var file fileConfig
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid provider drivers file: %w", err)
+	}
+
+	configs := make([]Config, 0, len(file.Providers))
+	seen := make(map[string]bool, len(file.Providers))
+	for _, p := range file.Providers {
+		cfg := Config{
+			Code:      strings.TrimSpace(p.Code),
+			Style:     Style(strings.ToLower(strings.TrimSpace(p.Style))),
+			BaseURL:   os.ExpandEnv(p.BaseURL),
+			APIKey:    os.ExpandEnv(p.APIKey),
+			APISecret: os.ExpandEnv(p.APISecret),
+			Timeout:   DefaultTimeout,
+		}
+		if p.Timeout != "" {
+			timeout, err := time.ParseDuration(p.Timeout)
+			if err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("invalid timeout %q for provider %s", p.Timeout, cfg.Code)
+			}
+			cfg.Timeout = timeout
+		}
+		if err := cfg.validate(); err != nil {
+			return nil, err
+		}
+		if seen[cfg.Code] {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateCode, cfg.Code)
+		}
+		seen[cfg.Code] = true
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+func (c Config) validate() error {
+	if c.Code == "" {
+		return ErrMissingCode
+	}
+	switch c.Style {
+	case StyleREST, StyleWebhook, StyleGRPC:
+	default:
+		return fmt.Errorf("%w %q for provider %s", ErrUnknownStyle, c.Style, c.Code)
+	}
+	if c.BaseURL == "" {
+		return fmt.Errorf("%w: %s", ErrMissingURL, c.Code)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package drivers
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseConfigs(t *testing.T) {
+	t.Setenv("ACME_API_KEY", "key-123")
+
+	configs, err := ParseConfigs([]byte(`{"providers": [
+		{"code": "ACME", "style": "REST", "base_url": "https://api.acme.test", "api_key": "${ACME_API_KEY}"},
+		{"code": "GATE", "style": "webhook", "base_url": "https://gate.test/hook", "timeout": "3s"}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseConfigs() error = %v", err)
+	}
+	if len(configs) != 2 {
+		t.Fatalf("expected 2 configs, got %d", len(configs))
+	}
+	if configs[0].Style != StyleREST || configs[0].APIKey != "key-123" || configs[0].Timeout != DefaultTimeout {
+		t.Errorf("unexpected REST config: %+v", configs[0])
+	}
+	if configs[1].Style != StyleWebhook || configs[1].Timeout != 3*time.Second {
+		t.Errorf("unexpected webhook config: %+v", configs[1])
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{"unknown style", `{"providers": [{"code": "A", "style": "soap", "base_url": "x"}]}`, ErrUnknownStyle},
+		{"no code", `{"providers": [{"style": "rest", "base_url": "x"}]}`, ErrMissingCode},
+		{"no url", `{"providers": [{"code": "A", "style": "rest"}]}`, ErrMissingURL},
+		{"duplicate code", `{"providers": [{"code": "A", "style": "rest", "base_url": "x"}, {"code": "A", "style": "grpc", "base_url": "y"}]}`, ErrDuplicateCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseConfigs([]byte(tt.data)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseConfigs() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseConfigs([]byte(`{"providers": [{"code": "A", "style": "rest", "base_url": "x", "timeout": "soon"}]}`)); err == nil {
+		t.Error("expected an error for an invalid timeout")
+	}
+}
@@ -0,0 +1,29 @@
+package drivers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+)
+
+// TestProviderAPIContract records the session API the REST and webhook
+// drivers expect. The provider simulator verifies it, so the simulator
+// stays a faithful stand-in for real providers.
+func TestProviderAPIContract(t *testing.T) {
+	resource := contract.ShapeOf(session{})
+	contract.Record(t, contract.Contract{
+		Consumer: "parking",
+		Provider: "provider-sim",
+		Interactions: []contract.Interaction{
+			{Name: "rest start session", Kind: contract.KindHTTP, Method: http.MethodPost, Path: "/sessions",
+				Request: contract.ShapeOf(sessionRequest{}), Response: resource},
+			{Name: "rest session status", Kind: contract.KindHTTP, Method: http.MethodGet, Path: "/sessions/{id}",
+				Response: resource},
+			{Name: "rest end session", Kind: contract.KindHTTP, Method: http.MethodPost, Path: "/sessions/{id}/end",
+				Response: resource},
+			{Name: "webhook", Kind: contract.KindHTTP, Method: http.MethodPost, Path: "/webhook",
+				Request: contract.ShapeOf(webhookEnvelope{}), Response: resource},
+		},
+	})
+}
@@ -0,0 +1,181 @@
+package drivers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/adapters/external"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+func TestRESTDriver(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if r.Header.Get("X-API-Key") != "key" || r.Header.Get("X-API-Secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/sessions":
+			w.Write([]byte(`{"external_session_id":"ext-1","status":"active","entry_time":"2026-03-01T08:00:00Z"}`))
+		case "/v1/sessions/ext-1/end":
+			w.Write([]byte(`{"external_session_id":"ext-1","status":"completed","exit_time":"2026-03-01T09:00:00Z","duration_minutes":60,"amount":"4.50","currency":"MYR"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	driver := NewRESTDriver(Config{Code: "ACME", Style: StyleREST, BaseURL: server.URL + "/v1/", APIKey: "key", APISecret: "secret", Timeout: DefaultTimeout})
+	ctx := context.Background()
+
+	started, err := driver.StartSession(ctx, ports.StartSessionRequest{VehiclePlate: "WXY1234"})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if started.ExternalSessionID != "ext-1" || started.Status != "active" {
+		t.Errorf("unexpected start response: %+v", started)
+	}
+
+	ended, err := driver.EndSession(ctx, ports.EndSessionRequest{ExternalSessionID: "ext-1"})
+	if err != nil {
+		t.Fatalf("EndSession() error = %v", err)
+	}
+	if !ended.Amount.Equal(decimal.RequireFromString("4.50")) || ended.Duration != 60 || ended.Currency != "MYR" {
+		t.Errorf("unexpected end response: %+v", ended)
+	}
+
+	if _, err := driver.GetSessionStatus(ctx, uuid.New(), "missing"); err == nil {
+		t.Error("expected an error for a 404 status")
+	}
+	if len(paths) != 3 || paths[0] != "POST /v1/sessions" || paths[1] != "POST /v1/sessions/ext-1/end" {
+		t.Errorf("unexpected requests: %v", paths)
+	}
+}
+
+func TestWebhookDriver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(WebhookSignatureHeader) != SignWebhook("secret", r.Header.Get(WebhookTimestampHeader), body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var envelope webhookEnvelope
+		if err := json.Unmarshal(body, &envelope); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch envelope.Type {
+		case WebhookSessionStart:
+			if envelope.Session == nil || envelope.Session.VehiclePlate != "WXY1234" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"external_session_id":"hook-1","status":"active"}`))
+		case WebhookSessionStatus:
+			w.Write([]byte(`{"external_session_id":"` + envelope.ExternalSessionID + `","status":"active","duration_minutes":15,"amount":"1.25"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	driver := NewWebhookDriver(Config{Code: "GATE", Style: StyleWebhook, BaseURL: server.URL, APISecret: "secret", Timeout: DefaultTimeout})
+	ctx := context.Background()
+
+	started, err := driver.StartSession(ctx, ports.StartSessionRequest{VehiclePlate: "WXY1234"})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if started.ExternalSessionID != "hook-1" {
+		t.Errorf("unexpected start response: %+v", started)
+	}
+
+	status, err := driver.GetSessionStatus(ctx, uuid.New(), "hook-1")
+	if err != nil {
+		t.Fatalf("GetSessionStatus() error = %v", err)
+	}
+	if status.Duration != 15 || !status.Amount.Equal(decimal.RequireFromString("1.25")) {
+		t.Errorf("unexpected status response: %+v", status)
+	}
+
+	wrongSecret := NewWebhookDriver(Config{Code: "GATE", Style: StyleWebhook, BaseURL: server.URL, APISecret: "other", Timeout: DefaultTimeout})
+	if _, err := wrongSecret.StartSession(ctx, ports.StartSessionRequest{VehiclePlate: "WXY1234"}); err == nil {
+		t.Error("expected an error when the signature is rejected")
+	}
+}
+
+type fakeDirectory struct {
+	codes map[uuid.UUID]string
+	calls int
+}
+
+func (d *fakeDirectory) ProviderCode(ctx context.Context, providerID uuid.UUID) (string, error) {
+	d.calls++
+	code, ok := d.codes[providerID]
+	if !ok {
+		return "", errors.New("provider not found")
+	}
+	return code, nil
+}
+
+type fakeDriver struct {
+	starts int
+}
+
+func (d *fakeDriver) StartSession(ctx context.Context, req ports.StartSessionRequest) (*ports.StartSessionResponse, error) {
+	d.starts++
+	return &ports.StartSessionResponse{ExternalSessionID: "driver"}, nil
+}
+
+func (d *fakeDriver) EndSession(ctx context.Context, req ports.EndSessionRequest) (*ports.EndSessionResponse, error) {
+	return &ports.EndSessionResponse{}, nil
+}
+
+func (d *fakeDriver) GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*ports.SessionStatusResponse, error) {
+	return &ports.SessionStatusResponse{}, nil
+}
+
+func TestRouter(t *testing.T) {
+	acme, other, unknown := uuid.New(), uuid.New(), uuid.New()
+	directory := &fakeDirectory{codes: map[uuid.UUID]string{acme: "ACME", other: "OTHER"}}
+	driver := &fakeDriver{}
+	registry := NewRegistry()
+	registry.Register("ACME", driver)
+
+	router := NewRouter(external.NewMockProviderClient(), directory, registry)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		resp, err := router.StartSession(ctx, ports.StartSessionRequest{ProviderID: acme})
+		if err != nil {
+			t.Fatalf("StartSession() error = %v", err)
+		}
+		if resp.ExternalSessionID != "driver" {
+			t.Errorf("ACME sessions should go to its driver, got %q", resp.ExternalSessionID)
+		}
+	}
+	if driver.starts != 2 || directory.calls != 1 {
+		t.Errorf("expected 2 driver calls and 1 cached lookup, got %d and %d", driver.starts, directory.calls)
+	}
+
+	resp, err := router.StartSession(ctx, ports.StartSessionRequest{ProviderID: other})
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if resp.ExternalSessionID == "driver" {
+		t.Error("providers without a driver should go to the fallback")
+	}
+
+	if _, err := router.StartSession(ctx, ports.StartSessionRequest{ProviderID: unknown}); err == nil {
+		t.Error("expected an error for a provider the directory cannot resolve")
+	}
+}
@@ -0,0 +1,96 @@
+package drivers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/parking-super-app/pkg/grpc/client"
+	grpcClients "github.com/parking-super-app/services/parking/internal/adapters/grpc"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// Registry holds the session driver of each provider, keyed by provider code
+type Registry struct {
+	drivers map[string]ports.ProviderDriver
+	closers []io.Closer
+	mu      sync.RWMutex
+}
+
+// NewRegistry creates an empty driver registry
+func NewRegistry() *Registry {
+	return &Registry{drivers: make(map[string]ports.ProviderDriver)}
+}
+
+// Register sets the driver for a provider code, replacing any earlier one
+func (r *Registry) Register(code string, driver ports.ProviderDriver) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.drivers[code] = driver
+	if closer, ok := driver.(io.Closer); ok {
+		r.closers = append(r.closers, closer)
+	}
+}
+
+// Driver returns the driver registered for a provider code
+func (r *Registry) Driver(code string) (ports.ProviderDriver, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	driver, ok := r.drivers[code]
+	return driver, ok
+}
+
+// Len returns the number of registered drivers
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.drivers)
+}
+
+// Close releases the connections held by registered drivers
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var errs []error
+	for _, closer := range r.closers {
+		errs = append(errs, closer.Close())
+	}
+	r.closers = nil
+	return errors.Join(errs...)
+}
+
+// Build creates a registry with a driver for each config. grpcConfig
+// supplies the call policy for gRPC drivers, with the target replaced by
+// the provider's base URL.
+func Build(configs []Config, grpcConfig func(target string) client.Config) (*Registry, error) {
+	registry := NewRegistry()
+	for _, cfg := range configs {
+		driver, err := newDriver(cfg, grpcConfig)
+		if err != nil {
+			registry.Close()
+			return nil, fmt.Errorf("failed to create driver for provider %s: %w", cfg.Code, err)
+		}
+		registry.Register(cfg.Code, driver)
+	}
+	return registry, nil
+}
+
+func newDriver(cfg Config, grpcConfig func(target string) client.Config) (ports.ProviderDriver, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	switch cfg.Style {
+	case StyleREST:
+		return NewRESTDriver(cfg), nil
+	case StyleWebhook:
+		return NewWebhookDriver(cfg), nil
+	default:
+		c := client.DefaultConfig(cfg.BaseURL)
+		if grpcConfig != nil {
+			c = grpcConfig(cfg.BaseURL)
+		}
+		c.DefaultTimeout = cfg.Timeout
+		return grpcClients.NewProviderGRPCClient(c)
+	}
+}
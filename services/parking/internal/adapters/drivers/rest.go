@@ -0,0 +1,84 @@
+package drivers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// RESTDriver runs sessions against a provider's JSON API:
+//
+//	POST {base}/sessions            start a session
+//	GET  {base}/sessions/{id}       session status
+//	POST {base}/sessions/{id}/end   end a session
+//
+// Requests are authenticated with the X-API-Key and X-API-Secret headers.
+type RESTDriver struct {
+	cfg    Config
+	client *http.Client
+}
+
+// NewRESTDriver creates a REST driver for a provider
+func NewRESTDriver(cfg Config) *RESTDriver {
+	return &RESTDriver{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (d *RESTDriver) StartSession(ctx context.Context, req ports.StartSessionRequest) (*ports.StartSessionResponse, error) {
+	body, err := json.Marshal(newSessionRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	s, err := d.session(ctx, http.MethodPost, "/sessions", body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start provider session: %w", err)
+	}
+	return s.startResponse()
+}
+
+func (d *RESTDriver) EndSession(ctx context.Context, req ports.EndSessionRequest) (*ports.EndSessionResponse, error) {
+	s, err := d.session(ctx, http.MethodPost, "/sessions/"+url.PathEscape(req.ExternalSessionID)+"/end", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to end provider session: %w", err)
+	}
+	return s.endResponse()
+}
+
+func (d *RESTDriver) GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*ports.SessionStatusResponse, error) {
+	s, err := d.session(ctx, http.MethodGet, "/sessions/"+url.PathEscape(externalSessionID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider session status: %w", err)
+	}
+	return s.statusResponse()
+}
+
+func (d *RESTDriver) session(ctx context.Context, method, path string, body []byte) (*session, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(d.cfg.BaseURL, "/")+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-API-Key", d.cfg.APIKey)
+	req.Header.Set("X-API-Secret", d.cfg.APISecret)
+	return doSession(d.client, req)
+}
+
+// Ensure RESTDriver implements ports.ProviderDriver
+var _ ports.ProviderDriver = (*RESTDriver)(nil)
@@ -0,0 +1,75 @@
+package drivers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// Router sends session calls to the driver registered for the session's
+// provider. Providers without a driver, and every non-session call, go to
+// the fallback client.
+type Router struct {
+	ports.ProviderClient
+	directory ports.ProviderDirectory
+	registry  *Registry
+	codes     sync.Map // provider ID -> code
+}
+
+// NewRouter creates a router over a fallback client. directory resolves the
+// provider ID of a session to the code its driver is registered under.
+func NewRouter(fallback ports.ProviderClient, directory ports.ProviderDirectory, registry *Registry) *Router {
+	return &Router{
+		ProviderClient: fallback,
+		directory:      directory,
+		registry:       registry,
+	}
+}
+
+func (r *Router) StartSession(ctx context.Context, req ports.StartSessionRequest) (*ports.StartSessionResponse, error) {
+	driver, err := r.driver(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	return driver.StartSession(ctx, req)
+}
+
+func (r *Router) EndSession(ctx context.Context, req ports.EndSessionRequest) (*ports.EndSessionResponse, error) {
+	driver, err := r.driver(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	return driver.EndSession(ctx, req)
+}
+
+func (r *Router) GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*ports.SessionStatusResponse, error) {
+	driver, err := r.driver(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	return driver.GetSessionStatus(ctx, providerID, externalSessionID)
+}
+
+// driver picks the driver for a provider. An unresolvable provider fails
+// the call rather than going to the fallback, which may not know it.
+func (r *Router) driver(ctx context.Context, providerID uuid.UUID) (ports.ProviderDriver, error) {
+	code, ok := r.codes.Load(providerID)
+	if !ok {
+		found, err := r.directory.ProviderCode(ctx, providerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve provider code: %w", err)
+		}
+		r.codes.Store(providerID, found)
+		code = found
+	}
+	if driver, ok := r.registry.Driver(code.(string)); ok {
+		return driver, nil
+	}
+	return r.ProviderClient, nil
+}
+
+// Ensure Router implements ports.ProviderClient
+var _ ports.ProviderClient = (*Router)(nil)
@@ -0,0 +1,109 @@
+package drivers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// maxResponseSize caps the provider response bodies drivers read
+const maxResponseSize = 1 << 20
+
+// sessionRequest is the body HTTP drivers send to start a session
+type sessionRequest struct {
+	LocationID   string `json:"location_id"`
+	VehiclePlate string `json:"vehicle_plate"`
+	VehicleType  string `json:"vehicle_type"`
+	UserRef      string `json:"user_ref"`
+}
+
+func newSessionRequest(req ports.StartSessionRequest) sessionRequest {
+	return sessionRequest{
+		LocationID:   req.LocationID.String(),
+		VehiclePlate: req.VehiclePlate,
+		VehicleType:  req.VehicleType,
+		UserRef:      req.UserRef,
+	}
+}
+
+// session is the session resource HTTP providers reply with, the shape
+// the provider conformance suite checks
+type session struct {
+	ExternalSessionID string `json:"external_session_id"`
+	Status            string `json:"status"`
+	EntryTime         string `json:"entry_time"`
+	ExitTime          string `json:"exit_time,omitempty"`
+	DurationMinutes   int    `json:"duration_minutes"`
+	Amount            string `json:"amount,omitempty"`
+	Currency          string `json:"currency,omitempty"`
+}
+
+func (s *session) amount() (decimal.Decimal, error) {
+	if s.Amount == "" {
+		return decimal.Zero, nil
+	}
+	amount, err := decimal.NewFromString(s.Amount)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid amount from provider: %w", err)
+	}
+	return amount, nil
+}
+
+func (s *session) startResponse() (*ports.StartSessionResponse, error) {
+	if s.ExternalSessionID == "" {
+		return nil, fmt.Errorf("provider returned no session ID")
+	}
+	return &ports.StartSessionResponse{
+		ExternalSessionID: s.ExternalSessionID,
+		EntryTime:         s.EntryTime,
+		Status:            s.Status,
+	}, nil
+}
+
+func (s *session) endResponse() (*ports.EndSessionResponse, error) {
+	amount, err := s.amount()
+	if err != nil {
+		return nil, err
+	}
+	return &ports.EndSessionResponse{
+		ExitTime: s.ExitTime,
+		Duration: s.DurationMinutes,
+		Amount:   amount,
+		Currency: s.Currency,
+	}, nil
+}
+
+func (s *session) statusResponse() (*ports.SessionStatusResponse, error) {
+	amount, err := s.amount()
+	if err != nil {
+		return nil, err
+	}
+	return &ports.SessionStatusResponse{
+		Status:   s.Status,
+		Duration: s.DurationMinutes,
+		Amount:   amount,
+	}, nil
+}
+
+// doSession sends a request and decodes the session in the reply
+func doSession(httpClient *http.Client, req *http.Request) (*session, error) {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d", req.Method, req.URL.Path, resp.StatusCode)
+	}
+
+	var s session
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&s); err != nil {
+		return nil, fmt.Errorf("%s %s returned an invalid body: %w", req.Method, req.URL.Path, err)
+	}
+	return &s, nil
+}
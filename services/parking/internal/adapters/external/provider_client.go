@@ -41,3 +41,36 @@ func (c *MockProviderClient) GetSessionStatus(ctx context.Context, providerID uu
 		Amount:   decimal.NewFromFloat(2.50),
 	}, nil
 }
+
+func (c *MockProviderClient) GetPricing(ctx context.Context, locationID uuid.UUID, at time.Time) (*ports.LocationPricing, error) {
+	return &ports.LocationPricing{
+		HourlyRate: decimal.NewFromFloat(2.50),
+		DailyMax:   decimal.NewFromFloat(20.00),
+		Currency:   "MYR",
+	}, nil
+}
+
+// LookupCompounds reports no fines; there is no enforcement data in development
+func (c *MockProviderClient) LookupCompounds(ctx context.Context, plate string) (*ports.CompoundLookup, error) {
+	return &ports.CompoundLookup{Compounds: []ports.CompoundInfo{}}, nil
+}
+
+func (c *MockProviderClient) SettleCompound(ctx context.Context, req ports.SettleCompoundRequest) (*ports.CompoundSettlement, error) {
+	return &ports.CompoundSettlement{
+		ProviderReceipt: "MOCK-" + req.Reference,
+		SettledAt:       time.Now().UTC(),
+	}, nil
+}
+
+func (c *MockProviderClient) GetLocation(ctx context.Context, locationID uuid.UUID) (*ports.LocationInfo, error) {
+	return &ports.LocationInfo{
+		ID:          locationID,
+		Name:        "Mock Location",
+		TotalSpaces: 50,
+		Active:      true,
+	}, nil
+}
+
+func (c *MockProviderClient) ListNearbyLocations(ctx context.Context, lat, lng, radiusKm float64) ([]ports.LocationInfo, error) {
+	return []ports.LocationInfo{}, nil
+}
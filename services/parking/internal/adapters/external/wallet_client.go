@@ -2,29 +2,135 @@ package external
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
 	"github.com/parking-super-app/services/parking/internal/ports"
 	"github.com/shopspring/decimal"
 )
 
-// MockWalletClient simulates wallet service calls for development
-type MockWalletClient struct{}
+// MockWalletClient simulates wallet service calls for development.
+// Payments are kept in memory so the consistency checker sees them.
+type MockWalletClient struct {
+	mu       sync.Mutex
+	payments []domain.WalletPayment
+	holds    map[uuid.UUID]bool
+	refunds  map[string]*ports.RefundResponse
+}
 
 func NewMockWalletClient() *MockWalletClient {
-	return &MockWalletClient{}
+	return &MockWalletClient{
+		holds:   make(map[uuid.UUID]bool),
+		refunds: make(map[string]*ports.RefundResponse),
+	}
 }
 
 func (c *MockWalletClient) Pay(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error) {
+	return c.record(req.ReferenceID, req.IdempotencyKey, req.Amount), nil
+}
+
+func (c *MockWalletClient) record(referenceID, idempotencyKey string, amount decimal.Decimal) *ports.PaymentResponse {
+	payment := domain.WalletPayment{
+		TransactionID:  uuid.New(),
+		ReferenceID:    referenceID,
+		IdempotencyKey: idempotencyKey,
+		Amount:         amount,
+		Status:         "completed",
+		CreatedAt:      time.Now().UTC(),
+	}
+
+	c.mu.Lock()
+	c.payments = append(c.payments, payment)
+	c.mu.Unlock()
+
 	return &ports.PaymentResponse{
-		TransactionID: uuid.New(),
-		Status:        "completed",
-	}, nil
+		TransactionID: payment.TransactionID,
+		Status:        payment.Status,
+	}
+}
+
+// PayByCard approves every card. Card payments aren't wallet payments, so
+// they aren't listed for the consistency checker.
+func (c *MockWalletClient) PayByCard(ctx context.Context, req ports.CardPaymentRequest) (*ports.PaymentResponse, error) {
+	if req.CardToken == "" {
+		return nil, errors.New("card token is required")
+	}
+	return &ports.PaymentResponse{TransactionID: uuid.New(), Status: "completed"}, nil
+}
+
+func (c *MockWalletClient) HoldFunds(ctx context.Context, req ports.HoldRequest) (*ports.HoldResponse, error) {
+	holdID := uuid.New()
+
+	c.mu.Lock()
+	c.holds[holdID] = true
+	c.mu.Unlock()
+
+	return &ports.HoldResponse{HoldID: holdID, Status: "active"}, nil
+}
+
+func (c *MockWalletClient) CaptureHold(ctx context.Context, req ports.CaptureHoldRequest) (*ports.PaymentResponse, error) {
+	c.mu.Lock()
+	active := c.holds[req.HoldID]
+	delete(c.holds, req.HoldID)
+	c.mu.Unlock()
+
+	if !active {
+		return nil, errors.New("hold has already been captured or released")
+	}
+	return c.record(req.ReferenceID, req.IdempotencyKey, req.Amount), nil
+}
+
+func (c *MockWalletClient) ReleaseHold(ctx context.Context, holdID uuid.UUID) error {
+	c.mu.Lock()
+	delete(c.holds, holdID)
+	c.mu.Unlock()
+	return nil
+}
+
+// Refund answers a repeated idempotency key with the first refund
+func (c *MockWalletClient) Refund(ctx context.Context, req ports.RefundRequest) (*ports.RefundResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if refund, ok := c.refunds[req.IdempotencyKey]; ok {
+		return refund, nil
+	}
+	for _, p := range c.payments {
+		if p.TransactionID == req.TransactionID {
+			refund := &ports.RefundResponse{TransactionID: uuid.New(), Amount: p.Amount, Status: "completed"}
+			c.refunds[req.IdempotencyKey] = refund
+			return refund, nil
+		}
+	}
+	return nil, errors.New("transaction not found")
+}
+
+func (c *MockWalletClient) ListPayments(ctx context.Context, from, to time.Time) ([]domain.WalletPayment, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var payments []domain.WalletPayment
+	for _, p := range c.payments {
+		if !p.CreatedAt.Before(from) && p.CreatedAt.Before(to) {
+			payments = append(payments, p)
+		}
+	}
+	return payments, nil
 }
 
 func (c *MockWalletClient) GetWallet(ctx context.Context, userID uuid.UUID) (*ports.WalletInfo, error) {
+	return c.GetUserWallet(ctx, userID, uuid.Nil)
+}
+
+func (c *MockWalletClient) GetUserWallet(ctx context.Context, userID, walletID uuid.UUID) (*ports.WalletInfo, error) {
+	if walletID == uuid.Nil {
+		walletID = uuid.New()
+	}
 	return &ports.WalletInfo{
-		ID:       uuid.New(),
+		ID:       walletID,
 		UserID:   userID,
 		Balance:  decimal.NewFromFloat(100.00),
 		Currency: "MYR",
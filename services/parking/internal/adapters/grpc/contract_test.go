@@ -0,0 +1,85 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+)
+
+// The fields the clients in this package set and read; keep them in step
+// with the clients and rerun with CONTRACT_UPDATE=1
+
+func TestWalletContract(t *testing.T) {
+	methods := walletv1.File_wallet_v1_wallet_proto.Services().ByName("WalletService").Methods()
+	payment := []string{"wallet_id", "amount", "provider_id", "reference_id", "description", "idempotency_key"}
+	taxes := []string{"taxes[].name", "taxes[].rate", "taxes[].taxable_amount", "taxes[].amount"}
+
+	contract.Record(t, contract.Contract{
+		Consumer: "parking",
+		Provider: "wallet",
+		Interactions: []contract.Interaction{
+			contract.GRPCInteraction(methods.ByName("Pay"),
+				append(append([]string{}, payment...), taxes...),
+				[]string{"transaction_id", "status"}),
+			contract.GRPCInteraction(methods.ByName("GetWallet"),
+				[]string{"user_id", "wallet_id"},
+				[]string{"id", "balance", "currency", "status"}),
+			contract.GRPCInteraction(methods.ByName("ListPayments"),
+				[]string{"from", "to"},
+				[]string{"payments[].transaction_id", "payments[].reference_id", "payments[].idempotency_key",
+					"payments[].amount", "payments[].status", "payments[].created_at"}),
+			contract.GRPCInteraction(methods.ByName("HoldFunds"), payment, []string{"hold_id", "status"}),
+			contract.GRPCInteraction(methods.ByName("CaptureHold"),
+				append([]string{"hold_id", "amount", "reference_id", "description", "idempotency_key"}, taxes...),
+				[]string{"transaction_id"}),
+			contract.GRPCInteraction(methods.ByName("ReleaseHold"), []string{"hold_id"}, nil),
+			contract.GRPCInteraction(methods.ByName("Refund"),
+				[]string{"transaction_id", "reason", "idempotency_key"},
+				[]string{"transaction_id", "status", "amount"}),
+			contract.GRPCInteraction(methods.ByName("PayByCard"),
+				append([]string{"amount", "currency", "card_token", "provider_id", "reference_id", "description", "idempotency_key"}, taxes...),
+				[]string{"payment_id", "status"}),
+		},
+	})
+}
+
+func TestProviderContract(t *testing.T) {
+	methods := providerv1.File_provider_v1_provider_proto.Services().ByName("ProviderService").Methods()
+	location := []string{"id", "provider_id", "name", "address", "latitude", "longitude", "total_slots", "status"}
+	listed := make([]string, len(location))
+	for i, field := range location {
+		listed[i] = "locations[]." + field
+	}
+
+	contract.Record(t, contract.Contract{
+		Consumer: "parking",
+		Provider: "provider",
+		Interactions: []contract.Interaction{
+			contract.GRPCInteraction(methods.ByName("StartSession"),
+				[]string{"provider_id", "location_id", "vehicle_plate", "vehicle_type", "user_ref"},
+				[]string{"external_session_id", "entry_time", "status"}),
+			contract.GRPCInteraction(methods.ByName("EndSession"),
+				[]string{"provider_id", "external_session_id"},
+				[]string{"exit_time", "duration_minutes", "amount", "currency"}),
+			contract.GRPCInteraction(methods.ByName("GetSessionStatus"),
+				[]string{"provider_id", "external_session_id"},
+				[]string{"status", "duration_minutes", "current_amount"}),
+			contract.GRPCInteraction(methods.ByName("GetLocationPricing"),
+				[]string{"location_id", "at"},
+				[]string{"hourly_rate", "daily_max", "currency", "effective_from"}),
+			contract.GRPCInteraction(methods.ByName("GetLocation"), []string{"id"}, location[1:]),
+			contract.GRPCInteraction(methods.ByName("GetProvider"), []string{"id"}, []string{"code"}),
+			contract.GRPCInteraction(methods.ByName("ListLocations"), []string{"latitude", "longitude", "radius_km"}, listed),
+			contract.GRPCInteraction(methods.ByName("ListCompounds"),
+				[]string{"vehicle_plate"},
+				[]string{"compounds[].provider_id", "compounds[].reference", "compounds[].vehicle_plate", "compounds[].offence",
+					"compounds[].location", "compounds[].issued_at", "compounds[].due_at", "compounds[].amount",
+					"compounds[].currency", "failed_provider_ids[]"}),
+			contract.GRPCInteraction(methods.ByName("SettleCompound"),
+				[]string{"provider_id", "reference", "amount", "payment_reference"},
+				[]string{"provider_receipt", "settled_at"}),
+		},
+	})
+}
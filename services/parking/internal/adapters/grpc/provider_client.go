@@ -6,73 +6,338 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/client"
+	"github.com/parking-super-app/pkg/pricing"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"github.com/parking-super-app/services/parking/internal/domain"
 	"github.com/parking-super-app/services/parking/internal/ports"
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // ProviderGRPCClient implements ports.ProviderClient using gRPC
 type ProviderGRPCClient struct {
 	conn    *grpc.ClientConn
+	client  providerv1.ProviderServiceClient
 	address string
 }
 
-// NewProviderGRPCClient creates a new gRPC client for the provider service
-func NewProviderGRPCClient(address string) (*ProviderGRPCClient, error) {
-	conn, err := grpc.Dial(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// providerMethodTimeouts gives session calls, which wait on the operator's
+// gate systems, longer than the default deadline
+var providerMethodTimeouts = map[string]time.Duration{
+	"/provider.v1.ProviderService/StartSession": 10 * time.Second,
+	"/provider.v1.ProviderService/EndSession":   10 * time.Second,
+	// ListCompounds waits on every issuer's API
+	"/provider.v1.ProviderService/ListCompounds":  10 * time.Second,
+	"/provider.v1.ProviderService/SettleCompound": 10 * time.Second,
+}
+
+// NewProviderGRPCClient creates a new gRPC client for the provider service.
+// Timeouts set in cfg.MethodTimeouts take precedence over the built-in ones.
+func NewProviderGRPCClient(cfg client.Config) (*ProviderGRPCClient, error) {
+	timeouts := make(map[string]time.Duration, len(providerMethodTimeouts)+len(cfg.MethodTimeouts))
+	for method, timeout := range providerMethodTimeouts {
+		timeouts[method] = timeout
+	}
+	for method, timeout := range cfg.MethodTimeouts {
+		timeouts[method] = timeout
+	}
+	cfg.MethodTimeouts = timeouts
+
+	conn, err := client.New(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to provider service: %w", err)
 	}
 
 	return &ProviderGRPCClient{
 		conn:    conn,
-		address: address,
+		client:  providerv1.NewProviderServiceClient(conn),
+		address: cfg.Target,
 	}, nil
 }
 
 // StartSession initiates a parking session with the provider
 func (c *ProviderGRPCClient) StartSession(ctx context.Context, req ports.StartSessionRequest) (*ports.StartSessionResponse, error) {
-	// This is a simplified implementation
-	// In production with generated proto code, this would use the generated client
-
-	// The actual implementation would look like:
-	// resp, err := c.client.StartSession(ctx, &providerv1.StartSessionRequest{
-	//     ProviderId:   req.ProviderID.String(),
-	//     LocationId:   req.LocationID.String(),
-	//     VehiclePlate: req.VehiclePlate,
-	//     VehicleType:  req.VehicleType,
-	//     UserRef:      req.UserRef,
-	// })
-
-	// Simulated successful response
+	resp, err := c.client.StartSession(ctx, &providerv1.StartSessionRequest{
+		ProviderId:   req.ProviderID.String(),
+		LocationId:   req.LocationID.String(),
+		VehiclePlate: req.VehiclePlate,
+		VehicleType:  req.VehicleType,
+		UserRef:      req.UserRef,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start provider session: %w", err)
+	}
+
 	return &ports.StartSessionResponse{
-		ExternalSessionID: uuid.New().String(),
-		EntryTime:         time.Now().UTC().Format(time.RFC3339),
-		Status:            "active",
+		ExternalSessionID: resp.ExternalSessionId,
+		EntryTime:         resp.EntryTime,
+		Status:            resp.Status,
 	}, nil
 }
 
 // EndSession terminates a parking session
 func (c *ProviderGRPCClient) EndSession(ctx context.Context, req ports.EndSessionRequest) (*ports.EndSessionResponse, error) {
-	// Simulated response - in production this would use the generated client
+	resp, err := c.client.EndSession(ctx, &providerv1.EndSessionRequest{
+		ProviderId:        req.ProviderID.String(),
+		ExternalSessionId: req.ExternalSessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to end provider session: %w", err)
+	}
+
+	amount, err := decimal.NewFromString(resp.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from provider: %w", err)
+	}
+
 	return &ports.EndSessionResponse{
-		ExitTime: time.Now().UTC().Format(time.RFC3339),
-		Duration: 60, // 1 hour
-		Amount:   decimal.NewFromFloat(5.00),
-		Currency: "MYR",
+		ExitTime: resp.ExitTime,
+		Duration: int(resp.DurationMinutes),
+		Amount:   amount,
+		Currency: resp.Currency,
 	}, nil
 }
 
 // GetSessionStatus retrieves the current status of a session
 func (c *ProviderGRPCClient) GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*ports.SessionStatusResponse, error) {
-	// Simulated response - in production this would use the generated client
+	resp, err := c.client.GetSessionStatus(ctx, &providerv1.GetSessionStatusRequest{
+		ProviderId:        providerID.String(),
+		ExternalSessionId: externalSessionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider session status: %w", err)
+	}
+
+	amount, err := decimal.NewFromString(resp.CurrentAmount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount from provider: %w", err)
+	}
+
 	return &ports.SessionStatusResponse{
-		Status:   "active",
-		Duration: 30,
-		Amount:   decimal.NewFromFloat(2.50),
+		Status:   resp.Status,
+		Duration: int(resp.DurationMinutes),
+		Amount:   amount,
+	}, nil
+}
+
+// GetPricing retrieves the location pricing in effect at the given time
+func (c *ProviderGRPCClient) GetPricing(ctx context.Context, locationID uuid.UUID, at time.Time) (*ports.LocationPricing, error) {
+	resp, err := c.client.GetLocationPricing(ctx, &providerv1.GetLocationPricingRequest{
+		LocationId: locationID.String(),
+		At:         at.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location pricing: %w", err)
+	}
+
+	hourlyRate, err := decimal.NewFromString(resp.HourlyRate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hourly rate from provider: %w", err)
+	}
+	dailyMax, err := decimal.NewFromString(resp.DailyMax)
+	if err != nil {
+		return nil, fmt.Errorf("invalid daily max from provider: %w", err)
+	}
+	effectiveFrom, err := time.Parse(time.RFC3339, resp.EffectiveFrom)
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective_from from provider: %w", err)
+	}
+	schedule, err := fromPricingSchedule(resp.PricingSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pricing schedule from provider: %w", err)
+	}
+
+	return &ports.LocationPricing{
+		HourlyRate:    hourlyRate,
+		DailyMax:      dailyMax,
+		Currency:      resp.Currency,
+		EffectiveFrom: effectiveFrom,
+		Schedule:      schedule,
+	}, nil
+}
+
+// fromPricingSchedule converts and validates a location's pricing schedule,
+// nil when it has none
+func fromPricingSchedule(pb *providerv1.PricingSchedule) (*pricing.Schedule, error) {
+	if pb == nil {
+		return nil, nil
+	}
+	schedule := &pricing.Schedule{Timezone: pb.Timezone, Rules: make([]pricing.Rule, len(pb.Rules))}
+	for i, r := range pb.Rules {
+		rule := pricing.Rule{
+			Name:  r.Name,
+			Kind:  pricing.RuleKind(r.Kind),
+			Start: r.Start,
+			End:   r.End,
+		}
+		for _, d := range r.Days {
+			rule.Days = append(rule.Days, pricing.Day(d))
+		}
+		var err error
+		if rule.HourlyRate, err = decimalOrZero(r.HourlyRate); err != nil {
+			return nil, err
+		}
+		if rule.FlatRate, err = decimalOrZero(r.FlatRate); err != nil {
+			return nil, err
+		}
+		if r.GracePeriodMin > 0 {
+			grace := int(r.GracePeriodMin)
+			rule.GracePeriodMin = &grace
+		}
+		schedule.Rules[i] = rule
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func decimalOrZero(s string) (decimal.Decimal, error) {
+	if s == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(s)
+}
+
+// GetLocation retrieves a parking location and its capacity
+func (c *ProviderGRPCClient) GetLocation(ctx context.Context, locationID uuid.UUID) (*ports.LocationInfo, error) {
+	resp, err := c.client.GetLocation(ctx, &providerv1.GetLocationRequest{Id: locationID.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+
+	providerID, err := uuid.Parse(resp.ProviderId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid provider id from provider: %w", err)
+	}
+
+	return &ports.LocationInfo{
+		ID:          locationID,
+		ProviderID:  providerID,
+		Name:        resp.Name,
+		Address:     resp.Address,
+		Latitude:    resp.Latitude,
+		Longitude:   resp.Longitude,
+		TotalSpaces: int(resp.TotalSlots),
+		Active:      resp.Status == "active",
+	}, nil
+}
+
+// ProviderCode looks up the code a provider is registered under
+func (c *ProviderGRPCClient) ProviderCode(ctx context.Context, providerID uuid.UUID) (string, error) {
+	resp, err := c.client.GetProvider(ctx, &providerv1.GetProviderRequest{Id: providerID.String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to get provider: %w", err)
+	}
+	return resp.Code, nil
+}
+
+// ListNearbyLocations asks the provider service for locations around a point
+func (c *ProviderGRPCClient) ListNearbyLocations(ctx context.Context, lat, lng, radiusKm float64) ([]ports.LocationInfo, error) {
+	resp, err := c.client.ListLocations(ctx, &providerv1.ListLocationsRequest{
+		Latitude:  lat,
+		Longitude: lng,
+		RadiusKm:  radiusKm,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	locations := make([]ports.LocationInfo, 0, len(resp.Locations))
+	for _, l := range resp.Locations {
+		id, err := uuid.Parse(l.Id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid location id from provider: %w", err)
+		}
+		providerID, err := uuid.Parse(l.ProviderId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider id from provider: %w", err)
+		}
+		locations = append(locations, ports.LocationInfo{
+			ID:          id,
+			ProviderID:  providerID,
+			Name:        l.Name,
+			Address:     l.Address,
+			Latitude:    l.Latitude,
+			Longitude:   l.Longitude,
+			TotalSpaces: int(l.TotalSlots),
+			Active:      l.Status == "active",
+		})
+	}
+	return locations, nil
+}
+
+// LookupCompounds asks the provider service for a plate's outstanding fines
+func (c *ProviderGRPCClient) LookupCompounds(ctx context.Context, plate string) (*ports.CompoundLookup, error) {
+	resp, err := c.client.ListCompounds(ctx, &providerv1.ListCompoundsRequest{VehiclePlate: plate})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compounds: %w", err)
+	}
+
+	lookup := &ports.CompoundLookup{Compounds: make([]ports.CompoundInfo, 0, len(resp.Compounds))}
+	for _, c := range resp.Compounds {
+		providerID, err := uuid.Parse(c.ProviderId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider id from provider: %w", err)
+		}
+		amount, err := decimal.NewFromString(c.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compound amount from provider: %w", err)
+		}
+		issuedAt, err := time.Parse(time.RFC3339, c.IssuedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issued_at from provider: %w", err)
+		}
+
+		info := ports.CompoundInfo{
+			ProviderID:   providerID,
+			Reference:    c.Reference,
+			VehiclePlate: c.VehiclePlate,
+			Offence:      c.Offence,
+			Location:     c.Location,
+			IssuedAt:     issuedAt,
+			Amount:       amount,
+			Currency:     c.Currency,
+		}
+		if dueAt, err := time.Parse(time.RFC3339, c.DueAt); err == nil {
+			info.DueAt = &dueAt
+		}
+		lookup.Compounds = append(lookup.Compounds, info)
+	}
+	for _, id := range resp.FailedProviderIds {
+		if providerID, err := uuid.Parse(id); err == nil {
+			lookup.FailedProviderIDs = append(lookup.FailedProviderIDs, providerID)
+		}
+	}
+	return lookup, nil
+}
+
+// SettleCompound reports a paid fine to its issuer. A fine the issuer no
+// longer has outstanding is reported as domain.ErrCompoundNotPayable.
+func (c *ProviderGRPCClient) SettleCompound(ctx context.Context, req ports.SettleCompoundRequest) (*ports.CompoundSettlement, error) {
+	resp, err := c.client.SettleCompound(ctx, &providerv1.SettleCompoundRequest{
+		ProviderId:       req.ProviderID.String(),
+		Reference:        req.Reference,
+		Amount:           req.Amount.StringFixed(2),
+		PaymentReference: req.PaymentReference,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, domain.ErrCompoundNotPayable
+		}
+		return nil, fmt.Errorf("failed to settle compound: %w", err)
+	}
+
+	settledAt, err := time.Parse(time.RFC3339, resp.SettledAt)
+	if err != nil {
+		settledAt = time.Now().UTC()
+	}
+	return &ports.CompoundSettlement{
+		ProviderReceipt: resp.ProviderReceipt,
+		SettledAt:       settledAt,
 	}, nil
 }
 
@@ -84,5 +349,8 @@ func (c *ProviderGRPCClient) Close() error {
 	return nil
 }
 
-// Ensure ProviderGRPCClient implements ports.ProviderClient
-var _ ports.ProviderClient = (*ProviderGRPCClient)(nil)
+// Ensure ProviderGRPCClient implements ports.ProviderClient and ports.ProviderDirectory
+var (
+	_ ports.ProviderClient    = (*ProviderGRPCClient)(nil)
+	_ ports.ProviderDirectory = (*ProviderGRPCClient)(nil)
+)
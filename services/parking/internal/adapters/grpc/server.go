@@ -0,0 +1,187 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ParkingServiceServer implements parkingv1.ParkingServiceServer
+type ParkingServiceServer struct {
+	parkingv1.UnimplementedParkingServiceServer
+	parkingService *application.ParkingService
+}
+
+// NewParkingServiceServer creates a new gRPC server for the parking service
+func NewParkingServiceServer(ps *application.ParkingService) *ParkingServiceServer {
+	return &ParkingServiceServer{parkingService: ps}
+}
+
+// StartSession opens a parking session at a provider's location
+func (s *ParkingServiceServer) StartSession(ctx context.Context, req *parkingv1.StartSessionRequest) (*parkingv1.SessionResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+	providerID, err := uuid.Parse(req.ProviderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
+	}
+	locationID, err := uuid.Parse(req.LocationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid location_id")
+	}
+
+	resp, err := s.parkingService.StartSession(ctx, application.StartSessionRequest{
+		UserID:       userID,
+		ProviderID:   providerID,
+		LocationID:   locationID,
+		VehiclePlate: req.VehiclePlate,
+		VehicleType:  req.VehicleType,
+	})
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	return toSessionProto(resp), nil
+}
+
+// EndSession ends a session and charges it to the driver's wallet, or to
+// their organization's
+func (s *ParkingServiceServer) EndSession(ctx context.Context, req *parkingv1.EndSessionRequest) (*parkingv1.EndSessionResponse, error) {
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session_id")
+	}
+	endReq := application.EndSessionRequest{SessionID: sessionID}
+	if req.WalletId != "" {
+		if endReq.WalletID, err = uuid.Parse(req.WalletId); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid wallet_id")
+		}
+	}
+	if req.OrgId != "" {
+		orgID, err := uuid.Parse(req.OrgId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid org_id")
+		}
+		endReq.OrgID = &orgID
+	}
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+		if _, err := s.parkingService.GetUserSession(ctx, sessionID, userID); err != nil {
+			return nil, sessionError(err)
+		}
+	}
+
+	resp, err := s.parkingService.EndSession(ctx, endReq)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	return &parkingv1.EndSessionResponse{
+		SessionId:       resp.SessionID.String(),
+		DurationMinutes: int32(resp.Duration),
+		Amount:          resp.Amount.String(),
+		TaxAmount:       resp.TaxAmount.String(),
+		PaymentStatus:   resp.PaymentStatus,
+		SubscriptionId:  optionalID(resp.SubscriptionID),
+		ReservationId:   optionalID(resp.ReservationID),
+	}, nil
+}
+
+// GetSession retrieves a session, checking its owner when user_id is set
+func (s *ParkingServiceServer) GetSession(ctx context.Context, req *parkingv1.GetSessionRequest) (*parkingv1.SessionResponse, error) {
+	sessionID, err := uuid.Parse(req.SessionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid session_id")
+	}
+
+	var resp *application.SessionResponse
+	if req.UserId != "" {
+		userID, err := uuid.Parse(req.UserId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+		resp, err = s.parkingService.GetUserSession(ctx, sessionID, userID)
+		if err != nil {
+			return nil, sessionError(err)
+		}
+	} else {
+		resp, err = s.parkingService.GetSession(ctx, sessionID)
+		if err != nil {
+			return nil, sessionError(err)
+		}
+	}
+	return toSessionProto(resp), nil
+}
+
+// GetActiveSessions lists the user's sessions that have not ended
+func (s *ParkingServiceServer) GetActiveSessions(ctx context.Context, req *parkingv1.GetActiveSessionsRequest) (*parkingv1.GetActiveSessionsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	sessions, err := s.parkingService.GetActiveSessions(ctx, userID)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	resp := &parkingv1.GetActiveSessionsResponse{
+		Sessions: make([]*parkingv1.SessionResponse, len(sessions)),
+	}
+	for i, session := range sessions {
+		resp.Sessions[i] = toSessionProto(session)
+	}
+	return resp, nil
+}
+
+// sessionError maps domain errors to gRPC status codes
+func sessionError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		return status.Error(codes.NotFound, "parking session not found")
+	case errors.Is(err, domain.ErrSessionAccessDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrSessionAlreadyEnded), errors.Is(err, domain.ErrInvalidSessionTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidVehiclePlate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toSessionProto(s *application.SessionResponse) *parkingv1.SessionResponse {
+	return &parkingv1.SessionResponse{
+		Id:                s.ID.String(),
+		UserId:            s.UserID.String(),
+		ProviderId:        s.ProviderID.String(),
+		LocationId:        s.LocationID.String(),
+		ExternalSessionId: s.ExternalSessionID,
+		VehiclePlate:      s.VehiclePlate,
+		VehicleType:       s.VehicleType,
+		EntryTime:         s.EntryTime,
+		ExitTime:          s.ExitTime,
+		DurationMinutes:   int32(s.Duration),
+		Amount:            s.Amount.String(),
+		TaxAmount:         s.TaxAmount.String(),
+		Status:            s.Status,
+		PaymentStatus:     s.PaymentStatus,
+		SubscriptionId:    optionalID(s.SubscriptionID),
+		ReservationId:     optionalID(s.ReservationID),
+	}
+}
+
+func optionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -3,67 +3,253 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/client"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+	"github.com/parking-super-app/services/parking/internal/domain"
 	"github.com/parking-super-app/services/parking/internal/ports"
 	"github.com/shopspring/decimal"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // WalletGRPCClient implements ports.WalletClient using gRPC
 type WalletGRPCClient struct {
 	conn    *grpc.ClientConn
+	client  walletv1.WalletServiceClient
 	address string
 }
 
-// NewWalletGRPCClient creates a new gRPC client for the wallet service
-func NewWalletGRPCClient(address string) (*WalletGRPCClient, error) {
-	conn, err := grpc.Dial(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// NewWalletGRPCClient creates a new gRPC client for the wallet service.
+// Retrying Pay on UNAVAILABLE is safe because the wallet deduplicates
+// payments by idempotency key.
+func NewWalletGRPCClient(cfg client.Config) (*WalletGRPCClient, error) {
+	conn, err := client.New(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to wallet service: %w", err)
 	}
 
 	return &WalletGRPCClient{
 		conn:    conn,
-		address: address,
+		client:  walletv1.NewWalletServiceClient(conn),
+		address: cfg.Target,
 	}, nil
 }
 
 // Pay processes a payment through the wallet service
 func (c *WalletGRPCClient) Pay(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error) {
-	// This is a simplified implementation
-	// In production with generated proto code, this would use the generated client
-
-	// For now, we'll simulate the gRPC call
-	// The actual implementation would look like:
-	// resp, err := c.client.Pay(ctx, &walletv1.PayRequest{
-	//     WalletId:       req.WalletID.String(),
-	//     Amount:         req.Amount.String(),
-	//     ProviderId:     req.ProviderID.String(),
-	//     ReferenceId:    req.ReferenceID,
-	//     Description:    req.Description,
-	//     IdempotencyKey: req.IdempotencyKey,
-	// })
-
-	// Simulated successful response
+	pay := &walletv1.PayRequest{
+		WalletId:       req.WalletID.String(),
+		Amount:         req.Amount.String(),
+		ProviderId:     req.ProviderID.String(),
+		ReferenceId:    req.ReferenceID,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+		Taxes:          taxLines(req.Taxes),
+	}
+	if req.MemberID != nil {
+		pay.MemberId = req.MemberID.String()
+	}
+	resp, err := c.client.Pay(ctx, pay)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pay from wallet: %w", err)
+	}
+
+	transactionID, err := uuid.Parse(resp.TransactionId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction id from wallet: %w", err)
+	}
+
 	return &ports.PaymentResponse{
-		TransactionID: uuid.New(),
-		Status:        "completed",
+		TransactionID: transactionID,
+		Status:        resp.Status,
+	}, nil
+}
+
+// PayByCard charges a guest's card through the wallet service
+func (c *WalletGRPCClient) PayByCard(ctx context.Context, req ports.CardPaymentRequest) (*ports.PaymentResponse, error) {
+	resp, err := c.client.PayByCard(ctx, &walletv1.PayByCardRequest{
+		Amount:         req.Amount.String(),
+		Currency:       "MYR",
+		CardToken:      req.CardToken,
+		ProviderId:     req.ProviderID.String(),
+		ReferenceId:    req.ReferenceID,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+		Taxes:          taxLines(req.Taxes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pay by card: %w", err)
+	}
+
+	paymentID, err := uuid.Parse(resp.PaymentId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment id from wallet: %w", err)
+	}
+
+	return &ports.PaymentResponse{
+		TransactionID: paymentID,
+		Status:        resp.Status,
 	}, nil
 }
 
-// GetWallet retrieves wallet information by user ID
+// GetWallet retrieves the default wallet of a user
 func (c *WalletGRPCClient) GetWallet(ctx context.Context, userID uuid.UUID) (*ports.WalletInfo, error) {
-	// Simulated response - in production this would use the generated client
+	return c.GetUserWallet(ctx, userID, uuid.Nil)
+}
+
+// GetUserWallet retrieves one of a user's wallets, or their default wallet
+// when walletID is uuid.Nil
+func (c *WalletGRPCClient) GetUserWallet(ctx context.Context, userID, walletID uuid.UUID) (*ports.WalletInfo, error) {
+	req := &walletv1.GetWalletRequest{UserId: userID.String()}
+	if walletID != uuid.Nil {
+		req.WalletId = walletID.String()
+	}
+	resp, err := c.client.GetWallet(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	id, err := uuid.Parse(resp.Id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid wallet id from wallet: %w", err)
+	}
+	balance, err := decimal.NewFromString(resp.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance from wallet: %w", err)
+	}
+
 	return &ports.WalletInfo{
-		ID:       uuid.New(),
+		ID:       id,
 		UserID:   userID,
-		Balance:  decimal.NewFromFloat(100.00),
-		Currency: "MYR",
-		Status:   "active",
+		Balance:  balance,
+		Currency: resp.Currency,
+		Status:   resp.Status,
+	}, nil
+}
+
+// ListPayments retrieves wallet payments created in [from, to)
+func (c *WalletGRPCClient) ListPayments(ctx context.Context, from, to time.Time) ([]domain.WalletPayment, error) {
+	resp, err := c.client.ListPayments(ctx, &walletv1.ListPaymentsRequest{
+		From: from.UTC().Format(time.RFC3339),
+		To:   to.UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallet payments: %w", err)
+	}
+
+	payments := make([]domain.WalletPayment, 0, len(resp.Payments))
+	for _, p := range resp.Payments {
+		transactionID, err := uuid.Parse(p.TransactionId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction id from wallet: %w", err)
+		}
+		amount, err := decimal.NewFromString(p.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount from wallet: %w", err)
+		}
+		createdAt, err := time.Parse(time.RFC3339, p.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at from wallet: %w", err)
+		}
+
+		payments = append(payments, domain.WalletPayment{
+			TransactionID:  transactionID,
+			ReferenceID:    p.ReferenceId,
+			IdempotencyKey: p.IdempotencyKey,
+			Amount:         amount,
+			Status:         p.Status,
+			CreatedAt:      createdAt,
+		})
+	}
+
+	return payments, nil
+}
+
+// HoldFunds places a hold on a wallet
+func (c *WalletGRPCClient) HoldFunds(ctx context.Context, req ports.HoldRequest) (*ports.HoldResponse, error) {
+	resp, err := c.client.HoldFunds(ctx, &walletv1.HoldFundsRequest{
+		WalletId:       req.WalletID.String(),
+		Amount:         req.Amount.String(),
+		ProviderId:     req.ProviderID.String(),
+		ReferenceId:    req.ReferenceID,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold wallet funds: %w", err)
+	}
+
+	holdID, err := uuid.Parse(resp.HoldId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hold id from wallet: %w", err)
+	}
+
+	return &ports.HoldResponse{
+		HoldID: holdID,
+		Status: resp.Status,
+	}, nil
+}
+
+// CaptureHold settles a hold with a payment
+func (c *WalletGRPCClient) CaptureHold(ctx context.Context, req ports.CaptureHoldRequest) (*ports.PaymentResponse, error) {
+	resp, err := c.client.CaptureHold(ctx, &walletv1.CaptureHoldRequest{
+		HoldId:         req.HoldID.String(),
+		Amount:         req.Amount.String(),
+		ReferenceId:    req.ReferenceID,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+		Taxes:          taxLines(req.Taxes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture wallet hold: %w", err)
+	}
+
+	transactionID, err := uuid.Parse(resp.TransactionId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction id from wallet: %w", err)
+	}
+
+	return &ports.PaymentResponse{
+		TransactionID: transactionID,
+		Status:        "completed",
+	}, nil
+}
+
+// ReleaseHold returns a hold to the wallet
+func (c *WalletGRPCClient) ReleaseHold(ctx context.Context, holdID uuid.UUID) error {
+	_, err := c.client.ReleaseHold(ctx, &walletv1.ReleaseHoldRequest{HoldId: holdID.String()})
+	if err != nil {
+		return fmt.Errorf("failed to release wallet hold: %w", err)
+	}
+	return nil
+}
+
+// Refund returns a completed payment to the wallet
+func (c *WalletGRPCClient) Refund(ctx context.Context, req ports.RefundRequest) (*ports.RefundResponse, error) {
+	resp, err := c.client.Refund(ctx, &walletv1.RefundRequest{
+		TransactionId:  req.TransactionID.String(),
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refund wallet payment: %w", err)
+	}
+
+	transactionID, err := uuid.Parse(resp.TransactionId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction id from wallet: %w", err)
+	}
+	amount, err := decimal.NewFromString(resp.Amount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid refund amount from wallet: %w", err)
+	}
+
+	return &ports.RefundResponse{
+		TransactionID: transactionID,
+		Amount:        amount,
+		Status:        resp.Status,
 	}, nil
 }
 
@@ -77,3 +263,19 @@ func (c *WalletGRPCClient) Close() error {
 
 // Ensure WalletGRPCClient implements ports.WalletClient
 var _ ports.WalletClient = (*WalletGRPCClient)(nil)
+
+func taxLines(taxes []domain.TaxComponent) []*walletv1.TaxLine {
+	if len(taxes) == 0 {
+		return nil
+	}
+	lines := make([]*walletv1.TaxLine, len(taxes))
+	for i, tax := range taxes {
+		lines[i] = &walletv1.TaxLine{
+			Name:          tax.Name,
+			Rate:          tax.Rate.String(),
+			TaxableAmount: tax.Taxable.String(),
+			Amount:        tax.Amount.String(),
+		}
+	}
+	return lines
+}
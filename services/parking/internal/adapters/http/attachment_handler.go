@@ -0,0 +1,146 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// AttachmentHandler serves the photos users keep with their sessions
+type AttachmentHandler struct {
+	attachmentService *application.AttachmentService
+}
+
+func NewAttachmentHandler(attachmentService *application.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{attachmentService: attachmentService}
+}
+
+func mapAttachmentError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrAttachmentNotFound):
+		return http.StatusNotFound, "ATTACHMENT_NOT_FOUND", "Attachment not found"
+	case errors.Is(err, domain.ErrInvalidAttachmentKind):
+		return http.StatusBadRequest, "INVALID_KIND", "Kind must be entry_ticket, damage or other"
+	case errors.Is(err, domain.ErrUnsupportedAttachmentType):
+		return http.StatusBadRequest, "UNSUPPORTED_TYPE", "Attachments must be JPEG, PNG, HEIC or WebP images"
+	case errors.Is(err, domain.ErrAttachmentTooLarge):
+		return http.StatusBadRequest, "INVALID_SIZE", "Attachments must be between 1 byte and 10 MB"
+	case errors.Is(err, domain.ErrTooManyAttachments):
+		return http.StatusConflict, "TOO_MANY_ATTACHMENTS", "This session already has the most attachments allowed"
+	case errors.Is(err, domain.ErrAttachmentNotUploaded):
+		return http.StatusConflict, "NOT_UPLOADED", "The file has not been uploaded yet"
+	case errors.Is(err, domain.ErrAttachmentMismatch):
+		return http.StatusConflict, "UPLOAD_MISMATCH", "The uploaded file does not match the attachment's type or size"
+	case errors.Is(err, domain.ErrAttachmentsUnavailable):
+		return http.StatusServiceUnavailable, "ATTACHMENTS_UNAVAILABLE", "Attachments are not available"
+	default:
+		return mapDisputeError(err)
+	}
+}
+
+// Create records an attachment and returns the presigned request that
+// uploads its file
+func (h *AttachmentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	sessionID, ok := sessionIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.CreateAttachmentRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+	req.SessionID = sessionID
+
+	resp, err := h.attachmentService.CreateAttachment(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapAttachmentError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// Complete confirms the file has been uploaded
+func (h *AttachmentHandler) Complete(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	sessionID, ok := sessionIDParam(w, r)
+	if !ok {
+		return
+	}
+	attachmentID, err := uuid.Parse(chi.URLParam(r, "attachmentID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid attachment ID format")
+		return
+	}
+
+	resp, err := h.attachmentService.CompleteUpload(r.Context(), userID, sessionID, attachmentID)
+	if err != nil {
+		status, code, msg := mapAttachmentError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	sessionID, ok := sessionIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.attachmentService.GetUserAttachments(r.Context(), userID, sessionID)
+	if err != nil {
+		status, code, msg := mapAttachmentError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListForDispute lists the photos on a disputed session for its reviewer
+func (h *AttachmentHandler) ListForDispute(w http.ResponseWriter, r *http.Request) {
+	id, ok := disputeID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.attachmentService.GetDisputeAttachments(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapAttachmentError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func sessionIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid session ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,88 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// CompareHandler serves price comparison across nearby locations
+type CompareHandler struct {
+	compareService *application.CompareService
+}
+
+func NewCompareHandler(compareService *application.CompareService) *CompareHandler {
+	return &CompareHandler{compareService: compareService}
+}
+
+func mapCompareError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCoordinates):
+		return http.StatusBadRequest, "INVALID_COORDINATES", "lat and lng must be valid coordinates"
+	case errors.Is(err, domain.ErrInvalidDuration):
+		return http.StatusBadRequest, "INVALID_DURATION", "duration must be positive and at most 24 hours"
+	case errors.Is(err, domain.ErrInvalidSort):
+		return http.StatusBadRequest, "INVALID_SORT", "sort must be price or distance"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// Compare quotes a stay at every location near lat/lng. duration is minutes
+// or a Go duration such as 90m or 2h.
+func (h *CompareHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	lat, latErr := strconv.ParseFloat(q.Get("lat"), 64)
+	lng, lngErr := strconv.ParseFloat(q.Get("lng"), 64)
+	if latErr != nil || lngErr != nil {
+		status, code, msg := mapCompareError(domain.ErrInvalidCoordinates)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	duration, ok := parseQuoteDuration(q.Get("duration"))
+	if !ok {
+		status, code, msg := mapCompareError(domain.ErrInvalidDuration)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	var radiusKm float64
+	if v := q.Get("radius_km"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_RADIUS", "radius_km must be a number")
+			return
+		}
+		radiusKm = parsed
+	}
+
+	resp, err := h.compareService.Compare(r.Context(), application.CompareRequest{
+		Latitude:  lat,
+		Longitude: lng,
+		Duration:  duration,
+		RadiusKm:  radiusKm,
+		Sort:      domain.QuoteSort(q.Get("sort")),
+	})
+	if err != nil {
+		status, code, msg := mapCompareError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func parseQuoteDuration(v string) (time.Duration, bool) {
+	if minutes, err := strconv.Atoi(v); err == nil {
+		return time.Duration(minutes) * time.Minute, true
+	}
+	d, err := time.ParseDuration(v)
+	return d, err == nil
+}
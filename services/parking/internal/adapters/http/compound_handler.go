@@ -0,0 +1,118 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// CompoundHandler serves parking fine lookup and payment
+type CompoundHandler struct {
+	compoundService *application.CompoundService
+}
+
+func NewCompoundHandler(compoundService *application.CompoundService) *CompoundHandler {
+	return &CompoundHandler{compoundService: compoundService}
+}
+
+func mapCompoundError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrCompoundNotFound):
+		return http.StatusNotFound, "COMPOUND_NOT_FOUND", "Compound not found"
+	case errors.Is(err, domain.ErrCompoundNotPayable):
+		return http.StatusConflict, "COMPOUND_NOT_PAYABLE", "Compound is no longer outstanding"
+	case errors.Is(err, domain.ErrCompoundAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Compound was paid by another user"
+	case errors.Is(err, domain.ErrCompoundIssuerNoAnswer):
+		return http.StatusServiceUnavailable, "ISSUER_UNAVAILABLE", "Issuer of the compound cannot be reached, try again later"
+	case errors.Is(err, domain.ErrNoPlatesToCheck):
+		return http.StatusBadRequest, "NO_PLATES", "Give a vehicle plate or register a vehicle first"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *CompoundHandler) ListCompounds(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.compoundService.ListCompounds(r.Context(), userID, r.URL.Query().Get("plate"))
+	if err != nil {
+		status, code, msg := mapCompoundError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *CompoundHandler) PayCompound(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid compound ID format")
+		return
+	}
+
+	var req application.PayCompoundRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.CompoundID = id
+	req.UserID = userID
+
+	compound, err := h.compoundService.PayCompound(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapCompoundError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, compound)
+}
+
+func (h *CompoundHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid compound ID format")
+		return
+	}
+
+	receipt, err := h.compoundService.GetReceipt(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapCompoundError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, receipt)
+}
+
+func (h *CompoundHandler) RunSettlements(w http.ResponseWriter, r *http.Request) {
+	result, err := h.compoundService.ProcessSettlements(r.Context(), time.Now().UTC())
+	if err != nil {
+		status, code, msg := mapCompoundError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
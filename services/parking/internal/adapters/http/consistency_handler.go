@@ -0,0 +1,102 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// ConsistencyHandler exposes session/payment consistency reports to admins
+type ConsistencyHandler struct {
+	consistencyService *application.ConsistencyService
+}
+
+func NewConsistencyHandler(consistencyService *application.ConsistencyService) *ConsistencyHandler {
+	return &ConsistencyHandler{consistencyService: consistencyService}
+}
+
+func mapConsistencyError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrConsistencyReportNotFound):
+		return http.StatusNotFound, "REPORT_NOT_FOUND", "Consistency report not found"
+	case errors.Is(err, domain.ErrInvalidCheckWindow):
+		return http.StatusBadRequest, "INVALID_WINDOW", "from must be before to"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *ConsistencyHandler) RunCheck(w http.ResponseWriter, r *http.Request) {
+	var req application.RunConsistencyCheckRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	report, err := h.consistencyService.RunCheck(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapConsistencyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, report)
+}
+
+func (h *ConsistencyHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+
+	resp, err := h.consistencyService.ListReports(r.Context(), limit, offset)
+	if err != nil {
+		status, code, msg := mapConsistencyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *ConsistencyHandler) GetLatestReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.consistencyService.GetLatestReport(r.Context())
+	if err != nil {
+		status, code, msg := mapConsistencyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, report)
+}
+
+func (h *ConsistencyHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid report ID format")
+		return
+	}
+
+	report, err := h.consistencyService.GetReport(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapConsistencyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	parkingv1 "github.com/parking-super-app/pkg/proto/parking/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestContracts verifies the routes and gRPC methods that other services rely on
+func TestContracts(t *testing.T) {
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	contract.Verify(t, contract.Provider{
+		Name:   "parking",
+		Routes: router.router,
+		Services: []protoreflect.ServiceDescriptor{
+			parkingv1.File_parking_v1_parking_proto.Services().ByName("ParkingService"),
+		},
+	})
+}
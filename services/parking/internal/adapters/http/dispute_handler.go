@@ -0,0 +1,215 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// DisputeHandler serves users' session disputes and the admin review queue
+type DisputeHandler struct {
+	disputeService *application.DisputeService
+}
+
+func NewDisputeHandler(disputeService *application.DisputeService) *DisputeHandler {
+	return &DisputeHandler{disputeService: disputeService}
+}
+
+func mapDisputeError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrDisputeNotFound):
+		return http.StatusNotFound, "DISPUTE_NOT_FOUND", "Dispute not found"
+	case errors.Is(err, domain.ErrDisputeAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Dispute belongs to another user"
+	case errors.Is(err, domain.ErrInvalidDisputeReason):
+		return http.StatusBadRequest, "INVALID_REASON", "Reason must be wrong_amount, double_charge or other"
+	case errors.Is(err, domain.ErrInvalidDisputeResolution):
+		return http.StatusBadRequest, "INVALID_RESOLUTION", "Resolution must be refund or rejected"
+	case errors.Is(err, domain.ErrSessionNotDisputable):
+		return http.StatusConflict, "SESSION_NOT_DISPUTABLE", "Only completed, paid sessions can be disputed"
+	case errors.Is(err, domain.ErrDisputeAlreadyOpen):
+		return http.StatusConflict, "DISPUTE_EXISTS", "This session has already been disputed"
+	case errors.Is(err, domain.ErrDisputeSelfReview):
+		return http.StatusForbidden, "SELF_REVIEW", "You can't review or resolve your own dispute"
+	case errors.Is(err, domain.ErrInvalidDisputeTransition):
+		return http.StatusConflict, "INVALID_DISPUTE_STATE", "The dispute cannot do that in its current state"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *DisputeHandler) Open(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.OpenDisputeRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+
+	dispute, err := h.disputeService.OpenDispute(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, dispute)
+}
+
+func (h *DisputeHandler) GetUserDisputes(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := listParams(r)
+
+	resp, err := h.disputeService.GetUserDisputes(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *DisputeHandler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := disputeID(w, r)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.disputeService.GetUserDispute(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, dispute)
+}
+
+// List is the admin queue, optionally filtered with ?status=
+func (h *DisputeHandler) List(w http.ResponseWriter, r *http.Request) {
+	var status *domain.DisputeStatus
+	if s := r.URL.Query().Get("status"); s != "" {
+		filter := domain.DisputeStatus(s)
+		status = &filter
+	}
+	limit, offset := listParams(r)
+
+	resp, err := h.disputeService.ListDisputes(r.Context(), status, limit, offset)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *DisputeHandler) AdminGet(w http.ResponseWriter, r *http.Request) {
+	id, ok := disputeID(w, r)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.disputeService.GetDispute(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, dispute)
+}
+
+func (h *DisputeHandler) StartReview(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := disputeID(w, r)
+	if !ok {
+		return
+	}
+
+	dispute, err := h.disputeService.StartReview(r.Context(), id, adminID)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, dispute)
+}
+
+// Resolve settles a dispute under review; a refund resolution returns the
+// session payment to the user's wallet
+func (h *DisputeHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := disputeID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.ResolveDisputeRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.DisputeID = id
+	req.AdminID = adminID
+
+	dispute, err := h.disputeService.Resolve(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapDisputeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, dispute)
+}
+
+func disputeID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid dispute ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func listParams(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+	return limit, offset
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// GuestHandler serves plate-only parking for guests without an account. The
+// gateway only lets guest tokens through and passes on their device.
+type GuestHandler struct {
+	parkingService *application.ParkingService
+}
+
+func NewGuestHandler(parkingService *application.ParkingService) *GuestHandler {
+	return &GuestHandler{parkingService: parkingService}
+}
+
+func mapGuestError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrGuestDeviceRequired):
+		return http.StatusBadRequest, "MISSING_DEVICE_ID", "X-Device-ID header required"
+	case errors.Is(err, domain.ErrCardTokenRequired):
+		return http.StatusBadRequest, "CARD_TOKEN_REQUIRED", "A card token is required"
+	case errors.Is(err, domain.ErrCardChanged):
+		return http.StatusConflict, "CARD_CHANGED", "Session is already being charged to another card"
+	case errors.Is(err, domain.ErrSessionAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Session was started from another device"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *GuestHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	var req application.GuestStartSessionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.DeviceID = r.Header.Get("X-Device-ID")
+
+	resp, err := h.parkingService.StartGuestSession(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapGuestError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *GuestHandler) GetSessions(w http.ResponseWriter, r *http.Request) {
+	deviceID, ok := requireDeviceID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.parkingService.GetGuestSessions(r.Context(), deviceID)
+	if err != nil {
+		status, code, msg := mapGuestError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *GuestHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	deviceID, ok := requireDeviceID(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid session ID format")
+		return
+	}
+
+	resp, err := h.parkingService.GetGuestSession(r.Context(), sessionID, deviceID)
+	if err != nil {
+		status, code, msg := mapGuestError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// EndSession ends a guest session and charges the card
+//
+// POST /api/v1/guest/sessions/{id}/end
+// Request: { "card_token": "..." }
+func (h *GuestHandler) EndSession(w http.ResponseWriter, r *http.Request) {
+	deviceID, ok := requireDeviceID(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid session ID format")
+		return
+	}
+
+	var req application.GuestEndSessionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.SessionID = sessionID
+	req.DeviceID = deviceID
+
+	resp, err := h.parkingService.EndGuestSession(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapGuestError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// requireDeviceID reads the guest's device from the X-Device-ID header set
+// by the gateway
+func requireDeviceID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	deviceID := r.Header.Get("X-Device-ID")
+	if deviceID == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_DEVICE_ID", "X-Device-ID header required")
+		return "", false
+	}
+	return deviceID, true
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// ReservationHandler serves space bookings and location availability
+type ReservationHandler struct {
+	reservationService *application.ReservationService
+}
+
+func NewReservationHandler(reservationService *application.ReservationService) *ReservationHandler {
+	return &ReservationHandler{reservationService: reservationService}
+}
+
+func mapReservationError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrReservationNotFound):
+		return http.StatusNotFound, "RESERVATION_NOT_FOUND", "Reservation not found"
+	case errors.Is(err, domain.ErrInvalidReservationWindow):
+		return http.StatusBadRequest, "INVALID_WINDOW", "Reservation must start in the future and end after it starts"
+	case errors.Is(err, domain.ErrReservationOutOfRange):
+		return http.StatusBadRequest, "WINDOW_OUT_OF_RANGE", "Reservation is too long or too far ahead"
+	case errors.Is(err, domain.ErrReservationAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Reservation belongs to another user"
+	case errors.Is(err, domain.ErrReservationClosed):
+		return http.StatusConflict, "RESERVATION_CLOSED", "Reservation is no longer open"
+	case errors.Is(err, domain.ErrNoSpacesAvailable):
+		return http.StatusConflict, "NO_SPACES_AVAILABLE", "No spaces available for the requested time"
+	case errors.Is(err, domain.ErrLocationUnavailable):
+		return http.StatusBadRequest, "LOCATION_UNAVAILABLE", "Location is not taking reservations"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *ReservationHandler) Reserve(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.CreateReservationRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+
+	reservation, err := h.reservationService.Reserve(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, reservation)
+}
+
+func (h *ReservationHandler) GetUserReservations(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+
+	resp, err := h.reservationService.GetUserReservations(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *ReservationHandler) GetReservation(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid reservation ID format")
+		return
+	}
+
+	reservation, err := h.reservationService.GetUserReservation(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, reservation)
+}
+
+func (h *ReservationHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid reservation ID format")
+		return
+	}
+
+	reservation, err := h.reservationService.Cancel(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, reservation)
+}
+
+// GetAvailability answers how many spaces at a location can still be booked
+// between starts_at and ends_at (RFC 3339)
+func (h *ReservationHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	locationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_LOCATION_ID", "Invalid location ID format")
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, r.URL.Query().Get("starts_at"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_STARTS_AT", "starts_at must be an RFC 3339 timestamp")
+		return
+	}
+	to, err := time.Parse(time.RFC3339, r.URL.Query().Get("ends_at"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ENDS_AT", "ends_at must be an RFC 3339 timestamp")
+		return
+	}
+
+	availability, err := h.reservationService.GetAvailability(r.Context(), locationID, from, to)
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, availability)
+}
+
+func (h *ReservationHandler) RunNoShows(w http.ResponseWriter, r *http.Request) {
+	result, err := h.reservationService.ProcessNoShows(r.Context(), time.Now().UTC())
+	if err != nil {
+		status, code, msg := mapReservationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
@@ -5,54 +5,193 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/ports"
 )
 
 type Router struct {
-	parkingService *application.ParkingService
-	router         chi.Router
+	parkingService      *application.ParkingService
+	consistencyService  *application.ConsistencyService
+	subscriptionService *application.SubscriptionService
+	reservationService  *application.ReservationService
+	streetService       *application.StreetParkingService
+	compoundService     *application.CompoundService
+	compareService      *application.CompareService
+	disputeService      *application.DisputeService
+	taxService          *application.TaxService
+	attachmentService   *application.AttachmentService
+	stream              ports.SessionEventStream
+	router              chi.Router
+	handler             http.Handler
 }
 
-func NewRouter(parkingService *application.ParkingService) *Router {
+func NewRouter(
+	parkingService *application.ParkingService,
+	consistencyService *application.ConsistencyService,
+	subscriptionService *application.SubscriptionService,
+	reservationService *application.ReservationService,
+	streetService *application.StreetParkingService,
+	compoundService *application.CompoundService,
+	compareService *application.CompareService,
+	disputeService *application.DisputeService,
+	taxService *application.TaxService,
+	attachmentService *application.AttachmentService,
+	stream ports.SessionEventStream,
+) *Router {
 	r := &Router{
-		parkingService: parkingService,
-		router:         chi.NewRouter(),
+		parkingService:      parkingService,
+		consistencyService:  consistencyService,
+		subscriptionService: subscriptionService,
+		reservationService:  reservationService,
+		streetService:       streetService,
+		compoundService:     compoundService,
+		compareService:      compareService,
+		disputeService:      disputeService,
+		taxService:          taxService,
+		attachmentService:   attachmentService,
+		stream:              stream,
+		router:              chi.NewRouter(),
 	}
 
 	r.setupMiddleware()
 	r.setupRoutes()
+	r.handler = r.router
 
 	return r
 }
 
+// Use wraps the router with additional middleware. Unlike chi's Use it can be
+// called after routes are registered; middlewares run in the order given.
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		r.handler = middlewares[i](r.handler)
+	}
+}
+
 func (r *Router) setupMiddleware() {
 	r.router.Use(middleware.RequestID)
 	r.router.Use(middleware.RealIP)
-	r.router.Use(middleware.Logger)
 	r.router.Use(middleware.Recoverer)
+	r.router.Use(deadline.FromHeader)
 	r.router.Use(middleware.AllowContentType("application/json"))
 
-	r.router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			next.ServeHTTP(w, req)
-		})
-	})
+	r.router.Use(httpx.SecurityHeaders)
+	r.router.Use(httpx.JSONContentType)
 }
 
 func (r *Router) setupRoutes() {
 	handler := NewParkingHandler(r.parkingService)
+	streamHandler := NewStreamHandler(r.parkingService, r.stream)
+	consistencyHandler := NewConsistencyHandler(r.consistencyService)
+	subscriptionHandler := NewSubscriptionHandler(r.subscriptionService)
+	reservationHandler := NewReservationHandler(r.reservationService)
+	streetHandler := NewStreetHandler(r.streetService)
+	compoundHandler := NewCompoundHandler(r.compoundService)
+	compareHandler := NewCompareHandler(r.compareService)
+	disputeHandler := NewDisputeHandler(r.disputeService)
+	taxHandler := NewTaxHandler(r.taxService)
+	guestHandler := NewGuestHandler(r.parkingService)
+	attachmentHandler := NewAttachmentHandler(r.attachmentService)
 
 	r.router.Route("/api/v1/parking", func(router chi.Router) {
 		router.Post("/sessions", handler.StartSession)
 		router.Get("/sessions", handler.GetUserSessions)
 		router.Get("/sessions/active", handler.GetActiveSessions)
 		router.Get("/sessions/{id}", handler.GetSession)
+		router.Get("/sessions/{id}/events", streamHandler.StreamSessionEvents)
 		router.Post("/sessions/{id}/end", handler.EndSession)
 		router.Delete("/sessions/{id}", handler.CancelSession)
+		router.Post("/sessions/{id}/attachments", attachmentHandler.Create)
+		router.Get("/sessions/{id}/attachments", attachmentHandler.List)
+		router.Post("/sessions/{id}/attachments/{attachmentID}/complete", attachmentHandler.Complete)
 
 		router.Post("/vehicles", handler.RegisterVehicle)
-		router.Get("/vehicles", handler.GetUserVehicles)
+		router.With(httpx.ETag).Get("/vehicles", handler.GetUserVehicles)
+
+		router.Get("/subscriptions/plans", subscriptionHandler.ListPlans)
+		router.Post("/subscriptions", subscriptionHandler.Purchase)
+		router.Get("/subscriptions", subscriptionHandler.GetUserSubscriptions)
+		router.Delete("/subscriptions/{id}/renewal", subscriptionHandler.CancelRenewal)
+
+		router.Get("/locations/{id}/availability", reservationHandler.GetAvailability)
+		router.Post("/reservations", reservationHandler.Reserve)
+		router.Get("/reservations", reservationHandler.GetUserReservations)
+		router.Get("/reservations/{id}", reservationHandler.GetReservation)
+		router.Delete("/reservations/{id}", reservationHandler.Cancel)
+
+		router.Get("/street/zones", streetHandler.ListZones)
+		router.Post("/street/sessions", streetHandler.StartSession)
+		router.Get("/street/sessions", streetHandler.GetUserSessions)
+		router.Get("/street/sessions/{id}", streetHandler.GetSession)
+		router.Post("/street/sessions/{id}/extend", streetHandler.ExtendSession)
+
+		router.Get("/compounds", compoundHandler.ListCompounds)
+		router.Post("/compounds/{id}/pay", compoundHandler.PayCompound)
+		router.Get("/compounds/{id}/receipt", compoundHandler.GetReceipt)
+
+		router.Get("/compare", compareHandler.Compare)
+
+		router.Post("/disputes", disputeHandler.Open)
+		router.Get("/disputes", disputeHandler.GetUserDisputes)
+		router.Get("/disputes/{id}", disputeHandler.GetDispute)
+	})
+
+	r.router.Route("/api/v1/guest", func(router chi.Router) {
+		router.Post("/sessions", guestHandler.StartSession)
+		router.Get("/sessions", guestHandler.GetSessions)
+		router.Get("/sessions/{id}", guestHandler.GetSession)
+		router.Post("/sessions/{id}/end", guestHandler.EndSession)
+	})
+
+	r.router.Route("/api/v1/admin/consistency", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/checks", consistencyHandler.RunCheck)
+		router.Get("/reports", consistencyHandler.ListReports)
+		router.Get("/reports/latest", consistencyHandler.GetLatestReport)
+		router.Get("/reports/{id}", consistencyHandler.GetReport)
+	})
+
+	r.router.Route("/api/v1/admin/subscriptions", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/plans", subscriptionHandler.CreatePlan)
+		router.Post("/plans/{id}/deactivate", subscriptionHandler.DeactivatePlan)
+		router.Post("/renewals", subscriptionHandler.RunRenewals)
+	})
+
+	r.router.Route("/api/v1/admin/reservations", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/no-shows", reservationHandler.RunNoShows)
+	})
+
+	r.router.Route("/api/v1/admin/street", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/zones", streetHandler.CreateZone)
+		router.Post("/zones/{id}/deactivate", streetHandler.DeactivateZone)
+		router.Post("/expiries", streetHandler.RunExpiries)
+	})
+
+	r.router.Route("/api/v1/admin/compounds", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/settlements", compoundHandler.RunSettlements)
+	})
+
+	r.router.Route("/api/v1/admin/disputes", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Get("/", disputeHandler.List)
+		router.Get("/{id}", disputeHandler.AdminGet)
+		router.Post("/{id}/review", disputeHandler.StartReview)
+		router.Post("/{id}/resolve", disputeHandler.Resolve)
+		router.Get("/{id}/attachments", attachmentHandler.ListForDispute)
+	})
+
+	r.router.Route("/api/v1/admin/taxes", func(router chi.Router) {
+		router.Use(actor.RequireRole(actor.RoleAdmin))
+		router.Post("/", taxHandler.Create)
+		router.Get("/", taxHandler.List)
+		router.Delete("/{id}", taxHandler.Delete)
 	})
 
 	r.router.Get("/health", func(w http.ResponseWriter, req *http.Request) {
@@ -62,5 +201,5 @@ func (r *Router) setupRoutes() {
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
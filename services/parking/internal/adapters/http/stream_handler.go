@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+const (
+	streamHeartbeatInterval = 15 * time.Second
+	eventSessionSnapshot    = "parking.session.snapshot"
+)
+
+// StreamHandler serves session updates as Server-Sent Events
+type StreamHandler struct {
+	parkingService *application.ParkingService
+	stream         ports.SessionEventStream
+}
+
+func NewStreamHandler(parkingService *application.ParkingService, stream ports.SessionEventStream) *StreamHandler {
+	return &StreamHandler{parkingService: parkingService, stream: stream}
+}
+
+// StreamSessionEvents pushes status changes for a single session until it
+// reaches a terminal state or the client disconnects.
+func (h *StreamHandler) StreamSessionEvents(w http.ResponseWriter, r *http.Request) {
+	sessionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid session ID format")
+		return
+	}
+
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		return
+	}
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		return
+	}
+
+	session, err := h.parkingService.GetUserSession(r.Context(), sessionID, userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	// Subscribe before sending the snapshot so no update is lost in between
+	events, cancel, err := h.stream.Subscribe(r.Context(), sessionID, userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+	defer cancel()
+
+	// Streams outlive the server write timeout
+	rc := http.NewResponseController(w)
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(w, eventSessionSnapshot, session); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+	if isFinalStatus(session.Status) {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			rc.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if err := writeEvent(w, event.Type, event.Payload); err != nil {
+				return
+			}
+			rc.Flush()
+			if isTerminalEvent(event) {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, payload)
+	return err
+}
+
+// isFinalStatus reports whether a session has nothing more to stream. Pending,
+// active and ending sessions still change; a failed payment is only retried on
+// request, so the client opens a new stream for it.
+func isFinalStatus(status string) bool {
+	switch domain.SessionStatus(status) {
+	case domain.SessionStatusCompleted, domain.SessionStatusFailed, domain.SessionStatusCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTerminalEvent(event ports.Event) bool {
+	switch event.Type {
+	case ports.EventSessionEnded, ports.EventSessionCancelled, ports.EventSessionPaymentFailed:
+		return true
+	case ports.EventSessionStatusChanged:
+		to, _ := event.Payload["to"].(string)
+		return isFinalStatus(to)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+type stubSessionRepo struct {
+	ports.SessionRepository
+	session *domain.ParkingSession
+}
+
+func (r *stubSessionRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.ParkingSession, error) {
+	return r.session, nil
+}
+
+type stubEventStream struct {
+	events chan ports.Event
+}
+
+func (s *stubEventStream) Subscribe(ctx context.Context, sessionID, userID uuid.UUID) (<-chan ports.Event, func(), error) {
+	return s.events, func() {}, nil
+}
+
+func statusChanged(sessionID uuid.UUID, from, to domain.SessionStatus) ports.Event {
+	return ports.Event{
+		Type: ports.EventSessionStatusChanged,
+		Payload: map[string]interface{}{
+			"session_id": sessionID.String(),
+			"from":       string(from),
+			"to":         string(to),
+		},
+	}
+}
+
+func TestStreamSessionEvents_StreamsUntilFinalStatus(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name   string
+		status domain.SessionStatus
+		events func(id uuid.UUID) []ports.Event
+		want   []string
+	}{
+		{
+			name:   "pending session",
+			status: domain.SessionStatusPending,
+			events: func(id uuid.UUID) []ports.Event {
+				return []ports.Event{
+					statusChanged(id, domain.SessionStatusPending, domain.SessionStatusActive),
+					statusChanged(id, domain.SessionStatusActive, domain.SessionStatusCancelled),
+				}
+			},
+			want: []string{`"to":"active"`, `"to":"cancelled"`},
+		},
+		{
+			name:   "ending session",
+			status: domain.SessionStatusEnding,
+			events: func(id uuid.UUID) []ports.Event {
+				return []ports.Event{
+					statusChanged(id, domain.SessionStatusEnding, domain.SessionStatusCompleted),
+				}
+			},
+			want: []string{`"to":"completed"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := domain.NewParkingSession(userID, uuid.New(), uuid.New(), "WKL1234", "car")
+			if err != nil {
+				t.Fatalf("NewParkingSession() error = %v", err)
+			}
+			session.Status = tt.status
+
+			// One event more than expected: the stream must stop at the final status
+			sent := append(tt.events(session.ID), ports.Event{
+				Type:    ports.EventSessionAmountUpdated,
+				Payload: map[string]interface{}{"session_id": session.ID.String(), "amount": "9.99"},
+			})
+			stream := &stubEventStream{events: make(chan ports.Event, len(sent))}
+			for _, e := range sent {
+				stream.events <- e
+			}
+
+			service := application.NewParkingService(&stubSessionRepo{session: session}, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			handler := NewStreamHandler(service, stream)
+
+			req := httptest.NewRequest(http.MethodGet, "/sessions/"+session.ID.String()+"/events", nil)
+			req.Header.Set("X-User-ID", userID.String())
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", session.ID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			handler.StreamSessionEvents(rec, req)
+
+			body := rec.Body.String()
+			if !strings.Contains(body, "event: "+eventSessionSnapshot) {
+				t.Fatalf("snapshot not sent, body = %q", body)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("body should contain %s, got %q", want, body)
+				}
+			}
+			if strings.Contains(body, ports.EventSessionAmountUpdated) {
+				t.Errorf("stream should close after the final status, got %q", body)
+			}
+		})
+	}
+}
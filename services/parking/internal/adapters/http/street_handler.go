@@ -0,0 +1,222 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// StreetHandler serves council street zones and prepaid street sessions
+type StreetHandler struct {
+	streetService *application.StreetParkingService
+}
+
+func NewStreetHandler(streetService *application.StreetParkingService) *StreetHandler {
+	return &StreetHandler{streetService: streetService}
+}
+
+func mapStreetError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrZoneNotFound):
+		return http.StatusNotFound, "ZONE_NOT_FOUND", "Street zone not found"
+	case errors.Is(err, domain.ErrInvalidZone):
+		return http.StatusBadRequest, "INVALID_ZONE", "Invalid street zone"
+	case errors.Is(err, domain.ErrZoneExists):
+		return http.StatusConflict, "ZONE_EXISTS", "Street zone code already in use"
+	case errors.Is(err, domain.ErrZoneInactive):
+		return http.StatusBadRequest, "ZONE_INACTIVE", "Street zone is not taking payments"
+	case errors.Is(err, domain.ErrInvalidStreetDuration):
+		return http.StatusBadRequest, "INVALID_DURATION", "Duration must be a positive number of half hours"
+	case errors.Is(err, domain.ErrStreetDurationTooLong):
+		return http.StatusBadRequest, "DURATION_TOO_LONG", "Duration exceeds the zone's maximum stay"
+	case errors.Is(err, domain.ErrStreetSessionNotFound):
+		return http.StatusNotFound, "STREET_SESSION_NOT_FOUND", "Street session not found"
+	case errors.Is(err, domain.ErrStreetSessionExists):
+		return http.StatusConflict, "STREET_SESSION_EXISTS", "Vehicle already has a street session in this zone"
+	case errors.Is(err, domain.ErrStreetSessionAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Street session belongs to another user"
+	case errors.Is(err, domain.ErrStreetSessionExpired):
+		return http.StatusConflict, "STREET_SESSION_EXPIRED", "Street session has expired"
+	case errors.Is(err, domain.ErrStreetSessionNotExtendable):
+		return http.StatusConflict, "STREET_SESSION_INACTIVE", "Street session is not active"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *StreetHandler) ListZones(w http.ResponseWriter, r *http.Request) {
+	var providerID *uuid.UUID
+	if p := r.URL.Query().Get("provider_id"); p != "" {
+		parsed, err := uuid.Parse(p)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "Invalid provider ID format")
+			return
+		}
+		providerID = &parsed
+	}
+
+	zones, err := h.streetService.ListZones(r.Context(), providerID)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, zones)
+}
+
+func (h *StreetHandler) CreateZone(w http.ResponseWriter, r *http.Request) {
+	var req application.CreateZoneRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	zone, err := h.streetService.CreateZone(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, zone)
+}
+
+func (h *StreetHandler) DeactivateZone(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid zone ID format")
+		return
+	}
+
+	zone, err := h.streetService.DeactivateZone(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, zone)
+}
+
+func (h *StreetHandler) StartSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.StartStreetSessionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+
+	session, err := h.streetService.StartSession(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, session)
+}
+
+func (h *StreetHandler) GetUserSessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+
+	resp, err := h.streetService.GetUserSessions(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StreetHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid street session ID format")
+		return
+	}
+
+	session, err := h.streetService.GetUserSession(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, session)
+}
+
+func (h *StreetHandler) ExtendSession(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid street session ID format")
+		return
+	}
+
+	var req application.ExtendStreetSessionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.SessionID = id
+	req.UserID = userID
+
+	session, err := h.streetService.ExtendSession(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, session)
+}
+
+func (h *StreetHandler) RunExpiries(w http.ResponseWriter, r *http.Request) {
+	result, err := h.streetService.ProcessExpiries(r.Context(), time.Now().UTC())
+	if err != nil {
+		status, code, msg := mapStreetError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
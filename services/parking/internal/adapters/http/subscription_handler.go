@@ -0,0 +1,190 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// SubscriptionHandler serves season pass plans and purchases
+type SubscriptionHandler struct {
+	subscriptionService *application.SubscriptionService
+}
+
+func NewSubscriptionHandler(subscriptionService *application.SubscriptionService) *SubscriptionHandler {
+	return &SubscriptionHandler{subscriptionService: subscriptionService}
+}
+
+func mapSubscriptionError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrPlanNotFound):
+		return http.StatusNotFound, "PLAN_NOT_FOUND", "Subscription plan not found"
+	case errors.Is(err, domain.ErrInvalidPlan):
+		return http.StatusBadRequest, "INVALID_PLAN", "Invalid subscription plan"
+	case errors.Is(err, domain.ErrPlanInactive):
+		return http.StatusBadRequest, "PLAN_INACTIVE", "Subscription plan is no longer sold"
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		return http.StatusNotFound, "SUBSCRIPTION_NOT_FOUND", "Subscription not found"
+	case errors.Is(err, domain.ErrSubscriptionExists):
+		return http.StatusConflict, "SUBSCRIPTION_EXISTS", "Vehicle already has this pass"
+	case errors.Is(err, domain.ErrSubscriptionAccessDenied):
+		return http.StatusForbidden, "FORBIDDEN", "Subscription belongs to another user"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *SubscriptionHandler) ListPlans(w http.ResponseWriter, r *http.Request) {
+	providerID, err := uuid.Parse(r.URL.Query().Get("provider_id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "provider_id query parameter required")
+		return
+	}
+
+	var locationID *uuid.UUID
+	if l := r.URL.Query().Get("location_id"); l != "" {
+		parsed, err := uuid.Parse(l)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_LOCATION_ID", "Invalid location ID format")
+			return
+		}
+		locationID = &parsed
+	}
+
+	plans, err := h.subscriptionService.ListPlans(r.Context(), providerID, locationID)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, plans)
+}
+
+func (h *SubscriptionHandler) CreatePlan(w http.ResponseWriter, r *http.Request) {
+	var req application.CreatePlanRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	plan, err := h.subscriptionService.CreatePlan(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, plan)
+}
+
+func (h *SubscriptionHandler) DeactivatePlan(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid plan ID format")
+		return
+	}
+
+	plan, err := h.subscriptionService.DeactivatePlan(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, plan)
+}
+
+func (h *SubscriptionHandler) Purchase(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.PurchaseSubscriptionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+
+	subscription, err := h.subscriptionService.Purchase(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, subscription)
+}
+
+func (h *SubscriptionHandler) GetUserSubscriptions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.subscriptionService.GetUserSubscriptions(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, subscriptions)
+}
+
+func (h *SubscriptionHandler) CancelRenewal(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid subscription ID format")
+		return
+	}
+
+	subscription, err := h.subscriptionService.CancelRenewal(r.Context(), id, userID)
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, subscription)
+}
+
+func (h *SubscriptionHandler) RunRenewals(w http.ResponseWriter, r *http.Request) {
+	result, err := h.subscriptionService.ProcessRenewals(r.Context(), time.Now().UTC())
+	if err != nil {
+		status, code, msg := mapSubscriptionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
+
+// requireUserID reads the caller's ID from the X-User-ID header set by the gateway
+func requireUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		return uuid.Nil, false
+	}
+	return userID, true
+}
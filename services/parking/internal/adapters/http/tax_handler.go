@@ -0,0 +1,87 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/parking/internal/application"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// TaxHandler serves the admin API for providers' tax rates
+type TaxHandler struct {
+	taxService *application.TaxService
+}
+
+func NewTaxHandler(taxService *application.TaxService) *TaxHandler {
+	return &TaxHandler{taxService: taxService}
+}
+
+func mapTaxError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrTaxRateNotFound):
+		return http.StatusNotFound, "TAX_RATE_NOT_FOUND", "Tax rate not found"
+	case errors.Is(err, domain.ErrInvalidTaxRate):
+		return http.StatusBadRequest, "INVALID_TAX_RATE", "Tax needs a name and a rate from 0 to 1"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *TaxHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.CreateTaxRateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	if req.ProviderID == uuid.Nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "Provider ID is required")
+		return
+	}
+
+	rate, err := h.taxService.CreateRate(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapTaxError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, rate)
+}
+
+// List returns the rates of the provider given as ?provider_id=
+func (h *TaxHandler) List(w http.ResponseWriter, r *http.Request) {
+	providerID, err := uuid.Parse(r.URL.Query().Get("provider_id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "Invalid provider ID format")
+		return
+	}
+
+	resp, err := h.taxService.ListRates(r.Context(), providerID)
+	if err != nil {
+		status, code, msg := mapTaxError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *TaxHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid tax rate ID format")
+		return
+	}
+
+	if err := h.taxService.DeleteRate(r.Context(), id); err != nil {
+		status, code, msg := mapTaxError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
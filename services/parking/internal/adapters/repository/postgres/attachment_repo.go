@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type AttachmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAttachmentRepository(db *pgxpool.Pool) *AttachmentRepository {
+	return &AttachmentRepository{db: db}
+}
+
+const attachmentColumns = `id, session_id, uploaded_by, kind, content_type, size_bytes, object_key, status, created_at, uploaded_at`
+
+func (r *AttachmentRepository) Create(ctx context.Context, a *domain.Attachment) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO session_attachments (`+attachmentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, a.ID, a.SessionID, a.UploadedBy, a.Kind, a.ContentType, a.SizeBytes, a.ObjectKey, a.Status, a.CreatedAt, a.UploadedAt)
+	return err
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM session_attachments WHERE id = $1`
+	a, err := scanAttachment(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *AttachmentRepository) GetBySessionID(ctx context.Context, sessionID uuid.UUID) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM session_attachments
+		WHERE session_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []*domain.Attachment{}
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, a)
+	}
+	return attachments, rows.Err()
+}
+
+func (r *AttachmentRepository) CountActive(ctx context.Context, sessionID uuid.UUID, pendingSince time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM session_attachments
+		WHERE session_id = $1 AND (status = $2 OR created_at > $3)
+	`, sessionID, domain.AttachmentStatusUploaded, pendingSince).Scan(&count)
+	return count, err
+}
+
+func (r *AttachmentRepository) Update(ctx context.Context, a *domain.Attachment) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE session_attachments SET status = $2, uploaded_at = $3 WHERE id = $1
+	`, a.ID, a.Status, a.UploadedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func scanAttachment(row pgx.Row) (*domain.Attachment, error) {
+	a := &domain.Attachment{}
+	err := row.Scan(
+		&a.ID, &a.SessionID, &a.UploadedBy, &a.Kind, &a.ContentType, &a.SizeBytes, &a.ObjectKey, &a.Status, &a.CreatedAt, &a.UploadedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
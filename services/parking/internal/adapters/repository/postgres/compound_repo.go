@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// CompoundRepository keeps fines on the primary: lookups write them and
+// payments must see the latest status
+type CompoundRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewCompoundRepository(db, replica *pgxpool.Pool) *CompoundRepository {
+	return &CompoundRepository{db: db, replica: replica}
+}
+
+const compoundColumns = `
+	id, provider_id, reference, vehicle_plate, offence, location, issued_at, due_at, amount, currency,
+	status, paid_by, wallet_id, payment_id, receipt_number, provider_receipt, paid_at, settled_at,
+	created_at, updated_at
+`
+
+// Upsert reopens a closed fine the issuer reports again, and leaves fines
+// that are being paid or have been paid as they are
+func (r *CompoundRepository) Upsert(ctx context.Context, c *domain.Compound) (*domain.Compound, error) {
+	query := `
+		INSERT INTO compounds (` + compoundColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		ON CONFLICT (provider_id, reference) DO UPDATE SET
+			offence = EXCLUDED.offence,
+			location = EXCLUDED.location,
+			due_at = CASE WHEN compounds.status IN ('outstanding', 'closed') THEN EXCLUDED.due_at ELSE compounds.due_at END,
+			amount = CASE WHEN compounds.status IN ('outstanding', 'closed') THEN EXCLUDED.amount ELSE compounds.amount END,
+			status = CASE WHEN compounds.status = 'closed' THEN 'outstanding' ELSE compounds.status END,
+			updated_at = EXCLUDED.updated_at
+		RETURNING ` + compoundColumns
+	return r.scanCompound(r.db.QueryRow(ctx, query,
+		c.ID, c.ProviderID, c.Reference, c.VehiclePlate, c.Offence, c.Location, c.IssuedAt, c.DueAt, c.Amount, c.Currency,
+		c.Status, c.PaidBy, c.WalletID, c.PaymentID, nullString(c.ReceiptNumber), nullString(c.ProviderReceipt), c.PaidAt, c.SettledAt,
+		c.CreatedAt, c.UpdatedAt,
+	))
+}
+
+func (r *CompoundRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Compound, error) {
+	query := `SELECT ` + compoundColumns + ` FROM compounds WHERE id = $1`
+	return r.scanCompound(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *CompoundRepository) GetPaidByUser(ctx context.Context, userID uuid.UUID, plates []string) ([]*domain.Compound, error) {
+	query := `
+		SELECT ` + compoundColumns + `
+		FROM compounds
+		WHERE paid_by = $1 AND vehicle_plate = ANY($2) AND status IN ('paying', 'paid', 'settled')
+		ORDER BY issued_at DESC
+	`
+	return r.query(ctx, query, userID, plates)
+}
+
+func (r *CompoundRepository) Claim(ctx context.Context, c *domain.Compound) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE compounds SET amount = $2, status = $3, paid_by = $4, wallet_id = $5, updated_at = $6
+		WHERE id = $1 AND (status = 'outstanding' OR (status = 'paying' AND paid_by = $4))
+	`, c.ID, c.Amount, c.Status, c.PaidBy, c.WalletID, c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCompoundNotPayable
+	}
+	return nil
+}
+
+func (r *CompoundRepository) Update(ctx context.Context, c *domain.Compound) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE compounds SET
+			amount = $2, status = $3, paid_by = $4, wallet_id = $5, payment_id = $6, receipt_number = $7,
+			provider_receipt = $8, paid_at = $9, settled_at = $10, updated_at = $11
+		WHERE id = $1
+	`, c.ID, c.Amount, c.Status, c.PaidBy, c.WalletID, c.PaymentID, nullString(c.ReceiptNumber),
+		nullString(c.ProviderReceipt), c.PaidAt, c.SettledAt, c.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCompoundNotFound
+	}
+	return nil
+}
+
+func (r *CompoundRepository) GetUnsettled(ctx context.Context, before time.Time, limit int) ([]*domain.Compound, error) {
+	query := `
+		SELECT ` + compoundColumns + `
+		FROM compounds
+		WHERE status = 'paid' AND paid_at < $1
+		ORDER BY paid_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, query, before, limit)
+}
+
+func (r *CompoundRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Compound, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	compounds := []*domain.Compound{}
+	for rows.Next() {
+		c, err := r.scanCompound(rows)
+		if err != nil {
+			return nil, err
+		}
+		compounds = append(compounds, c)
+	}
+	return compounds, rows.Err()
+}
+
+func (r *CompoundRepository) scanCompound(row pgx.Row) (*domain.Compound, error) {
+	c := &domain.Compound{}
+	var receiptNumber, providerReceipt *string
+	err := row.Scan(
+		&c.ID, &c.ProviderID, &c.Reference, &c.VehiclePlate, &c.Offence, &c.Location, &c.IssuedAt, &c.DueAt, &c.Amount, &c.Currency,
+		&c.Status, &c.PaidBy, &c.WalletID, &c.PaymentID, &receiptNumber, &providerReceipt, &c.PaidAt, &c.SettledAt,
+		&c.CreatedAt, &c.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCompoundNotFound
+		}
+		return nil, err
+	}
+	if receiptNumber != nil {
+		c.ReceiptNumber = *receiptNumber
+	}
+	if providerReceipt != nil {
+		c.ProviderReceipt = *providerReceipt
+	}
+	return c, nil
+}
+
+func nullString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
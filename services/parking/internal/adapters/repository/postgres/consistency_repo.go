@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type ConsistencyReportRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewConsistencyReportRepository(db, replica *pgxpool.Pool) *ConsistencyReportRepository {
+	return &ConsistencyReportRepository{db: db, replica: replica}
+}
+
+func (r *ConsistencyReportRepository) Create(ctx context.Context, report *domain.ConsistencyReport) error {
+	issues, err := json.Marshal(report.Issues)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO consistency_reports (
+			id, window_start, window_end, sessions_checked, payments_checked,
+			issue_count, issues, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = r.db.Exec(ctx, query,
+		report.ID, report.WindowStart, report.WindowEnd, report.SessionsChecked,
+		report.PaymentsChecked, len(report.Issues), issues, report.CreatedAt,
+	)
+	return err
+}
+
+func (r *ConsistencyReportRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ConsistencyReport, error) {
+	query := `
+		SELECT id, window_start, window_end, sessions_checked, payments_checked, issues, created_at
+		FROM consistency_reports WHERE id = $1
+	`
+	return r.scanReport(r.replica.QueryRow(ctx, query, id))
+}
+
+func (r *ConsistencyReportRepository) GetLatest(ctx context.Context) (*domain.ConsistencyReport, error) {
+	query := `
+		SELECT id, window_start, window_end, sessions_checked, payments_checked, issues, created_at
+		FROM consistency_reports
+		ORDER BY window_end DESC
+		LIMIT 1
+	`
+	return r.scanReport(r.replica.QueryRow(ctx, query))
+}
+
+func (r *ConsistencyReportRepository) List(ctx context.Context, limit, offset int) ([]*domain.ConsistencyReport, error) {
+	query := `
+		SELECT id, window_start, window_end, sessions_checked, payments_checked, issues, created_at
+		FROM consistency_reports
+		ORDER BY window_end DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.replica.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []*domain.ConsistencyReport{}
+	for rows.Next() {
+		report, err := r.scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+func (r *ConsistencyReportRepository) scanReport(row pgx.Row) (*domain.ConsistencyReport, error) {
+	var report domain.ConsistencyReport
+	var issues []byte
+	err := row.Scan(
+		&report.ID, &report.WindowStart, &report.WindowEnd,
+		&report.SessionsChecked, &report.PaymentsChecked, &issues, &report.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrConsistencyReportNotFound
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(issues, &report.Issues); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type DisputeRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDisputeRepository(db *pgxpool.Pool) *DisputeRepository {
+	return &DisputeRepository{db: db}
+}
+
+const disputeColumns = `
+	id, session_id, user_id, payment_id, amount, currency, reason, description, status,
+	resolution, resolution_note, refund_transaction_id, reviewed_by, resolved_at, created_at, updated_at
+`
+
+func (r *DisputeRepository) Create(ctx context.Context, d *domain.Dispute) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO session_disputes (`+disputeColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`,
+		d.ID, d.SessionID, d.UserID, d.PaymentID, d.Amount, d.Currency, d.Reason, d.Description, d.Status,
+		d.Resolution, d.ResolutionNote, d.RefundTransactionID, d.ReviewedBy, d.ResolvedAt, d.CreatedAt, d.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return domain.ErrDisputeAlreadyOpen
+	}
+	return err
+}
+
+func (r *DisputeRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	query := `SELECT ` + disputeColumns + ` FROM session_disputes WHERE id = $1`
+	return r.scanDispute(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *DisputeRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Dispute, error) {
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM session_disputes
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, userID, limit, offset)
+}
+
+func (r *DisputeRepository) List(ctx context.Context, status *domain.DisputeStatus, limit, offset int) ([]*domain.Dispute, error) {
+	query := `
+		SELECT ` + disputeColumns + `
+		FROM session_disputes
+		WHERE $1::text IS NULL OR status = $1
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, status, limit, offset)
+}
+
+func (r *DisputeRepository) Update(ctx context.Context, d *domain.Dispute) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE session_disputes SET
+			status = $2, resolution = $3, resolution_note = $4, refund_transaction_id = $5,
+			reviewed_by = $6, resolved_at = $7, updated_at = $8
+		WHERE id = $1
+	`, d.ID, d.Status, d.Resolution, d.ResolutionNote, d.RefundTransactionID, d.ReviewedBy, d.ResolvedAt, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDisputeNotFound
+	}
+	return nil
+}
+
+func (r *DisputeRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.Dispute, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	disputes := []*domain.Dispute{}
+	for rows.Next() {
+		d, err := r.scanDispute(rows)
+		if err != nil {
+			return nil, err
+		}
+		disputes = append(disputes, d)
+	}
+	return disputes, rows.Err()
+}
+
+func (r *DisputeRepository) scanDispute(row pgx.Row) (*domain.Dispute, error) {
+	d := &domain.Dispute{}
+	err := row.Scan(
+		&d.ID, &d.SessionID, &d.UserID, &d.PaymentID, &d.Amount, &d.Currency, &d.Reason, &d.Description, &d.Status,
+		&d.Resolution, &d.ResolutionNote, &d.RefundTransactionID, &d.ReviewedBy, &d.ResolvedAt, &d.CreatedAt, &d.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDisputeNotFound
+		}
+		return nil, err
+	}
+	return d, nil
+}
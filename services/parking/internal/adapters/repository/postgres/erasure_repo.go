@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// ErasureRepository removes a deleted user's vehicles and plates
+type ErasureRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewErasureRepository(db *pgxpool.Pool) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// erasableTables lists the user's records that hold a plate, with the
+// statuses that mean the record is still in use
+var erasableTables = []struct {
+	table string
+	live  string
+}{
+	{"parking_sessions", "'pending', 'active', 'ending'"},
+	{"subscriptions", "'pending', 'active'"},
+	{"reservations", "'pending', 'confirmed'"},
+	{"street_sessions", "'pending', 'active'"},
+}
+
+// EraseUser deletes the user's saved vehicles and blanks the plate on
+// every finished record, in one transaction. The erasure stays open while
+// live records remain; running it again finishes them once they end.
+func (r *ErasureRepository) EraseUser(ctx context.Context, userID uuid.UUID, now time.Time) (*domain.ErasureResult, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_erasures (user_id, requested_at) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO NOTHING
+	`, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record erasure: %w", err)
+	}
+
+	result := &domain.ErasureResult{}
+	tag, err := tx.Exec(ctx, `DELETE FROM vehicles WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete vehicles: %w", err)
+	}
+	result.VehiclesDeleted = tag.RowsAffected()
+
+	for _, t := range erasableTables {
+		tag, err := tx.Exec(ctx, `
+			UPDATE `+t.table+` SET vehicle_plate = ''
+			WHERE user_id = $1 AND vehicle_plate <> '' AND status NOT IN (`+t.live+`)
+		`, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to anonymize %s: %w", t.table, err)
+		}
+		result.Anonymized += tag.RowsAffected()
+
+		var live int64
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM `+t.table+` WHERE user_id = $1 AND status IN (`+t.live+`)`, userID).Scan(&live)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count live %s: %w", t.table, err)
+		}
+		result.Live += live
+	}
+
+	if result.Live == 0 {
+		_, err = tx.Exec(ctx, `UPDATE user_erasures SET completed_at = $2 WHERE user_id = $1 AND completed_at IS NULL`, userID, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to complete erasure: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit erasure: %w", err)
+	}
+	return result, nil
+}
+
+// ListOpen returns users whose erasure is waiting on live records, oldest first
+func (r *ErasureRepository) ListOpen(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id FROM user_erasures
+		WHERE completed_at IS NULL
+		ORDER BY requested_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, id)
+	}
+	return userIDs, rows.Err()
+}
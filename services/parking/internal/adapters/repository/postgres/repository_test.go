@@ -0,0 +1,175 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/testenv"
+	"github.com/parking-super-app/services/parking/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/migrations"
+	"github.com/shopspring/decimal"
+)
+
+// Run with:
+//
+//	go test -tags integration ./internal/adapters/repository/postgres/...
+//
+// A Postgres container is started unless TEST_POSTGRES_URL is set.
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func newSession(t *testing.T, userID, providerID uuid.UUID) *domain.ParkingSession {
+	t.Helper()
+	session, err := domain.NewParkingSession(userID, providerID, uuid.New(), "WXY1234", "car")
+	if err != nil {
+		t.Fatalf("NewParkingSession() error = %v", err)
+	}
+	return session
+}
+
+func TestSessionRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	repo := postgres.NewSessionRepository(pool, pool)
+
+	userID, providerID := uuid.New(), uuid.New()
+	session := newSession(t, userID, providerID)
+	if err := repo.Create(ctx, session); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	pending := newSession(t, userID, providerID)
+	if err := repo.Create(ctx, pending); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.SessionStatusPending || got.PaymentStatus != domain.PaymentStatusNone || got.VehiclePlate != "WXY1234" {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("GetByID() of unknown session error = %v, want %v", err, domain.ErrSessionNotFound)
+	}
+
+	if err := session.Activate("ext-1"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if err := repo.Update(ctx, session); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	active, err := repo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetActiveByUserID() error = %v", err)
+	}
+	if len(active) != 1 || active[0].ExternalSessionID != "ext-1" {
+		t.Errorf("GetActiveByUserID() = %d sessions, want the activated one", len(active))
+	}
+	if all, err := repo.GetActive(ctx, 10); err != nil || len(all) != 1 {
+		t.Errorf("GetActive() = %d sessions, %v, want 1", len(all), err)
+	}
+
+	if err := session.End(decimal.RequireFromString("4.50")); err != nil {
+		t.Fatalf("End() error = %v", err)
+	}
+	if err := session.MarkPaid(uuid.New()); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	if err := repo.Update(ctx, session); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err = repo.GetByID(ctx, session.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Status != domain.SessionStatusCompleted || got.PaymentStatus != domain.PaymentStatusPaid ||
+		got.PaymentID == nil || got.ExitTime == nil || !got.Amount.Equal(decimal.RequireFromString("4.50")) {
+		t.Errorf("ended session = %+v", got)
+	}
+
+	ended, err := repo.GetEndedBetween(ctx, time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetEndedBetween() error = %v", err)
+	}
+	if len(ended) != 1 || ended[0].ID != session.ID {
+		t.Errorf("GetEndedBetween() = %d sessions, want the ended one", len(ended))
+	}
+
+	byUser, err := repo.GetByUserID(ctx, userID, 1, 0)
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if len(byUser) != 1 {
+		t.Errorf("GetByUserID() with limit 1 = %d sessions", len(byUser))
+	}
+	if byProvider, err := repo.GetByProviderID(ctx, providerID, 10, 0); err != nil || len(byProvider) != 2 {
+		t.Errorf("GetByProviderID() = %d sessions, %v, want 2", len(byProvider), err)
+	}
+	if count, err := repo.CountByUserID(ctx, userID); err != nil || count != 2 {
+		t.Errorf("CountByUserID() = %d, %v, want 2", count, err)
+	}
+
+	if err := repo.Update(ctx, newSession(t, userID, providerID)); !errors.Is(err, domain.ErrSessionNotFound) {
+		t.Errorf("Update() of unknown session error = %v, want %v", err, domain.ErrSessionNotFound)
+	}
+}
+
+func TestAttachmentRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	sessions := postgres.NewSessionRepository(pool, pool)
+	repo := postgres.NewAttachmentRepository(pool)
+
+	session := newSession(t, uuid.New(), uuid.New())
+	if err := sessions.Create(ctx, session); err != nil {
+		t.Fatalf("Create() session error = %v", err)
+	}
+
+	now := time.Now().UTC()
+	uploaded, _ := domain.NewAttachment(session, session.UserID, domain.AttachmentKindDamage, "image/jpeg", 4096, now.Add(-time.Hour))
+	abandoned, _ := domain.NewAttachment(session, session.UserID, domain.AttachmentKindOther, "image/png", 1024, now.Add(-time.Hour))
+	pending, _ := domain.NewAttachment(session, session.UserID, domain.AttachmentKindEntryTicket, "image/png", 2048, now)
+	for _, a := range []*domain.Attachment{uploaded, abandoned, pending} {
+		if err := repo.Create(ctx, a); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := uploaded.ConfirmUpload(4096, "image/jpeg", now); err != nil {
+		t.Fatalf("ConfirmUpload() error = %v", err)
+	}
+	if err := repo.Update(ctx, uploaded); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	got, err := repo.GetByID(ctx, uploaded.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !got.IsUploaded() || got.UploadedAt == nil || got.ObjectKey != uploaded.ObjectKey {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Errorf("GetByID() of unknown attachment error = %v, want %v", err, domain.ErrAttachmentNotFound)
+	}
+
+	if all, err := repo.GetBySessionID(ctx, session.ID); err != nil || len(all) != 3 {
+		t.Errorf("GetBySessionID() = %d attachments, %v, want 3", len(all), err)
+	}
+	// The abandoned upload is older than the cutoff and no longer counts
+	if count, err := repo.CountActive(ctx, session.ID, now.Add(-15*time.Minute)); err != nil || count != 2 {
+		t.Errorf("CountActive() = %d, %v, want 2", count, err)
+	}
+}
@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+// ReservationRepository reads the primary for everything that decides a
+// booking's next state; only the user's own listing uses the replica
+type ReservationRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewReservationRepository(db, replica *pgxpool.Pool) *ReservationRepository {
+	return &ReservationRepository{db: db, replica: replica}
+}
+
+const reservationColumns = `
+	id, user_id, provider_id, location_id, wallet_id, vehicle_plate, starts_at, ends_at,
+	arrival_from, no_show_at, amount, currency, hold_id, status, session_id, fee_amount,
+	payment_id, closed_at, created_at, updated_at
+`
+
+// overlapCondition matches bookings that take up a space at $1 during [$2, $3)
+const overlapCondition = `
+	location_id = $1 AND status IN ('pending', 'confirmed', 'converted')
+		AND starts_at < $3 AND ends_at > $2
+`
+
+// Reserve serialises bookings per location with a transaction-scoped
+// advisory lock, so two requests cannot both take the last space
+func (r *ReservationRepository) Reserve(ctx context.Context, res *domain.Reservation, capacity int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, res.LocationID.String()); err != nil {
+		return err
+	}
+
+	var reserved int
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM reservations WHERE `+overlapCondition,
+		res.LocationID, res.StartsAt, res.EndsAt,
+	).Scan(&reserved)
+	if err != nil {
+		return err
+	}
+	if reserved >= capacity {
+		return domain.ErrNoSpacesAvailable
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO reservations (`+reservationColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+	`,
+		res.ID, res.UserID, res.ProviderID, res.LocationID, res.WalletID, res.VehiclePlate, res.StartsAt, res.EndsAt,
+		res.ArrivalFrom, res.NoShowAt, res.Amount, res.Currency, res.HoldID, res.Status, res.SessionID, res.FeeAmount,
+		res.PaymentID, res.ClosedAt, res.CreatedAt, res.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ReservationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Reservation, error) {
+	query := `SELECT ` + reservationColumns + ` FROM reservations WHERE id = $1`
+	return r.scanReservation(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *ReservationRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE user_id = $1
+		ORDER BY starts_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, r.replica, query, userID, limit, offset)
+}
+
+func (r *ReservationRepository) GetConfirmedByPlate(ctx context.Context, locationID uuid.UUID, plate string) ([]*domain.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE location_id = $1 AND vehicle_plate = $2 AND status = 'confirmed'
+		ORDER BY starts_at ASC
+	`
+	return r.query(ctx, r.db, query, locationID, plate)
+}
+
+func (r *ReservationRepository) CountOverlapping(ctx context.Context, locationID uuid.UUID, from, to time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM reservations WHERE `+overlapCondition, locationID, from, to).Scan(&count)
+	return count, err
+}
+
+func (r *ReservationRepository) Update(ctx context.Context, res *domain.Reservation) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE reservations SET
+			amount = $2, hold_id = $3, status = $4, session_id = $5, fee_amount = $6,
+			payment_id = $7, closed_at = $8, updated_at = $9
+		WHERE id = $1
+	`, res.ID, res.Amount, res.HoldID, res.Status, res.SessionID, res.FeeAmount, res.PaymentID, res.ClosedAt, res.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrReservationNotFound
+	}
+	return nil
+}
+
+func (r *ReservationRepository) GetNoShows(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error) {
+	query := `
+		SELECT ` + reservationColumns + `
+		FROM reservations
+		WHERE status = 'confirmed' AND no_show_at <= $1
+		ORDER BY no_show_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *ReservationRepository) query(ctx context.Context, pool *pgxpool.Pool, query string, args ...interface{}) ([]*domain.Reservation, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := []*domain.Reservation{}
+	for rows.Next() {
+		res, err := r.scanReservation(rows)
+		if err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, res)
+	}
+	return reservations, rows.Err()
+}
+
+func (r *ReservationRepository) scanReservation(row pgx.Row) (*domain.Reservation, error) {
+	res := &domain.Reservation{}
+	err := row.Scan(
+		&res.ID, &res.UserID, &res.ProviderID, &res.LocationID, &res.WalletID, &res.VehiclePlate, &res.StartsAt, &res.EndsAt,
+		&res.ArrivalFrom, &res.NoShowAt, &res.Amount, &res.Currency, &res.HoldID, &res.Status, &res.SessionID, &res.FeeAmount,
+		&res.PaymentID, &res.ClosedAt, &res.CreatedAt, &res.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReservationNotFound
+		}
+		return nil, err
+	}
+	return res, nil
+}
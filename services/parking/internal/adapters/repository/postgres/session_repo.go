@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -11,39 +13,50 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// SessionRepository serves history and reporting reads from replica, which
+// is the primary itself when no read replica is configured
 type SessionRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
 }
 
-func NewSessionRepository(db *pgxpool.Pool) *SessionRepository {
-	return &SessionRepository{db: db}
+func NewSessionRepository(db, replica *pgxpool.Pool) *SessionRepository {
+	return &SessionRepository{db: db, replica: replica}
 }
 
 func (r *SessionRepository) Create(ctx context.Context, session *domain.ParkingSession) error {
+	taxes, err := marshalTaxes(session.Taxes)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO parking_sessions (
 			id, user_id, provider_id, location_id, external_session_id,
 			vehicle_plate, vehicle_type, entry_time, exit_time,
-			duration_minutes, amount, currency, status, payment_id,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
 	`
-	_, err := r.db.Exec(ctx, query,
-		session.ID, session.UserID, session.ProviderID, session.LocationID,
+	_, err = r.db.Exec(ctx, query,
+		session.ID, sessionUserID(session), session.ProviderID, session.LocationID,
 		session.ExternalSessionID, session.VehiclePlate, session.VehicleType,
 		session.EntryTime, session.ExitTime, session.Duration,
-		session.Amount, session.Currency, session.Status, session.PaymentID,
+		session.Amount, session.Currency, session.Status, session.PaymentStatus, session.PaymentID,
+		session.SubscriptionID, session.ReservationID, session.TaxAmount, taxes, guestDeviceID(session), session.PaymentCard,
 		session.CreatedAt, session.UpdatedAt,
 	)
 	return err
 }
 
+// GetByID reads the primary: EndSession and expiry decide the next state
+// from it, and a lagging replica could let a session end twice
 func (r *SessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ParkingSession, error) {
 	query := `
 		SELECT id, user_id, provider_id, location_id, external_session_id,
 			vehicle_plate, vehicle_type, entry_time, exit_time,
-			duration_minutes, amount, currency, status, payment_id,
-			created_at, updated_at
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
 		FROM parking_sessions WHERE id = $1
 	`
 	return r.scanSession(r.db.QueryRow(ctx, query, id))
@@ -53,14 +66,14 @@ func (r *SessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, l
 	query := `
 		SELECT id, user_id, provider_id, location_id, external_session_id,
 			vehicle_plate, vehicle_type, entry_time, exit_time,
-			duration_minutes, amount, currency, status, payment_id,
-			created_at, updated_at
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
 		FROM parking_sessions
 		WHERE user_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	rows, err := r.replica.Query(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -73,13 +86,34 @@ func (r *SessionRepository) GetActiveByUserID(ctx context.Context, userID uuid.U
 	query := `
 		SELECT id, user_id, provider_id, location_id, external_session_id,
 			vehicle_plate, vehicle_type, entry_time, exit_time,
-			duration_minutes, amount, currency, status, payment_id,
-			created_at, updated_at
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
 		FROM parking_sessions
 		WHERE user_id = $1 AND status = 'active'
 		ORDER BY entry_time DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.replica.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// GetActive feeds the expiry worker, so it reads the primary
+func (r *SessionRepository) GetActive(ctx context.Context, limit int) ([]*domain.ParkingSession, error) {
+	query := `
+		SELECT id, user_id, provider_id, location_id, external_session_id,
+			vehicle_plate, vehicle_type, entry_time, exit_time,
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
+		FROM parking_sessions
+		WHERE status = 'active'
+		ORDER BY entry_time
+		LIMIT $1
+	`
+	rows, err := r.db.Query(ctx, query, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -92,14 +126,14 @@ func (r *SessionRepository) GetByProviderID(ctx context.Context, providerID uuid
 	query := `
 		SELECT id, user_id, provider_id, location_id, external_session_id,
 			vehicle_plate, vehicle_type, entry_time, exit_time,
-			duration_minutes, amount, currency, status, payment_id,
-			created_at, updated_at
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
 		FROM parking_sessions
 		WHERE provider_id = $1
 		ORDER BY created_at DESC
 		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.Query(ctx, query, providerID, limit, offset)
+	rows, err := r.replica.Query(ctx, query, providerID, limit, offset)
 	if err != nil {
 		return nil, err
 	}
@@ -109,16 +143,22 @@ func (r *SessionRepository) GetByProviderID(ctx context.Context, providerID uuid
 }
 
 func (r *SessionRepository) Update(ctx context.Context, session *domain.ParkingSession) error {
+	taxes, err := marshalTaxes(session.Taxes)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE parking_sessions
 		SET external_session_id = $2, exit_time = $3, duration_minutes = $4,
-			amount = $5, status = $6, payment_id = $7, updated_at = $8
+			amount = $5, status = $6, payment_status = $7, payment_id = $8, subscription_id = $9,
+			tax_amount = $10, taxes = $11, payment_card = $12, updated_at = $13
 		WHERE id = $1
 	`
 	result, err := r.db.Exec(ctx, query,
 		session.ID, session.ExternalSessionID, session.ExitTime,
-		session.Duration, session.Amount, session.Status,
-		session.PaymentID, session.UpdatedAt,
+		session.Duration, session.Amount, session.Status, session.PaymentStatus,
+		session.PaymentID, session.SubscriptionID, session.TaxAmount, taxes, session.PaymentCard, session.UpdatedAt,
 	)
 	if err != nil {
 		return err
@@ -129,20 +169,77 @@ func (r *SessionRepository) Update(ctx context.Context, session *domain.ParkingS
 	return nil
 }
 
+func (r *SessionRepository) GetEndedBetween(ctx context.Context, from, to time.Time) ([]*domain.ParkingSession, error) {
+	query := `
+		SELECT id, user_id, provider_id, location_id, external_session_id,
+			vehicle_plate, vehicle_type, entry_time, exit_time,
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
+		FROM parking_sessions
+		WHERE exit_time >= $1 AND exit_time < $2
+		ORDER BY exit_time
+	`
+	rows, err := r.replica.Query(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// GetByGuestDeviceID returns the unclaimed guest sessions started from a device
+func (r *SessionRepository) GetByGuestDeviceID(ctx context.Context, deviceID string, limit, offset int) ([]*domain.ParkingSession, error) {
+	query := `
+		SELECT id, user_id, provider_id, location_id, external_session_id,
+			vehicle_plate, vehicle_type, entry_time, exit_time,
+			duration_minutes, amount, currency, status, payment_status, payment_id,
+			subscription_id, reservation_id, tax_amount, taxes, guest_device_id, payment_card, created_at, updated_at
+		FROM parking_sessions
+		WHERE guest_device_id = $1 AND user_id IS NULL
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.replica.Query(ctx, query, deviceID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanSessions(rows)
+}
+
+// ClaimGuestSessions moves a device's guest sessions to the user who
+// registered from it. The device is kept to show they were paid by card.
+func (r *SessionRepository) ClaimGuestSessions(ctx context.Context, deviceID string, userID uuid.UUID) (int, error) {
+	result, err := r.db.Exec(ctx, `
+		UPDATE parking_sessions
+		SET user_id = $2, updated_at = NOW()
+		WHERE guest_device_id = $1 AND user_id IS NULL
+	`, deviceID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int(result.RowsAffected()), nil
+}
+
 func (r *SessionRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
 	var count int
-	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM parking_sessions WHERE user_id = $1`, userID).Scan(&count)
+	err := r.replica.QueryRow(ctx, `SELECT COUNT(*) FROM parking_sessions WHERE user_id = $1`, userID).Scan(&count)
 	return count, err
 }
 
 func (r *SessionRepository) scanSession(row pgx.Row) (*domain.ParkingSession, error) {
 	var s domain.ParkingSession
 	var amount decimal.Decimal
+	var taxes []byte
+	var userID *uuid.UUID
+	var deviceID *string
 	err := row.Scan(
-		&s.ID, &s.UserID, &s.ProviderID, &s.LocationID, &s.ExternalSessionID,
+		&s.ID, &userID, &s.ProviderID, &s.LocationID, &s.ExternalSessionID,
 		&s.VehiclePlate, &s.VehicleType, &s.EntryTime, &s.ExitTime,
-		&s.Duration, &amount, &s.Currency, &s.Status, &s.PaymentID,
-		&s.CreatedAt, &s.UpdatedAt,
+		&s.Duration, &amount, &s.Currency, &s.Status, &s.PaymentStatus, &s.PaymentID,
+		&s.SubscriptionID, &s.ReservationID, &s.TaxAmount, &taxes, &deviceID, &s.PaymentCard, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -151,6 +248,10 @@ func (r *SessionRepository) scanSession(row pgx.Row) (*domain.ParkingSession, er
 		return nil, err
 	}
 	s.Amount = amount
+	setOwner(&s, userID, deviceID)
+	if err := unmarshalTaxes(taxes, &s); err != nil {
+		return nil, err
+	}
 	return &s, nil
 }
 
@@ -159,17 +260,63 @@ func (r *SessionRepository) scanSessions(rows pgx.Rows) ([]*domain.ParkingSessio
 	for rows.Next() {
 		var s domain.ParkingSession
 		var amount decimal.Decimal
+		var taxes []byte
+		var userID *uuid.UUID
+		var deviceID *string
 		err := rows.Scan(
-			&s.ID, &s.UserID, &s.ProviderID, &s.LocationID, &s.ExternalSessionID,
+			&s.ID, &userID, &s.ProviderID, &s.LocationID, &s.ExternalSessionID,
 			&s.VehiclePlate, &s.VehicleType, &s.EntryTime, &s.ExitTime,
-			&s.Duration, &amount, &s.Currency, &s.Status, &s.PaymentID,
-			&s.CreatedAt, &s.UpdatedAt,
+			&s.Duration, &amount, &s.Currency, &s.Status, &s.PaymentStatus, &s.PaymentID,
+			&s.SubscriptionID, &s.ReservationID, &s.TaxAmount, &taxes, &deviceID, &s.PaymentCard, &s.CreatedAt, &s.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
 		}
 		s.Amount = amount
+		setOwner(&s, userID, deviceID)
+		if err := unmarshalTaxes(taxes, &s); err != nil {
+			return nil, err
+		}
 		sessions = append(sessions, &s)
 	}
 	return sessions, rows.Err()
 }
+
+// sessionUserID stores an unclaimed guest session's user as NULL
+func sessionUserID(s *domain.ParkingSession) *uuid.UUID {
+	if s.IsGuest() {
+		return nil
+	}
+	return &s.UserID
+}
+
+func guestDeviceID(s *domain.ParkingSession) *string {
+	if !s.IsGuestCheckout() {
+		return nil
+	}
+	return &s.GuestDeviceID
+}
+
+func setOwner(s *domain.ParkingSession, userID *uuid.UUID, deviceID *string) {
+	if userID != nil {
+		s.UserID = *userID
+	}
+	if deviceID != nil {
+		s.GuestDeviceID = *deviceID
+	}
+}
+
+// marshalTaxes stores an untaxed session's breakdown as NULL
+func marshalTaxes(taxes []domain.TaxComponent) ([]byte, error) {
+	if len(taxes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(taxes)
+}
+
+func unmarshalTaxes(data []byte, s *domain.ParkingSession) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, &s.Taxes)
+}
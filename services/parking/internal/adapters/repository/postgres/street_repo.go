@@ -0,0 +1,233 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type StreetZoneRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewStreetZoneRepository(db, replica *pgxpool.Pool) *StreetZoneRepository {
+	return &StreetZoneRepository{db: db, replica: replica}
+}
+
+const streetZoneColumns = `
+	id, provider_id, code, name, rate_per_half_hour, currency, max_minutes, active, created_at, updated_at
+`
+
+func (r *StreetZoneRepository) Create(ctx context.Context, zone *domain.StreetZone) error {
+	query := `
+		INSERT INTO street_zones (` + streetZoneColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query,
+		zone.ID, zone.ProviderID, zone.Code, zone.Name, zone.RatePerHalfHour,
+		zone.Currency, zone.MaxMinutes, zone.Active, zone.CreatedAt, zone.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrZoneExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByID reads the primary so a rate change applies to the next payment
+func (r *StreetZoneRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.StreetZone, error) {
+	query := `SELECT ` + streetZoneColumns + ` FROM street_zones WHERE id = $1`
+	return r.scanZone(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *StreetZoneRepository) List(ctx context.Context, providerID *uuid.UUID, activeOnly bool) ([]*domain.StreetZone, error) {
+	query := `
+		SELECT ` + streetZoneColumns + `
+		FROM street_zones
+		WHERE ($1::uuid IS NULL OR provider_id = $1)
+			AND (active OR NOT $2)
+		ORDER BY code ASC
+	`
+	rows, err := r.replica.Query(ctx, query, providerID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	zones := []*domain.StreetZone{}
+	for rows.Next() {
+		zone, err := r.scanZone(rows)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, zone)
+	}
+	return zones, rows.Err()
+}
+
+func (r *StreetZoneRepository) Update(ctx context.Context, zone *domain.StreetZone) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE street_zones SET name = $2, rate_per_half_hour = $3, max_minutes = $4, active = $5, updated_at = $6
+		WHERE id = $1
+	`, zone.ID, zone.Name, zone.RatePerHalfHour, zone.MaxMinutes, zone.Active, zone.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrZoneNotFound
+	}
+	return nil
+}
+
+func (r *StreetZoneRepository) scanZone(row pgx.Row) (*domain.StreetZone, error) {
+	zone := &domain.StreetZone{}
+	err := row.Scan(
+		&zone.ID, &zone.ProviderID, &zone.Code, &zone.Name, &zone.RatePerHalfHour,
+		&zone.Currency, &zone.MaxMinutes, &zone.Active, &zone.CreatedAt, &zone.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrZoneNotFound
+		}
+		return nil, err
+	}
+	return zone, nil
+}
+
+// StreetSessionRepository serves the user's listing from the replica and
+// everything that pays or expires a session from the primary
+type StreetSessionRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewStreetSessionRepository(db, replica *pgxpool.Pool) *StreetSessionRepository {
+	return &StreetSessionRepository{db: db, replica: replica}
+}
+
+const streetSessionColumns = `
+	id, user_id, zone_id, provider_id, wallet_id, vehicle_plate, starts_at, expires_at, paid_minutes,
+	amount, currency, status, extensions, payment_id, reminder_sent_at, created_at, updated_at
+`
+
+func (r *StreetSessionRepository) Create(ctx context.Context, s *domain.StreetSession) error {
+	query := `
+		INSERT INTO street_sessions (` + streetSessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+	_, err := r.db.Exec(ctx, query,
+		s.ID, s.UserID, s.ZoneID, s.ProviderID, s.WalletID, s.VehiclePlate, s.StartsAt, s.ExpiresAt, s.PaidMinutes,
+		s.Amount, s.Currency, s.Status, s.Extensions, s.PaymentID, s.ReminderSentAt, s.CreatedAt, s.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrStreetSessionExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *StreetSessionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.StreetSession, error) {
+	query := `SELECT ` + streetSessionColumns + ` FROM street_sessions WHERE id = $1`
+	return r.scanSession(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *StreetSessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.StreetSession, error) {
+	query := `
+		SELECT ` + streetSessionColumns + `
+		FROM street_sessions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, r.replica, query, userID, limit, offset)
+}
+
+func (r *StreetSessionRepository) GetLive(ctx context.Context, zoneID uuid.UUID, plate string) (*domain.StreetSession, error) {
+	query := `
+		SELECT ` + streetSessionColumns + `
+		FROM street_sessions
+		WHERE zone_id = $1 AND vehicle_plate = $2 AND status IN ('pending', 'active')
+	`
+	return r.scanSession(r.db.QueryRow(ctx, query, zoneID, plate))
+}
+
+func (r *StreetSessionRepository) Update(ctx context.Context, s *domain.StreetSession) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE street_sessions SET
+			expires_at = $2, paid_minutes = $3, amount = $4, status = $5, extensions = $6,
+			payment_id = $7, reminder_sent_at = $8, updated_at = $9
+		WHERE id = $1
+	`, s.ID, s.ExpiresAt, s.PaidMinutes, s.Amount, s.Status, s.Extensions, s.PaymentID, s.ReminderSentAt, s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrStreetSessionNotFound
+	}
+	return nil
+}
+
+func (r *StreetSessionRepository) GetExpiringUnreminded(ctx context.Context, before time.Time, limit int) ([]*domain.StreetSession, error) {
+	query := `
+		SELECT ` + streetSessionColumns + `
+		FROM street_sessions
+		WHERE status = 'active' AND reminder_sent_at IS NULL AND expires_at < $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *StreetSessionRepository) GetLapsed(ctx context.Context, before time.Time, limit int) ([]*domain.StreetSession, error) {
+	query := `
+		SELECT ` + streetSessionColumns + `
+		FROM street_sessions
+		WHERE status = 'active' AND expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *StreetSessionRepository) query(ctx context.Context, pool *pgxpool.Pool, query string, args ...interface{}) ([]*domain.StreetSession, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*domain.StreetSession{}
+	for rows.Next() {
+		s, err := r.scanSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+func (r *StreetSessionRepository) scanSession(row pgx.Row) (*domain.StreetSession, error) {
+	s := &domain.StreetSession{}
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.ZoneID, &s.ProviderID, &s.WalletID, &s.VehiclePlate, &s.StartsAt, &s.ExpiresAt, &s.PaidMinutes,
+		&s.Amount, &s.Currency, &s.Status, &s.Extensions, &s.PaymentID, &s.ReminderSentAt, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrStreetSessionNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
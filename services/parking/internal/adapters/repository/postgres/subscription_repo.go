@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type SubscriptionPlanRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewSubscriptionPlanRepository(db, replica *pgxpool.Pool) *SubscriptionPlanRepository {
+	return &SubscriptionPlanRepository{db: db, replica: replica}
+}
+
+const subscriptionPlanColumns = `
+	id, provider_id, location_id, name, description, price, duration_days, active, created_at, updated_at
+`
+
+func (r *SubscriptionPlanRepository) Create(ctx context.Context, plan *domain.SubscriptionPlan) error {
+	query := `
+		INSERT INTO subscription_plans (` + subscriptionPlanColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query,
+		plan.ID, plan.ProviderID, plan.LocationID, plan.Name, plan.Description,
+		plan.Price, plan.DurationDays, plan.Active, plan.CreatedAt, plan.UpdatedAt,
+	)
+	return err
+}
+
+// GetByID reads the primary so a plan deactivated a moment ago is not sold
+func (r *SubscriptionPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.SubscriptionPlan, error) {
+	query := `SELECT ` + subscriptionPlanColumns + ` FROM subscription_plans WHERE id = $1`
+	return r.scanPlan(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *SubscriptionPlanRepository) List(ctx context.Context, providerID uuid.UUID, locationID *uuid.UUID, activeOnly bool) ([]*domain.SubscriptionPlan, error) {
+	query := `
+		SELECT ` + subscriptionPlanColumns + `
+		FROM subscription_plans
+		WHERE provider_id = $1
+			AND ($2::uuid IS NULL OR location_id IS NULL OR location_id = $2)
+			AND (active OR NOT $3)
+		ORDER BY price ASC
+	`
+	rows, err := r.replica.Query(ctx, query, providerID, locationID, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	plans := []*domain.SubscriptionPlan{}
+	for rows.Next() {
+		plan, err := r.scanPlan(rows)
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, rows.Err()
+}
+
+func (r *SubscriptionPlanRepository) Update(ctx context.Context, plan *domain.SubscriptionPlan) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE subscription_plans SET name = $2, description = $3, active = $4, updated_at = $5
+		WHERE id = $1
+	`, plan.ID, plan.Name, plan.Description, plan.Active, plan.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPlanNotFound
+	}
+	return nil
+}
+
+func (r *SubscriptionPlanRepository) scanPlan(row pgx.Row) (*domain.SubscriptionPlan, error) {
+	plan := &domain.SubscriptionPlan{}
+	var description *string
+	err := row.Scan(
+		&plan.ID, &plan.ProviderID, &plan.LocationID, &plan.Name, &description,
+		&plan.Price, &plan.DurationDays, &plan.Active, &plan.CreatedAt, &plan.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPlanNotFound
+		}
+		return nil, err
+	}
+	if description != nil {
+		plan.Description = *description
+	}
+	return plan, nil
+}
+
+// SubscriptionRepository keeps coverage lookups and the renewal job on the
+// primary; only the user's own listing is served from the replica
+type SubscriptionRepository struct {
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
+}
+
+func NewSubscriptionRepository(db, replica *pgxpool.Pool) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db, replica: replica}
+}
+
+const subscriptionColumns = `
+	id, user_id, plan_id, provider_id, location_id, vehicle_plate, wallet_id, status, auto_renew,
+	starts_at, expires_at, renewal_count, payment_id, expiry_notified_at, created_at, updated_at
+`
+
+func (r *SubscriptionRepository) Create(ctx context.Context, s *domain.Subscription) error {
+	query := `
+		INSERT INTO subscriptions (` + subscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+	`
+	_, err := r.db.Exec(ctx, query,
+		s.ID, s.UserID, s.PlanID, s.ProviderID, s.LocationID, s.VehiclePlate, s.WalletID, s.Status, s.AutoRenew,
+		s.StartsAt, s.ExpiresAt, s.RenewalCount, s.PaymentID, s.ExpiryNotifiedAt, s.CreatedAt, s.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrSubscriptionExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Subscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM subscriptions WHERE id = $1`
+	return r.scanSubscription(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *SubscriptionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+	`
+	return r.query(ctx, r.replica, query, userID)
+}
+
+func (r *SubscriptionRepository) GetByPlate(ctx context.Context, plate string, at time.Time) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE vehicle_plate = $1 AND status IN ('active', 'expired')
+			AND starts_at <= $2 AND expires_at > $2
+		ORDER BY expires_at DESC
+	`
+	return r.query(ctx, r.db, query, plate, at)
+}
+
+func (r *SubscriptionRepository) Update(ctx context.Context, s *domain.Subscription) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE subscriptions SET
+			status = $2, auto_renew = $3, expires_at = $4, renewal_count = $5,
+			payment_id = $6, expiry_notified_at = $7, updated_at = $8
+		WHERE id = $1
+	`, s.ID, s.Status, s.AutoRenew, s.ExpiresAt, s.RenewalCount, s.PaymentID, s.ExpiryNotifiedAt, s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+func (r *SubscriptionRepository) GetDueForRenewal(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'active' AND auto_renew AND expires_at < $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *SubscriptionRepository) GetExpiringUnnotified(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'active' AND expiry_notified_at IS NULL AND expires_at < $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *SubscriptionRepository) GetLapsed(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + `
+		FROM subscriptions
+		WHERE status = 'active' AND expires_at <= $1
+		ORDER BY expires_at ASC
+		LIMIT $2
+	`
+	return r.query(ctx, r.db, query, before, limit)
+}
+
+func (r *SubscriptionRepository) query(ctx context.Context, pool *pgxpool.Pool, query string, args ...interface{}) ([]*domain.Subscription, error) {
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []*domain.Subscription{}
+	for rows.Next() {
+		s, err := r.scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions, rows.Err()
+}
+
+func (r *SubscriptionRepository) scanSubscription(row pgx.Row) (*domain.Subscription, error) {
+	s := &domain.Subscription{}
+	err := row.Scan(
+		&s.ID, &s.UserID, &s.PlanID, &s.ProviderID, &s.LocationID, &s.VehiclePlate, &s.WalletID, &s.Status, &s.AutoRenew,
+		&s.StartsAt, &s.ExpiresAt, &s.RenewalCount, &s.PaymentID, &s.ExpiryNotifiedAt, &s.CreatedAt, &s.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return s, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == "23505"
+	}
+	return false
+}
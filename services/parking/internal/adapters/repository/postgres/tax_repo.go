@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/parking/internal/domain"
+)
+
+type TaxRateRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTaxRateRepository(db *pgxpool.Pool) *TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+const taxRateColumns = `id, provider_id, location_id, name, rate, inclusive, created_at, updated_at`
+
+func (r *TaxRateRepository) Create(ctx context.Context, rate *domain.TaxRate) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO tax_rates (`+taxRateColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, rate.ID, rate.ProviderID, rate.LocationID, rate.Name, rate.Rate, rate.Inclusive, rate.CreatedAt, rate.UpdatedAt)
+	return err
+}
+
+func (r *TaxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error) {
+	query := `SELECT ` + taxRateColumns + ` FROM tax_rates WHERE id = $1`
+	rate := &domain.TaxRate{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&rate.ID, &rate.ProviderID, &rate.LocationID, &rate.Name, &rate.Rate, &rate.Inclusive, &rate.CreatedAt, &rate.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTaxRateNotFound
+		}
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (r *TaxRateRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.TaxRate, error) {
+	query := `
+		SELECT ` + taxRateColumns + `
+		FROM tax_rates
+		WHERE provider_id = $1
+		ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []*domain.TaxRate{}
+	for rows.Next() {
+		rate := &domain.TaxRate{}
+		if err := rows.Scan(
+			&rate.ID, &rate.ProviderID, &rate.LocationID, &rate.Name, &rate.Rate, &rate.Inclusive, &rate.CreatedAt, &rate.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
+
+func (r *TaxRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM tax_rates WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTaxRateNotFound
+	}
+	return nil
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type VehicleRepository struct {
-	db *pgxpool.Pool
+	db      *pgxpool.Pool
+	replica *pgxpool.Pool
 }
 
-func NewVehicleRepository(db *pgxpool.Pool) *VehicleRepository {
-	return &VehicleRepository{db: db}
+func NewVehicleRepository(db, replica *pgxpool.Pool) *VehicleRepository {
+	return &VehicleRepository{db: db, replica: replica}
 }
 
 func (r *VehicleRepository) Create(ctx context.Context, vehicle *domain.Vehicle) error {
@@ -37,7 +38,7 @@ func (r *VehicleRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 		FROM vehicles WHERE id = $1
 	`
 	var v domain.Vehicle
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := r.replica.QueryRow(ctx, query, id).Scan(
 		&v.ID, &v.UserID, &v.Plate, &v.Type,
 		&v.Make, &v.Model, &v.Color, &v.IsDefault, &v.CreatedAt,
 	)
@@ -56,7 +57,7 @@ func (r *VehicleRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 		FROM vehicles WHERE user_id = $1
 		ORDER BY is_default DESC, created_at DESC
 	`
-	rows, err := r.db.Query(ctx, query, userID)
+	rows, err := r.replica.Query(ctx, query, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +84,7 @@ func (r *VehicleRepository) GetByPlate(ctx context.Context, plate string) (*doma
 		FROM vehicles WHERE plate = $1
 	`
 	var v domain.Vehicle
-	err := r.db.QueryRow(ctx, query, plate).Scan(
+	err := r.replica.QueryRow(ctx, query, plate).Scan(
 		&v.ID, &v.UserID, &v.Plate, &v.Type,
 		&v.Make, &v.Model, &v.Color, &v.IsDefault, &v.CreatedAt,
 	)
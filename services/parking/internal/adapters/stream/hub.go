@@ -0,0 +1,79 @@
+package stream
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/fanout"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// HubConfig holds connection limits for the session event hub
+type HubConfig struct {
+	MaxStreams        int // Total open streams across all users
+	MaxStreamsPerUser int // Open streams per user
+	BufferSize        int // Events buffered per stream before dropping
+}
+
+// DefaultHubConfig returns sensible default limits
+func DefaultHubConfig() HubConfig {
+	return HubConfig{
+		MaxStreams:        1000,
+		MaxStreamsPerUser: 3,
+		BufferSize:        16,
+	}
+}
+
+// Hub fans out session events to subscribers of that session.
+// Events arrive via Publish, typically from a Kafka consumer.
+type Hub struct {
+	subs *fanout.Hub[uuid.UUID, ports.Event]
+}
+
+// NewHub creates a new session event hub
+func NewHub(cfg HubConfig) *Hub {
+	return &Hub{subs: fanout.NewHub[uuid.UUID, ports.Event](fanout.Config{
+		MaxSubscribers:         cfg.MaxStreams,
+		MaxSubscribersPerOwner: cfg.MaxStreamsPerUser,
+		BufferSize:             cfg.BufferSize,
+	})}
+}
+
+// Subscribe registers a stream for a session, enforcing connection limits
+func (h *Hub) Subscribe(ctx context.Context, sessionID, userID uuid.UUID) (<-chan ports.Event, func(), error) {
+	events, cancel, err := h.subs.Subscribe(sessionID, userID.String())
+	if errors.Is(err, fanout.ErrLimitReached) {
+		return nil, nil, domain.ErrStreamLimitReached
+	}
+	return events, cancel, err
+}
+
+// Publish routes an event to the streams of the session named in its payload.
+// Slow subscribers that have a full buffer miss the event rather than block the hub.
+func (h *Hub) Publish(ctx context.Context, event ports.Event) error {
+	sessionID, ok := sessionIDFromPayload(event.Payload)
+	if !ok {
+		return nil
+	}
+	h.subs.Publish(sessionID, event)
+	return nil
+}
+
+// Count returns the number of open streams
+func (h *Hub) Count() int {
+	return h.subs.Count()
+}
+
+func sessionIDFromPayload(payload map[string]interface{}) (uuid.UUID, bool) {
+	raw, ok := payload["session_id"].(string)
+	if !ok {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
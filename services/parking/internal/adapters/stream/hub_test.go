@@ -0,0 +1,113 @@
+package stream
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+func TestHub_PublishFiltersBySession(t *testing.T) {
+	hub := NewHub(DefaultHubConfig())
+	userID := uuid.New()
+	sessionA := uuid.New()
+	sessionB := uuid.New()
+
+	eventsA, cancelA, err := hub.Subscribe(context.Background(), sessionA, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cancelA()
+
+	eventsB, cancelB, err := hub.Subscribe(context.Background(), sessionB, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer cancelB()
+
+	hub.Publish(context.Background(), ports.Event{
+		Type:    ports.EventSessionAmountUpdated,
+		Payload: map[string]interface{}{"session_id": sessionA.String()},
+	})
+
+	select {
+	case event := <-eventsA:
+		if event.Type != ports.EventSessionAmountUpdated {
+			t.Errorf("expected %s, got %s", ports.EventSessionAmountUpdated, event.Type)
+		}
+	default:
+		t.Error("expected event for session A")
+	}
+
+	select {
+	case event := <-eventsB:
+		t.Errorf("session B should not receive events for session A, got %s", event.Type)
+	default:
+	}
+}
+
+func TestHub_ConnectionLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     HubConfig
+		users   []uuid.UUID
+		wantErr []error
+	}{
+		{
+			name:    "per user limit",
+			cfg:     HubConfig{MaxStreams: 10, MaxStreamsPerUser: 1},
+			users:   []uuid.UUID{uuid.MustParse("11111111-1111-1111-1111-111111111111"), uuid.MustParse("11111111-1111-1111-1111-111111111111")},
+			wantErr: []error{nil, domain.ErrStreamLimitReached},
+		},
+		{
+			name:    "global limit",
+			cfg:     HubConfig{MaxStreams: 1, MaxStreamsPerUser: 5},
+			users:   []uuid.UUID{uuid.New(), uuid.New()},
+			wantErr: []error{nil, domain.ErrStreamLimitReached},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := NewHub(tt.cfg)
+			for i, userID := range tt.users {
+				_, cancel, err := hub.Subscribe(context.Background(), uuid.New(), userID)
+				if err != tt.wantErr[i] {
+					t.Fatalf("subscription %d: expected error %v, got %v", i, tt.wantErr[i], err)
+				}
+				if cancel != nil {
+					defer cancel()
+				}
+			}
+		})
+	}
+}
+
+func TestHub_CancelReleasesSlot(t *testing.T) {
+	hub := NewHub(HubConfig{MaxStreams: 1, MaxStreamsPerUser: 1})
+	userID := uuid.New()
+	sessionID := uuid.New()
+
+	events, cancel, err := hub.Subscribe(context.Background(), sessionID, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cancel()
+	cancel() // safe to call twice
+
+	if _, ok := <-events; ok {
+		t.Error("expected channel to be closed after cancel")
+	}
+	if hub.Count() != 0 {
+		t.Errorf("expected 0 open streams, got %d", hub.Count())
+	}
+
+	_, cancel, err = hub.Subscribe(context.Background(), sessionID, userID)
+	if err != nil {
+		t.Fatalf("expected slot to be released, got %v", err)
+	}
+	cancel()
+}
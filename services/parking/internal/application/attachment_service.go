@@ -0,0 +1,215 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/storage"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// AttachmentService keeps photos with sessions as evidence for disputes and
+// enforcement. Users upload straight to object storage with a presigned URL
+// and then confirm the upload; only confirmed photos are listed.
+type AttachmentService struct {
+	attachments ports.AttachmentRepository
+	sessions    ports.SessionRepository
+	disputes    ports.DisputeRepository
+	store       ports.AttachmentStore // nil when storage is not configured
+	logger      ports.Logger
+	config      AttachmentConfig
+}
+
+// AttachmentConfig controls how long presigned URLs stay valid
+type AttachmentConfig struct {
+	UploadURLTTL   time.Duration
+	DownloadURLTTL time.Duration
+}
+
+func NewAttachmentService(
+	attachments ports.AttachmentRepository,
+	sessions ports.SessionRepository,
+	disputes ports.DisputeRepository,
+	store ports.AttachmentStore,
+	logger ports.Logger,
+	config AttachmentConfig,
+) *AttachmentService {
+	return &AttachmentService{
+		attachments: attachments,
+		sessions:    sessions,
+		disputes:    disputes,
+		store:       store,
+		logger:      logger,
+		config:      config,
+	}
+}
+
+type CreateAttachmentRequest struct {
+	UserID      uuid.UUID             `json:"-"`
+	SessionID   uuid.UUID             `json:"-"`
+	Kind        domain.AttachmentKind `json:"kind"`
+	ContentType string                `json:"content_type"`
+	SizeBytes   int64                 `json:"size_bytes"`
+}
+
+// AttachmentUploadResponse is a new attachment and the request that uploads
+// its file
+type AttachmentUploadResponse struct {
+	Attachment *domain.Attachment        `json:"attachment"`
+	Upload     *storage.PresignedRequest `json:"upload"`
+}
+
+// AttachmentResponse is an uploaded attachment and where to download it
+type AttachmentResponse struct {
+	*domain.Attachment
+	Download *storage.PresignedRequest `json:"download"`
+}
+
+type AttachmentListResponse struct {
+	SessionID   uuid.UUID             `json:"session_id"`
+	Attachments []*AttachmentResponse `json:"attachments"`
+}
+
+// CreateAttachment records a photo on one of the user's sessions and
+// presigns its upload. The signed request only accepts a file of the
+// announced type and size.
+func (s *AttachmentService) CreateAttachment(ctx context.Context, req CreateAttachmentRequest) (*AttachmentUploadResponse, error) {
+	if s.store == nil {
+		return nil, domain.ErrAttachmentsUnavailable
+	}
+	session, err := s.userSession(ctx, req.SessionID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	attachment, err := domain.NewAttachment(session, req.UserID, req.Kind, req.ContentType, req.SizeBytes, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// Abandoned uploads stop counting once their URL has expired
+	count, err := s.attachments.CountActive(ctx, session.ID, now.Add(-s.config.UploadURLTTL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attachments: %w", err)
+	}
+	if count >= domain.MaxSessionAttachments {
+		return nil, domain.ErrTooManyAttachments
+	}
+
+	upload, err := s.store.PresignPut(ctx, attachment.ObjectKey, attachment.ContentType, attachment.SizeBytes, s.config.UploadURLTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+	if err := s.attachments.Create(ctx, attachment); err != nil {
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	return &AttachmentUploadResponse{Attachment: attachment, Upload: upload}, nil
+}
+
+// CompleteUpload checks the file is in storage as announced and marks the
+// attachment uploaded
+func (s *AttachmentService) CompleteUpload(ctx context.Context, userID, sessionID, attachmentID uuid.UUID) (*AttachmentResponse, error) {
+	if s.store == nil {
+		return nil, domain.ErrAttachmentsUnavailable
+	}
+	if _, err := s.userSession(ctx, sessionID, userID); err != nil {
+		return nil, err
+	}
+	attachment, err := s.attachments.GetByID(ctx, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.SessionID != sessionID {
+		return nil, domain.ErrAttachmentNotFound
+	}
+
+	if !attachment.IsUploaded() {
+		object, err := s.store.Stat(ctx, attachment.ObjectKey)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, domain.ErrAttachmentNotUploaded
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to check upload: %w", err)
+		}
+		if err := attachment.ConfirmUpload(object.Size, object.ContentType, time.Now().UTC()); err != nil {
+			return nil, err
+		}
+		if err := s.attachments.Update(ctx, attachment); err != nil {
+			return nil, fmt.Errorf("failed to update attachment: %w", err)
+		}
+
+		s.logger.WithContext(ctx).Info("session attachment uploaded",
+			ports.String("attachment_id", attachment.ID.String()),
+			ports.String("session_id", sessionID.String()),
+			ports.String("kind", string(attachment.Kind)),
+		)
+	}
+
+	return s.withDownload(ctx, attachment)
+}
+
+// GetUserAttachments lists the uploaded photos on one of the user's sessions
+func (s *AttachmentService) GetUserAttachments(ctx context.Context, userID, sessionID uuid.UUID) (*AttachmentListResponse, error) {
+	if _, err := s.userSession(ctx, sessionID, userID); err != nil {
+		return nil, err
+	}
+	return s.list(ctx, sessionID)
+}
+
+// GetDisputeAttachments lists the photos on a disputed session, for the
+// admin reviewing the dispute
+func (s *AttachmentService) GetDisputeAttachments(ctx context.Context, disputeID uuid.UUID) (*AttachmentListResponse, error) {
+	dispute, err := s.disputes.GetByID(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	return s.list(ctx, dispute.SessionID)
+}
+
+func (s *AttachmentService) userSession(ctx context.Context, sessionID, userID uuid.UUID) (*domain.ParkingSession, error) {
+	session, err := s.sessions.GetByID(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != userID {
+		return nil, domain.ErrSessionAccessDenied
+	}
+	return session, nil
+}
+
+func (s *AttachmentService) list(ctx context.Context, sessionID uuid.UUID) (*AttachmentListResponse, error) {
+	if s.store == nil {
+		return nil, domain.ErrAttachmentsUnavailable
+	}
+	attachments, err := s.attachments.GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	resp := &AttachmentListResponse{SessionID: sessionID, Attachments: []*AttachmentResponse{}}
+	for _, attachment := range attachments {
+		if !attachment.IsUploaded() {
+			continue
+		}
+		item, err := s.withDownload(ctx, attachment)
+		if err != nil {
+			return nil, err
+		}
+		resp.Attachments = append(resp.Attachments, item)
+	}
+	return resp, nil
+}
+
+func (s *AttachmentService) withDownload(ctx context.Context, attachment *domain.Attachment) (*AttachmentResponse, error) {
+	download, err := s.store.PresignGet(ctx, attachment.ObjectKey, s.config.DownloadURLTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign download: %w", err)
+	}
+	return &AttachmentResponse{Attachment: attachment, Download: download}, nil
+}
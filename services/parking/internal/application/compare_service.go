@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// CompareConfig bounds price comparison searches
+type CompareConfig struct {
+	DefaultRadiusKm float64
+	MaxRadiusKm     float64
+	PricingCacheTTL time.Duration // Location pricing changes rarely, so quotes reuse it this long
+	Concurrency     int           // Pricing lookups in flight at once
+}
+
+// CompareService quotes the cost of a stay at every location near a point
+type CompareService struct {
+	provider ports.ProviderClient
+	logger   ports.Logger
+	cfg      CompareConfig
+	pricing  *pricingCache
+}
+
+func NewCompareService(provider ports.ProviderClient, logger ports.Logger, cfg CompareConfig) *CompareService {
+	if cfg.DefaultRadiusKm <= 0 {
+		cfg.DefaultRadiusKm = 2
+	}
+	if cfg.MaxRadiusKm < cfg.DefaultRadiusKm {
+		cfg.MaxRadiusKm = cfg.DefaultRadiusKm
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 8
+	}
+	return &CompareService{
+		provider: provider,
+		logger:   logger,
+		cfg:      cfg,
+		pricing:  newPricingCache(cfg.PricingCacheTTL),
+	}
+}
+
+type CompareRequest struct {
+	Latitude  float64
+	Longitude float64
+	Duration  time.Duration
+	RadiusKm  float64
+	Sort      domain.QuoteSort
+}
+
+type PriceQuote struct {
+	LocationID    uuid.UUID       `json:"location_id"`
+	ProviderID    uuid.UUID       `json:"provider_id"`
+	Name          string          `json:"name"`
+	Address       string          `json:"address"`
+	Latitude      float64         `json:"latitude"`
+	Longitude     float64         `json:"longitude"`
+	DistanceKm    float64         `json:"distance_km"`
+	HourlyRate    decimal.Decimal `json:"hourly_rate"`
+	DailyMax      decimal.Decimal `json:"daily_max"`
+	EstimatedCost decimal.Decimal `json:"estimated_cost"`
+	Currency      string          `json:"currency"`
+}
+
+// CompareResponse lists quotes for the requested stay. Locations whose
+// pricing could not be fetched are left out and counted in Unpriced.
+type CompareResponse struct {
+	DurationMinutes int           `json:"duration_minutes"`
+	RadiusKm        float64       `json:"radius_km"`
+	Sort            string        `json:"sort"`
+	Quotes          []*PriceQuote `json:"quotes"`
+	Unpriced        int           `json:"unpriced"`
+}
+
+// Compare finds active locations within the radius and quotes the stay at
+// each, sorted by estimated cost or by distance
+func (s *CompareService) Compare(ctx context.Context, req CompareRequest) (*CompareResponse, error) {
+	if !domain.ValidCoordinates(req.Latitude, req.Longitude) {
+		return nil, domain.ErrInvalidCoordinates
+	}
+	if req.Duration <= 0 || req.Duration > domain.MaxQuoteDuration {
+		return nil, domain.ErrInvalidDuration
+	}
+	switch req.Sort {
+	case "":
+		req.Sort = domain.QuoteSortPrice
+	case domain.QuoteSortPrice, domain.QuoteSortDistance:
+	default:
+		return nil, domain.ErrInvalidSort
+	}
+	if req.RadiusKm <= 0 {
+		req.RadiusKm = s.cfg.DefaultRadiusKm
+	}
+	if req.RadiusKm > s.cfg.MaxRadiusKm {
+		req.RadiusKm = s.cfg.MaxRadiusKm
+	}
+
+	locations, err := s.provider.ListNearbyLocations(ctx, req.Latitude, req.Longitude, req.RadiusKm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nearby locations: %w", err)
+	}
+
+	// Stays are quoted from now, so peak and night rates apply as they would
+	// to a session started now
+	start := time.Now()
+	quotes := make([]*PriceQuote, len(locations))
+	sem := make(chan struct{}, s.cfg.Concurrency)
+	var wg sync.WaitGroup
+	for i, location := range locations {
+		if !location.Active {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, location ports.LocationInfo) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			pricing, err := s.locationPricing(ctx, location.ID)
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("failed to price location for comparison",
+					ports.String("location_id", location.ID.String()),
+					ports.Err(err),
+				)
+				return
+			}
+			quotes[i] = &PriceQuote{
+				LocationID:    location.ID,
+				ProviderID:    location.ProviderID,
+				Name:          location.Name,
+				Address:       location.Address,
+				Latitude:      location.Latitude,
+				Longitude:     location.Longitude,
+				DistanceKm:    roundKm(domain.DistanceKm(req.Latitude, req.Longitude, location.Latitude, location.Longitude)),
+				HourlyRate:    pricing.HourlyRate,
+				DailyMax:      pricing.DailyMax,
+				EstimatedCost: domain.EstimateStay(pricing.Tariff(), start, req.Duration),
+				Currency:      pricing.Currency,
+			}
+		}(i, location)
+	}
+	wg.Wait()
+
+	resp := &CompareResponse{
+		DurationMinutes: int(req.Duration.Minutes()),
+		RadiusKm:        req.RadiusKm,
+		Sort:            string(req.Sort),
+		Quotes:          make([]*PriceQuote, 0, len(quotes)),
+	}
+	for i, quote := range quotes {
+		if quote != nil {
+			resp.Quotes = append(resp.Quotes, quote)
+		} else if locations[i].Active {
+			resp.Unpriced++
+		}
+	}
+	sortQuotes(resp.Quotes, req.Sort)
+	return resp, nil
+}
+
+func (s *CompareService) locationPricing(ctx context.Context, locationID uuid.UUID) (*ports.LocationPricing, error) {
+	now := time.Now()
+	if pricing, ok := s.pricing.get(locationID, now); ok {
+		return pricing, nil
+	}
+	pricing, err := s.provider.GetPricing(ctx, locationID, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	s.pricing.put(locationID, pricing, now)
+	return pricing, nil
+}
+
+// sortQuotes orders by the chosen key and breaks ties with the other, so
+// equal prices list the nearest first
+func sortQuotes(quotes []*PriceQuote, by domain.QuoteSort) {
+	sort.SliceStable(quotes, func(i, j int) bool {
+		a, b := quotes[i], quotes[j]
+		if by == domain.QuoteSortDistance {
+			if a.DistanceKm != b.DistanceKm {
+				return a.DistanceKm < b.DistanceKm
+			}
+			return a.EstimatedCost.LessThan(b.EstimatedCost)
+		}
+		if !a.EstimatedCost.Equal(b.EstimatedCost) {
+			return a.EstimatedCost.LessThan(b.EstimatedCost)
+		}
+		return a.DistanceKm < b.DistanceKm
+	})
+}
+
+func roundKm(km float64) float64 {
+	return math.Round(km*100) / 100
+}
+
+// pricingCache keeps location pricing for a fixed time. Entries are replaced
+// on the next lookup after they expire.
+type pricingCache struct {
+	ttl     time.Duration
+	mu      sync.RWMutex
+	entries map[uuid.UUID]pricingCacheEntry
+}
+
+type pricingCacheEntry struct {
+	pricing   *ports.LocationPricing
+	expiresAt time.Time
+}
+
+func newPricingCache(ttl time.Duration) *pricingCache {
+	return &pricingCache{ttl: ttl, entries: make(map[uuid.UUID]pricingCacheEntry)}
+}
+
+func (c *pricingCache) get(locationID uuid.UUID, now time.Time) (*ports.LocationPricing, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[locationID]
+	if !ok || now.After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.pricing, true
+}
+
+func (c *pricingCache) put(locationID uuid.UUID, pricing *ports.LocationPricing, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[locationID] = pricingCacheEntry{pricing: pricing, expiresAt: now.Add(c.ttl)}
+}
@@ -0,0 +1,333 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// CompoundConfig controls the job that retries settling paid fines
+type CompoundConfig struct {
+	RetryDelay time.Duration // Payments younger than this are left to the request that made them
+	BatchSize  int
+}
+
+// CompoundService looks up parking fines at their issuers and pays them from
+// the wallet. Fines are stored when they are looked up, so a payment always
+// has a record to point at.
+type CompoundService struct {
+	compounds ports.CompoundRepository
+	vehicles  ports.VehicleRepository
+	provider  ports.ProviderClient
+	wallet    ports.WalletClient
+	events    ports.EventPublisher
+	logger    ports.Logger
+	cfg       CompoundConfig
+}
+
+func NewCompoundService(
+	compounds ports.CompoundRepository,
+	vehicles ports.VehicleRepository,
+	provider ports.ProviderClient,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg CompoundConfig,
+) *CompoundService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 200
+	}
+	return &CompoundService{
+		compounds: compounds,
+		vehicles:  vehicles,
+		provider:  provider,
+		wallet:    wallet,
+		events:    events,
+		logger:    logger,
+		cfg:       cfg,
+	}
+}
+
+type PayCompoundRequest struct {
+	CompoundID uuid.UUID `json:"-"`
+	UserID     uuid.UUID `json:"-"`
+	WalletID   uuid.UUID `json:"wallet_id"`
+}
+
+// CompoundListResponse lists a user's fines. Issuers that could not be asked
+// are listed so an empty result is not mistaken for a clean record.
+type CompoundListResponse struct {
+	Outstanding          []*domain.Compound `json:"outstanding"`
+	Paid                 []*domain.Compound `json:"paid"`
+	TotalOutstanding     decimal.Decimal    `json:"total_outstanding"`
+	UnavailableProviders []uuid.UUID        `json:"unavailable_providers,omitempty"`
+}
+
+// SettlementRunResult summarises one run of the settlement retry job
+type SettlementRunResult struct {
+	Settled int `json:"settled"`
+	Failed  int `json:"failed"`
+}
+
+// ListCompounds looks up outstanding fines for a plate, or for all of the
+// user's registered vehicles when no plate is given, along with the fines
+// on those plates the user has already paid
+func (s *CompoundService) ListCompounds(ctx context.Context, userID uuid.UUID, plate string) (*CompoundListResponse, error) {
+	plates, err := s.platesFor(ctx, userID, plate)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &CompoundListResponse{
+		Outstanding:      []*domain.Compound{},
+		TotalOutstanding: decimal.Zero,
+	}
+	unavailable := map[uuid.UUID]bool{}
+	for _, plate := range plates {
+		lookup, err := s.provider.LookupCompounds(ctx, plate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up compounds: %w", err)
+		}
+		for _, id := range lookup.FailedProviderIDs {
+			if !unavailable[id] {
+				unavailable[id] = true
+				resp.UnavailableProviders = append(resp.UnavailableProviders, id)
+			}
+		}
+
+		for _, info := range lookup.Compounds {
+			compound, err := s.compounds.Upsert(ctx, domain.NewCompound(
+				info.ProviderID, info.Reference, plate, info.Offence, info.Location,
+				info.IssuedAt, info.DueAt, info.Amount, info.Currency,
+			))
+			if err != nil {
+				return nil, fmt.Errorf("failed to save compound: %w", err)
+			}
+			if compound.Status == domain.CompoundStatusOutstanding {
+				resp.Outstanding = append(resp.Outstanding, compound)
+				resp.TotalOutstanding = resp.TotalOutstanding.Add(compound.Amount)
+			}
+		}
+	}
+
+	resp.Paid, err = s.compounds.GetPaidByUser(ctx, userID, plates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get paid compounds: %w", err)
+	}
+	return resp, nil
+}
+
+func (s *CompoundService) platesFor(ctx context.Context, userID uuid.UUID, plate string) ([]string, error) {
+	if plate != "" {
+		normalized := domain.NormalizePlate(plate)
+		if len(normalized) < 2 || len(normalized) > 10 {
+			return nil, domain.ErrInvalidVehiclePlate
+		}
+		return []string{normalized}, nil
+	}
+
+	vehicles, err := s.vehicles.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles: %w", err)
+	}
+	if len(vehicles) == 0 {
+		return nil, domain.ErrNoPlatesToCheck
+	}
+	plates := make([]string, 0, len(vehicles))
+	for _, v := range vehicles {
+		plates = append(plates, domain.NormalizePlate(v.Plate))
+	}
+	return plates, nil
+}
+
+// PayCompound pays a fine from the user's wallet and reports it to the
+// issuer. The fine is checked with the issuer first, so one already paid
+// elsewhere is not charged again. If the issuer cannot be told straight
+// away, the fine stays paid and the settlement job retries.
+func (s *CompoundService) PayCompound(ctx context.Context, req PayCompoundRequest) (*domain.Compound, error) {
+	compound, err := s.compounds.GetByID(ctx, req.CompoundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if compound.IsPaid() {
+		if !compound.IsPaidBy(req.UserID) {
+			return nil, domain.ErrCompoundNotPayable
+		}
+		if compound.Status == domain.CompoundStatusPaid {
+			s.settle(ctx, compound)
+		}
+		return compound, nil
+	}
+
+	if compound.Status == domain.CompoundStatusOutstanding {
+		if err := s.confirmOutstanding(ctx, compound); err != nil {
+			return nil, err
+		}
+	}
+	if err := compound.Claim(req.UserID, req.WalletID); err != nil {
+		return nil, err
+	}
+	if err := s.compounds.Claim(ctx, compound); err != nil {
+		return nil, err
+	}
+
+	paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
+		WalletID:       *compound.WalletID,
+		Amount:         compound.Amount,
+		ProviderID:     compound.ProviderID,
+		ReferenceID:    compound.ID.String(),
+		Description:    fmt.Sprintf("Parking compound %s for %s", compound.Reference, compound.VehiclePlate),
+		IdempotencyKey: domain.CompoundPaymentKey(compound.ID),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("compound payment failed",
+			ports.String("compound_id", compound.ID.String()),
+			ports.Err(err),
+		)
+		compound.Unclaim()
+		if updateErr := s.compounds.Update(ctx, compound); updateErr != nil {
+			s.logger.WithContext(ctx).Error("failed to release compound claim", ports.Err(updateErr))
+		}
+		return nil, fmt.Errorf("payment failed: %w", err)
+	}
+
+	compound.MarkPaid(paymentResp.TransactionID, time.Now().UTC())
+	if err := s.compounds.Update(ctx, compound); err != nil {
+		return nil, fmt.Errorf("failed to record compound payment: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("compound paid",
+		ports.String("compound_id", compound.ID.String()),
+		ports.String("receipt_number", compound.ReceiptNumber),
+	)
+	s.settle(ctx, compound)
+	return compound, nil
+}
+
+// confirmOutstanding asks the issuer whether the fine is still unpaid and
+// picks up any change to its amount. A fine the issuer no longer reports is
+// closed.
+func (s *CompoundService) confirmOutstanding(ctx context.Context, compound *domain.Compound) error {
+	lookup, err := s.provider.LookupCompounds(ctx, compound.VehiclePlate)
+	if err != nil {
+		return fmt.Errorf("failed to look up compounds: %w", err)
+	}
+	for _, id := range lookup.FailedProviderIDs {
+		if id == compound.ProviderID {
+			return domain.ErrCompoundIssuerNoAnswer
+		}
+	}
+
+	for _, info := range lookup.Compounds {
+		if info.ProviderID == compound.ProviderID && info.Reference == compound.Reference {
+			compound.Amount = info.Amount
+			return nil
+		}
+	}
+
+	compound.Close()
+	if err := s.compounds.Update(ctx, compound); err != nil {
+		return fmt.Errorf("failed to close compound: %w", err)
+	}
+	return domain.ErrCompoundNotPayable
+}
+
+// settle reports a paid fine to its issuer and reports whether it succeeded
+func (s *CompoundService) settle(ctx context.Context, compound *domain.Compound) bool {
+	log := s.logger.WithContext(ctx)
+
+	settlement, err := s.provider.SettleCompound(ctx, ports.SettleCompoundRequest{
+		ProviderID:       compound.ProviderID,
+		Reference:        compound.Reference,
+		Amount:           compound.Amount,
+		PaymentReference: compound.PaymentID.String(),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrCompoundNotPayable) {
+			// Paid elsewhere between the check and the charge; support refunds it
+			log.Error("issuer rejected compound payment",
+				ports.String("compound_id", compound.ID.String()),
+				ports.String("payment_id", compound.PaymentID.String()),
+			)
+		} else {
+			log.Warn("failed to settle compound with issuer",
+				ports.String("compound_id", compound.ID.String()),
+				ports.Err(err),
+			)
+		}
+		return false
+	}
+
+	compound.Settle(settlement.ProviderReceipt, settlement.SettledAt)
+	if err := s.compounds.Update(ctx, compound); err != nil {
+		log.Error("failed to save compound settlement",
+			ports.String("compound_id", compound.ID.String()),
+			ports.Err(err),
+		)
+		return false
+	}
+
+	s.publish(ctx, ports.EventCompoundSettled, map[string]interface{}{
+		"compound_id":      compound.ID.String(),
+		"user_id":          compound.PaidBy.String(),
+		"provider_id":      compound.ProviderID.String(),
+		"reference":        compound.Reference,
+		"vehicle_plate":    compound.VehiclePlate,
+		"amount":           compound.Amount.String(),
+		"payment_id":       compound.PaymentID.String(),
+		"receipt_number":   compound.ReceiptNumber,
+		"provider_receipt": compound.ProviderReceipt,
+	})
+	return true
+}
+
+// GetReceipt returns the receipt for a fine the user paid
+func (s *CompoundService) GetReceipt(ctx context.Context, id, userID uuid.UUID) (*domain.CompoundReceipt, error) {
+	compound, err := s.compounds.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !compound.IsPaidBy(userID) {
+		return nil, domain.ErrCompoundAccessDenied
+	}
+	return compound.Receipt()
+}
+
+// ProcessSettlements retries telling issuers about fines that were paid but
+// not acknowledged
+func (s *CompoundService) ProcessSettlements(ctx context.Context, now time.Time) (*SettlementRunResult, error) {
+	result := &SettlementRunResult{}
+
+	unsettled, err := s.compounds.GetUnsettled(ctx, now.Add(-s.cfg.RetryDelay), s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unsettled compounds: %w", err)
+	}
+	for _, compound := range unsettled {
+		if s.settle(ctx, compound) {
+			result.Settled++
+		} else {
+			result.Failed++
+		}
+	}
+
+	if result.Settled+result.Failed > 0 {
+		s.logger.WithContext(ctx).Info("compound settlement run finished",
+			ports.Any("settled", result.Settled),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+func (s *CompoundService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
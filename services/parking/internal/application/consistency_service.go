@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// ConsistencyCheckConfig controls the scheduled session/payment consistency check
+type ConsistencyCheckConfig struct {
+	Interval time.Duration // How much time each scheduled run covers
+	Lag      time.Duration // How far behind now the window ends, so in-flight payments can settle
+	Slack    time.Duration // Padding when fetching records, so writes on either side of a window edge still match
+}
+
+// ConsistencyService detects orphans left by the session/payment dual write:
+// sessions marked paid with no wallet transaction, and wallet transactions
+// with no paid session.
+type ConsistencyService struct {
+	sessions ports.SessionRepository
+	reports  ports.ConsistencyReportRepository
+	wallet   ports.WalletClient
+	events   ports.EventPublisher
+	logger   ports.Logger
+	cfg      ConsistencyCheckConfig
+
+	mu            sync.Mutex
+	lastWindowEnd time.Time
+}
+
+func NewConsistencyService(
+	sessions ports.SessionRepository,
+	reports ports.ConsistencyReportRepository,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg ConsistencyCheckConfig,
+) *ConsistencyService {
+	return &ConsistencyService{
+		sessions: sessions,
+		reports:  reports,
+		wallet:   wallet,
+		events:   events,
+		logger:   logger,
+		cfg:      cfg,
+	}
+}
+
+type RunConsistencyCheckRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+type ConsistencyReportListResponse struct {
+	Reports []*domain.ConsistencyReport `json:"reports"`
+	Limit   int                         `json:"limit"`
+	Offset  int                         `json:"offset"`
+}
+
+// RunCheck reconciles sessions and wallet payments over [from, to), stores the
+// report and publishes an alert event for every orphan found
+func (s *ConsistencyService) RunCheck(ctx context.Context, req RunConsistencyCheckRequest) (*domain.ConsistencyReport, error) {
+	report, err := domain.NewConsistencyReport(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchFrom := report.WindowStart.Add(-s.cfg.Slack)
+	fetchTo := report.WindowEnd.Add(s.cfg.Slack)
+
+	sessions, err := s.sessions.GetEndedBetween(ctx, fetchFrom, fetchTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ended sessions: %w", err)
+	}
+
+	payments, err := s.wallet.ListPayments(ctx, fetchFrom, fetchTo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallet payments: %w", err)
+	}
+
+	report.Reconcile(sessions, payments)
+
+	if err := s.reports.Create(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save consistency report: %w", err)
+	}
+
+	if report.HasIssues() {
+		s.logger.WithContext(ctx).Warn("session/payment consistency issues found",
+			ports.String("report_id", report.ID.String()),
+			ports.String("issues", strconv.Itoa(len(report.Issues))),
+		)
+		for _, issue := range report.Issues {
+			s.publishIssue(ctx, report, issue)
+		}
+	}
+
+	return report, nil
+}
+
+// RunScheduledCheck checks the window following the previous run, ending Lag before now.
+// After a restart it resumes from the latest stored report.
+func (s *ConsistencyService) RunScheduledCheck(ctx context.Context, now time.Time) (*domain.ConsistencyReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	to := now.Add(-s.cfg.Lag).UTC()
+	from := s.lastWindowEnd
+	if from.IsZero() {
+		latest, err := s.reports.GetLatest(ctx)
+		switch {
+		case err == nil:
+			from = latest.WindowEnd
+		case errors.Is(err, domain.ErrConsistencyReportNotFound):
+			from = to.Add(-s.cfg.Interval)
+		default:
+			return nil, fmt.Errorf("failed to get latest consistency report: %w", err)
+		}
+	}
+	if !from.Before(to) {
+		return nil, nil
+	}
+
+	report, err := s.RunCheck(ctx, RunConsistencyCheckRequest{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	s.lastWindowEnd = report.WindowEnd
+	return report, nil
+}
+
+func (s *ConsistencyService) GetReport(ctx context.Context, id uuid.UUID) (*domain.ConsistencyReport, error) {
+	return s.reports.GetByID(ctx, id)
+}
+
+func (s *ConsistencyService) GetLatestReport(ctx context.Context) (*domain.ConsistencyReport, error) {
+	return s.reports.GetLatest(ctx)
+}
+
+func (s *ConsistencyService) ListReports(ctx context.Context, limit, offset int) (*ConsistencyReportListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	reports, err := s.reports.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list consistency reports: %w", err)
+	}
+
+	return &ConsistencyReportListResponse{
+		Reports: reports,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+func (s *ConsistencyService) publishIssue(ctx context.Context, report *domain.ConsistencyReport, issue domain.ConsistencyIssue) {
+	payload := map[string]interface{}{
+		"report_id":    report.ID.String(),
+		"issue_type":   string(issue.Type),
+		"reference_id": issue.ReferenceID,
+		"amount":       issue.Amount.String(),
+		"occurred_at":  issue.OccurredAt.Format(time.RFC3339),
+	}
+	if issue.SessionID != nil {
+		payload["session_id"] = issue.SessionID.String()
+	}
+	if issue.TransactionID != nil {
+		payload["transaction_id"] = issue.TransactionID.String()
+	}
+
+	if err := s.events.Publish(ctx, ports.Event{
+		Type:    ports.EventConsistencyIssueDetected,
+		Payload: payload,
+	}); err != nil {
+		s.logger.WithContext(ctx).Error("failed to publish consistency issue", ports.Err(err))
+	}
+}
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// DisputeService lets users challenge what a session charged them and lets
+// admins review and settle those challenges. A dispute resolved in the user's
+// favour refunds the session payment to their wallet.
+type DisputeService struct {
+	disputes ports.DisputeRepository
+	sessions ports.SessionRepository
+	wallet   ports.WalletClient
+	events   ports.EventPublisher
+	logger   ports.Logger
+}
+
+func NewDisputeService(
+	disputes ports.DisputeRepository,
+	sessions ports.SessionRepository,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+) *DisputeService {
+	return &DisputeService{
+		disputes: disputes,
+		sessions: sessions,
+		wallet:   wallet,
+		events:   events,
+		logger:   logger,
+	}
+}
+
+type OpenDisputeRequest struct {
+	UserID      uuid.UUID            `json:"-"`
+	SessionID   uuid.UUID            `json:"session_id"`
+	Reason      domain.DisputeReason `json:"reason"`
+	Description string               `json:"description"`
+}
+
+type ResolveDisputeRequest struct {
+	DisputeID  uuid.UUID                `json:"-"`
+	AdminID    uuid.UUID                `json:"-"`
+	Resolution domain.DisputeResolution `json:"resolution"`
+	Note       string                   `json:"note"`
+}
+
+type DisputeListResponse struct {
+	Disputes []*domain.Dispute `json:"disputes"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// OpenDispute disputes one of the user's completed, paid sessions
+func (s *DisputeService) OpenDispute(ctx context.Context, req OpenDisputeRequest) (*domain.Dispute, error) {
+	session, err := s.sessions.GetByID(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if session.UserID != req.UserID {
+		return nil, domain.ErrSessionAccessDenied
+	}
+
+	dispute, err := domain.NewDispute(session, req.Reason, req.Description, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.disputes.Create(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to save dispute: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("dispute opened",
+		ports.String("dispute_id", dispute.ID.String()),
+		ports.String("session_id", session.ID.String()),
+	)
+	s.publish(ctx, ports.EventDisputeOpened, disputePayload(dispute))
+	return dispute, nil
+}
+
+// GetUserDisputes lists the user's disputes, newest first
+func (s *DisputeService) GetUserDisputes(ctx context.Context, userID uuid.UUID, limit, offset int) (*DisputeListResponse, error) {
+	limit = clampDisputeLimit(limit)
+	disputes, err := s.disputes.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disputes: %w", err)
+	}
+	return &DisputeListResponse{Disputes: disputes, Limit: limit, Offset: offset}, nil
+}
+
+// GetUserDispute retrieves a dispute, ensuring it belongs to the user
+func (s *DisputeService) GetUserDispute(ctx context.Context, id, userID uuid.UUID) (*domain.Dispute, error) {
+	dispute, err := s.disputes.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !dispute.IsOwnedBy(userID) {
+		return nil, domain.ErrDisputeAccessDenied
+	}
+	return dispute, nil
+}
+
+// ListDisputes is the admin queue, oldest first so nothing waits forever
+func (s *DisputeService) ListDisputes(ctx context.Context, status *domain.DisputeStatus, limit, offset int) (*DisputeListResponse, error) {
+	limit = clampDisputeLimit(limit)
+	disputes, err := s.disputes.List(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list disputes: %w", err)
+	}
+	return &DisputeListResponse{Disputes: disputes, Limit: limit, Offset: offset}, nil
+}
+
+func (s *DisputeService) GetDispute(ctx context.Context, id uuid.UUID) (*domain.Dispute, error) {
+	return s.disputes.GetByID(ctx, id)
+}
+
+// StartReview moves an open dispute under review by the admin
+func (s *DisputeService) StartReview(ctx context.Context, id, adminID uuid.UUID) (*domain.Dispute, error) {
+	dispute, err := s.disputes.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := dispute.StartReview(adminID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.disputes.Update(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	s.publish(ctx, ports.EventDisputeUnderReview, disputePayload(dispute))
+	return dispute, nil
+}
+
+// Resolve settles a dispute under review. A refund is made before the
+// dispute is closed; it is keyed on the dispute, so resolving again after a
+// failed save does not refund twice.
+func (s *DisputeService) Resolve(ctx context.Context, req ResolveDisputeRequest) (*domain.Dispute, error) {
+	if !req.Resolution.IsValid() {
+		return nil, domain.ErrInvalidDisputeResolution
+	}
+	dispute, err := s.disputes.GetByID(ctx, req.DisputeID)
+	if err != nil {
+		return nil, err
+	}
+	if dispute.Status != domain.DisputeStatusUnderReview {
+		return nil, fmt.Errorf("%w: %s to %s", domain.ErrInvalidDisputeTransition, dispute.Status, domain.DisputeStatusResolved)
+	}
+	// Checked again by the dispute, but the refund must not be made first
+	if dispute.IsOwnedBy(req.AdminID) {
+		return nil, domain.ErrDisputeSelfReview
+	}
+
+	var refundID *uuid.UUID
+	if req.Resolution == domain.DisputeResolutionRefund {
+		refund, err := s.wallet.Refund(ctx, ports.RefundRequest{
+			TransactionID:  dispute.PaymentID,
+			Reason:         "Parking dispute " + dispute.ID.String(),
+			IdempotencyKey: domain.DisputeRefundKey(dispute.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to refund disputed payment: %w", err)
+		}
+		refundID = &refund.TransactionID
+	}
+
+	if err := dispute.Resolve(req.AdminID, req.Resolution, req.Note, refundID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.disputes.Update(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to update dispute: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("dispute resolved",
+		ports.String("dispute_id", dispute.ID.String()),
+		ports.String("resolution", string(req.Resolution)),
+	)
+	s.publish(ctx, ports.EventDisputeResolved, disputePayload(dispute))
+	return dispute, nil
+}
+
+func clampDisputeLimit(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+func disputePayload(dispute *domain.Dispute) map[string]interface{} {
+	payload := map[string]interface{}{
+		"dispute_id": dispute.ID.String(),
+		"session_id": dispute.SessionID.String(),
+		"user_id":    dispute.UserID.String(),
+		"reason":     string(dispute.Reason),
+		"status":     string(dispute.Status),
+		"amount":     dispute.Amount.StringFixed(2),
+		"currency":   dispute.Currency,
+	}
+	if dispute.Resolution != nil {
+		payload["resolution"] = string(*dispute.Resolution)
+		payload["resolution_note"] = dispute.ResolutionNote
+	}
+	if dispute.RefundTransactionID != nil {
+		payload["refund_transaction_id"] = dispute.RefundTransactionID.String()
+	}
+	return payload
+}
+
+func (s *DisputeService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
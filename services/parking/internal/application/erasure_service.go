@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// ErasureService removes a deleted user's vehicles and plates when auth
+// reports the account erased. Sessions and payments stay for the records,
+// tied only to the user ID.
+type ErasureService struct {
+	erasures ports.ErasureRepository
+	logger   ports.Logger
+}
+
+func NewErasureService(erasures ports.ErasureRepository, logger ports.Logger) *ErasureService {
+	return &ErasureService{erasures: erasures, logger: logger}
+}
+
+// EventTypes lists the events the service consumes
+func (s *ErasureService) EventTypes() []string {
+	return []string{"user.deleted"}
+}
+
+// Handle erases the user named by a user.deleted event. It is safe to run
+// again for the same user.
+func (s *ErasureService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	raw, _ := payload["user_id"].(string)
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("user event has no user_id", ports.String("event_type", eventType))
+		return nil
+	}
+	_, err = s.erase(ctx, userID, time.Now().UTC())
+	return err
+}
+
+// FinishOpen retries erasures that were waiting on live records, and
+// returns how many are now complete
+func (s *ErasureService) FinishOpen(ctx context.Context, now time.Time, limit int) (int, error) {
+	userIDs, err := s.erasures.ListOpen(ctx, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list open erasures: %w", err)
+	}
+
+	finished := 0
+	for _, userID := range userIDs {
+		result, err := s.erase(ctx, userID, now)
+		if err != nil {
+			s.logger.WithContext(ctx).Error("failed to finish erasure",
+				ports.String("user_id", userID.String()), ports.Err(err))
+			continue
+		}
+		if result.Live == 0 {
+			finished++
+		}
+	}
+	return finished, nil
+}
+
+func (s *ErasureService) erase(ctx context.Context, userID uuid.UUID, now time.Time) (*domain.ErasureResult, error) {
+	result, err := s.erasures.EraseUser(ctx, userID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to erase user: %w", err)
+	}
+	s.logger.WithContext(ctx).Info("erased deleted user's parking data",
+		ports.String("user_id", userID.String()),
+		ports.Any("vehicles_deleted", result.VehiclesDeleted),
+		ports.Any("anonymized", result.Anonymized),
+		ports.Any("live", result.Live),
+	)
+	return result, nil
+}
@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+)
+
+// guestSessionLimit caps the guest history a device can list. Guests park
+// occasionally; regulars are expected to register.
+const guestSessionLimit = 20
+
+type GuestStartSessionRequest struct {
+	DeviceID     string    `json:"-"`
+	ProviderID   uuid.UUID `json:"provider_id"`
+	LocationID   uuid.UUID `json:"location_id"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	VehicleType  string    `json:"vehicle_type"`
+}
+
+type GuestEndSessionRequest struct {
+	SessionID uuid.UUID `json:"-"`
+	DeviceID  string    `json:"-"`
+	// CardToken is the payment gateway's token for the guest's card
+	CardToken string `json:"card_token"`
+}
+
+// StartGuestSession starts a plate-only session for a guest. It belongs to
+// the guest's device until they register.
+func (s *ParkingService) StartGuestSession(ctx context.Context, req GuestStartSessionRequest) (*SessionResponse, error) {
+	s.logger.WithContext(ctx).Info("starting guest parking session",
+		ports.String("provider_id", req.ProviderID.String()),
+	)
+
+	session, err := domain.NewGuestSession(req.DeviceID, req.ProviderID, req.LocationID, req.VehiclePlate, req.VehicleType)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.startWithProvider(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.toSessionResponse(session), nil
+}
+
+// GetGuestSession returns a guest session to the device that started it
+func (s *ParkingService) GetGuestSession(ctx context.Context, id uuid.UUID, deviceID string) (*SessionResponse, error) {
+	session, err := s.sessions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsGuestSessionOf(deviceID) {
+		return nil, domain.ErrSessionAccessDenied
+	}
+	return s.toSessionResponse(session), nil
+}
+
+// GetGuestSessions returns a device's latest guest sessions
+func (s *ParkingService) GetGuestSessions(ctx context.Context, deviceID string) (*SessionListResponse, error) {
+	sessions, err := s.sessions.GetByGuestDeviceID(ctx, deviceID, guestSessionLimit, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest sessions: %w", err)
+	}
+
+	responses := make([]*SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = s.toSessionResponse(session)
+	}
+	return &SessionListResponse{
+		Sessions: responses,
+		Total:    len(responses),
+		Limit:    guestSessionLimit,
+	}, nil
+}
+
+// EndGuestSession ends a guest session and charges the guest's card through
+// the wallet service's payment gateway
+func (s *ParkingService) EndGuestSession(ctx context.Context, req GuestEndSessionRequest) (*EndSessionResponse, error) {
+	s.logger.WithContext(ctx).Info("ending guest parking session", ports.String("session_id", req.SessionID.String()))
+
+	if req.CardToken == "" {
+		return nil, domain.ErrCardTokenRequired
+	}
+	session, err := s.sessions.GetByID(ctx, req.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsGuestSessionOf(req.DeviceID) {
+		return nil, domain.ErrSessionAccessDenied
+	}
+
+	// The card is saved with the session before it is charged, so a retry
+	// while the charge may still go through can't charge another card
+	switch {
+	case session.IsActive():
+		if err := session.ChargeCard(req.CardToken); err != nil {
+			return nil, err
+		}
+		if err := s.endWithProvider(ctx, session); err != nil {
+			return nil, err
+		}
+	case session.CanRetryPayment():
+		// The card was declined; charge the same amount again, usually to another card
+		if err := session.ChargeCard(req.CardToken); err != nil {
+			return nil, err
+		}
+		if err := session.RetryPayment(); err != nil {
+			return nil, err
+		}
+		if err := s.sessions.Update(ctx, session); err != nil {
+			return nil, fmt.Errorf("failed to update session: %w", err)
+		}
+		s.publishTransitions(ctx, session)
+	case session.IsEnding():
+		// An earlier attempt stopped while billing. Only its card may be
+		// charged, and the idempotency key keeps it from being charged twice.
+		if err := session.ChargeCard(req.CardToken); err != nil {
+			return nil, err
+		}
+		if err := s.sessions.Update(ctx, session); err != nil {
+			return nil, fmt.Errorf("failed to update session: %w", err)
+		}
+	default:
+		return nil, domain.ErrSessionAlreadyEnded
+	}
+
+	// A season pass is bought for a plate, so it covers guests too
+	if subscription := s.coveringSubscription(ctx, session); subscription != nil {
+		return s.settleWithSubscription(ctx, session, subscription)
+	}
+
+	paymentResp, err := s.wallet.PayByCard(ctx, ports.CardPaymentRequest{
+		Amount:         session.Amount,
+		CardToken:      req.CardToken,
+		ProviderID:     session.ProviderID,
+		ReferenceID:    session.ID.String(),
+		Description:    fmt.Sprintf("Parking at location %s", session.LocationID),
+		IdempotencyKey: cardPaymentKey(session),
+		Taxes:          session.Taxes,
+	})
+	if err != nil {
+		return nil, s.failPayment(ctx, session, err)
+	}
+	return s.finishPaid(ctx, session, paymentResp)
+}
+
+// cardPaymentKey keys a session's charge to the card it records. Retrying
+// with the same card can't charge it twice, and a declined guest can still
+// pay with another card.
+func cardPaymentKey(session *domain.ParkingSession) string {
+	return domain.PaymentIdempotencyKey(session.ID) + ":card:" + session.PaymentCard
+}
+
+// ClaimGuestSessions moves a device's guest sessions to the user who just
+// registered from it
+func (s *ParkingService) ClaimGuestSessions(ctx context.Context, deviceID string, userID uuid.UUID) (int, error) {
+	claimed, err := s.sessions.ClaimGuestSessions(ctx, deviceID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim guest sessions: %w", err)
+	}
+	if claimed > 0 {
+		s.logger.WithContext(ctx).Info("guest sessions claimed",
+			ports.String("user_id", userID.String()),
+			ports.Any("sessions", claimed),
+		)
+	}
+	return claimed, nil
+}
+
+// GuestClaims moves guest history to new accounts on user.registered
+type GuestClaims struct {
+	parking *ParkingService
+	logger  ports.Logger
+}
+
+func NewGuestClaims(parking *ParkingService, logger ports.Logger) *GuestClaims {
+	return &GuestClaims{parking: parking, logger: logger}
+}
+
+// EventTypes lists the events the handler consumes
+func (c *GuestClaims) EventTypes() []string {
+	return []string{"user.registered"}
+}
+
+// Handle claims the guest sessions of the device a user registered from.
+// Users who never parked as guests have no guest_device_id.
+func (c *GuestClaims) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	deviceID, _ := payload["guest_device_id"].(string)
+	if deviceID == "" {
+		return nil
+	}
+	raw, _ := payload["user_id"].(string)
+	userID, err := uuid.Parse(raw)
+	if err != nil {
+		c.logger.WithContext(ctx).Warn("user event has no user_id", ports.String("event_type", eventType))
+		return nil
+	}
+	_, err = c.parking.ClaimGuestSessions(ctx, deviceID, userID)
+	return err
+}
+
+// userRef is the user_id in a session's events. Guests have none, so
+// notifications skip them.
+func userRef(session *domain.ParkingSession) string {
+	if session.IsGuest() {
+		return ""
+	}
+	return session.UserID.String()
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/parking/internal/domain"
@@ -12,29 +13,41 @@ import (
 
 // ParkingService handles parking session use cases
 type ParkingService struct {
-	sessions   ports.SessionRepository
-	vehicles   ports.VehicleRepository
-	provider   ports.ProviderClient
-	wallet     ports.WalletClient
-	events     ports.EventPublisher
-	logger     ports.Logger
+	sessions      ports.SessionRepository
+	vehicles      ports.VehicleRepository
+	subscriptions ports.SubscriptionRepository
+	reservations  ports.ReservationRepository
+	taxes         ports.TaxRateRepository
+	provider      ports.ProviderClient
+	wallet        ports.WalletClient
+	events        ports.EventPublisher
+	flags         ports.FeatureFlags
+	logger        ports.Logger
 }
 
 func NewParkingService(
 	sessions ports.SessionRepository,
 	vehicles ports.VehicleRepository,
+	subscriptions ports.SubscriptionRepository,
+	reservations ports.ReservationRepository,
+	taxes ports.TaxRateRepository,
 	provider ports.ProviderClient,
 	wallet ports.WalletClient,
 	events ports.EventPublisher,
+	flags ports.FeatureFlags,
 	logger ports.Logger,
 ) *ParkingService {
 	return &ParkingService{
-		sessions: sessions,
-		vehicles: vehicles,
-		provider: provider,
-		wallet:   wallet,
-		events:   events,
-		logger:   logger,
+		sessions:      sessions,
+		vehicles:      vehicles,
+		subscriptions: subscriptions,
+		reservations:  reservations,
+		taxes:         taxes,
+		provider:      provider,
+		wallet:        wallet,
+		events:        events,
+		flags:         flags,
+		logger:        logger,
 	}
 }
 
@@ -49,30 +62,47 @@ type StartSessionRequest struct {
 }
 
 type SessionResponse struct {
-	ID                uuid.UUID        `json:"id"`
-	UserID            uuid.UUID        `json:"user_id"`
-	ProviderID        uuid.UUID        `json:"provider_id"`
-	LocationID        uuid.UUID        `json:"location_id"`
-	ExternalSessionID string           `json:"external_session_id,omitempty"`
-	VehiclePlate      string           `json:"vehicle_plate"`
-	VehicleType       string           `json:"vehicle_type"`
-	EntryTime         string           `json:"entry_time"`
-	ExitTime          string           `json:"exit_time,omitempty"`
-	Duration          int              `json:"duration_minutes"`
-	Amount            decimal.Decimal  `json:"amount"`
-	Status            string           `json:"status"`
+	ID                uuid.UUID             `json:"id"`
+	UserID            uuid.UUID             `json:"user_id"`
+	ProviderID        uuid.UUID             `json:"provider_id"`
+	LocationID        uuid.UUID             `json:"location_id"`
+	ExternalSessionID string                `json:"external_session_id,omitempty"`
+	VehiclePlate      string                `json:"vehicle_plate"`
+	VehicleType       string                `json:"vehicle_type"`
+	EntryTime         string                `json:"entry_time"`
+	ExitTime          string                `json:"exit_time,omitempty"`
+	Duration          int                   `json:"duration_minutes"`
+	Amount            decimal.Decimal       `json:"amount"`
+	TaxAmount         decimal.Decimal       `json:"tax_amount"`
+	Taxes             []domain.TaxComponent `json:"taxes,omitempty"`
+	Status            string                `json:"status"`
+	PaymentStatus     string                `json:"payment_status"`
+	SubscriptionID    *uuid.UUID            `json:"subscription_id,omitempty"`
+	ReservationID     *uuid.UUID            `json:"reservation_id,omitempty"`
 }
 
 type EndSessionRequest struct {
 	SessionID uuid.UUID `json:"session_id"`
-	WalletID  uuid.UUID `json:"wallet_id"`
+	// WalletID picks which of the driver's wallets pays. When unset the
+	// driver's default wallet is charged.
+	WalletID uuid.UUID `json:"wallet_id,omitempty"`
+	// OrgID charges the session to an organization the driver belongs to
+	// instead of WalletID
+	OrgID *uuid.UUID `json:"org_id,omitempty"`
 }
 
+// PaymentStatusCovered is reported for sessions paid for by a season pass
+const PaymentStatusCovered = "covered"
+
 type EndSessionResponse struct {
-	SessionID     uuid.UUID       `json:"session_id"`
-	Duration      int             `json:"duration_minutes"`
-	Amount        decimal.Decimal `json:"amount"`
-	PaymentStatus string          `json:"payment_status"`
+	SessionID      uuid.UUID             `json:"session_id"`
+	Duration       int                   `json:"duration_minutes"`
+	Amount         decimal.Decimal       `json:"amount"`
+	TaxAmount      decimal.Decimal       `json:"tax_amount"`
+	Taxes          []domain.TaxComponent `json:"taxes,omitempty"`
+	PaymentStatus  string                `json:"payment_status"`
+	SubscriptionID *uuid.UUID            `json:"subscription_id,omitempty"`
+	ReservationID  *uuid.UUID            `json:"reservation_id,omitempty"`
 }
 
 type SessionListResponse struct {
@@ -103,7 +133,7 @@ type VehicleResponse struct {
 
 // StartSession initiates a new parking session
 func (s *ParkingService) StartSession(ctx context.Context, req StartSessionRequest) (*SessionResponse, error) {
-	s.logger.Info("starting parking session",
+	s.logger.WithContext(ctx).Info("starting parking session",
 		ports.String("user_id", req.UserID.String()),
 		ports.String("provider_id", req.ProviderID.String()),
 	)
@@ -120,25 +150,51 @@ func (s *ParkingService) StartSession(ctx context.Context, req StartSessionReque
 		return nil, err
 	}
 
+	// A vehicle arriving for its booking takes up the reserved space
+	reservation := s.arrivingReservation(ctx, session)
+	if reservation != nil {
+		session.FulfilReservation(reservation.ID)
+	}
+
+	if err := s.startWithProvider(ctx, session); err != nil {
+		return nil, err
+	}
+	if reservation != nil {
+		s.convertReservation(ctx, reservation, session)
+	}
+	return s.toSessionResponse(session), nil
+}
+
+// startWithProvider opens a new session at the provider and activates it
+func (s *ParkingService) startWithProvider(ctx context.Context, session *domain.ParkingSession) error {
+	// Saved pending first, so a session the provider opens is never unrecorded
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
 	// Call provider API to start session
 	providerResp, err := s.provider.StartSession(ctx, ports.StartSessionRequest{
-		ProviderID:   req.ProviderID,
-		LocationID:   req.LocationID,
-		VehiclePlate: req.VehiclePlate,
-		VehicleType:  req.VehicleType,
+		ProviderID:   session.ProviderID,
+		LocationID:   session.LocationID,
+		VehiclePlate: session.VehiclePlate,
+		VehicleType:  session.VehicleType,
 		UserRef:      session.ID.String(),
 	})
 	if err != nil {
-		s.logger.Error("failed to start session with provider", ports.Err(err))
-		return nil, fmt.Errorf("failed to start session with provider: %w", err)
+		s.logger.WithContext(ctx).Error("failed to start session with provider", ports.Err(err))
+		if failErr := session.FailStart(); failErr == nil {
+			s.saveTransitions(ctx, session)
+		}
+		return fmt.Errorf("failed to start session with provider: %w", err)
 	}
 
-	session.SetExternalSessionID(providerResp.ExternalSessionID)
-
-	// Persist session
-	if err := s.sessions.Create(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to save session: %w", err)
+	if err := session.Activate(providerResp.ExternalSessionID); err != nil {
+		return err
 	}
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	s.publishTransitions(ctx, session)
 
 	// Publish event
 	go func() {
@@ -146,91 +202,468 @@ func (s *ParkingService) StartSession(ctx context.Context, req StartSessionReque
 			Type: ports.EventSessionStarted,
 			Payload: map[string]interface{}{
 				"session_id":  session.ID.String(),
-				"user_id":     session.UserID.String(),
+				"user_id":     userRef(session),
 				"provider_id": session.ProviderID.String(),
 				"plate":       session.VehiclePlate,
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
-
-	return s.toSessionResponse(session), nil
+	return nil
 }
 
 // EndSession completes a parking session and processes payment
 func (s *ParkingService) EndSession(ctx context.Context, req EndSessionRequest) (*EndSessionResponse, error) {
-	s.logger.Info("ending parking session", ports.String("session_id", req.SessionID.String()))
+	s.logger.WithContext(ctx).Info("ending parking session", ports.String("session_id", req.SessionID.String()))
 
 	session, err := s.sessions.GetByID(ctx, req.SessionID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !session.IsActive() {
+	switch {
+	case session.IsActive():
+		if err := s.endWithProvider(ctx, session); err != nil {
+			return nil, err
+		}
+	case session.CanRetryPayment():
+		// The session was billed but the payment failed; charge the same amount again
+		if err := session.RetryPayment(); err != nil {
+			return nil, err
+		}
+	case session.IsEnding():
+		// An earlier attempt stopped while billing. Payments carry the
+		// session's idempotency key, so charging again cannot charge twice.
+	default:
 		return nil, domain.ErrSessionAlreadyEnded
 	}
 
-	// Get final amount from provider
-	providerResp, err := s.provider.EndSession(ctx, ports.EndSessionRequest{
-		ProviderID:        session.ProviderID,
-		ExternalSessionID: session.ExternalSessionID,
-	})
-	if err != nil {
-		s.logger.Error("failed to end session with provider", ports.Err(err))
-		return nil, fmt.Errorf("failed to end session with provider: %w", err)
+	// Vehicles with a season pass for this location are not charged
+	if subscription := s.coveringSubscription(ctx, session); subscription != nil {
+		return s.settleWithSubscription(ctx, session, subscription)
 	}
 
-	// End session with the calculated amount
-	if err := session.End(providerResp.Amount); err != nil {
+	// Booked sessions are paid from the reservation's hold
+	reservation, err := s.sessionReservation(ctx, session)
+	if err != nil {
 		return nil, err
 	}
+	if reservation != nil {
+		return s.settleWithReservation(ctx, session, reservation)
+	}
 
 	// Process payment through wallet
-	paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
-		WalletID:       req.WalletID,
+	payment := ports.PaymentRequest{
 		Amount:         session.Amount,
 		ProviderID:     session.ProviderID,
 		ReferenceID:    session.ID.String(),
 		Description:    fmt.Sprintf("Parking at location %s", session.LocationID),
-		IdempotencyKey: fmt.Sprintf("parking-%s", session.ID),
-	})
+		IdempotencyKey: domain.PaymentIdempotencyKey(session.ID),
+		Taxes:          session.Taxes,
+	}
+	if req.OrgID != nil {
+		// The organization's wallet is held under its ID. The wallet checks
+		// that the driver is a member and within their monthly limit.
+		orgWallet, err := s.wallet.GetWallet(ctx, *req.OrgID)
+		if err != nil {
+			return nil, s.failPayment(ctx, session, err)
+		}
+		payment.WalletID = orgWallet.ID
+		payment.MemberID = &session.UserID
+	} else {
+		// Only the driver's own wallets can pay for their session
+		wallet, err := s.wallet.GetUserWallet(ctx, session.UserID, req.WalletID)
+		if err != nil {
+			return nil, s.failPayment(ctx, session, err)
+		}
+		payment.WalletID = wallet.ID
+	}
+	paymentResp, err := s.wallet.Pay(ctx, payment)
 	if err != nil {
-		s.logger.Error("payment failed", ports.Err(err))
-		// Session ended but payment failed - needs handling
-		session.Status = domain.SessionStatusFailed
-		s.sessions.Update(ctx, session)
-		return nil, fmt.Errorf("payment failed: %w", err)
+		return nil, s.failPayment(ctx, session, err)
 	}
+	return s.finishPaid(ctx, session, paymentResp)
+}
 
-	session.MarkPaid(paymentResp.TransactionID)
+// finishPaid records an ended session's payment
+func (s *ParkingService) finishPaid(ctx context.Context, session *domain.ParkingSession, paymentResp *ports.PaymentResponse) (*EndSessionResponse, error) {
+	if err := session.MarkPaid(paymentResp.TransactionID); err != nil {
+		return nil, err
+	}
 
 	// Update session
 	if err := s.sessions.Update(ctx, session); err != nil {
 		return nil, fmt.Errorf("failed to update session: %w", err)
 	}
+	s.publishTransitions(ctx, session)
 
 	// Publish event
 	go func() {
 		event := ports.Event{
 			Type: ports.EventSessionEnded,
-			Payload: map[string]interface{}{
+			Payload: withSessionRefs(session, map[string]interface{}{
 				"session_id": session.ID.String(),
-				"user_id":    session.UserID.String(),
+				"user_id":    userRef(session),
 				"amount":     session.Amount.String(),
 				"duration":   session.Duration,
-			},
+			}),
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
 	return &EndSessionResponse{
 		SessionID:     session.ID,
 		Duration:      session.Duration,
 		Amount:        session.Amount,
+		TaxAmount:     session.TaxAmount,
+		Taxes:         session.Taxes,
 		PaymentStatus: paymentResp.Status,
 	}, nil
 }
 
+// endWithProvider closes an active session at the provider and bills it.
+// The session is saved as ending before any money moves.
+func (s *ParkingService) endWithProvider(ctx context.Context, session *domain.ParkingSession) error {
+	// Get final amount from provider
+	providerResp, err := s.provider.EndSession(ctx, ports.EndSessionRequest{
+		ProviderID:        session.ProviderID,
+		ExternalSessionID: session.ExternalSessionID,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to end session with provider", ports.Err(err))
+		return fmt.Errorf("failed to end session with provider: %w", err)
+	}
+
+	// End session, then bill at the pricing that was active when the vehicle entered
+	// (behind a flag until entry-time pricing is fully rolled out)
+	if err := session.End(providerResp.Amount); err != nil {
+		return err
+	}
+	if s.flags.IsEnabled(ctx, ports.FlagEntryTimePricing, session.UserID.String()) {
+		session.Amount = s.billableAmount(ctx, session, providerResp.Amount)
+	}
+	if err := s.applyTax(ctx, session); err != nil {
+		return err
+	}
+
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	s.publishTransitions(ctx, session)
+	return nil
+}
+
+// applyTax adds the location's taxes to an ending session's charge
+func (s *ParkingService) applyTax(ctx context.Context, session *domain.ParkingSession) error {
+	rates, err := s.taxes.GetByProviderID(ctx, session.ProviderID)
+	if err != nil {
+		return fmt.Errorf("failed to get tax rates: %w", err)
+	}
+	session.ApplyTax(domain.ApplicableTaxRates(rates, session.LocationID))
+	return nil
+}
+
+// saveTransitions persists a session after a status change on a path that is
+// already failing, so a save error is only logged
+func (s *ParkingService) saveTransitions(ctx context.Context, session *domain.ParkingSession) {
+	if err := s.sessions.Update(ctx, session); err != nil {
+		s.logger.WithContext(ctx).Error("failed to update session",
+			ports.String("session_id", session.ID.String()),
+			ports.Err(err),
+		)
+		return
+	}
+	s.publishTransitions(ctx, session)
+}
+
+// withSessionRefs adds the references providers need to match a session
+// event to their own records: their provider and location IDs, the
+// session ID on their side, the vehicle and its entry and exit times, and
+// the wallet payment once there is one
+func withSessionRefs(session *domain.ParkingSession, payload map[string]interface{}) map[string]interface{} {
+	payload["provider_id"] = session.ProviderID.String()
+	payload["location_id"] = session.LocationID.String()
+	payload["external_session_id"] = session.ExternalSessionID
+	payload["currency"] = session.Currency
+	payload["vehicle_plate"] = session.VehiclePlate
+	payload["entry_time"] = session.EntryTime.Format(time.RFC3339)
+	if session.ExitTime != nil {
+		payload["exit_time"] = session.ExitTime.Format(time.RFC3339)
+	}
+	if session.PaymentID != nil {
+		payload["payment_id"] = session.PaymentID.String()
+	}
+	payload["tax_amount"] = session.TaxAmount.StringFixed(2)
+	if len(session.Taxes) > 0 {
+		payload["tax_breakdown"] = domain.TaxBreakdown(session.Taxes)
+	}
+	return payload
+}
+
+// publishTransitions publishes a status_changed event for each status change
+// made to the session since it was last saved
+func (s *ParkingService) publishTransitions(ctx context.Context, session *domain.ParkingSession) {
+	transitions := session.TakeTransitions()
+	if len(transitions) == 0 {
+		return
+	}
+	userID := userRef(session)
+	go func() {
+		for _, t := range transitions {
+			s.events.Publish(context.WithoutCancel(ctx), ports.Event{
+				Type: ports.EventSessionStatusChanged,
+				Payload: map[string]interface{}{
+					"session_id":     t.SessionID.String(),
+					"user_id":        userID,
+					"from":           string(t.From),
+					"to":             string(t.To),
+					"payment_status": string(t.PaymentStatus),
+					"changed_at":     t.At.Format(time.RFC3339),
+				},
+			})
+		}
+	}()
+}
+
+// failPayment marks an ended session whose payment did not go through. Ending
+// the session again retries the payment.
+func (s *ParkingService) failPayment(ctx context.Context, session *domain.ParkingSession, err error) error {
+	s.logger.WithContext(ctx).Error("payment failed", ports.Err(err))
+	if failErr := session.FailPayment(); failErr == nil {
+		s.saveTransitions(ctx, session)
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventSessionPaymentFailed,
+			Payload: map[string]interface{}{
+				"session_id": session.ID.String(),
+				"user_id":    userRef(session),
+				"amount":     session.Amount.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return fmt.Errorf("payment failed: %w", err)
+}
+
+// coveringSubscription finds a season pass that pays for the session. A failed
+// lookup is logged and the session is charged as usual.
+func (s *ParkingService) coveringSubscription(ctx context.Context, session *domain.ParkingSession) *domain.Subscription {
+	subscriptions, err := s.subscriptions.GetByPlate(ctx, domain.NormalizePlate(session.VehiclePlate), session.EntryTime)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("failed to check subscriptions, charging session",
+			ports.String("session_id", session.ID.String()),
+			ports.Err(err),
+		)
+		return nil
+	}
+	for _, subscription := range subscriptions {
+		if subscription.Covers(session) {
+			return subscription
+		}
+	}
+	return nil
+}
+
+// settleWithSubscription ends a session without a wallet payment
+func (s *ParkingService) settleWithSubscription(ctx context.Context, session *domain.ParkingSession, subscription *domain.Subscription) (*EndSessionResponse, error) {
+	charged := session.Amount
+	if err := session.CoverBySubscription(subscription.ID); err != nil {
+		return nil, err
+	}
+	s.releaseReservation(ctx, session)
+
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	s.publishTransitions(ctx, session)
+
+	s.logger.WithContext(ctx).Info("session covered by subscription",
+		ports.String("session_id", session.ID.String()),
+		ports.String("subscription_id", subscription.ID.String()),
+	)
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventSessionEnded,
+			Payload: withSessionRefs(session, map[string]interface{}{
+				"session_id":      session.ID.String(),
+				"user_id":         userRef(session),
+				"amount":          session.Amount.String(),
+				"duration":        session.Duration,
+				"subscription_id": subscription.ID.String(),
+				"waived_amount":   charged.String(),
+			}),
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return &EndSessionResponse{
+		SessionID:      session.ID,
+		Duration:       session.Duration,
+		Amount:         session.Amount,
+		PaymentStatus:  PaymentStatusCovered,
+		SubscriptionID: session.SubscriptionID,
+		ReservationID:  session.ReservationID,
+	}, nil
+}
+
+// arrivingReservation finds the booking a vehicle entering the location takes
+// up. A failed lookup is logged and the session starts unbooked.
+func (s *ParkingService) arrivingReservation(ctx context.Context, session *domain.ParkingSession) *domain.Reservation {
+	reservations, err := s.reservations.GetConfirmedByPlate(ctx, session.LocationID, domain.NormalizePlate(session.VehiclePlate))
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("failed to check reservations, starting unbooked session",
+			ports.String("session_id", session.ID.String()),
+			ports.Err(err),
+		)
+		return nil
+	}
+	for _, reservation := range reservations {
+		if reservation.AcceptsArrival(session.EntryTime) {
+			return reservation
+		}
+	}
+	return nil
+}
+
+// convertReservation records that the booking turned into the session. The
+// session already points at the booking, so a failed save only leaves the
+// booking open until the session settles it.
+func (s *ParkingService) convertReservation(ctx context.Context, reservation *domain.Reservation, session *domain.ParkingSession) {
+	if err := reservation.Convert(session.ID); err != nil {
+		return
+	}
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		s.logger.WithContext(ctx).Error("failed to convert reservation",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+		return
+	}
+
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{
+			Type: ports.EventReservationConverted,
+			Payload: reservationPayload(reservation, map[string]interface{}{
+				"session_id": session.ID.String(),
+			}),
+		})
+	}()
+}
+
+// sessionReservation returns the booking a session was started under, if
+// it still holds money to settle
+func (s *ParkingService) sessionReservation(ctx context.Context, session *domain.ParkingSession) (*domain.Reservation, error) {
+	if session.ReservationID == nil {
+		return nil, nil
+	}
+	reservation, err := s.reservations.GetByID(ctx, *session.ReservationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+	if reservation.HoldID == nil {
+		return nil, nil
+	}
+	return reservation, nil
+}
+
+// settleWithReservation takes the session's charge from the reservation's
+// hold under the session's own payment key, so the consistency check matches
+// it like any other session payment. The rest of the hold is returned.
+func (s *ParkingService) settleWithReservation(ctx context.Context, session *domain.ParkingSession, reservation *domain.Reservation) (*EndSessionResponse, error) {
+	paymentStatus := "completed"
+	var paymentID *uuid.UUID
+	if session.Amount.IsPositive() {
+		paymentResp, err := s.wallet.CaptureHold(ctx, ports.CaptureHoldRequest{
+			HoldID:         *reservation.HoldID,
+			Amount:         session.Amount,
+			ReferenceID:    session.ID.String(),
+			Description:    fmt.Sprintf("Parking at location %s", session.LocationID),
+			IdempotencyKey: domain.PaymentIdempotencyKey(session.ID),
+			Taxes:          session.Taxes,
+		})
+		if err != nil {
+			return nil, s.failPayment(ctx, session, err)
+		}
+		if err := session.MarkPaid(paymentResp.TransactionID); err != nil {
+			return nil, err
+		}
+		paymentID = &paymentResp.TransactionID
+		paymentStatus = paymentResp.Status
+	} else if err := s.wallet.ReleaseHold(ctx, *reservation.HoldID); err != nil {
+		return nil, s.failPayment(ctx, session, err)
+	} else if err := session.Waive(); err != nil {
+		return nil, err
+	}
+
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to update session: %w", err)
+	}
+	s.publishTransitions(ctx, session)
+	reservation.Complete(paymentID)
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		s.logger.WithContext(ctx).Error("failed to complete reservation",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventSessionEnded,
+			Payload: withSessionRefs(session, map[string]interface{}{
+				"session_id":     session.ID.String(),
+				"user_id":        userRef(session),
+				"amount":         session.Amount.String(),
+				"duration":       session.Duration,
+				"reservation_id": reservation.ID.String(),
+			}),
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return &EndSessionResponse{
+		SessionID:     session.ID,
+		Duration:      session.Duration,
+		Amount:        session.Amount,
+		TaxAmount:     session.TaxAmount,
+		Taxes:         session.Taxes,
+		PaymentStatus: paymentStatus,
+		ReservationID: session.ReservationID,
+	}, nil
+}
+
+// releaseReservation returns the whole hold of a booked session that is not
+// charged, because a season pass covered it or it was cancelled. A failure
+// is logged; the hold stays in place for support to release.
+func (s *ParkingService) releaseReservation(ctx context.Context, session *domain.ParkingSession) {
+	reservation, err := s.sessionReservation(ctx, session)
+	if err != nil {
+		s.logger.WithContext(ctx).Error("failed to release reservation hold", ports.Err(err))
+		return
+	}
+	if reservation == nil {
+		return
+	}
+	if err := s.wallet.ReleaseHold(ctx, *reservation.HoldID); err != nil {
+		s.logger.WithContext(ctx).Error("failed to release reservation hold",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+		return
+	}
+	reservation.Complete(nil)
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		s.logger.WithContext(ctx).Error("failed to complete reservation",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+	}
+}
+
 // GetSession retrieves a parking session by ID
 func (s *ParkingService) GetSession(ctx context.Context, id uuid.UUID) (*SessionResponse, error) {
 	session, err := s.sessions.GetByID(ctx, id)
@@ -240,6 +673,18 @@ func (s *ParkingService) GetSession(ctx context.Context, id uuid.UUID) (*Session
 	return s.toSessionResponse(session), nil
 }
 
+// GetUserSession retrieves a parking session, ensuring it belongs to the user
+func (s *ParkingService) GetUserSession(ctx context.Context, id, userID uuid.UUID) (*SessionResponse, error) {
+	session, err := s.sessions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOwnedBy(userID) {
+		return nil, domain.ErrSessionAccessDenied
+	}
+	return s.toSessionResponse(session), nil
+}
+
 // GetUserSessions retrieves parking sessions for a user
 func (s *ParkingService) GetUserSessions(ctx context.Context, userID uuid.UUID, limit, offset int) (*SessionListResponse, error) {
 	if limit <= 0 {
@@ -287,6 +732,79 @@ func (s *ParkingService) GetActiveSessions(ctx context.Context, userID uuid.UUID
 	return responses, nil
 }
 
+// SessionMonitorConfig controls the active session update job
+type SessionMonitorConfig struct {
+	Interval    time.Duration // How often the job runs
+	MaxDuration time.Duration // Sessions are auto-ended after this long
+	WarnBefore  time.Duration // How long before MaxDuration to warn the user
+	BatchSize   int
+}
+
+// PublishActiveSessionUpdates publishes the running amount for each active session
+// and warns users whose sessions are about to reach the maximum duration
+func (s *ParkingService) PublishActiveSessionUpdates(ctx context.Context, cfg SessionMonitorConfig) error {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 500
+	}
+
+	sessions, err := s.sessions.GetActive(ctx, cfg.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to get active sessions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	for _, session := range sessions {
+		status, err := s.provider.GetSessionStatus(ctx, session.ProviderID, session.ExternalSessionID)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("failed to get session status from provider",
+				ports.String("session_id", session.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+
+		s.events.Publish(ctx, ports.Event{
+			Type: ports.EventSessionAmountUpdated,
+			Payload: map[string]interface{}{
+				"session_id": session.ID.String(),
+				"user_id":    userRef(session),
+				"amount":     status.Amount.String(),
+				"duration":   status.Duration,
+			},
+		})
+
+		// Warn once: only on the run where the session first enters the warning window
+		if session.EndsWithin(cfg.MaxDuration, cfg.WarnBefore, now) &&
+			!session.EndsWithin(cfg.MaxDuration, cfg.WarnBefore-cfg.Interval, now) {
+			s.events.Publish(ctx, ports.Event{
+				Type: ports.EventSessionEndingSoon,
+				Payload: map[string]interface{}{
+					"session_id": session.ID.String(),
+					"user_id":    userRef(session),
+					"ends_at":    session.EntryTime.Add(cfg.MaxDuration).Format(time.RFC3339),
+				},
+			})
+		}
+	}
+
+	return nil
+}
+
+// billableAmount prices an ended session using the rate and schedule in effect at its
+// entry time, so pricing changes made while the vehicle is parked do not apply to it.
+// Falls back to the provider-reported amount when pricing is unavailable.
+func (s *ParkingService) billableAmount(ctx context.Context, session *domain.ParkingSession, fallback decimal.Decimal) decimal.Decimal {
+	pricing, err := s.provider.GetPricing(ctx, session.LocationID, session.EntryTime)
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("failed to get entry-time pricing, using provider amount",
+			ports.String("session_id", session.ID.String()),
+			ports.Err(err),
+		)
+		return fallback
+	}
+	return session.CalculateCharge(pricing.Tariff())
+}
+
 // CancelSession cancels an active session
 func (s *ParkingService) CancelSession(ctx context.Context, sessionID uuid.UUID) error {
 	session, err := s.sessions.GetByID(ctx, sessionID)
@@ -301,16 +819,18 @@ func (s *ParkingService) CancelSession(ctx context.Context, sessionID uuid.UUID)
 	if err := s.sessions.Update(ctx, session); err != nil {
 		return fmt.Errorf("failed to update session: %w", err)
 	}
+	s.publishTransitions(ctx, session)
+	s.releaseReservation(ctx, session)
 
 	go func() {
 		event := ports.Event{
 			Type: ports.EventSessionCancelled,
-			Payload: map[string]interface{}{
+			Payload: withSessionRefs(session, map[string]interface{}{
 				"session_id": session.ID.String(),
-				"user_id":    session.UserID.String(),
-			},
+				"user_id":    userRef(session),
+			}),
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
 	return nil
@@ -355,7 +875,12 @@ func (s *ParkingService) toSessionResponse(session *domain.ParkingSession) *Sess
 		EntryTime:         session.EntryTime.Format("2006-01-02T15:04:05Z"),
 		Duration:          session.CalculateDuration(),
 		Amount:            session.Amount,
+		TaxAmount:         session.TaxAmount,
+		Taxes:             session.Taxes,
 		Status:            string(session.Status),
+		PaymentStatus:     string(session.PaymentStatus),
+		SubscriptionID:    session.SubscriptionID,
+		ReservationID:     session.ReservationID,
 	}
 	if session.ExitTime != nil {
 		resp.ExitTime = session.ExitTime.Format("2006-01-02T15:04:05Z")
@@ -0,0 +1,338 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// ReservationConfig controls booking limits and the no-show job
+type ReservationConfig struct {
+	EarlyArrival     time.Duration // A vehicle may arrive this long before its booking starts
+	NoShowGrace      time.Duration // Bookings not arrived for this long after they start are closed
+	NoShowFeePercent int           // Share of the hold kept for no-shows and late cancellations
+	MaxDuration      time.Duration // Longest window that can be booked
+	MaxAdvance       time.Duration // How far ahead a booking may start
+	BatchSize        int
+}
+
+// ReservationService books spaces ahead of arrival. Bookings turn into
+// sessions in ParkingService.StartSession, which also settles their holds.
+type ReservationService struct {
+	reservations ports.ReservationRepository
+	provider     ports.ProviderClient
+	wallet       ports.WalletClient
+	events       ports.EventPublisher
+	logger       ports.Logger
+	cfg          ReservationConfig
+}
+
+func NewReservationService(
+	reservations ports.ReservationRepository,
+	provider ports.ProviderClient,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg ReservationConfig,
+) *ReservationService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 200
+	}
+	return &ReservationService{
+		reservations: reservations,
+		provider:     provider,
+		wallet:       wallet,
+		events:       events,
+		logger:       logger,
+		cfg:          cfg,
+	}
+}
+
+type CreateReservationRequest struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ProviderID   uuid.UUID `json:"provider_id"`
+	LocationID   uuid.UUID `json:"location_id"`
+	WalletID     uuid.UUID `json:"wallet_id"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+}
+
+type ReservationListResponse struct {
+	Reservations []*domain.Reservation `json:"reservations"`
+	Limit        int                   `json:"limit"`
+	Offset       int                   `json:"offset"`
+}
+
+// NoShowRunResult summarises one run of the no-show job
+type NoShowRunResult struct {
+	Closed int `json:"closed"`
+	Failed int `json:"failed"`
+}
+
+// Reserve claims a space for the window and holds the estimated charge in
+// the user's wallet. The space is given back if the hold fails.
+func (s *ReservationService) Reserve(ctx context.Context, req CreateReservationRequest) (*domain.Reservation, error) {
+	now := time.Now().UTC()
+	if req.EndsAt.Sub(req.StartsAt) > s.cfg.MaxDuration || req.StartsAt.Sub(now) > s.cfg.MaxAdvance {
+		return nil, domain.ErrReservationOutOfRange
+	}
+
+	reservation, err := domain.NewReservation(
+		req.UserID, req.ProviderID, req.LocationID, req.WalletID,
+		req.VehiclePlate, req.StartsAt, req.EndsAt,
+		s.cfg.EarlyArrival, s.cfg.NoShowGrace,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.provider.GetLocation(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if !location.Active || location.TotalSpaces <= 0 {
+		return nil, domain.ErrLocationUnavailable
+	}
+
+	pricing, err := s.provider.GetPricing(ctx, req.LocationID, reservation.StartsAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location pricing: %w", err)
+	}
+	amount := reservation.EstimateCharge(pricing.Tariff())
+	if pricing.Currency != "" {
+		reservation.Currency = pricing.Currency
+	}
+
+	if err := s.reservations.Reserve(ctx, reservation, location.TotalSpaces); err != nil {
+		return nil, err
+	}
+
+	var holdID *uuid.UUID
+	if amount.IsPositive() {
+		hold, err := s.wallet.HoldFunds(ctx, ports.HoldRequest{
+			WalletID:       reservation.WalletID,
+			Amount:         amount,
+			ProviderID:     reservation.ProviderID,
+			ReferenceID:    reservation.ID.String(),
+			Description:    fmt.Sprintf("Reservation at %s for %s", location.Name, reservation.VehiclePlate),
+			IdempotencyKey: domain.ReservationHoldKey(reservation.ID),
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("reservation hold failed", ports.Err(err))
+			reservation.Fail()
+			if updateErr := s.reservations.Update(ctx, reservation); updateErr != nil {
+				s.logger.WithContext(ctx).Error("failed to mark reservation failed", ports.Err(updateErr))
+			}
+			return nil, fmt.Errorf("failed to hold funds: %w", err)
+		}
+		holdID = &hold.HoldID
+	}
+
+	reservation.Confirm(holdID, amount)
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		return nil, fmt.Errorf("failed to confirm reservation: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("reservation confirmed",
+		ports.String("reservation_id", reservation.ID.String()),
+		ports.String("location_id", reservation.LocationID.String()),
+	)
+	s.publish(ctx, ports.EventReservationConfirmed, reservationPayload(reservation, nil))
+	return reservation, nil
+}
+
+// GetAvailability reports how many spaces at a location are left to book
+// for a window
+func (s *ReservationService) GetAvailability(ctx context.Context, locationID uuid.UUID, from, to time.Time) (*domain.LocationAvailability, error) {
+	if !to.After(from) {
+		return nil, domain.ErrInvalidReservationWindow
+	}
+
+	location, err := s.provider.GetLocation(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	reserved, err := s.reservations.CountOverlapping(ctx, locationID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count reservations: %w", err)
+	}
+
+	total := location.TotalSpaces
+	if !location.Active {
+		total = 0
+	}
+	return domain.NewLocationAvailability(locationID, from, to, total, reserved), nil
+}
+
+// GetUserReservations lists the user's bookings, latest start first
+func (s *ReservationService) GetUserReservations(ctx context.Context, userID uuid.UUID, limit, offset int) (*ReservationListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	reservations, err := s.reservations.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservations: %w", err)
+	}
+	return &ReservationListResponse{Reservations: reservations, Limit: limit, Offset: offset}, nil
+}
+
+// GetUserReservation retrieves a booking, ensuring it belongs to the user
+func (s *ReservationService) GetUserReservation(ctx context.Context, id, userID uuid.UUID) (*domain.Reservation, error) {
+	reservation, err := s.reservations.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !reservation.IsOwnedBy(userID) {
+		return nil, domain.ErrReservationAccessDenied
+	}
+	return reservation, nil
+}
+
+// Cancel gives up the user's booking. Cancelling before it starts returns
+// the whole hold; after that the no-show fee is kept.
+func (s *ReservationService) Cancel(ctx context.Context, id, userID uuid.UUID) (*domain.Reservation, error) {
+	reservation, err := s.GetUserReservation(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !reservation.IsConfirmed() {
+		return nil, domain.ErrReservationClosed
+	}
+
+	fee := reservation.CancellationFee(time.Now().UTC(), s.feePercent())
+	paymentID, err := s.settleHold(ctx, reservation, fee)
+	if err != nil {
+		return nil, err
+	}
+	if err := reservation.Cancel(fee, paymentID); err != nil {
+		return nil, err
+	}
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		return nil, fmt.Errorf("failed to cancel reservation: %w", err)
+	}
+
+	s.publish(ctx, ports.EventReservationCancelled, reservationPayload(reservation, nil))
+	return reservation, nil
+}
+
+// ProcessNoShows closes bookings the vehicle did not arrive for in time. The
+// no-show fee is taken from each hold and the rest goes back to the wallet.
+func (s *ReservationService) ProcessNoShows(ctx context.Context, now time.Time) (*NoShowRunResult, error) {
+	result := &NoShowRunResult{}
+
+	due, err := s.reservations.GetNoShows(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get no-show reservations: %w", err)
+	}
+	for _, reservation := range due {
+		if s.closeNoShow(ctx, reservation, now) {
+			result.Closed++
+		} else {
+			result.Failed++
+		}
+	}
+
+	if result.Closed > 0 || result.Failed > 0 {
+		s.logger.WithContext(ctx).Info("no-show run finished",
+			ports.Any("closed", result.Closed),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+func (s *ReservationService) closeNoShow(ctx context.Context, reservation *domain.Reservation, now time.Time) bool {
+	log := s.logger.WithContext(ctx)
+
+	fee := reservation.CancellationFee(now, s.feePercent())
+	paymentID, err := s.settleHold(ctx, reservation, fee)
+	if err != nil {
+		// Left confirmed so the next run tries again with the same fee key
+		log.Warn("failed to settle no-show hold",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+		return false
+	}
+	if err := reservation.MarkNoShow(fee, paymentID); err != nil {
+		return false
+	}
+	if err := s.reservations.Update(ctx, reservation); err != nil {
+		log.Error("failed to save no-show reservation",
+			ports.String("reservation_id", reservation.ID.String()),
+			ports.Err(err),
+		)
+		return false
+	}
+
+	s.publish(ctx, ports.EventReservationNoShow, reservationPayload(reservation, nil))
+	return true
+}
+
+// settleHold keeps fee of the reservation's hold and returns the rest. The
+// fee has a fixed idempotency key, so a retry after a failed save is not
+// charged twice.
+func (s *ReservationService) settleHold(ctx context.Context, reservation *domain.Reservation, fee decimal.Decimal) (*uuid.UUID, error) {
+	if reservation.HoldID == nil {
+		return nil, nil
+	}
+	if !fee.IsPositive() {
+		if err := s.wallet.ReleaseHold(ctx, *reservation.HoldID); err != nil {
+			return nil, fmt.Errorf("failed to release hold: %w", err)
+		}
+		return nil, nil
+	}
+
+	paymentResp, err := s.wallet.CaptureHold(ctx, ports.CaptureHoldRequest{
+		HoldID:         *reservation.HoldID,
+		Amount:         fee,
+		ReferenceID:    reservation.ID.String(),
+		Description:    fmt.Sprintf("Unused reservation for %s", reservation.VehiclePlate),
+		IdempotencyKey: domain.ReservationFeeKey(reservation.ID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to charge reservation fee: %w", err)
+	}
+	return &paymentResp.TransactionID, nil
+}
+
+func (s *ReservationService) feePercent() decimal.Decimal {
+	return decimal.NewFromInt(int64(s.cfg.NoShowFeePercent))
+}
+
+func reservationPayload(reservation *domain.Reservation, extra map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"reservation_id": reservation.ID.String(),
+		"user_id":        reservation.UserID.String(),
+		"location_id":    reservation.LocationID.String(),
+		"vehicle_plate":  reservation.VehiclePlate,
+		"starts_at":      reservation.StartsAt.Format(time.RFC3339),
+		"ends_at":        reservation.EndsAt.Format(time.RFC3339),
+		"amount":         reservation.Amount.String(),
+		"status":         string(reservation.Status),
+	}
+	if !reservation.FeeAmount.IsZero() {
+		payload["fee_amount"] = reservation.FeeAmount.String()
+		payload["refunded_amount"] = reservation.Refund().String()
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	return payload
+}
+
+func (s *ReservationService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
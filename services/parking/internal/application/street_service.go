@@ -0,0 +1,352 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// StreetConfig controls the street session expiry job
+type StreetConfig struct {
+	ReminderLead time.Duration // Users are reminded this long before their paid time runs out
+	BatchSize    int
+}
+
+// StreetParkingService sells prepaid time in council street zones. Unlike
+// gated sessions there is no entry or exit: the user pays for a duration
+// upfront and buys more before it runs out.
+type StreetParkingService struct {
+	zones    ports.StreetZoneRepository
+	sessions ports.StreetSessionRepository
+	wallet   ports.WalletClient
+	events   ports.EventPublisher
+	logger   ports.Logger
+	cfg      StreetConfig
+}
+
+func NewStreetParkingService(
+	zones ports.StreetZoneRepository,
+	sessions ports.StreetSessionRepository,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg StreetConfig,
+) *StreetParkingService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 200
+	}
+	return &StreetParkingService{
+		zones:    zones,
+		sessions: sessions,
+		wallet:   wallet,
+		events:   events,
+		logger:   logger,
+		cfg:      cfg,
+	}
+}
+
+type CreateZoneRequest struct {
+	ProviderID      uuid.UUID       `json:"provider_id"`
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	RatePerHalfHour decimal.Decimal `json:"rate_per_half_hour"`
+	MaxMinutes      int             `json:"max_minutes"`
+}
+
+type StartStreetSessionRequest struct {
+	UserID       uuid.UUID `json:"user_id"`
+	ZoneID       uuid.UUID `json:"zone_id"`
+	WalletID     uuid.UUID `json:"wallet_id"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	Minutes      int       `json:"minutes"`
+}
+
+type ExtendStreetSessionRequest struct {
+	SessionID uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"-"`
+	Minutes   int       `json:"minutes"`
+}
+
+type StreetSessionListResponse struct {
+	Sessions []*domain.StreetSession `json:"sessions"`
+	Limit    int                     `json:"limit"`
+	Offset   int                     `json:"offset"`
+}
+
+// StreetExpiryRunResult summarises one run of the street session expiry job
+type StreetExpiryRunResult struct {
+	Reminded int `json:"reminded"`
+	Expired  int `json:"expired"`
+}
+
+func (s *StreetParkingService) CreateZone(ctx context.Context, req CreateZoneRequest) (*domain.StreetZone, error) {
+	zone, err := domain.NewStreetZone(req.ProviderID, req.Code, req.Name, req.RatePerHalfHour, req.MaxMinutes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.zones.Create(ctx, zone); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("street zone created",
+		ports.String("zone_id", zone.ID.String()),
+		ports.String("code", zone.Code),
+	)
+	return zone, nil
+}
+
+// ListZones returns the zones taking payments, for one council or all of them
+func (s *StreetParkingService) ListZones(ctx context.Context, providerID *uuid.UUID) ([]*domain.StreetZone, error) {
+	zones, err := s.zones.List(ctx, providerID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zones: %w", err)
+	}
+	return zones, nil
+}
+
+// DeactivateZone stops a zone taking payments. Sessions already paid for run
+// until they expire but cannot be extended.
+func (s *StreetParkingService) DeactivateZone(ctx context.Context, id uuid.UUID) (*domain.StreetZone, error) {
+	zone, err := s.zones.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !zone.Active {
+		return zone, nil
+	}
+
+	zone.Deactivate()
+	if err := s.zones.Update(ctx, zone); err != nil {
+		return nil, fmt.Errorf("failed to update zone: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("street zone deactivated", ports.String("zone_id", id.String()))
+	return zone, nil
+}
+
+// StartSession pays for the chosen duration in a zone. The session is stored
+// as pending before the wallet is charged and activated once the payment
+// succeeds, the same way season passes are bought.
+func (s *StreetParkingService) StartSession(ctx context.Context, req StartStreetSessionRequest) (*domain.StreetSession, error) {
+	zone, err := s.zones.GetByID(ctx, req.ZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := domain.NewStreetSession(zone, req.UserID, req.WalletID, req.VehiclePlate, req.Minutes)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.closeLapsed(ctx, zone.ID, session.VehiclePlate); err != nil {
+		return nil, err
+	}
+	if err := s.sessions.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
+		WalletID:       session.WalletID,
+		Amount:         session.Amount,
+		ProviderID:     session.ProviderID,
+		ReferenceID:    session.ID.String(),
+		Description:    fmt.Sprintf("Street parking %s for %s", zone.Code, session.VehiclePlate),
+		IdempotencyKey: domain.StreetPaymentKey(session.ID, 0),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("street session payment failed",
+			ports.String("street_session_id", session.ID.String()),
+			ports.Err(err),
+		)
+		session.Fail()
+		if updateErr := s.sessions.Update(ctx, session); updateErr != nil {
+			s.logger.WithContext(ctx).Error("failed to mark street session failed", ports.Err(updateErr))
+		}
+		return nil, fmt.Errorf("payment failed: %w", err)
+	}
+
+	session.Activate(paymentResp.TransactionID)
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to activate street session: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("street session started",
+		ports.String("street_session_id", session.ID.String()),
+		ports.String("zone", zone.Code),
+	)
+	s.publish(ctx, ports.EventStreetSessionStarted, streetSessionPayload(session, zone, map[string]interface{}{
+		"amount": session.Amount.String(),
+	}))
+	return session, nil
+}
+
+// closeLapsed expires the vehicle's previous session in the zone if its time
+// ran out before the expiry job got to it, so a new one can be started. A
+// session that is still running has to be extended instead.
+func (s *StreetParkingService) closeLapsed(ctx context.Context, zoneID uuid.UUID, plate string) error {
+	live, err := s.sessions.GetLive(ctx, zoneID, plate)
+	if errors.Is(err, domain.ErrStreetSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check existing street session: %w", err)
+	}
+	if live.Status != domain.StreetSessionStatusActive || live.IsActiveAt(time.Now().UTC()) {
+		return domain.ErrStreetSessionExists
+	}
+
+	live.Expire()
+	if err := s.sessions.Update(ctx, live); err != nil {
+		return fmt.Errorf("failed to expire street session: %w", err)
+	}
+	return nil
+}
+
+// ExtendSession buys more time on a running session at the zone's current
+// rate. The payment key is fixed per extension, so retrying a request whose
+// save failed does not charge twice.
+func (s *StreetParkingService) ExtendSession(ctx context.Context, req ExtendStreetSessionRequest) (*domain.StreetSession, error) {
+	session, err := s.GetUserSession(ctx, req.SessionID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	zone, err := s.zones.GetByID(ctx, session.ZoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := session.ExtensionPrice(zone, req.Minutes, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
+		WalletID:       session.WalletID,
+		Amount:         amount,
+		ProviderID:     session.ProviderID,
+		ReferenceID:    session.ID.String(),
+		Description:    fmt.Sprintf("Street parking %s extension for %s", zone.Code, session.VehiclePlate),
+		IdempotencyKey: session.NextPaymentKey(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("payment failed: %w", err)
+	}
+
+	session.Extend(req.Minutes, amount, paymentResp.TransactionID)
+	if err := s.sessions.Update(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to extend street session: %w", err)
+	}
+
+	s.publish(ctx, ports.EventStreetSessionExtended, streetSessionPayload(session, zone, map[string]interface{}{
+		"amount":        amount.String(),
+		"added_minutes": req.Minutes,
+	}))
+	return session, nil
+}
+
+// GetUserSession retrieves a street session, ensuring it belongs to the user
+func (s *StreetParkingService) GetUserSession(ctx context.Context, id, userID uuid.UUID) (*domain.StreetSession, error) {
+	session, err := s.sessions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !session.IsOwnedBy(userID) {
+		return nil, domain.ErrStreetSessionAccessDenied
+	}
+	return session, nil
+}
+
+// GetUserSessions lists the user's street sessions, newest first
+func (s *StreetParkingService) GetUserSessions(ctx context.Context, userID uuid.UUID, limit, offset int) (*StreetSessionListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	sessions, err := s.sessions.GetByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get street sessions: %w", err)
+	}
+	return &StreetSessionListResponse{Sessions: sessions, Limit: limit, Offset: offset}, nil
+}
+
+// ProcessExpiries reminds users whose paid time is about to run out and
+// expires sessions that have run out
+func (s *StreetParkingService) ProcessExpiries(ctx context.Context, now time.Time) (*StreetExpiryRunResult, error) {
+	result := &StreetExpiryRunResult{}
+
+	expiring, err := s.sessions.GetExpiringUnreminded(ctx, now.Add(s.cfg.ReminderLead), s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring street sessions: %w", err)
+	}
+	for _, session := range expiring {
+		session.MarkReminded(now)
+		if err := s.sessions.Update(ctx, session); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to mark street session reminded",
+				ports.String("street_session_id", session.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		result.Reminded++
+		s.publish(ctx, ports.EventStreetSessionExpiringSoon, streetSessionPayload(session, nil, map[string]interface{}{
+			"minutes_left": int(session.ExpiresAt.Sub(now).Minutes()),
+		}))
+	}
+
+	lapsed, err := s.sessions.GetLapsed(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lapsed street sessions: %w", err)
+	}
+	for _, session := range lapsed {
+		session.Expire()
+		if err := s.sessions.Update(ctx, session); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to expire street session",
+				ports.String("street_session_id", session.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		result.Expired++
+		s.publish(ctx, ports.EventStreetSessionExpired, streetSessionPayload(session, nil, nil))
+	}
+
+	if result.Reminded+result.Expired > 0 {
+		s.logger.WithContext(ctx).Info("street expiry run finished",
+			ports.Any("reminded", result.Reminded),
+			ports.Any("expired", result.Expired),
+		)
+	}
+	return result, nil
+}
+
+func streetSessionPayload(session *domain.StreetSession, zone *domain.StreetZone, extra map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"street_session_id": session.ID.String(),
+		"user_id":           session.UserID.String(),
+		"zone_id":           session.ZoneID.String(),
+		"vehicle_plate":     session.VehiclePlate,
+		"expires_at":        session.ExpiresAt.Format(time.RFC3339),
+		"paid_minutes":      session.PaidMinutes,
+	}
+	if zone != nil {
+		payload["zone_code"] = zone.Code
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	return payload
+}
+
+func (s *StreetParkingService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
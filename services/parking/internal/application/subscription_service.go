@@ -0,0 +1,352 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// SubscriptionConfig controls the season pass renewal and expiry job
+type SubscriptionConfig struct {
+	RenewalLead   time.Duration // Auto-renewing passes are charged this long before they expire
+	ExpiryWarning time.Duration // Holders are warned this long before their pass expires
+	BatchSize     int
+}
+
+// SubscriptionService sells season passes and keeps them renewed. Sessions
+// covered by a pass are settled in ParkingService.EndSession.
+type SubscriptionService struct {
+	plans         ports.SubscriptionPlanRepository
+	subscriptions ports.SubscriptionRepository
+	wallet        ports.WalletClient
+	events        ports.EventPublisher
+	logger        ports.Logger
+	cfg           SubscriptionConfig
+}
+
+func NewSubscriptionService(
+	plans ports.SubscriptionPlanRepository,
+	subscriptions ports.SubscriptionRepository,
+	wallet ports.WalletClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg SubscriptionConfig,
+) *SubscriptionService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 200
+	}
+	return &SubscriptionService{
+		plans:         plans,
+		subscriptions: subscriptions,
+		wallet:        wallet,
+		events:        events,
+		logger:        logger,
+		cfg:           cfg,
+	}
+}
+
+type CreatePlanRequest struct {
+	ProviderID   uuid.UUID       `json:"provider_id"`
+	LocationID   *uuid.UUID      `json:"location_id,omitempty"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description"`
+	Price        decimal.Decimal `json:"price"`
+	DurationDays int             `json:"duration_days"`
+}
+
+type PurchaseSubscriptionRequest struct {
+	UserID       uuid.UUID `json:"user_id"`
+	PlanID       uuid.UUID `json:"plan_id"`
+	WalletID     uuid.UUID `json:"wallet_id"`
+	VehiclePlate string    `json:"vehicle_plate"`
+	AutoRenew    bool      `json:"auto_renew"`
+}
+
+// RenewalRunResult summarises one run of the renewal job
+type RenewalRunResult struct {
+	Renewed        int `json:"renewed"`
+	RenewalsFailed int `json:"renewals_failed"`
+	Warned         int `json:"warned"`
+	Expired        int `json:"expired"`
+}
+
+func (s *SubscriptionService) CreatePlan(ctx context.Context, req CreatePlanRequest) (*domain.SubscriptionPlan, error) {
+	plan, err := domain.NewSubscriptionPlan(req.ProviderID, req.LocationID, req.Name, req.Price, req.DurationDays)
+	if err != nil {
+		return nil, err
+	}
+	plan.Description = req.Description
+
+	if err := s.plans.Create(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to create plan: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("subscription plan created",
+		ports.String("plan_id", plan.ID.String()),
+		ports.String("provider_id", plan.ProviderID.String()),
+	)
+	return plan, nil
+}
+
+// ListPlans returns the plans on sale at a location, including the provider's
+// plans that cover all of its locations
+func (s *SubscriptionService) ListPlans(ctx context.Context, providerID uuid.UUID, locationID *uuid.UUID) ([]*domain.SubscriptionPlan, error) {
+	plans, err := s.plans.List(ctx, providerID, locationID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list plans: %w", err)
+	}
+	return plans, nil
+}
+
+// DeactivatePlan stops selling a plan. Passes already sold stay valid until
+// they expire but are no longer renewed.
+func (s *SubscriptionService) DeactivatePlan(ctx context.Context, id uuid.UUID) (*domain.SubscriptionPlan, error) {
+	plan, err := s.plans.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !plan.Active {
+		return plan, nil
+	}
+
+	plan.Deactivate()
+	if err := s.plans.Update(ctx, plan); err != nil {
+		return nil, fmt.Errorf("failed to update plan: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("subscription plan deactivated", ports.String("plan_id", id.String()))
+	return plan, nil
+}
+
+// Purchase buys a pass from the user's wallet. The pass is stored as pending
+// before the wallet is charged, so a payment always has a pass to point at;
+// it is activated once the payment succeeds and marked failed otherwise.
+func (s *SubscriptionService) Purchase(ctx context.Context, req PurchaseSubscriptionRequest) (*domain.Subscription, error) {
+	plan, err := s.plans.GetByID(ctx, req.PlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := domain.NewSubscription(plan, req.UserID, req.WalletID, req.VehiclePlate, req.AutoRenew)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.subscriptions.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
+		WalletID:       subscription.WalletID,
+		Amount:         plan.Price,
+		ProviderID:     plan.ProviderID,
+		ReferenceID:    subscription.ID.String(),
+		Description:    fmt.Sprintf("Season pass %s for %s", plan.Name, subscription.VehiclePlate),
+		IdempotencyKey: domain.SubscriptionPaymentKey(subscription.ID, 0),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("subscription payment failed",
+			ports.String("subscription_id", subscription.ID.String()),
+			ports.Err(err),
+		)
+		subscription.Fail()
+		if updateErr := s.subscriptions.Update(ctx, subscription); updateErr != nil {
+			s.logger.WithContext(ctx).Error("failed to mark subscription failed", ports.Err(updateErr))
+		}
+		return nil, fmt.Errorf("payment failed: %w", err)
+	}
+
+	subscription.Activate(paymentResp.TransactionID)
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to activate subscription: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("subscription purchased",
+		ports.String("subscription_id", subscription.ID.String()),
+		ports.String("plan_id", plan.ID.String()),
+	)
+	s.publish(ctx, ports.EventSubscriptionPurchased, subscriptionPayload(subscription, map[string]interface{}{
+		"plan_name": plan.Name,
+		"amount":    plan.Price.String(),
+	}))
+	return subscription, nil
+}
+
+// GetUserSubscriptions lists every pass the user has bought, newest first
+func (s *SubscriptionService) GetUserSubscriptions(ctx context.Context, userID uuid.UUID) ([]*domain.Subscription, error) {
+	subscriptions, err := s.subscriptions.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+	return subscriptions, nil
+}
+
+// CancelRenewal turns off auto-renew on the user's pass
+func (s *SubscriptionService) CancelRenewal(ctx context.Context, id, userID uuid.UUID) (*domain.Subscription, error) {
+	subscription, err := s.subscriptions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.IsOwnedBy(userID) {
+		return nil, domain.ErrSubscriptionAccessDenied
+	}
+	if !subscription.AutoRenew {
+		return subscription, nil
+	}
+
+	subscription.CancelRenewal()
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to update subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// ProcessRenewals renews auto-renewing passes that are close to expiry, warns
+// holders whose passes are about to run out and expires the ones that have.
+// Renewals run first so a pass that renews is not warned or expired.
+func (s *SubscriptionService) ProcessRenewals(ctx context.Context, now time.Time) (*RenewalRunResult, error) {
+	result := &RenewalRunResult{}
+
+	due, err := s.subscriptions.GetDueForRenewal(ctx, now.Add(s.cfg.RenewalLead), s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions due for renewal: %w", err)
+	}
+	for _, subscription := range due {
+		if s.renew(ctx, subscription) {
+			result.Renewed++
+		} else {
+			result.RenewalsFailed++
+		}
+	}
+
+	expiring, err := s.subscriptions.GetExpiringUnnotified(ctx, now.Add(s.cfg.ExpiryWarning), s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring subscriptions: %w", err)
+	}
+	for _, subscription := range expiring {
+		subscription.MarkExpiryNotified(now)
+		if err := s.subscriptions.Update(ctx, subscription); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to mark expiry notified",
+				ports.String("subscription_id", subscription.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		result.Warned++
+		s.publish(ctx, ports.EventSubscriptionExpiringSoon, subscriptionPayload(subscription, nil))
+	}
+
+	lapsed, err := s.subscriptions.GetLapsed(ctx, now, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lapsed subscriptions: %w", err)
+	}
+	for _, subscription := range lapsed {
+		subscription.Expire()
+		if err := s.subscriptions.Update(ctx, subscription); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to expire subscription",
+				ports.String("subscription_id", subscription.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		result.Expired++
+		s.publish(ctx, ports.EventSubscriptionExpired, subscriptionPayload(subscription, nil))
+	}
+
+	if result.Renewed+result.RenewalsFailed+result.Warned+result.Expired > 0 {
+		s.logger.WithContext(ctx).Info("subscription renewal run finished",
+			ports.Any("renewed", result.Renewed),
+			ports.Any("renewals_failed", result.RenewalsFailed),
+			ports.Any("warned", result.Warned),
+			ports.Any("expired", result.Expired),
+		)
+	}
+	return result, nil
+}
+
+// renew charges the next period of a pass. A failed renewal turns auto-renew
+// off so the wallet is not retried every run; the pass then lapses at its
+// expiry and the holder can buy a new one.
+func (s *SubscriptionService) renew(ctx context.Context, subscription *domain.Subscription) bool {
+	log := s.logger.WithContext(ctx)
+
+	plan, err := s.plans.GetByID(ctx, subscription.PlanID)
+	if err != nil {
+		log.Warn("failed to load plan for renewal",
+			ports.String("subscription_id", subscription.ID.String()),
+			ports.Err(err),
+		)
+		return false
+	}
+
+	reason := ""
+	if !plan.Active {
+		reason = domain.ErrPlanInactive.Error()
+	} else {
+		// The key is fixed per period, so a run that charged the wallet but
+		// failed to save the renewal is not charged again on the next run
+		paymentResp, err := s.wallet.Pay(ctx, ports.PaymentRequest{
+			WalletID:       subscription.WalletID,
+			Amount:         plan.Price,
+			ProviderID:     plan.ProviderID,
+			ReferenceID:    subscription.ID.String(),
+			Description:    fmt.Sprintf("Season pass %s renewal for %s", plan.Name, subscription.VehiclePlate),
+			IdempotencyKey: subscription.NextPaymentKey(),
+		})
+		if err == nil {
+			if err := subscription.Renew(plan, paymentResp.TransactionID); err != nil {
+				return false
+			}
+			if err := s.subscriptions.Update(ctx, subscription); err != nil {
+				log.Error("failed to save renewed subscription",
+					ports.String("subscription_id", subscription.ID.String()),
+					ports.Err(err),
+				)
+				return false
+			}
+			s.publish(ctx, ports.EventSubscriptionRenewed, subscriptionPayload(subscription, map[string]interface{}{
+				"amount": plan.Price.String(),
+			}))
+			return true
+		}
+		reason = err.Error()
+	}
+
+	log.Warn("subscription renewal failed",
+		ports.String("subscription_id", subscription.ID.String()),
+		ports.String("reason", reason),
+	)
+	subscription.CancelRenewal()
+	if err := s.subscriptions.Update(ctx, subscription); err != nil {
+		log.Error("failed to cancel renewal", ports.Err(err))
+	}
+	s.publish(ctx, ports.EventSubscriptionRenewalFailed, subscriptionPayload(subscription, map[string]interface{}{
+		"reason": reason,
+	}))
+	return false
+}
+
+func subscriptionPayload(subscription *domain.Subscription, extra map[string]interface{}) map[string]interface{} {
+	payload := map[string]interface{}{
+		"subscription_id": subscription.ID.String(),
+		"user_id":         subscription.UserID.String(),
+		"plan_id":         subscription.PlanID.String(),
+		"vehicle_plate":   subscription.VehiclePlate,
+		"auto_renew":      subscription.AutoRenew,
+		"expires_at":      subscription.ExpiresAt.Format(time.RFC3339),
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	return payload
+}
+
+func (s *SubscriptionService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/parking/internal/domain"
+	"github.com/parking-super-app/services/parking/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// TaxService manages the tax rates providers charge on parking. Rates take
+// effect for sessions that end after they are set.
+type TaxService struct {
+	taxes  ports.TaxRateRepository
+	logger ports.Logger
+}
+
+func NewTaxService(taxes ports.TaxRateRepository, logger ports.Logger) *TaxService {
+	return &TaxService{taxes: taxes, logger: logger}
+}
+
+type CreateTaxRateRequest struct {
+	ProviderID uuid.UUID       `json:"provider_id"`
+	LocationID *uuid.UUID      `json:"location_id,omitempty"` // All locations if not given
+	Name       string          `json:"name"`
+	Rate       decimal.Decimal `json:"rate"`
+	Inclusive  bool            `json:"inclusive"`
+}
+
+type TaxRateListResponse struct {
+	ProviderID uuid.UUID         `json:"provider_id"`
+	Rates      []*domain.TaxRate `json:"rates"`
+}
+
+func (s *TaxService) CreateRate(ctx context.Context, req CreateTaxRateRequest) (*domain.TaxRate, error) {
+	rate, err := domain.NewTaxRate(req.ProviderID, req.LocationID, req.Name, req.Rate, req.Inclusive)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.taxes.Create(ctx, rate); err != nil {
+		return nil, fmt.Errorf("failed to save tax rate: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("tax rate created",
+		ports.String("tax_rate_id", rate.ID.String()),
+		ports.String("provider_id", rate.ProviderID.String()),
+		ports.String("name", rate.Name),
+		ports.String("rate", rate.Rate.String()),
+	)
+	return rate, nil
+}
+
+func (s *TaxService) ListRates(ctx context.Context, providerID uuid.UUID) (*TaxRateListResponse, error) {
+	rates, err := s.taxes.GetByProviderID(ctx, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tax rates: %w", err)
+	}
+	return &TaxRateListResponse{ProviderID: providerID, Rates: rates}, nil
+}
+
+// DeleteRate stops charging a tax. Sessions already billed keep it.
+func (s *TaxService) DeleteRate(ctx context.Context, id uuid.UUID) error {
+	if err := s.taxes.Delete(ctx, id); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("tax rate deleted", ports.String("tax_rate_id", id.String()))
+	return nil
+}
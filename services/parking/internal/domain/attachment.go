@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAttachmentNotFound        = errors.New("attachment not found")
+	ErrInvalidAttachmentKind     = errors.New("attachment kind must be entry_ticket, damage or other")
+	ErrUnsupportedAttachmentType = errors.New("attachments must be JPEG, PNG, HEIC or WebP images")
+	ErrAttachmentTooLarge        = errors.New("attachment is too large")
+	ErrTooManyAttachments        = errors.New("session has too many attachments")
+	ErrAttachmentNotUploaded     = errors.New("attachment has not been uploaded")
+	ErrAttachmentMismatch        = errors.New("uploaded file does not match the attachment")
+	ErrAttachmentsUnavailable    = errors.New("attachment storage is not configured")
+)
+
+const (
+	// MaxAttachmentBytes is the largest photo a session can carry
+	MaxAttachmentBytes = 10 << 20
+	// MaxSessionAttachments bounds the photos on one session
+	MaxSessionAttachments = 10
+)
+
+// attachmentTypes maps the accepted content types to the extension their
+// objects are stored under
+var attachmentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/heic": ".heic",
+	"image/webp": ".webp",
+}
+
+// AttachmentKind is what a photo shows
+type AttachmentKind string
+
+const (
+	AttachmentKindEntryTicket AttachmentKind = "entry_ticket"
+	AttachmentKindDamage      AttachmentKind = "damage"
+	AttachmentKindOther       AttachmentKind = "other"
+)
+
+func (k AttachmentKind) IsValid() bool {
+	switch k {
+	case AttachmentKindEntryTicket, AttachmentKindDamage, AttachmentKindOther:
+		return true
+	}
+	return false
+}
+
+// AttachmentStatus moves pending → uploaded once the file is in storage
+type AttachmentStatus string
+
+const (
+	AttachmentStatusPending  AttachmentStatus = "pending"
+	AttachmentStatusUploaded AttachmentStatus = "uploaded"
+)
+
+// Attachment is a photo kept with a session as evidence, such as the entry
+// ticket or damage to the vehicle. The file itself is in object storage
+// under ObjectKey; clients upload and download it directly.
+type Attachment struct {
+	ID          uuid.UUID        `json:"id"`
+	SessionID   uuid.UUID        `json:"session_id"`
+	UploadedBy  uuid.UUID        `json:"uploaded_by"`
+	Kind        AttachmentKind   `json:"kind"`
+	ContentType string           `json:"content_type"`
+	SizeBytes   int64            `json:"size_bytes"`
+	ObjectKey   string           `json:"-"`
+	Status      AttachmentStatus `json:"status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UploadedAt  *time.Time       `json:"uploaded_at,omitempty"`
+}
+
+// NewAttachment records a photo the user is about to upload to a session
+func NewAttachment(session *ParkingSession, uploadedBy uuid.UUID, kind AttachmentKind, contentType string, sizeBytes int64, now time.Time) (*Attachment, error) {
+	if !kind.IsValid() {
+		return nil, ErrInvalidAttachmentKind
+	}
+	ext, ok := attachmentTypes[contentType]
+	if !ok {
+		return nil, ErrUnsupportedAttachmentType
+	}
+	if sizeBytes <= 0 || sizeBytes > MaxAttachmentBytes {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	id := uuid.New()
+	return &Attachment{
+		ID:          id,
+		SessionID:   session.ID,
+		UploadedBy:  uploadedBy,
+		Kind:        kind,
+		ContentType: contentType,
+		SizeBytes:   sizeBytes,
+		ObjectKey:   "sessions/" + session.ID.String() + "/" + id.String() + ext,
+		Status:      AttachmentStatusPending,
+		CreatedAt:   now,
+	}, nil
+}
+
+// ConfirmUpload marks the attachment uploaded once the stored file is the
+// one that was announced. Confirming again is a no-op.
+func (a *Attachment) ConfirmUpload(sizeBytes int64, contentType string, now time.Time) error {
+	if a.Status == AttachmentStatusUploaded {
+		return nil
+	}
+	if sizeBytes != a.SizeBytes || contentType != a.ContentType {
+		return ErrAttachmentMismatch
+	}
+	a.Status = AttachmentStatusUploaded
+	a.UploadedAt = &now
+	return nil
+}
+
+// IsUploaded reports whether the file is in storage
+func (a *Attachment) IsUploaded() bool {
+	return a.Status == AttachmentStatusUploaded
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAttachment(t *testing.T) {
+	now := time.Now().UTC()
+	session, err := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "WXY 1234", "car")
+	if err != nil {
+		t.Fatalf("NewParkingSession() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		kind        AttachmentKind
+		contentType string
+		size        int64
+		wantErr     error
+	}{
+		{"entry ticket photo", AttachmentKindEntryTicket, "image/jpeg", 2 << 20, nil},
+		{"damage photo at the limit", AttachmentKindDamage, "image/heic", MaxAttachmentBytes, nil},
+		{"unknown kind", "receipt", "image/jpeg", 1024, ErrInvalidAttachmentKind},
+		{"not an image", AttachmentKindOther, "application/pdf", 1024, ErrUnsupportedAttachmentType},
+		{"too large", AttachmentKindDamage, "image/png", MaxAttachmentBytes + 1, ErrAttachmentTooLarge},
+		{"empty", AttachmentKindDamage, "image/png", 0, ErrAttachmentTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAttachment(session, session.UserID, tt.kind, tt.contentType, tt.size, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewAttachment() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if a.Status != AttachmentStatusPending || a.IsUploaded() {
+				t.Errorf("Status = %s, want pending", a.Status)
+			}
+			if !strings.HasPrefix(a.ObjectKey, "sessions/"+session.ID.String()+"/") {
+				t.Errorf("ObjectKey = %s, want it under the session", a.ObjectKey)
+			}
+		})
+	}
+}
+
+func TestAttachment_ConfirmUpload(t *testing.T) {
+	now := time.Now().UTC()
+	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "WXY 1234", "car")
+
+	tests := []struct {
+		name        string
+		size        int64
+		contentType string
+		wantErr     error
+	}{
+		{"matching file", 4096, "image/jpeg", nil},
+		{"different size", 8192, "image/jpeg", ErrAttachmentMismatch},
+		{"different type", 4096, "image/png", ErrAttachmentMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAttachment(session, session.UserID, AttachmentKindDamage, "image/jpeg", 4096, now)
+			if err != nil {
+				t.Fatalf("NewAttachment() error = %v", err)
+			}
+			if err := a.ConfirmUpload(tt.size, tt.contentType, now); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConfirmUpload() error = %v, want %v", err, tt.wantErr)
+			}
+			if a.IsUploaded() != (tt.wantErr == nil) {
+				t.Errorf("IsUploaded() = %v", a.IsUploaded())
+			}
+		})
+	}
+}
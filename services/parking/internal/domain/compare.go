@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+	ErrInvalidDuration    = errors.New("duration must be positive and at most 24 hours")
+	ErrInvalidSort        = errors.New("sort must be price or distance")
+)
+
+// MaxQuoteDuration is the longest stay a price comparison will quote
+const MaxQuoteDuration = 24 * time.Hour
+
+// QuoteSort orders price comparison results
+type QuoteSort string
+
+const (
+	QuoteSortPrice    QuoteSort = "price"
+	QuoteSortDistance QuoteSort = "distance"
+)
+
+// EstimateCost prices a stay the way sessions are billed: whole hours at the
+// hourly rate, capped at the daily max when one is set
+func EstimateCost(duration time.Duration, hourlyRate, dailyMax decimal.Decimal) decimal.Decimal {
+	return EstimateStay(pricing.Tariff{HourlyRate: hourlyRate, DailyMax: dailyMax}, time.Now(), duration)
+}
+
+// EstimateStay prices a stay starting at start the way sessions are billed,
+// so a location's peak and night rates apply to the hours they cover
+func EstimateStay(tariff pricing.Tariff, start time.Time, duration time.Duration) decimal.Decimal {
+	return tariff.Price(start, start.Add(duration))
+}
+
+// ValidCoordinates checks a latitude and longitude are on the globe
+func ValidCoordinates(lat, lng float64) bool {
+	return lat >= -90 && lat <= 90 && lng >= -180 && lng <= 180
+}
+
+// DistanceKm is the great-circle distance between two points
+func DistanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadiusKm = 6371
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
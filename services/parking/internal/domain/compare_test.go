@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestEstimateCost(t *testing.T) {
+	rate := decimal.RequireFromString("3.00")
+	dailyMax := decimal.RequireFromString("20.00")
+
+	tests := []struct {
+		name     string
+		duration time.Duration
+		dailyMax decimal.Decimal
+		want     string
+	}{
+		{"one hour", time.Hour, dailyMax, "3"},
+		{"part hour rounds up", 61 * time.Minute, dailyMax, "6"},
+		{"capped at daily max", 10 * time.Hour, dailyMax, "20"},
+		{"no daily max", 10 * time.Hour, decimal.Zero, "30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EstimateCost(tt.duration, rate, tt.dailyMax)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("EstimateCost() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	// KLCC to KL Sentral
+	got := DistanceKm(3.1579, 101.7123, 3.1340, 101.6865)
+	if math.Abs(got-3.88) > 0.1 {
+		t.Errorf("DistanceKm() = %.2f, want about 3.88", got)
+	}
+	if d := DistanceKm(3.1, 101.7, 3.1, 101.7); d != 0 {
+		t.Errorf("DistanceKm() of the same point = %v, want 0", d)
+	}
+}
+
+func TestValidCoordinates(t *testing.T) {
+	tests := []struct {
+		lat, lng float64
+		want     bool
+	}{
+		{3.1579, 101.7123, true},
+		{-90, 180, true},
+		{91, 0, false},
+		{0, -181, false},
+	}
+	for _, tt := range tests {
+		if got := ValidCoordinates(tt.lat, tt.lng); got != tt.want {
+			t.Errorf("ValidCoordinates(%v, %v) = %v, want %v", tt.lat, tt.lng, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrCompoundNotFound       = errors.New("compound not found")
+	ErrCompoundNotPayable     = errors.New("compound is no longer outstanding")
+	ErrCompoundAccessDenied   = errors.New("compound was paid by another user")
+	ErrCompoundIssuerNoAnswer = errors.New("issuer of the compound could not be reached")
+	ErrNoPlatesToCheck        = errors.New("no vehicle plate given and no vehicles registered")
+)
+
+// CompoundPaymentPrefix marks wallet payments for parking fines
+const CompoundPaymentPrefix = "compound-"
+
+// CompoundPaymentKey is the wallet idempotency key for paying a fine. A fine
+// is paid at most once, so the key is fixed per compound.
+func CompoundPaymentKey(compoundID uuid.UUID) string {
+	return CompoundPaymentPrefix + compoundID.String()
+}
+
+// CompoundStatus represents where a fine is in its payment lifecycle
+type CompoundStatus string
+
+const (
+	CompoundStatusOutstanding CompoundStatus = "outstanding"
+	// CompoundStatusPaying is claimed by a user whose wallet payment is in flight
+	CompoundStatusPaying CompoundStatus = "paying"
+	// CompoundStatusPaid is charged to the wallet but not yet acknowledged by the issuer
+	CompoundStatusPaid    CompoundStatus = "paid"
+	CompoundStatusSettled CompoundStatus = "settled"
+	// CompoundStatusClosed is no longer reported by the issuer, e.g. paid at the counter
+	CompoundStatusClosed CompoundStatus = "closed"
+)
+
+// Compound is a parking fine issued by a provider's enforcement officers,
+// recorded when a user looks it up and kept with its payment references
+type Compound struct {
+	ID              uuid.UUID       `json:"id"`
+	ProviderID      uuid.UUID       `json:"provider_id"`
+	Reference       string          `json:"reference"`
+	VehiclePlate    string          `json:"vehicle_plate"`
+	Offence         string          `json:"offence"`
+	Location        string          `json:"location"`
+	IssuedAt        time.Time       `json:"issued_at"`
+	DueAt           *time.Time      `json:"due_at,omitempty"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	Status          CompoundStatus  `json:"status"`
+	PaidBy          *uuid.UUID      `json:"paid_by,omitempty"`
+	WalletID        *uuid.UUID      `json:"wallet_id,omitempty"`
+	PaymentID       *uuid.UUID      `json:"payment_id,omitempty"`
+	ReceiptNumber   string          `json:"receipt_number,omitempty"`
+	ProviderReceipt string          `json:"provider_receipt,omitempty"`
+	PaidAt          *time.Time      `json:"paid_at,omitempty"`
+	SettledAt       *time.Time      `json:"settled_at,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// NewCompound records an outstanding fine reported by its issuer
+func NewCompound(providerID uuid.UUID, reference, plate, offence, location string, issuedAt time.Time, dueAt *time.Time, amount decimal.Decimal, currency string) *Compound {
+	now := time.Now().UTC()
+	if currency == "" {
+		currency = "MYR"
+	}
+	return &Compound{
+		ID:           uuid.New(),
+		ProviderID:   providerID,
+		Reference:    strings.TrimSpace(reference),
+		VehiclePlate: NormalizePlate(plate),
+		Offence:      offence,
+		Location:     location,
+		IssuedAt:     issuedAt.UTC(),
+		DueAt:        dueAt,
+		Amount:       amount,
+		Currency:     currency,
+		Status:       CompoundStatusOutstanding,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Claim reserves an outstanding fine for the user about to pay it, so two
+// users cannot both be charged. A user may claim again a fine they already
+// claimed, to retry a payment that did not complete.
+func (c *Compound) Claim(userID, walletID uuid.UUID) error {
+	switch {
+	case c.Status == CompoundStatusPaying && c.PaidBy != nil && *c.PaidBy == userID:
+	case c.Status != CompoundStatusOutstanding:
+		return ErrCompoundNotPayable
+	}
+	c.Status = CompoundStatusPaying
+	c.PaidBy = &userID
+	c.WalletID = &walletID
+	c.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Unclaim returns a fine to outstanding after its payment failed
+func (c *Compound) Unclaim() {
+	c.Status = CompoundStatusOutstanding
+	c.PaidBy = nil
+	c.WalletID = nil
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// MarkPaid records the wallet payment and issues the receipt number
+func (c *Compound) MarkPaid(paymentID uuid.UUID, now time.Time) {
+	c.Status = CompoundStatusPaid
+	c.PaymentID = &paymentID
+	c.PaidAt = &now
+	c.ReceiptNumber = "CMP-" + now.Format("20060102") + "-" + strings.ToUpper(c.ID.String()[:8])
+	c.UpdatedAt = now
+}
+
+// Settle records the issuer's acknowledgement of the payment
+func (c *Compound) Settle(providerReceipt string, settledAt time.Time) {
+	c.Status = CompoundStatusSettled
+	c.ProviderReceipt = providerReceipt
+	c.SettledAt = &settledAt
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// Close marks an outstanding fine the issuer no longer reports
+func (c *Compound) Close() {
+	c.Status = CompoundStatusClosed
+	c.UpdatedAt = time.Now().UTC()
+}
+
+// IsPaid reports whether the fine has been charged to a wallet
+func (c *Compound) IsPaid() bool {
+	return c.Status == CompoundStatusPaid || c.Status == CompoundStatusSettled
+}
+
+// IsPaidBy checks if the given user paid, or is paying, the fine
+func (c *Compound) IsPaidBy(userID uuid.UUID) bool {
+	return c.PaidBy != nil && *c.PaidBy == userID
+}
+
+// CompoundReceipt is the proof of payment shown to the user
+type CompoundReceipt struct {
+	ReceiptNumber   string          `json:"receipt_number"`
+	CompoundID      uuid.UUID       `json:"compound_id"`
+	ProviderID      uuid.UUID       `json:"provider_id"`
+	Reference       string          `json:"reference"`
+	VehiclePlate    string          `json:"vehicle_plate"`
+	Offence         string          `json:"offence"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	TransactionID   uuid.UUID       `json:"transaction_id"`
+	PaidAt          time.Time       `json:"paid_at"`
+	ProviderReceipt string          `json:"provider_receipt,omitempty"`
+	SettledAt       *time.Time      `json:"settled_at,omitempty"`
+}
+
+// Receipt returns the payment receipt, or ErrCompoundNotFound if the fine
+// has not been paid
+func (c *Compound) Receipt() (*CompoundReceipt, error) {
+	if !c.IsPaid() || c.PaymentID == nil || c.PaidAt == nil {
+		return nil, ErrCompoundNotFound
+	}
+	return &CompoundReceipt{
+		ReceiptNumber:   c.ReceiptNumber,
+		CompoundID:      c.ID,
+		ProviderID:      c.ProviderID,
+		Reference:       c.Reference,
+		VehiclePlate:    c.VehiclePlate,
+		Offence:         c.Offence,
+		Amount:          c.Amount,
+		Currency:        c.Currency,
+		TransactionID:   *c.PaymentID,
+		PaidAt:          *c.PaidAt,
+		ProviderReceipt: c.ProviderReceipt,
+		SettledAt:       c.SettledAt,
+	}, nil
+}
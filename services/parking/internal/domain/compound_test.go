@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func newTestCompound() *Compound {
+	issuedAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	return NewCompound(uuid.New(), " MBPJ-001 ", "wxy 1234", "Parking without payment", "Jalan SS2/24", issuedAt, nil, decimal.NewFromInt(30), "")
+}
+
+func TestNewCompound(t *testing.T) {
+	c := newTestCompound()
+
+	if c.Reference != "MBPJ-001" {
+		t.Errorf("Reference = %q, want MBPJ-001", c.Reference)
+	}
+	if c.VehiclePlate != "WXY1234" {
+		t.Errorf("VehiclePlate = %q, want WXY1234", c.VehiclePlate)
+	}
+	if c.Currency != "MYR" {
+		t.Errorf("Currency = %q, want MYR", c.Currency)
+	}
+	if c.Status != CompoundStatusOutstanding {
+		t.Errorf("Status = %v, want %v", c.Status, CompoundStatusOutstanding)
+	}
+}
+
+func TestCompound_Claim(t *testing.T) {
+	payer := uuid.New()
+	tests := []struct {
+		name    string
+		setup   func(c *Compound)
+		userID  uuid.UUID
+		wantErr error
+	}{
+		{"outstanding", func(c *Compound) {}, payer, nil},
+		{"retry by same user", func(c *Compound) { c.Claim(payer, uuid.New()) }, payer, nil},
+		{"claimed by another user", func(c *Compound) { c.Claim(uuid.New(), uuid.New()) }, payer, ErrCompoundNotPayable},
+		{"already paid", func(c *Compound) {
+			c.Claim(payer, uuid.New())
+			c.MarkPaid(uuid.New(), time.Now().UTC())
+		}, payer, ErrCompoundNotPayable},
+		{"closed", func(c *Compound) { c.Close() }, payer, ErrCompoundNotPayable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCompound()
+			tt.setup(c)
+			err := c.Claim(tt.userID, uuid.New())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Claim() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (c.Status != CompoundStatusPaying || !c.IsPaidBy(tt.userID)) {
+				t.Errorf("after Claim() status = %v, paid by user = %v", c.Status, c.IsPaidBy(tt.userID))
+			}
+		})
+	}
+}
+
+func TestCompound_Unclaim(t *testing.T) {
+	c := newTestCompound()
+	userID := uuid.New()
+	c.Claim(userID, uuid.New())
+	c.Unclaim()
+
+	if c.Status != CompoundStatusOutstanding {
+		t.Errorf("Status = %v, want %v", c.Status, CompoundStatusOutstanding)
+	}
+	if c.IsPaidBy(userID) || c.WalletID != nil {
+		t.Error("Unclaim() should clear the payer and wallet")
+	}
+	if err := c.Claim(uuid.New(), uuid.New()); err != nil {
+		t.Errorf("Claim() by another user after Unclaim() error = %v", err)
+	}
+}
+
+func TestCompound_Receipt(t *testing.T) {
+	c := newTestCompound()
+	if _, err := c.Receipt(); !errors.Is(err, ErrCompoundNotFound) {
+		t.Fatalf("Receipt() before payment error = %v, want %v", err, ErrCompoundNotFound)
+	}
+
+	c.Claim(uuid.New(), uuid.New())
+	paymentID := uuid.New()
+	paidAt := time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC)
+	c.MarkPaid(paymentID, paidAt)
+
+	receipt, err := c.Receipt()
+	if err != nil {
+		t.Fatalf("Receipt() error = %v", err)
+	}
+	wantNumber := "CMP-20260305-" + strings.ToUpper(c.ID.String()[:8])
+	if receipt.ReceiptNumber != wantNumber {
+		t.Errorf("ReceiptNumber = %q, want %q", receipt.ReceiptNumber, wantNumber)
+	}
+	if receipt.TransactionID != paymentID {
+		t.Errorf("TransactionID = %v, want %v", receipt.TransactionID, paymentID)
+	}
+	if receipt.SettledAt != nil {
+		t.Error("SettledAt should be empty before the issuer acknowledges")
+	}
+
+	settledAt := paidAt.Add(time.Minute)
+	c.Settle("MBPJ-R-77", settledAt)
+	receipt, err = c.Receipt()
+	if err != nil {
+		t.Fatalf("Receipt() after Settle() error = %v", err)
+	}
+	if receipt.ProviderReceipt != "MBPJ-R-77" || receipt.SettledAt == nil || !receipt.SettledAt.Equal(settledAt) {
+		t.Errorf("settled receipt = %+v", receipt)
+	}
+	if c.Status != CompoundStatusSettled || !c.IsPaid() {
+		t.Errorf("Status = %v, want %v", c.Status, CompoundStatusSettled)
+	}
+}
@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCheckWindow        = errors.New("check window start must be before end")
+	ErrConsistencyReportNotFound = errors.New("consistency report not found")
+)
+
+// PaymentIdempotencyPrefix marks wallet payments made for parking sessions
+const PaymentIdempotencyPrefix = "parking-"
+
+// PaymentIdempotencyKey is the wallet idempotency key for a session's payment
+func PaymentIdempotencyKey(sessionID uuid.UUID) string {
+	return PaymentIdempotencyPrefix + sessionID.String()
+}
+
+// ConsistencyIssueType identifies which side of the session/payment dual write is missing
+type ConsistencyIssueType string
+
+const (
+	// IssuePaidSessionWithoutTransaction: session recorded a payment the wallet has no record of
+	IssuePaidSessionWithoutTransaction ConsistencyIssueType = "paid_session_without_transaction"
+	// IssueTransactionWithoutSession: wallet charged for a session that is not marked paid
+	IssueTransactionWithoutSession ConsistencyIssueType = "transaction_without_session"
+)
+
+// WalletPayment is a completed or attempted wallet payment, as reported by the wallet service
+type WalletPayment struct {
+	TransactionID  uuid.UUID       `json:"transaction_id"`
+	ReferenceID    string          `json:"reference_id"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Amount         decimal.Decimal `json:"amount"`
+	Status         string          `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// IsParkingPayment reports whether the payment was made for a parking session
+func (p WalletPayment) IsParkingPayment() bool {
+	return strings.HasPrefix(p.IdempotencyKey, PaymentIdempotencyPrefix)
+}
+
+// IsCompleted reports whether the wallet was actually debited
+func (p WalletPayment) IsCompleted() bool {
+	return p.Status == "completed"
+}
+
+// ConsistencyIssue is an orphan found on one side of the session/payment pair
+type ConsistencyIssue struct {
+	Type          ConsistencyIssueType `json:"type"`
+	SessionID     *uuid.UUID           `json:"session_id,omitempty"`
+	TransactionID *uuid.UUID           `json:"transaction_id,omitempty"`
+	ReferenceID   string               `json:"reference_id"`
+	Amount        decimal.Decimal      `json:"amount"`
+	OccurredAt    time.Time            `json:"occurred_at"`
+}
+
+// ConsistencyReport is the result of cross-checking sessions and wallet payments over a window
+type ConsistencyReport struct {
+	ID              uuid.UUID          `json:"id"`
+	WindowStart     time.Time          `json:"window_start"`
+	WindowEnd       time.Time          `json:"window_end"`
+	SessionsChecked int                `json:"sessions_checked"`
+	PaymentsChecked int                `json:"payments_checked"`
+	Issues          []ConsistencyIssue `json:"issues"`
+	CreatedAt       time.Time          `json:"created_at"`
+}
+
+// NewConsistencyReport creates an empty report for [windowStart, windowEnd)
+func NewConsistencyReport(windowStart, windowEnd time.Time) (*ConsistencyReport, error) {
+	if !windowStart.Before(windowEnd) {
+		return nil, ErrInvalidCheckWindow
+	}
+	return &ConsistencyReport{
+		ID:          uuid.New(),
+		WindowStart: windowStart.UTC(),
+		WindowEnd:   windowEnd.UTC(),
+		Issues:      []ConsistencyIssue{},
+		CreatedAt:   time.Now().UTC(),
+	}, nil
+}
+
+// Reconcile cross-references sessions and payments by reference ID.
+// Callers fetch both sides over a window padded on each side, so a session that
+// ended just before its payment was written (or vice versa) still finds its match.
+// Only records that fall inside the report window are judged, so each record is
+// checked by exactly one scheduled run.
+func (r *ConsistencyReport) Reconcile(sessions []*ParkingSession, payments []WalletPayment) {
+	paymentsByRef := make(map[string]WalletPayment)
+	for _, p := range payments {
+		if p.IsParkingPayment() && p.IsCompleted() {
+			paymentsByRef[p.ReferenceID] = p
+		}
+	}
+
+	paidSessions := make(map[string]bool)
+	for _, s := range sessions {
+		if s.PaymentID != nil {
+			paidSessions[s.ID.String()] = true
+		}
+	}
+
+	for _, s := range sessions {
+		// Guests pay by card, so there is no wallet payment to match
+		if s.IsGuestCheckout() {
+			continue
+		}
+		endedAt := s.UpdatedAt
+		if s.ExitTime != nil {
+			endedAt = *s.ExitTime
+		}
+		if !r.covers(endedAt) {
+			continue
+		}
+		r.SessionsChecked++
+
+		if s.PaymentID == nil {
+			continue
+		}
+		if _, ok := paymentsByRef[s.ID.String()]; !ok {
+			sessionID := s.ID
+			r.Issues = append(r.Issues, ConsistencyIssue{
+				Type:          IssuePaidSessionWithoutTransaction,
+				SessionID:     &sessionID,
+				TransactionID: s.PaymentID,
+				ReferenceID:   s.ID.String(),
+				Amount:        s.Amount,
+				OccurredAt:    endedAt,
+			})
+		}
+	}
+
+	for _, p := range payments {
+		if !p.IsParkingPayment() || !r.covers(p.CreatedAt) {
+			continue
+		}
+		r.PaymentsChecked++
+
+		if !p.IsCompleted() || paidSessions[p.ReferenceID] {
+			continue
+		}
+		transactionID := p.TransactionID
+		issue := ConsistencyIssue{
+			Type:          IssueTransactionWithoutSession,
+			TransactionID: &transactionID,
+			ReferenceID:   p.ReferenceID,
+			Amount:        p.Amount,
+			OccurredAt:    p.CreatedAt,
+		}
+		if sessionID, err := uuid.Parse(p.ReferenceID); err == nil {
+			issue.SessionID = &sessionID
+		}
+		r.Issues = append(r.Issues, issue)
+	}
+}
+
+// HasIssues reports whether any orphans were found
+func (r *ConsistencyReport) HasIssues() bool {
+	return len(r.Issues) > 0
+}
+
+func (r *ConsistencyReport) covers(t time.Time) bool {
+	return !t.Before(r.WindowStart) && t.Before(r.WindowEnd)
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewConsistencyReport_InvalidWindow(t *testing.T) {
+	now := time.Now()
+	if _, err := NewConsistencyReport(now, now); err != ErrInvalidCheckWindow {
+		t.Errorf("expected ErrInvalidCheckWindow, got %v", err)
+	}
+	if _, err := NewConsistencyReport(now, now.Add(-time.Minute)); err != ErrInvalidCheckWindow {
+		t.Errorf("expected ErrInvalidCheckWindow, got %v", err)
+	}
+}
+
+func TestConsistencyReport_Reconcile(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	inWindow := start.Add(30 * time.Minute)
+
+	paidSession := func(endedAt time.Time) *ParkingSession {
+		paymentID := uuid.New()
+		return &ParkingSession{
+			ID:        uuid.New(),
+			Status:    SessionStatusCompleted,
+			ExitTime:  &endedAt,
+			Amount:    decimal.NewFromFloat(5.00),
+			PaymentID: &paymentID,
+		}
+	}
+	paymentFor := func(session *ParkingSession, createdAt time.Time) WalletPayment {
+		return WalletPayment{
+			TransactionID:  *session.PaymentID,
+			ReferenceID:    session.ID.String(),
+			IdempotencyKey: PaymentIdempotencyKey(session.ID),
+			Amount:         session.Amount,
+			Status:         "completed",
+			CreatedAt:      createdAt,
+		}
+	}
+
+	matched := paidSession(inWindow)
+	orphanSession := paidSession(inWindow)
+
+	// Wallet debited, but the session update was lost and it is still marked unpaid
+	unpaid := &ParkingSession{ID: uuid.New(), Status: SessionStatusFailed, ExitTime: &inWindow}
+	orphanPayment := WalletPayment{
+		TransactionID:  uuid.New(),
+		ReferenceID:    unpaid.ID.String(),
+		IdempotencyKey: PaymentIdempotencyKey(unpaid.ID),
+		Amount:         decimal.NewFromFloat(3.00),
+		Status:         "completed",
+		CreatedAt:      inWindow,
+	}
+
+	// Session ended in the window, payment written just after it closed
+	edge := paidSession(end.Add(-time.Second))
+
+	// Paid by card at guest checkout, so there's no wallet payment
+	guest := paidSession(inWindow)
+	guest.GuestDeviceID = "device-1234"
+
+	// Outside the window on both sides: must not be judged by this run
+	outside := paidSession(end.Add(time.Minute))
+
+	failedPayment := WalletPayment{
+		TransactionID:  uuid.New(),
+		ReferenceID:    uuid.New().String(),
+		IdempotencyKey: PaymentIdempotencyKey(uuid.New()),
+		Status:         "failed",
+		CreatedAt:      inWindow,
+	}
+	otherPayment := WalletPayment{
+		TransactionID:  uuid.New(),
+		ReferenceID:    "invoice-123",
+		IdempotencyKey: "invoice-123",
+		Status:         "completed",
+		CreatedAt:      inWindow,
+	}
+
+	report, err := NewConsistencyReport(start, end)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	report.Reconcile(
+		[]*ParkingSession{matched, orphanSession, unpaid, edge, guest, outside},
+		[]WalletPayment{
+			paymentFor(matched, inWindow),
+			orphanPayment,
+			paymentFor(edge, end.Add(time.Second)),
+			failedPayment,
+			otherPayment,
+		},
+	)
+
+	if report.SessionsChecked != 4 {
+		t.Errorf("expected 4 sessions checked, got %d", report.SessionsChecked)
+	}
+	if report.PaymentsChecked != 3 {
+		t.Errorf("expected 3 payments checked, got %d", report.PaymentsChecked)
+	}
+	if len(report.Issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(report.Issues), report.Issues)
+	}
+
+	byType := make(map[ConsistencyIssueType]ConsistencyIssue)
+	for _, issue := range report.Issues {
+		byType[issue.Type] = issue
+	}
+
+	if issue, ok := byType[IssuePaidSessionWithoutTransaction]; !ok {
+		t.Error("expected a paid session without transaction")
+	} else if *issue.SessionID != orphanSession.ID {
+		t.Errorf("expected orphan session %s, got %s", orphanSession.ID, issue.SessionID)
+	}
+
+	if issue, ok := byType[IssueTransactionWithoutSession]; !ok {
+		t.Error("expected a transaction without session")
+	} else {
+		if *issue.TransactionID != orphanPayment.TransactionID {
+			t.Errorf("expected orphan transaction %s, got %s", orphanPayment.TransactionID, issue.TransactionID)
+		}
+		if issue.SessionID == nil || *issue.SessionID != unpaid.ID {
+			t.Error("expected orphan transaction to reference the unpaid session")
+		}
+	}
+}
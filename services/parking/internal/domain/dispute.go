@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrDisputeNotFound          = errors.New("dispute not found")
+	ErrDisputeAccessDenied      = errors.New("dispute belongs to another user")
+	ErrInvalidDisputeReason     = errors.New("dispute reason must be wrong_amount, double_charge or other")
+	ErrInvalidDisputeResolution = errors.New("dispute resolution must be refund or rejected")
+	ErrSessionNotDisputable     = errors.New("only completed, paid sessions can be disputed")
+	ErrDisputeAlreadyOpen       = errors.New("session already has a dispute")
+	ErrInvalidDisputeTransition = errors.New("invalid dispute status transition")
+	ErrDisputeSelfReview        = errors.New("a dispute can't be reviewed by the user who opened it")
+)
+
+// DisputeRefundKey is the wallet idempotency key for the refund a dispute
+// is resolved with, so a retried resolution never refunds twice
+func DisputeRefundKey(disputeID uuid.UUID) string {
+	return "dispute-" + disputeID.String()
+}
+
+// DisputeReason is why the user thinks they were charged wrongly
+type DisputeReason string
+
+const (
+	DisputeReasonWrongAmount  DisputeReason = "wrong_amount"
+	DisputeReasonDoubleCharge DisputeReason = "double_charge"
+	DisputeReasonOther        DisputeReason = "other"
+)
+
+func (r DisputeReason) IsValid() bool {
+	switch r {
+	case DisputeReasonWrongAmount, DisputeReasonDoubleCharge, DisputeReasonOther:
+		return true
+	}
+	return false
+}
+
+// DisputeStatus moves open → under_review → resolved
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen        DisputeStatus = "open"
+	DisputeStatusUnderReview DisputeStatus = "under_review"
+	DisputeStatusResolved    DisputeStatus = "resolved"
+)
+
+// DisputeResolution is how an admin settled a dispute
+type DisputeResolution string
+
+const (
+	// DisputeResolutionRefund returns the session payment to the wallet
+	DisputeResolutionRefund   DisputeResolution = "refund"
+	DisputeResolutionRejected DisputeResolution = "rejected"
+)
+
+func (r DisputeResolution) IsValid() bool {
+	return r == DisputeResolutionRefund || r == DisputeResolutionRejected
+}
+
+// Dispute is a user's challenge to what they were charged for a session.
+// A session has at most one dispute.
+type Dispute struct {
+	ID                  uuid.UUID          `json:"id"`
+	SessionID           uuid.UUID          `json:"session_id"`
+	UserID              uuid.UUID          `json:"user_id"`
+	PaymentID           uuid.UUID          `json:"payment_id"`
+	Amount              decimal.Decimal    `json:"amount"`
+	Currency            string             `json:"currency"`
+	Reason              DisputeReason      `json:"reason"`
+	Description         string             `json:"description,omitempty"`
+	Status              DisputeStatus      `json:"status"`
+	Resolution          *DisputeResolution `json:"resolution,omitempty"`
+	ResolutionNote      string             `json:"resolution_note,omitempty"`
+	RefundTransactionID *uuid.UUID         `json:"refund_transaction_id,omitempty"`
+	ReviewedBy          *uuid.UUID         `json:"reviewed_by,omitempty"`
+	ResolvedAt          *time.Time         `json:"resolved_at,omitempty"`
+	CreatedAt           time.Time          `json:"created_at"`
+	UpdatedAt           time.Time          `json:"updated_at"`
+}
+
+// NewDispute opens a dispute on a session the user was charged for
+func NewDispute(session *ParkingSession, reason DisputeReason, description string, now time.Time) (*Dispute, error) {
+	if !reason.IsValid() {
+		return nil, ErrInvalidDisputeReason
+	}
+	if !session.IsCompleted() || session.PaymentStatus != PaymentStatusPaid || session.PaymentID == nil {
+		return nil, ErrSessionNotDisputable
+	}
+
+	return &Dispute{
+		ID:          uuid.New(),
+		SessionID:   session.ID,
+		UserID:      session.UserID,
+		PaymentID:   *session.PaymentID,
+		Amount:      session.Amount,
+		Currency:    session.Currency,
+		Reason:      reason,
+		Description: strings.TrimSpace(description),
+		Status:      DisputeStatusOpen,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// StartReview records that an admin has picked the dispute up
+func (d *Dispute) StartReview(adminID uuid.UUID, now time.Time) error {
+	if d.IsOwnedBy(adminID) {
+		return ErrDisputeSelfReview
+	}
+	if d.Status != DisputeStatusOpen {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidDisputeTransition, d.Status, DisputeStatusUnderReview)
+	}
+	d.Status = DisputeStatusUnderReview
+	d.ReviewedBy = &adminID
+	d.UpdatedAt = now
+	return nil
+}
+
+// IsOwnedBy returns true if the user opened the dispute
+func (d *Dispute) IsOwnedBy(userID uuid.UUID) bool {
+	return d.UserID == userID
+}
+
+// Resolve closes a dispute under review. A refund resolution carries the
+// wallet transaction that returned the money. The user who opened the
+// dispute can't resolve it.
+func (d *Dispute) Resolve(adminID uuid.UUID, resolution DisputeResolution, note string, refundID *uuid.UUID, now time.Time) error {
+	if d.IsOwnedBy(adminID) {
+		return ErrDisputeSelfReview
+	}
+	if !resolution.IsValid() {
+		return ErrInvalidDisputeResolution
+	}
+	if d.Status != DisputeStatusUnderReview {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidDisputeTransition, d.Status, DisputeStatusResolved)
+	}
+	d.Status = DisputeStatusResolved
+	d.Resolution = &resolution
+	d.ResolutionNote = strings.TrimSpace(note)
+	d.RefundTransactionID = refundID
+	d.ReviewedBy = &adminID
+	d.ResolvedAt = &now
+	d.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func newPaidSession(t *testing.T) *ParkingSession {
+	t.Helper()
+	s, err := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "WXY 1234", "car")
+	if err != nil {
+		t.Fatalf("NewParkingSession() error = %v", err)
+	}
+	if err := s.Activate("ext-1"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if err := s.End(decimal.RequireFromString("6.00")); err != nil {
+		t.Fatalf("End() error = %v", err)
+	}
+	if err := s.MarkPaid(uuid.New()); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	return s
+}
+
+func TestNewDispute(t *testing.T) {
+	now := time.Now().UTC()
+
+	paid := newPaidSession(t)
+	active, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "WXY 1234", "car")
+	active.Activate("ext-2")
+
+	tests := []struct {
+		name    string
+		session *ParkingSession
+		reason  DisputeReason
+		wantErr error
+	}{
+		{"paid session", paid, DisputeReasonDoubleCharge, nil},
+		{"unknown reason", paid, "too_expensive", ErrInvalidDisputeReason},
+		{"session still active", active, DisputeReasonWrongAmount, ErrSessionNotDisputable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDispute(tt.session, tt.reason, "  charged twice ", now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewDispute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if d.Status != DisputeStatusOpen {
+				t.Errorf("Status = %s, want open", d.Status)
+			}
+			if d.PaymentID != *tt.session.PaymentID || !d.Amount.Equal(tt.session.Amount) {
+				t.Errorf("dispute does not carry the session payment")
+			}
+			if d.Description != "charged twice" {
+				t.Errorf("Description = %q", d.Description)
+			}
+		})
+	}
+}
+
+func TestDispute_Lifecycle(t *testing.T) {
+	now := time.Now().UTC()
+	admin := uuid.New()
+
+	d, err := NewDispute(newPaidSession(t), DisputeReasonWrongAmount, "", now)
+	if err != nil {
+		t.Fatalf("NewDispute() error = %v", err)
+	}
+
+	if err := d.Resolve(admin, DisputeResolutionRejected, "", nil, now); !errors.Is(err, ErrInvalidDisputeTransition) {
+		t.Errorf("Resolve() before review error = %v, want %v", err, ErrInvalidDisputeTransition)
+	}
+	if err := d.StartReview(d.UserID, now); !errors.Is(err, ErrDisputeSelfReview) {
+		t.Errorf("StartReview() by the user who opened it error = %v, want %v", err, ErrDisputeSelfReview)
+	}
+	if err := d.StartReview(admin, now); err != nil {
+		t.Fatalf("StartReview() error = %v", err)
+	}
+	if err := d.StartReview(admin, now); !errors.Is(err, ErrInvalidDisputeTransition) {
+		t.Errorf("StartReview() twice error = %v, want %v", err, ErrInvalidDisputeTransition)
+	}
+	if err := d.Resolve(admin, "partial", "", nil, now); !errors.Is(err, ErrInvalidDisputeResolution) {
+		t.Errorf("Resolve() with unknown resolution error = %v, want %v", err, ErrInvalidDisputeResolution)
+	}
+
+	refundID := uuid.New()
+	if err := d.Resolve(d.UserID, DisputeResolutionRefund, "", &refundID, now); !errors.Is(err, ErrDisputeSelfReview) {
+		t.Errorf("Resolve() by the user who opened it error = %v, want %v", err, ErrDisputeSelfReview)
+	}
+	if err := d.Resolve(admin, DisputeResolutionRefund, "rate misconfigured", &refundID, now); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if d.Status != DisputeStatusResolved || *d.Resolution != DisputeResolutionRefund || *d.RefundTransactionID != refundID {
+		t.Errorf("resolved dispute = %+v", d)
+	}
+	if err := d.Resolve(admin, DisputeResolutionRejected, "", nil, now); !errors.Is(err, ErrInvalidDisputeTransition) {
+		t.Errorf("Resolve() twice error = %v, want %v", err, ErrInvalidDisputeTransition)
+	}
+}
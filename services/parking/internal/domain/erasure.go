@@ -0,0 +1,10 @@
+package domain
+
+// ErasureResult counts what was scrubbed for a deleted user. Records still
+// in use (an active session, a live pass or an upcoming reservation) keep
+// their plate so the car can get out or in; Live counts them.
+type ErasureResult struct {
+	VehiclesDeleted int64 `json:"vehicles_deleted"`
+	Anonymized      int64 `json:"anonymized"`
+	Live            int64 `json:"live"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrGuestDeviceRequired = errors.New("guest sessions need the device they were started from")
+	ErrCardTokenRequired   = errors.New("guests pay by card; a card token is required")
+	ErrCardChanged         = errors.New("session is already being charged to another card")
+)
+
+// NewGuestSession creates a pending session for someone parking without an
+// account. It belongs to the device instead of a user until the guest
+// registers and their history is claimed.
+func NewGuestSession(deviceID string, providerID, locationID uuid.UUID, vehiclePlate, vehicleType string) (*ParkingSession, error) {
+	deviceID = strings.TrimSpace(deviceID)
+	if deviceID == "" {
+		return nil, ErrGuestDeviceRequired
+	}
+	session, err := NewParkingSession(uuid.Nil, providerID, locationID, vehiclePlate, vehicleType)
+	if err != nil {
+		return nil, err
+	}
+	session.GuestDeviceID = deviceID
+	return session, nil
+}
+
+// IsGuest reports whether the session belongs to a guest who hasn't
+// registered yet
+func (s *ParkingSession) IsGuest() bool {
+	return s.GuestDeviceID != "" && s.UserID == uuid.Nil
+}
+
+// IsGuestCheckout reports whether the session was started without an
+// account and so paid by card, even once the guest has registered and
+// claimed it
+func (s *ParkingSession) IsGuestCheckout() bool {
+	return s.GuestDeviceID != ""
+}
+
+// IsGuestSessionOf checks if a guest session was started from the device
+func (s *ParkingSession) IsGuestSessionOf(deviceID string) bool {
+	return s.IsGuest() && s.GuestDeviceID == deviceID
+}
+
+// ChargeCard records the card a guest session is charged to, keeping a
+// fingerprint rather than the token. An ending session's charge may still
+// go through, so it only takes the card it was first charged to; a guest
+// whose card was declined can pay with another.
+func (s *ParkingSession) ChargeCard(cardToken string) error {
+	card := sha256.Sum256([]byte(cardToken))
+	fingerprint := hex.EncodeToString(card[:8])
+	if s.IsEnding() && s.PaymentCard != "" && s.PaymentCard != fingerprint {
+		return ErrCardChanged
+	}
+	s.PaymentCard = fingerprint
+	return nil
+}
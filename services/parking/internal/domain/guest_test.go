@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewGuestSession(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		plate    string
+		wantErr  error
+	}{
+		{"plate only", "device-1234", "WXY1234", nil},
+		{"no device", "  ", "WXY1234", ErrGuestDeviceRequired},
+		{"invalid plate", "device-1234", "W", ErrInvalidVehiclePlate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := NewGuestSession(tt.deviceID, uuid.New(), uuid.New(), tt.plate, "car")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewGuestSession() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !session.IsGuest() || session.UserID != uuid.Nil {
+				t.Errorf("session = guest %v user %v, want a guest without a user", session.IsGuest(), session.UserID)
+			}
+		})
+	}
+}
+
+func TestParkingSession_GuestOwnership(t *testing.T) {
+	session, _ := NewGuestSession("device-1234", uuid.New(), uuid.New(), "WXY1234", "car")
+
+	if !session.IsGuestSessionOf("device-1234") {
+		t.Error("IsGuestSessionOf() = false for the device that started it")
+	}
+	if session.IsGuestSessionOf("device-5678") {
+		t.Error("IsGuestSessionOf() = true for another device")
+	}
+	if session.IsOwnedBy(uuid.Nil) {
+		t.Error("IsOwnedBy(uuid.Nil) = true for a guest session")
+	}
+
+	claimed := *session
+	claimed.UserID = uuid.New()
+	if claimed.IsGuest() || !claimed.IsGuestCheckout() || !claimed.IsOwnedBy(claimed.UserID) {
+		t.Error("a claimed guest session should belong to its user and still be a guest checkout")
+	}
+	if claimed.IsGuestSessionOf("device-1234") {
+		t.Error("IsGuestSessionOf() = true once the session is claimed")
+	}
+
+	userSession, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "WXY1234", "car")
+	if userSession.IsGuestSessionOf("") {
+		t.Error("IsGuestSessionOf(\"\") = true for a user's session")
+	}
+}
+
+func TestParkingSession_ChargeCard(t *testing.T) {
+	session, _ := NewGuestSession("device-1234", uuid.New(), uuid.New(), "WXY1234", "car")
+	session.Activate("ext-1")
+
+	if err := session.ChargeCard("tok_first"); err != nil {
+		t.Fatalf("ChargeCard() on an active session error = %v", err)
+	}
+	session.End(decimal.NewFromInt(10))
+	first := session.PaymentCard
+
+	// The first charge may still go through while the session is ending
+	if err := session.ChargeCard("tok_second"); !errors.Is(err, ErrCardChanged) {
+		t.Errorf("ChargeCard() with a second card while ending error = %v, want %v", err, ErrCardChanged)
+	}
+	if err := session.ChargeCard("tok_first"); err != nil || session.PaymentCard != first {
+		t.Errorf("ChargeCard() with the same card error = %v, card changed %v", err, session.PaymentCard != first)
+	}
+
+	// Once declined, the guest may pay with another card
+	session.FailPayment()
+	if err := session.ChargeCard("tok_second"); err != nil {
+		t.Fatalf("ChargeCard() after a decline error = %v", err)
+	}
+	if session.PaymentCard == first || session.PaymentCard == "" {
+		t.Errorf("PaymentCard = %q, want the second card's fingerprint", session.PaymentCard)
+	}
+}
@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrReservationNotFound      = errors.New("reservation not found")
+	ErrInvalidReservationWindow = errors.New("reservation must start in the future and end after it starts")
+	ErrReservationAccessDenied  = errors.New("reservation belongs to another user")
+	ErrReservationClosed        = errors.New("reservation is no longer open")
+	ErrNoSpacesAvailable        = errors.New("no spaces available at this location for the requested time")
+	ErrLocationUnavailable      = errors.New("location is not taking reservations")
+	ErrReservationOutOfRange    = errors.New("reservation is too long or too far ahead")
+)
+
+// ReservationKeyPrefix marks wallet holds and fees for reservations. Once the
+// vehicle arrives, the hold is captured under the session's payment key.
+const ReservationKeyPrefix = "reservation-"
+
+// ReservationHoldKey is the wallet idempotency key for the hold placed when a
+// reservation is booked
+func ReservationHoldKey(reservationID uuid.UUID) string {
+	return ReservationKeyPrefix + reservationID.String()
+}
+
+// ReservationFeeKey is the wallet idempotency key for the fee charged when a
+// reservation is cancelled late or not used
+func ReservationFeeKey(reservationID uuid.UUID) string {
+	return ReservationKeyPrefix + reservationID.String() + "-fee"
+}
+
+// ReservationStatus represents where a booking is in its lifecycle
+type ReservationStatus string
+
+const (
+	// ReservationStatusPending has claimed a space and is waiting for its wallet hold
+	ReservationStatusPending   ReservationStatus = "pending"
+	ReservationStatusConfirmed ReservationStatus = "confirmed"
+	// ReservationStatusConverted means the vehicle arrived and a session started
+	ReservationStatusConverted ReservationStatus = "converted"
+	ReservationStatusCompleted ReservationStatus = "completed"
+	ReservationStatusCancelled ReservationStatus = "cancelled"
+	ReservationStatusNoShow    ReservationStatus = "no_show"
+	ReservationStatusFailed    ReservationStatus = "failed"
+)
+
+// Reservation books a space at a location for a time window. The estimated
+// charge is held in the user's wallet and taken when the session it turns
+// into ends, or partly kept as a fee if the vehicle never arrives.
+type Reservation struct {
+	ID           uuid.UUID         `json:"id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	ProviderID   uuid.UUID         `json:"provider_id"`
+	LocationID   uuid.UUID         `json:"location_id"`
+	WalletID     uuid.UUID         `json:"wallet_id"`
+	VehiclePlate string            `json:"vehicle_plate"`
+	StartsAt     time.Time         `json:"starts_at"`
+	EndsAt       time.Time         `json:"ends_at"`
+	ArrivalFrom  time.Time         `json:"arrival_from"`
+	NoShowAt     time.Time         `json:"no_show_at"`
+	Amount       decimal.Decimal   `json:"amount"`
+	Currency     string            `json:"currency"`
+	HoldID       *uuid.UUID        `json:"hold_id,omitempty"`
+	Status       ReservationStatus `json:"status"`
+	SessionID    *uuid.UUID        `json:"session_id,omitempty"`
+	FeeAmount    decimal.Decimal   `json:"fee_amount"`
+	PaymentID    *uuid.UUID        `json:"payment_id,omitempty"`
+	ClosedAt     *time.Time        `json:"closed_at,omitempty"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// NewReservation creates a pending booking for [startsAt, endsAt). The vehicle
+// may arrive from earlyArrival before the start and counts as a no-show once
+// noShowGrace has passed after it.
+func NewReservation(
+	userID, providerID, locationID, walletID uuid.UUID,
+	vehiclePlate string,
+	startsAt, endsAt time.Time,
+	earlyArrival, noShowGrace time.Duration,
+) (*Reservation, error) {
+	plate := NormalizePlate(vehiclePlate)
+	if !isValidPlate(plate) {
+		return nil, ErrInvalidVehiclePlate
+	}
+	now := time.Now().UTC()
+	if !startsAt.After(now) || !endsAt.After(startsAt) {
+		return nil, ErrInvalidReservationWindow
+	}
+
+	noShowAt := startsAt.Add(noShowGrace)
+	if noShowAt.After(endsAt) {
+		noShowAt = endsAt
+	}
+	return &Reservation{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ProviderID:   providerID,
+		LocationID:   locationID,
+		WalletID:     walletID,
+		VehiclePlate: plate,
+		StartsAt:     startsAt.UTC(),
+		EndsAt:       endsAt.UTC(),
+		ArrivalFrom:  startsAt.Add(-earlyArrival).UTC(),
+		NoShowAt:     noShowAt.UTC(),
+		Amount:       decimal.Zero,
+		Currency:     "MYR",
+		Status:       ReservationStatusPending,
+		FeeAmount:    decimal.Zero,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Duration is the length of the booked window
+func (r *Reservation) Duration() time.Duration {
+	return r.EndsAt.Sub(r.StartsAt)
+}
+
+// EstimateAmount prices the booked window the way a session of the same
+// length is billed: whole hours at the hourly rate, capped at the daily max
+func (r *Reservation) EstimateAmount(hourlyRate, dailyMax decimal.Decimal) decimal.Decimal {
+	return r.EstimateCharge(pricing.Tariff{HourlyRate: hourlyRate, DailyMax: dailyMax})
+}
+
+// EstimateCharge prices the booked window by the location's tariff, as a
+// session parked for exactly that window would be billed
+func (r *Reservation) EstimateCharge(tariff pricing.Tariff) decimal.Decimal {
+	return tariff.Price(r.StartsAt, r.EndsAt)
+}
+
+// Confirm records the wallet hold that guarantees the booking. Bookings at
+// locations that charge nothing have no hold.
+func (r *Reservation) Confirm(holdID *uuid.UUID, amount decimal.Decimal) {
+	r.HoldID = holdID
+	r.Amount = amount
+	r.Status = ReservationStatusConfirmed
+	r.UpdatedAt = time.Now().UTC()
+}
+
+// Fail marks a booking whose hold could not be placed. It no longer takes
+// up a space.
+func (r *Reservation) Fail() {
+	now := time.Now().UTC()
+	r.Status = ReservationStatusFailed
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+}
+
+// IsConfirmed returns true while the space is held and the vehicle has not arrived
+func (r *Reservation) IsConfirmed() bool {
+	return r.Status == ReservationStatusConfirmed
+}
+
+// IsOwnedBy checks if the reservation belongs to the given user
+func (r *Reservation) IsOwnedBy(userID uuid.UUID) bool {
+	return r.UserID == userID
+}
+
+// AcceptsArrival reports whether a vehicle entering the location at the given
+// time takes up this booking
+func (r *Reservation) AcceptsArrival(at time.Time) bool {
+	return r.IsConfirmed() && !at.Before(r.ArrivalFrom) && at.Before(r.EndsAt)
+}
+
+// Convert records the session started when the vehicle arrived
+func (r *Reservation) Convert(sessionID uuid.UUID) error {
+	if !r.IsConfirmed() {
+		return ErrReservationClosed
+	}
+	r.SessionID = &sessionID
+	r.Status = ReservationStatusConverted
+	r.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Complete records how the session settled the hold. paymentID is nil when
+// nothing was charged.
+func (r *Reservation) Complete(paymentID *uuid.UUID) {
+	now := time.Now().UTC()
+	r.PaymentID = paymentID
+	r.Status = ReservationStatusCompleted
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+}
+
+// IsNoShow reports whether the vehicle missed its booking
+func (r *Reservation) IsNoShow(now time.Time) bool {
+	return r.IsConfirmed() && !now.Before(r.NoShowAt)
+}
+
+// CancellationFee is what the user forfeits for giving up the booking at the
+// given time: nothing before it starts, feePercent of the amount after
+func (r *Reservation) CancellationFee(now time.Time, feePercent decimal.Decimal) decimal.Decimal {
+	if now.Before(r.StartsAt) {
+		return decimal.Zero
+	}
+	return r.Amount.Mul(feePercent).Div(decimal.NewFromInt(100)).Round(2)
+}
+
+// Cancel closes a booking the user gave up, keeping fee of its hold
+func (r *Reservation) Cancel(fee decimal.Decimal, paymentID *uuid.UUID) error {
+	return r.close(ReservationStatusCancelled, fee, paymentID)
+}
+
+// MarkNoShow closes a booking the vehicle never arrived for, keeping fee of its hold
+func (r *Reservation) MarkNoShow(fee decimal.Decimal, paymentID *uuid.UUID) error {
+	return r.close(ReservationStatusNoShow, fee, paymentID)
+}
+
+func (r *Reservation) close(status ReservationStatus, fee decimal.Decimal, paymentID *uuid.UUID) error {
+	if !r.IsConfirmed() {
+		return ErrReservationClosed
+	}
+	now := time.Now().UTC()
+	r.Status = status
+	r.FeeAmount = fee
+	r.PaymentID = paymentID
+	r.ClosedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Refund is the part of the hold returned to the wallet after a fee
+func (r *Reservation) Refund() decimal.Decimal {
+	return r.Amount.Sub(r.FeeAmount)
+}
+
+// LocationAvailability is how many spaces at a location are free to book
+// for a time window
+type LocationAvailability struct {
+	LocationID  uuid.UUID `json:"location_id"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	TotalSpaces int       `json:"total_spaces"`
+	Reserved    int       `json:"reserved"`
+	Available   int       `json:"available"`
+}
+
+// NewLocationAvailability reports what is left of totalSpaces after reserved
+// bookings overlapping the window
+func NewLocationAvailability(locationID uuid.UUID, startsAt, endsAt time.Time, totalSpaces, reserved int) *LocationAvailability {
+	available := totalSpaces - reserved
+	if available < 0 {
+		available = 0
+	}
+	return &LocationAvailability{
+		LocationID:  locationID,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		TotalSpaces: totalSpaces,
+		Reserved:    reserved,
+		Available:   available,
+	}
+}
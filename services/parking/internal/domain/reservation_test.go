@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func newTestReservation(t *testing.T, startsIn, length time.Duration) *Reservation {
+	t.Helper()
+	start := time.Now().UTC().Add(startsIn)
+	r, err := NewReservation(uuid.New(), uuid.New(), uuid.New(), uuid.New(), "WXY 1234",
+		start, start.Add(length), 15*time.Minute, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("NewReservation() error = %v", err)
+	}
+	return r
+}
+
+func TestNewReservation(t *testing.T) {
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name    string
+		plate   string
+		start   time.Time
+		end     time.Time
+		wantErr error
+	}{
+		{"two hours tomorrow", "wxy 1234", now.Add(24 * time.Hour), now.Add(26 * time.Hour), nil},
+		{"starts in the past", "WXY1234", now.Add(-time.Minute), now.Add(time.Hour), ErrInvalidReservationWindow},
+		{"ends before it starts", "WXY1234", now.Add(2 * time.Hour), now.Add(time.Hour), ErrInvalidReservationWindow},
+		{"empty window", "WXY1234", now.Add(time.Hour), now.Add(time.Hour), ErrInvalidReservationWindow},
+		{"plate too short", "W", now.Add(time.Hour), now.Add(2 * time.Hour), ErrInvalidVehiclePlate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewReservation(uuid.New(), uuid.New(), uuid.New(), uuid.New(), tt.plate,
+				tt.start, tt.end, 15*time.Minute, 30*time.Minute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewReservation() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if r.Status != ReservationStatusPending || r.VehiclePlate != "WXY1234" {
+				t.Errorf("reservation = %+v, want pending with a normalised plate", r)
+			}
+			if !r.ArrivalFrom.Equal(r.StartsAt.Add(-15*time.Minute)) || !r.NoShowAt.Equal(r.StartsAt.Add(30*time.Minute)) {
+				t.Errorf("arrival from %v, no-show at %v", r.ArrivalFrom, r.NoShowAt)
+			}
+		})
+	}
+}
+
+func TestNewReservation_NoShowCappedAtEnd(t *testing.T) {
+	r := newTestReservation(t, time.Hour, 20*time.Minute)
+	if !r.NoShowAt.Equal(r.EndsAt) {
+		t.Errorf("NoShowAt = %v, want the end %v", r.NoShowAt, r.EndsAt)
+	}
+}
+
+func TestReservation_EstimateAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		length   time.Duration
+		hourly   string
+		dailyMax string
+		want     string
+	}{
+		{"whole hours", 2 * time.Hour, "3", "20", "6"},
+		{"part hour rounds up", 90 * time.Minute, "3", "20", "6"},
+		{"capped at daily max", 10 * time.Hour, "3", "20", "20"},
+		{"no daily max", 10 * time.Hour, "3", "0", "30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReservation(t, time.Hour, tt.length)
+			got := r.EstimateAmount(decimal.RequireFromString(tt.hourly), decimal.RequireFromString(tt.dailyMax))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("EstimateAmount() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReservation_AcceptsArrival(t *testing.T) {
+	r := newTestReservation(t, time.Hour, 2*time.Hour)
+
+	tests := []struct {
+		name    string
+		at      time.Time
+		confirm bool
+		want    bool
+	}{
+		{"not confirmed", r.StartsAt, false, false},
+		{"too early", r.ArrivalFrom.Add(-time.Minute), true, false},
+		{"early arrival", r.ArrivalFrom, true, true},
+		{"during window", r.StartsAt.Add(time.Hour), true, true},
+		{"at the end", r.EndsAt, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := *r
+			if tt.confirm {
+				res.Confirm(nil, decimal.NewFromInt(6))
+			}
+			if got := res.AcceptsArrival(tt.at); got != tt.want {
+				t.Errorf("AcceptsArrival() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReservation_CancellationFee(t *testing.T) {
+	r := newTestReservation(t, time.Hour, 2*time.Hour)
+	r.Confirm(nil, decimal.RequireFromString("10.05"))
+	fifty := decimal.NewFromInt(50)
+
+	if fee := r.CancellationFee(r.StartsAt.Add(-time.Minute), fifty); !fee.IsZero() {
+		t.Errorf("fee before start = %s, want 0", fee)
+	}
+	fee := r.CancellationFee(r.StartsAt, fifty)
+	if !fee.Equal(decimal.RequireFromString("5.03")) {
+		t.Errorf("fee after start = %s, want 5.03", fee)
+	}
+
+	if err := r.MarkNoShow(fee, nil); err != nil {
+		t.Fatalf("MarkNoShow() error = %v", err)
+	}
+	if !r.Refund().Equal(decimal.RequireFromString("5.02")) {
+		t.Errorf("Refund() = %s, want 5.02", r.Refund())
+	}
+}
+
+func TestReservation_Transitions(t *testing.T) {
+	r := newTestReservation(t, time.Hour, 2*time.Hour)
+
+	if err := r.Convert(uuid.New()); !errors.Is(err, ErrReservationClosed) {
+		t.Errorf("Convert() on pending error = %v, want %v", err, ErrReservationClosed)
+	}
+
+	r.Confirm(nil, decimal.Zero)
+	sessionID := uuid.New()
+	if err := r.Convert(sessionID); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if r.Status != ReservationStatusConverted || *r.SessionID != sessionID {
+		t.Errorf("reservation = %+v, want converted to %s", r, sessionID)
+	}
+	if err := r.Cancel(decimal.Zero, nil); !errors.Is(err, ErrReservationClosed) {
+		t.Errorf("Cancel() after arrival error = %v, want %v", err, ErrReservationClosed)
+	}
+	if r.IsNoShow(r.EndsAt) {
+		t.Error("IsNoShow() = true for a converted reservation")
+	}
+
+	r.Complete(nil)
+	if r.Status != ReservationStatusCompleted || r.ClosedAt == nil {
+		t.Errorf("reservation = %+v, want completed", r)
+	}
+}
+
+func TestNewLocationAvailability(t *testing.T) {
+	now := time.Now()
+	if a := NewLocationAvailability(uuid.New(), now, now.Add(time.Hour), 10, 4); a.Available != 6 {
+		t.Errorf("Available = %d, want 6", a.Available)
+	}
+	if a := NewLocationAvailability(uuid.New(), now, now.Add(time.Hour), 0, 4); a.Available != 0 {
+		t.Errorf("Available = %d, want 0 when overbooked", a.Available)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
 	"github.com/shopspring/decimal"
 )
 
@@ -14,13 +15,17 @@ var (
 	ErrSessionStillActive    = errors.New("session is still active")
 	ErrInvalidVehiclePlate   = errors.New("invalid vehicle plate number")
 	ErrInvalidSessionDuration = errors.New("invalid session duration")
+	ErrSessionAccessDenied   = errors.New("session belongs to another user")
+	ErrStreamLimitReached    = errors.New("too many open session streams")
 )
 
 // SessionStatus represents the current state of a parking session
 type SessionStatus string
 
 const (
+	SessionStatusPending   SessionStatus = "pending"
 	SessionStatusActive    SessionStatus = "active"
+	SessionStatusEnding    SessionStatus = "ending"
 	SessionStatusCompleted SessionStatus = "completed"
 	SessionStatusCancelled SessionStatus = "cancelled"
 	SessionStatusFailed    SessionStatus = "failed"
@@ -31,6 +36,7 @@ const (
 type ParkingSession struct {
 	ID                uuid.UUID       `json:"id"`
 	UserID            uuid.UUID       `json:"user_id"`
+	GuestDeviceID     string          `json:"guest_device_id,omitempty"`
 	ProviderID        uuid.UUID       `json:"provider_id"`
 	LocationID        uuid.UUID       `json:"location_id"`
 	ExternalSessionID string          `json:"external_session_id"`
@@ -40,14 +46,23 @@ type ParkingSession struct {
 	ExitTime          *time.Time      `json:"exit_time,omitempty"`
 	Duration          int             `json:"duration_minutes"`
 	Amount            decimal.Decimal `json:"amount"`
+	TaxAmount         decimal.Decimal `json:"tax_amount"`
+	Taxes             []TaxComponent  `json:"taxes,omitempty"`
 	Currency          string          `json:"currency"`
 	Status            SessionStatus   `json:"status"`
+	PaymentStatus     PaymentStatus   `json:"payment_status"`
 	PaymentID         *uuid.UUID      `json:"payment_id,omitempty"`
+	PaymentCard       string          `json:"-"` // Fingerprint of the card a guest's charge was attempted with
+	SubscriptionID    *uuid.UUID      `json:"subscription_id,omitempty"`
+	ReservationID     *uuid.UUID      `json:"reservation_id,omitempty"`
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
+
+	transitions []SessionTransition
 }
 
-// NewParkingSession creates a new active parking session
+// NewParkingSession creates a pending parking session, activated once the
+// provider has started it
 func NewParkingSession(
 	userID, providerID, locationID uuid.UUID,
 	vehiclePlate, vehicleType string,
@@ -67,7 +82,8 @@ func NewParkingSession(
 		EntryTime:    now,
 		Amount:       decimal.Zero,
 		Currency:     "MYR",
-		Status:       SessionStatusActive,
+		Status:       SessionStatusPending,
+		PaymentStatus: PaymentStatusNone,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
@@ -83,45 +99,71 @@ func (s *ParkingSession) IsCompleted() bool {
 	return s.Status == SessionStatusCompleted
 }
 
-// SetExternalSessionID sets the session ID from the provider's system
-func (s *ParkingSession) SetExternalSessionID(externalID string) {
-	s.ExternalSessionID = externalID
-	s.UpdatedAt = time.Now().UTC()
-}
-
-// End completes the parking session with the final amount
+// End stops the clock on an active session and bills it the final amount.
+// The session is ending until its payment settles.
 func (s *ParkingSession) End(amount decimal.Decimal) error {
 	if !s.IsActive() {
 		return ErrSessionAlreadyEnded
 	}
+	if err := s.transition(SessionStatusEnding); err != nil {
+		return err
+	}
 
-	now := time.Now().UTC()
-	s.ExitTime = &now
-	s.Duration = int(now.Sub(s.EntryTime).Minutes())
+	exit := s.UpdatedAt
+	s.ExitTime = &exit
+	s.Duration = int(exit.Sub(s.EntryTime).Minutes())
 	s.Amount = amount
-	s.Status = SessionStatusCompleted
-	s.UpdatedAt = now
+	s.PaymentStatus = PaymentStatusPending
 
 	return nil
 }
 
-// Cancel cancels an active session
+// Cancel cancels a session that has not ended
 func (s *ParkingSession) Cancel() error {
-	if !s.IsActive() {
+	if !s.Status.CanTransitionTo(SessionStatusCancelled) {
 		return ErrSessionAlreadyEnded
 	}
+	if err := s.transition(SessionStatusCancelled); err != nil {
+		return err
+	}
 
-	now := time.Now().UTC()
-	s.ExitTime = &now
-	s.Status = SessionStatusCancelled
-	s.UpdatedAt = now
+	exit := s.UpdatedAt
+	s.ExitTime = &exit
 
 	return nil
 }
 
-// MarkPaid records the payment for this session
-func (s *ParkingSession) MarkPaid(paymentID uuid.UUID) {
+// MarkPaid records the payment for an ending session and completes it
+func (s *ParkingSession) MarkPaid(paymentID uuid.UUID) error {
+	if err := s.settle(PaymentStatusPaid); err != nil {
+		return err
+	}
 	s.PaymentID = &paymentID
+	return nil
+}
+
+// CoverBySubscription settles an ending session under a season pass. Nothing
+// is charged, so the session never gets a payment.
+func (s *ParkingSession) CoverBySubscription(subscriptionID uuid.UUID) error {
+	if err := s.Waive(); err != nil {
+		return err
+	}
+	s.SubscriptionID = &subscriptionID
+	s.Amount = decimal.Zero
+	s.TaxAmount = decimal.Zero
+	s.Taxes = nil
+	return nil
+}
+
+// IsCoveredBySubscription returns true if a season pass paid for the session
+func (s *ParkingSession) IsCoveredBySubscription() bool {
+	return s.SubscriptionID != nil
+}
+
+// FulfilReservation links the session to the reservation it was started
+// under, so its charge is taken from the reservation's hold
+func (s *ParkingSession) FulfilReservation(reservationID uuid.UUID) {
+	s.ReservationID = &reservationID
 	s.UpdatedAt = time.Now().UTC()
 }
 
@@ -136,22 +178,31 @@ func (s *ParkingSession) CalculateDuration() int {
 
 // CalculateAmount calculates the parking fee based on hourly rate
 func (s *ParkingSession) CalculateAmount(hourlyRate, dailyMax decimal.Decimal) decimal.Decimal {
-	duration := s.CalculateDuration()
-	hours := decimal.NewFromInt(int64(duration)).Div(decimal.NewFromInt(60))
+	return s.CalculateCharge(pricing.Tariff{HourlyRate: hourlyRate, DailyMax: dailyMax})
+}
 
-	// Round up to nearest hour for billing
-	if duration%60 > 0 {
-		hours = hours.Ceil()
+// CalculateCharge prices the session so far, or the whole stay once it has
+// ended, by the location's tariff
+func (s *ParkingSession) CalculateCharge(tariff pricing.Tariff) decimal.Decimal {
+	endTime := time.Now().UTC()
+	if s.ExitTime != nil {
+		endTime = *s.ExitTime
 	}
+	return tariff.Price(s.EntryTime, endTime)
+}
 
-	amount := hours.Mul(hourlyRate)
+// IsOwnedBy checks if the session belongs to the given user
+func (s *ParkingSession) IsOwnedBy(userID uuid.UUID) bool {
+	return !s.IsGuest() && s.UserID == userID
+}
 
-	// Cap at daily maximum
-	if amount.GreaterThan(dailyMax) && dailyMax.GreaterThan(decimal.Zero) {
-		amount = dailyMax
+// EndsWithin reports whether an active session will hit maxDuration within the given window
+func (s *ParkingSession) EndsWithin(maxDuration, window time.Duration, now time.Time) bool {
+	if !s.IsActive() || maxDuration <= 0 {
+		return false
 	}
-
-	return amount.Round(2)
+	remaining := s.EntryTime.Add(maxDuration).Sub(now)
+	return remaining > 0 && remaining <= window
 }
 
 // isValidPlate validates Malaysian vehicle plate format (basic validation)
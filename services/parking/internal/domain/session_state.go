@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSessionTransition = errors.New("invalid session status transition")
+
+// PaymentStatus tracks the charge of a session once it is billed
+type PaymentStatus string
+
+const (
+	PaymentStatusNone    PaymentStatus = "none" // Not billed yet
+	PaymentStatusPending PaymentStatus = "pending"
+	PaymentStatusPaid    PaymentStatus = "paid"
+	PaymentStatusWaived  PaymentStatus = "waived" // Nothing to charge, e.g. covered by a season pass
+	PaymentStatusFailed  PaymentStatus = "failed"
+)
+
+// sessionTransitions lists the statuses each status may move to. A session
+// is pending until the provider opens it, and ending while it is billed.
+// A failed session goes back to ending when its payment is retried.
+var sessionTransitions = map[SessionStatus][]SessionStatus{
+	SessionStatusPending: {SessionStatusActive, SessionStatusFailed, SessionStatusCancelled},
+	SessionStatusActive:  {SessionStatusEnding, SessionStatusCancelled},
+	SessionStatusEnding:  {SessionStatusCompleted, SessionStatusFailed},
+	SessionStatusFailed:  {SessionStatusEnding},
+}
+
+// CanTransitionTo reports whether a session may move from s to the given status
+func (s SessionStatus) CanTransitionTo(to SessionStatus) bool {
+	for _, allowed := range sessionTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// SessionTransition is one status change of a session
+type SessionTransition struct {
+	SessionID     uuid.UUID
+	From          SessionStatus
+	To            SessionStatus
+	PaymentStatus PaymentStatus
+	At            time.Time
+}
+
+// transition moves the session to the given status, recording the change
+func (s *ParkingSession) transition(to SessionStatus) error {
+	if !s.Status.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidSessionTransition, s.Status, to)
+	}
+	now := time.Now().UTC()
+	s.transitions = append(s.transitions, SessionTransition{
+		SessionID: s.ID,
+		From:      s.Status,
+		To:        to,
+		At:        now,
+	})
+	s.Status = to
+	s.UpdatedAt = now
+	return nil
+}
+
+// TakeTransitions returns the status changes made since the last call, with
+// the payment status the session had after all of them
+func (s *ParkingSession) TakeTransitions() []SessionTransition {
+	transitions := s.transitions
+	s.transitions = nil
+	for i := range transitions {
+		transitions[i].PaymentStatus = s.PaymentStatus
+	}
+	return transitions
+}
+
+// Activate opens a pending session once the provider has started it
+func (s *ParkingSession) Activate(externalID string) error {
+	if err := s.transition(SessionStatusActive); err != nil {
+		return err
+	}
+	s.ExternalSessionID = externalID
+	return nil
+}
+
+// FailStart closes a pending session the provider could not start
+func (s *ParkingSession) FailStart() error {
+	if s.Status != SessionStatusPending {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidSessionTransition, s.Status, SessionStatusFailed)
+	}
+	return s.transition(SessionStatusFailed)
+}
+
+// settle completes an ending session with the outcome of its charge
+func (s *ParkingSession) settle(payment PaymentStatus) error {
+	if err := s.transition(SessionStatusCompleted); err != nil {
+		return err
+	}
+	s.PaymentStatus = payment
+	return nil
+}
+
+// Waive completes an ending session without charging it
+func (s *ParkingSession) Waive() error {
+	return s.settle(PaymentStatusWaived)
+}
+
+// FailPayment marks an ending session whose charge did not go through. Its
+// payment can be retried.
+func (s *ParkingSession) FailPayment() error {
+	if err := s.transition(SessionStatusFailed); err != nil {
+		return err
+	}
+	s.PaymentStatus = PaymentStatusFailed
+	return nil
+}
+
+// CanRetryPayment reports whether the session ended but could not be paid for
+func (s *ParkingSession) CanRetryPayment() bool {
+	return s.Status == SessionStatusFailed && s.PaymentStatus == PaymentStatusFailed
+}
+
+// RetryPayment moves a session whose payment failed back to ending, to be
+// charged the amount it was billed
+func (s *ParkingSession) RetryPayment() error {
+	if !s.CanRetryPayment() {
+		return fmt.Errorf("%w: %s to %s", ErrInvalidSessionTransition, s.Status, SessionStatusEnding)
+	}
+	if err := s.transition(SessionStatusEnding); err != nil {
+		return err
+	}
+	s.PaymentStatus = PaymentStatusPending
+	return nil
+}
+
+// IsEnding returns true while an ended session is being billed
+func (s *ParkingSession) IsEnding() bool {
+	return s.Status == SessionStatusEnding
+}
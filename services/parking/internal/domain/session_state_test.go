@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// activeSession returns a session the provider has started
+func activeSession(t *testing.T) *ParkingSession {
+	t.Helper()
+	session, err := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := session.Activate("ext-1"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	session.TakeTransitions()
+	return session
+}
+
+func TestSessionStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from SessionStatus
+		to   SessionStatus
+		want bool
+	}{
+		{SessionStatusPending, SessionStatusActive, true},
+		{SessionStatusPending, SessionStatusFailed, true},
+		{SessionStatusPending, SessionStatusEnding, false},
+		{SessionStatusActive, SessionStatusEnding, true},
+		{SessionStatusActive, SessionStatusCancelled, true},
+		{SessionStatusActive, SessionStatusCompleted, false},
+		{SessionStatusEnding, SessionStatusCompleted, true},
+		{SessionStatusEnding, SessionStatusFailed, true},
+		{SessionStatusEnding, SessionStatusCancelled, false},
+		{SessionStatusFailed, SessionStatusEnding, true},
+		{SessionStatusFailed, SessionStatusActive, false},
+		{SessionStatusCompleted, SessionStatusEnding, false},
+		{SessionStatusCancelled, SessionStatusActive, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParkingSession_Activate(t *testing.T) {
+	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	if err := session.Activate("ext-1"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+	if !session.IsActive() || session.ExternalSessionID != "ext-1" {
+		t.Errorf("expected active session ext-1, got %s %q", session.Status, session.ExternalSessionID)
+	}
+	if err := session.Activate("ext-2"); !errors.Is(err, ErrInvalidSessionTransition) {
+		t.Errorf("second Activate() error = %v, want ErrInvalidSessionTransition", err)
+	}
+}
+
+func TestParkingSession_FailStart(t *testing.T) {
+	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	if err := session.FailStart(); err != nil {
+		t.Fatalf("FailStart() error = %v", err)
+	}
+	if session.Status != SessionStatusFailed || session.CanRetryPayment() {
+		t.Errorf("a session that never started should be failed with nothing to retry, got %s/%s", session.Status, session.PaymentStatus)
+	}
+	if err := session.RetryPayment(); !errors.Is(err, ErrInvalidSessionTransition) {
+		t.Errorf("RetryPayment() error = %v, want ErrInvalidSessionTransition", err)
+	}
+
+	if err := activeSession(t).FailStart(); !errors.Is(err, ErrInvalidSessionTransition) {
+		t.Errorf("FailStart() on an active session error = %v, want ErrInvalidSessionTransition", err)
+	}
+}
+
+func TestParkingSession_RetryPayment(t *testing.T) {
+	session := activeSession(t)
+	amount := decimal.NewFromFloat(12.50)
+	session.End(amount)
+
+	if err := session.FailPayment(); err != nil {
+		t.Fatalf("FailPayment() error = %v", err)
+	}
+	if !session.CanRetryPayment() {
+		t.Fatal("a session whose payment failed should be retryable")
+	}
+	if err := session.RetryPayment(); err != nil {
+		t.Fatalf("RetryPayment() error = %v", err)
+	}
+	if !session.IsEnding() || session.PaymentStatus != PaymentStatusPending {
+		t.Errorf("expected ending with payment pending, got %s/%s", session.Status, session.PaymentStatus)
+	}
+	if !session.Amount.Equal(amount) {
+		t.Errorf("retry should keep the billed amount %s, got %s", amount, session.Amount)
+	}
+	if err := session.MarkPaid(uuid.New()); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	if session.CanRetryPayment() {
+		t.Error("a paid session should not be retryable")
+	}
+}
+
+func TestParkingSession_CoverBySubscription(t *testing.T) {
+	session := activeSession(t)
+	session.End(decimal.NewFromFloat(8))
+
+	if err := session.CoverBySubscription(uuid.New()); err != nil {
+		t.Fatalf("CoverBySubscription() error = %v", err)
+	}
+	if !session.IsCompleted() || session.PaymentStatus != PaymentStatusWaived || !session.Amount.IsZero() {
+		t.Errorf("expected completed, waived and free, got %s/%s %s", session.Status, session.PaymentStatus, session.Amount)
+	}
+}
+
+func TestParkingSession_TakeTransitions(t *testing.T) {
+	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session.Activate("ext-1")
+	session.End(decimal.NewFromFloat(5))
+	session.MarkPaid(uuid.New())
+
+	transitions := session.TakeTransitions()
+	want := []SessionStatus{SessionStatusActive, SessionStatusEnding, SessionStatusCompleted}
+	if len(transitions) != len(want) {
+		t.Fatalf("got %d transitions, want %d", len(transitions), len(want))
+	}
+	from := SessionStatusPending
+	for i, tr := range transitions {
+		if tr.From != from || tr.To != want[i] || tr.SessionID != session.ID {
+			t.Errorf("transition %d = %s->%s, want %s->%s", i, tr.From, tr.To, from, want[i])
+		}
+		if tr.PaymentStatus != PaymentStatusPaid {
+			t.Errorf("transition %d payment status = %s, want paid", i, tr.PaymentStatus)
+		}
+		from = want[i]
+	}
+
+	if len(session.TakeTransitions()) != 0 {
+		t.Error("transitions should be cleared once taken")
+	}
+}
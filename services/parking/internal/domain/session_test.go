@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
 	"github.com/shopspring/decimal"
 )
 
@@ -27,11 +28,11 @@ func TestNewParkingSession(t *testing.T) {
 	if session.VehiclePlate != "WKL1234" {
 		t.Errorf("expected plate WKL1234, got %s", session.VehiclePlate)
 	}
-	if session.Status != SessionStatusActive {
-		t.Errorf("expected status active, got %s", session.Status)
+	if session.Status != SessionStatusPending {
+		t.Errorf("expected status pending, got %s", session.Status)
 	}
-	if !session.IsActive() {
-		t.Error("new session should be active")
+	if session.IsActive() {
+		t.Error("new session should not be active before the provider starts it")
 	}
 }
 
@@ -43,7 +44,7 @@ func TestNewParkingSession_InvalidPlate(t *testing.T) {
 }
 
 func TestParkingSession_End(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	amount := decimal.NewFromFloat(10.00)
 
 	err := session.End(amount)
@@ -51,8 +52,11 @@ func TestParkingSession_End(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if session.Status != SessionStatusCompleted {
-		t.Errorf("expected status completed, got %s", session.Status)
+	if session.Status != SessionStatusEnding {
+		t.Errorf("expected status ending, got %s", session.Status)
+	}
+	if session.PaymentStatus != PaymentStatusPending {
+		t.Errorf("expected payment status pending, got %s", session.PaymentStatus)
 	}
 	if session.ExitTime == nil {
 		t.Error("expected exit time to be set")
@@ -60,13 +64,13 @@ func TestParkingSession_End(t *testing.T) {
 	if !session.Amount.Equal(amount) {
 		t.Errorf("expected amount %s, got %s", amount.String(), session.Amount.String())
 	}
-	if !session.IsCompleted() {
-		t.Error("session should be completed")
+	if session.IsCompleted() {
+		t.Error("session should not be completed before it is paid")
 	}
 }
 
 func TestParkingSession_EndTwice(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	session.End(decimal.NewFromFloat(10.00))
 
 	err := session.End(decimal.NewFromFloat(20.00))
@@ -76,7 +80,7 @@ func TestParkingSession_EndTwice(t *testing.T) {
 }
 
 func TestParkingSession_Cancel(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 
 	err := session.Cancel()
 	if err != nil {
@@ -92,7 +96,7 @@ func TestParkingSession_Cancel(t *testing.T) {
 }
 
 func TestParkingSession_CancelEnded(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	session.End(decimal.NewFromFloat(10.00))
 
 	err := session.Cancel()
@@ -102,11 +106,20 @@ func TestParkingSession_CancelEnded(t *testing.T) {
 }
 
 func TestParkingSession_MarkPaid(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	paymentID := uuid.New()
 
-	session.MarkPaid(paymentID)
+	if err := session.MarkPaid(paymentID); err == nil {
+		t.Error("expected an active session to refuse payment before it ends")
+	}
+	session.End(decimal.NewFromFloat(10.00))
+	if err := session.MarkPaid(paymentID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 
+	if !session.IsCompleted() || session.PaymentStatus != PaymentStatusPaid {
+		t.Errorf("expected completed and paid, got %s/%s", session.Status, session.PaymentStatus)
+	}
 	if session.PaymentID == nil {
 		t.Error("expected payment ID to be set")
 	}
@@ -116,7 +129,7 @@ func TestParkingSession_MarkPaid(t *testing.T) {
 }
 
 func TestParkingSession_CalculateDuration(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	session.EntryTime = time.Now().Add(-30 * time.Minute)
 
 	duration := session.CalculateDuration()
@@ -126,7 +139,7 @@ func TestParkingSession_CalculateDuration(t *testing.T) {
 }
 
 func TestParkingSession_CalculateAmount(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	session.EntryTime = time.Now().Add(-90 * time.Minute) // 1.5 hours
 
 	hourlyRate := decimal.NewFromFloat(5.00)
@@ -142,7 +155,7 @@ func TestParkingSession_CalculateAmount(t *testing.T) {
 }
 
 func TestParkingSession_CalculateAmount_DailyCap(t *testing.T) {
-	session, _ := NewParkingSession(uuid.New(), uuid.New(), uuid.New(), "ABC123", "car")
+	session := activeSession(t)
 	session.EntryTime = time.Now().Add(-12 * time.Hour)
 
 	hourlyRate := decimal.NewFromFloat(10.00)
@@ -156,6 +169,79 @@ func TestParkingSession_CalculateAmount_DailyCap(t *testing.T) {
 	}
 }
 
+func TestParkingSession_CalculateCharge_Schedule(t *testing.T) {
+	kl, err := time.LoadLocation("Asia/Kuala_Lumpur")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session := activeSession(t)
+	// Monday 20:00 to Tuesday 08:00 in Kuala Lumpur
+	session.EntryTime = time.Date(2026, 10, 12, 20, 0, 0, 0, kl).UTC()
+	exit := time.Date(2026, 10, 13, 8, 0, 0, 0, kl).UTC()
+	session.ExitTime = &exit
+
+	tariff := pricing.Tariff{
+		HourlyRate: decimal.NewFromFloat(3.00),
+		DailyMax:   decimal.NewFromFloat(50.00),
+		Schedule: &pricing.Schedule{
+			Timezone: "Asia/Kuala_Lumpur",
+			Rules: []pricing.Rule{
+				{Name: "night", Kind: pricing.RuleFlat, Start: "22:00", End: "07:00", FlatRate: decimal.NewFromFloat(5.00)},
+			},
+		},
+	}
+
+	// 20:00 and 21:00 at the hourly rate, one night flat rate, then 07:00
+	amount := session.CalculateCharge(tariff)
+	expected := decimal.NewFromFloat(14.00)
+	if !amount.Equal(expected) {
+		t.Errorf("expected amount %s, got %s", expected.String(), amount.String())
+	}
+}
+
+func TestParkingSession_IsOwnedBy(t *testing.T) {
+	userID := uuid.New()
+	session, _ := NewParkingSession(userID, uuid.New(), uuid.New(), "WKL1234", "car")
+
+	if !session.IsOwnedBy(userID) {
+		t.Error("session should be owned by its user")
+	}
+	if session.IsOwnedBy(uuid.New()) {
+		t.Error("session should not be owned by another user")
+	}
+}
+
+func TestParkingSession_EndsWithin(t *testing.T) {
+	session := activeSession(t)
+	session.EntryTime = time.Now().Add(-23*time.Hour - 50*time.Minute)
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		maxDuration time.Duration
+		window      time.Duration
+		want        bool
+	}{
+		{"inside warning window", 24 * time.Hour, 15 * time.Minute, true},
+		{"outside warning window", 24 * time.Hour, 5 * time.Minute, false},
+		{"already past max duration", 23 * time.Hour, 15 * time.Minute, false},
+		{"no max duration", 0, 15 * time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := session.EndsWithin(tt.maxDuration, tt.window, now); got != tt.want {
+				t.Errorf("EndsWithin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	session.Cancel()
+	if session.EndsWithin(24*time.Hour, 15*time.Minute, now) {
+		t.Error("inactive session should never be ending")
+	}
+}
+
 func TestIsValidPlate(t *testing.T) {
 	tests := []struct {
 		plate string
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrZoneNotFound               = errors.New("street zone not found")
+	ErrInvalidZone                = errors.New("invalid street zone")
+	ErrZoneExists                 = errors.New("street zone code already in use")
+	ErrZoneInactive               = errors.New("street zone is not taking payments")
+	ErrInvalidStreetDuration      = errors.New("duration must be a positive number of half hours")
+	ErrStreetDurationTooLong      = errors.New("duration exceeds the zone's maximum stay")
+	ErrStreetSessionNotFound      = errors.New("street session not found")
+	ErrStreetSessionExists        = errors.New("vehicle already has a street session in this zone")
+	ErrStreetSessionAccessDenied  = errors.New("street session belongs to another user")
+	ErrStreetSessionExpired       = errors.New("street session has expired")
+	ErrStreetSessionNotExtendable = errors.New("street session is not active")
+)
+
+// StreetBlock is the unit street parking is sold in
+const StreetBlock = 30 * time.Minute
+
+// StreetPaymentPrefix marks wallet payments for street sessions
+const StreetPaymentPrefix = "street-"
+
+// StreetPaymentKey is the wallet idempotency key for one purchase of time on
+// a street session. Purchase 0 starts the session; each extension adds one.
+func StreetPaymentKey(sessionID uuid.UUID, purchase int) string {
+	return fmt.Sprintf("%s%s-%d", StreetPaymentPrefix, sessionID, purchase)
+}
+
+// StreetZone is a council street parking area charged at a fixed rate per
+// half hour, with no barrier or entry record
+type StreetZone struct {
+	ID              uuid.UUID       `json:"id"`
+	ProviderID      uuid.UUID       `json:"provider_id"`
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	RatePerHalfHour decimal.Decimal `json:"rate_per_half_hour"`
+	Currency        string          `json:"currency"`
+	MaxMinutes      int             `json:"max_minutes"`
+	Active          bool            `json:"active"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// NewStreetZone creates a zone that takes payments immediately. The code is
+// what is printed on the street signs.
+func NewStreetZone(providerID uuid.UUID, code, name string, ratePerHalfHour decimal.Decimal, maxMinutes int) (*StreetZone, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if providerID == uuid.Nil || code == "" || strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidZone
+	}
+	if !ratePerHalfHour.IsPositive() || maxMinutes <= 0 || maxMinutes%int(StreetBlock.Minutes()) != 0 {
+		return nil, ErrInvalidZone
+	}
+
+	now := time.Now().UTC()
+	return &StreetZone{
+		ID:              uuid.New(),
+		ProviderID:      providerID,
+		Code:            code,
+		Name:            strings.TrimSpace(name),
+		RatePerHalfHour: ratePerHalfHour,
+		Currency:        "MYR",
+		MaxMinutes:      maxMinutes,
+		Active:          true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// Price is the charge for minutes of parking in the zone
+func (z *StreetZone) Price(minutes int) (decimal.Decimal, error) {
+	block := int(StreetBlock.Minutes())
+	if minutes <= 0 || minutes%block != 0 {
+		return decimal.Zero, ErrInvalidStreetDuration
+	}
+	return z.RatePerHalfHour.Mul(decimal.NewFromInt(int64(minutes / block))).Round(2), nil
+}
+
+// Deactivate stops the zone taking new payments. Running sessions stay
+// valid until they expire.
+func (z *StreetZone) Deactivate() {
+	z.Active = false
+	z.UpdatedAt = time.Now().UTC()
+}
+
+// StreetSessionStatus represents where a street session is in its lifecycle
+type StreetSessionStatus string
+
+const (
+	// StreetSessionStatusPending is waiting for its wallet payment
+	StreetSessionStatusPending StreetSessionStatus = "pending"
+	StreetSessionStatusActive  StreetSessionStatus = "active"
+	StreetSessionStatusExpired StreetSessionStatus = "expired"
+	StreetSessionStatusFailed  StreetSessionStatus = "failed"
+)
+
+// StreetSession is prepaid time in a street zone. The user picks the duration
+// upfront and pays before parking; more time can be bought until it expires.
+type StreetSession struct {
+	ID             uuid.UUID           `json:"id"`
+	UserID         uuid.UUID           `json:"user_id"`
+	ZoneID         uuid.UUID           `json:"zone_id"`
+	ProviderID     uuid.UUID           `json:"provider_id"`
+	WalletID       uuid.UUID           `json:"wallet_id"`
+	VehiclePlate   string              `json:"vehicle_plate"`
+	StartsAt       time.Time           `json:"starts_at"`
+	ExpiresAt      time.Time           `json:"expires_at"`
+	PaidMinutes    int                 `json:"paid_minutes"`
+	Amount         decimal.Decimal     `json:"amount"`
+	Currency       string              `json:"currency"`
+	Status         StreetSessionStatus `json:"status"`
+	Extensions     int                 `json:"extensions"`
+	PaymentID      *uuid.UUID          `json:"payment_id,omitempty"`
+	ReminderSentAt *time.Time          `json:"reminder_sent_at,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// NewStreetSession starts a pending session for minutes in zone, priced at
+// the zone's current rate. It runs from now once paid.
+func NewStreetSession(zone *StreetZone, userID, walletID uuid.UUID, vehiclePlate string, minutes int) (*StreetSession, error) {
+	if !zone.Active {
+		return nil, ErrZoneInactive
+	}
+	plate := NormalizePlate(vehiclePlate)
+	if !isValidPlate(plate) {
+		return nil, ErrInvalidVehiclePlate
+	}
+	amount, err := zone.Price(minutes)
+	if err != nil {
+		return nil, err
+	}
+	if minutes > zone.MaxMinutes {
+		return nil, ErrStreetDurationTooLong
+	}
+
+	now := time.Now().UTC()
+	return &StreetSession{
+		ID:           uuid.New(),
+		UserID:       userID,
+		ZoneID:       zone.ID,
+		ProviderID:   zone.ProviderID,
+		WalletID:     walletID,
+		VehiclePlate: plate,
+		StartsAt:     now,
+		ExpiresAt:    now.Add(time.Duration(minutes) * time.Minute),
+		PaidMinutes:  minutes,
+		Amount:       amount,
+		Currency:     zone.Currency,
+		Status:       StreetSessionStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Activate records the payment for the session's first block of time
+func (s *StreetSession) Activate(paymentID uuid.UUID) {
+	s.Status = StreetSessionStatusActive
+	s.PaymentID = &paymentID
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// Fail marks a session whose payment did not go through
+func (s *StreetSession) Fail() {
+	s.Status = StreetSessionStatusFailed
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// IsActiveAt reports whether the vehicle is covered at the given time
+func (s *StreetSession) IsActiveAt(at time.Time) bool {
+	return s.Status == StreetSessionStatusActive && at.Before(s.ExpiresAt)
+}
+
+// ExtensionPrice checks that minutes more can be bought at the given time and
+// returns what they cost. Time can only be added before the session runs out
+// and up to the zone's maximum stay.
+func (s *StreetSession) ExtensionPrice(zone *StreetZone, minutes int, now time.Time) (decimal.Decimal, error) {
+	if s.Status != StreetSessionStatusActive {
+		return decimal.Zero, ErrStreetSessionNotExtendable
+	}
+	if !now.Before(s.ExpiresAt) {
+		return decimal.Zero, ErrStreetSessionExpired
+	}
+	if !zone.Active {
+		return decimal.Zero, ErrZoneInactive
+	}
+	amount, err := zone.Price(minutes)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if s.PaidMinutes+minutes > zone.MaxMinutes {
+		return decimal.Zero, ErrStreetDurationTooLong
+	}
+	return amount, nil
+}
+
+// NextPaymentKey is the idempotency key for the next extension payment
+func (s *StreetSession) NextPaymentKey() string {
+	return StreetPaymentKey(s.ID, s.Extensions+1)
+}
+
+// Extend adds paid time to the end of the session. A new reminder is due
+// before the new expiry.
+func (s *StreetSession) Extend(minutes int, amount decimal.Decimal, paymentID uuid.UUID) {
+	s.ExpiresAt = s.ExpiresAt.Add(time.Duration(minutes) * time.Minute)
+	s.PaidMinutes += minutes
+	s.Amount = s.Amount.Add(amount)
+	s.Extensions++
+	s.PaymentID = &paymentID
+	s.ReminderSentAt = nil
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// MarkReminded records that the user was told the session is about to expire
+func (s *StreetSession) MarkReminded(now time.Time) {
+	s.ReminderSentAt = &now
+	s.UpdatedAt = now
+}
+
+// Expire ends a session whose paid time has run out
+func (s *StreetSession) Expire() {
+	s.Status = StreetSessionStatusExpired
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// IsOwnedBy checks if the street session belongs to the given user
+func (s *StreetSession) IsOwnedBy(userID uuid.UUID) bool {
+	return s.UserID == userID
+}
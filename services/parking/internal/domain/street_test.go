@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func newTestZone(t *testing.T) *StreetZone {
+	t.Helper()
+	zone, err := NewStreetZone(uuid.New(), " kl-01 ", "Jalan Ampang", decimal.RequireFromString("0.60"), 180)
+	if err != nil {
+		t.Fatalf("NewStreetZone() error = %v", err)
+	}
+	return zone
+}
+
+func TestNewStreetZone(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID uuid.UUID
+		code       string
+		rate       string
+		maxMinutes int
+		wantErr    error
+	}{
+		{"three hour zone", uuid.New(), "KL-01", "0.60", 180, nil},
+		{"no council", uuid.Nil, "KL-01", "0.60", 180, ErrInvalidZone},
+		{"blank code", uuid.New(), " ", "0.60", 180, ErrInvalidZone},
+		{"free zone", uuid.New(), "KL-01", "0", 180, ErrInvalidZone},
+		{"no maximum stay", uuid.New(), "KL-01", "0.60", 0, ErrInvalidZone},
+		{"maximum stay not in half hours", uuid.New(), "KL-01", "0.60", 100, ErrInvalidZone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStreetZone(tt.providerID, tt.code, "Jalan Ampang", decimal.RequireFromString(tt.rate), tt.maxMinutes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewStreetZone() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStreetZone_Price(t *testing.T) {
+	zone := newTestZone(t)
+	if zone.Code != "KL-01" {
+		t.Errorf("Code = %q, want KL-01", zone.Code)
+	}
+
+	tests := []struct {
+		minutes int
+		want    string
+		wantErr error
+	}{
+		{30, "0.6", nil},
+		{120, "2.4", nil},
+		{0, "0", ErrInvalidStreetDuration},
+		{45, "0", ErrInvalidStreetDuration},
+		{-30, "0", ErrInvalidStreetDuration},
+	}
+
+	for _, tt := range tests {
+		got, err := zone.Price(tt.minutes)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("Price(%d) error = %v, want %v", tt.minutes, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("Price(%d) = %s, want %s", tt.minutes, got, tt.want)
+		}
+	}
+}
+
+func TestNewStreetSession(t *testing.T) {
+	zone := newTestZone(t)
+
+	session, err := NewStreetSession(zone, uuid.New(), uuid.New(), "wxy 1234", 60)
+	if err != nil {
+		t.Fatalf("NewStreetSession() error = %v", err)
+	}
+	if session.Status != StreetSessionStatusPending || session.VehiclePlate != "WXY1234" {
+		t.Errorf("session = %+v, want pending with a normalised plate", session)
+	}
+	if session.ExpiresAt.Sub(session.StartsAt) != time.Hour || !session.Amount.Equal(decimal.RequireFromString("1.2")) {
+		t.Errorf("session runs %v for %s, want 1h for 1.2", session.ExpiresAt.Sub(session.StartsAt), session.Amount)
+	}
+
+	if _, err := NewStreetSession(zone, uuid.New(), uuid.New(), "WXY1234", 210); !errors.Is(err, ErrStreetDurationTooLong) {
+		t.Errorf("over maximum stay error = %v, want %v", err, ErrStreetDurationTooLong)
+	}
+
+	zone.Deactivate()
+	if _, err := NewStreetSession(zone, uuid.New(), uuid.New(), "WXY1234", 60); !errors.Is(err, ErrZoneInactive) {
+		t.Errorf("inactive zone error = %v, want %v", err, ErrZoneInactive)
+	}
+}
+
+func TestStreetSession_Extend(t *testing.T) {
+	zone := newTestZone(t)
+	session, err := NewStreetSession(zone, uuid.New(), uuid.New(), "WXY1234", 60)
+	if err != nil {
+		t.Fatalf("NewStreetSession() error = %v", err)
+	}
+	now := session.StartsAt.Add(50 * time.Minute)
+
+	if _, err := session.ExtensionPrice(zone, 30, now); !errors.Is(err, ErrStreetSessionNotExtendable) {
+		t.Errorf("pending session error = %v, want %v", err, ErrStreetSessionNotExtendable)
+	}
+
+	session.Activate(uuid.New())
+	session.MarkReminded(now)
+	if session.NextPaymentKey() != StreetPaymentKey(session.ID, 1) {
+		t.Errorf("NextPaymentKey() = %s", session.NextPaymentKey())
+	}
+
+	amount, err := session.ExtensionPrice(zone, 60, now)
+	if err != nil {
+		t.Fatalf("ExtensionPrice() error = %v", err)
+	}
+	expiresAt := session.ExpiresAt
+	session.Extend(60, amount, uuid.New())
+
+	if !session.ExpiresAt.Equal(expiresAt.Add(time.Hour)) || session.PaidMinutes != 120 || session.Extensions != 1 {
+		t.Errorf("session = %+v, want one hour added", session)
+	}
+	if session.ReminderSentAt != nil {
+		t.Error("ReminderSentAt not cleared by Extend()")
+	}
+	if !session.Amount.Equal(decimal.RequireFromString("2.4")) {
+		t.Errorf("Amount = %s, want 2.4", session.Amount)
+	}
+
+	if _, err := session.ExtensionPrice(zone, 90, now); !errors.Is(err, ErrStreetDurationTooLong) {
+		t.Errorf("past maximum stay error = %v, want %v", err, ErrStreetDurationTooLong)
+	}
+	if _, err := session.ExtensionPrice(zone, 30, session.ExpiresAt); !errors.Is(err, ErrStreetSessionExpired) {
+		t.Errorf("expired session error = %v, want %v", err, ErrStreetSessionExpired)
+	}
+	if session.IsActiveAt(session.ExpiresAt) {
+		t.Error("IsActiveAt(expiry) = true, want false")
+	}
+}
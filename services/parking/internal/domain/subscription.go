@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPlanNotFound             = errors.New("subscription plan not found")
+	ErrInvalidPlan              = errors.New("invalid subscription plan")
+	ErrPlanInactive             = errors.New("subscription plan is no longer sold")
+	ErrSubscriptionNotFound     = errors.New("subscription not found")
+	ErrSubscriptionExists       = errors.New("vehicle already has this pass")
+	ErrSubscriptionAccessDenied = errors.New("subscription belongs to another user")
+	ErrSubscriptionInactive     = errors.New("subscription is not active")
+)
+
+// SubscriptionPaymentPrefix marks wallet payments made for season passes. The
+// session consistency check only looks at PaymentIdempotencyPrefix payments.
+const SubscriptionPaymentPrefix = "subscription-"
+
+// SubscriptionPaymentKey is the wallet idempotency key for one period of a
+// subscription. Period 0 is the purchase; each renewal adds one.
+func SubscriptionPaymentKey(subscriptionID uuid.UUID, period int) string {
+	return fmt.Sprintf("%s%s-%d", SubscriptionPaymentPrefix, subscriptionID, period)
+}
+
+// SubscriptionPlan is a season pass a provider sells, valid at one of its
+// locations or, with no location, at all of them
+type SubscriptionPlan struct {
+	ID           uuid.UUID       `json:"id"`
+	ProviderID   uuid.UUID       `json:"provider_id"`
+	LocationID   *uuid.UUID      `json:"location_id,omitempty"`
+	Name         string          `json:"name"`
+	Description  string          `json:"description,omitempty"`
+	Price        decimal.Decimal `json:"price"`
+	DurationDays int             `json:"duration_days"`
+	Active       bool            `json:"active"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// NewSubscriptionPlan creates a plan that is on sale immediately
+func NewSubscriptionPlan(providerID uuid.UUID, locationID *uuid.UUID, name string, price decimal.Decimal, durationDays int) (*SubscriptionPlan, error) {
+	if providerID == uuid.Nil || strings.TrimSpace(name) == "" || !price.IsPositive() || durationDays <= 0 {
+		return nil, ErrInvalidPlan
+	}
+
+	now := time.Now().UTC()
+	return &SubscriptionPlan{
+		ID:           uuid.New(),
+		ProviderID:   providerID,
+		LocationID:   locationID,
+		Name:         strings.TrimSpace(name),
+		Price:        price,
+		DurationDays: durationDays,
+		Active:       true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Period is how long one purchase or renewal of the plan lasts
+func (p *SubscriptionPlan) Period() time.Duration {
+	return time.Duration(p.DurationDays) * 24 * time.Hour
+}
+
+// Deactivate takes the plan off sale. Existing passes stay valid but are not
+// renewed.
+func (p *SubscriptionPlan) Deactivate() {
+	p.Active = false
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// SubscriptionStatus represents where a pass is in its lifecycle
+type SubscriptionStatus string
+
+const (
+	// SubscriptionStatusPending is a purchase waiting for its wallet payment
+	SubscriptionStatusPending SubscriptionStatus = "pending"
+	SubscriptionStatusActive  SubscriptionStatus = "active"
+	SubscriptionStatusExpired SubscriptionStatus = "expired"
+	SubscriptionStatusFailed  SubscriptionStatus = "failed"
+)
+
+// Subscription is a user's season pass for one vehicle. Sessions for that
+// vehicle at the plan's locations are not charged while the pass is valid.
+type Subscription struct {
+	ID               uuid.UUID          `json:"id"`
+	UserID           uuid.UUID          `json:"user_id"`
+	PlanID           uuid.UUID          `json:"plan_id"`
+	ProviderID       uuid.UUID          `json:"provider_id"`
+	LocationID       *uuid.UUID         `json:"location_id,omitempty"`
+	VehiclePlate     string             `json:"vehicle_plate"`
+	WalletID         uuid.UUID          `json:"wallet_id"`
+	Status           SubscriptionStatus `json:"status"`
+	AutoRenew        bool               `json:"auto_renew"`
+	StartsAt         time.Time          `json:"starts_at"`
+	ExpiresAt        time.Time          `json:"expires_at"`
+	RenewalCount     int                `json:"renewal_count"`
+	PaymentID        *uuid.UUID         `json:"payment_id,omitempty"`
+	ExpiryNotifiedAt *time.Time         `json:"expiry_notified_at,omitempty"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// NewSubscription starts a pending pass on plan, valid from now for one
+// period once its payment goes through
+func NewSubscription(plan *SubscriptionPlan, userID, walletID uuid.UUID, vehiclePlate string, autoRenew bool) (*Subscription, error) {
+	if !plan.Active {
+		return nil, ErrPlanInactive
+	}
+	plate := NormalizePlate(vehiclePlate)
+	if !isValidPlate(plate) {
+		return nil, ErrInvalidVehiclePlate
+	}
+
+	now := time.Now().UTC()
+	return &Subscription{
+		ID:           uuid.New(),
+		UserID:       userID,
+		PlanID:       plan.ID,
+		ProviderID:   plan.ProviderID,
+		LocationID:   plan.LocationID,
+		VehiclePlate: plate,
+		WalletID:     walletID,
+		Status:       SubscriptionStatusPending,
+		AutoRenew:    autoRenew,
+		StartsAt:     now,
+		ExpiresAt:    now.Add(plan.Period()),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// Activate records the purchase payment
+func (s *Subscription) Activate(paymentID uuid.UUID) {
+	s.Status = SubscriptionStatusActive
+	s.PaymentID = &paymentID
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// Fail marks a purchase whose payment did not go through
+func (s *Subscription) Fail() {
+	s.Status = SubscriptionStatusFailed
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// Covers reports whether the pass pays for a session: same vehicle, one of
+// the plan's locations, and entry while the pass was valid. A session that
+// outlives the pass is still covered.
+func (s *Subscription) Covers(session *ParkingSession) bool {
+	if s.Status != SubscriptionStatusActive && s.Status != SubscriptionStatusExpired {
+		return false
+	}
+	if NormalizePlate(session.VehiclePlate) != s.VehiclePlate || session.ProviderID != s.ProviderID {
+		return false
+	}
+	if s.LocationID != nil && *s.LocationID != session.LocationID {
+		return false
+	}
+	return !session.EntryTime.Before(s.StartsAt) && session.EntryTime.Before(s.ExpiresAt)
+}
+
+// NextPaymentKey is the idempotency key for the next renewal payment
+func (s *Subscription) NextPaymentKey() string {
+	return SubscriptionPaymentKey(s.ID, s.RenewalCount+1)
+}
+
+// Renew extends the pass by one period of plan from its current expiry, so
+// renewing early does not lose any days
+func (s *Subscription) Renew(plan *SubscriptionPlan, paymentID uuid.UUID) error {
+	if s.Status != SubscriptionStatusActive {
+		return ErrSubscriptionInactive
+	}
+	s.ExpiresAt = s.ExpiresAt.Add(plan.Period())
+	s.RenewalCount++
+	s.PaymentID = &paymentID
+	s.ExpiryNotifiedAt = nil
+	s.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// CancelRenewal stops automatic renewal. The pass stays valid until it expires.
+func (s *Subscription) CancelRenewal() {
+	s.AutoRenew = false
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// Expire ends a pass whose validity has run out
+func (s *Subscription) Expire() {
+	s.Status = SubscriptionStatusExpired
+	s.UpdatedAt = time.Now().UTC()
+}
+
+// MarkExpiryNotified records that the user was told the pass is about to expire
+func (s *Subscription) MarkExpiryNotified(now time.Time) {
+	s.ExpiryNotifiedAt = &now
+	s.UpdatedAt = now
+}
+
+// IsOwnedBy checks if the subscription belongs to the given user
+func (s *Subscription) IsOwnedBy(userID uuid.UUID) bool {
+	return s.UserID == userID
+}
+
+// NormalizePlate upper-cases a plate and drops spaces, so "wxy 1234" and
+// "WXY1234" are the same vehicle
+func NormalizePlate(plate string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(plate), ""))
+}
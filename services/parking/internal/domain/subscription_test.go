@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewSubscriptionPlan(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID uuid.UUID
+		planName   string
+		price      string
+		days       int
+		wantErr    error
+	}{
+		{"monthly pass", uuid.New(), "Monthly", "150", 30, nil},
+		{"no provider", uuid.Nil, "Monthly", "150", 30, ErrInvalidPlan},
+		{"blank name", uuid.New(), "  ", "150", 30, ErrInvalidPlan},
+		{"free pass", uuid.New(), "Monthly", "0", 30, ErrInvalidPlan},
+		{"no duration", uuid.New(), "Monthly", "150", 0, ErrInvalidPlan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := NewSubscriptionPlan(tt.providerID, nil, tt.planName, decimal.RequireFromString(tt.price), tt.days)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSubscriptionPlan() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (!plan.Active || plan.Period() != time.Duration(tt.days)*24*time.Hour) {
+				t.Errorf("plan = %+v, want active with a %d day period", plan, tt.days)
+			}
+		})
+	}
+}
+
+func TestSubscription_Covers(t *testing.T) {
+	providerID := uuid.New()
+	locationID := uuid.New()
+	otherLocation := uuid.New()
+
+	plan, err := NewSubscriptionPlan(providerID, &locationID, "Monthly", decimal.NewFromInt(150), 30)
+	if err != nil {
+		t.Fatalf("NewSubscriptionPlan() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Subscription, session *ParkingSession)
+		want   bool
+	}{
+		{"same vehicle and location", func(s *Subscription, session *ParkingSession) {}, true},
+		{"plate written differently", func(s *Subscription, session *ParkingSession) { session.VehiclePlate = "wxy 1234" }, true},
+		{"expired pass, entry while valid", func(s *Subscription, session *ParkingSession) { s.Expire() }, true},
+		{"other vehicle", func(s *Subscription, session *ParkingSession) { session.VehiclePlate = "ABC999" }, false},
+		{"other location", func(s *Subscription, session *ParkingSession) { session.LocationID = otherLocation }, false},
+		{"other provider", func(s *Subscription, session *ParkingSession) { session.ProviderID = uuid.New() }, false},
+		{"entry before pass", func(s *Subscription, session *ParkingSession) {
+			session.EntryTime = s.StartsAt.Add(-time.Minute)
+		}, false},
+		{"entry after expiry", func(s *Subscription, session *ParkingSession) { session.EntryTime = s.ExpiresAt }, false},
+		{"unpaid purchase", func(s *Subscription, session *ParkingSession) { s.Status = SubscriptionStatusPending }, false},
+		{"failed purchase", func(s *Subscription, session *ParkingSession) { s.Fail() }, false},
+		{"provider-wide pass", func(s *Subscription, session *ParkingSession) {
+			s.LocationID = nil
+			session.LocationID = otherLocation
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewSubscription(plan, uuid.New(), uuid.New(), "WXY1234", true)
+			if err != nil {
+				t.Fatalf("NewSubscription() error = %v", err)
+			}
+			s.Activate(uuid.New())
+
+			session, err := NewParkingSession(s.UserID, providerID, locationID, "WXY1234", "car")
+			if err != nil {
+				t.Fatalf("NewParkingSession() error = %v", err)
+			}
+			session.EntryTime = s.StartsAt.Add(time.Hour)
+
+			tt.modify(s, session)
+			if got := s.Covers(session); got != tt.want {
+				t.Errorf("Covers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscription_Renew(t *testing.T) {
+	plan, err := NewSubscriptionPlan(uuid.New(), nil, "Monthly", decimal.NewFromInt(150), 30)
+	if err != nil {
+		t.Fatalf("NewSubscriptionPlan() error = %v", err)
+	}
+	s, err := NewSubscription(plan, uuid.New(), uuid.New(), "WXY1234", true)
+	if err != nil {
+		t.Fatalf("NewSubscription() error = %v", err)
+	}
+
+	if err := s.Renew(plan, uuid.New()); !errors.Is(err, ErrSubscriptionInactive) {
+		t.Fatalf("Renew() on pending pass error = %v, want %v", err, ErrSubscriptionInactive)
+	}
+
+	s.Activate(uuid.New())
+	s.MarkExpiryNotified(time.Now())
+	expiresAt := s.ExpiresAt
+	firstKey := s.NextPaymentKey()
+
+	if err := s.Renew(plan, uuid.New()); err != nil {
+		t.Fatalf("Renew() error = %v", err)
+	}
+	if !s.ExpiresAt.Equal(expiresAt.Add(plan.Period())) {
+		t.Errorf("ExpiresAt = %v, want %v", s.ExpiresAt, expiresAt.Add(plan.Period()))
+	}
+	if s.ExpiryNotifiedAt != nil {
+		t.Error("renewal should clear the expiry warning")
+	}
+	if s.NextPaymentKey() == firstKey || firstKey != SubscriptionPaymentKey(s.ID, 1) {
+		t.Errorf("payment keys %q then %q, want one per period", firstKey, s.NextPaymentKey())
+	}
+}
+
+func TestNewSubscription(t *testing.T) {
+	plan, err := NewSubscriptionPlan(uuid.New(), nil, "Monthly", decimal.NewFromInt(150), 30)
+	if err != nil {
+		t.Fatalf("NewSubscriptionPlan() error = %v", err)
+	}
+
+	s, err := NewSubscription(plan, uuid.New(), uuid.New(), " wxy 1234 ", false)
+	if err != nil {
+		t.Fatalf("NewSubscription() error = %v", err)
+	}
+	if s.VehiclePlate != "WXY1234" || s.Status != SubscriptionStatusPending {
+		t.Errorf("subscription = %+v, want pending pass for WXY1234", s)
+	}
+
+	if _, err := NewSubscription(plan, uuid.New(), uuid.New(), "X", false); !errors.Is(err, ErrInvalidVehiclePlate) {
+		t.Errorf("NewSubscription() with bad plate error = %v, want %v", err, ErrInvalidVehiclePlate)
+	}
+
+	plan.Deactivate()
+	if _, err := NewSubscription(plan, uuid.New(), uuid.New(), "WXY1234", false); !errors.Is(err, ErrPlanInactive) {
+		t.Errorf("NewSubscription() on inactive plan error = %v, want %v", err, ErrPlanInactive)
+	}
+}
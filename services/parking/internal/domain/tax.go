@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrTaxRateNotFound = errors.New("tax rate not found")
+	ErrInvalidTaxRate  = errors.New("tax needs a name and a rate from 0 to 1")
+)
+
+// TaxRate is a tax charged on parking at a provider's locations, e.g. SST at
+// 0.08. A rate with a LocationID applies only there; a location with rates of
+// its own ignores the provider-wide ones.
+type TaxRate struct {
+	ID         uuid.UUID       `json:"id"`
+	ProviderID uuid.UUID       `json:"provider_id"`
+	LocationID *uuid.UUID      `json:"location_id,omitempty"`
+	Name       string          `json:"name"`
+	Rate       decimal.Decimal `json:"rate"`
+	// Inclusive taxes are already in the provider's price; others are added
+	// on top of it
+	Inclusive bool      `json:"inclusive"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewTaxRate(providerID uuid.UUID, locationID *uuid.UUID, name string, rate decimal.Decimal, inclusive bool) (*TaxRate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, ErrInvalidTaxRate
+	}
+	now := time.Now().UTC()
+	return &TaxRate{
+		ID:         uuid.New(),
+		ProviderID: providerID,
+		LocationID: locationID,
+		Name:       name,
+		Rate:       rate,
+		Inclusive:  inclusive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+// TaxComponent is one tax in a charge: Amount of tax at Rate on Taxable
+type TaxComponent struct {
+	Name    string          `json:"name"`
+	Rate    decimal.Decimal `json:"rate"`
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// ApplicableTaxRates picks the rates that apply at a location from all of
+// its provider's rates
+func ApplicableTaxRates(rates []*TaxRate, locationID uuid.UUID) []*TaxRate {
+	var local, providerWide []*TaxRate
+	for _, rate := range rates {
+		switch {
+		case rate.LocationID == nil:
+			providerWide = append(providerWide, rate)
+		case *rate.LocationID == locationID:
+			local = append(local, rate)
+		}
+	}
+	if len(local) > 0 {
+		return local
+	}
+	return providerWide
+}
+
+// CalculateTax breaks a provider's price into its taxes. Inclusive taxes are
+// taken out of the price, leaving the taxable amount; other taxes are worked
+// out on that and added. It returns the taxes and the amount to charge.
+func CalculateTax(price decimal.Decimal, rates []*TaxRate) ([]TaxComponent, decimal.Decimal) {
+	if len(rates) == 0 || !price.IsPositive() {
+		return nil, price
+	}
+
+	inclusive := decimal.Zero
+	for _, rate := range rates {
+		if rate.Inclusive {
+			inclusive = inclusive.Add(rate.Rate)
+		}
+	}
+	taxable := price.Div(decimal.NewFromInt(1).Add(inclusive)).Round(2)
+
+	components := make([]TaxComponent, 0, len(rates))
+	charged := price
+	included := decimal.Zero
+	lastInclusive := -1
+	for _, rate := range rates {
+		amount := taxable.Mul(rate.Rate).Round(2)
+		if rate.Inclusive {
+			included = included.Add(amount)
+			lastInclusive = len(components)
+		} else {
+			charged = charged.Add(amount)
+		}
+		components = append(components, TaxComponent{Name: rate.Name, Rate: rate.Rate, Taxable: taxable, Amount: amount})
+	}
+	// Rounding each tax can leave a sen between the price and its parts;
+	// the last inclusive tax absorbs it so they always add up
+	if lastInclusive >= 0 {
+		diff := price.Sub(taxable).Sub(included)
+		components[lastInclusive].Amount = components[lastInclusive].Amount.Add(diff)
+	}
+	return components, charged
+}
+
+// ApplyTax bills an ending session's price with its taxes. The amount becomes
+// what the user is charged, including every tax.
+func (s *ParkingSession) ApplyTax(rates []*TaxRate) {
+	s.Taxes, s.Amount = CalculateTax(s.Amount, rates)
+	s.TaxAmount = TotalTax(s.Taxes)
+}
+
+// TotalTax adds up the taxes in a charge
+func TotalTax(taxes []TaxComponent) decimal.Decimal {
+	total := decimal.Zero
+	for _, tax := range taxes {
+		total = total.Add(tax.Amount)
+	}
+	return total
+}
+
+// TaxBreakdown describes a charge's taxes for receipts, e.g.
+// "SST 8%: RM 0.40"
+func TaxBreakdown(taxes []TaxComponent) string {
+	parts := make([]string, len(taxes))
+	for i, tax := range taxes {
+		parts[i] = fmt.Sprintf("%s %s%%: RM %s", tax.Name, tax.Rate.Shift(2).String(), tax.Amount.StringFixed(2))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewTaxRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		taxName string
+		rate    string
+		wantErr error
+	}{
+		{"sst", "SST", "0.08", nil},
+		{"zero rated", "SST", "0", nil},
+		{"no name", " ", "0.08", ErrInvalidTaxRate},
+		{"negative", "SST", "-0.01", ErrInvalidTaxRate},
+		{"percent instead of fraction", "SST", "8", ErrInvalidTaxRate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTaxRate(uuid.New(), nil, tt.taxName, decimal.RequireFromString(tt.rate), false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewTaxRate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplicableTaxRates(t *testing.T) {
+	provider := uuid.New()
+	location := uuid.New()
+	other := uuid.New()
+	sst, _ := NewTaxRate(provider, nil, "SST", decimal.RequireFromString("0.08"), false)
+	local, _ := NewTaxRate(provider, &location, "SST", decimal.RequireFromString("0.06"), false)
+
+	if got := ApplicableTaxRates([]*TaxRate{sst, local}, location); len(got) != 1 || got[0] != local {
+		t.Errorf("at location = %v, want only its own rate", got)
+	}
+	if got := ApplicableTaxRates([]*TaxRate{sst, local}, other); len(got) != 1 || got[0] != sst {
+		t.Errorf("elsewhere = %v, want the provider-wide rate", got)
+	}
+	if got := ApplicableTaxRates(nil, location); len(got) != 0 {
+		t.Errorf("without rates = %v, want none", got)
+	}
+}
+
+func TestCalculateTax(t *testing.T) {
+	rate := func(name, r string, inclusive bool) *TaxRate {
+		return &TaxRate{Name: name, Rate: decimal.RequireFromString(r), Inclusive: inclusive}
+	}
+
+	tests := []struct {
+		name        string
+		price       string
+		rates       []*TaxRate
+		wantCharged string
+		wantTaxes   []string
+	}{
+		{"exclusive", "5.00", []*TaxRate{rate("SST", "0.08", false)}, "5.40", []string{"0.40"}},
+		{"inclusive", "5.40", []*TaxRate{rate("SST", "0.08", true)}, "5.40", []string{"0.40"}},
+		{"inclusive remainder", "3.00", []*TaxRate{rate("SST", "0.06", true), rate("Levy", "0.01", true)}, "3.00", []string{"0.17", "0.03"}},
+		{"mixed", "10.60", []*TaxRate{rate("SST", "0.06", true), rate("Levy", "0.02", false)}, "10.80", []string{"0.60", "0.20"}},
+		{"no rates", "5.00", nil, "5.00", nil},
+		{"free session", "0", []*TaxRate{rate("SST", "0.08", false)}, "0", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taxes, charged := CalculateTax(decimal.RequireFromString(tt.price), tt.rates)
+			if !charged.Equal(decimal.RequireFromString(tt.wantCharged)) {
+				t.Errorf("charged = %s, want %s", charged, tt.wantCharged)
+			}
+			if len(taxes) != len(tt.wantTaxes) {
+				t.Fatalf("taxes = %+v, want %d", taxes, len(tt.wantTaxes))
+			}
+			for i, want := range tt.wantTaxes {
+				if !taxes[i].Amount.Equal(decimal.RequireFromString(want)) {
+					t.Errorf("%s = %s, want %s", taxes[i].Name, taxes[i].Amount, want)
+				}
+			}
+			// Inclusive taxes and the taxable amount add back up to the price
+			included := decimal.Zero
+			for i, tax := range taxes {
+				if tt.rates[i].Inclusive {
+					included = included.Add(tax.Amount)
+				}
+			}
+			if len(taxes) > 0 && !taxes[0].Taxable.Add(included).Equal(decimal.RequireFromString(tt.price)) {
+				t.Errorf("taxable %s + included %s != price %s", taxes[0].Taxable, included, tt.price)
+			}
+		})
+	}
+}
+
+func TestParkingSession_ApplyTax(t *testing.T) {
+	session := &ParkingSession{Amount: decimal.RequireFromString("5.00")}
+	session.ApplyTax([]*TaxRate{{Name: "SST", Rate: decimal.RequireFromString("0.08")}})
+
+	if !session.Amount.Equal(decimal.RequireFromString("5.40")) || !session.TaxAmount.Equal(decimal.RequireFromString("0.40")) {
+		t.Errorf("Amount = %s, TaxAmount = %s, want 5.40 with 0.40 tax", session.Amount, session.TaxAmount)
+	}
+	if got := TaxBreakdown(session.Taxes); got != "SST 8%: RM 0.40" {
+		t.Errorf("TaxBreakdown() = %q", got)
+	}
+}
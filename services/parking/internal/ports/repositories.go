@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/parking/internal/domain"
@@ -13,9 +14,25 @@ type SessionRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.ParkingSession, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.ParkingSession, error)
 	GetActiveByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.ParkingSession, error)
+	GetActive(ctx context.Context, limit int) ([]*domain.ParkingSession, error)
 	GetByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.ParkingSession, error)
 	Update(ctx context.Context, session *domain.ParkingSession) error
 	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// GetEndedBetween returns sessions whose exit time falls in [from, to)
+	GetEndedBetween(ctx context.Context, from, to time.Time) ([]*domain.ParkingSession, error)
+	// GetByGuestDeviceID returns the unclaimed guest sessions started from a device
+	GetByGuestDeviceID(ctx context.Context, deviceID string, limit, offset int) ([]*domain.ParkingSession, error)
+	// ClaimGuestSessions moves a device's guest sessions to userID and
+	// returns how many there were
+	ClaimGuestSessions(ctx context.Context, deviceID string, userID uuid.UUID) (int, error)
+}
+
+// ConsistencyReportRepository stores session/payment consistency check results
+type ConsistencyReportRepository interface {
+	Create(ctx context.Context, report *domain.ConsistencyReport) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ConsistencyReport, error)
+	GetLatest(ctx context.Context) (*domain.ConsistencyReport, error)
+	List(ctx context.Context, limit, offset int) ([]*domain.ConsistencyReport, error)
 }
 
 // VehicleRepository defines persistence operations for vehicles
@@ -27,3 +44,129 @@ type VehicleRepository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 	SetDefault(ctx context.Context, userID, vehicleID uuid.UUID) error
 }
+
+// SubscriptionPlanRepository stores the season passes providers sell
+type SubscriptionPlanRepository interface {
+	Create(ctx context.Context, plan *domain.SubscriptionPlan) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.SubscriptionPlan, error)
+	// List returns plans sold at a location: its own and provider-wide ones.
+	// A nil location lists all of the provider's plans.
+	List(ctx context.Context, providerID uuid.UUID, locationID *uuid.UUID, activeOnly bool) ([]*domain.SubscriptionPlan, error)
+	Update(ctx context.Context, plan *domain.SubscriptionPlan) error
+}
+
+// SubscriptionRepository stores users' season passes
+type SubscriptionRepository interface {
+	Create(ctx context.Context, subscription *domain.Subscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Subscription, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Subscription, error)
+	// GetByPlate returns the vehicle's active and expired passes that were
+	// valid at the given time
+	GetByPlate(ctx context.Context, plate string, at time.Time) ([]*domain.Subscription, error)
+	Update(ctx context.Context, subscription *domain.Subscription) error
+	// GetDueForRenewal returns active auto-renewing passes expiring before the given time
+	GetDueForRenewal(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error)
+	// GetExpiringUnnotified returns active passes expiring before the given
+	// time whose holder has not been warned yet
+	GetExpiringUnnotified(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error)
+	// GetLapsed returns active passes that expired before the given time
+	GetLapsed(ctx context.Context, before time.Time, limit int) ([]*domain.Subscription, error)
+}
+
+// ReservationRepository stores pre-booked spaces
+type ReservationRepository interface {
+	// Reserve saves the reservation only if fewer than capacity bookings at its
+	// location overlap its window, returning ErrNoSpacesAvailable otherwise
+	Reserve(ctx context.Context, reservation *domain.Reservation, capacity int) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Reservation, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Reservation, error)
+	// GetConfirmedByPlate returns the plate's confirmed bookings at a location
+	GetConfirmedByPlate(ctx context.Context, locationID uuid.UUID, plate string) ([]*domain.Reservation, error)
+	// CountOverlapping returns how many bookings at the location take up a
+	// space during [from, to)
+	CountOverlapping(ctx context.Context, locationID uuid.UUID, from, to time.Time) (int, error)
+	Update(ctx context.Context, reservation *domain.Reservation) error
+	// GetNoShows returns confirmed bookings whose no-show time is before the given time
+	GetNoShows(ctx context.Context, before time.Time, limit int) ([]*domain.Reservation, error)
+}
+
+// StreetZoneRepository stores council street parking zones
+type StreetZoneRepository interface {
+	Create(ctx context.Context, zone *domain.StreetZone) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.StreetZone, error)
+	// List returns zones of one council, or of all councils for a nil provider
+	List(ctx context.Context, providerID *uuid.UUID, activeOnly bool) ([]*domain.StreetZone, error)
+	Update(ctx context.Context, zone *domain.StreetZone) error
+}
+
+// StreetSessionRepository stores prepaid street parking sessions
+type StreetSessionRepository interface {
+	Create(ctx context.Context, session *domain.StreetSession) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.StreetSession, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.StreetSession, error)
+	// GetLive returns the vehicle's pending or active session in a zone
+	GetLive(ctx context.Context, zoneID uuid.UUID, plate string) (*domain.StreetSession, error)
+	Update(ctx context.Context, session *domain.StreetSession) error
+	// GetExpiringUnreminded returns active sessions expiring before the given
+	// time whose user has not been reminded yet
+	GetExpiringUnreminded(ctx context.Context, before time.Time, limit int) ([]*domain.StreetSession, error)
+	// GetLapsed returns active sessions that expired before the given time
+	GetLapsed(ctx context.Context, before time.Time, limit int) ([]*domain.StreetSession, error)
+}
+
+// CompoundRepository stores parking fines and their payment references
+type CompoundRepository interface {
+	// Upsert records a fine reported by its issuer and returns the stored
+	// record. Amount and due date are refreshed only while it is outstanding.
+	Upsert(ctx context.Context, compound *domain.Compound) (*domain.Compound, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Compound, error)
+	// GetPaidByUser returns the fines on the plates that the user has paid
+	GetPaidByUser(ctx context.Context, userID uuid.UUID, plates []string) ([]*domain.Compound, error)
+	// Claim saves a claimed fine only if it is still outstanding or already
+	// claimed by the same user, returning ErrCompoundNotPayable otherwise
+	Claim(ctx context.Context, compound *domain.Compound) error
+	Update(ctx context.Context, compound *domain.Compound) error
+	// GetUnsettled returns fines paid before the given time that the issuer
+	// has not acknowledged
+	GetUnsettled(ctx context.Context, before time.Time, limit int) ([]*domain.Compound, error)
+}
+
+// ErasureRepository scrubs deleted users' vehicles and plates
+type ErasureRepository interface {
+	EraseUser(ctx context.Context, userID uuid.UUID, now time.Time) (*domain.ErasureResult, error)
+	// ListOpen returns users whose erasure is waiting on live records
+	ListOpen(ctx context.Context, limit int) ([]uuid.UUID, error)
+}
+
+// DisputeRepository stores users' challenges to session charges
+type DisputeRepository interface {
+	// Create returns ErrDisputeAlreadyOpen if the session was already disputed
+	Create(ctx context.Context, dispute *domain.Dispute) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Dispute, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Dispute, error)
+	// List returns disputes oldest first, optionally only those in one status
+	List(ctx context.Context, status *domain.DisputeStatus, limit, offset int) ([]*domain.Dispute, error)
+	Update(ctx context.Context, dispute *domain.Dispute) error
+}
+
+// AttachmentRepository stores the metadata of session photos; the files are
+// in an AttachmentStore
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *domain.Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Attachment, error)
+	// GetBySessionID returns a session's attachments, oldest first
+	GetBySessionID(ctx context.Context, sessionID uuid.UUID) ([]*domain.Attachment, error)
+	// CountActive counts a session's uploaded attachments and those still
+	// pending that were created after pendingSince
+	CountActive(ctx context.Context, sessionID uuid.UUID, pendingSince time.Time) (int, error)
+	Update(ctx context.Context, attachment *domain.Attachment) error
+}
+
+// TaxRateRepository stores the taxes providers charge on parking
+type TaxRateRepository interface {
+	Create(ctx context.Context, rate *domain.TaxRate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TaxRate, error)
+	// GetByProviderID returns all of a provider's rates, oldest first
+	GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.TaxRate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
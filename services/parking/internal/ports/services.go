@@ -2,23 +2,19 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/pkg/storage"
+	"github.com/parking-super-app/services/parking/internal/domain"
 	"github.com/shopspring/decimal"
 )
 
 // Logger interface for structured logging
-type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-}
-
-type Field struct {
-	Key   string
-	Value interface{}
-}
+type Logger = logging.Logger
+type Field = logging.Field
 
 func String(key, value string) Field { return Field{Key: key, Value: value} }
 func Err(err error) Field            { return Field{Key: "error", Value: err} }
@@ -39,6 +35,64 @@ const (
 	EventSessionEnded     = "parking.session.ended"
 	EventSessionCancelled = "parking.session.cancelled"
 	EventPaymentRequired  = "parking.payment.required"
+
+	EventSessionAmountUpdated = "parking.session.amount_updated"
+	EventSessionEndingSoon    = "parking.session.ending_soon"
+	EventSessionPaymentFailed = "parking.session.payment_failed"
+	EventSessionStatusChanged = "parking.session.status_changed"
+
+	EventConsistencyIssueDetected = "parking.consistency.issue_detected"
+
+	EventSubscriptionPurchased     = "parking.subscription.purchased"
+	EventSubscriptionRenewed       = "parking.subscription.renewed"
+	EventSubscriptionRenewalFailed = "parking.subscription.renewal_failed"
+	EventSubscriptionExpiringSoon  = "parking.subscription.expiring_soon"
+	EventSubscriptionExpired       = "parking.subscription.expired"
+
+	EventReservationConfirmed = "parking.reservation.confirmed"
+	EventReservationConverted = "parking.reservation.converted"
+	EventReservationCancelled = "parking.reservation.cancelled"
+	EventReservationNoShow    = "parking.reservation.no_show"
+
+	EventStreetSessionStarted      = "parking.street_session.started"
+	EventStreetSessionExtended     = "parking.street_session.extended"
+	EventStreetSessionExpiringSoon = "parking.street_session.expiring_soon"
+	EventStreetSessionExpired      = "parking.street_session.expired"
+
+	EventCompoundSettled = "parking.compound.settled"
+
+	EventDisputeOpened      = "parking.dispute.opened"
+	EventDisputeUnderReview = "parking.dispute.under_review"
+	EventDisputeResolved    = "parking.dispute.resolved"
+)
+
+// SessionEventTypes lists the events that are relayed to session event streams
+var SessionEventTypes = []string{
+	EventSessionStarted,
+	EventSessionEnded,
+	EventSessionCancelled,
+	EventPaymentRequired,
+	EventSessionAmountUpdated,
+	EventSessionEndingSoon,
+	EventSessionPaymentFailed,
+	EventSessionStatusChanged,
+}
+
+// SessionEventStream fans out session events to connected clients.
+// The returned cancel func must be called when the client disconnects.
+type SessionEventStream interface {
+	Subscribe(ctx context.Context, sessionID, userID uuid.UUID) (<-chan Event, func(), error)
+}
+
+// FeatureFlags gates new flows while they are rolled out
+type FeatureFlags interface {
+	IsEnabled(ctx context.Context, key, userID string) bool
+}
+
+// Feature flags checked by the parking service
+const (
+	// FlagEntryTimePricing bills sessions at the location pricing in effect at entry
+	FlagEntryTimePricing = "parking.entry-time-pricing"
 )
 
 // ProviderClient communicates with parking provider APIs
@@ -46,6 +100,28 @@ type ProviderClient interface {
 	StartSession(ctx context.Context, req StartSessionRequest) (*StartSessionResponse, error)
 	EndSession(ctx context.Context, req EndSessionRequest) (*EndSessionResponse, error)
 	GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*SessionStatusResponse, error)
+	// GetPricing returns the location pricing that was in effect at the given time
+	GetPricing(ctx context.Context, locationID uuid.UUID, at time.Time) (*LocationPricing, error)
+	GetLocation(ctx context.Context, locationID uuid.UUID) (*LocationInfo, error)
+	// ListNearbyLocations returns active locations within radiusKm, nearest first
+	ListNearbyLocations(ctx context.Context, lat, lng, radiusKm float64) ([]LocationInfo, error)
+	// LookupCompounds returns a plate's outstanding fines at every provider that issues them
+	LookupCompounds(ctx context.Context, plate string) (*CompoundLookup, error)
+	// SettleCompound reports a paid fine to the provider that issued it
+	SettleCompound(ctx context.Context, req SettleCompoundRequest) (*CompoundSettlement, error)
+}
+
+// ProviderDriver runs sessions against one provider's own API. Drivers
+// come in REST, webhook and gRPC styles and are chosen by provider code.
+type ProviderDriver interface {
+	StartSession(ctx context.Context, req StartSessionRequest) (*StartSessionResponse, error)
+	EndSession(ctx context.Context, req EndSessionRequest) (*EndSessionResponse, error)
+	GetSessionStatus(ctx context.Context, providerID uuid.UUID, externalSessionID string) (*SessionStatusResponse, error)
+}
+
+// ProviderDirectory resolves a provider ID to its provider code
+type ProviderDirectory interface {
+	ProviderCode(ctx context.Context, providerID uuid.UUID) (string, error)
 }
 
 type StartSessionRequest struct {
@@ -80,10 +156,102 @@ type SessionStatusResponse struct {
 	Amount   decimal.Decimal
 }
 
+type LocationInfo struct {
+	ID          uuid.UUID
+	ProviderID  uuid.UUID
+	Name        string
+	Address     string
+	Latitude    float64
+	Longitude   float64
+	TotalSpaces int
+	Active      bool
+}
+
+// CompoundLookup is every outstanding fine found for a plate and the issuers
+// that could not be asked
+type CompoundLookup struct {
+	Compounds         []CompoundInfo
+	FailedProviderIDs []uuid.UUID
+}
+
+type CompoundInfo struct {
+	ProviderID   uuid.UUID
+	Reference    string
+	VehiclePlate string
+	Offence      string
+	Location     string
+	IssuedAt     time.Time
+	DueAt        *time.Time
+	Amount       decimal.Decimal
+	Currency     string
+}
+
+type SettleCompoundRequest struct {
+	ProviderID       uuid.UUID
+	Reference        string
+	Amount           decimal.Decimal
+	PaymentReference string
+}
+
+type CompoundSettlement struct {
+	ProviderReceipt string
+	SettledAt       time.Time
+}
+
+type LocationPricing struct {
+	HourlyRate    decimal.Decimal
+	DailyMax      decimal.Decimal
+	Currency      string
+	EffectiveFrom time.Time
+	// Schedule refines the hourly rate; nil for a location billed at its
+	// flat rate
+	Schedule *pricing.Schedule
+}
+
+// Tariff is what the location charges, for pricing a stay
+func (p *LocationPricing) Tariff() pricing.Tariff {
+	return pricing.Tariff{HourlyRate: p.HourlyRate, DailyMax: p.DailyMax, Schedule: p.Schedule}
+}
+
+// AttachmentStore holds the files of session attachments; storage.Store
+// satisfies it. Clients upload and download the files directly with
+// presigned requests, so they never pass through the service.
+type AttachmentStore interface {
+	PresignPut(ctx context.Context, key, contentType string, size int64, ttl time.Duration) (*storage.PresignedRequest, error)
+	PresignGet(ctx context.Context, key string, ttl time.Duration) (*storage.PresignedRequest, error)
+	// Stat returns storage.ErrNotFound until the file is uploaded
+	Stat(ctx context.Context, key string) (*storage.Object, error)
+}
+
 // WalletClient for payment operations
 type WalletClient interface {
 	Pay(ctx context.Context, req PaymentRequest) (*PaymentResponse, error)
 	GetWallet(ctx context.Context, userID uuid.UUID) (*WalletInfo, error)
+	// GetUserWallet returns one of the user's wallets, or their default
+	// wallet when walletID is uuid.Nil. Another user's wallet is not found.
+	GetUserWallet(ctx context.Context, userID, walletID uuid.UUID) (*WalletInfo, error)
+	// ListPayments returns wallet payments created in [from, to)
+	ListPayments(ctx context.Context, from, to time.Time) ([]domain.WalletPayment, error)
+	// HoldFunds sets money aside until it is captured or released
+	HoldFunds(ctx context.Context, req HoldRequest) (*HoldResponse, error)
+	// CaptureHold pays amount out of a hold and returns the rest to the wallet
+	CaptureHold(ctx context.Context, req CaptureHoldRequest) (*PaymentResponse, error)
+	ReleaseHold(ctx context.Context, holdID uuid.UUID) error
+	// Refund returns a completed payment to the wallet in full
+	Refund(ctx context.Context, req RefundRequest) (*RefundResponse, error)
+	// PayByCard charges a guest's card directly, as guests have no wallet
+	PayByCard(ctx context.Context, req CardPaymentRequest) (*PaymentResponse, error)
+}
+
+// CardPaymentRequest charges a card through the wallet service's payment gateway
+type CardPaymentRequest struct {
+	Amount         decimal.Decimal
+	CardToken      string
+	ProviderID     uuid.UUID
+	ReferenceID    string
+	Description    string
+	IdempotencyKey string
+	Taxes          []domain.TaxComponent
 }
 
 type PaymentRequest struct {
@@ -93,6 +261,10 @@ type PaymentRequest struct {
 	ReferenceID    string
 	Description    string
 	IdempotencyKey string
+	// MemberID is the driver a payment from an organization wallet is charged to
+	MemberID *uuid.UUID
+	// Taxes are the taxes included in Amount, recorded on the payment
+	Taxes []domain.TaxComponent
 }
 
 type PaymentResponse struct {
@@ -100,6 +272,41 @@ type PaymentResponse struct {
 	Status        string
 }
 
+type HoldRequest struct {
+	WalletID       uuid.UUID
+	Amount         decimal.Decimal
+	ProviderID     uuid.UUID
+	ReferenceID    string
+	Description    string
+	IdempotencyKey string
+}
+
+type HoldResponse struct {
+	HoldID uuid.UUID
+	Status string
+}
+
+type CaptureHoldRequest struct {
+	HoldID         uuid.UUID
+	Amount         decimal.Decimal
+	ReferenceID    string
+	Description    string
+	IdempotencyKey string
+	Taxes          []domain.TaxComponent
+}
+
+type RefundRequest struct {
+	TransactionID  uuid.UUID
+	Reason         string
+	IdempotencyKey string
+}
+
+type RefundResponse struct {
+	TransactionID uuid.UUID
+	Amount        decimal.Decimal
+	Status        string
+}
+
 type WalletInfo struct {
 	ID       uuid.UUID
 	UserID   uuid.UUID
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_sessions_exit_time;
+DROP TABLE IF EXISTS consistency_reports;
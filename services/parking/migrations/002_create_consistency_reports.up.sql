@@ -0,0 +1,18 @@
+-- Results of the session/payment consistency checker. Each report covers a time
+-- window; issues lists orphans found on either side of the dual write.
+CREATE TABLE consistency_reports (
+    id UUID PRIMARY KEY,
+    window_start TIMESTAMPTZ NOT NULL,
+    window_end TIMESTAMPTZ NOT NULL,
+    sessions_checked INT NOT NULL DEFAULT 0,
+    payments_checked INT NOT NULL DEFAULT 0,
+    issue_count INT NOT NULL DEFAULT 0,
+    issues JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_consistency_reports_window_end ON consistency_reports(window_end DESC);
+CREATE INDEX idx_consistency_reports_with_issues ON consistency_reports(window_end DESC) WHERE issue_count > 0;
+
+-- The checker looks up sessions by exit time
+CREATE INDEX idx_sessions_exit_time ON parking_sessions(exit_time) WHERE exit_time IS NOT NULL;
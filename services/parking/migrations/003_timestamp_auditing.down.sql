@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS update_vehicles_updated_at ON vehicles;
+DROP TRIGGER IF EXISTS update_parking_sessions_updated_at ON parking_sessions;
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+ALTER TABLE vehicles DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,23 @@
+-- Keep updated_at current on every UPDATE and stop created_at from being
+-- overwritten, whatever the repository sends.
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.created_at = OLD.created_at;
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+-- Vehicles are edited (default vehicle changes) but had no updated_at
+ALTER TABLE vehicles ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE TRIGGER update_parking_sessions_updated_at
+    BEFORE UPDATE ON parking_sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_vehicles_updated_at
+    BEFORE UPDATE ON vehicles
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,5 @@
+ALTER TABLE parking_sessions DROP COLUMN IF EXISTS subscription_id;
+DROP TRIGGER IF EXISTS update_subscriptions_updated_at ON subscriptions;
+DROP TRIGGER IF EXISTS update_subscription_plans_updated_at ON subscription_plans;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS subscription_plans;
@@ -0,0 +1,56 @@
+-- Season passes: providers sell plans valid at one location, or at all of
+-- theirs when location_id is NULL, and users buy them per vehicle
+CREATE TABLE subscription_plans (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    location_id UUID,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    price DECIMAL(19, 4) NOT NULL CHECK (price > 0),
+    duration_days INT NOT NULL CHECK (duration_days > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscription_plans_provider ON subscription_plans(provider_id, location_id);
+
+CREATE TABLE subscriptions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    plan_id UUID NOT NULL REFERENCES subscription_plans(id),
+    provider_id UUID NOT NULL,
+    location_id UUID,
+    vehicle_plate VARCHAR(20) NOT NULL,
+    wallet_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    auto_renew BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    renewal_count INT NOT NULL DEFAULT 0,
+    payment_id UUID,
+    expiry_notified_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_subscriptions_user_id ON subscriptions(user_id, created_at DESC);
+-- EndSession looks passes up by plate; the renewal job scans by expiry
+CREATE INDEX idx_subscriptions_plate ON subscriptions(vehicle_plate, expires_at);
+CREATE INDEX idx_subscriptions_active_expiry ON subscriptions(expires_at) WHERE status = 'active';
+
+-- A vehicle holds at most one live pass per plan
+CREATE UNIQUE INDEX idx_subscriptions_live ON subscriptions(plan_id, vehicle_plate) WHERE status IN ('pending', 'active');
+
+CREATE TRIGGER update_subscription_plans_updated_at
+    BEFORE UPDATE ON subscription_plans
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_subscriptions_updated_at
+    BEFORE UPDATE ON subscriptions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Sessions settled by a pass instead of a wallet payment
+ALTER TABLE parking_sessions ADD COLUMN subscription_id UUID REFERENCES subscriptions(id);
@@ -0,0 +1,3 @@
+ALTER TABLE parking_sessions DROP COLUMN IF EXISTS reservation_id;
+DROP TRIGGER IF EXISTS update_reservations_updated_at ON reservations;
+DROP TABLE IF EXISTS reservations;
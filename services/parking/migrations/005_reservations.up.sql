@@ -0,0 +1,39 @@
+-- Pre-booked spaces. The estimated charge is held in the wallet until the
+-- vehicle arrives and its session ends, or the booking is closed without it.
+CREATE TABLE reservations (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    provider_id UUID NOT NULL,
+    location_id UUID NOT NULL,
+    wallet_id UUID NOT NULL,
+    vehicle_plate VARCHAR(20) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    arrival_from TIMESTAMPTZ NOT NULL,
+    no_show_at TIMESTAMPTZ NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    hold_id UUID,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    session_id UUID,
+    fee_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    payment_id UUID,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_reservations_user_id ON reservations(user_id, starts_at DESC);
+-- Bookings that still take up a space, for availability and arrival matching
+CREATE INDEX idx_reservations_location_window ON reservations(location_id, starts_at, ends_at)
+    WHERE status IN ('pending', 'confirmed', 'converted');
+CREATE INDEX idx_reservations_no_show ON reservations(no_show_at) WHERE status = 'confirmed';
+
+CREATE TRIGGER update_reservations_updated_at
+    BEFORE UPDATE ON reservations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Sessions started under a reservation are paid from its hold
+ALTER TABLE parking_sessions ADD COLUMN reservation_id UUID REFERENCES reservations(id);
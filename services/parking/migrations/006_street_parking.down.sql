@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_street_sessions_updated_at ON street_sessions;
+DROP TRIGGER IF EXISTS update_street_zones_updated_at ON street_zones;
+DROP TABLE IF EXISTS street_sessions;
+DROP TABLE IF EXISTS street_zones;
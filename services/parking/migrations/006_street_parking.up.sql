@@ -0,0 +1,53 @@
+-- Council street parking: zones charged per half hour and sessions paid
+-- upfront for a chosen duration
+CREATE TABLE street_zones (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    code VARCHAR(20) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    rate_per_half_hour DECIMAL(19, 4) NOT NULL CHECK (rate_per_half_hour > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    max_minutes INT NOT NULL CHECK (max_minutes > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_street_zones_provider ON street_zones(provider_id);
+
+CREATE TABLE street_sessions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    zone_id UUID NOT NULL REFERENCES street_zones(id),
+    provider_id UUID NOT NULL,
+    wallet_id UUID NOT NULL,
+    vehicle_plate VARCHAR(20) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    paid_minutes INT NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    extensions INT NOT NULL DEFAULT 0,
+    payment_id UUID,
+    reminder_sent_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_street_sessions_user_id ON street_sessions(user_id, created_at DESC);
+CREATE INDEX idx_street_sessions_active_expiry ON street_sessions(expires_at) WHERE status = 'active';
+
+-- A vehicle has at most one live session per zone; more time is bought by
+-- extending it
+CREATE UNIQUE INDEX idx_street_sessions_live ON street_sessions(zone_id, vehicle_plate) WHERE status IN ('pending', 'active');
+
+CREATE TRIGGER update_street_zones_updated_at
+    BEFORE UPDATE ON street_zones
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_street_sessions_updated_at
+    BEFORE UPDATE ON street_sessions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
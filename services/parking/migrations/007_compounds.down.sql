@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_compounds_updated_at ON compounds;
+DROP TABLE IF EXISTS compounds;
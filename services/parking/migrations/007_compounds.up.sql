@@ -0,0 +1,34 @@
+-- Parking fines looked up at their issuers and paid from the wallet
+CREATE TABLE compounds (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    reference VARCHAR(64) NOT NULL,
+    vehicle_plate VARCHAR(20) NOT NULL,
+    offence TEXT NOT NULL DEFAULT '',
+    location TEXT NOT NULL DEFAULT '',
+    issued_at TIMESTAMPTZ NOT NULL,
+    due_at TIMESTAMPTZ,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    status VARCHAR(20) NOT NULL DEFAULT 'outstanding',
+    paid_by UUID,
+    wallet_id UUID,
+    payment_id UUID,
+    receipt_number VARCHAR(32),
+    provider_receipt VARCHAR(64),
+    paid_at TIMESTAMPTZ,
+    settled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (provider_id, reference)
+);
+
+CREATE INDEX idx_compounds_plate ON compounds(vehicle_plate, issued_at DESC);
+CREATE INDEX idx_compounds_paid_by ON compounds(paid_by) WHERE paid_by IS NOT NULL;
+-- The settlement retry job scans payments the issuer has not acknowledged
+CREATE INDEX idx_compounds_unsettled ON compounds(paid_at) WHERE status = 'paid';
+
+CREATE TRIGGER update_compounds_updated_at
+    BEFORE UPDATE ON compounds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
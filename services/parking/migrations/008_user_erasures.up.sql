@@ -0,0 +1,9 @@
+-- Users whose account was deleted. Records still in use at the time keep
+-- their plate; the erasure job finishes them once they end.
+CREATE TABLE user_erasures (
+    user_id UUID PRIMARY KEY,
+    requested_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_user_erasures_open ON user_erasures(requested_at) WHERE completed_at IS NULL;
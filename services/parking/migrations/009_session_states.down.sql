@@ -0,0 +1,5 @@
+ALTER TABLE parking_sessions DROP COLUMN IF EXISTS payment_status;
+
+-- Postgres cannot drop enum values; 'pending' and 'ending' stay on
+-- session_status. Sessions in them are failed for the older code.
+UPDATE parking_sessions SET status = 'failed' WHERE status IN ('pending', 'ending');
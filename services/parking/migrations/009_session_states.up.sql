@@ -0,0 +1,14 @@
+-- Session state machine: pending until the provider starts the session,
+-- ending while it is billed, and the payment outcome tracked separately
+ALTER TYPE session_status ADD VALUE IF NOT EXISTS 'pending' BEFORE 'active';
+ALTER TYPE session_status ADD VALUE IF NOT EXISTS 'ending' AFTER 'active';
+
+ALTER TABLE parking_sessions ADD COLUMN payment_status VARCHAR(20) NOT NULL DEFAULT 'none';
+
+UPDATE parking_sessions SET payment_status = CASE
+    WHEN payment_id IS NOT NULL THEN 'paid'
+    WHEN status = 'completed' THEN 'waived'
+    WHEN status = 'failed' THEN 'failed'
+    ELSE 'none'
+END;
+
@@ -0,0 +1,28 @@
+-- Challenges to what a session was charged. Each session can be disputed
+-- once; a dispute resolved with a refund records the wallet refund.
+CREATE TABLE session_disputes (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL UNIQUE REFERENCES parking_sessions(id),
+    user_id UUID NOT NULL,
+    payment_id UUID NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    reason VARCHAR(20) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    resolution VARCHAR(20),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    refund_transaction_id UUID,
+    reviewed_by UUID,
+    resolved_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_session_disputes_user_id ON session_disputes(user_id, created_at DESC);
+CREATE INDEX idx_session_disputes_status ON session_disputes(status, created_at);
+
+CREATE TRIGGER update_session_disputes_updated_at
+    BEFORE UPDATE ON session_disputes
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
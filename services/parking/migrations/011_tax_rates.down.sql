@@ -0,0 +1,5 @@
+ALTER TABLE parking_sessions
+    DROP COLUMN IF EXISTS taxes,
+    DROP COLUMN IF EXISTS tax_amount;
+
+DROP TABLE IF EXISTS tax_rates;
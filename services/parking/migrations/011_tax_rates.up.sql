@@ -0,0 +1,24 @@
+-- Taxes charged on parking, such as SST. A rate with a location_id applies
+-- only at that location; without one it covers all of the provider's.
+CREATE TABLE tax_rates (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    location_id UUID,
+    name VARCHAR(50) NOT NULL,
+    rate DECIMAL(7, 4) NOT NULL CHECK (rate >= 0 AND rate <= 1),
+    inclusive BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_tax_rates_provider_id ON tax_rates(provider_id);
+
+CREATE TRIGGER update_tax_rates_updated_at
+    BEFORE UPDATE ON tax_rates
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- The taxes in what each session was charged
+ALTER TABLE parking_sessions
+    ADD COLUMN tax_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    ADD COLUMN taxes JSONB;
@@ -0,0 +1,8 @@
+-- Unclaimed guest sessions have no user to keep them under
+DELETE FROM parking_sessions WHERE user_id IS NULL;
+
+DROP INDEX IF EXISTS idx_sessions_guest_device_id;
+ALTER TABLE parking_sessions
+    DROP CONSTRAINT IF EXISTS parking_sessions_owner,
+    DROP COLUMN IF EXISTS guest_device_id,
+    ALTER COLUMN user_id SET NOT NULL;
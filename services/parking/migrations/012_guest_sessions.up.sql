@@ -0,0 +1,8 @@
+-- Guests park without an account: their sessions belong to the device they
+-- were started from until the guest registers and the sessions are claimed
+ALTER TABLE parking_sessions
+    ALTER COLUMN user_id DROP NOT NULL,
+    ADD COLUMN guest_device_id VARCHAR(128),
+    ADD CONSTRAINT parking_sessions_owner CHECK (user_id IS NOT NULL OR guest_device_id IS NOT NULL);
+
+CREATE INDEX idx_sessions_guest_device_id ON parking_sessions(guest_device_id) WHERE guest_device_id IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Photos kept with a session as evidence, such as the entry ticket or damage.
+-- The files are in object storage under object_key; a row is pending until
+-- the client confirms its upload.
+CREATE TABLE session_attachments (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES parking_sessions(id) ON DELETE CASCADE,
+    uploaded_by UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    object_key TEXT NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    uploaded_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_session_attachments_session_id ON session_attachments(session_id, created_at);
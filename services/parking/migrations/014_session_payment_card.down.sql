@@ -0,0 +1 @@
+ALTER TABLE parking_sessions DROP COLUMN IF EXISTS payment_card;
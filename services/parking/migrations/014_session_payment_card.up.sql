@@ -0,0 +1,4 @@
+-- The card a guest session's charge was first attempted with. While the
+-- session is ending that charge may still go through, so a retry must use
+-- the same card.
+ALTER TABLE parking_sessions ADD COLUMN payment_card VARCHAR(64) NOT NULL DEFAULT '';
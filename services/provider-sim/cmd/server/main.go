@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/httpserver"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"github.com/parking-super-app/services/provider-sim/config"
+	grpcAdapter "github.com/parking-super-app/services/provider-sim/internal/adapters/grpc"
+	httpAdapter "github.com/parking-super-app/services/provider-sim/internal/adapters/http"
+	"github.com/parking-super-app/services/provider-sim/internal/application"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+	"google.golang.org/grpc"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("failed to load config: %v", err)
+	}
+
+	log.Println("Starting Provider Simulator...")
+
+	sim := application.NewSimulator(
+		domain.Pricing{
+			HourlyRate: cfg.Pricing.HourlyRate,
+			DailyMax:   cfg.Pricing.DailyMax,
+			Currency:   cfg.Pricing.Currency,
+		},
+		domain.Faults{
+			FailureRate:    cfg.Faults.FailureRate,
+			FailureStatus:  cfg.Faults.FailureStatus,
+			FailAfterApply: cfg.Faults.FailAfterApply,
+			Latency:        cfg.Faults.Latency,
+			Jitter:         cfg.Faults.Jitter,
+		},
+		cfg.Pricing.TimeScale,
+	)
+	if err := sim.Faults().Validate(); err != nil {
+		log.Fatalf("invalid fault settings: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(chimw.RequestID)
+	r.Use(chimw.RealIP)
+	r.Use(chimw.Logger)
+	r.Use(chimw.Recoverer)
+	httpAdapter.NewHandler(sim, cfg.Auth.APIKey, cfg.Auth.APISecret).Routes(r)
+
+	server, err := httpserver.New(":"+cfg.Server.Port, r, cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to configure HTTP server: %v", err)
+	}
+	go func() {
+		log.Printf("Provider Simulator HTTP listening on port %s", cfg.Server.Port)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(grpcAdapter.FaultInterceptor(sim)))
+	providerv1.RegisterProviderServiceServer(grpcServer, grpcAdapter.NewSessionServer(sim))
+	lis, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+	if err != nil {
+		log.Fatalf("failed to listen on gRPC port: %v", err)
+	}
+	go func() {
+		log.Printf("Provider Simulator gRPC listening on port %s", cfg.Server.GRPCPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+
+	// Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down Provider Simulator...")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+
+	grpcServer.GracefulStop()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("server forced to shutdown: %v", err)
+	}
+
+	log.Println("Provider Simulator stopped")
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/shopspring/decimal"
+)
+
+// Config holds provider simulator configuration
+type Config struct {
+	Server  ServerConfig
+	Auth    AuthConfig
+	Pricing PricingConfig
+	Faults  FaultsConfig
+}
+
+type ServerConfig struct {
+	Port     string
+	GRPCPort string
+	HTTP     httpserver.Config
+}
+
+// AuthConfig holds the credentials callers present. The secret also signs
+// webhooks.
+type AuthConfig struct {
+	APIKey    string
+	APISecret string
+}
+
+type PricingConfig struct {
+	HourlyRate decimal.Decimal
+	DailyMax   decimal.Decimal
+	Currency   string
+	// TimeScale runs the simulated clock faster than real time; 60 turns
+	// each second into a minute
+	TimeScale float64
+}
+
+// FaultsConfig holds the fault settings in effect at startup. They can be
+// changed at runtime with PUT /admin/faults.
+type FaultsConfig struct {
+	FailureRate    float64
+	FailureStatus  int
+	FailAfterApply bool
+	Latency        time.Duration
+	Jitter         time.Duration
+}
+
+func Load() (*Config, error) {
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	hourlyRate, err := decimal.NewFromString(getEnv("SIM_HOURLY_RATE", "2.50"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIM_HOURLY_RATE: %w", err)
+	}
+	dailyMax, err := decimal.NewFromString(getEnv("SIM_DAILY_MAX", "20.00"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIM_DAILY_MAX: %w", err)
+	}
+	timeScale, err := strconv.ParseFloat(getEnv("SIM_TIME_SCALE", "1"), 64)
+	if err != nil || timeScale <= 0 {
+		return nil, fmt.Errorf("invalid SIM_TIME_SCALE: must be a positive number")
+	}
+	failureRate, err := strconv.ParseFloat(getEnv("SIM_FAILURE_RATE", "0"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SIM_FAILURE_RATE: %w", err)
+	}
+	failAfterApply, _ := strconv.ParseBool(getEnv("SIM_FAIL_AFTER_APPLY", "false"))
+
+	return &Config{
+		Server: ServerConfig{
+			Port:     getEnv("SERVER_PORT", "8090"),
+			GRPCPort: getEnv("GRPC_PORT", "9090"),
+			HTTP:     httpCfg,
+		},
+		Auth: AuthConfig{
+			APIKey:    getEnv("SIM_API_KEY", "sim-key"),
+			APISecret: getEnv("SIM_API_SECRET", "sim-secret"),
+		},
+		Pricing: PricingConfig{
+			HourlyRate: hourlyRate,
+			DailyMax:   dailyMax,
+			Currency:   getEnv("SIM_CURRENCY", "MYR"),
+			TimeScale:  timeScale,
+		},
+		Faults: FaultsConfig{
+			FailureRate:    failureRate,
+			FailureStatus:  getIntEnv("SIM_FAILURE_STATUS", 503),
+			FailAfterApply: failAfterApply,
+			Latency:        getDurationEnv("SIM_LATENCY", 0),
+			Jitter:         getDurationEnv("SIM_LATENCY_JITTER", 0),
+		},
+	}, nil
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getIntEnv(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
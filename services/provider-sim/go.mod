@@ -0,0 +1,23 @@
+module github.com/parking-super-app/services/provider-sim
+
+go 1.25.5
+
+require (
+	github.com/go-chi/chi/v5 v5.2.3
+	github.com/google/uuid v1.6.0
+	github.com/parking-super-app/pkg v0.0.0-00010101000000-000000000000
+	github.com/shopspring/decimal v1.3.1
+	google.golang.org/grpc v1.64.0
+)
+
+require (
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
+	golang.org/x/net v0.47.0 // indirect
+	golang.org/x/sys v0.39.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240701130421-f6361c86f094 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
+
+replace github.com/parking-super-app/pkg => ../../pkg
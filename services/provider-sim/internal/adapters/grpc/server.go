@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"github.com/parking-super-app/services/provider-sim/internal/application"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SessionServer serves the session RPCs of provider.v1.ProviderService, the
+// API of gRPC-style providers. Other RPCs are unimplemented.
+type SessionServer struct {
+	providerv1.UnimplementedProviderServiceServer
+	sim *application.Simulator
+}
+
+// NewSessionServer creates a gRPC session server
+func NewSessionServer(sim *application.Simulator) *SessionServer {
+	return &SessionServer{sim: sim}
+}
+
+// StartSession opens a simulated session
+func (s *SessionServer) StartSession(ctx context.Context, req *providerv1.StartSessionRequest) (*providerv1.StartSessionResponse, error) {
+	session, err := s.sim.StartSession(ctx, application.StartSessionRequest{
+		LocationID:   req.LocationId,
+		VehiclePlate: req.VehiclePlate,
+		VehicleType:  req.VehicleType,
+		UserRef:      req.UserRef,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &providerv1.StartSessionResponse{
+		ExternalSessionId: session.ExternalSessionID,
+		EntryTime:         session.EntryTime.Format(time.RFC3339),
+		Status:            session.Status,
+	}, nil
+}
+
+// EndSession closes and bills a simulated session
+func (s *SessionServer) EndSession(ctx context.Context, req *providerv1.EndSessionRequest) (*providerv1.EndSessionResponse, error) {
+	session, err := s.sim.EndSession(ctx, req.ExternalSessionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &providerv1.EndSessionResponse{
+		ExitTime:        session.ExitTime.Format(time.RFC3339),
+		DurationMinutes: int32(session.DurationMinutes),
+		Amount:          session.Amount.StringFixed(2),
+		Currency:        s.sim.Pricing().Currency,
+	}, nil
+}
+
+// GetSessionStatus returns a simulated session's running duration and amount
+func (s *SessionServer) GetSessionStatus(ctx context.Context, req *providerv1.GetSessionStatusRequest) (*providerv1.SessionStatusResponse, error) {
+	session, err := s.sim.GetSession(ctx, req.ExternalSessionId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &providerv1.SessionStatusResponse{
+		Status:          session.Status,
+		DurationMinutes: int32(session.DurationMinutes),
+		CurrentAmount:   session.Amount.StringFixed(2),
+		Currency:        s.sim.Pricing().Currency,
+		EntryTime:       session.EntryTime.Format(time.RFC3339),
+	}, nil
+}
+
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidPlate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPlateAlreadyParked):
+		return status.Error(codes.AlreadyExists, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// FaultInterceptor applies the simulator's fault settings to every call.
+// HTTP failure statuses map to the nearest gRPC code.
+func FaultInterceptor(sim *application.Simulator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		outcome := sim.Roll()
+		if outcome.Delay > 0 {
+			timer := time.NewTimer(outcome.Delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+		if !outcome.Failed() {
+			return handler(ctx, req)
+		}
+		if sim.FailAfterApply() {
+			handler(ctx, req)
+		}
+		return nil, status.Error(failureCode(outcome.Status), "simulated provider failure")
+	}
+}
+
+func failureCode(httpStatus int) codes.Code {
+	switch {
+	case httpStatus == 429:
+		return codes.ResourceExhausted
+	case httpStatus == 504:
+		return codes.DeadlineExceeded
+	case httpStatus >= 500:
+		return codes.Unavailable
+	default:
+		return codes.FailedPrecondition
+	}
+}
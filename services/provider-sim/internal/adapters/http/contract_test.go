@@ -0,0 +1,30 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/contract"
+	"github.com/parking-super-app/services/provider-sim/internal/application"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+)
+
+// TestContracts verifies the simulator against the session API the
+// parking service's drivers expect of real providers
+func TestContracts(t *testing.T) {
+	sim := application.NewSimulator(domain.Pricing{}, domain.Faults{FailureStatus: 503}, 1)
+	r := chi.NewRouter()
+	NewHandler(sim, "key", "secret").Routes(r)
+
+	resource := contract.ShapeOf(SessionResponse{})
+	contract.Verify(t, contract.Provider{
+		Name:   "provider-sim",
+		Routes: r,
+		Bodies: map[string]contract.Shapes{
+			"rest start session":  {Request: contract.ShapeOf(sessionRequest{}), Response: resource},
+			"rest session status": {Response: resource},
+			"rest end session":    {Response: resource},
+			"webhook":             {Request: contract.ShapeOf(webhookEnvelope{}), Response: resource},
+		},
+	})
+}
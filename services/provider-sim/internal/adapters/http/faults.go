@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+)
+
+// Headers that override the fault settings for a single call, so a test
+// can fail one request without touching the shared settings
+const (
+	FailHeader    = "X-Sim-Fail"    // Status code to answer with
+	LatencyHeader = "X-Sim-Latency" // Delay before answering, e.g. 2s
+)
+
+// injectFaults delays and fails calls as the fault settings or the
+// per-call headers say
+func (h *Handler) injectFaults(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		outcome := h.sim.Roll()
+		if status, err := strconv.Atoi(r.Header.Get(FailHeader)); err == nil && status >= 400 && status <= 599 {
+			outcome.Status = status
+		}
+		if delay, err := time.ParseDuration(r.Header.Get(LatencyHeader)); err == nil && delay >= 0 {
+			outcome.Delay = delay
+		}
+
+		if outcome.Delay > 0 {
+			timer := time.NewTimer(outcome.Delay)
+			select {
+			case <-timer.C:
+			case <-r.Context().Done():
+				timer.Stop()
+				return
+			}
+		}
+		if !outcome.Failed() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if h.sim.FailAfterApply() {
+			next.ServeHTTP(discardWriter{header: make(http.Header)}, r)
+		}
+		writeFailure(w, r, outcome)
+	})
+}
+
+func writeFailure(w http.ResponseWriter, r *http.Request, outcome domain.Outcome) {
+	httpx.WriteError(w, r, outcome.Status, "SIMULATED_FAILURE", "Simulated provider failure")
+}
+
+// discardWriter swallows the reply of a call whose change is made but
+// whose response is to be lost
+type discardWriter struct {
+	header http.Header
+}
+
+func (d discardWriter) Header() http.Header         { return d.header }
+func (d discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (d discardWriter) WriteHeader(int)             {}
@@ -0,0 +1,327 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider-sim/internal/application"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+)
+
+// Headers of signed webhooks, matching the platform's webhook driver
+const (
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// maxBodySize caps request bodies
+const maxBodySize = 1 << 20
+
+// Handler serves the simulated provider API
+type Handler struct {
+	sim       *application.Simulator
+	apiKey    string
+	apiSecret string
+}
+
+// NewHandler creates a handler. Calls must carry apiKey and apiSecret, and
+// webhooks are signed with apiSecret.
+func NewHandler(sim *application.Simulator, apiKey, apiSecret string) *Handler {
+	return &Handler{sim: sim, apiKey: apiKey, apiSecret: apiSecret}
+}
+
+// Routes registers the REST, webhook, sandbox and admin routes
+func (h *Handler) Routes(r chi.Router) {
+	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		httpx.WriteRaw(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+
+	// REST driver API, also served under /sandbox for the conformance suite
+	sessionRoutes := func(r chi.Router) {
+		r.Use(h.authenticate, h.injectFaults)
+		r.Post("/sessions", h.StartSession)
+		r.Get("/sessions/{id}", h.GetSession)
+		r.Post("/sessions/{id}/end", h.EndSession)
+	}
+	r.Group(sessionRoutes)
+	r.Route("/sandbox", func(r chi.Router) {
+		r.Group(sessionRoutes)
+		r.Post("/webhooks/echo", h.EchoWebhook)
+	})
+
+	// Webhook driver API
+	r.With(h.injectFaults).Post("/webhook", h.Webhook)
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Get("/faults", h.GetFaults)
+		r.Put("/faults", h.SetFaults)
+		r.Get("/sessions", h.ListSessions)
+		r.Post("/reset", h.Reset)
+	})
+}
+
+// sessionRequest is the body of a start call
+type sessionRequest struct {
+	LocationID   string `json:"location_id"`
+	VehiclePlate string `json:"vehicle_plate"`
+	VehicleType  string `json:"vehicle_type"`
+	UserRef      string `json:"user_ref"`
+}
+
+func (req sessionRequest) toApplication() application.StartSessionRequest {
+	return application.StartSessionRequest{
+		LocationID:   req.LocationID,
+		VehiclePlate: req.VehiclePlate,
+		VehicleType:  req.VehicleType,
+		UserRef:      req.UserRef,
+	}
+}
+
+// SessionResponse is the session resource providers reply with
+type SessionResponse struct {
+	ExternalSessionID string `json:"external_session_id"`
+	Status            string `json:"status"`
+	EntryTime         string `json:"entry_time"`
+	ExitTime          string `json:"exit_time,omitempty"`
+	DurationMinutes   int    `json:"duration_minutes"`
+	Amount            string `json:"amount"`
+	Currency          string `json:"currency"`
+	VehiclePlate      string `json:"vehicle_plate"`
+	LocationID        string `json:"location_id,omitempty"`
+}
+
+func (h *Handler) toResponse(session *domain.Session) SessionResponse {
+	resp := SessionResponse{
+		ExternalSessionID: session.ExternalSessionID,
+		Status:            session.Status,
+		EntryTime:         session.EntryTime.Format(time.RFC3339),
+		DurationMinutes:   session.DurationMinutes,
+		Amount:            session.Amount.StringFixed(2),
+		Currency:          h.sim.Pricing().Currency,
+		VehiclePlate:      session.VehiclePlate,
+		LocationID:        session.LocationID,
+	}
+	if session.ExitTime != nil {
+		resp.ExitTime = session.ExitTime.Format(time.RFC3339)
+	}
+	return resp
+}
+
+// StartSession handles POST /sessions
+func (h *Handler) StartSession(w http.ResponseWriter, r *http.Request) {
+	var req sessionRequest
+	if err := httpx.DecodeJSONLimit(w, r, &req, maxBodySize); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	session, err := h.sim.StartSession(r.Context(), req.toApplication())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusCreated, h.toResponse(session))
+}
+
+// GetSession handles GET /sessions/{id}
+func (h *Handler) GetSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.sim.GetSession(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, h.toResponse(session))
+}
+
+// EndSession handles POST /sessions/{id}/end
+func (h *Handler) EndSession(w http.ResponseWriter, r *http.Request) {
+	session, err := h.sim.EndSession(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, h.toResponse(session))
+}
+
+// webhookEnvelope is the body the webhook driver POSTs
+type webhookEnvelope struct {
+	Type              string          `json:"type"`
+	ExternalSessionID string          `json:"external_session_id"`
+	Session           *sessionRequest `json:"session"`
+}
+
+// Webhook handles POST /webhook, the single endpoint of webhook-style providers
+func (h *Handler) Webhook(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.verifiedBody(w, r)
+	if !ok {
+		return
+	}
+	var envelope webhookEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		httpx.BadRequest(w, r, "INVALID_REQUEST", "Invalid webhook body")
+		return
+	}
+
+	var session *domain.Session
+	var err error
+	status := http.StatusOK
+	switch envelope.Type {
+	case "session.start":
+		if envelope.Session == nil {
+			httpx.BadRequest(w, r, "INVALID_REQUEST", "session.start needs a session")
+			return
+		}
+		session, err = h.sim.StartSession(r.Context(), envelope.Session.toApplication())
+		status = http.StatusCreated
+	case "session.end":
+		session, err = h.sim.EndSession(r.Context(), envelope.ExternalSessionID)
+	case "session.status":
+		session, err = h.sim.GetSession(r.Context(), envelope.ExternalSessionID)
+	default:
+		httpx.BadRequest(w, r, "UNKNOWN_EVENT", "Unknown webhook type: "+envelope.Type)
+		return
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	httpx.WriteRaw(w, status, h.toResponse(session))
+}
+
+// EchoWebhook handles POST /sandbox/webhooks/echo for the conformance suite
+func (h *Handler) EchoWebhook(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.verifiedBody(w, r); !ok {
+		return
+	}
+	httpx.WriteRaw(w, http.StatusOK, map[string]string{"signature": r.Header.Get(WebhookSignatureHeader)})
+}
+
+// verifiedBody reads the body of a webhook and checks its signature
+func (h *Handler) verifiedBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		httpx.BadRequest(w, r, "INVALID_REQUEST", "Could not read the webhook body")
+		return nil, false
+	}
+	err = domain.VerifyWebhook(h.apiSecret, r.Header.Get(WebhookTimestampHeader), r.Header.Get(WebhookSignatureHeader), body, time.Now())
+	if err != nil {
+		httpx.Unauthorized(w, r, "INVALID_SIGNATURE", "Webhook signature is invalid")
+		return nil, false
+	}
+	return body, true
+}
+
+// FaultsRequest sets the fault settings. Durations are strings such as "250ms".
+type FaultsRequest struct {
+	FailureRate    float64 `json:"failure_rate"`
+	FailureStatus  int     `json:"failure_status"`
+	FailNext       int     `json:"fail_next"`
+	FailAfterApply bool    `json:"fail_after_apply"`
+	Latency        string  `json:"latency"`
+	Jitter         string  `json:"jitter"`
+}
+
+func toFaultsResponse(f domain.Faults) FaultsRequest {
+	return FaultsRequest{
+		FailureRate:    f.FailureRate,
+		FailureStatus:  f.FailureStatus,
+		FailNext:       f.FailNext,
+		FailAfterApply: f.FailAfterApply,
+		Latency:        f.Latency.String(),
+		Jitter:         f.Jitter.String(),
+	}
+}
+
+// GetFaults handles GET /admin/faults
+func (h *Handler) GetFaults(w http.ResponseWriter, r *http.Request) {
+	httpx.OK(w, toFaultsResponse(h.sim.Faults()))
+}
+
+// SetFaults handles PUT /admin/faults
+func (h *Handler) SetFaults(w http.ResponseWriter, r *http.Request) {
+	var req FaultsRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	faults := domain.Faults{
+		FailureRate:    req.FailureRate,
+		FailureStatus:  req.FailureStatus,
+		FailNext:       req.FailNext,
+		FailAfterApply: req.FailAfterApply,
+	}
+	if faults.FailureStatus == 0 {
+		faults.FailureStatus = http.StatusServiceUnavailable
+	}
+	var err error
+	if faults.Latency, err = parseDuration(req.Latency); err != nil {
+		httpx.BadRequest(w, r, "INVALID_FAULTS", "latency must be a duration such as 250ms")
+		return
+	}
+	if faults.Jitter, err = parseDuration(req.Jitter); err != nil {
+		httpx.BadRequest(w, r, "INVALID_FAULTS", "jitter must be a duration such as 250ms")
+		return
+	}
+	if err := h.sim.SetFaults(faults); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	httpx.OK(w, toFaultsResponse(faults))
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(value)
+}
+
+// ListSessions handles GET /admin/sessions
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	sessions := h.sim.ListSessions(r.Context())
+	resp := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = h.toResponse(session)
+	}
+	httpx.OK(w, resp)
+}
+
+// Reset handles POST /admin/reset
+func (h *Handler) Reset(w http.ResponseWriter, r *http.Request) {
+	h.sim.Reset(r.Context())
+	httpx.NoContent(w)
+}
+
+// authenticate checks the X-API-Key and X-API-Secret headers
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("X-API-Key")
+		secret := r.Header.Get("X-API-Secret")
+		if subtle.ConstantTimeCompare([]byte(key), []byte(h.apiKey)) != 1 ||
+			subtle.ConstantTimeCompare([]byte(secret), []byte(h.apiSecret)) != 1 {
+			httpx.Unauthorized(w, r, "INVALID_CREDENTIALS", "Invalid API key or secret")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSessionNotFound):
+		httpx.NotFound(w, r, "SESSION_NOT_FOUND", "Session not found")
+	case errors.Is(err, domain.ErrInvalidPlate):
+		httpx.BadRequest(w, r, "INVALID_PLATE", "Vehicle plate is required")
+	case errors.Is(err, domain.ErrPlateAlreadyParked):
+		httpx.WriteError(w, r, http.StatusConflict, "ALREADY_PARKED", "Vehicle already has an active session at this location")
+	case errors.Is(err, domain.ErrInvalidFaults):
+		httpx.BadRequest(w, r, "INVALID_FAULTS", err.Error())
+	default:
+		httpx.InternalError(w, r)
+	}
+}
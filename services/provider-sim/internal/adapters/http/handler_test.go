@@ -0,0 +1,139 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/services/provider-sim/internal/application"
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, *application.Simulator) {
+	t.Helper()
+	sim := application.NewSimulator(domain.Pricing{
+		HourlyRate: decimal.RequireFromString("2.50"),
+		DailyMax:   decimal.RequireFromString("20.00"),
+		Currency:   "MYR",
+	}, domain.Faults{FailureStatus: http.StatusServiceUnavailable}, 1)
+	r := chi.NewRouter()
+	NewHandler(sim, "key", "secret").Routes(r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return server, sim
+}
+
+func call(t *testing.T, method, url string, body []byte, headers map[string]string) (*http.Response, SessionResponse) {
+	t.Helper()
+	req, _ := http.NewRequest(method, url, bytes.NewReader(body))
+	req.Header.Set("X-API-Key", "key")
+	req.Header.Set("X-API-Secret", "secret")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	var session SessionResponse
+	json.NewDecoder(resp.Body).Decode(&session)
+	return resp, session
+}
+
+func TestHandler_RESTSessions(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	resp, started := call(t, http.MethodPost, server.URL+"/sessions", []byte(`{"vehicle_plate":"wxy 1234","location_id":"loc-1"}`), nil)
+	if resp.StatusCode != http.StatusCreated || started.Status != "active" || started.ExternalSessionID == "" {
+		t.Fatalf("start: %d %+v", resp.StatusCode, started)
+	}
+	if _, err := time.Parse(time.RFC3339, started.EntryTime); err != nil {
+		t.Errorf("entry_time %q is not RFC 3339", started.EntryTime)
+	}
+
+	if resp, _ := call(t, http.MethodPost, server.URL+"/sessions", []byte(`{"vehicle_plate":"WXY1234","location_id":"loc-1"}`), nil); resp.StatusCode != http.StatusConflict {
+		t.Errorf("second session for the plate: status = %d, want 409", resp.StatusCode)
+	}
+
+	resp, status := call(t, http.MethodGet, server.URL+"/sandbox/sessions/"+started.ExternalSessionID, nil, nil)
+	if resp.StatusCode != http.StatusOK || status.Status != "active" {
+		t.Errorf("status: %d %+v", resp.StatusCode, status)
+	}
+
+	resp, ended := call(t, http.MethodPost, server.URL+"/sessions/"+started.ExternalSessionID+"/end", nil, nil)
+	if resp.StatusCode != http.StatusOK || ended.Status != "completed" || ended.ExitTime == "" || ended.Currency != "MYR" {
+		t.Errorf("end: %d %+v", resp.StatusCode, ended)
+	}
+
+	if resp, _ := call(t, http.MethodGet, server.URL+"/sessions/missing", nil, nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing session: status = %d, want 404", resp.StatusCode)
+	}
+	if resp, _ := call(t, http.MethodGet, server.URL+"/sessions/missing", nil, map[string]string{"X-API-Secret": "wrong"}); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("wrong secret: status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestHandler_Webhook(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	send := func(secret string, body []byte) (*http.Response, SessionResponse) {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		return call(t, http.MethodPost, server.URL+"/webhook", body, map[string]string{
+			WebhookTimestampHeader: timestamp,
+			WebhookSignatureHeader: domain.SignWebhook(secret, timestamp, body),
+		})
+	}
+
+	resp, started := send("secret", []byte(`{"type":"session.start","session":{"vehicle_plate":"ABC123"}}`))
+	if resp.StatusCode != http.StatusCreated || started.ExternalSessionID == "" {
+		t.Fatalf("session.start: %d %+v", resp.StatusCode, started)
+	}
+	resp, ended := send("secret", []byte(`{"type":"session.end","external_session_id":"`+started.ExternalSessionID+`"}`))
+	if resp.StatusCode != http.StatusOK || ended.Status != "completed" {
+		t.Errorf("session.end: %d %+v", resp.StatusCode, ended)
+	}
+	if resp, _ := send("wrong", []byte(`{"type":"session.status","external_session_id":"x"}`)); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("bad signature: status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestHandler_Faults(t *testing.T) {
+	server, sim := newTestServer(t)
+
+	if resp, _ := call(t, http.MethodPost, server.URL+"/sessions", []byte(`{"vehicle_plate":"AAA1"}`), map[string]string{FailHeader: "502"}); resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("X-Sim-Fail: status = %d, want 502", resp.StatusCode)
+	}
+	if len(sim.ListSessions(t.Context())) != 0 {
+		t.Error("a failed start should not open a session")
+	}
+
+	if err := sim.SetFaults(domain.Faults{FailureStatus: http.StatusGatewayTimeout, FailNext: 1, FailAfterApply: true}); err != nil {
+		t.Fatalf("SetFaults() error = %v", err)
+	}
+	if resp, _ := call(t, http.MethodPost, server.URL+"/sessions", []byte(`{"vehicle_plate":"AAA1"}`), nil); resp.StatusCode != http.StatusGatewayTimeout {
+		t.Errorf("FailNext: status = %d, want 504", resp.StatusCode)
+	}
+	if len(sim.ListSessions(t.Context())) != 1 {
+		t.Error("FailAfterApply should open the session even though the call failed")
+	}
+	if resp, _ := call(t, http.MethodPost, server.URL+"/sessions", []byte(`{"vehicle_plate":"BBB2"}`), nil); resp.StatusCode != http.StatusCreated {
+		t.Errorf("after FailNext: status = %d, want 201", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL+"/admin/faults", bytes.NewReader([]byte(`{"failure_rate":2}`)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid faults: status = %d, want 400", resp.StatusCode)
+	}
+}
@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/parking-super-app/services/provider-sim/internal/domain"
+)
+
+// StartSessionRequest is a vehicle entering a simulated car park
+type StartSessionRequest struct {
+	LocationID   string
+	VehiclePlate string
+	VehicleType  string
+	UserRef      string
+}
+
+// Simulator keeps sessions in memory and applies the configured faults to
+// every call. Time can run faster than the wall clock so that short tests
+// bill whole hours.
+type Simulator struct {
+	pricing   domain.Pricing
+	timeScale float64
+	started   time.Time
+	now       func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*domain.Session
+	faults   domain.Faults
+	rng      *rand.Rand
+}
+
+// NewSimulator creates a simulator. A timeScale of 60 makes every real
+// second a simulated minute.
+func NewSimulator(pricing domain.Pricing, faults domain.Faults, timeScale float64) *Simulator {
+	if timeScale <= 0 {
+		timeScale = 1
+	}
+	return &Simulator{
+		pricing:   pricing,
+		timeScale: timeScale,
+		started:   time.Now(),
+		now:       time.Now,
+		sessions:  make(map[string]*domain.Session),
+		faults:    faults,
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Pricing returns the tariff sessions are billed at
+func (s *Simulator) Pricing() domain.Pricing {
+	return s.pricing
+}
+
+// clock returns the simulated time
+func (s *Simulator) clock() time.Time {
+	now := s.now()
+	if s.timeScale == 1 {
+		return now.UTC()
+	}
+	elapsed := time.Duration(float64(now.Sub(s.started)) * s.timeScale)
+	return s.started.Add(elapsed).UTC()
+}
+
+// Faults returns the current fault settings
+func (s *Simulator) Faults() domain.Faults {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.faults
+}
+
+// SetFaults replaces the fault settings
+func (s *Simulator) SetFaults(faults domain.Faults) error {
+	if err := faults.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = faults
+	return nil
+}
+
+// Roll decides the outcome of an incoming call from the fault settings
+func (s *Simulator) Roll() domain.Outcome {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.faults.Roll(s.rng)
+}
+
+// FailAfterApply reports whether failed calls still make their change
+func (s *Simulator) FailAfterApply() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.faults.FailAfterApply
+}
+
+// StartSession opens a session. A plate can have one active session per location.
+func (s *Simulator) StartSession(ctx context.Context, req StartSessionRequest) (*domain.Session, error) {
+	session, err := domain.NewSession(req.LocationID, req.VehiclePlate, req.VehicleType, req.UserRef, s.clock())
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.sessions {
+		if existing.IsActive() && existing.VehiclePlate == session.VehiclePlate && existing.LocationID == session.LocationID {
+			return nil, domain.ErrPlateAlreadyParked
+		}
+	}
+	s.sessions[session.ExternalSessionID] = session
+	copied := *session
+	return &copied, nil
+}
+
+// GetSession returns a session with its running duration and amount
+func (s *Simulator) GetSession(ctx context.Context, externalSessionID string) (*domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[externalSessionID]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	session.Refresh(s.clock(), s.pricing)
+	copied := *session
+	return &copied, nil
+}
+
+// EndSession closes and bills a session. Ending an ended session returns
+// it unchanged, as providers do when a reply was lost and the call is retried.
+func (s *Simulator) EndSession(ctx context.Context, externalSessionID string) (*domain.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[externalSessionID]
+	if !ok {
+		return nil, domain.ErrSessionNotFound
+	}
+	if session.IsActive() {
+		if err := session.End(s.clock(), s.pricing); err != nil {
+			return nil, err
+		}
+	}
+	copied := *session
+	return &copied, nil
+}
+
+// ListSessions returns every session, newest first
+func (s *Simulator) ListSessions(ctx context.Context) []*domain.Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock()
+	sessions := make([]*domain.Session, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		session.Refresh(now, s.pricing)
+		copied := *session
+		sessions = append(sessions, &copied)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].EntryTime.After(sessions[j].EntryTime)
+	})
+	return sessions
+}
+
+// Reset drops every session
+func (s *Simulator) Reset(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions = make(map[string]*domain.Session)
+}
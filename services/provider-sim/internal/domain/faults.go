@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+var ErrInvalidFaults = errors.New("invalid fault settings")
+
+// Faults make the simulator behave like an unreliable provider
+type Faults struct {
+	// FailureRate is the share of calls, 0 to 1, answered with FailureStatus
+	FailureRate   float64
+	FailureStatus int
+	// FailNext fails the next n calls regardless of FailureRate
+	FailNext int
+	// FailAfterApply fails a call after its change is made, as when a
+	// provider opens the gate but the reply is lost
+	FailAfterApply bool
+	// Every call waits Latency plus up to Jitter before it is handled
+	Latency time.Duration
+	Jitter  time.Duration
+}
+
+// Validate checks the settings are usable
+func (f Faults) Validate() error {
+	if f.FailureRate < 0 || f.FailureRate > 1 {
+		return errors.Join(ErrInvalidFaults, errors.New("failure rate must be between 0 and 1"))
+	}
+	if f.FailureStatus < 400 || f.FailureStatus > 599 {
+		return errors.Join(ErrInvalidFaults, errors.New("failure status must be a 4xx or 5xx code"))
+	}
+	if f.FailNext < 0 || f.Latency < 0 || f.Jitter < 0 {
+		return errors.Join(ErrInvalidFaults, errors.New("fail next, latency and jitter cannot be negative"))
+	}
+	return nil
+}
+
+// Outcome is what the fault settings decided for one call
+type Outcome struct {
+	Delay  time.Duration
+	Status int // 0 lets the call succeed
+}
+
+// Failed returns true if the call is to be failed
+func (o Outcome) Failed() bool {
+	return o.Status != 0
+}
+
+// Roll decides the outcome of the next call, consuming one FailNext
+func (f *Faults) Roll(rng *rand.Rand) Outcome {
+	outcome := Outcome{Delay: f.Latency}
+	if f.Jitter > 0 {
+		outcome.Delay += time.Duration(rng.Int63n(int64(f.Jitter) + 1))
+	}
+	switch {
+	case f.FailNext > 0:
+		f.FailNext--
+		outcome.Status = f.FailureStatus
+	case f.FailureRate > 0 && rng.Float64() < f.FailureRate:
+		outcome.Status = f.FailureStatus
+	}
+	return outcome
+}
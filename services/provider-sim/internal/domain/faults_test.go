@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestFaults_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		faults  Faults
+		wantErr bool
+	}{
+		{"defaults", Faults{FailureStatus: 503}, false},
+		{"rate above one", Faults{FailureRate: 1.5, FailureStatus: 503}, true},
+		{"success status", Faults{FailureStatus: 200}, true},
+		{"negative latency", Faults{FailureStatus: 503, Latency: -time.Second}, true},
+		{"negative fail next", Faults{FailureStatus: 503, FailNext: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.faults.Validate()
+			if tt.wantErr != (err != nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidFaults) {
+				t.Errorf("Validate() error = %v, want ErrInvalidFaults", err)
+			}
+		})
+	}
+}
+
+func TestFaults_Roll(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	faults := Faults{FailureStatus: 502, FailNext: 2, Latency: 100 * time.Millisecond, Jitter: 50 * time.Millisecond}
+	for i := 0; i < 2; i++ {
+		outcome := faults.Roll(rng)
+		if outcome.Status != 502 {
+			t.Errorf("call %d: status = %d, want 502", i, outcome.Status)
+		}
+		if outcome.Delay < 100*time.Millisecond || outcome.Delay > 150*time.Millisecond {
+			t.Errorf("call %d: delay = %s, want 100ms to 150ms", i, outcome.Delay)
+		}
+	}
+	if outcome := faults.Roll(rng); outcome.Failed() {
+		t.Error("calls after FailNext is used up should succeed")
+	}
+
+	always := Faults{FailureRate: 1, FailureStatus: 503}
+	if outcome := always.Roll(rng); outcome.Status != 503 {
+		t.Errorf("FailureRate 1: status = %d, want 503", outcome.Status)
+	}
+}
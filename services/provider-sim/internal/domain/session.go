@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrSessionEnded       = errors.New("session already ended")
+	ErrInvalidPlate       = errors.New("invalid vehicle plate")
+	ErrPlateAlreadyParked = errors.New("vehicle already has an active session")
+)
+
+// Session statuses, as real providers report them
+const (
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed"
+)
+
+// Session is a parking session held by the simulated provider
+type Session struct {
+	ExternalSessionID string
+	LocationID        string
+	VehiclePlate      string
+	VehicleType       string
+	UserRef           string
+	Status            string
+	EntryTime         time.Time
+	ExitTime          *time.Time
+	DurationMinutes   int
+	Amount            decimal.Decimal
+}
+
+// NewSession opens a session for a vehicle entering at the given time
+func NewSession(locationID, plate, vehicleType, userRef string, entry time.Time) (*Session, error) {
+	plate = NormalizePlate(plate)
+	if plate == "" {
+		return nil, ErrInvalidPlate
+	}
+	if vehicleType == "" {
+		vehicleType = "car"
+	}
+	return &Session{
+		ExternalSessionID: "SIM-" + strings.ToUpper(uuid.New().String()[:8]),
+		LocationID:        locationID,
+		VehiclePlate:      plate,
+		VehicleType:       vehicleType,
+		UserRef:           userRef,
+		Status:            SessionStatusActive,
+		EntryTime:         entry.UTC(),
+		Amount:            decimal.Zero,
+	}, nil
+}
+
+// NormalizePlate uppercases a plate and removes spaces
+func NormalizePlate(plate string) string {
+	return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(plate), " ", ""))
+}
+
+// IsActive returns true while the vehicle is parked
+func (s *Session) IsActive() bool {
+	return s.Status == SessionStatusActive
+}
+
+// Refresh updates the duration and running amount of an active session
+func (s *Session) Refresh(now time.Time, pricing Pricing) {
+	if !s.IsActive() {
+		return
+	}
+	s.DurationMinutes = minutesBetween(s.EntryTime, now)
+	s.Amount = pricing.Charge(s.DurationMinutes)
+}
+
+// End closes the session at the given time and bills it
+func (s *Session) End(now time.Time, pricing Pricing) error {
+	if !s.IsActive() {
+		return ErrSessionEnded
+	}
+	exit := now.UTC()
+	s.DurationMinutes = minutesBetween(s.EntryTime, exit)
+	s.Amount = pricing.Charge(s.DurationMinutes)
+	s.Status = SessionStatusCompleted
+	s.ExitTime = &exit
+	return nil
+}
+
+func minutesBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	return int(to.Sub(from) / time.Minute)
+}
+
+// Pricing is the tariff the simulated provider charges
+type Pricing struct {
+	HourlyRate decimal.Decimal
+	DailyMax   decimal.Decimal
+	Currency   string
+}
+
+// Charge bills a stay the way operators do: every started hour at the
+// hourly rate, capped at the daily max for each day of the stay
+func (p Pricing) Charge(minutes int) decimal.Decimal {
+	if minutes <= 0 {
+		return decimal.Zero
+	}
+	hours := (minutes + 59) / 60
+	days, rest := hours/24, hours%24
+
+	amount := p.HourlyRate.Mul(decimal.NewFromInt(int64(rest)))
+	if p.DailyMax.IsPositive() && amount.GreaterThan(p.DailyMax) {
+		amount = p.DailyMax
+	}
+	daily := p.HourlyRate.Mul(decimal.NewFromInt(24))
+	if p.DailyMax.IsPositive() && daily.GreaterThan(p.DailyMax) {
+		daily = p.DailyMax
+	}
+	return amount.Add(daily.Mul(decimal.NewFromInt(int64(days)))).Round(2)
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var testPricing = Pricing{
+	HourlyRate: decimal.RequireFromString("2.50"),
+	DailyMax:   decimal.RequireFromString("20.00"),
+	Currency:   "MYR",
+}
+
+func TestPricing_Charge(t *testing.T) {
+	tests := []struct {
+		name    string
+		minutes int
+		want    string
+	}{
+		{"no time", 0, "0"},
+		{"first minute", 1, "2.5"},
+		{"one hour", 60, "2.5"},
+		{"started second hour", 61, "5"},
+		{"capped at daily max", 10 * 60, "20"},
+		{"one day and one hour", 25 * 60, "22.5"},
+		{"two full days", 48 * 60, "40"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testPricing.Charge(tt.minutes)
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Charge(%d) = %s, want %s", tt.minutes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSession_End(t *testing.T) {
+	entry := time.Date(2026, 3, 1, 8, 0, 0, 0, time.UTC)
+	session, err := NewSession("loc-1", " wxy 1234 ", "", "user-1", entry)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	if session.VehiclePlate != "WXY1234" || session.VehicleType != "car" || !session.IsActive() {
+		t.Errorf("unexpected new session: %+v", session)
+	}
+
+	session.Refresh(entry.Add(30*time.Minute), testPricing)
+	if session.DurationMinutes != 30 || !session.Amount.Equal(decimal.RequireFromString("2.50")) {
+		t.Errorf("Refresh() = %d minutes %s, want 30 minutes 2.50", session.DurationMinutes, session.Amount)
+	}
+
+	if err := session.End(entry.Add(90*time.Minute), testPricing); err != nil {
+		t.Fatalf("End() error = %v", err)
+	}
+	if session.Status != SessionStatusCompleted || session.DurationMinutes != 90 || !session.Amount.Equal(decimal.RequireFromString("5.00")) {
+		t.Errorf("unexpected ended session: %+v", session)
+	}
+	if err := session.End(entry.Add(2*time.Hour), testPricing); err != ErrSessionEnded {
+		t.Errorf("second End() error = %v, want ErrSessionEnded", err)
+	}
+
+	if _, err := NewSession("loc-1", "  ", "car", "", entry); err != ErrInvalidPlate {
+		t.Errorf("NewSession() with a blank plate error = %v, want ErrInvalidPlate", err)
+	}
+}
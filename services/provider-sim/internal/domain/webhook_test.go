@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	now := time.Unix(1772352000, 0)
+	body := []byte(`{"type":"session.status"}`)
+	timestamp := "1772352000"
+	signature := SignWebhook("secret", timestamp, body)
+
+	if err := VerifyWebhook("secret", timestamp, signature, body, now); err != nil {
+		t.Errorf("VerifyWebhook() error = %v", err)
+	}
+	if err := VerifyWebhook("other", timestamp, signature, body, now); err != ErrInvalidSignature {
+		t.Errorf("wrong secret: error = %v, want ErrInvalidSignature", err)
+	}
+	if err := VerifyWebhook("secret", timestamp, signature, []byte(`{}`), now); err != ErrInvalidSignature {
+		t.Errorf("changed body: error = %v, want ErrInvalidSignature", err)
+	}
+	if err := VerifyWebhook("secret", timestamp, signature, body, now.Add(10*time.Minute)); err != ErrInvalidSignature {
+		t.Errorf("stale timestamp: error = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -7,45 +7,72 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/pkg/eventstore"
 	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/httpserver"
 	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/migrate"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
+	"github.com/parking-super-app/pkg/storage"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/provider/config"
 	"github.com/parking-super-app/services/provider/internal/adapters/external"
 	grpcAdapter "github.com/parking-super-app/services/provider/internal/adapters/grpc"
 	httpAdapter "github.com/parking-super-app/services/provider/internal/adapters/http"
+	repocache "github.com/parking-super-app/services/provider/internal/adapters/repository/cache"
 	"github.com/parking-super-app/services/provider/internal/adapters/repository/postgres"
 	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
 	"github.com/parking-super-app/services/provider/internal/ports"
-	"google.golang.org/grpc"
+	"github.com/parking-super-app/services/provider/migrations"
+	"github.com/redis/go-redis/v9"
+	kafkago "github.com/segmentio/kafka-go"
 )
 
 func main() {
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	logger := external.NewStdLogger()
+	logger := logging.New(cfg.Log)
 	logger.Info("starting provider service")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Values with a reload hook change on SIGHUP or a POST to
+	// settings.ReloadPath; everything else needs a restart
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("log level", logger.ReloadLevel)
+	reloader.WatchSignals(ctx)
+
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -54,6 +81,17 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			logger.Info("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Connect to PostgreSQL
 	pool, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
 	if err != nil {
@@ -61,56 +99,377 @@ func main() {
 	}
 	defer pool.Close()
 
-	if err := pool.Ping(ctx); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	if err := startup.Wait(ctx, cfg.Startup, logger, "postgres", startup.Postgres(pool)); err != nil {
+		log.Fatalf("failed to reach database: %v", err)
 	}
 	logger.Info("connected to database")
 
+	// Schema migrations are embedded in the binary. "provider-service migrate ..."
+	// manages them and exits; otherwise they can be applied before serving.
+	migrator, err := migrate.New(pool, migrations.FS, logger)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.RunCommand(ctx, migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("failed to run migrations: %v", err)
+		}
+		return
+	}
+	if cfg.Database.MigrateOnStart {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+	}
+
 	// Initialize repositories
-	providerRepo := postgres.NewProviderRepository(pool)
+	var providerRepo ports.ProviderRepository = postgres.NewProviderRepository(pool)
 	credentialsRepo := postgres.NewCredentialsRepository(pool)
-	locationRepo := postgres.NewLocationRepository(pool)
+	var locationRepo ports.LocationRepository = postgres.NewLocationRepository(pool)
+	auditRepo := postgres.NewAuditRepository(pool)
+	pricingRepo := postgres.NewPricingRepository(pool)
+	webhookDeliveryRepo := postgres.NewWebhookDeliveryRepository(pool)
+	apiErrorRepo := postgres.NewAPIErrorRepository(pool)
+	conformanceRepo := postgres.NewConformanceRepository(pool)
+	auditStore := audit.NewPostgresStore(pool)
+	auditLog := audit.NewRecorder(auditStore, "provider")
+
+	// Cache provider and location reads, which every session start makes.
+	// All writers use the cached repositories so their changes evict entries.
+	var cachedProviders *repocache.ProviderRepository
+	var cachedLocations *repocache.LocationRepository
+	if cfg.Cache.Enabled {
+		var store cache.Store = cache.NewLRUStore(cfg.Cache.Size)
+		if cfg.Cache.RedisAddr != "" {
+			redisClient := redis.NewClient(&redis.Options{
+				Addr:     cfg.Cache.RedisAddr,
+				Password: cfg.Cache.RedisPassword,
+				DB:       cfg.Cache.RedisDB,
+			})
+			defer redisClient.Close()
+			store = cache.NewTiered(store, cache.NewRedisStore(redisClient, "provider:cache:"), cfg.Cache.LocalTTL)
+		}
+		cachedLocations = repocache.NewLocationRepository(locationRepo, store, cfg.Cache.TTL)
+		cachedProviders = repocache.NewProviderRepository(providerRepo, cachedLocations, store, cfg.Cache.TTL)
+		providerRepo, locationRepo = cachedProviders, cachedLocations
+		logger.Info("provider cache enabled", logging.Bool("redis", cfg.Cache.RedisAddr != ""))
+	}
+
+	// Record published events for the admin event browser
+	eventStore, closeEventStore, err := eventstore.OpenStore(ctx, eventstore.StoreConfig{
+		Backend:     cfg.EventStore.Backend,
+		PostgresURL: cfg.EventStore.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: event store unavailable, keeping events in memory: %v", err)
+		eventStore, closeEventStore = eventstore.NewMemoryStore(eventstore.DefaultMemoryCapacity), func() {}
+	}
+	defer closeEventStore()
 
 	// Initialize event publisher (Kafka or Noop)
 	var eventPublisher ports.EventPublisher
 	var kafkaPublisher *kafka.Publisher
 	if cfg.Kafka.Enabled {
+		if err := startup.Wait(ctx, cfg.Startup, logger, "kafka", startup.Kafka(cfg.Kafka.Brokers)); err != nil {
+			log.Fatalf("failed to reach Kafka: %v", err)
+		}
 		kafkaPublisher = kafka.NewPublisher(kafka.DefaultPublisherConfig(cfg.Kafka.Brokers, cfg.Kafka.Topic))
-		eventPublisher = &kafkaEventAdapter{publisher: kafkaPublisher}
+		eventPublisher = &kafkaEventAdapter{
+			publisher: eventstore.NewRecordingPublisher(kafkaPublisher, eventStore, "provider"),
+		}
 		logger.Info("Kafka event publisher initialized")
 	} else {
 		eventPublisher = external.NewNoopEventPublisher()
 	}
 
+	// Evict this replica's cache entries for changes made on other replicas.
+	// Each replica needs every event, so each has its own consumer group,
+	// starting from the latest event.
+	var cacheConsumer *kafka.Consumer
+	if cfg.Kafka.Enabled && cachedProviders != nil {
+		hostname, _ := os.Hostname()
+		consumerCfg := kafka.DefaultConsumerConfig(cfg.Kafka.Brokers, cfg.Kafka.Topic, "provider-cache-"+hostname)
+		consumerCfg.StartOffset = kafkago.LastOffset
+		cacheConsumer = kafka.NewConsumer(consumerCfg)
+		cacheConsumer.RegisterDefaultHandler(repocache.NewInvalidator(cachedProviders, cachedLocations).Handle)
+		go func() {
+			if err := cacheConsumer.Start(ctx); err != nil {
+				log.Printf("cache invalidation consumer error: %v", err)
+			}
+		}()
+	}
+
 	// Initialize application service
 	providerService := application.NewProviderService(
 		providerRepo,
 		credentialsRepo,
 		locationRepo,
+		conformanceRepo,
 		eventPublisher,
+		auditLog,
 		logger,
 	)
 
-	// Initialize HTTP router with tracing middleware
-	router := httpAdapter.NewRouter(providerService)
+	adminService := application.NewAdminService(
+		providerRepo,
+		locationRepo,
+		auditRepo,
+		conformanceRepo,
+		eventPublisher,
+		auditLog,
+		logger,
+	)
+
+	pricingService := application.NewPricingService(
+		providerRepo,
+		locationRepo,
+		pricingRepo,
+		eventPublisher,
+		logger,
+	)
+
+	portalService := application.NewPortalService(
+		providerRepo,
+		credentialsRepo,
+		webhookDeliveryRepo,
+		apiErrorRepo,
+		eventPublisher,
+		auditLog,
+		logger,
+		application.PortalConfig{
+			RotationGracePeriod: cfg.Portal.RotationGracePeriod,
+			APIErrorRetention:   cfg.Portal.APIErrorRetention,
+		},
+	)
+
+	conformanceService := application.NewConformanceService(
+		providerRepo,
+		credentialsRepo,
+		conformanceRepo,
+		external.NewHTTPSandboxClient(cfg.Conformance.RequestTimeout),
+		eventPublisher,
+		logger,
+	)
+
+	compoundService := application.NewCompoundService(
+		providerRepo,
+		credentialsRepo,
+		external.NewHTTPCompoundClient(cfg.Compounds.RequestTimeout),
+		logger,
+	)
+
+	// Events are sent one at a time, so a claimed batch is held for as long
+	// as sending all of it could take
+	webhookService := application.NewWebhookService(
+		providerRepo,
+		postgres.NewWebhookEventRepository(pool),
+		webhookDeliveryRepo,
+		external.NewHTTPWebhookSender(cfg.Webhooks.RequestTimeout),
+		eventPublisher,
+		auditLog,
+		logger,
+		application.WebhookConfig{
+			Retry: domain.WebhookRetryPolicy{
+				MaxAttempts: cfg.Webhooks.MaxAttempts,
+				BaseDelay:   cfg.Webhooks.RetryBaseDelay,
+				MaxDelay:    cfg.Webhooks.RetryMaxDelay,
+			},
+			BatchSize: cfg.Webhooks.BatchSize,
+			Lease:     cfg.Webhooks.RequestTimeout * time.Duration(cfg.Webhooks.BatchSize),
+		},
+	)
+
+	staffService := application.NewStaffService(
+		providerService,
+		pricingService,
+		locationRepo,
+		postgres.NewProviderSessionRepository(pool),
+		eventPublisher,
+		auditLog,
+		logger,
+	)
+
+	// Queue webhooks for payments and cancellations of providers' sessions
+	var parkingEventsConsumer, sessionReportConsumer *kafka.Consumer
+	if cfg.Kafka.Enabled && cfg.Kafka.ParkingEventsTopic != "" {
+		parkingEventsConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.ParkingEventsTopic,
+			cfg.Kafka.ConsumerGroup,
+		))
+		processed := kafka.NewPostgresProcessedStore(pool)
+		parkingEventsConsumer.SetProcessedStore(processed)
+		go kafka.PruneProcessed(ctx, processed, kafka.DefaultProcessedRetention)
+		for _, eventType := range webhookService.EventTypes() {
+			parkingEventsConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+				return webhookService.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		go func() {
+			logger.Info("starting Kafka consumer", ports.String("topic", cfg.Kafka.ParkingEventsTopic))
+			if err := parkingEventsConsumer.Start(ctx); err != nil {
+				log.Printf("Kafka consumer error: %v", err)
+			}
+		}()
+
+		// Session reports read the same events in their own group, so a
+		// failing webhook never holds them back
+		sessionReportConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.ParkingEventsTopic,
+			cfg.Kafka.ConsumerGroup+"-session-reports",
+		))
+		sessionReportConsumer.SetProcessedStore(processed)
+		for _, eventType := range staffService.EventTypes() {
+			sessionReportConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+				return staffService.Handle(ctx, event.Type, event.Payload)
+			})
+		}
+		go func() {
+			if err := sessionReportConsumer.Start(ctx); err != nil {
+				log.Printf("session report consumer error: %v", err)
+			}
+		}()
+	}
+
+	// Changes made by scheduled jobs are recorded as the system actor
+	jobCtx := actor.NewContext(ctx, actor.System)
+
+	// Send queued webhooks and retry failed ones once their backoff has passed
+	if cfg.Webhooks.DispatchEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Webhooks.DispatchInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case now := <-ticker.C:
+					if _, err := webhookService.DispatchDue(jobCtx, now.UTC()); err != nil {
+						logger.Error("webhook dispatch run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Lift suspensions whose scheduled reactivation date has passed
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := adminService.ReactivateDueProviders(jobCtx)
+				if err != nil {
+					logger.Error("scheduled reactivation failed", ports.Err(err))
+				} else if count > 0 {
+					logger.Info("reactivated suspended providers", ports.String("count", strconv.Itoa(count)))
+				}
+			}
+		}
+	}()
+
+	// Copy scheduled pricing onto locations once it takes effect
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				count, err := pricingService.ApplyDuePricing(jobCtx)
+				if err != nil {
+					logger.Error("scheduled pricing update failed", ports.Err(err))
+				} else if count > 0 {
+					logger.Info("applied scheduled pricing", ports.String("count", strconv.Itoa(count)))
+				}
+			}
+		}
+	}()
+
+	// Drop partner API errors that have aged out of the portal's retention window
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := portalService.PurgeExpiredAPIErrors(ctx, time.Now().UTC())
+				if err != nil {
+					logger.Error("API error log purge failed", ports.Err(err))
+				} else if removed > 0 {
+					logger.Info("purged API error log entries", ports.String("count", strconv.FormatInt(removed, 10)))
+				}
+			}
+		}
+	}()
+
+	// Purge providers and locations deleted for longer than the retention window
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				providers, locations, err := adminService.PurgeDeleted(jobCtx, time.Now().UTC(), cfg.Deletion.Retention)
+				if err != nil {
+					logger.Error("deleted record purge failed", ports.Err(err))
+				} else if providers > 0 || locations > 0 {
+					logger.Info("purged deleted providers and locations",
+						ports.String("providers", strconv.FormatInt(providers, 10)),
+						ports.String("locations", strconv.FormatInt(locations, 10)),
+					)
+				}
+			}
+		}
+	}()
+
+	// Provider logos: uploads are resized and stored for the CDN to serve
+	var logoStore ports.LogoStore
+	if cfg.Logos.Storage.Backend != "" {
+		store, err := storage.Open(cfg.Logos.Storage)
+		if err != nil {
+			log.Fatalf("failed to configure logo storage: %v", err)
+		}
+		logoStore = store
+		logger.Info("logo uploads enabled", ports.String("backend", cfg.Logos.Storage.Backend))
+	} else {
+		logger.Info("no logo storage configured, logo uploads disabled")
+	}
+	logoService := application.NewLogoService(providerRepo, logoStore, eventPublisher, auditLog, logger, application.LogoConfig{
+		CDNBaseURL: cfg.Logos.CDNBaseURL,
+	})
+
+	// Initialize HTTP router with request logging and tracing middleware.
+	// Tracing is added last so it wraps the request logger.
+	router := httpAdapter.NewRouter(providerService, adminService, pricingService, portalService, conformanceService, webhookService, staffService, logoService, auditStore)
+	router.Use(logging.RequestLogger(logger))
 	if cfg.OTEL.Enabled {
 		router.Use(middleware.Tracing(cfg.OTEL.ServiceName))
 	}
 
 	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	httpServer, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(router), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
 	// Create gRPC server
-	grpcServer := interceptors.NewServerWithDefaults()
-	providerGRPCServer := grpcAdapter.NewProviderServiceServer(providerService)
-	_ = providerGRPCServer // Register when proto is generated
-	// providerv1.RegisterProviderServiceServer(grpcServer, providerGRPCServer)
+	grpcTLS, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+	}
+	grpcServer := interceptors.NewServerWithDefaults(grpcTLS...)
+	providerGRPCServer := grpcAdapter.NewProviderServiceServer(providerService, pricingService, compoundService)
+	providerv1.RegisterProviderServiceServer(grpcServer, providerGRPCServer)
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
@@ -152,6 +511,23 @@ func main() {
 	grpcServer.GracefulStop()
 
 	// Close Kafka publisher
+	if cacheConsumer != nil {
+		if err := cacheConsumer.Close(); err != nil {
+			log.Printf("failed to close cache invalidation consumer: %v", err)
+		}
+	}
+
+	if parkingEventsConsumer != nil {
+		if err := parkingEventsConsumer.Close(); err != nil {
+			log.Printf("failed to close parking events consumer: %v", err)
+		}
+	}
+	if sessionReportConsumer != nil {
+		if err := sessionReportConsumer.Close(); err != nil {
+			log.Printf("failed to close session report consumer: %v", err)
+		}
+	}
+
 	if kafkaPublisher != nil {
 		if err := kafkaPublisher.Close(); err != nil {
 			log.Printf("failed to close Kafka publisher: %v", err)
@@ -164,13 +540,18 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	logger.Info("server stopped gracefully")
 }
 
 // kafkaEventAdapter adapts kafka.Publisher to ports.EventPublisher
 type kafkaEventAdapter struct {
-	publisher *kafka.Publisher
+	publisher kafka.EventPublisher
 }
 
 func (a *kafkaEventAdapter) Publish(ctx context.Context, event ports.Event) error {
@@ -5,22 +5,43 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
+	"github.com/parking-super-app/pkg/storage"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	GRPC     GRPCConfig
-	Kafka    KafkaConfig
-	OTEL     OTELConfig
+	Server      ServerConfig
+	Log         logging.Config
+	Startup     startup.Config
+	Database    DatabaseConfig
+	GRPC        GRPCConfig
+	Kafka       KafkaConfig
+	EventStore  EventStoreConfig
+	OTEL        OTELConfig
+	Portal      PortalConfig
+	Conformance ConformanceConfig
+	Compounds   CompoundsConfig
+	Cache       CacheConfig
+	Webhooks    WebhooksConfig
+	Logos       LogosConfig
+	Deletion    DeletionConfig
 }
 
 type ServerConfig struct {
 	Port string
+	HTTP httpserver.Config
 }
 
 type GRPCConfig struct {
 	Port string
+	// TLS secures the gRPC listener and calls to other services
+	TLS mtls.Config
 }
 
 type DatabaseConfig struct {
@@ -30,12 +51,24 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool
 }
 
 type KafkaConfig struct {
 	Brokers []string
 	Topic   string
 	Enabled bool
+	// ParkingEventsTopic feeds the outbound webhooks; empty disables the consumer
+	ParkingEventsTopic string
+	ConsumerGroup      string
+}
+
+// EventStoreConfig selects where published events are recorded for the
+// admin event browser: memory or postgres
+type EventStoreConfig struct {
+	Backend     string
+	PostgresURL string
 }
 
 type OTELConfig struct {
@@ -43,6 +76,64 @@ type OTELConfig struct {
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
+}
+
+type PortalConfig struct {
+	RotationGracePeriod time.Duration
+	APIErrorRetention   time.Duration
+}
+
+type ConformanceConfig struct {
+	RequestTimeout time.Duration
+}
+
+// CompoundsConfig controls calls to provider APIs for parking fines
+type CompoundsConfig struct {
+	RequestTimeout time.Duration
+}
+
+// CacheConfig controls the cache in front of provider and location reads.
+// Entries are kept in process memory for LocalTTL and, when RedisAddr is
+// set, in Redis for TTL; without Redis, local entries last TTL.
+type CacheConfig struct {
+	Enabled       bool
+	Size          int
+	TTL           time.Duration
+	LocalTTL      time.Duration
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+// WebhooksConfig controls outbound webhooks to provider endpoints. A failed
+// delivery is retried after RetryBaseDelay, doubling up to RetryMaxDelay,
+// until MaxAttempts attempts have been made.
+type WebhooksConfig struct {
+	DispatchEnabled  bool
+	DispatchInterval time.Duration
+	BatchSize        int
+	RequestTimeout   time.Duration
+	MaxAttempts      int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+}
+
+// LogosConfig selects where provider logos are stored and the CDN that
+// serves them. Logo uploads are disabled while no storage backend is set.
+type LogosConfig struct {
+	Storage    storage.Config
+	CDNBaseURL string
+}
+
+// DeletionConfig sets how long deleted providers and locations are kept,
+// and can be restored, before they are purged
+type DeletionConfig struct {
+	Retention time.Duration
 }
 
 func (d DatabaseConfig) ConnectionString() string {
@@ -53,38 +144,215 @@ func (d DatabaseConfig) ConnectionString() string {
 }
 
 func Load() (*Config, error) {
+	// Without CONFIG_STRICT these fall back to local development values
+	if err := settings.Require("DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME"); err != nil {
+		return nil, err
+	}
+
 	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
+	migrateOnStart, _ := strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	otelMetricsInterval, err := time.ParseDuration(getEnv("OTEL_METRICS_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTEL_METRICS_INTERVAL: %w", err)
+	}
+
+	rotationGrace, err := time.ParseDuration(getEnv("PORTAL_KEY_ROTATION_GRACE", "24h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PORTAL_KEY_ROTATION_GRACE: %w", err)
+	}
+	errorRetention, err := time.ParseDuration(getEnv("PORTAL_API_ERROR_RETENTION", "168h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid PORTAL_API_ERROR_RETENTION: %w", err)
+	}
+
+	conformanceTimeout, err := time.ParseDuration(getEnv("CONFORMANCE_REQUEST_TIMEOUT", "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid CONFORMANCE_REQUEST_TIMEOUT: %w", err)
+	}
+
+	compoundsTimeout, err := time.ParseDuration(getEnv("COMPOUNDS_REQUEST_TIMEOUT", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid COMPOUNDS_REQUEST_TIMEOUT: %w", err)
+	}
+
+	cacheCfg, err := loadCacheConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	webhooksCfg, err := loadWebhooksConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	deletedRetention, err := time.ParseDuration(getEnv("DELETED_RETENTION", "720h"))
+	if err != nil || deletedRetention <= 0 {
+		return nil, fmt.Errorf("invalid DELETED_RETENTION: must be a positive duration")
+	}
+
+	logosCfg, err := loadLogosConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	logCfg, err := logging.ConfigFromEnv("provider-service")
+	if err != nil {
+		return nil, err
+	}
+
+	startupCfg, err := startup.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
+		Log:     logCfg,
+		Startup: startupCfg,
 		GRPC: GRPCConfig{
 			Port: getEnv("GRPC_PORT", "9000"),
+			TLS:  grpcTLS,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5433"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "provider_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "5433"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "provider_db"),
+			SSLMode:        getEnv("DB_SSLMODE", "disable"),
+			MigrateOnStart: migrateOnStart,
 		},
 		Kafka: KafkaConfig{
 			Brokers: brokers,
 			Topic:   getEnv("KAFKA_TOPIC", "provider.events"),
 			Enabled: kafkaEnabled,
+
+			ParkingEventsTopic: getEnv("KAFKA_PARKING_EVENTS_TOPIC", "parking.events"),
+			ConsumerGroup:      getEnv("KAFKA_CONSUMER_GROUP", "provider-service"),
+		},
+		EventStore: EventStoreConfig{
+			Backend:     getEnv("EVENT_STORE_BACKEND", "memory"),
+			PostgresURL: getEnv("EVENT_STORE_POSTGRES_URL", ""),
 		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "provider-service"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "provider-service"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: otelMetricsInterval,
+			RuntimeMetrics:  otelRuntimeMetrics,
+		},
+		Portal: PortalConfig{
+			RotationGracePeriod: rotationGrace,
+			APIErrorRetention:   errorRetention,
+		},
+		Conformance: ConformanceConfig{
+			RequestTimeout: conformanceTimeout,
+		},
+		Compounds: CompoundsConfig{
+			RequestTimeout: compoundsTimeout,
 		},
+		Cache:    cacheCfg,
+		Webhooks: webhooksCfg,
+		Logos:    logosCfg,
+		Deletion: DeletionConfig{Retention: deletedRetention},
+	}, nil
+}
+
+func loadLogosConfig() (LogosConfig, error) {
+	logoStorage, err := storage.ConfigFromEnv("LOGOS")
+	if err != nil {
+		return LogosConfig{}, err
+	}
+	cdnBaseURL := getEnv("LOGOS_CDN_BASE_URL", "")
+	if logoStorage.Backend != "" && cdnBaseURL == "" {
+		return LogosConfig{}, fmt.Errorf("LOGOS_CDN_BASE_URL is required with LOGOS_STORAGE_BACKEND")
+	}
+	return LogosConfig{Storage: logoStorage, CDNBaseURL: cdnBaseURL}, nil
+}
+
+func loadCacheConfig() (CacheConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("CACHE_ENABLED", "true"))
+	size, err := strconv.Atoi(getEnv("CACHE_SIZE", "1000"))
+	if err != nil || size <= 0 {
+		return CacheConfig{}, fmt.Errorf("invalid CACHE_SIZE: must be a positive number")
+	}
+	ttl, err := time.ParseDuration(getEnv("CACHE_TTL", "5m"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("invalid CACHE_TTL: %w", err)
+	}
+	localTTL, err := time.ParseDuration(getEnv("CACHE_LOCAL_TTL", "30s"))
+	if err != nil {
+		return CacheConfig{}, fmt.Errorf("invalid CACHE_LOCAL_TTL: %w", err)
+	}
+	redisDB, _ := strconv.Atoi(getEnv("CACHE_REDIS_DB", "0"))
+
+	return CacheConfig{
+		Enabled:       enabled,
+		Size:          size,
+		TTL:           ttl,
+		LocalTTL:      localTTL,
+		RedisAddr:     getEnv("CACHE_REDIS_ADDR", ""),
+		RedisPassword: getEnv("CACHE_REDIS_PASSWORD", ""),
+		RedisDB:       redisDB,
+	}, nil
+}
+
+func loadWebhooksConfig() (WebhooksConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("WEBHOOK_DISPATCH_ENABLED", "true"))
+	interval, err := time.ParseDuration(getEnv("WEBHOOK_DISPATCH_INTERVAL", "10s"))
+	if err != nil || interval <= 0 {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_DISPATCH_INTERVAL: must be a positive duration")
+	}
+	batchSize, err := strconv.Atoi(getEnv("WEBHOOK_BATCH_SIZE", "100"))
+	if err != nil || batchSize <= 0 {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_BATCH_SIZE: must be a positive number")
+	}
+	timeout, err := time.ParseDuration(getEnv("WEBHOOK_REQUEST_TIMEOUT", "10s"))
+	if err != nil {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_REQUEST_TIMEOUT: %w", err)
+	}
+	maxAttempts, err := strconv.Atoi(getEnv("WEBHOOK_MAX_ATTEMPTS", "8"))
+	if err != nil || maxAttempts <= 0 {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_MAX_ATTEMPTS: must be a positive number")
+	}
+	baseDelay, err := time.ParseDuration(getEnv("WEBHOOK_RETRY_BASE_DELAY", "30s"))
+	if err != nil {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_RETRY_BASE_DELAY: %w", err)
+	}
+	maxDelay, err := time.ParseDuration(getEnv("WEBHOOK_RETRY_MAX_DELAY", "6h"))
+	if err != nil {
+		return WebhooksConfig{}, fmt.Errorf("invalid WEBHOOK_RETRY_MAX_DELAY: %w", err)
+	}
+
+	return WebhooksConfig{
+		DispatchEnabled:  enabled,
+		DispatchInterval: interval,
+		BatchSize:        batchSize,
+		RequestTimeout:   timeout,
+		MaxAttempts:      maxAttempts,
+		RetryBaseDelay:   baseDelay,
+		RetryMaxDelay:    maxDelay,
 	}, nil
 }
 
@@ -0,0 +1,115 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// HTTPCompoundClient looks up and settles parking fines through provider APIs
+type HTTPCompoundClient struct {
+	client *http.Client
+}
+
+// NewHTTPCompoundClient creates a compound client whose requests each time out after timeout
+func NewHTTPCompoundClient(timeout time.Duration) *HTTPCompoundClient {
+	return &HTTPCompoundClient{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *HTTPCompoundClient) Lookup(ctx context.Context, target ports.ProviderTarget, plate string) ([]ports.CompoundRecord, error) {
+	path := "/compounds?vehicle_plate=" + url.QueryEscape(plate)
+	resp, err := c.do(ctx, target, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GET /compounds returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Compounds []ports.CompoundRecord `json:"compounds"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("GET /compounds returned an invalid body: %w", err)
+	}
+	return body.Compounds, nil
+}
+
+func (c *HTTPCompoundClient) Settle(ctx context.Context, target ports.ProviderTarget, req ports.CompoundSettleRequest) (*domain.CompoundSettlement, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/compounds/" + url.PathEscape(req.Reference) + "/settle"
+	resp, err := c.do(ctx, target, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusConflict:
+		return nil, domain.ErrCompoundNotFound
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return nil, fmt.Errorf("POST %s returned status %d", path, resp.StatusCode)
+	}
+
+	var settlement struct {
+		ProviderReceipt string `json:"receipt"`
+		SettledAt       string `json:"settled_at"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&settlement); err != nil {
+		return nil, fmt.Errorf("POST %s returned an invalid body: %w", path, err)
+	}
+
+	settledAt, err := time.Parse(time.RFC3339, settlement.SettledAt)
+	if err != nil {
+		settledAt = time.Now().UTC()
+	}
+	return &domain.CompoundSettlement{
+		Reference:       req.Reference,
+		ProviderReceipt: settlement.ProviderReceipt,
+		SettledAt:       settledAt,
+	}, nil
+}
+
+func (c *HTTPCompoundClient) do(ctx context.Context, target ports.ProviderTarget, method, path string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(target.BaseURL, "/")+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid compound request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-API-Key", target.APIKey)
+	req.Header.Set("X-API-Secret", target.APISecret)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	return resp, nil
+}
+
+// Ensure HTTPCompoundClient implements ports.CompoundClient
+var _ ports.CompoundClient = (*HTTPCompoundClient)(nil)
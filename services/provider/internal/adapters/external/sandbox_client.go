@@ -0,0 +1,120 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// Headers sent with conformance webhooks. Providers verify the signature with
+// their sandbox API secret and echo the value they computed.
+const (
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// HTTPSandboxClient calls provider sandbox APIs over HTTP
+type HTTPSandboxClient struct {
+	client *http.Client
+}
+
+// NewHTTPSandboxClient creates a sandbox client whose requests each time out after timeout
+func NewHTTPSandboxClient(timeout time.Duration) *HTTPSandboxClient {
+	return &HTTPSandboxClient{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (c *HTTPSandboxClient) StartSession(ctx context.Context, target ports.SandboxTarget, req ports.SandboxSessionRequest) (*ports.SandboxSession, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return c.session(ctx, target, http.MethodPost, "/sandbox/sessions", body)
+}
+
+func (c *HTTPSandboxClient) GetSession(ctx context.Context, target ports.SandboxTarget, externalSessionID string) (*ports.SandboxSession, error) {
+	return c.session(ctx, target, http.MethodGet, "/sandbox/sessions/"+url.PathEscape(externalSessionID), nil)
+}
+
+func (c *HTTPSandboxClient) EndSession(ctx context.Context, target ports.SandboxTarget, externalSessionID string) (*ports.SandboxSession, error) {
+	return c.session(ctx, target, http.MethodPost, "/sandbox/sessions/"+url.PathEscape(externalSessionID)+"/end", nil)
+}
+
+func (c *HTTPSandboxClient) EchoWebhook(ctx context.Context, target ports.SandboxTarget, webhook ports.SignedWebhook) (int, string, error) {
+	req, err := c.newRequest(ctx, target, http.MethodPost, "/sandbox/webhooks/echo", webhook.Body)
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set(WebhookTimestampHeader, webhook.Timestamp)
+	req.Header.Set(WebhookSignatureHeader, webhook.Signature)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("webhook echo request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var echo struct {
+		Signature string `json:"signature"`
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&echo); err != nil {
+			return resp.StatusCode, "", fmt.Errorf("invalid webhook echo response: %w", err)
+		}
+	}
+	return resp.StatusCode, echo.Signature, nil
+}
+
+func (c *HTTPSandboxClient) session(ctx context.Context, target ports.SandboxTarget, method, path string, body []byte) (*ports.SandboxSession, error) {
+	req, err := c.newRequest(ctx, target, method, path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s %s returned status %d", method, path, resp.StatusCode)
+	}
+
+	var session ports.SandboxSession
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&session); err != nil {
+		return nil, fmt.Errorf("%s %s returned an invalid body: %w", method, path, err)
+	}
+	return &session, nil
+}
+
+func (c *HTTPSandboxClient) newRequest(ctx context.Context, target ports.SandboxTarget, method, path string, body []byte) (*http.Request, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(target.BaseURL, "/")+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sandbox request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("X-API-Key", target.APIKey)
+	req.Header.Set("X-API-Secret", target.APISecret)
+	return req, nil
+}
+
+// Ensure HTTPSandboxClient implements ports.SandboxClient
+var _ ports.SandboxClient = (*HTTPSandboxClient)(nil)
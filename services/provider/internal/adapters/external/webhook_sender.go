@@ -0,0 +1,56 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// HTTPWebhookSender posts signed webhooks to provider endpoints. Signatures
+// use the same scheme as the conformance kit, with the provider's webhook
+// secret as the key.
+type HTTPWebhookSender struct {
+	client *http.Client
+}
+
+// NewHTTPWebhookSender creates a sender whose requests each time out after timeout
+func NewHTTPWebhookSender(timeout time.Duration) *HTTPWebhookSender {
+	return &HTTPWebhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			// A redirect would resend the body somewhere the provider did not register
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *HTTPWebhookSender) Send(ctx context.Context, url string, body []byte, secret string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("invalid webhook request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	req.Header.Set(WebhookSignatureHeader, domain.SignWebhook(secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("webhook request failed: %w", err)
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// Ensure HTTPWebhookSender implements ports.WebhookSender
+var _ ports.WebhookSender = (*HTTPWebhookSender)(nil)
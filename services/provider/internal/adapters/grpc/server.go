@@ -2,9 +2,13 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
 	"github.com/parking-super-app/services/provider/internal/application"
 	"github.com/parking-super-app/services/provider/internal/domain"
 	"github.com/shopspring/decimal"
@@ -12,92 +16,27 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// ProviderServiceServer implements the gRPC ProviderService
+// ProviderServiceServer implements providerv1.ProviderServiceServer
 type ProviderServiceServer struct {
+	providerv1.UnimplementedProviderServiceServer
 	providerService *application.ProviderService
+	pricingService  *application.PricingService
+	compoundService *application.CompoundService
 }
 
 // NewProviderServiceServer creates a new gRPC server for the provider service
-func NewProviderServiceServer(ps *application.ProviderService) *ProviderServiceServer {
+func NewProviderServiceServer(ps *application.ProviderService, pricing *application.PricingService, compounds *application.CompoundService) *ProviderServiceServer {
 	return &ProviderServiceServer{
 		providerService: ps,
+		pricingService:  pricing,
+		compoundService: compounds,
 	}
 }
 
-// Request/Response types for gRPC
-
-type StartSessionRequest struct {
-	ProviderID   string
-	LocationID   string
-	VehiclePlate string
-	VehicleType  string
-	UserRef      string
-}
-
-type StartSessionResponse struct {
-	ExternalSessionID string
-	EntryTime         string
-	Status            string
-	ErrorMessage      string
-}
-
-type EndSessionRequest struct {
-	ProviderID        string
-	ExternalSessionID string
-}
-
-type EndSessionResponse struct {
-	ExitTime        string
-	DurationMinutes int32
-	Amount          string
-	Currency        string
-	ErrorMessage    string
-}
-
-type GetSessionStatusRequest struct {
-	ProviderID        string
-	ExternalSessionID string
-}
-
-type SessionStatusResponse struct {
-	Status          string
-	DurationMinutes int32
-	CurrentAmount   string
-	Currency        string
-	EntryTime       string
-}
-
-type GetProviderRequest struct {
-	ID string
-}
-
-type ProviderResponse struct {
-	ID         string
-	Name       string
-	Code       string
-	Status     string
-	MFEURL     string
-	APIBaseURL string
-	LogoURL    string
-	CreatedAt  string
-	UpdatedAt  string
-}
-
-type ListProvidersRequest struct {
-	ActiveOnly bool
-	Limit      int32
-	Offset     int32
-}
-
-type ListProvidersResponse struct {
-	Providers []*ProviderResponse
-	Total     int32
-}
-
 // StartSession initiates a parking session with the provider
 // This simulates the provider's API - in production this would call the actual provider
-func (s *ProviderServiceServer) StartSession(ctx context.Context, req *StartSessionRequest) (*StartSessionResponse, error) {
-	providerID, err := uuid.Parse(req.ProviderID)
+func (s *ProviderServiceServer) StartSession(ctx context.Context, req *providerv1.StartSessionRequest) (*providerv1.StartSessionResponse, error) {
+	providerID, err := uuid.Parse(req.ProviderId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
 	}
@@ -119,16 +58,16 @@ func (s *ProviderServiceServer) StartSession(ctx context.Context, req *StartSess
 	externalSessionID := uuid.New().String()
 	entryTime := time.Now().UTC()
 
-	return &StartSessionResponse{
-		ExternalSessionID: externalSessionID,
+	return &providerv1.StartSessionResponse{
+		ExternalSessionId: externalSessionID,
 		EntryTime:         entryTime.Format(time.RFC3339),
 		Status:            "active",
 	}, nil
 }
 
 // EndSession terminates a parking session
-func (s *ProviderServiceServer) EndSession(ctx context.Context, req *EndSessionRequest) (*EndSessionResponse, error) {
-	providerID, err := uuid.Parse(req.ProviderID)
+func (s *ProviderServiceServer) EndSession(ctx context.Context, req *providerv1.EndSessionRequest) (*providerv1.EndSessionResponse, error) {
+	providerID, err := uuid.Parse(req.ProviderId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
 	}
@@ -148,7 +87,7 @@ func (s *ProviderServiceServer) EndSession(ctx context.Context, req *EndSessionR
 	duration := 60 // Simulated 60 minutes
 	amount := decimal.NewFromFloat(5.00)
 
-	return &EndSessionResponse{
+	return &providerv1.EndSessionResponse{
 		ExitTime:        exitTime.Format(time.RFC3339),
 		DurationMinutes: int32(duration),
 		Amount:          amount.String(),
@@ -157,8 +96,8 @@ func (s *ProviderServiceServer) EndSession(ctx context.Context, req *EndSessionR
 }
 
 // GetSessionStatus retrieves the current status of a session
-func (s *ProviderServiceServer) GetSessionStatus(ctx context.Context, req *GetSessionStatusRequest) (*SessionStatusResponse, error) {
-	providerID, err := uuid.Parse(req.ProviderID)
+func (s *ProviderServiceServer) GetSessionStatus(ctx context.Context, req *providerv1.GetSessionStatusRequest) (*providerv1.SessionStatusResponse, error) {
+	providerID, err := uuid.Parse(req.ProviderId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
 	}
@@ -173,7 +112,7 @@ func (s *ProviderServiceServer) GetSessionStatus(ctx context.Context, req *GetSe
 	}
 
 	// Simulated session status
-	return &SessionStatusResponse{
+	return &providerv1.SessionStatusResponse{
 		Status:          "active",
 		DurationMinutes: 30,
 		CurrentAmount:   "2.50",
@@ -183,8 +122,8 @@ func (s *ProviderServiceServer) GetSessionStatus(ctx context.Context, req *GetSe
 }
 
 // GetProvider retrieves provider information by ID
-func (s *ProviderServiceServer) GetProvider(ctx context.Context, req *GetProviderRequest) (*ProviderResponse, error) {
-	id, err := uuid.Parse(req.ID)
+func (s *ProviderServiceServer) GetProvider(ctx context.Context, req *providerv1.GetProviderRequest) (*providerv1.ProviderResponse, error) {
+	id, err := uuid.Parse(req.Id)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid provider id")
 	}
@@ -197,39 +136,261 @@ func (s *ProviderServiceServer) GetProvider(ctx context.Context, req *GetProvide
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &ProviderResponse{
-		ID:         provider.ID.String(),
+	return &providerv1.ProviderResponse{
+		Id:         provider.ID.String(),
 		Name:       provider.Name,
 		Code:       provider.Code,
 		Status:     provider.Status,
-		MFEURL:     provider.MFEURL,
-		APIBaseURL: provider.APIBaseURL,
-		LogoURL:    provider.LogoURL,
+		MfeUrl:     provider.MFEURL,
+		ApiBaseUrl: provider.APIBaseURL,
+		LogoUrl:    provider.LogoURL,
 	}, nil
 }
 
 // ListProviders lists all available providers
-func (s *ProviderServiceServer) ListProviders(ctx context.Context, req *ListProvidersRequest) (*ListProvidersResponse, error) {
+func (s *ProviderServiceServer) ListProviders(ctx context.Context, req *providerv1.ListProvidersRequest) (*providerv1.ListProvidersResponse, error) {
 	providers, err := s.providerService.ListProviders(ctx, req.ActiveOnly)
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	responses := make([]*ProviderResponse, len(providers))
+	responses := make([]*providerv1.ProviderResponse, len(providers))
 	for i, p := range providers {
-		responses[i] = &ProviderResponse{
-			ID:         p.ID.String(),
+		responses[i] = &providerv1.ProviderResponse{
+			Id:         p.ID.String(),
 			Name:       p.Name,
 			Code:       p.Code,
 			Status:     p.Status,
-			MFEURL:     p.MFEURL,
-			APIBaseURL: p.APIBaseURL,
-			LogoURL:    p.LogoURL,
+			MfeUrl:     p.MFEURL,
+			ApiBaseUrl: p.APIBaseURL,
+			LogoUrl:    p.LogoURL,
 		}
 	}
 
-	return &ListProvidersResponse{
+	return &providerv1.ListProvidersResponse{
 		Providers: responses,
 		Total:     int32(len(responses)),
 	}, nil
 }
+
+// GetLocation retrieves a parking location. Providers do not report live
+// occupancy, so available_slots is the location's capacity.
+func (s *ProviderServiceServer) GetLocation(ctx context.Context, req *providerv1.GetLocationRequest) (*providerv1.LocationResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid location id")
+	}
+
+	location, err := s.providerService.GetLocation(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil, status.Error(codes.NotFound, "location not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	locationStatus := "active"
+	if !location.IsActive {
+		locationStatus = "inactive"
+	}
+	return &providerv1.LocationResponse{
+		Id:             location.ID.String(),
+		ProviderId:     location.ProviderID.String(),
+		Name:           location.Name,
+		Address:        location.Address,
+		Latitude:       location.Latitude,
+		Longitude:      location.Longitude,
+		TotalSlots:     int32(location.TotalSpaces),
+		AvailableSlots: int32(location.TotalSpaces),
+		Status:         locationStatus,
+	}, nil
+}
+
+// ListLocations lists active locations within radius_km of the coordinates,
+// nearest first, or a provider's locations when no radius is given
+func (s *ProviderServiceServer) ListLocations(ctx context.Context, req *providerv1.ListLocationsRequest) (*providerv1.ListLocationsResponse, error) {
+	var locations []*application.LocationResponse
+	var err error
+	switch {
+	case req.RadiusKm > 0:
+		if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+			return nil, status.Error(codes.InvalidArgument, "invalid coordinates")
+		}
+		locations, err = s.providerService.GetNearbyLocations(ctx, req.Latitude, req.Longitude, req.RadiusKm, domain.LocationFilter{})
+	case req.ProviderId != "":
+		providerID, parseErr := uuid.Parse(req.ProviderId)
+		if parseErr != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
+		}
+		locations, err = s.providerService.GetProviderLocations(ctx, providerID)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "radius_km or provider_id is required")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	total := len(locations)
+	offset := int(req.Offset)
+	if offset > total {
+		offset = total
+	}
+	locations = locations[offset:]
+	if req.Limit > 0 && int(req.Limit) < len(locations) {
+		locations = locations[:req.Limit]
+	}
+
+	resp := &providerv1.ListLocationsResponse{
+		Locations: make([]*providerv1.LocationResponse, 0, len(locations)),
+		Total:     int32(total),
+	}
+	for _, location := range locations {
+		locationStatus := "active"
+		if !location.IsActive {
+			locationStatus = "inactive"
+		}
+		resp.Locations = append(resp.Locations, &providerv1.LocationResponse{
+			Id:             location.ID.String(),
+			ProviderId:     location.ProviderID.String(),
+			Name:           location.Name,
+			Address:        location.Address,
+			Latitude:       location.Latitude,
+			Longitude:      location.Longitude,
+			TotalSlots:     int32(location.TotalSpaces),
+			AvailableSlots: int32(location.TotalSpaces),
+			Status:         locationStatus,
+		})
+	}
+	return resp, nil
+}
+
+// GetLocationPricing returns the pricing in effect at a location at the given time,
+// so sessions can be billed at the rate that applied when they started
+func (s *ProviderServiceServer) GetLocationPricing(ctx context.Context, req *providerv1.GetLocationPricingRequest) (*providerv1.LocationPricingResponse, error) {
+	locationID, err := uuid.Parse(req.LocationId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid location_id")
+	}
+
+	at := time.Now().UTC()
+	if req.At != "" {
+		at, err = time.Parse(time.RFC3339, req.At)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid at timestamp")
+		}
+	}
+
+	pricing, err := s.pricingService.GetLocationPricing(ctx, locationID, at)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil, status.Error(codes.NotFound, "location not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &providerv1.LocationPricingResponse{
+		LocationId:      pricing.LocationID.String(),
+		HourlyRate:      decimal.NewFromFloat(pricing.HourlyRate).StringFixed(2),
+		DailyMax:        decimal.NewFromFloat(pricing.DailyMax).StringFixed(2),
+		Currency:        pricing.Currency,
+		GracePeriodMin:  int32(pricing.GracePeriodMin),
+		EffectiveFrom:   pricing.EffectiveFrom.Format(time.RFC3339),
+		PricingSchedule: toPricingSchedule(pricing.PricingSchedule),
+	}, nil
+}
+
+func toPricingSchedule(schedule *pricing.Schedule) *providerv1.PricingSchedule {
+	if schedule == nil {
+		return nil
+	}
+	rules := make([]*providerv1.PricingRule, len(schedule.Rules))
+	for i, r := range schedule.Rules {
+		days := make([]string, len(r.Days))
+		for j, d := range r.Days {
+			days[j] = string(d)
+		}
+		rule := &providerv1.PricingRule{
+			Name:       r.Name,
+			Kind:       string(r.Kind),
+			Days:       days,
+			Start:      r.Start,
+			End:        r.End,
+			HourlyRate: r.HourlyRate.StringFixed(2),
+			FlatRate:   r.FlatRate.StringFixed(2),
+		}
+		if r.GracePeriodMin != nil {
+			rule.GracePeriodMin = int32(*r.GracePeriodMin)
+		}
+		rules[i] = rule
+	}
+	return &providerv1.PricingSchedule{Timezone: schedule.Timezone, Rules: rules}
+}
+
+// ListCompounds returns a plate's outstanding fines from every provider that
+// issues them. Providers that did not answer are named in failed_provider_ids.
+func (s *ProviderServiceServer) ListCompounds(ctx context.Context, req *providerv1.ListCompoundsRequest) (*providerv1.ListCompoundsResponse, error) {
+	if strings.TrimSpace(req.VehiclePlate) == "" {
+		return nil, status.Error(codes.InvalidArgument, "vehicle_plate is required")
+	}
+
+	result, err := s.compoundService.LookupCompounds(ctx, req.VehiclePlate)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &providerv1.ListCompoundsResponse{
+		Compounds: make([]*providerv1.Compound, len(result.Compounds)),
+	}
+	for i, c := range result.Compounds {
+		compound := &providerv1.Compound{
+			ProviderId:   c.ProviderID.String(),
+			Reference:    c.Reference,
+			VehiclePlate: c.VehiclePlate,
+			Offence:      c.Offence,
+			Location:     c.Location,
+			IssuedAt:     c.IssuedAt.Format(time.RFC3339),
+			Amount:       c.Amount.StringFixed(2),
+			Currency:     c.Currency,
+		}
+		if c.DueAt != nil {
+			compound.DueAt = c.DueAt.Format(time.RFC3339)
+		}
+		resp.Compounds[i] = compound
+	}
+	for _, id := range result.FailedProviderIDs {
+		resp.FailedProviderIds = append(resp.FailedProviderIds, id.String())
+	}
+	return resp, nil
+}
+
+// SettleCompound tells the issuing provider that a fine has been paid
+func (s *ProviderServiceServer) SettleCompound(ctx context.Context, req *providerv1.SettleCompoundRequest) (*providerv1.SettleCompoundResponse, error) {
+	providerID, err := uuid.Parse(req.ProviderId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid provider_id")
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || req.Reference == "" || req.PaymentReference == "" {
+		return nil, status.Error(codes.InvalidArgument, "reference, amount and payment_reference are required")
+	}
+
+	settlement, err := s.compoundService.SettleCompound(ctx, providerID, req.Reference, amount, req.PaymentReference)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProviderNotFound):
+			return nil, status.Error(codes.NotFound, "provider not found")
+		case errors.Is(err, domain.ErrCompoundNotFound):
+			return nil, status.Error(codes.NotFound, "compound not outstanding at provider")
+		case errors.Is(err, domain.ErrCompoundsNotSupported):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+	}
+
+	return &providerv1.SettleCompoundResponse{
+		Reference:       settlement.Reference,
+		ProviderReceipt: settlement.ProviderReceipt,
+		SettledAt:       settlement.SettledAt.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type AdminHandler struct {
+	adminService *application.AdminService
+}
+
+func NewAdminHandler(adminService *application.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+func mapAdminError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrProviderNotPending):
+		return http.StatusConflict, "PROVIDER_NOT_PENDING", "Provider is not pending approval"
+	case errors.Is(err, domain.ErrProviderNotSuspended):
+		return http.StatusConflict, "PROVIDER_NOT_SUSPENDED", "Provider is not suspended"
+	case errors.Is(err, domain.ErrReasonRequired):
+		return http.StatusBadRequest, "REASON_REQUIRED", "A reason is required"
+	case errors.Is(err, domain.ErrInvalidReactivation):
+		return http.StatusBadRequest, "INVALID_REACTIVATION_DATE", "Reactivation date must be in the future"
+	case errors.Is(err, domain.ErrProviderNotDeleted):
+		return http.StatusConflict, "PROVIDER_NOT_DELETED", "Provider is not deleted"
+	case errors.Is(err, domain.ErrLocationNotDeleted):
+		return http.StatusConflict, "LOCATION_NOT_DELETED", "Location is not deleted"
+	case errors.Is(err, domain.ErrLocationNotOwned):
+		return http.StatusNotFound, "LOCATION_NOT_FOUND", "Location not found"
+	case errors.Is(err, domain.ErrActorRequired):
+		return http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *AdminHandler) ListPendingProviders(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.adminService.ListPendingProviders(r.Context())
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) ApproveProvider(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	resp, err := h.adminService.ApproveProvider(r.Context(), id, r.Header.Get("X-User-ID"))
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) RejectProvider(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	var req application.RejectProviderRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.ProviderID = id
+	req.Actor = r.Header.Get("X-User-ID")
+
+	resp, err := h.adminService.RejectProvider(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) SuspendProvider(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	var req application.SuspendProviderRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.ProviderID = id
+	req.Actor = r.Header.Get("X-User-ID")
+
+	resp, err := h.adminService.SuspendProvider(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) GetProviderHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	limit := 50
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+
+	resp, err := h.adminService.GetProviderHistory(r.Context(), id, limit, offset)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) DeleteProvider(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	if err := h.adminService.DeleteProvider(r.Context(), id, r.Header.Get("X-User-ID")); err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) RestoreProvider(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	resp, err := h.adminService.RestoreProvider(r.Context(), id, r.Header.Get("X-User-ID"))
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) ListDeletedProviders(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.adminService.ListDeletedProviders(r.Context())
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	providerID, locationID, ok := providerLocationParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.adminService.DeleteLocation(r.Context(), providerID, locationID, r.Header.Get("X-User-ID")); err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminHandler) RestoreLocation(w http.ResponseWriter, r *http.Request) {
+	providerID, locationID, ok := providerLocationParams(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.adminService.RestoreLocation(r.Context(), providerID, locationID, r.Header.Get("X-User-ID"))
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *AdminHandler) ListDeletedLocations(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	resp, err := h.adminService.ListDeletedLocations(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func providerLocationParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	providerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+	locationID, err := uuid.Parse(chi.URLParam(r, "locationID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid location ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return providerID, locationID, true
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type ConformanceHandler struct {
+	conformanceService *application.ConformanceService
+}
+
+func NewConformanceHandler(conformanceService *application.ConformanceService) *ConformanceHandler {
+	return &ConformanceHandler{conformanceService: conformanceService}
+}
+
+func mapConformanceError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrConformanceReportNotFound):
+		return http.StatusNotFound, "CONFORMANCE_REPORT_NOT_FOUND", "No conformance report found for this provider"
+	case errors.Is(err, domain.ErrAPIBaseURLRequired):
+		return http.StatusUnprocessableEntity, "API_BASE_URL_REQUIRED", "Provider has no API base URL to test"
+	case errors.Is(err, domain.ErrSandboxCredentialsRequired):
+		return http.StatusUnprocessableEntity, "SANDBOX_CREDENTIALS_REQUIRED", "Provider needs active sandbox credentials"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// RunConformance runs the conformance kit against a provider on behalf of an admin
+func (h *ConformanceHandler) RunConformance(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	h.run(w, r, id, r.Header.Get("X-User-ID"))
+}
+
+func (h *ConformanceHandler) GetLatestReport(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	h.latest(w, r, id)
+}
+
+func (h *ConformanceHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	resp, err := h.conformanceService.ListReports(r.Context(), id, queryInt(r, "limit"), queryInt(r, "offset"))
+	if err != nil {
+		status, code, msg := mapConformanceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RunOwnConformance lets a partner test their own sandbox from the developer portal
+func (h *ConformanceHandler) RunOwnConformance(w http.ResponseWriter, r *http.Request) {
+	caller := portalCaller(r)
+	h.run(w, r, caller.ProviderID, "portal:"+caller.ID.String())
+}
+
+func (h *ConformanceHandler) GetOwnLatestReport(w http.ResponseWriter, r *http.Request) {
+	h.latest(w, r, portalCaller(r).ProviderID)
+}
+
+func (h *ConformanceHandler) run(w http.ResponseWriter, r *http.Request, providerID uuid.UUID, runBy string) {
+	resp, err := h.conformanceService.RunConformance(r.Context(), providerID, runBy)
+	if err != nil {
+		status, code, msg := mapConformanceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *ConformanceHandler) latest(w http.ResponseWriter, r *http.Request, providerID uuid.UUID) {
+	resp, err := h.conformanceService.GetLatestReport(r.Context(), providerID)
+	if err != nil {
+		status, code, msg := mapConformanceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
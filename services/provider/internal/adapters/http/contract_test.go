@@ -0,0 +1,21 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	providerv1 "github.com/parking-super-app/pkg/proto/provider/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestContracts verifies the routes and gRPC methods that other services rely on
+func TestContracts(t *testing.T) {
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	contract.Verify(t, contract.Provider{
+		Name:   "provider",
+		Routes: router.router,
+		Services: []protoreflect.ServiceDescriptor{
+			providerv1.File_provider_v1_provider_proto.Services().ByName("ProviderService"),
+		},
+	})
+}
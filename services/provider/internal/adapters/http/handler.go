@@ -1,12 +1,14 @@
 package http
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/provider/internal/application"
 	"github.com/parking-super-app/services/provider/internal/domain"
 )
@@ -19,33 +21,6 @@ func NewProviderHandler(providerService *application.ProviderService) *ProviderH
 	return &ProviderHandler{providerService: providerService}
 }
 
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   *APIError   `json:"error,omitempty"`
-}
-
-type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: status >= 200 && status < 300,
-		Data:    data,
-	})
-}
-
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: false,
-		Error:   &APIError{Code: code, Message: message},
-	})
-}
-
 func mapDomainError(err error) (int, string, string) {
 	switch {
 	case errors.Is(err, domain.ErrProviderNotFound):
@@ -56,8 +31,26 @@ func mapDomainError(err error) (int, string, string) {
 		return http.StatusBadRequest, "INVALID_CODE", "Provider code must be alphanumeric"
 	case errors.Is(err, domain.ErrInvalidMFEURL):
 		return http.StatusBadRequest, "INVALID_MFE_URL", "Invalid MFE URL"
+	case errors.Is(err, domain.ErrInvalidMFEVersion):
+		return http.StatusBadRequest, "INVALID_MFE_VERSION", "MFE version must be semantic (major.minor.patch)"
+	case errors.Is(err, domain.ErrInvalidMFECapability):
+		return http.StatusBadRequest, "INVALID_MFE_CAPABILITY", "MFE capabilities must be lowercase dotted names"
 	case errors.Is(err, domain.ErrProviderInactive):
 		return http.StatusForbidden, "PROVIDER_INACTIVE", "Provider is not active"
+	case errors.Is(err, domain.ErrConformanceNotPassed):
+		return http.StatusConflict, "CONFORMANCE_REQUIRED", "Provider must pass the API conformance check before activation"
+	case errors.Is(err, domain.ErrUnknownAmenity):
+		return http.StatusBadRequest, "UNKNOWN_AMENITY", "Amenities must be among " + amenityList()
+	case errors.Is(err, domain.ErrInvalidHeightLimit):
+		return http.StatusBadRequest, "INVALID_HEIGHT_LIMIT", "Height limit must be a positive number of centimetres"
+	case errors.Is(err, domain.ErrInvalidBoundingBox):
+		return http.StatusBadRequest, "INVALID_BBOX", err.Error()
+	case errors.Is(err, domain.ErrInvalidZoom):
+		return http.StatusBadRequest, "INVALID_ZOOM", err.Error()
+	case errors.Is(err, domain.ErrInvalidTile):
+		return http.StatusBadRequest, "INVALID_TILE", err.Error()
+	case errors.Is(err, domain.ErrBoundingBoxTooLarge):
+		return http.StatusBadRequest, "BBOX_TOO_LARGE", "Bounding box is too large for the zoom level; zoom in or narrow the box"
 	default:
 		return http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred"
 	}
@@ -65,54 +58,54 @@ func mapDomainError(err error) (int, string, string) {
 
 func (h *ProviderHandler) RegisterProvider(w http.ResponseWriter, r *http.Request) {
 	var req application.RegisterProviderRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	resp, err := h.providerService.RegisterProvider(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *ProviderHandler) GetProvider(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	resp, err := h.providerService.GetProvider(r.Context(), id)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *ProviderHandler) GetProviderByCode(w http.ResponseWriter, r *http.Request) {
 	code := chi.URLParam(r, "code")
 	if code == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_CODE", "Provider code is required")
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_CODE", "Provider code is required")
 		return
 	}
 
 	resp, err := h.providerService.GetProviderByCode(r.Context(), code)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *ProviderHandler) ListProviders(w http.ResponseWriter, r *http.Request) {
@@ -121,45 +114,86 @@ func (h *ProviderHandler) ListProviders(w http.ResponseWriter, r *http.Request)
 	resp, err := h.providerService.ListProviders(r.Context(), activeOnly)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetMFEManifest lists the MFE modules of active providers.
+//
+// GET /api/v1/providers/manifest
+func (h *ProviderHandler) GetMFEManifest(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.providerService.GetMFEManifest(r.Context())
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// PublishMFE records a new MFE release for the provider.
+//
+// POST /api/v1/providers/{id}/mfe
+// Request: { "mfe_url": "...", "version": "2.1.0", "capabilities": ["wallet.pay"], "health_url": "..." }
+func (h *ProviderHandler) PublishMFE(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	var req application.PublishMFERequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.providerService.PublishMFE(r.Context(), id, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *ProviderHandler) ActivateProvider(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	if err := h.providerService.ActivateProvider(r.Context(), id); err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "activated"})
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"status": "activated"})
 }
 
 func (h *ProviderHandler) DeactivateProvider(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	if err := h.providerService.DeactivateProvider(r.Context(), id); err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "deactivated"})
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"status": "deactivated"})
 }
 
 type GenerateCredentialsRequest struct {
@@ -170,12 +204,12 @@ func (h *ProviderHandler) GenerateCredentials(w http.ResponseWriter, r *http.Req
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	var req GenerateCredentialsRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
 		req.Environment = "sandbox"
 	}
 
@@ -187,24 +221,24 @@ func (h *ProviderHandler) GenerateCredentials(w http.ResponseWriter, r *http.Req
 	resp, err := h.providerService.GenerateCredentials(r.Context(), id, env)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *ProviderHandler) AddLocation(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	providerID, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	var req application.AddLocationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 	req.ProviderID = providerID
@@ -212,27 +246,173 @@ func (h *ProviderHandler) AddLocation(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.providerService.AddLocation(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *ProviderHandler) GetProviderLocations(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
 		return
 	}
 
 	resp, err := h.providerService.GetProviderLocations(r.Context(), id)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// maxNearbyRadiusKm bounds nearby searches so one request can't scan the
+// whole country
+const maxNearbyRadiusKm = 50
+
+// GetNearbyLocations lists active locations within radius_km (default 5)
+// of lat/lng, nearest first. amenities (comma-separated) keeps locations
+// offering all of them; vehicle_height_cm drops those too low for it.
+func (h *ProviderHandler) GetNearbyLocations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	lat, latErr := strconv.ParseFloat(query.Get("lat"), 64)
+	lng, lngErr := strconv.ParseFloat(query.Get("lng"), 64)
+	if latErr != nil || lngErr != nil || lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_COORDINATES", "lat and lng are required and must be valid coordinates")
+		return
+	}
+
+	radiusKm := 5.0
+	if v := query.Get("radius_km"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 || parsed > maxNearbyRadiusKm {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_RADIUS", "radius_km must be greater than 0 and at most 50")
+			return
+		}
+		radiusKm = parsed
+	}
+
+	vehicleHeight := 0
+	if v := query.Get("vehicle_height_cm"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_VEHICLE_HEIGHT", "vehicle_height_cm must be a positive whole number")
+			return
+		}
+		vehicleHeight = parsed
+	}
+	filter, err := domain.NewLocationFilter(queryList(query["amenities"]), vehicleHeight)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	resp, err := h.providerService.GetNearbyLocations(r.Context(), lat, lng, radiusKm, filter)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListAmenities lists the amenities locations can offer and be searched by
+func (h *ProviderHandler) ListAmenities(w http.ResponseWriter, r *http.Request) {
+	httpx.WriteJSON(w, http.StatusOK, map[string]interface{}{"amenities": domain.Amenities})
+}
+
+// GetLocationClusters groups the active locations in bbox
+// (west,south,east,north) for a map at zoom, so the app draws one pin per
+// cluster instead of every location
+func (h *ProviderHandler) GetLocationClusters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	box, err := parseBoundingBox(query.Get("bbox"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_BBOX", "bbox must be west,south,east,north in degrees")
+		return
+	}
+	zoom, err := strconv.Atoi(query.Get("zoom"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ZOOM", "zoom is required and must be a whole number")
+		return
+	}
+
+	h.writeClusters(w, r, box, zoom)
+}
+
+// GetLocationClusterTile groups the active locations in a map tile
+// ({z}/{x}/{y}, as map SDKs request them), so tiles can be cached whole
+func (h *ProviderHandler) GetLocationClusterTile(w http.ResponseWriter, r *http.Request) {
+	var tile domain.Tile
+	var errs [3]error
+	tile.Zoom, errs[0] = strconv.Atoi(chi.URLParam(r, "z"))
+	tile.X, errs[1] = strconv.Atoi(chi.URLParam(r, "x"))
+	tile.Y, errs[2] = strconv.Atoi(chi.URLParam(r, "y"))
+	if errors.Join(errs[:]...) != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TILE", "tile must be zoom/x/y in whole numbers")
+		return
+	}
+	if err := tile.Validate(); err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	h.writeClusters(w, r, tile.Bounds(), tile.Zoom)
+}
+
+func (h *ProviderHandler) writeClusters(w http.ResponseWriter, r *http.Request, box domain.BoundingBox, zoom int) {
+	resp, err := h.providerService.GetLocationClusters(r.Context(), box, zoom)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// parseBoundingBox reads west,south,east,north
+func parseBoundingBox(v string) (domain.BoundingBox, error) {
+	parts := strings.Split(v, ",")
+	if len(parts) != 4 {
+		return domain.BoundingBox{}, domain.ErrInvalidBoundingBox
+	}
+	var coords [4]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return domain.BoundingBox{}, domain.ErrInvalidBoundingBox
+		}
+		coords[i] = f
+	}
+	return domain.BoundingBox{West: coords[0], South: coords[1], East: coords[2], North: coords[3]}, nil
+}
+
+// queryList splits comma-separated and repeated query values into one list
+func queryList(values []string) []string {
+	var list []string
+	for _, v := range values {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+	}
+	return list
+}
+
+func amenityList() string {
+	names := make([]string, len(domain.Amenities))
+	for i, a := range domain.Amenities {
+		names[i] = string(a)
+	}
+	return strings.Join(names, ", ")
 }
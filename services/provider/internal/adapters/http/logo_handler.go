@@ -0,0 +1,61 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type LogoHandler struct {
+	logoService *application.LogoService
+}
+
+func NewLogoHandler(logoService *application.LogoService) *LogoHandler {
+	return &LogoHandler{logoService: logoService}
+}
+
+// logoContentTypes are the image types accepted for upload
+var logoContentTypes = []string{"image/png", "image/jpeg"}
+
+func mapLogoError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrUnsupportedLogoFormat):
+		return http.StatusUnsupportedMediaType, "UNSUPPORTED_LOGO_FORMAT", "Logo must be a PNG or JPEG image matching its Content-Type"
+	case errors.Is(err, domain.ErrLogoTooLarge):
+		return http.StatusRequestEntityTooLarge, "LOGO_TOO_LARGE", fmt.Sprintf("Logo must not exceed %d bytes", domain.MaxLogoBytes)
+	case errors.Is(err, domain.ErrInvalidLogoDimensions):
+		return http.StatusBadRequest, "INVALID_LOGO_DIMENSIONS", fmt.Sprintf(
+			"Logo sides must be between %d and %d pixels, the longer at most %d times the shorter",
+			domain.MinLogoSide, domain.MaxLogoSide, domain.MaxLogoAspect)
+	case errors.Is(err, domain.ErrLogosUnavailable):
+		return http.StatusServiceUnavailable, "LOGOS_UNAVAILABLE", "Logo uploads are not available"
+	default:
+		return mapStaffError(err)
+	}
+}
+
+// UploadLogo replaces the provider's logo with the image in the request
+// body.
+//
+// PUT /api/v1/provider-portal/logo
+// Content-Type: image/png or image/jpeg
+func (h *LogoHandler) UploadLogo(w http.ResponseWriter, r *http.Request) {
+	contentType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+
+	resp, err := h.logoService.UploadLogo(r.Context(), staffCaller(r), contentType, r.Body)
+	if err != nil {
+		status, code, msg := mapLogoError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type portalContextKey struct{}
+
+// maxRecordedErrorBody bounds how much of an error response is buffered for the error log
+const maxRecordedErrorBody = 4 << 10
+
+type PortalHandler struct {
+	portalService *application.PortalService
+}
+
+func NewPortalHandler(portalService *application.PortalService) *PortalHandler {
+	return &PortalHandler{portalService: portalService}
+}
+
+func mapPortalError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		return http.StatusUnauthorized, "INVALID_CREDENTIALS", "Invalid API key or secret"
+	case errors.Is(err, domain.ErrCredentialsNotFound):
+		return http.StatusNotFound, "CREDENTIALS_NOT_FOUND", "Credentials not found"
+	case errors.Is(err, domain.ErrCredentialsRevoked):
+		return http.StatusConflict, "CREDENTIALS_REVOKED", "Credentials are revoked or expired"
+	case errors.Is(err, domain.ErrSandboxCredentialLimit):
+		return http.StatusConflict, "SANDBOX_CREDENTIAL_LIMIT", "Sandbox credential limit reached"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// Authenticate resolves the X-API-Key and X-API-Secret headers to the caller's
+// credentials and stores them in the request context.
+func (h *PortalHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		creds, err := h.portalService.Authenticate(r.Context(), r.Header.Get("X-API-Key"), r.Header.Get("X-API-Secret"))
+		if err != nil {
+			status, code, msg := mapPortalError(err)
+			httpx.WriteError(w, r, status, code, msg)
+			return
+		}
+
+		// Changes made through the portal are attributed to the key that made them
+		ctx := context.WithValue(r.Context(), portalContextKey{}, creds)
+		ctx = actor.NewContext(ctx, "credentials:"+creds.ID.String())
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RecordErrors logs 4xx and 5xx responses against the authenticated caller so
+// partners can inspect them from the portal. It must run after Authenticate.
+func (h *PortalHandler) RecordErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &errorRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		creds := portalCaller(r)
+		if creds == nil || rec.status < http.StatusBadRequest {
+			return
+		}
+
+		var body httpx.Problem
+		var code, msg string
+		if json.Unmarshal(rec.body.Bytes(), &body) == nil {
+			code, msg = body.Code, body.Detail
+		}
+
+		entry := domain.NewAPIErrorLog(creds, r.Method, r.URL.Path, rec.status, code, msg, middleware.GetReqID(r.Context()))
+		h.portalService.RecordAPIError(r.Context(), entry)
+	})
+}
+
+func (h *PortalHandler) ListCredentials(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.portalService.ListCredentials(r.Context(), portalCaller(r))
+	if err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *PortalHandler) CreateSandboxCredentials(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.portalService.CreateSandboxCredentials(r.Context(), portalCaller(r))
+	if err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PortalHandler) RotateCredentials(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid credentials ID format")
+		return
+	}
+
+	resp, err := h.portalService.RotateCredentials(r.Context(), portalCaller(r), id)
+	if err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *PortalHandler) RevokeCredentials(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid credentials ID format")
+		return
+	}
+
+	if err := h.portalService.RevokeCredentials(r.Context(), portalCaller(r), id); err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]string{"status": "revoked"})
+}
+
+func (h *PortalHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit, offset := queryInt(r, "limit"), queryInt(r, "offset")
+
+	resp, err := h.portalService.ListWebhookDeliveries(r.Context(), portalCaller(r), limit, offset)
+	if err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *PortalHandler) ListAPIErrors(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.portalService.ListAPIErrors(r.Context(), portalCaller(r), queryInt(r, "limit"))
+	if err != nil {
+		status, code, msg := mapPortalError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func portalCaller(r *http.Request) *domain.ProviderCredentials {
+	creds, _ := r.Context().Value(portalContextKey{}).(*domain.ProviderCredentials)
+	return creds
+}
+
+func queryInt(r *http.Request, key string) int {
+	v, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// errorRecorder captures the status code and, for error responses, the body
+type errorRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *errorRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *errorRecorder) Write(b []byte) (int, error) {
+	if rec.status >= http.StatusBadRequest && rec.body.Len() < maxRecordedErrorBody {
+		remaining := maxRecordedErrorBody - rec.body.Len()
+		if len(b) < remaining {
+			remaining = len(b)
+		}
+		rec.body.Write(b[:remaining])
+	}
+	return rec.ResponseWriter.Write(b)
+}
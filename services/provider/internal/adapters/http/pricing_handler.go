@@ -0,0 +1,132 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type PricingHandler struct {
+	pricingService *application.PricingService
+}
+
+func NewPricingHandler(pricingService *application.PricingService) *PricingHandler {
+	return &PricingHandler{pricingService: pricingService}
+}
+
+func mapPricingError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrEmptyPricingUpdate):
+		return http.StatusBadRequest, "EMPTY_PRICING_UPDATE", "At least one location is required"
+	case errors.Is(err, domain.ErrDuplicatePricingEntry):
+		return http.StatusBadRequest, "DUPLICATE_LOCATION", "Each location may appear only once"
+	case errors.Is(err, domain.ErrInvalidPricing):
+		return http.StatusBadRequest, "INVALID_PRICING", "Hourly rate and daily max must not be negative"
+	case errors.Is(err, domain.ErrInvalidEffectiveFrom):
+		return http.StatusBadRequest, "INVALID_EFFECTIVE_FROM", "Effective date cannot be in the past"
+	case errors.Is(err, domain.ErrLocationNotOwned):
+		return http.StatusForbidden, "LOCATION_NOT_OWNED", "Location does not belong to this provider"
+	case errors.Is(err, domain.ErrPricingNotFound):
+		return http.StatusNotFound, "PRICING_NOT_FOUND", "No pricing found for this location"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *PricingHandler) BulkUpdatePricing(w http.ResponseWriter, r *http.Request) {
+	providerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+	if !canChangePricing(r, providerID) {
+		httpx.WriteError(w, r, http.StatusForbidden, "FORBIDDEN", "Only admins and the provider's own admins can change its pricing")
+		return
+	}
+
+	var req application.BulkPricingUpdateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.ProviderID = providerID
+	req.CreatedBy = r.Header.Get("X-User-ID")
+
+	resp, err := h.pricingService.BulkUpdatePricing(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapPricingError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// canChangePricing reports whether the gateway authenticated the caller as an
+// admin, or as a provider admin of the provider whose pricing is changed
+func canChangePricing(r *http.Request, providerID uuid.UUID) bool {
+	userID := r.Header.Get(actor.HeaderUserID)
+	if userID == "" {
+		return false
+	}
+	if r.Header.Get(actor.HeaderUserRole) == actor.RoleAdmin {
+		return true
+	}
+	staff, err := domain.NewStaff(userID, r.Header.Get(headerUserRole), r.Header.Get(headerProviderID))
+	return err == nil && staff.ProviderID == providerID && staff.CanManage() == nil
+}
+
+func (h *PricingHandler) GetLocationPricing(w http.ResponseWriter, r *http.Request) {
+	locationID, err := uuid.Parse(chi.URLParam(r, "locationID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid location ID format")
+		return
+	}
+
+	at := time.Now().UTC()
+	if raw := r.URL.Query().Get("at"); raw != "" {
+		at, err = time.Parse(time.RFC3339, raw)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TIME", "at must be an RFC3339 timestamp")
+			return
+		}
+	}
+
+	resp, err := h.pricingService.GetLocationPricing(r.Context(), locationID, at)
+	if err != nil {
+		status, code, msg := mapPricingError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *PricingHandler) GetPricingHistory(w http.ResponseWriter, r *http.Request) {
+	providerID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+	locationID, err := uuid.Parse(chi.URLParam(r, "locationID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid location ID format")
+		return
+	}
+
+	resp, err := h.pricingService.GetPricingHistory(r.Context(), providerID, locationID)
+	if err != nil {
+		status, code, msg := mapPricingError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,49 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+)
+
+func TestBulkUpdatePricing_RequiresScope(t *testing.T) {
+	providerID := uuid.New()
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"anonymous", nil},
+		{"user", map[string]string{actor.HeaderUserID: "u1", actor.HeaderUserRole: "user"}},
+		{"admin without user", map[string]string{actor.HeaderUserRole: actor.RoleAdmin}},
+		{"viewer of the provider", map[string]string{actor.HeaderUserID: "u1", headerUserRole: "provider_viewer", headerProviderID: providerID.String()}},
+		{"admin of another provider", map[string]string{actor.HeaderUserID: "u1", headerUserRole: "provider_admin", headerProviderID: uuid.NewString()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewPricingHandler(nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/providers/"+providerID.String()+"/pricing/bulk", strings.NewReader(`{"locations":[]}`))
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("id", providerID.String())
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rec := httptest.NewRecorder()
+
+			handler.BulkUpdatePricing(rec, req)
+
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusForbidden)
+			}
+		})
+	}
+}
@@ -5,56 +5,184 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/provider/internal/application"
 )
 
 type Router struct {
 	providerService *application.ProviderService
+	adminService    *application.AdminService
+	pricingService  *application.PricingService
+	portalService   *application.PortalService
+	conformance     *application.ConformanceService
+	webhookService  *application.WebhookService
+	staffService    *application.StaffService
+	logoService     *application.LogoService
+	auditStore      audit.Store
 	router          chi.Router
+	handler         http.Handler
 }
 
-func NewRouter(providerService *application.ProviderService) *Router {
+func NewRouter(
+	providerService *application.ProviderService,
+	adminService *application.AdminService,
+	pricingService *application.PricingService,
+	portalService *application.PortalService,
+	conformanceService *application.ConformanceService,
+	webhookService *application.WebhookService,
+	staffService *application.StaffService,
+	logoService *application.LogoService,
+	auditStore audit.Store,
+) *Router {
 	r := &Router{
 		providerService: providerService,
+		adminService:    adminService,
+		pricingService:  pricingService,
+		portalService:   portalService,
+		conformance:     conformanceService,
+		webhookService:  webhookService,
+		staffService:    staffService,
+		logoService:     logoService,
+		auditStore:      auditStore,
 		router:          chi.NewRouter(),
 	}
 
 	r.setupMiddleware()
 	r.setupRoutes()
+	r.handler = r.router
 
 	return r
 }
 
+// Use wraps the router with additional middleware. Unlike chi's Use it can be
+// called after routes are registered; middlewares run in the order given.
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		r.handler = middlewares[i](r.handler)
+	}
+}
+
 func (r *Router) setupMiddleware() {
 	r.router.Use(middleware.RequestID)
 	r.router.Use(middleware.RealIP)
-	r.router.Use(middleware.Logger)
+	r.router.Use(actor.Middleware)
+	r.router.Use(audit.Middleware)
 	r.router.Use(middleware.Recoverer)
-	r.router.Use(middleware.AllowContentType("application/json"))
+	r.router.Use(deadline.FromHeader)
 
-	r.router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			next.ServeHTTP(w, req)
-		})
-	})
+	r.router.Use(httpx.SecurityHeaders)
+	r.router.Use(httpx.JSONContentType)
 }
 
 func (r *Router) setupRoutes() {
 	handler := NewProviderHandler(r.providerService)
+	adminHandler := NewAdminHandler(r.adminService)
+	pricingHandler := NewPricingHandler(r.pricingService)
+	portalHandler := NewPortalHandler(r.portalService)
+	conformanceHandler := NewConformanceHandler(r.conformance)
+	webhookHandler := NewWebhookHandler(r.webhookService)
+	staffHandler := NewStaffHandler(r.staffService)
+	logoHandler := NewLogoHandler(r.logoService)
+
+	r.router.Group(func(router chi.Router) {
+		router.Use(middleware.AllowContentType("application/json"))
+
+		router.Route("/api/v1/providers", func(router chi.Router) {
+			router.Post("/", handler.RegisterProvider)
+			router.With(httpx.ETag).Get("/", handler.ListProviders)
+			router.Get("/code/{code}", handler.GetProviderByCode)
+			router.With(httpx.ETag).Get("/manifest", handler.GetMFEManifest)
+			router.With(httpx.ETag).Get("/locations/nearby", handler.GetNearbyLocations)
+			router.Get("/{id}", handler.GetProvider)
+			router.Post("/{id}/activate", handler.ActivateProvider)
+			router.Post("/{id}/deactivate", handler.DeactivateProvider)
+			router.Post("/{id}/credentials", handler.GenerateCredentials)
+			router.Post("/{id}/mfe", handler.PublishMFE)
+			router.Post("/{id}/locations", handler.AddLocation)
+			router.With(httpx.ETag).Get("/{id}/locations", handler.GetProviderLocations)
+			router.Post("/{id}/pricing/bulk", pricingHandler.BulkUpdatePricing)
+			router.Get("/{id}/locations/{locationID}/pricing", pricingHandler.GetLocationPricing)
+			router.Get("/{id}/locations/{locationID}/pricing/history", pricingHandler.GetPricingHistory)
+		})
 
-	r.router.Route("/api/v1/providers", func(router chi.Router) {
-		router.Post("/", handler.RegisterProvider)
-		router.Get("/", handler.ListProviders)
-		router.Get("/code/{code}", handler.GetProviderByCode)
-		router.Get("/{id}", handler.GetProvider)
-		router.Post("/{id}/activate", handler.ActivateProvider)
-		router.Post("/{id}/deactivate", handler.DeactivateProvider)
-		router.Post("/{id}/credentials", handler.GenerateCredentials)
-		router.Post("/{id}/locations", handler.AddLocation)
-		router.Get("/{id}/locations", handler.GetProviderLocations)
+		// Map clusters of locations across providers, and the amenities
+		// location searches filter by
+		router.Route("/api/v1/locations", func(router chi.Router) {
+			router.With(httpx.ETag).Get("/amenities", handler.ListAmenities)
+			router.With(httpx.ETag).Get("/clusters", handler.GetLocationClusters)
+			router.With(httpx.ETag).Get("/clusters/{z}/{x}/{y}", handler.GetLocationClusterTile)
+		})
+
+		router.Route("/api/v1/admin/providers", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Get("/pending", adminHandler.ListPendingProviders)
+			router.Get("/deleted", adminHandler.ListDeletedProviders)
+			router.Route("/audit", audit.NewHandler(r.auditStore).Routes)
+			router.Post("/{id}/approve", adminHandler.ApproveProvider)
+			router.Post("/{id}/reject", adminHandler.RejectProvider)
+			router.Post("/{id}/suspend", adminHandler.SuspendProvider)
+			router.Get("/{id}/history", adminHandler.GetProviderHistory)
+			router.Post("/{id}/conformance", conformanceHandler.RunConformance)
+			router.Get("/{id}/conformance", conformanceHandler.ListReports)
+			router.Get("/{id}/conformance/latest", conformanceHandler.GetLatestReport)
+			router.Put("/{id}/webhook", webhookHandler.ConfigureWebhook)
+			router.Get("/{id}/webhooks/events", webhookHandler.ListEvents)
+			router.Post("/{id}/webhooks/events/{eventID}/redrive", webhookHandler.RedriveEvent)
+			router.Delete("/{id}", adminHandler.DeleteProvider)
+			router.Post("/{id}/restore", adminHandler.RestoreProvider)
+			router.Get("/{id}/locations/deleted", adminHandler.ListDeletedLocations)
+			router.Delete("/{id}/locations/{locationID}", adminHandler.DeleteLocation)
+			router.Post("/{id}/locations/{locationID}/restore", adminHandler.RestoreLocation)
+		})
+
+		// Developer portal: partners authenticate with their own API key and secret
+		router.Route("/api/v1/portal", func(router chi.Router) {
+			router.Use(portalHandler.Authenticate)
+			router.Use(portalHandler.RecordErrors)
+			router.Get("/credentials", portalHandler.ListCredentials)
+			router.Post("/credentials/sandbox", portalHandler.CreateSandboxCredentials)
+			router.Post("/credentials/{id}/rotate", portalHandler.RotateCredentials)
+			router.Delete("/credentials/{id}", portalHandler.RevokeCredentials)
+			router.Put("/webhook", webhookHandler.ConfigureOwnWebhook)
+			router.Get("/webhooks/events", webhookHandler.ListOwnEvents)
+			router.Post("/webhooks/events/{eventID}/redrive", webhookHandler.RedriveOwnEvent)
+			router.Get("/webhooks/deliveries", portalHandler.ListWebhookDeliveries)
+			router.Get("/errors", portalHandler.ListAPIErrors)
+			router.Post("/conformance", conformanceHandler.RunOwnConformance)
+			router.Get("/conformance/latest", conformanceHandler.GetOwnLatestReport)
+		})
+
+		// Provider portal: provider staff sign in as users; the gateway forwards
+		// their provider and role from the access token
+		router.Route("/api/v1/provider-portal", func(router chi.Router) {
+			router.Use(staffHandler.Authenticate)
+			router.Get("/provider", staffHandler.GetProvider)
+			router.Get("/locations", staffHandler.ListLocations)
+			router.Post("/locations", staffHandler.AddLocation)
+			router.Get("/locations/{locationID}", staffHandler.GetLocation)
+			router.Put("/locations/{locationID}", staffHandler.UpdateLocation)
+			router.Delete("/locations/{locationID}", staffHandler.DeactivateLocation)
+			router.Get("/locations/{locationID}/pricing-schedule", staffHandler.GetPricingSchedule)
+			router.Put("/locations/{locationID}/pricing-schedule", staffHandler.SetPricingSchedule)
+			router.Delete("/locations/{locationID}/pricing-schedule", staffHandler.DeletePricingSchedule)
+			router.Get("/locations/{locationID}/amenities", staffHandler.GetAmenities)
+			router.Put("/locations/{locationID}/amenities", staffHandler.SetAmenities)
+			router.Post("/pricing/bulk", staffHandler.BulkUpdatePricing)
+			router.Get("/locations/{locationID}/hours", staffHandler.GetOperatingHours)
+			router.Put("/locations/{locationID}/hours", staffHandler.SetOperatingHours)
+			router.Delete("/locations/{locationID}/hours", staffHandler.DeleteOperatingHours)
+			router.Get("/sessions", staffHandler.SessionReport)
+		})
 	})
 
+	// Logos are uploaded as the image itself, so the route sits outside the
+	// JSON-only group
+	r.router.With(staffHandler.Authenticate, middleware.AllowContentType(logoContentTypes...)).
+		Put("/api/v1/provider-portal/logo", logoHandler.UploadLogo)
+
 	r.router.Get("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok"}`))
@@ -62,5 +190,5 @@ func (r *Router) setupRoutes() {
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
@@ -0,0 +1,396 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+// Headers the gateway sets from a provider staff member's access token
+const (
+	headerUserRole   = "X-User-Role"
+	headerProviderID = "X-Provider-ID"
+)
+
+type staffContextKey struct{}
+
+// StaffHandler serves the provider portal for providers' own staff, who
+// sign in as users and are scoped to their provider by the gateway
+type StaffHandler struct {
+	staffService *application.StaffService
+}
+
+func NewStaffHandler(staffService *application.StaffService) *StaffHandler {
+	return &StaffHandler{staffService: staffService}
+}
+
+func mapStaffError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrNotProviderStaff):
+		return http.StatusForbidden, "NOT_PROVIDER_STAFF", "A provider staff role is required"
+	case errors.Is(err, domain.ErrStaffReadOnly):
+		return http.StatusForbidden, "PROVIDER_ADMIN_REQUIRED", "Only provider admins can make changes"
+	case errors.Is(err, domain.ErrLocationNotOwned):
+		return http.StatusNotFound, "LOCATION_NOT_FOUND", "Location not found"
+	case errors.Is(err, domain.ErrInvalidLocation):
+		return http.StatusBadRequest, "INVALID_LOCATION", "Location needs a name and a non-negative number of spaces"
+	case errors.Is(err, domain.ErrInvalidReportPeriod):
+		return http.StatusBadRequest, "INVALID_REPORT_PERIOD", "Report period must be between 1 and 92 days"
+	case errors.Is(err, domain.ErrNoPricingSchedule):
+		return http.StatusNotFound, "PRICING_SCHEDULE_NOT_FOUND", "Location is billed at its flat rate"
+	case errors.Is(err, domain.ErrNoOperatingHours):
+		return http.StatusNotFound, "OPERATING_HOURS_NOT_FOUND", "Location is open around the clock"
+	case errors.Is(err, pricing.ErrInvalidTimezone):
+		return http.StatusBadRequest, "INVALID_TIMEZONE", "Timezone must be an IANA time zone such as Asia/Kuala_Lumpur"
+	case errors.Is(err, pricing.ErrInvalidRule):
+		return http.StatusBadRequest, "INVALID_PRICING_RULE", err.Error()
+	case errors.Is(err, pricing.ErrInvalidHours):
+		return http.StatusBadRequest, "INVALID_OPERATING_HOURS", err.Error()
+	default:
+		return mapPricingError(err)
+	}
+}
+
+// Authenticate resolves the caller's provider and role from the gateway's
+// headers. Callers without a provider staff role are refused.
+func (h *StaffHandler) Authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		staff, err := domain.NewStaff(r.Header.Get(actor.HeaderUserID), r.Header.Get(headerUserRole), r.Header.Get(headerProviderID))
+		if err != nil || staff.UserID == "" {
+			status, code, msg := mapStaffError(domain.ErrNotProviderStaff)
+			httpx.WriteError(w, r, status, code, msg)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), staffContextKey{}, staff)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (h *StaffHandler) GetProvider(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.staffService.GetProvider(r.Context(), staffCaller(r))
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.staffService.ListLocations(r.Context(), staffCaller(r))
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) AddLocation(w http.ResponseWriter, r *http.Request) {
+	var req application.AddLocationRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.AddLocation(r.Context(), staffCaller(r), req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *StaffHandler) GetLocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.staffService.GetLocation(r.Context(), staffCaller(r), id)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.UpdateLocationRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.UpdateLocation(r.Context(), staffCaller(r), id, req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// DeactivateLocation handles DELETE; the location is deactivated, not removed
+func (h *StaffHandler) DeactivateLocation(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.staffService.DeactivateLocation(r.Context(), staffCaller(r), id); err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *StaffHandler) GetAmenities(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.staffService.GetAmenities(r.Context(), staffCaller(r), id)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// SetAmenities replaces the location's amenities and height limit; a null
+// height limit removes it
+func (h *StaffHandler) SetAmenities(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.LocationAmenities
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.SetAmenities(r.Context(), staffCaller(r), id, req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) GetPricingSchedule(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.staffService.GetPricingSchedule(r.Context(), staffCaller(r), id)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) SetPricingSchedule(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req pricing.Schedule
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.SetPricingSchedule(r.Context(), staffCaller(r), id, &req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) DeletePricingSchedule(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.staffService.DeletePricingSchedule(r.Context(), staffCaller(r), id); err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *StaffHandler) BulkUpdatePricing(w http.ResponseWriter, r *http.Request) {
+	var req application.BulkPricingUpdateRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.BulkUpdatePricing(r.Context(), staffCaller(r), req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+func (h *StaffHandler) GetOperatingHours(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.staffService.GetOperatingHours(r.Context(), staffCaller(r), id)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) SetOperatingHours(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req pricing.Hours
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.staffService.SetOperatingHours(r.Context(), staffCaller(r), id, &req)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *StaffHandler) DeleteOperatingHours(w http.ResponseWriter, r *http.Request) {
+	id, ok := locationIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.staffService.DeleteOperatingHours(r.Context(), staffCaller(r), id); err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SessionReport lists sessions that entered between from and to
+// (YYYY-MM-DD, to exclusive, in UTC), the last 30 days by default
+func (h *StaffHandler) SessionReport(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	to := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1)
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TO", "to must be a date (YYYY-MM-DD)")
+			return
+		}
+		to = t
+	}
+	from := to.AddDate(0, 0, -30)
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_FROM", "from must be a date (YYYY-MM-DD)")
+			return
+		}
+		from = t
+	}
+	var locationID *uuid.UUID
+	if v := q.Get("location_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_LOCATION_ID", "Invalid location ID format")
+			return
+		}
+		locationID = &id
+	}
+
+	filter, err := domain.NewSessionFilter(from, to, locationID)
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	resp, err := h.staffService.SessionReport(r.Context(), staffCaller(r), filter, queryInt(r, "limit"), queryInt(r, "offset"))
+	if err != nil {
+		status, code, msg := mapStaffError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func staffCaller(r *http.Request) *domain.Staff {
+	staff, _ := r.Context().Value(staffContextKey{}).(*domain.Staff)
+	return staff
+}
+
+func locationIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "locationID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_LOCATION_ID", "Invalid location ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
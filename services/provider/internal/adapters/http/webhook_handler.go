@@ -0,0 +1,124 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/provider/internal/application"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type WebhookHandler struct {
+	webhookService *application.WebhookService
+}
+
+func NewWebhookHandler(webhookService *application.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+func mapWebhookError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhookURL):
+		return http.StatusBadRequest, "INVALID_WEBHOOK_URL", "Invalid webhook URL"
+	case errors.Is(err, domain.ErrWebhookEventNotFound):
+		return http.StatusNotFound, "WEBHOOK_EVENT_NOT_FOUND", "Webhook event not found"
+	case errors.Is(err, domain.ErrWebhookEventNotFailed):
+		return http.StatusConflict, "WEBHOOK_EVENT_NOT_FAILED", "Only failed webhook events can be redriven"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// ConfigureWebhook sets a provider's webhook endpoint on behalf of an admin
+func (h *WebhookHandler) ConfigureWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	h.configure(w, r, id)
+}
+
+func (h *WebhookHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	h.list(w, r, id)
+}
+
+func (h *WebhookHandler) RedriveEvent(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid provider ID format")
+		return
+	}
+
+	h.redrive(w, r, id)
+}
+
+// ConfigureOwnWebhook lets a partner set their webhook endpoint from the developer portal
+func (h *WebhookHandler) ConfigureOwnWebhook(w http.ResponseWriter, r *http.Request) {
+	h.configure(w, r, portalCaller(r).ProviderID)
+}
+
+func (h *WebhookHandler) ListOwnEvents(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, portalCaller(r).ProviderID)
+}
+
+func (h *WebhookHandler) RedriveOwnEvent(w http.ResponseWriter, r *http.Request) {
+	h.redrive(w, r, portalCaller(r).ProviderID)
+}
+
+func (h *WebhookHandler) configure(w http.ResponseWriter, r *http.Request, providerID uuid.UUID) {
+	var req application.ConfigureWebhookRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.webhookService.ConfigureWebhook(r.Context(), providerID, req)
+	if err != nil {
+		status, code, msg := mapWebhookError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) list(w http.ResponseWriter, r *http.Request, providerID uuid.UUID) {
+	filter := domain.WebhookEventStatus(r.URL.Query().Get("status"))
+
+	resp, err := h.webhookService.ListEvents(r.Context(), providerID, filter, queryInt(r, "limit"), queryInt(r, "offset"))
+	if err != nil {
+		status, code, msg := mapWebhookError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WebhookHandler) redrive(w http.ResponseWriter, r *http.Request, providerID uuid.UUID) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "eventID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ID", "Invalid webhook event ID format")
+		return
+	}
+
+	resp, err := h.webhookService.Redrive(r.Context(), providerID, eventID)
+	if err != nil {
+		status, code, msg := mapWebhookError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
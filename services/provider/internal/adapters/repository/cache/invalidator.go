@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// Invalidator evicts cache entries when a provider event says the provider
+// or one of its locations changed. Every replica consumes the events, so a
+// change made on one replica reaches the local caches of the others.
+type Invalidator struct {
+	providers *ProviderRepository
+	locations *LocationRepository
+}
+
+func NewInvalidator(providers *ProviderRepository, locations *LocationRepository) *Invalidator {
+	return &Invalidator{providers: providers, locations: locations}
+}
+
+// Handle is a kafka.EventHandler for the provider events topic
+func (i *Invalidator) Handle(ctx context.Context, event kafka.Event) error {
+	providerID := payloadID(event, "provider_id")
+	if locationID := payloadID(event, "location_id"); locationID != uuid.Nil {
+		i.locations.Invalidate(ctx, locationID, providerID)
+		return nil
+	}
+	if providerID == uuid.Nil {
+		return nil
+	}
+	i.providers.Invalidate(ctx, providerID)
+	switch event.Type {
+	case ports.EventProviderDeleted, ports.EventProviderRestored:
+		// Deleting or restoring a provider does the same to its locations
+		i.locations.InvalidateProvider(ctx, providerID)
+	}
+	return nil
+}
+
+func payloadID(event kafka.Event, key string) uuid.UUID {
+	s, _ := event.Payload[key].(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil
+	}
+	return id
+}
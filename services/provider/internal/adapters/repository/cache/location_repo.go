@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// LocationRepository caches GetByID and GetByProviderID. Nearby searches
+// are read from the wrapped repository.
+type LocationRepository struct {
+	ports.LocationRepository
+	store cache.Store
+	ttl   time.Duration
+}
+
+// NewLocationRepository wraps repo with a cache kept in store for ttl
+func NewLocationRepository(repo ports.LocationRepository, store cache.Store, ttl time.Duration) *LocationRepository {
+	return &LocationRepository{LocationRepository: repo, store: store, ttl: ttl}
+}
+
+func locationKey(id uuid.UUID) string { return "location:" + id.String() }
+
+func providerLocationsKey(providerID uuid.UUID) string {
+	return "locations:provider:" + providerID.String()
+}
+
+func (r *LocationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	var location domain.Location
+	if get(ctx, r.store, locationKey(id), &location) {
+		return &location, nil
+	}
+
+	l, err := r.LocationRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	set(ctx, r.store, locationKey(id), l, r.ttl)
+	return l, nil
+}
+
+func (r *LocationRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error) {
+	var locations []*domain.Location
+	if get(ctx, r.store, providerLocationsKey(providerID), &locations) {
+		return locations, nil
+	}
+
+	locations, err := r.LocationRepository.GetByProviderID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	set(ctx, r.store, providerLocationsKey(providerID), locations, r.ttl)
+	return locations, nil
+}
+
+func (r *LocationRepository) Create(ctx context.Context, location *domain.Location) error {
+	if err := r.LocationRepository.Create(ctx, location); err != nil {
+		return err
+	}
+	r.store.Delete(ctx, providerLocationsKey(location.ProviderID))
+	return nil
+}
+
+func (r *LocationRepository) Update(ctx context.Context, location *domain.Location) error {
+	if err := r.LocationRepository.Update(ctx, location); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, location.ID, location.ProviderID)
+	return nil
+}
+
+func (r *LocationRepository) Delete(ctx context.Context, id uuid.UUID, at time.Time) error {
+	// Look the location up first to know which provider's list to evict
+	location, lookupErr := r.LocationRepository.GetByID(ctx, id)
+	if err := r.LocationRepository.Delete(ctx, id, at); err != nil {
+		return err
+	}
+	providerID := uuid.Nil
+	if lookupErr == nil {
+		providerID = location.ProviderID
+	}
+	r.Invalidate(ctx, id, providerID)
+	return nil
+}
+
+func (r *LocationRepository) Restore(ctx context.Context, providerID, id uuid.UUID) error {
+	if err := r.LocationRepository.Restore(ctx, providerID, id); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, id, providerID)
+	return nil
+}
+
+// InvalidateProvider evicts the provider's location list and each of its
+// locations, deleted or not, after a change to the provider that carries to
+// its locations
+func (r *LocationRepository) InvalidateProvider(ctx context.Context, providerID uuid.UUID) {
+	keys := []string{providerLocationsKey(providerID)}
+	active, _ := r.LocationRepository.GetByProviderID(ctx, providerID)
+	deleted, _ := r.LocationRepository.GetDeletedByProviderID(ctx, providerID)
+	for _, l := range append(active, deleted...) {
+		keys = append(keys, locationKey(l.ID))
+	}
+	r.store.Delete(ctx, keys...)
+}
+
+// Invalidate evicts the cached location and, when providerID is known, the
+// provider's location list
+func (r *LocationRepository) Invalidate(ctx context.Context, id, providerID uuid.UUID) {
+	keys := []string{locationKey(id)}
+	if providerID != uuid.Nil {
+		keys = append(keys, providerLocationsKey(providerID))
+	}
+	r.store.Delete(ctx, keys...)
+}
@@ -0,0 +1,142 @@
+// Package cache decorates the provider and location repositories with a
+// read-through cache, so the lookups made on every session start don't hit
+// PostgreSQL each time.
+//
+// Writes made through the decorators evict the entries they change. Other
+// replicas evict theirs when the provider events for the change arrive (see
+// Invalidator), and every entry expires after its TTL regardless.
+package cache
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// ProviderRepository caches GetByID and GetByCode. Lists are read from the
+// wrapped repository. Deleting, restoring and purging a provider changes its
+// locations too, so those also evict the provider's entries in locations.
+type ProviderRepository struct {
+	ports.ProviderRepository
+	locations *LocationRepository
+	store     cache.Store
+	ttl       time.Duration
+}
+
+// NewProviderRepository wraps repo with a cache kept in store for ttl
+func NewProviderRepository(repo ports.ProviderRepository, locations *LocationRepository, store cache.Store, ttl time.Duration) *ProviderRepository {
+	return &ProviderRepository{ProviderRepository: repo, locations: locations, store: store, ttl: ttl}
+}
+
+func providerKey(id uuid.UUID) string { return "provider:" + id.String() }
+
+// Codes never change, so a code maps to the same ID for the provider's life
+func providerCodeKey(code string) string { return "provider:code:" + code }
+
+func (r *ProviderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Provider, error) {
+	var provider domain.Provider
+	if get(ctx, r.store, providerKey(id), &provider) {
+		return &provider, nil
+	}
+
+	p, err := r.ProviderRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	set(ctx, r.store, providerKey(id), p, r.ttl)
+	return p, nil
+}
+
+func (r *ProviderRepository) GetByCode(ctx context.Context, code string) (*domain.Provider, error) {
+	if id, err := r.store.Get(ctx, providerCodeKey(code)); err == nil {
+		if parsed, err := uuid.ParseBytes(id); err == nil {
+			if p, err := r.GetByID(ctx, parsed); err == nil && p.Code == code {
+				return p, nil
+			}
+		}
+	}
+
+	p, err := r.ProviderRepository.GetByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	r.store.Set(ctx, providerCodeKey(code), []byte(p.ID.String()), r.ttl)
+	set(ctx, r.store, providerKey(p.ID), p, r.ttl)
+	return p, nil
+}
+
+func (r *ProviderRepository) Update(ctx context.Context, provider *domain.Provider) error {
+	if err := r.ProviderRepository.Update(ctx, provider); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, provider.ID)
+	return nil
+}
+
+func (r *ProviderRepository) Delete(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := r.ProviderRepository.Delete(ctx, id, at); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, id)
+	r.locations.InvalidateProvider(ctx, id)
+	return nil
+}
+
+func (r *ProviderRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	if err := r.ProviderRepository.Restore(ctx, id); err != nil {
+		return err
+	}
+	r.Invalidate(ctx, id)
+	r.locations.InvalidateProvider(ctx, id)
+	return nil
+}
+
+func (r *ProviderRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	// Note the providers first: once purged they can't be listed
+	deleted, _ := r.ProviderRepository.GetDeleted(ctx)
+	n, err := r.ProviderRepository.Purge(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range deleted {
+		if p.DeletedAt != nil && p.DeletedAt.Before(deletedBefore) {
+			r.Invalidate(ctx, p.ID)
+			r.locations.InvalidateProvider(ctx, p.ID)
+		}
+	}
+	return n, nil
+}
+
+// Invalidate evicts the cached provider. The code mapping stays: it is
+// checked against the provider it leads to.
+func (r *ProviderRepository) Invalidate(ctx context.Context, id uuid.UUID) {
+	// The write already succeeded; a failed eviction only leaves the entry
+	// until its TTL
+	r.store.Delete(ctx, providerKey(id))
+}
+
+// get decodes the cached value for key into v and reports whether it was
+// there. Unreadable entries count as misses.
+func get(ctx context.Context, store cache.Store, key string, v any) bool {
+	data, err := store.Get(ctx, key)
+	if err != nil {
+		return false
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v) == nil
+}
+
+// set caches v under key. gob is used over JSON because it keeps fields the
+// API hides, such as the webhook secret.
+func set(ctx context.Context, store cache.Store, key string, v any, ttl time.Duration) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return
+	}
+	store.Set(ctx, key, buf.Bytes(), ttl)
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/cache"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+var errNotFound = errors.New("not found")
+
+// fakeDB holds one provider's locations and soft deletes them with it, as
+// the PostgreSQL repositories do
+type fakeDB struct {
+	locations map[uuid.UUID]*domain.Location
+	deleted   map[uuid.UUID]bool
+	reads     int
+}
+
+type fakeProviderRepo struct {
+	ports.ProviderRepository
+	db *fakeDB
+}
+
+func (r *fakeProviderRepo) Delete(ctx context.Context, id uuid.UUID, at time.Time) error {
+	for _, l := range r.db.locations {
+		if l.ProviderID == id {
+			r.db.deleted[l.ID] = true
+		}
+	}
+	return nil
+}
+
+func (r *fakeProviderRepo) Restore(ctx context.Context, id uuid.UUID) error {
+	for _, l := range r.db.locations {
+		if l.ProviderID == id {
+			delete(r.db.deleted, l.ID)
+		}
+	}
+	return nil
+}
+
+type fakeLocationRepo struct {
+	ports.LocationRepository
+	db *fakeDB
+}
+
+func (r *fakeLocationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Location, error) {
+	r.db.reads++
+	l, ok := r.db.locations[id]
+	if !ok || r.db.deleted[id] {
+		return nil, errNotFound
+	}
+	return l, nil
+}
+
+func (r *fakeLocationRepo) list(providerID uuid.UUID, deleted bool) []*domain.Location {
+	var out []*domain.Location
+	for _, l := range r.db.locations {
+		if l.ProviderID == providerID && r.db.deleted[l.ID] == deleted {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+func (r *fakeLocationRepo) GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error) {
+	r.db.reads++
+	return r.list(providerID, false), nil
+}
+
+func (r *fakeLocationRepo) GetDeletedByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error) {
+	return r.list(providerID, true), nil
+}
+
+func TestProviderRepository_DeleteEvictsLocations(t *testing.T) {
+	ctx := context.Background()
+	providerID := uuid.New()
+	location := &domain.Location{ID: uuid.New(), ProviderID: providerID, Name: "KLCC"}
+	db := &fakeDB{
+		locations: map[uuid.UUID]*domain.Location{location.ID: location},
+		deleted:   map[uuid.UUID]bool{},
+	}
+
+	store := cache.NewLRUStore(100)
+	locations := NewLocationRepository(&fakeLocationRepo{db: db}, store, time.Hour)
+	providers := NewProviderRepository(&fakeProviderRepo{db: db}, locations, store, time.Hour)
+
+	// Warm the cache
+	if _, err := locations.GetByID(ctx, location.ID); err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if _, err := locations.GetByProviderID(ctx, providerID); err != nil {
+		t.Fatalf("GetByProviderID() error = %v", err)
+	}
+	if _, err := locations.GetByID(ctx, location.ID); err != nil || db.reads != 2 {
+		t.Fatalf("location should be served from the cache, reads = %d", db.reads)
+	}
+
+	if err := providers.Delete(ctx, providerID, time.Now()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	if _, err := locations.GetByID(ctx, location.ID); !errors.Is(err, errNotFound) {
+		t.Errorf("GetByID() after provider delete error = %v, want %v", err, errNotFound)
+	}
+	if list, _ := locations.GetByProviderID(ctx, providerID); len(list) != 0 {
+		t.Errorf("GetByProviderID() after provider delete = %d locations, want 0", len(list))
+	}
+
+	if err := providers.Restore(ctx, providerID); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+
+	if _, err := locations.GetByID(ctx, location.ID); err != nil {
+		t.Errorf("GetByID() after provider restore error = %v", err)
+	}
+	if list, _ := locations.GetByProviderID(ctx, providerID); len(list) != 1 {
+		t.Errorf("GetByProviderID() after provider restore = %d locations, want 1", len(list))
+	}
+}
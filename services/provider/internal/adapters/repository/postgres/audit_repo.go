@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	changesJSON, err := json.Marshal(entry.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO provider_audit_log (
+			id, provider_id, actor, action, reason, changes, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = r.db.Exec(ctx, query,
+		entry.ID, entry.ProviderID, entry.Actor, entry.Action,
+		entry.Reason, changesJSON, entry.CreatedAt,
+	)
+	return err
+}
+
+func (r *AuditRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error) {
+	query := `
+		SELECT id, provider_id, actor, action, reason, changes, created_at
+		FROM provider_audit_log
+		WHERE provider_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, providerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		var changesJSON []byte
+		if err := rows.Scan(
+			&e.ID, &e.ProviderID, &e.Actor, &e.Action,
+			&e.Reason, &changesJSON, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changesJSON, &e.Changes); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type ConformanceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewConformanceRepository(db *pgxpool.Pool) *ConformanceRepository {
+	return &ConformanceRepository{db: db}
+}
+
+func (r *ConformanceRepository) Create(ctx context.Context, report *domain.ConformanceReport) error {
+	resultsJSON, err := json.Marshal(report.Results)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO provider_conformance_reports (
+			id, provider_id, api_base_url, score, passed, results,
+			run_by, started_at, completed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = r.db.Exec(ctx, query,
+		report.ID, report.ProviderID, report.APIBaseURL, report.Score, report.Passed,
+		resultsJSON, report.RunBy, report.StartedAt, report.CompletedAt,
+	)
+	return err
+}
+
+func (r *ConformanceRepository) GetLatestByProviderID(ctx context.Context, providerID uuid.UUID) (*domain.ConformanceReport, error) {
+	query := `
+		SELECT id, provider_id, api_base_url, score, passed, results,
+			run_by, started_at, completed_at
+		FROM provider_conformance_reports
+		WHERE provider_id = $1
+		ORDER BY completed_at DESC
+		LIMIT 1
+	`
+	report, err := scanConformanceReport(r.db.QueryRow(ctx, query, providerID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrConformanceReportNotFound
+	}
+	return report, err
+}
+
+func (r *ConformanceRepository) ListByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.ConformanceReport, error) {
+	query := `
+		SELECT id, provider_id, api_base_url, score, passed, results,
+			run_by, started_at, completed_at
+		FROM provider_conformance_reports
+		WHERE provider_id = $1
+		ORDER BY completed_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, providerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*domain.ConformanceReport
+	for rows.Next() {
+		report, err := scanConformanceReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+func scanConformanceReport(row pgx.Row) (*domain.ConformanceReport, error) {
+	var report domain.ConformanceReport
+	var resultsJSON []byte
+	if err := row.Scan(
+		&report.ID, &report.ProviderID, &report.APIBaseURL, &report.Score, &report.Passed,
+		&resultsJSON, &report.RunBy, &report.StartedAt, &report.CompletedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(resultsJSON, &report.Results); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
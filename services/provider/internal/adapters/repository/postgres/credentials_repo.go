@@ -52,6 +52,44 @@ func (r *CredentialsRepository) GetByProviderID(ctx context.Context, providerID
 	return r.scanCredentials(r.db.QueryRow(ctx, query, providerID, env))
 }
 
+func (r *CredentialsRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ProviderCredentials, error) {
+	query := `
+		SELECT id, provider_id, api_key, api_secret, environment,
+			is_active, created_at, expires_at
+		FROM provider_credentials WHERE id = $1
+	`
+	creds, err := r.scanCredentials(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, domain.ErrProviderNotFound) {
+		return nil, domain.ErrCredentialsNotFound
+	}
+	return creds, err
+}
+
+func (r *CredentialsRepository) ListByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.ProviderCredentials, error) {
+	query := `
+		SELECT id, provider_id, api_key, api_secret, environment,
+			is_active, created_at, expires_at
+		FROM provider_credentials
+		WHERE provider_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*domain.ProviderCredentials
+	for rows.Next() {
+		creds, err := r.scanCredentials(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, creds)
+	}
+	return list, rows.Err()
+}
+
 func (r *CredentialsRepository) Update(ctx context.Context, creds *domain.ProviderCredentials) error {
 	query := `
 		UPDATE provider_credentials
@@ -2,12 +2,17 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/lib/pq"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/pricing"
 	"github.com/parking-super-app/services/provider/internal/domain"
 )
 
@@ -20,22 +25,30 @@ func NewLocationRepository(db *pgxpool.Pool) *LocationRepository {
 }
 
 func (r *LocationRepository) Create(ctx context.Context, location *domain.Location) error {
+	scheduleJSON, hoursJSON, err := encodeSchedules(location)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO locations (
 			id, provider_id, name, address, city, state, postal_code,
 			latitude, longitude, total_spaces, amenities,
 			hourly_rate, daily_max, currency, grace_period_min,
-			is_active, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+			pricing_schedule, operating_hours, height_limit_cm,
+			is_active, created_at, updated_at, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		location.ID, location.ProviderID, location.Name, location.Address,
 		location.City, location.State, location.PostalCode,
 		location.Latitude, location.Longitude, location.TotalSpaces,
 		pq.Array(location.Amenities),
 		location.Pricing.HourlyRate, location.Pricing.DailyMax,
 		location.Pricing.Currency, location.Pricing.GracePeriodMin,
+		scheduleJSON, hoursJSON, location.HeightLimitCm,
 		location.IsActive, location.CreatedAt, location.UpdatedAt,
+		actor.FromContext(ctx),
 	)
 	return err
 }
@@ -45,8 +58,9 @@ func (r *LocationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 		SELECT id, provider_id, name, address, city, state, postal_code,
 			latitude, longitude, total_spaces, amenities,
 			hourly_rate, daily_max, currency, grace_period_min,
-			is_active, created_at, updated_at
-		FROM locations WHERE id = $1
+			pricing_schedule, operating_hours, height_limit_cm,
+			is_active, created_at, updated_at, deleted_at
+		FROM locations WHERE id = $1 AND deleted_at IS NULL
 	`
 	return r.scanLocation(r.db.QueryRow(ctx, query, id))
 }
@@ -56,8 +70,9 @@ func (r *LocationRepository) GetByProviderID(ctx context.Context, providerID uui
 		SELECT id, provider_id, name, address, city, state, postal_code,
 			latitude, longitude, total_spaces, amenities,
 			hourly_rate, daily_max, currency, grace_period_min,
-			is_active, created_at, updated_at
-		FROM locations WHERE provider_id = $1 AND is_active = true
+			pricing_schedule, operating_hours, height_limit_cm,
+			is_active, created_at, updated_at, deleted_at
+		FROM locations WHERE provider_id = $1 AND is_active = true AND deleted_at IS NULL
 		ORDER BY name
 	`
 	rows, err := r.db.Query(ctx, query, providerID)
@@ -77,22 +92,35 @@ func (r *LocationRepository) GetByProviderID(ctx context.Context, providerID uui
 	return locations, rows.Err()
 }
 
-func (r *LocationRepository) GetNearby(ctx context.Context, lat, lng float64, radiusKm float64) ([]*domain.Location, error) {
+// GetNearby returns the active locations within radiusKm that match filter,
+// nearest first. Amenities are matched through the GIN index on amenities.
+func (r *LocationRepository) GetNearby(ctx context.Context, lat, lng float64, radiusKm float64, filter domain.LocationFilter) ([]*domain.Location, error) {
 	// Using Haversine formula for distance calculation
 	// This is approximate but works well for short distances
+	// The distance is computed in a subquery because Postgres cannot filter on a
+	// select-list alias; acos is clamped against rounding above 1.
 	query := `
-		SELECT id, provider_id, name, address, city, state, postal_code,
-			latitude, longitude, total_spaces, amenities,
-			hourly_rate, daily_max, currency, grace_period_min,
-			is_active, created_at, updated_at,
-			(6371 * acos(cos(radians($1)) * cos(radians(latitude)) * cos(radians(longitude) - radians($2)) + sin(radians($1)) * sin(radians(latitude)))) AS distance
-		FROM locations
-		WHERE is_active = true
-		HAVING distance < $3
+		SELECT * FROM (
+			SELECT id, provider_id, name, address, city, state, postal_code,
+				latitude, longitude, total_spaces, amenities,
+				hourly_rate, daily_max, currency, grace_period_min,
+				pricing_schedule, operating_hours, height_limit_cm,
+				is_active, created_at, updated_at, deleted_at,
+				(6371 * acos(LEAST(1, cos(radians($1)) * cos(radians(latitude)) * cos(radians(longitude) - radians($2)) + sin(radians($1)) * sin(radians(latitude))))) AS distance
+			FROM locations
+			WHERE is_active = true AND deleted_at IS NULL
+				AND amenities @> $4::text[]
+				AND ($5 = 0 OR height_limit_cm IS NULL OR height_limit_cm >= $5)
+		) nearby
+		WHERE distance < $3
 		ORDER BY distance
 		LIMIT 50
 	`
-	rows, err := r.db.Query(ctx, query, lat, lng, radiusKm)
+	amenities := filter.Amenities
+	if amenities == nil {
+		amenities = []string{}
+	}
+	rows, err := r.db.Query(ctx, query, lat, lng, radiusKm, pq.Array(amenities), filter.VehicleHeightCm)
 	if err != nil {
 		return nil, err
 	}
@@ -109,20 +137,76 @@ func (r *LocationRepository) GetNearby(ctx context.Context, lat, lng float64, ra
 	return locations, rows.Err()
 }
 
+// Clusters groups the active locations in box by grid cell at cellZoom,
+// computing each location's cell as domain.CellOf does. The box filter uses
+// the coordinates index, so only the visible area is read.
+func (r *LocationRepository) Clusters(ctx context.Context, box domain.BoundingBox, cellZoom int) ([]*domain.Cluster, error) {
+	query := `
+		SELECT count(*), avg(latitude), avg(longitude), COALESCE(sum(total_spaces), 0),
+			min(latitude), min(longitude), max(latitude), max(longitude),
+			CASE WHEN count(*) = 1 THEN min(id::text)::uuid END
+		FROM (
+			SELECT id, latitude, longitude, total_spaces,
+				LEAST(GREATEST(floor((lng + 180) / 360 * $5::float8), 0), $5::float8 - 1) AS cx,
+				LEAST(GREATEST(floor((1 - ln(tan(radians(lat)) + 1 / cos(radians(lat))) / pi()) / 2 * $5::float8), 0), $5::float8 - 1) AS cy
+			FROM (
+				SELECT id, latitude, longitude, total_spaces,
+					LEAST(GREATEST(latitude::float8, -85.05112878), 85.05112878) AS lat,
+					longitude::float8 AS lng
+				FROM locations
+				WHERE is_active = true AND deleted_at IS NULL
+					AND latitude BETWEEN $1 AND $3
+					AND longitude BETWEEN $2 AND $4
+			) visible
+		) cells
+		GROUP BY cx, cy
+		ORDER BY count(*) DESC
+	`
+	cells := float64(int64(1) << cellZoom)
+	rows, err := r.db.Query(ctx, query, box.South, box.West, box.North, box.East, cells)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clusters []*domain.Cluster
+	for rows.Next() {
+		var c domain.Cluster
+		err := rows.Scan(
+			&c.Count, &c.Latitude, &c.Longitude, &c.TotalSpaces,
+			&c.Bounds.South, &c.Bounds.West, &c.Bounds.North, &c.Bounds.East,
+			&c.LocationID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, &c)
+	}
+	return clusters, rows.Err()
+}
+
 func (r *LocationRepository) Update(ctx context.Context, location *domain.Location) error {
+	scheduleJSON, hoursJSON, err := encodeSchedules(location)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		UPDATE locations
 		SET name = $2, address = $3, city = $4, state = $5, postal_code = $6,
 			latitude = $7, longitude = $8, total_spaces = $9, amenities = $10,
-			hourly_rate = $11, daily_max = $12, is_active = $13, updated_at = $14
-		WHERE id = $1
+			hourly_rate = $11, daily_max = $12, is_active = $13, updated_at = $14,
+			updated_by = $15, pricing_schedule = $16, operating_hours = $17,
+			height_limit_cm = $18
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	result, err := r.db.Exec(ctx, query,
 		location.ID, location.Name, location.Address, location.City,
 		location.State, location.PostalCode, location.Latitude, location.Longitude,
 		location.TotalSpaces, pq.Array(location.Amenities),
 		location.Pricing.HourlyRate, location.Pricing.DailyMax,
-		location.IsActive, location.UpdatedAt,
+		location.IsActive, location.UpdatedAt, actor.FromContext(ctx),
+		scheduleJSON, hoursJSON, location.HeightLimitCm,
 	)
 	if err != nil {
 		return err
@@ -133,8 +217,11 @@ func (r *LocationRepository) Update(ctx context.Context, location *domain.Locati
 	return nil
 }
 
-func (r *LocationRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.Exec(ctx, `DELETE FROM locations WHERE id = $1`, id)
+func (r *LocationRepository) Delete(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE locations SET deleted_at = $2, updated_by = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, at, actor.FromContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -144,16 +231,77 @@ func (r *LocationRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+func (r *LocationRepository) Restore(ctx context.Context, providerID, id uuid.UUID) error {
+	var deleted bool
+	err := r.db.QueryRow(ctx, `
+		SELECT deleted_at IS NOT NULL FROM locations WHERE id = $1 AND provider_id = $2
+	`, id, providerID).Scan(&deleted)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrLocationNotOwned
+		}
+		return err
+	}
+	if !deleted {
+		return domain.ErrLocationNotDeleted
+	}
+
+	_, err = r.db.Exec(ctx, `
+		UPDATE locations SET deleted_at = NULL, updated_by = $2
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id, actor.FromContext(ctx))
+	return err
+}
+
+func (r *LocationRepository) GetDeletedByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error) {
+	query := `
+		SELECT id, provider_id, name, address, city, state, postal_code,
+			latitude, longitude, total_spaces, amenities,
+			hourly_rate, daily_max, currency, grace_period_min,
+			pricing_schedule, operating_hours, height_limit_cm,
+			is_active, created_at, updated_at, deleted_at
+		FROM locations WHERE provider_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var locations []*domain.Location
+	for rows.Next() {
+		loc, err := r.scanLocationRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, loc)
+	}
+	return locations, rows.Err()
+}
+
+// Purge hard deletes locations deleted before deletedBefore, with their
+// pricing history
+func (r *LocationRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM locations WHERE deleted_at < $1`, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 func (r *LocationRepository) scanLocation(row pgx.Row) (*domain.Location, error) {
 	var loc domain.Location
 	var amenities []string
+	var scheduleJSON, hoursJSON []byte
 	err := row.Scan(
 		&loc.ID, &loc.ProviderID, &loc.Name, &loc.Address, &loc.City,
 		&loc.State, &loc.PostalCode, &loc.Latitude, &loc.Longitude,
 		&loc.TotalSpaces, pq.Array(&amenities),
 		&loc.Pricing.HourlyRate, &loc.Pricing.DailyMax,
 		&loc.Pricing.Currency, &loc.Pricing.GracePeriodMin,
-		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt,
+		&scheduleJSON, &hoursJSON, &loc.HeightLimitCm,
+		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt, &loc.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -162,30 +310,39 @@ func (r *LocationRepository) scanLocation(row pgx.Row) (*domain.Location, error)
 		return nil, err
 	}
 	loc.Amenities = amenities
+	if err := decodeSchedules(&loc, scheduleJSON, hoursJSON); err != nil {
+		return nil, err
+	}
 	return &loc, nil
 }
 
 func (r *LocationRepository) scanLocationRow(rows pgx.Rows) (*domain.Location, error) {
 	var loc domain.Location
 	var amenities []string
+	var scheduleJSON, hoursJSON []byte
 	err := rows.Scan(
 		&loc.ID, &loc.ProviderID, &loc.Name, &loc.Address, &loc.City,
 		&loc.State, &loc.PostalCode, &loc.Latitude, &loc.Longitude,
 		&loc.TotalSpaces, pq.Array(&amenities),
 		&loc.Pricing.HourlyRate, &loc.Pricing.DailyMax,
 		&loc.Pricing.Currency, &loc.Pricing.GracePeriodMin,
-		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt,
+		&scheduleJSON, &hoursJSON, &loc.HeightLimitCm,
+		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt, &loc.DeletedAt,
 	)
 	if err != nil {
 		return nil, err
 	}
 	loc.Amenities = amenities
+	if err := decodeSchedules(&loc, scheduleJSON, hoursJSON); err != nil {
+		return nil, err
+	}
 	return &loc, nil
 }
 
 func (r *LocationRepository) scanLocationRowWithDistance(rows pgx.Rows) (*domain.Location, error) {
 	var loc domain.Location
 	var amenities []string
+	var scheduleJSON, hoursJSON []byte
 	var distance float64
 	err := rows.Scan(
 		&loc.ID, &loc.ProviderID, &loc.Name, &loc.Address, &loc.City,
@@ -193,12 +350,48 @@ func (r *LocationRepository) scanLocationRowWithDistance(rows pgx.Rows) (*domain
 		&loc.TotalSpaces, pq.Array(&amenities),
 		&loc.Pricing.HourlyRate, &loc.Pricing.DailyMax,
 		&loc.Pricing.Currency, &loc.Pricing.GracePeriodMin,
-		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt,
+		&scheduleJSON, &hoursJSON, &loc.HeightLimitCm,
+		&loc.IsActive, &loc.CreatedAt, &loc.UpdatedAt, &loc.DeletedAt,
 		&distance,
 	)
 	if err != nil {
 		return nil, err
 	}
 	loc.Amenities = amenities
+	if err := decodeSchedules(&loc, scheduleJSON, hoursJSON); err != nil {
+		return nil, err
+	}
 	return &loc, nil
 }
+
+// encodeSchedules returns a location's pricing schedule and operating hours
+// as JSON, or NULL for those it doesn't have
+func encodeSchedules(location *domain.Location) (scheduleJSON, hoursJSON []byte, err error) {
+	if location.PricingSchedule != nil {
+		if scheduleJSON, err = json.Marshal(location.PricingSchedule); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode pricing schedule: %w", err)
+		}
+	}
+	if location.OperatingHours != nil {
+		if hoursJSON, err = json.Marshal(location.OperatingHours); err != nil {
+			return nil, nil, fmt.Errorf("failed to encode operating hours: %w", err)
+		}
+	}
+	return scheduleJSON, hoursJSON, nil
+}
+
+func decodeSchedules(loc *domain.Location, scheduleJSON, hoursJSON []byte) error {
+	if scheduleJSON != nil {
+		loc.PricingSchedule = &pricing.Schedule{}
+		if err := json.Unmarshal(scheduleJSON, loc.PricingSchedule); err != nil {
+			return fmt.Errorf("failed to decode pricing schedule: %w", err)
+		}
+	}
+	if hoursJSON != nil {
+		loc.OperatingHours = &pricing.Hours{}
+		if err := json.Unmarshal(hoursJSON, loc.OperatingHours); err != nil {
+			return fmt.Errorf("failed to decode operating hours: %w", err)
+		}
+	}
+	return nil
+}
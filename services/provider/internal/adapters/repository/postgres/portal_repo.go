@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+type WebhookDeliveryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(db *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO provider_webhook_deliveries (
+			id, provider_id, event_id, event_type, url, attempt, status,
+			response_code, error, duration_ms, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.db.Exec(ctx, query,
+		d.ID, d.ProviderID, d.EventID, d.EventType, d.URL, d.Attempt, d.Status,
+		d.ResponseCode, d.Error, d.DurationMs, d.CreatedAt,
+	)
+	return err
+}
+
+func (r *WebhookDeliveryRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	query := `
+		SELECT id, provider_id, event_id, event_type, url, attempt, status,
+			response_code, error, duration_ms, created_at
+		FROM provider_webhook_deliveries
+		WHERE provider_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, providerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(
+			&d.ID, &d.ProviderID, &d.EventID, &d.EventType, &d.URL, &d.Attempt, &d.Status,
+			&d.ResponseCode, &d.Error, &d.DurationMs, &d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+	return deliveries, rows.Err()
+}
+
+type APIErrorRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAPIErrorRepository(db *pgxpool.Pool) *APIErrorRepository {
+	return &APIErrorRepository{db: db}
+}
+
+func (r *APIErrorRepository) Create(ctx context.Context, e *domain.APIErrorLog) error {
+	query := `
+		INSERT INTO provider_api_errors (
+			id, provider_id, credentials_id, method, path, status_code,
+			error_code, message, request_id, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query,
+		e.ID, e.ProviderID, e.CredentialsID, e.Method, e.Path, e.StatusCode,
+		e.ErrorCode, e.Message, e.RequestID, e.CreatedAt,
+	)
+	return err
+}
+
+func (r *APIErrorRepository) GetByProviderID(ctx context.Context, providerID uuid.UUID, since time.Time, limit int) ([]*domain.APIErrorLog, error) {
+	query := `
+		SELECT id, provider_id, credentials_id, method, path, status_code,
+			error_code, message, request_id, created_at
+		FROM provider_api_errors
+		WHERE provider_id = $1 AND created_at >= $2
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, providerID, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.APIErrorLog
+	for rows.Next() {
+		var e domain.APIErrorLog
+		if err := rows.Scan(
+			&e.ID, &e.ProviderID, &e.CredentialsID, &e.Method, &e.Path, &e.StatusCode,
+			&e.ErrorCode, &e.Message, &e.RequestID, &e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+func (r *APIErrorRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM provider_api_errors WHERE created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+const pricingColumns = `id, location_id, hourly_rate, daily_max, currency, grace_period_min,
+	pricing_schedule, effective_from, created_by, applied_at, created_at`
+
+type PricingRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPricingRepository(db *pgxpool.Pool) *PricingRepository {
+	return &PricingRepository{db: db}
+}
+
+// CreateBatch inserts all versions in a single transaction so a bulk update
+// either takes effect for every location or none of them.
+func (r *PricingRepository) CreateBatch(ctx context.Context, versions []*domain.PricingVersion) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO location_pricing_versions (` + pricingColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	for _, v := range versions {
+		scheduleJSON, err := encodeSchedule(v.PricingSchedule)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, query,
+			v.ID, v.LocationID, v.HourlyRate, v.DailyMax, v.Currency, v.GracePeriodMin,
+			scheduleJSON, v.EffectiveFrom, v.CreatedBy, v.AppliedAt, v.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PricingRepository) GetByLocationID(ctx context.Context, locationID uuid.UUID) ([]*domain.PricingVersion, error) {
+	query := `
+		SELECT ` + pricingColumns + `
+		FROM location_pricing_versions
+		WHERE location_id = $1
+		ORDER BY effective_from DESC, created_at DESC
+	`
+	return r.queryVersions(ctx, query, locationID)
+}
+
+func (r *PricingRepository) GetEffective(ctx context.Context, locationID uuid.UUID, at time.Time) (*domain.PricingVersion, error) {
+	query := `
+		SELECT ` + pricingColumns + `
+		FROM location_pricing_versions
+		WHERE location_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC, created_at DESC
+		LIMIT 1
+	`
+	v, err := scanPricingVersion(r.db.QueryRow(ctx, query, locationID, at))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPricingNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+func (r *PricingRepository) GetUnapplied(ctx context.Context, now time.Time, limit int) ([]*domain.PricingVersion, error) {
+	query := `
+		SELECT ` + pricingColumns + `
+		FROM location_pricing_versions
+		WHERE applied_at IS NULL AND effective_from <= $1
+		ORDER BY effective_from ASC, created_at ASC
+		LIMIT $2
+	`
+	return r.queryVersions(ctx, query, now, limit)
+}
+
+func (r *PricingRepository) MarkApplied(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.db.Exec(ctx,
+		`UPDATE location_pricing_versions SET applied_at = $2 WHERE id = $1`,
+		id, at,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPricingNotFound
+	}
+	return nil
+}
+
+func (r *PricingRepository) SetScheduleAfter(ctx context.Context, locationID uuid.UUID, after time.Time, schedule *pricing.Schedule) error {
+	scheduleJSON, err := encodeSchedule(schedule)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx,
+		`UPDATE location_pricing_versions SET pricing_schedule = $3 WHERE location_id = $1 AND effective_from > $2`,
+		locationID, after, scheduleJSON,
+	)
+	return err
+}
+
+func (r *PricingRepository) queryVersions(ctx context.Context, query string, args ...interface{}) ([]*domain.PricingVersion, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*domain.PricingVersion
+	for rows.Next() {
+		v, err := scanPricingVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func scanPricingVersion(row pgx.Row) (*domain.PricingVersion, error) {
+	var v domain.PricingVersion
+	var scheduleJSON []byte
+	err := row.Scan(
+		&v.ID, &v.LocationID, &v.HourlyRate, &v.DailyMax, &v.Currency, &v.GracePeriodMin,
+		&scheduleJSON, &v.EffectiveFrom, &v.CreatedBy, &v.AppliedAt, &v.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if scheduleJSON != nil {
+		v.PricingSchedule = &pricing.Schedule{}
+		if err := json.Unmarshal(scheduleJSON, v.PricingSchedule); err != nil {
+			return nil, fmt.Errorf("failed to decode pricing schedule: %w", err)
+		}
+	}
+	return &v, nil
+}
+
+// encodeSchedule returns a schedule as JSON, or NULL for none
+func encodeSchedule(schedule *pricing.Schedule) ([]byte, error) {
+	if schedule == nil {
+		return nil, nil
+	}
+	scheduleJSON, err := json.Marshal(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pricing schedule: %w", err)
+	}
+	return scheduleJSON, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/actor"
 	"github.com/parking-super-app/services/provider/internal/domain"
 )
 
@@ -28,14 +30,17 @@ func (r *ProviderRepository) Create(ctx context.Context, provider *domain.Provid
 	query := `
 		INSERT INTO providers (
 			id, name, code, description, logo_url, status,
-			mfe_url, api_base_url, webhook_secret, config,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, updated_by
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 	_, err = r.db.Exec(ctx, query,
 		provider.ID, provider.Name, provider.Code, provider.Description,
-		provider.LogoURL, provider.Status, provider.MFEURL, provider.APIBaseURL,
+		provider.LogoURL, provider.Status, provider.StatusReason, provider.SuspendedUntil,
+		provider.MFEURL, provider.APIBaseURL, provider.WebhookURL,
 		provider.WebhookSecret, configJSON, provider.CreatedAt, provider.UpdatedAt,
+		actor.FromContext(ctx),
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -49,9 +54,10 @@ func (r *ProviderRepository) Create(ctx context.Context, provider *domain.Provid
 func (r *ProviderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Provider, error) {
 	query := `
 		SELECT id, name, code, description, logo_url, status,
-			mfe_url, api_base_url, webhook_secret, config,
-			created_at, updated_at
-		FROM providers WHERE id = $1
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers WHERE id = $1 AND deleted_at IS NULL
 	`
 	return r.scanProvider(r.db.QueryRow(ctx, query, id))
 }
@@ -59,9 +65,10 @@ func (r *ProviderRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 func (r *ProviderRepository) GetByCode(ctx context.Context, code string) (*domain.Provider, error) {
 	query := `
 		SELECT id, name, code, description, logo_url, status,
-			mfe_url, api_base_url, webhook_secret, config,
-			created_at, updated_at
-		FROM providers WHERE code = $1
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers WHERE code = $1 AND deleted_at IS NULL
 	`
 	return r.scanProvider(r.db.QueryRow(ctx, query, code))
 }
@@ -69,12 +76,13 @@ func (r *ProviderRepository) GetByCode(ctx context.Context, code string) (*domai
 func (r *ProviderRepository) GetAll(ctx context.Context, activeOnly bool) ([]*domain.Provider, error) {
 	query := `
 		SELECT id, name, code, description, logo_url, status,
-			mfe_url, api_base_url, webhook_secret, config,
-			created_at, updated_at
-		FROM providers
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers WHERE deleted_at IS NULL
 	`
 	if activeOnly {
-		query += ` WHERE status = 'active'`
+		query += ` AND status = 'active'`
 	}
 	query += ` ORDER BY name`
 
@@ -95,6 +103,32 @@ func (r *ProviderRepository) GetAll(ctx context.Context, activeOnly bool) ([]*do
 	return providers, rows.Err()
 }
 
+func (r *ProviderRepository) GetByStatus(ctx context.Context, status domain.ProviderStatus) ([]*domain.Provider, error) {
+	query := `
+		SELECT id, name, code, description, logo_url, status,
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers WHERE status = $1 AND deleted_at IS NULL
+		ORDER BY created_at
+	`
+	return r.queryProviders(ctx, query, status)
+}
+
+func (r *ProviderRepository) GetDueForReactivation(ctx context.Context, now time.Time) ([]*domain.Provider, error) {
+	query := `
+		SELECT id, name, code, description, logo_url, status,
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers
+		WHERE status = 'suspended' AND suspended_until IS NOT NULL AND suspended_until <= $1
+			AND deleted_at IS NULL
+		ORDER BY suspended_until
+	`
+	return r.queryProviders(ctx, query, now)
+}
+
 func (r *ProviderRepository) Update(ctx context.Context, provider *domain.Provider) error {
 	configJSON, err := json.Marshal(provider.Config)
 	if err != nil {
@@ -104,14 +138,17 @@ func (r *ProviderRepository) Update(ctx context.Context, provider *domain.Provid
 	query := `
 		UPDATE providers
 		SET name = $2, description = $3, logo_url = $4, status = $5,
-			mfe_url = $6, api_base_url = $7, webhook_secret = $8,
-			config = $9, updated_at = $10
-		WHERE id = $1
+			status_reason = $6, suspended_until = $7,
+			mfe_url = $8, api_base_url = $9, webhook_url = $10, webhook_secret = $11,
+			config = $12, updated_at = $13, updated_by = $14
+		WHERE id = $1 AND deleted_at IS NULL
 	`
 	result, err := r.db.Exec(ctx, query,
 		provider.ID, provider.Name, provider.Description, provider.LogoURL,
-		provider.Status, provider.MFEURL, provider.APIBaseURL,
+		provider.Status, provider.StatusReason, provider.SuspendedUntil,
+		provider.MFEURL, provider.APIBaseURL, provider.WebhookURL,
 		provider.WebhookSecret, configJSON, provider.UpdatedAt,
+		actor.FromContext(ctx),
 	)
 	if err != nil {
 		return err
@@ -122,15 +159,109 @@ func (r *ProviderRepository) Update(ctx context.Context, provider *domain.Provid
 	return nil
 }
 
-func (r *ProviderRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result, err := r.db.Exec(ctx, `DELETE FROM providers WHERE id = $1`, id)
+// Delete soft deletes the provider and its locations together, stamping
+// them with the same time so Restore can tell which locations went with it
+func (r *ProviderRepository) Delete(ctx context.Context, id uuid.UUID, at time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	updatedBy := actor.FromContext(ctx)
+	result, err := tx.Exec(ctx, `
+		UPDATE providers SET deleted_at = $2, updated_by = $3
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, at, updatedBy)
 	if err != nil {
 		return err
 	}
 	if result.RowsAffected() == 0 {
 		return domain.ErrProviderNotFound
 	}
-	return nil
+	_, err = tx.Exec(ctx, `
+		UPDATE locations SET deleted_at = $2, updated_by = $3
+		WHERE provider_id = $1 AND deleted_at IS NULL
+	`, id, at, updatedBy)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Restore undeletes the provider and the locations deleted with it.
+// Locations deleted on their own before the provider stay deleted.
+func (r *ProviderRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var deletedAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT deleted_at FROM providers WHERE id = $1 FOR UPDATE`, id).Scan(&deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrProviderNotFound
+		}
+		return err
+	}
+	if deletedAt == nil {
+		return domain.ErrProviderNotDeleted
+	}
+
+	updatedBy := actor.FromContext(ctx)
+	if _, err := tx.Exec(ctx, `UPDATE providers SET deleted_at = NULL, updated_by = $2 WHERE id = $1`, id, updatedBy); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE locations SET deleted_at = NULL, updated_by = $3
+		WHERE provider_id = $1 AND deleted_at = $2
+	`, id, *deletedAt, updatedBy)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *ProviderRepository) GetDeleted(ctx context.Context) ([]*domain.Provider, error) {
+	query := `
+		SELECT id, name, code, description, logo_url, status,
+			status_reason, suspended_until,
+			mfe_url, api_base_url, webhook_url, webhook_secret, config,
+			created_at, updated_at, deleted_at
+		FROM providers WHERE deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+	`
+	return r.queryProviders(ctx, query)
+}
+
+// Purge hard deletes providers deleted before deletedBefore. Their
+// locations, credentials and sessions go with them; their audit log stays.
+func (r *ProviderRepository) Purge(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	result, err := r.db.Exec(ctx, `DELETE FROM providers WHERE deleted_at < $1`, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+func (r *ProviderRepository) queryProviders(ctx context.Context, query string, args ...interface{}) ([]*domain.Provider, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var providers []*domain.Provider
+	for rows.Next() {
+		p, err := r.scanProviderRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	return providers, rows.Err()
 }
 
 func (r *ProviderRepository) scanProvider(row pgx.Row) (*domain.Provider, error) {
@@ -138,8 +269,9 @@ func (r *ProviderRepository) scanProvider(row pgx.Row) (*domain.Provider, error)
 	var configJSON []byte
 	err := row.Scan(
 		&p.ID, &p.Name, &p.Code, &p.Description, &p.LogoURL, &p.Status,
-		&p.MFEURL, &p.APIBaseURL, &p.WebhookSecret, &configJSON,
-		&p.CreatedAt, &p.UpdatedAt,
+		&p.StatusReason, &p.SuspendedUntil,
+		&p.MFEURL, &p.APIBaseURL, &p.WebhookURL, &p.WebhookSecret, &configJSON,
+		&p.CreatedAt, &p.UpdatedAt, &p.DeletedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -158,8 +290,9 @@ func (r *ProviderRepository) scanProviderRow(rows pgx.Rows) (*domain.Provider, e
 	var configJSON []byte
 	err := rows.Scan(
 		&p.ID, &p.Name, &p.Code, &p.Description, &p.LogoURL, &p.Status,
-		&p.MFEURL, &p.APIBaseURL, &p.WebhookSecret, &configJSON,
-		&p.CreatedAt, &p.UpdatedAt,
+		&p.StatusReason, &p.SuspendedUntil,
+		&p.MFEURL, &p.APIBaseURL, &p.WebhookURL, &p.WebhookSecret, &configJSON,
+		&p.CreatedAt, &p.UpdatedAt, &p.DeletedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -177,3 +310,11 @@ func isUniqueViolation(err error) bool {
 	}
 	return false
 }
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState() == "23503"
+	}
+	return false
+}
@@ -0,0 +1,491 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/pkg/testenv"
+	"github.com/parking-super-app/services/provider/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/migrations"
+	"github.com/shopspring/decimal"
+)
+
+// Run with:
+//
+//	go test -tags integration ./internal/adapters/repository/postgres/...
+//
+// A Postgres container is started unless TEST_POSTGRES_URL is set.
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func TestLocationRepository(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	pool := testenv.PostgresPool(t, migrations.FS)
+	providers := postgres.NewProviderRepository(pool)
+	repo := postgres.NewLocationRepository(pool)
+
+	provider, err := domain.NewProvider("Test Parking", "test-parking", "https://mfe.example.com", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if err := providers.Create(ctx, provider); err != nil {
+		t.Fatalf("Create() provider error = %v", err)
+	}
+
+	// Two locations in KL city centre and one in Penang
+	klcc := domain.NewLocation(provider.ID, "KLCC", "Jalan Ampang", "Kuala Lumpur", "WP", 3.1579, 101.7116)
+	klcc.SetPricing(3, 20)
+	klcc.AddAmenity("ev_charging")
+	pavilion := domain.NewLocation(provider.ID, "Pavilion", "Jalan Bukit Bintang", "Kuala Lumpur", "WP", 3.1488, 101.7133)
+	lowRoof := 190
+	if err := pavilion.SetAmenities([]string{"covered", "ev_charging"}, &lowRoof); err != nil {
+		t.Fatal(err)
+	}
+	penang := domain.NewLocation(provider.ID, "Gurney", "Gurney Drive", "George Town", "Penang", 5.4378, 100.3098)
+	for _, loc := range []*domain.Location{klcc, pavilion, penang} {
+		if err := repo.Create(ctx, loc); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.GetByID(ctx, klcc.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.Name != "KLCC" || got.Pricing.HourlyRate != 3 || got.Pricing.DailyMax != 20 ||
+		len(got.Amenities) != 1 || got.Amenities[0] != "ev_charging" {
+		t.Errorf("GetByID() = %+v", got)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("GetByID() of unknown location error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if got.PricingSchedule != nil || got.OperatingHours != nil {
+		t.Errorf("GetByID() schedule = %+v, hours = %+v, want neither", got.PricingSchedule, got.OperatingHours)
+	}
+
+	err = klcc.SetPricingSchedule(&pricing.Schedule{Timezone: "Asia/Kuala_Lumpur", Rules: []pricing.Rule{
+		{Name: "night", Kind: pricing.RuleFlat, Start: "22:00", End: "07:00", FlatRate: decimal.RequireFromString("5.00")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := klcc.SetOperatingHours(&pricing.Hours{Timezone: "Asia/Kuala_Lumpur", Windows: []pricing.OpeningWindow{
+		{Day: "mon", Open: "07:00", Close: "23:00"},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, klcc); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	got, err = repo.GetByID(ctx, klcc.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.PricingSchedule == nil || len(got.PricingSchedule.Rules) != 1 ||
+		!got.PricingSchedule.Rules[0].FlatRate.Equal(decimal.RequireFromString("5.00")) {
+		t.Errorf("GetByID() schedule = %+v", got.PricingSchedule)
+	}
+	if got.OperatingHours == nil || len(got.OperatingHours.Windows) != 1 || got.OperatingHours.Windows[0].Close != "23:00" {
+		t.Errorf("GetByID() hours = %+v", got.OperatingHours)
+	}
+
+	nearby, err := repo.GetNearby(ctx, 3.1579, 101.7116, 5, domain.LocationFilter{})
+	if err != nil {
+		t.Fatalf("GetNearby() error = %v", err)
+	}
+	if len(nearby) != 2 || nearby[0].ID != klcc.ID {
+		t.Errorf("GetNearby() = %d locations, want the two in KL nearest first", len(nearby))
+	}
+
+	covered, err := repo.GetNearby(ctx, 3.1579, 101.7116, 5, domain.LocationFilter{Amenities: []string{"covered", "ev_charging"}})
+	if err != nil {
+		t.Fatalf("GetNearby() error = %v", err)
+	}
+	if len(covered) != 1 || covered[0].ID != pavilion.ID || *covered[0].HeightLimitCm != 190 {
+		t.Errorf("GetNearby() with amenities = %d locations, want Pavilion", len(covered))
+	}
+	tall, err := repo.GetNearby(ctx, 3.1579, 101.7116, 5, domain.LocationFilter{Amenities: []string{"ev_charging"}, VehicleHeightCm: 200})
+	if err != nil {
+		t.Fatalf("GetNearby() error = %v", err)
+	}
+	if len(tall) != 1 || tall[0].ID != klcc.ID {
+		t.Errorf("GetNearby() for a tall vehicle = %d locations, want KLCC without a height limit", len(tall))
+	}
+
+	// At country zoom the two KL locations share a cluster and Penang is
+	// on its own
+	malaysia := domain.BoundingBox{South: 0.8, West: 99.6, North: 7.4, East: 119.3}
+	clusters, err := repo.Clusters(ctx, malaysia, domain.CellZoom(5))
+	if err != nil {
+		t.Fatalf("Clusters() error = %v", err)
+	}
+	if len(clusters) != 2 || clusters[0].Count != 2 || clusters[0].LocationID != nil ||
+		clusters[1].Count != 1 || clusters[1].LocationID == nil || *clusters[1].LocationID != penang.ID {
+		t.Errorf("Clusters() = %+v, want KL as a cluster of two and Penang alone", clusters)
+	}
+	if clusters[0].Bounds.South != pavilion.Latitude || clusters[0].Bounds.North != klcc.Latitude {
+		t.Errorf("KL cluster bounds = %+v", clusters[0].Bounds)
+	}
+
+	pavilion.Deactivate()
+	if err := repo.Update(ctx, pavilion); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	listed, err := repo.GetByProviderID(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("GetByProviderID() error = %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("GetByProviderID() = %d locations, want the 2 active ones", len(listed))
+	}
+
+	var updatedBy string
+	if err := pool.QueryRow(ctx, `SELECT updated_by FROM locations WHERE id = $1`, pavilion.ID).Scan(&updatedBy); err != nil {
+		t.Fatalf("read updated_by: %v", err)
+	}
+	if updatedBy != "admin-1" {
+		t.Errorf("updated_by = %q, want admin-1", updatedBy)
+	}
+
+	if err := repo.Delete(ctx, penang.ID, time.Now()); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(ctx, penang.ID, time.Now()); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("second Delete() error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	pool := testenv.PostgresPool(t, migrations.FS)
+	providers := postgres.NewProviderRepository(pool)
+	locations := postgres.NewLocationRepository(pool)
+	audits := postgres.NewAuditRepository(pool)
+
+	provider, err := domain.NewProvider("Deleted Parking", "deleted-parking", "https://mfe.example.com", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if err := providers.Create(ctx, provider); err != nil {
+		t.Fatalf("Create() provider error = %v", err)
+	}
+	klcc := domain.NewLocation(provider.ID, "KLCC", "Jalan Ampang", "Kuala Lumpur", "WP", 3.1579, 101.7116)
+	pavilion := domain.NewLocation(provider.ID, "Pavilion", "Jalan Bukit Bintang", "Kuala Lumpur", "WP", 3.1488, 101.7133)
+	for _, loc := range []*domain.Location{klcc, pavilion} {
+		if err := locations.Create(ctx, loc); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	// A location deleted on its own stays deleted when its provider is restored
+	if err := locations.Delete(ctx, pavilion.ID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Delete() location error = %v", err)
+	}
+	if _, err := locations.GetByID(ctx, pavilion.ID); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("GetByID() of deleted location error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if err := locations.Restore(ctx, uuid.New(), pavilion.ID); !errors.Is(err, domain.ErrLocationNotOwned) {
+		t.Errorf("Restore() by another provider error = %v, want %v", err, domain.ErrLocationNotOwned)
+	}
+	if err := locations.Restore(ctx, provider.ID, klcc.ID); !errors.Is(err, domain.ErrLocationNotDeleted) {
+		t.Errorf("Restore() of live location error = %v, want %v", err, domain.ErrLocationNotDeleted)
+	}
+
+	if err := providers.Delete(ctx, provider.ID, time.Now()); err != nil {
+		t.Fatalf("Delete() provider error = %v", err)
+	}
+	if _, err := providers.GetByID(ctx, provider.ID); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("GetByID() of deleted provider error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	if _, err := providers.GetByCode(ctx, provider.Code); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("GetByCode() of deleted provider error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+	live, err := locations.GetByProviderID(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("GetByProviderID() error = %v", err)
+	}
+	if len(live) != 0 {
+		t.Errorf("GetByProviderID() after delete = %d locations, want 0", len(live))
+	}
+	deleted, err := providers.GetDeleted(ctx)
+	if err != nil {
+		t.Fatalf("GetDeleted() error = %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].ID != provider.ID || deleted[0].DeletedAt == nil {
+		t.Errorf("GetDeleted() = %+v", deleted)
+	}
+
+	if err := providers.Restore(ctx, provider.ID); err != nil {
+		t.Fatalf("Restore() provider error = %v", err)
+	}
+	if err := providers.Restore(ctx, provider.ID); !errors.Is(err, domain.ErrProviderNotDeleted) {
+		t.Errorf("Restore() twice error = %v, want %v", err, domain.ErrProviderNotDeleted)
+	}
+	live, err = locations.GetByProviderID(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("GetByProviderID() error = %v", err)
+	}
+	if len(live) != 1 || live[0].ID != klcc.ID {
+		t.Errorf("GetByProviderID() after restore = %+v, want only KLCC", live)
+	}
+	gone, err := locations.GetDeletedByProviderID(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("GetDeletedByProviderID() error = %v", err)
+	}
+	if len(gone) != 1 || gone[0].ID != pavilion.ID {
+		t.Errorf("GetDeletedByProviderID() = %+v, want only Pavilion", gone)
+	}
+
+	// Purge removes only rows deleted before the cutoff
+	n, err := locations.Purge(ctx, time.Now().Add(-2*time.Hour))
+	if err != nil || n != 0 {
+		t.Errorf("Purge() before deletion = %d, %v, want 0", n, err)
+	}
+	n, err = locations.Purge(ctx, time.Now())
+	if err != nil || n != 1 {
+		t.Errorf("Purge() = %d, %v, want 1", n, err)
+	}
+	if err := locations.Restore(ctx, provider.ID, pavilion.ID); !errors.Is(err, domain.ErrLocationNotOwned) {
+		t.Errorf("Restore() of purged location error = %v, want %v", err, domain.ErrLocationNotOwned)
+	}
+
+	entry, err := domain.NewAuditEntry(provider.ID, "admin-1", domain.AuditActionDeleted, "closed down")
+	if err != nil {
+		t.Fatalf("NewAuditEntry() error = %v", err)
+	}
+	if err := audits.Create(ctx, entry); err != nil {
+		t.Fatalf("Create() audit entry error = %v", err)
+	}
+	if err := providers.Delete(ctx, provider.ID, time.Now().Add(-time.Hour)); err != nil {
+		t.Fatalf("Delete() provider error = %v", err)
+	}
+	n, err = providers.Purge(ctx, time.Now())
+	if err != nil || n != 1 {
+		t.Errorf("Purge() providers = %d, %v, want 1", n, err)
+	}
+	// The audit history outlives the purged provider
+	history, err := audits.GetByProviderID(ctx, provider.ID, 10, 0)
+	if err != nil {
+		t.Fatalf("GetByProviderID() audit log error = %v", err)
+	}
+	if len(history) != 1 || history[0].ID != entry.ID {
+		t.Errorf("audit log after purge = %+v, want the deleted entry", history)
+	}
+	if err := providers.Restore(ctx, provider.ID); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("Restore() of purged provider error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+}
+
+func TestWebhookEventRepository(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	pool := testenv.PostgresPool(t, migrations.FS)
+	providers := postgres.NewProviderRepository(pool)
+	repo := postgres.NewWebhookEventRepository(pool)
+
+	provider, err := domain.NewProvider("Test Parking", "test-parking", "https://mfe.example.com", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if err := provider.SetWebhook("https://hooks.example.com/parking", "whsec_test"); err != nil {
+		t.Fatalf("SetWebhook() error = %v", err)
+	}
+	if err := providers.Create(ctx, provider); err != nil {
+		t.Fatalf("Create() provider error = %v", err)
+	}
+	stored, err := providers.GetByID(ctx, provider.ID)
+	if err != nil {
+		t.Fatalf("GetByID() provider error = %v", err)
+	}
+	if stored.WebhookURL != provider.WebhookURL || stored.WebhookSecret != provider.WebhookSecret {
+		t.Errorf("GetByID() webhook = %q, want %q", stored.WebhookURL, provider.WebhookURL)
+	}
+
+	due, _ := domain.NewWebhookEvent(provider.ID, domain.WebhookEventPaymentCompleted, map[string]interface{}{"session_id": "s-1"})
+	later, _ := domain.NewWebhookEvent(provider.ID, domain.WebhookEventSessionCancelled, nil)
+	later.NextAttemptAt = time.Now().Add(time.Hour)
+	for _, e := range []*domain.WebhookEvent{due, later} {
+		if err := repo.Create(ctx, e); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	claimed, err := repo.ClaimDue(ctx, time.Now(), time.Minute, 10)
+	if err != nil {
+		t.Fatalf("ClaimDue() error = %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != due.ID || string(claimed[0].Payload) == "" {
+		t.Fatalf("ClaimDue() = %d events, want the due one", len(claimed))
+	}
+	if again, _ := repo.ClaimDue(ctx, time.Now(), time.Minute, 10); len(again) != 0 {
+		t.Errorf("ClaimDue() within the lease = %d events, want none", len(again))
+	}
+
+	claimed[0].Fail("endpoint returned status 500")
+	if err := repo.Update(ctx, claimed[0]); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	failed, err := repo.ListByProviderID(ctx, provider.ID, domain.WebhookEventFailed, 10, 0)
+	if err != nil {
+		t.Fatalf("ListByProviderID() error = %v", err)
+	}
+	if len(failed) != 1 || failed[0].LastError != "endpoint returned status 500" {
+		t.Errorf("ListByProviderID(failed) = %d events, want 1", len(failed))
+	}
+	if all, _ := repo.ListByProviderID(ctx, provider.ID, "", 10, 0); len(all) != 2 {
+		t.Errorf("ListByProviderID() = %d events, want 2", len(all))
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrWebhookEventNotFound) {
+		t.Errorf("GetByID() of unknown event error = %v, want %v", err, domain.ErrWebhookEventNotFound)
+	}
+}
+
+func TestProviderSessionRepository(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	pool := testenv.PostgresPool(t, migrations.FS)
+	providers := postgres.NewProviderRepository(pool)
+	repo := postgres.NewProviderSessionRepository(pool)
+
+	provider, err := domain.NewProvider("Report Parking", "report-parking", "https://mfe.example.com", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if err := providers.Create(ctx, provider); err != nil {
+		t.Fatalf("Create() provider error = %v", err)
+	}
+
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	locationID := uuid.New()
+	session := func(entry time.Time, status domain.ProviderSessionStatus, amount string, minutes int) *domain.ProviderSession {
+		return &domain.ProviderSession{
+			SessionID:       uuid.New(),
+			ProviderID:      provider.ID,
+			LocationID:      locationID,
+			Status:          status,
+			EntryTime:       entry,
+			DurationMinutes: minutes,
+			Amount:          decimal.RequireFromString(amount),
+			Currency:        "MYR",
+			RecordedAt:      entry,
+		}
+	}
+	ended := session(start.Add(time.Hour), domain.ProviderSessionEnded, "6.00", 90)
+	for _, s := range []*domain.ProviderSession{
+		ended,
+		session(start.Add(2*time.Hour), domain.ProviderSessionCancelled, "0", 0),
+		session(start.AddDate(0, 1, 0), domain.ProviderSessionEnded, "4.00", 60), // next month
+	} {
+		if err := repo.Save(ctx, s); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+	// A redelivered event leaves one row
+	if err := repo.Save(ctx, ended); err != nil {
+		t.Fatalf("Save() again error = %v", err)
+	}
+
+	filter, err := domain.NewSessionFilter(start, start.AddDate(0, 1, 0), nil)
+	if err != nil {
+		t.Fatalf("NewSessionFilter() error = %v", err)
+	}
+	totals, err := repo.Totals(ctx, provider.ID, filter)
+	if err != nil {
+		t.Fatalf("Totals() error = %v", err)
+	}
+	if totals.Sessions != 2 || totals.Cancelled != 1 || totals.Minutes != 90 || !totals.Revenue.Equal(decimal.RequireFromString("6")) {
+		t.Errorf("Totals() = %+v", totals)
+	}
+
+	listed, err := repo.List(ctx, provider.ID, filter, 10, 0)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(listed) != 2 || listed[1].SessionID != ended.SessionID {
+		t.Errorf("List() = %d sessions, want September's two newest first", len(listed))
+	}
+
+	other := uuid.New()
+	filter.LocationID = &other
+	if listed, _ := repo.List(ctx, provider.ID, filter, 10, 0); len(listed) != 0 {
+		t.Errorf("List() at another location = %d sessions, want 0", len(listed))
+	}
+
+	orphan := session(start, domain.ProviderSessionEnded, "1.00", 10)
+	orphan.ProviderID = uuid.New()
+	if err := repo.Save(ctx, orphan); !errors.Is(err, domain.ErrProviderNotFound) {
+		t.Errorf("Save() for unknown provider error = %v, want %v", err, domain.ErrProviderNotFound)
+	}
+}
+
+func TestPricingRepository_Schedules(t *testing.T) {
+	ctx := actor.NewContext(context.Background(), "admin-1")
+	pool := testenv.PostgresPool(t, migrations.FS)
+	providers := postgres.NewProviderRepository(pool)
+	locations := postgres.NewLocationRepository(pool)
+	repo := postgres.NewPricingRepository(pool)
+
+	provider, err := domain.NewProvider("Scheduled Parking", "scheduled-parking", "https://mfe.example.com", "https://api.example.com")
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	if err := providers.Create(ctx, provider); err != nil {
+		t.Fatalf("Create() provider error = %v", err)
+	}
+	location := domain.NewLocation(provider.ID, "KLCC", "Jalan Ampang", "Kuala Lumpur", "WP", 3.1579, 101.7116)
+	if err := locations.Create(ctx, location); err != nil {
+		t.Fatalf("Create() location error = %v", err)
+	}
+
+	peak := func(rate string) *pricing.Schedule {
+		return &pricing.Schedule{Timezone: "Asia/Kuala_Lumpur", Rules: []pricing.Rule{
+			{Name: "peak", Kind: pricing.RuleHourly, Start: "08:00", End: "18:00", HourlyRate: decimal.RequireFromString(rate)},
+		}}
+	}
+	now := time.Now().UTC()
+	location.PricingSchedule = peak("4.00")
+	original, _ := domain.NewPricingVersion(location, 2, 20, now.Add(-2*time.Hour))
+	location.PricingSchedule = peak("6.00")
+	changed, _ := domain.NewPricingVersion(location, 2, 20, now.Add(-time.Hour))
+	scheduled, _ := domain.NewPricingVersion(location, 3, 30, now.Add(time.Hour))
+	if err := repo.CreateBatch(ctx, []*domain.PricingVersion{original, changed, scheduled}); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	// A session that entered before the schedule changed is billed by the old one
+	got, err := repo.GetEffective(ctx, location.ID, now.Add(-90*time.Minute))
+	if err != nil {
+		t.Fatalf("GetEffective() error = %v", err)
+	}
+	if got.PricingSchedule == nil || !got.PricingSchedule.Rules[0].HourlyRate.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("schedule at entry = %+v, want the 4.00 peak rate", got.PricingSchedule)
+	}
+
+	// A schedule change carries into rate changes already scheduled
+	if err := repo.SetScheduleAfter(ctx, location.ID, now, nil); err != nil {
+		t.Fatalf("SetScheduleAfter() error = %v", err)
+	}
+	got, err = repo.GetEffective(ctx, location.ID, now.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("GetEffective() error = %v", err)
+	}
+	if got.ID != scheduled.ID || got.PricingSchedule != nil {
+		t.Errorf("scheduled version = %+v, want it without a schedule", got)
+	}
+	got, err = repo.GetEffective(ctx, location.ID, now)
+	if err != nil {
+		t.Fatalf("GetEffective() error = %v", err)
+	}
+	if got.ID != changed.ID || got.PricingSchedule == nil {
+		t.Errorf("current version = %+v, want its schedule untouched", got)
+	}
+}
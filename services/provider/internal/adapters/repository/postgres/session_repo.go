@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+const providerSessionColumns = `
+	session_id, provider_id, location_id, external_session_id, vehicle_plate, status,
+	entry_time, exit_time, duration_minutes, amount, currency, payment_id, recorded_at
+`
+
+// providerSessionFilter matches domain.SessionFilter; $1 is the provider
+const providerSessionFilter = `
+	provider_id = $1 AND entry_time >= $2 AND entry_time < $3
+	AND ($4::uuid IS NULL OR location_id = $4)
+`
+
+type ProviderSessionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewProviderSessionRepository(db *pgxpool.Pool) *ProviderSessionRepository {
+	return &ProviderSessionRepository{db: db}
+}
+
+// Save records a session, replacing what was recorded for it before, so a
+// redelivered event leaves the same row. Returns ErrProviderNotFound for
+// sessions of providers this service does not know.
+func (r *ProviderSessionRepository) Save(ctx context.Context, s *domain.ProviderSession) error {
+	query := `
+		INSERT INTO provider_sessions (` + providerSessionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (session_id) DO UPDATE SET
+			status = EXCLUDED.status, exit_time = EXCLUDED.exit_time,
+			duration_minutes = EXCLUDED.duration_minutes, amount = EXCLUDED.amount,
+			currency = EXCLUDED.currency, payment_id = EXCLUDED.payment_id,
+			recorded_at = EXCLUDED.recorded_at
+	`
+	_, err := r.db.Exec(ctx, query,
+		s.SessionID, s.ProviderID, s.LocationID, s.ExternalSessionID, s.VehiclePlate, s.Status,
+		s.EntryTime, s.ExitTime, s.DurationMinutes, s.Amount, s.Currency, s.PaymentID, s.RecordedAt,
+	)
+	if isForeignKeyViolation(err) {
+		return domain.ErrProviderNotFound
+	}
+	return err
+}
+
+// List returns the provider's sessions matching the filter, newest entry first
+func (r *ProviderSessionRepository) List(ctx context.Context, providerID uuid.UUID, filter domain.SessionFilter, limit, offset int) ([]*domain.ProviderSession, error) {
+	query := `
+		SELECT ` + providerSessionColumns + `
+		FROM provider_sessions
+		WHERE ` + providerSessionFilter + `
+		ORDER BY entry_time DESC
+		LIMIT $5 OFFSET $6
+	`
+	rows, err := r.db.Query(ctx, query, providerID, filter.From, filter.To, filter.LocationID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []*domain.ProviderSession
+	for rows.Next() {
+		s, err := scanProviderSession(rows)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
+// Totals sums up every session matching the filter. Cancelled sessions are
+// counted but add no minutes or revenue.
+func (r *ProviderSessionRepository) Totals(ctx context.Context, providerID uuid.UUID, filter domain.SessionFilter) (*domain.SessionTotals, error) {
+	query := `
+		SELECT
+			COUNT(*),
+			COUNT(*) FILTER (WHERE status = 'cancelled'),
+			COALESCE(SUM(duration_minutes) FILTER (WHERE status = 'ended'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE status = 'ended'), 0)
+		FROM provider_sessions
+		WHERE ` + providerSessionFilter
+
+	var t domain.SessionTotals
+	err := r.db.QueryRow(ctx, query, providerID, filter.From, filter.To, filter.LocationID).
+		Scan(&t.Sessions, &t.Cancelled, &t.Minutes, &t.Revenue)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func scanProviderSession(row pgx.Row) (*domain.ProviderSession, error) {
+	var s domain.ProviderSession
+	err := row.Scan(
+		&s.SessionID, &s.ProviderID, &s.LocationID, &s.ExternalSessionID, &s.VehiclePlate, &s.Status,
+		&s.EntryTime, &s.ExitTime, &s.DurationMinutes, &s.Amount, &s.Currency, &s.PaymentID, &s.RecordedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
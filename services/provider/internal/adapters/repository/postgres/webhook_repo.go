@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/provider/internal/domain"
+)
+
+const webhookEventColumns = `
+	id, provider_id, event_type, payload, status, attempts,
+	next_attempt_at, last_error, created_at, delivered_at
+`
+
+type WebhookEventRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookEventRepository(db *pgxpool.Pool) *WebhookEventRepository {
+	return &WebhookEventRepository{db: db}
+}
+
+func (r *WebhookEventRepository) Create(ctx context.Context, e *domain.WebhookEvent) error {
+	query := `
+		INSERT INTO provider_webhook_events (` + webhookEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query,
+		e.ID, e.ProviderID, e.EventType, e.Payload, e.Status, e.Attempts,
+		e.NextAttemptAt, e.LastError, e.CreatedAt, e.DeliveredAt,
+	)
+	return err
+}
+
+func (r *WebhookEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEvent, error) {
+	query := `SELECT ` + webhookEventColumns + ` FROM provider_webhook_events WHERE id = $1`
+	e, err := scanWebhookEvent(r.db.QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrWebhookEventNotFound
+	}
+	return e, err
+}
+
+func (r *WebhookEventRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookEvent, error) {
+	query := `
+		UPDATE provider_webhook_events SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM provider_webhook_events
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookEventColumns
+	return r.query(ctx, query, now, now.Add(lease), limit)
+}
+
+// ListByProviderID returns the provider's events, newest first. An empty
+// status lists events in any status.
+func (r *WebhookEventRepository) ListByProviderID(ctx context.Context, providerID uuid.UUID, status domain.WebhookEventStatus, limit, offset int) ([]*domain.WebhookEvent, error) {
+	query := `
+		SELECT ` + webhookEventColumns + `
+		FROM provider_webhook_events
+		WHERE provider_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC
+		LIMIT $3 OFFSET $4
+	`
+	return r.query(ctx, query, providerID, string(status), limit, offset)
+}
+
+func (r *WebhookEventRepository) Update(ctx context.Context, e *domain.WebhookEvent) error {
+	query := `
+		UPDATE provider_webhook_events
+		SET status = $2, attempts = $3, next_attempt_at = $4,
+			last_error = $5, delivered_at = $6
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query,
+		e.ID, e.Status, e.Attempts, e.NextAttemptAt, e.LastError, e.DeliveredAt,
+	)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrWebhookEventNotFound
+	}
+	return nil
+}
+
+func (r *WebhookEventRepository) query(ctx context.Context, query string, args ...interface{}) ([]*domain.WebhookEvent, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.WebhookEvent
+	for rows.Next() {
+		e, err := scanWebhookEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func scanWebhookEvent(row pgx.Row) (*domain.WebhookEvent, error) {
+	var e domain.WebhookEvent
+	err := row.Scan(
+		&e.ID, &e.ProviderID, &e.EventType, &e.Payload, &e.Status, &e.Attempts,
+		&e.NextAttemptAt, &e.LastError, &e.CreatedAt, &e.DeliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
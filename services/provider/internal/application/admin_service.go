@@ -0,0 +1,484 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// AdminService handles back-office use cases for provider onboarding and moderation
+type AdminService struct {
+	providers   ports.ProviderRepository
+	locations   ports.LocationRepository
+	audit       ports.AuditRepository
+	conformance ports.ConformanceRepository
+	events      ports.EventPublisher
+	auditLog    ports.AuditLog
+	logger      ports.Logger
+}
+
+func NewAdminService(
+	providers ports.ProviderRepository,
+	locations ports.LocationRepository,
+	audit ports.AuditRepository,
+	conformance ports.ConformanceRepository,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+) *AdminService {
+	return &AdminService{
+		providers:   providers,
+		locations:   locations,
+		audit:       audit,
+		conformance: conformance,
+		events:      events,
+		auditLog:    auditLog,
+		logger:      logger,
+	}
+}
+
+// Request/Response DTOs
+
+type AdminProviderResponse struct {
+	ProviderResponse
+	StatusReason   string     `json:"status_reason,omitempty"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+type RejectProviderRequest struct {
+	ProviderID uuid.UUID `json:"-"`
+	Actor      string    `json:"-"`
+	Reason     string    `json:"reason"`
+}
+
+type SuspendProviderRequest struct {
+	ProviderID   uuid.UUID  `json:"-"`
+	Actor        string     `json:"-"`
+	Reason       string     `json:"reason"`
+	ReactivateAt *time.Time `json:"reactivate_at,omitempty"`
+}
+
+type AuditEntryResponse struct {
+	ID         uuid.UUID                     `json:"id"`
+	ProviderID uuid.UUID                     `json:"provider_id"`
+	Actor      string                        `json:"actor"`
+	Action     string                        `json:"action"`
+	Reason     string                        `json:"reason,omitempty"`
+	Changes    map[string]domain.FieldChange `json:"changes"`
+	CreatedAt  time.Time                     `json:"created_at"`
+}
+
+// ListPendingProviders returns providers awaiting approval, oldest first
+func (s *AdminService) ListPendingProviders(ctx context.Context) ([]*AdminProviderResponse, error) {
+	providers, err := s.providers.GetByStatus(ctx, domain.ProviderStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending providers: %w", err)
+	}
+
+	responses := make([]*AdminProviderResponse, len(providers))
+	for i, p := range providers {
+		responses[i] = toAdminProviderResponse(p)
+	}
+	return responses, nil
+}
+
+// ApproveProvider activates a pending provider that has passed the API conformance check
+func (s *AdminService) ApproveProvider(ctx context.Context, id uuid.UUID, actor string) (*AdminProviderResponse, error) {
+	provider, err := s.providers.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewAuditEntry(provider.ID, actor, domain.AuditActionApproved, "")
+	if err != nil {
+		return nil, err
+	}
+	previous := provider.Status
+	before := *provider
+
+	if err := provider.Approve(); err != nil {
+		return nil, err
+	}
+	if err := requireConformance(ctx, s.conformance, provider); err != nil {
+		return nil, err
+	}
+	entry.RecordChange("status", string(previous), string(provider.Status))
+
+	if err := s.save(ctx, ports.AuditProviderApproved, before, provider, entry); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, ports.EventProviderApproved, provider, actor, "")
+	return toAdminProviderResponse(provider), nil
+}
+
+// RejectProvider declines a pending provider with a reason
+func (s *AdminService) RejectProvider(ctx context.Context, req RejectProviderRequest) (*AdminProviderResponse, error) {
+	provider, err := s.providers.GetByID(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewAuditEntry(provider.ID, req.Actor, domain.AuditActionRejected, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	previous := provider.Status
+	before := *provider
+
+	if err := provider.Reject(req.Reason); err != nil {
+		return nil, err
+	}
+	entry.RecordChange("status", string(previous), string(provider.Status))
+
+	if err := s.save(ctx, ports.AuditProviderRejected, before, provider, entry); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, ports.EventProviderRejected, provider, req.Actor, req.Reason)
+	return toAdminProviderResponse(provider), nil
+}
+
+// SuspendProvider suspends a provider, optionally scheduling automatic reactivation
+func (s *AdminService) SuspendProvider(ctx context.Context, req SuspendProviderRequest) (*AdminProviderResponse, error) {
+	provider, err := s.providers.GetByID(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := domain.NewAuditEntry(provider.ID, req.Actor, domain.AuditActionSuspended, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	previous := provider.Status
+	before := *provider
+
+	if err := provider.Suspend(req.Reason, req.ReactivateAt); err != nil {
+		return nil, err
+	}
+	entry.RecordChange("status", string(previous), string(provider.Status))
+	if req.ReactivateAt != nil {
+		entry.RecordChange("suspended_until", "", req.ReactivateAt.UTC().Format(time.RFC3339))
+	}
+
+	if err := s.save(ctx, ports.AuditProviderSuspended, before, provider, entry); err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, ports.EventProviderSuspended, provider, req.Actor, req.Reason)
+	return toAdminProviderResponse(provider), nil
+}
+
+// GetProviderHistory returns the audit trail for a provider, newest first.
+// The trail outlives the provider, so deleted and purged providers still
+// have one.
+func (s *AdminService) GetProviderHistory(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*AuditEntryResponse, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+
+	entries, err := s.audit.GetByProviderID(ctx, providerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provider history: %w", err)
+	}
+	if len(entries) == 0 && offset == 0 {
+		if _, err := s.providers.GetByID(ctx, providerID); err != nil {
+			return nil, err
+		}
+	}
+
+	responses := make([]*AuditEntryResponse, len(entries))
+	for i, e := range entries {
+		responses[i] = &AuditEntryResponse{
+			ID:         e.ID,
+			ProviderID: e.ProviderID,
+			Actor:      e.Actor,
+			Action:     string(e.Action),
+			Reason:     e.Reason,
+			Changes:    e.Changes,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+	return responses, nil
+}
+
+// ReactivateDueProviders lifts suspensions whose scheduled reactivation date has passed
+func (s *AdminService) ReactivateDueProviders(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	providers, err := s.providers.GetDueForReactivation(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get providers due for reactivation: %w", err)
+	}
+
+	reactivated := 0
+	for _, provider := range providers {
+		if !provider.IsDueForReactivation(now) {
+			continue
+		}
+
+		entry, err := domain.NewAuditEntry(provider.ID, domain.SystemActor, domain.AuditActionReactivated, "scheduled reactivation")
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("failed to create audit entry",
+				ports.String("provider_id", provider.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		previous := provider.Status
+		before := *provider
+
+		if err := provider.Reactivate(); err != nil {
+			s.logger.WithContext(ctx).Warn("failed to reactivate provider",
+				ports.String("provider_id", provider.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		entry.RecordChange("status", string(previous), string(provider.Status))
+
+		if err := s.save(ctx, ports.AuditProviderReactivated, before, provider, entry); err != nil {
+			s.logger.WithContext(ctx).Error("failed to save reactivated provider",
+				ports.String("provider_id", provider.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+
+		s.publish(ctx, ports.EventProviderReactivated, provider, domain.SystemActor, "")
+		reactivated++
+	}
+
+	return reactivated, nil
+}
+
+// DeleteProvider soft deletes a provider and its locations. They are hidden
+// everywhere but can be restored until the purge job removes them.
+func (s *AdminService) DeleteProvider(ctx context.Context, id uuid.UUID, actor string) error {
+	provider, err := s.providers.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	entry, err := domain.NewAuditEntry(provider.ID, actor, domain.AuditActionDeleted, "")
+	if err != nil {
+		return err
+	}
+	before := *provider
+
+	now := time.Now().UTC()
+	if err := s.providers.Delete(ctx, id, now); err != nil {
+		return fmt.Errorf("failed to delete provider: %w", err)
+	}
+	provider.DeletedAt = &now
+	entry.RecordChange("deleted_at", "", now.Format(time.RFC3339))
+	if err := s.audit.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditProviderDeleted, before, *provider, ""))
+
+	s.publish(ctx, ports.EventProviderDeleted, provider, actor, "")
+	return nil
+}
+
+// RestoreProvider undeletes a provider with the locations deleted along
+// with it
+func (s *AdminService) RestoreProvider(ctx context.Context, id uuid.UUID, actor string) (*AdminProviderResponse, error) {
+	entry, err := domain.NewAuditEntry(id, actor, domain.AuditActionRestored, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.providers.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+	provider, err := s.providers.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.RecordChange("deleted_at", "deleted", "")
+	if err := s.audit.Create(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, ports.AuditEvent{
+		Action:     ports.AuditProviderRestored,
+		TargetType: "provider",
+		TargetID:   provider.ID.String(),
+		After:      *provider,
+	})
+
+	s.publish(ctx, ports.EventProviderRestored, provider, actor, "")
+	return toAdminProviderResponse(provider), nil
+}
+
+// ListDeletedProviders returns the providers awaiting purge, most recently
+// deleted first
+func (s *AdminService) ListDeletedProviders(ctx context.Context) ([]*AdminProviderResponse, error) {
+	providers, err := s.providers.GetDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted providers: %w", err)
+	}
+
+	responses := make([]*AdminProviderResponse, len(providers))
+	for i, p := range providers {
+		responses[i] = toAdminProviderResponse(p)
+	}
+	return responses, nil
+}
+
+// DeleteLocation soft deletes one of a provider's locations
+func (s *AdminService) DeleteLocation(ctx context.Context, providerID, locationID uuid.UUID, actor string) error {
+	if actor == "" {
+		return domain.ErrActorRequired
+	}
+	location, err := s.locations.GetByID(ctx, locationID)
+	if errors.Is(err, domain.ErrProviderNotFound) || (err == nil && location.ProviderID != providerID) {
+		return domain.ErrLocationNotOwned
+	}
+	if err != nil {
+		return err
+	}
+	before := *location
+
+	now := time.Now().UTC()
+	if err := s.locations.Delete(ctx, locationID, now); err != nil {
+		return fmt.Errorf("failed to delete location: %w", err)
+	}
+	location.DeletedAt = &now
+	s.locationChanged(ctx, ports.AuditLocationDeleted, ports.EventLocationDeleted, &before, location)
+	return nil
+}
+
+// RestoreLocation undeletes one of a provider's locations. The provider
+// itself must not be deleted.
+func (s *AdminService) RestoreLocation(ctx context.Context, providerID, locationID uuid.UUID, actor string) (*domain.Location, error) {
+	if actor == "" {
+		return nil, domain.ErrActorRequired
+	}
+	if _, err := s.providers.GetByID(ctx, providerID); err != nil {
+		return nil, err
+	}
+	if err := s.locations.Restore(ctx, providerID, locationID); err != nil {
+		return nil, err
+	}
+	location, err := s.locations.GetByID(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.locationChanged(ctx, ports.AuditLocationRestored, ports.EventLocationRestored, nil, location)
+	return location, nil
+}
+
+// ListDeletedLocations returns a provider's locations awaiting purge, most
+// recently deleted first
+func (s *AdminService) ListDeletedLocations(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error) {
+	if _, err := s.providers.GetByID(ctx, providerID); err != nil {
+		return nil, err
+	}
+	locations, err := s.locations.GetDeletedByProviderID(ctx, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted locations: %w", err)
+	}
+	return locations, nil
+}
+
+// PurgeDeleted hard deletes providers and locations that have been deleted
+// for longer than retention, and returns how many of each were removed
+func (s *AdminService) PurgeDeleted(ctx context.Context, now time.Time, retention time.Duration) (int64, int64, error) {
+	cutoff, err := domain.PurgeCutoff(now, retention)
+	if err != nil {
+		return 0, 0, err
+	}
+	providers, err := s.providers.Purge(ctx, cutoff)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to purge providers: %w", err)
+	}
+	locations, err := s.locations.Purge(ctx, cutoff)
+	if err != nil {
+		return providers, 0, fmt.Errorf("failed to purge locations: %w", err)
+	}
+	return providers, locations, nil
+}
+
+// save stores the provider and its history entry, and records the change
+// in the service audit trail
+func (s *AdminService) save(ctx context.Context, action string, before domain.Provider, provider *domain.Provider, entry *domain.AuditEntry) error {
+	if err := s.providers.Update(ctx, provider); err != nil {
+		return fmt.Errorf("failed to update provider: %w", err)
+	}
+	if err := s.audit.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(action, before, *provider, entry.Reason))
+	return nil
+}
+
+func (s *AdminService) publish(ctx context.Context, eventType string, provider *domain.Provider, actor, reason string) {
+	go func() {
+		event := ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"provider_id": provider.ID.String(),
+				"status":      string(provider.Status),
+				"actor":       actor,
+				"reason":      reason,
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
+
+func (s *AdminService) locationChanged(ctx context.Context, action, eventType string, before, after *domain.Location) {
+	event := ports.AuditEvent{
+		Action:     action,
+		TargetType: "location",
+		TargetID:   after.ID.String(),
+		After:      *after,
+	}
+	if before != nil {
+		event.Before = *before
+	}
+	recordAudit(ctx, s.auditLog, s.logger, event)
+
+	go func() {
+		event := ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"location_id": after.ID.String(),
+				"provider_id": after.ProviderID.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
+
+func toAdminProviderResponse(p *domain.Provider) *AdminProviderResponse {
+	return &AdminProviderResponse{
+		ProviderResponse: ProviderResponse{
+			ID:          p.ID,
+			Name:        p.Name,
+			Code:        p.Code,
+			Description: p.Description,
+			LogoURL:     p.LogoURL,
+			Status:      string(p.Status),
+			MFEURL:      p.MFEURL,
+			APIBaseURL:  p.APIBaseURL,
+			Config:      p.Config,
+		},
+		StatusReason:   p.StatusReason,
+		SuspendedUntil: p.SuspendedUntil,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+		DeletedAt:      p.DeletedAt,
+	}
+}
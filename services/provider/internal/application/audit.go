@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// recordAudit writes an entry to the audit trail. The operation has already
+// been applied by the time it is recorded, so a failure is logged rather
+// than returned to the caller.
+func recordAudit(ctx context.Context, log ports.AuditLog, logger ports.Logger, event ports.AuditEvent) {
+	if err := log.Record(ctx, event); err != nil {
+		logger.WithContext(ctx).Error("failed to write audit log",
+			ports.String("action", event.Action),
+			ports.String("target_id", event.TargetID),
+			ports.Err(err),
+		)
+	}
+}
+
+// providerAuditEvent snapshots a provider before and after a change. The
+// webhook secret is excluded by the provider's JSON form.
+func providerAuditEvent(action string, before, after domain.Provider, reason string) ports.AuditEvent {
+	return ports.AuditEvent{
+		Action:     action,
+		TargetType: "provider",
+		TargetID:   after.ID.String(),
+		Reason:     reason,
+		Before:     before,
+		After:      after,
+	}
+}
+
+// credentialsAuditEvent snapshots API credentials; the secret is never
+// part of their JSON form. before is nil for newly issued credentials.
+func credentialsAuditEvent(action string, before *domain.ProviderCredentials, after domain.ProviderCredentials) ports.AuditEvent {
+	return ports.AuditEvent{
+		Action:     action,
+		TargetType: "credentials",
+		TargetID:   after.ID.String(),
+		Before:     before,
+		After:      after,
+	}
+}
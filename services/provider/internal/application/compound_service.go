@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// CompoundService fans fine lookups out to every provider that issues
+// compounds and relays payments back to the issuer
+type CompoundService struct {
+	providers   ports.ProviderRepository
+	credentials ports.CredentialsRepository
+	client      ports.CompoundClient
+	logger      ports.Logger
+}
+
+func NewCompoundService(
+	providers ports.ProviderRepository,
+	credentials ports.CredentialsRepository,
+	client ports.CompoundClient,
+	logger ports.Logger,
+) *CompoundService {
+	return &CompoundService{
+		providers:   providers,
+		credentials: credentials,
+		client:      client,
+		logger:      logger,
+	}
+}
+
+// CompoundLookupResult is every outstanding fine found for a plate. Providers
+// that could not be asked are listed so callers can tell "no fines" from
+// "no answer".
+type CompoundLookupResult struct {
+	Compounds         []*domain.Compound
+	FailedProviderIDs []uuid.UUID
+}
+
+// LookupCompounds asks every compound-issuing provider for the plate's
+// outstanding fines. Providers are queried concurrently and one failing does
+// not hide the others' results.
+func (s *CompoundService) LookupCompounds(ctx context.Context, plate string) (*CompoundLookupResult, error) {
+	plate = strings.ToUpper(strings.Join(strings.Fields(plate), ""))
+
+	providers, err := s.providers.GetAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+
+	result := &CompoundLookupResult{Compounds: []*domain.Compound{}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, provider := range providers {
+		if !provider.IssuesCompounds() {
+			continue
+		}
+		wg.Add(1)
+		go func(provider *domain.Provider) {
+			defer wg.Done()
+			compounds, err := s.lookup(ctx, provider, plate)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				s.logger.WithContext(ctx).Warn("compound lookup failed",
+					ports.String("provider_id", provider.ID.String()),
+					ports.Err(err),
+				)
+				result.FailedProviderIDs = append(result.FailedProviderIDs, provider.ID)
+				return
+			}
+			result.Compounds = append(result.Compounds, compounds...)
+		}(provider)
+	}
+	wg.Wait()
+
+	return result, nil
+}
+
+func (s *CompoundService) lookup(ctx context.Context, provider *domain.Provider, plate string) ([]*domain.Compound, error) {
+	target, err := s.target(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	records, err := s.client.Lookup(ctx, target, plate)
+	if err != nil {
+		return nil, err
+	}
+
+	compounds := make([]*domain.Compound, 0, len(records))
+	for _, record := range records {
+		issuedAt, err := time.Parse(time.RFC3339, record.IssuedAt)
+		if record.Reference == "" || err != nil || !record.Amount.IsPositive() {
+			s.logger.WithContext(ctx).Warn("skipping malformed compound",
+				ports.String("provider_id", provider.ID.String()),
+				ports.String("reference", record.Reference),
+			)
+			continue
+		}
+
+		compound := &domain.Compound{
+			ProviderID:   provider.ID,
+			Reference:    record.Reference,
+			VehiclePlate: plate,
+			Offence:      record.Offence,
+			Location:     record.Location,
+			IssuedAt:     issuedAt.UTC(),
+			Amount:       record.Amount,
+			Currency:     record.Currency,
+		}
+		if dueAt, err := time.Parse(time.RFC3339, record.DueAt); err == nil {
+			dueAt = dueAt.UTC()
+			compound.DueAt = &dueAt
+		}
+		if compound.Currency == "" {
+			compound.Currency = "MYR"
+		}
+		compounds = append(compounds, compound)
+	}
+	return compounds, nil
+}
+
+// SettleCompound reports a paid fine to the provider that issued it
+func (s *CompoundService) SettleCompound(ctx context.Context, providerID uuid.UUID, reference string, amount decimal.Decimal, paymentReference string) (*domain.CompoundSettlement, error) {
+	provider, err := s.providers.GetByID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if !provider.IssuesCompounds() {
+		return nil, domain.ErrCompoundsNotSupported
+	}
+
+	target, err := s.target(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	settlement, err := s.client.Settle(ctx, target, ports.CompoundSettleRequest{
+		Reference:        reference,
+		Amount:           amount,
+		PaymentReference: paymentReference,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("compound settled with provider",
+		ports.String("provider_id", providerID.String()),
+		ports.String("reference", reference),
+	)
+	return settlement, nil
+}
+
+// target resolves the provider's production API and credentials
+func (s *CompoundService) target(ctx context.Context, provider *domain.Provider) (ports.ProviderTarget, error) {
+	creds, err := s.credentials.GetByProviderID(ctx, provider.ID, domain.EnvironmentProduction)
+	if err != nil {
+		return ports.ProviderTarget{}, fmt.Errorf("failed to get production credentials: %w", err)
+	}
+	return ports.ProviderTarget{
+		BaseURL:   provider.APIBaseURL,
+		APIKey:    creds.APIKey,
+		APISecret: creds.APISecret,
+	}, nil
+}
@@ -0,0 +1,291 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// ConformanceService runs the provider API conformance kit against a
+// provider's sandbox and keeps the scored reports that gate activation
+type ConformanceService struct {
+	providers   ports.ProviderRepository
+	credentials ports.CredentialsRepository
+	reports     ports.ConformanceRepository
+	sandbox     ports.SandboxClient
+	events      ports.EventPublisher
+	logger      ports.Logger
+}
+
+func NewConformanceService(
+	providers ports.ProviderRepository,
+	credentials ports.CredentialsRepository,
+	reports ports.ConformanceRepository,
+	sandbox ports.SandboxClient,
+	events ports.EventPublisher,
+	logger ports.Logger,
+) *ConformanceService {
+	return &ConformanceService{
+		providers:   providers,
+		credentials: credentials,
+		reports:     reports,
+		sandbox:     sandbox,
+		events:      events,
+		logger:      logger,
+	}
+}
+
+// RunConformance exercises the provider's sandbox with a start/status/end
+// session round trip and a signed webhook echo, then stores the scored report
+func (s *ConformanceService) RunConformance(ctx context.Context, providerID uuid.UUID, runBy string) (*domain.ConformanceReport, error) {
+	provider, err := s.providers.GetByID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.APIBaseURL == "" {
+		return nil, domain.ErrAPIBaseURLRequired
+	}
+
+	creds, err := s.credentials.GetByProviderID(ctx, providerID, domain.EnvironmentSandbox)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) || errors.Is(err, domain.ErrCredentialsNotFound) {
+			return nil, domain.ErrSandboxCredentialsRequired
+		}
+		return nil, fmt.Errorf("failed to get sandbox credentials: %w", err)
+	}
+	if !creds.IsValid() {
+		return nil, domain.ErrSandboxCredentialsRequired
+	}
+
+	target := ports.SandboxTarget{
+		BaseURL:   provider.APIBaseURL,
+		APIKey:    creds.APIKey,
+		APISecret: creds.APISecret,
+	}
+	report := domain.NewConformanceReport(provider.ID, provider.APIBaseURL, runBy)
+
+	s.runSessionChecks(ctx, report, target)
+	s.runWebhookChecks(ctx, report, target)
+	report.Complete()
+
+	if err := s.reports.Create(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save conformance report: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("conformance check completed",
+		ports.String("provider_id", provider.ID.String()),
+		ports.Any("score", report.Score),
+		ports.Any("passed", report.Passed),
+	)
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventConformanceCompleted,
+			Payload: map[string]interface{}{
+				"provider_id": provider.ID.String(),
+				"report_id":   report.ID.String(),
+				"score":       report.Score,
+				"passed":      report.Passed,
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return report, nil
+}
+
+// GetLatestReport returns the most recent conformance report for a provider
+func (s *ConformanceService) GetLatestReport(ctx context.Context, providerID uuid.UUID) (*domain.ConformanceReport, error) {
+	if _, err := s.providers.GetByID(ctx, providerID); err != nil {
+		return nil, err
+	}
+	return s.reports.GetLatestByProviderID(ctx, providerID)
+}
+
+// ListReports returns a provider's conformance reports, newest first
+func (s *ConformanceService) ListReports(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.ConformanceReport, error) {
+	if _, err := s.providers.GetByID(ctx, providerID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	reports, err := s.reports.ListByProviderID(ctx, providerID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conformance reports: %w", err)
+	}
+	return reports, nil
+}
+
+func (s *ConformanceService) runSessionChecks(ctx context.Context, report *domain.ConformanceReport, target ports.SandboxTarget) {
+	var started *ports.SandboxSession
+	report.Record(timed(domain.CheckStartSession, func() error {
+		session, err := s.sandbox.StartSession(ctx, target, ports.SandboxSessionRequest{
+			VehiclePlate: "CONF1234",
+			VehicleType:  "car",
+			UserRef:      "conformance-" + report.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+		if err := checkStartedSession(session); err != nil {
+			return err
+		}
+		started = session
+		return nil
+	}))
+
+	if started == nil {
+		report.Skip(domain.CheckSessionStatus, "no sandbox session was started")
+		report.Skip(domain.CheckEndSession, "no sandbox session was started")
+		return
+	}
+
+	report.Record(timed(domain.CheckSessionStatus, func() error {
+		session, err := s.sandbox.GetSession(ctx, target, started.ExternalSessionID)
+		if err != nil {
+			return err
+		}
+		return checkSessionStatus(session)
+	}))
+
+	report.Record(timed(domain.CheckEndSession, func() error {
+		session, err := s.sandbox.EndSession(ctx, target, started.ExternalSessionID)
+		if err != nil {
+			return err
+		}
+		return checkEndedSession(started, session)
+	}))
+}
+
+func (s *ConformanceService) runWebhookChecks(ctx context.Context, report *domain.ConformanceReport, target ports.SandboxTarget) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"event":     "conformance.ping",
+		"report_id": report.ID.String(),
+		"sent_at":   time.Now().UTC().Format(time.RFC3339),
+	})
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := domain.SignWebhook(target.APISecret, timestamp, body)
+
+	report.Record(timed(domain.CheckWebhookSignature, func() error {
+		status, echoed, err := s.sandbox.EchoWebhook(ctx, target, ports.SignedWebhook{
+			Body:      body,
+			Timestamp: timestamp,
+			Signature: signature,
+		})
+		if err != nil {
+			return err
+		}
+		if status < 200 || status >= 300 {
+			return fmt.Errorf("signed webhook was answered with status %d", status)
+		}
+		if echoed != signature {
+			return fmt.Errorf("echoed signature %q does not match the one sent", echoed)
+		}
+		return nil
+	}))
+
+	report.Record(timed(domain.CheckWebhookRejectsInvalid, func() error {
+		status, _, err := s.sandbox.EchoWebhook(ctx, target, ports.SignedWebhook{
+			Body:      body,
+			Timestamp: timestamp,
+			Signature: domain.SignWebhook("invalid-"+target.APISecret, timestamp, body),
+		})
+		if err != nil {
+			return err
+		}
+		if status != http.StatusUnauthorized && status != http.StatusForbidden {
+			return fmt.Errorf("webhook with an invalid signature was answered with status %d, want 401 or 403", status)
+		}
+		return nil
+	}))
+}
+
+// requireConformance fails unless the provider's latest conformance report
+// passed against its current API base URL
+func requireConformance(ctx context.Context, reports ports.ConformanceRepository, provider *domain.Provider) error {
+	report, err := reports.GetLatestByProviderID(ctx, provider.ID)
+	if errors.Is(err, domain.ErrConformanceReportNotFound) {
+		return domain.ErrConformanceNotPassed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get conformance report: %w", err)
+	}
+	if !report.Qualifies(provider) {
+		return domain.ErrConformanceNotPassed
+	}
+	return nil
+}
+
+// timed runs fn and returns the check, elapsed time and error in the order Record takes them
+func timed(check domain.ConformanceCheck, fn func() error) (domain.ConformanceCheck, time.Duration, error) {
+	start := time.Now()
+	err := fn()
+	return check, time.Since(start), err
+}
+
+func checkStartedSession(session *ports.SandboxSession) error {
+	if session.ExternalSessionID == "" {
+		return errors.New("external_session_id is missing")
+	}
+	if session.Status != "active" {
+		return fmt.Errorf("status is %q, want \"active\"", session.Status)
+	}
+	if _, err := time.Parse(time.RFC3339, session.EntryTime); err != nil {
+		return fmt.Errorf("entry_time %q is not RFC 3339", session.EntryTime)
+	}
+	return nil
+}
+
+func checkSessionStatus(session *ports.SandboxSession) error {
+	if session.Status != "active" {
+		return fmt.Errorf("status is %q, want \"active\"", session.Status)
+	}
+	if session.DurationMinutes < 0 {
+		return fmt.Errorf("duration_minutes is negative: %d", session.DurationMinutes)
+	}
+	return checkAmount(session)
+}
+
+func checkEndedSession(started, ended *ports.SandboxSession) error {
+	exit, err := time.Parse(time.RFC3339, ended.ExitTime)
+	if err != nil {
+		return fmt.Errorf("exit_time %q is not RFC 3339", ended.ExitTime)
+	}
+	entry, _ := time.Parse(time.RFC3339, started.EntryTime)
+	if exit.Before(entry) {
+		return errors.New("exit_time is before entry_time")
+	}
+	if ended.DurationMinutes < 0 {
+		return fmt.Errorf("duration_minutes is negative: %d", ended.DurationMinutes)
+	}
+	return checkAmount(ended)
+}
+
+func checkAmount(session *ports.SandboxSession) error {
+	amount, err := decimal.NewFromString(session.Amount)
+	if err != nil {
+		return fmt.Errorf("amount %q is not a decimal", session.Amount)
+	}
+	if amount.IsNegative() {
+		return fmt.Errorf("amount is negative: %s", session.Amount)
+	}
+	if session.Currency == "" {
+		return errors.New("currency is missing")
+	}
+	return nil
+}
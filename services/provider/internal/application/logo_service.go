@@ -0,0 +1,135 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// LogoService takes providers' logo uploads. Each logo is stored in
+// several sizes, and the provider's logo URL points at the CDN copy.
+type LogoService struct {
+	providers ports.ProviderRepository
+	store     ports.LogoStore
+	events    ports.EventPublisher
+	auditLog  ports.AuditLog
+	logger    ports.Logger
+	config    LogoConfig
+}
+
+// LogoConfig sets where stored logos are served from
+type LogoConfig struct {
+	// CDNBaseURL is prefixed to a logo's storage key to give its public URL
+	CDNBaseURL string
+}
+
+// NewLogoService creates the service. A nil store disables uploads.
+func NewLogoService(
+	providers ports.ProviderRepository,
+	store ports.LogoStore,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+	config LogoConfig,
+) *LogoService {
+	config.CDNBaseURL = strings.TrimRight(config.CDNBaseURL, "/")
+	return &LogoService{
+		providers: providers,
+		store:     store,
+		events:    events,
+		auditLog:  auditLog,
+		logger:    logger,
+		config:    config,
+	}
+}
+
+// LogoResponse is the provider's new logo and the URL of each size, keyed
+// by the size of the box it fits
+type LogoResponse struct {
+	LogoURL  string            `json:"logo_url"`
+	Variants map[string]string `json:"variants"`
+}
+
+// UploadLogo validates an image, stores its variants and makes it the
+// logo of the staff member's provider
+func (s *LogoService) UploadLogo(ctx context.Context, staff *domain.Staff, contentType string, body io.Reader) (*LogoResponse, error) {
+	if err := staff.CanManage(); err != nil {
+		return nil, err
+	}
+	if s.store == nil {
+		return nil, domain.ErrLogosUnavailable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, domain.MaxLogoBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logo: %w", err)
+	}
+	if len(data) > domain.MaxLogoBytes {
+		return nil, domain.ErrLogoTooLarge
+	}
+
+	// Check the dimensions before decoding the pixels, so an oversized
+	// image is never held in memory
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedLogoFormat
+	}
+	if err := domain.ValidateLogo(contentType, format, cfg.Width, cfg.Height); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedLogoFormat
+	}
+
+	provider, err := s.providers.GetByID(ctx, staff.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	before := *provider
+
+	version := domain.LogoVersion(data)
+	resp := &LogoResponse{Variants: make(map[string]string, len(domain.LogoVariantSizes))}
+	for _, size := range domain.LogoVariantSizes {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, domain.ResizeLogo(img, size)); err != nil {
+			return nil, fmt.Errorf("failed to encode logo: %w", err)
+		}
+		key := domain.LogoKey(provider.ID, version, size)
+		if err := s.store.Put(ctx, key, &buf, int64(buf.Len()), "image/png"); err != nil {
+			return nil, fmt.Errorf("failed to store logo: %w", err)
+		}
+		resp.Variants[strconv.Itoa(size)] = s.config.CDNBaseURL + "/" + key
+	}
+	resp.LogoURL = resp.Variants[strconv.Itoa(domain.LogoDefaultSize)]
+
+	if err := provider.SetLogo(resp.LogoURL); err != nil {
+		return nil, err
+	}
+	if err := s.providers.Update(ctx, provider); err != nil {
+		return nil, fmt.Errorf("failed to update provider: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditLogoUpdated, before, *provider, ""))
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventLogoUpdated,
+			Payload: map[string]interface{}{
+				"provider_id": provider.ID.String(),
+				"logo_url":    provider.LogoURL,
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return resp, nil
+}
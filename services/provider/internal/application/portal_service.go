@@ -0,0 +1,317 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+const (
+	defaultPortalPageSize = 50
+	maxPortalPageSize     = 200
+)
+
+// PortalConfig controls key rotation and error log retention for the developer portal
+type PortalConfig struct {
+	RotationGracePeriod time.Duration
+	APIErrorRetention   time.Duration
+}
+
+// PortalService handles self-service use cases for approved provider developers.
+// Callers are identified by their own API key and secret rather than a user session.
+type PortalService struct {
+	providers   ports.ProviderRepository
+	credentials ports.CredentialsRepository
+	deliveries  ports.WebhookDeliveryRepository
+	apiErrors   ports.APIErrorRepository
+	events      ports.EventPublisher
+	auditLog    ports.AuditLog
+	logger      ports.Logger
+	cfg         PortalConfig
+}
+
+func NewPortalService(
+	providers ports.ProviderRepository,
+	credentials ports.CredentialsRepository,
+	deliveries ports.WebhookDeliveryRepository,
+	apiErrors ports.APIErrorRepository,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+	cfg PortalConfig,
+) *PortalService {
+	return &PortalService{
+		providers:   providers,
+		credentials: credentials,
+		deliveries:  deliveries,
+		apiErrors:   apiErrors,
+		events:      events,
+		auditLog:    auditLog,
+		logger:      logger,
+		cfg:         cfg,
+	}
+}
+
+// Request/Response DTOs
+
+type PortalCredentialsResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	APIKey      string     `json:"api_key"`
+	Environment string     `json:"environment"`
+	IsActive    bool       `json:"is_active"`
+	IsCurrent   bool       `json:"is_current"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+type IssuedCredentialsResponse struct {
+	ID          uuid.UUID `json:"id"`
+	APIKey      string    `json:"api_key"`
+	APISecret   string    `json:"api_secret"`
+	Environment string    `json:"environment"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type RotateCredentialsResponse struct {
+	Credentials       IssuedCredentialsResponse `json:"credentials"`
+	PreviousID        uuid.UUID                 `json:"previous_id"`
+	PreviousExpiresAt *time.Time                `json:"previous_expires_at,omitempty"`
+}
+
+// Authenticate resolves an API key and secret to the caller's credentials.
+// Only active (approved and not suspended) providers may use the portal.
+func (s *PortalService) Authenticate(ctx context.Context, apiKey, apiSecret string) (*domain.ProviderCredentials, error) {
+	if apiKey == "" || apiSecret == "" {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	creds, err := s.credentials.GetByAPIKey(ctx, apiKey)
+	if err != nil {
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to look up credentials: %w", err)
+	}
+	if !creds.VerifySecret(apiSecret) || !creds.IsValid() {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	provider, err := s.providers.GetByID(ctx, creds.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if !provider.IsActive() {
+		return nil, domain.ErrProviderInactive
+	}
+
+	return creds, nil
+}
+
+// ListCredentials returns the caller's credentials without secrets
+func (s *PortalService) ListCredentials(ctx context.Context, caller *domain.ProviderCredentials) ([]*PortalCredentialsResponse, error) {
+	list, err := s.credentials.ListByProviderID(ctx, caller.ProviderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	responses := make([]*PortalCredentialsResponse, 0, len(list))
+	for _, c := range list {
+		if !caller.CanManage(c) {
+			continue
+		}
+		responses = append(responses, &PortalCredentialsResponse{
+			ID:          c.ID,
+			APIKey:      c.APIKey,
+			Environment: string(c.Environment),
+			IsActive:    c.IsValid(),
+			IsCurrent:   c.ID == caller.ID,
+			CreatedAt:   c.CreatedAt,
+			ExpiresAt:   c.ExpiresAt,
+		})
+	}
+	return responses, nil
+}
+
+// CreateSandboxCredentials issues a new sandbox key pair for the caller's provider
+func (s *PortalService) CreateSandboxCredentials(ctx context.Context, caller *domain.ProviderCredentials) (*IssuedCredentialsResponse, error) {
+	list, err := s.credentials.ListByProviderID(ctx, caller.ProviderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	active := 0
+	for _, c := range list {
+		if c.Environment == domain.EnvironmentSandbox && c.IsValid() {
+			active++
+		}
+	}
+	if active >= domain.MaxActiveSandboxCredentials {
+		return nil, domain.ErrSandboxCredentialLimit
+	}
+
+	creds, err := domain.NewProviderCredentials(caller.ProviderID, domain.EnvironmentSandbox)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate credentials: %w", err)
+	}
+	if err := s.credentials.Create(ctx, creds); err != nil {
+		return nil, fmt.Errorf("failed to store credentials: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, credentialsAuditEvent(ports.AuditCredentialsIssued, nil, *creds))
+
+	s.publish(ctx, ports.EventCredentialsCreated, creds, caller)
+	return toIssuedCredentialsResponse(creds), nil
+}
+
+// RotateCredentials replaces one of the caller's keys. The old key keeps
+// working for the configured grace period.
+func (s *PortalService) RotateCredentials(ctx context.Context, caller *domain.ProviderCredentials, id uuid.UUID) (*RotateCredentialsResponse, error) {
+	current, err := s.managedCredentials(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *current
+	replacement, err := current.Rotate(s.cfg.RotationGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.credentials.Create(ctx, replacement); err != nil {
+		return nil, fmt.Errorf("failed to store credentials: %w", err)
+	}
+	if err := s.credentials.Update(ctx, current); err != nil {
+		return nil, fmt.Errorf("failed to expire rotated credentials: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, credentialsAuditEvent(ports.AuditCredentialsRotated, &before, *current))
+	recordAudit(ctx, s.auditLog, s.logger, credentialsAuditEvent(ports.AuditCredentialsIssued, nil, *replacement))
+
+	s.publish(ctx, ports.EventCredentialsRotated, replacement, caller)
+	return &RotateCredentialsResponse{
+		Credentials:       *toIssuedCredentialsResponse(replacement),
+		PreviousID:        current.ID,
+		PreviousExpiresAt: current.ExpiresAt,
+	}, nil
+}
+
+// RevokeCredentials immediately disables one of the caller's keys
+func (s *PortalService) RevokeCredentials(ctx context.Context, caller *domain.ProviderCredentials, id uuid.UUID) error {
+	creds, err := s.managedCredentials(ctx, caller, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.credentials.Revoke(ctx, creds.ID); err != nil {
+		return fmt.Errorf("failed to revoke credentials: %w", err)
+	}
+	revoked := *creds
+	revoked.IsActive = false
+	recordAudit(ctx, s.auditLog, s.logger, credentialsAuditEvent(ports.AuditCredentialsRevoked, creds, revoked))
+
+	s.publish(ctx, ports.EventCredentialsRevoked, creds, caller)
+	return nil
+}
+
+// ListWebhookDeliveries returns recent webhook delivery attempts, newest first
+func (s *PortalService) ListWebhookDeliveries(ctx context.Context, caller *domain.ProviderCredentials, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	limit, offset = pageBounds(limit, offset)
+
+	deliveries, err := s.deliveries.GetByProviderID(ctx, caller.ProviderID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	if deliveries == nil {
+		deliveries = []*domain.WebhookDelivery{}
+	}
+	return deliveries, nil
+}
+
+// ListAPIErrors returns the caller's failed API requests within the retention window
+func (s *PortalService) ListAPIErrors(ctx context.Context, caller *domain.ProviderCredentials, limit int) ([]*domain.APIErrorLog, error) {
+	limit, _ = pageBounds(limit, 0)
+	since := time.Now().UTC().Add(-s.cfg.APIErrorRetention)
+
+	entries, err := s.apiErrors.GetByProviderID(ctx, caller.ProviderID, since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API errors: %w", err)
+	}
+	if entries == nil {
+		entries = []*domain.APIErrorLog{}
+	}
+	return entries, nil
+}
+
+// RecordAPIError stores a failed request for the provider to inspect later.
+// Failures are logged rather than returned so they never affect the response.
+func (s *PortalService) RecordAPIError(ctx context.Context, entry *domain.APIErrorLog) {
+	if err := s.apiErrors.Create(ctx, entry); err != nil {
+		s.logger.WithContext(ctx).Warn("failed to record API error",
+			ports.String("provider_id", entry.ProviderID.String()),
+			ports.Err(err),
+		)
+	}
+}
+
+// PurgeExpiredAPIErrors removes error log entries older than the retention window
+func (s *PortalService) PurgeExpiredAPIErrors(ctx context.Context, now time.Time) (int64, error) {
+	removed, err := s.apiErrors.DeleteBefore(ctx, now.Add(-s.cfg.APIErrorRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge API errors: %w", err)
+	}
+	return removed, nil
+}
+
+// managedCredentials loads credentials the caller is allowed to manage.
+// Anything else is reported as not found so key IDs of other providers do not leak.
+func (s *PortalService) managedCredentials(ctx context.Context, caller *domain.ProviderCredentials, id uuid.UUID) (*domain.ProviderCredentials, error) {
+	creds, err := s.credentials.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.CanManage(creds) {
+		return nil, domain.ErrCredentialsNotFound
+	}
+	return creds, nil
+}
+
+func (s *PortalService) publish(ctx context.Context, eventType string, creds, caller *domain.ProviderCredentials) {
+	go func() {
+		event := ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"provider_id":    creds.ProviderID.String(),
+				"credentials_id": creds.ID.String(),
+				"environment":    string(creds.Environment),
+				"actor":          caller.ID.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
+
+func pageBounds(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = defaultPortalPageSize
+	}
+	if limit > maxPortalPageSize {
+		limit = maxPortalPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
+
+func toIssuedCredentialsResponse(c *domain.ProviderCredentials) *IssuedCredentialsResponse {
+	return &IssuedCredentialsResponse{
+		ID:          c.ID,
+		APIKey:      c.APIKey,
+		APISecret:   c.APISecret,
+		Environment: string(c.Environment),
+		CreatedAt:   c.CreatedAt,
+	}
+}
@@ -0,0 +1,362 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// effectiveFromTolerance allows for clock skew between the caller and this service
+// when a bulk update is submitted to take effect "now".
+const effectiveFromTolerance = time.Minute
+
+// PricingService handles location pricing maintenance and lookups
+type PricingService struct {
+	providers ports.ProviderRepository
+	locations ports.LocationRepository
+	pricing   ports.PricingRepository
+	events    ports.EventPublisher
+	logger    ports.Logger
+}
+
+func NewPricingService(
+	providers ports.ProviderRepository,
+	locations ports.LocationRepository,
+	pricing ports.PricingRepository,
+	events ports.EventPublisher,
+	logger ports.Logger,
+) *PricingService {
+	return &PricingService{
+		providers: providers,
+		locations: locations,
+		pricing:   pricing,
+		events:    events,
+		logger:    logger,
+	}
+}
+
+// Request/Response DTOs
+
+type LocationPricingUpdate struct {
+	LocationID uuid.UUID `json:"location_id"`
+	HourlyRate float64   `json:"hourly_rate"`
+	DailyMax   float64   `json:"daily_max"`
+}
+
+type BulkPricingUpdateRequest struct {
+	ProviderID    uuid.UUID               `json:"-"`
+	EffectiveFrom *time.Time              `json:"effective_from,omitempty"`
+	CreatedBy     string                  `json:"-"`
+	Locations     []LocationPricingUpdate `json:"locations"`
+}
+
+type BulkPricingUpdateResponse struct {
+	ProviderID    uuid.UUID                 `json:"provider_id"`
+	EffectiveFrom time.Time                 `json:"effective_from"`
+	Applied       bool                      `json:"applied"`
+	Versions      []*PricingVersionResponse `json:"versions"`
+}
+
+type PricingVersionResponse struct {
+	ID             uuid.UUID `json:"id,omitempty"`
+	LocationID     uuid.UUID `json:"location_id"`
+	HourlyRate     float64   `json:"hourly_rate"`
+	DailyMax       float64   `json:"daily_max"`
+	Currency       string    `json:"currency"`
+	GracePeriodMin int       `json:"grace_period_min"`
+	EffectiveFrom  time.Time `json:"effective_from"`
+	CreatedAt      time.Time `json:"created_at"`
+	// PricingSchedule refines the version's hourly rate
+	PricingSchedule *pricing.Schedule `json:"pricing_schedule,omitempty"`
+}
+
+// BulkUpdatePricing schedules new pricing for several of a provider's locations at once.
+// Earlier versions are kept so sessions already in progress keep billing at the rate
+// that was active when they entered.
+func (s *PricingService) BulkUpdatePricing(ctx context.Context, req BulkPricingUpdateRequest) (*BulkPricingUpdateResponse, error) {
+	if len(req.Locations) == 0 {
+		return nil, domain.ErrEmptyPricingUpdate
+	}
+
+	provider, err := s.providers.GetByID(ctx, req.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if !provider.IsActive() {
+		return nil, domain.ErrProviderInactive
+	}
+
+	now := time.Now().UTC()
+	effectiveFrom := now
+	if req.EffectiveFrom != nil {
+		if req.EffectiveFrom.Before(now.Add(-effectiveFromTolerance)) {
+			return nil, domain.ErrInvalidEffectiveFrom
+		}
+		if req.EffectiveFrom.After(now) {
+			effectiveFrom = req.EffectiveFrom.UTC()
+		}
+	}
+	applyNow := !effectiveFrom.After(now)
+
+	seen := make(map[uuid.UUID]bool, len(req.Locations))
+	locations := make([]*domain.Location, 0, len(req.Locations))
+	var versions, updates []*domain.PricingVersion
+
+	for _, update := range req.Locations {
+		if seen[update.LocationID] {
+			return nil, domain.ErrDuplicatePricingEntry
+		}
+		seen[update.LocationID] = true
+
+		location, err := s.locations.GetByID(ctx, update.LocationID)
+		if err != nil {
+			return nil, err
+		}
+		if location.ProviderID != provider.ID {
+			return nil, domain.ErrLocationNotOwned
+		}
+
+		baseline, err := s.baselineVersion(ctx, location)
+		if err != nil {
+			return nil, err
+		}
+		if baseline != nil {
+			versions = append(versions, baseline)
+		}
+
+		version, err := domain.NewPricingVersion(location, update.HourlyRate, update.DailyMax, effectiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		version.CreatedBy = req.CreatedBy
+
+		versions = append(versions, version)
+		updates = append(updates, version)
+		locations = append(locations, location)
+	}
+
+	if err := s.pricing.CreateBatch(ctx, versions); err != nil {
+		return nil, fmt.Errorf("failed to save pricing versions: %w", err)
+	}
+
+	if applyNow {
+		for i, version := range updates {
+			if err := s.apply(ctx, locations[i], version, now); err != nil {
+				// The version is saved, so the scheduled job will retry applying it
+				s.logger.WithContext(ctx).Warn("failed to apply pricing to location",
+					ports.String("location_id", version.LocationID.String()),
+					ports.Err(err),
+				)
+			}
+		}
+	}
+
+	locationIDs := make([]string, len(updates))
+	responses := make([]*PricingVersionResponse, len(updates))
+	for i, version := range updates {
+		locationIDs[i] = version.LocationID.String()
+		responses[i] = toPricingVersionResponse(version)
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventPricingScheduled,
+			Payload: map[string]interface{}{
+				"provider_id":    provider.ID.String(),
+				"location_ids":   locationIDs,
+				"effective_from": effectiveFrom.Format(time.RFC3339),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return &BulkPricingUpdateResponse{
+		ProviderID:    provider.ID,
+		EffectiveFrom: effectiveFrom,
+		Applied:       applyNow,
+		Versions:      responses,
+	}, nil
+}
+
+// GetLocationPricing returns the pricing, schedule included, that was in effect at a
+// location at the given time. Locations with no recorded history fall back to their
+// current pricing.
+func (s *PricingService) GetLocationPricing(ctx context.Context, locationID uuid.UUID, at time.Time) (*PricingVersionResponse, error) {
+	location, err := s.locations.GetByID(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := s.pricing.GetEffective(ctx, locationID, at)
+	if err == nil {
+		return toPricingVersionResponse(version), nil
+	}
+	if !errors.Is(err, domain.ErrPricingNotFound) {
+		return nil, fmt.Errorf("failed to get effective pricing: %w", err)
+	}
+
+	return &PricingVersionResponse{
+		LocationID:      location.ID,
+		HourlyRate:      location.Pricing.HourlyRate,
+		DailyMax:        location.Pricing.DailyMax,
+		Currency:        location.Pricing.Currency,
+		GracePeriodMin:  location.Pricing.GracePeriodMin,
+		EffectiveFrom:   location.CreatedAt,
+		CreatedAt:       location.CreatedAt,
+		PricingSchedule: location.PricingSchedule,
+	}, nil
+}
+
+// GetPricingHistory returns all pricing versions for a provider's location, newest first
+func (s *PricingService) GetPricingHistory(ctx context.Context, providerID, locationID uuid.UUID) ([]*PricingVersionResponse, error) {
+	location, err := s.locations.GetByID(ctx, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if location.ProviderID != providerID {
+		return nil, domain.ErrLocationNotOwned
+	}
+
+	versions, err := s.pricing.GetByLocationID(ctx, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing history: %w", err)
+	}
+
+	responses := make([]*PricingVersionResponse, len(versions))
+	for i, v := range versions {
+		responses[i] = toPricingVersionResponse(v)
+	}
+	return responses, nil
+}
+
+// RecordSchedule versions a location's new pricing schedule from now, so sessions
+// already in progress keep billing by the schedule in place when they entered.
+// before is the location as it was; after has the new schedule.
+func (s *PricingService) RecordSchedule(ctx context.Context, before, after *domain.Location, createdBy string) error {
+	baseline, err := s.baselineVersion(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	version, err := domain.NewPricingVersion(after, after.Pricing.HourlyRate, after.Pricing.DailyMax, now)
+	if err != nil {
+		return err
+	}
+	version.CreatedBy = createdBy
+	version.MarkApplied(now)
+
+	versions := []*domain.PricingVersion{version}
+	if baseline != nil {
+		versions = []*domain.PricingVersion{baseline, version}
+	}
+	if err := s.pricing.CreateBatch(ctx, versions); err != nil {
+		return fmt.Errorf("failed to save pricing versions: %w", err)
+	}
+	if err := s.pricing.SetScheduleAfter(ctx, after.ID, now, after.PricingSchedule); err != nil {
+		return fmt.Errorf("failed to update scheduled pricing: %w", err)
+	}
+	return nil
+}
+
+// ApplyDuePricing copies scheduled pricing that has taken effect onto its location,
+// so location listings show the current rate.
+func (s *PricingService) ApplyDuePricing(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	versions, err := s.pricing.GetUnapplied(ctx, now, 100)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due pricing versions: %w", err)
+	}
+
+	applied := 0
+	for _, version := range versions {
+		location, err := s.locations.GetByID(ctx, version.LocationID)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("failed to load location for pricing",
+				ports.String("location_id", version.LocationID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+
+		if err := s.apply(ctx, location, version, now); err != nil {
+			s.logger.WithContext(ctx).Error("failed to apply pricing to location",
+				ports.String("location_id", version.LocationID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		applied++
+	}
+
+	return applied, nil
+}
+
+// baselineVersion records the location's current pricing as its first version,
+// so sessions that entered before the first bulk update keep the original rate.
+func (s *PricingService) baselineVersion(ctx context.Context, location *domain.Location) (*domain.PricingVersion, error) {
+	history, err := s.pricing.GetByLocationID(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pricing history: %w", err)
+	}
+	if len(history) > 0 {
+		return nil, nil
+	}
+
+	baseline, err := domain.NewPricingVersion(location, location.Pricing.HourlyRate, location.Pricing.DailyMax, location.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	baseline.CreatedBy = domain.SystemActor
+	baseline.MarkApplied(baseline.CreatedAt)
+	return baseline, nil
+}
+
+func (s *PricingService) apply(ctx context.Context, location *domain.Location, version *domain.PricingVersion, now time.Time) error {
+	location.SetPricing(version.HourlyRate, version.DailyMax)
+	if err := s.locations.Update(ctx, location); err != nil {
+		return fmt.Errorf("failed to update location pricing: %w", err)
+	}
+
+	version.MarkApplied(now)
+	if err := s.pricing.MarkApplied(ctx, version.ID, now); err != nil {
+		return fmt.Errorf("failed to mark pricing applied: %w", err)
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventPricingApplied,
+			Payload: map[string]interface{}{
+				"location_id":    location.ID.String(),
+				"provider_id":    location.ProviderID.String(),
+				"hourly_rate":    version.HourlyRate,
+				"daily_max":      version.DailyMax,
+				"effective_from": version.EffectiveFrom.Format(time.RFC3339),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return nil
+}
+
+func toPricingVersionResponse(v *domain.PricingVersion) *PricingVersionResponse {
+	return &PricingVersionResponse{
+		ID:             v.ID,
+		LocationID:     v.LocationID,
+		HourlyRate:     v.HourlyRate,
+		DailyMax:       v.DailyMax,
+		Currency:       v.Currency,
+		GracePeriodMin: v.GracePeriodMin,
+		EffectiveFrom:  v.EffectiveFrom,
+		CreatedAt:      v.CreatedAt,
+
+		PricingSchedule: v.PricingSchedule,
+	}
+}
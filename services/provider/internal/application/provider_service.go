@@ -3,8 +3,10 @@ package application
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
 	"github.com/parking-super-app/services/provider/internal/domain"
 	"github.com/parking-super-app/services/provider/internal/ports"
 )
@@ -14,7 +16,9 @@ type ProviderService struct {
 	providers   ports.ProviderRepository
 	credentials ports.CredentialsRepository
 	locations   ports.LocationRepository
+	conformance ports.ConformanceRepository
 	events      ports.EventPublisher
+	auditLog    ports.AuditLog
 	logger      ports.Logger
 }
 
@@ -22,14 +26,18 @@ func NewProviderService(
 	providers ports.ProviderRepository,
 	credentials ports.CredentialsRepository,
 	locations ports.LocationRepository,
+	conformance ports.ConformanceRepository,
 	events ports.EventPublisher,
+	auditLog ports.AuditLog,
 	logger ports.Logger,
 ) *ProviderService {
 	return &ProviderService{
 		providers:   providers,
 		credentials: credentials,
 		locations:   locations,
+		conformance: conformance,
 		events:      events,
+		auditLog:    auditLog,
 		logger:      logger,
 	}
 }
@@ -57,6 +65,26 @@ type ProviderResponse struct {
 	Config      domain.ProviderConfig `json:"config"`
 }
 
+// PublishMFERequest points a provider at a new micro-frontend release
+type PublishMFERequest struct {
+	MFEURL       string   `json:"mfe_url"`
+	Version      string   `json:"version"`
+	Capabilities []string `json:"capabilities"`
+	HealthURL    string   `json:"health_url"`
+}
+
+// MFEManifestEntry is one provider module the shell app can load
+type MFEManifestEntry struct {
+	ProviderID   uuid.UUID `json:"provider_id"`
+	Code         string    `json:"code"`
+	Name         string    `json:"name"`
+	LogoURL      string    `json:"logo_url,omitempty"`
+	MFEURL       string    `json:"mfe_url"`
+	Version      string    `json:"version"`
+	Capabilities []string  `json:"capabilities"`
+	HealthURL    string    `json:"health_url"`
+}
+
 type CredentialsResponse struct {
 	APIKey      string `json:"api_key"`
 	APISecret   string `json:"api_secret"`
@@ -85,12 +113,28 @@ type LocationResponse struct {
 	Latitude    float64                `json:"latitude"`
 	Longitude   float64                `json:"longitude"`
 	TotalSpaces int                    `json:"total_spaces"`
+	IsActive    bool                   `json:"is_active"`
 	Pricing     domain.LocationPricing `json:"pricing"`
+	Amenities   []string               `json:"amenities"`
+	// HeightLimitCm is omitted for a location without one
+	HeightLimitCm *int `json:"height_limit_cm,omitempty"`
+	// PricingSchedule and OperatingHours are omitted for a location billed
+	// at its flat rate and open around the clock
+	PricingSchedule *pricing.Schedule `json:"pricing_schedule,omitempty"`
+	OperatingHours  *pricing.Hours    `json:"operating_hours,omitempty"`
+	OpenNow         bool              `json:"open_now"`
+}
+
+// LocationClustersResponse holds the location clusters of a map area
+type LocationClustersResponse struct {
+	Zoom     int                `json:"zoom"`
+	Bounds   domain.BoundingBox `json:"bounds"`
+	Clusters []*domain.Cluster  `json:"clusters"`
 }
 
 // RegisterProvider creates a new parking provider
 func (s *ProviderService) RegisterProvider(ctx context.Context, req RegisterProviderRequest) (*ProviderResponse, error) {
-	s.logger.Info("registering provider", ports.String("code", req.Code))
+	s.logger.WithContext(ctx).Info("registering provider", ports.String("code", req.Code))
 
 	// Check if provider code already exists
 	existing, err := s.providers.GetByCode(ctx, req.Code)
@@ -118,7 +162,7 @@ func (s *ProviderService) RegisterProvider(ctx context.Context, req RegisterProv
 				"code":        provider.Code,
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
 	return s.toProviderResponse(provider), nil
@@ -156,17 +200,23 @@ func (s *ProviderService) ListProviders(ctx context.Context, activeOnly bool) ([
 	return responses, nil
 }
 
-// ActivateProvider activates a pending or inactive provider
+// ActivateProvider activates a pending or inactive provider once it has
+// passed the API conformance check
 func (s *ProviderService) ActivateProvider(ctx context.Context, id uuid.UUID) error {
 	provider, err := s.providers.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if err := requireConformance(ctx, s.conformance, provider); err != nil {
+		return err
+	}
 
+	before := *provider
 	provider.Activate()
 	if err := s.providers.Update(ctx, provider); err != nil {
 		return fmt.Errorf("failed to activate provider: %w", err)
 	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditProviderActivated, before, *provider, ""))
 
 	go func() {
 		event := ports.Event{
@@ -175,7 +225,7 @@ func (s *ProviderService) ActivateProvider(ctx context.Context, id uuid.UUID) er
 				"provider_id": provider.ID.String(),
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
 	return nil
@@ -188,14 +238,97 @@ func (s *ProviderService) DeactivateProvider(ctx context.Context, id uuid.UUID)
 		return err
 	}
 
+	before := *provider
 	provider.Deactivate()
 	if err := s.providers.Update(ctx, provider); err != nil {
 		return fmt.Errorf("failed to deactivate provider: %w", err)
 	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditProviderDeactivated, before, *provider, ""))
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventProviderDeactivated,
+			Payload: map[string]interface{}{
+				"provider_id": provider.ID.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
 
 	return nil
 }
 
+// PublishMFE records a provider's new micro-frontend release
+func (s *ProviderService) PublishMFE(ctx context.Context, id uuid.UUID, req PublishMFERequest) (*ProviderResponse, error) {
+	provider, err := s.providers.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *provider
+	err = provider.PublishMFE(req.MFEURL, domain.MFEModule{
+		Version:      req.Version,
+		Capabilities: req.Capabilities,
+		HealthURL:    req.HealthURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.providers.Update(ctx, provider); err != nil {
+		return nil, fmt.Errorf("failed to publish MFE: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditMFEPublished, before, *provider, ""))
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventMFEPublished,
+			Payload: map[string]interface{}{
+				"provider_id": provider.ID.String(),
+				"mfe_url":     provider.MFEURL,
+				"version":     provider.Config.MFE.Version,
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return s.toProviderResponse(provider), nil
+}
+
+// GetMFEManifest lists the modules of every active provider with a published
+// MFE release, for the shell app to discover
+func (s *ProviderService) GetMFEManifest(ctx context.Context) ([]*MFEManifestEntry, error) {
+	providers, err := s.providers.GetAll(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list providers: %w", err)
+	}
+
+	entries := make([]*MFEManifestEntry, 0, len(providers))
+	for _, p := range providers {
+		if p.MFEURL == "" || p.Config.MFE.Version == "" {
+			continue
+		}
+		healthURL := p.Config.MFE.HealthURL
+		if healthURL == "" {
+			healthURL = p.MFEURL
+		}
+		capabilities := p.Config.MFE.Capabilities
+		if capabilities == nil {
+			capabilities = []string{}
+		}
+		entries = append(entries, &MFEManifestEntry{
+			ProviderID:   p.ID,
+			Code:         p.Code,
+			Name:         p.Name,
+			LogoURL:      p.LogoURL,
+			MFEURL:       p.MFEURL,
+			Version:      p.Config.MFE.Version,
+			Capabilities: capabilities,
+			HealthURL:    healthURL,
+		})
+	}
+	return entries, nil
+}
+
 // GenerateCredentials creates API credentials for a provider
 func (s *ProviderService) GenerateCredentials(ctx context.Context, providerID uuid.UUID, env domain.Environment) (*CredentialsResponse, error) {
 	// Verify provider exists
@@ -212,6 +345,7 @@ func (s *ProviderService) GenerateCredentials(ctx context.Context, providerID uu
 	if err := s.credentials.Create(ctx, creds); err != nil {
 		return nil, fmt.Errorf("failed to store credentials: %w", err)
 	}
+	recordAudit(ctx, s.auditLog, s.logger, credentialsAuditEvent(ports.AuditCredentialsIssued, nil, *creds))
 
 	// Return credentials with secret visible only once
 	return &CredentialsResponse{
@@ -256,12 +390,21 @@ func (s *ProviderService) AddLocation(ctx context.Context, req AddLocationReques
 				"provider_id": provider.ID.String(),
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
 	return s.toLocationResponse(location), nil
 }
 
+// GetLocation retrieves a single parking location
+func (s *ProviderService) GetLocation(ctx context.Context, id uuid.UUID) (*LocationResponse, error) {
+	location, err := s.locations.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.toLocationResponse(location), nil
+}
+
 // GetProviderLocations retrieves all locations for a provider
 func (s *ProviderService) GetProviderLocations(ctx context.Context, providerID uuid.UUID) ([]*LocationResponse, error) {
 	locations, err := s.locations.GetByProviderID(ctx, providerID)
@@ -276,9 +419,10 @@ func (s *ProviderService) GetProviderLocations(ctx context.Context, providerID u
 	return responses, nil
 }
 
-// GetNearbyLocations finds parking locations near coordinates
-func (s *ProviderService) GetNearbyLocations(ctx context.Context, lat, lng, radiusKm float64) ([]*LocationResponse, error) {
-	locations, err := s.locations.GetNearby(ctx, lat, lng, radiusKm)
+// GetNearbyLocations finds parking locations near coordinates that match
+// filter
+func (s *ProviderService) GetNearbyLocations(ctx context.Context, lat, lng, radiusKm float64, filter domain.LocationFilter) ([]*LocationResponse, error) {
+	locations, err := s.locations.GetNearby(ctx, lat, lng, radiusKm, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get nearby locations: %w", err)
 	}
@@ -290,6 +434,22 @@ func (s *ProviderService) GetNearbyLocations(ctx context.Context, lat, lng, radi
 	return responses, nil
 }
 
+// GetLocationClusters groups the active locations in box for a map at zoom
+func (s *ProviderService) GetLocationClusters(ctx context.Context, box domain.BoundingBox, zoom int) (*LocationClustersResponse, error) {
+	if err := domain.CheckClusterArea(box, zoom); err != nil {
+		return nil, err
+	}
+
+	clusters, err := s.locations.Clusters(ctx, box, domain.CellZoom(zoom))
+	if err != nil {
+		return nil, fmt.Errorf("failed to cluster locations: %w", err)
+	}
+	if clusters == nil {
+		clusters = []*domain.Cluster{}
+	}
+	return &LocationClustersResponse{Zoom: zoom, Bounds: box, Clusters: clusters}, nil
+}
+
 func (s *ProviderService) toProviderResponse(p *domain.Provider) *ProviderResponse {
 	return &ProviderResponse{
 		ID:          p.ID,
@@ -314,6 +474,13 @@ func (s *ProviderService) toLocationResponse(l *domain.Location) *LocationRespon
 		Latitude:    l.Latitude,
 		Longitude:   l.Longitude,
 		TotalSpaces: l.TotalSpaces,
+		IsActive:    l.IsActive,
 		Pricing:     l.Pricing,
+		Amenities:   l.Amenities,
+
+		HeightLimitCm:   l.HeightLimitCm,
+		PricingSchedule: l.PricingSchedule,
+		OperatingHours:  l.OperatingHours,
+		OpenNow:         l.IsActive && l.OperatingHours.OpenAt(time.Now()),
 	}
 }
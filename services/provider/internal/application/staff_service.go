@@ -0,0 +1,424 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// StaffService serves the provider portal used by a provider's own staff.
+// Every call is scoped to the provider the staff member works for; only
+// provider admins can change locations.
+type StaffService struct {
+	providerService *ProviderService
+	pricingService  *PricingService
+	locations       ports.LocationRepository
+	sessions        ports.ProviderSessionRepository
+	events          ports.EventPublisher
+	auditLog        ports.AuditLog
+	logger          ports.Logger
+}
+
+func NewStaffService(
+	providerService *ProviderService,
+	pricingService *PricingService,
+	locations ports.LocationRepository,
+	sessions ports.ProviderSessionRepository,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+) *StaffService {
+	return &StaffService{
+		providerService: providerService,
+		pricingService:  pricingService,
+		locations:       locations,
+		sessions:        sessions,
+		events:          events,
+		auditLog:        auditLog,
+		logger:          logger,
+	}
+}
+
+// Request/Response DTOs
+
+// UpdateLocationRequest replaces a location's details. Pricing is changed
+// through the pricing endpoints so every change is versioned.
+type UpdateLocationRequest struct {
+	Name        string  `json:"name"`
+	Address     string  `json:"address"`
+	City        string  `json:"city"`
+	State       string  `json:"state"`
+	PostalCode  string  `json:"postal_code"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	TotalSpaces int     `json:"total_spaces"`
+}
+
+type SessionReportResponse struct {
+	From       time.Time                 `json:"from"`
+	To         time.Time                 `json:"to"`
+	LocationID *uuid.UUID                `json:"location_id,omitempty"`
+	Totals     *domain.SessionTotals     `json:"totals"`
+	Sessions   []*domain.ProviderSession `json:"sessions"`
+	Limit      int                       `json:"limit"`
+	Offset     int                       `json:"offset"`
+}
+
+// GetProvider returns the staff member's provider
+func (s *StaffService) GetProvider(ctx context.Context, staff *domain.Staff) (*ProviderResponse, error) {
+	return s.providerService.GetProvider(ctx, staff.ProviderID)
+}
+
+// ListLocations returns the provider's locations
+func (s *StaffService) ListLocations(ctx context.Context, staff *domain.Staff) ([]*LocationResponse, error) {
+	return s.providerService.GetProviderLocations(ctx, staff.ProviderID)
+}
+
+// GetLocation returns one of the provider's locations
+func (s *StaffService) GetLocation(ctx context.Context, staff *domain.Staff, id uuid.UUID) (*LocationResponse, error) {
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.providerService.toLocationResponse(location), nil
+}
+
+// AddLocation adds a location to the staff member's provider
+func (s *StaffService) AddLocation(ctx context.Context, staff *domain.Staff, req AddLocationRequest) (*LocationResponse, error) {
+	if err := staff.CanManage(); err != nil {
+		return nil, err
+	}
+	req.ProviderID = staff.ProviderID
+	return s.providerService.AddLocation(ctx, req)
+}
+
+// UpdateLocation changes where one of the provider's locations is and how
+// it is described
+func (s *StaffService) UpdateLocation(ctx context.Context, staff *domain.Staff, id uuid.UUID, req UpdateLocationRequest) (*LocationResponse, error) {
+	if err := staff.CanManage(); err != nil {
+		return nil, err
+	}
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *location
+
+	if err := location.UpdateDetails(req.Name, req.Address, req.City, req.State, req.PostalCode, req.Latitude, req.Longitude, req.TotalSpaces); err != nil {
+		return nil, err
+	}
+	if err := s.locations.Update(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+	s.locationChanged(ctx, ports.AuditLocationUpdated, ports.EventLocationUpdated, before, *location)
+
+	return s.providerService.toLocationResponse(location), nil
+}
+
+// DeactivateLocation stops new sessions at one of the provider's
+// locations. The location is kept so past sessions still resolve.
+func (s *StaffService) DeactivateLocation(ctx context.Context, staff *domain.Staff, id uuid.UUID) error {
+	if err := staff.CanManage(); err != nil {
+		return err
+	}
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return err
+	}
+	before := *location
+
+	location.Deactivate()
+	if err := s.locations.Update(ctx, location); err != nil {
+		return fmt.Errorf("failed to update location: %w", err)
+	}
+	s.locationChanged(ctx, ports.AuditLocationDeactivated, ports.EventLocationDeactivated, before, *location)
+	return nil
+}
+
+// LocationAmenities is what a location offers and the tallest vehicle it
+// takes
+type LocationAmenities struct {
+	Amenities     []string `json:"amenities"`
+	HeightLimitCm *int     `json:"height_limit_cm"`
+}
+
+// GetAmenities returns what one of the provider's locations offers
+func (s *StaffService) GetAmenities(ctx context.Context, staff *domain.Staff, id uuid.UUID) (*LocationAmenities, error) {
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	return &LocationAmenities{Amenities: location.Amenities, HeightLimitCm: location.HeightLimitCm}, nil
+}
+
+// SetAmenities replaces what one of the provider's locations offers and its
+// height limit
+func (s *StaffService) SetAmenities(ctx context.Context, staff *domain.Staff, id uuid.UUID, req LocationAmenities) (*LocationAmenities, error) {
+	location, err := s.changeLocation(ctx, staff, id, func(l *domain.Location) error {
+		return l.SetAmenities(req.Amenities, req.HeightLimitCm)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LocationAmenities{Amenities: location.Amenities, HeightLimitCm: location.HeightLimitCm}, nil
+}
+
+// GetPricingSchedule returns the pricing schedule of one of the provider's
+// locations
+func (s *StaffService) GetPricingSchedule(ctx context.Context, staff *domain.Staff, id uuid.UUID) (*pricing.Schedule, error) {
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	if location.PricingSchedule == nil {
+		return nil, domain.ErrNoPricingSchedule
+	}
+	return location.PricingSchedule, nil
+}
+
+// SetPricingSchedule replaces the pricing schedule of one of the provider's
+// locations. Sessions are billed by the schedule in place when they entered.
+func (s *StaffService) SetPricingSchedule(ctx context.Context, staff *domain.Staff, id uuid.UUID, schedule *pricing.Schedule) (*pricing.Schedule, error) {
+	location, err := s.changeSchedule(ctx, staff, id, func(l *domain.Location) error {
+		return l.SetPricingSchedule(schedule)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location.PricingSchedule, nil
+}
+
+// DeletePricingSchedule returns one of the provider's locations to its
+// flat rate
+func (s *StaffService) DeletePricingSchedule(ctx context.Context, staff *domain.Staff, id uuid.UUID) error {
+	_, err := s.changeSchedule(ctx, staff, id, func(l *domain.Location) error {
+		if l.PricingSchedule == nil {
+			return domain.ErrNoPricingSchedule
+		}
+		return l.SetPricingSchedule(nil)
+	})
+	return err
+}
+
+// BulkUpdatePricing schedules new rates for several of the provider's
+// locations at once
+func (s *StaffService) BulkUpdatePricing(ctx context.Context, staff *domain.Staff, req BulkPricingUpdateRequest) (*BulkPricingUpdateResponse, error) {
+	if err := staff.CanManage(); err != nil {
+		return nil, err
+	}
+	req.ProviderID = staff.ProviderID
+	req.CreatedBy = staff.UserID
+	return s.pricingService.BulkUpdatePricing(ctx, req)
+}
+
+// GetOperatingHours returns the operating hours of one of the provider's
+// locations
+func (s *StaffService) GetOperatingHours(ctx context.Context, staff *domain.Staff, id uuid.UUID) (*pricing.Hours, error) {
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	if location.OperatingHours == nil {
+		return nil, domain.ErrNoOperatingHours
+	}
+	return location.OperatingHours, nil
+}
+
+// SetOperatingHours replaces the operating hours of one of the provider's
+// locations
+func (s *StaffService) SetOperatingHours(ctx context.Context, staff *domain.Staff, id uuid.UUID, hours *pricing.Hours) (*pricing.Hours, error) {
+	location, err := s.changeLocation(ctx, staff, id, func(l *domain.Location) error {
+		return l.SetOperatingHours(hours)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return location.OperatingHours, nil
+}
+
+// DeleteOperatingHours opens one of the provider's locations around the
+// clock
+func (s *StaffService) DeleteOperatingHours(ctx context.Context, staff *domain.Staff, id uuid.UUID) error {
+	_, err := s.changeLocation(ctx, staff, id, func(l *domain.Location) error {
+		if l.OperatingHours == nil {
+			return domain.ErrNoOperatingHours
+		}
+		return l.SetOperatingHours(nil)
+	})
+	return err
+}
+
+// SessionReport lists the provider's finished sessions in the filter's
+// period with totals for the whole period
+func (s *StaffService) SessionReport(ctx context.Context, staff *domain.Staff, filter domain.SessionFilter, limit, offset int) (*SessionReportResponse, error) {
+	if filter.LocationID != nil {
+		if _, err := s.ownedLocation(ctx, staff, *filter.LocationID); err != nil {
+			return nil, err
+		}
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	totals, err := s.sessions.Totals(ctx, staff.ProviderID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total sessions: %w", err)
+	}
+	sessions, err := s.sessions.List(ctx, staff.ProviderID, filter, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	if sessions == nil {
+		sessions = []*domain.ProviderSession{}
+	}
+
+	return &SessionReportResponse{
+		From:       filter.From,
+		To:         filter.To,
+		LocationID: filter.LocationID,
+		Totals:     totals,
+		Sessions:   sessions,
+		Limit:      limit,
+		Offset:     offset,
+	}, nil
+}
+
+// EventTypes are the parking events recorded for session reports
+func (s *StaffService) EventTypes() []string {
+	return []string{parkingSessionEnded, parkingSessionCancelled}
+}
+
+// Handle records a finished session for its provider's reports. Events
+// without a provider or entry time are skipped.
+func (s *StaffService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	status := domain.ProviderSessionEnded
+	if eventType == parkingSessionCancelled {
+		status = domain.ProviderSessionCancelled
+	}
+
+	sessionID, err := uuid.Parse(payloadString(payload, "session_id"))
+	if err != nil {
+		return nil
+	}
+	providerID, err := uuid.Parse(payloadString(payload, "provider_id"))
+	if err != nil {
+		return nil
+	}
+	locationID, _ := uuid.Parse(payloadString(payload, "location_id"))
+	entryTime, err := time.Parse(time.RFC3339, payloadString(payload, "entry_time"))
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("parking event has no entry time",
+			ports.String("session_id", sessionID.String()))
+		return nil
+	}
+
+	session := &domain.ProviderSession{
+		SessionID:         sessionID,
+		ProviderID:        providerID,
+		LocationID:        locationID,
+		ExternalSessionID: payloadString(payload, "external_session_id"),
+		VehiclePlate:      payloadString(payload, "vehicle_plate"),
+		Status:            status,
+		EntryTime:         entryTime,
+		Currency:          payloadString(payload, "currency"),
+		PaymentID:         payloadString(payload, "payment_id"),
+		RecordedAt:        time.Now().UTC(),
+	}
+	if exit, err := time.Parse(time.RFC3339, payloadString(payload, "exit_time")); err == nil {
+		session.ExitTime = &exit
+	}
+	if status == domain.ProviderSessionEnded {
+		session.Amount, _ = decimal.NewFromString(payloadString(payload, "amount"))
+		// Numbers arrive as float64 once the event is decoded from JSON
+		if duration, ok := payload["duration"].(float64); ok {
+			session.DurationMinutes = int(duration)
+		}
+	}
+
+	if err := s.sessions.Save(ctx, session); err != nil {
+		// The provider may not exist here; such sessions are not reported
+		if errors.Is(err, domain.ErrProviderNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to record session: %w", err)
+	}
+	return nil
+}
+
+// ownedLocation loads a location, refusing ones that belong to another
+// provider
+func (s *StaffService) ownedLocation(ctx context.Context, staff *domain.Staff, id uuid.UUID) (*domain.Location, error) {
+	location, err := s.locations.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if location.ProviderID != staff.ProviderID {
+		return nil, domain.ErrLocationNotOwned
+	}
+	return location, nil
+}
+
+// changeLocation applies a provider admin's change to one of the provider's
+// locations, saves it and records it
+func (s *StaffService) changeLocation(ctx context.Context, staff *domain.Staff, id uuid.UUID, change func(*domain.Location) error) (*domain.Location, error) {
+	if err := staff.CanManage(); err != nil {
+		return nil, err
+	}
+	location, err := s.ownedLocation(ctx, staff, id)
+	if err != nil {
+		return nil, err
+	}
+	before := *location
+
+	if err := change(location); err != nil {
+		return nil, err
+	}
+	if err := s.locations.Update(ctx, location); err != nil {
+		return nil, fmt.Errorf("failed to update location: %w", err)
+	}
+	s.locationChanged(ctx, ports.AuditLocationUpdated, ports.EventLocationUpdated, before, *location)
+	return location, nil
+}
+
+// changeSchedule changes a location's pricing schedule, versioning it before
+// the location is saved so billing never sees a schedule without its version
+func (s *StaffService) changeSchedule(ctx context.Context, staff *domain.Staff, id uuid.UUID, change func(*domain.Location) error) (*domain.Location, error) {
+	return s.changeLocation(ctx, staff, id, func(l *domain.Location) error {
+		before := *l
+		if err := change(l); err != nil {
+			return err
+		}
+		return s.pricingService.RecordSchedule(ctx, &before, l, staff.UserID)
+	})
+}
+
+func (s *StaffService) locationChanged(ctx context.Context, action, eventType string, before, after domain.Location) {
+	recordAudit(ctx, s.auditLog, s.logger, ports.AuditEvent{
+		Action:     action,
+		TargetType: "location",
+		TargetID:   after.ID.String(),
+		Before:     before,
+		After:      after,
+	})
+
+	go func() {
+		event := ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"location_id": after.ID.String(),
+				"provider_id": after.ProviderID.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
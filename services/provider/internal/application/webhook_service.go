@@ -0,0 +1,306 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/parking-super-app/services/provider/internal/ports"
+)
+
+// Parking events that are forwarded to the provider that ran the session
+const (
+	parkingSessionEnded     = "parking.session.ended"
+	parkingSessionCancelled = "parking.session.cancelled"
+)
+
+// webhookFields are the parking event fields a provider receives. The user's
+// identity is deliberately left out.
+var webhookFields = []string{
+	"session_id", "external_session_id", "location_id",
+	"amount", "currency", "duration", "payment_id",
+}
+
+// WebhookConfig controls delivery of outbound webhooks
+type WebhookConfig struct {
+	Retry     domain.WebhookRetryPolicy
+	BatchSize int
+	Lease     time.Duration // A claimed event not sent within this is claimed again
+}
+
+// WebhookService notifies providers of what happens to their sessions on our
+// side. Events are queued from the parking service's event stream and sent
+// by a dispatcher, which retries failures with backoff and logs every attempt.
+type WebhookService struct {
+	providers  ports.ProviderRepository
+	events     ports.WebhookEventRepository
+	deliveries ports.WebhookDeliveryRepository
+	sender     ports.WebhookSender
+	publisher  ports.EventPublisher
+	auditLog   ports.AuditLog
+	logger     ports.Logger
+	cfg        WebhookConfig
+}
+
+func NewWebhookService(
+	providers ports.ProviderRepository,
+	events ports.WebhookEventRepository,
+	deliveries ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	publisher ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+	cfg WebhookConfig,
+) *WebhookService {
+	if cfg.Retry.MaxAttempts <= 0 {
+		cfg.Retry = domain.DefaultWebhookRetryPolicy()
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = 5 * time.Minute
+	}
+	return &WebhookService{
+		providers:  providers,
+		events:     events,
+		deliveries: deliveries,
+		sender:     sender,
+		publisher:  publisher,
+		auditLog:   auditLog,
+		logger:     logger,
+		cfg:        cfg,
+	}
+}
+
+// Request/Response DTOs
+
+type ConfigureWebhookRequest struct {
+	URL          string `json:"url"`
+	RotateSecret bool   `json:"rotate_secret"`
+}
+
+// WebhookSettingsResponse carries the signing secret only when it was just
+// generated; it cannot be read back later
+type WebhookSettingsResponse struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+type WebhookDispatchResult struct {
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+}
+
+// ConfigureWebhook sets where a provider's webhooks are sent. A secret is
+// generated the first time and on request; an empty URL stops deliveries
+// and drops the secret.
+func (s *WebhookService) ConfigureWebhook(ctx context.Context, providerID uuid.UUID, req ConfigureWebhookRequest) (*WebhookSettingsResponse, error) {
+	provider, err := s.providers.GetByID(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+	before := *provider
+
+	secret, generated := provider.WebhookSecret, ""
+	if req.URL == "" {
+		secret = ""
+	} else if secret == "" || req.RotateSecret {
+		if generated, err = domain.GenerateWebhookSecret(); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		secret = generated
+	}
+	if err := provider.SetWebhook(req.URL, secret); err != nil {
+		return nil, err
+	}
+
+	if err := s.providers.Update(ctx, provider); err != nil {
+		return nil, fmt.Errorf("failed to update provider: %w", err)
+	}
+	recordAudit(ctx, s.auditLog, s.logger, providerAuditEvent(ports.AuditWebhookConfigured, before, *provider, ""))
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventWebhookConfigured,
+			Payload: map[string]interface{}{
+				"provider_id":    provider.ID.String(),
+				"webhook_url":    provider.WebhookURL,
+				"secret_rotated": generated != "",
+			},
+		}
+		s.publisher.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return &WebhookSettingsResponse{URL: provider.WebhookURL, Secret: generated}, nil
+}
+
+// EventTypes lists the parking events the service handles
+func (s *WebhookService) EventTypes() []string {
+	return []string{parkingSessionEnded, parkingSessionCancelled}
+}
+
+// Handle queues a webhook for a parking event. Ended sessions are only
+// forwarded when a payment was taken. Events that cannot be forwarded are
+// skipped; only a failed lookup or write fails the event for redelivery.
+func (s *WebhookService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	var webhookType string
+	switch eventType {
+	case parkingSessionEnded:
+		if payloadString(payload, "payment_id") == "" {
+			return nil
+		}
+		webhookType = domain.WebhookEventPaymentCompleted
+	case parkingSessionCancelled:
+		webhookType = domain.WebhookEventSessionCancelled
+	default:
+		return nil
+	}
+
+	providerID, err := uuid.Parse(payloadString(payload, "provider_id"))
+	if err != nil {
+		s.logger.WithContext(ctx).Warn("parking event has no provider", ports.String("event_type", eventType))
+		return nil
+	}
+	provider, err := s.providers.GetByID(ctx, providerID)
+	if errors.Is(err, domain.ErrProviderNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load provider: %w", err)
+	}
+	if provider.WebhookURL == "" {
+		return nil
+	}
+
+	data := make(map[string]interface{}, len(webhookFields))
+	for _, field := range webhookFields {
+		if value, ok := payload[field]; ok {
+			data[field] = value
+		}
+	}
+	event, err := domain.NewWebhookEvent(provider.ID, webhookType, data)
+	if err != nil {
+		return fmt.Errorf("failed to build webhook event: %w", err)
+	}
+	if err := s.events.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to queue webhook event: %w", err)
+	}
+	return nil
+}
+
+// DispatchDue sends the webhooks whose next attempt is due
+func (s *WebhookService) DispatchDue(ctx context.Context, now time.Time) (*WebhookDispatchResult, error) {
+	due, err := s.events.ClaimDue(ctx, now, s.cfg.Lease, s.cfg.BatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due webhook events: %w", err)
+	}
+
+	result := &WebhookDispatchResult{}
+	for _, event := range due {
+		s.deliver(ctx, event)
+
+		switch event.Status {
+		case domain.WebhookEventDelivered:
+			result.Delivered++
+		case domain.WebhookEventFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+		if err := s.events.Update(ctx, event); err != nil {
+			s.logger.WithContext(ctx).Error("failed to update webhook event",
+				ports.String("event_id", event.ID.String()),
+				ports.Err(err),
+			)
+		}
+	}
+
+	if len(due) > 0 {
+		s.logger.WithContext(ctx).Info("webhook dispatch run finished",
+			ports.Any("delivered", result.Delivered),
+			ports.Any("retrying", result.Retrying),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+// deliver makes one attempt to send an event and records the outcome on it
+func (s *WebhookService) deliver(ctx context.Context, event *domain.WebhookEvent) {
+	provider, err := s.providers.GetByID(ctx, event.ProviderID)
+	if err != nil {
+		// Retried on the next run once the lease expires
+		s.logger.WithContext(ctx).Warn("failed to load provider for webhook",
+			ports.String("event_id", event.ID.String()),
+			ports.Err(err),
+		)
+		return
+	}
+	if provider.WebhookURL == "" {
+		event.Fail(domain.ErrWebhookNotConfigured.Error())
+		return
+	}
+
+	start := time.Now()
+	status, sendErr := s.sender.Send(ctx, provider.WebhookURL, event.Payload, provider.WebhookSecret)
+	delivery := domain.NewWebhookDelivery(provider.ID, event.EventType, provider.WebhookURL, event.NextAttempt(), status, sendErr, time.Since(start))
+	delivery.EventID = &event.ID
+
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		s.logger.WithContext(ctx).Warn("failed to log webhook delivery",
+			ports.String("event_id", event.ID.String()),
+			ports.Err(err),
+		)
+	}
+	event.RecordAttempt(delivery, s.cfg.Retry, time.Now().UTC())
+}
+
+// ListEvents returns a provider's queued and sent webhooks, newest first,
+// optionally only those in one status
+func (s *WebhookService) ListEvents(ctx context.Context, providerID uuid.UUID, status domain.WebhookEventStatus, limit, offset int) ([]*domain.WebhookEvent, error) {
+	limit, offset = pageBounds(limit, offset)
+
+	events, err := s.events.ListByProviderID(ctx, providerID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook events: %w", err)
+	}
+	if events == nil {
+		events = []*domain.WebhookEvent{}
+	}
+	return events, nil
+}
+
+// Redrive queues a failed webhook for delivery again. Events of other
+// providers are reported as not found.
+func (s *WebhookService) Redrive(ctx context.Context, providerID, eventID uuid.UUID) (*domain.WebhookEvent, error) {
+	event, err := s.events.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.ProviderID != providerID {
+		return nil, domain.ErrWebhookEventNotFound
+	}
+
+	if err := event.Redrive(time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.events.Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to update webhook event: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("webhook event redriven",
+		ports.String("event_id", event.ID.String()),
+		ports.String("provider_id", providerID.String()),
+	)
+	return event, nil
+}
+
+func payloadString(payload map[string]interface{}, key string) string {
+	s, _ := payload[key].(string)
+	return s
+}
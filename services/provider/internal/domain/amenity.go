@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	// ErrUnknownAmenity is returned for an amenity not in the catalogue
+	ErrUnknownAmenity = errors.New("unknown amenity")
+	// ErrInvalidHeightLimit is returned for a height limit that is not a
+	// positive number of centimetres
+	ErrInvalidHeightLimit = errors.New("height limit must be a positive number of centimetres")
+)
+
+// Amenity is something a location offers drivers, and can be searched by
+type Amenity string
+
+const (
+	AmenityCovered        Amenity = "covered"
+	AmenityEVCharging     Amenity = "ev_charging"
+	AmenityDisabledAccess Amenity = "disabled_access"
+	AmenityMotorcycleBays Amenity = "motorcycle_bays"
+	AmenitySecurity       Amenity = "security"
+	AmenityCarWash        Amenity = "car_wash"
+)
+
+// Amenities is the catalogue of amenities a location can list
+var Amenities = []Amenity{
+	AmenityCovered,
+	AmenityEVCharging,
+	AmenityDisabledAccess,
+	AmenityMotorcycleBays,
+	AmenitySecurity,
+	AmenityCarWash,
+}
+
+// ParseAmenities checks each amenity is in the catalogue and returns them
+// sorted without repeats
+func ParseAmenities(values []string) ([]string, error) {
+	seen := make(map[string]bool, len(values))
+	amenities := make([]string, 0, len(values))
+	for _, v := range values {
+		if !knownAmenity(v) {
+			return nil, ErrUnknownAmenity
+		}
+		if !seen[v] {
+			seen[v] = true
+			amenities = append(amenities, v)
+		}
+	}
+	sort.Strings(amenities)
+	return amenities, nil
+}
+
+func knownAmenity(v string) bool {
+	for _, a := range Amenities {
+		if string(a) == v {
+			return true
+		}
+	}
+	return false
+}
+
+// LocationFilter narrows a location search. The zero filter matches every
+// location.
+type LocationFilter struct {
+	// Amenities the location must offer, all of them
+	Amenities []string
+	// VehicleHeightCm excludes locations whose height limit is lower; zero
+	// does not filter by height
+	VehicleHeightCm int
+}
+
+// NewLocationFilter validates a search's amenities and vehicle height
+func NewLocationFilter(amenities []string, vehicleHeightCm int) (LocationFilter, error) {
+	parsed, err := ParseAmenities(amenities)
+	if err != nil {
+		return LocationFilter{}, err
+	}
+	if vehicleHeightCm < 0 {
+		return LocationFilter{}, ErrInvalidHeightLimit
+	}
+	return LocationFilter{Amenities: parsed, VehicleHeightCm: vehicleHeightCm}, nil
+}
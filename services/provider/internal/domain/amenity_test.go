@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewLocationFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		amenities     []string
+		vehicleHeight int
+		want          []string
+		wantErr       error
+	}{
+		{"no filter", nil, 0, []string{}, nil},
+		{"sorted without repeats", []string{"ev_charging", "covered", "ev_charging"}, 0, []string{"covered", "ev_charging"}, nil},
+		{"unknown amenity", []string{"valet"}, 0, nil, ErrUnknownAmenity},
+		{"negative height", nil, -1, nil, ErrInvalidHeightLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := NewLocationFilter(tt.amenities, tt.vehicleHeight)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewLocationFilter() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(filter.Amenities, tt.want) {
+				t.Errorf("Amenities = %v, want %v", filter.Amenities, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocation_SetAmenities(t *testing.T) {
+	location := NewLocation(uuid.New(), "Test", "Address", "City", "State", 0, 0)
+	height := 210
+
+	if err := location.SetAmenities([]string{"motorcycle_bays", "covered"}, &height); err != nil {
+		t.Fatalf("SetAmenities() error = %v", err)
+	}
+	if !reflect.DeepEqual(location.Amenities, []string{"covered", "motorcycle_bays"}) || *location.HeightLimitCm != 210 {
+		t.Errorf("amenities = %v, height limit = %d", location.Amenities, *location.HeightLimitCm)
+	}
+
+	zero := 0
+	if err := location.SetAmenities(nil, &zero); !errors.Is(err, ErrInvalidHeightLimit) {
+		t.Errorf("SetAmenities() error = %v, want %v", err, ErrInvalidHeightLimit)
+	}
+	if err := location.SetAmenities([]string{"helipad"}, nil); !errors.Is(err, ErrUnknownAmenity) {
+		t.Errorf("SetAmenities() error = %v, want %v", err, ErrUnknownAmenity)
+	}
+	if len(location.Amenities) != 2 {
+		t.Error("a rejected change should leave the amenities as they were")
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrActorRequired = errors.New("actor is required")
+)
+
+// AuditAction identifies the kind of change recorded in the audit log
+type AuditAction string
+
+const (
+	AuditActionApproved    AuditAction = "approved"
+	AuditActionRejected    AuditAction = "rejected"
+	AuditActionSuspended   AuditAction = "suspended"
+	AuditActionReactivated AuditAction = "reactivated"
+	AuditActionDeleted     AuditAction = "deleted"
+	AuditActionRestored    AuditAction = "restored"
+)
+
+// SystemActor is recorded when a change is made by a scheduled job rather than a person
+const SystemActor = "system"
+
+// FieldChange captures the before and after value of a single field
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// AuditEntry records who changed what on a provider and when
+type AuditEntry struct {
+	ID         uuid.UUID              `json:"id"`
+	ProviderID uuid.UUID              `json:"provider_id"`
+	Actor      string                 `json:"actor"`
+	Action     AuditAction            `json:"action"`
+	Reason     string                 `json:"reason,omitempty"`
+	Changes    map[string]FieldChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// NewAuditEntry creates an audit entry for an action taken on a provider
+func NewAuditEntry(providerID uuid.UUID, actor string, action AuditAction, reason string) (*AuditEntry, error) {
+	if actor == "" {
+		return nil, ErrActorRequired
+	}
+
+	return &AuditEntry{
+		ID:         uuid.New(),
+		ProviderID: providerID,
+		Actor:      actor,
+		Action:     action,
+		Reason:     reason,
+		Changes:    make(map[string]FieldChange),
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// RecordChange adds a field change to the entry, skipping values that did not change
+func (e *AuditEntry) RecordChange(field, from, to string) {
+	if from == to {
+		return
+	}
+	if e.Changes == nil {
+		e.Changes = make(map[string]FieldChange)
+	}
+	e.Changes[field] = FieldChange{From: from, To: to}
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAuditEntry(t *testing.T) {
+	providerID := uuid.New()
+
+	entry, err := NewAuditEntry(providerID, "admin-1", AuditActionRejected, "missing license")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if entry.ID == uuid.Nil {
+		t.Error("expected ID to be set")
+	}
+	if entry.ProviderID != providerID {
+		t.Errorf("expected provider ID %v, got %v", providerID, entry.ProviderID)
+	}
+	if entry.Actor != "admin-1" {
+		t.Errorf("expected actor admin-1, got %s", entry.Actor)
+	}
+	if entry.Reason != "missing license" {
+		t.Errorf("expected reason to be set, got %s", entry.Reason)
+	}
+}
+
+func TestNewAuditEntry_MissingActor(t *testing.T) {
+	_, err := NewAuditEntry(uuid.New(), "", AuditActionApproved, "")
+	if err != ErrActorRequired {
+		t.Errorf("expected ErrActorRequired, got %v", err)
+	}
+}
+
+func TestAuditEntry_RecordChange(t *testing.T) {
+	entry, _ := NewAuditEntry(uuid.New(), "admin-1", AuditActionApproved, "")
+
+	entry.RecordChange("status", "pending", "active")
+	entry.RecordChange("name", "Same", "Same")
+
+	if len(entry.Changes) != 1 {
+		t.Fatalf("expected 1 change, got %d", len(entry.Changes))
+	}
+	change := entry.Changes["status"]
+	if change.From != "pending" || change.To != "active" {
+		t.Errorf("unexpected change recorded: %+v", change)
+	}
+}
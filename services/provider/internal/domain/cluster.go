@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidBoundingBox is returned for a box outside valid coordinates,
+	// or one whose west edge is east of its east edge
+	ErrInvalidBoundingBox = errors.New("bounding box must be west,south,east,north within valid coordinates")
+	// ErrInvalidZoom is returned for a zoom level outside 0 to MaxZoom
+	ErrInvalidZoom = errors.New("zoom must be between 0 and 20")
+	// ErrInvalidTile is returned for tile coordinates outside their zoom level
+	ErrInvalidTile = errors.New("tile is outside the map at its zoom level")
+	// ErrBoundingBoxTooLarge is returned when a box spans more clusters than
+	// one response may hold; zoom in or ask for a smaller box
+	ErrBoundingBoxTooLarge = errors.New("bounding box is too large for the zoom level")
+)
+
+const (
+	// MaxZoom is the closest zoom level clusters are computed for
+	MaxZoom = 20
+
+	// ClusterCellBits splits each map tile into a 2^bits by 2^bits grid of
+	// cells, each becoming at most one cluster: 8x8 cells of 32 pixels on a
+	// 256 pixel tile, about the size of a pin
+	ClusterCellBits = 3
+
+	// MaxClusterCells bounds the grid cells one search may cover
+	MaxClusterCells = 4096
+
+	// maxMercatorLat is where the Web Mercator map ends
+	maxMercatorLat = 85.05112878
+)
+
+// BoundingBox is an area of the map in degrees
+type BoundingBox struct {
+	South float64 `json:"south"`
+	West  float64 `json:"west"`
+	North float64 `json:"north"`
+	East  float64 `json:"east"`
+}
+
+// Validate checks the box lies within valid coordinates. Boxes crossing the
+// antimeridian are not supported.
+func (b BoundingBox) Validate() error {
+	if b.South < -90 || b.North > 90 || b.West < -180 || b.East > 180 ||
+		b.South > b.North || b.West > b.East {
+		return ErrInvalidBoundingBox
+	}
+	return nil
+}
+
+// Cluster is a group of locations close together at a zoom level. A cluster
+// of one location carries its ID, so the app can show the location itself.
+type Cluster struct {
+	Latitude    float64     `json:"latitude"`
+	Longitude   float64     `json:"longitude"`
+	Count       int         `json:"count"`
+	TotalSpaces int         `json:"total_spaces"`
+	Bounds      BoundingBox `json:"bounds"`
+	LocationID  *uuid.UUID  `json:"location_id,omitempty"`
+}
+
+// Tile is a Web Mercator ("slippy map") tile, as used by map SDKs
+type Tile struct {
+	Zoom int
+	X    int
+	Y    int
+}
+
+// Validate checks the tile exists at its zoom level
+func (t Tile) Validate() error {
+	if t.Zoom < 0 || t.Zoom > MaxZoom {
+		return ErrInvalidZoom
+	}
+	n := 1 << t.Zoom
+	if t.X < 0 || t.X >= n || t.Y < 0 || t.Y >= n {
+		return ErrInvalidTile
+	}
+	return nil
+}
+
+// Bounds returns the area the tile covers
+func (t Tile) Bounds() BoundingBox {
+	n := float64(int(1) << t.Zoom)
+	return BoundingBox{
+		West:  float64(t.X)/n*360 - 180,
+		East:  float64(t.X+1)/n*360 - 180,
+		North: tileLat(float64(t.Y), n),
+		South: tileLat(float64(t.Y+1), n),
+	}
+}
+
+func tileLat(y, n float64) float64 {
+	return math.Atan(math.Sinh(math.Pi*(1-2*y/n))) * 180 / math.Pi
+}
+
+// CellZoom is the zoom level whose tiles are the cluster cells at zoom
+func CellZoom(zoom int) int {
+	return zoom + ClusterCellBits
+}
+
+// CellOf returns the grid cell containing a point at a cell zoom level
+func CellOf(lat, lng float64, cellZoom int) (x, y int) {
+	n := float64(int(1) << cellZoom)
+	lat = math.Max(-maxMercatorLat, math.Min(maxMercatorLat, lat))
+	rad := lat * math.Pi / 180
+	x = int(math.Floor((lng + 180) / 360 * n))
+	y = int(math.Floor((1 - math.Log(math.Tan(rad)+1/math.Cos(rad))/math.Pi) / 2 * n))
+	maxCell := int(n) - 1
+	return min(max(x, 0), maxCell), min(max(y, 0), maxCell)
+}
+
+// CheckClusterArea validates a search for the clusters in box at zoom
+func CheckClusterArea(box BoundingBox, zoom int) error {
+	if zoom < 0 || zoom > MaxZoom {
+		return ErrInvalidZoom
+	}
+	if err := box.Validate(); err != nil {
+		return err
+	}
+	cellZoom := CellZoom(zoom)
+	minX, minY := CellOf(box.North, box.West, cellZoom)
+	maxX, maxY := CellOf(box.South, box.East, cellZoom)
+	if (maxX-minX+1)*(maxY-minY+1) > MaxClusterCells {
+		return ErrBoundingBoxTooLarge
+	}
+	return nil
+}
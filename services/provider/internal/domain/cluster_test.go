@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTile_Bounds(t *testing.T) {
+	tests := []struct {
+		name string
+		tile Tile
+		want BoundingBox
+	}{
+		{"whole world", Tile{Zoom: 0}, BoundingBox{South: -maxMercatorLat, West: -180, North: maxMercatorLat, East: 180}},
+		{"north-east quarter", Tile{Zoom: 1, X: 1, Y: 0}, BoundingBox{South: 0, West: 0, North: maxMercatorLat, East: 180}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.tile.Bounds()
+			for _, pair := range [][2]float64{{got.South, tt.want.South}, {got.West, tt.want.West}, {got.North, tt.want.North}, {got.East, tt.want.East}} {
+				if math.Abs(pair[0]-pair[1]) > 1e-6 {
+					t.Fatalf("Bounds() = %+v, want %+v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestTile_Validate(t *testing.T) {
+	tests := []struct {
+		name string
+		tile Tile
+		want error
+	}{
+		{"valid", Tile{Zoom: 12, X: 3253, Y: 2012}, nil},
+		{"zoom too deep", Tile{Zoom: 21}, ErrInvalidZoom},
+		{"x beyond the map", Tile{Zoom: 2, X: 4}, ErrInvalidTile},
+		{"negative y", Tile{Zoom: 2, Y: -1}, ErrInvalidTile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tile.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCellOf_MatchesTile(t *testing.T) {
+	// KLCC lies in tile 12/3205/2012, so its cell at zoom 15 is within it
+	x, y := CellOf(3.1579, 101.7116, 15)
+	if x>>ClusterCellBits != 3205 || y>>ClusterCellBits != 2012 {
+		t.Errorf("CellOf() = %d,%d, want a cell of tile 3205,2012", x, y)
+	}
+
+	bounds := Tile{Zoom: 12, X: 3205, Y: 2012}.Bounds()
+	if 3.1579 < bounds.South || 3.1579 > bounds.North || 101.7116 < bounds.West || 101.7116 > bounds.East {
+		t.Errorf("tile bounds %+v do not hold the point", bounds)
+	}
+}
+
+func TestCheckClusterArea(t *testing.T) {
+	klangValley := BoundingBox{South: 2.9, West: 101.4, North: 3.3, East: 101.8}
+
+	tests := []struct {
+		name string
+		box  BoundingBox
+		zoom int
+		want error
+	}{
+		{"city at city zoom", klangValley, 11, nil},
+		{"city at street zoom", klangValley, 16, ErrBoundingBoxTooLarge},
+		{"whole world zoomed out", BoundingBox{South: -85, West: -180, North: 85, East: 180}, 2, nil},
+		{"inverted box", BoundingBox{South: 3.3, West: 101.4, North: 2.9, East: 101.8}, 11, ErrInvalidBoundingBox},
+		{"crosses the antimeridian", BoundingBox{South: -10, West: 170, North: 10, East: -170}, 5, ErrInvalidBoundingBox},
+		{"latitude out of range", BoundingBox{South: -91, West: 0, North: 0, East: 1}, 5, ErrInvalidBoundingBox},
+		{"negative zoom", klangValley, -1, ErrInvalidZoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckClusterArea(tt.box, tt.zoom); !errors.Is(err, tt.want) {
+				t.Errorf("CheckClusterArea() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
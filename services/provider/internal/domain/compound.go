@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrCompoundsNotSupported = errors.New("provider does not issue compounds")
+	ErrCompoundNotFound      = errors.New("compound not found at provider")
+)
+
+// FeatureCompounds marks providers, usually councils, whose API serves
+// parking fines
+const FeatureCompounds = "compounds"
+
+// Compound is an outstanding parking fine as reported by the provider that
+// issued it
+type Compound struct {
+	ProviderID   uuid.UUID       `json:"provider_id"`
+	Reference    string          `json:"reference"`
+	VehiclePlate string          `json:"vehicle_plate"`
+	Offence      string          `json:"offence"`
+	Location     string          `json:"location"`
+	IssuedAt     time.Time       `json:"issued_at"`
+	DueAt        *time.Time      `json:"due_at,omitempty"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+}
+
+// CompoundSettlement is the provider's acknowledgement of a paid fine
+type CompoundSettlement struct {
+	Reference       string    `json:"reference"`
+	ProviderReceipt string    `json:"provider_receipt"`
+	SettledAt       time.Time `json:"settled_at"`
+}
+
+// IssuesCompounds reports whether fines can be looked up at the provider
+func (p *Provider) IssuesCompounds() bool {
+	return p.Status == ProviderStatusActive && p.APIBaseURL != "" && p.HasFeature(FeatureCompounds)
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrConformanceReportNotFound  = errors.New("conformance report not found")
+	ErrConformanceNotPassed       = errors.New("provider has not passed the API conformance check")
+	ErrAPIBaseURLRequired         = errors.New("provider API base URL is required")
+	ErrSandboxCredentialsRequired = errors.New("active sandbox credentials are required")
+)
+
+// ConformanceCheck identifies one step of the provider API conformance kit
+type ConformanceCheck string
+
+const (
+	CheckStartSession          ConformanceCheck = "start_session"
+	CheckSessionStatus         ConformanceCheck = "session_status"
+	CheckEndSession            ConformanceCheck = "end_session"
+	CheckWebhookSignature      ConformanceCheck = "webhook_signature"
+	CheckWebhookRejectsInvalid ConformanceCheck = "webhook_rejects_invalid_signature"
+)
+
+// ConformanceChecks lists every check in the order the kit runs them
+var ConformanceChecks = []ConformanceCheck{
+	CheckStartSession,
+	CheckSessionStatus,
+	CheckEndSession,
+	CheckWebhookSignature,
+	CheckWebhookRejectsInvalid,
+}
+
+// conformanceWeights are the points each check contributes to a 100-point score
+var conformanceWeights = map[ConformanceCheck]int{
+	CheckStartSession:          30,
+	CheckSessionStatus:         15,
+	CheckEndSession:            30,
+	CheckWebhookSignature:      15,
+	CheckWebhookRejectsInvalid: 10,
+}
+
+// criticalChecks must pass regardless of the overall score: without them the
+// super app cannot bill a session or trust a webhook
+var criticalChecks = []ConformanceCheck{
+	CheckStartSession,
+	CheckEndSession,
+	CheckWebhookSignature,
+}
+
+// ConformancePassingScore is the minimum score a report needs to pass
+const ConformancePassingScore = 80
+
+// ConformanceResult is the outcome of a single check
+type ConformanceResult struct {
+	Check     ConformanceCheck `json:"check"`
+	Passed    bool             `json:"passed"`
+	Points    int              `json:"points"`
+	LatencyMs int64            `json:"latency_ms"`
+	Detail    string           `json:"detail,omitempty"`
+}
+
+// ConformanceReport is a scored run of the conformance kit against a provider's sandbox
+type ConformanceReport struct {
+	ID          uuid.UUID           `json:"id"`
+	ProviderID  uuid.UUID           `json:"provider_id"`
+	APIBaseURL  string              `json:"api_base_url"`
+	Score       int                 `json:"score"`
+	Passed      bool                `json:"passed"`
+	Results     []ConformanceResult `json:"results"`
+	RunBy       string              `json:"run_by"`
+	StartedAt   time.Time           `json:"started_at"`
+	CompletedAt time.Time           `json:"completed_at"`
+}
+
+// NewConformanceReport starts a report for a run against apiBaseURL
+func NewConformanceReport(providerID uuid.UUID, apiBaseURL, runBy string) *ConformanceReport {
+	return &ConformanceReport{
+		ID:         uuid.New(),
+		ProviderID: providerID,
+		APIBaseURL: apiBaseURL,
+		Results:    []ConformanceResult{},
+		RunBy:      runBy,
+		StartedAt:  time.Now().UTC(),
+	}
+}
+
+// Record stores the outcome of a check. A nil err means the check passed.
+func (r *ConformanceReport) Record(check ConformanceCheck, latency time.Duration, err error) {
+	result := ConformanceResult{
+		Check:     check,
+		Passed:    err == nil,
+		LatencyMs: latency.Milliseconds(),
+	}
+	if err != nil {
+		result.Detail = err.Error()
+	} else {
+		result.Points = conformanceWeights[check]
+	}
+	r.Results = append(r.Results, result)
+}
+
+// Skip records a check that could not run because an earlier one failed
+func (r *ConformanceReport) Skip(check ConformanceCheck, reason string) {
+	r.Results = append(r.Results, ConformanceResult{
+		Check:  check,
+		Detail: "skipped: " + reason,
+	})
+}
+
+// Result returns the recorded outcome of a check
+func (r *ConformanceReport) Result(check ConformanceCheck) (ConformanceResult, bool) {
+	for _, result := range r.Results {
+		if result.Check == check {
+			return result, true
+		}
+	}
+	return ConformanceResult{}, false
+}
+
+// Complete scores the report. It passes when the score reaches
+// ConformancePassingScore and every critical check passed.
+func (r *ConformanceReport) Complete() {
+	r.Score = 0
+	for _, result := range r.Results {
+		r.Score += result.Points
+	}
+
+	r.Passed = r.Score >= ConformancePassingScore
+	for _, check := range criticalChecks {
+		if result, ok := r.Result(check); !ok || !result.Passed {
+			r.Passed = false
+		}
+	}
+	r.CompletedAt = time.Now().UTC()
+}
+
+// Qualifies reports whether the report allows the provider to be activated.
+// A report only counts for the API base URL it was run against.
+func (r *ConformanceReport) Qualifies(p *Provider) bool {
+	return r.Passed && r.ProviderID == p.ID && r.APIBaseURL == p.APIBaseURL
+}
+
+// SignWebhook computes the signature sent with a webhook: an HMAC-SHA256 over
+// the timestamp and body, so a captured payload cannot be replayed later
+// under a fresh timestamp
+func SignWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
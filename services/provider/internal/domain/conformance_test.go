@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestConformanceReport_Complete(t *testing.T) {
+	failed := errors.New("unexpected status")
+
+	tests := []struct {
+		name       string
+		failing    []ConformanceCheck
+		wantScore  int
+		wantPassed bool
+	}{
+		{"all checks pass", nil, 100, true},
+		{"optional check fails", []ConformanceCheck{CheckWebhookRejectsInvalid}, 90, true},
+		{"score below threshold", []ConformanceCheck{CheckSessionStatus, CheckWebhookRejectsInvalid}, 75, false},
+		{"critical check fails", []ConformanceCheck{CheckWebhookSignature}, 85, false},
+		{"start fails", []ConformanceCheck{CheckStartSession}, 70, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewConformanceReport(uuid.New(), "https://api.example.com", "admin-1")
+			for _, check := range ConformanceChecks {
+				var err error
+				for _, f := range tt.failing {
+					if f == check {
+						err = failed
+					}
+				}
+				report.Record(check, 50*time.Millisecond, err)
+			}
+			report.Complete()
+
+			if report.Score != tt.wantScore {
+				t.Errorf("expected score %d, got %d", tt.wantScore, report.Score)
+			}
+			if report.Passed != tt.wantPassed {
+				t.Errorf("expected passed %v, got %v", tt.wantPassed, report.Passed)
+			}
+			if report.CompletedAt.IsZero() {
+				t.Error("expected completed_at to be set")
+			}
+		})
+	}
+}
+
+func TestConformanceReport_RecordFailure(t *testing.T) {
+	report := NewConformanceReport(uuid.New(), "https://api.example.com", "admin-1")
+	report.Record(CheckStartSession, 120*time.Millisecond, errors.New("status 500"))
+	report.Skip(CheckSessionStatus, "no session was started")
+
+	start, ok := report.Result(CheckStartSession)
+	if !ok {
+		t.Fatal("expected start_session result")
+	}
+	if start.Passed || start.Points != 0 || start.Detail != "status 500" || start.LatencyMs != 120 {
+		t.Errorf("unexpected result: %+v", start)
+	}
+
+	status, _ := report.Result(CheckSessionStatus)
+	if status.Passed || status.Detail != "skipped: no session was started" {
+		t.Errorf("unexpected skipped result: %+v", status)
+	}
+
+	if _, ok := report.Result(CheckEndSession); ok {
+		t.Error("expected no end_session result")
+	}
+}
+
+func TestConformanceReport_Qualifies(t *testing.T) {
+	provider, _ := NewProvider("Test Provider", "TEST", "https://mfe.example.com", "https://api.example.com")
+
+	passed := NewConformanceReport(provider.ID, provider.APIBaseURL, "admin-1")
+	for _, check := range ConformanceChecks {
+		passed.Record(check, time.Millisecond, nil)
+	}
+	passed.Complete()
+
+	failed := NewConformanceReport(provider.ID, provider.APIBaseURL, "admin-1")
+	failed.Complete()
+
+	stale := NewConformanceReport(provider.ID, "https://old.example.com", "admin-1")
+	for _, check := range ConformanceChecks {
+		stale.Record(check, time.Millisecond, nil)
+	}
+	stale.Complete()
+
+	tests := []struct {
+		name   string
+		report *ConformanceReport
+		want   bool
+	}{
+		{"passed report", passed, true},
+		{"failed report", failed, false},
+		{"report for another base URL", stale, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.report.Qualifies(provider); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"event":"conformance.ping"}`)
+
+	sig := SignWebhook("secret", "1700000000", body)
+	if sig != SignWebhook("secret", "1700000000", body) {
+		t.Error("expected signature to be deterministic")
+	}
+	if len(sig) != len("sha256=")+64 || sig[:7] != "sha256=" {
+		t.Errorf("unexpected signature format: %s", sig)
+	}
+	if sig == SignWebhook("other", "1700000000", body) {
+		t.Error("expected signature to depend on the secret")
+	}
+	if sig == SignWebhook("secret", "1700000001", body) {
+		t.Error("expected signature to depend on the timestamp")
+	}
+}
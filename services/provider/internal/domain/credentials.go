@@ -2,12 +2,24 @@ package domain
 
 import (
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrCredentialsNotFound    = errors.New("credentials not found")
+	ErrInvalidCredentials     = errors.New("invalid API key or secret")
+	ErrCredentialsRevoked     = errors.New("credentials are revoked or expired")
+	ErrSandboxCredentialLimit = errors.New("sandbox credential limit reached")
+)
+
+// MaxActiveSandboxCredentials caps how many sandbox keys a provider can self-serve
+const MaxActiveSandboxCredentials = 5
+
 // Environment represents the deployment environment for credentials
 type Environment string
 
@@ -74,6 +86,30 @@ func (c *ProviderCredentials) SetExpiration(expiresAt time.Time) {
 	c.ExpiresAt = &expiresAt
 }
 
+// VerifySecret compares the given secret against the stored one in constant time
+func (c *ProviderCredentials) VerifySecret(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(c.APISecret), []byte(secret)) == 1
+}
+
+// Rotate issues replacement credentials in the same environment. The current
+// credentials stay valid for the grace period so integrations can switch over.
+func (c *ProviderCredentials) Rotate(grace time.Duration) (*ProviderCredentials, error) {
+	if !c.IsValid() {
+		return nil, ErrCredentialsRevoked
+	}
+
+	replacement, err := NewProviderCredentials(c.ProviderID, c.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := replacement.CreatedAt.Add(grace)
+	if c.ExpiresAt == nil || expiresAt.Before(*c.ExpiresAt) {
+		c.SetExpiration(expiresAt)
+	}
+	return replacement, nil
+}
+
 // generateSecureKey generates a cryptographically secure random key
 func generateSecureKey(length int) (string, error) {
 	bytes := make([]byte, length)
@@ -92,3 +92,69 @@ func TestProviderCredentials_Revoke(t *testing.T) {
 		t.Error("credentials should be inactive after revoke")
 	}
 }
+
+func TestProviderCredentials_VerifySecret(t *testing.T) {
+	creds, _ := NewProviderCredentials(uuid.New(), EnvironmentSandbox)
+
+	if !creds.VerifySecret(creds.APISecret) {
+		t.Error("expected matching secret to verify")
+	}
+	if creds.VerifySecret("wrong") {
+		t.Error("expected wrong secret to be rejected")
+	}
+	if creds.VerifySecret("") {
+		t.Error("expected empty secret to be rejected")
+	}
+}
+
+func TestProviderCredentials_Rotate(t *testing.T) {
+	t.Run("issues replacement and expires current after grace", func(t *testing.T) {
+		creds, _ := NewProviderCredentials(uuid.New(), EnvironmentProduction)
+
+		replacement, err := creds.Rotate(24 * time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if replacement.ID == creds.ID || replacement.APIKey == creds.APIKey {
+			t.Error("expected new credentials")
+		}
+		if replacement.ProviderID != creds.ProviderID {
+			t.Error("expected replacement for the same provider")
+		}
+		if replacement.Environment != EnvironmentProduction {
+			t.Errorf("expected environment production, got %s", replacement.Environment)
+		}
+		if creds.ExpiresAt == nil {
+			t.Fatal("expected current credentials to get an expiry")
+		}
+		want := replacement.CreatedAt.Add(24 * time.Hour)
+		if !creds.ExpiresAt.Equal(want) {
+			t.Errorf("expected expiry %v, got %v", want, *creds.ExpiresAt)
+		}
+		if !creds.IsValid() {
+			t.Error("current credentials should stay valid during grace period")
+		}
+	})
+
+	t.Run("keeps earlier expiry", func(t *testing.T) {
+		creds, _ := NewProviderCredentials(uuid.New(), EnvironmentSandbox)
+		soon := time.Now().Add(time.Hour)
+		creds.SetExpiration(soon)
+
+		if _, err := creds.Rotate(24 * time.Hour); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !creds.ExpiresAt.Equal(soon) {
+			t.Errorf("expected expiry to stay %v, got %v", soon, *creds.ExpiresAt)
+		}
+	})
+
+	t.Run("revoked credentials cannot rotate", func(t *testing.T) {
+		creds, _ := NewProviderCredentials(uuid.New(), EnvironmentSandbox)
+		creds.Revoke()
+
+		if _, err := creds.Rotate(time.Hour); err != ErrCredentialsRevoked {
+			t.Errorf("expected ErrCredentialsRevoked, got %v", err)
+		}
+	})
+}
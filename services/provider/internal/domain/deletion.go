@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Soft delete errors
+var (
+	ErrProviderNotDeleted = errors.New("provider is not deleted")
+	ErrLocationNotDeleted = errors.New("location is not deleted")
+	ErrInvalidRetention   = errors.New("deleted record retention must be positive")
+)
+
+// IsDeleted reports whether the provider is soft deleted
+func (p *Provider) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
+
+// IsDeleted reports whether the location is soft deleted
+func (l *Location) IsDeleted() bool {
+	return l.DeletedAt != nil
+}
+
+// PurgeCutoff returns the deletion time before which records are purged.
+// Deleted providers and locations are hidden from every query but kept, and
+// can be restored, until they have been deleted for longer than retention.
+func PurgeCutoff(now time.Time, retention time.Duration) (time.Time, error) {
+	if retention <= 0 {
+		return time.Time{}, ErrInvalidRetention
+	}
+	return now.Add(-retention), nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestPurgeCutoff(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      time.Time
+		wantErr   error
+	}{
+		{"thirty days", 30 * 24 * time.Hour, time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC), nil},
+		{"one hour", time.Hour, time.Date(2024, 6, 30, 11, 0, 0, 0, time.UTC), nil},
+		{"zero", 0, time.Time{}, ErrInvalidRetention},
+		{"negative", -time.Hour, time.Time{}, ErrInvalidRetention},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PurgeCutoff(now, tt.retention)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PurgeCutoff() error = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("PurgeCutoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDeleted(t *testing.T) {
+	provider, _ := NewProvider("Test", "TESTCO", "https://mfe.example.com", "https://api.example.com")
+	location := NewLocation(uuid.New(), "Test", "Address", "City", "State", 0, 0)
+	if provider.IsDeleted() || location.IsDeleted() {
+		t.Fatal("new provider or location is deleted")
+	}
+
+	now := time.Now().UTC()
+	provider.DeletedAt, location.DeletedAt = &now, &now
+	if !provider.IsDeleted() || !location.IsDeleted() {
+		t.Error("IsDeleted() = false with DeletedAt set")
+	}
+}
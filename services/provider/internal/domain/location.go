@@ -1,9 +1,22 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+)
+
+var (
+	// ErrInvalidLocation is returned for a location without a name or with a
+	// negative number of spaces
+	ErrInvalidLocation = errors.New("location needs a name and a non-negative number of spaces")
+	// ErrNoPricingSchedule is returned for a location billed at its flat rate
+	ErrNoPricingSchedule = errors.New("location has no pricing schedule")
+	// ErrNoOperatingHours is returned for a location open around the clock
+	ErrNoOperatingHours = errors.New("location has no operating hours")
 )
 
 // Location represents a parking location operated by a provider
@@ -20,9 +33,18 @@ type Location struct {
 	TotalSpaces int             `json:"total_spaces"`
 	Amenities   []string        `json:"amenities"`
 	Pricing     LocationPricing `json:"pricing"`
-	IsActive    bool            `json:"is_active"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	// PricingSchedule refines the flat rate by time of day and week
+	PricingSchedule *pricing.Schedule `json:"pricing_schedule,omitempty"`
+	// OperatingHours is when the location is open; without it, always
+	OperatingHours *pricing.Hours `json:"operating_hours,omitempty"`
+	// HeightLimitCm is the tallest vehicle the location takes; nil for no
+	// limit
+	HeightLimitCm *int      `json:"height_limit_cm,omitempty"`
+	IsActive      bool      `json:"is_active"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// DeletedAt is set while the location is soft deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // LocationPricing defines the pricing structure for a location
@@ -63,12 +85,73 @@ func (l *Location) SetPricing(hourlyRate, dailyMax float64) {
 	l.UpdatedAt = time.Now().UTC()
 }
 
+// SetPricingSchedule replaces the location's pricing schedule; nil removes
+// it, so every hour is billed at the flat rate
+func (l *Location) SetPricingSchedule(schedule *pricing.Schedule) error {
+	if schedule != nil {
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	l.PricingSchedule = schedule
+	l.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetOperatingHours replaces the location's operating hours; nil removes
+// them, so the location is always open
+func (l *Location) SetOperatingHours(hours *pricing.Hours) error {
+	if hours != nil {
+		if err := hours.Validate(); err != nil {
+			return err
+		}
+	}
+	l.OperatingHours = hours
+	l.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// SetAmenities replaces what the location offers and its height limit.
+// Amenities must be in the catalogue; repeats are dropped.
+func (l *Location) SetAmenities(amenities []string, heightLimitCm *int) error {
+	parsed, err := ParseAmenities(amenities)
+	if err != nil {
+		return err
+	}
+	if heightLimitCm != nil && *heightLimitCm <= 0 {
+		return ErrInvalidHeightLimit
+	}
+	l.Amenities = parsed
+	l.HeightLimitCm = heightLimitCm
+	l.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
 // AddAmenity adds an amenity to the location
 func (l *Location) AddAmenity(amenity string) {
 	l.Amenities = append(l.Amenities, amenity)
 	l.UpdatedAt = time.Now().UTC()
 }
 
+// UpdateDetails changes where the location is and how it is described.
+// Pricing is changed through pricing versions instead.
+func (l *Location) UpdateDetails(name, address, city, state, postalCode string, lat, lng float64, totalSpaces int) error {
+	name = strings.TrimSpace(name)
+	if name == "" || totalSpaces < 0 {
+		return ErrInvalidLocation
+	}
+	l.Name = name
+	l.Address = address
+	l.City = city
+	l.State = state
+	l.PostalCode = postalCode
+	l.Latitude = lat
+	l.Longitude = lng
+	l.TotalSpaces = totalSpaces
+	l.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
 // Deactivate disables the location
 func (l *Location) Deactivate() {
 	l.IsActive = false
@@ -1,9 +1,11 @@
 package domain
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
 )
 
 func TestNewLocation(t *testing.T) {
@@ -86,3 +88,63 @@ func TestLocation_Deactivate(t *testing.T) {
 		t.Error("location should be inactive after deactivation")
 	}
 }
+
+func TestLocation_UpdateDetails(t *testing.T) {
+	location := NewLocation(uuid.New(), "KLCC Parking", "Suria KLCC", "Kuala Lumpur", "Wilayah Persekutuan", 3.1579, 101.7116)
+	location.SetPricing(5, 30)
+
+	if err := location.UpdateDetails(" KLCC Basement ", "Suria KLCC B2", "Kuala Lumpur", "Wilayah Persekutuan", "50088", 3.158, 101.712, 400); err != nil {
+		t.Fatalf("UpdateDetails() error = %v", err)
+	}
+	if location.Name != "KLCC Basement" || location.TotalSpaces != 400 || location.PostalCode != "50088" {
+		t.Errorf("UpdateDetails() left %+v", location)
+	}
+	if location.Pricing.HourlyRate != 5 {
+		t.Errorf("UpdateDetails() changed pricing to %v", location.Pricing.HourlyRate)
+	}
+
+	if err := location.UpdateDetails(" ", "", "", "", "", 0, 0, 10); !errors.Is(err, ErrInvalidLocation) {
+		t.Errorf("UpdateDetails() without a name error = %v, want %v", err, ErrInvalidLocation)
+	}
+	if err := location.UpdateDetails("KLCC", "", "", "", "", 0, 0, -1); !errors.Is(err, ErrInvalidLocation) {
+		t.Errorf("UpdateDetails() with negative spaces error = %v, want %v", err, ErrInvalidLocation)
+	}
+}
+
+func TestLocation_SetPricingSchedule(t *testing.T) {
+	location := NewLocation(uuid.New(), "Test", "Address", "City", "State", 0, 0)
+
+	invalid := &pricing.Schedule{Timezone: "Asia/Kuala_Lumpur", Rules: []pricing.Rule{{Name: "peak", Kind: "tiered", Start: "08:00", End: "18:00"}}}
+	if err := location.SetPricingSchedule(invalid); !errors.Is(err, pricing.ErrInvalidRule) {
+		t.Fatalf("SetPricingSchedule() error = %v, want %v", err, pricing.ErrInvalidRule)
+	}
+	if location.PricingSchedule != nil {
+		t.Fatal("an invalid schedule should not be set")
+	}
+
+	schedule := &pricing.Schedule{Timezone: "Asia/Kuala_Lumpur", Rules: []pricing.Rule{{Name: "peak", Kind: pricing.RuleHourly, Start: "08:00", End: "18:00"}}}
+	if err := location.SetPricingSchedule(schedule); err != nil {
+		t.Fatalf("SetPricingSchedule() error = %v", err)
+	}
+	if location.PricingSchedule != schedule {
+		t.Error("expected the schedule to be set")
+	}
+
+	if err := location.SetPricingSchedule(nil); err != nil || location.PricingSchedule != nil {
+		t.Errorf("SetPricingSchedule(nil) = %v, want the schedule removed", err)
+	}
+}
+
+func TestLocation_SetOperatingHours(t *testing.T) {
+	location := NewLocation(uuid.New(), "Test", "Address", "City", "State", 0, 0)
+
+	hours := &pricing.Hours{Timezone: "Asia/Kuala_Lumpur", Windows: []pricing.OpeningWindow{{Day: "someday", Open: "07:00", Close: "23:00"}}}
+	if err := location.SetOperatingHours(hours); !errors.Is(err, pricing.ErrInvalidHours) {
+		t.Fatalf("SetOperatingHours() error = %v, want %v", err, pricing.ErrInvalidHours)
+	}
+
+	hours.Windows[0].Day = "mon"
+	if err := location.SetOperatingHours(hours); err != nil || location.OperatingHours != hours {
+		t.Errorf("SetOperatingHours() = %v, want the hours set", err)
+	}
+}
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Logo errors
+var (
+	ErrUnsupportedLogoFormat = errors.New("logo must be a PNG or JPEG image")
+	ErrLogoTooLarge          = errors.New("logo file is too large")
+	ErrInvalidLogoDimensions = errors.New("logo dimensions are out of range")
+	ErrInvalidLogoURL        = errors.New("invalid logo URL")
+	ErrLogosUnavailable      = errors.New("logo storage is not configured")
+)
+
+// Logo limits. Logos are shown in lists and on the provider's page, so very
+// wide or very small images are refused.
+const (
+	MaxLogoBytes = 2 << 20
+	MinLogoSide  = 128
+	MaxLogoSide  = 4096
+	// MaxLogoAspect is how many times longer one side may be than the other
+	MaxLogoAspect = 4
+)
+
+// LogoVariantSizes are the boxes, in pixels, each logo is resized to fit.
+// LogoDefaultSize is the variant the provider's logo URL points at.
+var LogoVariantSizes = []int{64, 128, 256, 512}
+
+const LogoDefaultSize = 256
+
+// logoFormats maps the accepted content types to the image format decoders
+// report
+var logoFormats = map[string]string{
+	"image/png":  "png",
+	"image/jpeg": "jpeg",
+}
+
+// ValidateLogo checks an uploaded logo's content type, the format its data
+// decodes as and its dimensions
+func ValidateLogo(contentType, format string, width, height int) error {
+	want, ok := logoFormats[contentType]
+	if !ok || format != want {
+		return ErrUnsupportedLogoFormat
+	}
+	short, long := min(width, height), max(width, height)
+	if short < MinLogoSide || long > MaxLogoSide || long > short*MaxLogoAspect {
+		return ErrInvalidLogoDimensions
+	}
+	return nil
+}
+
+// LogoVersion names a logo by its content, so uploading the same image
+// again reuses its objects and each new logo gets new URLs the CDN has not
+// cached
+func LogoVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// LogoKey is where a variant of a provider's logo is stored
+func LogoKey(providerID uuid.UUID, version string, size int) string {
+	return fmt.Sprintf("logos/%s/%s/%d.png", providerID, version, size)
+}
+
+// ResizeLogo scales img to fit a size by size box, keeping its aspect
+// ratio. Images that already fit are copied unscaled. Each output pixel is
+// the average of the source pixels it covers.
+func ResizeLogo(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dw, dh := sw, sh
+	if sw > size || sh > size {
+		if sw >= sh {
+			dw, dh = size, max(1, sh*size/sw)
+		} else {
+			dw, dh = max(1, sw*size/sh), size
+		}
+	}
+
+	// Averaging premultiplied colours keeps transparent edges from darkening
+	src := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+	if dw == sw && dh == sh {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		y0, y1 := y*sh/dh, max((y+1)*sh/dh, y*sh/dh+1)
+		for x := 0; x < dw; x++ {
+			x0, x1 := x*sw/dw, max((x+1)*sw/dw, x*sw/dw+1)
+
+			var r, g, b, a, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r, g, b, a = r+int(p[0]), g+int(p[1]), b+int(p[2]), a+int(p[3])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// SetLogo points the provider at a new logo
+func (p *Provider) SetLogo(logoURL string) error {
+	if !isValidURL(logoURL) {
+		return ErrInvalidLogoURL
+	}
+	p.LogoURL = logoURL
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
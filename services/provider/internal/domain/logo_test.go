@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestValidateLogo(t *testing.T) {
+	tests := []struct {
+		name          string
+		contentType   string
+		format        string
+		width, height int
+		wantErr       error
+	}{
+		{"square png", "image/png", "png", 512, 512, nil},
+		{"wide jpeg", "image/jpeg", "jpeg", 1600, 400, nil},
+		{"webp", "image/webp", "webp", 512, 512, ErrUnsupportedLogoFormat},
+		{"png sent as jpeg", "image/jpeg", "png", 512, 512, ErrUnsupportedLogoFormat},
+		{"too small", "image/png", "png", 100, 100, ErrInvalidLogoDimensions},
+		{"too large", "image/png", "png", 5000, 5000, ErrInvalidLogoDimensions},
+		{"too wide", "image/png", "png", 2000, 400, ErrInvalidLogoDimensions},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateLogo(tt.contentType, tt.format, tt.width, tt.height); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateLogo() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResizeLogo(t *testing.T) {
+	// Left half red, right half transparent
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 200; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+
+	tests := []struct {
+		size          int
+		width, height int
+	}{
+		{512, 400, 200},
+		{256, 256, 128},
+		{64, 64, 32},
+	}
+	for _, tt := range tests {
+		got := ResizeLogo(img, tt.size)
+		if got.Bounds().Dx() != tt.width || got.Bounds().Dy() != tt.height {
+			t.Errorf("ResizeLogo(%d) = %v, want %dx%d", tt.size, got.Bounds(), tt.width, tt.height)
+		}
+		if left := got.RGBAAt(0, 0); left.R != 255 || left.A != 255 {
+			t.Errorf("ResizeLogo(%d) left = %v, want opaque red", tt.size, left)
+		}
+		if right := got.RGBAAt(tt.width-1, 0); right.A != 0 {
+			t.Errorf("ResizeLogo(%d) right = %v, want transparent", tt.size, right)
+		}
+	}
+
+	// A pixel covering both halves averages them
+	if mid := ResizeLogo(img, 1).RGBAAt(0, 0); mid.R != 127 || mid.A != 127 {
+		t.Errorf("ResizeLogo(1) = %v, want half-transparent red", mid)
+	}
+}
+
+func TestProvider_SetLogo(t *testing.T) {
+	provider, _ := NewProvider("Test", "TEST", "https://mfe.example.com", "https://api.example.com")
+
+	if err := provider.SetLogo("https://cdn.example.com/logos/a/b/256.png"); err != nil {
+		t.Fatalf("SetLogo() error = %v", err)
+	}
+	if provider.LogoURL != "https://cdn.example.com/logos/a/b/256.png" {
+		t.Errorf("LogoURL = %s", provider.LogoURL)
+	}
+	if err := provider.SetLogo("logos/a/b/256.png"); !errors.Is(err, ErrInvalidLogoURL) {
+		t.Errorf("SetLogo() of a relative URL error = %v, want %v", err, ErrInvalidLogoURL)
+	}
+}
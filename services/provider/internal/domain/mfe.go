@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrInvalidMFEVersion    = errors.New("MFE version must be semantic (major.minor.patch)")
+	ErrInvalidMFECapability = errors.New("invalid MFE capability")
+)
+
+var (
+	semverPattern     = regexp.MustCompile(`^\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?$`)
+	capabilityPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z][a-z0-9_]*)*$`)
+)
+
+// MFEModule describes the provider's published micro-frontend release.
+// Capabilities name the shell app features the module needs, such as
+// "wallet.pay" or "location", so older shells can skip modules they can't
+// host.
+type MFEModule struct {
+	Version      string   `json:"version,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+	// HealthURL is probed before the shell loads the module; the MFE URL is
+	// probed when it is empty
+	HealthURL string `json:"health_url,omitempty"`
+}
+
+// PublishMFE points the provider at a new MFE release
+func (p *Provider) PublishMFE(mfeURL string, module MFEModule) error {
+	if !isValidURL(mfeURL) {
+		return ErrInvalidMFEURL
+	}
+	if module.HealthURL != "" && !isValidURL(module.HealthURL) {
+		return ErrInvalidMFEURL
+	}
+	if !semverPattern.MatchString(module.Version) {
+		return ErrInvalidMFEVersion
+	}
+	seen := make(map[string]bool, len(module.Capabilities))
+	capabilities := make([]string, 0, len(module.Capabilities))
+	for _, c := range module.Capabilities {
+		if !capabilityPattern.MatchString(c) {
+			return ErrInvalidMFECapability
+		}
+		if !seen[c] {
+			seen[c] = true
+			capabilities = append(capabilities, c)
+		}
+	}
+	module.Capabilities = capabilities
+
+	p.MFEURL = mfeURL
+	p.Config.MFE = module
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProvider_PublishMFE(t *testing.T) {
+	tests := []struct {
+		name    string
+		mfeURL  string
+		module  MFEModule
+		wantErr error
+	}{
+		{
+			name:   "release",
+			mfeURL: "https://mfe.example.com/v2/remoteEntry.js",
+			module: MFEModule{Version: "2.1.0", Capabilities: []string{"wallet.pay", "location"}},
+		},
+		{
+			name:   "pre-release with health URL",
+			mfeURL: "https://mfe.example.com/remoteEntry.js",
+			module: MFEModule{Version: "3.0.0-rc.1", HealthURL: "https://mfe.example.com/health"},
+		},
+		{
+			name:    "invalid MFE URL",
+			mfeURL:  "mfe.example.com",
+			module:  MFEModule{Version: "1.0.0"},
+			wantErr: ErrInvalidMFEURL,
+		},
+		{
+			name:    "invalid health URL",
+			mfeURL:  "https://mfe.example.com",
+			module:  MFEModule{Version: "1.0.0", HealthURL: "ftp://mfe.example.com"},
+			wantErr: ErrInvalidMFEURL,
+		},
+		{
+			name:    "missing version",
+			mfeURL:  "https://mfe.example.com",
+			wantErr: ErrInvalidMFEVersion,
+		},
+		{
+			name:    "non-semantic version",
+			mfeURL:  "https://mfe.example.com",
+			module:  MFEModule{Version: "v2"},
+			wantErr: ErrInvalidMFEVersion,
+		},
+		{
+			name:    "invalid capability",
+			mfeURL:  "https://mfe.example.com",
+			module:  MFEModule{Version: "1.0.0", Capabilities: []string{"Wallet Pay"}},
+			wantErr: ErrInvalidMFECapability,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, _ := NewProvider("Test", "test", "https://old.example.com", "https://api.example.com")
+
+			err := provider.PublishMFE(tt.mfeURL, tt.module)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublishMFE() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if provider.MFEURL != "https://old.example.com" {
+					t.Errorf("MFEURL = %s, want it unchanged", provider.MFEURL)
+				}
+				return
+			}
+			if provider.MFEURL != tt.mfeURL || provider.Config.MFE.Version != tt.module.Version {
+				t.Errorf("PublishMFE() left %s at %s", provider.MFEURL, provider.Config.MFE.Version)
+			}
+		})
+	}
+}
+
+func TestProvider_PublishMFE_DeduplicatesCapabilities(t *testing.T) {
+	provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
+
+	module := MFEModule{Version: "1.0.0", Capabilities: []string{"wallet.pay", "camera", "wallet.pay"}}
+	if err := provider.PublishMFE("https://mfe.example.com", module); err != nil {
+		t.Fatalf("PublishMFE() error = %v", err)
+	}
+
+	want := []string{"wallet.pay", "camera"}
+	if !reflect.DeepEqual(provider.Config.MFE.Capabilities, want) {
+		t.Errorf("Capabilities = %v, want %v", provider.Config.MFE.Capabilities, want)
+	}
+}
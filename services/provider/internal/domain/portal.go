@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CanManage reports whether a developer signed in with these credentials may
+// manage the target credentials. Sandbox keys only reach other sandbox keys so
+// a leaked test key cannot touch production access.
+func (c *ProviderCredentials) CanManage(target *ProviderCredentials) bool {
+	if c.ProviderID != target.ProviderID {
+		return false
+	}
+	return c.Environment == EnvironmentProduction || target.Environment == EnvironmentSandbox
+}
+
+// WebhookDeliveryStatus is the outcome of a single webhook delivery attempt
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery records one attempt to deliver an event to a provider endpoint
+type WebhookDelivery struct {
+	ID           uuid.UUID             `json:"id"`
+	ProviderID   uuid.UUID             `json:"provider_id"`
+	EventID      *uuid.UUID            `json:"event_id,omitempty"`
+	EventType    string                `json:"event_type"`
+	URL          string                `json:"url"`
+	Attempt      int                   `json:"attempt"`
+	Status       WebhookDeliveryStatus `json:"status"`
+	ResponseCode int                   `json:"response_code,omitempty"`
+	Error        string                `json:"error,omitempty"`
+	DurationMs   int64                 `json:"duration_ms"`
+	CreatedAt    time.Time             `json:"created_at"`
+}
+
+// NewWebhookDelivery records the result of a delivery attempt. A transport
+// error or a non-2xx response marks the attempt as failed.
+func NewWebhookDelivery(providerID uuid.UUID, eventType, url string, attempt, responseCode int, err error, duration time.Duration) *WebhookDelivery {
+	d := &WebhookDelivery{
+		ID:           uuid.New(),
+		ProviderID:   providerID,
+		EventType:    eventType,
+		URL:          url,
+		Attempt:      attempt,
+		Status:       WebhookDeliverySucceeded,
+		ResponseCode: responseCode,
+		DurationMs:   duration.Milliseconds(),
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err != nil {
+		d.Status = WebhookDeliveryFailed
+		d.Error = err.Error()
+	} else if responseCode < 200 || responseCode >= 300 {
+		d.Status = WebhookDeliveryFailed
+	}
+	return d
+}
+
+// APIErrorLog records a failed API call made with a provider's credentials so
+// partner developers can debug their integration without asking ops for logs
+type APIErrorLog struct {
+	ID            uuid.UUID `json:"id"`
+	ProviderID    uuid.UUID `json:"provider_id"`
+	CredentialsID uuid.UUID `json:"credentials_id"`
+	Method        string    `json:"method"`
+	Path          string    `json:"path"`
+	StatusCode    int       `json:"status_code"`
+	ErrorCode     string    `json:"error_code,omitempty"`
+	Message       string    `json:"message,omitempty"`
+	RequestID     string    `json:"request_id,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// NewAPIErrorLog creates an error log entry for a request
+func NewAPIErrorLog(creds *ProviderCredentials, method, path string, statusCode int, errorCode, message, requestID string) *APIErrorLog {
+	return &APIErrorLog{
+		ID:            uuid.New(),
+		ProviderID:    creds.ProviderID,
+		CredentialsID: creds.ID,
+		Method:        method,
+		Path:          path,
+		StatusCode:    statusCode,
+		ErrorCode:     errorCode,
+		Message:       message,
+		RequestID:     requestID,
+		CreatedAt:     time.Now().UTC(),
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestProviderCredentials_CanManage(t *testing.T) {
+	providerID := uuid.New()
+	sandbox, _ := NewProviderCredentials(providerID, EnvironmentSandbox)
+	production, _ := NewProviderCredentials(providerID, EnvironmentProduction)
+	other, _ := NewProviderCredentials(uuid.New(), EnvironmentSandbox)
+
+	tests := []struct {
+		name   string
+		caller *ProviderCredentials
+		target *ProviderCredentials
+		want   bool
+	}{
+		{"sandbox manages sandbox", sandbox, sandbox, true},
+		{"sandbox cannot manage production", sandbox, production, false},
+		{"production manages production", production, production, true},
+		{"production manages sandbox", production, sandbox, true},
+		{"other provider", production, other, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.caller.CanManage(tt.target); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewWebhookDelivery(t *testing.T) {
+	providerID := uuid.New()
+
+	tests := []struct {
+		name         string
+		responseCode int
+		err          error
+		wantStatus   WebhookDeliveryStatus
+		wantError    string
+	}{
+		{"2xx succeeds", 200, nil, WebhookDeliverySucceeded, ""},
+		{"5xx fails", 503, nil, WebhookDeliveryFailed, ""},
+		{"transport error fails", 0, errors.New("connection refused"), WebhookDeliveryFailed, "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewWebhookDelivery(providerID, "session.completed", "https://example.com/hook", 1, tt.responseCode, tt.err, 120*time.Millisecond)
+
+			if d.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, d.Status)
+			}
+			if d.Error != tt.wantError {
+				t.Errorf("expected error %q, got %q", tt.wantError, d.Error)
+			}
+			if d.DurationMs != 120 {
+				t.Errorf("expected duration 120ms, got %d", d.DurationMs)
+			}
+			if d.ProviderID != providerID {
+				t.Errorf("expected provider ID %v, got %v", providerID, d.ProviderID)
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorLog(t *testing.T) {
+	creds, _ := NewProviderCredentials(uuid.New(), EnvironmentSandbox)
+
+	entry := NewAPIErrorLog(creds, "POST", "/api/v1/portal/credentials/sandbox", 409, "SANDBOX_CREDENTIAL_LIMIT", "limit reached", "req-1")
+
+	if entry.ProviderID != creds.ProviderID {
+		t.Errorf("expected provider ID %v, got %v", creds.ProviderID, entry.ProviderID)
+	}
+	if entry.CredentialsID != creds.ID {
+		t.Errorf("expected credentials ID %v, got %v", creds.ID, entry.CredentialsID)
+	}
+	if entry.StatusCode != 409 || entry.ErrorCode != "SANDBOX_CREDENTIAL_LIMIT" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
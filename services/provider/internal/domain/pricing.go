@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrLocationNotOwned      = errors.New("location does not belong to provider")
+	ErrInvalidPricing        = errors.New("hourly rate and daily max must not be negative")
+	ErrInvalidEffectiveFrom  = errors.New("effective date cannot be in the past")
+	ErrEmptyPricingUpdate    = errors.New("pricing update must include at least one location")
+	ErrDuplicatePricingEntry = errors.New("pricing update contains the same location more than once")
+	ErrPricingNotFound       = errors.New("no pricing effective at the requested time")
+)
+
+// PricingVersion is an immutable snapshot of a location's pricing that applies
+// from EffectiveFrom until a later version supersedes it. Sessions are billed
+// against the version in effect at their entry time.
+type PricingVersion struct {
+	ID             uuid.UUID `json:"id"`
+	LocationID     uuid.UUID `json:"location_id"`
+	HourlyRate     float64   `json:"hourly_rate"`
+	DailyMax       float64   `json:"daily_max"`
+	Currency       string    `json:"currency"`
+	GracePeriodMin int       `json:"grace_period_min"`
+	// PricingSchedule is the schedule refining the hourly rate, if any
+	PricingSchedule *pricing.Schedule `json:"pricing_schedule,omitempty"`
+	EffectiveFrom   time.Time         `json:"effective_from"`
+	CreatedBy       string            `json:"created_by,omitempty"`
+	AppliedAt       *time.Time        `json:"applied_at,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// NewPricingVersion creates a pricing version for a location. Currency, grace
+// period and schedule are carried over from the location's current pricing.
+func NewPricingVersion(location *Location, hourlyRate, dailyMax float64, effectiveFrom time.Time) (*PricingVersion, error) {
+	if hourlyRate < 0 || dailyMax < 0 {
+		return nil, ErrInvalidPricing
+	}
+
+	return &PricingVersion{
+		ID:              uuid.New(),
+		LocationID:      location.ID,
+		HourlyRate:      hourlyRate,
+		DailyMax:        dailyMax,
+		Currency:        location.Pricing.Currency,
+		GracePeriodMin:  location.Pricing.GracePeriodMin,
+		PricingSchedule: location.PricingSchedule,
+		EffectiveFrom:   effectiveFrom.UTC(),
+		CreatedAt:       time.Now().UTC(),
+	}, nil
+}
+
+// Pricing returns the version as a LocationPricing value
+func (v *PricingVersion) Pricing() LocationPricing {
+	return LocationPricing{
+		HourlyRate:     v.HourlyRate,
+		DailyMax:       v.DailyMax,
+		Currency:       v.Currency,
+		GracePeriodMin: v.GracePeriodMin,
+	}
+}
+
+// Tariff returns what the version charges
+func (v *PricingVersion) Tariff() pricing.Tariff {
+	return pricing.Tariff{
+		HourlyRate: decimal.NewFromFloat(v.HourlyRate),
+		DailyMax:   decimal.NewFromFloat(v.DailyMax),
+		Schedule:   v.PricingSchedule,
+	}
+}
+
+// IsEffectiveAt reports whether the version has taken effect at the given time
+func (v *PricingVersion) IsEffectiveAt(at time.Time) bool {
+	return !v.EffectiveFrom.After(at)
+}
+
+// MarkApplied records that the version has been copied onto the location
+func (v *PricingVersion) MarkApplied(at time.Time) {
+	applied := at.UTC()
+	v.AppliedAt = &applied
+}
+
+// ResolvePricing picks the version in effect at the given time: the one with the
+// latest EffectiveFrom that is not after it. Ties go to the most recently created.
+func ResolvePricing(versions []*PricingVersion, at time.Time) (*PricingVersion, error) {
+	var current *PricingVersion
+	for _, v := range versions {
+		if !v.IsEffectiveAt(at) {
+			continue
+		}
+		if current == nil ||
+			v.EffectiveFrom.After(current.EffectiveFrom) ||
+			(v.EffectiveFrom.Equal(current.EffectiveFrom) && v.CreatedAt.After(current.CreatedAt)) {
+			current = v
+		}
+	}
+	if current == nil {
+		return nil, ErrPricingNotFound
+	}
+	return current, nil
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewPricingVersion(t *testing.T) {
+	location := NewLocation(uuid.New(), "KLCC", "Jalan Ampang", "Kuala Lumpur", "WP", 3.15, 101.71)
+	effectiveFrom := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		hourlyRate float64
+		dailyMax   float64
+		wantErr    error
+	}{
+		{"valid pricing", 3.50, 25.00, nil},
+		{"free parking", 0, 0, nil},
+		{"negative hourly rate", -1, 25.00, ErrInvalidPricing},
+		{"negative daily max", 3.50, -1, ErrInvalidPricing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := NewPricingVersion(location, tt.hourlyRate, tt.dailyMax, effectiveFrom)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if version.LocationID != location.ID {
+				t.Errorf("expected location ID %v, got %v", location.ID, version.LocationID)
+			}
+			if version.Currency != location.Pricing.Currency {
+				t.Errorf("expected currency %s, got %s", location.Pricing.Currency, version.Currency)
+			}
+			if version.GracePeriodMin != location.Pricing.GracePeriodMin {
+				t.Errorf("expected grace period %d, got %d", location.Pricing.GracePeriodMin, version.GracePeriodMin)
+			}
+			if version.AppliedAt != nil {
+				t.Error("expected new version to be unapplied")
+			}
+		})
+	}
+}
+
+func TestResolvePricing(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	original := &PricingVersion{HourlyRate: 2.00, EffectiveFrom: base, CreatedAt: base}
+	increase := &PricingVersion{HourlyRate: 3.00, EffectiveFrom: base.Add(48 * time.Hour), CreatedAt: base.Add(time.Hour)}
+	correction := &PricingVersion{HourlyRate: 2.80, EffectiveFrom: base.Add(48 * time.Hour), CreatedAt: base.Add(2 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		versions []*PricingVersion
+		at       time.Time
+		want     *PricingVersion
+		wantErr  error
+	}{
+		{"before any version", []*PricingVersion{original}, base.Add(-time.Minute), nil, ErrPricingNotFound},
+		{"at effective time", []*PricingVersion{original, increase}, base, original, nil},
+		{"session entered before increase", []*PricingVersion{increase, original}, base.Add(47 * time.Hour), original, nil},
+		{"session entered after increase", []*PricingVersion{original, increase}, base.Add(48 * time.Hour), increase, nil},
+		{"later submission wins a tie", []*PricingVersion{correction, original, increase}, base.Add(72 * time.Hour), correction, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolvePricing(tt.versions, tt.at)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected version %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPricingVersion_ScheduleChangedMidSession(t *testing.T) {
+	location := NewLocation(uuid.New(), "KLCC", "Jalan Ampang", "Kuala Lumpur", "WP", 3.15, 101.71)
+	location.SetPricing(2.00, 0)
+	peak := func(rate string) *pricing.Schedule {
+		return &pricing.Schedule{Timezone: "UTC", Rules: []pricing.Rule{
+			{Name: "peak", Kind: pricing.RuleHourly, Start: "08:00", End: "18:00", HourlyRate: decimal.RequireFromString(rate)},
+		}}
+	}
+
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := location.SetPricingSchedule(peak("4.00")); err != nil {
+		t.Fatalf("SetPricingSchedule() error = %v", err)
+	}
+	original, err := NewPricingVersion(location, 2.00, 0, base)
+	if err != nil {
+		t.Fatalf("NewPricingVersion() error = %v", err)
+	}
+
+	// The peak rate doubles at noon, while a car that entered at 09:00 is parked
+	if err := location.SetPricingSchedule(peak("8.00")); err != nil {
+		t.Fatalf("SetPricingSchedule() error = %v", err)
+	}
+	changed, err := NewPricingVersion(location, 2.00, 0, base.Add(12*time.Hour))
+	if err != nil {
+		t.Fatalf("NewPricingVersion() error = %v", err)
+	}
+	versions := []*PricingVersion{original, changed}
+
+	entry, exit := base.Add(9*time.Hour), base.Add(14*time.Hour)
+	atEntry, err := ResolvePricing(versions, entry)
+	if err != nil {
+		t.Fatalf("ResolvePricing() error = %v", err)
+	}
+	if got := atEntry.Tariff().Price(entry, exit); !got.Equal(decimal.RequireFromString("20.00")) {
+		t.Errorf("charge for a session entered before the change = %s, want 20.00 at the old peak rate", got)
+	}
+
+	later := base.Add(13 * time.Hour)
+	afterChange, err := ResolvePricing(versions, later)
+	if err != nil {
+		t.Fatalf("ResolvePricing() error = %v", err)
+	}
+	if got := afterChange.Tariff().Price(later, later.Add(time.Hour)); !got.Equal(decimal.RequireFromString("8.00")) {
+		t.Errorf("charge for a session entered after the change = %s, want 8.00", got)
+	}
+}
+
+func TestPricingVersion_MarkApplied(t *testing.T) {
+	version := &PricingVersion{}
+	now := time.Now()
+
+	version.MarkApplied(now)
+
+	if version.AppliedAt == nil || !version.AppliedAt.Equal(now) {
+		t.Errorf("expected applied at %v, got %v", now, version.AppliedAt)
+	}
+}
@@ -15,32 +15,43 @@ var (
 	ErrInvalidWebhookURL     = errors.New("invalid webhook URL")
 	ErrInvalidMFEURL         = errors.New("invalid MFE URL")
 	ErrProviderInactive      = errors.New("provider is inactive")
+	ErrProviderNotPending    = errors.New("provider is not pending approval")
+	ErrProviderNotSuspended  = errors.New("provider is not suspended")
+	ErrReasonRequired        = errors.New("reason is required")
+	ErrInvalidReactivation   = errors.New("reactivation date must be in the future")
 )
 
 // ProviderStatus represents the operational status of a parking provider
 type ProviderStatus string
 
 const (
-	ProviderStatusActive   ProviderStatus = "active"
-	ProviderStatusInactive ProviderStatus = "inactive"
-	ProviderStatusPending  ProviderStatus = "pending"
+	ProviderStatusActive    ProviderStatus = "active"
+	ProviderStatusInactive  ProviderStatus = "inactive"
+	ProviderStatusPending   ProviderStatus = "pending"
+	ProviderStatusRejected  ProviderStatus = "rejected"
+	ProviderStatusSuspended ProviderStatus = "suspended"
 )
 
 // Provider represents a parking provider that integrates with the super app.
 // Each provider operates their own parking infrastructure and exposes it via MFE.
 type Provider struct {
-	ID            uuid.UUID      `json:"id"`
-	Name          string         `json:"name"`
-	Code          string         `json:"code"`
-	Description   string         `json:"description"`
-	LogoURL       string         `json:"logo_url,omitempty"`
-	Status        ProviderStatus `json:"status"`
-	MFEURL        string         `json:"mfe_url"`
-	APIBaseURL    string         `json:"api_base_url"`
-	WebhookSecret string         `json:"-"`
-	Config        ProviderConfig `json:"config"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID             uuid.UUID      `json:"id"`
+	Name           string         `json:"name"`
+	Code           string         `json:"code"`
+	Description    string         `json:"description"`
+	LogoURL        string         `json:"logo_url,omitempty"`
+	Status         ProviderStatus `json:"status"`
+	StatusReason   string         `json:"status_reason,omitempty"`
+	SuspendedUntil *time.Time     `json:"suspended_until,omitempty"`
+	MFEURL         string         `json:"mfe_url"`
+	APIBaseURL     string         `json:"api_base_url"`
+	WebhookURL     string         `json:"webhook_url,omitempty"`
+	WebhookSecret  string         `json:"-"`
+	Config         ProviderConfig `json:"config"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	// DeletedAt is set while the provider is soft deleted
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
 }
 
 // ProviderConfig holds provider-specific configuration
@@ -50,6 +61,7 @@ type ProviderConfig struct {
 	RequiresPlateValidation bool              `json:"requires_plate_validation"`
 	Features                map[string]bool   `json:"features"`
 	CustomSettings          map[string]string `json:"custom_settings"`
+	MFE                     MFEModule         `json:"mfe"`
 }
 
 // NewProvider creates a new provider with default values
@@ -98,6 +110,72 @@ func (p *Provider) Deactivate() {
 	p.UpdatedAt = time.Now().UTC()
 }
 
+// IsPending checks if provider is awaiting admin approval
+func (p *Provider) IsPending() bool {
+	return p.Status == ProviderStatusPending
+}
+
+// Approve moves a pending provider to active
+func (p *Provider) Approve() error {
+	if !p.IsPending() {
+		return ErrProviderNotPending
+	}
+	p.Status = ProviderStatusActive
+	p.StatusReason = ""
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Reject declines a pending provider application
+func (p *Provider) Reject(reason string) error {
+	if !p.IsPending() {
+		return ErrProviderNotPending
+	}
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	p.Status = ProviderStatusRejected
+	p.StatusReason = reason
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Suspend temporarily disables a provider. If until is set, the provider
+// becomes eligible for automatic reactivation at that time.
+func (p *Provider) Suspend(reason string, until *time.Time) error {
+	if reason == "" {
+		return ErrReasonRequired
+	}
+	if until != nil && !until.After(time.Now()) {
+		return ErrInvalidReactivation
+	}
+	p.Status = ProviderStatusSuspended
+	p.StatusReason = reason
+	p.SuspendedUntil = until
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// Reactivate lifts a suspension and returns the provider to active
+func (p *Provider) Reactivate() error {
+	if p.Status != ProviderStatusSuspended {
+		return ErrProviderNotSuspended
+	}
+	p.Status = ProviderStatusActive
+	p.StatusReason = ""
+	p.SuspendedUntil = nil
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// IsDueForReactivation checks if a scheduled suspension has elapsed
+func (p *Provider) IsDueForReactivation(now time.Time) bool {
+	if p.Status != ProviderStatusSuspended || p.SuspendedUntil == nil {
+		return false
+	}
+	return !p.SuspendedUntil.After(now)
+}
+
 // SetWebhookSecret sets the webhook secret for signature verification
 func (p *Provider) SetWebhookSecret(secret string) {
 	p.WebhookSecret = secret
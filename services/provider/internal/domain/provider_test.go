@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -109,6 +110,104 @@ func TestProvider_Deactivate(t *testing.T) {
 	}
 }
 
+func TestProvider_Approve(t *testing.T) {
+	provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
+
+	if err := provider.Approve(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Status != ProviderStatusActive {
+		t.Errorf("expected status active, got %s", provider.Status)
+	}
+
+	if err := provider.Approve(); err != ErrProviderNotPending {
+		t.Errorf("expected ErrProviderNotPending, got %v", err)
+	}
+}
+
+func TestProvider_Reject(t *testing.T) {
+	provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
+
+	if err := provider.Reject(""); err != ErrReasonRequired {
+		t.Errorf("expected ErrReasonRequired, got %v", err)
+	}
+
+	if err := provider.Reject("incomplete documents"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if provider.Status != ProviderStatusRejected {
+		t.Errorf("expected status rejected, got %s", provider.Status)
+	}
+	if provider.StatusReason != "incomplete documents" {
+		t.Errorf("expected reason to be recorded, got %s", provider.StatusReason)
+	}
+
+	if err := provider.Approve(); err != ErrProviderNotPending {
+		t.Errorf("expected ErrProviderNotPending, got %v", err)
+	}
+}
+
+func TestProvider_Suspend(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		reason  string
+		until   *time.Time
+		wantErr error
+	}{
+		{"indefinite suspension", "fraud investigation", nil, nil},
+		{"scheduled reactivation", "maintenance", &future, nil},
+		{"missing reason", "", &future, ErrReasonRequired},
+		{"reactivation in the past", "maintenance", &past, ErrInvalidReactivation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
+			provider.Activate()
+
+			err := provider.Suspend(tt.reason, tt.until)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr == nil && provider.Status != ProviderStatusSuspended {
+				t.Errorf("expected status suspended, got %s", provider.Status)
+			}
+		})
+	}
+}
+
+func TestProvider_Reactivate(t *testing.T) {
+	provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
+	provider.Activate()
+
+	if err := provider.Reactivate(); err != ErrProviderNotSuspended {
+		t.Errorf("expected ErrProviderNotSuspended, got %v", err)
+	}
+
+	until := time.Now().Add(time.Hour)
+	provider.Suspend("maintenance", &until)
+
+	if provider.IsDueForReactivation(time.Now()) {
+		t.Error("should not be due before the reactivation date")
+	}
+	if !provider.IsDueForReactivation(until.Add(time.Minute)) {
+		t.Error("should be due after the reactivation date")
+	}
+
+	if err := provider.Reactivate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !provider.IsActive() {
+		t.Error("provider should be active after reactivation")
+	}
+	if provider.SuspendedUntil != nil {
+		t.Error("expected suspended_until to be cleared")
+	}
+}
+
 func TestProvider_UpdateMFEURL(t *testing.T) {
 	provider, _ := NewProvider("Test", "test", "https://mfe.example.com", "https://api.example.com")
 
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidReportPeriod is returned for a session report period that is
+// empty or too long
+var ErrInvalidReportPeriod = errors.New("report period must be between 1 and 92 days")
+
+// MaxReportPeriod bounds a session report so it stays cheap to build
+const MaxReportPeriod = 92 * 24 * time.Hour
+
+// ProviderSessionStatus is how a session at a provider's location finished
+type ProviderSessionStatus string
+
+const (
+	ProviderSessionEnded     ProviderSessionStatus = "ended"
+	ProviderSessionCancelled ProviderSessionStatus = "cancelled"
+)
+
+// ProviderSession is a finished parking session at one of a provider's
+// locations, recorded from the parking service's events for the provider's
+// reports. The driver's identity is not kept.
+type ProviderSession struct {
+	SessionID         uuid.UUID             `json:"session_id"`
+	ProviderID        uuid.UUID             `json:"provider_id"`
+	LocationID        uuid.UUID             `json:"location_id"`
+	ExternalSessionID string                `json:"external_session_id,omitempty"`
+	VehiclePlate      string                `json:"vehicle_plate"`
+	Status            ProviderSessionStatus `json:"status"`
+	EntryTime         time.Time             `json:"entry_time"`
+	ExitTime          *time.Time            `json:"exit_time,omitempty"`
+	DurationMinutes   int                   `json:"duration_minutes"`
+	Amount            decimal.Decimal       `json:"amount"`
+	Currency          string                `json:"currency"`
+	PaymentID         string                `json:"payment_id,omitempty"`
+	RecordedAt        time.Time             `json:"recorded_at"`
+}
+
+// SessionFilter selects the sessions in a report: those that entered in
+// [From, To), optionally at one location
+type SessionFilter struct {
+	From       time.Time
+	To         time.Time
+	LocationID *uuid.UUID
+}
+
+// NewSessionFilter checks the report period
+func NewSessionFilter(from, to time.Time, locationID *uuid.UUID) (SessionFilter, error) {
+	if !to.After(from) || to.Sub(from) > MaxReportPeriod {
+		return SessionFilter{}, ErrInvalidReportPeriod
+	}
+	return SessionFilter{From: from, To: to, LocationID: locationID}, nil
+}
+
+// SessionTotals sums up the sessions in a report
+type SessionTotals struct {
+	Sessions  int             `json:"sessions"`
+	Cancelled int             `json:"cancelled"`
+	Minutes   int             `json:"minutes"`
+	Revenue   decimal.Decimal `json:"revenue"`
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSessionFilter(t *testing.T) {
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		to      time.Time
+		wantErr error
+	}{
+		{"one month", from.AddDate(0, 1, 0), nil},
+		{"longest period", from.Add(MaxReportPeriod), nil},
+		{"too long", from.Add(MaxReportPeriod + time.Hour), ErrInvalidReportPeriod},
+		{"empty", from, ErrInvalidReportPeriod},
+		{"reversed", from.AddDate(0, 0, -1), ErrInvalidReportPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSessionFilter(from, tt.to, nil); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewSessionFilter() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
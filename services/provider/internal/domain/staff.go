@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Provider staff errors
+var (
+	ErrNotProviderStaff = errors.New("caller is not staff of a provider")
+	ErrStaffReadOnly    = errors.New("provider role cannot make changes")
+)
+
+// StaffRole is a provider staff member's role, granted by the auth service
+// and carried in their access token
+type StaffRole string
+
+const (
+	// StaffRoleAdmin manages the provider's locations
+	StaffRoleAdmin StaffRole = "provider_admin"
+	// StaffRoleViewer sees locations and session reports only
+	StaffRoleViewer StaffRole = "provider_viewer"
+)
+
+// Staff is a signed-in user acting for the provider they work for. The
+// provider portal only shows them that provider's data.
+type Staff struct {
+	UserID     string
+	ProviderID uuid.UUID
+	Role       StaffRole
+}
+
+// NewStaff builds the caller's scope from the role and provider ID the
+// gateway forwards from their token.
+func NewStaff(userID, role, providerID string) (*Staff, error) {
+	r := StaffRole(role)
+	if r != StaffRoleAdmin && r != StaffRoleViewer {
+		return nil, ErrNotProviderStaff
+	}
+	id, err := uuid.Parse(providerID)
+	if err != nil {
+		return nil, ErrNotProviderStaff
+	}
+	return &Staff{UserID: userID, ProviderID: id, Role: r}, nil
+}
+
+// CanManage returns ErrStaffReadOnly unless the staff member may change
+// the provider's locations
+func (s *Staff) CanManage() error {
+	if s.Role != StaffRoleAdmin {
+		return ErrStaffReadOnly
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewStaff(t *testing.T) {
+	providerID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		role       string
+		providerID string
+		wantErr    error
+		wantManage error
+	}{
+		{"admin", "provider_admin", providerID, nil, nil},
+		{"viewer", "provider_viewer", providerID, nil, ErrStaffReadOnly},
+		{"customer", "", "", ErrNotProviderStaff, nil},
+		{"unknown role", "admin", providerID, ErrNotProviderStaff, nil},
+		{"bad provider ID", "provider_admin", "prov-1", ErrNotProviderStaff, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			staff, err := NewStaff("user-1", tt.role, tt.providerID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewStaff() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if staff.ProviderID.String() != tt.providerID {
+				t.Errorf("ProviderID = %v, want %v", staff.ProviderID, tt.providerID)
+			}
+			if err := staff.CanManage(); !errors.Is(err, tt.wantManage) {
+				t.Errorf("CanManage() = %v, want %v", err, tt.wantManage)
+			}
+		})
+	}
+}
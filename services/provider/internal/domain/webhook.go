@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotConfigured  = errors.New("provider has no webhook URL")
+	ErrWebhookEventNotFound  = errors.New("webhook event not found")
+	ErrWebhookEventNotFailed = errors.New("only failed webhook events can be redriven")
+)
+
+// Events delivered to provider webhook endpoints
+const (
+	WebhookEventPaymentCompleted = "payment.completed"
+	WebhookEventSessionCancelled = "session.cancelled"
+)
+
+// WebhookEventStatus tracks an outbound webhook through its delivery attempts
+type WebhookEventStatus string
+
+const (
+	WebhookEventPending   WebhookEventStatus = "pending"
+	WebhookEventDelivered WebhookEventStatus = "delivered"
+	WebhookEventFailed    WebhookEventStatus = "failed"
+)
+
+// WebhookRetryPolicy controls how failed deliveries are retried. The delay
+// doubles after each failed attempt, starting at BaseDelay and capped at
+// MaxDelay; an event is failed once MaxAttempts attempts have failed.
+type WebhookRetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultWebhookRetryPolicy() WebhookRetryPolicy {
+	return WebhookRetryPolicy{MaxAttempts: 8, BaseDelay: 30 * time.Second, MaxDelay: 6 * time.Hour}
+}
+
+// Backoff returns the delay before the attempt after the given failed one
+func (p WebhookRetryPolicy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// WebhookEvent is a notification queued for delivery to a provider's webhook
+// endpoint. Payload is the exact body that is signed and sent, so every
+// attempt and a redrive deliver the same bytes.
+type WebhookEvent struct {
+	ID            uuid.UUID          `json:"id"`
+	ProviderID    uuid.UUID          `json:"provider_id"`
+	EventType     string             `json:"event_type"`
+	Payload       json.RawMessage    `json:"payload"`
+	Status        WebhookEventStatus `json:"status"`
+	Attempts      int                `json:"attempts"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	LastError     string             `json:"last_error,omitempty"`
+	CreatedAt     time.Time          `json:"created_at"`
+	DeliveredAt   *time.Time         `json:"delivered_at,omitempty"`
+}
+
+// webhookEnvelope is the body providers receive
+type webhookEnvelope struct {
+	ID        uuid.UUID              `json:"id"`
+	Type      string                 `json:"type"`
+	CreatedAt time.Time              `json:"created_at"`
+	Data      map[string]interface{} `json:"data"`
+}
+
+// NewWebhookEvent queues an event for immediate delivery. The event ID is
+// part of the body so providers can ignore repeated deliveries.
+func NewWebhookEvent(providerID uuid.UUID, eventType string, data map[string]interface{}) (*WebhookEvent, error) {
+	now := time.Now().UTC()
+	e := &WebhookEvent{
+		ID:            uuid.New(),
+		ProviderID:    providerID,
+		EventType:     eventType,
+		Status:        WebhookEventPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	payload, err := json.Marshal(webhookEnvelope{ID: e.ID, Type: eventType, CreatedAt: now, Data: data})
+	if err != nil {
+		return nil, err
+	}
+	e.Payload = payload
+	return e, nil
+}
+
+// RecordAttempt applies the outcome of a delivery attempt. A failed attempt
+// is retried after the policy's backoff until the attempts run out.
+func (e *WebhookEvent) RecordAttempt(d *WebhookDelivery, policy WebhookRetryPolicy, now time.Time) {
+	e.Attempts = d.Attempt
+	if d.Status == WebhookDeliverySucceeded {
+		e.Status = WebhookEventDelivered
+		e.LastError = ""
+		e.DeliveredAt = &now
+		return
+	}
+
+	e.LastError = d.Error
+	if e.LastError == "" {
+		e.LastError = "endpoint returned " + httpStatusText(d.ResponseCode)
+	}
+	if e.Attempts >= policy.MaxAttempts {
+		e.Status = WebhookEventFailed
+		return
+	}
+	e.NextAttemptAt = now.Add(policy.Backoff(e.Attempts))
+}
+
+// Fail stops delivery without another attempt, such as when the provider has
+// removed its webhook URL
+func (e *WebhookEvent) Fail(reason string) {
+	e.Status = WebhookEventFailed
+	e.LastError = reason
+}
+
+// Redrive queues a failed event for delivery again with a fresh set of attempts
+func (e *WebhookEvent) Redrive(now time.Time) error {
+	if e.Status != WebhookEventFailed {
+		return ErrWebhookEventNotFailed
+	}
+	e.Status = WebhookEventPending
+	e.Attempts = 0
+	e.NextAttemptAt = now
+	e.LastError = ""
+	return nil
+}
+
+// NextAttempt is the attempt number of the next delivery
+func (e *WebhookEvent) NextAttempt() int {
+	return e.Attempts + 1
+}
+
+// SetWebhook sets the endpoint that receives outbound webhooks and the secret
+// they are signed with. An empty URL stops deliveries.
+func (p *Provider) SetWebhook(webhookURL, secret string) error {
+	if webhookURL != "" && !isValidURL(webhookURL) {
+		return ErrInvalidWebhookURL
+	}
+	p.WebhookURL = webhookURL
+	p.WebhookSecret = secret
+	p.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// GenerateWebhookSecret creates a random secret for signing webhooks
+func GenerateWebhookSecret() (string, error) {
+	secret, err := generateSecureKey(32)
+	if err != nil {
+		return "", err
+	}
+	return "whsec_" + secret, nil
+}
+
+func httpStatusText(code int) string {
+	if code == 0 {
+		return "no response"
+	}
+	return "status " + strconv.Itoa(code)
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWebhookRetryPolicy_Backoff(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxAttempts: 10, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{4, 8 * time.Minute},
+		{5, 10 * time.Minute},
+		{40, 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestNewWebhookEvent(t *testing.T) {
+	providerID := uuid.New()
+
+	e, err := NewWebhookEvent(providerID, WebhookEventPaymentCompleted, map[string]interface{}{"session_id": "s-1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Status != WebhookEventPending || e.NextAttempt() != 1 {
+		t.Errorf("expected a pending event on its first attempt, got %s on attempt %d", e.Status, e.NextAttempt())
+	}
+
+	var body struct {
+		ID   uuid.UUID         `json:"id"`
+		Type string            `json:"type"`
+		Data map[string]string `json:"data"`
+	}
+	if err := json.Unmarshal(e.Payload, &body); err != nil {
+		t.Fatalf("payload is not JSON: %v", err)
+	}
+	if body.ID != e.ID || body.Type != WebhookEventPaymentCompleted || body.Data["session_id"] != "s-1" {
+		t.Errorf("unexpected payload %s", e.Payload)
+	}
+}
+
+func TestWebhookEvent_RecordAttempt(t *testing.T) {
+	policy := WebhookRetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name         string
+		attempt      int
+		responseCode int
+		err          error
+		wantStatus   WebhookEventStatus
+		wantNext     time.Time
+		wantError    string
+	}{
+		{"success", 1, 204, nil, WebhookEventDelivered, now, ""},
+		{"first failure is retried", 1, 500, nil, WebhookEventPending, now.Add(time.Minute), "endpoint returned status 500"},
+		{"second failure backs off", 2, 0, errors.New("timeout"), WebhookEventPending, now.Add(2 * time.Minute), "timeout"},
+		{"last failure gives up", 3, 502, nil, WebhookEventFailed, now, "endpoint returned status 502"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, _ := NewWebhookEvent(uuid.New(), WebhookEventSessionCancelled, nil)
+			e.NextAttemptAt = now
+			d := NewWebhookDelivery(e.ProviderID, e.EventType, "https://example.com/hook", tt.attempt, tt.responseCode, tt.err, time.Second)
+
+			e.RecordAttempt(d, policy, now)
+
+			if e.Status != tt.wantStatus {
+				t.Errorf("expected status %s, got %s", tt.wantStatus, e.Status)
+			}
+			if !e.NextAttemptAt.Equal(tt.wantNext) {
+				t.Errorf("expected next attempt at %v, got %v", tt.wantNext, e.NextAttemptAt)
+			}
+			if e.LastError != tt.wantError {
+				t.Errorf("expected error %q, got %q", tt.wantError, e.LastError)
+			}
+			if e.Attempts != tt.attempt {
+				t.Errorf("expected %d attempts, got %d", tt.attempt, e.Attempts)
+			}
+		})
+	}
+}
+
+func TestWebhookEvent_Redrive(t *testing.T) {
+	e, _ := NewWebhookEvent(uuid.New(), WebhookEventPaymentCompleted, nil)
+	if err := e.Redrive(time.Now()); !errors.Is(err, ErrWebhookEventNotFailed) {
+		t.Errorf("expected %v for a pending event, got %v", ErrWebhookEventNotFailed, err)
+	}
+
+	e.Attempts = 8
+	e.Fail("endpoint returned status 500")
+	now := time.Now().UTC().Add(time.Hour)
+	if err := e.Redrive(now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e.Status != WebhookEventPending || e.Attempts != 0 || !e.NextAttemptAt.Equal(now) || e.LastError != "" {
+		t.Errorf("expected a fresh pending event, got %+v", e)
+	}
+}
+
+func TestProvider_SetWebhook(t *testing.T) {
+	p, _ := NewProvider("Metro Parking", "metro", "https://mfe.example.com", "https://api.example.com")
+
+	if err := p.SetWebhook("ftp://example.com/hook", "secret"); !errors.Is(err, ErrInvalidWebhookURL) {
+		t.Errorf("expected %v, got %v", ErrInvalidWebhookURL, err)
+	}
+	if err := p.SetWebhook("https://example.com/hook", "secret"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := p.SetWebhook("", ""); err != nil || p.WebhookURL != "" {
+		t.Errorf("expected the webhook to be removed, got %q and %v", p.WebhookURL, err)
+	}
+}
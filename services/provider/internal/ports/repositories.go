@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/pricing"
 	"github.com/parking-super-app/services/provider/internal/domain"
 )
 
@@ -13,8 +15,20 @@ type ProviderRepository interface {
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Provider, error)
 	GetByCode(ctx context.Context, code string) (*domain.Provider, error)
 	GetAll(ctx context.Context, activeOnly bool) ([]*domain.Provider, error)
+	GetByStatus(ctx context.Context, status domain.ProviderStatus) ([]*domain.Provider, error)
+	GetDueForReactivation(ctx context.Context, now time.Time) ([]*domain.Provider, error)
 	Update(ctx context.Context, provider *domain.Provider) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete soft deletes the provider and its locations at the given time.
+	// Reads other than GetDeleted no longer return them.
+	Delete(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Restore undeletes the provider and the locations deleted with it. It
+	// returns domain.ErrProviderNotDeleted for a provider that isn't deleted.
+	Restore(ctx context.Context, id uuid.UUID) error
+	// GetDeleted returns the deleted providers, most recently deleted first
+	GetDeleted(ctx context.Context) ([]*domain.Provider, error)
+	// Purge removes providers deleted before the given time, with everything
+	// that belongs to them, and returns how many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 // CredentialsRepository defines the interface for credential persistence
@@ -22,6 +36,8 @@ type CredentialsRepository interface {
 	Create(ctx context.Context, creds *domain.ProviderCredentials) error
 	GetByAPIKey(ctx context.Context, apiKey string) (*domain.ProviderCredentials, error)
 	GetByProviderID(ctx context.Context, providerID uuid.UUID, env domain.Environment) (*domain.ProviderCredentials, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ProviderCredentials, error)
+	ListByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.ProviderCredentials, error)
 	Update(ctx context.Context, creds *domain.ProviderCredentials) error
 	Revoke(ctx context.Context, id uuid.UUID) error
 }
@@ -31,7 +47,80 @@ type LocationRepository interface {
 	Create(ctx context.Context, location *domain.Location) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Location, error)
 	GetByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error)
-	GetNearby(ctx context.Context, lat, lng float64, radiusKm float64) ([]*domain.Location, error)
+	// GetNearby returns the active locations within radiusKm matching filter, nearest first
+	GetNearby(ctx context.Context, lat, lng float64, radiusKm float64, filter domain.LocationFilter) ([]*domain.Location, error)
+	// Clusters groups the active locations in box into grid cells at cellZoom
+	Clusters(ctx context.Context, box domain.BoundingBox, cellZoom int) ([]*domain.Cluster, error)
 	Update(ctx context.Context, location *domain.Location) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Delete soft deletes the location at the given time
+	Delete(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Restore undeletes one of the provider's locations. It returns
+	// domain.ErrLocationNotOwned when the provider has no such location and
+	// domain.ErrLocationNotDeleted when it isn't deleted.
+	Restore(ctx context.Context, providerID, id uuid.UUID) error
+	// GetDeletedByProviderID returns the provider's deleted locations, most
+	// recently deleted first
+	GetDeletedByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.Location, error)
+	// Purge removes locations deleted before the given time and returns how
+	// many were removed
+	Purge(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// AuditRepository defines the interface for provider audit log persistence
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain.AuditEntry) error
+	GetByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.AuditEntry, error)
+}
+
+// PricingRepository defines the interface for location pricing history persistence
+type PricingRepository interface {
+	CreateBatch(ctx context.Context, versions []*domain.PricingVersion) error
+	GetByLocationID(ctx context.Context, locationID uuid.UUID) ([]*domain.PricingVersion, error)
+	GetEffective(ctx context.Context, locationID uuid.UUID, at time.Time) (*domain.PricingVersion, error)
+	GetUnapplied(ctx context.Context, now time.Time, limit int) ([]*domain.PricingVersion, error)
+	MarkApplied(ctx context.Context, id uuid.UUID, at time.Time) error
+	// SetScheduleAfter gives versions taking effect after the given time the
+	// location's new schedule, so a scheduled rate change doesn't undo it
+	SetScheduleAfter(ctx context.Context, locationID uuid.UUID, after time.Time, schedule *pricing.Schedule) error
+}
+
+// WebhookDeliveryRepository defines the interface for webhook delivery log persistence
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+	GetByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.WebhookDelivery, error)
+}
+
+// WebhookEventRepository defines the interface for the outbound webhook queue
+type WebhookEventRepository interface {
+	Create(ctx context.Context, event *domain.WebhookEvent) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.WebhookEvent, error)
+	// ClaimDue returns pending events whose next attempt is due and pushes
+	// their next attempt back by lease, so other replicas skip them while
+	// they are being sent
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookEvent, error)
+	ListByProviderID(ctx context.Context, providerID uuid.UUID, status domain.WebhookEventStatus, limit, offset int) ([]*domain.WebhookEvent, error)
+	Update(ctx context.Context, event *domain.WebhookEvent) error
+}
+
+// APIErrorRepository defines the interface for partner API error log persistence
+type APIErrorRepository interface {
+	Create(ctx context.Context, entry *domain.APIErrorLog) error
+	GetByProviderID(ctx context.Context, providerID uuid.UUID, since time.Time, limit int) ([]*domain.APIErrorLog, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ConformanceRepository defines the interface for conformance report persistence
+type ConformanceRepository interface {
+	Create(ctx context.Context, report *domain.ConformanceReport) error
+	GetLatestByProviderID(ctx context.Context, providerID uuid.UUID) (*domain.ConformanceReport, error)
+	ListByProviderID(ctx context.Context, providerID uuid.UUID, limit, offset int) ([]*domain.ConformanceReport, error)
+}
+
+// ProviderSessionRepository defines the interface for the sessions behind
+// provider session reports
+type ProviderSessionRepository interface {
+	// Save inserts the session or replaces what was recorded for it
+	Save(ctx context.Context, session *domain.ProviderSession) error
+	List(ctx context.Context, providerID uuid.UUID, filter domain.SessionFilter, limit, offset int) ([]*domain.ProviderSession, error)
+	Totals(ctx context.Context, providerID uuid.UUID, filter domain.SessionFilter) (*domain.SessionTotals, error)
 }
@@ -2,23 +2,20 @@ package ports
 
 import (
 	"context"
+	"io"
+
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/services/provider/internal/domain"
+	"github.com/shopspring/decimal"
 )
 
 // Logger defines the logging interface
-type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-}
-
-type Field struct {
-	Key   string
-	Value interface{}
-}
+type Logger = logging.Logger
+type Field = logging.Field
 
-func String(key, value string) Field { return Field{Key: key, Value: value} }
-func Err(err error) Field            { return Field{Key: "error", Value: err} }
+func String(key, value string) Field        { return Field{Key: key, Value: value} }
+func Err(err error) Field                   { return Field{Key: "error", Value: err} }
 func Any(key string, val interface{}) Field { return Field{Key: key, Value: val} }
 
 // EventPublisher publishes domain events
@@ -32,13 +29,141 @@ type Event struct {
 }
 
 const (
-	EventProviderCreated     = "provider.created"
-	EventProviderActivated   = "provider.activated"
-	EventProviderDeactivated = "provider.deactivated"
-	EventLocationAdded       = "provider.location.added"
+	EventProviderCreated      = "provider.created"
+	EventProviderActivated    = "provider.activated"
+	EventProviderDeactivated  = "provider.deactivated"
+	EventLocationAdded        = "provider.location.added"
+	EventProviderApproved     = "provider.approved"
+	EventProviderRejected     = "provider.rejected"
+	EventProviderSuspended    = "provider.suspended"
+	EventProviderReactivated  = "provider.reactivated"
+	EventPricingScheduled     = "provider.location.pricing_scheduled"
+	EventPricingApplied       = "provider.location.pricing_applied"
+	EventCredentialsCreated   = "provider.credentials.created"
+	EventCredentialsRotated   = "provider.credentials.rotated"
+	EventCredentialsRevoked   = "provider.credentials.revoked"
+	EventConformanceCompleted = "provider.conformance.completed"
+	EventMFEPublished         = "provider.mfe.published"
+	EventWebhookConfigured    = "provider.webhook.configured"
+	EventLocationUpdated      = "provider.location.updated"
+	EventLocationDeactivated  = "provider.location.deactivated"
+	EventLogoUpdated          = "provider.logo.updated"
+	EventProviderDeleted      = "provider.deleted"
+	EventProviderRestored     = "provider.restored"
+	EventLocationDeleted      = "provider.location.deleted"
+	EventLocationRestored     = "provider.location.restored"
 )
 
-// WebhookSender sends webhooks to provider endpoints
+// AuditLog records sensitive operations in the service's audit trail
+type AuditLog interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+type AuditEvent = audit.Event
+
+// Audit actions
+const (
+	AuditProviderActivated   = "provider.activated"
+	AuditProviderDeactivated = "provider.deactivated"
+	AuditProviderApproved    = "provider.approved"
+	AuditProviderRejected    = "provider.rejected"
+	AuditProviderSuspended   = "provider.suspended"
+	AuditProviderReactivated = "provider.reactivated"
+	AuditCredentialsIssued   = "credentials.issued"
+	AuditCredentialsRotated  = "credentials.rotated"
+	AuditCredentialsRevoked  = "credentials.revoked"
+	AuditMFEPublished        = "provider.mfe.published"
+	AuditWebhookConfigured   = "provider.webhook.configured"
+	AuditLocationUpdated     = "location.updated"
+	AuditLocationDeactivated = "location.deactivated"
+	AuditLogoUpdated         = "provider.logo.updated"
+	AuditProviderDeleted     = "provider.deleted"
+	AuditProviderRestored    = "provider.restored"
+	AuditLocationDeleted     = "location.deleted"
+	AuditLocationRestored    = "location.restored"
+)
+
+// LogoStore holds providers' logos, which a CDN serves from the same keys
+type LogoStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+}
+
+// WebhookSender sends webhooks to provider endpoints. The body is signed with
+// the secret; the returned status is 0 when no response was received.
 type WebhookSender interface {
-	Send(ctx context.Context, url string, payload interface{}, secret string) error
+	Send(ctx context.Context, url string, body []byte, secret string) (int, error)
+}
+
+// SandboxClient calls a provider's sandbox API for the conformance kit
+type SandboxClient interface {
+	StartSession(ctx context.Context, target SandboxTarget, req SandboxSessionRequest) (*SandboxSession, error)
+	GetSession(ctx context.Context, target SandboxTarget, externalSessionID string) (*SandboxSession, error)
+	EndSession(ctx context.Context, target SandboxTarget, externalSessionID string) (*SandboxSession, error)
+	// EchoWebhook delivers a signed webhook and returns the HTTP status and the
+	// signature the provider computed for it
+	EchoWebhook(ctx context.Context, target SandboxTarget, webhook SignedWebhook) (int, string, error)
+}
+
+// SandboxTarget is the provider API being tested and the credentials to call it with
+type SandboxTarget struct {
+	BaseURL   string
+	APIKey    string
+	APISecret string
+}
+
+type SandboxSessionRequest struct {
+	VehiclePlate string `json:"vehicle_plate"`
+	VehicleType  string `json:"vehicle_type"`
+	UserRef      string `json:"user_ref"`
+}
+
+type SandboxSession struct {
+	ExternalSessionID string `json:"external_session_id"`
+	Status            string `json:"status"`
+	EntryTime         string `json:"entry_time"`
+	ExitTime          string `json:"exit_time,omitempty"`
+	DurationMinutes   int    `json:"duration_minutes"`
+	Amount            string `json:"amount,omitempty"`
+	Currency          string `json:"currency,omitempty"`
+}
+
+type SignedWebhook struct {
+	Body      []byte
+	Timestamp string
+	Signature string
+}
+
+// CompoundClient calls a provider's production API for the parking fines it
+// has issued
+type CompoundClient interface {
+	// Lookup returns the outstanding fines for a vehicle plate
+	Lookup(ctx context.Context, target ProviderTarget, plate string) ([]CompoundRecord, error)
+	// Settle reports a fine as paid. It returns domain.ErrCompoundNotFound if
+	// the provider no longer has it outstanding.
+	Settle(ctx context.Context, target ProviderTarget, req CompoundSettleRequest) (*domain.CompoundSettlement, error)
+}
+
+// ProviderTarget is a provider's production API and the credentials to call it with
+type ProviderTarget struct {
+	BaseURL   string
+	APIKey    string
+	APISecret string
+}
+
+// CompoundRecord is a fine as the provider API returns it
+type CompoundRecord struct {
+	Reference    string          `json:"reference"`
+	VehiclePlate string          `json:"vehicle_plate"`
+	Offence      string          `json:"offence"`
+	Location     string          `json:"location"`
+	IssuedAt     string          `json:"issued_at"`
+	DueAt        string          `json:"due_at,omitempty"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency"`
+}
+
+type CompoundSettleRequest struct {
+	Reference        string          `json:"-"`
+	Amount           decimal.Decimal `json:"amount"`
+	PaymentReference string          `json:"payment_reference"`
 }
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_providers_suspended_until;
+DROP TABLE IF EXISTS provider_audit_log;
+
+ALTER TABLE providers
+    DROP COLUMN IF EXISTS suspended_until,
+    DROP COLUMN IF EXISTS status_reason;
+
+-- Postgres cannot drop enum values; move affected rows back to a known status
+UPDATE providers SET status = 'inactive' WHERE status IN ('rejected', 'suspended');
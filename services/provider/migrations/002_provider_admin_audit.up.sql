@@ -0,0 +1,23 @@
+-- Provider Service: Admin approval workflow and audit log
+
+ALTER TYPE provider_status ADD VALUE IF NOT EXISTS 'rejected';
+ALTER TYPE provider_status ADD VALUE IF NOT EXISTS 'suspended';
+
+ALTER TABLE providers
+    ADD COLUMN status_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN suspended_until TIMESTAMPTZ;
+
+-- Provider audit log: who changed what on a provider and when
+CREATE TABLE provider_audit_log (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_provider_audit_log_provider_id ON provider_audit_log(provider_id, created_at DESC);
+CREATE INDEX idx_providers_suspended_until ON providers(suspended_until) WHERE suspended_until IS NOT NULL;
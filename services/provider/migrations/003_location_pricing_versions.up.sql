@@ -0,0 +1,20 @@
+-- Provider Service: Versioned location pricing
+
+-- Pricing versions: every rate a location has had, with the time it took effect.
+-- Sessions are billed against the version in effect at their entry time.
+CREATE TABLE location_pricing_versions (
+    id UUID PRIMARY KEY,
+    location_id UUID NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    hourly_rate DECIMAL(10, 2) NOT NULL,
+    daily_max DECIMAL(10, 2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    grace_period_min INT NOT NULL DEFAULT 15,
+    effective_from TIMESTAMPTZ NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    applied_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_location_pricing_versions_effective ON location_pricing_versions(location_id, effective_from DESC, created_at DESC);
+CREATE INDEX idx_location_pricing_versions_unapplied ON location_pricing_versions(effective_from) WHERE applied_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_provider_api_errors_created_at;
+DROP INDEX IF EXISTS idx_provider_api_errors_provider_id;
+DROP INDEX IF EXISTS idx_provider_webhook_deliveries_provider_id;
+DROP TABLE IF EXISTS provider_api_errors;
+DROP TABLE IF EXISTS provider_webhook_deliveries;
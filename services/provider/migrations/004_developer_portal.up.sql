@@ -0,0 +1,34 @@
+-- Provider Service: Developer portal delivery and error logs
+
+-- Webhook delivery log: one row per attempt to call a provider endpoint
+CREATE TABLE provider_webhook_deliveries (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    url TEXT NOT NULL,
+    attempt INT NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL,
+    response_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- API error log: failed partner requests, kept for a limited retention window
+CREATE TABLE provider_api_errors (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    credentials_id UUID NOT NULL REFERENCES provider_credentials(id) ON DELETE CASCADE,
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status_code INT NOT NULL,
+    error_code VARCHAR(100) NOT NULL DEFAULT '',
+    message TEXT NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_provider_webhook_deliveries_provider_id ON provider_webhook_deliveries(provider_id, created_at DESC);
+CREATE INDEX idx_provider_api_errors_provider_id ON provider_api_errors(provider_id, created_at DESC);
+CREATE INDEX idx_provider_api_errors_created_at ON provider_api_errors(created_at);
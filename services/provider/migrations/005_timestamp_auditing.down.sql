@@ -0,0 +1,6 @@
+ALTER TABLE locations DROP COLUMN IF EXISTS updated_by;
+ALTER TABLE providers DROP COLUMN IF EXISTS updated_by;
+
+DROP TRIGGER IF EXISTS update_locations_updated_at ON locations;
+DROP TRIGGER IF EXISTS update_providers_updated_at ON providers;
+DROP FUNCTION IF EXISTS update_updated_at_column();
@@ -0,0 +1,24 @@
+-- Maintain audit timestamps in the database so they stay correct no matter
+-- which code path writes the row. created_at can never be changed by an update.
+CREATE OR REPLACE FUNCTION update_updated_at_column()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.created_at = OLD.created_at;
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER update_providers_updated_at
+    BEFORE UPDATE ON providers
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER update_locations_updated_at
+    BEFORE UPDATE ON locations
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Who made the last change: the admin's user ID, or 'system' for scheduled jobs
+ALTER TABLE providers ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE locations ADD COLUMN updated_by VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_provider_conformance_reports_provider_id;
+DROP TABLE IF EXISTS provider_conformance_reports;
@@ -0,0 +1,15 @@
+-- Provider Service: API conformance reports
+-- A provider must have a passing report for its current api_base_url before it can be activated
+CREATE TABLE provider_conformance_reports (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    api_base_url VARCHAR(500) NOT NULL,
+    score INTEGER NOT NULL,
+    passed BOOLEAN NOT NULL,
+    results JSONB NOT NULL DEFAULT '[]',
+    run_by VARCHAR(255) NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_provider_conformance_reports_provider_id ON provider_conformance_reports(provider_id, completed_at DESC);
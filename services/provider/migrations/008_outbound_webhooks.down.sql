@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_provider_webhook_deliveries_event_id;
+DROP INDEX IF EXISTS idx_provider_webhook_events_provider_id;
+DROP INDEX IF EXISTS idx_provider_webhook_events_due;
+
+ALTER TABLE provider_webhook_deliveries DROP COLUMN IF EXISTS event_id;
+DROP TABLE IF EXISTS provider_webhook_events;
+ALTER TABLE providers DROP COLUMN IF EXISTS webhook_url;
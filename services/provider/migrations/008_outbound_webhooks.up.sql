@@ -0,0 +1,25 @@
+-- Provider Service: outbound webhooks to provider endpoints
+
+ALTER TABLE providers ADD COLUMN webhook_url VARCHAR(500) NOT NULL DEFAULT '';
+
+-- Outbound webhook queue: one row per event, retried until delivered or failed
+CREATE TABLE provider_webhook_events (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ
+);
+
+-- Delivery attempts link back to the event they delivered
+ALTER TABLE provider_webhook_deliveries ADD COLUMN event_id UUID REFERENCES provider_webhook_events(id) ON DELETE SET NULL;
+
+-- Indexes
+CREATE INDEX idx_provider_webhook_events_due ON provider_webhook_events(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_provider_webhook_events_provider_id ON provider_webhook_events(provider_id, created_at DESC);
+CREATE INDEX idx_provider_webhook_deliveries_event_id ON provider_webhook_deliveries(event_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_provider_sessions_location_entry;
+DROP INDEX IF EXISTS idx_provider_sessions_provider_entry;
+
+DROP TABLE IF EXISTS provider_sessions;
@@ -0,0 +1,23 @@
+-- Provider Service: finished parking sessions for provider session reports
+
+-- One row per session, recorded from the parking service's ended and
+-- cancelled events. The driver's identity is not kept.
+CREATE TABLE provider_sessions (
+    session_id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL REFERENCES providers(id) ON DELETE CASCADE,
+    location_id UUID NOT NULL,
+    external_session_id VARCHAR(255) NOT NULL DEFAULT '',
+    vehicle_plate VARCHAR(20) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL,
+    entry_time TIMESTAMPTZ NOT NULL,
+    exit_time TIMESTAMPTZ,
+    duration_minutes INT NOT NULL DEFAULT 0,
+    amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    payment_id VARCHAR(255) NOT NULL DEFAULT '',
+    recorded_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Indexes
+CREATE INDEX idx_provider_sessions_provider_entry ON provider_sessions(provider_id, entry_time DESC);
+CREATE INDEX idx_provider_sessions_location_entry ON provider_sessions(location_id, entry_time DESC);
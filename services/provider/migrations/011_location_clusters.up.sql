@@ -0,0 +1,5 @@
+-- Provider Service: map clusters of locations
+
+-- Cluster and nearby searches only read active locations within a bounding
+-- box, so index just those
+CREATE INDEX idx_locations_active_coordinates ON locations(latitude, longitude) WHERE is_active = true;
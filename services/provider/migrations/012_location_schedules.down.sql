@@ -0,0 +1,3 @@
+ALTER TABLE locations
+    DROP COLUMN IF EXISTS operating_hours,
+    DROP COLUMN IF EXISTS pricing_schedule;
@@ -0,0 +1,7 @@
+-- Provider Service: pricing schedules and operating hours
+
+-- Both are NULL for a location billed at its flat rate and open around the
+-- clock. See pkg/pricing for the document shapes.
+ALTER TABLE locations
+    ADD COLUMN pricing_schedule JSONB,
+    ADD COLUMN operating_hours JSONB;
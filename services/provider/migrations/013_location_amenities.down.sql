@@ -0,0 +1,3 @@
+ALTER TABLE locations DROP COLUMN IF EXISTS height_limit_cm;
+DROP INDEX IF EXISTS idx_locations_amenities;
+ALTER TABLE locations ALTER COLUMN amenities DROP NOT NULL;
@@ -0,0 +1,11 @@
+-- Provider Service: amenity search
+
+-- Searches keep locations whose amenities contain every one asked for,
+-- which the GIN index answers. Amenities are never NULL, so an empty
+-- filter matches every location.
+UPDATE locations SET amenities = '{}' WHERE amenities IS NULL;
+ALTER TABLE locations ALTER COLUMN amenities SET NOT NULL;
+CREATE INDEX idx_locations_amenities ON locations USING GIN (amenities);
+
+-- The tallest vehicle a location takes, in centimetres; NULL for no limit
+ALTER TABLE locations ADD COLUMN height_limit_cm INT CHECK (height_limit_cm > 0);
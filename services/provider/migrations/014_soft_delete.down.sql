@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_locations_active_coordinates;
+CREATE INDEX idx_locations_active_coordinates ON locations(latitude, longitude) WHERE is_active = true;
+
+DROP INDEX IF EXISTS idx_locations_deleted_at;
+DROP INDEX IF EXISTS idx_providers_deleted_at;
+ALTER TABLE locations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE providers DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,16 @@
+-- Provider Service: soft delete for providers and locations
+
+-- Deleted rows are kept, hidden from every query, until the purge job
+-- removes those deleted for longer than the retention window. A provider's
+-- locations are deleted with it at the same time, which is how restoring
+-- the provider finds them.
+ALTER TABLE providers ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE locations ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_providers_deleted_at ON providers(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_locations_deleted_at ON locations(provider_id, deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Cluster and nearby searches skip deleted locations
+DROP INDEX IF EXISTS idx_locations_active_coordinates;
+CREATE INDEX idx_locations_active_coordinates ON locations(latitude, longitude)
+    WHERE is_active = true AND deleted_at IS NULL;
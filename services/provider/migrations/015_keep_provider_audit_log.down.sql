@@ -0,0 +1,4 @@
+DELETE FROM provider_audit_log WHERE provider_id NOT IN (SELECT id FROM providers);
+ALTER TABLE provider_audit_log
+    ADD CONSTRAINT provider_audit_log_provider_id_fkey
+    FOREIGN KEY (provider_id) REFERENCES providers(id) ON DELETE CASCADE;
@@ -0,0 +1,6 @@
+-- Provider Service: keep a purged provider's audit history
+
+-- Purging a provider deleted its audit log through the foreign key. The
+-- history outlives the provider, so the rows keep the provider's ID with
+-- nothing to cascade from.
+ALTER TABLE provider_audit_log DROP CONSTRAINT IF EXISTS provider_audit_log_provider_id_fkey;
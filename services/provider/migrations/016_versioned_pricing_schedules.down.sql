@@ -0,0 +1 @@
+ALTER TABLE location_pricing_versions DROP COLUMN IF EXISTS pricing_schedule;
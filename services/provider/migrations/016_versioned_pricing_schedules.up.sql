@@ -0,0 +1,12 @@
+-- Provider Service: versioned pricing schedules
+
+-- Each pricing version carries the schedule in place with it, so a session
+-- is billed by the schedule in effect at its entry time like its rates.
+-- Existing versions take the location's current schedule, which is what
+-- they were billed by until now.
+ALTER TABLE location_pricing_versions ADD COLUMN pricing_schedule JSONB;
+
+UPDATE location_pricing_versions v
+SET pricing_schedule = l.pricing_schedule
+FROM locations l
+WHERE l.id = v.location_id AND l.pricing_schedule IS NOT NULL;
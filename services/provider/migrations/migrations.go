@@ -0,0 +1,8 @@
+// Package migrations embeds the provider service schema migrations so the
+// server binary can apply them without the files on disk.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -11,9 +11,19 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/eventstore"
+	"github.com/parking-super-app/pkg/featureflags"
+	"github.com/parking-super-app/pkg/grpc/client"
 	"github.com/parking-super-app/pkg/grpc/interceptors"
+	"github.com/parking-super-app/pkg/httpserver"
 	"github.com/parking-super-app/pkg/kafka"
+	"github.com/parking-super-app/pkg/logging"
 	"github.com/parking-super-app/pkg/middleware"
+	"github.com/parking-super-app/pkg/migrate"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
 	"github.com/parking-super-app/pkg/telemetry"
 	"github.com/parking-super-app/services/wallet/config"
 	"github.com/parking-super-app/services/wallet/internal/adapters/external"
@@ -21,33 +31,45 @@ import (
 	httpAdapter "github.com/parking-super-app/services/wallet/internal/adapters/http"
 	"github.com/parking-super-app/services/wallet/internal/adapters/repository/postgres"
 	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
 	"github.com/parking-super-app/services/wallet/internal/ports"
-	"google.golang.org/grpc"
+	"github.com/parking-super-app/services/wallet/migrations"
 )
 
 func main() {
 	// Load configuration from environment
+	configFile, err := settings.Load()
+	if err != nil {
+		log.Fatalf("failed to read config file: %v", err)
+	}
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
 	// Initialize logger
-	logger := external.NewStdLogger()
+	logger := logging.New(cfg.Log)
 	logger.Info("starting wallet service")
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Initialize OpenTelemetry tracing
+	// Values with a reload hook change on SIGHUP or a POST to
+	// settings.ReloadPath; everything else needs a restart
+	reloader := settings.NewReloader(configFile)
+	reloader.OnReload("log level", logger.ReloadLevel)
+	reloader.WatchSignals(ctx)
+
+	// Initialize OpenTelemetry tracing and metrics
+	otelCfg := telemetry.DefaultConfig(cfg.OTEL.ServiceName)
+	otelCfg.OTLPEndpoint = cfg.OTEL.Endpoint
+	otelCfg.Insecure = cfg.OTEL.Insecure
+	otelCfg.MetricsInterval = cfg.OTEL.MetricsInterval
+	otelCfg.RuntimeMetrics = cfg.OTEL.RuntimeMetrics
+
 	var tracerShutdown func(context.Context) error
 	if cfg.OTEL.Enabled {
-		shutdown, err := telemetry.InitTracer(ctx, telemetry.Config{
-			ServiceName:  cfg.OTEL.ServiceName,
-			OTLPEndpoint: cfg.OTEL.Endpoint,
-			Insecure:     cfg.OTEL.Insecure,
-			Environment:  "development",
-		})
+		shutdown, err := telemetry.InitTracer(ctx, otelCfg)
 		if err != nil {
 			log.Printf("warning: failed to initialize tracer: %v", err)
 		} else {
@@ -56,6 +78,17 @@ func main() {
 		}
 	}
 
+	var meterShutdown func(context.Context) error
+	if cfg.OTEL.Enabled && cfg.OTEL.MetricsEnabled {
+		shutdown, err := telemetry.InitMeter(ctx, otelCfg)
+		if err != nil {
+			log.Printf("warning: failed to initialize metrics: %v", err)
+		} else {
+			meterShutdown = shutdown
+			logger.Info("OpenTelemetry metrics initialized")
+		}
+	}
+
 	// Connect to PostgreSQL
 	pool, err := pgxpool.New(ctx, cfg.Database.ConnectionString())
 	if err != nil {
@@ -64,59 +97,462 @@ func main() {
 	defer pool.Close()
 
 	// Verify database connection
-	if err := pool.Ping(ctx); err != nil {
-		log.Fatalf("failed to ping database: %v", err)
+	if err := startup.Wait(ctx, cfg.Startup, logger, "postgres", startup.Postgres(pool)); err != nil {
+		log.Fatalf("failed to reach database: %v", err)
 	}
 	logger.Info("connected to database")
 
+	// Schema migrations are embedded in the binary. "wallet-service migrate ..."
+	// manages them and exits; otherwise they can be applied before serving.
+	migrator, err := migrate.New(pool, migrations.FS, logger)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := migrate.RunCommand(ctx, migrator, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("failed to run migrations: %v", err)
+		}
+		return
+	}
+	if cfg.Database.MigrateOnStart {
+		if _, err := migrator.Up(ctx); err != nil {
+			log.Fatalf("failed to apply migrations: %v", err)
+		}
+	}
+
+	// Lag-tolerant reads go to the read replica when one is configured
+	readPool := pool
+	if cfg.Database.ReplicaURL != "" {
+		replica, err := pgxpool.New(ctx, cfg.Database.ReplicaURL)
+		if err != nil {
+			log.Fatalf("failed to connect to read replica: %v", err)
+		}
+		defer replica.Close()
+		if err := startup.Wait(ctx, cfg.Startup, logger, "postgres-replica", startup.Postgres(replica)); err != nil {
+			log.Fatalf("failed to reach read replica: %v", err)
+		}
+		readPool = replica
+		logger.Info("routing reads to database replica")
+	}
+
 	// Initialize repositories (adapters)
-	walletRepo := postgres.NewWalletRepository(pool)
-	txRepo := postgres.NewTransactionRepository(pool)
+	walletRepo := postgres.NewWalletRepository(pool, readPool)
+	txRepo := postgres.NewTransactionRepository(pool, readPool)
+	ledgerRepo := postgres.NewLedgerRepository(pool)
+	limitRepo := postgres.NewSpendingLimitRepository(pool)
+	discrepancyRepo := postgres.NewDiscrepancyRepository(pool)
+	promotionRepo := postgres.NewPromotionRepository(pool)
+	holdRepo := postgres.NewHoldRepository(pool)
+	profileRepo := postgres.NewUserProfileRepository(pool)
+	orgRepo := postgres.NewOrganizationRepository(pool)
+	uow := postgres.NewUnitOfWork(pool)
+	auditStore := audit.NewPostgresStore(pool)
+
+	// Record published events for the admin event browser
+	eventStore, closeEventStore, err := eventstore.OpenStore(ctx, eventstore.StoreConfig{
+		Backend:     cfg.EventStore.Backend,
+		PostgresURL: cfg.EventStore.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: event store unavailable, keeping events in memory: %v", err)
+		eventStore, closeEventStore = eventstore.NewMemoryStore(eventstore.DefaultMemoryCapacity), func() {}
+	}
+	defer closeEventStore()
 
 	// Initialize event publisher (Kafka or Noop)
 	var eventPublisher ports.EventPublisher
 	var kafkaPublisher *kafka.Publisher
 	if cfg.Kafka.Enabled {
+		if err := startup.Wait(ctx, cfg.Startup, logger, "kafka", startup.Kafka(cfg.Kafka.Brokers)); err != nil {
+			log.Fatalf("failed to reach Kafka: %v", err)
+		}
 		kafkaPublisher = kafka.NewPublisher(kafka.DefaultPublisherConfig(cfg.Kafka.Brokers, cfg.Kafka.Topic))
-		eventPublisher = &kafkaEventAdapter{publisher: kafkaPublisher}
+		eventPublisher = &kafkaEventAdapter{
+			publisher: eventstore.NewRecordingPublisher(kafkaPublisher, eventStore, "wallet"),
+		}
 		logger.Info("Kafka event publisher initialized")
 	} else {
 		eventPublisher = external.NewNoopEventPublisher()
 	}
 
-	// Initialize external services
-	paymentGateway := external.NewMockPaymentGateway()
+	// Keep the local copies of user contact details and organizations
+	// current from auth events
+	profileService := application.NewUserProfileService(profileRepo, logger)
+	orgService := application.NewOrganizationService(orgRepo, walletRepo, txRepo, logger)
+	// Referrals attributed by auth pay both users on the referee's first
+	// payment, from the referrals ledger account
+	referralService := application.NewReferralService(
+		postgres.NewReferralRepository(pool),
+		walletRepo,
+		txRepo,
+		ledgerRepo,
+		uow,
+		eventPublisher,
+		logger,
+		domain.ReferralPolicy{
+			ReferrerReward: cfg.Referrals.ReferrerReward,
+			RefereeReward:  cfg.Referrals.RefereeReward,
+			Window:         cfg.Referrals.Window,
+		},
+	)
+	authEventHandlers := []authEventHandler{profileService, orgService, referralService}
+
+	// "wallet-service replay -topic auth.events ..." hands a range of the
+	// topic to the auth event handlers again and exits, e.g. to rebuild
+	// user_profiles
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		replayCfg, err := kafka.ParseReplayArgs(os.Args[2:])
+		if err != nil {
+			log.Fatalf("invalid replay arguments: %v", err)
+		}
+		replayCfg.Brokers = cfg.Kafka.Brokers
+		replayer := kafka.NewReplayer(replayCfg)
+		for _, h := range authEventHandlers {
+			for _, eventType := range h.EventTypes() {
+				replayer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+					return h.Handle(ctx, event.Type, event.Payload)
+				})
+			}
+		}
+		if _, err := replayer.Run(ctx, os.Stdout); err != nil {
+			log.Fatalf("failed to replay events: %v", err)
+		}
+		return
+	}
+
+	var userEventsConsumer *kafka.Consumer
+	if cfg.Kafka.Enabled && cfg.Kafka.UserEventsTopic != "" {
+		userEventsConsumer = kafka.NewConsumer(kafka.DefaultConsumerConfig(
+			cfg.Kafka.Brokers,
+			cfg.Kafka.UserEventsTopic,
+			cfg.Kafka.ConsumerGroup,
+		))
+		processed := kafka.NewPostgresProcessedStore(pool)
+		userEventsConsumer.SetProcessedStore(processed)
+		go kafka.PruneProcessed(ctx, processed, kafka.DefaultProcessedRetention)
+		for _, h := range authEventHandlers {
+			for _, eventType := range h.EventTypes() {
+				userEventsConsumer.RegisterHandler(eventType, func(ctx context.Context, event kafka.Event) error {
+					return h.Handle(ctx, event.Type, event.Payload)
+				})
+			}
+		}
+		go func() {
+			logger.Info("starting Kafka consumer", ports.String("topic", cfg.Kafka.UserEventsTopic))
+			if err := userEventsConsumer.Start(ctx); err != nil {
+				log.Printf("Kafka consumer error: %v", err)
+			}
+		}()
+	}
+
+	// Initialize external services. Top-ups by FPX and card, and guest card
+	// payments, go to the real gateways when they are configured; everything
+	// else stays on the mock.
+	paymentGateway := external.NewGatewayRouter(external.NewMockPaymentGateway())
+	if cfg.Payments.FPX.Enabled() {
+		privateKey, fpxKey, err := external.LoadFPXKeys(cfg.Payments.FPX.PrivateKeyFile, cfg.Payments.FPX.CertFile)
+		if err != nil {
+			log.Fatalf("failed to load FPX keys: %v", err)
+		}
+		paymentGateway.Register("fpx", external.NewFPXGateway(external.FPXConfig{
+			Endpoint:       cfg.Payments.FPX.Endpoint,
+			ExchangeID:     cfg.Payments.FPX.ExchangeID,
+			SellerID:       cfg.Payments.FPX.SellerID,
+			SellerBankCode: cfg.Payments.FPX.SellerBankCode,
+		}, privateKey, fpxKey), "fpx")
+		logger.Info("FPX top-ups enabled")
+	}
+	if cfg.Payments.Stripe.Enabled() {
+		stripe := external.NewStripeGateway(
+			cfg.Payments.Stripe.SecretKey,
+			cfg.Payments.Stripe.WebhookSecret,
+			cfg.Payments.ReturnURL,
+		)
+		paymentGateway.Register("stripe", stripe, "card")
+		paymentGateway.RegisterCards(stripe)
+		logger.Info("card top-ups and guest card payments enabled")
+	}
+
+	// Feature flags gate new flows such as gateway-backed top-ups
+	flagStore, closeFlagStore, err := featureflags.OpenStore(ctx, featureflags.StoreConfig{
+		Backend:       cfg.Flags.Backend,
+		RedisAddr:     cfg.Flags.RedisAddr,
+		RedisPassword: cfg.Flags.RedisPassword,
+		RedisDB:       cfg.Flags.RedisDB,
+		PostgresURL:   cfg.Flags.PostgresURL,
+	})
+	if err != nil {
+		log.Printf("warning: feature flag store unavailable, using defaults: %v", err)
+		flagStore, closeFlagStore = featureflags.NewMemoryStore(), func() {}
+	}
+	defer closeFlagStore()
+
+	flags := featureflags.NewClient(flagStore, featureflags.ClientConfig{
+		RefreshInterval: cfg.Flags.RefreshInterval,
+		// Top-ups charge the gateway unless the store explicitly turns it
+		// off, so an unreachable store never credits wallets for free
+		Defaults: map[string]bool{
+			ports.FlagGatewayTopUp: true,
+		},
+	})
+	if err := flags.Start(ctx); err != nil {
+		logger.Warn("failed to load feature flags, using defaults", ports.Err(err))
+	}
+	reloader.OnReload("feature flags", func() error { return flags.Refresh(ctx) })
 
 	// Initialize application service (use cases)
 	walletService := application.NewWalletService(
 		walletRepo,
 		txRepo,
-		nil, // Unit of Work - not implemented yet
+		ledgerRepo,
+		limitRepo,
+		uow,
 		paymentGateway,
 		eventPublisher,
+		flags,
+		domain.NewRoundingPolicy(cfg.Rounding.FiveSenMethods),
+		logger,
+	)
+	if cfg.Risk.Enabled {
+		walletService.SetRiskChecker(application.NewRuleRiskChecker(txRepo, domain.RiskRules{
+			VelocityWindow:    cfg.Risk.VelocityWindow,
+			VelocityLimit:     cfg.Risk.VelocityLimit,
+			UnusualFactor:     cfg.Risk.UnusualFactor,
+			UnusualMinimum:    cfg.Risk.UnusualMinimum,
+			UnusualMinHistory: cfg.Risk.UnusualMinHistory,
+			ChallengeScore:    cfg.Risk.ChallengeScore,
+			BlockScore:        cfg.Risk.BlockScore,
+		}))
+	}
+	var authClient *grpcAdapter.AuthGRPCClient
+	if cfg.StepUp.Enabled || cfg.PIN.Required {
+		clientCfg := client.DefaultConfig(cfg.AuthClient.GRPC)
+		clientCfg.DefaultTimeout = cfg.AuthClient.CallTimeout
+		if err := clientCfg.UseMutualTLS(cfg.GRPC.TLS); err != nil {
+			log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+		}
+		authClient, err = grpcAdapter.NewAuthGRPCClient(clientCfg)
+		if err != nil {
+			log.Fatalf("failed to create auth client: %v", err)
+		}
+	}
+	if cfg.StepUp.Enabled {
+		walletService.SetStepUp(authClient, domain.StepUpPolicy{PaymentThreshold: cfg.StepUp.PaymentThreshold})
+	}
+	if cfg.PIN.Required {
+		walletService.SetPINVerifier(authClient)
+	}
+	walletService.SetBalanceAlerts(postgres.NewBalanceAlertRepository(pool))
+	walletService.SetOrganizations(orgRepo)
+	walletService.SetKYCLimits(profileRepo, domain.KYCPolicy{
+		domain.KYCLevelBasic: {
+			MaxBalance: cfg.KYC.BasicMaxBalance,
+			MaxTopUp:   cfg.KYC.BasicMaxTopUp,
+			MaxPayment: cfg.KYC.BasicMaxPayment,
+		},
+		domain.KYCLevelVerified: {
+			MaxBalance: cfg.KYC.VerifiedMaxBalance,
+			MaxTopUp:   cfg.KYC.VerifiedMaxTopUp,
+			MaxPayment: cfg.KYC.VerifiedMaxPayment,
+		},
+	})
+
+	// The ledger is the source of truth for balances; reconciliation flags
+	// wallets whose stored balance drifted from it
+	ledgerService := application.NewLedgerService(
+		walletRepo,
+		ledgerRepo,
+		discrepancyRepo,
+		uow,
+		eventPublisher,
+		logger,
+		application.ReconciliationConfig{
+			BatchSize:  cfg.Ledger.BatchSize,
+			AutoRepair: cfg.Ledger.AutoRepair,
+		},
+	)
+	if cfg.Ledger.ReconcileEnabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Ledger.ReconcileInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := ledgerService.ReconcileAll(ctx); err != nil {
+						logger.Error("ledger reconciliation failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Promo codes credit wallets against the promotions ledger account
+	promotionService := application.NewPromotionService(
+		promotionRepo,
+		walletRepo,
+		txRepo,
+		ledgerRepo,
+		uow,
+		eventPublisher,
+		logger,
+	)
+
+	// Holds set money aside for parking reservations until they are settled
+	holdService := application.NewHoldService(
+		holdRepo,
+		walletRepo,
+		txRepo,
+		ledgerRepo,
+		uow,
+		eventPublisher,
 		logger,
 	)
 
-	// Initialize HTTP router with tracing middleware
-	router := httpAdapter.NewRouter(walletService)
+	// Freezes, unfreezes and reversals by support staff are recorded in the audit trail
+	adminService := application.NewWalletAdminService(
+		walletRepo,
+		txRepo,
+		ledgerRepo,
+		uow,
+		eventPublisher,
+		audit.NewRecorder(auditStore, "wallet"),
+		logger,
+	)
+
+	// Business accounts are invoiced for the previous month; the run repeats
+	// so a missed month end is picked up on the next tick
+	invoiceService := application.NewInvoiceService(
+		postgres.NewBusinessAccountRepository(pool),
+		postgres.NewInvoiceRepository(pool),
+		walletRepo,
+		txRepo,
+		external.NewHTMLInvoiceRenderer(),
+		eventPublisher,
+		logger,
+		application.InvoiceConfig{
+			Tax:      domain.InvoiceTax{Name: cfg.Invoices.TaxName, Rate: cfg.Invoices.TaxRate},
+			Location: cfg.Invoices.Location,
+		},
+	)
+	if cfg.Invoices.Enabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Invoices.RunInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := invoiceService.IssueMonthly(ctx, time.Now()); err != nil {
+						logger.Error("invoice run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Providers pay a commission on each payment under the fee schedule in
+	// force when it was made
+	feeRepo := postgres.NewFeeScheduleRepository(pool)
+	walletService.SetFees(feeRepo)
+	holdService.SetFees(feeRepo)
+	feeService := application.NewFeeService(feeRepo, audit.NewRecorder(auditStore, "wallet"), logger)
+
+	// Providers are settled for the previous day; like invoicing, the run
+	// repeats so a missed night is picked up on the next tick
+	settlementService := application.NewSettlementService(
+		postgres.NewSettlementRepository(pool),
+		txRepo,
+		eventPublisher,
+		audit.NewRecorder(auditStore, "wallet"),
+		logger,
+		application.SettlementConfig{Location: cfg.Settlement.Location},
+	)
+	if cfg.Settlement.Enabled {
+		go func() {
+			ticker := time.NewTicker(cfg.Settlement.RunInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := settlementService.SettleDaily(ctx, time.Now()); err != nil {
+						logger.Error("settlement run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	// Parking payments earn loyalty points, redeemed for wallet credit
+	// against the loyalty ledger account
+	loyaltyService := application.NewLoyaltyService(
+		postgres.NewLoyaltyRepository(pool),
+		walletRepo,
+		txRepo,
+		ledgerRepo,
+		uow,
+		eventPublisher,
+		logger,
+		domain.LoyaltyPolicy{
+			PointsPerRinggit: cfg.Loyalty.PointsPerRinggit,
+			PointValue:       cfg.Loyalty.PointValue,
+			MinRedemption:    cfg.Loyalty.MinRedemption,
+			Expiry:           cfg.Loyalty.Expiry,
+		},
+	)
+	if cfg.Loyalty.Enabled {
+		walletService.SetLoyalty(loyaltyService)
+		holdService.SetLoyalty(loyaltyService)
+		adminService.SetLoyalty(loyaltyService)
+		go func() {
+			ticker := time.NewTicker(cfg.Loyalty.ExpiryInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if _, err := loyaltyService.ExpirePoints(ctx, time.Now()); err != nil {
+						logger.Error("loyalty expiry run failed", ports.Err(err))
+					}
+				}
+			}
+		}()
+	}
+
+	if cfg.Referrals.Enabled {
+		walletService.SetReferrals(referralService)
+		holdService.SetReferrals(referralService)
+	}
+
+	// Initialize HTTP router with request logging and tracing middleware.
+	// Tracing is added last so it wraps the request logger.
+	router := httpAdapter.NewRouter(walletService, ledgerService, promotionService, adminService, invoiceService, orgService, settlementService, feeService, loyaltyService, referralService, auditStore, paymentGateway)
+	router.Use(logging.RequestLogger(logger))
 	if cfg.OTEL.Enabled {
 		router.Use(middleware.Tracing(cfg.OTEL.ServiceName))
 	}
 
 	// Create HTTP server
-	httpServer := &http.Server{
-		Addr:         ":" + cfg.Server.Port,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+	httpServer, err := httpserver.New(":"+cfg.Server.Port, reloader.Mount(router), cfg.Server.HTTP)
+	if err != nil {
+		log.Fatalf("failed to create HTTP server: %v", err)
 	}
 
 	// Create gRPC server
-	grpcServer := interceptors.NewServerWithDefaults()
-	walletGRPCServer := grpcAdapter.NewWalletServiceServer(walletService)
-	_ = walletGRPCServer // Register when proto is generated
-	// walletv1.RegisterWalletServiceServer(grpcServer, walletGRPCServer)
+	grpcTLS, err := interceptors.WithMutualTLS(cfg.GRPC.TLS)
+	if err != nil {
+		log.Fatalf("failed to load gRPC TLS certificates: %v", err)
+	}
+	grpcServer := interceptors.NewServerWithDefaults(grpcTLS...)
+	// Guests parking without a wallet pay by card
+	cardPaymentService := application.NewCardPaymentService(postgres.NewCardPaymentRepository(pool), paymentGateway, logger)
+	walletGRPCServer := grpcAdapter.NewWalletServiceServer(walletService, holdService, cardPaymentService)
+	walletv1.RegisterWalletServiceServer(grpcServer, walletGRPCServer)
 
 	// Start gRPC server
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPC.Port)
@@ -156,6 +592,15 @@ func main() {
 
 	// Shutdown gRPC server
 	grpcServer.GracefulStop()
+	if authClient != nil {
+		authClient.Close()
+	}
+
+	if userEventsConsumer != nil {
+		if err := userEventsConsumer.Close(); err != nil {
+			log.Printf("failed to close Kafka consumer: %v", err)
+		}
+	}
 
 	// Close Kafka publisher
 	if kafkaPublisher != nil {
@@ -170,13 +615,24 @@ func main() {
 			log.Printf("failed to shutdown tracer: %v", err)
 		}
 	}
+	if meterShutdown != nil {
+		if err := meterShutdown(shutdownCtx); err != nil {
+			log.Printf("failed to shutdown meter provider: %v", err)
+		}
+	}
 
 	logger.Info("server stopped gracefully")
 }
 
 // kafkaEventAdapter adapts kafka.Publisher to ports.EventPublisher
+// authEventHandler consumes events from the auth topic
+type authEventHandler interface {
+	EventTypes() []string
+	Handle(ctx context.Context, eventType string, payload map[string]interface{}) error
+}
+
 type kafkaEventAdapter struct {
-	publisher *kafka.Publisher
+	publisher kafka.EventPublisher
 }
 
 func (a *kafkaEventAdapter) Publish(ctx context.Context, event ports.Event) error {
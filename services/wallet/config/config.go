@@ -5,24 +5,52 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
+	_ "time/tzdata" // Invoice months follow INVOICE_TIMEZONE on hosts without zoneinfo
+
+	"github.com/parking-super-app/pkg/grpc/mtls"
+	"github.com/parking-super-app/pkg/httpserver"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/pkg/settings"
+	"github.com/parking-super-app/pkg/startup"
+	"github.com/shopspring/decimal"
 )
 
 // Config holds all configuration for the wallet service.
 // Configuration is loaded from environment variables following 12-factor app principles.
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Kafka    KafkaConfig
-	GRPC     GRPCConfig
-	OTEL     OTELConfig
+	Server     ServerConfig
+	Log        logging.Config
+	Startup    startup.Config
+	Database   DatabaseConfig
+	Kafka      KafkaConfig
+	EventStore EventStoreConfig
+	GRPC       GRPCConfig
+	OTEL       OTELConfig
+	Flags      FlagsConfig
+	Rounding   RoundingConfig
+	Ledger     LedgerConfig
+	Payments   PaymentsConfig
+	KYC        KYCConfig
+	Risk       RiskConfig
+	AuthClient AuthClientConfig
+	StepUp     StepUpConfig
+	PIN        PINConfig
+	Invoices   InvoicesConfig
+	Settlement SettlementConfig
+	Loyalty    LoyaltyConfig
+	Referrals  ReferralConfig
 }
 
 type ServerConfig struct {
 	Port string
+	HTTP httpserver.Config
 }
 
 type GRPCConfig struct {
 	Port string
+	// TLS secures the gRPC listener and calls to other services
+	TLS mtls.Config
 }
 
 type DatabaseConfig struct {
@@ -32,12 +60,25 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+	// MigrateOnStart applies pending migrations before serving
+	MigrateOnStart bool
+	// ReplicaURL is an optional read replica DSN for lag-tolerant queries
+	ReplicaURL string
 }
 
 type KafkaConfig struct {
-	Brokers []string
-	Topic   string
-	Enabled bool
+	Brokers         []string
+	Topic           string
+	UserEventsTopic string // Auth events that keep user_profiles current; empty disables the consumer
+	ConsumerGroup   string
+	Enabled         bool
+}
+
+// EventStoreConfig selects where published events are recorded for the
+// admin event browser: memory or postgres
+type EventStoreConfig struct {
+	Backend     string
+	PostgresURL string
 }
 
 type OTELConfig struct {
@@ -45,6 +86,150 @@ type OTELConfig struct {
 	Endpoint    string
 	ServiceName string
 	Insecure    bool
+
+	// Metrics are pushed to the same collector as traces
+	MetricsEnabled  bool
+	MetricsInterval time.Duration
+	RuntimeMetrics  bool
+}
+
+// FlagsConfig selects the shared feature flag store: memory, redis or postgres
+type FlagsConfig struct {
+	Backend         string
+	RedisAddr       string
+	RedisPassword   string
+	RedisDB         int
+	PostgresURL     string
+	RefreshInterval time.Duration
+}
+
+// RoundingConfig lists the payment methods charged with 5-sen rounding
+type RoundingConfig struct {
+	FiveSenMethods []string
+}
+
+// LedgerConfig controls the scheduled ledger reconciliation
+type LedgerConfig struct {
+	ReconcileEnabled  bool
+	ReconcileInterval time.Duration
+	BatchSize         int
+	AutoRepair        bool
+}
+
+// InvoicesConfig controls the monthly invoice run for business accounts.
+// Amounts are taken to include TaxRate of tax named TaxName.
+type InvoicesConfig struct {
+	Enabled     bool
+	RunInterval time.Duration
+	TaxName     string
+	TaxRate     decimal.Decimal
+	Location    *time.Location
+}
+
+// SettlementConfig controls the nightly provider settlement run. Days start
+// at midnight in Location.
+type SettlementConfig struct {
+	Enabled     bool
+	RunInterval time.Duration
+	Location    *time.Location
+}
+
+// LoyaltyConfig controls the points programme. Earning and the expiry job
+// stop when it is disabled; points already earned can still be redeemed.
+type LoyaltyConfig struct {
+	Enabled          bool
+	PointsPerRinggit decimal.Decimal
+	PointValue       decimal.Decimal
+	MinRedemption    int64
+	Expiry           time.Duration
+	ExpiryInterval   time.Duration
+}
+
+// ReferralConfig sets the rewards paid to both sides of a referral on the
+// referee's first payment. Referrals are still recorded when it is
+// disabled, but nothing is paid.
+type ReferralConfig struct {
+	Enabled        bool
+	ReferrerReward decimal.Decimal
+	RefereeReward  decimal.Decimal
+	Window         time.Duration
+}
+
+// PaymentsConfig enables the real top-up gateways. A gateway is off until its
+// credentials are set; unrouted payment methods use the mock gateway.
+type PaymentsConfig struct {
+	// ReturnURL is where the user lands after a bank or 3-D Secure redirect
+	ReturnURL string
+	FPX       FPXConfig
+	Stripe    StripeConfig
+}
+
+// FPXConfig holds the merchant's FPX exchange registration and keys
+type FPXConfig struct {
+	Endpoint       string
+	ExchangeID     string
+	SellerID       string
+	SellerBankCode string
+	PrivateKeyFile string
+	CertFile       string
+}
+
+func (c FPXConfig) Enabled() bool {
+	return c.SellerID != ""
+}
+
+// StripeConfig holds the Stripe keys used for card top-ups
+type StripeConfig struct {
+	SecretKey     string
+	WebhookSecret string
+}
+
+func (c StripeConfig) Enabled() bool {
+	return c.SecretKey != ""
+}
+
+// KYCConfig caps wallets by their owner's KYC level. A nil limit is not
+// enforced; set the variable to "none" to lift a default.
+type KYCConfig struct {
+	BasicMaxBalance    *decimal.Decimal
+	BasicMaxTopUp      *decimal.Decimal
+	BasicMaxPayment    *decimal.Decimal
+	VerifiedMaxBalance *decimal.Decimal
+	VerifiedMaxTopUp   *decimal.Decimal
+	VerifiedMaxPayment *decimal.Decimal
+}
+
+// RiskConfig tunes the rule-based checks run before top-ups and payments
+type RiskConfig struct {
+	Enabled           bool
+	VelocityWindow    time.Duration
+	VelocityLimit     int
+	UnusualFactor     decimal.Decimal
+	UnusualMinimum    decimal.Decimal
+	UnusualMinHistory int
+	ChallengeScore    int
+	BlockScore        int
+}
+
+// AuthClientConfig is how the wallet reaches the auth service for step-up
+// and PIN checks.
+type AuthClientConfig struct {
+	GRPC        string
+	CallTimeout time.Duration
+}
+
+// StepUpConfig has users confirm large payments, and transactions the risk
+// check challenges, with an OTP from the auth service. A nil threshold
+// leaves step-up to the risk check alone.
+type StepUpConfig struct {
+	Enabled          bool
+	PaymentThreshold *decimal.Decimal
+}
+
+// PINConfig has users enter their transaction PIN for payments they make
+// in the app.
+type PINConfig struct {
+	Required bool
 }
 
 func (d DatabaseConfig) ConnectionString() string {
@@ -55,39 +240,359 @@ func (d DatabaseConfig) ConnectionString() string {
 }
 
 func Load() (*Config, error) {
+	// Without CONFIG_STRICT these fall back to local development values
+	if err := settings.Require("DB_HOST", "DB_USER", "DB_PASSWORD", "DB_NAME"); err != nil {
+		return nil, err
+	}
+
 	kafkaEnabled, _ := strconv.ParseBool(getEnv("KAFKA_ENABLED", "false"))
 	otelEnabled, _ := strconv.ParseBool(getEnv("OTEL_ENABLED", "false"))
+	migrateOnStart, _ := strconv.ParseBool(getEnv("DB_MIGRATE_ON_START", "false"))
 	otelInsecure, _ := strconv.ParseBool(getEnv("OTEL_INSECURE", "true"))
+	otelMetrics, _ := strconv.ParseBool(getEnv("OTEL_METRICS_ENABLED", "true"))
+	otelRuntimeMetrics, _ := strconv.ParseBool(getEnv("OTEL_RUNTIME_METRICS", "true"))
+	otelMetricsInterval, err := time.ParseDuration(getEnv("OTEL_METRICS_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OTEL_METRICS_INTERVAL: %w", err)
+	}
+	flagsRedisDB, _ := strconv.Atoi(getEnv("FEATURE_FLAGS_REDIS_DB", "0"))
+	reconcileEnabled, _ := strconv.ParseBool(getEnv("LEDGER_RECONCILE_ENABLED", "true"))
+	autoRepair, _ := strconv.ParseBool(getEnv("LEDGER_AUTO_REPAIR", "false"))
+	reconcileBatch, _ := strconv.Atoi(getEnv("LEDGER_RECONCILE_BATCH_SIZE", "100"))
+	flagsRefresh, err := time.ParseDuration(getEnv("FEATURE_FLAGS_REFRESH_INTERVAL", "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEATURE_FLAGS_REFRESH_INTERVAL: %w", err)
+	}
+	reconcileInterval, err := time.ParseDuration(getEnv("LEDGER_RECONCILE_INTERVAL", "1h"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid LEDGER_RECONCILE_INTERVAL: %w", err)
+	}
+
+	kycCfg, err := loadKYCConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	riskCfg, err := loadRiskConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	authClientCfg, err := loadAuthClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	stepUpCfg, err := loadStepUpConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	pinRequired, _ := strconv.ParseBool(getEnv("PIN_REQUIRED", "true"))
+
+	invoicesCfg, err := loadInvoicesConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	settlementCfg, err := loadSettlementConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	loyaltyCfg, err := loadLoyaltyConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	referralCfg, err := loadReferralConfig()
+	if err != nil {
+		return nil, err
+	}
 
 	// Parse Kafka brokers (comma-separated)
 	brokers := strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ",")
 
+	httpCfg, err := httpserver.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	logCfg, err := logging.ConfigFromEnv("wallet-service")
+	if err != nil {
+		return nil, err
+	}
+
+	startupCfg, err := startup.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	grpcTLS, err := mtls.ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Port: getEnv("SERVER_PORT", "8080"),
+			HTTP: httpCfg,
 		},
+		Log:     logCfg,
+		Startup: startupCfg,
 		GRPC: GRPCConfig{
 			Port: getEnv("GRPC_PORT", "9000"),
+			TLS:  grpcTLS,
 		},
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5433"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "wallet_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:           getEnv("DB_HOST", "localhost"),
+			Port:           getEnv("DB_PORT", "5433"),
+			User:           getEnv("DB_USER", "postgres"),
+			Password:       getEnv("DB_PASSWORD", "postgres"),
+			DBName:         getEnv("DB_NAME", "wallet_db"),
+			SSLMode:        getEnv("DB_SSLMODE", "disable"),
+			MigrateOnStart: migrateOnStart,
+			ReplicaURL:     getEnv("DB_REPLICA_URL", ""),
 		},
 		Kafka: KafkaConfig{
-			Brokers: brokers,
-			Topic:   getEnv("KAFKA_TOPIC", "wallet.events"),
-			Enabled: kafkaEnabled,
+			Brokers:         brokers,
+			Topic:           getEnv("KAFKA_TOPIC", "wallet.events"),
+			UserEventsTopic: getEnv("KAFKA_USER_EVENTS_TOPIC", "auth.events"),
+			ConsumerGroup:   getEnv("KAFKA_CONSUMER_GROUP", "wallet-service"),
+			Enabled:         kafkaEnabled,
+		},
+		EventStore: EventStoreConfig{
+			Backend:     getEnv("EVENT_STORE_BACKEND", "memory"),
+			PostgresURL: getEnv("EVENT_STORE_POSTGRES_URL", ""),
 		},
 		OTEL: OTELConfig{
-			Enabled:     otelEnabled,
-			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
-			ServiceName: getEnv("OTEL_SERVICE_NAME", "wallet-service"),
-			Insecure:    otelInsecure,
+			Enabled:         otelEnabled,
+			Endpoint:        getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "localhost:4317"),
+			ServiceName:     getEnv("OTEL_SERVICE_NAME", "wallet-service"),
+			Insecure:        otelInsecure,
+			MetricsEnabled:  otelMetrics,
+			MetricsInterval: otelMetricsInterval,
+			RuntimeMetrics:  otelRuntimeMetrics,
+		},
+		Flags: FlagsConfig{
+			Backend:         getEnv("FEATURE_FLAGS_BACKEND", "memory"),
+			RedisAddr:       getEnv("FEATURE_FLAGS_REDIS_ADDR", "localhost:6379"),
+			RedisPassword:   getEnv("FEATURE_FLAGS_REDIS_PASSWORD", ""),
+			RedisDB:         flagsRedisDB,
+			PostgresURL:     getEnv("FEATURE_FLAGS_POSTGRES_URL", ""),
+			RefreshInterval: flagsRefresh,
+		},
+		Rounding: RoundingConfig{
+			FiveSenMethods: strings.Split(getEnv("ROUNDING_5SEN_METHODS", "cash"), ","),
+		},
+		Ledger: LedgerConfig{
+			ReconcileEnabled:  reconcileEnabled,
+			ReconcileInterval: reconcileInterval,
+			BatchSize:         reconcileBatch,
+			AutoRepair:        autoRepair,
 		},
+		Payments: PaymentsConfig{
+			ReturnURL: getEnv("PAYMENT_RETURN_URL", "parkingapp://wallet/topup/return"),
+			FPX: FPXConfig{
+				Endpoint:       getEnv("FPX_ENDPOINT", "https://uat.mepsfpx.com.my/FPXMain/seller2DReceiver.jsp"),
+				ExchangeID:     getEnv("FPX_EXCHANGE_ID", ""),
+				SellerID:       getEnv("FPX_SELLER_ID", ""),
+				SellerBankCode: getEnv("FPX_SELLER_BANK_CODE", "01"),
+				PrivateKeyFile: getEnv("FPX_PRIVATE_KEY_FILE", ""),
+				CertFile:       getEnv("FPX_CERT_FILE", ""),
+			},
+			Stripe: StripeConfig{
+				SecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+				WebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+			},
+		},
+		KYC:        kycCfg,
+		Risk:       riskCfg,
+		AuthClient: authClientCfg,
+		StepUp:     stepUpCfg,
+		PIN:        PINConfig{Required: pinRequired},
+		Invoices:   invoicesCfg,
+		Settlement: settlementCfg,
+		Loyalty:    loyaltyCfg,
+		Referrals:  referralCfg,
+	}, nil
+}
+
+// loadKYCConfig reads the limits in MYR. Unverified users default to
+// e-money amounts that need no identity checks.
+func loadKYCConfig() (KYCConfig, error) {
+	var cfg KYCConfig
+	limits := []struct {
+		key, fallback string
+		dst           **decimal.Decimal
+	}{
+		{"KYC_BASIC_MAX_BALANCE", "200", &cfg.BasicMaxBalance},
+		{"KYC_BASIC_MAX_TOPUP", "200", &cfg.BasicMaxTopUp},
+		{"KYC_BASIC_MAX_PAYMENT", "100", &cfg.BasicMaxPayment},
+		{"KYC_VERIFIED_MAX_BALANCE", "5000", &cfg.VerifiedMaxBalance},
+		{"KYC_VERIFIED_MAX_TOPUP", "2000", &cfg.VerifiedMaxTopUp},
+		{"KYC_VERIFIED_MAX_PAYMENT", "none", &cfg.VerifiedMaxPayment},
+	}
+	for _, l := range limits {
+		v := getEnv(l.key, l.fallback)
+		if v == "none" {
+			continue
+		}
+		limit, err := decimal.NewFromString(v)
+		if err != nil || !limit.IsPositive() {
+			return KYCConfig{}, fmt.Errorf("invalid %s: must be a positive amount or none", l.key)
+		}
+		*l.dst = &limit
+	}
+	return cfg, nil
+}
+
+func loadRiskConfig() (RiskConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("RISK_CHECKS_ENABLED", "true"))
+	window, err := time.ParseDuration(getEnv("RISK_VELOCITY_WINDOW", "10m"))
+	if err != nil {
+		return RiskConfig{}, fmt.Errorf("invalid RISK_VELOCITY_WINDOW: %w", err)
+	}
+	factor, err := decimal.NewFromString(getEnv("RISK_UNUSUAL_AMOUNT_FACTOR", "5"))
+	if err != nil {
+		return RiskConfig{}, fmt.Errorf("invalid RISK_UNUSUAL_AMOUNT_FACTOR: %w", err)
+	}
+	minimum, err := decimal.NewFromString(getEnv("RISK_UNUSUAL_AMOUNT_MIN", "50"))
+	if err != nil {
+		return RiskConfig{}, fmt.Errorf("invalid RISK_UNUSUAL_AMOUNT_MIN: %w", err)
+	}
+	velocityLimit, _ := strconv.Atoi(getEnv("RISK_VELOCITY_LIMIT", "5"))
+	minHistory, _ := strconv.Atoi(getEnv("RISK_UNUSUAL_AMOUNT_MIN_HISTORY", "3"))
+	challengeScore, _ := strconv.Atoi(getEnv("RISK_CHALLENGE_SCORE", "40"))
+	blockScore, _ := strconv.Atoi(getEnv("RISK_BLOCK_SCORE", "80"))
+
+	return RiskConfig{
+		Enabled:           enabled,
+		VelocityWindow:    window,
+		VelocityLimit:     velocityLimit,
+		UnusualFactor:     factor,
+		UnusualMinimum:    minimum,
+		UnusualMinHistory: minHistory,
+		ChallengeScore:    challengeScore,
+		BlockScore:        blockScore,
+	}, nil
+}
+
+func loadAuthClientConfig() (AuthClientConfig, error) {
+	timeout, err := time.ParseDuration(getEnv("AUTH_GRPC_TIMEOUT", "3s"))
+	if err != nil {
+		return AuthClientConfig{}, fmt.Errorf("invalid AUTH_GRPC_TIMEOUT: %w", err)
+	}
+	return AuthClientConfig{
+		GRPC:        getEnv("AUTH_SERVICE_GRPC", "localhost:9081"),
+		CallTimeout: timeout,
+	}, nil
+}
+
+func loadStepUpConfig() (StepUpConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("STEP_UP_ENABLED", "true"))
+	cfg := StepUpConfig{Enabled: enabled}
+	if v := getEnv("STEP_UP_PAYMENT_THRESHOLD", "200"); v != "none" {
+		threshold, err := decimal.NewFromString(v)
+		if err != nil || !threshold.IsPositive() {
+			return StepUpConfig{}, fmt.Errorf("invalid STEP_UP_PAYMENT_THRESHOLD: must be a positive amount or none")
+		}
+		cfg.PaymentThreshold = &threshold
+	}
+	return cfg, nil
+}
+
+func loadInvoicesConfig() (InvoicesConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("INVOICE_RUN_ENABLED", "true"))
+	interval, err := time.ParseDuration(getEnv("INVOICE_RUN_INTERVAL", "1h"))
+	if err != nil {
+		return InvoicesConfig{}, fmt.Errorf("invalid INVOICE_RUN_INTERVAL: %w", err)
+	}
+	rate, err := decimal.NewFromString(getEnv("INVOICE_TAX_RATE", "0.08"))
+	if err != nil || rate.IsNegative() {
+		return InvoicesConfig{}, fmt.Errorf("invalid INVOICE_TAX_RATE: must be a fraction such as 0.08")
+	}
+	loc, err := time.LoadLocation(getEnv("INVOICE_TIMEZONE", "Asia/Kuala_Lumpur"))
+	if err != nil {
+		return InvoicesConfig{}, fmt.Errorf("invalid INVOICE_TIMEZONE: %w", err)
+	}
+	return InvoicesConfig{
+		Enabled:     enabled,
+		RunInterval: interval,
+		TaxName:     getEnv("INVOICE_TAX_NAME", "SST"),
+		TaxRate:     rate,
+		Location:    loc,
+	}, nil
+}
+
+func loadSettlementConfig() (SettlementConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("SETTLEMENT_RUN_ENABLED", "true"))
+	interval, err := time.ParseDuration(getEnv("SETTLEMENT_RUN_INTERVAL", "1h"))
+	if err != nil {
+		return SettlementConfig{}, fmt.Errorf("invalid SETTLEMENT_RUN_INTERVAL: %w", err)
+	}
+	loc, err := time.LoadLocation(getEnv("SETTLEMENT_TIMEZONE", "Asia/Kuala_Lumpur"))
+	if err != nil {
+		return SettlementConfig{}, fmt.Errorf("invalid SETTLEMENT_TIMEZONE: %w", err)
+	}
+	return SettlementConfig{
+		Enabled:     enabled,
+		RunInterval: interval,
+		Location:    loc,
+	}, nil
+}
+
+func loadLoyaltyConfig() (LoyaltyConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("LOYALTY_ENABLED", "true"))
+	rate, err := decimal.NewFromString(getEnv("LOYALTY_POINTS_PER_RINGGIT", "1"))
+	if err != nil || rate.IsNegative() {
+		return LoyaltyConfig{}, fmt.Errorf("invalid LOYALTY_POINTS_PER_RINGGIT: must be zero or more")
+	}
+	value, err := decimal.NewFromString(getEnv("LOYALTY_POINT_VALUE", "0.01"))
+	if err != nil || !value.IsPositive() {
+		return LoyaltyConfig{}, fmt.Errorf("invalid LOYALTY_POINT_VALUE: must be a positive amount such as 0.01")
+	}
+	minRedemption, err := strconv.ParseInt(getEnv("LOYALTY_MIN_REDEMPTION", "100"), 10, 64)
+	if err != nil || minRedemption < 1 {
+		return LoyaltyConfig{}, fmt.Errorf("invalid LOYALTY_MIN_REDEMPTION: must be at least 1")
+	}
+	expiry, err := time.ParseDuration(getEnv("LOYALTY_POINTS_EXPIRY", "8760h"))
+	if err != nil || expiry <= 0 {
+		return LoyaltyConfig{}, fmt.Errorf("invalid LOYALTY_POINTS_EXPIRY: must be a positive duration")
+	}
+	interval, err := time.ParseDuration(getEnv("LOYALTY_EXPIRY_INTERVAL", "1h"))
+	if err != nil {
+		return LoyaltyConfig{}, fmt.Errorf("invalid LOYALTY_EXPIRY_INTERVAL: %w", err)
+	}
+	return LoyaltyConfig{
+		Enabled:          enabled,
+		PointsPerRinggit: rate,
+		PointValue:       value,
+		MinRedemption:    minRedemption,
+		Expiry:           expiry,
+		ExpiryInterval:   interval,
+	}, nil
+}
+
+func loadReferralConfig() (ReferralConfig, error) {
+	enabled, _ := strconv.ParseBool(getEnv("REFERRAL_ENABLED", "true"))
+	referrerReward, err := decimal.NewFromString(getEnv("REFERRAL_REFERRER_REWARD", "5.00"))
+	if err != nil || referrerReward.IsNegative() {
+		return ReferralConfig{}, fmt.Errorf("invalid REFERRAL_REFERRER_REWARD: must be an amount such as 5.00")
+	}
+	refereeReward, err := decimal.NewFromString(getEnv("REFERRAL_REFEREE_REWARD", "5.00"))
+	if err != nil || refereeReward.IsNegative() {
+		return ReferralConfig{}, fmt.Errorf("invalid REFERRAL_REFEREE_REWARD: must be an amount such as 5.00")
+	}
+	window, err := time.ParseDuration(getEnv("REFERRAL_WINDOW", "720h"))
+	if err != nil || window <= 0 {
+		return ReferralConfig{}, fmt.Errorf("invalid REFERRAL_WINDOW: must be a positive duration")
+	}
+	return ReferralConfig{
+		Enabled:        enabled,
+		ReferrerReward: referrerReward,
+		RefereeReward:  refereeReward,
+		Window:         window,
 	}, nil
 }
 
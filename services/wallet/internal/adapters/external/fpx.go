@@ -0,0 +1,236 @@
+package external
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// FPX message fields covered by the checksum, in the order PayNet signs them
+var (
+	fpxRequestFields = []string{
+		"fpx_buyerAccNo", "fpx_buyerBankBranch", "fpx_buyerBankId", "fpx_buyerEmail",
+		"fpx_buyerIban", "fpx_buyerId", "fpx_buyerName", "fpx_makerName", "fpx_msgToken",
+		"fpx_msgType", "fpx_productDesc", "fpx_sellerBankCode", "fpx_sellerExId",
+		"fpx_sellerExOrderNo", "fpx_sellerId", "fpx_sellerOrderNo", "fpx_sellerTxnTime",
+		"fpx_txnAmount", "fpx_txnCurrency", "fpx_version",
+	}
+	fpxResponseFields = []string{
+		"fpx_buyerBankBranch", "fpx_buyerBankId", "fpx_buyerIban", "fpx_buyerId",
+		"fpx_buyerName", "fpx_creditAuthCode", "fpx_creditAuthNo", "fpx_debitAuthCode",
+		"fpx_debitAuthNo", "fpx_fpxTxnId", "fpx_fpxTxnTime", "fpx_makerName", "fpx_msgToken",
+		"fpx_msgType", "fpx_sellerExId", "fpx_sellerExOrderNo", "fpx_sellerId",
+		"fpx_sellerOrderNo", "fpx_sellerTxnTime", "fpx_txnAmount", "fpx_txnCurrency",
+	}
+)
+
+// FPX debit authorisation codes
+const (
+	fpxApproved       = "00"
+	fpxPendingAuth    = "09"
+	fpxPendingPayment = "99"
+)
+
+// malaysiaTime is the zone FPX expects seller transaction times in
+var malaysiaTime = time.FixedZone("MYT", 8*60*60)
+
+// FPXConfig identifies the merchant to PayNet's FPX exchange
+type FPXConfig struct {
+	Endpoint       string // Bank selection page the user's browser posts to
+	ExchangeID     string
+	SellerID       string
+	SellerBankCode string
+	Version        string
+}
+
+// FPXGateway takes top-ups through FPX online banking. A top-up always starts
+// pending: the user is redirected to their bank, and FPX confirms the debit
+// with a signed direct AC message to our webhook.
+type FPXGateway struct {
+	cfg        FPXConfig
+	privateKey *rsa.PrivateKey
+	fpxKey     *rsa.PublicKey
+	now        func() time.Time
+}
+
+// NewFPXGateway signs requests with the merchant key and verifies responses
+// with the key from PayNet's FPX certificate
+func NewFPXGateway(cfg FPXConfig, privateKey *rsa.PrivateKey, fpxKey *rsa.PublicKey) *FPXGateway {
+	if cfg.Version == "" {
+		cfg.Version = "7.0"
+	}
+	return &FPXGateway{cfg: cfg, privateKey: privateKey, fpxKey: fpxKey, now: time.Now}
+}
+
+// LoadFPXKeys reads the merchant's PEM private key and PayNet's PEM certificate
+func LoadFPXKeys(privateKeyFile, certFile string) (*rsa.PrivateKey, *rsa.PublicKey, error) {
+	keyPEM, err := os.ReadFile(privateKeyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read FPX private key: %w", err)
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, nil, errors.New("FPX private key is not PEM encoded")
+	}
+	var privateKey *rsa.PrivateKey
+	if parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		key, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, nil, errors.New("FPX private key is not an RSA key")
+		}
+		privateKey = key
+	} else if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse FPX private key: %w", err)
+	}
+
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read FPX certificate: %w", err)
+	}
+	block, _ = pem.Decode(certPEM)
+	if block == nil {
+		return nil, nil, errors.New("FPX certificate is not PEM encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse FPX certificate: %w", err)
+	}
+	fpxKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, nil, errors.New("FPX certificate does not hold an RSA key")
+	}
+	return privateKey, fpxKey, nil
+}
+
+// ProcessTopUp builds the signed authorisation request (AR) the user's
+// browser posts to FPX. The buyer's bank is passed as the request token.
+func (g *FPXGateway) ProcessTopUp(ctx context.Context, req ports.TopUpRequest) (*ports.TopUpResponse, error) {
+	if req.Token == "" {
+		return &ports.TopUpResponse{Status: ports.GatewayStatusFailed, Message: "Select a bank to pay with FPX"}, nil
+	}
+	if req.Reference == "" {
+		return nil, errors.New("FPX top-up needs a transaction reference")
+	}
+
+	fields := map[string]string{
+		"fpx_msgType":         "AR",
+		"fpx_msgToken":        "01",
+		"fpx_sellerExId":      g.cfg.ExchangeID,
+		"fpx_sellerExOrderNo": req.Reference,
+		"fpx_sellerTxnTime":   g.now().In(malaysiaTime).Format("20060102150405"),
+		"fpx_sellerOrderNo":   req.Reference,
+		"fpx_sellerId":        g.cfg.SellerID,
+		"fpx_sellerBankCode":  g.cfg.SellerBankCode,
+		"fpx_txnCurrency":     "MYR",
+		"fpx_txnAmount":       req.Amount.StringFixed(2),
+		"fpx_buyerBankId":     req.Token,
+		"fpx_productDesc":     "Wallet top-up",
+		"fpx_version":         g.cfg.Version,
+	}
+	for _, name := range fpxRequestFields {
+		if _, ok := fields[name]; !ok {
+			fields[name] = ""
+		}
+	}
+
+	checksum, err := g.sign(checksumSource(fields, fpxRequestFields))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign FPX request: %w", err)
+	}
+	fields["fpx_checkSum"] = checksum
+
+	return &ports.TopUpResponse{
+		TransactionID: req.Reference,
+		Status:        ports.GatewayStatusPending,
+		Message:       "Continue to your bank to approve the top-up",
+		Redirect: &ports.Redirect{
+			URL:    g.cfg.Endpoint,
+			Method: http.MethodPost,
+			Fields: fields,
+		},
+	}, nil
+}
+
+// VerifyWebhook checks the checksum on a direct AC message from FPX. Pending
+// authorisations need no action; FPX sends another AC once the bank decides.
+func (g *FPXGateway) VerifyWebhook(payload []byte, header http.Header) (*ports.GatewayEvent, error) {
+	values, err := url.ParseQuery(string(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhook, err)
+	}
+	fields := make(map[string]string, len(fpxResponseFields))
+	for _, name := range fpxResponseFields {
+		fields[name] = values.Get(name)
+	}
+
+	if err := g.verify(checksumSource(fields, fpxResponseFields), values.Get("fpx_checkSum")); err != nil {
+		return nil, fmt.Errorf("%w: FPX checksum mismatch", domain.ErrInvalidWebhook)
+	}
+	if fields["fpx_msgType"] != "AC" || fields["fpx_sellerId"] != g.cfg.SellerID {
+		return nil, fmt.Errorf("%w: unexpected FPX message", domain.ErrInvalidWebhook)
+	}
+
+	event := &ports.GatewayEvent{
+		Gateway:              "fpx",
+		Reference:            fields["fpx_sellerOrderNo"],
+		GatewayTransactionID: fields["fpx_fpxTxnId"],
+	}
+	switch code := fields["fpx_debitAuthCode"]; code {
+	case fpxApproved:
+		amount, err := decimal.NewFromString(fields["fpx_txnAmount"])
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad FPX amount", domain.ErrInvalidWebhook)
+		}
+		event.Status = ports.GatewayStatusSuccess
+		event.Amount = amount
+	case fpxPendingAuth, fpxPendingPayment:
+		return nil, nil
+	default:
+		event.Status = ports.GatewayStatusFailed
+		event.Message = "FPX debit was not authorised (code " + code + ")"
+	}
+	return event, nil
+}
+
+func checksumSource(fields map[string]string, names []string) string {
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = fields[name]
+	}
+	return strings.Join(values, "|")
+}
+
+// FPX checksums are SHA1withRSA signatures, hex encoded in upper case
+func (g *FPXGateway) sign(source string) (string, error) {
+	digest := sha1.Sum([]byte(source))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, g.privateKey, crypto.SHA1, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(sig)), nil
+}
+
+func (g *FPXGateway) verify(source, checksum string) error {
+	sig, err := hex.DecodeString(checksum)
+	if err != nil {
+		return err
+	}
+	digest := sha1.Sum([]byte(source))
+	return rsa.VerifyPKCS1v15(g.fpxKey, crypto.SHA1, digest[:], sig)
+}
@@ -0,0 +1,81 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// TopUpGateway is a gateway that takes top-ups and confirms them by webhook
+type TopUpGateway interface {
+	ProcessTopUp(ctx context.Context, req ports.TopUpRequest) (*ports.TopUpResponse, error)
+	VerifyWebhook(payload []byte, header http.Header) (*ports.GatewayEvent, error)
+}
+
+// CardGateway charges a card directly, with no redirect or webhook
+type CardGateway interface {
+	ProcessPayment(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error)
+}
+
+// GatewayRouter sends each top-up to the gateway registered for its payment
+// method, each card payment to the card gateway, and each webhook to the
+// gateway it names. Refunds, unrouted methods and card payments with no card
+// gateway go to the fallback gateway.
+type GatewayRouter struct {
+	fallback ports.PaymentGateway
+	cards    CardGateway
+	methods  map[string]TopUpGateway
+	gateways map[string]TopUpGateway
+}
+
+func NewGatewayRouter(fallback ports.PaymentGateway) *GatewayRouter {
+	return &GatewayRouter{
+		fallback: fallback,
+		methods:  make(map[string]TopUpGateway),
+		gateways: make(map[string]TopUpGateway),
+	}
+}
+
+// Register routes top-ups paid with any of methods to gateway, and webhooks
+// posted for name to its verifier
+func (r *GatewayRouter) Register(name string, gateway TopUpGateway, methods ...string) {
+	r.gateways[name] = gateway
+	for _, method := range methods {
+		r.methods[strings.ToLower(method)] = gateway
+	}
+}
+
+// RegisterCards routes payments made with a card token to gateway
+func (r *GatewayRouter) RegisterCards(gateway CardGateway) {
+	r.cards = gateway
+}
+
+func (r *GatewayRouter) ProcessTopUp(ctx context.Context, req ports.TopUpRequest) (*ports.TopUpResponse, error) {
+	if gateway, ok := r.methods[strings.ToLower(req.PaymentMethod)]; ok {
+		return gateway.ProcessTopUp(ctx, req)
+	}
+	return r.fallback.ProcessTopUp(ctx, req)
+}
+
+func (r *GatewayRouter) ProcessPayment(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error) {
+	if req.Token != "" && r.cards != nil {
+		return r.cards.ProcessPayment(ctx, req)
+	}
+	return r.fallback.ProcessPayment(ctx, req)
+}
+
+func (r *GatewayRouter) ProcessRefund(ctx context.Context, req ports.RefundRequest) (*ports.RefundResponse, error) {
+	return r.fallback.ProcessRefund(ctx, req)
+}
+
+func (r *GatewayRouter) VerifyWebhook(gateway string, payload []byte, header http.Header) (*ports.GatewayEvent, error) {
+	g, ok := r.gateways[gateway]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown gateway %q", domain.ErrInvalidWebhook, gateway)
+	}
+	return g.VerifyWebhook(payload, header)
+}
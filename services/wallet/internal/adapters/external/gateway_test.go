@@ -0,0 +1,353 @@
+package external
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+func TestStripeGateway_ProcessTopUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantStatus   string
+		wantRedirect string
+		wantCaptured string
+	}{
+		{
+			name:         "captured",
+			status:       http.StatusOK,
+			body:         `{"id":"pi_1","status":"succeeded","amount_received":5000}`,
+			wantStatus:   ports.GatewayStatusSuccess,
+			wantCaptured: "50",
+		},
+		{
+			name:         "3-D Secure",
+			status:       http.StatusOK,
+			body:         `{"id":"pi_1","status":"requires_action","next_action":{"type":"redirect_to_url","redirect_to_url":{"url":"https://hooks.stripe.com/3ds"}}}`,
+			wantStatus:   ports.GatewayStatusPending,
+			wantRedirect: "https://hooks.stripe.com/3ds",
+		},
+		{
+			name:       "card declined",
+			status:     http.StatusPaymentRequired,
+			body:       `{"error":{"type":"card_error","code":"card_declined","message":"Your card was declined."}}`,
+			wantStatus: ports.GatewayStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form url.Values
+			var idempotencyKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				form = r.PostForm
+				idempotencyKey = r.Header.Get("Idempotency-Key")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			gw := NewStripeGateway("sk_test", "whsec", "app://return")
+			gw.baseURL = server.URL
+
+			resp, err := gw.ProcessTopUp(context.Background(), ports.TopUpRequest{
+				Amount:         decimal.RequireFromString("50.00"),
+				Currency:       "MYR",
+				Token:          "pm_card",
+				IdempotencyKey: "idem-1",
+				Reference:      "tx-1",
+			})
+			if err != nil {
+				t.Fatalf("ProcessTopUp() error = %v", err)
+			}
+
+			if form.Get("amount") != "5000" || form.Get("currency") != "myr" {
+				t.Errorf("amount = %s %s, want 5000 myr", form.Get("amount"), form.Get("currency"))
+			}
+			if form.Get("metadata[transaction_id]") != "tx-1" {
+				t.Errorf("transaction_id metadata = %q, want tx-1", form.Get("metadata[transaction_id]"))
+			}
+			if idempotencyKey != "idem-1" {
+				t.Errorf("Idempotency-Key = %q, want idem-1", idempotencyKey)
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", resp.Status, tt.wantStatus)
+			}
+			if tt.wantRedirect != "" && (resp.Redirect == nil || resp.Redirect.URL != tt.wantRedirect) {
+				t.Errorf("redirect = %+v, want %s", resp.Redirect, tt.wantRedirect)
+			}
+			if tt.wantCaptured != "" && !resp.CapturedAmount.Equal(decimal.RequireFromString(tt.wantCaptured)) {
+				t.Errorf("captured = %s, want %s", resp.CapturedAmount, tt.wantCaptured)
+			}
+		})
+	}
+}
+
+func TestStripeGateway_ProcessPayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		body       string
+		wantStatus string
+	}{
+		{
+			name:       "charged",
+			status:     http.StatusOK,
+			body:       `{"id":"pi_1","status":"succeeded","amount_received":750}`,
+			wantStatus: ports.GatewayStatusSuccess,
+		},
+		{
+			name:       "needs 3-D Secure",
+			status:     http.StatusPaymentRequired,
+			body:       `{"error":{"type":"card_error","code":"authentication_required","message":"Your card requires authentication."}}`,
+			wantStatus: ports.GatewayStatusFailed,
+		},
+		{
+			name:       "left processing",
+			status:     http.StatusOK,
+			body:       `{"id":"pi_1","status":"processing"}`,
+			wantStatus: ports.GatewayStatusFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var form url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				r.ParseForm()
+				form = r.PostForm
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			gw := NewStripeGateway("sk_test", "whsec", "app://return")
+			gw.baseURL = server.URL
+
+			resp, err := gw.ProcessPayment(context.Background(), ports.PaymentRequest{
+				Amount:         decimal.RequireFromString("7.50"),
+				Currency:       "MYR",
+				Token:          "pm_card",
+				ReferenceID:    "cp-1",
+				IdempotencyKey: "idem-1",
+			})
+			if err != nil {
+				t.Fatalf("ProcessPayment() error = %v", err)
+			}
+
+			if form.Get("amount") != "750" || form.Get("off_session") != "true" || form.Get("payment_method") != "pm_card" {
+				t.Errorf("form = %v, want an off-session charge of 750 on pm_card", form)
+			}
+			// Without a transaction_id the intent's webhooks aren't taken for top-ups
+			if form.Get("metadata[transaction_id]") != "" {
+				t.Errorf("transaction_id metadata = %q, want none", form.Get("metadata[transaction_id]"))
+			}
+			if resp.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", resp.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+type recordingCardGateway struct {
+	calls int
+}
+
+func (g *recordingCardGateway) ProcessPayment(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error) {
+	g.calls++
+	return &ports.PaymentResponse{TransactionID: "pi_1", Status: ports.GatewayStatusSuccess}, nil
+}
+
+func TestGatewayRouter_ProcessPayment(t *testing.T) {
+	cards := &recordingCardGateway{}
+	router := NewGatewayRouter(NewMockPaymentGateway())
+	router.RegisterCards(cards)
+
+	resp, err := router.ProcessPayment(context.Background(), ports.PaymentRequest{Amount: decimal.NewFromInt(5), Token: "pm_card"})
+	if err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	if cards.calls != 1 || resp.TransactionID != "pi_1" {
+		t.Errorf("card payment went to the fallback, want the card gateway")
+	}
+
+	if _, err := router.ProcessPayment(context.Background(), ports.PaymentRequest{Amount: decimal.NewFromInt(5)}); err != nil {
+		t.Fatalf("ProcessPayment() error = %v", err)
+	}
+	if cards.calls != 1 {
+		t.Errorf("payment without a card token went to the card gateway")
+	}
+}
+
+func TestStripeGateway_VerifyWebhook(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	gw := NewStripeGateway("sk_test", "whsec", "app://return")
+	gw.now = func() time.Time { return now }
+
+	payload := []byte(`{"type":"payment_intent.succeeded","data":{"object":{"id":"pi_1","status":"succeeded","amount_received":2550,"metadata":{"transaction_id":"tx-1"}}}}`)
+	sign := func(secret string, at time.Time) http.Header {
+		mac := hmac.New(sha256.New, []byte(secret))
+		fmt.Fprintf(mac, "%d.%s", at.Unix(), payload)
+		header := http.Header{}
+		header.Set("Stripe-Signature", fmt.Sprintf("t=%d,v1=%s", at.Unix(), hex.EncodeToString(mac.Sum(nil))))
+		return header
+	}
+
+	event, err := gw.VerifyWebhook(payload, sign("whsec", now))
+	if err != nil {
+		t.Fatalf("VerifyWebhook() error = %v", err)
+	}
+	if event.Reference != "tx-1" || event.Status != ports.GatewayStatusSuccess {
+		t.Errorf("event = %+v, want successful tx-1", event)
+	}
+	if !event.Amount.Equal(decimal.RequireFromString("25.50")) {
+		t.Errorf("amount = %s, want 25.50", event.Amount)
+	}
+
+	rejected := []struct {
+		name   string
+		header http.Header
+	}{
+		{"wrong secret", sign("other", now)},
+		{"replayed", sign("whsec", now.Add(-time.Hour))},
+		{"unsigned", http.Header{}},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gw.VerifyWebhook(payload, tt.header); !errors.Is(err, domain.ErrInvalidWebhook) {
+				t.Errorf("VerifyWebhook() error = %v, want %v", err, domain.ErrInvalidWebhook)
+			}
+		})
+	}
+}
+
+func TestFPXGateway_ProcessTopUp(t *testing.T) {
+	merchantKey, fpxKey := generateKey(t), generateKey(t)
+	gw := NewFPXGateway(FPXConfig{Endpoint: "https://fpx.test/pay", ExchangeID: "EX001", SellerID: "SE001", SellerBankCode: "01"}, merchantKey, &fpxKey.PublicKey)
+
+	resp, err := gw.ProcessTopUp(context.Background(), ports.TopUpRequest{
+		Amount:    decimal.RequireFromString("30"),
+		Token:     "TEST0021",
+		Reference: "tx-1",
+	})
+	if err != nil {
+		t.Fatalf("ProcessTopUp() error = %v", err)
+	}
+	if resp.Status != ports.GatewayStatusPending || resp.Redirect == nil {
+		t.Fatalf("response = %+v, want pending with redirect", resp)
+	}
+
+	fields := resp.Redirect.Fields
+	if resp.Redirect.Method != http.MethodPost || resp.Redirect.URL != "https://fpx.test/pay" {
+		t.Errorf("redirect = %s %s, want POST https://fpx.test/pay", resp.Redirect.Method, resp.Redirect.URL)
+	}
+	if fields["fpx_txnAmount"] != "30.00" || fields["fpx_buyerBankId"] != "TEST0021" || fields["fpx_sellerOrderNo"] != "tx-1" {
+		t.Errorf("fields = %v", fields)
+	}
+
+	// The merchant's signature must verify against its public key, as FPX checks it
+	verifier := NewFPXGateway(FPXConfig{}, nil, &merchantKey.PublicKey)
+	if err := verifier.verify(checksumSource(fields, fpxRequestFields), fields["fpx_checkSum"]); err != nil {
+		t.Errorf("checksum does not verify: %v", err)
+	}
+
+	declined, err := gw.ProcessTopUp(context.Background(), ports.TopUpRequest{Amount: decimal.NewFromInt(30), Reference: "tx-2"})
+	if err != nil || declined.Status != ports.GatewayStatusFailed {
+		t.Errorf("top-up without a bank = %+v, %v; want failed", declined, err)
+	}
+}
+
+func TestFPXGateway_VerifyWebhook(t *testing.T) {
+	merchantKey, fpxKey := generateKey(t), generateKey(t)
+	gw := NewFPXGateway(FPXConfig{SellerID: "SE001"}, merchantKey, &fpxKey.PublicKey)
+	// Signs AC messages the way FPX does, with its own key
+	fpx := NewFPXGateway(FPXConfig{}, fpxKey, nil)
+
+	message := func(debitAuthCode string) url.Values {
+		fields := map[string]string{}
+		for _, name := range fpxResponseFields {
+			fields[name] = ""
+		}
+		fields["fpx_msgType"] = "AC"
+		fields["fpx_sellerId"] = "SE001"
+		fields["fpx_sellerOrderNo"] = "tx-1"
+		fields["fpx_fpxTxnId"] = "2310011200000001"
+		fields["fpx_txnAmount"] = "30.00"
+		fields["fpx_debitAuthCode"] = debitAuthCode
+
+		checksum, err := fpx.sign(checksumSource(fields, fpxResponseFields))
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		values := url.Values{}
+		for k, v := range fields {
+			values.Set(k, v)
+		}
+		values.Set("fpx_checkSum", checksum)
+		return values
+	}
+
+	tests := []struct {
+		name       string
+		values     url.Values
+		wantStatus string
+		wantNil    bool
+		wantErr    error
+	}{
+		{"approved", message("00"), ports.GatewayStatusSuccess, false, nil},
+		{"rejected by bank", message("51"), ports.GatewayStatusFailed, false, nil},
+		{"still pending", message("09"), "", true, nil},
+		{"tampered amount", func() url.Values {
+			v := message("00")
+			v.Set("fpx_txnAmount", "3000.00")
+			return v
+		}(), "", false, domain.ErrInvalidWebhook},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := gw.VerifyWebhook([]byte(tt.values.Encode()), http.Header{})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifyWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if tt.wantNil {
+				if event != nil {
+					t.Errorf("event = %+v, want none", event)
+				}
+				return
+			}
+			if event.Status != tt.wantStatus || event.Reference != "tx-1" || event.GatewayTransactionID != "2310011200000001" {
+				t.Errorf("event = %+v, want %s for tx-1", event, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func generateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	return key
+}
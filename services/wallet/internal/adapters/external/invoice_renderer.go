@@ -0,0 +1,66 @@
+package external
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+var invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+	"money":   func(d decimal.Decimal) string { return d.StringFixed(2) },
+	"percent": func(d decimal.Decimal) string { return d.Mul(decimal.NewFromInt(100)).String() + "%" },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Invoice {{.Number}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+th, td { border-bottom: 1px solid #ddd; padding: 6px; text-align: left; }
+td.amount, th.amount { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Tax Invoice {{.Number}}</h1>
+<p>Issued {{.IssuedAt.Format "2 January 2006"}} for {{.PeriodStart.Format "January 2006"}}</p>
+<h2>Bill to</h2>
+<p>{{.CompanyName}}{{if .RegistrationNumber}}<br>Registration no. {{.RegistrationNumber}}{{end}}{{if .TaxID}}<br>Tax ID {{.TaxID}}{{end}}{{if .BillingAddress}}<br>{{.BillingAddress}}{{end}}</p>
+<table>
+<thead><tr><th>Date</th><th>Description</th><th>Reference</th><th class="amount">Tax</th><th class="amount">Amount ({{.Currency}})</th></tr></thead>
+<tbody>
+{{- range .Lines}}
+<tr><td>{{.Date.Format "2006-01-02"}}</td><td>{{.Description}}</td><td>{{.ReferenceID}}</td><td class="amount">{{money .Tax}}</td><td class="amount">{{money .Amount}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+<table>
+<tr><td>Subtotal</td><td class="amount">{{money .Subtotal}}</td></tr>
+{{- range .TaxLines}}
+<tr><td>{{.Name}} {{percent .Rate}} on {{money .Taxable}}</td><td class="amount">{{money .Amount}}</td></tr>
+{{- end}}
+<tr><th>Total</th><th class="amount">{{.Currency}} {{money .Total}}</th></tr>
+</table>
+</body>
+</html>
+`))
+
+// HTMLInvoiceRenderer renders invoices as standalone HTML pages that print
+// cleanly to PDF from any browser
+type HTMLInvoiceRenderer struct{}
+
+func NewHTMLInvoiceRenderer() *HTMLInvoiceRenderer {
+	return &HTMLInvoiceRenderer{}
+}
+
+func (r *HTMLInvoiceRenderer) Render(ctx context.Context, invoice *domain.Invoice) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if err := invoiceTemplate.Execute(&buf, invoice); err != nil {
+		return nil, "", fmt.Errorf("failed to render invoice: %w", err)
+	}
+	return buf.Bytes(), "text/html; charset=utf-8", nil
+}
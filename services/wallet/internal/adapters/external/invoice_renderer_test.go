@@ -0,0 +1,44 @@
+package external
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestHTMLInvoiceRenderer_Render(t *testing.T) {
+	account, err := domain.NewBusinessAccount(uuid.New(), "Acme <Fleet> Sdn Bhd", "202301012345", "", "")
+	if err != nil {
+		t.Fatalf("NewBusinessAccount() error = %v", err)
+	}
+	wallet := domain.NewWallet(account.UserID, "MYR")
+	payment := domain.NewTransaction(wallet.ID, domain.TransactionTypePayment, decimal.RequireFromString("10.80"), decimal.Zero, "session-1", "key-1", "Parking at KLCC")
+	payment.Status = domain.TransactionStatusCompleted
+
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	invoice, err := domain.NewInvoice(account, wallet, start, start.AddDate(0, 1, 0), []*domain.Transaction{payment},
+		domain.InvoiceTax{Name: "SST", Rate: decimal.RequireFromString("0.08")})
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+
+	doc, contentType, err := NewHTMLInvoiceRenderer().Render(context.Background(), invoice)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.HasPrefix(contentType, "text/html") {
+		t.Errorf("content type = %q, want HTML", contentType)
+	}
+
+	html := string(doc)
+	for _, want := range []string{invoice.Number, "September 2026", "Parking at KLCC", "SST 8%", "MYR 10.80", "Acme &lt;Fleet&gt; Sdn Bhd"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("rendered invoice is missing %q", want)
+		}
+	}
+}
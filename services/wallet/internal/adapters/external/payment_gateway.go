@@ -21,9 +21,10 @@ func (g *MockPaymentGateway) ProcessTopUp(ctx context.Context, req ports.TopUpRe
 	time.Sleep(100 * time.Millisecond)
 
 	return &ports.TopUpResponse{
-		TransactionID: uuid.New().String(),
-		Status:        "success",
-		Message:       "Top-up processed successfully",
+		TransactionID:  uuid.New().String(),
+		Status:         "success",
+		Message:        "Top-up processed successfully",
+		CapturedAmount: req.Amount,
 	}, nil
 }
 
@@ -0,0 +1,270 @@
+package external
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// stripeSignatureTolerance is how old a webhook timestamp may be before the
+// notification is treated as a replay
+const stripeSignatureTolerance = 5 * time.Minute
+
+// StripeGateway charges saved cards through Stripe PaymentIntents. Top-up
+// cards that need 3-D Secure come back pending with a redirect to the issuer;
+// the final outcome arrives by webhook.
+type StripeGateway struct {
+	secretKey     string
+	webhookSecret string
+	returnURL     string
+	baseURL       string
+	client        *http.Client
+	now           func() time.Time
+}
+
+func NewStripeGateway(secretKey, webhookSecret, returnURL string) *StripeGateway {
+	return &StripeGateway{
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		returnURL:     returnURL,
+		baseURL:       "https://api.stripe.com",
+		client:        &http.Client{Timeout: 30 * time.Second},
+		now:           time.Now,
+	}
+}
+
+type stripePaymentIntent struct {
+	ID             string            `json:"id"`
+	Status         string            `json:"status"`
+	AmountReceived int64             `json:"amount_received"`
+	Metadata       map[string]string `json:"metadata"`
+	NextAction     *struct {
+		Type          string `json:"type"`
+		RedirectToURL *struct {
+			URL string `json:"url"`
+		} `json:"redirect_to_url"`
+	} `json:"next_action"`
+	LastPaymentError *stripeError `json:"last_payment_error"`
+}
+
+type stripeError struct {
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (g *StripeGateway) ProcessTopUp(ctx context.Context, req ports.TopUpRequest) (*ports.TopUpResponse, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount.Shift(2).IntPart(), 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	form.Set("payment_method", req.Token)
+	form.Set("payment_method_types[]", "card")
+	form.Set("confirm", "true")
+	form.Set("return_url", g.returnURL)
+	form.Set("metadata[transaction_id]", req.Reference)
+	form.Set("metadata[user_id]", req.UserID)
+
+	intent, cardErr, err := g.createIntent(ctx, form, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cardErr != nil {
+		return &ports.TopUpResponse{Status: ports.GatewayStatusFailed, Message: cardErr.Message}, nil
+	}
+	return intentResponse(intent), nil
+}
+
+// ProcessPayment charges a guest's card off-session. The guest isn't there
+// to complete 3-D Secure, so a card that asks for it is a decline. The
+// intent carries no transaction_id, so its webhooks are ignored.
+func (g *StripeGateway) ProcessPayment(ctx context.Context, req ports.PaymentRequest) (*ports.PaymentResponse, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(req.Amount.Shift(2).IntPart(), 10))
+	form.Set("currency", strings.ToLower(req.Currency))
+	form.Set("payment_method", req.Token)
+	form.Set("payment_method_types[]", "card")
+	form.Set("confirm", "true")
+	form.Set("off_session", "true")
+	form.Set("description", req.Description)
+	form.Set("metadata[card_payment_id]", req.ReferenceID)
+
+	intent, cardErr, err := g.createIntent(ctx, form, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cardErr != nil {
+		return &ports.PaymentResponse{Status: ports.GatewayStatusFailed, Message: cardErr.Message}, nil
+	}
+
+	resp := &ports.PaymentResponse{TransactionID: intent.ID}
+	switch {
+	case intent.Status == "succeeded":
+		resp.Status = ports.GatewayStatusSuccess
+	case intent.LastPaymentError != nil:
+		resp.Status = ports.GatewayStatusFailed
+		resp.Message = intent.LastPaymentError.Message
+	default:
+		resp.Status = ports.GatewayStatusFailed
+		resp.Message = "Card payment was not completed"
+	}
+	return resp, nil
+}
+
+// createIntent creates and confirms a PaymentIntent. A declined card is an
+// answer, not an outage, so it comes back as a stripeError rather than err.
+func (g *StripeGateway) createIntent(ctx context.Context, form url.Values, idempotencyKey string) (*stripePaymentIntent, *stripeError, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/v1/payment_intents", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+g.secretKey)
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := g.client.Do(httpReq)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to call Stripe: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read Stripe response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error stripeError `json:"error"`
+		}
+		json.Unmarshal(body, &apiErr)
+		if apiErr.Error.Type == "card_error" {
+			return nil, &apiErr.Error, nil
+		}
+		return nil, nil, fmt.Errorf("stripe returned %d: %s", resp.StatusCode, apiErr.Error.Message)
+	}
+
+	var intent stripePaymentIntent
+	if err := json.Unmarshal(body, &intent); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode Stripe response: %w", err)
+	}
+	return &intent, nil, nil
+}
+
+func intentResponse(intent *stripePaymentIntent) *ports.TopUpResponse {
+	resp := &ports.TopUpResponse{TransactionID: intent.ID}
+	switch intent.Status {
+	case "succeeded":
+		resp.Status = ports.GatewayStatusSuccess
+		resp.CapturedAmount = decimal.New(intent.AmountReceived, -2)
+	case "requires_action":
+		resp.Status = ports.GatewayStatusPending
+		resp.Message = "Card requires authentication"
+		if intent.NextAction != nil && intent.NextAction.RedirectToURL != nil {
+			resp.Redirect = &ports.Redirect{URL: intent.NextAction.RedirectToURL.URL, Method: http.MethodGet}
+		}
+	case "processing":
+		resp.Status = ports.GatewayStatusPending
+		resp.Message = "Payment is processing"
+	default:
+		resp.Status = ports.GatewayStatusFailed
+		resp.Message = "Card payment was not completed"
+		if intent.LastPaymentError != nil {
+			resp.Message = intent.LastPaymentError.Message
+		}
+	}
+	return resp
+}
+
+// VerifyWebhook checks the Stripe-Signature header and decodes payment intent
+// events for our top-ups
+func (g *StripeGateway) VerifyWebhook(payload []byte, header http.Header) (*ports.GatewayEvent, error) {
+	if err := g.verifySignature(payload, header.Get("Stripe-Signature")); err != nil {
+		return nil, err
+	}
+
+	var event struct {
+		Type string `json:"type"`
+		Data struct {
+			Object stripePaymentIntent `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidWebhook, err)
+	}
+
+	intent := event.Data.Object
+	reference := intent.Metadata["transaction_id"]
+	if reference == "" {
+		return nil, nil
+	}
+
+	gatewayEvent := &ports.GatewayEvent{
+		Gateway:              "stripe",
+		Reference:            reference,
+		GatewayTransactionID: intent.ID,
+	}
+	switch event.Type {
+	case "payment_intent.succeeded":
+		gatewayEvent.Status = ports.GatewayStatusSuccess
+		gatewayEvent.Amount = decimal.New(intent.AmountReceived, -2)
+	case "payment_intent.payment_failed", "payment_intent.canceled":
+		gatewayEvent.Status = ports.GatewayStatusFailed
+		if intent.LastPaymentError != nil {
+			gatewayEvent.Message = intent.LastPaymentError.Message
+		}
+	default:
+		return nil, nil
+	}
+	return gatewayEvent, nil
+}
+
+func (g *StripeGateway) verifySignature(payload []byte, header string) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == "" || len(signatures) == 0 {
+		return fmt.Errorf("%w: missing Stripe signature", domain.ErrInvalidWebhook)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: bad Stripe signature timestamp", domain.ErrInvalidWebhook)
+	}
+	if age := g.now().Sub(time.Unix(unix, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return fmt.Errorf("%w: Stripe signature timestamp outside tolerance", domain.ErrInvalidWebhook)
+	}
+
+	mac := hmac.New(sha256.New, []byte(g.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: Stripe signature mismatch", domain.ErrInvalidWebhook)
+}
@@ -0,0 +1,92 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/grpc/client"
+	authv1 "github.com/parking-super-app/pkg/proto/auth/v1"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AuthGRPCClient implements ports.StepUpService and ports.PINVerifier
+// against the auth service
+type AuthGRPCClient struct {
+	conn   *grpc.ClientConn
+	client authv1.AuthServiceClient
+}
+
+// NewAuthGRPCClient creates a new gRPC client for the auth service.
+// Retries only happen on UNAVAILABLE, before auth has acted on the call.
+func NewAuthGRPCClient(cfg client.Config) (*AuthGRPCClient, error) {
+	conn, err := client.New(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	return &AuthGRPCClient{conn: conn, client: authv1.NewAuthServiceClient(conn)}, nil
+}
+
+// Challenge asks auth to send the user a one-time code for the transaction
+func (c *AuthGRPCClient) Challenge(ctx context.Context, req ports.StepUpRequest) (*ports.StepUpChallenge, error) {
+	resp, err := c.client.CreateStepUpChallenge(ctx, &authv1.CreateStepUpChallengeRequest{
+		UserId: req.UserID.String(),
+		Action: domain.StepUpAction(req.Type),
+		Amount: domain.StepUpAmount(req.Amount),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create step-up challenge: %w", err)
+	}
+	return &ports.StepUpChallenge{
+		ChallengeID: resp.ChallengeId,
+		Method:      resp.Method,
+		ExpiresAt:   time.Unix(resp.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// Redeem has auth check and use up the user's step-up token
+func (c *AuthGRPCClient) Redeem(ctx context.Context, token string, req ports.StepUpRequest) error {
+	_, err := c.client.RedeemStepUpToken(ctx, &authv1.RedeemStepUpTokenRequest{
+		Token:  token,
+		UserId: req.UserID.String(),
+		Action: domain.StepUpAction(req.Type),
+		Amount: domain.StepUpAmount(req.Amount),
+	})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return domain.ErrStepUpInvalid
+		}
+		return fmt.Errorf("failed to redeem step-up token: %w", err)
+	}
+	return nil
+}
+
+// VerifyPIN has auth check the user's transaction PIN
+func (c *AuthGRPCClient) VerifyPIN(ctx context.Context, userID uuid.UUID, pin string) error {
+	_, err := c.client.VerifyPIN(ctx, &authv1.VerifyPINRequest{
+		UserId: userID.String(),
+		Pin:    pin,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.PermissionDenied, codes.InvalidArgument:
+			return domain.ErrPINIncorrect
+		case codes.ResourceExhausted:
+			return domain.ErrPINLocked
+		case codes.FailedPrecondition:
+			return domain.ErrPINNotSet
+		}
+		return fmt.Errorf("failed to verify PIN: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (c *AuthGRPCClient) Close() error {
+	return c.conn.Close()
+}
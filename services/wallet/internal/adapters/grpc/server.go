@@ -2,8 +2,11 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
 	"github.com/parking-super-app/services/wallet/internal/application"
 	"github.com/parking-super-app/services/wallet/internal/domain"
 	"github.com/shopspring/decimal"
@@ -11,62 +14,27 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// WalletServiceServer implements the gRPC WalletService
-// This is a manual implementation until proto files are generated
+// WalletServiceServer implements walletv1.WalletServiceServer. TopUp and
+// GetTransactions are only offered over REST and answer Unimplemented.
 type WalletServiceServer struct {
+	walletv1.UnimplementedWalletServiceServer
 	walletService *application.WalletService
+	holdService   *application.HoldService
+	cardPayments  *application.CardPaymentService
 }
 
 // NewWalletServiceServer creates a new gRPC server for the wallet service
-func NewWalletServiceServer(ws *application.WalletService) *WalletServiceServer {
+func NewWalletServiceServer(ws *application.WalletService, hs *application.HoldService, cps *application.CardPaymentService) *WalletServiceServer {
 	return &WalletServiceServer{
 		walletService: ws,
+		holdService:   hs,
+		cardPayments:  cps,
 	}
 }
 
-// PayRequest represents a payment request
-type PayRequest struct {
-	WalletID       string
-	Amount         string
-	Currency       string
-	ProviderID     string
-	ReferenceID    string
-	Description    string
-	IdempotencyKey string
-}
-
-// PayResponse represents a payment response
-type PayResponse struct {
-	TransactionID string
-	Status        string
-	BalanceAfter  string
-	ErrorMessage  string
-}
-
-// GetWalletRequest represents a get wallet request
-type GetWalletRequest struct {
-	UserID string
-}
-
-// GetWalletByIDRequest represents a get wallet by ID request
-type GetWalletByIDRequest struct {
-	WalletID string
-}
-
-// GetWalletResponse represents a wallet response
-type GetWalletResponse struct {
-	ID        string
-	UserID    string
-	Balance   string
-	Currency  string
-	Status    string
-	CreatedAt string
-	UpdatedAt string
-}
-
 // Pay processes a payment from a wallet
-func (s *WalletServiceServer) Pay(ctx context.Context, req *PayRequest) (*PayResponse, error) {
-	walletID, err := uuid.Parse(req.WalletID)
+func (s *WalletServiceServer) Pay(ctx context.Context, req *walletv1.PayRequest) (*walletv1.PayResponse, error) {
+	walletID, err := uuid.Parse(req.WalletId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid wallet_id")
 	}
@@ -77,20 +45,39 @@ func (s *WalletServiceServer) Pay(ctx context.Context, req *PayRequest) (*PayRes
 	}
 
 	providerID := uuid.Nil
-	if req.ProviderID != "" {
-		providerID, _ = uuid.Parse(req.ProviderID)
+	if req.ProviderId != "" {
+		providerID, _ = uuid.Parse(req.ProviderId)
+	}
+
+	var memberID *uuid.UUID
+	if req.MemberId != "" {
+		id, err := uuid.Parse(req.MemberId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid member_id")
+		}
+		memberID = &id
+	}
+	taxes, err := taxLines(req.Taxes)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := s.walletService.Pay(ctx, application.PaymentRequest{
 		WalletID:       walletID,
 		Amount:         amount,
 		ProviderID:     providerID,
-		ReferenceID:    req.ReferenceID,
+		ReferenceID:    req.ReferenceId,
 		Description:    req.Description,
 		IdempotencyKey: req.IdempotencyKey,
+		MemberID:       memberID,
+		Taxes:          taxes,
+		Category:       domain.TransactionCategory(req.Category),
 	})
 
 	if err != nil {
+		if errors.Is(err, domain.ErrConcurrentModification) {
+			return nil, status.Error(codes.Aborted, "wallet was updated concurrently")
+		}
 		switch err {
 		case domain.ErrWalletNotFound:
 			return nil, status.Error(codes.NotFound, "wallet not found")
@@ -100,26 +87,40 @@ func (s *WalletServiceServer) Pay(ctx context.Context, req *PayRequest) (*PayRes
 			return nil, status.Error(codes.FailedPrecondition, "wallet is inactive")
 		case domain.ErrInvalidAmount:
 			return nil, status.Error(codes.InvalidArgument, "invalid amount")
+		case domain.ErrInvalidCategory:
+			return nil, status.Error(codes.InvalidArgument, "invalid category")
+		case domain.ErrPerTransactionLimitExceeded, domain.ErrDailyLimitExceeded, domain.ErrMonthlyLimitExceeded,
+			domain.ErrKYCPaymentLimitExceeded, domain.ErrRiskChallengeRequired, domain.ErrMemberLimitExceeded:
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case domain.ErrRiskBlocked, domain.ErrNotOrgMember:
+			return nil, status.Error(codes.PermissionDenied, err.Error())
 		default:
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 	}
 
-	return &PayResponse{
-		TransactionID: resp.ID.String(),
+	return &walletv1.PayResponse{
+		TransactionId: resp.ID.String(),
 		Status:        resp.Status,
 		BalanceAfter:  resp.BalanceAfter.String(),
 	}, nil
 }
 
-// GetWallet retrieves wallet information by user ID
-func (s *WalletServiceServer) GetWallet(ctx context.Context, req *GetWalletRequest) (*GetWalletResponse, error) {
-	userID, err := uuid.Parse(req.UserID)
+// GetWallet retrieves a user's default wallet, or the one named by wallet_id
+func (s *WalletServiceServer) GetWallet(ctx context.Context, req *walletv1.GetWalletRequest) (*walletv1.GetWalletResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
 	}
+	walletID := uuid.Nil
+	if req.WalletId != "" {
+		walletID, err = uuid.Parse(req.WalletId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid wallet_id")
+		}
+	}
 
-	wallet, err := s.walletService.GetWallet(ctx, userID)
+	wallet, err := s.walletService.GetUserWallet(ctx, userID, walletID)
 	if err != nil {
 		if err == domain.ErrWalletNotFound {
 			return nil, status.Error(codes.NotFound, "wallet not found")
@@ -127,18 +128,31 @@ func (s *WalletServiceServer) GetWallet(ctx context.Context, req *GetWalletReque
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &GetWalletResponse{
-		ID:       wallet.ID.String(),
-		UserID:   wallet.UserID.String(),
-		Balance:  wallet.Balance.String(),
-		Currency: wallet.Currency,
-		Status:   wallet.Status,
-	}, nil
+	return toWalletProto(wallet), nil
+}
+
+// ListWallets returns all of a user's wallets, default first
+func (s *WalletServiceServer) ListWallets(ctx context.Context, req *walletv1.ListWalletsRequest) (*walletv1.ListWalletsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user_id")
+	}
+
+	wallets, err := s.walletService.ListWallets(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &walletv1.ListWalletsResponse{Wallets: make([]*walletv1.GetWalletResponse, len(wallets))}
+	for i, wallet := range wallets {
+		resp.Wallets[i] = toWalletProto(wallet)
+	}
+	return resp, nil
 }
 
 // GetWalletByID retrieves wallet information by wallet ID
-func (s *WalletServiceServer) GetWalletByID(ctx context.Context, req *GetWalletByIDRequest) (*GetWalletResponse, error) {
-	walletID, err := uuid.Parse(req.WalletID)
+func (s *WalletServiceServer) GetWalletByID(ctx context.Context, req *walletv1.GetWalletByIDRequest) (*walletv1.GetWalletResponse, error) {
+	walletID, err := uuid.Parse(req.WalletId)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, "invalid wallet_id")
 	}
@@ -151,11 +165,267 @@ func (s *WalletServiceServer) GetWalletByID(ctx context.Context, req *GetWalletB
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	return &GetWalletResponse{
-		ID:       wallet.ID.String(),
-		UserID:   wallet.UserID.String(),
-		Balance:  wallet.Balance.String(),
-		Currency: wallet.Currency,
-		Status:   wallet.Status,
+	return toWalletProto(wallet), nil
+}
+
+func toWalletProto(wallet *application.WalletResponse) *walletv1.GetWalletResponse {
+	return &walletv1.GetWalletResponse{
+		Id:        wallet.ID.String(),
+		UserId:    wallet.UserID.String(),
+		Balance:   wallet.Balance.String(),
+		Currency:  wallet.Currency,
+		Status:    wallet.Status,
+		Label:     wallet.Label,
+		IsDefault: wallet.IsDefault,
+	}
+}
+
+// ListPayments returns payment transactions created in a time window
+func (s *WalletServiceServer) ListPayments(ctx context.Context, req *walletv1.ListPaymentsRequest) (*walletv1.ListPaymentsResponse, error) {
+	from, err := time.Parse(time.RFC3339, req.From)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from")
+	}
+	to, err := time.Parse(time.RFC3339, req.To)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to")
+	}
+
+	records, err := s.walletService.ListPayments(ctx, from, to)
+	if err != nil {
+		if err == domain.ErrInvalidTimeRange {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &walletv1.ListPaymentsResponse{Payments: make([]*walletv1.PaymentRecord, len(records))}
+	for i, r := range records {
+		resp.Payments[i] = &walletv1.PaymentRecord{
+			TransactionId:  r.TransactionID.String(),
+			ReferenceId:    r.ReferenceID,
+			IdempotencyKey: r.IdempotencyKey,
+			Amount:         r.Amount.String(),
+			Status:         r.Status,
+			CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return resp, nil
+}
+
+// HoldFunds sets part of a wallet's balance aside
+func (s *WalletServiceServer) HoldFunds(ctx context.Context, req *walletv1.HoldFundsRequest) (*walletv1.HoldResponse, error) {
+	walletID, err := uuid.Parse(req.WalletId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid wallet_id")
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+	if req.IdempotencyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	providerID := uuid.Nil
+	if req.ProviderId != "" {
+		providerID, _ = uuid.Parse(req.ProviderId)
+	}
+
+	resp, err := s.holdService.PlaceHold(ctx, application.PlaceHoldRequest{
+		WalletID:       walletID,
+		Amount:         amount,
+		ProviderID:     providerID,
+		ReferenceID:    req.ReferenceId,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return toHoldResponse(resp), nil
+}
+
+// CaptureHold settles a hold with a payment
+func (s *WalletServiceServer) CaptureHold(ctx context.Context, req *walletv1.CaptureHoldRequest) (*walletv1.CaptureHoldResponse, error) {
+	holdID, err := uuid.Parse(req.HoldId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid hold_id")
+	}
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+	if req.IdempotencyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+	taxes, err := taxLines(req.Taxes)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, payment, err := s.holdService.CaptureHold(ctx, application.CaptureHoldRequest{
+		HoldID:         holdID,
+		Amount:         amount,
+		ReferenceID:    req.ReferenceId,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+		Taxes:          taxes,
+	})
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return &walletv1.CaptureHoldResponse{
+		HoldId:         hold.Hold.ID.String(),
+		TransactionId:  payment.ID.String(),
+		CapturedAmount: payment.Amount.String(),
+		BalanceAfter:   hold.Balance.String(),
 	}, nil
 }
+
+// ReleaseHold returns all held funds to the wallet
+func (s *WalletServiceServer) ReleaseHold(ctx context.Context, req *walletv1.ReleaseHoldRequest) (*walletv1.HoldResponse, error) {
+	holdID, err := uuid.Parse(req.HoldId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid hold_id")
+	}
+
+	resp, err := s.holdService.ReleaseHold(ctx, holdID)
+	if err != nil {
+		return nil, holdError(err)
+	}
+	return toHoldResponse(resp), nil
+}
+
+// Refund returns a completed payment to its wallet in full
+func (s *WalletServiceServer) Refund(ctx context.Context, req *walletv1.RefundRequest) (*walletv1.RefundResponse, error) {
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction_id")
+	}
+	if req.IdempotencyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	resp, err := s.walletService.Refund(ctx, application.RefundRequest{
+		TransactionID:  transactionID,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrConcurrentModification):
+			return nil, status.Error(codes.Aborted, "wallet was updated concurrently")
+		case errors.Is(err, domain.ErrTransactionNotFound):
+			return nil, status.Error(codes.NotFound, "transaction not found")
+		case errors.Is(err, domain.ErrWalletNotFound):
+			return nil, status.Error(codes.NotFound, "wallet not found")
+		case errors.Is(err, domain.ErrNotRefundable):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &walletv1.RefundResponse{
+		TransactionId: resp.ID.String(),
+		Status:        resp.Status,
+		Amount:        resp.Amount.String(),
+		BalanceAfter:  resp.BalanceAfter.String(),
+	}, nil
+}
+
+// PayByCard charges a guest's card directly
+func (s *WalletServiceServer) PayByCard(ctx context.Context, req *walletv1.PayByCardRequest) (*walletv1.PayByCardResponse, error) {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid amount")
+	}
+	providerID := uuid.Nil
+	if req.ProviderId != "" {
+		providerID, _ = uuid.Parse(req.ProviderId)
+	}
+	taxes, err := taxLines(req.Taxes)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := s.cardPayments.PayByCard(ctx, application.CardPaymentRequest{
+		Amount:         amount,
+		Currency:       req.Currency,
+		CardToken:      req.CardToken,
+		ProviderID:     providerID,
+		ReferenceID:    req.ReferenceId,
+		Description:    req.Description,
+		IdempotencyKey: req.IdempotencyKey,
+		Taxes:          taxes,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrCardDeclined):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		case errors.Is(err, domain.ErrInvalidAmount), errors.Is(err, domain.ErrCardTokenRequired),
+			errors.Is(err, domain.ErrCardIdempotencyKey):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		default:
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return &walletv1.PayByCardResponse{
+		PaymentId:        payment.ID.String(),
+		Status:           string(payment.Status),
+		GatewayReference: payment.GatewayReference,
+	}, nil
+}
+
+// taxLines reads the taxes a caller says are included in a payment
+func taxLines(lines []*walletv1.TaxLine) ([]domain.TaxLine, error) {
+	taxes := make([]domain.TaxLine, 0, len(lines))
+	for _, line := range lines {
+		rate, err := decimal.NewFromString(line.Rate)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid tax rate")
+		}
+		taxable, err := decimal.NewFromString(line.TaxableAmount)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid taxable amount")
+		}
+		amount, err := decimal.NewFromString(line.Amount)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid tax amount")
+		}
+		taxes = append(taxes, domain.TaxLine{Name: line.Name, Rate: rate, Taxable: taxable, Amount: amount})
+	}
+	return taxes, nil
+}
+
+func toHoldResponse(resp *application.HoldResponse) *walletv1.HoldResponse {
+	return &walletv1.HoldResponse{
+		HoldId:       resp.Hold.ID.String(),
+		Status:       string(resp.Hold.Status),
+		Amount:       resp.Hold.Amount.String(),
+		BalanceAfter: resp.Balance.String(),
+	}
+}
+
+func holdError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return status.Error(codes.Aborted, "wallet was updated concurrently")
+	case errors.Is(err, domain.ErrWalletNotFound):
+		return status.Error(codes.NotFound, "wallet not found")
+	case errors.Is(err, domain.ErrHoldNotFound):
+		return status.Error(codes.NotFound, "hold not found")
+	case errors.Is(err, domain.ErrInsufficientBalance):
+		return status.Error(codes.FailedPrecondition, "insufficient balance")
+	case errors.Is(err, domain.ErrWalletInactive):
+		return status.Error(codes.FailedPrecondition, "wallet is inactive")
+	case errors.Is(err, domain.ErrHoldSettled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return status.Error(codes.InvalidArgument, "invalid amount")
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
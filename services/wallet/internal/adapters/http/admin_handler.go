@@ -0,0 +1,102 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// AdminHandler serves back-office wallet actions
+type AdminHandler struct {
+	adminService *application.WalletAdminService
+}
+
+func NewAdminHandler(adminService *application.WalletAdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+func mapAdminError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrWalletFrozen):
+		return http.StatusConflict, "WALLET_FROZEN", "Wallet is already frozen"
+	case errors.Is(err, domain.ErrWalletNotFrozen):
+		return http.StatusConflict, "WALLET_NOT_FROZEN", "Wallet is not frozen"
+	case errors.Is(err, domain.ErrReasonRequired):
+		return http.StatusBadRequest, "REASON_REQUIRED", "A reason is required"
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound, "TRANSACTION_NOT_FOUND", "Transaction not found"
+	case errors.Is(err, domain.ErrNotReversible):
+		return http.StatusConflict, "NOT_REVERSIBLE", "This transaction cannot be reversed"
+	case errors.Is(err, domain.ErrAlreadyReversed):
+		return http.StatusConflict, "ALREADY_REVERSED", "Transaction has already been reversed"
+	case errors.Is(err, domain.ErrAdminRequired):
+		return http.StatusForbidden, "FORBIDDEN", "This action requires the admin role"
+	case errors.Is(err, domain.ErrSelfReversal):
+		return http.StatusForbidden, "SELF_REVERSAL", "You can't reverse transactions on your own wallet"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *AdminHandler) Freeze(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.adminService.FreezeWallet)
+}
+
+func (h *AdminHandler) Unfreeze(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.adminService.UnfreezeWallet)
+}
+
+func (h *AdminHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id uuid.UUID, req application.WalletStatusRequest) (*application.WalletResponse, error)) {
+	walletID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+		return
+	}
+
+	var req application.WalletStatusRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	wallet, err := change(r.Context(), walletID, req)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, wallet)
+}
+
+// Reverse undoes a completed transaction with a compensating transaction
+// recorded against the acting admin, whose role the router has checked
+func (h *AdminHandler) Reverse(w http.ResponseWriter, r *http.Request) {
+	transactionID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TRANSACTION_ID", "Invalid transaction ID format")
+		return
+	}
+
+	var req application.ReverseTransactionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.TransactionID = transactionID
+
+	reversal, err := h.adminService.ReverseTransaction(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapAdminError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, reversal)
+}
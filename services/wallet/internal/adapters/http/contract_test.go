@@ -0,0 +1,21 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/parking-super-app/pkg/contract"
+	walletv1 "github.com/parking-super-app/pkg/proto/wallet/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// TestContracts verifies the routes and gRPC methods that other services rely on
+func TestContracts(t *testing.T) {
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+	contract.Verify(t, contract.Provider{
+		Name:   "wallet",
+		Routes: router.router,
+		Services: []protoreflect.ServiceDescriptor{
+			walletv1.File_wallet_v1_wallet_proto.Services().ByName("WalletService"),
+		},
+	})
+}
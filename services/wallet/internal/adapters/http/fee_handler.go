@@ -0,0 +1,87 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// FeeHandler serves providers' fee schedules to back-office staff
+type FeeHandler struct {
+	feeService *application.FeeService
+}
+
+func NewFeeHandler(feeService *application.FeeService) *FeeHandler {
+	return &FeeHandler{feeService: feeService}
+}
+
+func mapFeeError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidFeeSchedule):
+		return http.StatusBadRequest, "INVALID_FEE", "Percentage must be from 0 to 100 and the fixed fee not negative"
+	case errors.Is(err, domain.ErrFeeEffectiveInPast):
+		return http.StatusBadRequest, "EFFECTIVE_IN_PAST", "Fee changes cannot take effect in the past"
+	case errors.Is(err, domain.ErrFeeScheduleExists):
+		return http.StatusConflict, "FEE_SCHEDULE_EXISTS", "A fee change already takes effect at that time"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// List returns the provider's current fee, past fees and scheduled changes
+func (h *FeeHandler) List(w http.ResponseWriter, r *http.Request) {
+	providerID, ok := feeProviderID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.feeService.ListSchedules(r.Context(), providerID)
+	if err != nil {
+		status, code, msg := mapFeeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// Schedule changes the provider's fee from effective_from, or now
+func (h *FeeHandler) Schedule(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	providerID, ok := feeProviderID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.FeeScheduleRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	schedule, err := h.feeService.ScheduleChange(r.Context(), providerID, adminID, req)
+	if err != nil {
+		status, code, msg := mapFeeError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, schedule)
+}
+
+func feeProviderID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "providerID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "Invalid provider ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -1,12 +1,15 @@
 package http
 
 import (
-	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/wallet/internal/application"
 	"github.com/parking-super-app/services/wallet/internal/domain"
 )
@@ -19,45 +22,76 @@ func NewWalletHandler(walletService *application.WalletService) *WalletHandler {
 	return &WalletHandler{walletService: walletService}
 }
 
-type APIResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   *APIError   `json:"error,omitempty"`
-}
-
-type APIError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
-
-func writeJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: status >= 200 && status < 300,
-		Data:    data,
-	})
-}
-
-func writeError(w http.ResponseWriter, status int, code, message string) {
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(APIResponse{
-		Success: false,
-		Error:   &APIError{Code: code, Message: message},
-	})
-}
-
 func mapDomainError(err error) (int, string, string) {
 	switch {
 	case errors.Is(err, domain.ErrWalletNotFound):
 		return http.StatusNotFound, "WALLET_NOT_FOUND", "Wallet not found"
 	case errors.Is(err, domain.ErrWalletAlreadyExists):
 		return http.StatusConflict, "WALLET_EXISTS", "Wallet already exists for this user"
+	case errors.Is(err, domain.ErrInvalidWalletLabel):
+		return http.StatusBadRequest, "INVALID_WALLET_LABEL", "Wallet label must be 1 to 50 characters"
+	case errors.Is(err, domain.ErrWalletLabelTaken):
+		return http.StatusConflict, "WALLET_LABEL_TAKEN", "You already have a wallet with this label"
+	case errors.Is(err, domain.ErrWalletLimitReached):
+		return http.StatusConflict, "WALLET_LIMIT_REACHED", "You have the most wallets allowed"
 	case errors.Is(err, domain.ErrInsufficientBalance):
 		return http.StatusBadRequest, "INSUFFICIENT_BALANCE", "Insufficient balance"
 	case errors.Is(err, domain.ErrInvalidAmount):
 		return http.StatusBadRequest, "INVALID_AMOUNT", "Amount must be positive"
 	case errors.Is(err, domain.ErrWalletInactive):
 		return http.StatusForbidden, "WALLET_INACTIVE", "Wallet is inactive"
+	case errors.Is(err, domain.ErrConcurrentModification):
+		return http.StatusConflict, "CONCURRENT_MODIFICATION", "Wallet was updated concurrently, please retry"
+	case errors.Is(err, domain.ErrPerTransactionLimitExceeded):
+		return http.StatusBadRequest, "PER_TRANSACTION_LIMIT_EXCEEDED", "Payment exceeds the per-transaction limit"
+	case errors.Is(err, domain.ErrDailyLimitExceeded):
+		return http.StatusBadRequest, "DAILY_LIMIT_EXCEEDED", "Payment exceeds the daily spending limit"
+	case errors.Is(err, domain.ErrMonthlyLimitExceeded):
+		return http.StatusBadRequest, "MONTHLY_LIMIT_EXCEEDED", "Payment exceeds the monthly spending limit"
+	case errors.Is(err, domain.ErrKYCBalanceLimitExceeded):
+		return http.StatusForbidden, "KYC_BALANCE_LIMIT_EXCEEDED", "Verify your identity to hold a higher balance"
+	case errors.Is(err, domain.ErrKYCTopUpLimitExceeded):
+		return http.StatusForbidden, "KYC_TOPUP_LIMIT_EXCEEDED", "Verify your identity to top up this amount"
+	case errors.Is(err, domain.ErrMemberLimitExceeded):
+		return http.StatusBadRequest, "MEMBER_LIMIT_EXCEEDED", "Payment exceeds the member's monthly limit"
+	case errors.Is(err, domain.ErrNotOrgMember):
+		return http.StatusForbidden, "NOT_ORGANIZATION_MEMBER", "Payer is not a member of the organization"
+	case errors.Is(err, domain.ErrKYCPaymentLimitExceeded):
+		return http.StatusForbidden, "KYC_PAYMENT_LIMIT_EXCEEDED", "Verify your identity to make this payment"
+	case errors.Is(err, domain.ErrRiskChallengeRequired):
+		return http.StatusForbidden, "RISK_CHALLENGE_REQUIRED", "Please confirm this transaction with an OTP"
+	case errors.Is(err, domain.ErrRiskBlocked):
+		return http.StatusForbidden, "RISK_BLOCKED", "This transaction was blocked. Contact support if this is unexpected"
+	case errors.Is(err, domain.ErrStepUpRequired):
+		return http.StatusForbidden, "STEP_UP_REQUIRED", "Please confirm this transaction with a one-time code"
+	case errors.Is(err, domain.ErrStepUpInvalid):
+		return http.StatusForbidden, "STEP_UP_INVALID", "Confirmation has expired or was already used. Please confirm again"
+	case errors.Is(err, domain.ErrStepUpType):
+		return http.StatusBadRequest, "INVALID_TYPE", "Only payments and top-ups can be confirmed"
+	case errors.Is(err, domain.ErrPINRequired):
+		return http.StatusForbidden, "PIN_REQUIRED", "Enter your transaction PIN to pay"
+	case errors.Is(err, domain.ErrPINIncorrect):
+		return http.StatusForbidden, "PIN_INCORRECT", "Incorrect PIN"
+	case errors.Is(err, domain.ErrPINLocked):
+		return http.StatusLocked, "PIN_LOCKED", "Too many incorrect PINs. Try again later or reset your PIN"
+	case errors.Is(err, domain.ErrPINNotSet):
+		return http.StatusForbidden, "PIN_NOT_SET", "Set a transaction PIN to pay"
+	case errors.Is(err, domain.ErrInvalidLimit):
+		return http.StatusBadRequest, "INVALID_LIMIT", "Spending limits must be positive"
+	case errors.Is(err, domain.ErrInvalidThreshold):
+		return http.StatusBadRequest, "INVALID_THRESHOLD", "Low-balance threshold must be positive"
+	case errors.Is(err, domain.ErrTopUpDeclined):
+		return http.StatusPaymentRequired, "TOPUP_DECLINED", "Top-up was declined by the payment gateway"
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound, "TRANSACTION_NOT_FOUND", "Transaction not found"
+	case errors.Is(err, domain.ErrInvalidCategory):
+		return http.StatusBadRequest, "INVALID_CATEGORY", "Category must be parking, ev_charging, fine, topup or transfer"
+	case errors.Is(err, domain.ErrInvalidTag):
+		return http.StatusBadRequest, "INVALID_TAG", "Tags are up to 30 letters, digits, dashes or underscores"
+	case errors.Is(err, domain.ErrTooManyTags):
+		return http.StatusBadRequest, "TOO_MANY_TAGS", "A transaction can have at most 10 tags"
+	case errors.Is(err, domain.ErrInvalidTimeRange):
+		return http.StatusBadRequest, "INVALID_TIME_RANGE", "from must be before to"
 	default:
 		return http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred"
 	}
@@ -65,48 +99,128 @@ func mapDomainError(err error) (int, string, string) {
 
 func (h *WalletHandler) CreateWallet(w http.ResponseWriter, r *http.Request) {
 	var req application.CreateWalletRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
 	resp, err := h.walletService.CreateWallet(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusCreated, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
+// GetWallet returns the user's default wallet, or the one of their wallets
+// named by ?wallet_id=
 func (h *WalletHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
-	userIDStr := r.Header.Get("X-User-ID")
-	if userIDStr == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+	userID, ok := requireUserID(w, r)
+	if !ok {
 		return
 	}
 
-	userID, err := uuid.Parse(userIDStr)
+	walletID := uuid.Nil
+	if v := r.URL.Query().Get("wallet_id"); v != "" {
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+			return
+		}
+		walletID = parsed
+	}
+
+	resp, err := h.walletService.GetUserWallet(r.Context(), userID, walletID)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListWallets returns all the user's wallets, default first
+//
+// GET /api/v1/wallet/wallets
+func (h *WalletHandler) ListWallets(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
 		return
 	}
 
-	resp, err := h.walletService.GetWallet(r.Context(), userID)
+	resp, err := h.walletService.ListWallets(r.Context(), userID)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// AddWallet opens another labeled wallet for the user
+//
+// POST /api/v1/wallet/wallets
+// Request: { "label": "Business", "currency": "MYR" }
+func (h *WalletHandler) AddWallet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.CreateWalletRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.UserID = userID
+	if req.Label == "" {
+		status, code, msg := mapDomainError(domain.ErrInvalidWalletLabel)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	resp, err := h.walletService.CreateWallet(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// SetDefaultWallet makes one of the user's wallets their default
+//
+// PUT /api/v1/wallet/wallets/{id}/default
+func (h *WalletHandler) SetDefaultWallet(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	walletID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+		return
+	}
+
+	resp, err := h.walletService.SetDefaultWallet(r.Context(), userID, walletID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *WalletHandler) TopUp(w http.ResponseWriter, r *http.Request) {
 	var req application.TopUpRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
@@ -114,21 +228,23 @@ func (h *WalletHandler) TopUp(w http.ResponseWriter, r *http.Request) {
 	if idempotencyKey != "" {
 		req.IdempotencyKey = idempotencyKey
 	}
+	req.DeviceID = r.Header.Get("X-Device-ID")
+	req.StepUpToken = r.Header.Get("X-Step-Up-Token")
 
 	resp, err := h.walletService.TopUp(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusOK, resp)
 }
 
 func (h *WalletHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	var req application.PaymentRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
 		return
 	}
 
@@ -136,30 +252,70 @@ func (h *WalletHandler) Pay(w http.ResponseWriter, r *http.Request) {
 	if idempotencyKey != "" {
 		req.IdempotencyKey = idempotencyKey
 	}
+	req.DeviceID = r.Header.Get("X-Device-ID")
+	req.StepUpToken = r.Header.Get("X-Step-Up-Token")
+	req.Interactive = true
 
 	resp, err := h.walletService.Pay(r.Context(), req)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RequestStepUp has a one-time code sent to the user for a payment or
+// top-up turned down with STEP_UP_REQUIRED. The user confirms it with
+// auth and retries with the token in X-Step-Up-Token.
+func (h *WalletHandler) RequestStepUp(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.StepUpChallengeRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.walletService.RequestStepUp(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	httpx.WriteJSON(w, http.StatusCreated, resp)
 }
 
 func (h *WalletHandler) GetTransactions(w http.ResponseWriter, r *http.Request) {
 	walletIDStr := r.URL.Query().Get("wallet_id")
 	if walletIDStr == "" {
-		writeError(w, http.StatusBadRequest, "MISSING_WALLET_ID", "wallet_id query parameter required")
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_WALLET_ID", "wallet_id query parameter required")
 		return
 	}
 
 	walletID, err := uuid.Parse(walletIDStr)
 	if err != nil {
-		writeError(w, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
 		return
 	}
 
+	var filter domain.TransactionFilter
+	if c := r.URL.Query().Get("category"); c != "" {
+		category, err := domain.ParseCategory(c)
+		if err != nil {
+			status, code, msg := mapDomainError(err)
+			httpx.WriteError(w, r, status, code, msg)
+			return
+		}
+		filter.Category = category
+	}
+	filter.Tag = strings.ToLower(r.URL.Query().Get("tag"))
+
 	limit := 20
 	offset := 0
 
@@ -174,12 +330,176 @@ func (h *WalletHandler) GetTransactions(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	resp, err := h.walletService.GetTransactions(r.Context(), walletID, limit, offset)
+	resp, err := h.walletService.GetTransactions(r.Context(), walletID, filter, limit, offset)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// TagTransaction replaces the tags on one of the user's transactions
+func (h *WalletHandler) TagTransaction(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	txID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TRANSACTION_ID", "Invalid transaction ID format")
+		return
+	}
+
+	var req struct {
+		Tags []string `json:"tags"`
+	}
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.walletService.TagTransaction(r.Context(), userID, txID, req.Tags)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetAnalytics breaks a wallet's money in and out down by category between
+// from and to (RFC 3339), by default since the start of this month. It
+// reports the default wallet unless ?wallet_id= names another.
+func (h *WalletHandler) GetAnalytics(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	walletID := uuid.Nil
+	if v := r.URL.Query().Get("wallet_id"); v != "" {
+		parsed, err := uuid.Parse(v)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+			return
+		}
+		walletID = parsed
+	}
+
+	to := time.Now()
+	_, from := domain.SpendingPeriods(to)
+	for param, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		if v := r.URL.Query().Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TIME", "from and to must be RFC 3339 times")
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	resp, err := h.walletService.GetSpendingBreakdown(r.Context(), userID, walletID, from, to)
 	if err != nil {
 		status, code, msg := mapDomainError(err)
-		writeError(w, status, code, msg)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WalletHandler) GetSpendingLimits(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, resp)
+	resp, err := h.walletService.GetSpendingLimits(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WalletHandler) SetSpendingLimits(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.SpendingLimitsRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.walletService.SetSpendingLimits(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WalletHandler) GetBalanceAlert(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.walletService.GetBalanceAlert(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *WalletHandler) SetBalanceAlert(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.BalanceAlertRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.walletService.SetBalanceAlert(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// requireUserID reads the caller's user ID set by the API gateway
+func requireUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	userIDStr := r.Header.Get("X-User-ID")
+	if userIDStr == "" {
+		httpx.WriteError(w, r, http.StatusBadRequest, "MISSING_USER_ID", "X-User-ID header required")
+		return uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		return uuid.Nil, false
+	}
+	return userID, true
 }
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// InvoiceHandler serves business accounts and their monthly invoices
+type InvoiceHandler struct {
+	invoiceService *application.InvoiceService
+}
+
+func NewInvoiceHandler(invoiceService *application.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{invoiceService: invoiceService}
+}
+
+func mapInvoiceError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrBusinessAccountNotFound):
+		return http.StatusNotFound, "BUSINESS_ACCOUNT_NOT_FOUND", "Business account not found"
+	case errors.Is(err, domain.ErrInvalidBusinessAccount):
+		return http.StatusBadRequest, "INVALID_BUSINESS_ACCOUNT", "Company name is required"
+	case errors.Is(err, domain.ErrInvoiceNotFound):
+		return http.StatusNotFound, "INVOICE_NOT_FOUND", "Invoice not found"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *InvoiceHandler) GetBusinessAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	account, err := h.invoiceService.GetBusinessAccount(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, account)
+}
+
+func (h *InvoiceHandler) SetBusinessAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.BusinessAccountRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	account, err := h.invoiceService.SetBusinessAccount(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, account)
+}
+
+func (h *InvoiceHandler) DeleteBusinessAccount(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.invoiceService.DeleteBusinessAccount(r.Context(), userID); err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *InvoiceHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := pagination(r)
+
+	resp, err := h.invoiceService.ListInvoices(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *InvoiceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, invoice)
+}
+
+// Download returns the invoice document as it was sent to the customer
+func (h *InvoiceHandler) Download(w http.ResponseWriter, r *http.Request) {
+	invoice, ok := h.loadInvoice(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", invoice.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(invoice.Document)))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+invoice.Number+`.html"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(invoice.Document)
+}
+
+// Run issues last month's invoices now instead of waiting for the scheduled run
+func (h *InvoiceHandler) Run(w http.ResponseWriter, r *http.Request) {
+	result, err := h.invoiceService.IssueMonthly(r.Context(), time.Now())
+	if err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *InvoiceHandler) loadInvoice(w http.ResponseWriter, r *http.Request) (*domain.Invoice, bool) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return nil, false
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_INVOICE_ID", "Invalid invoice ID format")
+		return nil, false
+	}
+
+	invoice, err := h.invoiceService.GetInvoice(r.Context(), userID, id)
+	if err != nil {
+		status, code, msg := mapInvoiceError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return nil, false
+	}
+	return invoice, true
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// LedgerHandler exposes ledger reconciliation and repair to admins
+type LedgerHandler struct {
+	ledgerService *application.LedgerService
+}
+
+func NewLedgerHandler(ledgerService *application.LedgerService) *LedgerHandler {
+	return &LedgerHandler{ledgerService: ledgerService}
+}
+
+func mapLedgerError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrDiscrepancyNotFound):
+		return http.StatusNotFound, "DISCREPANCY_NOT_FOUND", "Wallet has no open ledger discrepancy"
+	case errors.Is(err, domain.ErrUnbalancedPosting):
+		return http.StatusInternalServerError, "UNBALANCED_POSTING", "Ledger posting does not balance"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *LedgerHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	result, err := h.ledgerService.ReconcileAll(r.Context())
+	if err != nil {
+		status, code, msg := mapLedgerError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
+
+func (h *LedgerHandler) ListDiscrepancies(w http.ResponseWriter, r *http.Request) {
+	limit, offset := pagination(r)
+
+	resp, err := h.ledgerService.ListDiscrepancies(r.Context(), limit, offset)
+	if err != nil {
+		status, code, msg := mapLedgerError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *LedgerHandler) Repair(w http.ResponseWriter, r *http.Request) {
+	walletID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+		return
+	}
+
+	discrepancy, err := h.ledgerService.Repair(r.Context(), walletID)
+	if err != nil {
+		status, code, msg := mapLedgerError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, discrepancy)
+}
+
+func (h *LedgerHandler) GetEntries(w http.ResponseWriter, r *http.Request) {
+	walletID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_WALLET_ID", "Invalid wallet ID format")
+		return
+	}
+	limit, offset := pagination(r)
+
+	resp, err := h.ledgerService.GetEntries(r.Context(), walletID, limit, offset)
+	if err != nil {
+		status, code, msg := mapLedgerError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func pagination(r *http.Request) (int, int) {
+	limit := 20
+	offset := 0
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil {
+			limit = parsed
+		}
+	}
+	if o := r.URL.Query().Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil {
+			offset = parsed
+		}
+	}
+	return limit, offset
+}
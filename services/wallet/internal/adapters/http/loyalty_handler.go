@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// LoyaltyHandler serves users' loyalty points and the expiry job
+type LoyaltyHandler struct {
+	loyaltyService *application.LoyaltyService
+}
+
+func NewLoyaltyHandler(loyaltyService *application.LoyaltyService) *LoyaltyHandler {
+	return &LoyaltyHandler{loyaltyService: loyaltyService}
+}
+
+func mapLoyaltyError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPointsAmount):
+		return http.StatusBadRequest, "INVALID_POINTS", "Points must be positive"
+	case errors.Is(err, domain.ErrRedemptionTooSmall):
+		return http.StatusBadRequest, "REDEMPTION_TOO_SMALL", "Redemption is below the minimum"
+	case errors.Is(err, domain.ErrInsufficientPoints):
+		return http.StatusBadRequest, "INSUFFICIENT_POINTS", "Not enough loyalty points"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *LoyaltyHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.loyaltyService.GetBalance(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapLoyaltyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *LoyaltyHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := pagination(r)
+
+	resp, err := h.loyaltyService.GetHistory(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapLoyaltyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// Redeem turns points into wallet credit
+//
+// POST /api/v1/wallet/loyalty/redeem
+// Request: { "points": 500 }
+func (h *LoyaltyHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.RedeemPointsRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.loyaltyService.Redeem(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapLoyaltyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RunExpiry expires lapsed points now instead of waiting for the job
+func (h *LoyaltyHandler) RunExpiry(w http.ResponseWriter, r *http.Request) {
+	expired, err := h.loyaltyService.ExpirePoints(r.Context(), time.Now())
+	if err != nil {
+		status, code, msg := mapLoyaltyError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, map[string]int{"expired": expired})
+}
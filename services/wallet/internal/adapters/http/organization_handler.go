@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// OrganizationHandler serves organization wallets to their owners
+type OrganizationHandler struct {
+	orgService *application.OrganizationService
+}
+
+func NewOrganizationHandler(orgService *application.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{orgService: orgService}
+}
+
+func mapOrganizationError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return http.StatusNotFound, "ORGANIZATION_NOT_FOUND", "Organization not found"
+	case errors.Is(err, domain.ErrNotOrgMember):
+		return http.StatusNotFound, "MEMBER_NOT_FOUND", "Member not found"
+	case errors.Is(err, domain.ErrInvalidLimit):
+		return http.StatusBadRequest, "INVALID_LIMIT", "Monthly limit must be positive"
+	case errors.Is(err, domain.ErrInvalidTimeRange):
+		return http.StatusBadRequest, "INVALID_TIME_RANGE", "from must be before to"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *OrganizationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	orgs, err := h.orgService.ListOrganizations(r.Context(), userID)
+	if err != nil {
+		status, code, msg := mapOrganizationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, orgs)
+}
+
+func (h *OrganizationHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgRequest(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := h.orgService.GetOrganization(r.Context(), userID, orgID)
+	if err != nil {
+		status, code, msg := mapOrganizationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, org)
+}
+
+func (h *OrganizationHandler) SetMemberLimit(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgRequest(w, r)
+	if !ok {
+		return
+	}
+	memberID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_USER_ID", "Invalid user ID format")
+		return
+	}
+
+	var req application.MemberLimitRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	member, err := h.orgService.SetMemberLimit(r.Context(), userID, orgID, memberID, req)
+	if err != nil {
+		status, code, msg := mapOrganizationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, member)
+}
+
+// Transactions reports the organization's payments between from and to
+// (RFC 3339), by default since the start of this month
+func (h *OrganizationHandler) Transactions(w http.ResponseWriter, r *http.Request) {
+	userID, orgID, ok := orgRequest(w, r)
+	if !ok {
+		return
+	}
+
+	to := time.Now()
+	_, from := domain.SpendingPeriods(to)
+	for param, t := range map[string]*time.Time{"from": &from, "to": &to} {
+		if v := r.URL.Query().Get(param); v != "" {
+			parsed, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_TIME", "from and to must be RFC 3339 times")
+				return
+			}
+			*t = parsed
+		}
+	}
+
+	report, err := h.orgService.Report(r.Context(), userID, orgID, from, to)
+	if err != nil {
+		status, code, msg := mapOrganizationError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, report)
+}
+
+func orgRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	orgID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_ORGANIZATION_ID", "Invalid organization ID format")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return userID, orgID, true
+}
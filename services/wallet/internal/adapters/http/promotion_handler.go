@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// PromotionHandler serves promo code administration and redemption
+type PromotionHandler struct {
+	promotionService *application.PromotionService
+}
+
+func NewPromotionHandler(promotionService *application.PromotionService) *PromotionHandler {
+	return &PromotionHandler{promotionService: promotionService}
+}
+
+func mapPromotionError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrPromotionNotFound):
+		return http.StatusNotFound, "PROMOTION_NOT_FOUND", "Promo code not found"
+	case errors.Is(err, domain.ErrPromotionCodeExists):
+		return http.StatusConflict, "PROMOTION_CODE_EXISTS", "Promo code already exists"
+	case errors.Is(err, domain.ErrInvalidPromotion):
+		return http.StatusBadRequest, "INVALID_PROMOTION", "Invalid promotion"
+	case errors.Is(err, domain.ErrPromotionInactive):
+		return http.StatusBadRequest, "PROMOTION_INACTIVE", "Promo code is not active"
+	case errors.Is(err, domain.ErrPromotionExpired):
+		return http.StatusBadRequest, "PROMOTION_EXPIRED", "Promo code has expired"
+	case errors.Is(err, domain.ErrPromotionExhausted):
+		return http.StatusConflict, "PROMOTION_EXHAUSTED", "Promo code has been fully redeemed"
+	case errors.Is(err, domain.ErrPromotionAlreadyRedeemed):
+		return http.StatusConflict, "PROMOTION_ALREADY_REDEEMED", "Promo code already redeemed"
+	case errors.Is(err, domain.ErrPromotionNeedsTopUp):
+		return http.StatusBadRequest, "PROMOTION_NEEDS_TOPUP", "Promo code applies to a completed top-up"
+	case errors.Is(err, domain.ErrPromotionMinTopUp):
+		return http.StatusBadRequest, "PROMOTION_MIN_TOPUP", "Top-up is below the promo code minimum"
+	case errors.Is(err, domain.ErrTopUpAlreadyPromoted):
+		return http.StatusConflict, "TOPUP_ALREADY_PROMOTED", "Top-up already received a promo bonus"
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound, "TRANSACTION_NOT_FOUND", "Transaction not found"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+func (h *PromotionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req application.CreatePromotionRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapPromotionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusCreated, promotion)
+}
+
+func (h *PromotionHandler) List(w http.ResponseWriter, r *http.Request) {
+	limit, offset := pagination(r)
+	activeOnly := r.URL.Query().Get("active") == "true"
+
+	resp, err := h.promotionService.ListPromotions(r.Context(), activeOnly, limit, offset)
+	if err != nil {
+		status, code, msg := mapPromotionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *PromotionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROMOTION_ID", "Invalid promotion ID format")
+		return
+	}
+
+	promotion, err := h.promotionService.GetPromotion(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapPromotionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, promotion)
+}
+
+func (h *PromotionHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROMOTION_ID", "Invalid promotion ID format")
+		return
+	}
+
+	promotion, err := h.promotionService.DeactivatePromotion(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapPromotionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, promotion)
+}
+
+func (h *PromotionHandler) Redeem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.RedeemRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+
+	resp, err := h.promotionService.Redeem(r.Context(), userID, req)
+	if err != nil {
+		status, code, msg := mapPromotionError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
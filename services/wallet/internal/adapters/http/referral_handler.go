@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+)
+
+// ReferralHandler serves the referrals dashboard
+type ReferralHandler struct {
+	referralService *application.ReferralService
+}
+
+func NewReferralHandler(referralService *application.ReferralService) *ReferralHandler {
+	return &ReferralHandler{referralService: referralService}
+}
+
+// GetDashboard lists the friends the user referred and what they earned
+//
+// GET /api/v1/wallet/referrals?limit=20&offset=0
+func (h *ReferralHandler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	userID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	limit, offset := pagination(r)
+
+	resp, err := h.referralService.GetDashboard(r.Context(), userID, limit, offset)
+	if err != nil {
+		status, code, msg := mapDomainError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
@@ -5,52 +5,185 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/deadline"
+	"github.com/parking-super-app/pkg/httpx"
 	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/ports"
 )
 
 type Router struct {
-	walletService *application.WalletService
-	router        chi.Router
+	walletService     *application.WalletService
+	ledgerService     *application.LedgerService
+	promotionService  *application.PromotionService
+	adminService      *application.WalletAdminService
+	invoiceService    *application.InvoiceService
+	orgService        *application.OrganizationService
+	settlementService *application.SettlementService
+	feeService        *application.FeeService
+	loyaltyService    *application.LoyaltyService
+	referralService   *application.ReferralService
+	auditStore        audit.Store
+	webhooks          ports.WebhookVerifier
+	router            chi.Router
+	handler           http.Handler
 }
 
-func NewRouter(walletService *application.WalletService) *Router {
+func NewRouter(
+	walletService *application.WalletService,
+	ledgerService *application.LedgerService,
+	promotionService *application.PromotionService,
+	adminService *application.WalletAdminService,
+	invoiceService *application.InvoiceService,
+	orgService *application.OrganizationService,
+	settlementService *application.SettlementService,
+	feeService *application.FeeService,
+	loyaltyService *application.LoyaltyService,
+	referralService *application.ReferralService,
+	auditStore audit.Store,
+	webhooks ports.WebhookVerifier,
+) *Router {
 	r := &Router{
-		walletService: walletService,
-		router:        chi.NewRouter(),
+		walletService:     walletService,
+		ledgerService:     ledgerService,
+		promotionService:  promotionService,
+		adminService:      adminService,
+		invoiceService:    invoiceService,
+		orgService:        orgService,
+		settlementService: settlementService,
+		feeService:        feeService,
+		loyaltyService:    loyaltyService,
+		referralService:   referralService,
+		auditStore:        auditStore,
+		webhooks:          webhooks,
+		router:            chi.NewRouter(),
 	}
 
 	r.setupMiddleware()
 	r.setupRoutes()
+	r.handler = r.router
 
 	return r
 }
 
+// Use wraps the router with additional middleware. Unlike chi's Use it can be
+// called after routes are registered; middlewares run in the order given.
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		r.handler = middlewares[i](r.handler)
+	}
+}
+
 func (r *Router) setupMiddleware() {
 	r.router.Use(middleware.RequestID)
 	r.router.Use(middleware.RealIP)
-	r.router.Use(middleware.Logger)
+	r.router.Use(actor.Middleware)
+	r.router.Use(audit.Middleware)
 	r.router.Use(middleware.Recoverer)
-	r.router.Use(middleware.AllowContentType("application/json"))
+	r.router.Use(deadline.FromHeader)
 
-	r.router.Use(func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			next.ServeHTTP(w, req)
-		})
-	})
+	r.router.Use(httpx.SecurityHeaders)
+	r.router.Use(httpx.JSONContentType)
 }
 
 func (r *Router) setupRoutes() {
 	handler := NewWalletHandler(r.walletService)
+	ledgerHandler := NewLedgerHandler(r.ledgerService)
+	promotionHandler := NewPromotionHandler(r.promotionService)
+	webhookHandler := NewWebhookHandler(r.walletService, r.webhooks)
+	adminHandler := NewAdminHandler(r.adminService)
+	invoiceHandler := NewInvoiceHandler(r.invoiceService)
+	orgHandler := NewOrganizationHandler(r.orgService)
+	settlementHandler := NewSettlementHandler(r.settlementService)
+	feeHandler := NewFeeHandler(r.feeService)
+	loyaltyHandler := NewLoyaltyHandler(r.loyaltyService)
+	referralHandler := NewReferralHandler(r.referralService)
+
+	r.router.Group(func(router chi.Router) {
+		router.Use(middleware.AllowContentType("application/json"))
+
+		router.Route("/api/v1/wallet", func(router chi.Router) {
+			router.Post("/", handler.CreateWallet)
+			router.Get("/", handler.GetWallet)
+			router.Get("/wallets", handler.ListWallets)
+			router.Post("/wallets", handler.AddWallet)
+			router.Put("/wallets/{id}/default", handler.SetDefaultWallet)
+			router.Post("/topup", handler.TopUp)
+			router.Post("/pay", handler.Pay)
+			router.Post("/step-up", handler.RequestStepUp)
+			router.Get("/transactions", handler.GetTransactions)
+			router.Put("/transactions/{id}/tags", handler.TagTransaction)
+			router.Get("/analytics", handler.GetAnalytics)
+			router.Get("/limits", handler.GetSpendingLimits)
+			router.Put("/limits", handler.SetSpendingLimits)
+			router.Get("/balance-alert", handler.GetBalanceAlert)
+			router.Put("/balance-alert", handler.SetBalanceAlert)
+			router.Post("/promotions/redeem", promotionHandler.Redeem)
+			router.Get("/loyalty", loyaltyHandler.GetBalance)
+			router.Get("/loyalty/history", loyaltyHandler.GetHistory)
+			router.Post("/loyalty/redeem", loyaltyHandler.Redeem)
+			router.Get("/referrals", referralHandler.GetDashboard)
+			router.Get("/business-account", invoiceHandler.GetBusinessAccount)
+			router.Put("/business-account", invoiceHandler.SetBusinessAccount)
+			router.Delete("/business-account", invoiceHandler.DeleteBusinessAccount)
+			router.Get("/invoices", invoiceHandler.List)
+			router.Get("/invoices/{id}", invoiceHandler.Get)
+			router.Get("/invoices/{id}/download", invoiceHandler.Download)
+			router.Get("/orgs", orgHandler.List)
+			router.Get("/orgs/{id}", orgHandler.Get)
+			router.Put("/orgs/{id}/members/{userID}/limit", orgHandler.SetMemberLimit)
+			router.Get("/orgs/{id}/transactions", orgHandler.Transactions)
+		})
+
+		router.Route("/api/v1/admin/ledger", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Post("/reconcile", ledgerHandler.Reconcile)
+			router.Get("/discrepancies", ledgerHandler.ListDiscrepancies)
+			router.Post("/wallets/{id}/repair", ledgerHandler.Repair)
+			router.Get("/wallets/{id}/entries", ledgerHandler.GetEntries)
+		})
 
-	r.router.Route("/api/v1/wallet", func(router chi.Router) {
-		router.Post("/", handler.CreateWallet)
-		router.Get("/", handler.GetWallet)
-		router.Post("/topup", handler.TopUp)
-		router.Post("/pay", handler.Pay)
-		router.Get("/transactions", handler.GetTransactions)
+		router.Route("/api/v1/admin/promotions", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Post("/", promotionHandler.Create)
+			router.Get("/", promotionHandler.List)
+			router.Get("/{id}", promotionHandler.Get)
+			router.Post("/{id}/deactivate", promotionHandler.Deactivate)
+		})
+
+		router.With(actor.RequireRole(actor.RoleAdmin)).Post("/api/v1/admin/invoices/run", invoiceHandler.Run)
+		router.With(actor.RequireRole(actor.RoleAdmin)).Post("/api/v1/admin/loyalty/expiries", loyaltyHandler.RunExpiry)
+
+		router.Route("/api/v1/admin/settlements", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Post("/run", settlementHandler.Run)
+			router.Get("/", settlementHandler.List)
+			router.Get("/{id}", settlementHandler.Get)
+			router.Get("/{id}/export", settlementHandler.Export)
+			router.Post("/{id}/settle", settlementHandler.Settle)
+		})
+
+		router.Route("/api/v1/admin/fees", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Get("/{providerID}", feeHandler.List)
+			router.Post("/{providerID}", feeHandler.Schedule)
+		})
+
+		router.Route("/api/v1/admin/wallets", func(router chi.Router) {
+			router.Use(actor.RequireRole(actor.RoleAdmin))
+			router.Route("/audit", audit.NewHandler(r.auditStore).Routes)
+			router.Post("/{id}/freeze", adminHandler.Freeze)
+			router.Post("/{id}/unfreeze", adminHandler.Unfreeze)
+		})
+
+		router.With(actor.RequireRole(actor.RoleAdmin)).Post("/api/v1/admin/transactions/{id}/reverse", adminHandler.Reverse)
 	})
 
+	// Gateways post in their own formats (FPX sends form-encoded bodies), so
+	// webhooks sit outside the JSON-only group
+	r.router.Post("/api/v1/wallet/webhooks/{gateway}", webhookHandler.Receive)
+
 	r.router.Get("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok"}`))
@@ -58,5 +191,5 @@ func (r *Router) setupRoutes() {
 }
 
 func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	r.router.ServeHTTP(w, req)
+	r.handler.ServeHTTP(w, req)
 }
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminRoutesRequireAdminRole(t *testing.T) {
+	router := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	routes := []struct {
+		method, path string
+	}{
+		{http.MethodGet, "/api/v1/admin/promotions/"},
+		{http.MethodPost, "/api/v1/admin/promotions/"},
+		{http.MethodPost, "/api/v1/admin/promotions/8d3c2f0e-4b7a-4a57-9f3e-1c2d3e4f5a6b/deactivate"},
+		{http.MethodPost, "/api/v1/admin/transactions/8d3c2f0e-4b7a-4a57-9f3e-1c2d3e4f5a6b/reverse"},
+	}
+	for _, route := range routes {
+		for _, role := range []string{"", "customer"} {
+			req := httptest.NewRequest(route.method, route.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-User-ID", "0b8f6f0a-7d3e-4c1a-9a52-3f1e2d4c5b6a")
+			if role != "" {
+				req.Header.Set("X-User-Role", role)
+			}
+			rec := httptest.NewRecorder()
+			router.router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusForbidden {
+				t.Errorf("%s %s with role %q: got %d, want 403", route.method, route.path, role, rec.Code)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+// SettlementHandler serves provider settlements to back-office staff
+type SettlementHandler struct {
+	settlementService *application.SettlementService
+}
+
+func NewSettlementHandler(settlementService *application.SettlementService) *SettlementHandler {
+	return &SettlementHandler{settlementService: settlementService}
+}
+
+func mapSettlementError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrSettlementNotFound):
+		return http.StatusNotFound, "SETTLEMENT_NOT_FOUND", "Settlement not found"
+	case errors.Is(err, domain.ErrAlreadySettled):
+		return http.StatusConflict, "ALREADY_SETTLED", "Settlement has already been paid out"
+	case errors.Is(err, domain.ErrSettlementReferenceRequired):
+		return http.StatusBadRequest, "REFERENCE_REQUIRED", "A payout reference is required"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// Run settles yesterday now instead of waiting for the scheduled run, or the
+// day given as ?date=YYYY-MM-DD
+func (h *SettlementHandler) Run(w http.ResponseWriter, r *http.Request) {
+	var result *application.SettlementRunResult
+	var err error
+	if d := r.URL.Query().Get("date"); d != "" {
+		date, parseErr := time.Parse("2006-01-02", d)
+		if parseErr != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_DATE", "Date must be YYYY-MM-DD")
+			return
+		}
+		result, err = h.settlementService.SettleDay(r.Context(), date)
+	} else {
+		result, err = h.settlementService.SettleDaily(r.Context(), time.Now())
+	}
+	if err != nil {
+		status, code, msg := mapSettlementError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, result)
+}
+
+// List returns settlements, optionally filtered with ?provider_id= and ?status=
+func (h *SettlementHandler) List(w http.ResponseWriter, r *http.Request) {
+	var providerID *uuid.UUID
+	if p := r.URL.Query().Get("provider_id"); p != "" {
+		id, err := uuid.Parse(p)
+		if err != nil {
+			httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_PROVIDER_ID", "Invalid provider ID format")
+			return
+		}
+		providerID = &id
+	}
+	var status *domain.SettlementStatus
+	if s := r.URL.Query().Get("status"); s != "" {
+		filter := domain.SettlementStatus(s)
+		status = &filter
+	}
+	limit, offset := pagination(r)
+
+	resp, err := h.settlementService.ListSettlements(r.Context(), providerID, status, limit, offset)
+	if err != nil {
+		status, code, msg := mapSettlementError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *SettlementHandler) Get(w http.ResponseWriter, r *http.Request) {
+	settlement, ok := h.loadSettlement(w, r)
+	if !ok {
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, settlement)
+}
+
+// Export returns the settlement's lines as CSV for the provider, with the
+// totals as a final row
+func (h *SettlementHandler) Export(w http.ResponseWriter, r *http.Request) {
+	settlement, ok := h.loadSettlement(w, r)
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	out.Write([]string{"transaction_id", "type", "date", "reference_id", "description", "amount", "fee", "net"})
+	for _, line := range settlement.Lines {
+		out.Write([]string{
+			line.TransactionID.String(),
+			string(line.Type),
+			line.Date.UTC().Format(time.RFC3339),
+			line.ReferenceID,
+			line.Description,
+			line.Amount.StringFixed(2),
+			line.Fee.StringFixed(2),
+			line.Net.StringFixed(2),
+		})
+	}
+	out.Write([]string{
+		"", "total", "", settlement.Reference, "",
+		settlement.GrossAmount.Sub(settlement.RefundAmount).StringFixed(2),
+		settlement.FeeAmount.StringFixed(2),
+		settlement.NetAmount.StringFixed(2),
+	})
+	out.Flush()
+	if err := out.Error(); err != nil {
+		httpx.WriteError(w, r, http.StatusInternalServerError, "EXPORT_FAILED", "Failed to export settlement")
+		return
+	}
+
+	filename := "settlement-" + settlement.ProviderID.String() + "-" + settlement.PeriodStart.Format("2006-01-02") + ".csv"
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// Settle marks a settlement as paid out with the transfer's reference number
+func (h *SettlementHandler) Settle(w http.ResponseWriter, r *http.Request) {
+	adminID, ok := requireUserID(w, r)
+	if !ok {
+		return
+	}
+	id, ok := settlementID(w, r)
+	if !ok {
+		return
+	}
+
+	var req application.MarkSettledRequest
+	if err := httpx.DecodeJSON(w, r, &req); err != nil {
+		httpx.WriteDecodeError(w, r, err)
+		return
+	}
+	req.SettlementID = id
+	req.AdminID = adminID
+
+	settlement, err := h.settlementService.MarkSettled(r.Context(), req)
+	if err != nil {
+		status, code, msg := mapSettlementError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	httpx.WriteJSON(w, http.StatusOK, settlement)
+}
+
+func (h *SettlementHandler) loadSettlement(w http.ResponseWriter, r *http.Request) (*domain.Settlement, bool) {
+	id, ok := settlementID(w, r)
+	if !ok {
+		return nil, false
+	}
+
+	settlement, err := h.settlementService.GetSettlement(r.Context(), id)
+	if err != nil {
+		status, code, msg := mapSettlementError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return nil, false
+	}
+	return settlement, true
+}
+
+func settlementID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusBadRequest, "INVALID_SETTLEMENT_ID", "Invalid settlement ID format")
+		return uuid.Nil, false
+	}
+	return id, true
+}
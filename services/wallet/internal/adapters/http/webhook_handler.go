@@ -0,0 +1,62 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/parking-super-app/pkg/httpx"
+	"github.com/parking-super-app/services/wallet/internal/application"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// maxWebhookBody caps gateway notifications; real ones are a few kilobytes
+const maxWebhookBody = 64 << 10
+
+// WebhookHandler receives asynchronous payment confirmations from gateways
+type WebhookHandler struct {
+	walletService *application.WalletService
+	verifier      ports.WebhookVerifier
+}
+
+func NewWebhookHandler(walletService *application.WalletService, verifier ports.WebhookVerifier) *WebhookHandler {
+	return &WebhookHandler{walletService: walletService, verifier: verifier}
+}
+
+func mapWebhookError(err error) (int, string, string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		return http.StatusBadRequest, "INVALID_WEBHOOK", "Webhook could not be verified"
+	case errors.Is(err, domain.ErrTransactionNotFound):
+		return http.StatusNotFound, "TRANSACTION_NOT_FOUND", "Transaction not found"
+	default:
+		return mapDomainError(err)
+	}
+}
+
+// Receive verifies a gateway notification and settles the top-up it refers
+// to. Any non-2xx answer makes the gateway retry later.
+func (h *WebhookHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		httpx.WriteError(w, r, http.StatusRequestEntityTooLarge, "BODY_TOO_LARGE", "Webhook body too large")
+		return
+	}
+
+	event, err := h.verifier.VerifyWebhook(chi.URLParam(r, "gateway"), payload, r.Header)
+	if err == nil && event != nil {
+		_, err = h.walletService.ConfirmTopUp(r.Context(), event)
+	}
+	if err != nil {
+		status, code, msg := mapWebhookError(err)
+		httpx.WriteError(w, r, status, code, msg)
+		return
+	}
+
+	// FPX expects a plain "OK" acknowledgement; other gateways ignore the body
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("OK"))
+}
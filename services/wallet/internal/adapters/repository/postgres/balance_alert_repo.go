@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type BalanceAlertRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewBalanceAlertRepository(db *pgxpool.Pool) *BalanceAlertRepository {
+	return &BalanceAlertRepository{db: db}
+}
+
+func (r *BalanceAlertRepository) GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.BalanceAlert, error) {
+	query := `SELECT wallet_id, threshold, updated_at FROM balance_alerts WHERE wallet_id = $1`
+	alert := &domain.BalanceAlert{}
+	err := r.db.QueryRow(ctx, query, walletID).Scan(&alert.WalletID, &alert.Threshold, &alert.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.BalanceAlert{WalletID: walletID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return alert, nil
+}
+
+func (r *BalanceAlertRepository) Save(ctx context.Context, alert *domain.BalanceAlert) error {
+	query := `
+		INSERT INTO balance_alerts (wallet_id, threshold, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (wallet_id) DO UPDATE
+		SET threshold = EXCLUDED.threshold, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.Exec(ctx, query, alert.WalletID, alert.Threshold, alert.UpdatedAt)
+	return err
+}
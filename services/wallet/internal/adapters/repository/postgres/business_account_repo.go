@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type BusinessAccountRepository struct {
+	db dbtx
+}
+
+func NewBusinessAccountRepository(db *pgxpool.Pool) *BusinessAccountRepository {
+	return &BusinessAccountRepository{db: db}
+}
+
+const businessAccountColumns = `
+	user_id, company_name, COALESCE(registration_number, ''), COALESCE(tax_id, ''),
+	COALESCE(billing_address, ''), created_at, updated_at
+`
+
+func (r *BusinessAccountRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.BusinessAccount, error) {
+	query := `SELECT ` + businessAccountColumns + ` FROM business_accounts WHERE user_id = $1`
+	account, err := scanBusinessAccount(r.db.QueryRow(ctx, query, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrBusinessAccountNotFound
+	}
+	return account, err
+}
+
+// Save creates the account or replaces its billing details, keeping when it
+// was first set up
+func (r *BusinessAccountRepository) Save(ctx context.Context, a *domain.BusinessAccount) error {
+	query := `
+		INSERT INTO business_accounts (user_id, company_name, registration_number, tax_id, billing_address, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7)
+		ON CONFLICT (user_id) DO UPDATE
+		SET company_name = EXCLUDED.company_name,
+			registration_number = EXCLUDED.registration_number,
+			tax_id = EXCLUDED.tax_id,
+			billing_address = EXCLUDED.billing_address
+		RETURNING created_at, updated_at
+	`
+	return r.db.QueryRow(ctx, query,
+		a.UserID, a.CompanyName, a.RegistrationNumber, a.TaxID, a.BillingAddress, a.CreatedAt, a.UpdatedAt,
+	).Scan(&a.CreatedAt, &a.UpdatedAt)
+}
+
+func (r *BusinessAccountRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM business_accounts WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrBusinessAccountNotFound
+	}
+	return nil
+}
+
+func (r *BusinessAccountRepository) List(ctx context.Context, after uuid.UUID, limit int) ([]*domain.BusinessAccount, error) {
+	query := `SELECT ` + businessAccountColumns + ` FROM business_accounts WHERE user_id > $1 ORDER BY user_id LIMIT $2`
+	rows, err := r.db.Query(ctx, query, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var accounts []*domain.BusinessAccount
+	for rows.Next() {
+		account, err := scanBusinessAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func scanBusinessAccount(row pgx.Row) (*domain.BusinessAccount, error) {
+	var a domain.BusinessAccount
+	err := row.Scan(&a.UserID, &a.CompanyName, &a.RegistrationNumber, &a.TaxID, &a.BillingAddress, &a.CreatedAt, &a.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type CardPaymentRepository struct {
+	db dbtx
+}
+
+func NewCardPaymentRepository(db *pgxpool.Pool) *CardPaymentRepository {
+	return &CardPaymentRepository{db: db}
+}
+
+const cardPaymentColumns = `id, amount, currency, provider_id, reference_id, description, idempotency_key, status,
+	COALESCE(gateway_reference, ''), COALESCE(failure_reason, ''), tax_amount, taxes, created_at, updated_at`
+
+func (r *CardPaymentRepository) Create(ctx context.Context, p *domain.CardPayment) error {
+	taxes, err := marshalTaxes(p.Taxes)
+	if err != nil {
+		return fmt.Errorf("failed to encode taxes: %w", err)
+	}
+	query := `
+		INSERT INTO card_payments (
+			id, amount, currency, provider_id, reference_id, description, idempotency_key, status,
+			gateway_reference, failure_reason, tax_amount, taxes, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NULLIF($10, ''), $11, $12, $13, $14)
+	`
+	_, err = r.db.Exec(ctx, query,
+		p.ID, p.Amount, p.Currency, p.ProviderID, p.ReferenceID, p.Description, p.IdempotencyKey, p.Status,
+		p.GatewayReference, p.FailureReason, p.TaxAmount, taxes, p.CreatedAt, p.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrCardPaymentExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *CardPaymentRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.CardPayment, error) {
+	query := `SELECT ` + cardPaymentColumns + ` FROM card_payments WHERE idempotency_key = $1`
+	p, err := scanCardPayment(r.db.QueryRow(ctx, query, key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCardPaymentNotFound
+	}
+	return p, err
+}
+
+func (r *CardPaymentRepository) Update(ctx context.Context, p *domain.CardPayment) error {
+	query := `
+		UPDATE card_payments
+		SET status = $2, gateway_reference = NULLIF($3, ''), failure_reason = NULLIF($4, ''), updated_at = $5
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query, p.ID, p.Status, p.GatewayReference, p.FailureReason, p.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrCardPaymentNotFound
+	}
+	return nil
+}
+
+func scanCardPayment(row pgx.Row) (*domain.CardPayment, error) {
+	var p domain.CardPayment
+	var taxes []byte
+	err := row.Scan(
+		&p.ID, &p.Amount, &p.Currency, &p.ProviderID, &p.ReferenceID, &p.Description, &p.IdempotencyKey, &p.Status,
+		&p.GatewayReference, &p.FailureReason, &p.TaxAmount, &taxes, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(taxes) > 0 {
+		if err := json.Unmarshal(taxes, &p.Taxes); err != nil {
+			return nil, fmt.Errorf("failed to decode taxes: %w", err)
+		}
+	}
+	return &p, nil
+}
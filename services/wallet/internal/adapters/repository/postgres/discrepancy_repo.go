@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type DiscrepancyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDiscrepancyRepository(db *pgxpool.Pool) *DiscrepancyRepository {
+	return &DiscrepancyRepository{db: db}
+}
+
+const discrepancyColumns = `
+	id, wallet_id, wallet_balance, ledger_balance, drift, detected_at, resolved_at, resolution
+`
+
+// Record keeps the first detection time of a wallet's open discrepancy and
+// refreshes the balances it reports
+func (r *DiscrepancyRepository) Record(ctx context.Context, d *domain.LedgerDiscrepancy) error {
+	query := `
+		INSERT INTO ledger_discrepancies (` + discrepancyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, NULL, NULL)
+		ON CONFLICT (wallet_id) WHERE resolved_at IS NULL DO UPDATE
+		SET wallet_balance = EXCLUDED.wallet_balance,
+			ledger_balance = EXCLUDED.ledger_balance,
+			drift = EXCLUDED.drift
+		RETURNING id, detected_at
+	`
+	return r.db.QueryRow(ctx, query,
+		d.ID, d.WalletID, d.WalletBalance, d.LedgerBalance, d.Drift, d.DetectedAt,
+	).Scan(&d.ID, &d.DetectedAt)
+}
+
+func (r *DiscrepancyRepository) GetOpenByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.LedgerDiscrepancy, error) {
+	query := `SELECT ` + discrepancyColumns + ` FROM ledger_discrepancies WHERE wallet_id = $1 AND resolved_at IS NULL`
+	return r.scanDiscrepancy(r.db.QueryRow(ctx, query, walletID))
+}
+
+func (r *DiscrepancyRepository) Resolve(ctx context.Context, d *domain.LedgerDiscrepancy) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE ledger_discrepancies SET resolved_at = $2, resolution = $3
+		WHERE id = $1 AND resolved_at IS NULL
+	`, d.ID, d.ResolvedAt, d.Resolution)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrDiscrepancyNotFound
+	}
+	return nil
+}
+
+func (r *DiscrepancyRepository) ListOpen(ctx context.Context, limit, offset int) ([]*domain.LedgerDiscrepancy, error) {
+	query := `
+		SELECT ` + discrepancyColumns + `
+		FROM ledger_discrepancies
+		WHERE resolved_at IS NULL
+		ORDER BY detected_at DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.db.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var discrepancies []*domain.LedgerDiscrepancy
+	for rows.Next() {
+		d, err := r.scanDiscrepancy(rows)
+		if err != nil {
+			return nil, err
+		}
+		discrepancies = append(discrepancies, d)
+	}
+	return discrepancies, rows.Err()
+}
+
+func (r *DiscrepancyRepository) scanDiscrepancy(row pgx.Row) (*domain.LedgerDiscrepancy, error) {
+	d := &domain.LedgerDiscrepancy{}
+	var resolution *string
+	err := row.Scan(
+		&d.ID, &d.WalletID, &d.WalletBalance, &d.LedgerBalance, &d.Drift,
+		&d.DetectedAt, &d.ResolvedAt, &resolution,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDiscrepancyNotFound
+		}
+		return nil, err
+	}
+	if resolution != nil {
+		d.Resolution = *resolution
+	}
+	return d, nil
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type FeeScheduleRepository struct {
+	db dbtx
+}
+
+func NewFeeScheduleRepository(db *pgxpool.Pool) *FeeScheduleRepository {
+	return &FeeScheduleRepository{db: db}
+}
+
+const feeScheduleColumns = `id, provider_id, percentage, fixed_fee, effective_from, created_by, created_at`
+
+func (r *FeeScheduleRepository) Create(ctx context.Context, f *domain.FeeSchedule) error {
+	query := `INSERT INTO fee_schedules (` + feeScheduleColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.Exec(ctx, query,
+		f.ID, f.ProviderID, f.Percentage, f.FixedFee, f.EffectiveFrom, f.CreatedBy, f.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrFeeScheduleExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *FeeScheduleRepository) GetEffective(ctx context.Context, providerID uuid.UUID, at time.Time) (*domain.FeeSchedule, error) {
+	query := `
+		SELECT ` + feeScheduleColumns + `
+		FROM fee_schedules
+		WHERE provider_id = $1 AND effective_from <= $2
+		ORDER BY effective_from DESC
+		LIMIT 1
+	`
+	f, err := scanFeeSchedule(r.db.QueryRow(ctx, query, providerID, at))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrFeeScheduleNotFound
+	}
+	return f, err
+}
+
+func (r *FeeScheduleRepository) ListByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.FeeSchedule, error) {
+	query := `
+		SELECT ` + feeScheduleColumns + `
+		FROM fee_schedules
+		WHERE provider_id = $1
+		ORDER BY effective_from DESC
+	`
+	rows, err := r.db.Query(ctx, query, providerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var schedules []*domain.FeeSchedule
+	for rows.Next() {
+		f, err := scanFeeSchedule(rows)
+		if err != nil {
+			return nil, err
+		}
+		schedules = append(schedules, f)
+	}
+	return schedules, rows.Err()
+}
+
+func scanFeeSchedule(row pgx.Row) (*domain.FeeSchedule, error) {
+	var f domain.FeeSchedule
+	err := row.Scan(&f.ID, &f.ProviderID, &f.Percentage, &f.FixedFee, &f.EffectiveFrom, &f.CreatedBy, &f.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type HoldRepository struct {
+	db dbtx
+}
+
+func NewHoldRepository(db *pgxpool.Pool) *HoldRepository {
+	return &HoldRepository{db: db}
+}
+
+const holdColumns = `
+	id, wallet_id, amount, provider_id, reference_id, idempotency_key, status, captured_amount,
+	hold_transaction_id, payment_transaction_id, created_at, updated_at, settled_at
+`
+
+func (r *HoldRepository) Create(ctx context.Context, h *domain.Hold) error {
+	query := `
+		INSERT INTO holds (` + holdColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err := r.db.Exec(ctx, query,
+		h.ID, h.WalletID, h.Amount, h.ProviderID, h.ReferenceID, h.IdempotencyKey, h.Status, h.CapturedAmount,
+		h.HoldTransactionID, h.PaymentTransactionID, h.CreatedAt, h.UpdatedAt, h.SettledAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrDuplicateTransaction
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *HoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error) {
+	query := `SELECT ` + holdColumns + ` FROM holds WHERE id = $1`
+	return r.scanHold(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *HoldRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Hold, error) {
+	if key == "" {
+		return nil, domain.ErrHoldNotFound
+	}
+	query := `SELECT ` + holdColumns + ` FROM holds WHERE idempotency_key = $1`
+	return r.scanHold(r.db.QueryRow(ctx, query, key))
+}
+
+func (r *HoldRepository) Update(ctx context.Context, h *domain.Hold) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE holds SET status = $2, captured_amount = $3, payment_transaction_id = $4, settled_at = $5
+		WHERE id = $1
+	`, h.ID, h.Status, h.CapturedAmount, h.PaymentTransactionID, h.SettledAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrHoldNotFound
+	}
+	return nil
+}
+
+func (r *HoldRepository) scanHold(row pgx.Row) (*domain.Hold, error) {
+	h := &domain.Hold{}
+	var providerID *uuid.UUID
+	var referenceID, idempotencyKey *string
+	err := row.Scan(
+		&h.ID, &h.WalletID, &h.Amount, &providerID, &referenceID, &idempotencyKey, &h.Status, &h.CapturedAmount,
+		&h.HoldTransactionID, &h.PaymentTransactionID, &h.CreatedAt, &h.UpdatedAt, &h.SettledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrHoldNotFound
+		}
+		return nil, err
+	}
+	if providerID != nil {
+		h.ProviderID = *providerID
+	}
+	if referenceID != nil {
+		h.ReferenceID = *referenceID
+	}
+	if idempotencyKey != nil {
+		h.IdempotencyKey = *idempotencyKey
+	}
+	return h, nil
+}
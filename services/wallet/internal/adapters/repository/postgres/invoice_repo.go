@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type InvoiceRepository struct {
+	db dbtx
+}
+
+func NewInvoiceRepository(db *pgxpool.Pool) *InvoiceRepository {
+	return &InvoiceRepository{db: db}
+}
+
+const invoiceColumns = `
+	id, number, user_id, wallet_id, company_name, COALESCE(registration_number, ''),
+	COALESCE(tax_id, ''), COALESCE(billing_address, ''), period_start, period_end, currency,
+	lines, tax_lines, subtotal, tax_total, total, issued_at
+`
+
+func (r *InvoiceRepository) Create(ctx context.Context, inv *domain.Invoice) error {
+	lines, err := json.Marshal(inv.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to encode invoice lines: %w", err)
+	}
+	taxLines, err := json.Marshal(inv.TaxLines)
+	if err != nil {
+		return fmt.Errorf("failed to encode tax lines: %w", err)
+	}
+
+	query := `
+		INSERT INTO invoices (
+			id, number, user_id, wallet_id, company_name, registration_number, tax_id, billing_address,
+			period_start, period_end, currency, lines, tax_lines, subtotal, tax_total, total,
+			document, content_type, issued_at
+		)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, ''), NULLIF($8, ''),
+			$9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+	`
+	_, err = r.db.Exec(ctx, query,
+		inv.ID, inv.Number, inv.UserID, inv.WalletID, inv.CompanyName, inv.RegistrationNumber, inv.TaxID, inv.BillingAddress,
+		inv.PeriodStart, inv.PeriodEnd, inv.Currency, lines, taxLines, inv.Subtotal, inv.TaxTotal, inv.Total,
+		inv.Document, inv.ContentType, inv.IssuedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrInvoiceAlreadyIssued
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *InvoiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + `, document, content_type FROM invoices WHERE id = $1`
+	var document []byte
+	var contentType string
+	inv, err := scanInvoice(r.db.QueryRow(ctx, query, id), &document, &contentType)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	inv.Document, inv.ContentType = document, contentType
+	return inv, nil
+}
+
+func (r *InvoiceRepository) ExistsForPeriod(ctx context.Context, userID uuid.UUID, periodStart time.Time) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM invoices WHERE user_id = $1 AND period_start = $2)`,
+		userID, periodStart,
+	).Scan(&exists)
+	return exists, err
+}
+
+func (r *InvoiceRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Invoice, error) {
+	query := `
+		SELECT ` + invoiceColumns + `
+		FROM invoices
+		WHERE user_id = $1
+		ORDER BY period_start DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []*domain.Invoice
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, inv)
+	}
+	return invoices, rows.Err()
+}
+
+// scanInvoice reads invoiceColumns followed by any extra destinations
+func scanInvoice(row pgx.Row, extra ...any) (*domain.Invoice, error) {
+	var inv domain.Invoice
+	var lines, taxLines []byte
+	dest := []any{
+		&inv.ID, &inv.Number, &inv.UserID, &inv.WalletID, &inv.CompanyName, &inv.RegistrationNumber,
+		&inv.TaxID, &inv.BillingAddress, &inv.PeriodStart, &inv.PeriodEnd, &inv.Currency,
+		&lines, &taxLines, &inv.Subtotal, &inv.TaxTotal, &inv.Total, &inv.IssuedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(lines, &inv.Lines); err != nil {
+		return nil, fmt.Errorf("failed to decode invoice lines: %w", err)
+	}
+	if err := json.Unmarshal(taxLines, &inv.TaxLines); err != nil {
+		return nil, fmt.Errorf("failed to decode tax lines: %w", err)
+	}
+	return &inv, nil
+}
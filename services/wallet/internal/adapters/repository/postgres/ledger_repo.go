@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+type LedgerRepository struct {
+	db dbtx
+}
+
+func NewLedgerRepository(db *pgxpool.Pool) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Append writes a posting, refusing one that does not balance
+func (r *LedgerRepository) Append(ctx context.Context, entries []domain.LedgerEntry) error {
+	if err := domain.ValidatePosting(entries); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO ledger_entries (transaction_id, account, amount, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, e := range entries {
+		if _, err := r.db.Exec(ctx, query, e.TransactionID, string(e.Account), e.Amount, e.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *LedgerRepository) Balance(ctx context.Context, walletID uuid.UUID) (decimal.Decimal, error) {
+	query := `
+		WITH snapshot AS (
+			SELECT balance, last_entry_id FROM balance_snapshots
+			WHERE wallet_id = $1
+			ORDER BY last_entry_id DESC
+			LIMIT 1
+		)
+		SELECT COALESCE((SELECT balance FROM snapshot), 0) + COALESCE(SUM(e.amount), 0)
+		FROM ledger_entries e
+		WHERE e.account = $2 AND e.id > COALESCE((SELECT last_entry_id FROM snapshot), 0)
+	`
+	var balance decimal.Decimal
+	err := r.db.QueryRow(ctx, query, walletID, string(domain.WalletAccount(walletID))).Scan(&balance)
+	return balance, err
+}
+
+// Snapshot relies on the caller holding the wallet's row lock: every posting
+// to the wallet takes the same lock, so no entry below the new cut-off can
+// still be in flight.
+func (r *LedgerRepository) Snapshot(ctx context.Context, walletID uuid.UUID) (*domain.BalanceSnapshot, error) {
+	account := string(domain.WalletAccount(walletID))
+
+	var lastEntryID *int64
+	err := r.db.QueryRow(ctx, `SELECT MAX(id) FROM ledger_entries WHERE account = $1`, account).Scan(&lastEntryID)
+	if err != nil {
+		return nil, err
+	}
+	if lastEntryID == nil {
+		return nil, nil
+	}
+
+	var previous int64
+	err = r.db.QueryRow(ctx, `
+		SELECT last_entry_id FROM balance_snapshots
+		WHERE wallet_id = $1 ORDER BY last_entry_id DESC LIMIT 1
+	`, walletID).Scan(&previous)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+	if previous >= *lastEntryID {
+		return nil, nil
+	}
+
+	balance, err := r.Balance(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &domain.BalanceSnapshot{
+		WalletID:    walletID,
+		Balance:     balance,
+		LastEntryID: *lastEntryID,
+		CreatedAt:   time.Now().UTC(),
+	}
+	_, err = r.db.Exec(ctx, `
+		INSERT INTO balance_snapshots (wallet_id, last_entry_id, balance, created_at)
+		VALUES ($1, $2, $3, $4)
+	`, snapshot.WalletID, snapshot.LastEntryID, snapshot.Balance, snapshot.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (r *LedgerRepository) GetEntries(ctx context.Context, walletID uuid.UUID, limit, offset int) ([]domain.LedgerEntry, error) {
+	query := `
+		SELECT id, transaction_id, account, amount, created_at
+		FROM ledger_entries
+		WHERE account = $1
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, string(domain.WalletAccount(walletID)), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []domain.LedgerEntry
+	for rows.Next() {
+		var e domain.LedgerEntry
+		var account string
+		if err := rows.Scan(&e.ID, &e.TransactionID, &account, &e.Amount, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		e.Account = domain.LedgerAccount(account)
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
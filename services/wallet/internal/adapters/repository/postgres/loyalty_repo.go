@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type LoyaltyRepository struct {
+	db dbtx
+}
+
+func NewLoyaltyRepository(db *pgxpool.Pool) *LoyaltyRepository {
+	return &LoyaltyRepository{db: db}
+}
+
+const loyaltyColumns = `id, user_id, type, points, remaining, transaction_id, expires_at, created_at`
+
+func (r *LoyaltyRepository) Create(ctx context.Context, e *domain.LoyaltyEntry) error {
+	query := `
+		INSERT INTO loyalty_entries (` + loyaltyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Exec(ctx, query,
+		e.ID, e.UserID, e.Type, e.Points, e.Remaining, e.TransactionID, e.ExpiresAt, e.CreatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrLoyaltyEntryExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *LoyaltyRepository) OpenLotsForUpdate(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.LoyaltyEntry, error) {
+	query := `
+		SELECT ` + loyaltyColumns + `
+		FROM loyalty_entries
+		WHERE user_id = $1 AND type = 'earn' AND remaining > 0 AND expires_at > $2
+		ORDER BY expires_at, created_at
+		FOR UPDATE
+	`
+	return r.query(ctx, query, userID, now)
+}
+
+func (r *LoyaltyRepository) GetLotForUpdate(ctx context.Context, paymentID uuid.UUID) (*domain.LoyaltyEntry, error) {
+	query := `
+		SELECT ` + loyaltyColumns + `
+		FROM loyalty_entries
+		WHERE transaction_id = $1 AND type = 'earn'
+		FOR UPDATE
+	`
+	lot, err := scanLoyaltyEntry(r.db.QueryRow(ctx, query, paymentID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrLoyaltyLotNotFound
+	}
+	return lot, err
+}
+
+func (r *LoyaltyRepository) UpdateRemaining(ctx context.Context, lot *domain.LoyaltyEntry) error {
+	result, err := r.db.Exec(ctx, `UPDATE loyalty_entries SET remaining = $2 WHERE id = $1`, lot.ID, lot.Remaining)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrLoyaltyLotNotFound
+	}
+	return nil
+}
+
+func (r *LoyaltyRepository) Balance(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error) {
+	var balance int64
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE(SUM(remaining), 0)
+		FROM loyalty_entries
+		WHERE user_id = $1 AND type = 'earn' AND remaining > 0 AND expires_at > $2
+	`, userID, now).Scan(&balance)
+	return balance, err
+}
+
+func (r *LoyaltyRepository) ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.LoyaltyEntry, error) {
+	query := `
+		SELECT ` + loyaltyColumns + `
+		FROM loyalty_entries
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	return r.query(ctx, query, userID, limit, offset)
+}
+
+func (r *LoyaltyRepository) ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.LoyaltyEntry, error) {
+	query := `
+		SELECT ` + loyaltyColumns + `
+		FROM loyalty_entries
+		WHERE type = 'earn' AND remaining > 0 AND expires_at <= $1
+		ORDER BY expires_at
+		LIMIT $2
+	`
+	return r.query(ctx, query, now, limit)
+}
+
+func (r *LoyaltyRepository) query(ctx context.Context, query string, args ...any) ([]*domain.LoyaltyEntry, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.LoyaltyEntry
+	for rows.Next() {
+		e, err := scanLoyaltyEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+func scanLoyaltyEntry(row pgx.Row) (*domain.LoyaltyEntry, error) {
+	var e domain.LoyaltyEntry
+	err := row.Scan(&e.ID, &e.UserID, &e.Type, &e.Points, &e.Remaining, &e.TransactionID, &e.ExpiresAt, &e.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type OrganizationRepository struct {
+	db dbtx
+}
+
+func NewOrganizationRepository(db *pgxpool.Pool) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+const (
+	organizationColumns = `id, name, owner_id, wallet_id, created_at`
+	orgMemberColumns    = `org_id, user_id, role, monthly_limit, updated_at`
+)
+
+// Create stores the organization. Redelivered org.created events find it
+// already there and change nothing.
+func (r *OrganizationRepository) Create(ctx context.Context, org *domain.Organization) error {
+	query := `
+		INSERT INTO organizations (` + organizationColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query, org.ID, org.Name, org.OwnerID, org.WalletID, org.CreatedAt)
+	return err
+}
+
+func (r *OrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE id = $1`
+	return scanOrganization(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *OrganizationRepository) GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE wallet_id = $1`
+	return scanOrganization(r.db.QueryRow(ctx, query, walletID))
+}
+
+func (r *OrganizationRepository) ListByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*domain.Organization, error) {
+	query := `SELECT ` + organizationColumns + ` FROM organizations WHERE owner_id = $1 ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		org, err := scanOrganization(rows)
+		if err != nil {
+			return nil, err
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rows.Err()
+}
+
+func (r *OrganizationRepository) SaveMember(ctx context.Context, m *domain.OrgMember) error {
+	query := `
+		INSERT INTO org_members (` + orgMemberColumns + `)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (org_id, user_id) DO UPDATE
+		SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.Exec(ctx, query, m.OrgID, m.UserID, m.Role, m.MonthlyLimit, m.UpdatedAt)
+	return err
+}
+
+func (r *OrganizationRepository) GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE org_id = $1 AND user_id = $2`
+	m, err := scanOrgMember(r.db.QueryRow(ctx, query, orgID, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrNotOrgMember
+	}
+	return m, err
+}
+
+func (r *OrganizationRepository) UpdateMemberLimit(ctx context.Context, m *domain.OrgMember) error {
+	query := `UPDATE org_members SET monthly_limit = $3, updated_at = $4 WHERE org_id = $1 AND user_id = $2`
+	tag, err := r.db.Exec(ctx, query, m.OrgID, m.UserID, m.MonthlyLimit, m.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotOrgMember
+	}
+	return nil
+}
+
+// DeleteMember removes a member; removing one already gone is not an error
+func (r *OrganizationRepository) DeleteMember(ctx context.Context, orgID, userID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM org_members WHERE org_id = $1 AND user_id = $2`, orgID, userID)
+	return err
+}
+
+func (r *OrganizationRepository) ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error) {
+	query := `SELECT ` + orgMemberColumns + ` FROM org_members WHERE org_id = $1 ORDER BY updated_at`
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []*domain.OrgMember
+	for rows.Next() {
+		m, err := scanOrgMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func scanOrganization(row pgx.Row) (*domain.Organization, error) {
+	org := &domain.Organization{}
+	err := row.Scan(&org.ID, &org.Name, &org.OwnerID, &org.WalletID, &org.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+func scanOrgMember(row pgx.Row) (*domain.OrgMember, error) {
+	m := &domain.OrgMember{}
+	if err := row.Scan(&m.OrgID, &m.UserID, &m.Role, &m.MonthlyLimit, &m.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type PromotionRepository struct {
+	db dbtx
+}
+
+func NewPromotionRepository(db *pgxpool.Pool) *PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+const promotionColumns = `
+	id, code, description, bonus_type, bonus_value, max_bonus, min_top_up, max_redemptions,
+	max_per_wallet, redemption_count, active, starts_at, expires_at, created_at, updated_at
+`
+
+func (r *PromotionRepository) Create(ctx context.Context, p *domain.Promotion) error {
+	query := `
+		INSERT INTO promotions (` + promotionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.ID, p.Code, p.Description, p.BonusType, p.BonusValue, p.MaxBonus, p.MinTopUp, p.MaxRedemptions,
+		p.MaxPerWallet, p.RedemptionCount, p.Active, p.StartsAt, p.ExpiresAt, p.CreatedAt, p.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrPromotionCodeExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PromotionRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE id = $1`
+	return r.scanPromotion(r.db.QueryRow(ctx, query, id))
+}
+
+// GetByCodeForUpdate locks the promotion row until the surrounding unit of
+// work commits
+func (r *PromotionRepository) GetByCodeForUpdate(ctx context.Context, code string) (*domain.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotions WHERE code = $1 FOR UPDATE`
+	return r.scanPromotion(r.db.QueryRow(ctx, query, code))
+}
+
+func (r *PromotionRepository) List(ctx context.Context, activeOnly bool, limit, offset int) ([]*domain.Promotion, error) {
+	query := `
+		SELECT ` + promotionColumns + `
+		FROM promotions
+		WHERE active OR NOT $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, activeOnly, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var promotions []*domain.Promotion
+	for rows.Next() {
+		p, err := r.scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+	return promotions, rows.Err()
+}
+
+func (r *PromotionRepository) Update(ctx context.Context, p *domain.Promotion) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE promotions SET redemption_count = $2, active = $3
+		WHERE id = $1
+	`, p.ID, p.RedemptionCount, p.Active)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrPromotionNotFound
+	}
+	return nil
+}
+
+func (r *PromotionRepository) CountRedemptions(ctx context.Context, promotionID, walletID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM promotion_redemptions WHERE promotion_id = $1 AND wallet_id = $2
+	`, promotionID, walletID).Scan(&count)
+	return count, err
+}
+
+func (r *PromotionRepository) TopUpRedeemed(ctx context.Context, topUpTransactionID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM promotion_redemptions WHERE topup_transaction_id = $1)
+	`, topUpTransactionID).Scan(&exists)
+	return exists, err
+}
+
+func (r *PromotionRepository) RecordRedemption(ctx context.Context, redemption *domain.PromotionRedemption) error {
+	query := `
+		INSERT INTO promotion_redemptions (id, promotion_id, wallet_id, transaction_id, topup_transaction_id, bonus, redeemed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query,
+		redemption.ID, redemption.PromotionID, redemption.WalletID, redemption.TransactionID,
+		redemption.TopUpTransactionID, redemption.Bonus, redemption.RedeemedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTopUpAlreadyPromoted
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *PromotionRepository) scanPromotion(row pgx.Row) (*domain.Promotion, error) {
+	p := &domain.Promotion{}
+	var description *string
+	err := row.Scan(
+		&p.ID, &p.Code, &description, &p.BonusType, &p.BonusValue, &p.MaxBonus, &p.MinTopUp, &p.MaxRedemptions,
+		&p.MaxPerWallet, &p.RedemptionCount, &p.Active, &p.StartsAt, &p.ExpiresAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrPromotionNotFound
+		}
+		return nil, err
+	}
+	if description != nil {
+		p.Description = *description
+	}
+	return p, nil
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type ReferralRepository struct {
+	db dbtx
+}
+
+func NewReferralRepository(db *pgxpool.Pool) *ReferralRepository {
+	return &ReferralRepository{db: db}
+}
+
+const referralColumns = `id, referrer_id, referee_id, status, referrer_reward, referee_reward, expires_at, rewarded_at, created_at`
+
+func (r *ReferralRepository) Create(ctx context.Context, ref *domain.Referral) error {
+	query := `
+		INSERT INTO referrals (` + referralColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT DO NOTHING
+	`
+	_, err := r.db.Exec(ctx, query,
+		ref.ID, ref.ReferrerID, ref.RefereeID, ref.Status, ref.ReferrerReward, ref.RefereeReward,
+		ref.ExpiresAt, ref.RewardedAt, ref.CreatedAt,
+	)
+	return err
+}
+
+func (r *ReferralRepository) GetPendingByRefereeID(ctx context.Context, refereeID uuid.UUID) (*domain.Referral, error) {
+	query := `SELECT ` + referralColumns + ` FROM referrals WHERE referee_id = $1 AND status = 'pending'`
+	return r.get(ctx, query, refereeID)
+}
+
+func (r *ReferralRepository) GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.Referral, error) {
+	query := `SELECT ` + referralColumns + ` FROM referrals WHERE id = $1 FOR UPDATE`
+	return r.get(ctx, query, id)
+}
+
+func (r *ReferralRepository) get(ctx context.Context, query string, arg any) (*domain.Referral, error) {
+	ref, err := scanReferral(r.db.QueryRow(ctx, query, arg))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrReferralNotFound
+	}
+	return ref, err
+}
+
+func (r *ReferralRepository) Update(ctx context.Context, ref *domain.Referral) error {
+	query := `
+		UPDATE referrals
+		SET status = $2, referrer_reward = $3, referee_reward = $4, rewarded_at = $5
+		WHERE id = $1
+	`
+	result, err := r.db.Exec(ctx, query, ref.ID, ref.Status, ref.ReferrerReward, ref.RefereeReward, ref.RewardedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrReferralNotFound
+	}
+	return nil
+}
+
+func (r *ReferralRepository) ListByReferrerID(ctx context.Context, referrerID uuid.UUID, limit, offset int) ([]*domain.Referral, error) {
+	query := `
+		SELECT ` + referralColumns + `
+		FROM referrals
+		WHERE referrer_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, referrerID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var referrals []*domain.Referral
+	for rows.Next() {
+		ref, err := scanReferral(rows)
+		if err != nil {
+			return nil, err
+		}
+		referrals = append(referrals, ref)
+	}
+	return referrals, rows.Err()
+}
+
+func (r *ReferralRepository) Summary(ctx context.Context, referrerID uuid.UUID) (*domain.ReferralSummary, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE status = 'pending'),
+			COUNT(*) FILTER (WHERE status = 'rewarded'),
+			COUNT(*) FILTER (WHERE status = 'expired'),
+			COALESCE(SUM(referrer_reward), 0)
+		FROM referrals
+		WHERE referrer_id = $1
+	`
+	var s domain.ReferralSummary
+	if err := r.db.QueryRow(ctx, query, referrerID).Scan(&s.Pending, &s.Rewarded, &s.Expired, &s.Earned); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func scanReferral(row pgx.Row) (*domain.Referral, error) {
+	var ref domain.Referral
+	err := row.Scan(
+		&ref.ID, &ref.ReferrerID, &ref.RefereeID, &ref.Status, &ref.ReferrerReward, &ref.RefereeReward,
+		&ref.ExpiresAt, &ref.RewardedAt, &ref.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ref, nil
+}
@@ -0,0 +1,225 @@
+//go:build integration
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/testenv"
+	"github.com/parking-super-app/services/wallet/internal/adapters/repository/postgres"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/migrations"
+	"github.com/shopspring/decimal"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(testenv.Run(m))
+}
+
+func TestWalletRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	repo := postgres.NewWalletRepository(pool, pool)
+
+	wallet := domain.NewWallet(uuid.New(), "MYR")
+	if err := repo.Create(ctx, wallet); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := repo.Create(ctx, domain.NewWallet(wallet.UserID, "MYR")); !errors.Is(err, domain.ErrWalletAlreadyExists) {
+		t.Errorf("second Create() error = %v, want %v", err, domain.ErrWalletAlreadyExists)
+	}
+
+	got, err := repo.GetByUserID(ctx, wallet.UserID)
+	if err != nil {
+		t.Fatalf("GetByUserID() error = %v", err)
+	}
+	if got.ID != wallet.ID || got.Currency != "MYR" || !got.Balance.IsZero() || got.Status != wallet.Status {
+		t.Errorf("GetByUserID() = %+v, want %+v", got, wallet)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrWalletNotFound) {
+		t.Errorf("GetByID() of unknown wallet error = %v, want %v", err, domain.ErrWalletNotFound)
+	}
+
+	exists, err := repo.ExistsByUserID(ctx, wallet.UserID)
+	if err != nil || !exists {
+		t.Errorf("ExistsByUserID() = %v, %v, want true", exists, err)
+	}
+	exists, err = repo.ExistsByUserID(ctx, uuid.New())
+	if err != nil || exists {
+		t.Errorf("ExistsByUserID() of unknown user = %v, %v, want false", exists, err)
+	}
+
+	business, err := domain.NewLabeledWallet(wallet.UserID, "MYR", "Business", []*domain.Wallet{got})
+	if err != nil {
+		t.Fatalf("NewLabeledWallet() error = %v", err)
+	}
+	if err := repo.Create(ctx, business); err != nil {
+		t.Fatalf("Create() of a second wallet error = %v", err)
+	}
+	if err := repo.SetDefault(ctx, wallet.UserID, business.ID); err != nil {
+		t.Fatalf("SetDefault() error = %v", err)
+	}
+	if def, err := repo.GetByUserID(ctx, wallet.UserID); err != nil || def.ID != business.ID {
+		t.Errorf("GetByUserID() after SetDefault = %v, %v, want the business wallet", def, err)
+	}
+	all, err := repo.ListByUserID(ctx, wallet.UserID)
+	if err != nil || len(all) != 2 || all[0].ID != business.ID || all[1].IsDefault {
+		t.Errorf("ListByUserID() = %v, %v, want business then personal", all, err)
+	}
+	if err := repo.SetDefault(ctx, uuid.New(), wallet.ID); !errors.Is(err, domain.ErrWalletNotFound) {
+		t.Errorf("SetDefault() of another user's wallet error = %v, want %v", err, domain.ErrWalletNotFound)
+	}
+
+	if err := got.Credit(decimal.RequireFromString("12.50")); err != nil {
+		t.Fatalf("Credit() error = %v", err)
+	}
+	if err := repo.Update(ctx, got); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	stored, err := repo.GetByID(ctx, wallet.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if !stored.Balance.Equal(decimal.RequireFromString("12.50")) {
+		t.Errorf("balance = %s, want 12.50", stored.Balance)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := repo.Create(ctx, domain.NewWallet(uuid.New(), "MYR")); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	first, err := repo.ListIDs(ctx, uuid.Nil, 2)
+	if err != nil {
+		t.Fatalf("ListIDs() error = %v", err)
+	}
+	rest, err := repo.ListIDs(ctx, first[len(first)-1], 10)
+	if err != nil {
+		t.Fatalf("ListIDs() error = %v", err)
+	}
+	if len(first) != 2 || len(rest) != 1 {
+		t.Errorf("pages = %d + %d wallets, want 2 + 1", len(first), len(rest))
+	}
+}
+
+func TestTransactionRepository(t *testing.T) {
+	ctx := context.Background()
+	pool := testenv.PostgresPool(t, migrations.FS)
+	wallets := postgres.NewWalletRepository(pool, pool)
+	repo := postgres.NewTransactionRepository(pool, pool)
+
+	wallet := domain.NewWallet(uuid.New(), "MYR")
+	if err := wallets.Create(ctx, wallet); err != nil {
+		t.Fatalf("Create() wallet error = %v", err)
+	}
+
+	topUp := domain.NewTransaction(wallet.ID, domain.TransactionTypeTopUp, decimal.NewFromInt(50), decimal.Zero, "ref-1", "key-1", "Top up")
+	if err := repo.Create(ctx, topUp); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	dup := domain.NewTransaction(wallet.ID, domain.TransactionTypeTopUp, decimal.NewFromInt(50), decimal.Zero, "ref-1", "key-1", "Top up")
+	if err := repo.Create(ctx, dup); !errors.Is(err, domain.ErrDuplicateTransaction) {
+		t.Errorf("Create() with a reused idempotency key error = %v, want %v", err, domain.ErrDuplicateTransaction)
+	}
+
+	topUp.Complete(decimal.NewFromInt(50))
+	if err := repo.Update(ctx, topUp); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	payment := domain.NewTransaction(wallet.ID, domain.TransactionTypePayment, decimal.NewFromInt(8), decimal.NewFromInt(50), "ref-2", "key-2", "Parking")
+	payment.SetProvider(uuid.New())
+	payment.Complete(decimal.NewFromInt(42))
+	if err := repo.Create(ctx, payment); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	got, err := repo.GetByIdempotencyKey(ctx, "key-1")
+	if err != nil {
+		t.Fatalf("GetByIdempotencyKey() error = %v", err)
+	}
+	if got.ID != topUp.ID || got.Status != domain.TransactionStatusCompleted || !got.BalanceAfter.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("GetByIdempotencyKey() = %+v", got)
+	}
+	got, err = repo.GetByID(ctx, payment.ID)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if got.ProviderID == nil || *got.ProviderID != *payment.ProviderID {
+		t.Errorf("provider = %v, want %v", got.ProviderID, payment.ProviderID)
+	}
+	if _, err := repo.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrTransactionNotFound) {
+		t.Errorf("GetByID() of unknown transaction error = %v, want %v", err, domain.ErrTransactionNotFound)
+	}
+	if _, err := repo.GetByIdempotencyKey(ctx, ""); !errors.Is(err, domain.ErrTransactionNotFound) {
+		t.Errorf("GetByIdempotencyKey(\"\") error = %v, want %v", err, domain.ErrTransactionNotFound)
+	}
+
+	history, err := repo.GetByWalletID(ctx, wallet.ID, domain.TransactionFilter{}, 10, 0)
+	if err != nil {
+		t.Fatalf("GetByWalletID() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ID != payment.ID {
+		t.Errorf("GetByWalletID() = %d transactions, want 2 with the payment first", len(history))
+	}
+	count, err := repo.CountByWalletID(ctx, wallet.ID, domain.TransactionFilter{})
+	if err != nil || count != 2 {
+		t.Errorf("CountByWalletID() = %d, %v, want 2", count, err)
+	}
+
+	if err := payment.SetTags([]string{"Work", "klcc"}); err != nil {
+		t.Fatalf("SetTags() error = %v", err)
+	}
+	if err := repo.UpdateTags(ctx, payment); err != nil {
+		t.Fatalf("UpdateTags() error = %v", err)
+	}
+	for _, filter := range []domain.TransactionFilter{{Category: domain.CategoryParking}, {Tag: "work"}} {
+		filtered, err := repo.GetByWalletID(ctx, wallet.ID, filter, 10, 0)
+		if err != nil || len(filtered) != 1 || filtered[0].ID != payment.ID {
+			t.Errorf("GetByWalletID(%+v) = %d transactions, %v, want the payment", filter, len(filtered), err)
+			continue
+		}
+		if len(filtered[0].Tags) != 2 || filtered[0].Tags[0] != "work" {
+			t.Errorf("tags = %v, want [work klcc]", filtered[0].Tags)
+		}
+	}
+	if count, err := repo.CountByWalletID(ctx, wallet.ID, domain.TransactionFilter{Category: domain.CategoryFine}); err != nil || count != 0 {
+		t.Errorf("CountByWalletID() of fines = %d, %v, want 0", count, err)
+	}
+
+	since := time.Now().Add(-time.Hour)
+	spent, err := repo.SumCompletedSince(ctx, wallet.ID, domain.TransactionTypePayment, since)
+	if err != nil {
+		t.Fatalf("SumCompletedSince() error = %v", err)
+	}
+	if !spent.Equal(decimal.NewFromInt(8)) {
+		t.Errorf("SumCompletedSince() = %s, want 8", spent)
+	}
+
+	spending, err := repo.SpendingByCategory(ctx, wallet.ID, since, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("SpendingByCategory() error = %v", err)
+	}
+	if len(spending) != 2 || spending[0].Category != domain.CategoryParking || !spending[0].Spent.Equal(decimal.NewFromInt(8)) ||
+		spending[1].Category != domain.CategoryTopUp || !spending[1].Received.Equal(decimal.NewFromInt(50)) {
+		t.Errorf("SpendingByCategory() = %+v, want parking spent 8 and topup received 50", spending)
+	}
+
+	payments, err := repo.GetByTypeBetween(ctx, domain.TransactionTypePayment, since, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GetByTypeBetween() error = %v", err)
+	}
+	if len(payments) != 1 || payments[0].ID != payment.ID {
+		t.Errorf("GetByTypeBetween() = %d transactions, want the payment", len(payments))
+	}
+
+	missing := domain.NewTransaction(wallet.ID, domain.TransactionTypeTopUp, decimal.NewFromInt(1), decimal.Zero, "", "", "")
+	if err := repo.Update(ctx, missing); !errors.Is(err, domain.ErrTransactionNotFound) {
+		t.Errorf("Update() of unknown transaction error = %v, want %v", err, domain.ErrTransactionNotFound)
+	}
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type SettlementRepository struct {
+	db dbtx
+}
+
+func NewSettlementRepository(db *pgxpool.Pool) *SettlementRepository {
+	return &SettlementRepository{db: db}
+}
+
+const settlementColumns = `
+	id, provider_id, period_start, period_end, payment_count, gross_amount, refund_amount,
+	fee_amount, net_amount, status, COALESCE(reference, ''), settled_by, settled_at, created_at, updated_at
+`
+
+func (r *SettlementRepository) Create(ctx context.Context, s *domain.Settlement) error {
+	lines, err := json.Marshal(s.Lines)
+	if err != nil {
+		return fmt.Errorf("failed to encode settlement lines: %w", err)
+	}
+
+	query := `
+		INSERT INTO settlements (
+			id, provider_id, period_start, period_end, lines, payment_count, gross_amount,
+			refund_amount, fee_amount, net_amount, status, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+	_, err = r.db.Exec(ctx, query,
+		s.ID, s.ProviderID, s.PeriodStart, s.PeriodEnd, lines, s.PaymentCount, s.GrossAmount,
+		s.RefundAmount, s.FeeAmount, s.NetAmount, s.Status, s.CreatedAt, s.UpdatedAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrSettlementExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *SettlementRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Settlement, error) {
+	query := `SELECT ` + settlementColumns + `, lines FROM settlements WHERE id = $1`
+	var lines []byte
+	s, err := scanSettlement(r.db.QueryRow(ctx, query, id), &lines)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSettlementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(lines, &s.Lines); err != nil {
+		return nil, fmt.Errorf("failed to decode settlement lines: %w", err)
+	}
+	return s, nil
+}
+
+func (r *SettlementRepository) List(ctx context.Context, providerID *uuid.UUID, status *domain.SettlementStatus, limit, offset int) ([]*domain.Settlement, error) {
+	query := `
+		SELECT ` + settlementColumns + `
+		FROM settlements
+		WHERE ($1::uuid IS NULL OR provider_id = $1) AND ($2::text IS NULL OR status = $2)
+		ORDER BY period_start DESC, provider_id
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.db.Query(ctx, query, providerID, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var settlements []*domain.Settlement
+	for rows.Next() {
+		s, err := scanSettlement(rows)
+		if err != nil {
+			return nil, err
+		}
+		settlements = append(settlements, s)
+	}
+	return settlements, rows.Err()
+}
+
+func (r *SettlementRepository) Update(ctx context.Context, s *domain.Settlement) error {
+	result, err := r.db.Exec(ctx, `
+		UPDATE settlements SET status = $2, reference = NULLIF($3, ''), settled_by = $4, settled_at = $5
+		WHERE id = $1
+	`, s.ID, s.Status, s.Reference, s.SettledBy, s.SettledAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrSettlementNotFound
+	}
+	return nil
+}
+
+// scanSettlement reads settlementColumns followed by any extra destinations
+func scanSettlement(row pgx.Row, extra ...any) (*domain.Settlement, error) {
+	var s domain.Settlement
+	dest := []any{
+		&s.ID, &s.ProviderID, &s.PeriodStart, &s.PeriodEnd, &s.PaymentCount, &s.GrossAmount, &s.RefundAmount,
+		&s.FeeAmount, &s.NetAmount, &s.Status, &s.Reference, &s.SettledBy, &s.SettledAt, &s.CreatedAt, &s.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type SpendingLimitRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewSpendingLimitRepository(db *pgxpool.Pool) *SpendingLimitRepository {
+	return &SpendingLimitRepository{db: db}
+}
+
+// GetByWalletID reads the primary so a limit takes effect on the next payment
+func (r *SpendingLimitRepository) GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.SpendingLimits, error) {
+	query := `
+		SELECT wallet_id, per_transaction_limit, daily_limit, monthly_limit, updated_at
+		FROM spending_limits WHERE wallet_id = $1
+	`
+	limits := &domain.SpendingLimits{}
+	err := r.db.QueryRow(ctx, query, walletID).Scan(
+		&limits.WalletID, &limits.PerTransactionLimit, &limits.DailyLimit, &limits.MonthlyLimit, &limits.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &domain.SpendingLimits{WalletID: walletID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (r *SpendingLimitRepository) Save(ctx context.Context, limits *domain.SpendingLimits) error {
+	query := `
+		INSERT INTO spending_limits (wallet_id, per_transaction_limit, daily_limit, monthly_limit, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (wallet_id) DO UPDATE
+		SET per_transaction_limit = EXCLUDED.per_transaction_limit,
+			daily_limit = EXCLUDED.daily_limit,
+			monthly_limit = EXCLUDED.monthly_limit,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.Exec(ctx, query,
+		limits.WalletID, limits.PerTransactionLimit, limits.DailyLimit, limits.MonthlyLimit, limits.UpdatedAt,
+	)
+	return err
+}
@@ -2,7 +2,9 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -12,25 +14,33 @@ import (
 )
 
 type TransactionRepository struct {
-	db *pgxpool.Pool
+	db      dbtx
+	replica dbtx
 }
 
-func NewTransactionRepository(db *pgxpool.Pool) *TransactionRepository {
-	return &TransactionRepository{db: db}
+func NewTransactionRepository(db, replica *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{db: db, replica: replica}
 }
 
 func (r *TransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
+	taxes, err := marshalTaxes(tx.Taxes)
+	if err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO transactions (
 			id, wallet_id, type, amount, balance_before, balance_after,
 			reference_id, provider_id, status, description, idempotency_key,
-			created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err = r.db.Exec(ctx, query,
 		tx.ID, tx.WalletID, tx.Type, tx.Amount, tx.BalanceBefore, tx.BalanceAfter,
 		tx.ReferenceID, tx.ProviderID, tx.Status, tx.Description, tx.IdempotencyKey,
-		tx.CreatedAt, tx.UpdatedAt,
+		tx.ParentTransactionID, tx.DeviceID, tx.RiskScore, tx.MemberID, tx.AdminID, tx.FeeAmount, tx.NetAmount,
+		tx.TaxAmount, taxes, tx.Category, tagsOrEmpty(tx.Tags), tx.CreatedAt, tx.UpdatedAt,
 	)
 	if err != nil {
 		if isUniqueViolation(err) {
@@ -45,12 +55,15 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	query := `
 		SELECT id, wallet_id, type, amount, balance_before, balance_after,
 			reference_id, provider_id, status, description, idempotency_key,
-			created_at, updated_at
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
 		FROM transactions WHERE id = $1
 	`
-	return r.scanTransaction(r.db.QueryRow(ctx, query, id))
+	return r.scanTransaction(r.replica.QueryRow(ctx, query, id))
 }
 
+// GetByIdempotencyKey reads the primary: a lagging replica would let a
+// retried request through as a duplicate
 func (r *TransactionRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Transaction, error) {
 	if key == "" {
 		return nil, domain.ErrTransactionNotFound
@@ -58,23 +71,109 @@ func (r *TransactionRepository) GetByIdempotencyKey(ctx context.Context, key str
 	query := `
 		SELECT id, wallet_id, type, amount, balance_before, balance_after,
 			reference_id, provider_id, status, description, idempotency_key,
-			created_at, updated_at
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
 		FROM transactions WHERE idempotency_key = $1
 	`
 	return r.scanTransaction(r.db.QueryRow(ctx, query, key))
 }
 
-func (r *TransactionRepository) GetByWalletID(ctx context.Context, walletID uuid.UUID, limit, offset int) ([]*domain.Transaction, error) {
+func (r *TransactionRepository) GetByWalletID(ctx context.Context, walletID uuid.UUID, filter domain.TransactionFilter, limit, offset int) ([]*domain.Transaction, error) {
 	query := `
 		SELECT id, wallet_id, type, amount, balance_before, balance_after,
 			reference_id, provider_id, status, description, idempotency_key,
-			created_at, updated_at
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
 		FROM transactions
-		WHERE wallet_id = $1
+		WHERE wallet_id = $1 ` + filterClause + `
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $4 OFFSET $5
+	`
+	rows, err := r.replica.Query(ctx, query, walletID, filter.Category, filter.Tag, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		tx, err := r.scanTransactionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *TransactionRepository) GetByTypeBetween(ctx context.Context, txType domain.TransactionType, from, to time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, wallet_id, type, amount, balance_before, balance_after,
+			reference_id, provider_id, status, description, idempotency_key,
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
+		FROM transactions
+		WHERE type = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at
+	`
+	rows, err := r.replica.Query(ctx, query, txType, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		tx, err := r.scanTransactionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *TransactionRepository) GetByWalletTypeBetween(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, from, to time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, wallet_id, type, amount, balance_before, balance_after,
+			reference_id, provider_id, status, description, idempotency_key,
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
+		FROM transactions
+		WHERE wallet_id = $1 AND type = $2 AND created_at >= $3 AND created_at < $4
+		ORDER BY created_at
+	`
+	rows, err := r.replica.Query(ctx, query, walletID, txType, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*domain.Transaction
+	for rows.Next() {
+		tx, err := r.scanTransactionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, tx)
+	}
+	return transactions, rows.Err()
+}
+
+func (r *TransactionRepository) GetProviderTransactionsBetween(ctx context.Context, from, to time.Time) ([]*domain.Transaction, error) {
+	query := `
+		SELECT id, wallet_id, type, amount, balance_before, balance_after,
+			reference_id, provider_id, status, description, idempotency_key,
+			parent_transaction_id, device_id, risk_score, member_id, admin_id,
+			fee_amount, net_amount, tax_amount, taxes, category, tags, created_at, updated_at
+		FROM transactions
+		WHERE provider_id IS NOT NULL AND type IN ($1, $2, $3, $4)
+			AND created_at >= $5 AND created_at < $6
+		ORDER BY created_at
 	`
-	rows, err := r.db.Query(ctx, query, walletID, limit, offset)
+	rows, err := r.replica.Query(ctx, query,
+		domain.TransactionTypePayment, domain.TransactionTypeRefund, domain.TransactionTypeReversal,
+		domain.TransactionTypeRoundingAdjustment, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -94,10 +193,10 @@ func (r *TransactionRepository) GetByWalletID(ctx context.Context, walletID uuid
 func (r *TransactionRepository) Update(ctx context.Context, tx *domain.Transaction) error {
 	query := `
 		UPDATE transactions
-		SET status = $2, balance_after = $3, updated_at = $4
+		SET status = $2, balance_before = $3, balance_after = $4, reference_id = $5, updated_at = $6
 		WHERE id = $1
 	`
-	result, err := r.db.Exec(ctx, query, tx.ID, tx.Status, tx.BalanceAfter, tx.UpdatedAt)
+	result, err := r.db.Exec(ctx, query, tx.ID, tx.Status, tx.BalanceBefore, tx.BalanceAfter, tx.ReferenceID, tx.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -107,20 +206,118 @@ func (r *TransactionRepository) Update(ctx context.Context, tx *domain.Transacti
 	return nil
 }
 
-func (r *TransactionRepository) CountByWalletID(ctx context.Context, walletID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM transactions WHERE wallet_id = $1`
+// SumCompletedSince adds up a wallet's completed transactions of one type.
+// Spending limits call it under the wallet lock, so it reads the primary.
+func (r *TransactionRepository) SumCompletedSince(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, since time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE wallet_id = $1 AND type = $2 AND status = 'completed' AND created_at >= $3
+	`
+	var sum decimal.Decimal
+	err := r.db.QueryRow(ctx, query, walletID, txType, since).Scan(&sum)
+	return sum, err
+}
+
+// SumMemberCompletedSince adds up the completed payments one member charged
+// to an organization wallet. Member limits call it under the wallet lock.
+func (r *TransactionRepository) SumMemberCompletedSince(ctx context.Context, walletID, memberID uuid.UUID, since time.Time) (decimal.Decimal, error) {
+	query := `
+		SELECT COALESCE(SUM(amount), 0)
+		FROM transactions
+		WHERE wallet_id = $1 AND member_id = $2 AND type = 'payment' AND status = 'completed' AND created_at >= $3
+	`
+	var sum decimal.Decimal
+	err := r.db.QueryRow(ctx, query, walletID, memberID, since).Scan(&sum)
+	return sum, err
+}
+
+// GetRiskHistory summarises a wallet's top-ups and payments for risk checks.
+// It reads the primary so a burst of requests sees its own transactions.
+func (r *TransactionRepository) GetRiskHistory(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, deviceID string, since time.Time) (*domain.RiskHistory, error) {
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at >= $4),
+			COUNT(*) FILTER (WHERE status = 'completed' AND type = $2),
+			COALESCE(AVG(amount) FILTER (WHERE status = 'completed' AND type = $2), 0),
+			COUNT(*) FILTER (WHERE status = 'completed' AND device_id <> '' AND device_id = $3) > 0,
+			COUNT(*) FILTER (WHERE status = 'completed' AND device_id <> '') > 0
+		FROM transactions
+		WHERE wallet_id = $1 AND type IN ('topup', 'payment')
+	`
+	var h domain.RiskHistory
+	err := r.db.QueryRow(ctx, query, walletID, txType, deviceID, since).Scan(
+		&h.Recent, &h.Completed, &h.Average, &h.KnownDevice, &h.HasDevices,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *TransactionRepository) CountByWalletID(ctx context.Context, walletID uuid.UUID, filter domain.TransactionFilter) (int, error) {
+	query := `SELECT COUNT(*) FROM transactions WHERE wallet_id = $1 ` + filterClause
 	var count int
-	err := r.db.QueryRow(ctx, query, walletID).Scan(&count)
+	err := r.replica.QueryRow(ctx, query, walletID, filter.Category, filter.Tag).Scan(&count)
 	return count, err
 }
 
+// filterClause applies a TransactionFilter passed as $2 and $3
+const filterClause = `AND ($2 = '' OR category = $2) AND ($3 = '' OR $3 = ANY(tags))`
+
+func (r *TransactionRepository) UpdateTags(ctx context.Context, tx *domain.Transaction) error {
+	query := `UPDATE transactions SET tags = $2, updated_at = $3 WHERE id = $1`
+	result, err := r.db.Exec(ctx, query, tx.ID, tagsOrEmpty(tx.Tags), tx.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrTransactionNotFound
+	}
+	return nil
+}
+
+// SpendingByCategory totals a wallet's completed and refunded transactions
+// created in [from, to) by category. Holds are left out: a captured hold is
+// counted by its payment.
+func (r *TransactionRepository) SpendingByCategory(ctx context.Context, walletID uuid.UUID, from, to time.Time) ([]domain.CategorySpending, error) {
+	query := `
+		SELECT category, COUNT(*),
+			COALESCE(SUM(balance_before - balance_after) FILTER (WHERE balance_after < balance_before), 0),
+			COALESCE(SUM(balance_after - balance_before) FILTER (WHERE balance_after > balance_before), 0)
+		FROM transactions
+		WHERE wallet_id = $1 AND category <> '' AND status IN ('completed', 'refunded')
+			AND type NOT IN ('hold', 'hold_release')
+			AND created_at >= $2 AND created_at < $3
+		GROUP BY category
+		ORDER BY category
+	`
+	rows, err := r.replica.Query(ctx, query, walletID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []domain.CategorySpending
+	for rows.Next() {
+		var c domain.CategorySpending
+		if err := rows.Scan(&c.Category, &c.Transactions, &c.Spent, &c.Received); err != nil {
+			return nil, err
+		}
+		categories = append(categories, c)
+	}
+	return categories, rows.Err()
+}
+
 func (r *TransactionRepository) scanTransaction(row pgx.Row) (*domain.Transaction, error) {
 	tx := &domain.Transaction{}
 	var amount, balanceBefore, balanceAfter decimal.Decimal
+	var taxes []byte
 	err := row.Scan(
 		&tx.ID, &tx.WalletID, &tx.Type, &amount, &balanceBefore, &balanceAfter,
 		&tx.ReferenceID, &tx.ProviderID, &tx.Status, &tx.Description, &tx.IdempotencyKey,
-		&tx.CreatedAt, &tx.UpdatedAt,
+		&tx.ParentTransactionID, &tx.DeviceID, &tx.RiskScore, &tx.MemberID, &tx.AdminID, &tx.FeeAmount, &tx.NetAmount,
+		&tx.TaxAmount, &taxes, &tx.Category, &tx.Tags, &tx.CreatedAt, &tx.UpdatedAt,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -131,16 +328,23 @@ func (r *TransactionRepository) scanTransaction(row pgx.Row) (*domain.Transactio
 	tx.Amount = amount
 	tx.BalanceBefore = balanceBefore
 	tx.BalanceAfter = balanceAfter
+	if len(taxes) > 0 {
+		if err := json.Unmarshal(taxes, &tx.Taxes); err != nil {
+			return nil, err
+		}
+	}
 	return tx, nil
 }
 
 func (r *TransactionRepository) scanTransactionRow(rows pgx.Rows) (*domain.Transaction, error) {
 	tx := &domain.Transaction{}
 	var amount, balanceBefore, balanceAfter decimal.Decimal
+	var taxes []byte
 	err := rows.Scan(
 		&tx.ID, &tx.WalletID, &tx.Type, &amount, &balanceBefore, &balanceAfter,
 		&tx.ReferenceID, &tx.ProviderID, &tx.Status, &tx.Description, &tx.IdempotencyKey,
-		&tx.CreatedAt, &tx.UpdatedAt,
+		&tx.ParentTransactionID, &tx.DeviceID, &tx.RiskScore, &tx.MemberID, &tx.AdminID, &tx.FeeAmount, &tx.NetAmount,
+		&tx.TaxAmount, &taxes, &tx.Category, &tx.Tags, &tx.CreatedAt, &tx.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -148,5 +352,26 @@ func (r *TransactionRepository) scanTransactionRow(rows pgx.Rows) (*domain.Trans
 	tx.Amount = amount
 	tx.BalanceBefore = balanceBefore
 	tx.BalanceAfter = balanceAfter
+	if len(taxes) > 0 {
+		if err := json.Unmarshal(taxes, &tx.Taxes); err != nil {
+			return nil, err
+		}
+	}
 	return tx, nil
 }
+
+// tagsOrEmpty stores a transaction without tags as an empty array
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// marshalTaxes stores an untaxed transaction's breakdown as NULL
+func marshalTaxes(taxes []domain.TaxLine) ([]byte, error) {
+	if len(taxes) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(taxes)
+}
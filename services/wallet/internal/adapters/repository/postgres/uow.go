@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// dbtx is implemented by both *pgxpool.Pool and pgx.Tx, so the same
+// repository code runs standalone or inside a unit of work
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// UnitOfWork runs a function inside a database transaction. It commits when
+// the function returns nil and rolls back otherwise.
+type UnitOfWork struct {
+	db *pgxpool.Pool
+}
+
+func NewUnitOfWork(db *pgxpool.Pool) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+func (u *UnitOfWork) Execute(ctx context.Context, fn func(tx ports.Transaction) error) error {
+	return pgx.BeginFunc(ctx, u.db, func(tx pgx.Tx) error {
+		return fn(&transaction{
+			wallets:      &WalletRepository{db: tx, replica: tx},
+			transactions: &TransactionRepository{db: tx, replica: tx},
+			ledger:       &LedgerRepository{db: tx},
+			promotions:   &PromotionRepository{db: tx},
+			holds:        &HoldRepository{db: tx},
+			loyalty:      &LoyaltyRepository{db: tx},
+			referrals:    &ReferralRepository{db: tx},
+		})
+	})
+}
+
+type transaction struct {
+	wallets      *WalletRepository
+	transactions *TransactionRepository
+	ledger       *LedgerRepository
+	promotions   *PromotionRepository
+	holds        *HoldRepository
+	loyalty      *LoyaltyRepository
+	referrals    *ReferralRepository
+}
+
+func (t *transaction) Wallets() ports.WalletRepository {
+	return t.wallets
+}
+
+func (t *transaction) Transactions() ports.TransactionRepository {
+	return t.transactions
+}
+
+func (t *transaction) Ledger() ports.LedgerRepository {
+	return t.ledger
+}
+
+func (t *transaction) Promotions() ports.PromotionRepository {
+	return t.promotions
+}
+
+func (t *transaction) Holds() ports.HoldRepository {
+	return t.holds
+}
+
+func (t *transaction) Loyalty() ports.LoyaltyRepository {
+	return t.loyalty
+}
+
+func (t *transaction) Referrals() ports.ReferralRepository {
+	return t.referrals
+}
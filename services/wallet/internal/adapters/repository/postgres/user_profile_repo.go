@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+)
+
+type UserProfileRepository struct {
+	db dbtx
+}
+
+func NewUserProfileRepository(db *pgxpool.Pool) *UserProfileRepository {
+	return &UserProfileRepository{db: db}
+}
+
+// Upsert keeps whichever copy has the newer updated_at, so replayed or
+// reordered events never roll a profile back
+func (r *UserProfileRepository) Upsert(ctx context.Context, p *domain.UserProfile) (bool, error) {
+	query := `
+		INSERT INTO user_profiles (user_id, phone, email, full_name, kyc_level, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET phone = EXCLUDED.phone, email = EXCLUDED.email,
+			full_name = EXCLUDED.full_name, kyc_level = EXCLUDED.kyc_level,
+			updated_at = EXCLUDED.updated_at
+		WHERE user_profiles.updated_at < EXCLUDED.updated_at
+	`
+	tag, err := r.db.Exec(ctx, query, p.UserID, p.Phone, p.Email, p.FullName, p.KYCLevel, p.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *UserProfileRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
+	query := `
+		SELECT user_id, phone, email, full_name, kyc_level, updated_at
+		FROM user_profiles WHERE user_id = $1
+	`
+	var p domain.UserProfile
+	err := r.db.QueryRow(ctx, query, userID).Scan(&p.UserID, &p.Phone, &p.Email, &p.FullName, &p.KYCLevel, &p.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserProfileNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
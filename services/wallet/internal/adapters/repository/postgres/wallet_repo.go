@@ -11,21 +11,26 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// WalletRepository writes to the primary and serves lag-tolerant reads from
+// replica, which is the primary itself when no read replica is configured
 type WalletRepository struct {
-	db *pgxpool.Pool
+	db      dbtx
+	replica dbtx
 }
 
-func NewWalletRepository(db *pgxpool.Pool) *WalletRepository {
-	return &WalletRepository{db: db}
+func NewWalletRepository(db, replica *pgxpool.Pool) *WalletRepository {
+	return &WalletRepository{db: db, replica: replica}
 }
 
+const walletColumns = `id, user_id, label, is_default, balance, currency, status, version, created_at, updated_at`
+
 func (r *WalletRepository) Create(ctx context.Context, wallet *domain.Wallet) error {
 	query := `
-		INSERT INTO wallets (id, user_id, balance, currency, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO wallets (id, user_id, label, is_default, balance, currency, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
 	_, err := r.db.Exec(ctx, query,
-		wallet.ID, wallet.UserID, wallet.Balance, wallet.Currency,
+		wallet.ID, wallet.UserID, wallet.Label, wallet.IsDefault, wallet.Balance, wallet.Currency,
 		wallet.Status, wallet.CreatedAt, wallet.UpdatedAt,
 	)
 	if err != nil {
@@ -38,65 +43,94 @@ func (r *WalletRepository) Create(ctx context.Context, wallet *domain.Wallet) er
 }
 
 func (r *WalletRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Wallet, error) {
-	query := `
-		SELECT id, user_id, balance, currency, status, created_at, updated_at
-		FROM wallets WHERE id = $1
-	`
-	wallet := &domain.Wallet{}
-	var balance decimal.Decimal
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&wallet.ID, &wallet.UserID, &balance, &wallet.Currency,
-		&wallet.Status, &wallet.CreatedAt, &wallet.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrWalletNotFound
-		}
-		return nil, err
-	}
-	wallet.Balance = balance
-	return wallet, nil
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE id = $1`
+	return getWallet(r.replica.QueryRow(ctx, query, id))
 }
 
+// GetByIDForUpdate locks the wallet row until the surrounding unit of work
+// commits, so concurrent balance changes are applied one after another
+func (r *WalletRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Wallet, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE id = $1 FOR UPDATE`
+	return getWallet(r.db.QueryRow(ctx, query, id))
+}
+
+// GetByUserID returns the user's default wallet
 func (r *WalletRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Wallet, error) {
+	query := `SELECT ` + walletColumns + ` FROM wallets WHERE user_id = $1 AND is_default`
+	return getWallet(r.replica.QueryRow(ctx, query, userID))
+}
+
+// ListByUserID returns all the user's wallets, default first. It guards
+// wallet creation, so it reads the primary.
+func (r *WalletRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
 	query := `
-		SELECT id, user_id, balance, currency, status, created_at, updated_at
+		SELECT ` + walletColumns + `
 		FROM wallets WHERE user_id = $1
+		ORDER BY is_default DESC, created_at
 	`
-	wallet := &domain.Wallet{}
-	var balance decimal.Decimal
-	err := r.db.QueryRow(ctx, query, userID).Scan(
-		&wallet.ID, &wallet.UserID, &balance, &wallet.Currency,
-		&wallet.Status, &wallet.CreatedAt, &wallet.UpdatedAt,
-	)
+	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, domain.ErrWalletNotFound
-		}
 		return nil, err
 	}
-	wallet.Balance = balance
-	return wallet, nil
+	defer rows.Close()
+
+	var wallets []*domain.Wallet
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, err
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, rows.Err()
 }
 
+// SetDefault makes walletID the user's default wallet. It takes two
+// statements, so run it in a unit of work.
+func (r *WalletRepository) SetDefault(ctx context.Context, userID, walletID uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, `UPDATE wallets SET is_default = FALSE WHERE user_id = $1 AND is_default`, userID); err != nil {
+		return err
+	}
+	result, err := r.db.Exec(ctx, `UPDATE wallets SET is_default = TRUE WHERE id = $1 AND user_id = $2`, walletID, userID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrWalletNotFound
+	}
+	return nil
+}
+
+// Update writes the wallet only if nobody else has since the caller read it.
+// A stale version yields ErrConcurrentModification; on success the wallet's
+// Version is advanced to match the stored row.
 func (r *WalletRepository) Update(ctx context.Context, wallet *domain.Wallet) error {
 	query := `
 		UPDATE wallets
-		SET balance = $2, status = $3, updated_at = $4
-		WHERE id = $1
+		SET balance = $2, status = $3, updated_at = $4, version = version + 1
+		WHERE id = $1 AND version = $5
 	`
 	result, err := r.db.Exec(ctx, query,
-		wallet.ID, wallet.Balance, wallet.Status, wallet.UpdatedAt,
+		wallet.ID, wallet.Balance, wallet.Status, wallet.UpdatedAt, wallet.Version,
 	)
 	if err != nil {
 		return err
 	}
 	if result.RowsAffected() == 0 {
+		var exists bool
+		if err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM wallets WHERE id = $1)`, wallet.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return domain.ErrConcurrentModification
+		}
 		return domain.ErrWalletNotFound
 	}
+	wallet.Version++
 	return nil
 }
 
+// ExistsByUserID guards wallet creation, so it reads the primary
 func (r *WalletRepository) ExistsByUserID(ctx context.Context, userID uuid.UUID) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM wallets WHERE user_id = $1)`
 	var exists bool
@@ -104,6 +138,46 @@ func (r *WalletRepository) ExistsByUserID(ctx context.Context, userID uuid.UUID)
 	return exists, err
 }
 
+func (r *WalletRepository) ListIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `SELECT id FROM wallets WHERE id > $1 ORDER BY id LIMIT $2`, after, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func getWallet(row pgx.Row) (*domain.Wallet, error) {
+	wallet, err := scanWallet(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrWalletNotFound
+	}
+	return wallet, err
+}
+
+func scanWallet(row pgx.Row) (*domain.Wallet, error) {
+	wallet := &domain.Wallet{}
+	var balance decimal.Decimal
+	err := row.Scan(
+		&wallet.ID, &wallet.UserID, &wallet.Label, &wallet.IsDefault, &balance, &wallet.Currency,
+		&wallet.Status, &wallet.Version, &wallet.CreatedAt, &wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	wallet.Balance = balance
+	return wallet, nil
+}
+
 func isUniqueViolation(err error) bool {
 	var pgErr interface{ SQLState() string }
 	if errors.As(err, &pgErr) {
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// CardPaymentService charges cards directly, for guests who park without
+// a wallet. Each charge is recorded before the gateway is called, so a
+// retry with the same idempotency key returns the first attempt's outcome
+// instead of charging the card again.
+type CardPaymentService struct {
+	payments ports.CardPaymentRepository
+	gateway  ports.PaymentGateway
+	logger   ports.Logger
+}
+
+func NewCardPaymentService(payments ports.CardPaymentRepository, gateway ports.PaymentGateway, logger ports.Logger) *CardPaymentService {
+	return &CardPaymentService{payments: payments, gateway: gateway, logger: logger}
+}
+
+// Request/Response DTOs
+
+type CardPaymentRequest struct {
+	Amount         decimal.Decimal
+	Currency       string
+	CardToken      string
+	ProviderID     uuid.UUID
+	ReferenceID    string
+	Description    string
+	IdempotencyKey string
+	Taxes          []domain.TaxLine
+}
+
+// PayByCard charges the card and records the payment. A declined card
+// returns the failed payment with domain.ErrCardDeclined.
+func (s *CardPaymentService) PayByCard(ctx context.Context, req CardPaymentRequest) (*domain.CardPayment, error) {
+	if req.CardToken == "" {
+		return nil, domain.ErrCardTokenRequired
+	}
+	payment, err := domain.NewCardPayment(req.Amount, req.Currency, req.ProviderID, req.ReferenceID, req.Description, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	payment.ApplyTaxes(req.Taxes)
+
+	if err := s.payments.Create(ctx, payment); err != nil {
+		if !errors.Is(err, domain.ErrCardPaymentExists) {
+			return nil, fmt.Errorf("failed to save card payment: %w", err)
+		}
+		payment, err = s.payments.GetByIdempotencyKey(ctx, payment.IdempotencyKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get card payment: %w", err)
+		}
+	}
+
+	switch payment.Status {
+	case domain.CardPaymentStatusCompleted:
+		return payment, nil
+	case domain.CardPaymentStatusFailed:
+		return payment, domain.ErrCardDeclined
+	}
+	return s.charge(ctx, payment, req.CardToken)
+}
+
+// charge asks the gateway to charge a pending payment. A payment left
+// pending by a gateway error is charged again on retry; the gateway's own
+// idempotency keeps the card from being charged twice.
+func (s *CardPaymentService) charge(ctx context.Context, payment *domain.CardPayment, cardToken string) (*domain.CardPayment, error) {
+	resp, err := s.gateway.ProcessPayment(ctx, ports.PaymentRequest{
+		Amount:         payment.Amount,
+		Currency:       payment.Currency,
+		Description:    payment.Description,
+		ReferenceID:    payment.ID.String(),
+		IdempotencyKey: payment.IdempotencyKey,
+		Token:          cardToken,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("card payment gateway error",
+			ports.String("card_payment_id", payment.ID.String()),
+			ports.Err(err),
+		)
+		return nil, fmt.Errorf("failed to charge card: %w", err)
+	}
+
+	now := time.Now().UTC()
+	if resp.Status == ports.GatewayStatusSuccess {
+		payment.Complete(resp.TransactionID, now)
+	} else {
+		// Guests pay as they leave, so there's no waiting on a 3-D Secure
+		// redirect; anything short of an immediate charge is a decline
+		payment.Fail(resp.Message, now)
+	}
+	if err := s.payments.Update(ctx, payment); err != nil {
+		return nil, fmt.Errorf("failed to update card payment: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("card payment processed",
+		ports.String("card_payment_id", payment.ID.String()),
+		ports.String("reference_id", payment.ReferenceID),
+		ports.String("status", string(payment.Status)),
+	)
+	if payment.Status == domain.CardPaymentStatusFailed {
+		return payment, domain.ErrCardDeclined
+	}
+	return payment, nil
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// FeeService manages the commission charged on each provider's payments.
+// A fee change is a new schedule taking effect at a set time; payments are
+// charged under whichever schedule was in force when they were made.
+type FeeService struct {
+	schedules ports.FeeScheduleRepository
+	auditLog  ports.AuditLog
+	logger    ports.Logger
+}
+
+func NewFeeService(schedules ports.FeeScheduleRepository, auditLog ports.AuditLog, logger ports.Logger) *FeeService {
+	return &FeeService{schedules: schedules, auditLog: auditLog, logger: logger}
+}
+
+// Request/Response DTOs
+
+type FeeScheduleRequest struct {
+	Percentage    decimal.Decimal `json:"percentage"`
+	FixedFee      decimal.Decimal `json:"fixed_fee"`
+	EffectiveFrom *time.Time      `json:"effective_from"` // Now if not given
+}
+
+type FeeScheduleListResponse struct {
+	ProviderID uuid.UUID             `json:"provider_id"`
+	Current    *domain.FeeSchedule   `json:"current,omitempty"`
+	Schedules  []*domain.FeeSchedule `json:"schedules"`
+}
+
+// ListSchedules returns a provider's fee history and upcoming changes, with
+// the schedule in force now
+func (s *FeeService) ListSchedules(ctx context.Context, providerID uuid.UUID) (*FeeScheduleListResponse, error) {
+	schedules, err := s.schedules.ListByProviderID(ctx, providerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fee schedules: %w", err)
+	}
+	resp := &FeeScheduleListResponse{ProviderID: providerID, Schedules: schedules}
+	if resp.Schedules == nil {
+		resp.Schedules = []*domain.FeeSchedule{}
+	}
+
+	now := time.Now()
+	for _, schedule := range schedules {
+		if !schedule.EffectiveFrom.After(now) {
+			resp.Current = schedule
+			break
+		}
+	}
+	return resp, nil
+}
+
+// ScheduleChange sets a provider's fee from a given time on
+func (s *FeeService) ScheduleChange(ctx context.Context, providerID, adminID uuid.UUID, req FeeScheduleRequest) (*domain.FeeSchedule, error) {
+	var effectiveFrom time.Time
+	if req.EffectiveFrom != nil {
+		effectiveFrom = req.EffectiveFrom.UTC()
+	}
+	schedule, err := domain.NewFeeSchedule(providerID, req.Percentage, req.FixedFee, effectiveFrom, adminID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.schedules.Create(ctx, schedule); err != nil {
+		if errors.Is(err, domain.ErrFeeScheduleExists) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save fee schedule: %w", err)
+	}
+
+	event := ports.AuditEvent{
+		Action:     ports.AuditFeeScheduled,
+		TargetType: "provider",
+		TargetID:   providerID.String(),
+		After:      *schedule,
+	}
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		s.logger.WithContext(ctx).Error("failed to write audit log",
+			ports.String("action", event.Action),
+			ports.String("provider_id", providerID.String()),
+			ports.Err(err),
+		)
+	}
+
+	s.logger.WithContext(ctx).Info("provider fee scheduled",
+		ports.String("provider_id", providerID.String()),
+		ports.String("percentage", schedule.Percentage.String()),
+		ports.String("fixed_fee", schedule.FixedFee.String()),
+		ports.String("effective_from", schedule.EffectiveFrom.Format(time.RFC3339)),
+	)
+	return schedule, nil
+}
+
+// SetFees charges providers' commission on payments made to them
+func (s *WalletService) SetFees(schedules ports.FeeScheduleRepository) {
+	s.fees = schedules
+}
+
+// SetFees charges providers' commission on the payments holds are captured as
+func (s *HoldService) SetFees(schedules ports.FeeScheduleRepository) {
+	s.fees = schedules
+}
+
+// applyFee records the fee on a provider payment under the schedule in
+// force when it was made. Providers without a schedule pay no fee.
+func applyFee(ctx context.Context, schedules ports.FeeScheduleRepository, payment *domain.Transaction) error {
+	if schedules == nil || payment.ProviderID == nil || *payment.ProviderID == uuid.Nil {
+		return nil
+	}
+	schedule, err := schedules.GetEffective(ctx, *payment.ProviderID, payment.CreatedAt)
+	if errors.Is(err, domain.ErrFeeScheduleNotFound) {
+		payment.ApplyFee(decimal.Zero)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+	payment.ApplyFee(schedule.FeeFor(payment.Amount))
+	return nil
+}
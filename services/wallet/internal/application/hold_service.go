@@ -0,0 +1,350 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// HoldService sets wallet money aside for payments whose final amount is
+// settled later, such as parking reservations. Holds are not checked against
+// spending limits; their captures are recorded as ordinary payments.
+type HoldService struct {
+	holds        ports.HoldRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	uow          ports.UnitOfWork
+	events       ports.EventPublisher
+	fees         ports.FeeScheduleRepository
+	loyalty      *LoyaltyService
+	referrals    *ReferralService
+	logger       ports.Logger
+}
+
+func NewHoldService(
+	holds ports.HoldRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	logger ports.Logger,
+) *HoldService {
+	return &HoldService{
+		holds:        holds,
+		wallets:      wallets,
+		transactions: transactions,
+		ledger:       ledger,
+		uow:          uow,
+		events:       events,
+		logger:       logger,
+	}
+}
+
+type PlaceHoldRequest struct {
+	WalletID       uuid.UUID       `json:"wallet_id"`
+	Amount         decimal.Decimal `json:"amount"`
+	ProviderID     uuid.UUID       `json:"provider_id"`
+	ReferenceID    string          `json:"reference_id"`
+	Description    string          `json:"description"`
+	IdempotencyKey string          `json:"idempotency_key"`
+}
+
+// CaptureHoldRequest settles a hold with a payment of Amount, which may be
+// more or less than the amount held
+type CaptureHoldRequest struct {
+	HoldID         uuid.UUID       `json:"hold_id"`
+	Amount         decimal.Decimal `json:"amount"`
+	ReferenceID    string          `json:"reference_id"`
+	Description    string          `json:"description"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	// Taxes are the taxes included in Amount
+	Taxes []domain.TaxLine `json:"-"`
+}
+
+type HoldResponse struct {
+	Hold    *domain.Hold    `json:"hold"`
+	Balance decimal.Decimal `json:"balance"`
+}
+
+// PlaceHold takes the amount out of the wallet's balance until the hold is
+// captured or released. Retrying with the same idempotency key returns the
+// hold already placed.
+func (s *HoldService) PlaceHold(ctx context.Context, req PlaceHoldRequest) (*HoldResponse, error) {
+	if existing, err := s.holds.GetByIdempotencyKey(ctx, req.IdempotencyKey); err == nil {
+		return s.toHoldResponse(ctx, existing)
+	}
+
+	hold, err := domain.NewHold(req.WalletID, req.Amount, req.ProviderID, req.ReferenceID, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	description := req.Description
+	if description == "" {
+		description = "Hold"
+	}
+
+	var balance decimal.Decimal
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		wallet, err := uow.Wallets().GetByIDForUpdate(ctx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+		if !wallet.CanTransact() {
+			return domain.ErrWalletInactive
+		}
+
+		tx := domain.NewHoldTransaction(hold, wallet.Balance, description)
+		if err := wallet.Debit(hold.Amount); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, wallet); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+
+		tx.Complete(wallet.Balance)
+		if err := uow.Transactions().Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		if err := post(ctx, uow, tx); err != nil {
+			return err
+		}
+
+		hold.HoldTransactionID = tx.ID
+		if err := uow.Holds().Create(ctx, hold); err != nil {
+			return err
+		}
+		balance = wallet.Balance
+		return nil
+	})
+	if err != nil {
+		// A concurrent retry with the same key placed the hold first
+		if errors.Is(err, domain.ErrDuplicateTransaction) {
+			if existing, getErr := s.holds.GetByIdempotencyKey(ctx, req.IdempotencyKey); getErr == nil {
+				return s.toHoldResponse(ctx, existing)
+			}
+		}
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("hold placed",
+		ports.String("hold_id", hold.ID.String()),
+		ports.String("wallet_id", hold.WalletID.String()),
+		ports.String("amount", hold.Amount.String()),
+	)
+	s.publish(ctx, ports.EventHoldPlaced, hold, nil)
+	return &HoldResponse{Hold: hold, Balance: balance}, nil
+}
+
+// CaptureHold returns the held amount to the wallet and takes the captured
+// amount as a payment, in one unit of work. Capturing more than was held
+// needs the difference in the wallet.
+func (s *HoldService) CaptureHold(ctx context.Context, req CaptureHoldRequest) (*HoldResponse, *TransactionResponse, error) {
+	if !req.Amount.IsPositive() {
+		return nil, nil, domain.ErrInvalidAmount
+	}
+
+	if payment, err := s.transactions.GetByIdempotencyKey(ctx, req.IdempotencyKey); err == nil {
+		hold, err := s.holds.GetByID(ctx, req.HoldID)
+		if err != nil {
+			return nil, nil, err
+		}
+		resp, err := s.toHoldResponse(ctx, hold)
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp, toTransactionResponse(payment), nil
+	}
+
+	hold, err := s.holds.GetByID(ctx, req.HoldID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var payment *domain.Transaction
+	var balance decimal.Decimal
+	var userID uuid.UUID
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		wallet, err := uow.Wallets().GetByIDForUpdate(ctx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+		userID = wallet.UserID
+		// Re-read under the wallet lock so a concurrent release cannot also settle it
+		hold, err = uow.Holds().GetByID(ctx, req.HoldID)
+		if err != nil {
+			return err
+		}
+		if !hold.IsActive() {
+			return domain.ErrHoldSettled
+		}
+
+		if err := s.returnHold(ctx, uow, wallet, hold); err != nil {
+			return err
+		}
+
+		payment = domain.NewTransaction(
+			wallet.ID,
+			domain.TransactionTypePayment,
+			req.Amount,
+			wallet.Balance,
+			req.ReferenceID,
+			req.IdempotencyKey,
+			req.Description,
+		)
+		payment.SetProvider(hold.ProviderID)
+		payment.AddMetadata("hold_id", hold.ID.String())
+		payment.ApplyTaxes(req.Taxes)
+		if err := applyFee(ctx, s.fees, payment); err != nil {
+			return err
+		}
+		if err := wallet.Debit(req.Amount); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, wallet); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+		payment.Complete(wallet.Balance)
+		if err := uow.Transactions().Create(ctx, payment); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		if err := post(ctx, uow, payment); err != nil {
+			return err
+		}
+
+		if err := hold.Capture(req.Amount, payment.ID); err != nil {
+			return err
+		}
+		if err := uow.Holds().Update(ctx, hold); err != nil {
+			return fmt.Errorf("failed to update hold: %w", err)
+		}
+		balance = wallet.Balance
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("hold captured",
+		ports.String("hold_id", hold.ID.String()),
+		ports.String("captured", req.Amount.String()),
+	)
+	s.publish(ctx, ports.EventHoldCaptured, hold, map[string]interface{}{
+		"transaction_id":  payment.ID.String(),
+		"captured_amount": req.Amount.String(),
+	})
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{
+			Type: ports.EventPaymentCompleted,
+			Payload: map[string]interface{}{
+				"transaction_id": payment.ID.String(),
+				"wallet_id":      payment.WalletID.String(),
+				"provider_id":    hold.ProviderID.String(),
+				"amount":         payment.Amount.String(),
+			},
+		})
+	}()
+	earnPoints(ctx, s.loyalty, s.logger, userID, payment)
+	rewardReferral(ctx, s.referrals, s.logger, userID)
+	return &HoldResponse{Hold: hold, Balance: balance}, toTransactionResponse(payment), nil
+}
+
+// ReleaseHold returns the whole held amount to the wallet. Releasing a hold
+// that was already released is a no-op.
+func (s *HoldService) ReleaseHold(ctx context.Context, holdID uuid.UUID) (*HoldResponse, error) {
+	hold, err := s.holds.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.Status == domain.HoldStatusReleased {
+		return s.toHoldResponse(ctx, hold)
+	}
+
+	var balance decimal.Decimal
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		wallet, err := uow.Wallets().GetByIDForUpdate(ctx, hold.WalletID)
+		if err != nil {
+			return err
+		}
+		hold, err = uow.Holds().GetByID(ctx, holdID)
+		if err != nil {
+			return err
+		}
+		if !hold.IsActive() {
+			return domain.ErrHoldSettled
+		}
+
+		if err := s.returnHold(ctx, uow, wallet, hold); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, wallet); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+		if err := hold.Release(); err != nil {
+			return err
+		}
+		if err := uow.Holds().Update(ctx, hold); err != nil {
+			return fmt.Errorf("failed to update hold: %w", err)
+		}
+		balance = wallet.Balance
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("hold released", ports.String("hold_id", hold.ID.String()))
+	s.publish(ctx, ports.EventHoldReleased, hold, nil)
+	return &HoldResponse{Hold: hold, Balance: balance}, nil
+}
+
+// returnHold credits the held amount back to the locked wallet and books the
+// release. The caller saves the wallet.
+func (s *HoldService) returnHold(ctx context.Context, uow ports.Transaction, wallet *domain.Wallet, hold *domain.Hold) error {
+	release := domain.NewHoldRelease(hold, wallet.Balance)
+	if err := wallet.Credit(hold.Amount); err != nil {
+		return err
+	}
+	release.Complete(wallet.Balance)
+	if err := uow.Transactions().Create(ctx, release); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return post(ctx, uow, release)
+}
+
+func (s *HoldService) toHoldResponse(ctx context.Context, hold *domain.Hold) (*HoldResponse, error) {
+	wallet, err := s.wallets.GetByID(ctx, hold.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	return &HoldResponse{Hold: hold, Balance: wallet.Balance}, nil
+}
+
+func (s *HoldService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger, holds: s.holds})
+	}
+	return s.uow.Execute(ctx, fn)
+}
+
+func (s *HoldService) publish(ctx context.Context, eventType string, hold *domain.Hold, extra map[string]interface{}) {
+	payload := map[string]interface{}{
+		"hold_id":      hold.ID.String(),
+		"wallet_id":    hold.WalletID.String(),
+		"provider_id":  hold.ProviderID.String(),
+		"reference_id": hold.ReferenceID,
+		"amount":       hold.Amount.String(),
+	}
+	for k, v := range extra {
+		payload[k] = v
+	}
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
@@ -0,0 +1,260 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// InvoiceConfig controls the monthly invoice run
+type InvoiceConfig struct {
+	Tax       domain.InvoiceTax
+	Location  *time.Location // Months start at midnight here
+	BatchSize int            // Business accounts loaded per page
+}
+
+// InvoiceService bills business accounts once a month. Each run invoices the
+// previous month for every account not yet invoiced for it, so runs can be
+// repeated, or made from several instances, without billing anyone twice.
+type InvoiceService struct {
+	accounts     ports.BusinessAccountRepository
+	invoices     ports.InvoiceRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	renderer     ports.InvoiceRenderer
+	events       ports.EventPublisher
+	logger       ports.Logger
+	cfg          InvoiceConfig
+}
+
+func NewInvoiceService(
+	accounts ports.BusinessAccountRepository,
+	invoices ports.InvoiceRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	renderer ports.InvoiceRenderer,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg InvoiceConfig,
+) *InvoiceService {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &InvoiceService{
+		accounts:     accounts,
+		invoices:     invoices,
+		wallets:      wallets,
+		transactions: transactions,
+		renderer:     renderer,
+		events:       events,
+		logger:       logger,
+		cfg:          cfg,
+	}
+}
+
+// Request/Response DTOs
+
+type BusinessAccountRequest struct {
+	CompanyName        string `json:"company_name"`
+	RegistrationNumber string `json:"registration_number"`
+	TaxID              string `json:"tax_id"`
+	BillingAddress     string `json:"billing_address"`
+}
+
+type InvoiceListResponse struct {
+	Invoices []*domain.Invoice `json:"invoices"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// InvoiceRunResult summarises one monthly invoice run
+type InvoiceRunResult struct {
+	PeriodStart time.Time `json:"period_start"`
+	Issued      int       `json:"issued"`
+	Skipped     int       `json:"skipped"`
+	Failed      int       `json:"failed"`
+}
+
+func (s *InvoiceService) GetBusinessAccount(ctx context.Context, userID uuid.UUID) (*domain.BusinessAccount, error) {
+	return s.accounts.GetByUserID(ctx, userID)
+}
+
+// SetBusinessAccount turns on monthly invoicing for a user, or updates the
+// billing details printed on their next invoice
+func (s *InvoiceService) SetBusinessAccount(ctx context.Context, userID uuid.UUID, req BusinessAccountRequest) (*domain.BusinessAccount, error) {
+	if _, err := s.wallets.GetByUserID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	account, err := domain.NewBusinessAccount(userID, req.CompanyName, req.RegistrationNumber, req.TaxID, req.BillingAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accounts.Save(ctx, account); err != nil {
+		return nil, fmt.Errorf("failed to save business account: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("business account updated", ports.String("user_id", userID.String()))
+	return account, nil
+}
+
+// DeleteBusinessAccount stops monthly invoicing. Issued invoices are kept.
+func (s *InvoiceService) DeleteBusinessAccount(ctx context.Context, userID uuid.UUID) error {
+	if err := s.accounts.Delete(ctx, userID); err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("business account removed", ports.String("user_id", userID.String()))
+	return nil
+}
+
+// IssueMonthly invoices the month before now for every business account. An
+// account that cannot be invoiced is logged and counted as failed so one bad
+// account does not stop the run.
+func (s *InvoiceService) IssueMonthly(ctx context.Context, now time.Time) (*InvoiceRunResult, error) {
+	start, end := domain.InvoicePeriod(now, s.cfg.Location)
+	result := &InvoiceRunResult{PeriodStart: start}
+
+	after := uuid.Nil
+	for {
+		accounts, err := s.accounts.List(ctx, after, s.cfg.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list business accounts: %w", err)
+		}
+		for _, account := range accounts {
+			issued, err := s.issue(ctx, account, start, end)
+			switch {
+			case err != nil:
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				result.Failed++
+				s.logger.WithContext(ctx).Error("failed to issue invoice",
+					ports.String("user_id", account.UserID.String()),
+					ports.Err(err),
+				)
+			case issued:
+				result.Issued++
+			default:
+				result.Skipped++
+			}
+		}
+		if len(accounts) < s.cfg.BatchSize {
+			break
+		}
+		after = accounts[len(accounts)-1].UserID
+	}
+
+	if result.Issued > 0 || result.Failed > 0 {
+		s.logger.WithContext(ctx).Info("invoice run finished",
+			ports.String("period_start", start.Format("2006-01")),
+			ports.Any("issued", result.Issued),
+			ports.Any("skipped", result.Skipped),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+// issue invoices one account for [start, end). It reports false when the
+// account was already invoiced or had nothing to bill.
+func (s *InvoiceService) issue(ctx context.Context, account *domain.BusinessAccount, start, end time.Time) (bool, error) {
+	exists, err := s.invoices.ExistsForPeriod(ctx, account.UserID, start)
+	if err != nil {
+		return false, fmt.Errorf("failed to check for an invoice: %w", err)
+	}
+	if exists {
+		return false, nil
+	}
+
+	wallet, err := s.wallets.GetByUserID(ctx, account.UserID)
+	if err != nil {
+		return false, err
+	}
+	payments, err := s.transactions.GetByWalletTypeBetween(ctx, wallet.ID, domain.TransactionTypePayment, start, end)
+	if err != nil {
+		return false, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	invoice, err := domain.NewInvoice(account, wallet, start, end, payments, s.cfg.Tax)
+	if errors.Is(err, domain.ErrNothingToInvoice) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	invoice.Document, invoice.ContentType, err = s.renderer.Render(ctx, invoice)
+	if err != nil {
+		return false, err
+	}
+
+	// Another instance may have issued it since the check above
+	if err := s.invoices.Create(ctx, invoice); err != nil {
+		if errors.Is(err, domain.ErrInvoiceAlreadyIssued) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save invoice: %w", err)
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventInvoiceIssued,
+			Payload: map[string]interface{}{
+				"invoice_id":     invoice.ID.String(),
+				"invoice_number": invoice.Number,
+				"user_id":        invoice.UserID.String(),
+				"company_name":   invoice.CompanyName,
+				"period":         invoice.PeriodStart.Format("January 2006"),
+				"total":          invoice.Total.StringFixed(2),
+				"tax_total":      invoice.TaxTotal.StringFixed(2),
+				"currency":       invoice.Currency,
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+	return true, nil
+}
+
+// ListInvoices returns a user's invoices, newest first
+func (s *InvoiceService) ListInvoices(ctx context.Context, userID uuid.UUID, limit, offset int) (*InvoiceListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	invoices, err := s.invoices.ListByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invoices: %w", err)
+	}
+	if invoices == nil {
+		invoices = []*domain.Invoice{}
+	}
+
+	return &InvoiceListResponse{
+		Invoices: invoices,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+// GetInvoice returns one of a user's invoices with its rendered document.
+// Other users' invoices are reported as not found.
+func (s *InvoiceService) GetInvoice(ctx context.Context, userID, invoiceID uuid.UUID) (*domain.Invoice, error) {
+	invoice, err := s.invoices.GetByID(ctx, invoiceID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.UserID != userID {
+		return nil, domain.ErrInvoiceNotFound
+	}
+	return invoice, nil
+}
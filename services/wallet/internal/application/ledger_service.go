@@ -0,0 +1,313 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationConfig controls the scheduled ledger reconciliation
+type ReconciliationConfig struct {
+	BatchSize  int  // Wallets checked per page
+	AutoRepair bool // Reset drifted wallet balances to the ledger instead of only alerting
+}
+
+// LedgerService keeps the stored wallet balances honest. The ledger is the
+// source of truth: reconciliation derives each wallet's balance from its
+// entries, snapshots it, and flags any wallet whose stored balance drifted.
+type LedgerService struct {
+	wallets       ports.WalletRepository
+	ledger        ports.LedgerRepository
+	discrepancies ports.DiscrepancyRepository
+	uow           ports.UnitOfWork
+	events        ports.EventPublisher
+	logger        ports.Logger
+	cfg           ReconciliationConfig
+}
+
+func NewLedgerService(
+	wallets ports.WalletRepository,
+	ledger ports.LedgerRepository,
+	discrepancies ports.DiscrepancyRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	cfg ReconciliationConfig,
+) *LedgerService {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &LedgerService{
+		wallets:       wallets,
+		ledger:        ledger,
+		discrepancies: discrepancies,
+		uow:           uow,
+		events:        events,
+		logger:        logger,
+		cfg:           cfg,
+	}
+}
+
+// ReconciliationResult summarises one reconciliation run
+type ReconciliationResult struct {
+	WalletsChecked int `json:"wallets_checked"`
+	Discrepancies  int `json:"discrepancies"`
+	Repaired       int `json:"repaired"`
+	Failed         int `json:"failed"`
+}
+
+type DiscrepancyListResponse struct {
+	Discrepancies []*domain.LedgerDiscrepancy `json:"discrepancies"`
+	Limit         int                         `json:"limit"`
+	Offset        int                         `json:"offset"`
+}
+
+type LedgerEntryListResponse struct {
+	Entries []domain.LedgerEntry `json:"entries"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// ReconcileAll checks every wallet. A wallet that cannot be checked is logged
+// and counted as failed so one bad row does not stop the run.
+func (s *LedgerService) ReconcileAll(ctx context.Context) (*ReconciliationResult, error) {
+	result := &ReconciliationResult{}
+	after := uuid.Nil
+	for {
+		ids, err := s.wallets.ListIDs(ctx, after, s.cfg.BatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wallets: %w", err)
+		}
+		for _, id := range ids {
+			result.WalletsChecked++
+			d, repaired, err := s.reconcile(ctx, id)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				result.Failed++
+				s.logger.WithContext(ctx).Error("failed to reconcile wallet",
+					ports.String("wallet_id", id.String()),
+					ports.Err(err),
+				)
+				continue
+			}
+			if d != nil {
+				result.Discrepancies++
+			}
+			if repaired {
+				result.Repaired++
+			}
+		}
+		if len(ids) < s.cfg.BatchSize {
+			break
+		}
+		after = ids[len(ids)-1]
+	}
+
+	s.logger.WithContext(ctx).Info("ledger reconciliation finished",
+		ports.Any("wallets_checked", result.WalletsChecked),
+		ports.Any("discrepancies", result.Discrepancies),
+		ports.Any("repaired", result.Repaired),
+		ports.Any("failed", result.Failed),
+	)
+	return result, nil
+}
+
+// reconcile compares one wallet with its ledger under the wallet's row lock,
+// so no posting can land between reading the two balances. It returns the
+// discrepancy found, if any, and whether it was repaired.
+func (s *LedgerService) reconcile(ctx context.Context, walletID uuid.UUID) (*domain.LedgerDiscrepancy, bool, error) {
+	var found *domain.LedgerDiscrepancy
+	var repaired bool
+	err := s.atomically(ctx, func(uow ports.Transaction) error {
+		found, repaired = nil, false
+
+		wallet, err := uow.Wallets().GetByIDForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		ledgerBalance, err := uow.Ledger().Balance(ctx, walletID)
+		if err != nil {
+			return fmt.Errorf("failed to derive ledger balance: %w", err)
+		}
+
+		if wallet.Balance.Equal(ledgerBalance) {
+			if err := s.resolveOpen(ctx, walletID, domain.ResolutionSelfHealed); err != nil {
+				return err
+			}
+		} else {
+			found = domain.NewLedgerDiscrepancy(walletID, wallet.Balance, ledgerBalance)
+			if err := s.discrepancies.Record(ctx, found); err != nil {
+				return fmt.Errorf("failed to record discrepancy: %w", err)
+			}
+			if s.cfg.AutoRepair {
+				if err := s.rebase(ctx, uow, wallet, ledgerBalance); err != nil {
+					return err
+				}
+				found.Resolve(domain.ResolutionBalanceReset)
+				if err := s.discrepancies.Resolve(ctx, found); err != nil {
+					return fmt.Errorf("failed to resolve discrepancy: %w", err)
+				}
+				repaired = true
+			}
+		}
+
+		if _, err := uow.Ledger().Snapshot(ctx, walletID); err != nil {
+			return fmt.Errorf("failed to snapshot ledger balance: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if found != nil {
+		s.logger.WithContext(ctx).Error("wallet balance drifted from ledger",
+			ports.String("wallet_id", walletID.String()),
+			ports.String("wallet_balance", found.WalletBalance.String()),
+			ports.String("ledger_balance", found.LedgerBalance.String()),
+			ports.Any("repaired", repaired),
+		)
+		s.publish(ctx, ports.EventLedgerDiscrepancyDetected, found)
+		if repaired {
+			s.publish(ctx, ports.EventLedgerRepaired, found)
+		}
+	}
+	return found, repaired, nil
+}
+
+// Repair resets a wallet's stored balance to the one derived from its ledger
+// and closes its open discrepancy
+func (s *LedgerService) Repair(ctx context.Context, walletID uuid.UUID) (*domain.LedgerDiscrepancy, error) {
+	var repaired *domain.LedgerDiscrepancy
+	err := s.atomically(ctx, func(uow ports.Transaction) error {
+		wallet, err := uow.Wallets().GetByIDForUpdate(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		d, err := s.discrepancies.GetOpenByWalletID(ctx, walletID)
+		if err != nil {
+			return err
+		}
+		ledgerBalance, err := uow.Ledger().Balance(ctx, walletID)
+		if err != nil {
+			return fmt.Errorf("failed to derive ledger balance: %w", err)
+		}
+
+		if err := s.rebase(ctx, uow, wallet, ledgerBalance); err != nil {
+			return err
+		}
+		d.Resolve(domain.ResolutionBalanceReset)
+		if err := s.discrepancies.Resolve(ctx, d); err != nil {
+			return fmt.Errorf("failed to resolve discrepancy: %w", err)
+		}
+		repaired = d
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Warn("wallet balance reset to ledger",
+		ports.String("wallet_id", walletID.String()),
+		ports.String("drift", repaired.Drift.String()),
+	)
+	s.publish(ctx, ports.EventLedgerRepaired, repaired)
+	return repaired, nil
+}
+
+func (s *LedgerService) ListDiscrepancies(ctx context.Context, limit, offset int) (*DiscrepancyListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	discrepancies, err := s.discrepancies.ListOpen(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list discrepancies: %w", err)
+	}
+
+	return &DiscrepancyListResponse{
+		Discrepancies: discrepancies,
+		Limit:         limit,
+		Offset:        offset,
+	}, nil
+}
+
+func (s *LedgerService) GetEntries(ctx context.Context, walletID uuid.UUID, limit, offset int) (*LedgerEntryListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if _, err := s.wallets.GetByID(ctx, walletID); err != nil {
+		return nil, err
+	}
+	entries, err := s.ledger.GetEntries(ctx, walletID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+
+	return &LedgerEntryListResponse{
+		Entries: entries,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+func (s *LedgerService) rebase(ctx context.Context, uow ports.Transaction, wallet *domain.Wallet, ledgerBalance decimal.Decimal) error {
+	wallet.RebaseBalance(ledgerBalance)
+	if err := uow.Wallets().Update(ctx, wallet); err != nil {
+		return fmt.Errorf("failed to reset wallet balance: %w", err)
+	}
+	return nil
+}
+
+// resolveOpen closes a wallet's open discrepancy, if it has one
+func (s *LedgerService) resolveOpen(ctx context.Context, walletID uuid.UUID, resolution string) error {
+	d, err := s.discrepancies.GetOpenByWalletID(ctx, walletID)
+	if errors.Is(err, domain.ErrDiscrepancyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get open discrepancy: %w", err)
+	}
+	d.Resolve(resolution)
+	if err := s.discrepancies.Resolve(ctx, d); err != nil {
+		return fmt.Errorf("failed to resolve discrepancy: %w", err)
+	}
+	return nil
+}
+
+func (s *LedgerService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, ledger: s.ledger})
+	}
+	return s.uow.Execute(ctx, fn)
+}
+
+func (s *LedgerService) publish(ctx context.Context, eventType string, d *domain.LedgerDiscrepancy) {
+	go func() {
+		event := ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"discrepancy_id": d.ID.String(),
+				"wallet_id":      d.WalletID.String(),
+				"wallet_balance": d.WalletBalance.String(),
+				"ledger_balance": d.LedgerBalance.String(),
+				"drift":          d.Drift.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
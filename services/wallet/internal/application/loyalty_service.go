@@ -0,0 +1,385 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// expiryBatchSize caps the lots one expiry run closes; the next run carries on
+const expiryBatchSize = 500
+
+// LoyaltyService runs the points programme. Payments earn points, which
+// users redeem for wallet credit booked against the loyalty account. Points
+// expire a fixed time after they are earned, and a refunded or reversed
+// payment takes back whatever is left of the points it earned.
+type LoyaltyService struct {
+	loyalty      ports.LoyaltyRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	uow          ports.UnitOfWork
+	events       ports.EventPublisher
+	logger       ports.Logger
+	policy       domain.LoyaltyPolicy
+}
+
+func NewLoyaltyService(
+	loyalty ports.LoyaltyRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	policy domain.LoyaltyPolicy,
+) *LoyaltyService {
+	return &LoyaltyService{
+		loyalty:      loyalty,
+		wallets:      wallets,
+		transactions: transactions,
+		ledger:       ledger,
+		uow:          uow,
+		events:       events,
+		logger:       logger,
+		policy:       policy,
+	}
+}
+
+type LoyaltyBalanceResponse struct {
+	Points           int64           `json:"points"`
+	Value            decimal.Decimal `json:"value"`
+	PointsPerRinggit decimal.Decimal `json:"points_per_ringgit"`
+	PointValue       decimal.Decimal `json:"point_value"`
+	MinRedemption    int64           `json:"min_redemption"`
+}
+
+type LoyaltyHistoryResponse struct {
+	Entries []*domain.LoyaltyEntry `json:"entries"`
+	Limit   int                    `json:"limit"`
+	Offset  int                    `json:"offset"`
+}
+
+type RedeemPointsRequest struct {
+	Points int64 `json:"points"`
+}
+
+type RedeemPointsResponse struct {
+	Points      int64                `json:"points"`
+	Credit      decimal.Decimal      `json:"credit"`
+	Remaining   int64                `json:"remaining"`
+	Balance     decimal.Decimal      `json:"balance"`
+	Transaction *TransactionResponse `json:"transaction"`
+}
+
+func (s *LoyaltyService) GetBalance(ctx context.Context, userID uuid.UUID) (*LoyaltyBalanceResponse, error) {
+	points, err := s.loyalty.Balance(ctx, userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get points balance: %w", err)
+	}
+	return &LoyaltyBalanceResponse{
+		Points:           points,
+		Value:            s.policy.CreditFor(points),
+		PointsPerRinggit: s.policy.PointsPerRinggit,
+		PointValue:       s.policy.PointValue,
+		MinRedemption:    s.policy.MinRedemption,
+	}, nil
+}
+
+func (s *LoyaltyService) GetHistory(ctx context.Context, userID uuid.UUID, limit, offset int) (*LoyaltyHistoryResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	entries, err := s.loyalty.ListByUserID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list points history: %w", err)
+	}
+	return &LoyaltyHistoryResponse{Entries: entries, Limit: limit, Offset: offset}, nil
+}
+
+// Earn records the points a completed payment earns for userID. Earning is
+// keyed by the payment, so calling it again for the same payment is a no-op.
+func (s *LoyaltyService) Earn(ctx context.Context, userID uuid.UUID, payment *domain.Transaction) error {
+	points := s.policy.PointsFor(payment.Amount)
+	if points == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	lot := domain.NewLoyaltyEarn(userID, payment.ID, points, s.policy.Expiry, now)
+	if err := s.loyalty.Create(ctx, lot); err != nil {
+		if errors.Is(err, domain.ErrLoyaltyEntryExists) {
+			return nil
+		}
+		return fmt.Errorf("failed to record points: %w", err)
+	}
+
+	balance, err := s.loyalty.Balance(ctx, userID, now)
+	if err != nil {
+		return fmt.Errorf("failed to get points balance: %w", err)
+	}
+	s.publish(ctx, ports.EventLoyaltyEarned, map[string]interface{}{
+		"user_id":        userID.String(),
+		"wallet_id":      payment.WalletID.String(),
+		"transaction_id": payment.ID.String(),
+		"amount":         payment.Amount.StringFixed(2),
+		"points":         points,
+		"balance":        balance,
+		"expires_at":     lot.ExpiresAt.Format(time.RFC3339),
+	})
+	return nil
+}
+
+// Redeem turns points into wallet credit. The user's lots are locked before
+// the wallet, so concurrent redemptions spend them one at a time, and the
+// credit, its ledger posting and the spend commit together.
+func (s *LoyaltyService) Redeem(ctx context.Context, userID uuid.UUID, req RedeemPointsRequest) (*RedeemPointsResponse, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !wallet.CanTransact() {
+		return nil, domain.ErrWalletInactive
+	}
+
+	now := time.Now()
+	credit := s.policy.CreditFor(req.Points)
+	var tx *domain.Transaction
+	var remaining int64
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		lots, err := uow.Loyalty().OpenLotsForUpdate(ctx, userID, now)
+		if err != nil {
+			return fmt.Errorf("failed to load points: %w", err)
+		}
+		var balance int64
+		for _, lot := range lots {
+			balance += lot.Remaining
+		}
+		if err := s.policy.CheckRedemption(req.Points, balance); err != nil {
+			return err
+		}
+		changed, err := domain.SpendLots(lots, req.Points, now)
+		if err != nil {
+			return err
+		}
+		for _, lot := range changed {
+			if err := uow.Loyalty().UpdateRemaining(ctx, lot); err != nil {
+				return fmt.Errorf("failed to update points: %w", err)
+			}
+		}
+		remaining = balance - req.Points
+
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+		balanceBefore := locked.Balance
+		if err := locked.Credit(credit); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+
+		tx = domain.NewLoyaltyCredit(locked.ID, req.Points, credit, balanceBefore)
+		if err := uow.Transactions().Create(ctx, tx); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		if err := post(ctx, uow, tx); err != nil {
+			return err
+		}
+		return uow.Loyalty().Create(ctx, domain.NewLoyaltyRedeem(userID, tx.ID, req.Points, now))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("loyalty points redeemed",
+		ports.String("user_id", userID.String()),
+		ports.String("transaction_id", tx.ID.String()),
+		ports.String("credit", credit.String()),
+	)
+	s.publish(ctx, ports.EventLoyaltyRedeemed, map[string]interface{}{
+		"user_id":        userID.String(),
+		"wallet_id":      wallet.ID.String(),
+		"transaction_id": tx.ID.String(),
+		"points":         req.Points,
+		"credit":         credit.StringFixed(2),
+		"currency":       wallet.Currency,
+		"balance":        remaining,
+	})
+
+	return &RedeemPointsResponse{
+		Points:      req.Points,
+		Credit:      credit,
+		Remaining:   remaining,
+		Balance:     tx.BalanceAfter,
+		Transaction: toTransactionResponse(tx),
+	}, nil
+}
+
+// Forfeit takes back what is left of the points a payment earned, once the
+// payment has been refunded or reversed
+func (s *LoyaltyService) Forfeit(ctx context.Context, paymentID uuid.UUID) error {
+	var entry *domain.LoyaltyEntry
+	err := s.atomically(ctx, func(uow ports.Transaction) error {
+		lot, err := uow.Loyalty().GetLotForUpdate(ctx, paymentID)
+		if err != nil {
+			if errors.Is(err, domain.ErrLoyaltyLotNotFound) {
+				return nil
+			}
+			return err
+		}
+		entry = lot.Forfeit(time.Now())
+		if entry == nil {
+			return nil
+		}
+		if err := uow.Loyalty().UpdateRemaining(ctx, lot); err != nil {
+			return fmt.Errorf("failed to update points: %w", err)
+		}
+		return uow.Loyalty().Create(ctx, entry)
+	})
+	if err != nil {
+		return err
+	}
+
+	if entry != nil {
+		s.logger.WithContext(ctx).Info("loyalty points forfeited",
+			ports.String("user_id", entry.UserID.String()),
+			ports.String("transaction_id", paymentID.String()),
+		)
+	}
+	return nil
+}
+
+// ExpirePoints closes lots that expired with points left and tells each
+// user how many of their points expired. It returns the lots closed.
+func (s *LoyaltyService) ExpirePoints(ctx context.Context, now time.Time) (int, error) {
+	lots, err := s.loyalty.ListExpired(ctx, now, expiryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired points: %w", err)
+	}
+
+	expired := make(map[uuid.UUID]int64)
+	closed := 0
+	for _, lot := range lots {
+		var entry *domain.LoyaltyEntry
+		err := s.atomically(ctx, func(uow ports.Transaction) error {
+			// Re-read under the lock: a refund may have forfeited it since
+			locked, err := uow.Loyalty().GetLotForUpdate(ctx, *lot.TransactionID)
+			if err != nil {
+				return err
+			}
+			entry = locked.Expire(now)
+			if entry == nil {
+				return nil
+			}
+			if err := uow.Loyalty().UpdateRemaining(ctx, locked); err != nil {
+				return fmt.Errorf("failed to update points: %w", err)
+			}
+			return uow.Loyalty().Create(ctx, entry)
+		})
+		if err != nil {
+			s.logger.WithContext(ctx).Error("failed to expire points",
+				ports.String("entry_id", lot.ID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		if entry != nil {
+			expired[entry.UserID] -= entry.Points
+			closed++
+		}
+	}
+
+	for userID, points := range expired {
+		balance, err := s.loyalty.Balance(ctx, userID, now)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("failed to get points balance",
+				ports.String("user_id", userID.String()),
+				ports.Err(err),
+			)
+			continue
+		}
+		s.publish(ctx, ports.EventLoyaltyExpired, map[string]interface{}{
+			"user_id": userID.String(),
+			"points":  points,
+			"balance": balance,
+		})
+	}
+	if closed > 0 {
+		s.logger.WithContext(ctx).Info("loyalty points expired", ports.Any("lots", closed))
+	}
+	return closed, nil
+}
+
+// SetLoyalty earns points on payments and takes them back on refunds
+func (s *WalletService) SetLoyalty(loyalty *LoyaltyService) {
+	s.loyalty = loyalty
+}
+
+// SetLoyalty earns points on the payments holds are captured as
+func (s *HoldService) SetLoyalty(loyalty *LoyaltyService) {
+	s.loyalty = loyalty
+}
+
+// SetLoyalty takes back the points of reversed payments
+func (s *WalletAdminService) SetLoyalty(loyalty *LoyaltyService) {
+	s.loyalty = loyalty
+}
+
+// earnPoints awards a payment's points off the request path. A failure only
+// costs the user the points, so it is logged rather than returned.
+func earnPoints(ctx context.Context, loyalty *LoyaltyService, logger ports.Logger, userID uuid.UUID, payment *domain.Transaction) {
+	if loyalty == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := loyalty.Earn(ctx, userID, payment); err != nil {
+			logger.WithContext(ctx).Warn("failed to award loyalty points",
+				ports.String("transaction_id", payment.ID.String()),
+				ports.Err(err),
+			)
+		}
+	}()
+}
+
+// forfeitPoints takes back a returned payment's points off the request path
+func forfeitPoints(ctx context.Context, loyalty *LoyaltyService, logger ports.Logger, paymentID uuid.UUID) {
+	if loyalty == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := loyalty.Forfeit(ctx, paymentID); err != nil {
+			logger.WithContext(ctx).Warn("failed to forfeit loyalty points",
+				ports.String("transaction_id", paymentID.String()),
+				ports.Err(err),
+			)
+		}
+	}()
+}
+
+func (s *LoyaltyService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger, loyalty: s.loyalty})
+	}
+	return s.uow.Execute(ctx, fn)
+}
+
+func (s *LoyaltyService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
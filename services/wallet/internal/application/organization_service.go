@@ -0,0 +1,276 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// orgEvents are the auth events that create organizations and change who
+// may pay from their wallets
+var orgEvents = []string{
+	"org.created",
+	"org.member.joined",
+	"org.member.removed",
+}
+
+// OrganizationService keeps the local copy of organizations current from
+// auth events, opens their wallets, and lets owners set member limits and
+// see what members spent
+type OrganizationService struct {
+	orgs         ports.OrganizationRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	logger       ports.Logger
+}
+
+func NewOrganizationService(
+	orgs ports.OrganizationRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	logger ports.Logger,
+) *OrganizationService {
+	return &OrganizationService{
+		orgs:         orgs,
+		wallets:      wallets,
+		transactions: transactions,
+		logger:       logger,
+	}
+}
+
+// Request/Response DTOs
+
+type MemberLimitRequest struct {
+	MonthlyLimit *decimal.Decimal `json:"monthly_limit"`
+}
+
+type OrganizationResponse struct {
+	*domain.Organization
+	Balance  decimal.Decimal `json:"balance"`
+	Currency string          `json:"currency"`
+}
+
+type OrgMemberResponse struct {
+	*domain.OrgMember
+	SpentThisMonth decimal.Decimal `json:"spent_this_month"`
+}
+
+type OrganizationDetailResponse struct {
+	OrganizationResponse
+	Members []*OrgMemberResponse `json:"members"`
+}
+
+// EventTypes lists the events the service consumes
+func (s *OrganizationService) EventTypes() []string {
+	return orgEvents
+}
+
+// Handle applies an organization event. Events may be redelivered, so each
+// is applied idempotently. Malformed events are logged and skipped; storage
+// errors fail the event.
+func (s *OrganizationService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	id := func(key string) (uuid.UUID, bool) {
+		v, _ := payload[key].(string)
+		parsed, err := uuid.Parse(v)
+		return parsed, err == nil
+	}
+	orgID, ok := id("org_id")
+	if !ok {
+		s.logger.WithContext(ctx).Warn("organization event has no org_id", ports.String("event_type", eventType))
+		return nil
+	}
+
+	switch eventType {
+	case "org.created":
+		ownerID, ok := id("owner_id")
+		if !ok {
+			s.logger.WithContext(ctx).Warn("org.created has no owner_id", ports.String("org_id", orgID.String()))
+			return nil
+		}
+		name, _ := payload["name"].(string)
+		return s.create(ctx, orgID, ownerID, name)
+	case "org.member.joined", "org.member.removed":
+		userID, ok := id("user_id")
+		if !ok {
+			s.logger.WithContext(ctx).Warn("organization event has no user_id", ports.String("event_type", eventType))
+			return nil
+		}
+		if eventType == "org.member.removed" {
+			if err := s.orgs.DeleteMember(ctx, orgID, userID); err != nil {
+				return fmt.Errorf("failed to remove organization member: %w", err)
+			}
+			return nil
+		}
+		role, _ := payload["role"].(string)
+		return s.saveMember(ctx, orgID, userID, role)
+	}
+	return nil
+}
+
+// create opens the organization's wallet and stores the organization with
+// its owner as the first member
+func (s *OrganizationService) create(ctx context.Context, orgID, ownerID uuid.UUID, name string) error {
+	wallet, err := s.wallets.GetByUserID(ctx, orgID)
+	if errors.Is(err, domain.ErrWalletNotFound) {
+		wallet = domain.NewWallet(orgID, "MYR")
+		err = s.wallets.Create(ctx, wallet)
+		if errors.Is(err, domain.ErrWalletAlreadyExists) {
+			wallet, err = s.wallets.GetByUserID(ctx, orgID)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open organization wallet: %w", err)
+	}
+
+	org := &domain.Organization{
+		ID:        orgID,
+		Name:      name,
+		OwnerID:   ownerID,
+		WalletID:  wallet.ID,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := s.orgs.Create(ctx, org); err != nil {
+		return fmt.Errorf("failed to store organization: %w", err)
+	}
+	if err := s.saveMember(ctx, orgID, ownerID, "owner"); err != nil {
+		return err
+	}
+
+	s.logger.WithContext(ctx).Info("organization wallet opened",
+		ports.String("org_id", orgID.String()),
+		ports.String("wallet_id", wallet.ID.String()),
+	)
+	return nil
+}
+
+func (s *OrganizationService) saveMember(ctx context.Context, orgID, userID uuid.UUID, role string) error {
+	if role == "" {
+		role = "member"
+	}
+	member := &domain.OrgMember{OrgID: orgID, UserID: userID, Role: role, UpdatedAt: time.Now().UTC()}
+	if err := s.orgs.SaveMember(ctx, member); err != nil {
+		return fmt.Errorf("failed to store organization member: %w", err)
+	}
+	return nil
+}
+
+// ListOrganizations returns the organizations the user owns with their
+// wallet balances
+func (s *OrganizationService) ListOrganizations(ctx context.Context, ownerID uuid.UUID) ([]*OrganizationResponse, error) {
+	orgs, err := s.orgs.ListByOwnerID(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	resp := make([]*OrganizationResponse, 0, len(orgs))
+	for _, org := range orgs {
+		wallet, err := s.wallets.GetByID(ctx, org.WalletID)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, toOrganizationResponse(org, wallet))
+	}
+	return resp, nil
+}
+
+// GetOrganization returns an owned organization with its members and what
+// each spent this month
+func (s *OrganizationService) GetOrganization(ctx context.Context, ownerID, orgID uuid.UUID) (*OrganizationDetailResponse, error) {
+	org, wallet, err := s.owned(ctx, ownerID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgs.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	_, monthStart := domain.SpendingPeriods(time.Now())
+	resp := &OrganizationDetailResponse{
+		OrganizationResponse: *toOrganizationResponse(org, wallet),
+		Members:              make([]*OrgMemberResponse, 0, len(members)),
+	}
+	for _, m := range members {
+		spent, err := s.transactions.SumMemberCompletedSince(ctx, wallet.ID, m.UserID, monthStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sum member payments: %w", err)
+		}
+		resp.Members = append(resp.Members, &OrgMemberResponse{OrgMember: m, SpentThisMonth: spent})
+	}
+	return resp, nil
+}
+
+// SetMemberLimit caps what a member may charge to the organization wallet
+// each month. A nil limit removes the cap.
+func (s *OrganizationService) SetMemberLimit(ctx context.Context, ownerID, orgID, userID uuid.UUID, req MemberLimitRequest) (*domain.OrgMember, error) {
+	if _, _, err := s.owned(ctx, ownerID, orgID); err != nil {
+		return nil, err
+	}
+	member, err := s.orgs.GetMember(ctx, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := member.SetMonthlyLimit(req.MonthlyLimit); err != nil {
+		return nil, err
+	}
+	if err := s.orgs.UpdateMemberLimit(ctx, member); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("organization member limit updated",
+		ports.String("org_id", orgID.String()),
+		ports.String("user_id", userID.String()),
+	)
+	return member, nil
+}
+
+// Report totals the organization's payments in [from, to) per member
+func (s *OrganizationService) Report(ctx context.Context, ownerID, orgID uuid.UUID, from, to time.Time) (*domain.OrgReport, error) {
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+	org, wallet, err := s.owned(ctx, ownerID, orgID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.orgs.ListMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+	payments, err := s.transactions.GetByWalletTypeBetween(ctx, wallet.ID, domain.TransactionTypePayment, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+	return domain.NewOrgReport(org, wallet, members, payments, from, to), nil
+}
+
+// owned returns the organization and its wallet. Organizations the user
+// doesn't own are reported as not found.
+func (s *OrganizationService) owned(ctx context.Context, ownerID, orgID uuid.UUID) (*domain.Organization, *domain.Wallet, error) {
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if org.OwnerID != ownerID {
+		return nil, nil, domain.ErrOrganizationNotFound
+	}
+	wallet, err := s.wallets.GetByID(ctx, org.WalletID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, wallet, nil
+}
+
+func toOrganizationResponse(org *domain.Organization, wallet *domain.Wallet) *OrganizationResponse {
+	return &OrganizationResponse{
+		Organization: org,
+		Balance:      wallet.Balance,
+		Currency:     wallet.Currency,
+	}
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"context"
+
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// SetPINVerifier has users enter their transaction PIN for payments they
+// make in the app
+func (s *WalletService) SetPINVerifier(pins ports.PINVerifier) {
+	s.pins = pins
+}
+
+// checkPIN has auth check the PIN before the wallet is debited. Wrong PINs
+// count towards auth's lockout, so this runs once per payment.
+func (s *WalletService) checkPIN(ctx context.Context, wallet *domain.Wallet, pin string) error {
+	if s.pins == nil {
+		return nil
+	}
+	if pin == "" {
+		return domain.ErrPINRequired
+	}
+	if err := s.pins.VerifyPIN(ctx, wallet.UserID, pin); err != nil {
+		s.logger.WithContext(ctx).Warn("payment PIN rejected",
+			ports.String("wallet_id", wallet.ID.String()),
+			ports.Err(err))
+		return err
+	}
+	return nil
+}
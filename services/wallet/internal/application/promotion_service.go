@@ -0,0 +1,315 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// PromotionService runs promo code campaigns. Admins define the codes; users
+// redeem them for wallet credit, booked against the promotions account.
+type PromotionService struct {
+	promotions   ports.PromotionRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	uow          ports.UnitOfWork
+	events       ports.EventPublisher
+	logger       ports.Logger
+}
+
+func NewPromotionService(
+	promotions ports.PromotionRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	logger ports.Logger,
+) *PromotionService {
+	return &PromotionService{
+		promotions:   promotions,
+		wallets:      wallets,
+		transactions: transactions,
+		ledger:       ledger,
+		uow:          uow,
+		events:       events,
+		logger:       logger,
+	}
+}
+
+type CreatePromotionRequest struct {
+	Code           string           `json:"code"`
+	Description    string           `json:"description"`
+	BonusType      domain.BonusType `json:"bonus_type"`
+	BonusValue     decimal.Decimal  `json:"bonus_value"`
+	MaxBonus       *decimal.Decimal `json:"max_bonus,omitempty"`
+	MinTopUp       decimal.Decimal  `json:"min_top_up"`
+	MaxRedemptions *int             `json:"max_redemptions,omitempty"`
+	MaxPerWallet   int              `json:"max_per_wallet"`
+	StartsAt       time.Time        `json:"starts_at"`
+	ExpiresAt      *time.Time       `json:"expires_at,omitempty"`
+}
+
+// RedeemRequest redeems a promo code. Percentage promotions name the
+// completed top-up the bonus is paid on.
+type RedeemRequest struct {
+	Code               string     `json:"code"`
+	TopUpTransactionID *uuid.UUID `json:"top_up_transaction_id,omitempty"`
+}
+
+type RedemptionResponse struct {
+	Code        string               `json:"code"`
+	Bonus       decimal.Decimal      `json:"bonus"`
+	Balance     decimal.Decimal      `json:"balance"`
+	Transaction *TransactionResponse `json:"transaction"`
+}
+
+type PromotionListResponse struct {
+	Promotions []*domain.Promotion `json:"promotions"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+}
+
+func (s *PromotionService) CreatePromotion(ctx context.Context, req CreatePromotionRequest) (*domain.Promotion, error) {
+	promotion, err := domain.NewPromotion(req.Code, req.Description, req.BonusType, req.BonusValue, req.StartsAt, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	if err := promotion.SetLimits(req.MaxBonus, req.MinTopUp, req.MaxRedemptions, req.MaxPerWallet); err != nil {
+		return nil, err
+	}
+
+	if err := s.promotions.Create(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("promotion created",
+		ports.String("promotion_id", promotion.ID.String()),
+		ports.String("code", promotion.Code),
+	)
+	s.publish(ctx, ports.EventPromotionCreated, map[string]interface{}{
+		"promotion_id": promotion.ID.String(),
+		"code":         promotion.Code,
+		"bonus_type":   string(promotion.BonusType),
+		"bonus_value":  promotion.BonusValue.String(),
+	})
+	return promotion, nil
+}
+
+func (s *PromotionService) GetPromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	return s.promotions.GetByID(ctx, id)
+}
+
+func (s *PromotionService) ListPromotions(ctx context.Context, activeOnly bool, limit, offset int) (*PromotionListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	promotions, err := s.promotions.List(ctx, activeOnly, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	return &PromotionListResponse{
+		Promotions: promotions,
+		Limit:      limit,
+		Offset:     offset,
+	}, nil
+}
+
+// DeactivatePromotion stops a code being redeemed. Credits already paid stay.
+func (s *PromotionService) DeactivatePromotion(ctx context.Context, id uuid.UUID) (*domain.Promotion, error) {
+	promotion, err := s.promotions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !promotion.Active {
+		return promotion, nil
+	}
+
+	promotion.Deactivate()
+	if err := s.promotions.Update(ctx, promotion); err != nil {
+		return nil, fmt.Errorf("failed to update promotion: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("promotion deactivated", ports.String("promotion_id", id.String()))
+	s.publish(ctx, ports.EventPromotionDeactivated, map[string]interface{}{
+		"promotion_id":     promotion.ID.String(),
+		"code":             promotion.Code,
+		"redemption_count": promotion.RedemptionCount,
+	})
+	return promotion, nil
+}
+
+// Redeem credits a promo code's bonus to the user's wallet. The promotion row
+// is locked before the wallet, so the usage caps are checked and counted by
+// one redemption at a time, and the credit, its ledger posting and the usage
+// record commit together.
+func (s *PromotionService) Redeem(ctx context.Context, userID uuid.UUID, req RedeemRequest) (*RedemptionResponse, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var promotion *domain.Promotion
+	var credit *domain.Transaction
+	var redemption *domain.PromotionRedemption
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		promotion, err = uow.Promotions().GetByCodeForUpdate(ctx, domain.NormalizePromoCode(req.Code))
+		if err != nil {
+			return err
+		}
+		if err := promotion.CanRedeem(time.Now()); err != nil {
+			return err
+		}
+
+		used, err := uow.Promotions().CountRedemptions(ctx, promotion.ID, wallet.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count redemptions: %w", err)
+		}
+		if used >= promotion.MaxPerWallet {
+			return domain.ErrPromotionAlreadyRedeemed
+		}
+
+		topUpAmount := decimal.Zero
+		var topUpID *uuid.UUID
+		if promotion.NeedsTopUp() {
+			topUp, err := s.qualifyingTopUp(ctx, uow, promotion, wallet.ID, req.TopUpTransactionID)
+			if err != nil {
+				return err
+			}
+			topUpAmount, topUpID = topUp.Amount, &topUp.ID
+		}
+		bonus, err := promotion.Bonus(topUpAmount)
+		if err != nil {
+			return err
+		}
+
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+		balanceBefore := locked.Balance
+		if err := locked.Credit(bonus); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+
+		credit = domain.NewPromoCredit(locked.ID, promotion, bonus, balanceBefore)
+		if err := uow.Transactions().Create(ctx, credit); err != nil {
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+		if err := post(ctx, uow, credit); err != nil {
+			return err
+		}
+
+		redemption = &domain.PromotionRedemption{
+			ID:                 uuid.New(),
+			PromotionID:        promotion.ID,
+			WalletID:           locked.ID,
+			TransactionID:      credit.ID,
+			TopUpTransactionID: topUpID,
+			Bonus:              bonus,
+			RedeemedAt:         time.Now().UTC(),
+		}
+		if err := uow.Promotions().RecordRedemption(ctx, redemption); err != nil {
+			return err
+		}
+		promotion.Redeem()
+		if err := uow.Promotions().Update(ctx, promotion); err != nil {
+			return fmt.Errorf("failed to update promotion: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.WithContext(ctx).Info("promotion redeemed",
+		ports.String("promotion_id", promotion.ID.String()),
+		ports.String("wallet_id", wallet.ID.String()),
+		ports.String("bonus", redemption.Bonus.String()),
+	)
+	payload := map[string]interface{}{
+		"promotion_id":     promotion.ID.String(),
+		"code":             promotion.Code,
+		"bonus_type":       string(promotion.BonusType),
+		"wallet_id":        wallet.ID.String(),
+		"user_id":          userID.String(),
+		"transaction_id":   credit.ID.String(),
+		"bonus":            redemption.Bonus.String(),
+		"redemption_count": promotion.RedemptionCount,
+	}
+	if redemption.TopUpTransactionID != nil {
+		payload["top_up_transaction_id"] = redemption.TopUpTransactionID.String()
+	}
+	s.publish(ctx, ports.EventPromotionRedeemed, payload)
+
+	return &RedemptionResponse{
+		Code:    promotion.Code,
+		Bonus:   redemption.Bonus,
+		Balance: credit.BalanceAfter,
+		Transaction: &TransactionResponse{
+			ID:            credit.ID,
+			Type:          string(credit.Type),
+			Amount:        credit.Amount,
+			BalanceBefore: credit.BalanceBefore,
+			BalanceAfter:  credit.BalanceAfter,
+			Status:        string(credit.Status),
+			Description:   credit.Description,
+			CreatedAt:     credit.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		},
+	}, nil
+}
+
+// qualifyingTopUp loads the top-up a percentage bonus is paid on. It must be
+// the wallet's own completed top-up, made during the campaign, and not have
+// earned a bonus already.
+func (s *PromotionService) qualifyingTopUp(ctx context.Context, uow ports.Transaction, promotion *domain.Promotion, walletID uuid.UUID, topUpID *uuid.UUID) (*domain.Transaction, error) {
+	if topUpID == nil {
+		return nil, domain.ErrPromotionNeedsTopUp
+	}
+	topUp, err := uow.Transactions().GetByID(ctx, *topUpID)
+	if err != nil {
+		return nil, err
+	}
+	if topUp.WalletID != walletID {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if topUp.Type != domain.TransactionTypeTopUp || !topUp.IsCompleted() || topUp.CreatedAt.Before(promotion.StartsAt) {
+		return nil, domain.ErrPromotionNeedsTopUp
+	}
+
+	redeemed, err := uow.Promotions().TopUpRedeemed(ctx, topUp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check top-up redemptions: %w", err)
+	}
+	if redeemed {
+		return nil, domain.ErrTopUpAlreadyPromoted
+	}
+	return topUp, nil
+}
+
+func (s *PromotionService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger, promotions: s.promotions})
+	}
+	return s.uow.Execute(ctx, fn)
+}
+
+func (s *PromotionService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
@@ -0,0 +1,266 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// ReferralService pays referral rewards. Auth attributes a new user to the
+// friend whose code they registered with; when the new user makes their
+// first payment inside the window, both are credited from the referrals
+// account.
+type ReferralService struct {
+	referrals    ports.ReferralRepository
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	uow          ports.UnitOfWork
+	events       ports.EventPublisher
+	logger       ports.Logger
+	policy       domain.ReferralPolicy
+}
+
+func NewReferralService(
+	referrals ports.ReferralRepository,
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	logger ports.Logger,
+	policy domain.ReferralPolicy,
+) *ReferralService {
+	return &ReferralService{
+		referrals:    referrals,
+		wallets:      wallets,
+		transactions: transactions,
+		ledger:       ledger,
+		uow:          uow,
+		events:       events,
+		logger:       logger,
+		policy:       policy,
+	}
+}
+
+type ReferralDashboardResponse struct {
+	*domain.ReferralSummary
+	ReferrerReward decimal.Decimal    `json:"referrer_reward"`
+	RefereeReward  decimal.Decimal    `json:"referee_reward"`
+	Referrals      []*domain.Referral `json:"referrals"`
+	Limit          int                `json:"limit"`
+	Offset         int                `json:"offset"`
+}
+
+// EventTypes lists the events the service consumes
+func (s *ReferralService) EventTypes() []string {
+	return []string{"user.referred"}
+}
+
+// Handle stores a referral attributed by auth. Redelivered events are
+// ignored; malformed ones are logged and skipped.
+func (s *ReferralService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	id := func(key string) uuid.UUID {
+		v, _ := payload[key].(string)
+		parsed, _ := uuid.Parse(v)
+		return parsed
+	}
+	referralID, referrerID, refereeID := id("referral_id"), id("referrer_id"), id("referee_id")
+	if referralID == uuid.Nil || referrerID == uuid.Nil || refereeID == uuid.Nil {
+		s.logger.WithContext(ctx).Warn("referral event is missing IDs", ports.String("event_type", eventType))
+		return nil
+	}
+
+	referral := domain.NewReferral(referralID, referrerID, refereeID, s.policy, time.Now())
+	if err := s.referrals.Create(ctx, referral); err != nil {
+		return fmt.Errorf("failed to store referral: %w", err)
+	}
+	return nil
+}
+
+// GetDashboard returns the referrer's referrals, newest first, with totals
+// and the rewards on offer
+func (s *ReferralService) GetDashboard(ctx context.Context, userID uuid.UUID, limit, offset int) (*ReferralDashboardResponse, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	summary, err := s.referrals.Summary(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarise referrals: %w", err)
+	}
+	referrals, err := s.referrals.ListByReferrerID(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list referrals: %w", err)
+	}
+	if referrals == nil {
+		referrals = []*domain.Referral{}
+	}
+	return &ReferralDashboardResponse{
+		ReferralSummary: summary,
+		ReferrerReward:  s.policy.ReferrerReward,
+		RefereeReward:   s.policy.RefereeReward,
+		Referrals:       referrals,
+		Limit:           limit,
+		Offset:          offset,
+	}, nil
+}
+
+// Reward pays both parties when userID, a referee, makes their first
+// payment. The referral is locked first and the two wallets after it in ID
+// order, so the rewards and the referral's settlement commit together once.
+func (s *ReferralService) Reward(ctx context.Context, userID uuid.UUID) error {
+	pending, err := s.referrals.GetPendingByRefereeID(ctx, userID)
+	if errors.Is(err, domain.ErrReferralNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var referral *domain.Referral
+	var payouts []*referralPayout
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		locked, err := uow.Referrals().GetForUpdate(ctx, pending.ID)
+		if err != nil {
+			return err
+		}
+		referral = locked
+		if err := referral.Reward(s.policy, time.Now()); err != nil {
+			if errors.Is(err, domain.ErrReferralExpired) {
+				return uow.Referrals().Update(ctx, referral)
+			}
+			return err
+		}
+
+		payouts = []*referralPayout{
+			{userID: referral.ReferrerID, party: domain.ReferralReferrer, reward: referral.ReferrerReward},
+			{userID: referral.RefereeID, party: domain.ReferralReferee, reward: referral.RefereeReward},
+		}
+		for _, p := range payouts {
+			wallet, err := uow.Wallets().GetByUserID(ctx, p.userID)
+			if err != nil {
+				return err
+			}
+			p.walletID = wallet.ID
+		}
+		slices.SortFunc(payouts, func(a, b *referralPayout) int {
+			return bytes.Compare(a.walletID[:], b.walletID[:])
+		})
+		for _, p := range payouts {
+			if err := s.credit(ctx, uow, referral, p); err != nil {
+				return err
+			}
+		}
+		return uow.Referrals().Update(ctx, referral)
+	})
+	if err != nil {
+		return err
+	}
+
+	if referral.Status == domain.ReferralExpired {
+		s.logger.WithContext(ctx).Info("referral expired before the first payment",
+			ports.String("referral_id", referral.ID.String()),
+		)
+		return nil
+	}
+	s.logger.WithContext(ctx).Info("referral rewarded",
+		ports.String("referral_id", referral.ID.String()),
+		ports.String("referrer_id", referral.ReferrerID.String()),
+		ports.String("referee_id", referral.RefereeID.String()),
+	)
+	for _, p := range payouts {
+		if p.tx == nil {
+			continue
+		}
+		s.publish(ctx, ports.EventReferralRewarded, map[string]interface{}{
+			"user_id":        p.userID.String(),
+			"party":          string(p.party),
+			"referral_id":    referral.ID.String(),
+			"wallet_id":      p.walletID.String(),
+			"transaction_id": p.tx.ID.String(),
+			"amount":         p.tx.Amount.StringFixed(2),
+			"balance":        p.tx.BalanceAfter.StringFixed(2),
+		})
+	}
+	return nil
+}
+
+// referralPayout is one party's side of a reward
+type referralPayout struct {
+	userID   uuid.UUID
+	party    domain.ReferralParty
+	reward   decimal.Decimal
+	walletID uuid.UUID
+	tx       *domain.Transaction
+}
+
+// credit pays one party's reward into their locked wallet
+func (s *ReferralService) credit(ctx context.Context, uow ports.Transaction, referral *domain.Referral, p *referralPayout) error {
+	if !p.reward.IsPositive() {
+		return nil
+	}
+	wallet, err := uow.Wallets().GetByIDForUpdate(ctx, p.walletID)
+	if err != nil {
+		return err
+	}
+	balanceBefore := wallet.Balance
+	if err := wallet.Credit(p.reward); err != nil {
+		return err
+	}
+	if err := uow.Wallets().Update(ctx, wallet); err != nil {
+		return fmt.Errorf("failed to update wallet: %w", err)
+	}
+	p.tx = domain.NewReferralCredit(wallet.ID, referral, p.party, p.reward, balanceBefore)
+	if err := uow.Transactions().Create(ctx, p.tx); err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+	return post(ctx, uow, p.tx)
+}
+
+// SetReferrals rewards referrals on referees' first payments
+func (s *WalletService) SetReferrals(referrals *ReferralService) {
+	s.referrals = referrals
+}
+
+// SetReferrals rewards referrals on the payments holds are captured as
+func (s *HoldService) SetReferrals(referrals *ReferralService) {
+	s.referrals = referrals
+}
+
+// rewardReferral settles a referee's pending referral off the request path.
+// A failure leaves the referral pending for the next payment.
+func rewardReferral(ctx context.Context, referrals *ReferralService, logger ports.Logger, userID uuid.UUID) {
+	if referrals == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		if err := referrals.Reward(ctx, userID); err != nil {
+			logger.WithContext(ctx).Warn("failed to reward referral",
+				ports.String("user_id", userID.String()),
+				ports.Err(err),
+			)
+		}
+	}()
+}
+
+func (s *ReferralService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger, referrals: s.referrals})
+	}
+	return s.uow.Execute(ctx, fn)
+}
+
+func (s *ReferralService) publish(ctx context.Context, eventType string, payload map[string]interface{}) {
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// RuleRiskChecker scores operations with fixed rules against the wallet's
+// own transactions
+type RuleRiskChecker struct {
+	transactions ports.TransactionRepository
+	rules        domain.RiskRules
+}
+
+func NewRuleRiskChecker(transactions ports.TransactionRepository, rules domain.RiskRules) *RuleRiskChecker {
+	return &RuleRiskChecker{transactions: transactions, rules: rules}
+}
+
+func (c *RuleRiskChecker) Assess(ctx context.Context, check ports.RiskCheck) (*domain.RiskAssessment, error) {
+	since := time.Now().Add(-c.rules.VelocityWindow)
+	history, err := c.transactions.GetRiskHistory(ctx, check.WalletID, check.Type, check.DeviceID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get risk history: %w", err)
+	}
+	return c.rules.Assess(check.Amount, check.DeviceID, *history), nil
+}
+
+// SetRiskChecker scores top-ups and payments before they run
+func (s *WalletService) SetRiskChecker(risk ports.RiskChecker) {
+	s.risk = risk
+}
+
+// assessRisk checks an operation and returns the error for one that may
+// not proceed. A checker that fails is logged and the operation allowed:
+// an outage in fraud scoring should not stop every payment.
+func (s *WalletService) assessRisk(ctx context.Context, wallet *domain.Wallet, txType domain.TransactionType, amount decimal.Decimal, deviceID string) (*domain.RiskAssessment, error) {
+	if s.risk == nil {
+		return nil, nil
+	}
+	assessment, err := s.risk.Assess(ctx, ports.RiskCheck{
+		WalletID: wallet.ID,
+		UserID:   wallet.UserID,
+		Type:     txType,
+		Amount:   amount,
+		DeviceID: deviceID,
+		IP:       audit.IPFromContext(ctx),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("risk check failed, allowing transaction",
+			ports.String("wallet_id", wallet.ID.String()), ports.Err(err))
+		return nil, nil
+	}
+
+	if assessment.Decision != domain.RiskAllow {
+		s.logger.WithContext(ctx).Warn("transaction flagged by risk check",
+			ports.String("wallet_id", wallet.ID.String()),
+			ports.String("decision", string(assessment.Decision)),
+			ports.Any("reasons", assessment.Reasons),
+		)
+		go func() {
+			event := ports.Event{
+				Type: ports.EventRiskFlagged,
+				Payload: map[string]interface{}{
+					"wallet_id": wallet.ID.String(),
+					"user_id":   wallet.UserID.String(),
+					"type":      string(txType),
+					"amount":    amount.String(),
+					"device_id": deviceID,
+					"score":     assessment.Score,
+					"decision":  string(assessment.Decision),
+					"reasons":   assessment.Reasons,
+				},
+			}
+			s.events.Publish(context.WithoutCancel(ctx), event)
+		}()
+	}
+	return assessment, assessment.Err()
+}
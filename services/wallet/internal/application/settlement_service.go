@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// SettlementConfig controls the nightly settlement run
+type SettlementConfig struct {
+	Location *time.Location // Days start at midnight here
+}
+
+// SettlementService works out what each provider is owed for a day of
+// parking payments and tracks the payout. A run settles every provider not
+// yet settled for the day, so runs can be repeated, or made from several
+// instances, without settling anyone twice.
+type SettlementService struct {
+	settlements  ports.SettlementRepository
+	transactions ports.TransactionRepository
+	events       ports.EventPublisher
+	auditLog     ports.AuditLog
+	logger       ports.Logger
+	cfg          SettlementConfig
+}
+
+func NewSettlementService(
+	settlements ports.SettlementRepository,
+	transactions ports.TransactionRepository,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+	cfg SettlementConfig,
+) *SettlementService {
+	if cfg.Location == nil {
+		cfg.Location = time.UTC
+	}
+	return &SettlementService{
+		settlements:  settlements,
+		transactions: transactions,
+		events:       events,
+		auditLog:     auditLog,
+		logger:       logger,
+		cfg:          cfg,
+	}
+}
+
+// Request/Response DTOs
+
+type MarkSettledRequest struct {
+	SettlementID uuid.UUID `json:"-"`
+	AdminID      uuid.UUID `json:"-"`
+	Reference    string    `json:"reference"`
+}
+
+type SettlementListResponse struct {
+	Settlements []*domain.Settlement `json:"settlements"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+// SettlementRunResult summarises one daily settlement run
+type SettlementRunResult struct {
+	PeriodStart time.Time `json:"period_start"`
+	Created     int       `json:"created"`
+	Skipped     int       `json:"skipped"`
+	Failed      int       `json:"failed"`
+}
+
+// SettleDaily settles the day before now
+func (s *SettlementService) SettleDaily(ctx context.Context, now time.Time) (*SettlementRunResult, error) {
+	start, end := domain.SettlementPeriod(now, s.cfg.Location)
+	return s.run(ctx, start, end)
+}
+
+// SettleDay settles the calendar day of date, for backfilling a missed run
+func (s *SettlementService) SettleDay(ctx context.Context, date time.Time) (*SettlementRunResult, error) {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, s.cfg.Location)
+	return s.run(ctx, start, start.AddDate(0, 0, 1))
+}
+
+// run creates a settlement for every provider with transactions in
+// [start, end). A provider that cannot be settled is logged and counted as
+// failed so one bad provider does not stop the run.
+func (s *SettlementService) run(ctx context.Context, start, end time.Time) (*SettlementRunResult, error) {
+	result := &SettlementRunResult{PeriodStart: start}
+
+	transactions, err := s.transactions.GetProviderTransactionsBetween(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider transactions: %w", err)
+	}
+
+	var providers []uuid.UUID
+	byProvider := make(map[uuid.UUID][]*domain.Transaction)
+	for _, tx := range transactions {
+		id := *tx.ProviderID
+		if _, ok := byProvider[id]; !ok {
+			providers = append(providers, id)
+		}
+		byProvider[id] = append(byProvider[id], tx)
+	}
+
+	for _, providerID := range providers {
+		created, err := s.settle(ctx, providerID, start, end, byProvider[providerID])
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			result.Failed++
+			s.logger.WithContext(ctx).Error("failed to settle provider",
+				ports.String("provider_id", providerID.String()),
+				ports.Err(err),
+			)
+		case created:
+			result.Created++
+		default:
+			result.Skipped++
+		}
+	}
+
+	if result.Created > 0 || result.Failed > 0 {
+		s.logger.WithContext(ctx).Info("settlement run finished",
+			ports.String("period_start", start.Format("2006-01-02")),
+			ports.Any("created", result.Created),
+			ports.Any("skipped", result.Skipped),
+			ports.Any("failed", result.Failed),
+		)
+	}
+	return result, nil
+}
+
+// settle creates one provider's settlement. It reports false when the
+// provider was already settled for the day or had nothing to settle.
+func (s *SettlementService) settle(ctx context.Context, providerID uuid.UUID, start, end time.Time, transactions []*domain.Transaction) (bool, error) {
+	settlement, err := domain.NewSettlement(providerID, start, end, transactions)
+	if errors.Is(err, domain.ErrNothingToSettle) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.settlements.Create(ctx, settlement); err != nil {
+		if errors.Is(err, domain.ErrSettlementExists) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to save settlement: %w", err)
+	}
+
+	s.publish(ctx, ports.EventSettlementCreated, settlement)
+	return true, nil
+}
+
+// ListSettlements returns settlements newest first, optionally for one
+// provider or in one status
+func (s *SettlementService) ListSettlements(ctx context.Context, providerID *uuid.UUID, status *domain.SettlementStatus, limit, offset int) (*SettlementListResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	settlements, err := s.settlements.List(ctx, providerID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list settlements: %w", err)
+	}
+	if settlements == nil {
+		settlements = []*domain.Settlement{}
+	}
+
+	return &SettlementListResponse{
+		Settlements: settlements,
+		Limit:       limit,
+		Offset:      offset,
+	}, nil
+}
+
+// GetSettlement returns a settlement with its lines
+func (s *SettlementService) GetSettlement(ctx context.Context, id uuid.UUID) (*domain.Settlement, error) {
+	return s.settlements.GetByID(ctx, id)
+}
+
+// MarkSettled records the payout reference once the provider has been paid
+func (s *SettlementService) MarkSettled(ctx context.Context, req MarkSettledRequest) (*domain.Settlement, error) {
+	settlement, err := s.settlements.GetByID(ctx, req.SettlementID)
+	if err != nil {
+		return nil, err
+	}
+	before := *settlement
+	before.Lines = nil
+
+	if err := settlement.MarkSettled(req.Reference, req.AdminID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	if err := s.settlements.Update(ctx, settlement); err != nil {
+		return nil, fmt.Errorf("failed to update settlement: %w", err)
+	}
+
+	after := *settlement
+	after.Lines = nil
+	event := ports.AuditEvent{
+		Action:     ports.AuditSettlementSettled,
+		TargetType: "settlement",
+		TargetID:   settlement.ID.String(),
+		Reason:     settlement.Reference,
+		Before:     before,
+		After:      after,
+	}
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		s.logger.WithContext(ctx).Error("failed to write audit log",
+			ports.String("action", event.Action),
+			ports.String("settlement_id", settlement.ID.String()),
+			ports.Err(err),
+		)
+	}
+
+	s.logger.WithContext(ctx).Info("settlement paid out",
+		ports.String("settlement_id", settlement.ID.String()),
+		ports.String("provider_id", settlement.ProviderID.String()),
+		ports.String("reference", settlement.Reference),
+	)
+	s.publish(ctx, ports.EventSettlementSettled, settlement)
+	return settlement, nil
+}
+
+func (s *SettlementService) publish(ctx context.Context, eventType string, settlement *domain.Settlement) {
+	payload := map[string]interface{}{
+		"settlement_id": settlement.ID.String(),
+		"provider_id":   settlement.ProviderID.String(),
+		"period_start":  settlement.PeriodStart.Format("2006-01-02"),
+		"payment_count": settlement.PaymentCount,
+		"gross_amount":  settlement.GrossAmount.StringFixed(2),
+		"refund_amount": settlement.RefundAmount.StringFixed(2),
+		"fee_amount":    settlement.FeeAmount.StringFixed(2),
+		"net_amount":    settlement.NetAmount.StringFixed(2),
+		"status":        string(settlement.Status),
+	}
+	if settlement.Reference != "" {
+		payload["reference"] = settlement.Reference
+	}
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{Type: eventType, Payload: payload})
+	}()
+}
@@ -0,0 +1,87 @@
+package application
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+	"github.com/shopspring/decimal"
+)
+
+// StepUpChallengeRequest asks for a one-time code to confirm a payment or
+// top-up the wallet turned down with STEP_UP_REQUIRED
+type StepUpChallengeRequest struct {
+	WalletID uuid.UUID              `json:"wallet_id"`
+	Type     domain.TransactionType `json:"type"`
+	Amount   decimal.Decimal        `json:"amount"`
+}
+
+// SetStepUp has the user confirm payments at or over the policy's threshold,
+// and any transaction the risk check wants challenged, through auth
+func (s *WalletService) SetStepUp(stepUp ports.StepUpService, policy domain.StepUpPolicy) {
+	s.stepUp = stepUp
+	s.stepUpPolicy = policy
+}
+
+// RequestStepUp has auth send the wallet owner a one-time code. Confirming
+// it with auth gives the token to retry the transaction with.
+func (s *WalletService) RequestStepUp(ctx context.Context, userID uuid.UUID, req StepUpChallengeRequest) (*ports.StepUpChallenge, error) {
+	if s.stepUp == nil {
+		return nil, domain.ErrStepUpInvalid
+	}
+	if req.Type != domain.TransactionTypePayment && req.Type != domain.TransactionTypeTopUp {
+		return nil, domain.ErrStepUpType
+	}
+	if req.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	wallet, err := s.wallets.GetByID(ctx, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.UserID != userID {
+		return nil, domain.ErrWalletNotFound
+	}
+
+	return s.stepUp.Challenge(ctx, ports.StepUpRequest{
+		UserID: wallet.UserID,
+		Type:   req.Type,
+		Amount: req.Amount,
+	})
+}
+
+// confirmStepUp decides whether the transaction needs the user's
+// confirmation and, if so, redeems their token. riskErr is the risk
+// check's verdict: a challenge can be answered with step-up, a block
+// cannot. Only transactions the user makes in the app can be confirmed;
+// for the rest a challenge stands.
+func (s *WalletService) confirmStepUp(ctx context.Context, wallet *domain.Wallet, txType domain.TransactionType, amount decimal.Decimal, token string, interactive bool, riskErr error) error {
+	if s.stepUp == nil || !interactive {
+		return riskErr
+	}
+	if riskErr != nil && !errors.Is(riskErr, domain.ErrRiskChallengeRequired) {
+		return riskErr
+	}
+	if riskErr == nil && !s.stepUpPolicy.Requires(txType, amount) {
+		return nil
+	}
+	if token == "" {
+		return domain.ErrStepUpRequired
+	}
+
+	err := s.stepUp.Redeem(ctx, token, ports.StepUpRequest{
+		UserID: wallet.UserID,
+		Type:   txType,
+		Amount: amount,
+	})
+	if err != nil {
+		return err
+	}
+	s.logger.WithContext(ctx).Info("transaction confirmed with step-up",
+		ports.String("wallet_id", wallet.ID.String()),
+		ports.String("type", string(txType)))
+	return nil
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// userEvents are the auth events that carry a user's contact details and
+// KYC level.
+// user.deleted carries none, so storing it blanks the profile; its
+// updated_at keeps late events from restoring what was erased. Wallet and
+// transaction records stay, as the law requires, tied only to the user ID.
+var userEvents = []string{
+	"user.registered",
+	"user.synced",
+	"user.profile_updated",
+	"user.kyc_updated",
+	"user.deleted",
+}
+
+// UserProfileService keeps the local copy of user contact details, used on
+// receipts and statements, up to date from auth events
+type UserProfileService struct {
+	profiles ports.UserProfileRepository
+	logger   ports.Logger
+}
+
+func NewUserProfileService(profiles ports.UserProfileRepository, logger ports.Logger) *UserProfileService {
+	return &UserProfileService{profiles: profiles, logger: logger}
+}
+
+// EventTypes lists the events the service consumes
+func (s *UserProfileService) EventTypes() []string {
+	return userEvents
+}
+
+// Handle stores the profile carried by a user event. Malformed events are
+// logged and skipped; storage errors fail the event.
+func (s *UserProfileService) Handle(ctx context.Context, eventType string, payload map[string]interface{}) error {
+	str := func(key string) string {
+		v, _ := payload[key].(string)
+		return v
+	}
+	log := s.logger.WithContext(ctx)
+
+	userID, err := uuid.Parse(str("user_id"))
+	if err != nil {
+		log.Warn("user event has no user_id", ports.String("event_type", eventType))
+		return nil
+	}
+	// Events published before updated_at was added sort before any snapshot
+	var updatedAt time.Time
+	if v := str("updated_at"); v != "" {
+		if updatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			log.Warn("user event has an invalid updated_at",
+				ports.String("event_type", eventType),
+				ports.String("user_id", userID.String()),
+			)
+			return nil
+		}
+	}
+
+	profile := &domain.UserProfile{
+		UserID:    userID,
+		Phone:     str("phone"),
+		Email:     str("email"),
+		FullName:  str("full_name"),
+		KYCLevel:  domain.KYCLevelBasic,
+		UpdatedAt: updatedAt.UTC(),
+	}
+	if str("kyc_level") == string(domain.KYCLevelVerified) {
+		profile.KYCLevel = domain.KYCLevelVerified
+	}
+	if _, err := s.profiles.Upsert(ctx, profile); err != nil {
+		return fmt.Errorf("failed to store user profile: %w", err)
+	}
+	return nil
+}
+
+// GetProfile returns the stored copy of a user's contact details
+func (s *UserProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error) {
+	return s.profiles.GetByUserID(ctx, userID)
+}
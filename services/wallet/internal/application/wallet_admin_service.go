@@ -0,0 +1,253 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/actor"
+	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/parking-super-app/services/wallet/internal/ports"
+)
+
+// WalletAdminService handles back-office actions on wallets. Every change is
+// written to the audit trail with the acting admin and their reason.
+type WalletAdminService struct {
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	uow          ports.UnitOfWork
+	events       ports.EventPublisher
+	auditLog     ports.AuditLog
+	loyalty      *LoyaltyService
+	logger       ports.Logger
+}
+
+func NewWalletAdminService(
+	wallets ports.WalletRepository,
+	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	uow ports.UnitOfWork,
+	events ports.EventPublisher,
+	auditLog ports.AuditLog,
+	logger ports.Logger,
+) *WalletAdminService {
+	return &WalletAdminService{
+		wallets:      wallets,
+		transactions: transactions,
+		ledger:       ledger,
+		uow:          uow,
+		events:       events,
+		auditLog:     auditLog,
+		logger:       logger,
+	}
+}
+
+type WalletStatusRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ReverseTransactionRequest struct {
+	TransactionID uuid.UUID `json:"-"`
+	Reason        string    `json:"reason"`
+}
+
+// actingAdmin returns the platform admin the gateway verified for this
+// request. It comes from the admin role checked on the route, never from
+// anything the request body or a plain user ID could claim.
+func actingAdmin(ctx context.Context) (uuid.UUID, error) {
+	id, ok := actor.UserWithRole(ctx, actor.RoleAdmin)
+	if !ok {
+		return uuid.Nil, domain.ErrAdminRequired
+	}
+	adminID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, domain.ErrAdminRequired
+	}
+	return adminID, nil
+}
+
+// FreezeWallet stops a wallet from transacting until it is unfrozen
+func (s *WalletAdminService) FreezeWallet(ctx context.Context, walletID uuid.UUID, req WalletStatusRequest) (*WalletResponse, error) {
+	return s.changeStatus(ctx, walletID, req, ports.AuditWalletFrozen, ports.EventWalletFrozen, func(w *domain.Wallet) error {
+		if w.Status == domain.WalletStatusFrozen {
+			return domain.ErrWalletFrozen
+		}
+		w.Freeze()
+		return nil
+	})
+}
+
+// UnfreezeWallet lets a frozen wallet transact again
+func (s *WalletAdminService) UnfreezeWallet(ctx context.Context, walletID uuid.UUID, req WalletStatusRequest) (*WalletResponse, error) {
+	return s.changeStatus(ctx, walletID, req, ports.AuditWalletUnfrozen, ports.EventWalletUnfrozen, func(w *domain.Wallet) error {
+		return w.Unfreeze()
+	})
+}
+
+func (s *WalletAdminService) changeStatus(
+	ctx context.Context,
+	walletID uuid.UUID,
+	req WalletStatusRequest,
+	action, eventType string,
+	apply func(*domain.Wallet) error,
+) (*WalletResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrReasonRequired
+	}
+
+	wallet, err := s.wallets.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	before := *wallet
+
+	if err := apply(wallet); err != nil {
+		return nil, err
+	}
+	if err := s.wallets.Update(ctx, wallet); err != nil {
+		return nil, fmt.Errorf("failed to update wallet: %w", err)
+	}
+
+	event := ports.AuditEvent{
+		Action:     action,
+		TargetType: "wallet",
+		TargetID:   wallet.ID.String(),
+		Reason:     reason,
+		Before:     before,
+		After:      *wallet,
+	}
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		s.logger.WithContext(ctx).Error("failed to write audit log",
+			ports.String("action", action),
+			ports.String("wallet_id", wallet.ID.String()),
+			ports.Err(err),
+		)
+	}
+
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{
+			Type: eventType,
+			Payload: map[string]interface{}{
+				"wallet_id": wallet.ID.String(),
+				"user_id":   wallet.UserID.String(),
+				"status":    string(wallet.Status),
+				"reason":    reason,
+			},
+		})
+	}()
+
+	return toWalletResponse(wallet), nil
+}
+
+// ReverseTransaction undoes a completed transaction with a compensating one.
+// History is never rewritten: the original stays as it was and the reversal
+// links back to it. Each transaction can be reversed once.
+func (s *WalletAdminService) ReverseTransaction(ctx context.Context, req ReverseTransactionRequest) (*domain.Transaction, error) {
+	adminID, err := actingAdmin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrReasonRequired
+	}
+
+	original, err := s.transactions.GetByID(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reversal *domain.Transaction
+	var userID uuid.UUID
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, original.WalletID)
+		if err != nil {
+			return err
+		}
+		userID = locked.UserID
+		if userID == adminID {
+			return domain.ErrSelfReversal
+		}
+
+		// Re-read under the wallet lock so a concurrent refund is seen
+		current, err := uow.Transactions().GetByID(ctx, original.ID)
+		if err != nil {
+			return err
+		}
+		reversal, err = domain.NewReversal(current, locked.Balance, adminID, reason)
+		if err != nil {
+			return err
+		}
+		if err := uow.Transactions().Create(ctx, reversal); err != nil {
+			if errors.Is(err, domain.ErrDuplicateTransaction) {
+				return domain.ErrAlreadyReversed
+			}
+			return fmt.Errorf("failed to create transaction: %w", err)
+		}
+
+		locked.ApplyReversal(reversal)
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+		if err := uow.Ledger().Append(ctx, domain.NewReversalPosting(reversal, current)); err != nil {
+			return fmt.Errorf("failed to post ledger entries: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := ports.AuditEvent{
+		Action:     ports.AuditTransactionReversed,
+		TargetType: "transaction",
+		TargetID:   original.ID.String(),
+		Reason:     reason,
+		After:      *reversal,
+	}
+	if err := s.auditLog.Record(ctx, event); err != nil {
+		s.logger.WithContext(ctx).Error("failed to write audit log",
+			ports.String("action", event.Action),
+			ports.String("transaction_id", original.ID.String()),
+			ports.Err(err),
+		)
+	}
+
+	s.logger.WithContext(ctx).Info("transaction reversed",
+		ports.String("transaction_id", original.ID.String()),
+		ports.String("reversal_id", reversal.ID.String()),
+		ports.String("admin_id", adminID.String()),
+	)
+	go func() {
+		s.events.Publish(context.WithoutCancel(ctx), ports.Event{
+			Type: ports.EventTransactionReversed,
+			Payload: map[string]interface{}{
+				"transaction_id":          reversal.ID.String(),
+				"original_transaction_id": original.ID.String(),
+				"original_type":           string(original.Type),
+				"wallet_id":               reversal.WalletID.String(),
+				"user_id":                 userID.String(),
+				"amount":                  reversal.SignedAmount().String(),
+				"balance_after":           reversal.BalanceAfter.String(),
+				"admin_id":                adminID.String(),
+				"reason":                  reason,
+			},
+		})
+	}()
+	if original.Type == domain.TransactionTypePayment {
+		forfeitPoints(ctx, s.loyalty, s.logger, original.ID)
+	}
+
+	return reversal, nil
+}
+
+func (s *WalletAdminService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	if s.uow == nil {
+		return fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger})
+	}
+	return s.uow.Execute(ctx, fn)
+}
@@ -2,7 +2,9 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/wallet/internal/domain"
@@ -10,51 +12,127 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// errTopUpSettled stops a unit of work that finds the top-up already completed
+// or failed, e.g. when a gateway delivers the same webhook twice
+var errTopUpSettled = errors.New("top-up already settled")
+
+// maxConcurrencyRetries bounds how often a unit of work is replayed after
+// another writer changed the wallet first
+const maxConcurrencyRetries = 3
+
 type WalletService struct {
 	wallets      ports.WalletRepository
 	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	limits       ports.SpendingLimitRepository
+	alerts       ports.BalanceAlertRepository
+	orgs         ports.OrganizationRepository
 	uow          ports.UnitOfWork
 	gateway      ports.PaymentGateway
 	events       ports.EventPublisher
+	flags        ports.FeatureFlags
+	rounding     domain.RoundingPolicy
+	profiles     ports.UserProfileRepository
+	kyc          domain.KYCPolicy
+	risk         ports.RiskChecker
+	stepUp       ports.StepUpService
+	stepUpPolicy domain.StepUpPolicy
+	pins         ports.PINVerifier
+	fees         ports.FeeScheduleRepository
+	loyalty      *LoyaltyService
+	referrals    *ReferralService
 	logger       ports.Logger
 }
 
 func NewWalletService(
 	wallets ports.WalletRepository,
 	transactions ports.TransactionRepository,
+	ledger ports.LedgerRepository,
+	limits ports.SpendingLimitRepository,
 	uow ports.UnitOfWork,
 	gateway ports.PaymentGateway,
 	events ports.EventPublisher,
+	flags ports.FeatureFlags,
+	rounding domain.RoundingPolicy,
 	logger ports.Logger,
 ) *WalletService {
 	return &WalletService{
 		wallets:      wallets,
 		transactions: transactions,
+		ledger:       ledger,
+		limits:       limits,
 		uow:          uow,
 		gateway:      gateway,
 		events:       events,
+		flags:        flags,
+		rounding:     rounding,
 		logger:       logger,
 	}
 }
 
+// SetKYCLimits caps wallets by their owner's KYC level, read from the
+// local copy of user profiles
+func (s *WalletService) SetKYCLimits(profiles ports.UserProfileRepository, policy domain.KYCPolicy) {
+	s.profiles = profiles
+	s.kyc = policy
+}
+
+// SetBalanceAlerts turns on low-balance alerts, stored in alerts
+func (s *WalletService) SetBalanceAlerts(alerts ports.BalanceAlertRepository) {
+	s.alerts = alerts
+}
+
+// SetOrganizations lets organization members charge payments to their
+// organization's wallet
+func (s *WalletService) SetOrganizations(orgs ports.OrganizationRepository) {
+	s.orgs = orgs
+}
+
+// kycLimits returns the limits for the wallet owner's KYC level. Users whose
+// profile has not arrived yet get the basic limits.
+func (s *WalletService) kycLimits(ctx context.Context, userID uuid.UUID) (domain.KYCLimits, error) {
+	if s.profiles == nil {
+		return domain.KYCLimits{}, nil
+	}
+	level := domain.KYCLevelBasic
+	profile, err := s.profiles.GetByUserID(ctx, userID)
+	switch {
+	case err == nil:
+		level = profile.KYCLevel
+	case !errors.Is(err, domain.ErrUserProfileNotFound):
+		return domain.KYCLimits{}, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	return s.kyc.For(level), nil
+}
+
+// CreateWalletRequest opens a wallet. Without a label it opens the user's
+// first, default wallet; with one it adds another labeled wallet.
 type CreateWalletRequest struct {
 	UserID   uuid.UUID `json:"user_id"`
 	Currency string    `json:"currency"`
+	Label    string    `json:"label,omitempty"`
 }
 
 type WalletResponse struct {
-	ID       uuid.UUID       `json:"id"`
-	UserID   uuid.UUID       `json:"user_id"`
-	Balance  decimal.Decimal `json:"balance"`
-	Currency string          `json:"currency"`
-	Status   string          `json:"status"`
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Label     string          `json:"label"`
+	IsDefault bool            `json:"is_default"`
+	Balance   decimal.Decimal `json:"balance"`
+	Currency  string          `json:"currency"`
+	Status    string          `json:"status"`
 }
 
+// TopUpRequest tops up a wallet. Token is the saved card for card top-ups and
+// the buyer's bank code for FPX.
 type TopUpRequest struct {
 	WalletID       uuid.UUID       `json:"wallet_id"`
 	Amount         decimal.Decimal `json:"amount"`
 	PaymentMethod  string          `json:"payment_method"`
+	Token          string          `json:"token,omitempty"`
 	IdempotencyKey string          `json:"idempotency_key"`
+	DeviceID       string          `json:"-"`
+	StepUpToken    string          `json:"-"`
 }
 
 type PaymentRequest struct {
@@ -64,6 +142,19 @@ type PaymentRequest struct {
 	ReferenceID    string          `json:"reference_id"`
 	Description    string          `json:"description"`
 	IdempotencyKey string          `json:"idempotency_key"`
+	DeviceID       string          `json:"-"`
+	StepUpToken    string          `json:"-"`
+	PIN            string          `json:"pin,omitempty"`
+	// MemberID is the organization member a payment from an organization
+	// wallet is charged to
+	MemberID *uuid.UUID `json:"-"`
+	// Interactive is set for payments the user makes in the app. Payments
+	// other services make for them can't stop for a PIN or step-up.
+	Interactive bool `json:"-"`
+	// Taxes are the taxes included in Amount, as the caller worked them out
+	Taxes []domain.TaxLine `json:"-"`
+	// Category overrides the one worked out from the idempotency key
+	Category domain.TransactionCategory `json:"category,omitempty"`
 }
 
 type TransactionResponse struct {
@@ -75,6 +166,58 @@ type TransactionResponse struct {
 	Status        string          `json:"status"`
 	Description   string          `json:"description"`
 	CreatedAt     string          `json:"created_at"`
+	Category      string          `json:"category,omitempty"`
+	Tags          []string        `json:"tags,omitempty"`
+	// Set on payments that recorded the taxes in their amount
+	TaxAmount *decimal.Decimal `json:"tax_amount,omitempty"`
+	Taxes     []domain.TaxLine `json:"taxes,omitempty"`
+	// Set when rounding changed the charged amount
+	ChargedAmount      *decimal.Decimal `json:"charged_amount,omitempty"`
+	RoundingAdjustment *decimal.Decimal `json:"rounding_adjustment,omitempty"`
+	// Set while a top-up waits for the user at their bank or card issuer
+	Redirect *PaymentRedirect `json:"redirect,omitempty"`
+}
+
+// PaymentRedirect tells the client where to finish a pending top-up. FPX
+// needs Fields posted as a form; 3-D Secure is a plain GET of URL.
+type PaymentRedirect struct {
+	URL    string            `json:"url"`
+	Method string            `json:"method"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+type RefundRequest struct {
+	TransactionID  uuid.UUID `json:"transaction_id"`
+	Reason         string    `json:"reason"`
+	IdempotencyKey string    `json:"idempotency_key"`
+}
+
+// PaymentRecord is a payment transaction as seen by reconciliation jobs
+type PaymentRecord struct {
+	TransactionID  uuid.UUID       `json:"transaction_id"`
+	ReferenceID    string          `json:"reference_id"`
+	IdempotencyKey string          `json:"idempotency_key"`
+	Amount         decimal.Decimal `json:"amount"`
+	Status         string          `json:"status"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// SpendingLimitsRequest replaces a wallet's limits; a null limit removes it
+type SpendingLimitsRequest struct {
+	PerTransactionLimit *decimal.Decimal `json:"per_transaction_limit"`
+	DailyLimit          *decimal.Decimal `json:"daily_limit"`
+	MonthlyLimit        *decimal.Decimal `json:"monthly_limit"`
+}
+
+// BalanceAlertRequest sets a wallet's low-balance threshold; null turns the alert off
+type BalanceAlertRequest struct {
+	Threshold *decimal.Decimal `json:"threshold"`
+}
+
+type SpendingLimitsResponse struct {
+	*domain.SpendingLimits
+	SpentToday     decimal.Decimal `json:"spent_today"`
+	SpentThisMonth decimal.Decimal `json:"spent_this_month"`
 }
 
 type TransactionListResponse struct {
@@ -85,23 +228,38 @@ type TransactionListResponse struct {
 }
 
 func (s *WalletService) CreateWallet(ctx context.Context, req CreateWalletRequest) (*WalletResponse, error) {
-	s.logger.Info("creating wallet", ports.String("user_id", req.UserID.String()))
-
-	exists, err := s.wallets.ExistsByUserID(ctx, req.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check wallet existence: %w", err)
-	}
-	if exists {
-		return nil, domain.ErrWalletAlreadyExists
-	}
+	s.logger.WithContext(ctx).Info("creating wallet", ports.String("user_id", req.UserID.String()))
 
 	currency := req.Currency
 	if currency == "" {
 		currency = "MYR"
 	}
 
-	wallet := domain.NewWallet(req.UserID, currency)
+	var wallet *domain.Wallet
+	if req.Label == "" {
+		exists, err := s.wallets.ExistsByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check wallet existence: %w", err)
+		}
+		if exists {
+			return nil, domain.ErrWalletAlreadyExists
+		}
+		wallet = domain.NewWallet(req.UserID, currency)
+	} else {
+		existing, err := s.wallets.ListByUserID(ctx, req.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list wallets: %w", err)
+		}
+		wallet, err = domain.NewLabeledWallet(req.UserID, currency, req.Label, existing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.wallets.Create(ctx, wallet); err != nil {
+		if errors.Is(err, domain.ErrWalletAlreadyExists) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to create wallet: %w", err)
 	}
 
@@ -109,39 +267,90 @@ func (s *WalletService) CreateWallet(ctx context.Context, req CreateWalletReques
 		event := ports.Event{
 			Type: ports.EventWalletCreated,
 			Payload: map[string]interface{}{
-				"wallet_id": wallet.ID.String(),
-				"user_id":   wallet.UserID.String(),
+				"wallet_id":  wallet.ID.String(),
+				"user_id":    wallet.UserID.String(),
+				"label":      wallet.Label,
+				"is_default": wallet.IsDefault,
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
 
-	return &WalletResponse{
-		ID:       wallet.ID,
-		UserID:   wallet.UserID,
-		Balance:  wallet.Balance,
-		Currency: wallet.Currency,
-		Status:   string(wallet.Status),
-	}, nil
+	return toWalletResponse(wallet), nil
 }
 
+// GetWallet returns the user's default wallet
 func (s *WalletService) GetWallet(ctx context.Context, userID uuid.UUID) (*WalletResponse, error) {
 	wallet, err := s.wallets.GetByUserID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
+	return toWalletResponse(wallet), nil
+}
+
+// GetUserWallet returns the user's wallet walletID, or their default wallet
+// when walletID is nil. Another user's wallet is reported as not found.
+func (s *WalletService) GetUserWallet(ctx context.Context, userID, walletID uuid.UUID) (*WalletResponse, error) {
+	if walletID == uuid.Nil {
+		return s.GetWallet(ctx, userID)
+	}
+	wallet, err := s.wallets.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet.UserID != userID {
+		return nil, domain.ErrWalletNotFound
+	}
+	return toWalletResponse(wallet), nil
+}
+
+// ListWallets returns all the user's wallets, default first
+func (s *WalletService) ListWallets(ctx context.Context, userID uuid.UUID) ([]*WalletResponse, error) {
+	wallets, err := s.wallets.ListByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list wallets: %w", err)
+	}
+	resp := make([]*WalletResponse, len(wallets))
+	for i, wallet := range wallets {
+		resp[i] = toWalletResponse(wallet)
+	}
+	return resp, nil
+}
+
+// SetDefaultWallet makes one of the user's wallets the one used when no
+// wallet is named
+func (s *WalletService) SetDefaultWallet(ctx context.Context, userID, walletID uuid.UUID) (*WalletResponse, error) {
+	err := s.atomically(ctx, func(uow ports.Transaction) error {
+		return uow.Wallets().SetDefault(ctx, userID, walletID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetUserWallet(ctx, userID, walletID)
+}
 
+func (s *WalletService) GetWalletByID(ctx context.Context, walletID uuid.UUID) (*WalletResponse, error) {
+	wallet, err := s.wallets.GetByID(ctx, walletID)
+	if err != nil {
+		return nil, err
+	}
+	return toWalletResponse(wallet), nil
+}
+
+func toWalletResponse(wallet *domain.Wallet) *WalletResponse {
 	return &WalletResponse{
-		ID:       wallet.ID,
-		UserID:   wallet.UserID,
-		Balance:  wallet.Balance,
-		Currency: wallet.Currency,
-		Status:   string(wallet.Status),
-	}, nil
+		ID:        wallet.ID,
+		UserID:    wallet.UserID,
+		Label:     wallet.Label,
+		IsDefault: wallet.IsDefault,
+		Balance:   wallet.Balance,
+		Currency:  wallet.Currency,
+		Status:    string(wallet.Status),
+	}
 }
 
 func (s *WalletService) TopUp(ctx context.Context, req TopUpRequest) (*TransactionResponse, error) {
-	s.logger.Info("processing topup",
+	s.logger.WithContext(ctx).Info("processing topup",
 		ports.String("wallet_id", req.WalletID.String()),
 		ports.String("amount", req.Amount.String()),
 	)
@@ -152,7 +361,7 @@ func (s *WalletService) TopUp(ctx context.Context, req TopUpRequest) (*Transacti
 
 	existingTx, err := s.transactions.GetByIdempotencyKey(ctx, req.IdempotencyKey)
 	if err == nil && existingTx != nil {
-		return s.toTransactionResponse(existingTx), nil
+		return toTransactionResponse(existingTx), nil
 	}
 
 	wallet, err := s.wallets.GetByID(ctx, req.WalletID)
@@ -164,6 +373,29 @@ func (s *WalletService) TopUp(ctx context.Context, req TopUpRequest) (*Transacti
 		return nil, domain.ErrWalletInactive
 	}
 
+	// Checked when the top-up starts rather than when the gateway confirms
+	// it, so money the user has already been charged is always credited
+	holderID, err := s.holder(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+	kycLimits, err := s.kycLimits(ctx, holderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := kycLimits.CheckTopUp(wallet.Balance, req.Amount); err != nil {
+		return nil, err
+	}
+	risk, err := s.assessRisk(ctx, wallet, domain.TransactionTypeTopUp, req.Amount, req.DeviceID)
+	if err := s.confirmStepUp(ctx, wallet, domain.TransactionTypeTopUp, req.Amount, req.StepUpToken, true, err); err != nil {
+		return nil, err
+	}
+
+	charged, adjustment := s.rounding.Apply(req.PaymentMethod, req.Amount)
+	if charged.LessThanOrEqual(decimal.Zero) {
+		return nil, domain.ErrInvalidAmount
+	}
+
 	tx := domain.NewTransaction(
 		wallet.ID,
 		domain.TransactionTypeTopUp,
@@ -173,26 +405,210 @@ func (s *WalletService) TopUp(ctx context.Context, req TopUpRequest) (*Transacti
 		req.IdempotencyKey,
 		"Wallet top-up",
 	)
+	tx.SetRisk(req.DeviceID, risk)
 
 	if err := s.transactions.Create(ctx, tx); err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	if err := wallet.Credit(req.Amount); err != nil {
-		tx.Fail()
-		s.transactions.Update(ctx, tx)
-		return nil, err
+	// Charge the payment method before crediting, for users in the gateway top-up rollout
+	if s.flags.IsEnabled(ctx, ports.FlagGatewayTopUp, wallet.UserID.String()) {
+		gatewayResp, err := s.chargeTopUp(ctx, wallet, tx, req, charged)
+		if err != nil {
+			tx.Fail()
+			s.transactions.Update(ctx, tx)
+			return nil, err
+		}
+		if gatewayResp.Status == ports.GatewayStatusPending {
+			return s.awaitGateway(ctx, tx, gatewayResp)
+		}
+		// The gateway's capture is the source of truth for what the user paid
+		captured := charged
+		if !gatewayResp.CapturedAmount.IsZero() {
+			captured = gatewayResp.CapturedAmount
+		}
+		charged, adjustment = captured, captured.Sub(req.Amount)
 	}
 
-	if err := s.wallets.Update(ctx, wallet); err != nil {
-		tx.Fail()
-		s.transactions.Update(ctx, tx)
-		return nil, fmt.Errorf("failed to update wallet: %w", err)
+	adj, _, err := s.creditTopUp(ctx, tx, req.Amount, adjustment)
+	if err != nil {
+		s.failTransaction(ctx, tx)
+		return nil, err
 	}
 
-	tx.Complete(wallet.Balance)
+	return s.topUpCompleted(ctx, wallet.UserID, tx, adj, charged, adjustment), nil
+}
+
+// awaitGateway leaves a top-up pending until the gateway's webhook confirms
+// it, and hands the client the redirect that lets the user approve it
+func (s *WalletService) awaitGateway(ctx context.Context, tx *domain.Transaction, gatewayResp *ports.TopUpResponse) (*TransactionResponse, error) {
+	tx.AwaitGateway(gatewayResp.TransactionID)
 	if err := s.transactions.Update(ctx, tx); err != nil {
-		s.logger.Error("failed to update transaction status", ports.Err(err))
+		return nil, fmt.Errorf("failed to record gateway reference: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("top-up awaiting gateway confirmation",
+		ports.String("transaction_id", tx.ID.String()),
+		ports.String("gateway_reference", gatewayResp.TransactionID),
+	)
+
+	resp := toTransactionResponse(tx)
+	if gatewayResp.Redirect != nil {
+		resp.Redirect = &PaymentRedirect{
+			URL:    gatewayResp.Redirect.URL,
+			Method: gatewayResp.Redirect.Method,
+			Fields: gatewayResp.Redirect.Fields,
+		}
+	}
+	return resp, nil
+}
+
+// ConfirmTopUp settles a pending top-up from a verified gateway webhook.
+// Repeated notifications for a settled top-up return it unchanged.
+func (s *WalletService) ConfirmTopUp(ctx context.Context, event *ports.GatewayEvent) (*TransactionResponse, error) {
+	s.logger.WithContext(ctx).Info("processing gateway webhook",
+		ports.String("gateway", event.Gateway),
+		ports.String("reference", event.Reference),
+		ports.String("status", event.Status),
+	)
+
+	id, err := uuid.Parse(event.Reference)
+	if err != nil {
+		return nil, domain.ErrTransactionNotFound
+	}
+	tx, err := s.transactions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Type != domain.TransactionTypeTopUp {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if !tx.IsPending() {
+		return toTransactionResponse(tx), nil
+	}
+
+	switch event.Status {
+	case ports.GatewayStatusSuccess:
+		captured := event.Amount
+		if captured.IsZero() {
+			captured = tx.Amount
+		}
+		adjustment := captured.Sub(tx.Amount)
+
+		// The gateway has taken the money, so a failure here is left for the
+		// webhook retry rather than marking the top-up failed
+		adj, userID, err := s.creditTopUp(ctx, tx, tx.Amount, adjustment)
+		if errors.Is(err, errTopUpSettled) {
+			return s.settledTopUp(ctx, tx.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return s.topUpCompleted(ctx, userID, tx, adj, captured, adjustment), nil
+
+	case ports.GatewayStatusFailed:
+		err := s.atomically(ctx, func(uow ports.Transaction) error {
+			if _, err := uow.Wallets().GetByIDForUpdate(ctx, tx.WalletID); err != nil {
+				return err
+			}
+			current, err := uow.Transactions().GetByID(ctx, tx.ID)
+			if err != nil {
+				return err
+			}
+			if !current.IsPending() {
+				return errTopUpSettled
+			}
+			current.BalanceAfter = current.BalanceBefore
+			current.Fail()
+			tx = current
+			return uow.Transactions().Update(ctx, current)
+		})
+		if errors.Is(err, errTopUpSettled) {
+			return s.settledTopUp(ctx, tx.ID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to mark top-up failed: %w", err)
+		}
+		s.logger.WithContext(ctx).Warn("top-up failed at payment gateway",
+			ports.String("transaction_id", tx.ID.String()),
+			ports.String("message", event.Message),
+		)
+		return toTransactionResponse(tx), nil
+
+	default:
+		return toTransactionResponse(tx), nil
+	}
+}
+
+// creditTopUp credits a pending top-up to its wallet and books it, plus any
+// rounding adjustment, in the ledger. It also returns the wallet's owner.
+func (s *WalletService) creditTopUp(ctx context.Context, tx *domain.Transaction, amount, adjustment decimal.Decimal) (*domain.Transaction, uuid.UUID, error) {
+	var adj *domain.Transaction
+	var userID uuid.UUID
+	err := s.atomically(ctx, func(uow ports.Transaction) error {
+		adj = nil
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, tx.WalletID)
+		if err != nil {
+			return err
+		}
+		userID = locked.UserID
+		// Under the wallet lock, so a duplicate webhook cannot credit twice
+		current, err := uow.Transactions().GetByID(ctx, tx.ID)
+		if err != nil {
+			return err
+		}
+		if !current.IsPending() {
+			return errTopUpSettled
+		}
+
+		balanceBefore := locked.Balance
+		if err := locked.Credit(amount.Add(adjustment)); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+
+		tx.BalanceBefore = balanceBefore
+		tx.Complete(balanceBefore.Add(amount))
+		if err := uow.Transactions().Update(ctx, tx); err != nil {
+			return fmt.Errorf("failed to complete transaction: %w", err)
+		}
+		if err := post(ctx, uow, tx); err != nil {
+			return err
+		}
+
+		// The rounding difference is posted in the same unit of work so the
+		// ledger always sums to the wallet balance
+		if !adjustment.IsZero() {
+			adj = domain.NewRoundingAdjustment(tx, adjustment)
+			if err := uow.Transactions().Create(ctx, adj); err != nil {
+				return fmt.Errorf("failed to record rounding adjustment: %w", err)
+			}
+			if err := post(ctx, uow, adj); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return adj, userID, err
+}
+
+func (s *WalletService) settledTopUp(ctx context.Context, id uuid.UUID) (*TransactionResponse, error) {
+	tx, err := s.transactions.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toTransactionResponse(tx), nil
+}
+
+// topUpCompleted builds the response for a credited top-up and announces it
+func (s *WalletService) topUpCompleted(ctx context.Context, userID uuid.UUID, tx, adj *domain.Transaction, charged, adjustment decimal.Decimal) *TransactionResponse {
+	resp := toTransactionResponse(tx)
+	if adj != nil {
+		s.publishRoundingAdjusted(ctx, tx, adj, adjustment)
+		resp.ChargedAmount = &charged
+		resp.RoundingAdjustment = &adjustment
 	}
 
 	go func() {
@@ -200,18 +616,62 @@ func (s *WalletService) TopUp(ctx context.Context, req TopUpRequest) (*Transacti
 			Type: ports.EventTopUpCompleted,
 			Payload: map[string]interface{}{
 				"transaction_id": tx.ID.String(),
-				"wallet_id":      wallet.ID.String(),
-				"amount":         req.Amount.String(),
+				"wallet_id":      tx.WalletID.String(),
+				"user_id":        userID.String(),
+				"amount":         charged.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+
+	return resp
+}
+
+// publishRoundingAdjusted announces the ledger entry that makes the parent
+// amount plus its adjustment equal what the gateway captured
+func (s *WalletService) publishRoundingAdjusted(ctx context.Context, parent, adj *domain.Transaction, adjustment decimal.Decimal) {
+	go func() {
+		event := ports.Event{
+			Type: ports.EventRoundingAdjusted,
+			Payload: map[string]interface{}{
+				"transaction_id":        adj.ID.String(),
+				"parent_transaction_id": parent.ID.String(),
+				"wallet_id":             adj.WalletID.String(),
+				"adjustment":            adjustment.String(),
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
+}
 
-	return s.toTransactionResponse(tx), nil
+// chargeTopUp asks the gateway for the money. The response is either a
+// capture or a pending charge the user still has to approve.
+func (s *WalletService) chargeTopUp(ctx context.Context, wallet *domain.Wallet, tx *domain.Transaction, req TopUpRequest, amount decimal.Decimal) (*ports.TopUpResponse, error) {
+	resp, err := s.gateway.ProcessTopUp(ctx, ports.TopUpRequest{
+		Amount:         amount,
+		Currency:       wallet.Currency,
+		PaymentMethod:  req.PaymentMethod,
+		Token:          req.Token,
+		UserID:         wallet.UserID.String(),
+		IdempotencyKey: req.IdempotencyKey,
+		Reference:      tx.ID.String(),
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).Error("payment gateway top-up failed", ports.Err(err))
+		return nil, fmt.Errorf("failed to process top-up: %w", err)
+	}
+	if resp.Status != ports.GatewayStatusSuccess && resp.Status != ports.GatewayStatusPending {
+		s.logger.WithContext(ctx).Warn("top-up declined by payment gateway",
+			ports.String("wallet_id", wallet.ID.String()),
+			ports.String("message", resp.Message),
+		)
+		return nil, domain.ErrTopUpDeclined
+	}
+	return resp, nil
 }
 
 func (s *WalletService) Pay(ctx context.Context, req PaymentRequest) (*TransactionResponse, error) {
-	s.logger.Info("processing payment",
+	s.logger.WithContext(ctx).Info("processing payment",
 		ports.String("wallet_id", req.WalletID.String()),
 		ports.String("amount", req.Amount.String()),
 	)
@@ -219,10 +679,15 @@ func (s *WalletService) Pay(ctx context.Context, req PaymentRequest) (*Transacti
 	if req.Amount.LessThanOrEqual(decimal.Zero) {
 		return nil, domain.ErrInvalidAmount
 	}
+	if req.Category != "" {
+		if _, err := domain.ParseCategory(string(req.Category)); err != nil {
+			return nil, err
+		}
+	}
 
 	existingTx, err := s.transactions.GetByIdempotencyKey(ctx, req.IdempotencyKey)
 	if err == nil && existingTx != nil {
-		return s.toTransactionResponse(existingTx), nil
+		return toTransactionResponse(existingTx), nil
 	}
 
 	wallet, err := s.wallets.GetByID(ctx, req.WalletID)
@@ -234,10 +699,46 @@ func (s *WalletService) Pay(ctx context.Context, req PaymentRequest) (*Transacti
 		return nil, domain.ErrWalletInactive
 	}
 
+	org, member, err := s.orgPayer(ctx, wallet, req)
+	if err != nil {
+		return nil, err
+	}
+	holderID := wallet.UserID
+	if org != nil {
+		holderID = org.OwnerID
+	}
+
+	if req.Interactive {
+		if err := s.checkPIN(ctx, wallet, req.PIN); err != nil {
+			return nil, err
+		}
+	}
+
 	if !wallet.HasSufficientBalance(req.Amount) {
 		return nil, domain.ErrInsufficientBalance
 	}
 
+	kycLimits, err := s.kycLimits(ctx, holderID)
+	if err != nil {
+		return nil, err
+	}
+	if err := kycLimits.CheckPayment(req.Amount); err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending limits: %w", err)
+	}
+	if err := limits.CheckAmount(req.Amount); err != nil {
+		s.publishLimitReached(ctx, wallet, limits, req.Amount, err)
+		return nil, err
+	}
+	risk, err := s.assessRisk(ctx, wallet, domain.TransactionTypePayment, req.Amount, req.DeviceID)
+	if err := s.confirmStepUp(ctx, wallet, domain.TransactionTypePayment, req.Amount, req.StepUpToken, req.Interactive, err); err != nil {
+		return nil, err
+	}
+
 	tx := domain.NewTransaction(
 		wallet.ID,
 		domain.TransactionTypePayment,
@@ -248,26 +749,60 @@ func (s *WalletService) Pay(ctx context.Context, req PaymentRequest) (*Transacti
 		req.Description,
 	)
 	tx.SetProvider(req.ProviderID)
+	tx.SetRisk(req.DeviceID, risk)
+	if req.Category != "" {
+		tx.Category = req.Category
+	}
+	if member != nil {
+		tx.MemberID = &member.UserID
+	}
+	tx.ApplyTaxes(req.Taxes)
+	if err := applyFee(ctx, s.fees, tx); err != nil {
+		return nil, err
+	}
 
 	if err := s.transactions.Create(ctx, tx); err != nil {
 		return nil, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
-	if err := wallet.Debit(req.Amount); err != nil {
-		tx.Fail()
-		s.transactions.Update(ctx, tx)
-		return nil, err
-	}
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, wallet.ID)
+		if err != nil {
+			return err
+		}
+		// Checked under the lock so concurrent payments cannot both fit
+		// under the same limit
+		if limits.HasPeriodLimits() {
+			if err := checkSpending(ctx, uow, limits, req.Amount); err != nil {
+				return err
+			}
+		}
+		if member != nil && member.MonthlyLimit != nil {
+			if err := checkMemberSpending(ctx, uow, member, wallet.ID, req.Amount); err != nil {
+				return err
+			}
+		}
 
-	if err := s.wallets.Update(ctx, wallet); err != nil {
-		tx.Fail()
-		s.transactions.Update(ctx, tx)
-		return nil, fmt.Errorf("failed to update wallet: %w", err)
-	}
+		balanceBefore := locked.Balance
+		// Re-checked under the lock: the balance may have changed since the read above
+		if err := locked.Debit(req.Amount); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
 
-	tx.Complete(wallet.Balance)
-	if err := s.transactions.Update(ctx, tx); err != nil {
-		s.logger.Error("failed to update transaction status", ports.Err(err))
+		tx.BalanceBefore = balanceBefore
+		tx.Complete(locked.Balance)
+		if err := uow.Transactions().Update(ctx, tx); err != nil {
+			return fmt.Errorf("failed to complete transaction: %w", err)
+		}
+		return post(ctx, uow, tx)
+	})
+	if err != nil {
+		s.failTransaction(ctx, tx)
+		s.publishLimitReached(ctx, wallet, limits, req.Amount, err)
+		return nil, err
 	}
 
 	go func() {
@@ -276,17 +811,412 @@ func (s *WalletService) Pay(ctx context.Context, req PaymentRequest) (*Transacti
 			Payload: map[string]interface{}{
 				"transaction_id": tx.ID.String(),
 				"wallet_id":      wallet.ID.String(),
+				"user_id":        wallet.UserID.String(),
 				"provider_id":    req.ProviderID.String(),
 				"amount":         req.Amount.String(),
 			},
 		}
-		s.events.Publish(context.Background(), event)
+		if member != nil {
+			event.Payload["member_id"] = member.UserID.String()
+		}
+		event.Payload["tax_amount"] = decimal.Zero.StringFixed(2)
+		if tx.TaxAmount != nil {
+			event.Payload["tax_amount"] = tx.TaxAmount.StringFixed(2)
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
 	}()
+	s.alertLowBalance(ctx, wallet, tx)
+	// Members spending their organization's money earn nothing
+	if member == nil {
+		earnPoints(ctx, s.loyalty, s.logger, wallet.UserID, tx)
+		rewardReferral(ctx, s.referrals, s.logger, wallet.UserID)
+	}
 
-	return s.toTransactionResponse(tx), nil
+	return toTransactionResponse(tx), nil
 }
 
-func (s *WalletService) GetTransactions(ctx context.Context, walletID uuid.UUID, limit, offset int) (*TransactionListResponse, error) {
+// alertLowBalance tells the user when a completed debit took their balance
+// below their alert threshold. The alert is looked up off the request path;
+// a failed lookup only skips the alert.
+func (s *WalletService) alertLowBalance(ctx context.Context, wallet *domain.Wallet, tx *domain.Transaction) {
+	if s.alerts == nil {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		alert, err := s.alerts.GetByWalletID(ctx, wallet.ID)
+		if err != nil {
+			s.logger.WithContext(ctx).Warn("failed to get balance alert",
+				ports.String("wallet_id", wallet.ID.String()),
+				ports.Err(err),
+			)
+			return
+		}
+		if !alert.Crossed(tx.BalanceBefore, tx.BalanceAfter) {
+			return
+		}
+
+		event := ports.Event{
+			Type: ports.EventBalanceLow,
+			Payload: map[string]interface{}{
+				"wallet_id":      wallet.ID.String(),
+				"user_id":        wallet.UserID.String(),
+				"transaction_id": tx.ID.String(),
+				"balance":        tx.BalanceAfter.StringFixed(2),
+				"threshold":      alert.Threshold.StringFixed(2),
+				"currency":       wallet.Currency,
+			},
+		}
+		s.events.Publish(ctx, event)
+	}()
+}
+
+// holder returns the user whose KYC level caps the wallet: the owner for
+// an organization wallet, otherwise the wallet's own user
+func (s *WalletService) holder(ctx context.Context, wallet *domain.Wallet) (uuid.UUID, error) {
+	if s.orgs == nil {
+		return wallet.UserID, nil
+	}
+	org, err := s.orgs.GetByWalletID(ctx, wallet.ID)
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		return wallet.UserID, nil
+	case err != nil:
+		return uuid.Nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org.OwnerID, nil
+}
+
+// orgPayer returns the organization owning the wallet, if any, and the
+// member the payment is charged to. Organization wallets pay only for their
+// members, and only when another service pays on the member's behalf; the
+// app can't pay from them directly.
+func (s *WalletService) orgPayer(ctx context.Context, wallet *domain.Wallet, req PaymentRequest) (*domain.Organization, *domain.OrgMember, error) {
+	var org *domain.Organization
+	if s.orgs != nil {
+		var err error
+		org, err = s.orgs.GetByWalletID(ctx, wallet.ID)
+		if err != nil && !errors.Is(err, domain.ErrOrganizationNotFound) {
+			return nil, nil, fmt.Errorf("failed to get organization: %w", err)
+		}
+	}
+	if org == nil {
+		if req.MemberID != nil {
+			return nil, nil, domain.ErrNotOrgMember
+		}
+		return nil, nil, nil
+	}
+
+	if req.MemberID == nil || req.Interactive {
+		return nil, nil, domain.ErrNotOrgMember
+	}
+	member, err := s.orgs.GetMember(ctx, org.ID, *req.MemberID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return org, member, nil
+}
+
+// checkMemberSpending enforces a member's monthly limit against what they
+// charged to the organization wallet this month
+func checkMemberSpending(ctx context.Context, uow ports.Transaction, member *domain.OrgMember, walletID uuid.UUID, amount decimal.Decimal) error {
+	_, monthStart := domain.SpendingPeriods(time.Now())
+	spent, err := uow.Transactions().SumMemberCompletedSince(ctx, walletID, member.UserID, monthStart)
+	if err != nil {
+		return fmt.Errorf("failed to sum member payments: %w", err)
+	}
+	return member.CheckLimit(amount, spent)
+}
+
+// checkSpending enforces daily and monthly limits against the payments the
+// wallet completed in the current periods
+func checkSpending(ctx context.Context, uow ports.Transaction, limits *domain.SpendingLimits, amount decimal.Decimal) error {
+	spentToday, spentThisMonth, err := spentSince(ctx, uow.Transactions(), limits.WalletID, time.Now())
+	if err != nil {
+		return err
+	}
+	return limits.Check(amount, spentToday, spentThisMonth)
+}
+
+func spentSince(ctx context.Context, transactions ports.TransactionRepository, walletID uuid.UUID, now time.Time) (decimal.Decimal, decimal.Decimal, error) {
+	dayStart, monthStart := domain.SpendingPeriods(now)
+	spentToday, err := transactions.SumCompletedSince(ctx, walletID, domain.TransactionTypePayment, dayStart)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("failed to sum today's payments: %w", err)
+	}
+	spentThisMonth, err := transactions.SumCompletedSince(ctx, walletID, domain.TransactionTypePayment, monthStart)
+	if err != nil {
+		return decimal.Zero, decimal.Zero, fmt.Errorf("failed to sum this month's payments: %w", err)
+	}
+	return spentToday, spentThisMonth, nil
+}
+
+// publishLimitReached tells the user's notification channels that a payment
+// was refused by one of their spending limits. Other errors are ignored.
+func (s *WalletService) publishLimitReached(ctx context.Context, wallet *domain.Wallet, limits *domain.SpendingLimits, amount decimal.Decimal, err error) {
+	limitType, limit, ok := limits.Limit(err)
+	if !ok {
+		return
+	}
+
+	s.logger.WithContext(ctx).Info("payment refused by spending limit",
+		ports.String("wallet_id", wallet.ID.String()),
+		ports.String("limit_type", string(limitType)),
+	)
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventSpendingLimitReached,
+			Payload: map[string]interface{}{
+				"wallet_id":  wallet.ID.String(),
+				"user_id":    wallet.UserID.String(),
+				"limit_type": string(limitType),
+				"limit":      limit.String(),
+				"amount":     amount.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+}
+
+// GetSpendingLimits returns the limits on a user's wallet and what has been
+// spent against them
+func (s *WalletService) GetSpendingLimits(ctx context.Context, userID uuid.UUID) (*SpendingLimitsResponse, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := s.limits.GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spending limits: %w", err)
+	}
+	return s.toSpendingLimitsResponse(ctx, limits)
+}
+
+// SetSpendingLimits replaces the limits on a user's wallet
+func (s *WalletService) SetSpendingLimits(ctx context.Context, userID uuid.UUID, req SpendingLimitsRequest) (*SpendingLimitsResponse, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := domain.NewSpendingLimits(wallet.ID, req.PerTransactionLimit, req.DailyLimit, req.MonthlyLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.limits.Save(ctx, limits); err != nil {
+		return nil, fmt.Errorf("failed to save spending limits: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("spending limits updated", ports.String("wallet_id", wallet.ID.String()))
+	return s.toSpendingLimitsResponse(ctx, limits)
+}
+
+func (s *WalletService) toSpendingLimitsResponse(ctx context.Context, limits *domain.SpendingLimits) (*SpendingLimitsResponse, error) {
+	spentToday, spentThisMonth, err := spentSince(ctx, s.transactions, limits.WalletID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &SpendingLimitsResponse{
+		SpendingLimits: limits,
+		SpentToday:     spentToday,
+		SpentThisMonth: spentThisMonth,
+	}, nil
+}
+
+// GetBalanceAlert returns the low-balance alert on a user's wallet
+func (s *WalletService) GetBalanceAlert(ctx context.Context, userID uuid.UUID) (*domain.BalanceAlert, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err := s.alerts.GetByWalletID(ctx, wallet.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance alert: %w", err)
+	}
+	return alert, nil
+}
+
+// SetBalanceAlert replaces the low-balance alert on a user's wallet
+func (s *WalletService) SetBalanceAlert(ctx context.Context, userID uuid.UUID, req BalanceAlertRequest) (*domain.BalanceAlert, error) {
+	wallet, err := s.wallets.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	alert, err := domain.NewBalanceAlert(wallet.ID, req.Threshold)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.alerts.Save(ctx, alert); err != nil {
+		return nil, fmt.Errorf("failed to save balance alert: %w", err)
+	}
+
+	s.logger.WithContext(ctx).Info("balance alert updated", ports.String("wallet_id", wallet.ID.String()))
+	return alert, nil
+}
+
+// Refund returns a completed payment's amount to its wallet and marks the
+// payment refunded. A payment can be refunded once.
+func (s *WalletService) Refund(ctx context.Context, req RefundRequest) (*TransactionResponse, error) {
+	s.logger.WithContext(ctx).Info("processing refund", ports.String("transaction_id", req.TransactionID.String()))
+
+	existingTx, err := s.transactions.GetByIdempotencyKey(ctx, req.IdempotencyKey)
+	if err == nil && existingTx != nil {
+		return toTransactionResponse(existingTx), nil
+	}
+
+	payment, err := s.transactions.GetByID(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !payment.CanRefund() {
+		return nil, domain.ErrNotRefundable
+	}
+
+	refund := domain.NewRefund(payment, req.IdempotencyKey, req.Reason)
+	if err := s.transactions.Create(ctx, refund); err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	var userID uuid.UUID
+	err = s.atomically(ctx, func(uow ports.Transaction) error {
+		locked, err := uow.Wallets().GetByIDForUpdate(ctx, payment.WalletID)
+		if err != nil {
+			return err
+		}
+		userID = locked.UserID
+
+		// Every change to the payment happens under its wallet's lock, so
+		// this read cannot race with a concurrent refund
+		current, err := uow.Transactions().GetByID(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+		// A reversed payment has already been returned
+		if _, err := uow.Transactions().GetByIdempotencyKey(ctx, domain.ReversalKey(payment.ID)); err == nil {
+			return domain.ErrNotRefundable
+		}
+		if err := current.MarkRefunded(); err != nil {
+			return err
+		}
+
+		balanceBefore := locked.Balance
+		if err := locked.Credit(current.Amount); err != nil {
+			return err
+		}
+		if err := uow.Wallets().Update(ctx, locked); err != nil {
+			return fmt.Errorf("failed to update wallet: %w", err)
+		}
+		if err := uow.Transactions().Update(ctx, current); err != nil {
+			return fmt.Errorf("failed to mark payment refunded: %w", err)
+		}
+
+		refund.BalanceBefore = balanceBefore
+		refund.Complete(locked.Balance)
+		if err := uow.Transactions().Update(ctx, refund); err != nil {
+			return fmt.Errorf("failed to complete transaction: %w", err)
+		}
+		return post(ctx, uow, refund)
+	})
+	if err != nil {
+		s.failTransaction(ctx, refund)
+		return nil, err
+	}
+
+	go func() {
+		event := ports.Event{
+			Type: ports.EventRefundCompleted,
+			Payload: map[string]interface{}{
+				"transaction_id":        refund.ID.String(),
+				"parent_transaction_id": payment.ID.String(),
+				"wallet_id":             refund.WalletID.String(),
+				"user_id":               userID.String(),
+				"amount":                refund.Amount.String(),
+			},
+		}
+		s.events.Publish(context.WithoutCancel(ctx), event)
+	}()
+	forfeitPoints(ctx, s.loyalty, s.logger, payment.ID)
+
+	return toTransactionResponse(refund), nil
+}
+
+// atomically runs fn in a unit of work, holding the wallet row lock from the
+// read to the write. Without a unit of work fn runs on the plain repositories.
+func (s *WalletService) atomically(ctx context.Context, fn func(uow ports.Transaction) error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if s.uow == nil {
+			err = fn(repositories{wallets: s.wallets, transactions: s.transactions, ledger: s.ledger})
+		} else {
+			err = s.uow.Execute(ctx, fn)
+		}
+		// Losing an optimistic-lock race leaves nothing written, so the unit
+		// of work can be replayed against the fresh wallet row
+		if !errors.Is(err, domain.ErrConcurrentModification) || attempt > maxConcurrencyRetries {
+			return err
+		}
+		s.logger.WithContext(ctx).Warn("retrying after concurrent wallet update",
+			ports.Any("attempt", attempt),
+		)
+	}
+}
+
+// post books a completed transaction in the ledger, in the same unit of work
+// that moved the wallet balance
+func post(ctx context.Context, uow ports.Transaction, tx *domain.Transaction) error {
+	entries := domain.NewPosting(tx)
+	if entries == nil {
+		return nil
+	}
+	if err := uow.Ledger().Append(ctx, entries); err != nil {
+		return fmt.Errorf("failed to post ledger entries: %w", err)
+	}
+	return nil
+}
+
+// failTransaction records that a pending transaction did not move any money
+func (s *WalletService) failTransaction(ctx context.Context, tx *domain.Transaction) {
+	tx.BalanceAfter = tx.BalanceBefore
+	tx.Fail()
+	if err := s.transactions.Update(ctx, tx); err != nil {
+		s.logger.WithContext(ctx).Error("failed to mark transaction failed",
+			ports.String("transaction_id", tx.ID.String()),
+			ports.Err(err),
+		)
+	}
+}
+
+type repositories struct {
+	wallets      ports.WalletRepository
+	transactions ports.TransactionRepository
+	ledger       ports.LedgerRepository
+	promotions   ports.PromotionRepository
+	holds        ports.HoldRepository
+	loyalty      ports.LoyaltyRepository
+	referrals    ports.ReferralRepository
+}
+
+func (r repositories) Wallets() ports.WalletRepository {
+	return r.wallets
+}
+
+func (r repositories) Transactions() ports.TransactionRepository {
+	return r.transactions
+}
+
+func (r repositories) Ledger() ports.LedgerRepository {
+	return r.ledger
+}
+
+func (r repositories) Promotions() ports.PromotionRepository {
+	return r.promotions
+}
+
+func (s *WalletService) GetTransactions(ctx context.Context, walletID uuid.UUID, filter domain.TransactionFilter, limit, offset int) (*TransactionListResponse, error) {
 	if limit <= 0 {
 		limit = 20
 	}
@@ -294,19 +1224,19 @@ func (s *WalletService) GetTransactions(ctx context.Context, walletID uuid.UUID,
 		limit = 100
 	}
 
-	transactions, err := s.transactions.GetByWalletID(ctx, walletID, limit, offset)
+	transactions, err := s.transactions.GetByWalletID(ctx, walletID, filter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 
-	total, err := s.transactions.CountByWalletID(ctx, walletID)
+	total, err := s.transactions.CountByWalletID(ctx, walletID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count transactions: %w", err)
 	}
 
 	var txResponses []*TransactionResponse
 	for _, tx := range transactions {
-		txResponses = append(txResponses, s.toTransactionResponse(tx))
+		txResponses = append(txResponses, toTransactionResponse(tx))
 	}
 
 	return &TransactionListResponse{
@@ -317,7 +1247,72 @@ func (s *WalletService) GetTransactions(ctx context.Context, walletID uuid.UUID,
 	}, nil
 }
 
-func (s *WalletService) toTransactionResponse(tx *domain.Transaction) *TransactionResponse {
+// TagTransaction replaces the tags on one of the user's transactions
+func (s *WalletService) TagTransaction(ctx context.Context, userID, txID uuid.UUID, tags []string) (*TransactionResponse, error) {
+	tx, err := s.transactions.GetByID(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetUserWallet(ctx, userID, tx.WalletID); err != nil {
+		if errors.Is(err, domain.ErrWalletNotFound) {
+			return nil, domain.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	if err := tx.SetTags(tags); err != nil {
+		return nil, err
+	}
+	if err := s.transactions.UpdateTags(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to save tags: %w", err)
+	}
+	return toTransactionResponse(tx), nil
+}
+
+// GetSpendingBreakdown totals one of the user's wallets by category over
+// [from, to). A nil walletID means their default wallet.
+func (s *WalletService) GetSpendingBreakdown(ctx context.Context, userID, walletID uuid.UUID, from, to time.Time) (*domain.SpendingBreakdown, error) {
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+	wallet, err := s.GetUserWallet(ctx, userID, walletID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := s.transactions.SpendingByCategory(ctx, wallet.ID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total spending: %w", err)
+	}
+	return domain.NewSpendingBreakdown(wallet.ID, from, to, categories), nil
+}
+
+// ListPayments returns payment transactions created in [from, to)
+func (s *WalletService) ListPayments(ctx context.Context, from, to time.Time) ([]*PaymentRecord, error) {
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidTimeRange
+	}
+
+	transactions, err := s.transactions.GetByTypeBetween(ctx, domain.TransactionTypePayment, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list payments: %w", err)
+	}
+
+	records := make([]*PaymentRecord, len(transactions))
+	for i, tx := range transactions {
+		records[i] = &PaymentRecord{
+			TransactionID:  tx.ID,
+			ReferenceID:    tx.ReferenceID,
+			IdempotencyKey: tx.IdempotencyKey,
+			Amount:         tx.Amount,
+			Status:         string(tx.Status),
+			CreatedAt:      tx.CreatedAt,
+		}
+	}
+	return records, nil
+}
+
+func toTransactionResponse(tx *domain.Transaction) *TransactionResponse {
 	return &TransactionResponse{
 		ID:            tx.ID,
 		Type:          string(tx.Type),
@@ -327,5 +1322,21 @@ func (s *WalletService) toTransactionResponse(tx *domain.Transaction) *Transacti
 		Status:        string(tx.Status),
 		Description:   tx.Description,
 		CreatedAt:     tx.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		Category:      string(tx.Category),
+		Tags:          tx.Tags,
+		TaxAmount:     tx.TaxAmount,
+		Taxes:         tx.Taxes,
 	}
 }
+
+func (r repositories) Holds() ports.HoldRepository {
+	return r.holds
+}
+
+func (r repositories) Loyalty() ports.LoyaltyRepository {
+	return r.loyalty
+}
+
+func (r repositories) Referrals() ports.ReferralRepository {
+	return r.referrals
+}
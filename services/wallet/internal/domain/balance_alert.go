@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrInvalidThreshold = errors.New("low-balance threshold must be positive")
+
+// BalanceAlert tells the user when their balance falls below a threshold.
+// A nil threshold turns the alert off.
+type BalanceAlert struct {
+	WalletID  uuid.UUID        `json:"wallet_id"`
+	Threshold *decimal.Decimal `json:"threshold"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// NewBalanceAlert validates and returns a wallet's low-balance alert
+func NewBalanceAlert(walletID uuid.UUID, threshold *decimal.Decimal) (*BalanceAlert, error) {
+	if threshold != nil && !threshold.IsPositive() {
+		return nil, ErrInvalidThreshold
+	}
+	return &BalanceAlert{
+		WalletID:  walletID,
+		Threshold: threshold,
+		UpdatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// Crossed reports whether a debit took the balance from at or above the
+// threshold to below it. Debits while already below do not alert again.
+func (a *BalanceAlert) Crossed(before, after decimal.Decimal) bool {
+	if a.Threshold == nil {
+		return false
+	}
+	return before.GreaterThanOrEqual(*a.Threshold) && after.LessThan(*a.Threshold)
+}
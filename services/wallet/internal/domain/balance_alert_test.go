@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewBalanceAlert(t *testing.T) {
+	zero := decimal.Zero
+	if _, err := NewBalanceAlert(uuid.New(), &zero); !errors.Is(err, ErrInvalidThreshold) {
+		t.Errorf("NewBalanceAlert(0) error = %v, want %v", err, ErrInvalidThreshold)
+	}
+	if _, err := NewBalanceAlert(uuid.New(), nil); err != nil {
+		t.Errorf("NewBalanceAlert(nil) error = %v, want the alert turned off", err)
+	}
+}
+
+func TestBalanceAlert_Crossed(t *testing.T) {
+	threshold := decimal.RequireFromString("20")
+	alert, err := NewBalanceAlert(uuid.New(), &threshold)
+	if err != nil {
+		t.Fatalf("NewBalanceAlert() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   bool
+	}{
+		{"stays above", "50", "30", false},
+		{"lands on the threshold", "50", "20", false},
+		{"drops below", "50", "19.99", true},
+		{"starts on the threshold", "20", "15", true},
+		{"already below", "15", "10", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alert.Crossed(decimal.RequireFromString(tt.before), decimal.RequireFromString(tt.after))
+			if got != tt.want {
+				t.Errorf("Crossed(%s, %s) = %v, want %v", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+
+	off := &BalanceAlert{WalletID: alert.WalletID}
+	if off.Crossed(decimal.RequireFromString("50"), decimal.Zero) {
+		t.Error("an alert without a threshold should never fire")
+	}
+}
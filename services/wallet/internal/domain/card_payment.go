@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrCardPaymentNotFound = errors.New("card payment not found")
+	ErrCardPaymentExists   = errors.New("a card payment with this idempotency key already exists")
+	ErrCardTokenRequired   = errors.New("a card token is required")
+	ErrCardDeclined        = errors.New("card payment was declined by the payment gateway")
+	ErrCardIdempotencyKey  = errors.New("card payments need an idempotency key")
+)
+
+type CardPaymentStatus string
+
+const (
+	CardPaymentStatusPending   CardPaymentStatus = "pending"
+	CardPaymentStatusCompleted CardPaymentStatus = "completed"
+	CardPaymentStatusFailed    CardPaymentStatus = "failed"
+)
+
+// CardPayment is a payment charged straight to a card instead of a wallet,
+// for guests parking without an account
+type CardPayment struct {
+	ID             uuid.UUID         `json:"id"`
+	Amount         decimal.Decimal   `json:"amount"`
+	Currency       string            `json:"currency"`
+	ProviderID     *uuid.UUID        `json:"provider_id,omitempty"`
+	ReferenceID    string            `json:"reference_id"`
+	Description    string            `json:"description"`
+	IdempotencyKey string            `json:"-"`
+	Status         CardPaymentStatus `json:"status"`
+	// GatewayReference is the gateway's ID for the charge, once it is made
+	GatewayReference string `json:"gateway_reference,omitempty"`
+	FailureReason    string `json:"failure_reason,omitempty"`
+	// Taxes included in Amount
+	TaxAmount *decimal.Decimal `json:"tax_amount,omitempty"`
+	Taxes     []TaxLine        `json:"taxes,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// NewCardPayment starts a card payment. It is pending until the gateway
+// answers; the idempotency key stops a retry charging the card twice.
+func NewCardPayment(amount decimal.Decimal, currency string, providerID uuid.UUID, referenceID, description, idempotencyKey string) (*CardPayment, error) {
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+	idempotencyKey = strings.TrimSpace(idempotencyKey)
+	if idempotencyKey == "" {
+		return nil, ErrCardIdempotencyKey
+	}
+	if currency == "" {
+		currency = "MYR"
+	}
+	now := time.Now().UTC()
+	p := &CardPayment{
+		ID:             uuid.New(),
+		Amount:         amount,
+		Currency:       currency,
+		ReferenceID:    referenceID,
+		Description:    description,
+		IdempotencyKey: idempotencyKey,
+		Status:         CardPaymentStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if providerID != uuid.Nil {
+		p.ProviderID = &providerID
+	}
+	return p, nil
+}
+
+// ApplyTaxes records the taxes included in the payment's amount
+func (p *CardPayment) ApplyTaxes(taxes []TaxLine) {
+	if len(taxes) == 0 {
+		return
+	}
+	total := decimal.Zero
+	for _, tax := range taxes {
+		total = total.Add(tax.Amount)
+	}
+	p.Taxes = taxes
+	p.TaxAmount = &total
+}
+
+// Complete records the gateway's successful charge
+func (p *CardPayment) Complete(gatewayReference string, now time.Time) {
+	p.Status = CardPaymentStatusCompleted
+	p.GatewayReference = gatewayReference
+	p.UpdatedAt = now
+}
+
+// Fail records why the gateway didn't charge the card
+func (p *CardPayment) Fail(reason string, now time.Time) {
+	p.Status = CardPaymentStatusFailed
+	p.FailureReason = reason
+	p.UpdatedAt = now
+}
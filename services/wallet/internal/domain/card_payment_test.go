@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewCardPayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     string
+		providerID uuid.UUID
+		key        string
+		wantErr    error
+	}{
+		{"provider payment", "5.40", uuid.New(), "key-1", nil},
+		{"no provider", "5.40", uuid.Nil, "key-1", nil},
+		{"zero amount", "0", uuid.New(), "key-1", ErrInvalidAmount},
+		{"negative amount", "-1", uuid.New(), "key-1", ErrInvalidAmount},
+		{"no idempotency key", "5.40", uuid.New(), " ", ErrCardIdempotencyKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewCardPayment(decimal.RequireFromString(tt.amount), "", tt.providerID, "session-1", "Parking", tt.key)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewCardPayment() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if p.Status != CardPaymentStatusPending || p.Currency != "MYR" {
+				t.Errorf("NewCardPayment() = %s %s, want pending MYR", p.Status, p.Currency)
+			}
+			if (p.ProviderID != nil) != (tt.providerID != uuid.Nil) {
+				t.Errorf("ProviderID = %v, want %v", p.ProviderID, tt.providerID)
+			}
+		})
+	}
+}
+
+func TestCardPayment_Outcome(t *testing.T) {
+	now := time.Now().UTC()
+	p, _ := NewCardPayment(decimal.RequireFromString("5.40"), "MYR", uuid.New(), "session-1", "Parking", "key-1")
+	p.ApplyTaxes([]TaxLine{{Name: "SST", Rate: decimal.RequireFromString("0.08"), Taxable: decimal.RequireFromString("5.00"), Amount: decimal.RequireFromString("0.40")}})
+	if p.TaxAmount == nil || !p.TaxAmount.Equal(decimal.RequireFromString("0.40")) {
+		t.Fatalf("TaxAmount = %v, want 0.40", p.TaxAmount)
+	}
+
+	p.Complete("ch_123", now)
+	if p.Status != CardPaymentStatusCompleted || p.GatewayReference != "ch_123" {
+		t.Errorf("after Complete = %s %q", p.Status, p.GatewayReference)
+	}
+
+	declined, _ := NewCardPayment(decimal.RequireFromString("5.40"), "MYR", uuid.New(), "session-2", "Parking", "key-2")
+	declined.Fail("insufficient funds", now)
+	if declined.Status != CardPaymentStatusFailed || declined.FailureReason != "insufficient funds" {
+		t.Errorf("after Fail = %s %q", declined.Status, declined.FailureReason)
+	}
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInvalidCategory = errors.New("invalid transaction category")
+	ErrInvalidTag      = errors.New("tags are up to 30 letters, digits, dashes or underscores")
+	ErrTooManyTags     = errors.New("too many tags on one transaction")
+)
+
+// MaxTransactionTags is how many tags one transaction can carry
+const MaxTransactionTags = 10
+
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,29}$`)
+
+// TransactionCategory groups transactions by what the money was for.
+// Credits such as promotions and rewards are left uncategorized.
+type TransactionCategory string
+
+const (
+	CategoryParking    TransactionCategory = "parking"
+	CategoryEVCharging TransactionCategory = "ev_charging"
+	CategoryFine       TransactionCategory = "fine"
+	CategoryTopUp      TransactionCategory = "topup"
+	CategoryTransfer   TransactionCategory = "transfer"
+)
+
+// Idempotency key prefixes callers give payments that are not for parking
+const (
+	finePaymentPrefix     = "compound-"
+	chargingPaymentPrefix = "charging-"
+)
+
+// ParseCategory validates a category name
+func ParseCategory(s string) (TransactionCategory, error) {
+	switch c := TransactionCategory(s); c {
+	case CategoryParking, CategoryEVCharging, CategoryFine, CategoryTopUp, CategoryTransfer:
+		return c, nil
+	}
+	return "", ErrInvalidCategory
+}
+
+// CategoryFor works out a new transaction's category from its type and, for
+// payments and holds, the idempotency key the paying service gave it.
+// Refunds, reversals and rounding adjustments take their parent's category.
+func CategoryFor(txType TransactionType, idempotencyKey string) TransactionCategory {
+	switch txType {
+	case TransactionTypeTopUp:
+		return CategoryTopUp
+	case TransactionTypeTransfer:
+		return CategoryTransfer
+	case TransactionTypePayment, TransactionTypeHold, TransactionTypeHoldRelease:
+		switch {
+		case strings.HasPrefix(idempotencyKey, finePaymentPrefix):
+			return CategoryFine
+		case strings.HasPrefix(idempotencyKey, chargingPaymentPrefix):
+			return CategoryEVCharging
+		}
+		return CategoryParking
+	}
+	return ""
+}
+
+// TransactionFilter narrows a wallet's transaction history. Zero fields
+// match every transaction.
+type TransactionFilter struct {
+	Category TransactionCategory
+	Tag      string
+}
+
+// SetTags replaces the transaction's tags. Tags are lowercased and
+// duplicates dropped; an empty list clears them.
+func (t *Transaction) SetTags(tags []string) error {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return ErrInvalidTag
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	if len(normalized) > MaxTransactionTags {
+		return ErrTooManyTags
+	}
+	t.Tags = normalized
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// CategorySpending totals one category's completed transactions in a period.
+// Refunds and reversals count against the category they came back from.
+type CategorySpending struct {
+	Category     TransactionCategory `json:"category"`
+	Transactions int                 `json:"transactions"`
+	Spent        decimal.Decimal     `json:"spent"`
+	Received     decimal.Decimal     `json:"received"`
+}
+
+// SpendingBreakdown is a wallet's money in and out by category
+type SpendingBreakdown struct {
+	WalletID      uuid.UUID          `json:"wallet_id"`
+	From          time.Time          `json:"from"`
+	To            time.Time          `json:"to"`
+	Categories    []CategorySpending `json:"categories"`
+	TotalSpent    decimal.Decimal    `json:"total_spent"`
+	TotalReceived decimal.Decimal    `json:"total_received"`
+}
+
+// NewSpendingBreakdown totals a wallet's categories for [from, to)
+func NewSpendingBreakdown(walletID uuid.UUID, from, to time.Time, categories []CategorySpending) *SpendingBreakdown {
+	b := &SpendingBreakdown{
+		WalletID:      walletID,
+		From:          from,
+		To:            to,
+		Categories:    categories,
+		TotalSpent:    decimal.Zero,
+		TotalReceived: decimal.Zero,
+	}
+	if b.Categories == nil {
+		b.Categories = []CategorySpending{}
+	}
+	for _, c := range b.Categories {
+		b.TotalSpent = b.TotalSpent.Add(c.Spent)
+		b.TotalReceived = b.TotalReceived.Add(c.Received)
+	}
+	return b
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestCategoryFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		txType TransactionType
+		key    string
+		want   TransactionCategory
+	}{
+		{"top-up", TransactionTypeTopUp, "", CategoryTopUp},
+		{"transfer", TransactionTypeTransfer, "", CategoryTransfer},
+		{"session payment", TransactionTypePayment, "parking-" + uuid.NewString(), CategoryParking},
+		{"compound payment", TransactionTypePayment, "compound-" + uuid.NewString(), CategoryFine},
+		{"charging payment", TransactionTypePayment, "charging-" + uuid.NewString(), CategoryEVCharging},
+		{"reservation hold", TransactionTypeHold, "reservation-" + uuid.NewString(), CategoryParking},
+		{"promo credit", TransactionTypePromoCredit, "", ""},
+		{"refund", TransactionTypeRefund, "dispute-" + uuid.NewString(), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CategoryFor(tt.txType, tt.key); got != tt.want {
+				t.Errorf("CategoryFor(%s, %q) = %q, want %q", tt.txType, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewRefund_KeepsCategory(t *testing.T) {
+	payment := NewTransaction(uuid.New(), TransactionTypePayment, decimal.NewFromInt(10), decimal.NewFromInt(50),
+		"ref", "compound-"+uuid.NewString(), "Compound")
+	payment.Complete(decimal.NewFromInt(40))
+
+	if refund := NewRefund(payment, "dispute-1", ""); refund.Category != CategoryFine {
+		t.Errorf("refund category = %q, want %q", refund.Category, CategoryFine)
+	}
+}
+
+func TestTransaction_SetTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr error
+	}{
+		{"normalized", []string{" Work ", "work", "klcc_trip"}, []string{"work", "klcc_trip"}, nil},
+		{"cleared", nil, []string{}, nil},
+		{"blank tag", []string{" "}, nil, ErrInvalidTag},
+		{"punctuation", []string{"work!"}, nil, ErrInvalidTag},
+		{"too long", []string{strings.Repeat("a", 31)}, nil, ErrInvalidTag},
+		{"too many", strings.Split("a,b,c,d,e,f,g,h,i,j,k", ","), nil, ErrTooManyTags},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := NewTransaction(uuid.New(), TransactionTypeTopUp, decimal.NewFromInt(10), decimal.Zero, "", "", "")
+			err := tx.SetTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetTags() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && strings.Join(tx.Tags, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("tags = %v, want %v", tx.Tags, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSpendingBreakdown(t *testing.T) {
+	now := time.Now()
+	b := NewSpendingBreakdown(uuid.New(), now.Add(-time.Hour), now, []CategorySpending{
+		{Category: CategoryParking, Transactions: 3, Spent: decimal.RequireFromString("24.50"), Received: decimal.RequireFromString("4.00")},
+		{Category: CategoryTopUp, Transactions: 1, Spent: decimal.Zero, Received: decimal.NewFromInt(100)},
+	})
+	if !b.TotalSpent.Equal(decimal.RequireFromString("24.50")) || !b.TotalReceived.Equal(decimal.NewFromInt(104)) {
+		t.Errorf("totals = %s spent, %s received, want 24.50 and 104", b.TotalSpent, b.TotalReceived)
+	}
+
+	if empty := NewSpendingBreakdown(uuid.New(), now.Add(-time.Hour), now, nil); empty.Categories == nil {
+		t.Error("an empty breakdown should list no categories, not null")
+	}
+}
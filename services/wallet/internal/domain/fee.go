@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrFeeScheduleNotFound = errors.New("provider has no fee schedule")
+	ErrInvalidFeeSchedule  = errors.New("fee percentage must be from 0 to 100 and the fixed fee not negative")
+	ErrFeeEffectiveInPast  = errors.New("fee changes cannot take effect in the past")
+	ErrFeeScheduleExists   = errors.New("provider already has a fee change taking effect at that time")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// FeeSchedule is the platform's commission on a provider's parking payments
+// from EffectiveFrom until the provider's next schedule takes effect.
+// Schedules are never edited, so past payments keep the fee they were charged.
+type FeeSchedule struct {
+	ID            uuid.UUID       `json:"id"`
+	ProviderID    uuid.UUID       `json:"provider_id"`
+	Percentage    decimal.Decimal `json:"percentage"` // Of the gross amount, e.g. 5.5 for 5.5%
+	FixedFee      decimal.Decimal `json:"fixed_fee"`  // Added per payment
+	EffectiveFrom time.Time       `json:"effective_from"`
+	CreatedBy     uuid.UUID       `json:"created_by"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// NewFeeSchedule creates a fee change for a provider. It may take effect now
+// or later, but not before now.
+func NewFeeSchedule(providerID uuid.UUID, percentage, fixedFee decimal.Decimal, effectiveFrom time.Time, createdBy uuid.UUID, now time.Time) (*FeeSchedule, error) {
+	if percentage.IsNegative() || percentage.GreaterThan(hundred) || fixedFee.IsNegative() {
+		return nil, ErrInvalidFeeSchedule
+	}
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+	if effectiveFrom.Before(now.Truncate(time.Minute)) {
+		return nil, ErrFeeEffectiveInPast
+	}
+
+	return &FeeSchedule{
+		ID:            uuid.New(),
+		ProviderID:    providerID,
+		Percentage:    percentage,
+		FixedFee:      fixedFee,
+		EffectiveFrom: effectiveFrom,
+		CreatedBy:     createdBy,
+		CreatedAt:     now,
+	}, nil
+}
+
+// FeeFor returns the fee on a gross payment, rounded to the sen. The fee
+// never exceeds the payment.
+func (f *FeeSchedule) FeeFor(gross decimal.Decimal) decimal.Decimal {
+	fee := gross.Mul(f.Percentage).Div(hundred).Add(f.FixedFee).Round(2)
+	if fee.GreaterThan(gross) {
+		return gross
+	}
+	return fee
+}
+
+// ApplyFee records the platform's fee on a payment. Amount stays the gross
+// the wallet was charged; NetAmount is what the provider is owed.
+func (t *Transaction) ApplyFee(fee decimal.Decimal) {
+	net := t.Amount.Sub(fee)
+	t.FeeAmount = &fee
+	t.NetAmount = &net
+}
+
+// copyFee carries a payment's fee onto the transaction undoing it, so the
+// provider gives back the net and the platform the fee
+func (t *Transaction) copyFee(original *Transaction) {
+	if original.FeeAmount != nil && t.Amount.Equal(original.Amount) {
+		t.ApplyFee(*original.FeeAmount)
+	}
+}
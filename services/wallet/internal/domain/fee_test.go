@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewFeeSchedule(t *testing.T) {
+	now := time.Date(2026, 9, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		percentage    string
+		fixed         string
+		effectiveFrom time.Time
+		wantErr       error
+	}{
+		{"takes effect now", "5", "0.20", time.Time{}, nil},
+		{"scheduled change", "4.5", "0", now.AddDate(0, 1, 0), nil},
+		{"negative percentage", "-1", "0", time.Time{}, ErrInvalidFeeSchedule},
+		{"over 100 percent", "100.01", "0", time.Time{}, ErrInvalidFeeSchedule},
+		{"negative fixed fee", "5", "-0.10", time.Time{}, ErrInvalidFeeSchedule},
+		{"backdated", "5", "0", now.Add(-time.Hour), ErrFeeEffectiveInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := NewFeeSchedule(uuid.New(), decimal.RequireFromString(tt.percentage),
+				decimal.RequireFromString(tt.fixed), tt.effectiveFrom, uuid.New(), now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewFeeSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && schedule.EffectiveFrom.Before(now) {
+				t.Errorf("EffectiveFrom = %s, want now or later", schedule.EffectiveFrom)
+			}
+		})
+	}
+}
+
+func TestFeeSchedule_FeeFor(t *testing.T) {
+	tests := []struct {
+		percentage string
+		fixed      string
+		gross      string
+		want       string
+	}{
+		{"5", "0", "10.00", "0.50"},
+		{"5", "0.20", "10.00", "0.70"},
+		{"2.5", "0", "3.30", "0.08"},
+		{"0", "0.50", "0.30", "0.30"},
+		{"0", "0", "12.00", "0"},
+	}
+
+	for _, tt := range tests {
+		schedule := &FeeSchedule{Percentage: decimal.RequireFromString(tt.percentage), FixedFee: decimal.RequireFromString(tt.fixed)}
+		got := schedule.FeeFor(decimal.RequireFromString(tt.gross))
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s%% + %s on %s = %s, want %s", tt.percentage, tt.fixed, tt.gross, got, tt.want)
+		}
+	}
+}
+
+func TestFee_CarriedToRefundAndSettlement(t *testing.T) {
+	provider := uuid.New()
+	payment := NewTransaction(uuid.New(), TransactionTypePayment, decimal.NewFromInt(10), decimal.NewFromInt(30), "session", "", "")
+	payment.SetProvider(provider)
+	payment.ApplyFee(decimal.RequireFromString("0.70"))
+	payment.Complete(decimal.NewFromInt(20))
+
+	if !payment.NetAmount.Equal(decimal.RequireFromString("9.30")) {
+		t.Fatalf("NetAmount = %s, want 9.30", payment.NetAmount)
+	}
+
+	refund := NewRefund(payment, "", "")
+	if refund.FeeAmount == nil || !refund.FeeAmount.Equal(*payment.FeeAmount) {
+		t.Fatalf("refund FeeAmount = %v, want the payment's fee", refund.FeeAmount)
+	}
+	refund.Complete(decimal.NewFromInt(30))
+	payment.Status = TransactionStatusRefunded
+
+	other := NewTransaction(uuid.New(), TransactionTypePayment, decimal.NewFromInt(5), decimal.NewFromInt(5), "session", "", "")
+	other.SetProvider(provider)
+	other.ApplyFee(decimal.RequireFromString("0.45"))
+	other.Complete(decimal.Zero)
+
+	start := time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC)
+	s, err := NewSettlement(provider, start, start.AddDate(0, 0, 1), []*Transaction{payment, refund, other})
+	if err != nil {
+		t.Fatalf("NewSettlement() error = %v", err)
+	}
+	if !s.Lines[1].Fee.Equal(decimal.RequireFromString("-0.70")) {
+		t.Errorf("refund line fee = %s, want -0.70", s.Lines[1].Fee)
+	}
+	if !s.FeeAmount.Equal(decimal.RequireFromString("0.45")) {
+		t.Errorf("FeeAmount = %s, want 0.45", s.FeeAmount)
+	}
+	if !s.NetAmount.Equal(decimal.RequireFromString("4.55")) {
+		t.Errorf("NetAmount = %s, want 4.55", s.NetAmount)
+	}
+}
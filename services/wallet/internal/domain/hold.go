@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrHoldNotFound = errors.New("hold not found")
+	ErrHoldSettled  = errors.New("hold has already been captured or released")
+)
+
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"
+	HoldStatusCaptured HoldStatus = "captured"
+	HoldStatusReleased HoldStatus = "released"
+)
+
+// Hold sets money aside for a payment whose final amount is not known yet,
+// such as a parking reservation. The held amount leaves the balance when the
+// hold is placed. Capturing it returns the hold and takes the final amount as
+// a payment; releasing it returns the whole amount.
+type Hold struct {
+	ID                   uuid.UUID       `json:"id"`
+	WalletID             uuid.UUID       `json:"wallet_id"`
+	Amount               decimal.Decimal `json:"amount"`
+	ProviderID           uuid.UUID       `json:"provider_id"`
+	ReferenceID          string          `json:"reference_id"`
+	IdempotencyKey       string          `json:"idempotency_key"`
+	Status               HoldStatus      `json:"status"`
+	CapturedAmount       decimal.Decimal `json:"captured_amount"`
+	HoldTransactionID    uuid.UUID       `json:"hold_transaction_id"`
+	PaymentTransactionID *uuid.UUID      `json:"payment_transaction_id,omitempty"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
+	SettledAt            *time.Time      `json:"settled_at,omitempty"`
+}
+
+// NewHold creates an active hold. The transaction that debits the wallet is
+// created separately by NewHoldTransaction.
+func NewHold(walletID uuid.UUID, amount decimal.Decimal, providerID uuid.UUID, referenceID, idempotencyKey string) (*Hold, error) {
+	if !amount.IsPositive() {
+		return nil, ErrInvalidAmount
+	}
+
+	now := time.Now().UTC()
+	return &Hold{
+		ID:             uuid.New(),
+		WalletID:       walletID,
+		Amount:         amount,
+		ProviderID:     providerID,
+		ReferenceID:    referenceID,
+		IdempotencyKey: idempotencyKey,
+		Status:         HoldStatusActive,
+		CapturedAmount: decimal.Zero,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// IsActive reports whether the hold still has money set aside
+func (h *Hold) IsActive() bool {
+	return h.Status == HoldStatusActive
+}
+
+// Capture records the payment that settled the hold
+func (h *Hold) Capture(amount decimal.Decimal, paymentID uuid.UUID) error {
+	if !h.IsActive() {
+		return ErrHoldSettled
+	}
+	now := time.Now().UTC()
+	h.Status = HoldStatusCaptured
+	h.CapturedAmount = amount
+	h.PaymentTransactionID = &paymentID
+	h.SettledAt = &now
+	h.UpdatedAt = now
+	return nil
+}
+
+// Release records that the whole hold went back to the wallet
+func (h *Hold) Release() error {
+	if !h.IsActive() {
+		return ErrHoldSettled
+	}
+	now := time.Now().UTC()
+	h.Status = HoldStatusReleased
+	h.SettledAt = &now
+	h.UpdatedAt = now
+	return nil
+}
+
+// releaseKey is the idempotency key of the transaction returning a hold
+func (h *Hold) releaseKey() string {
+	if h.IdempotencyKey == "" {
+		return ""
+	}
+	return h.IdempotencyKey + ":release"
+}
+
+// NewHoldTransaction creates the pending transaction that takes a hold's
+// amount out of the wallet
+func NewHoldTransaction(hold *Hold, balanceBefore decimal.Decimal, description string) *Transaction {
+	tx := NewTransaction(
+		hold.WalletID,
+		TransactionTypeHold,
+		hold.Amount,
+		balanceBefore,
+		hold.ReferenceID,
+		hold.IdempotencyKey,
+		description,
+	)
+	tx.SetProvider(hold.ProviderID)
+	return tx
+}
+
+// NewHoldRelease creates the pending transaction that returns a hold's amount
+// to the wallet, when it is released or before its capture payment
+func NewHoldRelease(hold *Hold, balanceBefore decimal.Decimal) *Transaction {
+	tx := NewTransaction(
+		hold.WalletID,
+		TransactionTypeHoldRelease,
+		hold.Amount,
+		balanceBefore,
+		hold.ReferenceID,
+		hold.releaseKey(),
+		"Hold released",
+	)
+	tx.ParentTransactionID = &hold.HoldTransactionID
+	tx.SetProvider(hold.ProviderID)
+	return tx
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewHold(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  string
+		wantErr error
+	}{
+		{"positive amount", "25.50", nil},
+		{"zero amount", "0", ErrInvalidAmount},
+		{"negative amount", "-5", ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold, err := NewHold(uuid.New(), decimal.RequireFromString(tt.amount), uuid.New(), "ref", "key")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewHold() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !hold.IsActive() {
+				t.Errorf("Status = %v, want %v", hold.Status, HoldStatusActive)
+			}
+		})
+	}
+}
+
+func TestHold_Settle(t *testing.T) {
+	tests := []struct {
+		name       string
+		settle     func(h *Hold) error
+		wantStatus HoldStatus
+	}{
+		{"capture", func(h *Hold) error { return h.Capture(decimal.NewFromInt(4), uuid.New()) }, HoldStatusCaptured},
+		{"release", func(h *Hold) error { return h.Release() }, HoldStatusReleased},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold, err := NewHold(uuid.New(), decimal.NewFromInt(10), uuid.New(), "ref", "key")
+			if err != nil {
+				t.Fatalf("NewHold() error = %v", err)
+			}
+
+			if err := tt.settle(hold); err != nil {
+				t.Fatalf("first settle error = %v", err)
+			}
+			if hold.Status != tt.wantStatus || hold.SettledAt == nil {
+				t.Errorf("hold = %+v, want %v with a settled time", hold, tt.wantStatus)
+			}
+
+			if err := hold.Release(); !errors.Is(err, ErrHoldSettled) {
+				t.Errorf("Release() after settle error = %v, want %v", err, ErrHoldSettled)
+			}
+			if err := hold.Capture(decimal.NewFromInt(1), uuid.New()); !errors.Is(err, ErrHoldSettled) {
+				t.Errorf("Capture() after settle error = %v, want %v", err, ErrHoldSettled)
+			}
+		})
+	}
+}
+
+func TestNewHoldRelease(t *testing.T) {
+	hold, err := NewHold(uuid.New(), decimal.NewFromInt(10), uuid.New(), "ref", "key")
+	if err != nil {
+		t.Fatalf("NewHold() error = %v", err)
+	}
+	placed := NewHoldTransaction(hold, decimal.NewFromInt(50), "Reservation")
+	placed.Complete(decimal.NewFromInt(40))
+	hold.HoldTransactionID = placed.ID
+
+	release := NewHoldRelease(hold, decimal.NewFromInt(40))
+	release.Complete(decimal.NewFromInt(50))
+	if release.Type != TransactionTypeHoldRelease {
+		t.Errorf("Type = %v, want %v", release.Type, TransactionTypeHoldRelease)
+	}
+	if release.ParentTransactionID == nil || *release.ParentTransactionID != placed.ID {
+		t.Errorf("ParentTransactionID = %v, want %v", release.ParentTransactionID, placed.ID)
+	}
+	if release.IdempotencyKey == placed.IdempotencyKey {
+		t.Error("release must not reuse the hold's idempotency key")
+	}
+	for _, tx := range []*Transaction{placed, release} {
+		entries := NewPosting(tx)
+		if len(entries) != 2 || entries[1].Account != AccountHolds {
+			t.Errorf("NewPosting(%v) = %+v, want the other side on %v", tx.Type, entries, AccountHolds)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrBusinessAccountNotFound = errors.New("business account not found")
+	ErrInvalidBusinessAccount  = errors.New("business account needs a company name")
+	ErrInvoiceNotFound         = errors.New("invoice not found")
+	ErrInvoiceAlreadyIssued    = errors.New("invoice already issued for this period")
+	ErrNothingToInvoice        = errors.New("no payments in the invoice period")
+)
+
+// BusinessAccount marks a user as a business customer who gets a monthly
+// invoice for their parking. The billing details are printed on it.
+type BusinessAccount struct {
+	UserID             uuid.UUID `json:"user_id"`
+	CompanyName        string    `json:"company_name"`
+	RegistrationNumber string    `json:"registration_number,omitempty"`
+	TaxID              string    `json:"tax_id,omitempty"`
+	BillingAddress     string    `json:"billing_address,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+func NewBusinessAccount(userID uuid.UUID, companyName, registrationNumber, taxID, billingAddress string) (*BusinessAccount, error) {
+	companyName = strings.TrimSpace(companyName)
+	if companyName == "" {
+		return nil, ErrInvalidBusinessAccount
+	}
+	now := time.Now().UTC()
+	return &BusinessAccount{
+		UserID:             userID,
+		CompanyName:        companyName,
+		RegistrationNumber: strings.TrimSpace(registrationNumber),
+		TaxID:              strings.TrimSpace(taxID),
+		BillingAddress:     strings.TrimSpace(billingAddress),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+	}, nil
+}
+
+// InvoiceTax is the tax included in invoiced amounts, e.g. SST at 8%.
+// A zero rate leaves invoices without tax lines.
+type InvoiceTax struct {
+	Name string
+	Rate decimal.Decimal
+}
+
+// Included returns the tax contained in a tax-inclusive amount
+func (t InvoiceTax) Included(gross decimal.Decimal) decimal.Decimal {
+	if !t.Rate.IsPositive() {
+		return decimal.Zero
+	}
+	return gross.Mul(t.Rate).Div(decimal.NewFromInt(1).Add(t.Rate)).Round(2)
+}
+
+// InvoicePeriod returns the calendar month before the one containing now, in loc
+func InvoicePeriod(now time.Time, loc *time.Location) (start, end time.Time) {
+	local := now.In(loc)
+	end = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, loc)
+	return end.AddDate(0, -1, 0), end
+}
+
+// InvoiceLine is one paid parking session. Amount includes Tax.
+type InvoiceLine struct {
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Date          time.Time       `json:"date"`
+	Description   string          `json:"description"`
+	ReferenceID   string          `json:"reference_id"`
+	Amount        decimal.Decimal `json:"amount"`
+	Tax           decimal.Decimal `json:"tax"`
+}
+
+// TaxLine is one tax included in a payment, or its total across an invoice
+type TaxLine struct {
+	Name    string          `json:"name"`
+	Rate    decimal.Decimal `json:"rate"`
+	Taxable decimal.Decimal `json:"taxable"`
+	Amount  decimal.Decimal `json:"amount"`
+}
+
+// Invoice bills a business account for a month of payments. The billing
+// details are copied at issue so later changes to the account leave issued
+// invoices alone; Document holds the rendered copy sent to the customer.
+type Invoice struct {
+	ID                 uuid.UUID       `json:"id"`
+	Number             string          `json:"number"`
+	UserID             uuid.UUID       `json:"user_id"`
+	WalletID           uuid.UUID       `json:"wallet_id"`
+	CompanyName        string          `json:"company_name"`
+	RegistrationNumber string          `json:"registration_number,omitempty"`
+	TaxID              string          `json:"tax_id,omitempty"`
+	BillingAddress     string          `json:"billing_address,omitempty"`
+	PeriodStart        time.Time       `json:"period_start"`
+	PeriodEnd          time.Time       `json:"period_end"`
+	Currency           string          `json:"currency"`
+	Lines              []InvoiceLine   `json:"lines"`
+	TaxLines           []TaxLine       `json:"tax_lines"`
+	Subtotal           decimal.Decimal `json:"subtotal"`
+	TaxTotal           decimal.Decimal `json:"tax_total"`
+	Total              decimal.Decimal `json:"total"`
+	IssuedAt           time.Time       `json:"issued_at"`
+	Document           []byte          `json:"-"`
+	ContentType        string          `json:"-"`
+}
+
+// NewInvoice bills the completed payments among payments, which must fall in
+// [start, end). Payments that recorded their taxes are invoiced with them;
+// tax on the rest is worked out from their tax-inclusive amounts.
+func NewInvoice(account *BusinessAccount, wallet *Wallet, start, end time.Time, payments []*Transaction, tax InvoiceTax) (*Invoice, error) {
+	inv := &Invoice{
+		ID:                 uuid.New(),
+		UserID:             account.UserID,
+		WalletID:           wallet.ID,
+		CompanyName:        account.CompanyName,
+		RegistrationNumber: account.RegistrationNumber,
+		TaxID:              account.TaxID,
+		BillingAddress:     account.BillingAddress,
+		PeriodStart:        start,
+		PeriodEnd:          end,
+		Currency:           wallet.Currency,
+		TaxLines:           []TaxLine{},
+		IssuedAt:           time.Now().UTC(),
+	}
+	inv.Number = fmt.Sprintf("INV-%s-%s", start.Format("200601"), strings.ToUpper(inv.ID.String()[:8]))
+
+	for _, tx := range payments {
+		if tx.Type != TransactionTypePayment || tx.Status != TransactionStatusCompleted {
+			continue
+		}
+		line := InvoiceLine{
+			TransactionID: tx.ID,
+			Date:          tx.CreatedAt,
+			Description:   tx.Description,
+			ReferenceID:   tx.ReferenceID,
+			Amount:        tx.Amount,
+		}
+		if len(tx.Taxes) > 0 {
+			for _, t := range tx.Taxes {
+				line.Tax = line.Tax.Add(t.Amount)
+				inv.addTax(t)
+			}
+		} else if tax.Rate.IsPositive() {
+			line.Tax = tax.Included(tx.Amount)
+			inv.addTax(TaxLine{Name: tax.Name, Rate: tax.Rate, Taxable: tx.Amount.Sub(line.Tax), Amount: line.Tax})
+		}
+		inv.Lines = append(inv.Lines, line)
+		inv.Total = inv.Total.Add(line.Amount)
+		inv.TaxTotal = inv.TaxTotal.Add(line.Tax)
+	}
+	if len(inv.Lines) == 0 {
+		return nil, ErrNothingToInvoice
+	}
+
+	inv.Subtotal = inv.Total.Sub(inv.TaxTotal)
+	return inv, nil
+}
+
+// addTax adds one payment's tax to the invoice's total for that tax
+func (inv *Invoice) addTax(tax TaxLine) {
+	for i := range inv.TaxLines {
+		if inv.TaxLines[i].Name == tax.Name && inv.TaxLines[i].Rate.Equal(tax.Rate) {
+			inv.TaxLines[i].Taxable = inv.TaxLines[i].Taxable.Add(tax.Taxable)
+			inv.TaxLines[i].Amount = inv.TaxLines[i].Amount.Add(tax.Amount)
+			return
+		}
+	}
+	inv.TaxLines = append(inv.TaxLines, tax)
+}
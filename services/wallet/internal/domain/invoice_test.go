@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestInvoicePeriod(t *testing.T) {
+	myt := time.FixedZone("MYT", 8*60*60)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStart string
+	}{
+		{"mid month", time.Date(2026, 9, 15, 12, 0, 0, 0, time.UTC), "2026-08-01"},
+		{"UTC day before local month start", time.Date(2026, 9, 30, 17, 0, 0, 0, time.UTC), "2026-09-01"},
+		{"January bills December", time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC), "2025-12-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := InvoicePeriod(tt.now, myt)
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if !end.Equal(start.AddDate(0, 1, 0)) {
+				t.Errorf("end = %s, want a month after start", end)
+			}
+		})
+	}
+}
+
+func TestInvoiceTax_Included(t *testing.T) {
+	sst := InvoiceTax{Name: "SST", Rate: decimal.RequireFromString("0.08")}
+
+	tests := []struct {
+		gross string
+		want  string
+	}{
+		{"10.80", "0.8"},
+		{"5.00", "0.37"},
+		{"0", "0"},
+	}
+
+	for _, tt := range tests {
+		got := sst.Included(decimal.RequireFromString(tt.gross))
+		if !got.Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("Included(%s) = %s, want %s", tt.gross, got, tt.want)
+		}
+	}
+
+	if got := (InvoiceTax{}).Included(decimal.RequireFromString("10")); !got.IsZero() {
+		t.Errorf("Included() without a rate = %s, want 0", got)
+	}
+}
+
+func TestNewInvoice(t *testing.T) {
+	account, err := NewBusinessAccount(uuid.New(), "  Acme Logistics Sdn Bhd ", "202301012345", "", "")
+	if err != nil {
+		t.Fatalf("NewBusinessAccount() error = %v", err)
+	}
+	wallet := NewWallet(account.UserID, "MYR")
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	payment := func(amount string, status TransactionStatus) *Transaction {
+		tx := NewTransaction(wallet.ID, TransactionTypePayment, decimal.RequireFromString(amount), decimal.Zero, "session", uuid.NewString(), "Parking")
+		tx.Status = status
+		return tx
+	}
+	sst := InvoiceTax{Name: "SST", Rate: decimal.RequireFromString("0.08")}
+
+	inv, err := NewInvoice(account, wallet, start, end, []*Transaction{
+		payment("10.80", TransactionStatusCompleted),
+		payment("5.40", TransactionStatusCompleted),
+		payment("3.00", TransactionStatusRefunded),
+	}, sst)
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+
+	if inv.CompanyName != "Acme Logistics Sdn Bhd" {
+		t.Errorf("CompanyName = %q", inv.CompanyName)
+	}
+	if len(inv.Lines) != 2 {
+		t.Fatalf("Lines = %d, want the 2 completed payments", len(inv.Lines))
+	}
+	if !inv.Total.Equal(decimal.RequireFromString("16.20")) {
+		t.Errorf("Total = %s, want 16.20", inv.Total)
+	}
+	if !inv.TaxTotal.Equal(decimal.RequireFromString("1.20")) {
+		t.Errorf("TaxTotal = %s, want 1.20", inv.TaxTotal)
+	}
+	if !inv.Subtotal.Add(inv.TaxTotal).Equal(inv.Total) {
+		t.Errorf("Subtotal %s + TaxTotal %s != Total %s", inv.Subtotal, inv.TaxTotal, inv.Total)
+	}
+	if len(inv.TaxLines) != 1 || inv.TaxLines[0].Name != "SST" {
+		t.Errorf("TaxLines = %+v, want one SST line", inv.TaxLines)
+	}
+	if inv.Number[:11] != "INV-202609-" {
+		t.Errorf("Number = %q, want the period in it", inv.Number)
+	}
+
+	_, err = NewInvoice(account, wallet, start, end, []*Transaction{payment("3.00", TransactionStatusFailed)}, sst)
+	if !errors.Is(err, ErrNothingToInvoice) {
+		t.Errorf("NewInvoice() without completed payments error = %v, want %v", err, ErrNothingToInvoice)
+	}
+
+	if _, err := NewBusinessAccount(uuid.New(), " ", "", "", ""); !errors.Is(err, ErrInvalidBusinessAccount) {
+		t.Errorf("NewBusinessAccount() without a name error = %v, want %v", err, ErrInvalidBusinessAccount)
+	}
+}
+
+func TestNewInvoice_RecordedTaxes(t *testing.T) {
+	account, _ := NewBusinessAccount(uuid.New(), "Acme Logistics Sdn Bhd", "", "", "")
+	wallet := NewWallet(account.UserID, "MYR")
+	start := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+
+	taxed := NewTransaction(wallet.ID, TransactionTypePayment, decimal.RequireFromString("5.40"), decimal.Zero, "session", uuid.NewString(), "Parking")
+	taxed.ApplyTaxes([]TaxLine{{
+		Name: "SST", Rate: decimal.RequireFromString("0.08"),
+		Taxable: decimal.RequireFromString("5.00"), Amount: decimal.RequireFromString("0.40"),
+	}})
+	taxed.Status = TransactionStatusCompleted
+	untaxed := NewTransaction(wallet.ID, TransactionTypePayment, decimal.RequireFromString("10.80"), decimal.Zero, "session", uuid.NewString(), "Parking")
+	untaxed.Status = TransactionStatusCompleted
+
+	// The recorded SST and the configured SST are one tax line
+	sst := InvoiceTax{Name: "SST", Rate: decimal.RequireFromString("0.08")}
+	inv, err := NewInvoice(account, wallet, start, start.AddDate(0, 1, 0), []*Transaction{taxed, untaxed}, sst)
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+	if len(inv.TaxLines) != 1 {
+		t.Fatalf("TaxLines = %+v, want one SST line", inv.TaxLines)
+	}
+	if !inv.TaxLines[0].Amount.Equal(decimal.RequireFromString("1.20")) || !inv.TaxLines[0].Taxable.Equal(decimal.RequireFromString("15.00")) {
+		t.Errorf("SST line = %+v, want 1.20 on 15.00", inv.TaxLines[0])
+	}
+
+	// Without a configured tax only recorded taxes are invoiced
+	inv, err = NewInvoice(account, wallet, start, start.AddDate(0, 1, 0), []*Transaction{taxed, untaxed}, InvoiceTax{})
+	if err != nil {
+		t.Fatalf("NewInvoice() error = %v", err)
+	}
+	if !inv.TaxTotal.Equal(decimal.RequireFromString("0.40")) {
+		t.Errorf("TaxTotal = %s, want 0.40", inv.TaxTotal)
+	}
+
+	refund := NewRefund(taxed, "", "")
+	if refund.TaxAmount == nil || !refund.TaxAmount.Equal(decimal.RequireFromString("0.40")) {
+		t.Errorf("refund TaxAmount = %v, want the payment's 0.40", refund.TaxAmount)
+	}
+}
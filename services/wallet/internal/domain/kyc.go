@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrKYCBalanceLimitExceeded = errors.New("top-up would take the balance over the limit for the user's verification level")
+	ErrKYCTopUpLimitExceeded   = errors.New("top-up exceeds the limit for the user's verification level")
+	ErrKYCPaymentLimitExceeded = errors.New("payment exceeds the limit for the user's verification level")
+)
+
+// KYCLevel is how far the auth service has verified a user's identity.
+// Users we have no record of are treated as basic.
+type KYCLevel string
+
+const (
+	KYCLevelBasic    KYCLevel = "basic"
+	KYCLevelVerified KYCLevel = "verified"
+)
+
+// KYCLimits caps a wallet by its owner's verification level. A nil limit is
+// not enforced.
+type KYCLimits struct {
+	MaxBalance *decimal.Decimal `json:"max_balance"`
+	MaxTopUp   *decimal.Decimal `json:"max_top_up"`
+	MaxPayment *decimal.Decimal `json:"max_payment"`
+}
+
+// CheckTopUp reports whether a top-up of amount onto balance is allowed
+func (l KYCLimits) CheckTopUp(balance, amount decimal.Decimal) error {
+	if l.MaxTopUp != nil && amount.GreaterThan(*l.MaxTopUp) {
+		return ErrKYCTopUpLimitExceeded
+	}
+	if l.MaxBalance != nil && balance.Add(amount).GreaterThan(*l.MaxBalance) {
+		return ErrKYCBalanceLimitExceeded
+	}
+	return nil
+}
+
+// CheckPayment reports whether a payment of amount is allowed
+func (l KYCLimits) CheckPayment(amount decimal.Decimal) error {
+	if l.MaxPayment != nil && amount.GreaterThan(*l.MaxPayment) {
+		return ErrKYCPaymentLimitExceeded
+	}
+	return nil
+}
+
+// KYCPolicy holds the limits for each verification level
+type KYCPolicy map[KYCLevel]KYCLimits
+
+// For returns the limits for a level. Unknown levels get the basic limits,
+// so a user is never granted more than we know they are entitled to.
+func (p KYCPolicy) For(level KYCLevel) KYCLimits {
+	if limits, ok := p[level]; ok {
+		return limits
+	}
+	return p[KYCLevelBasic]
+}
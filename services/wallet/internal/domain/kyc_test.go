@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestKYCLimits_CheckTopUp(t *testing.T) {
+	maxBalance := decimal.NewFromInt(200)
+	maxTopUp := decimal.NewFromInt(100)
+	limits := KYCLimits{MaxBalance: &maxBalance, MaxTopUp: &maxTopUp}
+
+	tests := []struct {
+		name    string
+		balance int64
+		amount  int64
+		wantErr error
+	}{
+		{"within limits", 50, 100, nil},
+		{"up to max balance", 100, 100, nil},
+		{"over max top-up", 0, 101, ErrKYCTopUpLimitExceeded},
+		{"over max balance", 150, 60, ErrKYCBalanceLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.CheckTopUp(decimal.NewFromInt(tt.balance), decimal.NewFromInt(tt.amount))
+			if err != tt.wantErr {
+				t.Errorf("CheckTopUp() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := (KYCLimits{}).CheckTopUp(decimal.NewFromInt(1e6), decimal.NewFromInt(1e6)); err != nil {
+		t.Errorf("unset limits should allow any top-up, got %v", err)
+	}
+}
+
+func TestKYCLimits_CheckPayment(t *testing.T) {
+	maxPayment := decimal.NewFromInt(100)
+	limits := KYCLimits{MaxPayment: &maxPayment}
+
+	if err := limits.CheckPayment(decimal.NewFromInt(100)); err != nil {
+		t.Errorf("CheckPayment(100) error = %v, want nil", err)
+	}
+	if err := limits.CheckPayment(decimal.RequireFromString("100.01")); err != ErrKYCPaymentLimitExceeded {
+		t.Errorf("CheckPayment(100.01) error = %v, want %v", err, ErrKYCPaymentLimitExceeded)
+	}
+}
+
+func TestKYCPolicy_For(t *testing.T) {
+	basic := decimal.NewFromInt(200)
+	policy := KYCPolicy{
+		KYCLevelBasic:    {MaxBalance: &basic},
+		KYCLevelVerified: {},
+	}
+
+	tests := []struct {
+		level      KYCLevel
+		wantCapped bool
+	}{
+		{KYCLevelBasic, true},
+		{KYCLevelVerified, false},
+		{"", true},
+		{"premium", true},
+	}
+
+	for _, tt := range tests {
+		got := policy.For(tt.level)
+		if (got.MaxBalance != nil) != tt.wantCapped {
+			t.Errorf("For(%q).MaxBalance = %v, want capped %v", tt.level, got.MaxBalance, tt.wantCapped)
+		}
+	}
+}
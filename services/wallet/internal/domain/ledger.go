@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrUnbalancedPosting   = errors.New("ledger posting does not balance")
+	ErrDiscrepancyNotFound = errors.New("ledger discrepancy not found")
+)
+
+// LedgerAccount identifies one side of a double-entry posting. Every wallet
+// has its own account; money entering or leaving the platform is booked
+// against the external and system accounts below.
+type LedgerAccount string
+
+const (
+	// AccountPaymentGateway funds top-ups
+	AccountPaymentGateway LedgerAccount = "external:payment_gateway"
+	// AccountProviders receives payments and returns refunds
+	AccountProviders LedgerAccount = "external:providers"
+	// AccountTransfers is the counterpart of wallet-to-wallet transfers
+	AccountTransfers LedgerAccount = "external:transfers"
+	// AccountRounding absorbs cash rounding differences
+	AccountRounding LedgerAccount = "system:rounding"
+	// AccountPromotions funds promotion bonuses
+	AccountPromotions LedgerAccount = "system:promotions"
+	// AccountLoyalty funds redeemed loyalty points
+	AccountLoyalty LedgerAccount = "system:loyalty"
+	// AccountReferrals funds referral rewards
+	AccountReferrals LedgerAccount = "system:referrals"
+	// AccountHolds keeps money held for captures that have not happened yet
+	AccountHolds LedgerAccount = "system:holds"
+)
+
+const walletAccountPrefix = "wallet:"
+
+// WalletAccount is the ledger account holding a wallet's money
+func WalletAccount(walletID uuid.UUID) LedgerAccount {
+	return LedgerAccount(walletAccountPrefix + walletID.String())
+}
+
+// LedgerEntry is one immutable line of a posting. Amount is signed: positive
+// entries add to the account, negative entries take from it.
+type LedgerEntry struct {
+	ID            int64           `json:"id"`
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Account       LedgerAccount   `json:"account"`
+	Amount        decimal.Decimal `json:"amount"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// counterAccount is where the other side of a transaction is booked
+func counterAccount(txType TransactionType) LedgerAccount {
+	switch txType {
+	case TransactionTypeTopUp:
+		return AccountPaymentGateway
+	case TransactionTypeRoundingAdjustment:
+		return AccountRounding
+	case TransactionTypeTransfer:
+		return AccountTransfers
+	case TransactionTypePromoCredit:
+		return AccountPromotions
+	case TransactionTypeLoyaltyCredit:
+		return AccountLoyalty
+	case TransactionTypeReferralCredit:
+		return AccountReferrals
+	case TransactionTypeHold, TransactionTypeHoldRelease:
+		return AccountHolds
+	default:
+		return AccountProviders
+	}
+}
+
+// NewPosting returns the balanced entries recording a completed transaction:
+// its effect on the wallet and the opposite movement on the counter account.
+// A transaction that did not move money has no entries.
+func NewPosting(tx *Transaction) []LedgerEntry {
+	return posting(tx, counterAccount(tx.Type))
+}
+
+// NewReversalPosting records a reversal against the account its original was
+// booked against, so the two postings cancel out
+func NewReversalPosting(reversal, original *Transaction) []LedgerEntry {
+	return posting(reversal, counterAccount(original.Type))
+}
+
+func posting(tx *Transaction, counter LedgerAccount) []LedgerEntry {
+	delta := tx.SignedAmount()
+	if delta.IsZero() {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	return []LedgerEntry{
+		{TransactionID: tx.ID, Account: WalletAccount(tx.WalletID), Amount: delta, CreatedAt: now},
+		{TransactionID: tx.ID, Account: counter, Amount: delta.Neg(), CreatedAt: now},
+	}
+}
+
+// ValidatePosting checks the double-entry invariant: at least two entries
+// whose amounts sum to zero.
+func ValidatePosting(entries []LedgerEntry) error {
+	if len(entries) < 2 {
+		return ErrUnbalancedPosting
+	}
+	sum := decimal.Zero
+	for _, e := range entries {
+		sum = sum.Add(e.Amount)
+	}
+	if !sum.IsZero() {
+		return ErrUnbalancedPosting
+	}
+	return nil
+}
+
+// BalanceSnapshot folds a wallet's ledger entries up to LastEntryID into a
+// single balance, so deriving the current balance only sums later entries.
+type BalanceSnapshot struct {
+	WalletID    uuid.UUID       `json:"wallet_id"`
+	Balance     decimal.Decimal `json:"balance"`
+	LastEntryID int64           `json:"last_entry_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Discrepancy resolutions
+const (
+	// ResolutionBalanceReset means the wallet balance was reset to the ledger
+	ResolutionBalanceReset = "balance_reset"
+	// ResolutionSelfHealed means a later check found the two back in step
+	ResolutionSelfHealed = "self_healed"
+)
+
+// LedgerDiscrepancy records a wallet whose stored balance disagrees with the
+// balance derived from its ledger. The ledger is the source of truth.
+type LedgerDiscrepancy struct {
+	ID            uuid.UUID       `json:"id"`
+	WalletID      uuid.UUID       `json:"wallet_id"`
+	WalletBalance decimal.Decimal `json:"wallet_balance"`
+	LedgerBalance decimal.Decimal `json:"ledger_balance"`
+	Drift         decimal.Decimal `json:"drift"`
+	DetectedAt    time.Time       `json:"detected_at"`
+	ResolvedAt    *time.Time      `json:"resolved_at,omitempty"`
+	Resolution    string          `json:"resolution,omitempty"`
+}
+
+func NewLedgerDiscrepancy(walletID uuid.UUID, walletBalance, ledgerBalance decimal.Decimal) *LedgerDiscrepancy {
+	return &LedgerDiscrepancy{
+		ID:            uuid.New(),
+		WalletID:      walletID,
+		WalletBalance: walletBalance,
+		LedgerBalance: ledgerBalance,
+		Drift:         walletBalance.Sub(ledgerBalance),
+		DetectedAt:    time.Now().UTC(),
+	}
+}
+
+// Resolve closes the discrepancy
+func (d *LedgerDiscrepancy) Resolve(resolution string) {
+	now := time.Now().UTC()
+	d.ResolvedAt = &now
+	d.Resolution = resolution
+}
+
+// RebaseBalance replaces the stored balance with the ledger-derived one when
+// repairing drift
+func (w *Wallet) RebaseBalance(ledgerBalance decimal.Decimal) {
+	w.Balance = ledgerBalance
+	w.UpdatedAt = time.Now().UTC()
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewPosting(t *testing.T) {
+	walletID := uuid.New()
+
+	tests := []struct {
+		name          string
+		txType        TransactionType
+		before, after string
+		wantCounter   LedgerAccount
+	}{
+		{"top-up credits wallet from gateway", TransactionTypeTopUp, "10", "30", AccountPaymentGateway},
+		{"payment debits wallet to providers", TransactionTypePayment, "30", "22.50", AccountProviders},
+		{"refund credits wallet from providers", TransactionTypeRefund, "22.50", "30", AccountProviders},
+		{"rounding adjustment books against rounding", TransactionTypeRoundingAdjustment, "30", "30.02", AccountRounding},
+		{"transfer books against transfers", TransactionTypeTransfer, "30", "20", AccountTransfers},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &Transaction{
+				ID:            uuid.New(),
+				WalletID:      walletID,
+				Type:          tt.txType,
+				BalanceBefore: decimal.RequireFromString(tt.before),
+				BalanceAfter:  decimal.RequireFromString(tt.after),
+			}
+			delta := tx.SignedAmount()
+
+			entries := NewPosting(tx)
+			if err := ValidatePosting(entries); err != nil {
+				t.Fatalf("ValidatePosting() error = %v", err)
+			}
+			if entries[0].Account != WalletAccount(walletID) || !entries[0].Amount.Equal(delta) {
+				t.Errorf("wallet entry = %s %s, want %s %s", entries[0].Account, entries[0].Amount, WalletAccount(walletID), delta)
+			}
+			if entries[1].Account != tt.wantCounter || !entries[1].Amount.Equal(delta.Neg()) {
+				t.Errorf("counter entry = %s %s, want %s %s", entries[1].Account, entries[1].Amount, tt.wantCounter, delta.Neg())
+			}
+			for _, e := range entries {
+				if e.TransactionID != tx.ID {
+					t.Errorf("entry transaction = %s, want %s", e.TransactionID, tx.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPosting_NoMovement(t *testing.T) {
+	tx := &Transaction{
+		ID:            uuid.New(),
+		WalletID:      uuid.New(),
+		Type:          TransactionTypePayment,
+		BalanceBefore: decimal.NewFromInt(10),
+		BalanceAfter:  decimal.NewFromInt(10),
+	}
+	if entries := NewPosting(tx); entries != nil {
+		t.Errorf("NewPosting() = %v, want no entries", entries)
+	}
+}
+
+func TestValidatePosting(t *testing.T) {
+	entry := func(amount string) LedgerEntry {
+		return LedgerEntry{Account: AccountProviders, Amount: decimal.RequireFromString(amount)}
+	}
+
+	tests := []struct {
+		name    string
+		entries []LedgerEntry
+		wantErr error
+	}{
+		{"balanced pair", []LedgerEntry{entry("5"), entry("-5")}, nil},
+		{"balanced split", []LedgerEntry{entry("5"), entry("-4.98"), entry("-0.02")}, nil},
+		{"unbalanced", []LedgerEntry{entry("5"), entry("-4")}, ErrUnbalancedPosting},
+		{"single entry", []LedgerEntry{entry("0")}, ErrUnbalancedPosting},
+		{"empty", nil, ErrUnbalancedPosting},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidatePosting(tt.entries); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidatePosting() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewLedgerDiscrepancy(t *testing.T) {
+	d := NewLedgerDiscrepancy(uuid.New(), decimal.RequireFromString("12.50"), decimal.RequireFromString("10"))
+	if !d.Drift.Equal(decimal.RequireFromString("2.50")) {
+		t.Errorf("drift = %s, want 2.50", d.Drift)
+	}
+	if d.ResolvedAt != nil {
+		t.Error("new discrepancy should be open")
+	}
+
+	d.Resolve(ResolutionBalanceReset)
+	if d.ResolvedAt == nil || d.Resolution != ResolutionBalanceReset {
+		t.Errorf("Resolve() left resolved_at=%v resolution=%q", d.ResolvedAt, d.Resolution)
+	}
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrInsufficientPoints  = errors.New("not enough loyalty points")
+	ErrRedemptionTooSmall  = errors.New("redemption is below the minimum")
+	ErrInvalidPointsAmount = errors.New("points must be positive")
+	ErrLoyaltyLotNotFound  = errors.New("loyalty lot not found")
+	ErrLoyaltyEntryExists  = errors.New("loyalty entry already recorded for this transaction")
+)
+
+type LoyaltyEntryType string
+
+const (
+	// LoyaltyEarn is a lot of points earned on a payment. Its Remaining
+	// points are spent oldest first until it expires.
+	LoyaltyEarn LoyaltyEntryType = "earn"
+	// LoyaltyRedeem spends points for wallet credit
+	LoyaltyRedeem LoyaltyEntryType = "redeem"
+	// LoyaltyExpire removes what was left of a lot when it expired
+	LoyaltyExpire LoyaltyEntryType = "expire"
+	// LoyaltyForfeit takes back what is left of a lot when its payment is
+	// refunded or reversed
+	LoyaltyForfeit LoyaltyEntryType = "forfeit"
+)
+
+// LoyaltyPolicy sets how points are earned and what they are worth
+type LoyaltyPolicy struct {
+	// PointsPerRinggit earned on each ringgit paid, rounded down per payment
+	PointsPerRinggit decimal.Decimal
+	// PointValue is the wallet credit one point redeems for
+	PointValue decimal.Decimal
+	// MinRedemption is the fewest points redeemed at once
+	MinRedemption int64
+	// Expiry is how long earned points last
+	Expiry time.Duration
+}
+
+// PointsFor returns the points a payment of amount earns
+func (p LoyaltyPolicy) PointsFor(amount decimal.Decimal) int64 {
+	if !amount.IsPositive() || !p.PointsPerRinggit.IsPositive() {
+		return 0
+	}
+	return amount.Mul(p.PointsPerRinggit).Floor().IntPart()
+}
+
+// CreditFor returns the wallet credit points redeem for, rounded down to the sen
+func (p LoyaltyPolicy) CreditFor(points int64) decimal.Decimal {
+	return decimal.NewFromInt(points).Mul(p.PointValue).RoundDown(2)
+}
+
+// CheckRedemption validates redeeming points out of a balance
+func (p LoyaltyPolicy) CheckRedemption(points, balance int64) error {
+	switch {
+	case points <= 0:
+		return ErrInvalidPointsAmount
+	case points < p.MinRedemption || !p.CreditFor(points).IsPositive():
+		return ErrRedemptionTooSmall
+	case points > balance:
+		return ErrInsufficientPoints
+	}
+	return nil
+}
+
+// LoyaltyEntry is one line of a user's points ledger. Points is signed:
+// earned lots are positive, everything else takes points away. Only earned
+// lots track Remaining and expire.
+type LoyaltyEntry struct {
+	ID            uuid.UUID        `json:"id"`
+	UserID        uuid.UUID        `json:"user_id"`
+	Type          LoyaltyEntryType `json:"type"`
+	Points        int64            `json:"points"`
+	Remaining     int64            `json:"remaining,omitempty"`
+	TransactionID *uuid.UUID       `json:"transaction_id,omitempty"`
+	ExpiresAt     *time.Time       `json:"expires_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// NewLoyaltyEarn records points earned on the payment paymentID
+func NewLoyaltyEarn(userID, paymentID uuid.UUID, points int64, expiry time.Duration, now time.Time) *LoyaltyEntry {
+	expiresAt := now.Add(expiry).UTC()
+	return &LoyaltyEntry{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Type:          LoyaltyEarn,
+		Points:        points,
+		Remaining:     points,
+		TransactionID: &paymentID,
+		ExpiresAt:     &expiresAt,
+		CreatedAt:     now.UTC(),
+	}
+}
+
+// NewLoyaltyRedeem records points spent on the credit transaction creditID
+func NewLoyaltyRedeem(userID, creditID uuid.UUID, points int64, now time.Time) *LoyaltyEntry {
+	return &LoyaltyEntry{
+		ID:            uuid.New(),
+		UserID:        userID,
+		Type:          LoyaltyRedeem,
+		Points:        -points,
+		TransactionID: &creditID,
+		CreatedAt:     now.UTC(),
+	}
+}
+
+// Expired reports whether an earned lot can no longer be spent
+func (e *LoyaltyEntry) Expired(now time.Time) bool {
+	return e.ExpiresAt != nil && !now.Before(*e.ExpiresAt)
+}
+
+// Expire empties an expired lot and returns the entry recording it, or nil
+// when nothing was left
+func (e *LoyaltyEntry) Expire(now time.Time) *LoyaltyEntry {
+	return e.close(LoyaltyExpire, now)
+}
+
+// Forfeit empties a lot whose payment was returned and returns the entry
+// recording it, or nil when nothing was left. Points already spent stay spent.
+func (e *LoyaltyEntry) Forfeit(now time.Time) *LoyaltyEntry {
+	return e.close(LoyaltyForfeit, now)
+}
+
+func (e *LoyaltyEntry) close(entryType LoyaltyEntryType, now time.Time) *LoyaltyEntry {
+	if e.Type != LoyaltyEarn || e.Remaining <= 0 {
+		return nil
+	}
+	points := e.Remaining
+	e.Remaining = 0
+	return &LoyaltyEntry{
+		ID:            uuid.New(),
+		UserID:        e.UserID,
+		Type:          entryType,
+		Points:        -points,
+		TransactionID: e.TransactionID,
+		CreatedAt:     now.UTC(),
+	}
+}
+
+// SpendLots takes points from earned lots in the order given, which should
+// be soonest to expire first, and returns the lots it changed. Nothing is
+// changed when the unexpired lots do not hold enough.
+func SpendLots(lots []*LoyaltyEntry, points int64, now time.Time) ([]*LoyaltyEntry, error) {
+	var available int64
+	for _, lot := range lots {
+		if !lot.Expired(now) {
+			available += lot.Remaining
+		}
+	}
+	if points > available {
+		return nil, ErrInsufficientPoints
+	}
+
+	var changed []*LoyaltyEntry
+	for _, lot := range lots {
+		if points == 0 {
+			break
+		}
+		if lot.Expired(now) || lot.Remaining == 0 {
+			continue
+		}
+		take := min(lot.Remaining, points)
+		lot.Remaining -= take
+		points -= take
+		changed = append(changed, lot)
+	}
+	return changed, nil
+}
+
+// NewLoyaltyCredit creates the completed transaction that pays redeemed
+// points into a wallet whose balance is balanceBefore
+func NewLoyaltyCredit(walletID uuid.UUID, points int64, credit, balanceBefore decimal.Decimal) *Transaction {
+	tx := NewTransaction(
+		walletID,
+		TransactionTypeLoyaltyCredit,
+		credit,
+		balanceBefore,
+		"",
+		"",
+		"Redeemed "+decimal.NewFromInt(points).String()+" loyalty points",
+	)
+	tx.Complete(balanceBefore.Add(credit))
+	return tx
+}
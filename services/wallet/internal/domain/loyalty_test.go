@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var testLoyaltyPolicy = LoyaltyPolicy{
+	PointsPerRinggit: decimal.NewFromInt(1),
+	PointValue:       decimal.RequireFromString("0.01"),
+	MinRedemption:    100,
+	Expiry:           365 * 24 * time.Hour,
+}
+
+func TestLoyaltyPolicy_PointsFor(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		rate   string
+		want   int64
+	}{
+		{"whole ringgit", "12.00", "1", 12},
+		{"rounds down", "12.99", "1", 12},
+		{"double points", "7.50", "2", 15},
+		{"under one point", "0.50", "1", 0},
+		{"no earning", "50.00", "0", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := testLoyaltyPolicy
+			policy.PointsPerRinggit = decimal.RequireFromString(tt.rate)
+			if got := policy.PointsFor(decimal.RequireFromString(tt.amount)); got != tt.want {
+				t.Errorf("PointsFor(%s) = %d, want %d", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoyaltyPolicy_CheckRedemption(t *testing.T) {
+	tests := []struct {
+		name    string
+		points  int64
+		balance int64
+		wantErr error
+	}{
+		{"valid", 250, 300, nil},
+		{"whole balance", 300, 300, nil},
+		{"zero", 0, 300, ErrInvalidPointsAmount},
+		{"below minimum", 99, 300, ErrRedemptionTooSmall},
+		{"more than balance", 301, 300, ErrInsufficientPoints},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := testLoyaltyPolicy.CheckRedemption(tt.points, tt.balance); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckRedemption() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if got := testLoyaltyPolicy.CreditFor(250); !got.Equal(decimal.RequireFromString("2.50")) {
+		t.Errorf("CreditFor(250) = %s, want 2.50", got)
+	}
+}
+
+func TestSpendLots(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+	lots := func() []*LoyaltyEntry {
+		expired := NewLoyaltyEarn(userID, uuid.New(), 500, time.Hour, now.Add(-2*time.Hour))
+		return []*LoyaltyEntry{
+			expired,
+			NewLoyaltyEarn(userID, uuid.New(), 100, time.Hour, now),
+			NewLoyaltyEarn(userID, uuid.New(), 200, 2*time.Hour, now),
+		}
+	}
+
+	t.Run("oldest first", func(t *testing.T) {
+		l := lots()
+		changed, err := SpendLots(l, 150, now)
+		if err != nil {
+			t.Fatalf("SpendLots() error = %v", err)
+		}
+		if len(changed) != 2 || l[1].Remaining != 0 || l[2].Remaining != 150 {
+			t.Errorf("remaining = %d, %d, want 0, 150", l[1].Remaining, l[2].Remaining)
+		}
+		if l[0].Remaining != 500 {
+			t.Error("expired lot should not be spent")
+		}
+	})
+
+	t.Run("not enough unexpired points", func(t *testing.T) {
+		l := lots()
+		if _, err := SpendLots(l, 301, now); !errors.Is(err, ErrInsufficientPoints) {
+			t.Fatalf("SpendLots() error = %v, want %v", err, ErrInsufficientPoints)
+		}
+		if l[1].Remaining != 100 || l[2].Remaining != 200 {
+			t.Error("lots should be unchanged when the spend fails")
+		}
+	})
+}
+
+func TestLoyaltyEntry_Expire(t *testing.T) {
+	now := time.Now()
+	lot := NewLoyaltyEarn(uuid.New(), uuid.New(), 120, time.Hour, now.Add(-2*time.Hour))
+	if !lot.Expired(now) {
+		t.Fatal("lot should have expired")
+	}
+	lot.Remaining = 40
+
+	entry := lot.Expire(now)
+	if entry == nil || entry.Type != LoyaltyExpire || entry.Points != -40 || lot.Remaining != 0 {
+		t.Fatalf("Expire() = %+v, remaining %d; want -40 points and an empty lot", entry, lot.Remaining)
+	}
+	if *entry.TransactionID != *lot.TransactionID {
+		t.Error("expiry should point at the lot's payment")
+	}
+	if lot.Forfeit(now) != nil {
+		t.Error("an empty lot has nothing to forfeit")
+	}
+}
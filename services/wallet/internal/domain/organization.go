@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNotOrgMember         = errors.New("payer is not a member of the organization")
+	ErrMemberLimitExceeded  = errors.New("payment exceeds the member's monthly limit")
+)
+
+// Organization is the wallet service's copy of an organization from auth.
+// Its wallet is an ordinary wallet whose user ID is the organization's ID.
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	WalletID  uuid.UUID `json:"wallet_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OrgMember may charge payments to the organization's wallet, up to their
+// monthly limit. A nil limit is not enforced.
+type OrgMember struct {
+	OrgID        uuid.UUID        `json:"org_id"`
+	UserID       uuid.UUID        `json:"user_id"`
+	Role         string           `json:"role"`
+	MonthlyLimit *decimal.Decimal `json:"monthly_limit"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// SetMonthlyLimit changes the member's limit; nil removes it
+func (m *OrgMember) SetMonthlyLimit(limit *decimal.Decimal) error {
+	if limit != nil && !limit.IsPositive() {
+		return ErrInvalidLimit
+	}
+	m.MonthlyLimit = limit
+	m.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// CheckLimit reports whether a payment of amount fits in what is left of the
+// member's monthly limit
+func (m *OrgMember) CheckLimit(amount, spentThisMonth decimal.Decimal) error {
+	if m.MonthlyLimit != nil && spentThisMonth.Add(amount).GreaterThan(*m.MonthlyLimit) {
+		return ErrMemberLimitExceeded
+	}
+	return nil
+}
+
+// MemberSpending is one member's share of an organization's payments
+type MemberSpending struct {
+	UserID       uuid.UUID        `json:"user_id"`
+	Payments     int              `json:"payments"`
+	Total        decimal.Decimal  `json:"total"`
+	MonthlyLimit *decimal.Decimal `json:"monthly_limit,omitempty"`
+}
+
+// OrgReport totals an organization's completed payments over a period, per
+// member. Former members keep their line so the totals add up.
+type OrgReport struct {
+	OrgID        uuid.UUID         `json:"org_id"`
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	Currency     string            `json:"currency"`
+	Payments     int               `json:"payments"`
+	Total        decimal.Decimal   `json:"total"`
+	Members      []*MemberSpending `json:"members"`
+	Transactions []*Transaction    `json:"transactions"`
+}
+
+// NewOrgReport builds the report for [from, to) from the organization
+// wallet's payments in that period
+func NewOrgReport(org *Organization, wallet *Wallet, members []*OrgMember, payments []*Transaction, from, to time.Time) *OrgReport {
+	report := &OrgReport{
+		OrgID:        org.ID,
+		From:         from,
+		To:           to,
+		Currency:     wallet.Currency,
+		Total:        decimal.Zero,
+		Members:      []*MemberSpending{},
+		Transactions: []*Transaction{},
+	}
+
+	byUser := make(map[uuid.UUID]*MemberSpending, len(members))
+	for _, m := range members {
+		line := &MemberSpending{UserID: m.UserID, Total: decimal.Zero, MonthlyLimit: m.MonthlyLimit}
+		byUser[m.UserID] = line
+		report.Members = append(report.Members, line)
+	}
+
+	for _, tx := range payments {
+		if tx.Status != TransactionStatusCompleted {
+			continue
+		}
+		report.Transactions = append(report.Transactions, tx)
+		report.Payments++
+		report.Total = report.Total.Add(tx.Amount)
+		if tx.MemberID == nil {
+			continue
+		}
+		line, ok := byUser[*tx.MemberID]
+		if !ok {
+			line = &MemberSpending{UserID: *tx.MemberID, Total: decimal.Zero}
+			byUser[*tx.MemberID] = line
+			report.Members = append(report.Members, line)
+		}
+		line.Payments++
+		line.Total = line.Total.Add(tx.Amount)
+	}
+
+	sort.SliceStable(report.Members, func(i, j int) bool {
+		return report.Members[i].Total.GreaterThan(report.Members[j].Total)
+	})
+	return report
+}
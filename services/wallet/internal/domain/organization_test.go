@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestOrgMember_CheckLimit(t *testing.T) {
+	limit := decimal.RequireFromString("100")
+
+	tests := []struct {
+		name    string
+		limit   *decimal.Decimal
+		spent   string
+		amount  string
+		wantErr error
+	}{
+		{"no limit", nil, "5000", "10", nil},
+		{"under limit", &limit, "80", "10", nil},
+		{"exactly at limit", &limit, "90", "10", nil},
+		{"over limit", &limit, "95", "10", ErrMemberLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &OrgMember{MonthlyLimit: tt.limit}
+			err := m.CheckLimit(decimal.RequireFromString(tt.amount), decimal.RequireFromString(tt.spent))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckLimit() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	m := &OrgMember{}
+	zero := decimal.Zero
+	if err := m.SetMonthlyLimit(&zero); !errors.Is(err, ErrInvalidLimit) {
+		t.Errorf("SetMonthlyLimit(0) error = %v, want %v", err, ErrInvalidLimit)
+	}
+}
+
+func TestNewOrgReport(t *testing.T) {
+	org := &Organization{ID: uuid.New(), OwnerID: uuid.New()}
+	wallet := NewWallet(org.ID, "MYR")
+	alice, bob, former := uuid.New(), uuid.New(), uuid.New()
+	members := []*OrgMember{{OrgID: org.ID, UserID: alice}, {OrgID: org.ID, UserID: bob}}
+
+	payment := func(member uuid.UUID, amount string, status TransactionStatus) *Transaction {
+		tx := NewTransaction(wallet.ID, TransactionTypePayment, decimal.RequireFromString(amount), decimal.Zero, "session", uuid.NewString(), "Parking")
+		tx.MemberID = &member
+		tx.Status = status
+		return tx
+	}
+
+	from := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	report := NewOrgReport(org, wallet, members, []*Transaction{
+		payment(alice, "5.00", TransactionStatusCompleted),
+		payment(alice, "7.50", TransactionStatusCompleted),
+		payment(bob, "20.00", TransactionStatusFailed),
+		payment(former, "3.00", TransactionStatusCompleted),
+	}, from, from.AddDate(0, 1, 0))
+
+	if report.Payments != 3 || !report.Total.Equal(decimal.RequireFromString("15.50")) {
+		t.Errorf("report = %d payments totalling %s, want 3 totalling 15.50", report.Payments, report.Total)
+	}
+	if len(report.Members) != 3 {
+		t.Fatalf("Members = %d, want both members and the former one", len(report.Members))
+	}
+	if top := report.Members[0]; top.UserID != alice || top.Payments != 2 {
+		t.Errorf("top spender = %+v, want alice with 2 payments", top)
+	}
+	if last := report.Members[2]; last.UserID != bob || !last.Total.IsZero() {
+		t.Errorf("last = %+v, want bob with nothing spent", last)
+	}
+}
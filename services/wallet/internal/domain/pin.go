@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// Transaction PIN errors. The PIN itself is kept and checked by auth.
+var (
+	ErrPINRequired  = errors.New("enter your transaction PIN to pay")
+	ErrPINIncorrect = errors.New("transaction PIN is incorrect")
+	ErrPINLocked    = errors.New("transaction PIN is locked after too many wrong attempts")
+	ErrPINNotSet    = errors.New("set a transaction PIN before paying")
+)
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPromotionNotFound        = errors.New("promotion not found")
+	ErrPromotionCodeExists      = errors.New("promo code already exists")
+	ErrInvalidPromotion         = errors.New("invalid promotion")
+	ErrPromotionInactive        = errors.New("promotion is not active")
+	ErrPromotionExpired         = errors.New("promotion has expired")
+	ErrPromotionExhausted       = errors.New("promotion has been fully redeemed")
+	ErrPromotionAlreadyRedeemed = errors.New("promotion already redeemed by this wallet")
+	ErrPromotionNeedsTopUp      = errors.New("promotion applies to a completed top-up")
+	ErrPromotionMinTopUp        = errors.New("top-up is below the promotion minimum")
+	ErrTopUpAlreadyPromoted     = errors.New("top-up already received a promotion bonus")
+)
+
+type BonusType string
+
+const (
+	// BonusFixed credits BonusValue ringgit
+	BonusFixed BonusType = "fixed"
+	// BonusPercentage credits BonusValue percent of a top-up, up to MaxBonus
+	BonusPercentage BonusType = "percentage"
+)
+
+// Promotion is an admin-defined promo code that credits wallets. Fixed
+// bonuses are plain credits; percentage bonuses are paid on a top-up.
+type Promotion struct {
+	ID              uuid.UUID        `json:"id"`
+	Code            string           `json:"code"`
+	Description     string           `json:"description"`
+	BonusType       BonusType        `json:"bonus_type"`
+	BonusValue      decimal.Decimal  `json:"bonus_value"`
+	MaxBonus        *decimal.Decimal `json:"max_bonus,omitempty"`
+	MinTopUp        decimal.Decimal  `json:"min_top_up"`
+	MaxRedemptions  *int             `json:"max_redemptions,omitempty"`
+	MaxPerWallet    int              `json:"max_per_wallet"`
+	RedemptionCount int              `json:"redemption_count"`
+	Active          bool             `json:"active"`
+	StartsAt        time.Time        `json:"starts_at"`
+	ExpiresAt       *time.Time       `json:"expires_at,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// NormalizePromoCode makes codes case-insensitive
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func NewPromotion(code, description string, bonusType BonusType, bonusValue decimal.Decimal, startsAt time.Time, expiresAt *time.Time) (*Promotion, error) {
+	code = NormalizePromoCode(code)
+	if code == "" || !bonusValue.IsPositive() {
+		return nil, ErrInvalidPromotion
+	}
+	switch bonusType {
+	case BonusFixed:
+	case BonusPercentage:
+		if bonusValue.GreaterThan(decimal.NewFromInt(100)) {
+			return nil, ErrInvalidPromotion
+		}
+	default:
+		return nil, ErrInvalidPromotion
+	}
+
+	now := time.Now().UTC()
+	if startsAt.IsZero() {
+		startsAt = now
+	}
+	if expiresAt != nil && !expiresAt.After(startsAt) {
+		return nil, ErrInvalidPromotion
+	}
+
+	return &Promotion{
+		ID:           uuid.New(),
+		Code:         code,
+		Description:  description,
+		BonusType:    bonusType,
+		BonusValue:   bonusValue,
+		MinTopUp:     decimal.Zero,
+		MaxPerWallet: 1,
+		Active:       true,
+		StartsAt:     startsAt.UTC(),
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}, nil
+}
+
+// SetLimits applies the optional caps. maxPerWallet of zero keeps the default
+// of one redemption per wallet.
+func (p *Promotion) SetLimits(maxBonus *decimal.Decimal, minTopUp decimal.Decimal, maxRedemptions *int, maxPerWallet int) error {
+	if maxBonus != nil && !maxBonus.IsPositive() {
+		return ErrInvalidPromotion
+	}
+	if minTopUp.IsNegative() || maxPerWallet < 0 {
+		return ErrInvalidPromotion
+	}
+	if maxRedemptions != nil && *maxRedemptions <= 0 {
+		return ErrInvalidPromotion
+	}
+	p.MaxBonus = maxBonus
+	p.MinTopUp = minTopUp
+	p.MaxRedemptions = maxRedemptions
+	if maxPerWallet > 0 {
+		p.MaxPerWallet = maxPerWallet
+	}
+	return nil
+}
+
+// NeedsTopUp reports whether the bonus is computed from a top-up
+func (p *Promotion) NeedsTopUp() bool {
+	return p.BonusType == BonusPercentage
+}
+
+// CanRedeem checks the promotion itself: active, in its window and not used
+// up. Per-wallet usage is checked separately.
+func (p *Promotion) CanRedeem(now time.Time) error {
+	switch {
+	case !p.Active:
+		return ErrPromotionInactive
+	case now.Before(p.StartsAt):
+		return ErrPromotionInactive
+	case p.ExpiresAt != nil && !now.Before(*p.ExpiresAt):
+		return ErrPromotionExpired
+	case p.MaxRedemptions != nil && p.RedemptionCount >= *p.MaxRedemptions:
+		return ErrPromotionExhausted
+	}
+	return nil
+}
+
+// Bonus returns what a redemption credits. topUp is the qualifying top-up
+// amount, ignored for fixed bonuses.
+func (p *Promotion) Bonus(topUp decimal.Decimal) (decimal.Decimal, error) {
+	if p.BonusType == BonusFixed {
+		return p.BonusValue, nil
+	}
+	if topUp.LessThan(p.MinTopUp) {
+		return decimal.Zero, ErrPromotionMinTopUp
+	}
+	bonus := topUp.Mul(p.BonusValue).Div(decimal.NewFromInt(100)).RoundDown(2)
+	if p.MaxBonus != nil && bonus.GreaterThan(*p.MaxBonus) {
+		bonus = *p.MaxBonus
+	}
+	if !bonus.IsPositive() {
+		return decimal.Zero, ErrPromotionMinTopUp
+	}
+	return bonus, nil
+}
+
+// Redeem counts a redemption against the promotion's cap
+func (p *Promotion) Redeem() {
+	p.RedemptionCount++
+	p.UpdatedAt = time.Now().UTC()
+}
+
+func (p *Promotion) Deactivate() {
+	p.Active = false
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// PromotionRedemption records one wallet redeeming a promotion
+type PromotionRedemption struct {
+	ID                 uuid.UUID       `json:"id"`
+	PromotionID        uuid.UUID       `json:"promotion_id"`
+	WalletID           uuid.UUID       `json:"wallet_id"`
+	TransactionID      uuid.UUID       `json:"transaction_id"`
+	TopUpTransactionID *uuid.UUID      `json:"top_up_transaction_id,omitempty"`
+	Bonus              decimal.Decimal `json:"bonus"`
+	RedeemedAt         time.Time       `json:"redeemed_at"`
+}
+
+// NewPromoCredit creates the completed transaction that pays a bonus into a
+// wallet whose balance is balanceBefore
+func NewPromoCredit(walletID uuid.UUID, promotion *Promotion, bonus, balanceBefore decimal.Decimal) *Transaction {
+	tx := NewTransaction(
+		walletID,
+		TransactionTypePromoCredit,
+		bonus,
+		balanceBefore,
+		promotion.Code,
+		"",
+		"Promo code "+promotion.Code,
+	)
+	tx.Complete(balanceBefore.Add(bonus))
+	return tx
+}
@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewPromotion(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+
+	tests := []struct {
+		name      string
+		code      string
+		bonusType BonusType
+		value     string
+		expiresAt *time.Time
+		wantErr   error
+	}{
+		{"fixed bonus", " welcome10 ", BonusFixed, "10", nil, nil},
+		{"percentage bonus", "TOPUP5", BonusPercentage, "5", nil, nil},
+		{"empty code", "  ", BonusFixed, "10", nil, ErrInvalidPromotion},
+		{"zero bonus", "ZERO", BonusFixed, "0", nil, ErrInvalidPromotion},
+		{"percentage over 100", "DOUBLE", BonusPercentage, "150", nil, ErrInvalidPromotion},
+		{"unknown bonus type", "ODD", BonusType("cashback"), "5", nil, ErrInvalidPromotion},
+		{"expires before it starts", "LATE", BonusFixed, "5", &past, ErrInvalidPromotion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPromotion(tt.code, "", tt.bonusType, decimal.RequireFromString(tt.value), now, tt.expiresAt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewPromotion() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if p.Code != NormalizePromoCode(tt.code) || !p.Active || p.MaxPerWallet != 1 {
+				t.Errorf("promotion = %+v, want active, upper-cased code and one redemption per wallet", p)
+			}
+		})
+	}
+}
+
+func TestPromotion_CanRedeem(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Hour)
+	expired := now.Add(-time.Minute)
+	capped := 2
+
+	tests := []struct {
+		name    string
+		modify  func(p *Promotion)
+		wantErr error
+	}{
+		{"redeemable", func(p *Promotion) {}, nil},
+		{"deactivated", func(p *Promotion) { p.Deactivate() }, ErrPromotionInactive},
+		{"not started", func(p *Promotion) { p.StartsAt = future }, ErrPromotionInactive},
+		{"expired", func(p *Promotion) { p.ExpiresAt = &expired }, ErrPromotionExpired},
+		{"cap reached", func(p *Promotion) {
+			p.MaxRedemptions = &capped
+			p.Redeem()
+			p.Redeem()
+		}, ErrPromotionExhausted},
+		{"under cap", func(p *Promotion) {
+			p.MaxRedemptions = &capped
+			p.Redeem()
+		}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPromotion("CODE", "", BonusFixed, decimal.NewFromInt(5), now.Add(-time.Hour), nil)
+			if err != nil {
+				t.Fatalf("NewPromotion() error = %v", err)
+			}
+			tt.modify(p)
+			if err := p.CanRedeem(now); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CanRedeem() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromotion_Bonus(t *testing.T) {
+	maxBonus := decimal.NewFromInt(15)
+
+	tests := []struct {
+		name      string
+		bonusType BonusType
+		value     string
+		minTopUp  string
+		topUp     string
+		want      string
+		wantErr   error
+	}{
+		{"fixed ignores top-up", BonusFixed, "10", "0", "0", "10", nil},
+		{"percentage of top-up", BonusPercentage, "10", "0", "50", "5", nil},
+		{"percentage rounds down to the sen", BonusPercentage, "3", "0", "33.33", "0.99", nil},
+		{"percentage capped", BonusPercentage, "10", "0", "500", "15", nil},
+		{"below minimum top-up", BonusPercentage, "10", "20", "19.99", "", ErrPromotionMinTopUp},
+		{"bonus rounds to nothing", BonusPercentage, "1", "0", "0.50", "", ErrPromotionMinTopUp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewPromotion("CODE", "", tt.bonusType, decimal.RequireFromString(tt.value), time.Time{}, nil)
+			if err != nil {
+				t.Fatalf("NewPromotion() error = %v", err)
+			}
+			if err := p.SetLimits(&maxBonus, decimal.RequireFromString(tt.minTopUp), nil, 0); err != nil {
+				t.Fatalf("SetLimits() error = %v", err)
+			}
+
+			got, err := p.Bonus(decimal.RequireFromString(tt.topUp))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Bonus() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Bonus() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPromoCredit(t *testing.T) {
+	p, err := NewPromotion("WELCOME", "", BonusFixed, decimal.NewFromInt(10), time.Time{}, nil)
+	if err != nil {
+		t.Fatalf("NewPromotion() error = %v", err)
+	}
+
+	tx := NewPromoCredit(uuid.New(), p, decimal.NewFromInt(10), decimal.NewFromInt(25))
+	if !tx.IsCompleted() || !tx.BalanceAfter.Equal(decimal.NewFromInt(35)) {
+		t.Errorf("credit = %+v, want completed with balance 35", tx)
+	}
+
+	entries := NewPosting(tx)
+	if err := ValidatePosting(entries); err != nil {
+		t.Fatalf("ValidatePosting() error = %v", err)
+	}
+	if entries[1].Account != AccountPromotions || !entries[1].Amount.Equal(decimal.NewFromInt(-10)) {
+		t.Errorf("counter entry = %+v, want -10 from %s", entries[1], AccountPromotions)
+	}
+}
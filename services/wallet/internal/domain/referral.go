@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrReferralNotFound   = errors.New("referral not found")
+	ErrReferralNotPending = errors.New("referral has already been settled")
+	ErrReferralExpired    = errors.New("referral expired before the first payment")
+)
+
+type ReferralStatus string
+
+const (
+	// ReferralPending waits for the referee's first payment
+	ReferralPending ReferralStatus = "pending"
+	// ReferralRewarded has paid both parties
+	ReferralRewarded ReferralStatus = "rewarded"
+	// ReferralExpired ran out of time before the referee paid
+	ReferralExpired ReferralStatus = "expired"
+)
+
+// ReferralParty says which side of a referral a reward went to
+type ReferralParty string
+
+const (
+	ReferralReferrer ReferralParty = "referrer"
+	ReferralReferee  ReferralParty = "referee"
+)
+
+// ReferralPolicy sets what a referral pays and how long the referee has to
+// make the payment that earns it
+type ReferralPolicy struct {
+	ReferrerReward decimal.Decimal
+	RefereeReward  decimal.Decimal
+	Window         time.Duration
+}
+
+// Referral is a user who registered with another user's code. Both are
+// rewarded once, when the referee makes their first payment inside the
+// window. The ID is the one auth gave the referral.
+type Referral struct {
+	ID             uuid.UUID       `json:"id"`
+	ReferrerID     uuid.UUID       `json:"referrer_id"`
+	RefereeID      uuid.UUID       `json:"referee_id"`
+	Status         ReferralStatus  `json:"status"`
+	ReferrerReward decimal.Decimal `json:"referrer_reward"`
+	RefereeReward  decimal.Decimal `json:"referee_reward"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	RewardedAt     *time.Time      `json:"rewarded_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// NewReferral starts a pending referral that expires after the policy's window
+func NewReferral(id, referrerID, refereeID uuid.UUID, policy ReferralPolicy, now time.Time) *Referral {
+	return &Referral{
+		ID:         id,
+		ReferrerID: referrerID,
+		RefereeID:  refereeID,
+		Status:     ReferralPending,
+		ExpiresAt:  now.Add(policy.Window).UTC(),
+		CreatedAt:  now.UTC(),
+	}
+}
+
+// Reward settles the referral on the referee's first payment. A referral
+// past its window is marked expired and returns ErrReferralExpired, which
+// the caller still saves.
+func (r *Referral) Reward(policy ReferralPolicy, now time.Time) error {
+	if r.Status != ReferralPending {
+		return ErrReferralNotPending
+	}
+	if !now.Before(r.ExpiresAt) {
+		r.Status = ReferralExpired
+		return ErrReferralExpired
+	}
+	rewardedAt := now.UTC()
+	r.Status = ReferralRewarded
+	r.ReferrerReward = policy.ReferrerReward
+	r.RefereeReward = policy.RefereeReward
+	r.RewardedAt = &rewardedAt
+	return nil
+}
+
+// ReferralSummary totals a referrer's referrals for their dashboard
+type ReferralSummary struct {
+	Pending  int             `json:"pending"`
+	Rewarded int             `json:"rewarded"`
+	Expired  int             `json:"expired"`
+	Earned   decimal.Decimal `json:"earned"`
+}
+
+// NewReferralCredit creates the completed transaction that pays one party's
+// reward into a wallet whose balance is balanceBefore. The idempotency key
+// stops a referral paying the same party twice.
+func NewReferralCredit(walletID uuid.UUID, referral *Referral, party ReferralParty, reward, balanceBefore decimal.Decimal) *Transaction {
+	tx := NewTransaction(
+		walletID,
+		TransactionTypeReferralCredit,
+		reward,
+		balanceBefore,
+		referral.ID.String(),
+		"referral-"+referral.ID.String()+"-"+string(party),
+		"Referral reward",
+	)
+	tx.Complete(balanceBefore.Add(reward))
+	return tx
+}
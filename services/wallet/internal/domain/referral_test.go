@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var testReferralPolicy = ReferralPolicy{
+	ReferrerReward: decimal.RequireFromString("5.00"),
+	RefereeReward:  decimal.RequireFromString("3.00"),
+	Window:         30 * 24 * time.Hour,
+}
+
+func TestReferral_Reward(t *testing.T) {
+	created := time.Now()
+
+	tests := []struct {
+		name       string
+		status     ReferralStatus
+		paidAfter  time.Duration
+		wantErr    error
+		wantStatus ReferralStatus
+	}{
+		{"first payment in window", ReferralPending, time.Hour, nil, ReferralRewarded},
+		{"payment after window", ReferralPending, 31 * 24 * time.Hour, ErrReferralExpired, ReferralExpired},
+		{"already rewarded", ReferralRewarded, time.Hour, ErrReferralNotPending, ReferralRewarded},
+		{"already expired", ReferralExpired, time.Hour, ErrReferralNotPending, ReferralExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReferral(uuid.New(), uuid.New(), uuid.New(), testReferralPolicy, created)
+			r.Status = tt.status
+
+			err := r.Reward(testReferralPolicy, created.Add(tt.paidAfter))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Reward() error = %v, want %v", err, tt.wantErr)
+			}
+			if r.Status != tt.wantStatus {
+				t.Errorf("Status = %s, want %s", r.Status, tt.wantStatus)
+			}
+			if err == nil && (!r.ReferrerReward.Equal(testReferralPolicy.ReferrerReward) || r.RewardedAt == nil) {
+				t.Errorf("rewarded referral = %+v, want the policy's rewards", r)
+			}
+		})
+	}
+}
+
+func TestNewReferralCredit(t *testing.T) {
+	r := NewReferral(uuid.New(), uuid.New(), uuid.New(), testReferralPolicy, time.Now())
+	walletID := uuid.New()
+
+	referrer := NewReferralCredit(walletID, r, ReferralReferrer, testReferralPolicy.ReferrerReward, decimal.RequireFromString("10.00"))
+	if referrer.Status != TransactionStatusCompleted || !referrer.BalanceAfter.Equal(decimal.RequireFromString("15.00")) {
+		t.Errorf("credit = %+v, want a completed credit to 15.00", referrer)
+	}
+	referee := NewReferralCredit(walletID, r, ReferralReferee, testReferralPolicy.RefereeReward, decimal.Zero)
+	if referrer.IdempotencyKey == referee.IdempotencyKey {
+		t.Error("each party's reward should have its own idempotency key")
+	}
+	if counterAccount(referrer.Type) != AccountReferrals {
+		t.Errorf("counter account = %s, want %s", counterAccount(referrer.Type), AccountReferrals)
+	}
+}
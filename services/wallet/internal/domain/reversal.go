@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNotReversible   = errors.New("only completed top-ups, payments, refunds, transfers and credits can be reversed")
+	ErrAlreadyReversed = errors.New("transaction has already been reversed")
+	ErrAdminRequired   = errors.New("only platform admins can do this")
+	ErrSelfReversal    = errors.New("admins can't reverse transactions on their own wallet")
+)
+
+// ReversalKey is the idempotency key of a transaction's reversal. Keys are
+// unique, so a transaction can only ever be reversed once.
+func ReversalKey(originalID uuid.UUID) string {
+	return "reversal-" + originalID.String()
+}
+
+// CanReverse reports whether an admin may reverse the transaction. Holds
+// are settled through their own capture and release, a refunded payment has
+// already been undone, and reversals are not themselves reversed.
+func (t *Transaction) CanReverse() bool {
+	if t.Status != TransactionStatusCompleted || t.SignedAmount().IsZero() {
+		return false
+	}
+	switch t.Type {
+	case TransactionTypeTopUp, TransactionTypePayment, TransactionTypeRefund, TransactionTypeTransfer,
+		TransactionTypePromoCredit, TransactionTypeRoundingAdjustment:
+		return true
+	}
+	return false
+}
+
+// NewReversal creates the compensating transaction that undoes original's
+// effect on a wallet whose balance is now balance. The original is never
+// changed; the reversal points back at it and records the admin who made it.
+func NewReversal(original *Transaction, balance decimal.Decimal, adminID uuid.UUID, reason string) (*Transaction, error) {
+	if !original.CanReverse() {
+		return nil, ErrNotReversible
+	}
+	after := balance.Sub(original.SignedAmount())
+	if after.IsNegative() {
+		return nil, ErrInsufficientBalance
+	}
+
+	tx := NewTransaction(
+		original.WalletID,
+		TransactionTypeReversal,
+		original.Amount,
+		balance,
+		original.ReferenceID,
+		ReversalKey(original.ID),
+		reason,
+	)
+	tx.ParentTransactionID = &original.ID
+	tx.ProviderID = original.ProviderID
+	tx.Category = original.Category
+	tx.MemberID = original.MemberID
+	tx.AdminID = &adminID
+	tx.copyFee(original)
+	tx.copyTaxes(original)
+	tx.Complete(after)
+	return tx, nil
+}
+
+// ApplyReversal moves the wallet balance by a reversal. Unlike Credit and
+// Debit it works on frozen wallets, which is often where admins need it.
+func (w *Wallet) ApplyReversal(reversal *Transaction) {
+	w.Balance = reversal.BalanceAfter
+	w.UpdatedAt = time.Now().UTC()
+}
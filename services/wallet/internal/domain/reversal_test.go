@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestNewReversal(t *testing.T) {
+	walletID := uuid.New()
+	admin := uuid.New()
+
+	completed := func(txType TransactionType, before, after string) *Transaction {
+		tx := NewTransaction(walletID, txType, decimal.RequireFromString(before).Sub(decimal.RequireFromString(after)).Abs(),
+			decimal.RequireFromString(before), "ref", uuid.NewString(), "")
+		tx.Complete(decimal.RequireFromString(after))
+		return tx
+	}
+	refunded := completed(TransactionTypePayment, "30", "20")
+	refunded.Status = TransactionStatusRefunded
+
+	tests := []struct {
+		name        string
+		original    *Transaction
+		balance     string
+		wantBalance string
+		wantErr     error
+	}{
+		{"payment is credited back", completed(TransactionTypePayment, "30", "22.50"), "22.50", "30", nil},
+		{"top-up is taken back", completed(TransactionTypeTopUp, "0", "50"), "80", "30", nil},
+		{"top-up already spent", completed(TransactionTypeTopUp, "0", "50"), "20", "", ErrInsufficientBalance},
+		{"refunded payment", refunded, "30", "", ErrNotReversible},
+		{"hold", completed(TransactionTypeHold, "30", "20"), "20", "", ErrNotReversible},
+		{"reversal", completed(TransactionTypeReversal, "20", "30"), "30", "", ErrNotReversible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reversal, err := NewReversal(tt.original, decimal.RequireFromString(tt.balance), admin, "Chargeback")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewReversal() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reversal.BalanceAfter.Equal(decimal.RequireFromString(tt.wantBalance)) {
+				t.Errorf("BalanceAfter = %s, want %s", reversal.BalanceAfter, tt.wantBalance)
+			}
+			if !reversal.SignedAmount().Equal(tt.original.SignedAmount().Neg()) {
+				t.Errorf("reversal moves %s, want %s", reversal.SignedAmount(), tt.original.SignedAmount().Neg())
+			}
+			if *reversal.ParentTransactionID != tt.original.ID || *reversal.AdminID != admin {
+				t.Errorf("reversal does not link the original and admin: %+v", reversal)
+			}
+			if reversal.IdempotencyKey != ReversalKey(tt.original.ID) {
+				t.Errorf("IdempotencyKey = %q", reversal.IdempotencyKey)
+			}
+			if tt.original.Status != TransactionStatusCompleted {
+				t.Errorf("original status changed to %s", tt.original.Status)
+			}
+
+			entries := NewReversalPosting(reversal, tt.original)
+			original := NewPosting(tt.original)
+			if entries[1].Account != original[1].Account || !entries[1].Amount.Equal(original[1].Amount.Neg()) {
+				t.Errorf("reversal counter entry = %s %s, want it to cancel %s %s",
+					entries[1].Account, entries[1].Amount, original[1].Account, original[1].Amount)
+			}
+		})
+	}
+}
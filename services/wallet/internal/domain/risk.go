@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrRiskBlocked           = errors.New("transaction blocked by risk checks")
+	ErrRiskChallengeRequired = errors.New("transaction needs the user to confirm it")
+)
+
+// RiskDecision is what a risk check says should happen to an operation
+type RiskDecision string
+
+const (
+	RiskAllow     RiskDecision = "allow"
+	RiskChallenge RiskDecision = "challenge" // proceed once the user confirms with an OTP
+	RiskBlock     RiskDecision = "block"
+)
+
+// Reasons a rule can flag an operation
+const (
+	RiskReasonVelocity      = "velocity"
+	RiskReasonUnusualAmount = "unusual_amount"
+	RiskReasonNewDevice     = "new_device"
+)
+
+// RiskAssessment is the outcome of a risk check. Score runs from 0 (no
+// concern) to 100.
+type RiskAssessment struct {
+	Score    int          `json:"score"`
+	Decision RiskDecision `json:"decision"`
+	Reasons  []string     `json:"reasons,omitempty"`
+}
+
+// Err returns the error for an operation the assessment does not allow
+func (a *RiskAssessment) Err() error {
+	switch a.Decision {
+	case RiskBlock:
+		return ErrRiskBlocked
+	case RiskChallenge:
+		return ErrRiskChallengeRequired
+	default:
+		return nil
+	}
+}
+
+// RiskHistory is what a wallet has done before, as the rules see it
+type RiskHistory struct {
+	// Recent counts top-ups and payments attempted within the velocity window
+	Recent int
+	// Completed and Average describe completed transactions of the type
+	// being checked
+	Completed int
+	Average   decimal.Decimal
+	// KnownDevice is set when a completed transaction came from the device;
+	// HasDevices when any did
+	KnownDevice bool
+	HasDevices  bool
+}
+
+// RiskRules scores an operation against the wallet's history. Each rule
+// that fires adds its weight; the total decides the outcome.
+type RiskRules struct {
+	VelocityWindow time.Duration
+	VelocityLimit  int
+	// An amount is unusual when it is more than UnusualFactor times the
+	// wallet's average and over UnusualMinimum, once the wallet has
+	// UnusualMinHistory completed transactions to compare with
+	UnusualFactor     decimal.Decimal
+	UnusualMinimum    decimal.Decimal
+	UnusualMinHistory int
+	ChallengeScore    int
+	BlockScore        int
+}
+
+// Rule weights
+const (
+	riskWeightVelocity      = 50
+	riskWeightUnusualAmount = 40
+	riskWeightNewDevice     = 30
+)
+
+// Assess scores an operation of amount from deviceID. An empty device ID
+// cannot be judged new.
+func (r RiskRules) Assess(amount decimal.Decimal, deviceID string, history RiskHistory) *RiskAssessment {
+	a := &RiskAssessment{Decision: RiskAllow}
+	flag := func(reason string, weight int) {
+		a.Reasons = append(a.Reasons, reason)
+		a.Score += weight
+	}
+
+	if r.VelocityLimit > 0 && history.Recent >= r.VelocityLimit {
+		flag(RiskReasonVelocity, riskWeightVelocity)
+	}
+	if history.Completed >= r.UnusualMinHistory && amount.GreaterThan(r.UnusualMinimum) &&
+		amount.GreaterThan(history.Average.Mul(r.UnusualFactor)) {
+		flag(RiskReasonUnusualAmount, riskWeightUnusualAmount)
+	}
+	if deviceID != "" && history.HasDevices && !history.KnownDevice {
+		flag(RiskReasonNewDevice, riskWeightNewDevice)
+	}
+
+	if a.Score > 100 {
+		a.Score = 100
+	}
+	switch {
+	case r.BlockScore > 0 && a.Score >= r.BlockScore:
+		a.Decision = RiskBlock
+	case r.ChallengeScore > 0 && a.Score >= r.ChallengeScore:
+		a.Decision = RiskChallenge
+	}
+	return a
+}
+
+// SetRisk records the device an operation came from and, when it was
+// checked, its risk score
+func (t *Transaction) SetRisk(deviceID string, a *RiskAssessment) {
+	t.DeviceID = deviceID
+	if a != nil {
+		score := a.Score
+		t.RiskScore = &score
+	}
+}
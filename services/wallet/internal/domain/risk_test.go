@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestRiskRules_Assess(t *testing.T) {
+	rules := RiskRules{
+		VelocityWindow:    10 * time.Minute,
+		VelocityLimit:     5,
+		UnusualFactor:     decimal.NewFromInt(5),
+		UnusualMinimum:    decimal.NewFromInt(50),
+		UnusualMinHistory: 3,
+		ChallengeScore:    40,
+		BlockScore:        80,
+	}
+	usual := RiskHistory{Completed: 10, Average: decimal.NewFromInt(20), HasDevices: true, KnownDevice: true}
+
+	tests := []struct {
+		name         string
+		amount       int64
+		deviceID     string
+		history      func(h RiskHistory) RiskHistory
+		wantScore    int
+		wantDecision RiskDecision
+	}{
+		{"usual payment", 20, "phone-1", func(h RiskHistory) RiskHistory { return h }, 0, RiskAllow},
+		{"new device only", 20, "phone-2", func(h RiskHistory) RiskHistory {
+			h.KnownDevice = false
+			return h
+		}, 30, RiskAllow},
+		{"unusual amount", 150, "phone-1", func(h RiskHistory) RiskHistory { return h }, 40, RiskChallenge},
+		{"large but under minimum", 50, "phone-1", func(h RiskHistory) RiskHistory {
+			h.Average = decimal.NewFromInt(1)
+			return h
+		}, 0, RiskAllow},
+		{"large without history", 150, "phone-1", func(h RiskHistory) RiskHistory {
+			h.Completed = 2
+			return h
+		}, 0, RiskAllow},
+		{"velocity", 20, "phone-1", func(h RiskHistory) RiskHistory {
+			h.Recent = 5
+			return h
+		}, 50, RiskChallenge},
+		{"velocity from new device", 20, "phone-2", func(h RiskHistory) RiskHistory {
+			h.Recent = 5
+			h.KnownDevice = false
+			return h
+		}, 80, RiskBlock},
+		{"first device on wallet", 20, "phone-1", func(h RiskHistory) RiskHistory {
+			h.HasDevices = false
+			h.KnownDevice = false
+			return h
+		}, 0, RiskAllow},
+		{"no device ID", 20, "", func(h RiskHistory) RiskHistory {
+			h.KnownDevice = false
+			return h
+		}, 0, RiskAllow},
+		{"everything", 500, "phone-2", func(h RiskHistory) RiskHistory {
+			h.Recent = 9
+			h.KnownDevice = false
+			return h
+		}, 100, RiskBlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rules.Assess(decimal.NewFromInt(tt.amount), tt.deviceID, tt.history(usual))
+			if got.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d (reasons %v)", got.Score, tt.wantScore, got.Reasons)
+			}
+			if got.Decision != tt.wantDecision {
+				t.Errorf("Decision = %v, want %v", got.Decision, tt.wantDecision)
+			}
+		})
+	}
+}
+
+func TestRiskAssessment_Err(t *testing.T) {
+	tests := []struct {
+		decision RiskDecision
+		want     error
+	}{
+		{RiskAllow, nil},
+		{RiskChallenge, ErrRiskChallengeRequired},
+		{RiskBlock, ErrRiskBlocked},
+	}
+
+	for _, tt := range tests {
+		a := &RiskAssessment{Decision: tt.decision}
+		if err := a.Err(); err != tt.want {
+			t.Errorf("Err() for %v = %v, want %v", tt.decision, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type RoundingRule string
+
+const (
+	RoundingNone RoundingRule = "none"
+	// Rounding5Sen rounds to the nearest 5 sen, as Bank Negara Malaysia
+	// requires for cash settlement: 1.01/1.02 -> 1.00, 1.03/1.04 -> 1.05
+	Rounding5Sen RoundingRule = "5sen"
+)
+
+var fiveSenSteps = decimal.NewFromInt(20)
+
+// Apply returns the amount actually charged under this rule
+func (r RoundingRule) Apply(amount decimal.Decimal) decimal.Decimal {
+	switch r {
+	case Rounding5Sen:
+		return amount.Mul(fiveSenSteps).Round(0).Div(fiveSenSteps).Round(2)
+	default:
+		return amount
+	}
+}
+
+// RoundingPolicy maps payment methods to the rounding rule applied when charging them.
+// Methods not listed are charged the exact amount.
+type RoundingPolicy map[string]RoundingRule
+
+// NewRoundingPolicy builds a policy applying 5-sen rounding to the given methods
+func NewRoundingPolicy(fiveSenMethods []string) RoundingPolicy {
+	policy := make(RoundingPolicy)
+	for _, method := range fiveSenMethods {
+		method = strings.ToLower(strings.TrimSpace(method))
+		if method != "" {
+			policy[method] = Rounding5Sen
+		}
+	}
+	return policy
+}
+
+func (p RoundingPolicy) RuleFor(paymentMethod string) RoundingRule {
+	if rule, ok := p[strings.ToLower(paymentMethod)]; ok {
+		return rule
+	}
+	return RoundingNone
+}
+
+// Apply returns the charged amount for a payment method and the difference
+// from the calculated amount (charged - calculated, may be negative).
+func (p RoundingPolicy) Apply(paymentMethod string, amount decimal.Decimal) (charged, adjustment decimal.Decimal) {
+	charged = p.RuleFor(paymentMethod).Apply(amount)
+	return charged, charged.Sub(amount)
+}
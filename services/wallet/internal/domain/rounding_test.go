@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestRoundingRule_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   RoundingRule
+		amount string
+		want   string
+	}{
+		{"5sen round down 1 sen", Rounding5Sen, "10.01", "10.00"},
+		{"5sen round down 2 sen", Rounding5Sen, "10.02", "10.00"},
+		{"5sen round up 3 sen", Rounding5Sen, "10.03", "10.05"},
+		{"5sen round up 4 sen", Rounding5Sen, "10.04", "10.05"},
+		{"5sen exact", Rounding5Sen, "10.05", "10.05"},
+		{"5sen round down 6 sen", Rounding5Sen, "10.06", "10.05"},
+		{"5sen round up 8 sen", Rounding5Sen, "10.08", "10.10"},
+		{"5sen round up to next ringgit", Rounding5Sen, "10.98", "11.00"},
+		{"none leaves amount", RoundingNone, "10.03", "10.03"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.Apply(decimal.RequireFromString(tt.amount))
+			if !got.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Apply(%s) = %s, want %s", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundingPolicy_Apply(t *testing.T) {
+	policy := NewRoundingPolicy([]string{"cash", " Kiosk "})
+
+	tests := []struct {
+		name           string
+		method         string
+		amount         string
+		wantCharged    string
+		wantAdjustment string
+	}{
+		{"rounded up", "cash", "12.43", "12.45", "0.02"},
+		{"rounded down", "cash", "12.41", "12.40", "-0.01"},
+		{"method matched case-insensitively", "KIOSK", "12.43", "12.45", "0.02"},
+		{"unlisted method", "card", "12.43", "12.43", "0"},
+		{"no method", "", "12.43", "12.43", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			charged, adjustment := policy.Apply(tt.method, decimal.RequireFromString(tt.amount))
+			if !charged.Equal(decimal.RequireFromString(tt.wantCharged)) {
+				t.Errorf("charged = %s, want %s", charged, tt.wantCharged)
+			}
+			if !adjustment.Equal(decimal.RequireFromString(tt.wantAdjustment)) {
+				t.Errorf("adjustment = %s, want %s", adjustment, tt.wantAdjustment)
+			}
+		})
+	}
+}
+
+func TestNewRoundingAdjustment(t *testing.T) {
+	parent := NewTransaction(uuid.New(), TransactionTypeTopUp, decimal.RequireFromString("12.43"),
+		decimal.RequireFromString("100.00"), "", "idem-1", "Wallet top-up")
+	parent.Complete(decimal.RequireFromString("112.43"))
+
+	tests := []struct {
+		name       string
+		adjustment string
+		wantAmount string
+		wantAfter  string
+	}{
+		{"credit", "0.02", "0.02", "112.45"},
+		{"debit", "-0.01", "0.01", "112.42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adj := NewRoundingAdjustment(parent, decimal.RequireFromString(tt.adjustment))
+
+			if adj.Type != TransactionTypeRoundingAdjustment {
+				t.Errorf("expected type rounding_adjustment, got %s", adj.Type)
+			}
+			if adj.ParentTransactionID == nil || *adj.ParentTransactionID != parent.ID {
+				t.Error("expected parent transaction ID to be set")
+			}
+			if !adj.Amount.Equal(decimal.RequireFromString(tt.wantAmount)) {
+				t.Errorf("amount = %s, want %s", adj.Amount, tt.wantAmount)
+			}
+			if !adj.BalanceBefore.Equal(parent.BalanceAfter) {
+				t.Errorf("balance before = %s, want %s", adj.BalanceBefore, parent.BalanceAfter)
+			}
+			if !adj.BalanceAfter.Equal(decimal.RequireFromString(tt.wantAfter)) {
+				t.Errorf("balance after = %s, want %s", adj.BalanceAfter, tt.wantAfter)
+			}
+			if !adj.SignedAmount().Equal(decimal.RequireFromString(tt.adjustment)) {
+				t.Errorf("signed amount = %s, want %s", adj.SignedAmount(), tt.adjustment)
+			}
+			if adj.IdempotencyKey != "idem-1:rounding" {
+				t.Errorf("idempotency key = %s, want idem-1:rounding", adj.IdempotencyKey)
+			}
+		})
+	}
+}
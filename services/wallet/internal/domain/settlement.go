@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrSettlementNotFound          = errors.New("settlement not found")
+	ErrSettlementExists            = errors.New("provider already has a settlement for this day")
+	ErrNothingToSettle             = errors.New("no provider transactions in the settlement period")
+	ErrAlreadySettled              = errors.New("settlement has already been paid out")
+	ErrSettlementReferenceRequired = errors.New("a payout reference is required")
+)
+
+// SettlementStatus tracks a settlement until the provider has been paid
+type SettlementStatus string
+
+const (
+	SettlementStatusPending SettlementStatus = "pending"
+	SettlementStatusSettled SettlementStatus = "settled"
+)
+
+// SettlementPeriod returns the day before the one containing now, in loc
+func SettlementPeriod(now time.Time, loc *time.Location) (start, end time.Time) {
+	local := now.In(loc)
+	end = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	return end.AddDate(0, 0, -1), end
+}
+
+// SettlementLine is one transaction a provider is paid or charged for.
+// Amount is the gross the wallet paid: positive for payments, negative for
+// money returned to a wallet. Fee is the platform's share of it and Net what
+// is left for the provider.
+type SettlementLine struct {
+	TransactionID uuid.UUID       `json:"transaction_id"`
+	Type          TransactionType `json:"type"`
+	Date          time.Time       `json:"date"`
+	ReferenceID   string          `json:"reference_id"`
+	Description   string          `json:"description"`
+	Amount        decimal.Decimal `json:"amount"`
+	Fee           decimal.Decimal `json:"fee"`
+	Net           decimal.Decimal `json:"net"`
+}
+
+// Settlement is what a provider is owed for one day of parking payments.
+// Lines are stored as calculated, so an export always matches the payout.
+type Settlement struct {
+	ID           uuid.UUID        `json:"id"`
+	ProviderID   uuid.UUID        `json:"provider_id"`
+	PeriodStart  time.Time        `json:"period_start"`
+	PeriodEnd    time.Time        `json:"period_end"`
+	Lines        []SettlementLine `json:"lines,omitempty"`
+	PaymentCount int              `json:"payment_count"`
+	GrossAmount  decimal.Decimal  `json:"gross_amount"`
+	RefundAmount decimal.Decimal  `json:"refund_amount"`
+	FeeAmount    decimal.Decimal  `json:"fee_amount"`
+	NetAmount    decimal.Decimal  `json:"net_amount"`
+	Status       SettlementStatus `json:"status"`
+	Reference    string           `json:"reference,omitempty"`
+	SettledBy    *uuid.UUID       `json:"settled_by,omitempty"`
+	SettledAt    *time.Time       `json:"settled_at,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// SettlementLineFor returns the line for a transaction booked against a
+// provider, or false for transactions providers are not settled on. The
+// provider is owed whatever the transaction took from the wallet, less the
+// fee charged on it; money returned to a wallet returns its fee too.
+func SettlementLineFor(tx *Transaction) (SettlementLine, bool) {
+	if tx.ProviderID == nil {
+		return SettlementLine{}, false
+	}
+	switch tx.Type {
+	case TransactionTypePayment:
+		// A refunded payment was still paid; its refund is a line of its own
+		if tx.Status != TransactionStatusCompleted && tx.Status != TransactionStatusRefunded {
+			return SettlementLine{}, false
+		}
+	case TransactionTypeRefund, TransactionTypeReversal, TransactionTypeRoundingAdjustment:
+		if !tx.IsCompleted() {
+			return SettlementLine{}, false
+		}
+	default:
+		return SettlementLine{}, false
+	}
+
+	amount := tx.SignedAmount().Neg()
+	fee := decimal.Zero
+	if tx.FeeAmount != nil {
+		fee = *tx.FeeAmount
+		if amount.IsNegative() {
+			fee = fee.Neg()
+		}
+	}
+	return SettlementLine{
+		TransactionID: tx.ID,
+		Type:          tx.Type,
+		Date:          tx.CreatedAt,
+		ReferenceID:   tx.ReferenceID,
+		Description:   tx.Description,
+		Amount:        amount,
+		Fee:           fee,
+		Net:           amount.Sub(fee),
+	}, true
+}
+
+// NewSettlement totals a provider's transactions for [start, end). The net
+// amount is what the provider is paid: payments less refunds and fees.
+func NewSettlement(providerID uuid.UUID, start, end time.Time, transactions []*Transaction) (*Settlement, error) {
+	now := time.Now().UTC()
+	s := &Settlement{
+		ID:          uuid.New(),
+		ProviderID:  providerID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Status:      SettlementStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	for _, tx := range transactions {
+		if tx.ProviderID == nil || *tx.ProviderID != providerID {
+			continue
+		}
+		line, ok := SettlementLineFor(tx)
+		if !ok {
+			continue
+		}
+		s.Lines = append(s.Lines, line)
+		if line.Type == TransactionTypePayment {
+			s.PaymentCount++
+		}
+		if line.Amount.IsPositive() {
+			s.GrossAmount = s.GrossAmount.Add(line.Amount)
+		} else {
+			s.RefundAmount = s.RefundAmount.Sub(line.Amount)
+		}
+		s.FeeAmount = s.FeeAmount.Add(line.Fee)
+	}
+	if len(s.Lines) == 0 {
+		return nil, ErrNothingToSettle
+	}
+	s.NetAmount = s.GrossAmount.Sub(s.RefundAmount).Sub(s.FeeAmount)
+	return s, nil
+}
+
+// MarkSettled records the payout made to the provider
+func (s *Settlement) MarkSettled(reference string, adminID uuid.UUID, now time.Time) error {
+	reference = strings.TrimSpace(reference)
+	if reference == "" {
+		return ErrSettlementReferenceRequired
+	}
+	if s.Status == SettlementStatusSettled {
+		return ErrAlreadySettled
+	}
+	s.Status = SettlementStatusSettled
+	s.Reference = reference
+	s.SettledBy = &adminID
+	s.SettledAt = &now
+	s.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestSettlementPeriod(t *testing.T) {
+	myt := time.FixedZone("MYT", 8*60*60)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantStart string
+	}{
+		{"mid day", time.Date(2026, 9, 15, 6, 0, 0, 0, time.UTC), "2026-09-14"},
+		{"UTC evening is the next local day", time.Date(2026, 9, 15, 17, 0, 0, 0, time.UTC), "2026-09-15"},
+		{"first of the month", time.Date(2026, 10, 1, 1, 0, 0, 0, time.UTC), "2026-09-30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := SettlementPeriod(tt.now, myt)
+			if got := start.Format("2006-01-02"); got != tt.wantStart {
+				t.Errorf("start = %s, want %s", got, tt.wantStart)
+			}
+			if !end.Equal(start.AddDate(0, 0, 1)) {
+				t.Errorf("end = %s, want a day after start", end)
+			}
+		})
+	}
+}
+
+func TestNewSettlement(t *testing.T) {
+	provider := uuid.New()
+	other := uuid.New()
+	start := time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC)
+
+	booked := func(providerID uuid.UUID, txType TransactionType, before, after string) *Transaction {
+		b, a := decimal.RequireFromString(before), decimal.RequireFromString(after)
+		tx := NewTransaction(uuid.New(), txType, b.Sub(a).Abs(), b, "session", "", "")
+		tx.SetProvider(providerID)
+		tx.Complete(a)
+		return tx
+	}
+	refunded := booked(provider, TransactionTypePayment, "20", "14")
+	refunded.Status = TransactionStatusRefunded
+	pending := NewTransaction(uuid.New(), TransactionTypePayment, decimal.NewFromInt(9), decimal.NewFromInt(9), "session", "", "")
+	pending.SetProvider(provider)
+
+	transactions := []*Transaction{
+		booked(provider, TransactionTypePayment, "30", "25.50"),
+		refunded,
+		booked(provider, TransactionTypeRefund, "14", "20"),
+		booked(provider, TransactionTypeRoundingAdjustment, "25.50", "25.45"),
+		booked(other, TransactionTypePayment, "10", "5"),
+		booked(provider, TransactionTypeHold, "40", "30"),
+		pending,
+	}
+
+	s, err := NewSettlement(provider, start, start.AddDate(0, 0, 1), transactions)
+	if err != nil {
+		t.Fatalf("NewSettlement() error = %v", err)
+	}
+	if len(s.Lines) != 4 {
+		t.Fatalf("got %d lines, want 4: %+v", len(s.Lines), s.Lines)
+	}
+	if s.PaymentCount != 2 {
+		t.Errorf("PaymentCount = %d, want 2", s.PaymentCount)
+	}
+	for field, tt := range map[string]struct{ got, want string }{
+		"GrossAmount":  {s.GrossAmount.String(), "10.55"},
+		"RefundAmount": {s.RefundAmount.String(), "6"},
+		"NetAmount":    {s.NetAmount.String(), "4.55"},
+	} {
+		if !decimal.RequireFromString(tt.got).Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s = %s, want %s", field, tt.got, tt.want)
+		}
+	}
+	if s.Status != SettlementStatusPending {
+		t.Errorf("Status = %s, want pending", s.Status)
+	}
+
+	if _, err := NewSettlement(uuid.New(), start, start.AddDate(0, 0, 1), transactions); !errors.Is(err, ErrNothingToSettle) {
+		t.Errorf("NewSettlement() for an idle provider error = %v, want ErrNothingToSettle", err)
+	}
+}
+
+func TestSettlement_MarkSettled(t *testing.T) {
+	admin := uuid.New()
+	now := time.Now().UTC()
+	s := &Settlement{Status: SettlementStatusPending}
+
+	if err := s.MarkSettled("  ", admin, now); !errors.Is(err, ErrSettlementReferenceRequired) {
+		t.Fatalf("MarkSettled() without a reference error = %v", err)
+	}
+	if err := s.MarkSettled(" IBG-20260915-001 ", admin, now); err != nil {
+		t.Fatalf("MarkSettled() error = %v", err)
+	}
+	if s.Status != SettlementStatusSettled || s.Reference != "IBG-20260915-001" || *s.SettledBy != admin {
+		t.Errorf("settlement not marked settled: %+v", s)
+	}
+	if err := s.MarkSettled("IBG-20260915-002", admin, now); !errors.Is(err, ErrAlreadySettled) {
+		t.Errorf("second MarkSettled() error = %v, want ErrAlreadySettled", err)
+	}
+}
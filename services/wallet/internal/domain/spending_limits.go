@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrPerTransactionLimitExceeded = errors.New("payment exceeds the per-transaction limit")
+	ErrDailyLimitExceeded          = errors.New("payment exceeds the daily spending limit")
+	ErrMonthlyLimitExceeded        = errors.New("payment exceeds the monthly spending limit")
+	ErrInvalidLimit                = errors.New("spending limits must be positive")
+)
+
+// LimitType names the limit a payment ran into
+type LimitType string
+
+const (
+	LimitPerTransaction LimitType = "per_transaction"
+	LimitDaily          LimitType = "daily"
+	LimitMonthly        LimitType = "monthly"
+)
+
+// spendingLocation is where spending days and months begin; all our wallets
+// are in Malaysia
+var spendingLocation = time.FixedZone("MYT", 8*60*60)
+
+// SpendingLimits caps what a wallet can pay out. A nil limit is not enforced.
+type SpendingLimits struct {
+	WalletID            uuid.UUID        `json:"wallet_id"`
+	PerTransactionLimit *decimal.Decimal `json:"per_transaction_limit"`
+	DailyLimit          *decimal.Decimal `json:"daily_limit"`
+	MonthlyLimit        *decimal.Decimal `json:"monthly_limit"`
+	UpdatedAt           time.Time        `json:"updated_at"`
+}
+
+// NewSpendingLimits validates and returns a wallet's limits
+func NewSpendingLimits(walletID uuid.UUID, perTransaction, daily, monthly *decimal.Decimal) (*SpendingLimits, error) {
+	for _, limit := range []*decimal.Decimal{perTransaction, daily, monthly} {
+		if limit != nil && !limit.IsPositive() {
+			return nil, ErrInvalidLimit
+		}
+	}
+	return &SpendingLimits{
+		WalletID:            walletID,
+		PerTransactionLimit: perTransaction,
+		DailyLimit:          daily,
+		MonthlyLimit:        monthly,
+		UpdatedAt:           time.Now().UTC(),
+	}, nil
+}
+
+// CheckAmount enforces the per-transaction cap, which needs no spending history
+func (l *SpendingLimits) CheckAmount(amount decimal.Decimal) error {
+	if l.PerTransactionLimit != nil && amount.GreaterThan(*l.PerTransactionLimit) {
+		return ErrPerTransactionLimitExceeded
+	}
+	return nil
+}
+
+// Check reports the first limit a payment of amount would break, given what
+// the wallet already spent today and this month
+func (l *SpendingLimits) Check(amount, spentToday, spentThisMonth decimal.Decimal) error {
+	if err := l.CheckAmount(amount); err != nil {
+		return err
+	}
+	if l.DailyLimit != nil && spentToday.Add(amount).GreaterThan(*l.DailyLimit) {
+		return ErrDailyLimitExceeded
+	}
+	if l.MonthlyLimit != nil && spentThisMonth.Add(amount).GreaterThan(*l.MonthlyLimit) {
+		return ErrMonthlyLimitExceeded
+	}
+	return nil
+}
+
+// HasPeriodLimits reports whether checking needs the wallet's spending history
+func (l *SpendingLimits) HasPeriodLimits() bool {
+	return l.DailyLimit != nil || l.MonthlyLimit != nil
+}
+
+// Limit returns the limit behind a limit error
+func (l *SpendingLimits) Limit(err error) (LimitType, decimal.Decimal, bool) {
+	switch {
+	case errors.Is(err, ErrPerTransactionLimitExceeded):
+		return LimitPerTransaction, *l.PerTransactionLimit, true
+	case errors.Is(err, ErrDailyLimitExceeded):
+		return LimitDaily, *l.DailyLimit, true
+	case errors.Is(err, ErrMonthlyLimitExceeded):
+		return LimitMonthly, *l.MonthlyLimit, true
+	default:
+		return "", decimal.Zero, false
+	}
+}
+
+// SpendingPeriods returns when the current spending day and month began
+func SpendingPeriods(now time.Time) (dayStart, monthStart time.Time) {
+	local := now.In(spendingLocation)
+	dayStart = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, spendingLocation)
+	monthStart = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, spendingLocation)
+	return dayStart.UTC(), monthStart.UTC()
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestSpendingLimits_Check(t *testing.T) {
+	limit := func(s string) *decimal.Decimal {
+		d := decimal.RequireFromString(s)
+		return &d
+	}
+	limits, err := NewSpendingLimits(uuid.New(), limit("50"), limit("100"), limit("500"))
+	if err != nil {
+		t.Fatalf("NewSpendingLimits() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		amount    string
+		today     string
+		thisMonth string
+		wantErr   error
+		wantLimit LimitType
+	}{
+		{"within all limits", "20", "30", "200", nil, ""},
+		{"exactly at daily limit", "20", "80", "200", nil, ""},
+		{"over per-transaction cap", "50.01", "0", "0", ErrPerTransactionLimitExceeded, LimitPerTransaction},
+		{"over daily limit", "20", "80.01", "200", ErrDailyLimitExceeded, LimitDaily},
+		{"over monthly limit", "20", "0", "490", ErrMonthlyLimitExceeded, LimitMonthly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limits.Check(
+				decimal.RequireFromString(tt.amount),
+				decimal.RequireFromString(tt.today),
+				decimal.RequireFromString(tt.thisMonth),
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Check() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				return
+			}
+			if limitType, _, ok := limits.Limit(err); !ok || limitType != tt.wantLimit {
+				t.Errorf("Limit() = %s, %v; want %s", limitType, ok, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestSpendingLimits_Unset(t *testing.T) {
+	limits, err := NewSpendingLimits(uuid.New(), nil, nil, nil)
+	if err != nil {
+		t.Fatalf("NewSpendingLimits() error = %v", err)
+	}
+	if limits.HasPeriodLimits() {
+		t.Error("expected no period limits")
+	}
+	if err := limits.Check(decimal.NewFromInt(1000000), decimal.Zero, decimal.Zero); err != nil {
+		t.Errorf("Check() error = %v, want nil", err)
+	}
+}
+
+func TestNewSpendingLimits_Invalid(t *testing.T) {
+	zero := decimal.Zero
+	if _, err := NewSpendingLimits(uuid.New(), nil, &zero, nil); !errors.Is(err, ErrInvalidLimit) {
+		t.Errorf("NewSpendingLimits() error = %v, want %v", err, ErrInvalidLimit)
+	}
+}
+
+func TestSpendingPeriods(t *testing.T) {
+	// 1 March 2024 00:30 in Malaysia is still 29 February in UTC
+	now := time.Date(2024, 2, 29, 16, 30, 0, 0, time.UTC)
+	day, month := SpendingPeriods(now)
+
+	if want := time.Date(2024, 2, 29, 16, 0, 0, 0, time.UTC); !day.Equal(want) {
+		t.Errorf("day start = %s, want %s", day, want)
+	}
+	if want := time.Date(2024, 2, 29, 16, 0, 0, 0, time.UTC); !month.Equal(want) {
+		t.Errorf("month start = %s, want %s", month, want)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrStepUpRequired = errors.New("confirm this transaction with a one-time code to continue")
+	ErrStepUpInvalid  = errors.New("step-up token is invalid, expired or already used")
+	ErrStepUpType     = errors.New("only payments and top-ups can be confirmed with step-up")
+)
+
+// StepUpAction names a transaction type in challenges sent to auth. A
+// token confirms one action for one amount.
+func StepUpAction(txType TransactionType) string {
+	return "wallet." + string(txType)
+}
+
+// StepUpAmount formats an amount the same way for the challenge and the
+// token redeemed against it.
+func StepUpAmount(amount decimal.Decimal) string {
+	return amount.StringFixed(2)
+}
+
+// StepUpPolicy decides which transactions the user must confirm beyond
+// their session. A nil threshold confirms none on amount alone; the risk
+// check can still ask for one.
+type StepUpPolicy struct {
+	PaymentThreshold *decimal.Decimal
+}
+
+// Requires reports whether a transaction of this type and amount needs
+// step-up
+func (p StepUpPolicy) Requires(txType TransactionType, amount decimal.Decimal) bool {
+	switch txType {
+	case TransactionTypePayment, TransactionTypeTransfer:
+		return p.PaymentThreshold != nil && amount.GreaterThanOrEqual(*p.PaymentThreshold)
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStepUpPolicy_Requires(t *testing.T) {
+	threshold := decimal.NewFromInt(500)
+	policy := StepUpPolicy{PaymentThreshold: &threshold}
+
+	tests := []struct {
+		name   string
+		policy StepUpPolicy
+		txType TransactionType
+		amount string
+		want   bool
+	}{
+		{"payment under threshold", policy, TransactionTypePayment, "499.99", false},
+		{"payment at threshold", policy, TransactionTypePayment, "500.00", true},
+		{"payment over threshold", policy, TransactionTypePayment, "750", true},
+		{"transfer over threshold", policy, TransactionTypeTransfer, "750", true},
+		{"top-up over threshold", policy, TransactionTypeTopUp, "750", false},
+		{"no threshold", StepUpPolicy{}, TransactionTypePayment, "10000", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Requires(tt.txType, decimal.RequireFromString(tt.amount)); got != tt.want {
+				t.Errorf("Requires() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStepUpAmount(t *testing.T) {
+	for in, want := range map[string]string{"500": "500.00", "12.5": "12.50", "0.05": "0.05"} {
+		if got := StepUpAmount(decimal.RequireFromString(in)); got != want {
+			t.Errorf("StepUpAmount(%s) = %q, want %q", in, got, want)
+		}
+	}
+}
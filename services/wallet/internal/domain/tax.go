@@ -0,0 +1,24 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// ApplyTaxes records the taxes included in a payment's amount
+func (t *Transaction) ApplyTaxes(taxes []TaxLine) {
+	if len(taxes) == 0 {
+		return
+	}
+	total := decimal.Zero
+	for _, tax := range taxes {
+		total = total.Add(tax.Amount)
+	}
+	t.Taxes = taxes
+	t.TaxAmount = &total
+}
+
+// copyTaxes carries a payment's taxes onto the transaction undoing it in
+// full, so the tax charged is given back with it
+func (t *Transaction) copyTaxes(original *Transaction) {
+	if t.Amount.Equal(original.Amount) {
+		t.ApplyTaxes(original.Taxes)
+	}
+}
@@ -14,6 +14,21 @@ const (
 	TransactionTypePayment  TransactionType = "payment"
 	TransactionTypeRefund   TransactionType = "refund"
 	TransactionTypeTransfer TransactionType = "transfer"
+	// TransactionTypeRoundingAdjustment records the difference between the calculated
+	// amount of a parent transaction and the amount actually charged after rounding
+	TransactionTypeRoundingAdjustment TransactionType = "rounding_adjustment"
+	// TransactionTypePromoCredit pays a promotion bonus into the wallet
+	TransactionTypePromoCredit TransactionType = "promo_credit"
+	// TransactionTypeLoyaltyCredit pays redeemed loyalty points into the wallet
+	TransactionTypeLoyaltyCredit TransactionType = "loyalty_credit"
+	// TransactionTypeReferralCredit pays a referral reward into the wallet
+	TransactionTypeReferralCredit TransactionType = "referral_credit"
+	// TransactionTypeHold sets money aside for a later capture, and
+	// TransactionTypeHoldRelease gives it back
+	TransactionTypeHold        TransactionType = "hold"
+	TransactionTypeHoldRelease TransactionType = "hold_release"
+	// TransactionTypeReversal undoes an earlier transaction at an admin's request
+	TransactionTypeReversal TransactionType = "reversal"
 )
 
 type TransactionStatus string
@@ -26,20 +41,31 @@ const (
 )
 
 type Transaction struct {
-	ID             uuid.UUID         `json:"id"`
-	WalletID       uuid.UUID         `json:"wallet_id"`
-	Type           TransactionType   `json:"type"`
-	Amount         decimal.Decimal   `json:"amount"`
-	BalanceBefore  decimal.Decimal   `json:"balance_before"`
-	BalanceAfter   decimal.Decimal   `json:"balance_after"`
-	ReferenceID    string            `json:"reference_id"`
-	ProviderID     *uuid.UUID        `json:"provider_id,omitempty"`
-	Status         TransactionStatus `json:"status"`
-	Description    string            `json:"description"`
-	IdempotencyKey string            `json:"idempotency_key"`
-	Metadata       map[string]string `json:"metadata,omitempty"`
-	CreatedAt      time.Time         `json:"created_at"`
-	UpdatedAt      time.Time         `json:"updated_at"`
+	ID                  uuid.UUID           `json:"id"`
+	WalletID            uuid.UUID           `json:"wallet_id"`
+	Type                TransactionType     `json:"type"`
+	Amount              decimal.Decimal     `json:"amount"`
+	BalanceBefore       decimal.Decimal     `json:"balance_before"`
+	BalanceAfter        decimal.Decimal     `json:"balance_after"`
+	ReferenceID         string              `json:"reference_id"`
+	ProviderID          *uuid.UUID          `json:"provider_id,omitempty"`
+	ParentTransactionID *uuid.UUID          `json:"parent_transaction_id,omitempty"`
+	Status              TransactionStatus   `json:"status"`
+	Description         string              `json:"description"`
+	IdempotencyKey      string              `json:"idempotency_key"`
+	Metadata            map[string]string   `json:"metadata,omitempty"`
+	DeviceID            string              `json:"device_id,omitempty"`
+	RiskScore           *int                `json:"risk_score,omitempty"`
+	MemberID            *uuid.UUID          `json:"member_id,omitempty"`  // Organization member who paid from an org wallet
+	AdminID             *uuid.UUID          `json:"admin_id,omitempty"`   // Admin who made a reversal
+	FeeAmount           *decimal.Decimal    `json:"fee_amount,omitempty"` // Platform fee on a provider payment
+	NetAmount           *decimal.Decimal    `json:"net_amount,omitempty"` // Amount less the fee, owed to the provider
+	TaxAmount           *decimal.Decimal    `json:"tax_amount,omitempty"` // Tax included in Amount
+	Taxes               []TaxLine           `json:"taxes,omitempty"`
+	Category            TransactionCategory `json:"category,omitempty"`
+	Tags                []string            `json:"tags,omitempty"` // Set by the user to organise their history
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
 }
 
 func NewTransaction(
@@ -64,22 +90,99 @@ func NewTransaction(
 		Description:    description,
 		IdempotencyKey: idempotencyKey,
 		Metadata:       make(map[string]string),
+		Category:       CategoryFor(txType, idempotencyKey),
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 }
 
+// NewRoundingAdjustment records a rounding difference against a completed parent
+// transaction. The adjustment is charged - calculated: positive credits the wallet,
+// negative debits it. Amount is always stored positive; the direction is carried
+// by the balances.
+func NewRoundingAdjustment(parent *Transaction, adjustment decimal.Decimal) *Transaction {
+	idempotencyKey := ""
+	if parent.IdempotencyKey != "" {
+		idempotencyKey = parent.IdempotencyKey + ":rounding"
+	}
+
+	tx := NewTransaction(
+		parent.WalletID,
+		TransactionTypeRoundingAdjustment,
+		adjustment.Abs(),
+		parent.BalanceAfter,
+		parent.ReferenceID,
+		idempotencyKey,
+		"Rounding adjustment",
+	)
+	tx.ParentTransactionID = &parent.ID
+	tx.ProviderID = parent.ProviderID
+	tx.Category = parent.Category
+	tx.Complete(parent.BalanceAfter.Add(adjustment))
+	return tx
+}
+
+// NewRefund creates a pending refund that returns a payment's amount to its wallet
+func NewRefund(payment *Transaction, idempotencyKey, reason string) *Transaction {
+	if reason == "" {
+		reason = "Refund"
+	}
+	tx := NewTransaction(
+		payment.WalletID,
+		TransactionTypeRefund,
+		payment.Amount,
+		payment.BalanceAfter,
+		payment.ReferenceID,
+		idempotencyKey,
+		reason,
+	)
+	tx.ParentTransactionID = &payment.ID
+	tx.ProviderID = payment.ProviderID
+	tx.Category = payment.Category
+	tx.copyFee(payment)
+	tx.copyTaxes(payment)
+	return tx
+}
+
+// SignedAmount is the transaction's effect on the wallet balance
+func (t *Transaction) SignedAmount() decimal.Decimal {
+	return t.BalanceAfter.Sub(t.BalanceBefore)
+}
+
 func (t *Transaction) Complete(balanceAfter decimal.Decimal) {
 	t.Status = TransactionStatusCompleted
 	t.BalanceAfter = balanceAfter
 	t.UpdatedAt = time.Now().UTC()
 }
 
+// AwaitGateway records the gateway's reference for a top-up that completes
+// asynchronously
+func (t *Transaction) AwaitGateway(gatewayReference string) {
+	t.ReferenceID = gatewayReference
+	t.UpdatedAt = time.Now().UTC()
+}
+
 func (t *Transaction) Fail() {
 	t.Status = TransactionStatusFailed
 	t.UpdatedAt = time.Now().UTC()
 }
 
+// CanRefund reports whether the transaction is a completed payment
+func (t *Transaction) CanRefund() bool {
+	return t.Type == TransactionTypePayment && t.Status == TransactionStatusCompleted
+}
+
+// MarkRefunded flags a payment whose amount has been returned. Its balances
+// are left as they were: the refund is a separate ledger entry.
+func (t *Transaction) MarkRefunded() error {
+	if !t.CanRefund() {
+		return ErrNotRefundable
+	}
+	t.Status = TransactionStatusRefunded
+	t.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
 func (t *Transaction) SetProvider(providerID uuid.UUID) {
 	t.ProviderID = &providerID
 }
@@ -165,3 +165,60 @@ func TestNewPaymentMethod(t *testing.T) {
 		t.Error("expected IsDefault to be false initially")
 	}
 }
+
+func TestNewRefund(t *testing.T) {
+	payment := NewTransaction(uuid.New(), TransactionTypePayment, decimal.NewFromInt(12), decimal.NewFromInt(50), "session-1", "pay-1", "Parking")
+	payment.SetProvider(uuid.New())
+	payment.Complete(decimal.NewFromInt(38))
+
+	refund := NewRefund(payment, "refund-1", "")
+
+	if refund.Type != TransactionTypeRefund || refund.Status != TransactionStatusPending {
+		t.Errorf("expected pending refund, got %s/%s", refund.Type, refund.Status)
+	}
+	if !refund.Amount.Equal(payment.Amount) {
+		t.Errorf("expected amount %s, got %s", payment.Amount, refund.Amount)
+	}
+	if refund.ParentTransactionID == nil || *refund.ParentTransactionID != payment.ID {
+		t.Error("expected refund to reference the payment")
+	}
+	if refund.ProviderID == nil || *refund.ProviderID != *payment.ProviderID {
+		t.Error("expected refund to carry the payment's provider")
+	}
+	if refund.Description != "Refund" {
+		t.Errorf("expected default description, got %q", refund.Description)
+	}
+}
+
+func TestTransaction_MarkRefunded(t *testing.T) {
+	tests := []struct {
+		name    string
+		txType  TransactionType
+		status  TransactionStatus
+		wantErr error
+	}{
+		{"completed payment", TransactionTypePayment, TransactionStatusCompleted, nil},
+		{"pending payment", TransactionTypePayment, TransactionStatusPending, ErrNotRefundable},
+		{"already refunded", TransactionTypePayment, TransactionStatusRefunded, ErrNotRefundable},
+		{"top-up", TransactionTypeTopUp, TransactionStatusCompleted, ErrNotRefundable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := NewTransaction(uuid.New(), tt.txType, decimal.NewFromInt(10), decimal.NewFromInt(10), "", "", "")
+			tx.Status = tt.status
+			before := tx.BalanceAfter
+
+			err := tx.MarkRefunded()
+			if err != tt.wantErr {
+				t.Fatalf("MarkRefunded() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && tx.Status != TransactionStatusRefunded {
+				t.Errorf("expected status refunded, got %s", tx.Status)
+			}
+			if !tx.BalanceAfter.Equal(before) {
+				t.Error("MarkRefunded must not change balances")
+			}
+		})
+	}
+}
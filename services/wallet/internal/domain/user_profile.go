@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUserProfileNotFound = errors.New("user profile not found")
+
+// UserProfile is the wallet service's copy of a user's contact details and
+// KYC level, kept in step with the auth service from its user events
+type UserProfile struct {
+	UserID    uuid.UUID `json:"user_id"`
+	Phone     string    `json:"phone"`
+	Email     string    `json:"email"`
+	FullName  string    `json:"full_name"`
+	KYCLevel  KYCLevel  `json:"kyc_level"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
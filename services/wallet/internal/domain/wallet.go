@@ -2,7 +2,9 @@ package domain
 
 import (
 	"errors"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
@@ -16,8 +18,31 @@ var (
 	ErrWalletInactive       = errors.New("wallet is inactive")
 	ErrTransactionNotFound  = errors.New("transaction not found")
 	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrTopUpDeclined        = errors.New("top-up was declined by the payment gateway")
+	ErrInvalidTimeRange     = errors.New("from must be before to")
+	ErrNotRefundable        = errors.New("only completed payments can be refunded")
+	ErrInvalidWebhook       = errors.New("invalid payment gateway webhook")
+	ErrWalletFrozen         = errors.New("wallet is already frozen")
+	ErrWalletNotFrozen      = errors.New("wallet is not frozen")
+	ErrReasonRequired       = errors.New("reason is required")
+	ErrInvalidWalletLabel   = errors.New("wallet label must be 1 to 50 characters")
+	ErrWalletLabelTaken     = errors.New("user already has a wallet with this label")
+	ErrWalletLimitReached   = errors.New("user has the most wallets allowed")
 )
 
+const (
+	// DefaultWalletLabel names the wallet every user starts with
+	DefaultWalletLabel = "Personal"
+	// MaxWalletsPerUser caps how many wallets one user may hold
+	MaxWalletsPerUser = 5
+
+	maxWalletLabelLength = 50
+)
+
+// ErrConcurrentModification is returned when a wallet changed between being
+// read and written back; the caller should reload it and try again.
+var ErrConcurrentModification = errors.New("wallet was modified concurrently")
+
 type WalletStatus string
 
 const (
@@ -26,21 +51,30 @@ const (
 	WalletStatusFrozen   WalletStatus = "frozen"
 )
 
+// Wallet holds a user's money. A user may have several labeled wallets,
+// e.g. personal and business; exactly one is their default, which is used
+// whenever a wallet isn't named.
 type Wallet struct {
 	ID        uuid.UUID       `json:"id"`
 	UserID    uuid.UUID       `json:"user_id"`
+	Label     string          `json:"label"`
+	IsDefault bool            `json:"is_default"`
 	Balance   decimal.Decimal `json:"balance"`
 	Currency  string          `json:"currency"`
 	Status    WalletStatus    `json:"status"`
+	Version   int64           `json:"version"`
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
+// NewWallet creates a user's first wallet, which is their default
 func NewWallet(userID uuid.UUID, currency string) *Wallet {
 	now := time.Now().UTC()
 	return &Wallet{
 		ID:        uuid.New(),
 		UserID:    userID,
+		Label:     DefaultWalletLabel,
+		IsDefault: true,
 		Balance:   decimal.Zero,
 		Currency:  currency,
 		Status:    WalletStatusActive,
@@ -49,6 +83,27 @@ func NewWallet(userID uuid.UUID, currency string) *Wallet {
 	}
 }
 
+// NewLabeledWallet creates another wallet for a user who holds existing.
+// It becomes the default only if the user has no wallet yet.
+func NewLabeledWallet(userID uuid.UUID, currency, label string, existing []*Wallet) (*Wallet, error) {
+	label = strings.TrimSpace(label)
+	if label == "" || utf8.RuneCountInString(label) > maxWalletLabelLength {
+		return nil, ErrInvalidWalletLabel
+	}
+	if len(existing) >= MaxWalletsPerUser {
+		return nil, ErrWalletLimitReached
+	}
+	for _, w := range existing {
+		if strings.EqualFold(w.Label, label) {
+			return nil, ErrWalletLabelTaken
+		}
+	}
+	wallet := NewWallet(userID, currency)
+	wallet.Label = label
+	wallet.IsDefault = len(existing) == 0
+	return wallet, nil
+}
+
 func (w *Wallet) IsActive() bool {
 	return w.Status == WalletStatusActive
 }
@@ -97,3 +152,12 @@ func (w *Wallet) Activate() {
 	w.Status = WalletStatusActive
 	w.UpdatedAt = time.Now().UTC()
 }
+
+// Unfreeze reactivates a frozen wallet. Inactive wallets stay inactive.
+func (w *Wallet) Unfreeze() error {
+	if w.Status != WalletStatusFrozen {
+		return ErrWalletNotFrozen
+	}
+	w.Activate()
+	return nil
+}
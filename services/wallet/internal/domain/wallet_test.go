@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -28,6 +30,48 @@ func TestNewWallet(t *testing.T) {
 	if wallet.Status != WalletStatusActive {
 		t.Errorf("expected status active, got %s", wallet.Status)
 	}
+	if !wallet.IsDefault || wallet.Label != DefaultWalletLabel {
+		t.Errorf("expected the default %q wallet, got %q default %v", DefaultWalletLabel, wallet.Label, wallet.IsDefault)
+	}
+}
+
+func TestNewLabeledWallet(t *testing.T) {
+	userID := uuid.New()
+	personal := NewWallet(userID, "MYR")
+	full := []*Wallet{personal}
+	for len(full) < MaxWalletsPerUser {
+		full = append(full, NewWallet(userID, "MYR"))
+	}
+
+	tests := []struct {
+		name        string
+		label       string
+		existing    []*Wallet
+		wantErr     error
+		wantDefault bool
+	}{
+		{"second wallet", " Business ", []*Wallet{personal}, nil, false},
+		{"first wallet is the default", "Business", nil, nil, true},
+		{"blank label", "  ", []*Wallet{personal}, ErrInvalidWalletLabel, false},
+		{"label too long", strings.Repeat("a", 51), []*Wallet{personal}, ErrInvalidWalletLabel, false},
+		{"label taken ignoring case", "personal", []*Wallet{personal}, ErrWalletLabelTaken, false},
+		{"too many wallets", "Business", full, ErrWalletLimitReached, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet, err := NewLabeledWallet(userID, "MYR", tt.label, tt.existing)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewLabeledWallet() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if wallet.Label != "Business" || wallet.IsDefault != tt.wantDefault {
+				t.Errorf("wallet = %q default %v, want %q default %v", wallet.Label, wallet.IsDefault, "Business", tt.wantDefault)
+			}
+		})
+	}
 }
 
 func TestWallet_Credit(t *testing.T) {
@@ -220,3 +264,32 @@ func TestWallet_Activate(t *testing.T) {
 		t.Error("active wallet should be able to transact")
 	}
 }
+
+func TestWallet_Unfreeze(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  WalletStatus
+		wantErr error
+	}{
+		{"frozen wallet", WalletStatusFrozen, nil},
+		{"active wallet", WalletStatusActive, ErrWalletNotFrozen},
+		{"inactive wallet", WalletStatusInactive, ErrWalletNotFrozen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wallet := &Wallet{ID: uuid.New(), Status: tt.status}
+
+			err := wallet.Unfreeze()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Unfreeze() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && wallet.Status != WalletStatusActive {
+				t.Errorf("status = %s, want active", wallet.Status)
+			}
+			if err != nil && wallet.Status != tt.status {
+				t.Errorf("status changed to %s on error", wallet.Status)
+			}
+		})
+	}
+}
@@ -2,26 +2,73 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/parking-super-app/services/wallet/internal/domain"
+	"github.com/shopspring/decimal"
 )
 
 type WalletRepository interface {
 	Create(ctx context.Context, wallet *domain.Wallet) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Wallet, error)
+	// GetByIDForUpdate locks the wallet until the unit of work ends. Outside
+	// a unit of work it is a plain read.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Wallet, error)
+	// GetByUserID returns the user's default wallet
 	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.Wallet, error)
+	// ListByUserID returns all the user's wallets, default first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error)
+	// SetDefault makes one of the user's wallets their default; run it in
+	// a unit of work. Returns domain.ErrWalletNotFound if the wallet isn't
+	// the user's.
+	SetDefault(ctx context.Context, userID, walletID uuid.UUID) error
 	Update(ctx context.Context, wallet *domain.Wallet) error
 	ExistsByUserID(ctx context.Context, userID uuid.UUID) (bool, error)
+	// ListIDs pages through every wallet ID in order, starting after after
+	ListIDs(ctx context.Context, after uuid.UUID, limit int) ([]uuid.UUID, error)
 }
 
 type TransactionRepository interface {
 	Create(ctx context.Context, tx *domain.Transaction) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Transaction, error)
 	GetByIdempotencyKey(ctx context.Context, key string) (*domain.Transaction, error)
-	GetByWalletID(ctx context.Context, walletID uuid.UUID, limit, offset int) ([]*domain.Transaction, error)
+	GetByWalletID(ctx context.Context, walletID uuid.UUID, filter domain.TransactionFilter, limit, offset int) ([]*domain.Transaction, error)
 	Update(ctx context.Context, tx *domain.Transaction) error
-	CountByWalletID(ctx context.Context, walletID uuid.UUID) (int, error)
+	// UpdateTags saves the tags the user set on a transaction
+	UpdateTags(ctx context.Context, tx *domain.Transaction) error
+	CountByWalletID(ctx context.Context, walletID uuid.UUID, filter domain.TransactionFilter) (int, error)
+	// SpendingByCategory totals a wallet's money in and out by category in [from, to)
+	SpendingByCategory(ctx context.Context, walletID uuid.UUID, from, to time.Time) ([]domain.CategorySpending, error)
+	GetByTypeBetween(ctx context.Context, txType domain.TransactionType, from, to time.Time) ([]*domain.Transaction, error)
+	SumCompletedSince(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, since time.Time) (decimal.Decimal, error)
+	// GetRiskHistory counts top-ups and payments since the start of the
+	// velocity window and summarises completed ones of txType
+	GetRiskHistory(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, deviceID string, since time.Time) (*domain.RiskHistory, error)
+	// GetByWalletTypeBetween returns a wallet's transactions of txType
+	// created in [from, to), oldest first
+	GetByWalletTypeBetween(ctx context.Context, walletID uuid.UUID, txType domain.TransactionType, from, to time.Time) ([]*domain.Transaction, error)
+	// SumMemberCompletedSince adds up the completed payments one member
+	// charged to an organization wallet. It reads the primary.
+	SumMemberCompletedSince(ctx context.Context, walletID, memberID uuid.UUID, since time.Time) (decimal.Decimal, error)
+	// GetProviderTransactionsBetween returns the payments, refunds, reversals
+	// and rounding adjustments booked against any provider in [from, to),
+	// oldest first
+	GetProviderTransactionsBetween(ctx context.Context, from, to time.Time) ([]*domain.Transaction, error)
+}
+
+// SpendingLimitRepository stores per-wallet spending limits. A wallet with no
+// stored limits gets an empty SpendingLimits.
+type SpendingLimitRepository interface {
+	GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.SpendingLimits, error)
+	Save(ctx context.Context, limits *domain.SpendingLimits) error
+}
+
+// BalanceAlertRepository stores per-wallet low-balance alerts. A wallet with
+// no stored alert gets one without a threshold.
+type BalanceAlertRepository interface {
+	GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.BalanceAlert, error)
+	Save(ctx context.Context, alert *domain.BalanceAlert) error
 }
 
 type PaymentMethodRepository interface {
@@ -33,6 +80,84 @@ type PaymentMethodRepository interface {
 	SetDefault(ctx context.Context, userID, methodID uuid.UUID) error
 }
 
+// LedgerRepository stores the double-entry ledger. Append and Snapshot must
+// run in the unit of work holding the wallet's lock.
+type LedgerRepository interface {
+	Append(ctx context.Context, entries []domain.LedgerEntry) error
+	// Balance derives a wallet's balance from its latest snapshot plus the
+	// entries posted since
+	Balance(ctx context.Context, walletID uuid.UUID) (decimal.Decimal, error)
+	// Snapshot folds entries posted since the last snapshot into a new one.
+	// It returns nil when there was nothing new to fold.
+	Snapshot(ctx context.Context, walletID uuid.UUID) (*domain.BalanceSnapshot, error)
+	GetEntries(ctx context.Context, walletID uuid.UUID, limit, offset int) ([]domain.LedgerEntry, error)
+}
+
+type DiscrepancyRepository interface {
+	// Record stores a discrepancy, replacing the wallet's open one if any
+	Record(ctx context.Context, d *domain.LedgerDiscrepancy) error
+	GetOpenByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.LedgerDiscrepancy, error)
+	Resolve(ctx context.Context, d *domain.LedgerDiscrepancy) error
+	ListOpen(ctx context.Context, limit, offset int) ([]*domain.LedgerDiscrepancy, error)
+}
+
+// PromotionRepository stores promo codes and their redemptions.
+// GetByCodeForUpdate locks the promotion until the unit of work ends, so its
+// usage cap is checked and counted by one redemption at a time.
+type PromotionRepository interface {
+	Create(ctx context.Context, promotion *domain.Promotion) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Promotion, error)
+	GetByCodeForUpdate(ctx context.Context, code string) (*domain.Promotion, error)
+	List(ctx context.Context, activeOnly bool, limit, offset int) ([]*domain.Promotion, error)
+	Update(ctx context.Context, promotion *domain.Promotion) error
+	CountRedemptions(ctx context.Context, promotionID, walletID uuid.UUID) (int, error)
+	// TopUpRedeemed reports whether a top-up has already earned a bonus
+	TopUpRedeemed(ctx context.Context, topUpTransactionID uuid.UUID) (bool, error)
+	RecordRedemption(ctx context.Context, redemption *domain.PromotionRedemption) error
+}
+
+// LoyaltyRepository stores users' loyalty points ledgers. Create returns
+// domain.ErrLoyaltyEntryExists when the transaction already has an entry of
+// that type. The ForUpdate reads lock lots until the unit of work ends.
+type LoyaltyRepository interface {
+	Create(ctx context.Context, entry *domain.LoyaltyEntry) error
+	// OpenLotsForUpdate returns the user's unexpired lots with points left,
+	// soonest to expire first
+	OpenLotsForUpdate(ctx context.Context, userID uuid.UUID, now time.Time) ([]*domain.LoyaltyEntry, error)
+	// GetLotForUpdate returns the lot earned on a payment, or
+	// domain.ErrLoyaltyLotNotFound if it earned none
+	GetLotForUpdate(ctx context.Context, paymentID uuid.UUID) (*domain.LoyaltyEntry, error)
+	UpdateRemaining(ctx context.Context, lot *domain.LoyaltyEntry) error
+	// Balance sums the points left in the user's unexpired lots
+	Balance(ctx context.Context, userID uuid.UUID, now time.Time) (int64, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.LoyaltyEntry, error)
+	// ListExpired returns lots that have expired with points left, oldest first
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]*domain.LoyaltyEntry, error)
+}
+
+// ReferralRepository stores referrals. Create ignores a referral already
+// stored, so redelivered events are harmless.
+type ReferralRepository interface {
+	Create(ctx context.Context, referral *domain.Referral) error
+	// GetPendingByRefereeID returns domain.ErrReferralNotFound unless the
+	// user has a referral waiting for their first payment
+	GetPendingByRefereeID(ctx context.Context, refereeID uuid.UUID) (*domain.Referral, error)
+	// GetForUpdate locks the referral until the unit of work ends
+	GetForUpdate(ctx context.Context, id uuid.UUID) (*domain.Referral, error)
+	Update(ctx context.Context, referral *domain.Referral) error
+	ListByReferrerID(ctx context.Context, referrerID uuid.UUID, limit, offset int) ([]*domain.Referral, error)
+	Summary(ctx context.Context, referrerID uuid.UUID) (*domain.ReferralSummary, error)
+}
+
+// HoldRepository stores wallet holds. A hold changes only in the unit of work
+// holding its wallet's lock.
+type HoldRepository interface {
+	Create(ctx context.Context, hold *domain.Hold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Hold, error)
+	GetByIdempotencyKey(ctx context.Context, key string) (*domain.Hold, error)
+	Update(ctx context.Context, hold *domain.Hold) error
+}
+
 type UnitOfWork interface {
 	Execute(ctx context.Context, fn func(tx Transaction) error) error
 }
@@ -40,4 +165,85 @@ type UnitOfWork interface {
 type Transaction interface {
 	Wallets() WalletRepository
 	Transactions() TransactionRepository
+	Ledger() LedgerRepository
+	Promotions() PromotionRepository
+	Holds() HoldRepository
+	Loyalty() LoyaltyRepository
+	Referrals() ReferralRepository
+}
+
+// UserProfileRepository stores the local copy of user contact details
+type UserProfileRepository interface {
+	// Upsert stores the profile unless the stored copy is as new or newer,
+	// and reports whether it was stored
+	Upsert(ctx context.Context, profile *domain.UserProfile) (bool, error)
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.UserProfile, error)
+}
+
+// BusinessAccountRepository stores the users billed by monthly invoice
+type BusinessAccountRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.BusinessAccount, error)
+	Save(ctx context.Context, account *domain.BusinessAccount) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// List pages through every business account in user ID order, starting
+	// after after
+	List(ctx context.Context, after uuid.UUID, limit int) ([]*domain.BusinessAccount, error)
+}
+
+// InvoiceRepository stores issued invoices. Create returns
+// domain.ErrInvoiceAlreadyIssued when the account already has one for the period.
+type InvoiceRepository interface {
+	Create(ctx context.Context, invoice *domain.Invoice) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Invoice, error)
+	ExistsForPeriod(ctx context.Context, userID uuid.UUID, periodStart time.Time) (bool, error)
+	// ListByUserID returns a user's invoices newest first, without their documents
+	ListByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*domain.Invoice, error)
+}
+
+// CardPaymentRepository stores payments charged straight to cards. Create
+// returns domain.ErrCardPaymentExists when the idempotency key is taken.
+type CardPaymentRepository interface {
+	Create(ctx context.Context, payment *domain.CardPayment) error
+	// GetByIdempotencyKey returns domain.ErrCardPaymentNotFound if there is none
+	GetByIdempotencyKey(ctx context.Context, key string) (*domain.CardPayment, error)
+	Update(ctx context.Context, payment *domain.CardPayment) error
+}
+
+// FeeScheduleRepository stores providers' fee schedules. Create returns
+// domain.ErrFeeScheduleExists when a change already takes effect at that time.
+type FeeScheduleRepository interface {
+	Create(ctx context.Context, schedule *domain.FeeSchedule) error
+	// GetEffective returns the schedule in force for the provider at at, or
+	// domain.ErrFeeScheduleNotFound if none has taken effect
+	GetEffective(ctx context.Context, providerID uuid.UUID, at time.Time) (*domain.FeeSchedule, error)
+	// ListByProviderID returns a provider's schedules, latest first
+	ListByProviderID(ctx context.Context, providerID uuid.UUID) ([]*domain.FeeSchedule, error)
+}
+
+// SettlementRepository stores provider settlements. Create returns
+// domain.ErrSettlementExists when the provider already has one for the day.
+type SettlementRepository interface {
+	Create(ctx context.Context, settlement *domain.Settlement) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Settlement, error)
+	// List returns settlements newest first without their lines, optionally
+	// filtered by provider and status
+	List(ctx context.Context, providerID *uuid.UUID, status *domain.SettlementStatus, limit, offset int) ([]*domain.Settlement, error)
+	Update(ctx context.Context, settlement *domain.Settlement) error
+}
+
+// OrganizationRepository stores the local copy of organizations and their
+// members. Get lookups return domain.ErrOrganizationNotFound and
+// domain.ErrNotOrgMember.
+type OrganizationRepository interface {
+	// Create stores an organization; creating one that exists does nothing
+	Create(ctx context.Context, org *domain.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	GetByWalletID(ctx context.Context, walletID uuid.UUID) (*domain.Organization, error)
+	ListByOwnerID(ctx context.Context, ownerID uuid.UUID) ([]*domain.Organization, error)
+	// SaveMember adds a member or updates their role, keeping their limit
+	SaveMember(ctx context.Context, member *domain.OrgMember) error
+	GetMember(ctx context.Context, orgID, userID uuid.UUID) (*domain.OrgMember, error)
+	UpdateMemberLimit(ctx context.Context, member *domain.OrgMember) error
+	DeleteMember(ctx context.Context, orgID, userID uuid.UUID) error
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]*domain.OrgMember, error)
 }
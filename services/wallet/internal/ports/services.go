@@ -2,7 +2,13 @@ package ports
 
 import (
 	"context"
+	"net/http"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/parking-super-app/pkg/audit"
+	"github.com/parking-super-app/pkg/logging"
+	"github.com/parking-super-app/services/wallet/internal/domain"
 	"github.com/shopspring/decimal"
 )
 
@@ -16,15 +22,54 @@ type TopUpRequest struct {
 	Amount        decimal.Decimal
 	Currency      string
 	PaymentMethod string
+	// Token is the saved card for card top-ups and the buyer's bank for FPX
 	Token         string
 	UserID        string
 	IdempotencyKey string
+	// Reference is our transaction ID; gateways echo it back in webhooks
+	Reference string
 }
 
+// Gateway statuses. A pending top-up waits for the user to finish a bank
+// redirect or 3-D Secure challenge and is confirmed later by webhook.
+const (
+	GatewayStatusSuccess = "success"
+	GatewayStatusPending = "pending"
+	GatewayStatusFailed  = "failed"
+)
+
 type TopUpResponse struct {
 	TransactionID string
 	Status        string
 	Message       string
+	// CapturedAmount is what the gateway actually captured; zero means the requested amount
+	CapturedAmount decimal.Decimal
+	// Redirect is where the user completes a pending top-up
+	Redirect *Redirect
+}
+
+// Redirect sends the user to the bank or card issuer. FPX expects a form
+// POST of Fields; 3-D Secure is a plain GET of URL.
+type Redirect struct {
+	URL    string
+	Method string
+	Fields map[string]string
+}
+
+// GatewayEvent is a verified asynchronous payment notification
+type GatewayEvent struct {
+	Gateway              string
+	Reference            string
+	GatewayTransactionID string
+	Status               string
+	Amount               decimal.Decimal
+	Message              string
+}
+
+// WebhookVerifier authenticates and decodes gateway notifications. It
+// returns a nil event for notifications that need no action.
+type WebhookVerifier interface {
+	VerifyWebhook(gateway string, payload []byte, header http.Header) (*GatewayEvent, error)
 }
 
 type PaymentRequest struct {
@@ -33,6 +78,8 @@ type PaymentRequest struct {
 	Description    string
 	ReferenceID    string
 	IdempotencyKey string
+	// Token is the card to charge, for payments made without a wallet
+	Token string
 }
 
 type PaymentResponse struct {
@@ -63,25 +110,118 @@ type Event struct {
 }
 
 const (
-	EventWalletCreated    = "wallet.created"
-	EventTopUpCompleted   = "wallet.topup.completed"
-	EventPaymentCompleted = "wallet.payment.completed"
-	EventRefundCompleted  = "wallet.refund.completed"
+	EventWalletCreated             = "wallet.created"
+	EventTopUpCompleted            = "wallet.topup.completed"
+	EventPaymentCompleted          = "wallet.payment.completed"
+	EventRefundCompleted           = "wallet.refund.completed"
+	EventRoundingAdjusted          = "wallet.rounding.adjusted"
+	EventLedgerDiscrepancyDetected = "wallet.ledger.discrepancy_detected"
+	EventLedgerRepaired            = "wallet.ledger.repaired"
+	EventSpendingLimitReached      = "wallet.spending_limit.reached"
+	EventPromotionCreated          = "wallet.promotion.created"
+	EventPromotionRedeemed         = "wallet.promotion.redeemed"
+	EventPromotionDeactivated      = "wallet.promotion.deactivated"
+	EventHoldPlaced                = "wallet.hold.placed"
+	EventHoldCaptured              = "wallet.hold.captured"
+	EventHoldReleased              = "wallet.hold.released"
+	EventWalletFrozen              = "wallet.frozen"
+	EventWalletUnfrozen            = "wallet.unfrozen"
+	EventRiskFlagged               = "wallet.risk.flagged"
+	EventBalanceLow                = "wallet.balance.low"
+	EventInvoiceIssued             = "wallet.invoice.issued"
+	EventTransactionReversed       = "wallet.transaction.reversed"
+	EventSettlementCreated         = "wallet.settlement.created"
+	EventSettlementSettled         = "wallet.settlement.settled"
+	EventLoyaltyEarned             = "wallet.loyalty.earned"
+	EventLoyaltyRedeemed           = "wallet.loyalty.redeemed"
+	EventLoyaltyExpired            = "wallet.loyalty.expired"
+	EventReferralRewarded          = "wallet.referral.rewarded"
+)
+
+// AuditLog records sensitive operations in the service's audit trail
+type AuditLog interface {
+	Record(ctx context.Context, event AuditEvent) error
+}
+
+type AuditEvent = audit.Event
+
+// Audit actions
+const (
+	AuditWalletFrozen        = "wallet.frozen"
+	AuditWalletUnfrozen      = "wallet.unfrozen"
+	AuditTransactionReversed = "transaction.reversed"
+	AuditSettlementSettled   = "settlement.settled"
+	AuditFeeScheduled        = "fee.scheduled"
 )
 
-type Logger interface {
-	Debug(msg string, fields ...Field)
-	Info(msg string, fields ...Field)
-	Warn(msg string, fields ...Field)
-	Error(msg string, fields ...Field)
-	WithFields(fields ...Field) Logger
+// RiskChecker scores a top-up or payment before it runs. The built-in
+// checker applies rules to the wallet's own history; a fraud vendor can be
+// plugged in behind the same interface.
+type RiskChecker interface {
+	Assess(ctx context.Context, check RiskCheck) (*domain.RiskAssessment, error)
+}
+
+// RiskCheck describes the operation being scored
+type RiskCheck struct {
+	WalletID uuid.UUID
+	UserID   uuid.UUID
+	Type     domain.TransactionType
+	Amount   decimal.Decimal
+	DeviceID string
+	IP       string
+}
+
+// StepUpService asks the auth service to have the user confirm a
+// transaction, and redeems the token they get for confirming it
+type StepUpService interface {
+	// Challenge sends the user a one-time code for the action
+	Challenge(ctx context.Context, req StepUpRequest) (*StepUpChallenge, error)
+
+	// Redeem checks the token against the action and uses it up. Returns
+	// domain.ErrStepUpInvalid for a token auth rejects.
+	Redeem(ctx context.Context, token string, req StepUpRequest) error
+}
+
+// StepUpRequest is the transaction a challenge or token is for
+type StepUpRequest struct {
+	UserID uuid.UUID
+	Type   domain.TransactionType
+	Amount decimal.Decimal
+}
+
+// StepUpChallenge is a challenge auth has sent to the user
+type StepUpChallenge struct {
+	ChallengeID string    `json:"challenge_id"`
+	Method      string    `json:"method"`
+	ExpiresAt   time.Time `json:"expires_at"`
 }
 
-type Field struct {
-	Key   string
-	Value interface{}
+// PINVerifier checks a user's transaction PIN with the auth service
+type PINVerifier interface {
+	// VerifyPIN returns domain.ErrPINIncorrect, ErrPINLocked or ErrPINNotSet
+	// when auth turns the PIN down.
+	VerifyPIN(ctx context.Context, userID uuid.UUID, pin string) error
 }
 
+// InvoiceRenderer produces the document sent to the customer for an invoice
+type InvoiceRenderer interface {
+	Render(ctx context.Context, invoice *domain.Invoice) (document []byte, contentType string, err error)
+}
+
+// FeatureFlags gates new flows while they are rolled out
+type FeatureFlags interface {
+	IsEnabled(ctx context.Context, key, userID string) bool
+}
+
+// Feature flags checked by the wallet service
+const (
+	// FlagGatewayTopUp charges the payment gateway before crediting a top-up
+	FlagGatewayTopUp = "wallet.gateway-topup"
+)
+
+type Logger = logging.Logger
+type Field = logging.Field
+
 func String(key, value string) Field { return Field{Key: key, Value: value} }
 func Err(err error) Field            { return Field{Key: "error", Value: err} }
 func Any(key string, value interface{}) Field { return Field{Key: key, Value: value} }
@@ -0,0 +1,3 @@
+-- Enum values cannot be dropped in PostgreSQL; 'rounding_adjustment' is left in place
+DROP INDEX IF EXISTS idx_transactions_parent_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS parent_transaction_id;
@@ -0,0 +1,9 @@
+-- Rounding adjustments: when a payment method rounds the charged amount (e.g. 5-sen
+-- cash rounding), the difference is posted as its own ledger entry linked to the
+-- original transaction, so parent + adjustment equals the gateway capture.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'rounding_adjustment';
+
+-- Amount stays positive; the direction is balance_after - balance_before
+ALTER TABLE transactions ADD COLUMN parent_transaction_id UUID REFERENCES transactions(id);
+
+CREATE INDEX idx_transactions_parent_id ON transactions(parent_transaction_id) WHERE parent_transaction_id IS NOT NULL;
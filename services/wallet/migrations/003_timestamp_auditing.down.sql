@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS update_payment_methods_updated_at ON payment_methods;
+DROP TRIGGER IF EXISTS update_transactions_updated_at ON transactions;
+DROP TRIGGER IF EXISTS update_wallets_updated_at ON wallets;
+DROP FUNCTION IF EXISTS update_updated_at_column();
+
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS updated_at;
@@ -0,0 +1,3 @@
+-- Optimistic locking: every balance or status change bumps the version, and
+-- writers compare it so a stale read can never overwrite a newer balance.
+ALTER TABLE wallets ADD COLUMN version BIGINT NOT NULL DEFAULT 0;
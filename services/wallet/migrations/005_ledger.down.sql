@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS ledger_discrepancies;
+DROP TABLE IF EXISTS balance_snapshots;
+DROP TRIGGER IF EXISTS ledger_entries_append_only ON ledger_entries;
+DROP FUNCTION IF EXISTS reject_ledger_mutation();
+DROP TABLE IF EXISTS ledger_entries;
@@ -0,0 +1,75 @@
+-- Double-entry ledger: every completed transaction posts balanced entries to
+-- the wallet's account and a counter account, so a wallet's balance can be
+-- derived from its entries. wallets.balance stays as a cache for row locking
+-- and balance checks and is reconciled against the ledger.
+CREATE TABLE ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    account VARCHAR(100) NOT NULL,
+    amount DECIMAL(19, 4) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT nonzero_entry CHECK (amount <> 0)
+);
+
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account, id);
+CREATE INDEX idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
+
+-- Entries are never changed; corrections are new postings
+CREATE OR REPLACE FUNCTION reject_ledger_mutation()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'ledger entries are append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON ledger_entries
+    FOR EACH ROW
+    EXECUTE FUNCTION reject_ledger_mutation();
+
+-- Snapshots fold a wallet's entries up to last_entry_id into one balance
+CREATE TABLE balance_snapshots (
+    wallet_id UUID NOT NULL REFERENCES wallets(id),
+    last_entry_id BIGINT NOT NULL,
+    balance DECIMAL(19, 4) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (wallet_id, last_entry_id)
+);
+
+-- Wallets whose cached balance disagreed with the ledger
+CREATE TABLE ledger_discrepancies (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL REFERENCES wallets(id),
+    wallet_balance DECIMAL(19, 4) NOT NULL,
+    ledger_balance DECIMAL(19, 4) NOT NULL,
+    drift DECIMAL(19, 4) NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMPTZ,
+    resolution VARCHAR(50)
+);
+
+-- At most one open discrepancy per wallet; repeat detections update it
+CREATE UNIQUE INDEX idx_ledger_discrepancies_open ON ledger_discrepancies(wallet_id) WHERE resolved_at IS NULL;
+
+-- Backfill the ledger from transactions that already moved money
+INSERT INTO ledger_entries (transaction_id, account, amount, created_at)
+SELECT id, account, amount, created_at FROM (
+    SELECT id, 'wallet:' || wallet_id AS account, balance_after - balance_before AS amount, created_at
+    FROM transactions
+    WHERE status IN ('completed', 'refunded') AND balance_after <> balance_before
+    UNION ALL
+    SELECT id,
+        CASE type
+            WHEN 'topup' THEN 'external:payment_gateway'
+            WHEN 'rounding_adjustment' THEN 'system:rounding'
+            WHEN 'transfer' THEN 'external:transfers'
+            ELSE 'external:providers'
+        END,
+        balance_before - balance_after,
+        created_at
+    FROM transactions
+    WHERE status IN ('completed', 'refunded') AND balance_after <> balance_before
+) postings
+ORDER BY created_at;
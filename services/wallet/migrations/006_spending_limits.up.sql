@@ -0,0 +1,12 @@
+-- Per-wallet spending limits. A NULL limit is not enforced; a wallet with no
+-- row has no limits.
+CREATE TABLE spending_limits (
+    wallet_id UUID PRIMARY KEY REFERENCES wallets(id),
+    per_transaction_limit DECIMAL(19, 4) CHECK (per_transaction_limit > 0),
+    daily_limit DECIMAL(19, 4) CHECK (daily_limit > 0),
+    monthly_limit DECIMAL(19, 4) CHECK (monthly_limit > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Daily and monthly checks sum a wallet's recent payments
+CREATE INDEX idx_transactions_wallet_type_created ON transactions(wallet_id, type, created_at);
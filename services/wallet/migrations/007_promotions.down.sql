@@ -0,0 +1,4 @@
+-- Enum values cannot be dropped in PostgreSQL; 'promo_credit' is left in place
+DROP TABLE IF EXISTS promotion_redemptions;
+DROP TRIGGER IF EXISTS update_promotions_updated_at ON promotions;
+DROP TABLE IF EXISTS promotions;
@@ -0,0 +1,44 @@
+-- Promo codes: admin-defined campaigns that credit wallets. A fixed bonus is
+-- a plain credit; a percentage bonus is paid on one of the wallet's top-ups.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'promo_credit';
+
+CREATE TABLE promotions (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT,
+    bonus_type VARCHAR(20) NOT NULL CHECK (bonus_type IN ('fixed', 'percentage')),
+    bonus_value DECIMAL(19, 4) NOT NULL CHECK (bonus_value > 0),
+    max_bonus DECIMAL(19, 4) CHECK (max_bonus > 0),
+    min_top_up DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    max_redemptions INTEGER CHECK (max_redemptions > 0),
+    max_per_wallet INTEGER NOT NULL DEFAULT 1 CHECK (max_per_wallet > 0),
+    redemption_count INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    starts_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Backstop for the usage cap checked under the promotion's row lock
+    CONSTRAINT within_redemption_cap CHECK (max_redemptions IS NULL OR redemption_count <= max_redemptions)
+);
+
+CREATE TRIGGER update_promotions_updated_at
+    BEFORE UPDATE ON promotions
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE promotion_redemptions (
+    id UUID PRIMARY KEY,
+    promotion_id UUID NOT NULL REFERENCES promotions(id),
+    wallet_id UUID NOT NULL REFERENCES wallets(id),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    topup_transaction_id UUID REFERENCES transactions(id),
+    bonus DECIMAL(19, 4) NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_promotion_redemptions_wallet ON promotion_redemptions(promotion_id, wallet_id);
+
+-- A top-up earns at most one bonus
+CREATE UNIQUE INDEX idx_promotion_redemptions_topup ON promotion_redemptions(topup_transaction_id) WHERE topup_transaction_id IS NOT NULL;
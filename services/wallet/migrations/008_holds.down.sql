@@ -0,0 +1,3 @@
+-- Enum values cannot be dropped in PostgreSQL; 'hold' and 'hold_release' are left in place
+DROP TRIGGER IF EXISTS update_holds_updated_at ON holds;
+DROP TABLE IF EXISTS holds;
@@ -0,0 +1,28 @@
+-- Holds: money set aside from a wallet until the final amount is known, then
+-- captured as a payment or released back to the wallet
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'hold';
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'hold_release';
+
+CREATE TABLE holds (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL REFERENCES wallets(id),
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    provider_id UUID,
+    reference_id VARCHAR(255),
+    idempotency_key VARCHAR(255),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'captured', 'released')),
+    captured_amount DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    hold_transaction_id UUID NOT NULL REFERENCES transactions(id),
+    payment_transaction_id UUID REFERENCES transactions(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    settled_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_holds_wallet_id ON holds(wallet_id, created_at DESC);
+CREATE UNIQUE INDEX idx_holds_unique_idempotency ON holds(idempotency_key) WHERE idempotency_key IS NOT NULL AND idempotency_key != '';
+
+CREATE TRIGGER update_holds_updated_at
+    BEFORE UPDATE ON holds
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
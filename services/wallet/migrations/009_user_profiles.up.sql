@@ -0,0 +1,8 @@
+-- Local copy of user contact details, filled from auth user events
+CREATE TABLE user_profiles (
+    user_id UUID PRIMARY KEY,
+    phone VARCHAR(20) NOT NULL DEFAULT '',
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    full_name VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,21 @@
+-- Wallet Service: audit trail for sensitive operations
+-- Shared format with the other services; see pkg/audit
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    service VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    action VARCHAR(128) NOT NULL,
+    target_type VARCHAR(64) NOT NULL DEFAULT '',
+    target_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    before_state JSONB,
+    after_state JSONB,
+    changes JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_audit_log_occurred_at ON audit_log(occurred_at DESC);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor, occurred_at DESC);
+CREATE INDEX idx_audit_log_action ON audit_log(action, occurred_at DESC);
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id, occurred_at DESC);
@@ -0,0 +1,2 @@
+-- KYC level replicated from the auth service; caps balances, top-ups and payments
+ALTER TABLE user_profiles ADD COLUMN kyc_level VARCHAR(20) NOT NULL DEFAULT 'basic';
@@ -0,0 +1,2 @@
+ALTER TABLE transactions DROP COLUMN IF EXISTS risk_score;
+ALTER TABLE transactions DROP COLUMN IF EXISTS device_id;
@@ -0,0 +1,3 @@
+-- Device a top-up or payment came from, and the score the risk check gave it
+ALTER TABLE transactions ADD COLUMN device_id VARCHAR(128) NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN risk_score SMALLINT;
@@ -0,0 +1,9 @@
+-- Events each consumer group has handled, so redelivered events are skipped
+CREATE TABLE processed_events (
+    consumer_group VARCHAR(255) NOT NULL,
+    event_id VARCHAR(255) NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (consumer_group, event_id)
+);
+
+CREATE INDEX idx_processed_events_processed_at ON processed_events(processed_at);
@@ -0,0 +1,7 @@
+-- Low-balance alerts: the user is told when a payment takes the balance below
+-- the threshold. A wallet with no row, or a NULL threshold, has no alert.
+CREATE TABLE balance_alerts (
+    wallet_id UUID PRIMARY KEY REFERENCES wallets(id),
+    threshold DECIMAL(19, 4) CHECK (threshold > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
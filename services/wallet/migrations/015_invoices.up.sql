@@ -0,0 +1,44 @@
+-- Business accounts get a monthly invoice for their parking payments. Lines
+-- and tax lines are stored as issued; the rendered document is kept so the
+-- copy a customer downloads matches the one they were emailed.
+CREATE TABLE business_accounts (
+    user_id UUID PRIMARY KEY,
+    company_name VARCHAR(255) NOT NULL,
+    registration_number VARCHAR(50),
+    tax_id VARCHAR(50),
+    billing_address TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER update_business_accounts_updated_at
+    BEFORE UPDATE ON business_accounts
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY,
+    number VARCHAR(50) NOT NULL UNIQUE,
+    user_id UUID NOT NULL,
+    wallet_id UUID NOT NULL REFERENCES wallets(id),
+    company_name VARCHAR(255) NOT NULL,
+    registration_number VARCHAR(50),
+    tax_id VARCHAR(50),
+    billing_address TEXT,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    lines JSONB NOT NULL,
+    tax_lines JSONB NOT NULL,
+    subtotal DECIMAL(19, 4) NOT NULL,
+    tax_total DECIMAL(19, 4) NOT NULL,
+    total DECIMAL(19, 4) NOT NULL,
+    document BYTEA NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    issued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- One invoice per account per month, however many instances run the job
+    CONSTRAINT one_invoice_per_period UNIQUE (user_id, period_start)
+);
+
+CREATE INDEX idx_invoices_user ON invoices(user_id, period_start DESC);
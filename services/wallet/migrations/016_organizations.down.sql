@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_wallet_member_created;
+ALTER TABLE transactions DROP COLUMN IF EXISTS member_id;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS organizations;
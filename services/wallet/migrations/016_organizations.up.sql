@@ -0,0 +1,27 @@
+-- Organizations, copied from auth's org.* events. Members may charge parking
+-- to the organization's wallet up to their monthly limit; each such payment
+-- records the member who made it.
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    owner_id UUID NOT NULL,
+    wallet_id UUID NOT NULL UNIQUE REFERENCES wallets(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_organizations_owner ON organizations(owner_id);
+
+CREATE TABLE org_members (
+    org_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    monthly_limit DECIMAL(19, 4) CHECK (monthly_limit > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_id, user_id)
+);
+
+ALTER TABLE transactions ADD COLUMN member_id UUID;
+
+CREATE INDEX idx_transactions_wallet_member_created
+    ON transactions(wallet_id, member_id, created_at)
+    WHERE member_id IS NOT NULL;
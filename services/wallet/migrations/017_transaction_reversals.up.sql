@@ -0,0 +1,6 @@
+-- Admin reversals: a compensating transaction pointing at the original through
+-- parent_transaction_id. Its idempotency key is derived from the original, so
+-- the unique key index allows one reversal per transaction.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'reversal';
+
+ALTER TABLE transactions ADD COLUMN admin_id UUID;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_transactions_provider_created;
+DROP TABLE IF EXISTS settlements;
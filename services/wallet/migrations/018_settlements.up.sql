@@ -0,0 +1,34 @@
+-- Providers are settled once a day for the parking payments booked against
+-- them, less refunds and reversals. Lines are stored as calculated so the
+-- exported CSV always matches the payout.
+CREATE TABLE settlements (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    lines JSONB NOT NULL,
+    payment_count INTEGER NOT NULL,
+    gross_amount DECIMAL(19, 4) NOT NULL,
+    refund_amount DECIMAL(19, 4) NOT NULL,
+    net_amount DECIMAL(19, 4) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'settled')),
+    reference VARCHAR(100),
+    settled_by UUID,
+    settled_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- One settlement per provider per day, however many instances run the job
+    CONSTRAINT one_settlement_per_period UNIQUE (provider_id, period_start)
+);
+
+CREATE INDEX idx_settlements_period ON settlements(period_start DESC);
+CREATE INDEX idx_settlements_pending ON settlements(period_start) WHERE status = 'pending';
+
+CREATE TRIGGER update_settlements_updated_at
+    BEFORE UPDATE ON settlements
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- The nightly run reads every provider's transactions for one day
+CREATE INDEX idx_transactions_provider_created ON transactions(created_at) WHERE provider_id IS NOT NULL;
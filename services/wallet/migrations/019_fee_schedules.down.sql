@@ -0,0 +1,4 @@
+ALTER TABLE settlements DROP COLUMN IF EXISTS fee_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS net_amount;
+ALTER TABLE transactions DROP COLUMN IF EXISTS fee_amount;
+DROP TABLE IF EXISTS fee_schedules;
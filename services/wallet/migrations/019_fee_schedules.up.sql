@@ -0,0 +1,21 @@
+-- The platform's commission on provider payments: a percentage plus a fixed
+-- fee per payment. Changes are new rows taking effect at a given time, so the
+-- schedule a past payment was charged under is never lost.
+CREATE TABLE fee_schedules (
+    id UUID PRIMARY KEY,
+    provider_id UUID NOT NULL,
+    percentage DECIMAL(7, 4) NOT NULL CHECK (percentage >= 0 AND percentage <= 100),
+    fixed_fee DECIMAL(19, 4) NOT NULL CHECK (fixed_fee >= 0),
+    effective_from TIMESTAMPTZ NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT one_fee_change_at_a_time UNIQUE (provider_id, effective_from)
+);
+
+-- Gross is amount; the fee and net are set on payments to providers and
+-- carried onto their refunds and reversals
+ALTER TABLE transactions ADD COLUMN fee_amount DECIMAL(19, 4);
+ALTER TABLE transactions ADD COLUMN net_amount DECIMAL(19, 4);
+
+ALTER TABLE settlements ADD COLUMN fee_amount DECIMAL(19, 4) NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS taxes,
+    DROP COLUMN IF EXISTS tax_amount;
@@ -0,0 +1,5 @@
+-- The taxes included in a payment's amount, as the parking service worked
+-- them out, for receipts and invoices
+ALTER TABLE transactions
+    ADD COLUMN tax_amount DECIMAL(19, 4),
+    ADD COLUMN taxes JSONB;
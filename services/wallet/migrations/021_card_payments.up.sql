@@ -0,0 +1,20 @@
+-- Payments charged straight to a card, for guests parking without a wallet.
+-- The idempotency key makes a retried charge return the first attempt.
+CREATE TABLE card_payments (
+    id UUID PRIMARY KEY,
+    amount DECIMAL(19, 4) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'MYR',
+    provider_id UUID,
+    reference_id VARCHAR(255) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    idempotency_key VARCHAR(255) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'completed', 'failed')),
+    gateway_reference VARCHAR(255),
+    failure_reason TEXT,
+    tax_amount DECIMAL(19, 4),
+    taxes JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_card_payments_reference ON card_payments(reference_id);
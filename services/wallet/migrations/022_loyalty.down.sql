@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped in PostgreSQL; 'loyalty_credit' is left in place
+DROP TABLE IF EXISTS loyalty_entries;
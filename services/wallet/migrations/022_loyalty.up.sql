@@ -0,0 +1,25 @@
+-- Loyalty points: a per-user ledger of points earned on payments, redeemed
+-- for wallet credit, expired or taken back when a payment is returned.
+-- Earned lots keep what is left of them and are spent soonest to expire first.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'loyalty_credit';
+
+CREATE TABLE loyalty_entries (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('earn', 'redeem', 'expire', 'forfeit')),
+    points BIGINT NOT NULL,
+    remaining BIGINT NOT NULL DEFAULT 0 CHECK (remaining >= 0),
+    transaction_id UUID,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT loyalty_entry_sign CHECK ((type = 'earn') = (points > 0)),
+    CONSTRAINT loyalty_lot_remaining CHECK (type = 'earn' OR remaining = 0),
+    CONSTRAINT loyalty_lot_within_points CHECK (remaining <= GREATEST(points, 0))
+);
+
+CREATE INDEX idx_loyalty_entries_user ON loyalty_entries(user_id, created_at DESC);
+CREATE INDEX idx_loyalty_lots_open ON loyalty_entries(expires_at) WHERE type = 'earn' AND remaining > 0;
+
+-- A payment earns once, and its lot expires or is forfeited once
+CREATE UNIQUE INDEX idx_loyalty_entries_transaction ON loyalty_entries(transaction_id, type) WHERE transaction_id IS NOT NULL;
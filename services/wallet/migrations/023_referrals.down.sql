@@ -0,0 +1,2 @@
+-- Enum values cannot be dropped in PostgreSQL; 'referral_credit' is left in place
+DROP TABLE IF EXISTS referrals;
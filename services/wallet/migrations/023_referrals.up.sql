@@ -0,0 +1,18 @@
+-- Referrals attributed by auth at registration. Both parties are paid once,
+-- on the referee's first payment inside the window; the credits are booked
+-- against system:referrals.
+ALTER TYPE transaction_type ADD VALUE IF NOT EXISTS 'referral_credit';
+
+CREATE TABLE referrals (
+    id UUID PRIMARY KEY,
+    referrer_id UUID NOT NULL,
+    referee_id UUID NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'rewarded', 'expired')),
+    referrer_reward DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    referee_reward DECIMAL(19, 4) NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    rewarded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_referrals_referrer ON referrals(referrer_id, created_at DESC);
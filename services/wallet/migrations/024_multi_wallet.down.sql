@@ -0,0 +1,7 @@
+-- Fails while any user has more than one wallet
+DROP INDEX IF EXISTS idx_wallets_user_label;
+DROP INDEX IF EXISTS idx_wallets_user_default;
+ALTER TABLE wallets ADD CONSTRAINT wallets_user_id_key UNIQUE (user_id);
+ALTER TABLE wallets
+    DROP COLUMN is_default,
+    DROP COLUMN label;
@@ -0,0 +1,11 @@
+-- Users may hold several labeled wallets, e.g. personal and business. One is
+-- the default, used whenever a wallet isn't named. Existing wallets become
+-- their user's default "Personal" wallet.
+ALTER TABLE wallets
+    ADD COLUMN label VARCHAR(50) NOT NULL DEFAULT 'Personal',
+    ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT TRUE;
+
+ALTER TABLE wallets DROP CONSTRAINT wallets_user_id_key;
+
+CREATE UNIQUE INDEX idx_wallets_user_default ON wallets(user_id) WHERE is_default;
+CREATE UNIQUE INDEX idx_wallets_user_label ON wallets(user_id, LOWER(label));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_transactions_tags;
+DROP INDEX IF EXISTS idx_transactions_wallet_category;
+
+ALTER TABLE transactions
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS category;
@@ -0,0 +1,24 @@
+-- Transactions carry a category saying what the money was for, and tags the
+-- user adds to organise their history.
+ALTER TABLE transactions
+    ADD COLUMN category VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Backfill from the references the paying services gave existing payments;
+-- refunds, reversals and rounding adjustments follow their parent
+UPDATE transactions SET category = CASE
+    WHEN type = 'topup' THEN 'topup'
+    WHEN type = 'transfer' THEN 'transfer'
+    WHEN idempotency_key LIKE 'compound-%' THEN 'fine'
+    WHEN idempotency_key LIKE 'charging-%' THEN 'ev_charging'
+    ELSE 'parking'
+END
+WHERE type IN ('topup', 'transfer', 'payment', 'hold', 'hold_release');
+
+UPDATE transactions t SET category = p.category
+FROM transactions p
+WHERE t.parent_transaction_id = p.id
+    AND t.type IN ('refund', 'reversal', 'rounding_adjustment');
+
+CREATE INDEX idx_transactions_wallet_category ON transactions(wallet_id, category, created_at DESC);
+CREATE INDEX idx_transactions_tags ON transactions USING GIN (tags);